@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	composecli "github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/catalog"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+const appCatalogEnvVar = "UNCLOUD_APP_CATALOG"
+
+type appInstallOptions struct {
+	app     string
+	catalog string
+	// overrideFreeze is the reason for installing the app despite the deploy freeze of the cluster.
+	overrideFreeze string
+	set            []string
+	yes            bool
+
+	context string
+}
+
+// NewAppCommand creates a new command group to install apps from an app catalog.
+func NewAppCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "app",
+		Short: "Install common self-hosted apps from an app catalog.",
+		Long: "Install common self-hosted apps from an app catalog.\n\n" +
+			"An app catalog is a collection of app templates. Each template is a parameterised Compose file with\n" +
+			"the app metadata in " + catalog.AppFileName + " that describes its parameters and their defaults.\n" +
+			"The built-in catalog is used by default. Use --catalog or " + appCatalogEnvVar + " to use another one:\n" +
+			"  /path/to/catalog                           Local directory\n" +
+			"  git+https://github.com/user/apps.git#main  Git repository with an optional branch or tag\n" +
+			"  oci://ghcr.io/user/apps:1.0                OCI image whose filesystem is the catalog",
+	}
+	cmd.AddCommand(
+		newAppInspectCommand(),
+		newAppInstallCommand(),
+		newAppListCommand(),
+	)
+	return cmd
+}
+
+// addCatalogFlag adds the --catalog flag to the app command.
+func addCatalogFlag(cmd *cobra.Command, catalogSource *string) {
+	cmd.Flags().StringVar(catalogSource, "catalog", "",
+		"App catalog source: a local directory, git+URL[#ref], or oci://IMAGE. "+
+			"(default is the built-in catalog) [$"+appCatalogEnvVar+"]")
+}
+
+func newAppListCommand() *cobra.Command {
+	var catalogSource string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List apps available in the app catalog.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "catalog", appCatalogEnvVar)
+
+			c, err := catalog.Open(cmd.Context(), catalogSource)
+			if err != nil {
+				return fmt.Errorf("open app catalog: %w", err)
+			}
+			defer c.Close()
+
+			apps, err := c.Apps()
+			if err != nil {
+				return fmt.Errorf("list apps: %w", err)
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			if _, err = fmt.Fprintln(tw, "NAME\tVERSION\tDESCRIPTION"); err != nil {
+				return fmt.Errorf("write header: %w", err)
+			}
+			for _, app := range apps {
+				if _, err = fmt.Fprintf(tw, "%s\t%s\t%s\n", app.Name, app.Version, app.Description); err != nil {
+					return fmt.Errorf("write row: %w", err)
+				}
+			}
+			return tw.Flush()
+		},
+	}
+	addCatalogFlag(cmd, &catalogSource)
+	return cmd
+}
+
+func newAppInspectCommand() *cobra.Command {
+	var catalogSource string
+	cmd := &cobra.Command{
+		Use:   "inspect APP",
+		Short: "Display the description and parameters of an app in the app catalog.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "catalog", appCatalogEnvVar)
+
+			c, err := catalog.Open(cmd.Context(), catalogSource)
+			if err != nil {
+				return fmt.Errorf("open app catalog: %w", err)
+			}
+			defer c.Close()
+
+			app, err := c.App(args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Name:         %s\n", app.Name)
+			fmt.Printf("Version:      %s\n", app.Version)
+			fmt.Printf("Description:  %s\n", app.Description)
+			fmt.Println()
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			if _, err = fmt.Fprintln(tw, "PARAMETER\tDEFAULT\tDESCRIPTION"); err != nil {
+				return fmt.Errorf("write header: %w", err)
+			}
+			for _, p := range app.Parameters {
+				def := p.Default
+				switch {
+				case def != "":
+				case p.Generate:
+					def = "(generated)"
+				case p.Required:
+					def = "(required)"
+				}
+				if _, err = fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Name, def, p.Description); err != nil {
+					return fmt.Errorf("write row: %w", err)
+				}
+			}
+			return tw.Flush()
+		},
+	}
+	addCatalogFlag(cmd, &catalogSource)
+	return cmd
+}
+
+func newAppInstallCommand() *cobra.Command {
+	opts := appInstallOptions{}
+	cmd := &cobra.Command{
+		Use:   "install APP",
+		Short: "Install an app from the app catalog.",
+		Long: "Install an app from the app catalog by deploying its services with the given parameters.\n" +
+			"Unset parameters get their default values. Parameters like passwords get random values if not set.\n" +
+			"The random values of an already installed app are kept when it's installed again.",
+		Example: `  # Install PostgreSQL with a random password.
+  uc app install postgres
+
+  # Install PostgreSQL with a custom password and database.
+  uc app install postgres --set password=secret --set database=app
+
+  # Install an app from a catalog in a Git repository.
+  uc app install myapp --catalog git+https://github.com/user/apps.git`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "catalog", appCatalogEnvVar)
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.app = args[0]
+			return installApp(cmd.Context(), uncli, opts)
+		},
+	}
+
+	addCatalogFlag(cmd, &opts.catalog)
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Install even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
+	cmd.Flags().StringArrayVar(&opts.set, "set", nil,
+		"Set an app parameter. Can be specified multiple times. Format: name=value")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Auto-confirm deployment plan. Should be explicitly set when running non-interactively. "+
+			"[$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context to install the app to. (default is the current context)")
+
+	return cmd
+}
+
+func installApp(ctx context.Context, uncli *cli.CLI, opts appInstallOptions) error {
+	set := make(map[string]string, len(opts.set))
+	for _, s := range opts.set {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid parameter '%s': expected name=value format", s)
+		}
+		set[name] = value
+	}
+
+	c, err := catalog.Open(ctx, opts.catalog)
+	if err != nil {
+		return fmt.Errorf("open app catalog: %w", err)
+	}
+	defer c.Close()
+
+	app, err := c.App(opts.app)
+	if err != nil {
+		return err
+	}
+	values, generated, err := app.Values(set)
+	if err != nil {
+		return err
+	}
+
+	project, err := compose.LoadProject(ctx, []string{app.ComposePath()}, composecli.WithEnv(app.Env(values)))
+	if err != nil {
+		return fmt.Errorf("load app '%s' compose file: %w", app.Name, err)
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	// Keep the values of the generated parameters of an already installed app, e.g. not to change the password
+	// of an existing database on re-install.
+	kept, err := keepInstalledValues(ctx, clusterClient, project, values, generated)
+	if err != nil {
+		return err
+	}
+	if len(kept) > 0 {
+		generated = slices.DeleteFunc(generated, func(name string) bool {
+			return slices.Contains(kept, name)
+		})
+		project, err = compose.LoadProject(ctx, []string{app.ComposePath()}, composecli.WithEnv(app.Env(values)))
+		if err != nil {
+			return fmt.Errorf("load app '%s' compose file: %w", app.Name, err)
+		}
+	}
+
+	fmt.Printf("Installing app %s %s with parameters:\n", app.Name, app.Version)
+	for _, p := range app.Parameters {
+		value := values[p.Name]
+		if (p.Secret || p.Generate) && value != "" {
+			value = "********"
+		}
+		switch {
+		case slices.Contains(generated, p.Name):
+			value += " (generated)"
+		case slices.Contains(kept, p.Name):
+			value += " (installed)"
+		}
+		fmt.Printf("  %s: %s\n", p.Name, value)
+	}
+	if len(generated) > 0 {
+		fmt.Println("Generated values are set in the environment of the app services. " +
+			"Run 'uc service export SERVICE' to see them.")
+	}
+	fmt.Println()
+
+	return deployProject(ctx, uncli, clusterClient, project, deployOptions{
+		overrideFreeze: opts.overrideFreeze,
+		yes:            opts.yes,
+	})
+}
+
+// keepInstalledValues replaces the generated values with the values the parameters have in the deployed services
+// of the app. A parameter value is found by the environment variable of a project service that is set to
+// the generated value. It returns the names of the parameters whose values have been replaced.
+func keepInstalledValues(
+	ctx context.Context, clusterClient *client.Client, project *types.Project, values map[string]string,
+	generated []string,
+) ([]string, error) {
+	var kept []string
+	for _, name := range generated {
+		for _, s := range project.Services {
+			env, ok := envVarWithValue(s.Environment, values[name])
+			if !ok {
+				continue
+			}
+			svc, err := clusterClient.InspectService(ctx, s.Name)
+			if err != nil {
+				if errors.Is(err, api.ErrNotFound) {
+					continue
+				}
+				return nil, fmt.Errorf("inspect service '%s': %w", s.Name, err)
+			}
+			if len(svc.Containers) == 0 {
+				continue
+			}
+			if value, ok := svc.Containers[0].Container.ServiceSpec.Container.Env[env]; ok && value != "" {
+				values[name] = value
+				kept = append(kept, name)
+				break
+			}
+		}
+	}
+	return kept, nil
+}
+
+// envVarWithValue returns the name of the environment variable set to the given value.
+func envVarWithValue(env types.MappingWithEquals, value string) (string, bool) {
+	for k, v := range env {
+		if v != nil && *v == value {
+			return k, true
+		}
+	}
+	return "", false
+}
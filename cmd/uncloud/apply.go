@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
+	"github.com/psviderski/uncloud/cmd/uncloud/dns"
+	"github.com/psviderski/uncloud/cmd/uncloud/machine"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+const defaultClusterSpecFile = "cluster.yaml"
+
+// errApplyCancelled is returned when the user declines the cluster plan.
+var errApplyCancelled = errors.New("apply cancelled")
+
+type applyOptions struct {
+	dnsEndpoint string
+	files       []string
+	profiles    []string
+	noBuild     bool
+	// overrideFreeze is the reason for deploying services despite the deploy freeze of the cluster.
+	overrideFreeze string
+	recreate       bool
+	skipScan       bool
+	yes            bool
+
+	context string
+}
+
+// NewApplyCommand creates a new command to converge a cluster to the state declared in a cluster spec file.
+func NewApplyCommand() *cobra.Command {
+	opts := applyOptions{}
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update a cluster and its services from a cluster spec file.",
+		Long: "Create or update a cluster and its services from a cluster spec file.\n\n" +
+			"A cluster spec file is a Compose file with the top-level '" + cli.ClusterExtensionKey + "' extension that declares\n" +
+			"the cluster machines reachable over SSH. The command compares the declared machines and services with\n" +
+			"the live cluster state and converges it: initialises the cluster if its context doesn't exist, adds missing\n" +
+			"machines, updates their public IPs, and deploys changed services. Cluster machines that aren't declared\n" +
+			"in the spec are left untouched. Applying the same spec again is a no-op.",
+		Example: `  # Create or update the cluster declared in cluster.yaml in the current directory.
+  uc apply
+
+  # Apply a cluster spec split into several files, e.g. machines and services.
+  uc apply -f cluster.yaml -f services.yaml
+
+  # Apply without confirmation prompts, e.g. in CI/CD pipelines.
+  uc apply -f cluster.yaml --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return runApply(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context to apply the spec to. Overrides the context declared in the spec.\n"+
+			"(default is the spec context or the current context)")
+	cmd.Flags().StringVar(&opts.dnsEndpoint, "dns-endpoint", dns.DefaultUncloudDNSAPIEndpoint,
+		"API endpoint for the Uncloud DNS service used to reserve a cluster domain for a new cluster.")
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", []string{defaultClusterSpecFile},
+		"One or more cluster spec files to apply.")
+	cmd.Flags().BoolVarP(&opts.noBuild, "no-build", "n", false,
+		"Do not build images before deploying services. (default false)")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Deploy even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
+	cmd.Flags().StringSliceVarP(&opts.profiles, "profile", "p", nil,
+		"One or more Compose profiles to enable.")
+	cmd.Flags().BoolVar(&opts.recreate, "recreate", false,
+		"Recreate containers even if their configuration and image haven't changed.")
+	cmd.Flags().BoolVar(&opts.skipScan, "skip-scan", false,
+		"Skip scanning the images for vulnerabilities before deploying them even if image scanning\n"+
+			"is enabled with the 'image-scan.scanner' cluster setting.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Auto-confirm cluster and deployment plans. Should be explicitly set when running non-interactively,\n"+
+			"e.g., in CI/CD pipelines. [$UNCLOUD_AUTO_CONFIRM]")
+
+	return cmd
+}
+
+// runApply parses the cluster spec file(s) and converges the cluster machines and services to the declared state.
+func runApply(ctx context.Context, uncli *cli.CLI, opts applyOptions) error {
+	project, err := compose.LoadProject(ctx, opts.files, projectOpts(deployOptions{profiles: opts.profiles})...)
+	if err != nil {
+		return fmt.Errorf("load cluster spec file(s): %w", err)
+	}
+	spec, err := cli.ClusterSpecFromProject(project)
+	if err != nil {
+		return err
+	}
+
+	contextName, exists := uncli.SpecContext(opts.context, spec)
+	if exists {
+		err = applyMachines(ctx, uncli, contextName, spec, opts)
+	} else {
+		err = createCluster(ctx, uncli, contextName, spec, opts)
+	}
+	if err != nil {
+		if errors.Is(err, errApplyCancelled) {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+		return err
+	}
+
+	if err = buildProject(ctx, uncli, project, opts.noBuild); err != nil {
+		return err
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if spec.CaddyEnabled() {
+		if err = applyCaddy(ctx, uncli, clusterClient); err != nil {
+			return err
+		}
+	}
+
+	if len(project.Services) == 0 {
+		return nil
+	}
+	fmt.Println()
+	return deployProject(ctx, uncli, clusterClient, project, deployOptions{
+		overrideFreeze: opts.overrideFreeze,
+		recreate:       opts.recreate,
+		skipScan:       opts.skipScan,
+		yes:            opts.yes,
+	})
+}
+
+// createCluster initialises a new cluster with the first declared machine and adds the rest of the machines to it.
+func createCluster(
+	ctx context.Context, uncli *cli.CLI, contextName string, spec cli.ClusterSpec, opts applyOptions,
+) error {
+	network, err := spec.NetworkPrefix()
+	if err != nil {
+		return err
+	}
+	// All declared machines have to be provisioned for a new cluster.
+	plan, err := cli.PlanMachines(spec, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Cluster plan:")
+	fmt.Printf("- Initialise cluster [context=%s network=%s] with machine %s (%s)\n",
+		contextName, network, plan.Add[0].Name, plan.Add[0].SSH)
+	for _, m := range plan.Add[1:] {
+		fmt.Printf("- Add machine %s (%s)\n", m.Name, m.SSH)
+	}
+	fmt.Println()
+	if err = confirmPlan(opts.yes); err != nil {
+		return err
+	}
+
+	first := plan.Add[0]
+	remoteMachine, publicIP, err := machineProvisionOptions(first)
+	if err != nil {
+		return err
+	}
+	initClient, err := uncli.InitCluster(ctx, cli.InitClusterOptions{
+		Context:       contextName,
+		MachineName:   first.Name,
+		Network:       network,
+		PublicIP:      publicIP,
+		RemoteMachine: remoteMachine,
+		SkipInstall:   first.NoInstall,
+		Version:       first.Version,
+		PreScript:     first.PreScript,
+		PostScript:    first.PostScript,
+	})
+	if err != nil {
+		return fmt.Errorf("initialise cluster with machine '%s': %w", first.Name, err)
+	}
+	defer initClient.Close()
+
+	fmt.Println("Waiting for the machine to be ready...")
+	fmt.Println()
+	if err = machine.WaitClusterInitialised(ctx, initClient); err != nil {
+		return fmt.Errorf("wait for cluster to be initialised on machine '%s': %w", first.Name, err)
+	}
+	if spec.DNSEnabled() {
+		domain, err := initClient.ReserveDomain(ctx, &pb.ReserveDomainRequest{Endpoint: opts.dnsEndpoint})
+		if err != nil {
+			return fmt.Errorf("reserve cluster domain in Uncloud DNS: %w", err)
+		}
+		fmt.Printf("Reserved cluster domain: %s\n", domain.Name)
+	}
+
+	for _, m := range plan.Add[1:] {
+		if err = addMachine(ctx, uncli, contextName, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMachines adds the declared machines missing in the existing cluster and updates the public IPs of the cluster
+// machines that differ from the declared ones.
+func applyMachines(
+	ctx context.Context, uncli *cli.CLI, contextName string, spec cli.ClusterSpec, opts applyOptions,
+) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	machines, err := clusterClient.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	plan, err := cli.PlanMachines(spec, machines)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range plan.Undeclared {
+		fmt.Printf("Machine '%s' is not declared in the cluster spec and will be left untouched.\n", m.Machine.Name)
+	}
+	if plan.Empty() {
+		fmt.Println("Machines are up to date.")
+		return nil
+	}
+
+	fmt.Println("Cluster plan:")
+	cli.PrintMachinesPlan(uncli.Output.Writer(), plan)
+	fmt.Println()
+	if err = confirmPlan(opts.yes); err != nil {
+		return err
+	}
+
+	for _, u := range plan.UpdatePublicIP {
+		req := &pb.UpdateMachineRequest{
+			MachineId: u.Machine.Machine.Id,
+			// Empty IP to signal removal.
+			PublicIp: &pb.IP{},
+		}
+		if u.PublicIP.IsValid() {
+			req.PublicIp = pb.NewIP(u.PublicIP)
+		}
+		if _, err = clusterClient.UpdateMachine(ctx, req); err != nil {
+			return fmt.Errorf("update machine '%s': %w", u.Machine.Machine.Name, err)
+		}
+		fmt.Printf("Machine '%s' public IP updated.\n", u.Machine.Machine.Name)
+	}
+
+	for _, m := range plan.Add {
+		if err = addMachine(ctx, uncli, contextName, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addMachine provisions the declared machine, adds it to the cluster, and waits for it to join the cluster.
+func addMachine(ctx context.Context, uncli *cli.CLI, contextName string, m cli.MachineSpec) error {
+	remoteMachine, publicIP, err := machineProvisionOptions(m)
+	if err != nil {
+		return err
+	}
+	dnsEndpoints, err := m.ParsedDNSEndpoints()
+	if err != nil {
+		return fmt.Errorf("machine '%s': %w", m.Name, err)
+	}
+
+	clusterClient, machineClient, err := uncli.AddMachine(ctx, cli.AddMachineOptions{
+		Context:       contextName,
+		MachineName:   m.Name,
+		PublicIP:      publicIP,
+		RemoteMachine: remoteMachine,
+		SkipInstall:   m.NoInstall,
+		Version:       m.Version,
+		PreScript:     m.PreScript,
+		PostScript:    m.PostScript,
+		DNSEndpoints:  dnsEndpoints,
+	})
+	if err != nil {
+		return fmt.Errorf("add machine '%s': %w", m.Name, err)
+	}
+	defer clusterClient.Close()
+	defer machineClient.Close()
+
+	fmt.Println("Waiting for the machine to be ready...")
+	fmt.Println()
+	if err = machine.WaitClusterInitialised(ctx, machineClient); err != nil {
+		return fmt.Errorf("wait for cluster to be initialised on machine '%s': %w", m.Name, err)
+	}
+	return nil
+}
+
+func machineProvisionOptions(m cli.MachineSpec) (*cli.RemoteMachine, *netip.Addr, error) {
+	remoteMachine, err := m.RemoteMachine()
+	if err != nil {
+		return nil, nil, fmt.Errorf("machine '%s': %w", m.Name, err)
+	}
+	publicIP, err := m.PublicIPAddr()
+	if err != nil {
+		return nil, nil, fmt.Errorf("machine '%s': %w", m.Name, err)
+	}
+	return remoteMachine, publicIP, nil
+}
+
+// applyCaddy deploys the Caddy service to the cluster machines that don't run it yet.
+func applyCaddy(ctx context.Context, uncli *cli.CLI, clusterClient *client.Client) error {
+	d, err := caddy.NewClusterDeployment(ctx, clusterClient)
+	if err != nil {
+		return err
+	}
+	plan, err := d.Plan(ctx)
+	if err != nil {
+		return fmt.Errorf("plan caddy deployment: %w", err)
+	}
+	if len(plan.Operations) == 0 {
+		return nil
+	}
+
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		if _, err = d.Run(ctx); err != nil {
+			return fmt.Errorf("deploy caddy: %w", err)
+		}
+		return nil
+	}, uncli.ProgressOut(), fmt.Sprintf("Deploying service %s", d.Spec.Name))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return caddy.UpdateDomainRecords(ctx, clusterClient, uncli.ProgressOut())
+}
+
+// confirmPlan asks the user to confirm the printed cluster plan unless it's auto-confirmed with --yes.
+// It returns errApplyCancelled if the user declines the plan.
+func confirmPlan(yes bool) error {
+	if yes {
+		return nil
+	}
+	if !cli.IsStdinTerminal() {
+		return errors.New("cannot ask to confirm cluster plan in non-interactive mode, " +
+			"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+	}
+
+	confirmed, err := cli.Confirm()
+	if err != nil {
+		return fmt.Errorf("confirm cluster plan: %w", err)
+	}
+	if !confirmed {
+		return errApplyCancelled
+	}
+	return nil
+}
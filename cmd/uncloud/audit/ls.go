@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List the recorded exec sessions on all machines.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	results, err := client.ListExecSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("list exec sessions: %w", err)
+	}
+
+	type machineSession struct {
+		machine string
+		api.ExecSession
+	}
+	var sessions []machineSession
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to list exec sessions on machine '%s': %v\n",
+				r.Machine.Name, r.Err)
+			continue
+		}
+		for _, s := range r.Value {
+			sessions = append(sessions, machineSession{machine: r.Machine.Name, ExecSession: s})
+		}
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No recorded exec sessions found.")
+		return nil
+	}
+	slices.SortFunc(sessions, func(a, b machineSession) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ID\tMACHINE\tSERVICE\tCOMMAND\tSTARTED\tDURATION\tEXIT CODE")
+	for _, s := range sessions {
+		service := s.ServiceName
+		if service == "" {
+			service = "-"
+		}
+		duration, exitCode := "-", "-"
+		if s.Ended() {
+			duration = s.EndedAt.Sub(s.StartedAt).Round(time.Second).String()
+			exitCode = strconv.Itoa(s.ExitCode)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.ID, s.machine, service, strings.Join(s.Command, " "),
+			units.HumanDuration(time.Since(s.StartedAt))+" ago", duration, exitCode)
+	}
+	return tw.Flush()
+}
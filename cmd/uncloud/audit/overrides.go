@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewFreezeOverridesCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "freeze-overrides",
+		Short: "List the operations that overrode a deploy freeze.",
+		Long: `List the operations that overrode a deploy freeze set with 'uc cluster freeze'. The operations
+are run with '--override-freeze REASON' and recorded along with the local user and host that ran them.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listFreezeOverrides(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func listFreezeOverrides(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	overrides, err := client.ListFreezeOverrides(ctx)
+	if err != nil {
+		return fmt.Errorf("list freeze overrides: %w", err)
+	}
+	if len(overrides) == 0 {
+		fmt.Println("No freeze overrides recorded.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tUSER\tOPERATION\tREASON\tFREEZE REASON")
+	for _, o := range overrides {
+		user, freezeReason := o.User, o.FreezeReason
+		if user == "" {
+			user = "-"
+		}
+		if freezeReason == "" {
+			freezeReason = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			o.Time.Local().Format(time.DateTime), user, o.Operation, o.Reason, freezeReason)
+	}
+	return tw.Flush()
+}
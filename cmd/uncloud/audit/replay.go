@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/audit"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type replayOptions struct {
+	raw     bool
+	speed   float64
+	maxIdle time.Duration
+	context string
+}
+
+func NewReplayCommand() *cobra.Command {
+	opts := replayOptions{}
+	cmd := &cobra.Command{
+		Use:   "replay SESSION-ID",
+		Short: "Replay a recorded exec session in the terminal.",
+		Example: `  # Replay the session at double speed skipping pauses longer than 1 second.
+  uc audit replay 4f2a9c1e8b7d6a5f3e2d1c0b9a8f7e6d --speed 2 --max-idle 1s
+
+  # Save the recording to play it with asciinema.
+  uc audit replay 4f2a9c1e8b7d6a5f3e2d1c0b9a8f7e6d --raw > session.cast
+  asciinema play session.cast`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return replay(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.raw, "raw", false,
+		"Write the recording in the asciinema v2 format to stdout instead of replaying it.")
+	cmd.Flags().Float64Var(&opts.speed, "speed", 1, "Playback speed multiplier.")
+	cmd.Flags().DurationVar(&opts.maxIdle, "max-idle", 2*time.Second,
+		"Maximum pause between the output events. Zero disables the limit.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func replay(ctx context.Context, uncli *cli.CLI, id string, opts replayOptions) error {
+	if opts.speed <= 0 {
+		return errors.New("speed must be greater than 0")
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	session, err := client.GetExecSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("exec session '%s' not found", id)
+		}
+		return fmt.Errorf("get exec session: %w", err)
+	}
+
+	if opts.raw {
+		return client.ExecSessionRecording(ctx, session, os.Stdout)
+	}
+
+	// Stream the recording to the player to start replaying before it's fully downloaded.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(client.ExecSessionRecording(ctx, session, pw))
+	}()
+	defer pr.Close()
+
+	if err = audit.Play(pr, os.Stdout, audit.PlayOptions{Speed: opts.speed, MaxIdle: opts.maxIdle}); err != nil {
+		return fmt.Errorf("replay exec session: %w", err)
+	}
+	return nil
+}
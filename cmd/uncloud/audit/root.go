@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect and replay the recorded exec sessions and list the deploy freeze overrides.",
+		Long: `Inspect and replay the recorded exec sessions and list the deploy freeze overrides.
+When the 'audit.record-exec' cluster setting is enabled, the interactive exec sessions with a TTY in service
+containers are recorded in the asciinema v2 format on the machines that run them, including the input, output,
+and timing. The recordings are stored in /var/lib/uncloud/audit/exec and aren't removed automatically.
+
+The operations that override a deploy freeze set with 'uc cluster freeze' are always recorded in the cluster.`,
+	}
+	cmd.AddCommand(
+		NewFreezeOverridesCommand(),
+		NewListCommand(),
+		NewReplayCommand(),
+	)
+	return cmd
+}
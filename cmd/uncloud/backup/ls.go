@@ -0,0 +1,68 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	service string
+	context string
+}
+
+func NewListCommand() *cobra.Command {
+	opts := listOptions{}
+	cmd := &cobra.Command{
+		Use:     "ls SERVICE",
+		Aliases: []string{"list"},
+		Short:   "List backups of a service.",
+		Long: "List backups of a service in the backup storage, newest first. The service doesn't have to exist " +
+			"in the cluster.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return list(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	backups, err := client.ListBackups(ctx, opts.service)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		fmt.Printf("No backups found for service '%s'.\n", opts.service)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err = fmt.Fprintln(tw, "CREATED\tENGINE\tSIZE\tKEY"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, b := range backups {
+		created := units.HumanDuration(time.Since(b.CreatedAt)) + " ago"
+		if _, err = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			created, b.Engine, units.HumanSize(float64(b.Size)), b.Key); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	return tw.Flush()
+}
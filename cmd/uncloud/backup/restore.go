@@ -0,0 +1,88 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type restoreOptions struct {
+	service string
+	key     string
+	yes     bool
+	context string
+}
+
+func NewRestoreCommand() *cobra.Command {
+	opts := restoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore SERVICE [KEY]",
+		Short: "Restore a backup into a running service container.",
+		Long: `Restore a backup into a running service container. The latest backup of the service is restored
+if KEY is not specified. Use 'uc backup ls' to list the backup keys.
+
+PostgreSQL and MySQL backups replace the existing databases included in the backup. Redis backups replace
+the whole dataset: the server is shut down without saving and restarted by the container restart policy to load
+the restored snapshot. Redis servers with append-only file persistence load the AOF instead so it must be disabled
+before restoring.`,
+		Example: `  # Restore the latest backup of the 'db' service.
+  uc backup restore db
+
+  # Restore a specific backup of the 'db' service.
+  uc backup restore db db/20250301T030000Z.postgres.sql.gz`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			if len(args) > 1 {
+				opts.key = args[1]
+			}
+			return restore(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before restoring the backup.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func restore(ctx context.Context, uncli *cli.CLI, opts restoreOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if !opts.yes {
+		what := "the latest backup"
+		if opts.key != "" {
+			what = "backup '" + opts.key + "'"
+		}
+		fmt.Printf("This will restore %s into service '%s' and overwrite its current data.\n", what, opts.service)
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm restore: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. Backup was not restored.")
+			return nil
+		}
+	}
+
+	var b api.Backup
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		b, err = client.RestoreBackup(ctx, opts.service, opts.key)
+		return err
+	}, uncli.ProgressOut(), "Restoring backup")
+	if err != nil {
+		return fmt.Errorf("restore backup: %w", err)
+	}
+
+	fmt.Printf("Backup '%s' restored into service '%s'.\n", b.Key, opts.service)
+	return nil
+}
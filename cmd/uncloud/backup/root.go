@@ -0,0 +1,31 @@
+package backup
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Manage scheduled database backups of services.",
+		Long: `Manage scheduled database backups of services.
+
+Services opt in to backups with the 'x-backup' extension in the Compose file that sets the database engine
+(postgres, mysql, or redis), and optionally the interval between backups and the number of backups to keep.
+Backups are uploaded to the S3-compatible object storage configured with 'uc backup storage set'.`,
+		Example: `  # Back up the 'db' service every 6 hours and keep the last 28 backups (compose.yaml).
+  services:
+    db:
+      image: postgres:17
+      x-backup:
+        engine: postgres
+        interval: 6h
+        retention: 28`,
+	}
+	cmd.AddCommand(
+		NewListCommand(),
+		NewRestoreCommand(),
+		NewStorageCommand(),
+	)
+	return cmd
+}
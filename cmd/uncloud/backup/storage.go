@@ -0,0 +1,134 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewStorageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Configure the object storage for backups.",
+	}
+	cmd.AddCommand(
+		newStorageSetCommand(),
+		newStorageShowCommand(),
+	)
+	return cmd
+}
+
+type storageSetOptions struct {
+	storage api.BackupStorage
+	context string
+}
+
+func newStorageSetCommand() *cobra.Command {
+	opts := storageSetOptions{}
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Configure the S3-compatible object storage for backups.",
+		Long: "Configure the S3-compatible object storage such as AWS S3, Cloudflare R2, Backblaze B2, or MinIO " +
+			"for backups.\nThe configuration including the credentials is stored in the cluster store.",
+		Example: `  # Store backups in an AWS S3 bucket.
+  uc backup storage set --endpoint https://s3.eu-central-1.amazonaws.com --region eu-central-1 \
+    --bucket my-backups --access-key-id AKIA... --secret-access-key ...
+
+  # Store backups in a MinIO bucket under the 'prod' prefix using credentials from the environment.
+  AWS_ACCESS_KEY_ID=... AWS_SECRET_ACCESS_KEY=... \
+    uc backup storage set --endpoint https://minio.example.com --bucket backups --prefix prod`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "access-key-id", "AWS_ACCESS_KEY_ID")
+			cli.BindEnvToFlag(cmd, "secret-access-key", "AWS_SECRET_ACCESS_KEY")
+
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setStorage(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.storage.Endpoint, "endpoint", "",
+		"URL of the S3 API, e.g. https://s3.eu-central-1.amazonaws.com.")
+	cmd.Flags().StringVar(&opts.storage.Region, "region", "",
+		"Region of the bucket. (default us-east-1)")
+	cmd.Flags().StringVar(&opts.storage.Bucket, "bucket", "",
+		"Name of the bucket to store backups in.")
+	cmd.Flags().StringVar(&opts.storage.Prefix, "prefix", "",
+		"Key prefix for the backups within the bucket.")
+	cmd.Flags().StringVar(&opts.storage.AccessKeyID, "access-key-id", "",
+		"Access key ID. [$AWS_ACCESS_KEY_ID]")
+	cmd.Flags().StringVar(&opts.storage.SecretAccessKey, "secret-access-key", "",
+		"Secret access key. [$AWS_SECRET_ACCESS_KEY]")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	_ = cmd.MarkFlagRequired("endpoint")
+	_ = cmd.MarkFlagRequired("bucket")
+
+	return cmd
+}
+
+func setStorage(ctx context.Context, uncli *cli.CLI, opts storageSetOptions) error {
+	if err := opts.storage.Validate(); err != nil {
+		return err
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if err = client.SetBackupStorage(ctx, opts.storage); err != nil {
+		return fmt.Errorf("set backup storage: %w", err)
+	}
+	fmt.Println("Backup storage configured.")
+	return nil
+}
+
+func newStorageShowCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the object storage configuration for backups.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return showStorage(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func showStorage(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	storage, err := client.GetBackupStorage(ctx)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			fmt.Println("Backup storage not configured.")
+			return nil
+		}
+		return fmt.Errorf("get backup storage: %w", err)
+	}
+
+	region := storage.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	fmt.Printf("Endpoint:       %s\n", storage.Endpoint)
+	fmt.Printf("Region:         %s\n", region)
+	fmt.Printf("Bucket:         %s\n", storage.Bucket)
+	fmt.Printf("Prefix:         %s\n", storage.Prefix)
+	fmt.Printf("Access key ID:  %s\n", storage.AccessKeyID)
+	return nil
+}
@@ -73,5 +73,6 @@ func runBuild(ctx context.Context, uncli *cli.CLI, opts cli.BuildOptions) error
 		return nil
 	}
 
+	opts.Output = uncli.Output
 	return cli.BuildServices(ctx, servicesToBuild, opts)
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/docker/cli/cli/streams"
 	"github.com/docker/compose/v2/pkg/progress"
@@ -15,14 +16,16 @@ import (
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
 	"github.com/spf13/cobra"
 )
 
 type deployOptions struct {
-	caddyfile string
-	image     string
-	machines  []string
-	context   string
+	caddyfile      string
+	image          string
+	machines       []string
+	overrideFreeze string
+	context        string
 }
 
 func NewDeployCommand() *cobra.Command {
@@ -46,6 +49,8 @@ func NewDeployCommand() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
 		"Machine names to deploy to. Can be specified multiple times or as a comma-separated "+
 			"list of machine names. (default is all machines)")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Deploy Caddy even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
 	cmd.Flags().StringVarP(
 		&opts.context, "context", "c", "",
 		"Name of the cluster context to deploy to. (default is the current context)",
@@ -140,6 +145,11 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 		fmt.Println(plan.Format(resolver))
 		fmt.Println()
 
+		ctx, err = clusterClient.CheckDeployFreeze(ctx, "deploy service "+client.CaddyServiceName, opts.overrideFreeze)
+		if err != nil {
+			return err
+		}
+
 		confirmed, err := cli.Confirm()
 		if err != nil {
 			return fmt.Errorf("confirm deployment: %w", err)
@@ -164,6 +174,51 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 	return UpdateDomainRecords(ctx, clusterClient, uncli.ProgressOut())
 }
 
+// NewClusterDeployment creates a deployment of the Caddy service to all cluster machines. It uses the image of the
+// deployed Caddy service to deploy it to new machines without upgrading it on existing ones, or the latest image
+// if the service is not deployed yet.
+func NewClusterDeployment(ctx context.Context, clusterClient *client.Client) (*deploy.Deployment, error) {
+	image, err := deployedImage(ctx, clusterClient)
+	if err != nil {
+		return nil, err
+	}
+	d, err := clusterClient.NewCaddyDeployment(image, "", api.Placement{})
+	if err != nil {
+		return nil, fmt.Errorf("create caddy deployment: %w", err)
+	}
+	return d, nil
+}
+
+// deployedImage returns the image of the most recently created container of the deployed Caddy service or an empty
+// string if the service is not deployed.
+func deployedImage(ctx context.Context, clusterClient *client.Client) (string, error) {
+	svc, err := clusterClient.InspectService(ctx, client.CaddyServiceName)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("inspect caddy service: %w", err)
+	}
+	if len(svc.Containers) == 0 {
+		return "", nil
+	}
+
+	image := svc.Containers[0].Container.Config.Image
+	// Find the latest created container and use its image.
+	var latestCreated time.Time
+	for _, c := range svc.Containers[1:] {
+		created, err := time.Parse(time.RFC3339Nano, c.Container.Created)
+		if err != nil {
+			continue
+		}
+		if created.After(latestCreated) {
+			latestCreated = created
+			image = c.Container.Config.Image
+		}
+	}
+	return image, nil
+}
+
 func UpdateDomainRecords(ctx context.Context, clusterClient *client.Client, progressOut *streams.Out) error {
 	domain, err := clusterClient.GetDomain(ctx)
 	if err != nil {
@@ -0,0 +1,253 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type capacityOptions struct {
+	service  string
+	replicas int
+	context  string
+}
+
+func NewCapacityCommand() *cobra.Command {
+	opts := capacityOptions{}
+	cmd := &cobra.Command{
+		Use:   "capacity",
+		Short: "Show the total, reserved, and used resources of the cluster.",
+		Long: `Show the total, reserved, and used CPU, memory, and disk of each machine and the resources reserved by services.
+
+The reserved CPU is the sum of the CPU limits ('cpus') and the reserved memory is the sum of the memory reservations
+('mem_reservation') of the running containers. Machines with more reserved than total CPU or memory are flagged
+as over-committed. Use --service to estimate how many more replicas of a service fit within the unreserved resources.`,
+		Example: `  # Show the cluster capacity.
+  uc cluster capacity
+
+  # Check if 3 more replicas of the 'web' service fit in the cluster.
+  uc cluster capacity --service web --replicas 3`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return capacity(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.service, "service", "",
+		"Name of the service to estimate the headroom for additional replicas.")
+	cmd.Flags().IntVar(&opts.replicas, "replicas", 1,
+		"Number of additional replicas of the service to check if they fit. Used with --service.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func capacity(ctx context.Context, uncli *cli.CLI, opts capacityOptions) error {
+	if opts.replicas < 1 {
+		return errors.New("replicas must be at least 1")
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	report, err := client.ClusterCapacity(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster capacity: %w", err)
+	}
+
+	if err = printMachines(report); err != nil {
+		return err
+	}
+	fmt.Println()
+	if err = printServices(report); err != nil {
+		return err
+	}
+
+	if opts.service == "" {
+		return nil
+	}
+	svc, err := client.InspectService(ctx, opts.service)
+	if err != nil {
+		return fmt.Errorf("inspect service: %w", err)
+	}
+	if len(svc.Containers) == 0 {
+		return fmt.Errorf("service '%s' has no containers to estimate the headroom from", svc.Name)
+	}
+	fmt.Println()
+	printHeadroom(report, svc, opts.replicas)
+	return nil
+}
+
+func printMachines(report api.CapacityReport) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "MACHINE\tCPU USED/RESERVED/TOTAL\tMEMORY USED/RESERVED/TOTAL\t"+
+		"DISK USED/TOTAL\tNOTES"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	var total api.MachineCapacity
+	total.Machine.Resources = &api.MachineResources{}
+	total.Usage = &api.MachineUsage{}
+	for _, m := range report.Machines {
+		var notes []string
+		if !m.Machine.Available() {
+			notes = append(notes, "down")
+		}
+		if m.Machine.Cordoned {
+			notes = append(notes, "cordoned")
+		}
+		if m.Overcommitted() {
+			notes = append(notes, "over-committed")
+		}
+		if m.Machine.Resources == nil {
+			notes = append(notes, "resources unknown")
+		}
+
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", m.Machine.Name,
+			cpuColumn(m), memoryColumn(m), diskColumn(m), strings.Join(notes, ", ")); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+
+		total.ReservedCPU += m.ReservedCPU
+		total.ReservedMemory += m.ReservedMemory
+		if r := m.Machine.Resources; r != nil {
+			total.Machine.Resources.CPUs += r.CPUs
+			total.Machine.Resources.Memory += r.Memory
+			total.Machine.Resources.Disk += r.Disk
+		}
+		if u := m.Usage; u != nil {
+			total.Usage.CPU += u.CPU
+			total.Usage.Memory += u.Memory
+			total.Usage.Disk += u.Disk
+		}
+	}
+
+	if _, err := fmt.Fprintf(tw, "TOTAL\t%s\t%s\t%s\t\n",
+		cpuColumn(total), memoryColumn(total), diskColumn(total)); err != nil {
+		return fmt.Errorf("write row: %w", err)
+	}
+	return tw.Flush()
+}
+
+func cpuColumn(m api.MachineCapacity) string {
+	used, total := "-", "-"
+	if m.Usage != nil {
+		used = strconv.FormatFloat(m.Usage.CPU, 'f', 1, 64)
+	}
+	if m.Machine.Resources != nil {
+		total = strconv.Itoa(m.Machine.Resources.CPUs)
+	}
+	return used + " / " + formatCPU(m.ReservedCPU) + " / " + total
+}
+
+func memoryColumn(m api.MachineCapacity) string {
+	used, total := "-", "-"
+	if m.Usage != nil {
+		used = units.BytesSize(float64(m.Usage.Memory))
+	}
+	if m.Machine.Resources != nil {
+		total = units.BytesSize(float64(m.Machine.Resources.Memory))
+	}
+	return used + " / " + units.BytesSize(float64(m.ReservedMemory)) + " / " + total
+}
+
+func diskColumn(m api.MachineCapacity) string {
+	used, total := "-", "-"
+	if m.Usage != nil && m.Usage.Disk > 0 {
+		used = units.HumanSize(float64(m.Usage.Disk))
+	}
+	if m.Machine.Resources != nil && m.Machine.Resources.Disk > 0 {
+		total = units.HumanSize(float64(m.Machine.Resources.Disk))
+	}
+	return used + " / " + total
+}
+
+// formatCPU formats the CPU nanocores as a number of cores.
+func formatCPU(nanocores int64) string {
+	return strconv.FormatFloat(float64(nanocores)/api.Core, 'f', -1, 64)
+}
+
+func printServices(report api.CapacityReport) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "SERVICE\tREPLICAS\tCPU PER REPLICA\tMEMORY PER REPLICA\tCPU TOTAL\t"+
+		"MEMORY TOTAL"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, s := range report.Services {
+		cpu, cpuTotal, memory, memoryTotal := "-", "-", "-", "-"
+		if s.CPU > 0 {
+			cpu = formatCPU(s.CPU)
+			cpuTotal = formatCPU(s.CPU * int64(s.Replicas))
+		}
+		if s.Memory > 0 {
+			memory = units.BytesSize(float64(s.Memory))
+			memoryTotal = units.BytesSize(float64(s.Memory * int64(s.Replicas)))
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			s.Service, s.Replicas, cpu, memory, cpuTotal, memoryTotal); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	return tw.Flush()
+}
+
+// printHeadroom prints how many more replicas of the service fit on the machines within their unreserved resources.
+func printHeadroom(report api.CapacityReport, svc api.Service, replicas int) {
+	spec := svc.Containers[0].Container.ServiceSpec
+	res := spec.Container.Resources
+
+	headroom := report.Headroom(res.CPU, res.MemoryReservation, spec.Placement.Machines)
+	if headroom == nil {
+		fmt.Printf("Service '%s' doesn't reserve CPU or memory so the number of its replicas is not limited "+
+			"by reservations.\n", svc.Name)
+		return
+	}
+
+	var reqs []string
+	if res.CPU > 0 {
+		reqs = append(reqs, formatCPU(res.CPU)+" CPU")
+	}
+	if res.MemoryReservation > 0 {
+		reqs = append(reqs, units.BytesSize(float64(res.MemoryReservation))+" of memory")
+	}
+	fmt.Printf("Service '%s' reserves %s per replica.\n", svc.Name, strings.Join(reqs, " and "))
+
+	names := make([]string, 0, len(headroom))
+	fit := 0
+	for name, n := range headroom {
+		fit += n
+		if n > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	perMachine := make([]string, len(names))
+	for i, name := range names {
+		perMachine[i] = fmt.Sprintf("%s (%d)", name, headroom[name])
+	}
+
+	if fit == 0 {
+		fmt.Println("No more replicas fit within the unreserved resources of the eligible machines.")
+	} else {
+		fmt.Printf("Up to %d more replicas fit: %s.\n", fit, strings.Join(perMachine, ", "))
+	}
+	if replicas <= fit {
+		fmt.Printf("%d more replicas fit in the cluster.\n", replicas)
+	} else {
+		fmt.Printf("%d more replicas don't fit in the cluster: add machines or reduce the reservations.\n",
+			replicas)
+	}
+}
@@ -0,0 +1,173 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewEnvCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Manage the default environment variables of all service containers.",
+		Long: `Manage the default environment variables set in all service containers, e.g. TZ or HTTP_PROXY.
+Variables set by a service take precedence over the defaults. Changes apply to new containers, redeploy
+the services to apply them to the existing ones.
+
+Every service container also gets the following variables with its metadata:
+  ` + api.EnvMachine + `      Name of the machine running the container.
+  ` + api.EnvMachineID + `   ID of the machine running the container.
+  ` + api.EnvMachineIP + `   IP address of the machine in the cluster network.
+  ` + api.EnvService + `      Name of the service.
+  ` + api.EnvServiceID + `   ID of the service.
+  ` + api.EnvReplica + `      Name of the container that uniquely identifies the service replica.`,
+	}
+	cmd.AddCommand(
+		newEnvListCommand(),
+		newEnvSetCommand(),
+		newEnvUnsetCommand(),
+	)
+	return cmd
+}
+
+func newEnvListCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List the default environment variables.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listEnv(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newEnvSetCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "set KEY=VALUE [KEY=VALUE...]",
+		Short: "Set default environment variables.",
+		Example: `  # Use the same time zone and HTTP proxy in all service containers.
+  uc cluster env set TZ=Europe/Berlin HTTP_PROXY=http://proxy.internal:3128`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			vars := make(api.EnvVars, len(args))
+			for _, arg := range args {
+				k, v, ok := strings.Cut(arg, "=")
+				if !ok {
+					return fmt.Errorf("invalid environment variable '%s', expected KEY=VALUE", arg)
+				}
+				vars[k] = v
+			}
+			return updateEnv(cmd.Context(), uncli, contextName, func(env api.EnvVars) error {
+				maps.Copy(env, vars)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newEnvUnsetCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "unset KEY [KEY...]",
+		Short: "Remove default environment variables.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return updateEnv(cmd.Context(), uncli, contextName, func(env api.EnvVars) error {
+				for _, k := range args {
+					if _, ok := env[k]; !ok {
+						return fmt.Errorf("default environment variable '%s' not found", k)
+					}
+					delete(env, k)
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func listEnv(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	if len(settings.DefaultEnv) == 0 {
+		fmt.Println("No default environment variables set.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE")
+	for _, k := range slices.Sorted(maps.Keys(settings.DefaultEnv)) {
+		fmt.Fprintf(tw, "%s\t%s\n", k, settings.DefaultEnv[k])
+	}
+	return tw.Flush()
+}
+
+// updateEnv applies the change to the default environment variables and stores them in the cluster settings
+// unless they have been changed concurrently.
+func updateEnv(ctx context.Context, uncli *cli.CLI, contextName string, update func(env api.EnvVars) error) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	env := make(api.EnvVars, len(settings.DefaultEnv))
+	maps.Copy(env, settings.DefaultEnv)
+	if err = update(env); err != nil {
+		return err
+	}
+	settings.DefaultEnv = env
+	if len(env) == 0 {
+		settings.DefaultEnv = nil
+	}
+	if err = settings.Validate(); err != nil {
+		return err
+	}
+
+	if _, err = client.SetSettings(ctx, settings); err != nil {
+		return fmt.Errorf("set cluster settings: %w", err)
+	}
+	fmt.Println("Default environment variables updated. They apply to new containers, " +
+		"redeploy the services to apply them to the existing ones.")
+	return nil
+}
@@ -0,0 +1,129 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewFreezeCommand() *cobra.Command {
+	var contextName, until, reason string
+	cmd := &cobra.Command{
+		Use:   "freeze [--until TIME --reason REASON]",
+		Short: "Freeze deploys in the cluster until the given time, or show the current freeze.",
+		Long: `Freeze deploys in the cluster until the given time, or show the current freeze if --until is not set.
+
+While deploys are frozen, the commands that change services, such as 'uc deploy', 'uc service run',
+'uc service scale', 'uc service rollback', and 'uc service rm', fail unless they're run with
+'--override-freeze REASON'. Overrides are recorded in the audit log of the cluster and can be listed with
+'uc audit freeze-overrides'. Freezing again replaces the current freeze.
+
+The end time is a weekday and time (Mon 09:00) or a time (09:00) meaning its next occurrence, a duration (12h),
+a date with an optional time (2026-01-05 09:00), or an RFC 3339 timestamp. Times are in the local time zone.`,
+		Example: `  # Prevent deploys over the weekend.
+  uc cluster freeze --until "Mon 09:00" --reason "weekend"
+
+  # Show the current freeze.
+  uc cluster freeze`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			if until == "" {
+				if reason != "" {
+					return errors.New("--until must be set to freeze deploys")
+				}
+				return showFreeze(cmd.Context(), uncli, contextName)
+			}
+			return freeze(cmd.Context(), uncli, contextName, until, reason)
+		},
+	}
+	cmd.Flags().StringVar(&until, "until", "", "Time until which deploys are frozen, e.g. \"Mon 09:00\".")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason for freezing deploys shown to anyone who tries to deploy.")
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func NewUnfreezeCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "unfreeze",
+		Short: "Lift the deploy freeze before it ends.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			client, err := uncli.ConnectCluster(cmd.Context(), contextName)
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer client.Close()
+
+			if err = client.RemoveDeployFreeze(cmd.Context()); err != nil {
+				if errors.Is(err, api.ErrNotFound) {
+					fmt.Println("Deploys are not frozen.")
+					return nil
+				}
+				return fmt.Errorf("remove deploy freeze: %w", err)
+			}
+			fmt.Println("Deploy freeze lifted.")
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func freeze(ctx context.Context, uncli *cli.CLI, contextName, until, reason string) error {
+	untilTime, err := api.ParseFreezeUntil(until, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if err = client.SetDeployFreeze(ctx, api.DeployFreeze{Until: untilTime.UTC(), Reason: reason}); err != nil {
+		return fmt.Errorf("set deploy freeze: %w", err)
+	}
+	fmt.Printf("Deploys frozen until %s (in %s).\n",
+		untilTime.Local().Format(time.RFC1123), units.HumanDuration(time.Until(untilTime)))
+	return nil
+}
+
+func showFreeze(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	f, err := client.DeployFreeze(ctx)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("get deploy freeze: %w", err)
+	}
+	if errors.Is(err, api.ErrNotFound) || !f.Active(time.Now()) {
+		fmt.Println("Deploys are not frozen.")
+		return nil
+	}
+
+	fmt.Printf("Deploys frozen until %s (in %s).\n",
+		f.Until.Local().Format(time.RFC1123), units.HumanDuration(time.Until(f.Until)))
+	if f.Reason != "" {
+		fmt.Printf("Reason: %s\n", f.Reason)
+	}
+	return nil
+}
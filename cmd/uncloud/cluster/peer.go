@@ -0,0 +1,394 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type peerOptions struct {
+	allow   []string
+	yes     bool
+	context string
+}
+
+func NewPeerCommand() *cobra.Command {
+	opts := peerOptions{}
+	cmd := &cobra.Command{
+		Use:   "peer OTHER-CONTEXT",
+		Short: "Peer the cluster with the cluster of another context over encrypted WireGuard tunnels.",
+		Long: `Peer the cluster with the cluster of another context over encrypted WireGuard tunnels.
+
+The command exchanges the WireGuard public keys, endpoints, and subnets of the machines between the clusters so
+that every machine of one cluster becomes a WireGuard peer of every machine of the other. The containers in one
+cluster can then reach the containers in the other by their IP addresses, e.g. for staged migrations or disaster
+recovery setups. The cluster management network is never routed between the clusters.
+
+The networks of the clusters must not overlap. Use 'uc network migrate' to renumber one of them first if they do.
+Use --allow to only route specific IP ranges of either cluster. The IP ranges of a cluster not covered
+by any --allow range are not reachable from the other cluster unless no --allow range is within its network.
+
+The peering is a snapshot of the machines at the time of peering. Run the command again after adding or removing
+machines or changing their endpoints in either cluster to update it.`,
+		Example: `  # Peer the current cluster with the cluster of the 'dr' context.
+  uc cluster peer dr
+
+  # Only allow reaching the database subnet of the 'dr' cluster from the 'prod' cluster and vice versa.
+  uc cluster peer dr -c prod --allow 10.211.3.0/24 --allow 10.210.5.0/24`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return peer(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+	cmd.Flags().StringSliceVar(&opts.allow, "allow", nil,
+		"IP range (CIDR) of either cluster allowed to be reached from the other cluster. Can be specified\n"+
+			"multiple times. (default is the whole networks of both clusters)")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before peering the clusters. [$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func NewUnpeerCommand() *cobra.Command {
+	opts := peerOptions{}
+	cmd := &cobra.Command{
+		Use:   "unpeer NAME",
+		Short: "Remove the peering with another cluster.",
+		Long: `Remove the peering with another cluster. If NAME is a context in the Uncloud config, the peering is also
+removed from its cluster. Otherwise, run the command against the other cluster as well to remove it there.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return unpeer(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before removing the peering. [$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func NewPeersCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "peers",
+		Short: "List the other clusters peered with the cluster.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listPeers(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+// peeredCluster is a cluster connected to be peered with another one.
+type peeredCluster struct {
+	name    string
+	client  *client.Client
+	network netip.Prefix
+	// allowed are the IP ranges of the cluster allowed to be reached from the other cluster.
+	allowed  []netip.Prefix
+	machines []api.PeerMachine
+}
+
+func peer(ctx context.Context, uncli *cli.CLI, otherContext string, opts peerOptions) error {
+	localContext, err := resolveContextName(uncli, opts.context)
+	if err != nil {
+		return err
+	}
+	if localContext == otherContext {
+		return errors.New("can't peer a cluster with itself")
+	}
+	var allowed []netip.Prefix
+	for _, cidr := range opts.allow {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid allowed IP range '%s': %w", cidr, err)
+		}
+		if prefix != prefix.Masked() {
+			return fmt.Errorf("allowed IP range '%s' has host bits set, did you mean '%s'?", prefix, prefix.Masked())
+		}
+		allowed = append(allowed, prefix)
+	}
+
+	local, err := connectPeeredCluster(ctx, uncli, localContext)
+	if err != nil {
+		return err
+	}
+	defer local.client.Close()
+	other, err := connectPeeredCluster(ctx, uncli, otherContext)
+	if err != nil {
+		return err
+	}
+	defer other.client.Close()
+
+	if local.network.Overlaps(other.network) {
+		return fmt.Errorf("network %s of cluster '%s' overlaps network %s of cluster '%s'. "+
+			"Renumber one of the clusters with 'uc network migrate' first",
+			local.network, local.name, other.network, other.name)
+	}
+	for _, prefix := range allowed {
+		switch {
+		case local.network.Overlaps(prefix):
+			local.allowed = append(local.allowed, prefix)
+		case other.network.Overlaps(prefix):
+			other.allowed = append(other.allowed, prefix)
+		default:
+			return fmt.Errorf("allowed IP range %s is outside the networks of both clusters (%s and %s)",
+				prefix, local.network, other.network)
+		}
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CLUSTER\tNETWORK\tMACHINES\tREACHABLE FROM PEER")
+	for _, c := range []*peeredCluster{local, other} {
+		reachable := "whole network"
+		if len(c.allowed) > 0 {
+			reachable = joinPrefixes(c.allowed)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", c.name, c.network, len(c.machines), reachable)
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	if !opts.yes {
+		if !cli.IsStdinTerminal() {
+			return errors.New("cannot ask to confirm peering in non-interactive mode, " +
+				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+		}
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm peering: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	// Remember the existing peering with the other cluster to restore it if peering the other cluster fails.
+	peerings, err := local.client.ListClusterPeerings(ctx)
+	if err != nil {
+		return fmt.Errorf("list peerings of cluster '%s': %w", local.name, err)
+	}
+	var previous *api.ClusterPeering
+	if i := slices.IndexFunc(peerings, func(p api.ClusterPeering) bool { return p.Name == other.name }); i >= 0 {
+		previous = &peerings[i]
+	}
+
+	// Both peerings share the allowed IPs of both clusters so that each side can scope the addresses of the other.
+	allowedIPs := append(local.allowed[:len(local.allowed):len(local.allowed)], other.allowed...)
+	if err = local.client.SetClusterPeering(ctx, api.ClusterPeering{
+		Name:       other.name,
+		Network:    other.network,
+		Machines:   other.machines,
+		AllowedIPs: allowedIPs,
+	}); err != nil {
+		return fmt.Errorf("store peering with cluster '%s' in cluster '%s': %w", other.name, local.name, err)
+	}
+	if err = other.client.SetClusterPeering(ctx, api.ClusterPeering{
+		Name:       local.name,
+		Network:    local.network,
+		Machines:   local.machines,
+		AllowedIPs: allowedIPs,
+	}); err != nil {
+		err = fmt.Errorf("store peering with cluster '%s' in cluster '%s': %w", local.name, other.name, err)
+		// Roll back the peering in the local cluster even if the command is interrupted to not leave it one-sided.
+		if rbErr := rollbackPeering(context.WithoutCancel(ctx), local, other.name, previous); rbErr != nil {
+			return fmt.Errorf("%w. Failed to roll back the peering in cluster '%s': %v. "+
+				"Run 'uc cluster unpeer %s -c %s' to undo it or retry the command",
+				err, local.name, rbErr, other.name, local.name)
+		}
+		return fmt.Errorf("%w. The peering in cluster '%s' has been rolled back", err, local.name)
+	}
+
+	fmt.Printf("Clusters '%s' and '%s' peered. The machines are reconfiguring their WireGuard peers.\n",
+		local.name, other.name)
+	return nil
+}
+
+// rollbackPeering restores the previous peering with the named cluster in the cluster or removes the peering
+// if there was none.
+func rollbackPeering(ctx context.Context, c *peeredCluster, name string, previous *api.ClusterPeering) error {
+	if previous != nil {
+		return c.client.SetClusterPeering(ctx, *previous)
+	}
+	if err := c.client.RemoveClusterPeering(ctx, name); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// resolveContextName returns the context name or the current context name if not specified.
+func resolveContextName(uncli *cli.CLI, contextName string) (string, error) {
+	if contextName != "" {
+		return contextName, nil
+	}
+	if uncli.Config == nil || uncli.Config.CurrentContext == "" {
+		return "", errors.New("the current cluster context is not set, specify it with the '--context' flag")
+	}
+	return uncli.Config.CurrentContext, nil
+}
+
+// connectPeeredCluster connects to the cluster of the context and collects its network and machines.
+func connectPeeredCluster(ctx context.Context, uncli *cli.CLI, contextName string) (*peeredCluster, error) {
+	c, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("connect to cluster '%s': %w", contextName, err)
+	}
+	pc := &peeredCluster{name: contextName, client: c}
+
+	if pc.network, err = c.ClusterNetwork(ctx); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("get network of cluster '%s': %w", contextName, err)
+	}
+	members, err := c.ListMachines(ctx, nil)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("list machines of cluster '%s': %w", contextName, err)
+	}
+	for _, member := range members {
+		m := member.Machine
+		if err = m.Network.Validate(); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("invalid network configuration of machine '%s' in cluster '%s': %w",
+				m.Name, contextName, err)
+		}
+		subnet, _ := m.Network.Subnet.ToPrefix()
+		pm := api.PeerMachine{
+			Name:      m.Name,
+			Subnet:    subnet,
+			PublicKey: m.Network.PublicKey,
+		}
+		for _, ep := range m.Network.Endpoints {
+			if addrPort, err := ep.ToAddrPort(); err == nil {
+				pm.Endpoints = append(pm.Endpoints, addrPort)
+			}
+		}
+		pc.machines = append(pc.machines, pm)
+	}
+	return pc, nil
+}
+
+func unpeer(ctx context.Context, uncli *cli.CLI, name string, opts peerOptions) error {
+	localContext, err := resolveContextName(uncli, opts.context)
+	if err != nil {
+		return err
+	}
+
+	if !opts.yes {
+		if !cli.IsStdinTerminal() {
+			return errors.New("cannot ask to confirm removing the peering in non-interactive mode, " +
+				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+		}
+		fmt.Printf("The containers in clusters '%s' and '%s' will no longer be able to reach each other.\n",
+			localContext, name)
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm removing peering: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	c, err := uncli.ConnectCluster(ctx, localContext)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	if err = c.RemoveClusterPeering(ctx, name); err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("cluster '%s' is not peered with cluster '%s'", localContext, name)
+		}
+		return fmt.Errorf("remove peering: %w", err)
+	}
+	fmt.Printf("Peering with cluster '%s' removed from cluster '%s'.\n", name, localContext)
+
+	// Remove the peering from the other cluster as well if it's a known context.
+	if uncli.Config == nil {
+		return nil
+	}
+	if _, ok := uncli.Config.Contexts[name]; !ok {
+		fmt.Printf("Context '%s' not found in the Uncloud config, run 'uc cluster unpeer %s' against "+
+			"the other cluster to remove the peering there.\n", name, localContext)
+		return nil
+	}
+	other, err := uncli.ConnectCluster(ctx, name)
+	if err != nil {
+		return fmt.Errorf("connect to cluster '%s' to remove the peering there: %w", name, err)
+	}
+	defer other.Close()
+
+	if err = other.RemoveClusterPeering(ctx, localContext); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("remove peering from cluster '%s': %w", name, err)
+	}
+	fmt.Printf("Peering with cluster '%s' removed from cluster '%s'.\n", localContext, name)
+	return nil
+}
+
+func listPeers(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	c, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	peerings, err := c.ListClusterPeerings(ctx)
+	if err != nil {
+		return fmt.Errorf("list cluster peerings: %w", err)
+	}
+	if len(peerings) == 0 {
+		fmt.Println("The cluster is not peered with any other cluster.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tNETWORK\tMACHINES\tALLOWED IPS\tPEERED")
+	for _, p := range peerings {
+		allowedIPs := "all"
+		if len(p.AllowedIPs) > 0 {
+			allowedIPs = joinPrefixes(p.AllowedIPs)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", p.Name, p.Network, len(p.Machines), allowedIPs,
+			p.CreatedAt.Local().Format(time.DateTime))
+	}
+	return tw.Flush()
+}
+
+func joinPrefixes(prefixes []netip.Prefix) string {
+	s := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		s[i] = p.String()
+	}
+	return strings.Join(s, ", ")
+}
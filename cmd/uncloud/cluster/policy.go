@@ -0,0 +1,271 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewPolicyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Manage cluster policies enforced by machines.",
+	}
+	cmd.AddCommand(
+		newImageSigningCommand(),
+	)
+	return cmd
+}
+
+func newImageSigningCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image-signing",
+		Short: "Manage the policy that requires service images to be signed with cosign.",
+		Long: `Manage the policy that requires service images to be signed with cosign.
+
+When at least one trusted key or identity is added, machines verify that the images are signed by one of them
+before pulling an image or creating a service container, and reject unsigned images. The signatures are looked up
+in the image registry where cosign stores them. Images pushed directly to machines with 'uc image push' can't be
+verified and are rejected if the policy applies to them. Use 'scope' to limit the policy to specific repositories.
+
+Keyless signing certificates are verified against the trusted roots at the time they were issued. The signing time
+is not verified in the Rekor transparency log.`,
+	}
+	cmd.AddCommand(
+		newImageSigningShowCommand(),
+		newImageSigningAddKeyCommand(),
+		newImageSigningAddIdentityCommand(),
+		newImageSigningRemoveCommand(),
+		newImageSigningScopeCommand(),
+	)
+	return cmd
+}
+
+func newImageSigningShowCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the trusted keys and identities of the image signing policy.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return showImageSigning(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newImageSigningAddKeyCommand() *cobra.Command {
+	var contextName, keyFile string
+	cmd := &cobra.Command{
+		Use:   "add-key NAME --key FILE",
+		Short: "Trust images signed with a cosign key pair.",
+		Example: `  # Require images to be signed with the key generated by 'cosign generate-key-pair'.
+  uc cluster policy image-signing add-key release --key cosign.pub`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			keyPEM, err := os.ReadFile(keyFile)
+			if err != nil {
+				return fmt.Errorf("read public key: %w", err)
+			}
+			return updateImageSigning(cmd.Context(), uncli, contextName, func(p *api.ImageSigningPolicy) error {
+				p.Keys = append(p.Keys, api.SigningKey{Name: args[0], PublicKey: string(keyPEM)})
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&keyFile, "key", "", "Path to the PEM-encoded public key, e.g. cosign.pub.")
+	_ = cmd.MarkFlagRequired("key")
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newImageSigningAddIdentityCommand() *cobra.Command {
+	var contextName, issuer, subject, rootsFile string
+	cmd := &cobra.Command{
+		Use:   "add-identity NAME --issuer URL --subject SUBJECT --roots FILE",
+		Short: "Trust images signed with keyless signing by an OIDC identity.",
+		Example: `  # Require images to be signed by the release workflow in GitHub Actions.
+  uc cluster policy image-signing add-identity github-release \
+    --issuer https://token.actions.githubusercontent.com \
+    --subject https://github.com/acme/app/.github/workflows/release.yaml@refs/heads/main \
+    --roots fulcio_v1.crt.pem`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			roots, err := os.ReadFile(rootsFile)
+			if err != nil {
+				return fmt.Errorf("read root certificates: %w", err)
+			}
+			return updateImageSigning(cmd.Context(), uncli, contextName, func(p *api.ImageSigningPolicy) error {
+				p.Identities = append(p.Identities, api.SigningIdentity{
+					Name:    args[0],
+					Issuer:  issuer,
+					Subject: subject,
+					Roots:   string(roots),
+				})
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&issuer, "issuer", "", "OIDC issuer that authenticated the signer.")
+	cmd.Flags().StringVar(&subject, "subject", "",
+		"Email or URI subject of the signing certificate issued to the signer.")
+	cmd.Flags().StringVar(&rootsFile, "roots", "",
+		"Path to the PEM-encoded root CA certificates of the Fulcio instance that issues the signing certificates.")
+	_ = cmd.MarkFlagRequired("issuer")
+	_ = cmd.MarkFlagRequired("subject")
+	_ = cmd.MarkFlagRequired("roots")
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newImageSigningRemoveCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "rm NAME [NAME...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove trusted keys or identities. The policy is disabled when none are left.",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return updateImageSigning(cmd.Context(), uncli, contextName, func(p *api.ImageSigningPolicy) error {
+				for _, name := range args {
+					if !slices.Contains(p.Names(), name) {
+						return fmt.Errorf("trusted key or identity '%s' not found", name)
+					}
+				}
+				p.Keys = slices.DeleteFunc(p.Keys, func(k api.SigningKey) bool {
+					return slices.Contains(args, k.Name)
+				})
+				p.Identities = slices.DeleteFunc(p.Identities, func(id api.SigningIdentity) bool {
+					return slices.Contains(args, id.Name)
+				})
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newImageSigningScopeCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "scope [PATTERN...]",
+		Short: "Limit the policy to images from the repositories matching the patterns, or all images if none.",
+		Example: `  # Only require images from the acme organisation to be signed.
+  uc cluster policy image-signing scope 'ghcr.io/acme/*'
+
+  # Require all images to be signed.
+  uc cluster policy image-signing scope`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return updateImageSigning(cmd.Context(), uncli, contextName, func(p *api.ImageSigningPolicy) error {
+				p.Images = args
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func showImageSigning(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	policy := settings.ImageSigning
+	if !policy.Enabled() {
+		fmt.Println("Image signing policy is disabled. Add a trusted key or identity to enable it.")
+		return nil
+	}
+
+	scope := "all images"
+	if len(policy.Images) > 0 {
+		scope = strings.Join(policy.Images, ", ")
+	}
+	fmt.Printf("Applies to: %s\n\n", scope)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tISSUER\tSUBJECT")
+	for _, k := range policy.Keys {
+		fmt.Fprintf(tw, "%s\tkey\t-\t-\n", k.Name)
+	}
+	for _, id := range policy.Identities {
+		fmt.Fprintf(tw, "%s\tidentity\t%s\t%s\n", id.Name, id.Issuer, id.Subject)
+	}
+	return tw.Flush()
+}
+
+// updateImageSigning applies the change to the image signing policy and stores it in the cluster settings
+// unless they have been changed concurrently.
+func updateImageSigning(
+	ctx context.Context, uncli *cli.CLI, contextName string, update func(p *api.ImageSigningPolicy) error,
+) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	policy := &api.ImageSigningPolicy{}
+	if settings.ImageSigning != nil {
+		policy = settings.ImageSigning
+	}
+	if err = update(policy); err != nil {
+		return err
+	}
+	if err = policy.Validate(); err != nil {
+		return fmt.Errorf("invalid image signing policy: %w", err)
+	}
+	settings.ImageSigning = policy
+	if len(policy.Names()) == 0 && len(policy.Images) == 0 {
+		settings.ImageSigning = nil
+	}
+
+	if _, err = client.SetSettings(ctx, settings); err != nil {
+		return fmt.Errorf("set cluster settings: %w", err)
+	}
+	if policy.Enabled() {
+		fmt.Printf("Image signing policy updated, trusted: %s.\n", strings.Join(policy.Names(), ", "))
+	} else {
+		fmt.Println("Image signing policy updated, it's disabled as there are no trusted keys or identities.")
+	}
+	return nil
+}
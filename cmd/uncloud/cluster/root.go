@@ -0,0 +1,24 @@
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Inspect and configure the cluster as a whole.",
+	}
+	cmd.AddCommand(
+		NewCapacityCommand(),
+		NewEnvCommand(),
+		NewFreezeCommand(),
+		NewPeerCommand(),
+		NewPeersCommand(),
+		NewPolicyCommand(),
+		NewSettingsCommand(),
+		NewUnfreezeCommand(),
+		NewUnpeerCommand(),
+	)
+	return cmd
+}
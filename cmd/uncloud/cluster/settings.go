@@ -0,0 +1,176 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewSettingsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Manage cluster-wide settings.",
+		Long: `Manage cluster-wide settings stored in the cluster store. Machines apply changes without restarting.
+
+Available settings:
+  ` + api.SettingDomain + `                      Default domain for the service hostnames used when no Uncloud DNS
+                              domain is reserved (see 'uc dns').
+  ` + api.SettingACMEEmail + `                  Email address used by Caddy to register an ACME account for obtaining
+                              TLS certificates.
+  ` + api.SettingDefaultRestartPolicy + `      Restart policy for the service containers that don't specify one:
+                              no|always|unless-stopped|on-failure[:max-retries]. Applies to new
+                              containers. (default unless-stopped)
+  ` + api.SettingImageGCAge + `                Age after which images not used by any container are removed from
+                              the machines hourly, e.g. 168h. (default disabled)
+  ` + api.SettingContainerSyncInterval + `    Interval at which machines sync their containers to the cluster store
+                              in addition to syncing them on changes. (default 30s)
+  ` + api.SettingResourcesUpdateInterval + `  Interval at which machines refresh their resource inventory in the
+                              cluster store. (default 10m)
+  ` + api.SettingImageScanner + `          Vulnerability scanner installed locally used to scan the images before
+                              deploying them with 'uc deploy': trivy|grype. (default disabled)
+  ` + api.SettingImageScanFailOn + `          Minimum severity of vulnerabilities that blocks a deployment, lower
+                              ones are reported as warnings: critical|high|medium|low|unknown.
+                              (default critical)
+  ` + api.SettingTrashRetention + `             Period for which services removed with 'uc service rm' are kept
+                              stopped in the trash and can be restored with 'uc service restore',
+                              e.g. 72h. (default disabled)
+  ` + api.SettingHTTPProxy + `                  Proxy URL for the outbound HTTP requests of the machine daemons,
+                              Docker daemons, and service containers, e.g. http://proxy:3128.
+                              Changes in Docker require restarting it. Applies to new containers.
+  ` + api.SettingHTTPSProxy + `                 Proxy URL for the outbound HTTPS requests, see proxy.http.
+  ` + api.SettingNoProxy + `              Comma-separated hosts, domains, and IP ranges accessed bypassing
+                              the proxy in addition to localhost, .internal, and the cluster network.
+  ` + api.SettingAuditRecordExec + `           Record the interactive exec sessions in service containers for
+                              replaying them with 'uc audit replay': true|false. (default false)
+  ` + api.SettingRegistryMirrors + `            Comma-separated URLs of the registry mirrors the Docker daemons pull
+                              Docker Hub images through, e.g. https://mirror.gcr.io. Docker is
+                              reloaded to apply changes. See 'uc image cache' for a built-in cache.`,
+	}
+	cmd.AddCommand(
+		newSettingsGetCommand(),
+		newSettingsSetCommand(),
+	)
+	return cmd
+}
+
+func newSettingsGetCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "get [KEY]",
+		Short: "Show all cluster settings or the value of a single setting.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			key := ""
+			if len(args) > 0 {
+				key = args[0]
+			}
+			return getSettings(cmd.Context(), uncli, contextName, key)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newSettingsSetCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "set KEY=VALUE [KEY=VALUE...]",
+		Short: "Set one or more cluster settings. An empty value resets a setting to its default.",
+		Example: `  # Use example.com as the default domain for the service hostnames.
+  uc cluster settings set domain=example.com
+
+  # Set the ACME email and remove unused images older than a week.
+  uc cluster settings set acme-email=ops@example.com gc.image-age=168h
+
+  # Reset the default restart policy.
+  uc cluster settings set default-restart-policy=`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setSettings(cmd.Context(), uncli, contextName, args)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func getSettings(ctx context.Context, uncli *cli.CLI, contextName, key string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+
+	if key != "" {
+		value, err := settings.Get(key)
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE")
+	for _, k := range api.SettingKeys {
+		value, _ := settings.Get(k)
+		if value == "" {
+			value = "(default)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", k, value)
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nVersion: %d\n", settings.Version)
+	return nil
+}
+
+func setSettings(ctx context.Context, uncli *cli.CLI, contextName string, args []string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid setting '%s', expected KEY=VALUE", arg)
+		}
+		if err = settings.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	// The settings are only stored if they haven't been changed by someone else since they were read.
+	settings, err = client.SetSettings(ctx, settings)
+	if err != nil {
+		return fmt.Errorf("set cluster settings: %w", err)
+	}
+	fmt.Printf("Cluster settings updated (version %d).\n", settings.Version)
+	return nil
+}
@@ -0,0 +1,117 @@
+package context
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/spf13/cobra"
+)
+
+type checkOptions struct {
+	prune bool
+	yes   bool
+}
+
+func NewCheckCommand() *cobra.Command {
+	opts := checkOptions{}
+	cmd := &cobra.Command{
+		Use:   "check [CONTEXT]",
+		Short: "Check the health of the connections of a cluster context.",
+		Long: `Check the health of the connections of a cluster context (default is the current context).
+
+Each connection is checked by connecting to the machine over SSH or TCP, calling its API, and verifying that
+the machine is a member of the context cluster. Connections to machines that were removed from the cluster
+or re-imaged are reported as stale and can be removed with --prune. Unreachable connections are only reported
+as the machines may be temporarily down.`,
+		Example: `  # Check the connections of the current context.
+  uc ctx check
+
+  # Check the connections of the 'prod' context and remove the stale ones.
+  uc ctx check prod --prune`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			contextName := ""
+			if len(args) == 1 {
+				contextName = args[0]
+			}
+			return check(cmd.Context(), uncli, contextName, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.prune, "prune", false,
+		"Remove the stale connections from the context.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before removing the stale connections.")
+
+	return cmd
+}
+
+func check(ctx context.Context, uncli *cli.CLI, contextName string, opts checkOptions) error {
+	checks, err := uncli.CheckContextConnections(ctx, contextName)
+	if err != nil {
+		return err
+	}
+	if contextName == "" {
+		contextName = uncli.Config.CurrentContext
+	}
+	if len(checks) == 0 {
+		fmt.Printf("No connections found in context '%s'.\n", contextName)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CONNECTION\tMACHINE\tSTATUS")
+	var stale []config.MachineConnection
+	for _, c := range checks {
+		machine := c.Machine
+		if machine == "" {
+			machine = "-"
+		}
+		status := string(c.Status)
+		if c.Err != nil {
+			status += ": " + c.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Connection, machine, status)
+
+		if c.Status == cli.ConnectionStale {
+			stale = append(stale, c.Connection)
+		}
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+	fmt.Println()
+	if !opts.prune {
+		fmt.Printf("Found %d stale connection(s). Run with --prune to remove them from context '%s'.\n",
+			len(stale), contextName)
+		return nil
+	}
+
+	if !opts.yes {
+		fmt.Printf("%d stale connection(s) will be removed from context '%s'.\n", len(stale), contextName)
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No connections were removed.")
+			return nil
+		}
+	}
+
+	if err = uncli.RemoveContextConnections(contextName, stale); err != nil {
+		return fmt.Errorf("remove stale connections: %w", err)
+	}
+	fmt.Printf("Removed %d stale connection(s) from context '%s'.\n", len(stale), contextName)
+	return nil
+}
@@ -10,6 +10,27 @@ func NewRootCommand() *cobra.Command {
 		Use:     "ctx",
 		Aliases: []string{"context"},
 		Short:   "Switch between different cluster contexts. Contains subcommands to manage contexts.",
+		Long: `Switch between different cluster contexts. Contains subcommands to manage contexts.
+
+The contexts are read from the following config files merged in the order of increasing precedence:
+  1. System config: /etc/uncloud/config.yaml
+  2. User config: ~/.config/uncloud/config.yaml (or --uncloud-config)
+  3. Project config: .uncloud/config.yaml in the current directory or its closest parent
+
+A context defined in multiple files is taken from the file with the highest precedence. The current context set
+in the project config pins the context for everyone working in the project. Changes to contexts are always saved
+to the user config.
+
+An SSH connection with 'read_only: true' connects to the read-only API socket of the machine that only allows
+inspecting the cluster, e.g. for monitoring hosts. The machine enforces it for the SSH users that are only
+members of the uncloud-readonly group and have no login shell so they can't run commands on the machine:
+  sudo useradd --shell /usr/sbin/nologin --groups uncloud-readonly observer
+
+  contexts:
+    monitoring:
+      connections:
+        - ssh: observer@203.0.113.10
+          read_only: true`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 			return selectContext(uncli)
@@ -17,6 +38,7 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		NewCheckCommand(),
 		NewListCommand(),
 		NewUseCommand(),
 	)
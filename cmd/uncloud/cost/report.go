@@ -0,0 +1,112 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type reportOptions struct {
+	by      string
+	context string
+}
+
+func NewReportCommand() *cobra.Command {
+	opts := reportOptions{}
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Estimate the monthly cost by service, owner, or machine.",
+		Long: `Estimate the monthly cost of the cluster machines and attribute it to services, owners, or machines.
+
+The monthly cost of a machine is its hourly price times 730 hours. Each running container is attributed the share of
+the machine cost equal to the average of its shares of the machine CPU ('cpus') and memory ('mem_reservation') it
+reserves. The cost of the unreserved resources is split equally between the containers without reservations or is
+reported as unallocated if all containers reserve resources. Machines without an hourly price are excluded.`,
+		Example: `  # Estimate the monthly cost of each service.
+  uc cost report
+
+  # Estimate the monthly cost of each client hosted on the cluster.
+  uc cost report --by owner`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return report(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.by, "by", api.CostByService,
+		fmt.Sprintf("Attribute the cost by '%s', '%s', or '%s'.", api.CostByService, api.CostByOwner, api.CostByMachine))
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func report(ctx context.Context, uncli *cli.CLI, opts reportOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	costs, err := client.ClusterCosts(ctx)
+	if err != nil {
+		return fmt.Errorf("estimate cluster costs: %w", err)
+	}
+	items, err := costs.By(opts.by)
+	if err != nil {
+		return err
+	}
+
+	if len(costs.Providers) == 0 {
+		fmt.Println("No machines with an hourly price. " +
+			"Set it with 'uc machine update MACHINE --hourly-price PRICE' to estimate the costs.")
+		return nil
+	}
+
+	total := costs.Total()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	header := strings.ToUpper(opts.by) + "\tCONTAINERS\tMONTHLY COST\tSHARE"
+	if opts.by == api.CostByMachine {
+		header = "MACHINE\tPROVIDER\tCONTAINERS\tMONTHLY COST\tSHARE"
+	}
+	fmt.Fprintln(tw, header)
+	for _, item := range items {
+		name := item.Name
+		if name == "" {
+			name = "(no owner)"
+		}
+		if opts.by == api.CostByMachine {
+			provider := costs.Providers[item.Name]
+			if provider == "" {
+				provider = "-"
+			}
+			name += "\t" + provider
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.2f\t%s\n", name, item.Containers, item.Monthly, share(item.Monthly, total))
+	}
+	if unallocated := costs.UnallocatedTotal(); opts.by != api.CostByMachine && unallocated > 0 {
+		fmt.Fprintf(tw, "(unallocated)\t-\t%.2f\t%s\n", unallocated, share(unallocated, total))
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nTotal estimated monthly cost: %.2f\n", total)
+	if len(costs.Unpriced) > 0 {
+		fmt.Printf("Machines without an hourly price are excluded: %s\n", strings.Join(costs.Unpriced, ", "))
+	}
+	return nil
+}
+
+// share formats the cost as a percentage of the total cost.
+func share(cost, total float64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", cost/total*100)
+}
@@ -0,0 +1,19 @@
+package cost
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Estimate the cost of the cluster machines and services.",
+		Long: `Estimate the cost of the cluster machines and services.
+Set the hourly price and provider of each machine with 'uc machine update --hourly-price --provider' and the owner
+of the services with the 'x-owner' Compose extension to attribute the cost of the machines to the services or owners.`,
+	}
+	cmd.AddCommand(
+		NewReportCommand(),
+	)
+	return cmd
+}
@@ -7,8 +7,10 @@ import (
 	"strings"
 
 	composecli "github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/scan"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/psviderski/uncloud/pkg/client/compose"
@@ -19,10 +21,26 @@ import (
 type deployOptions struct {
 	files    []string
 	profiles []string
+	// project overrides the project name inferred from the Compose file or directory.
+	project  string
 	services []string
 	noBuild  bool
+	// overrideFreeze is the reason for deploying despite the deploy freeze of the cluster. It's recorded
+	// in the audit log of the cluster.
+	overrideFreeze string
+	// parallel is the maximum number of services deployed concurrently. Default is deploy.DefaultConcurrency if 0.
+	parallel int
 	recreate bool
-	yes      bool
+	// requireApproval stores the deployment in the cluster as a deploy request that another operator must approve
+	// with 'uc deploy approve' instead of deploying the services.
+	requireApproval bool
+	// skipScan skips scanning the images for vulnerabilities even if image scanning is enabled for the cluster.
+	skipScan bool
+	// snapshotVolumes snapshots the volumes of the updated services before deploying them.
+	snapshotVolumes bool
+	// tenant sets the tenant of all project services overriding the x-tenant extension in the Compose file.
+	tenant string
+	yes    bool
 
 	context string
 }
@@ -33,6 +51,16 @@ func NewDeployCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "deploy [FLAGS] [SERVICE...]",
 		Short: "Deploy services from a Compose file.",
+		Long: `Deploy services from a Compose file.
+
+With the global '--context-group' flag, the images are built once and the services are deployed to the clusters
+of all contexts in the group one by one. A per-context override file next to the Compose file, e.g.
+compose.prod-eu.yaml for the context 'prod-eu', is merged into the Compose file for that context. The deployment
+continues with the remaining contexts if it fails for one of them and the combined status is printed at the end.
+
+With '--require-approval', the services are not deployed but stored in the cluster as a deploy request. Another
+operator reviews it with 'uc deploy requests' and deploys it with 'uc deploy approve ID' or rejects it with
+'uc deploy reject ID'. The operators are identified by the local user and host that run the commands.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
 
@@ -52,14 +80,41 @@ func NewDeployCommand() *cobra.Command {
 		"One or more Compose files to deploy services from. (default compose.yaml)")
 	cmd.Flags().BoolVarP(&opts.noBuild, "no-build", "n", false,
 		"Do not build images before deploying services. (default false)")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Deploy even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
+	cmd.Flags().IntVar(&opts.parallel, "parallel", deploy.DefaultConcurrency,
+		"Maximum number of services to deploy concurrently. Services are deployed after the services\n"+
+			"they depend on with 'depends_on'. Use 1 to deploy services one by one.")
 	cmd.Flags().StringSliceVarP(&opts.profiles, "profile", "p", nil,
 		"One or more Compose profiles to enable.")
+	cmd.Flags().StringVar(&opts.project, "project", "",
+		"Project name to deploy the services as. (default is the top-level 'name' in the Compose file\n"+
+			"or the project directory name)")
 	cmd.Flags().BoolVar(&opts.recreate, "recreate", false,
 		"Recreate containers even if their configuration and image haven't changed.")
+	cmd.Flags().BoolVar(&opts.requireApproval, "require-approval", false,
+		"Store the deployment in the cluster as a deploy request instead of deploying the services.\n"+
+			"Another operator must approve it with 'uc deploy approve' to deploy the services.")
+	cmd.Flags().BoolVar(&opts.skipScan, "skip-scan", false,
+		"Skip scanning the images for vulnerabilities before deploying them even if image scanning\n"+
+			"is enabled with the 'image-scan.scanner' cluster setting.")
+	cmd.Flags().BoolVar(&opts.snapshotVolumes, "snapshot-volumes", false,
+		"Snapshot the volumes of the updated services before deploying them to be able to restore\n"+
+			"the data with 'uc service rollback --with-data'. The containers using a volume that isn't on btrfs\n"+
+			"or ZFS are stopped while the volume is archived to get a consistent snapshot.")
+	cmd.Flags().StringVar(&opts.tenant, "tenant", "",
+		"Tenant to deploy the services for. Overrides the 'x-tenant' extension of all services in the Compose file.\n"+
+			"The services are isolated from the services of other tenants and count towards the tenant quota.")
 	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
 		"Auto-confirm deployment plan. Should be explicitly set when running non-interactively,\n"+
 			"e.g., in CI/CD pipelines. [$UNCLOUD_AUTO_CONFIRM]")
 
+	cmd.AddCommand(
+		newDeployApproveCommand(),
+		newDeployRejectCommand(),
+		newDeployRequestsCommand(),
+	)
+
 	// TODO: Consider adding a filter flag to specify which machines to deploy to but keep the rest running.
 	//  Could be useful to test a new version on a subset of machines before rolling out to all.
 
@@ -79,123 +134,307 @@ func projectOpts(opts deployOptions) []composecli.ProjectOptionsFn {
 
 // runDeploy parses the Compose file(s) and deploys the services.
 func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
-	projectOpts := projectOpts(opts)
+	if uncli.ContextGroup() != "" && opts.context != "" {
+		return errors.New("the '--context' and '--context-group' flags can't be used together")
+	}
 
-	project, err := compose.LoadProject(ctx, opts.files, projectOpts...)
+	project, err := loadDeployProject(ctx, opts.files, opts)
 	if err != nil {
-		return fmt.Errorf("load compose file(s): %w", err)
+		return err
+	}
+
+	if err = buildProject(ctx, uncli, project, opts.noBuild); err != nil {
+		return err
+	}
+
+	if uncli.ContextGroup() != "" {
+		return deployGroup(ctx, uncli, project, opts)
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	return deployProject(ctx, uncli, clusterClient, project, opts)
+}
+
+// loadDeployProject loads the project from the Compose file(s) with the project name and services selected
+// in the deploy options.
+func loadDeployProject(ctx context.Context, files []string, opts deployOptions) (*types.Project, error) {
+	project, err := compose.LoadProject(ctx, files, projectOpts(opts)...)
+	if err != nil {
+		return nil, fmt.Errorf("load compose file(s): %w", err)
+	}
+	if opts.project != "" {
+		if err = api.ValidateProjectName(opts.project); err != nil {
+			return nil, err
+		}
+		project.Name = opts.project
+	}
+	if opts.tenant != "" {
+		for name, s := range project.Services {
+			if s.Extensions == nil {
+				s.Extensions = make(types.Extensions)
+			}
+			s.Extensions[compose.TenantExtensionKey] = opts.tenant
+			project.Services[name] = s
+		}
 	}
 
 	if len(opts.services) > 0 {
 		// Includes service dependencies by default. This is the default docker compose behavior.
 		project, err = project.WithSelectedServices(opts.services)
 		if err != nil {
-			return fmt.Errorf("select services: %w", err)
+			return nil, fmt.Errorf("select services: %w", err)
 		}
 	}
+	return project, nil
+}
 
+// buildProject builds and pushes the images of the project services that need to be built unless noBuild is set.
+func buildProject(ctx context.Context, uncli *cli.CLI, project *types.Project, noBuild bool) error {
 	servicesToBuild := cli.GetServicesThatNeedBuild(project)
-
-	if len(servicesToBuild) > 0 {
-		if opts.noBuild {
-			fmt.Println("Not building services as requested.")
-		} else {
-			buildOpts := cli.BuildOptions{
-				Push:    true,
-				NoCache: false,
-			}
-
-			if err := cli.BuildServices(ctx, servicesToBuild, buildOpts); err != nil {
-				return fmt.Errorf("build services: %w", err)
-			}
-		}
+	if len(servicesToBuild) == 0 {
+		return nil
 	}
 
-	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
-	if err != nil {
-		return fmt.Errorf("connect to cluster: %w", err)
+	if noBuild {
+		uncli.Output.Report(cli.StepBuildImages, "Not building services as requested.")
+		return nil
 	}
-	defer clusterClient.Close()
+	buildOpts := cli.BuildOptions{
+		Push:    true,
+		NoCache: false,
+		Output:  uncli.Output,
+	}
+	if err := cli.BuildServices(ctx, servicesToBuild, buildOpts); err != nil {
+		return fmt.Errorf("build services: %w", err)
+	}
+	return nil
+}
+
+// deployProject plans the deployment of the project services, asks for the plan confirmation unless auto-confirmed,
+// and executes the plan.
+func deployProject(
+	ctx context.Context, uncli *cli.CLI, clusterClient *client.Client, project *types.Project, opts deployOptions,
+) error {
+	_, err := deployProjectOutcome(ctx, uncli, clusterClient, project, opts)
+	return err
+}
+
+// deployOutcome describes how the deployment of a project ended if it didn't fail.
+type deployOutcome string
+
+const (
+	deployUpToDate  deployOutcome = "up to date"
+	deployCancelled deployOutcome = "cancelled"
+	deployCompleted deployOutcome = "deployed"
+	deployRequested deployOutcome = "awaiting approval"
+)
 
+// deployProjectOutcome is deployProject that also returns how the deployment ended.
+func deployProjectOutcome(
+	ctx context.Context, uncli *cli.CLI, clusterClient *client.Client, project *types.Project, opts deployOptions,
+) (deployOutcome, error) {
 	var strategy deploy.Strategy
 	if opts.recreate {
 		strategy = &deploy.RollingStrategy{ForceRecreate: true}
 	}
 	composeDeploy, err := compose.NewDeploymentWithStrategy(ctx, clusterClient, project, strategy)
 	if err != nil {
-		return fmt.Errorf("create compose deployment: %w", err)
+		return "", fmt.Errorf("create compose deployment: %w", err)
 	}
 
 	plan, err := composeDeploy.Plan(ctx)
 	if err != nil {
-		return fmt.Errorf("plan deployment: %w", err)
+		return "", fmt.Errorf("plan deployment: %w", err)
 	}
 
+	for _, name := range composeDeploy.UpToDate() {
+		uncli.Output.Report(cli.StepServiceUpToDate, "Service %s is up to date.", name)
+	}
 	if len(plan.Operations) == 0 {
-		fmt.Println("Services are up to date.")
-		return nil
+		uncli.Output.Report(cli.StepServiceUpToDate, "Services are up to date.")
+		return deployUpToDate, nil
+	}
+	if len(composeDeploy.UpToDate()) > 0 {
+		fmt.Fprintln(uncli.Output.Stream())
 	}
 
-	fmt.Println("Deployment plan:")
-	if err = printPlan(ctx, clusterClient, plan); err != nil {
-		return fmt.Errorf("print deployment plan: %w", err)
+	confirm := !opts.yes && !opts.requireApproval
+	if err = printDeploymentPlan(ctx, uncli.Output, clusterClient, plan, confirm); err != nil {
+		return "", err
+	}
+
+	if !opts.skipScan {
+		if err = scanImages(ctx, uncli.Output, clusterClient, deploy.OperationImages(&plan)); err != nil {
+			return "", err
+		}
+	}
+
+	if opts.requireApproval {
+		if err = requestDeployApproval(ctx, clusterClient, composeDeploy, opts); err != nil {
+			return "", err
+		}
+		return deployRequested, nil
+	}
+
+	operation := "deploy project " + project.Name
+	if ctx, err = clusterClient.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return "", err
 	}
-	fmt.Println()
 
 	// Ask for plan confirmation before proceeding with the deployment unless auto-confirmed with --yes.
 	if !opts.yes {
 		if !cli.IsStdinTerminal() {
-			return errors.New("cannot ask to confirm deployment plan in non-interactive mode, " +
+			return "", errors.New("cannot ask to confirm deployment plan in non-interactive mode, " +
 				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
 		}
 
 		confirmed, err := cli.Confirm()
 		if err != nil {
-			return fmt.Errorf("confirm deployment: %w", err)
+			return "", fmt.Errorf("confirm deployment: %w", err)
 		}
 		if !confirmed {
-			fmt.Println("Cancelled. No changes were made.")
-			return nil
+			fmt.Fprintln(uncli.Output.Writer(), "Cancelled. No changes were made.")
+			return deployCancelled, nil
 		}
 	}
 
+	if err = executeDeployPlan(ctx, uncli, clusterClient, plan, opts.snapshotVolumes, opts.parallel); err != nil {
+		return "", err
+	}
+	return deployCompleted, nil
+}
+
+// executeDeployPlan records the revisions of the updated services and executes the deployment plan deploying up to
+// parallel services concurrently. Default is deploy.DefaultConcurrency if parallel is 0.
+func executeDeployPlan(
+	ctx context.Context,
+	uncli *cli.CLI,
+	clusterClient *client.Client,
+	plan deploy.SequenceOperation,
+	snapshotVolumes bool,
+	parallel int,
+) error {
+	if parallel == 0 {
+		parallel = deploy.DefaultConcurrency
+	}
 	return progress.RunWithTitle(ctx, func(ctx context.Context) error {
-		if err := plan.Execute(ctx, clusterClient); err != nil {
+		if err := saveServiceRevisions(ctx, clusterClient, plan, snapshotVolumes); err != nil {
+			return err
+		}
+		if err := plan.ExecuteConcurrently(ctx, clusterClient, parallel); err != nil {
 			return fmt.Errorf("deploy services: %w", err)
 		}
 		return nil
 	}, uncli.ProgressOut(), "Deploying services")
 }
 
-func printPlan(ctx context.Context, cli *client.Client, plan deploy.SequenceOperation) error {
-	for _, op := range plan.Operations {
-		svcPlan, ok := op.(*deploy.Plan)
-		if !ok {
-			fmt.Println("- " + op.Format(nil))
-			continue
-		}
+// printDeploymentPlan reports the deployment plan to the output. The plan is printed even if the output is quiet
+// when the user is asked to confirm it.
+func printDeploymentPlan(
+	ctx context.Context,
+	out cli.OutputOptions,
+	clusterClient *client.Client,
+	plan deploy.SequenceOperation,
+	confirm bool,
+) error {
+	var buf strings.Builder
+	if err := cli.PrintDeploymentPlan(ctx, &buf, clusterClient, plan); err != nil {
+		return fmt.Errorf("print deployment plan: %w", err)
+	}
+	msg := "Deployment plan:\n" + strings.TrimSuffix(buf.String(), "\n")
+	if confirm && out.Quiet && out.OnStep == nil {
+		fmt.Fprintf(out.Writer(), "%s\n\n", msg)
+		return nil
+	}
+	out.Report(cli.StepDeploymentPlan, "%s", msg)
+	fmt.Fprintln(out.Stream())
+	return nil
+}
 
-		svc, err := cli.InspectService(ctx, svcPlan.ServiceID)
-		if err != nil && !errors.Is(err, api.ErrNotFound) {
-			return fmt.Errorf("inspect service: %w", err)
-		}
-		// Initialise a machine and container name resolver to properly format the service plan output.
-		resolver, err := cli.ServiceOperationNameResolver(ctx, svc)
+// scanImages scans the images for vulnerabilities with the scanner configured in the cluster settings and fails if
+// any of them has vulnerabilities with the severity that blocks the deployment according to the cluster policy.
+// Vulnerabilities with a lower severity are reported as warnings. It's a no-op if image scanning is disabled.
+func scanImages(ctx context.Context, out cli.OutputOptions, clusterClient *client.Client, images []string) error {
+	if len(images) == 0 {
+		return nil
+	}
+	settings, err := clusterClient.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	if settings.ImageScanner == "" {
+		return nil
+	}
+	scanner, err := scan.New(settings.ImageScanner)
+	if err != nil {
+		return err
+	}
+	policy := scan.Policy{FailOn: settings.ScanFailOn()}
+
+	var blocked []string
+	for _, image := range images {
+		out.Report(cli.StepScanImage, "Scanning image %s with %s...", image, scanner.Name())
+		report, err := scanner.Scan(ctx, image)
 		if err != nil {
-			return fmt.Errorf("create machine and container name resolver for service operations: %w", err)
+			return fmt.Errorf("scan image '%s': %w", image, err)
 		}
 
-		fmt.Printf("- Deploy service [name=%s]\n", svcPlan.ServiceName)
-		fmt.Println(indent(svcPlan.Format(resolver), "  "))
+		blocking := policy.Blocking(report)
+		if len(blocking) == 0 {
+			if len(report.Vulnerabilities) > 0 {
+				client.PrintWarning(fmt.Sprintf("image '%s' has vulnerabilities: %s", image, report.Summary()))
+			}
+			continue
+		}
+
+		fmt.Fprintf(out.Writer(), "Image %s has vulnerabilities: %s\n", image, report.Summary())
+		for _, v := range blocking {
+			fix := "no fix available"
+			if v.FixedVersion != "" {
+				fix = "fixed in " + v.FixedVersion
+			}
+			fmt.Fprintf(out.Writer(), "  %s %s %s %s (%s)\n", v.Severity, v.ID, v.Package, v.InstalledVersion, fix)
+		}
+		blocked = append(blocked, image)
 	}
+	fmt.Fprintln(out.Stream())
 
+	if len(blocked) > 0 {
+		return fmt.Errorf("image scan policy blocks the deployment: found vulnerabilities with %s or higher "+
+			"severity in %s, fix them or use --skip-scan to deploy anyway",
+			strings.ToLower(string(policy.FailOn)), strings.Join(blocked, ", "))
+	}
 	return nil
 }
 
-func indent(text, prefix string) string {
-	lines := strings.Split(text, "\n")
-	for i, line := range lines {
-		lines[i] = prefix + line
+// saveServiceRevisions records the current state of the existing services that are about to be updated so they can
+// be rolled back with 'uc service rollback'. Their volumes are also snapshotted if snapshotVolumes is set.
+func saveServiceRevisions(
+	ctx context.Context, clusterClient *client.Client, plan deploy.SequenceOperation, snapshotVolumes bool,
+) error {
+	for _, op := range plan.Operations {
+		servicePlan, ok := op.(*deploy.Plan)
+		if !ok {
+			continue
+		}
+
+		svc, err := clusterClient.InspectService(ctx, servicePlan.ServiceID)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				// A new service has nothing to roll back to.
+				continue
+			}
+			return fmt.Errorf("inspect service '%s': %w", servicePlan.ServiceName, err)
+		}
+		if _, err = clusterClient.SaveServiceRevision(ctx, svc, snapshotVolumes); err != nil {
+			return fmt.Errorf("save revision of service '%s': %w", svc.Name, err)
+		}
 	}
-	return strings.Join(lines, "\n")
+
+	return nil
 }
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+)
+
+// contextDeployment is the result of deploying the project to the cluster of a context in a context group.
+type contextDeployment struct {
+	context string
+	// override is the path to the per-context Compose override file applied to the project if any.
+	override string
+	outcome  deployOutcome
+	err      error
+	services []api.Service
+}
+
+// deployGroup deploys the project to the clusters of all contexts in the context group one by one. The services
+// are built only once before deploying. A per-context override file next to the first Compose file, e.g.
+// compose.prod-eu.yaml for the context prod-eu, is merged into the project for that context. The deployment
+// continues with the remaining contexts if it fails for one of them and the combined status is printed at the end.
+func deployGroup(ctx context.Context, uncli *cli.CLI, project *types.Project, opts deployOptions) error {
+	contexts, err := uncli.Config.ContextGroup(uncli.ContextGroup())
+	if err != nil {
+		return err
+	}
+
+	var results []contextDeployment
+	for _, name := range contexts {
+		if ctx.Err() != nil {
+			break
+		}
+		fmt.Printf("Deploying to cluster context '%s'...\n", name)
+		r := deployToContext(ctx, uncli, name, project, opts)
+		if r.err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to deploy to cluster context '%s': %v\n", name, r.err)
+		}
+		fmt.Println()
+		results = append(results, r)
+	}
+
+	if err = printGroupDeployStatus(results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	if len(results) < len(contexts) {
+		return fmt.Errorf("deployment interrupted after %d of %d contexts: %w",
+			len(results), len(contexts), ctx.Err())
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to deploy to %d of %d contexts", failed, len(contexts))
+	}
+	return nil
+}
+
+// deployToContext deploys the project with the per-context override applied to the cluster of the context
+// and lists the deployed project services.
+func deployToContext(
+	ctx context.Context, uncli *cli.CLI, contextName string, project *types.Project, opts deployOptions,
+) contextDeployment {
+	r := contextDeployment{context: contextName}
+
+	contextProject := project
+	if r.override = contextOverrideFile(project, contextName); r.override != "" {
+		fmt.Printf("Applying the context override file '%s'.\n", r.override)
+		files := append(project.ComposeFiles[:len(project.ComposeFiles):len(project.ComposeFiles)], r.override)
+		opts.project = project.Name
+		if contextProject, r.err = loadDeployProject(ctx, files, opts); r.err != nil {
+			return r
+		}
+	}
+
+	clusterClient, err := uncli.ConnectContext(ctx, contextName)
+	if err != nil {
+		r.err = fmt.Errorf("connect to cluster: %w", err)
+		return r
+	}
+	defer clusterClient.Close()
+
+	if r.outcome, r.err = deployProjectOutcome(ctx, uncli, clusterClient, contextProject, opts); r.err != nil {
+		return r
+	}
+	r.services, r.err = projectServices(ctx, clusterClient, contextProject)
+	return r
+}
+
+// contextOverrideFile returns the path to the per-context override file for the first Compose file of the project
+// if it exists, e.g. compose.prod-eu.yaml for compose.yaml and the context prod-eu. Otherwise, an empty string.
+func contextOverrideFile(project *types.Project, contextName string) string {
+	if len(project.ComposeFiles) == 0 {
+		return ""
+	}
+	base := project.ComposeFiles[0]
+	ext := filepath.Ext(base)
+	path := strings.TrimSuffix(base, ext) + "." + contextName + ext
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// projectServices returns the services of the project deployed to the cluster.
+func projectServices(ctx context.Context, clusterClient *client.Client, project *types.Project) ([]api.Service, error) {
+	services, err := clusterClient.ListServicesFiltered(ctx, &api.ServiceFilter{Project: project.Name})
+	if err != nil {
+		return nil, fmt.Errorf("list services: %w", err)
+	}
+	names := project.ServiceNames()
+	return slices.DeleteFunc(services, func(s api.Service) bool {
+		return !slices.Contains(names, s.Name)
+	}), nil
+}
+
+// printGroupDeployStatus prints the combined status of the deployment to the clusters of the context group.
+func printGroupDeployStatus(results []contextDeployment) error {
+	fmt.Println("Deployment status:")
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CONTEXT\tRESULT\tSERVICE\tREPLICAS\tIMAGE")
+	for _, r := range results {
+		result := string(r.outcome)
+		if r.override != "" {
+			result += " (with " + filepath.Base(r.override) + ")"
+		}
+		if r.err != nil {
+			result = "failed: " + r.err.Error()
+		}
+		if len(r.services) == 0 {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\n", r.context, result)
+			continue
+		}
+		for _, s := range r.services {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n",
+				r.context, result, s.Name, len(s.Containers), strings.Join(s.Images(), ", "))
+		}
+	}
+	return tw.Flush()
+}
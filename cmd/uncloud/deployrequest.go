@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/spf13/cobra"
+)
+
+type deployApproveOptions struct {
+	id             string
+	overrideFreeze string
+	skipScan       bool
+	yes            bool
+
+	context string
+}
+
+func newDeployApproveCommand() *cobra.Command {
+	opts := deployApproveOptions{}
+	cmd := &cobra.Command{
+		Use:   "approve ID",
+		Short: "Approve a deploy request and deploy its services.",
+		Long: `Approve a deploy request created with 'uc deploy --require-approval' and deploy its services.
+The deployment is planned against the current cluster state. The request must be approved by another operator
+than the one who created it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.id = args[0]
+			return approveDeployRequest(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Deploy even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
+	cmd.Flags().BoolVar(&opts.skipScan, "skip-scan", false,
+		"Skip scanning the images for vulnerabilities before deploying them even if image scanning\n"+
+			"is enabled with the 'image-scan.scanner' cluster setting.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Auto-confirm deployment plan. [$UNCLOUD_AUTO_CONFIRM]")
+
+	return cmd
+}
+
+func newDeployRejectCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "reject ID",
+		Short: "Reject a deploy request without deploying its services.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			clusterClient, err := uncli.ConnectCluster(cmd.Context(), contextName)
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer clusterClient.Close()
+
+			r, err := clusterClient.ReviewDeployRequest(cmd.Context(), args[0], false, client.LocalUser())
+			if err != nil {
+				if errors.Is(err, api.ErrNotFound) {
+					return fmt.Errorf("deploy request '%s' not found", args[0])
+				}
+				return fmt.Errorf("reject deploy request: %w", err)
+			}
+			fmt.Printf("Deploy request %s for project %s rejected.\n", r.ID, r.Project)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func newDeployRequestsCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "requests",
+		Short: "List the pending and recently reviewed deploy requests.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listDeployRequests(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+// requestDeployApproval stores the project services of the compose deployment in the cluster as a deploy request
+// that another operator must approve.
+func requestDeployApproval(
+	ctx context.Context, clusterClient *client.Client, composeDeploy *compose.Deployment, opts deployOptions,
+) error {
+	specs, err := composeDeploy.ServiceSpecs(ctx)
+	if err != nil {
+		return err
+	}
+	r, err := clusterClient.CreateDeployRequest(ctx, api.DeployRequest{
+		Project:         composeDeploy.Project.Name,
+		Specs:           specs,
+		ExternalVolumes: composeDeploy.ExternalVolumes(),
+		Recreate:        opts.recreate,
+		SnapshotVolumes: opts.snapshotVolumes,
+		RequestedBy:     client.LocalUser(),
+	})
+	if err != nil {
+		return fmt.Errorf("create deploy request: %w", err)
+	}
+
+	fmt.Printf("Deploy request %s created. Another operator can deploy it with 'uc deploy approve %s' "+
+		"or reject it with 'uc deploy reject %s'.\n", r.ID, r.ID, r.ID)
+	return nil
+}
+
+func approveDeployRequest(ctx context.Context, uncli *cli.CLI, opts deployApproveOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	r, err := clusterClient.DeployRequest(ctx, opts.id)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("deploy request '%s' not found", opts.id)
+		}
+		return fmt.Errorf("get deploy request: %w", err)
+	}
+	if r.Status != api.DeployRequestPending {
+		return fmt.Errorf("deploy request '%s' is already %s by %s", r.ID, r.Status, r.ReviewedBy)
+	}
+	approver := client.LocalUser()
+	if approver == r.RequestedBy {
+		return fmt.Errorf("deploy request '%s' was created by you (%s) and must be approved by another operator",
+			r.ID, approver)
+	}
+
+	fmt.Printf("Deploy request %s for project %s (services: %s) by %s, created %s ago.\n",
+		r.ID, r.Project, strings.Join(r.ServiceNames(), ", "), r.RequestedBy,
+		units.HumanDuration(time.Since(r.CreatedAt)))
+
+	var strategy deploy.Strategy
+	if r.Recreate {
+		strategy = &deploy.RollingStrategy{ForceRecreate: true}
+	}
+	composeDeploy, err := compose.NewDeploymentFromSpecs(
+		ctx, clusterClient, r.Project, r.Specs, r.ExternalVolumes, strategy)
+	if err != nil {
+		return fmt.Errorf("create compose deployment: %w", err)
+	}
+	plan, err := composeDeploy.Plan(ctx)
+	if err != nil {
+		return fmt.Errorf("plan deployment: %w", err)
+	}
+
+	if len(plan.Operations) > 0 {
+		if err = printDeploymentPlan(ctx, uncli.Output, clusterClient, plan, !opts.yes); err != nil {
+			return err
+		}
+
+		if !opts.skipScan {
+			if err = scanImages(ctx, uncli.Output, clusterClient, deploy.OperationImages(&plan)); err != nil {
+				return err
+			}
+		}
+		operation := fmt.Sprintf("approve deploy request %s of project %s", r.ID, r.Project)
+		if ctx, err = clusterClient.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+			return err
+		}
+	}
+
+	if !opts.yes {
+		if !cli.IsStdinTerminal() {
+			return errors.New("cannot ask to confirm deployment plan in non-interactive mode, " +
+				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+		}
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm deployment: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. The deploy request is still pending.")
+			return nil
+		}
+	}
+
+	// Approve the request before deploying so it can't be deployed twice by concurrent approvers.
+	if _, err = clusterClient.ReviewDeployRequest(ctx, r.ID, true, approver); err != nil {
+		return fmt.Errorf("approve deploy request: %w", err)
+	}
+	if len(plan.Operations) == 0 {
+		fmt.Println("Deploy request approved. Services are up to date.")
+		return nil
+	}
+	return executeDeployPlan(ctx, uncli, clusterClient, plan, r.SnapshotVolumes, 0)
+}
+
+func listDeployRequests(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	requests, err := clusterClient.ListDeployRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("list deploy requests: %w", err)
+	}
+	if len(requests) == 0 {
+		fmt.Println("No deploy requests found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ID\tPROJECT\tSERVICES\tREQUESTED BY\tCREATED\tSTATUS\tREVIEWED BY")
+	for _, r := range requests {
+		reviewer := r.ReviewedBy
+		if reviewer == "" {
+			reviewer = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.ID, r.Project, strings.Join(r.ServiceNames(), ", "), r.RequestedBy,
+			units.HumanDuration(time.Since(r.CreatedAt))+" ago", r.Status, reviewer)
+	}
+	return tw.Flush()
+}
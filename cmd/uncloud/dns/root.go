@@ -7,16 +7,18 @@ import (
 func NewRootCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "dns",
-		Short: "Manage cluster domain in Uncloud DNS.",
-		Long: "Manage cluster domain in Uncloud DNS.\n" +
+		Short: "Manage cluster domain in Uncloud DNS and the resolution of published domains.",
+		Long: "Manage cluster domain in Uncloud DNS and the resolution of published domains.\n" +
 			"DNS commands allow you to reserve or release a unique '<id>.cluster.uncloud.run' domain for your " +
 			"cluster. When reserved, Caddy service deployments will automatically update DNS records to route " +
-			"traffic to the services in the cluster.",
+			"traffic to the services in the cluster. The split-horizon commands configure how the cluster DNS " +
+			"resolves the domains published by services for the containers in the cluster.",
 	}
 	cmd.AddCommand(
 		NewReleaseCommand(),
 		NewReserveCommand(),
 		NewShowCommand(),
+		NewSplitHorizonCommand(),
 	)
 	return cmd
 }
@@ -0,0 +1,206 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewSplitHorizonCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "split-horizon",
+		Short: "Manage how the published service domains are resolved inside the cluster.",
+		Long: `Manage how the cluster DNS resolves the domains published by services for the containers in the cluster.
+Instead of resolving them to the public address of the cluster and sending the traffic back into the cluster,
+the cluster DNS resolves the published domains to the addresses in the cluster network. The mode of each domain
+is one of:
+  ` + string(api.SplitHorizonProxy) + `    Resolve to the Caddy container on the same machine or to all Caddy containers
+           if there is none on the machine (default).
+  ` + string(api.SplitHorizonDirect) + `   Resolve to the containers of the service, bypassing Caddy.
+           Clients must connect to the container port.
+  ` + string(api.SplitHorizonOff) + `      Resolve using the upstream DNS servers.`,
+	}
+	cmd.AddCommand(
+		newSplitHorizonListCommand(),
+		newSplitHorizonSetCommand(),
+		newSplitHorizonUnsetCommand(),
+	)
+	return cmd
+}
+
+func newSplitHorizonListCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List the published service domains and their split-horizon DNS modes.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listSplitHorizon(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newSplitHorizonSetCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "set DOMAIN MODE",
+		Short: "Set the split-horizon DNS mode of a published domain.",
+		Example: `  # Resolve app.example.com directly to the containers of the service that publishes it.
+  uc dns split-horizon set app.example.com direct
+
+  # Resolve www.example.com to the public address of the cluster.
+  uc dns split-horizon set www.example.com off`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			domain := strings.TrimSuffix(strings.ToLower(args[0]), ".")
+			mode := api.SplitHorizonMode(strings.ToLower(args[1]))
+			return updateSplitHorizon(cmd.Context(), uncli, contextName, func(modes map[string]api.SplitHorizonMode) error {
+				modes[domain] = mode
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newSplitHorizonUnsetCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "unset DOMAIN [DOMAIN...]",
+		Short: "Reset the split-horizon DNS mode of published domains to the default.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return updateSplitHorizon(cmd.Context(), uncli, contextName, func(modes map[string]api.SplitHorizonMode) error {
+				for _, arg := range args {
+					domain := strings.TrimSuffix(strings.ToLower(arg), ".")
+					if _, ok := modes[domain]; !ok {
+						return fmt.Errorf("split-horizon DNS mode for domain '%s' not set", domain)
+					}
+					delete(modes, domain)
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func listSplitHorizon(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	services, err := client.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+
+	// Map the published domains to the services publishing them.
+	domainServices := make(map[string][]string)
+	for _, svc := range services {
+		for _, ctr := range svc.Containers {
+			ports, err := ctr.Container.ServicePorts()
+			if err != nil {
+				continue
+			}
+			for _, port := range ports {
+				if port.Hostname == "" || port.Mode == api.PortModeHost {
+					continue
+				}
+				domain := strings.ToLower(port.Hostname)
+				if !slices.Contains(domainServices[domain], svc.Name) {
+					domainServices[domain] = append(domainServices[domain], svc.Name)
+				}
+			}
+		}
+	}
+	// Also list the domains with a configured mode that are not published (anymore).
+	for domain := range settings.SplitHorizon {
+		if _, ok := domainServices[domain]; !ok {
+			domainServices[domain] = nil
+		}
+	}
+
+	if len(domainServices) == 0 {
+		fmt.Println("No published service domains found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "DOMAIN\tSERVICE\tMODE")
+	for _, domain := range slices.Sorted(maps.Keys(domainServices)) {
+		svcNames := "(not published)"
+		if len(domainServices[domain]) > 0 {
+			slices.Sort(domainServices[domain])
+			svcNames = strings.Join(domainServices[domain], ", ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", domain, svcNames, settings.SplitHorizonMode(domain))
+	}
+	return tw.Flush()
+}
+
+// updateSplitHorizon applies the change to the split-horizon DNS modes and stores them in the cluster settings
+// unless they have been changed concurrently.
+func updateSplitHorizon(
+	ctx context.Context, uncli *cli.CLI, contextName string, update func(modes map[string]api.SplitHorizonMode) error,
+) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	modes := make(map[string]api.SplitHorizonMode, len(settings.SplitHorizon))
+	maps.Copy(modes, settings.SplitHorizon)
+	if err = update(modes); err != nil {
+		return err
+	}
+	settings.SplitHorizon = modes
+	if len(modes) == 0 {
+		settings.SplitHorizon = nil
+	}
+	if err = settings.Validate(); err != nil {
+		return err
+	}
+
+	if _, err = client.SetSettings(ctx, settings); err != nil {
+		return fmt.Errorf("set cluster settings: %w", err)
+	}
+	fmt.Println("Split-horizon DNS modes updated.")
+	return nil
+}
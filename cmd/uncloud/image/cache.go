@@ -0,0 +1,150 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the built-in pull-through cache of Docker Hub images.",
+		Long: `Manage the built-in pull-through cache of Docker Hub images.
+The cache runs as the '` + client.RegistryCacheServiceName + `' service on a single machine and is used by the Docker
+daemons of all machines as the first registry mirror. Docker Hub images are pulled from Docker Hub only once and
+then served from the cache to the machines over the cluster network, so deploys aren't broken by the Docker Hub
+pull rate limits. Docker falls back to pulling from Docker Hub if the cache is unavailable.
+Other registry mirrors can be configured with the '` + api.SettingRegistryMirrors + `' cluster setting.`,
+	}
+	cmd.AddCommand(
+		newCacheDisableCommand(),
+		newCacheEnableCommand(),
+	)
+	return cmd
+}
+
+type cacheEnableOptions struct {
+	machine string
+	image   string
+	context string
+}
+
+func newCacheEnableCommand() *cobra.Command {
+	opts := cacheEnableOptions{}
+	cmd := &cobra.Command{
+		Use:   "enable MACHINE",
+		Short: "Deploy the pull-through cache to a machine and use it as a registry mirror on all machines.",
+		Example: `  # Run the cache on machine 'storage1' with enough disk space for the cached images.
+  uc image cache enable storage1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.machine = args[0]
+			return enableCache(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.image, "image", "",
+		"Docker image of the registry to run as the cache. (default "+client.RegistryCacheImage+")")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newCacheDisableCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Stop using the pull-through cache as a registry mirror and remove the cache service.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return disableCache(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func enableCache(ctx context.Context, uncli *cli.CLI, opts cacheEnableOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	d, mirror, err := clusterClient.NewRegistryCacheDeployment(ctx, opts.machine, opts.image)
+	if err != nil {
+		return fmt.Errorf("create registry cache deployment: %w", err)
+	}
+	plan, err := d.Plan(ctx)
+	if err != nil {
+		return fmt.Errorf("plan registry cache deployment: %w", err)
+	}
+
+	if len(plan.Operations) > 0 {
+		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			if _, err = d.Run(ctx); err != nil {
+				return fmt.Errorf("deploy registry cache: %w", err)
+			}
+			return nil
+		}, uncli.ProgressOut(), fmt.Sprintf("Deploying service %s", d.Spec.Name))
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	settings, err := clusterClient.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	if settings.RegistryCache == mirror {
+		fmt.Printf("Registry cache %s is already enabled.\n", mirror)
+		return nil
+	}
+	settings.RegistryCache = mirror
+	if _, err = clusterClient.SetSettings(ctx, settings); err != nil {
+		return fmt.Errorf("set cluster settings: %w", err)
+	}
+	fmt.Printf("Registry cache %s enabled. The machines reload Docker to use it within a few seconds.\n", mirror)
+	return nil
+}
+
+func disableCache(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	// Stop using the cache before removing it so that the machines don't try to pull through a removed cache.
+	settings, err := clusterClient.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	if settings.RegistryCache != "" {
+		settings.RegistryCache = ""
+		if _, err = clusterClient.SetSettings(ctx, settings); err != nil {
+			return fmt.Errorf("set cluster settings: %w", err)
+		}
+	}
+
+	err = clusterClient.RemoveService(ctx, client.RegistryCacheServiceName)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("remove service '%s': %w", client.RegistryCacheServiceName, err)
+	}
+	fmt.Println("Registry cache disabled.")
+	return nil
+}
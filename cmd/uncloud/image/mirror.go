@@ -0,0 +1,164 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/bwlimit"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+type mirrorOptions struct {
+	files       []string
+	toMachines  []string
+	fromMachine string
+	local       bool
+	bwLimit     string
+	context     string
+}
+
+func NewMirrorCommand() *cobra.Command {
+	opts := mirrorOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "mirror [IMAGE...]",
+		Short: "Distribute images to machines fetching each image from the registry only once.",
+		Long: `Distribute images to machines fetching each image from the registry only once.
+
+The images are the specified ones or all images referenced by the services in the Compose file(s). If none of
+the machines has an image, it's pulled on one machine (or uploaded from the local Docker with --local) and then
+copied to the rest of the machines directly over the cluster network. Each machine copies only the image layers
+it doesn't have from the nearest machine that already has the image. Machines that already have an image are
+skipped. This avoids pulling the same images from the upstream registry on every machine in bandwidth-constrained
+or air-gapped environments.`,
+		Example: `  # Mirror all images in compose.yaml to all machines.
+  uc image mirror
+
+  # Mirror the images in a specific Compose file to specific machines.
+  uc image mirror -f compose.prod.yaml --to-machine machine1,machine2
+
+  # Upload images from the local Docker to an air-gapped cluster without access to the registry.
+  uc image mirror --local
+
+  # Pull an image on a specific machine and distribute it to all machines.
+  uc image mirror postgres:17 --from-machine machine1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return mirror(cmd.Context(), uncli, args, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
+		"One or more Compose files to read the images from if no images are specified. (default compose.yaml)")
+	cmd.Flags().StringSliceVar(&opts.toMachines, "to-machine", []string{"all"},
+		"Machine names or IDs to distribute the images to. Can be specified multiple times or as a comma-separated "+
+			"list. 'all' means all available machines.")
+	cmd.Flags().StringVar(&opts.fromMachine, "from-machine", "",
+		"Machine name or ID to pull the images on if none of the machines has them. "+
+			"(default is the first target machine)")
+	cmd.Flags().BoolVar(&opts.local, "local", false,
+		"Upload the images missing in the cluster from the local Docker instead of pulling them from the registry.")
+	cmd.Flags().StringVar(&opts.bwLimit, "bwlimit", "",
+		"Limit the bandwidth of uploading the images with --local, e.g. 512K or 10M bytes per second.\n"+
+			"Units are 1024-based. (default unlimited)")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func mirror(ctx context.Context, uncli *cli.CLI, images []string, opts mirrorOptions) error {
+	if len(images) == 0 {
+		var err error
+		if images, err = composeImages(ctx, opts.files); err != nil {
+			return err
+		}
+	}
+
+	bwLimit, err := bwlimit.Parse(opts.bwLimit)
+	if err != nil {
+		return err
+	}
+	mirrorOpts := client.MirrorImageOptions{Source: opts.fromMachine, BandwidthLimit: bwLimit}
+	machines := cli.ExpandCommaSeparatedValues(opts.toMachines)
+	if !slices.Contains(machines, "all") {
+		mirrorOpts.Machines = machines
+	}
+	if opts.local {
+		dockerCli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+		if err != nil {
+			return fmt.Errorf("create local Docker client: %w", err)
+		}
+		defer dockerCli.Close()
+		mirrorOpts.Local = dockerCli
+	}
+
+	c, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	results := make([]client.MirrorImageResult, len(images))
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		for i, image := range images {
+			if results[i], err = c.MirrorImage(ctx, image, mirrorOpts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, uncli.ProgressOut(), "Mirroring images")
+	if err != nil {
+		return err
+	}
+
+	for i, image := range images {
+		r := results[i]
+		fetched := "already present"
+		if r.Fetched {
+			fetched = "pulled"
+			if opts.local {
+				fetched = "uploaded"
+			}
+		}
+		copied := "no machines"
+		if len(r.Copied) > 0 {
+			copied = strings.Join(r.Copied, ", ")
+		}
+		fmt.Printf("%s: %s on %s, copied to %s.\n", image, fetched, r.Source, copied)
+	}
+	return nil
+}
+
+// composeImages returns the unique images of the services in the Compose files sorted by name.
+func composeImages(ctx context.Context, files []string) ([]string, error) {
+	project, err := compose.LoadProject(ctx, files)
+	if err != nil {
+		return nil, fmt.Errorf("load compose file(s): %w", err)
+	}
+
+	var images []string
+	for _, s := range project.Services {
+		if s.Image == "" {
+			client.PrintWarning(fmt.Sprintf("skipping service '%s' without an image", s.Name))
+			continue
+		}
+		if !slices.Contains(images, s.Image) {
+			images = append(images, s.Image)
+		}
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no images found in the Compose file(s)")
+	}
+	slices.Sort(images)
+	return images, nil
+}
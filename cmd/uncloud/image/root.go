@@ -11,6 +11,8 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		NewCacheCommand(),
+		NewMirrorCommand(),
 		NewPushCommand(),
 	)
 
@@ -0,0 +1,281 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewHACommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ha",
+		Short: "Manage a virtual IP that moves to a healthy ingress machine.",
+		Long: `Manage a virtual IP that moves to a healthy ingress machine.
+With ingress high availability, the inbound traffic is sent to a single virtual IP held by one of the ingress
+machines at a time. The machines run the '` + client.KeepalivedServiceName + `' service that elects the holder using
+VRRP and moves the virtual IP to the next machine in the order of preference within a few seconds if the holder
+or its Caddy fails. The virtual IP must be routable to all the machines, e.g. an unused IP in the subnet of
+the machines' network interface. On clouds that don't allow that, use --notify-master to assign a floating IP
+to the new holder with the cloud provider API instead.`,
+	}
+	cmd.AddCommand(
+		newHADisableCommand(),
+		newHAEnableCommand(),
+		newHAShowCommand(),
+	)
+	return cmd
+}
+
+type haEnableOptions struct {
+	vip          string
+	iface        string
+	machines     []string
+	routerID     uint32
+	notifyMaster string
+	image        string
+	context      string
+}
+
+func newHAEnableCommand() *cobra.Command {
+	opts := haEnableOptions{}
+	cmd := &cobra.Command{
+		Use:   "enable VIP",
+		Short: "Move a virtual IP between the ingress machines to keep it on a healthy machine.",
+		Example: `  # Hold virtual IP 192.168.1.100 on interface eth0 of the machines running Caddy.
+  uc ingress ha enable 192.168.1.100 --interface eth0
+
+  # Prefer machine 'lb1' and fail over to machine 'lb2'.
+  uc ingress ha enable 192.168.1.100 --interface eth0 --machine lb1,lb2
+
+  # Assign a cloud floating IP to the machine that becomes the holder.
+  uc ingress ha enable 203.0.113.100 --interface eth0 --notify-master /etc/keepalived/assign-floating-ip.sh`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.vip = args[0]
+			return enableHA(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.image, "image", "",
+		"Docker image to run keepalived from. keepalived is installed on start if the image doesn't include it. "+
+			"(default "+client.KeepalivedImage+")")
+	cmd.Flags().StringVarP(&opts.iface, "interface", "i", "",
+		"Network interface of the machines to assign the virtual IP to, e.g. eth0.")
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Name or ID of a machine that can hold the virtual IP in the order of preference. Can be specified "+
+			"multiple times or as a comma-separated list of machine names or IDs. "+
+			"(default is the machines running Caddy)")
+	cmd.Flags().StringVar(&opts.notifyMaster, "notify-master", "",
+		"Shell command to run in the keepalived container when a machine becomes the holder of the virtual IP, "+
+			"e.g. to assign a cloud floating IP to the machine. The machine directory "+
+			"/var/lib/uncloud/keepalived is mounted at /etc/keepalived in the container.")
+	cmd.Flags().Uint32Var(&opts.routerID, "router-id", api.DefaultVRRPRouterID,
+		"VRRP virtual router ID (1-255) that must be unique among the VRRP routers in the network.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	_ = cmd.MarkFlagRequired("interface")
+	return cmd
+}
+
+func newHADisableCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Release the virtual IP and remove the keepalived service.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return disableHA(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newHAShowCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the virtual IP and the machines that can hold it.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return showHA(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func enableHA(ctx context.Context, uncli *cli.CLI, opts haEnableOptions) error {
+	vip, err := netip.ParseAddr(opts.vip)
+	if err != nil {
+		return fmt.Errorf("invalid virtual IP '%s': %w", opts.vip, err)
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	machineIDs, err := haMachines(ctx, clusterClient, cli.ExpandCommaSeparatedValues(opts.machines))
+	if err != nil {
+		return err
+	}
+	policy := &api.IngressHAPolicy{
+		VirtualIP:    vip,
+		Interface:    opts.iface,
+		Machines:     machineIDs,
+		RouterID:     opts.routerID,
+		NotifyMaster: opts.notifyMaster,
+	}
+
+	// Store the policy before deploying keepalived so the machines write the configuration keepalived waits for.
+	settings, err := clusterClient.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	settings.IngressHA = policy
+	if err = settings.Validate(); err != nil {
+		return err
+	}
+	if _, err = clusterClient.SetSettings(ctx, settings); err != nil {
+		return fmt.Errorf("set cluster settings: %w", err)
+	}
+
+	d := clusterClient.NewKeepalivedDeployment(opts.image, policy)
+	plan, err := d.Plan(ctx)
+	if err != nil {
+		return fmt.Errorf("plan keepalived deployment: %w", err)
+	}
+	if len(plan.Operations) > 0 {
+		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			if _, err = d.Run(ctx); err != nil {
+				return fmt.Errorf("deploy keepalived: %w", err)
+			}
+			return nil
+		}, uncli.ProgressOut(), fmt.Sprintf("Deploying service %s", d.Spec.Name))
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Ingress HA enabled. Virtual IP %s is assigned to a healthy machine within a few seconds.\n", vip)
+	return nil
+}
+
+// haMachines resolves the names or IDs of the machines to IDs preserving the order. If no machines are given,
+// it returns the IDs of the machines running Caddy.
+func haMachines(ctx context.Context, clusterClient *client.Client, namesOrIDs []string) ([]string, error) {
+	var ids []string
+	if len(namesOrIDs) == 0 {
+		caddy, err := clusterClient.InspectService(ctx, client.CaddyServiceName)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return nil, errors.New("caddy service not found, specify the machines with --machine")
+			}
+			return nil, fmt.Errorf("inspect caddy service: %w", err)
+		}
+		for _, ctr := range caddy.Containers {
+			if !slices.Contains(ids, ctr.MachineID) {
+				ids = append(ids, ctr.MachineID)
+			}
+		}
+		slices.Sort(ids)
+		return ids, nil
+	}
+
+	for _, nameOrID := range namesOrIDs {
+		machine, err := clusterClient.InspectMachine(ctx, nameOrID)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return nil, fmt.Errorf("machine '%s' not found", nameOrID)
+			}
+			return nil, fmt.Errorf("inspect machine: %w", err)
+		}
+		ids = append(ids, machine.Machine.Id)
+	}
+	return ids, nil
+}
+
+func disableHA(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	err = clusterClient.RemoveService(ctx, client.KeepalivedServiceName)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("remove service '%s': %w", client.KeepalivedServiceName, err)
+	}
+
+	settings, err := clusterClient.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	if settings.IngressHA != nil {
+		settings.IngressHA = nil
+		if _, err = clusterClient.SetSettings(ctx, settings); err != nil {
+			return fmt.Errorf("set cluster settings: %w", err)
+		}
+	}
+	fmt.Println("Ingress HA disabled.")
+	return nil
+}
+
+func showHA(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	settings, err := clusterClient.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	policy := settings.IngressHA
+	if policy == nil {
+		fmt.Println("Ingress HA is disabled.")
+		return nil
+	}
+
+	machines := make([]string, len(policy.Machines))
+	for i, id := range policy.Machines {
+		machines[i] = id
+		if machine, err := clusterClient.InspectMachine(ctx, id); err == nil {
+			machines[i] = machine.Machine.Name
+		} else if errors.Is(err, api.ErrNotFound) {
+			machines[i] += " (not found)"
+		}
+	}
+
+	fmt.Printf("Virtual IP: %s\n", policy.VirtualIP)
+	fmt.Printf("Interface:  %s\n", policy.Interface)
+	fmt.Printf("Machines:   %s\n", strings.Join(machines, ", "))
+	fmt.Printf("Router ID:  %d\n", policy.VRRPRouterID())
+	if policy.NotifyMaster != "" {
+		fmt.Printf("Notify:     %s\n", policy.NotifyMaster)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package ingress
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingress",
+		Short: "Manage how the inbound traffic reaches the cluster.",
+	}
+	cmd.AddCommand(
+		NewHACommand(),
+	)
+	return cmd
+}
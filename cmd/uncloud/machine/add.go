@@ -2,17 +2,19 @@ package machine
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net"
 	"net/netip"
+	"strconv"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
+	"github.com/psviderski/uncloud/internal/bwlimit"
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/config"
-	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/internal/machine/network"
 	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
@@ -20,13 +22,19 @@ import (
 )
 
 type addOptions struct {
-	name      string
-	noCaddy   bool
-	noInstall bool
-	publicIP  string
-	sshKey    string
-	context   string
-	version   string
+	bwLimit      string
+	dnsEndpoints []string
+	name         string
+	noCaddy      bool
+	noInstall    bool
+	postScript   string
+	preScript    string
+	publicIP     string
+	resume       bool
+	retries      int
+	sshKey       string
+	context      string
+	version      string
 }
 
 func NewAddCommand() *cobra.Command {
@@ -52,6 +60,18 @@ func NewAddCommand() *cobra.Command {
 			return add(cmd.Context(), uncli, remoteMachine, opts)
 		},
 	}
+	cmd.Flags().StringVar(
+		&opts.bwLimit, "bwlimit", "",
+		"Limit the bandwidth of downloading the Uncloud binaries on the machine during installation, "+
+			"e.g. 512K or 10M bytes per second. Units are 1024-based. (default unlimited)",
+	)
+	cmd.Flags().StringSliceVar(
+		&opts.dnsEndpoints, "dns-endpoint", nil,
+		fmt.Sprintf("WireGuard endpoint of the machine specified as a DNS name in the HOST[:PORT] format (default port "+
+			"is %d). Other machines periodically resolve it to keep the machine reachable when its IP changes, "+
+			"e.g. when using a dynamic DNS (DynDNS) service. Can be specified multiple times or as a comma-separated list.",
+			network.WireGuardPort),
+	)
 	cmd.Flags().StringVarP(&opts.name, "name", "n", "", "Assign a name to the machine.")
 	cmd.Flags().BoolVar(
 		&opts.noCaddy, "no-caddy", false,
@@ -62,14 +82,37 @@ func NewAddCommand() *cobra.Command {
 		"Skip installation of Docker, Uncloud daemon, and dependencies on the machine. "+
 			"Assumes they're already installed and running.",
 	)
+	cmd.Flags().StringVar(
+		&opts.preScript, "pre-script", "",
+		"Path to a local script to run on the machine over SSH before installing Uncloud. "+
+			"The script is run with bash as root. Useful for host hardening and other bootstrap tasks.",
+	)
+	cmd.Flags().StringVar(
+		&opts.postScript, "post-script", "",
+		"Path to a local script to run on the machine over SSH after installing Uncloud. "+
+			"The script is run with bash as root.",
+	)
+	_ = cmd.MarkFlagFilename("pre-script")
+	_ = cmd.MarkFlagFilename("post-script")
 	cmd.Flags().StringVar(
 		&opts.publicIP, "public-ip", "auto",
 		"Public IP address of the machine for ingress configuration. Use 'auto' for automatic detection, "+
 			fmt.Sprintf("blank '' or '%s' to disable ingress on this machine, or specify an IP address.", PublicIPNone),
 	)
+	cmd.Flags().BoolVar(
+		&opts.resume, "resume", false,
+		"Resume a previous attempt to add the machine that failed or was interrupted, skipping the completed steps.",
+	)
+	cmd.Flags().IntVar(
+		&opts.retries, "retries", 0,
+		"Number of times to retry provisioning the machine after a failure, e.g. a network error or a locked "+
+			"package manager. The completed provisioning steps are skipped on retry.",
+	)
 	cmd.Flags().StringVarP(
 		&opts.sshKey, "ssh-key", "i", "",
-		fmt.Sprintf("Path to SSH private key for remote login (if not already added to SSH agent). (default %q)",
+		fmt.Sprintf("Path to SSH private key for remote login (if not already added to SSH agent) or a secret "+
+			"reference to retrieve it with the 1Password, Bitwarden, or Vault CLI: op://VAULT/ITEM/FIELD, "+
+			"bw://ITEM, vault://MOUNT/PATH[?field=FIELD]. (default %q)",
 			cli.DefaultSSHKeyPath),
 	)
 	cmd.Flags().StringVar(
@@ -99,13 +142,32 @@ func add(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine,
 		publicIP = &ip
 	}
 
+	dnsEndpoints := make([]string, len(opts.dnsEndpoints))
+	for i, ep := range opts.dnsEndpoints {
+		host, port, err := parseDNSEndpoint(ep)
+		if err != nil {
+			return err
+		}
+		dnsEndpoints[i] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+
+	downloadLimit, err := bwlimit.Parse(opts.bwLimit)
+	if err != nil {
+		return err
+	}
 	clusterClient, machineClient, err := uncli.AddMachine(ctx, cli.AddMachineOptions{
-		Context:       opts.context,
-		MachineName:   opts.name,
-		PublicIP:      publicIP,
-		RemoteMachine: remoteMachine,
-		SkipInstall:   opts.noInstall,
-		Version:       opts.version,
+		DownloadLimit:    downloadLimit,
+		Context:          opts.context,
+		DNSEndpoints:     dnsEndpoints,
+		MachineName:      opts.name,
+		PublicIP:         publicIP,
+		RemoteMachine:    remoteMachine,
+		SkipInstall:      opts.noInstall,
+		Version:          opts.version,
+		PreScript:        opts.preScript,
+		PostScript:       opts.postScript,
+		ProvisionRetries: opts.retries,
+		Resume:           opts.resume,
 	})
 	if err != nil {
 		return err
@@ -120,7 +182,7 @@ func add(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine,
 	// Wait for the cluster to be initialised to be able to deploy the Caddy service.
 	fmt.Println("Waiting for the machine to be ready...")
 	fmt.Println()
-	if err = waitClusterInitialised(ctx, machineClient); err != nil {
+	if err = WaitClusterInitialised(ctx, machineClient); err != nil {
 		return fmt.Errorf("wait for cluster to be initialised on machine: %w", err)
 	}
 
@@ -129,33 +191,11 @@ func add(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine,
 	// NOTE: We use the cluster client to inspect and scale the Caddy service because the newly added machine may have
 	// issues accessing the Machine API of existing machines in the cluster.
 	// See the issue for more details: https://github.com/psviderski/uncloud/issues/65.
-	caddyImage := ""
-	caddySvc, err := clusterClient.InspectService(ctx, client.CaddyServiceName)
-	if err != nil {
-		if !errors.Is(err, api.ErrNotFound) {
-			return fmt.Errorf("inspect caddy service: %w", err)
-		}
-	} else {
-		caddyImage = caddySvc.Containers[0].Container.Config.Image
-		// Find the latest created container and use its image.
-		var latestCreated time.Time
-		for _, c := range caddySvc.Containers[1:] {
-			created, err := time.Parse(time.RFC3339Nano, c.Container.Created)
-			if err != nil {
-				continue
-			}
-			if created.After(latestCreated) {
-				latestCreated = created
-				caddyImage = c.Container.Config.Image
-			}
-		}
-	}
-
 	// TODO: scale the existing Caddy service to the new machine instead of running a new deployment
 	//  that may cause a small downtime.
-	d, err := clusterClient.NewCaddyDeployment(caddyImage, "", api.Placement{})
+	d, err := caddy.NewClusterDeployment(ctx, clusterClient)
 	if err != nil {
-		return fmt.Errorf("create caddy deployment: %w", err)
+		return err
 	}
 
 	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
@@ -172,7 +212,8 @@ func add(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine,
 	return caddy.UpdateDomainRecords(ctx, machineClient, uncli.ProgressOut())
 }
 
-func waitClusterInitialised(ctx context.Context, client *client.Client) error {
+// WaitClusterInitialised waits for the machine to join the cluster and its cluster API to become available.
+func WaitClusterInitialised(ctx context.Context, client *client.Client) error {
 	boff := backoff.WithContext(backoff.NewExponentialBackOff(
 		backoff.WithMaxInterval(1*time.Second),
 		backoff.WithMaxElapsedTime(5*time.Minute),
@@ -0,0 +1,183 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewAutoUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auto-update",
+		Short: "Manage automatic OS security updates of machines.",
+		Long: `Manage automatic OS security updates of machines.
+
+When enabled, machines install security updates with unattended-upgrades within the recurring maintenance
+window. Machines are updated one at a time in the order of their IDs:
+  1. Drain: stop the service containers on the machine so that the ingress stops routing traffic to them.
+  2. Update: install the pending updates with unattended-upgrade.
+  3. Reboot the machine if required by the installed updates.
+  4. Start the drained containers and wait for them to become healthy.
+The next machine starts updating only after the previous one has completed. If the update of a machine
+fails, the rollout is halted until the next maintenance window.
+
+Automatic updates are only supported on Debian-based distributions with the unattended-upgrades package
+installed. Services should run multiple replicas on different machines to stay available during the updates.`,
+	}
+	cmd.AddCommand(
+		newAutoUpdateEnableCommand(),
+		newAutoUpdateDisableCommand(),
+		newAutoUpdateStatusCommand(),
+	)
+	return cmd
+}
+
+func newAutoUpdateEnableCommand() *cobra.Command {
+	var contextName, window string
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Enable automatic OS security updates of machines within a maintenance window.",
+		Example: `  # Update machines every Sunday between 03:00 and 05:00 UTC.
+  uc machine auto-update enable --window "Sun 03:00-05:00"
+
+  # Update machines every Saturday and Sunday between 22:00 and 02:00 UTC.
+  uc machine auto-update enable --window "Sat,Sun 22:00-02:00"
+
+  # Update machines every day between 04:00 and 05:00 UTC.
+  uc machine auto-update enable --window "04:00-05:00"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setAutoUpdate(cmd.Context(), uncli, contextName, true, window)
+		},
+	}
+	cmd.Flags().StringVar(&window, "window", "",
+		"Recurring maintenance window in UTC in the format '[DAYS ]HH:MM-HH:MM', e.g. 'Sun 03:00-05:00'.\n"+
+			"DAYS is an optional comma-separated list of weekdays. Every day if omitted.\n"+
+			"(default is the previously configured window)")
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newAutoUpdateDisableCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Disable automatic OS security updates of machines.",
+		Long: "Disable automatic OS security updates of machines. A machine that is already being updated " +
+			"completes its update.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setAutoUpdate(cmd.Context(), uncli, contextName, false, "")
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func setAutoUpdate(ctx context.Context, uncli *cli.CLI, contextName string, enabled bool, window string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if err = client.SetAutoUpdate(ctx, enabled, window); err != nil {
+		return fmt.Errorf("set auto-update config: %w", err)
+	}
+
+	if !enabled {
+		fmt.Println("Automatic OS updates disabled.")
+		return nil
+	}
+	au, err := client.GetAutoUpdate(ctx)
+	if err != nil {
+		return fmt.Errorf("get auto-update config: %w", err)
+	}
+	fmt.Printf("Automatic OS updates enabled within the maintenance window: %s (UTC).\n", au.Window)
+	return nil
+}
+
+func newAutoUpdateStatusCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Display the automatic OS updates configuration and the last update of each machine.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return autoUpdateStatus(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func autoUpdateStatus(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	au, err := client.GetAutoUpdate(ctx)
+	if err != nil {
+		return fmt.Errorf("get auto-update config: %w", err)
+	}
+	machines, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+
+	state := "disabled"
+	if au.Enabled {
+		state = "enabled"
+	}
+	fmt.Printf("Automatic OS updates: %s\n", state)
+	if au.Window != "" {
+		fmt.Printf("Maintenance window:   %s (UTC)\n", au.Window)
+	}
+	fmt.Println()
+
+	updates := make(map[string]int)
+	for i, u := range au.Machines {
+		updates[u.MachineID] = i
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err = fmt.Fprintln(tw, "MACHINE\tLAST UPDATE\tWINDOW START\tUPDATED\tERROR"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, m := range machines {
+		lastUpdate, windowStart, updated, updateErr := "never", "-", "-", ""
+		if i, ok := updates[m.Machine.Id]; ok {
+			u := au.Machines[i]
+			lastUpdate = u.State
+			windowStart = u.WindowStart.UTC().Format("Mon 2006-01-02 15:04")
+			updated = units.HumanDuration(time.Since(u.UpdatedAt)) + " ago"
+			updateErr = u.Error
+		}
+		_, err = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			m.Machine.Name, lastUpdate, windowStart, updated, updateErr)
+		if err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	return tw.Flush()
+}
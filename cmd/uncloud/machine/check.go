@@ -0,0 +1,87 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/spf13/cobra"
+)
+
+type checkOptions struct {
+	sshKey string
+}
+
+func NewCheckCommand() *cobra.Command {
+	opts := checkOptions{}
+	cmd := &cobra.Command{
+		Use:   "check [USER@]HOST[:PORT]",
+		Short: "Check if a remote machine meets the prerequisites to be added to a cluster.",
+		Long: `Check if a remote machine meets the prerequisites to be added to a cluster.
+
+The command connects to the machine over SSH and checks its operating system and architecture, user privileges,
+available memory and disk space, and other requirements without installing or changing anything on the machine.
+Use it to validate candidate hosts before running 'uc machine init' or 'uc machine add'.`,
+		Example: `  # Check a machine with the root user.
+  uc machine check root@<your-server-ip>
+
+  # Check a machine with a non-root user and custom SSH port and key.
+  uc machine check ubuntu@<your-server-ip>:2222 -i ~/.ssh/mykey`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			user, host, port, err := config.SSHDestination(args[0]).Parse()
+			if err != nil {
+				return fmt.Errorf("parse remote machine: %w", err)
+			}
+			remoteMachine := &cli.RemoteMachine{
+				User:    user,
+				Host:    host,
+				Port:    port,
+				KeyPath: opts.sshKey,
+			}
+
+			return check(cmd.Context(), uncli, remoteMachine)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&opts.sshKey, "ssh-key", "i", "",
+		fmt.Sprintf("Path to SSH private key for remote login (if not already added to SSH agent) or a secret "+
+			"reference to retrieve it with the 1Password, Bitwarden, or Vault CLI: op://VAULT/ITEM/FIELD, "+
+			"bw://ITEM, vault://MOUNT/PATH[?field=FIELD]. (default %q)",
+			cli.DefaultSSHKeyPath),
+	)
+
+	return cmd
+}
+
+func check(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine) error {
+	checks, err := uncli.CheckMachinePrerequisites(ctx, remoteMachine)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tDETAILS")
+	failed := 0
+	for _, c := range checks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Name, c.Status, c.Message)
+		if c.Status == cli.PrerequisiteFailed {
+			failed++
+		}
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("machine doesn't meet %d prerequisite(s)", failed)
+	}
+	fmt.Println("Machine meets all the prerequisites.")
+	return nil
+}
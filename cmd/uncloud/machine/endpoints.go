@@ -0,0 +1,160 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/spf13/cobra"
+)
+
+type endpointsOptions struct {
+	set     []string
+	auto    bool
+	context string
+}
+
+func NewEndpointsCommand() *cobra.Command {
+	opts := endpointsOptions{}
+	cmd := &cobra.Command{
+		Use:   "endpoints MACHINE",
+		Short: "View or override WireGuard endpoints of a machine.",
+		Long: `View or override WireGuard endpoints of a machine.
+
+Endpoints are the addresses other machines use to establish WireGuard tunnels to the machine.
+By default, the machine detects its routable and public IPs and announces them to the cluster
+automatically, including when they change, for example, after the public IP has been reassigned.
+
+Use --set to override the announced endpoints, for example, when the machine is behind a NAT
+with port forwarding, or to announce a DNS name updated by a dynamic DNS (DynDNS) service.
+Automatic detection is disabled for a machine with overridden endpoints until it's re-enabled
+with --auto. DNS endpoints are kept when automatic detection is re-enabled.`,
+		Example: `  # View the endpoints of machine1.
+  uc machine endpoints machine1
+
+  # Announce a single endpoint with a custom port.
+  uc machine endpoints machine1 --set 203.0.113.10:51821
+
+  # Announce a DNS name kept up to date by a dynamic DNS service.
+  uc machine endpoints machine1 --set home.example.dyndns.org
+
+  # Re-enable automatic endpoint detection.
+  uc machine endpoints machine1 --auto`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return endpoints(cmd.Context(), uncli, opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringSliceVar(
+		&opts.set, "set", nil,
+		fmt.Sprintf("Override the endpoints of the machine with the specified IP[:PORT] addresses or HOST[:PORT] "+
+			"DNS names (default port is %d). DNS names are periodically resolved by other machines. "+
+			"Can be specified multiple times or as a comma-separated list.", network.WireGuardPort),
+	)
+	cmd.Flags().BoolVar(
+		&opts.auto, "auto", false,
+		"Re-enable automatic detection of the machine endpoints.",
+	)
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	cmd.MarkFlagsMutuallyExclusive("set", "auto")
+
+	return cmd
+}
+
+func endpoints(ctx context.Context, uncli *cli.CLI, opts endpointsOptions, machineNameOrID string) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	member, err := client.InspectMachine(ctx, machineNameOrID)
+	if err != nil {
+		return fmt.Errorf("find machine: %w", err)
+	}
+	machine := member.Machine
+
+	if len(opts.set) > 0 || opts.auto {
+		manual := len(opts.set) > 0
+		req := &pb.UpdateMachineRequest{
+			MachineId:       machine.Id,
+			ManualEndpoints: &manual,
+		}
+		for _, s := range opts.set {
+			addrPort, dnsEndpoint, err := parseEndpoint(s)
+			if err != nil {
+				return err
+			}
+			if dnsEndpoint != "" {
+				req.DnsEndpoints = append(req.DnsEndpoints, dnsEndpoint)
+			} else {
+				req.Endpoints = append(req.Endpoints, pb.NewIPPort(addrPort))
+			}
+		}
+
+		if machine, err = client.UpdateMachine(ctx, req); err != nil {
+			return fmt.Errorf("update machine: %w", err)
+		}
+		if manual {
+			fmt.Printf("Endpoints of machine %q overridden.\n", machine.Name)
+		} else {
+			fmt.Printf("Automatic endpoint detection re-enabled for machine %q. "+
+				"The machine will announce its detected endpoints within a minute.\n", machine.Name)
+		}
+	}
+
+	mode := "auto-detected"
+	if machine.ManualEndpoints {
+		mode = "manual"
+	}
+	fmt.Printf("Endpoints of machine %q (%s):\n", machine.Name, mode)
+	for _, ep := range machine.Network.DnsEndpoints {
+		fmt.Printf("  %s (DNS)\n", ep)
+	}
+	for _, ep := range machine.Network.Endpoints {
+		addrPort, _ := ep.ToAddrPort()
+		fmt.Printf("  %s\n", addrPort)
+	}
+
+	return nil
+}
+
+// parseEndpoint parses an endpoint in the IP[:PORT] or HOST[:PORT] format using the default WireGuard port
+// if the port is omitted. It returns either an IP endpoint or a DNS endpoint in the host:port format.
+func parseEndpoint(s string) (netip.AddrPort, string, error) {
+	if addrPort, err := netip.ParseAddrPort(s); err == nil {
+		if addrPort.Port() == 0 {
+			return netip.AddrPort{}, "", fmt.Errorf("invalid endpoint %q: port must be non-zero", s)
+		}
+		return addrPort, "", nil
+	}
+	if ip, err := netip.ParseAddr(s); err == nil {
+		return netip.AddrPortFrom(ip, network.WireGuardPort), "", nil
+	}
+
+	host, port, err := parseDNSEndpoint(s)
+	if err != nil {
+		return netip.AddrPort{}, "", err
+	}
+	return netip.AddrPort{}, net.JoinHostPort(host, strconv.Itoa(int(port))), nil
+}
+
+// parseDNSEndpoint parses an endpoint specified as a DNS name in the HOST[:PORT] format using the default WireGuard
+// port if the port is omitted.
+func parseDNSEndpoint(s string) (string, uint16, error) {
+	if !strings.Contains(s, ":") {
+		s = net.JoinHostPort(s, strconv.Itoa(network.WireGuardPort))
+	}
+	return network.ParseDNSEndpoint(s)
+}
@@ -0,0 +1,25 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDNSEndpoint(t *testing.T) {
+	t.Parallel()
+
+	host, port, err := parseDNSEndpoint("home.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "home.example.com", host)
+	assert.EqualValues(t, 51820, port, "default WireGuard port must be used")
+
+	host, port, err = parseDNSEndpoint("home.example.com:51000")
+	require.NoError(t, err)
+	assert.Equal(t, "home.example.com", host)
+	assert.EqualValues(t, 51000, port)
+
+	_, _, err = parseDNSEndpoint("203.0.113.10")
+	assert.ErrorContains(t, err, "host must be a DNS name")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
 	"github.com/psviderski/uncloud/cmd/uncloud/dns"
+	"github.com/psviderski/uncloud/internal/bwlimit"
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/config"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
@@ -17,13 +18,17 @@ import (
 )
 
 type initOptions struct {
+	bwLimit     string
 	dnsEndpoint string
 	name        string
 	network     string
 	noCaddy     bool
 	noDNS       bool
 	noInstall   bool
+	postScript  string
+	preScript   string
 	publicIP    string
+	retries     int
 	sshKey      string
 	version     string
 	context     string
@@ -70,6 +75,11 @@ func NewInitCommand() *cobra.Command {
 			return initCluster(cmd.Context(), uncli, remoteMachine, opts)
 		},
 	}
+	cmd.Flags().StringVar(
+		&opts.bwLimit, "bwlimit", "",
+		"Limit the bandwidth of downloading the Uncloud binaries on the machine during installation, "+
+			"e.g. 512K or 10M bytes per second. Units are 1024-based. (default unlimited)",
+	)
 	cmd.Flags().StringVar(&opts.dnsEndpoint, "dns-endpoint", dns.DefaultUncloudDNSAPIEndpoint,
 		"API endpoint for the Uncloud DNS service.")
 	cmd.Flags().StringVarP(
@@ -93,14 +103,33 @@ func NewInitCommand() *cobra.Command {
 		"Skip installation of Docker, Uncloud daemon, and dependencies on the machine. "+
 			"Assumes they're already installed and running.",
 	)
+	cmd.Flags().StringVar(
+		&opts.preScript, "pre-script", "",
+		"Path to a local script to run on the machine over SSH before installing Uncloud. "+
+			"The script is run with bash as root. Useful for host hardening and other bootstrap tasks.",
+	)
+	cmd.Flags().StringVar(
+		&opts.postScript, "post-script", "",
+		"Path to a local script to run on the machine over SSH after installing Uncloud. "+
+			"The script is run with bash as root.",
+	)
+	_ = cmd.MarkFlagFilename("pre-script")
+	_ = cmd.MarkFlagFilename("post-script")
 	cmd.Flags().StringVar(
 		&opts.publicIP, "public-ip", "auto",
 		"Public IP address of the machine for ingress configuration. Use 'auto' for automatic detection, "+
 			fmt.Sprintf("blank '' or '%s' to disable ingress on this machine, or specify an IP address.", PublicIPNone),
 	)
+	cmd.Flags().IntVar(
+		&opts.retries, "retries", 0,
+		"Number of times to retry provisioning the machine after a failure, e.g. a network error or a locked "+
+			"package manager. The completed provisioning steps are skipped on retry.",
+	)
 	cmd.Flags().StringVarP(
 		&opts.sshKey, "ssh-key", "i", "",
-		fmt.Sprintf("Path to SSH private key for remote login (if not already added to SSH agent). (default %q)",
+		fmt.Sprintf("Path to SSH private key for remote login (if not already added to SSH agent) or a secret "+
+			"reference to retrieve it with the 1Password, Bitwarden, or Vault CLI: op://VAULT/ITEM/FIELD, "+
+			"bw://ITEM, vault://MOUNT/PATH[?field=FIELD]. (default %q)",
 			cli.DefaultSSHKeyPath),
 	)
 	cmd.Flags().StringVar(
@@ -134,14 +163,22 @@ func initCluster(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteM
 		}
 		publicIP = &ip
 	}
+	downloadLimit, err := bwlimit.Parse(opts.bwLimit)
+	if err != nil {
+		return err
+	}
 	client, err := uncli.InitCluster(ctx, cli.InitClusterOptions{
-		Context:       opts.context,
-		MachineName:   opts.name,
-		Network:       netPrefix,
-		PublicIP:      publicIP,
-		RemoteMachine: remoteMachine,
-		SkipInstall:   opts.noInstall,
-		Version:       opts.version,
+		DownloadLimit:    downloadLimit,
+		Context:          opts.context,
+		MachineName:      opts.name,
+		Network:          netPrefix,
+		PublicIP:         publicIP,
+		RemoteMachine:    remoteMachine,
+		SkipInstall:      opts.noInstall,
+		Version:          opts.version,
+		PreScript:        opts.preScript,
+		PostScript:       opts.postScript,
+		ProvisionRetries: opts.retries,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,142 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/spf13/cobra"
+)
+
+type inspectOptions struct {
+	machine  string
+	lastBoot bool
+	context  string
+}
+
+func NewInspectCommand() *cobra.Command {
+	opts := inspectOptions{}
+	cmd := &cobra.Command{
+		Use:   "inspect MACHINE",
+		Short: "Display detailed information about a machine.",
+		Example: `  # Show the details of machine 'machine1'.
+  uc machine inspect machine1
+
+  # Show the state recovery performed by the machine daemon after the last reboot of 'machine1'.
+  uc machine inspect machine1 --last-boot`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.machine = args[0]
+			return inspect(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.lastBoot, "last-boot", false,
+		"Show the report of the state recovery performed by the machine daemon after the last reboot:\n"+
+			"recreated WireGuard interface, containers reconnected to the overlay network and restarted.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if opts.lastBoot {
+		report, err := client.MachineLastBootReport(ctx, opts.machine)
+		if err != nil {
+			return fmt.Errorf("get last boot report: %w", err)
+		}
+
+		fmt.Printf("Boot ID:    %s\n", report.BootID)
+		fmt.Printf("Booted:     %s (%s ago)\n", report.BootTime.Local().Format(time.DateTime),
+			units.HumanDuration(time.Since(report.BootTime)))
+		fmt.Printf("Recovered:  %s (in %s)\n", report.RecoveredAt.Local().Format(time.DateTime),
+			report.RecoveredAt.Sub(report.BootTime).Round(time.Second))
+		fmt.Println()
+		if len(report.Actions) == 0 {
+			fmt.Println("No recovery actions were needed.")
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if _, err = fmt.Fprintln(tw, "RESOURCE\tACTION\tRESULT"); err != nil {
+			return fmt.Errorf("write header: %w", err)
+		}
+		for _, a := range report.Actions {
+			result := "ok"
+			if a.Error != "" {
+				result = "failed: " + a.Error
+			}
+			if _, err = fmt.Fprintf(tw, "%s\t%s\t%s\n", a.Resource, a.Action, result); err != nil {
+				return fmt.Errorf("write row: %w", err)
+			}
+		}
+		return tw.Flush()
+	}
+
+	m, err := client.Machine(ctx, opts.machine)
+	if err != nil {
+		return fmt.Errorf("inspect machine: %w", err)
+	}
+
+	publicIP := "-"
+	if m.PublicIP.IsValid() {
+		publicIP = m.PublicIP.String()
+	}
+	endpoints := m.DNSEndpoints
+	for _, ep := range m.Endpoints {
+		endpoints = append(endpoints, ep.String())
+	}
+	state := capitalise(m.State)
+	if m.Cordoned {
+		state += " (cordoned)"
+	}
+	arch := m.Arch
+	if arch == "" {
+		arch = "-"
+	}
+
+	fmt.Printf("ID:                   %s\n", m.ID)
+	fmt.Printf("Name:                 %s\n", m.Name)
+	fmt.Printf("State:                %s\n", state)
+	fmt.Printf("Arch:                 %s\n", arch)
+	fmt.Printf("Address:              %s\n", netip.PrefixFrom(network.MachineIP(m.Subnet), m.Subnet.Bits()))
+	fmt.Printf("Management IP:        %s\n", m.ManagementIP)
+	fmt.Printf("Public IP:            %s\n", publicIP)
+	fmt.Printf("WireGuard endpoints:  %s\n", strings.Join(endpoints, ", "))
+	if r := m.Resources; r != nil {
+		disk := "-"
+		if r.Disk > 0 {
+			disk = units.HumanSize(float64(r.Disk))
+		}
+		fmt.Printf("CPUs:                 %d\n", r.CPUs)
+		fmt.Printf("Memory:               %s\n", units.BytesSize(float64(r.Memory)))
+		fmt.Printf("Disk:                 %s\n", disk)
+		fmt.Printf("OS:                   %s\n", r.OS)
+		fmt.Printf("Kernel:               %s\n", r.Kernel)
+		fmt.Printf("Docker version:       %s\n", r.DockerVersion)
+	}
+	if c := m.Cost; c != nil {
+		provider := c.Provider
+		if provider == "" {
+			provider = "-"
+		}
+		fmt.Printf("Hourly price:         %g\n", c.HourlyPrice)
+		fmt.Printf("Provider:             %s\n", provider)
+	}
+	return nil
+}
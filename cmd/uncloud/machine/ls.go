@@ -5,34 +5,52 @@ import (
 	"fmt"
 	"net/netip"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/docker/go-units"
 	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/internal/machine/network"
 	"github.com/spf13/cobra"
 )
 
+const outputWide = "wide"
+
+type listOptions struct {
+	output  string
+	context string
+}
+
 func NewListCommand() *cobra.Command {
-	var contextName string
+	opts := listOptions{}
 	cmd := &cobra.Command{
 		Use:     "ls",
 		Aliases: []string{"list"},
 		Short:   "List machines in a cluster.",
+		Example: `  # List machines with their CPU, memory, disk, OS, and Docker version.
+  uc machine ls -o wide`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
-			return list(cmd.Context(), uncli, contextName)
+			if opts.output != "" && opts.output != outputWide {
+				return fmt.Errorf("invalid output format: %q, supported formats: %s", opts.output, outputWide)
+			}
+			return list(cmd.Context(), uncli, opts)
 		},
 	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"Output format. Use 'wide' to also show the resources of the machines.")
 	cmd.Flags().StringVarP(
-		&contextName, "context", "c", "",
+		&opts.context, "context", "c", "",
 		"Name of the cluster context. (default is the current context)",
 	)
 	return cmd
 }
 
-func list(ctx context.Context, uncli *cli.CLI, clusterName string) error {
-	client, err := uncli.ConnectCluster(ctx, clusterName)
+func list(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
 	if err != nil {
 		return fmt.Errorf("connect to cluster: %w", err)
 	}
@@ -46,7 +64,11 @@ func list(ctx context.Context, uncli *cli.CLI, clusterName string) error {
 	// Print the list of machines in a table format.
 	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 	// Print header.
-	if _, err = fmt.Fprintln(tw, "NAME\tSTATE\tADDRESS\tPUBLIC IP\tWIREGUARD ENDPOINTS\tMACHINE ID"); err != nil {
+	header := "NAME\tSTATE\tADDRESS\tPUBLIC IP\tWIREGUARD ENDPOINTS\tMACHINE ID"
+	if opts.output == outputWide {
+		header += "\tARCH\tCPUS\tMEMORY\tDISK\tOS\tKERNEL\tDOCKER"
+	}
+	if _, err = fmt.Fprintln(tw, header); err != nil {
 		return fmt.Errorf("write header: %w", err)
 	}
 	// Print rows.
@@ -61,22 +83,53 @@ func list(ctx context.Context, uncli *cli.CLI, clusterName string) error {
 			publicIP = ip.String()
 		}
 
-		endpoints := make([]string, len(m.Network.Endpoints))
-		for i, ep := range m.Network.Endpoints {
+		endpoints := slices.Clone(m.Network.DnsEndpoints)
+		for _, ep := range m.Network.Endpoints {
 			addrPort, _ := ep.ToAddrPort()
-			endpoints[i] = addrPort.String()
+			endpoints = append(endpoints, addrPort.String())
 		}
 
-		if _, err = fmt.Fprintf(
-			tw, "%s\t%s\t%s\t%s\t%s\t%s\n", m.Name, capitalise(member.State.String()), subnet, publicIP,
-			strings.Join(endpoints, ", "), member.Machine.Id,
-		); err != nil {
+		state := capitalise(member.State.String())
+		if m.Cordoned {
+			state += " (cordoned)"
+		}
+
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", m.Name, state, subnet, publicIP,
+			strings.Join(endpoints, ", "), member.Machine.Id)
+		if opts.output == outputWide {
+			row += "\t" + strings.Join(resourceColumns(m), "\t")
+		}
+		if _, err = fmt.Fprintln(tw, row); err != nil {
 			return fmt.Errorf("write row: %w", err)
 		}
 	}
 	return tw.Flush()
 }
 
+// resourceColumns returns the ARCH, CPUS, MEMORY, DISK, OS, KERNEL, and DOCKER column values for the machine.
+// Unknown values are shown as "-".
+func resourceColumns(m *pb.MachineInfo) []string {
+	cols := []string{orDash(m.Arch), "-", "-", "-", "-", "-", "-"}
+	if r := m.Resources; r != nil {
+		cols[1] = strconv.Itoa(int(r.Cpus))
+		cols[2] = units.BytesSize(float64(r.Memory))
+		if r.Disk > 0 {
+			cols[3] = units.HumanSize(float64(r.Disk))
+		}
+		cols[4] = orDash(r.Os)
+		cols[5] = orDash(r.Kernel)
+		cols[6] = orDash(r.DockerVersion)
+	}
+	return cols
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 // capitalise returns a string where the first character is upper case, and the rest is lower case.
 func capitalise(s string) string {
 	if s == "" {
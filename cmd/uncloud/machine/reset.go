@@ -0,0 +1,234 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type resetOptions struct {
+	sshKey  string
+	yes     bool
+	context string
+}
+
+func NewResetCommand() *cobra.Command {
+	opts := resetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "reset MACHINE|[USER@]HOST[:PORT]",
+		Short: "Reset a machine to the uninitialised state and remove it from the cluster.",
+		Long: `Reset a machine to the uninitialised state and remove it from the cluster.
+
+The machine can be specified by its name or ID in the cluster, or by its SSH destination if it's not reachable
+through the cluster, for example, when the cluster is gone or the machine was never fully added.
+
+Resetting stops and removes all service containers, removes the machine from the cluster, and wipes Uncloud data,
+WireGuard interfaces, and firewall rules on the machine. Connections to the machine are removed from all cluster
+contexts in the local Uncloud config.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			return reset(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	cmd.Flags().StringVarP(&opts.sshKey, "ssh-key", "i", "",
+		fmt.Sprintf("Path to SSH private key for SSH remote login if the machine is specified by its SSH "+
+			"destination. (default %s)", cli.DefaultSSHKeyPath))
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before resetting the machine.")
+
+	return cmd
+}
+
+func reset(ctx context.Context, uncli *cli.CLI, target string, opts resetOptions) error {
+	// The cluster is only needed to remove the machine from it, so resetting a machine over SSH is still possible
+	// when the cluster is unreachable.
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		client.PrintWarning(fmt.Sprintf("Failed to connect to the cluster: %v", err))
+	} else {
+		defer clusterClient.Close()
+	}
+
+	var m *pb.MachineInfo
+	// member is true if the machine is registered in the cluster.
+	var member bool
+	// machineCtx and machineClient are used to manage the machine either through the cluster or directly over SSH.
+	var machineCtx context.Context
+	var machineClient *client.Client
+	var hosts []string
+
+	if clusterClient != nil {
+		mctx, machines, err := api.ProxyMachinesContext(ctx, clusterClient, []string{target})
+		if err != nil {
+			return err
+		}
+		if len(machines) > 0 {
+			m = machines[0].Machine
+			member = true
+			machineCtx, machineClient = mctx, clusterClient
+		}
+	}
+
+	initialised := true
+	if m == nil {
+		// The target is not a machine name or ID in the cluster, try to connect to it as an SSH destination.
+		user, host, port, err := config.SSHDestination(target).Parse()
+		if err != nil {
+			return fmt.Errorf("parse remote machine: %w", err)
+		}
+		remoteMachine := &cli.RemoteMachine{
+			User:    user,
+			Host:    host,
+			Port:    port,
+			KeyPath: opts.sshKey,
+		}
+		sshClient, err := cli.ConnectRemoteMachine(ctx, remoteMachine)
+		if err != nil {
+			return fmt.Errorf("machine '%s' not found in the cluster and failed to connect to it over SSH: %w",
+				target, err)
+		}
+		defer sshClient.Close()
+		machineCtx, machineClient = ctx, sshClient
+		hosts = append(hosts, host)
+
+		minfo, err := sshClient.Inspect(ctx, &emptypb.Empty{})
+		if err != nil {
+			return fmt.Errorf("inspect machine: %w", err)
+		}
+		initialised = minfo.Id != ""
+		if initialised && clusterClient != nil {
+			machines, err := clusterClient.ListMachines(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("list machines: %w", err)
+			}
+			if mm := machines.FindByNameOrID(minfo.Id); mm != nil {
+				m = mm.Machine
+				member = true
+			}
+		}
+		if m == nil {
+			m = minfo
+		}
+	}
+	hosts = append(hosts, cli.MachineHosts(m)...)
+
+	if member {
+		if err = checkNotProxyMachine(ctx, clusterClient, m); err != nil {
+			return err
+		}
+	}
+
+	name := m.Name
+	if name == "" {
+		name = target
+	}
+
+	var containers []api.ServiceContainer
+	reachable := false
+	if initialised {
+		machineContainers, err := machineClient.Docker.ListServiceContainers(
+			machineCtx, "", container.ListOptions{All: true})
+		if err == nil {
+			reachable = true
+			if len(machineContainers) > 0 {
+				containers = machineContainers[0].Containers
+			}
+		}
+	}
+
+	switch {
+	case !initialised:
+		fmt.Printf("Machine '%s' is not initialised.\n", name)
+		fmt.Println("This will remove connections to the machine from the local Uncloud config.")
+	case !reachable:
+		fmt.Printf("Machine '%s' is unreachable.\n", name)
+		if member {
+			fmt.Println("This will remove the machine from the cluster without resetting it and remove connections " +
+				"to it from the local Uncloud config.")
+		} else {
+			fmt.Println("This will remove connections to the machine from the local Uncloud config.")
+		}
+	default:
+		if len(containers) > 0 {
+			plural := ""
+			if len(containers) > 1 {
+				plural = "s"
+			}
+			fmt.Printf("Found %d service container%s on machine '%s':\n", len(containers), plural, name)
+			fmt.Println(formatContainerTree(containers))
+			fmt.Println()
+		} else {
+			fmt.Printf("No service containers found on machine '%s'.\n", name)
+		}
+		fmt.Println("This will remove all service containers from the machine, remove it from the cluster, " +
+			"wipe Uncloud data, WireGuard interfaces, and firewall rules on the machine, and remove connections " +
+			"to it from the local Uncloud config.")
+	}
+
+	if !opts.yes {
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm reset: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. Machine was not reset.")
+			return nil
+		}
+	}
+
+	if reachable && len(containers) > 0 {
+		err = progress.RunWithTitle(machineCtx, func(ctx context.Context) error {
+			return removeContainers(ctx, machineClient, containers)
+		}, uncli.ProgressOut(), "Removing containers")
+		if err != nil {
+			return fmt.Errorf("remove containers: %w", err)
+		}
+		fmt.Println()
+	}
+
+	if member {
+		if _, err = clusterClient.RemoveMachine(ctx, &pb.RemoveMachineRequest{Id: m.Id}); err != nil {
+			return fmt.Errorf("remove machine from cluster: %w", err)
+		}
+		fmt.Printf("Machine '%s' removed from the cluster.\n", name)
+	}
+
+	if reachable {
+		if _, err = machineClient.MachineClient.Reset(machineCtx, &pb.ResetRequest{}); err != nil {
+			return fmt.Errorf("reset machine: %w", err)
+		}
+		fmt.Println("Machine reset initiated and will complete in the background.")
+	}
+
+	if uncli.Config == nil {
+		return nil
+	}
+	removed, err := uncli.RemoveMachineConnections(ctx, hosts)
+	if err != nil {
+		return fmt.Errorf("remove machine connections from config: %w", err)
+	}
+	for _, contextName := range slices.Sorted(maps.Keys(removed)) {
+		for _, conn := range removed[contextName] {
+			fmt.Printf("Connection '%s' removed from context '%s'.\n", conn, contextName)
+		}
+	}
+
+	return nil
+}
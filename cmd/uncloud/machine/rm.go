@@ -16,6 +16,7 @@ import (
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -67,22 +68,8 @@ func remove(ctx context.Context, uncli *cli.CLI, nameOrID string, opts removeOpt
 	}
 	m := machines[0].Machine
 
-	// Verify if the machine being removed is the proxy machine we're connected to.
-	proxyMachine, err := client.MachineClient.Inspect(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("inspect proxy machine: %w", err)
-	}
-	if proxyMachine.Id == m.Id {
-		allMachines, err := client.ListMachines(ctx, nil)
-		if err != nil {
-			return fmt.Errorf("list machines: %w", err)
-		}
-		if len(allMachines) > 1 {
-			return errors.New("cannot remove the machine you are currently connected to. " +
-				"Please connect to another machine in the cluster and try again. " +
-				"Use --connect flag or update 'connections' for the cluster context in your Uncloud config")
-			// It's ok to remove the proxy machine if it's the last one in the cluster.
-		}
+	if err = checkNotProxyMachine(ctx, client, m); err != nil {
+		return err
 	}
 
 	// TODO: mark the machine as being removed and unschedulable when this is possible to prevent new containers
@@ -164,6 +151,29 @@ func remove(ctx context.Context, uncli *cli.CLI, nameOrID string, opts removeOpt
 	return nil
 }
 
+// checkNotProxyMachine returns an error if the machine is the proxy machine the client is connected to and there are
+// other machines in the cluster. It's ok to remove the proxy machine if it's the last one in the cluster.
+func checkNotProxyMachine(ctx context.Context, client *client.Client, m *pb.MachineInfo) error {
+	proxyMachine, err := client.MachineClient.Inspect(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("inspect proxy machine: %w", err)
+	}
+	if proxyMachine.Id != m.Id {
+		return nil
+	}
+
+	allMachines, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if len(allMachines) > 1 {
+		return errors.New("cannot remove the machine you are currently connected to. " +
+			"Please connect to another machine in the cluster and try again. " +
+			"Use --connect flag or update 'connections' for the cluster context in your Uncloud config")
+	}
+	return nil
+}
+
 // formatContainerTree formats a list of containers grouped by service as a tree structure.
 func formatContainerTree(containers []api.ServiceContainer) string {
 	if len(containers) == 0 {
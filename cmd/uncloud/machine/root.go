@@ -12,11 +12,17 @@ func NewRootCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		NewAddCommand(),
+		NewAutoUpdateCommand(),
+		NewCheckCommand(),
+		NewEndpointsCommand(),
 		NewInitCommand(),
+		NewInspectCommand(),
 		NewListCommand(),
 		NewRenameCommand(),
+		NewResetCommand(),
 		NewRmCommand(),
 		NewUpdateCommand(),
+		NewUpgradeCommand(),
 		NewTokenCommand(),
 	)
 	return cmd
@@ -1,10 +1,22 @@
 package machine
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/daemon"
 	"github.com/psviderski/uncloud/internal/machine"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/connector"
 	"github.com/spf13/cobra"
 )
 
@@ -17,23 +29,250 @@ func NewTokenCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "token",
 		Short: "Print the local machine's token for adding it to a cluster.",
+		Long: fmt.Sprintf(`Print the local machine's token for adding it to a cluster.
+
+The token is the '%s' prefix followed by a base64-encoded JSON object. The current token format
+version is %d. Tokens without a version were created by older releases and are treated as version 1.
+Tokens with a newer version than supported are rejected. Use 'uc machine token decode' to inspect
+the fields of a token.`, machine.TokenPrefix, machine.TokenVersion),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			token, err := daemon.MachineToken(opts.dataDir)
-			if err != nil {
-				return fmt.Errorf("get machine token: %w", err)
+			return showLocalToken(opts.dataDir, nil, false)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&opts.dataDir, "data-dir", "d", machine.DefaultDataDir,
+		"Directory for storing persistent machine state.")
+	_ = cmd.MarkPersistentFlagDirname("data-dir")
+
+	cmd.AddCommand(
+		newTokenShowCommand(&opts),
+		newTokenDecodeCommand(),
+		newTokenRotateCommand(&opts),
+	)
+
+	return cmd
+}
+
+type tokenShowOptions struct {
+	decode       bool
+	dnsEndpoints []string
+	machine      string
+	context      string
+}
+
+func newTokenShowCommand(tokenOpts *tokenOptions) *cobra.Command {
+	opts := tokenShowOptions{}
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the token of the local machine or a machine in the cluster.",
+		Long: `Print the token of the local machine or a machine in the cluster.
+
+Without --machine, the token is generated offline from the local machine state which doesn't require
+the Uncloud daemon to be running. With --machine, the token is requested from the specified machine
+in the cluster.
+
+Use --dns-endpoint to include WireGuard endpoints specified as DNS names in the token, e.g. when the machine
+has a dynamic IP updated by a dynamic DNS (DynDNS) service. Machines in the cluster periodically resolve them
+to keep the machine reachable when its IP changes.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.machine == "" {
+				return showLocalToken(tokenOpts.dataDir, opts.dnsEndpoints, opts.decode)
 			}
-			tokenStr, err := token.String()
+
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return showMachineToken(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.decode, "decode", false,
+		"Print the decoded token fields instead of the encoded token.")
+	cmd.Flags().StringSliceVar(&opts.dnsEndpoints, "dns-endpoint", nil,
+		fmt.Sprintf("WireGuard endpoint of the machine specified as a DNS name in the HOST[:PORT] format "+
+			"to include in the token (default port is %d). Can be specified multiple times or as a "+
+			"comma-separated list.", network.WireGuardPort))
+	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
+		"Name or ID of the machine in the cluster to print the token for. (default is the local machine)")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. Only used with --machine. (default is the current context)")
+
+	return cmd
+}
+
+func showLocalToken(dataDir string, dnsEndpoints []string, decode bool) error {
+	token, err := daemon.MachineToken(dataDir)
+	if err != nil {
+		return fmt.Errorf("get machine token: %w", err)
+	}
+	if err = addTokenDNSEndpoints(&token, dnsEndpoints); err != nil {
+		return err
+	}
+	return printToken(token, decode)
+}
+
+func showMachineToken(ctx context.Context, uncli *cli.CLI, opts tokenShowOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	tokenStr, err := client.MachineToken(ctx, opts.machine)
+	if err != nil {
+		return fmt.Errorf("get machine token: %w", err)
+	}
+	if !opts.decode && len(opts.dnsEndpoints) == 0 {
+		fmt.Println(tokenStr)
+		return nil
+	}
+
+	token, err := machine.ParseToken(tokenStr)
+	if err != nil {
+		return fmt.Errorf("parse machine token: %w", err)
+	}
+	if err = addTokenDNSEndpoints(&token, opts.dnsEndpoints); err != nil {
+		return err
+	}
+	return printToken(token, opts.decode)
+}
+
+// addTokenDNSEndpoints adds the given DNS endpoints to the token using the default WireGuard port if not specified.
+func addTokenDNSEndpoints(token *machine.Token, dnsEndpoints []string) error {
+	for _, ep := range dnsEndpoints {
+		host, port, err := parseDNSEndpoint(ep)
+		if err != nil {
+			return err
+		}
+		ep = net.JoinHostPort(host, strconv.Itoa(int(port)))
+		if !slices.Contains(token.DNSEndpoints, ep) {
+			token.DNSEndpoints = append(token.DNSEndpoints, ep)
+		}
+	}
+	return nil
+}
+
+func newTokenDecodeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode TOKEN",
+		Short: "Decode a machine token and print its fields.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := machine.ParseToken(strings.TrimSpace(args[0]))
 			if err != nil {
-				return fmt.Errorf("encode machine token: %w", err)
+				return fmt.Errorf("parse machine token: %w", err)
 			}
-			fmt.Println(tokenStr)
-			return nil
+			return printDecodedToken(token)
 		},
 	}
+	return cmd
+}
 
-	cmd.Flags().StringVarP(&opts.dataDir, "data-dir", "d", machine.DefaultDataDir,
-		"Directory for storing persistent machine state.")
-	_ = cmd.MarkFlagDirname("data-dir")
+type tokenRotateOptions struct {
+	machine string
+	context string
+}
+
+func newTokenRotateCommand(tokenOpts *tokenOptions) *cobra.Command {
+	opts := tokenRotateOptions{}
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Regenerate the token of the local machine or a machine in the cluster with a new key pair.",
+		Long: `Regenerate the token of the local machine or a machine in the cluster with a new WireGuard key pair.
+
+Tokens printed before the rotation can no longer be used to add the machine to a cluster.
+This is useful, for example, when the machine was created from an image that already contained
+the machine state. Only the token of a machine that is not yet a member of a cluster can be rotated.
+
+Without --machine, the token of the local machine is rotated by the running Uncloud daemon. If the daemon
+isn't running, the key pair is replaced in the local machine state and picked up when the daemon starts.
+With --machine, the token is rotated by the specified machine reachable through the cluster.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.machine == "" {
+				return rotateLocalToken(cmd.Context(), tokenOpts.dataDir)
+			}
+
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return rotateMachineToken(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
+		"Name or ID of the machine in the cluster to rotate the token for. (default is the local machine)")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. Only used with --machine. (default is the current context)")
 
 	return cmd
 }
+
+// rotateLocalToken rotates the token of the local machine through the running daemon so that it uses the new key
+// pair right away. The machine state is only edited directly if the daemon isn't running.
+func rotateLocalToken(ctx context.Context, dataDir string) error {
+	if _, err := os.Stat(machine.DefaultUncloudSockPath); errors.Is(err, os.ErrNotExist) {
+		token, err := daemon.RotateMachineToken(dataDir)
+		if err != nil {
+			return fmt.Errorf("rotate machine token: %w", err)
+		}
+		return printToken(token, false)
+	}
+
+	c, err := client.New(ctx, connector.NewUnixConnector(machine.DefaultUncloudSockPath))
+	if err != nil {
+		return fmt.Errorf("connect to local machine daemon: %w", err)
+	}
+	defer c.Close()
+
+	token, err := c.RotateMachineToken(ctx, "")
+	if err != nil {
+		return fmt.Errorf("rotate machine token: %w", err)
+	}
+	fmt.Println(token)
+	return nil
+}
+
+func rotateMachineToken(ctx context.Context, uncli *cli.CLI, opts tokenRotateOptions) error {
+	c, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	token, err := c.RotateMachineToken(ctx, opts.machine)
+	if err != nil {
+		return fmt.Errorf("rotate machine token: %w", err)
+	}
+	fmt.Println(token)
+	return nil
+}
+
+func printToken(token machine.Token, decode bool) error {
+	if decode {
+		return printDecodedToken(token)
+	}
+
+	tokenStr, err := token.String()
+	if err != nil {
+		return fmt.Errorf("encode machine token: %w", err)
+	}
+	fmt.Println(tokenStr)
+	return nil
+}
+
+func printDecodedToken(token machine.Token) error {
+	publicIP := "-"
+	if token.PublicIP.IsValid() {
+		publicIP = token.PublicIP.String()
+	}
+	endpoints := make([]string, 0, len(token.DNSEndpoints)+len(token.Endpoints))
+	endpoints = append(endpoints, token.DNSEndpoints...)
+	for _, ep := range token.Endpoints {
+		endpoints = append(endpoints, ep.String())
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Version:\t%d\n", token.Version)
+	fmt.Fprintf(tw, "Public key:\t%s\n", token.PublicKey)
+	fmt.Fprintf(tw, "Public IP:\t%s\n", publicIP)
+	fmt.Fprintf(tw, "Endpoints:\t%s\n", strings.Join(endpoints, ", "))
+	return tw.Flush()
+}
@@ -11,9 +11,11 @@ import (
 )
 
 type updateOptions struct {
-	name     string
-	publicIP string
-	context  string
+	name        string
+	publicIP    string
+	hourlyPrice float64
+	provider    string
+	context     string
 }
 
 func NewUpdateCommand() *cobra.Command {
@@ -26,6 +28,7 @@ func NewUpdateCommand() *cobra.Command {
 This command allows setting various machine properties including:
 - Machine name (--name)
 - Public IP address (--public-ip)
+- Hourly price and provider used to estimate the costs with 'uc cost report' (--hourly-price, --provider)
 
 At least one flag must be specified to perform an update operation.`,
 		Args: cobra.ExactArgs(1),
@@ -43,6 +46,14 @@ At least one flag must be specified to perform an update operation.`,
 		&opts.publicIP, "public-ip", "",
 		fmt.Sprintf("Public IP address of the machine for ingress configuration. Use '%s' or '' to remove the public IP.", PublicIPNone),
 	)
+	cmd.Flags().Float64Var(
+		&opts.hourlyPrice, "hourly-price", 0,
+		"Price of running the machine per hour, e.g. 0.05. Use 0 to remove the price.",
+	)
+	cmd.Flags().StringVar(
+		&opts.provider, "provider", "",
+		"Cloud or hosting provider of the machine, e.g. hetzner. Use '' to remove the provider.",
+	)
 	cmd.Flags().StringVarP(
 		&opts.context, "context", "c", "",
 		"Name of the cluster context. (default is the current context)",
@@ -53,8 +64,12 @@ At least one flag must be specified to perform an update operation.`,
 
 func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts updateOptions, machineNameOrID string) error {
 	// Check if at least one flag was explicitly set
-	if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("public-ip") {
-		return fmt.Errorf("at least one update flag must be specified (--name, --public-ip)")
+	costChanged := cmd.Flags().Changed("hourly-price") || cmd.Flags().Changed("provider")
+	if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("public-ip") && !costChanged {
+		return fmt.Errorf("at least one update flag must be specified (--name, --public-ip, --hourly-price, --provider)")
+	}
+	if opts.hourlyPrice < 0 {
+		return fmt.Errorf("hourly price must not be negative")
 	}
 
 	client, err := uncli.ConnectCluster(ctx, opts.context)
@@ -92,6 +107,21 @@ func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts update
 		}
 	}
 
+	if costChanged {
+		// Keep the current cost metadata that isn't updated.
+		req.Cost = &pb.MachineCost{}
+		if current := machine.Machine.Cost; current != nil {
+			req.Cost.HourlyPrice = current.HourlyPrice
+			req.Cost.Provider = current.Provider
+		}
+		if cmd.Flags().Changed("hourly-price") {
+			req.Cost.HourlyPrice = opts.hourlyPrice
+		}
+		if cmd.Flags().Changed("provider") {
+			req.Cost.Provider = opts.provider
+		}
+	}
+
 	// Perform the update operation
 	updatedMachine, err := client.UpdateMachine(ctx, req)
 	if err != nil {
@@ -119,6 +149,11 @@ func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts update
 		changes = append(changes, fmt.Sprintf("public IP: %s -> %s", oldIP, newIP))
 	}
 
+	if costChanged {
+		changes = append(changes, fmt.Sprintf("cost: %s -> %s",
+			formatCost(machine.Machine.Cost), formatCost(updatedMachine.Cost)))
+	}
+
 	fmt.Printf("Machine %q (ID: %s) configuration updated:\n", updatedMachine.Name, updatedMachine.Id)
 	for _, change := range changes {
 		fmt.Printf("  %s\n", change)
@@ -126,3 +161,15 @@ func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts update
 
 	return nil
 }
+
+// formatCost formats the machine cost metadata as the hourly price and provider.
+func formatCost(c *pb.MachineCost) string {
+	if c == nil {
+		return "none"
+	}
+	s := fmt.Sprintf("%g/hour", c.HourlyPrice)
+	if c.Provider != "" {
+		s += " (" + c.Provider + ")"
+	}
+	return s
+}
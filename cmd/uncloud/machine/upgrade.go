@@ -0,0 +1,138 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// upgradeTimeout is how long to wait for a machine daemon to restart with the new version.
+const upgradeTimeout = 3 * time.Minute
+
+type upgradeOptions struct {
+	channel string
+	version string
+	context string
+}
+
+func NewUpgradeCommand() *cobra.Command {
+	opts := upgradeOptions{}
+	cmd := &cobra.Command{
+		Use:   "upgrade [MACHINE...]",
+		Short: "Upgrade the machine daemon on machines to the latest release on the release channel.",
+		Long: `Upgrade the machine daemon (uncloudd) on machines to the latest release on the release channel.
+
+Each machine downloads the daemon binary verified against the release checksums signed with the Uncloud release
+signing key and restarts the daemon to run it. Machines are upgraded one at a time, the next machine starts
+upgrading only after the previous one is running the new version. All available machines are upgraded
+if no machines are specified.
+
+The release channel defaults to the channel saved with 'uc self-update --channel' to keep the CLI and machine
+daemons on the same channel.`,
+		Example: `  # Upgrade all machines to the latest release on the saved or stable channel.
+  uc machine upgrade
+
+  # Upgrade specific machines to the latest beta release.
+  uc machine upgrade machine1 machine2 --channel beta
+
+  # Upgrade all machines to a specific version.
+  uc machine upgrade --version 0.9.0`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return upgrade(cmd.Context(), uncli, opts, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.channel, "channel", "",
+		"Release channel to upgrade from: 'stable' or 'beta'. (default is the saved channel or 'stable')")
+	cmd.Flags().StringVar(&opts.version, "version", "",
+		"Specific version to upgrade to, e.g. 0.9.0. (default is the latest release on the channel)")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func upgrade(ctx context.Context, uncli *cli.CLI, opts upgradeOptions, names []string) error {
+	channel, err := uncli.ReleaseChannel(opts.channel)
+	if err != nil {
+		return err
+	}
+
+	c, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	machines, err := c.Machines(ctx, &api.MachineFilter{NamesOrIDs: names})
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if len(names) > 0 && len(machines) != len(names) {
+		return fmt.Errorf("some of the specified machines not found: %v", names)
+	}
+
+	for _, m := range machines {
+		if !m.Available() {
+			if len(names) > 0 {
+				return fmt.Errorf("machine '%s' is down", m.Name)
+			}
+			client.PrintWarning(fmt.Sprintf("skipping machine '%s' as it's down", m.Name))
+			continue
+		}
+
+		resp, err := c.UpgradeMachine(ctx, m.ID, string(channel), opts.version)
+		if err != nil {
+			return fmt.Errorf("upgrade machine '%s': %w", m.Name, err)
+		}
+		if !resp.Restarting {
+			fmt.Printf("Machine '%s' is already running version %s.\n", m.Name, resp.Version)
+			continue
+		}
+
+		fmt.Printf("Upgrading machine '%s' to version %s...\n", m.Name, resp.Version)
+		if err = waitDaemonVersion(ctx, c, m.ID, resp.Version); err != nil {
+			return fmt.Errorf("upgrade machine '%s': %w", m.Name, err)
+		}
+		fmt.Printf("Machine '%s' upgraded to version %s.\n", m.Name, resp.Version)
+	}
+
+	if opts.channel != "" {
+		return uncli.SaveReleaseChannel(channel)
+	}
+	return nil
+}
+
+// waitDaemonVersion waits until the machine daemon restarts and reports the expected version.
+func waitDaemonVersion(ctx context.Context, c *client.Client, machineID, version string) error {
+	ctx, cancel := context.WithTimeout(ctx, upgradeTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Errors are expected while the daemon is restarting.
+			results, err := c.BatchInspectMachines(ctx, &api.MachineFilter{NamesOrIDs: []string{machineID}})
+			if err == nil && len(results) == 1 && results[0].Err == nil &&
+				results[0].Value.DaemonVersion == version {
+				return nil
+			}
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out waiting for the machine daemon to restart with version %s", version)
+			}
+			return ctx.Err()
+		}
+	}
+}
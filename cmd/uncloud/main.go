@@ -4,14 +4,29 @@ import (
 	"context"
 	"fmt"
 	"net/netip"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
+	"github.com/psviderski/uncloud/cmd/uncloud/audit"
+	"github.com/psviderski/uncloud/cmd/uncloud/backup"
 	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
+	"github.com/psviderski/uncloud/cmd/uncloud/cluster"
 	cmdcontext "github.com/psviderski/uncloud/cmd/uncloud/context"
+	"github.com/psviderski/uncloud/cmd/uncloud/cost"
 	"github.com/psviderski/uncloud/cmd/uncloud/dns"
 	"github.com/psviderski/uncloud/cmd/uncloud/image"
+	"github.com/psviderski/uncloud/cmd/uncloud/ingress"
 	"github.com/psviderski/uncloud/cmd/uncloud/machine"
+	"github.com/psviderski/uncloud/cmd/uncloud/network"
+	"github.com/psviderski/uncloud/cmd/uncloud/postgres"
+	"github.com/psviderski/uncloud/cmd/uncloud/project"
+	"github.com/psviderski/uncloud/cmd/uncloud/quota"
 	"github.com/psviderski/uncloud/cmd/uncloud/service"
+	"github.com/psviderski/uncloud/cmd/uncloud/state"
+	"github.com/psviderski/uncloud/cmd/uncloud/storage"
+	"github.com/psviderski/uncloud/cmd/uncloud/tenant"
 	"github.com/psviderski/uncloud/cmd/uncloud/volume"
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/config"
@@ -23,6 +38,10 @@ import (
 type globalOptions struct {
 	configPath string
 	connect    string
+	// contextGroup is the context group to run the command against all clusters of.
+	contextGroup string
+	quiet        bool
+	noColor      bool
 }
 
 func main() {
@@ -36,6 +55,7 @@ func main() {
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			cli.BindEnvToFlag(cmd, "connect", "UNCLOUD_CONNECT")
 			cli.BindEnvToFlag(cmd, "uncloud-config", "UNCLOUD_CONFIG")
+			cli.BindEnvToFlag(cmd, "context-group", "UNCLOUD_CONTEXT_GROUP")
 
 			var conn *config.MachineConnection
 			if opts.connect != "" {
@@ -63,6 +83,13 @@ func main() {
 			if err != nil {
 				return fmt.Errorf("initialise CLI: %w", err)
 			}
+			uncli.SetOutput(cli.OutputOptions{
+				Quiet:   opts.quiet,
+				NoColor: opts.noColor || os.Getenv("NO_COLOR") != "",
+			})
+			if err = uncli.SetContextGroup(opts.contextGroup); err != nil {
+				return err
+			}
 			cmd.SetContext(context.WithValue(cmd.Context(), "cli", uncli))
 			return nil
 		},
@@ -71,27 +98,67 @@ func main() {
 	cmd.PersistentFlags().StringVar(&opts.connect, "connect", "",
 		"Connect to a remote cluster machine without using the Uncloud configuration file. [$UNCLOUD_CONNECT]\n"+
 			"Format: [ssh://]user@host[:port] or tcp://host:port")
+	cmd.PersistentFlags().StringVar(&opts.contextGroup, "context-group", "",
+		"Run the command against all cluster contexts of the context group defined in 'context_groups'\n"+
+			"of the Uncloud configuration file. Supported by 'service ls' and 'deploy'. [$UNCLOUD_CONTEXT_GROUP]")
 	cmd.PersistentFlags().StringVar(&opts.configPath, "uncloud-config", "~/.config/uncloud/config.yaml",
 		"Path to the Uncloud configuration file. [$UNCLOUD_CONFIG]")
 	_ = cmd.MarkPersistentFlagFilename("uncloud-config", "yaml", "yml")
+	cmd.PersistentFlags().BoolVarP(&opts.quiet, "quiet", "q", false,
+		"Suppress progress and informational output. Prompts, warnings, and errors are still displayed.")
+	cmd.PersistentFlags().BoolVar(&opts.noColor, "no-color", false,
+		"Disable colored output. [$NO_COLOR]")
 	// TODO: make --context a global flag and pass it as a value of the command context.
 
 	cmd.AddCommand(
+		NewApplyCommand(),
+		NewAppCommand(),
 		NewDeployCommand(),
 		NewDocsCommand(),
 		NewBuildCommand(),
+		NewSelfUpdateCommand(),
+		NewVersionCommand(),
+		audit.NewRootCommand(),
+		backup.NewRootCommand(),
 		caddy.NewRootCommand(),
+		cluster.NewRootCommand(),
 		cmdcontext.NewRootCommand(),
+		cost.NewRootCommand(),
 		dns.NewRootCommand(),
 		image.NewRootCommand(),
+		ingress.NewRootCommand(),
 		machine.NewRootCommand(),
+		network.NewRootCommand(),
+		postgres.NewRootCommand(),
+		project.NewRootCommand(),
+		quota.NewRootCommand(),
 		service.NewRootCommand(),
 		service.NewInspectCommand(),
 		service.NewListCommand(),
 		service.NewRmCommand(),
 		service.NewRunCommand(),
 		service.NewScaleCommand(),
+		state.NewRootCommand(),
+		storage.NewRootCommand(),
+		tenant.NewRootCommand(),
 		volume.NewRootCommand(),
 	)
-	cobra.CheckErr(cmd.Execute())
+	cobra.CheckErr(cmd.ExecuteContext(interruptContext()))
+}
+
+// interruptContext returns a context that is cancelled on the first interrupt or termination signal to let
+// the running operation stop gracefully, e.g. save its progress to be resumed later. The second signal terminates
+// the process immediately.
+func interruptContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		fmt.Fprintln(os.Stderr, "\nInterrupting... Press Ctrl-C again to force exit.")
+		cancel()
+		<-sigs
+		os.Exit(130)
+	}()
+	return ctx
 }
@@ -0,0 +1,188 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewEgressCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "egress",
+		Short: "Manage the gateway machine for the outbound internet traffic of services.",
+		Long: `Manage the gateway machine for the outbound internet traffic of services.
+By default, service containers access the internet from the machines they run on. With an egress gateway,
+the outbound internet traffic of all or selected services is routed through the gateway machine over the cluster
+network, so it comes from the public IP of the gateway machine, e.g. a static IP allowlisted by a third party.
+The traffic within the cluster network isn't affected. The routed traffic is dropped while the gateway machine
+is unavailable.`,
+	}
+	cmd.AddCommand(
+		newEgressSetCommand(),
+		newEgressShowCommand(),
+		newEgressUnsetCommand(),
+	)
+	return cmd
+}
+
+type egressSetOptions struct {
+	gateway  string
+	services []string
+	context  string
+}
+
+func newEgressSetCommand() *cobra.Command {
+	opts := egressSetOptions{}
+	cmd := &cobra.Command{
+		Use:   "set MACHINE",
+		Short: "Route the outbound internet traffic of services through a gateway machine.",
+		Example: `  # Route the outbound traffic of all services through machine 'gw'.
+  uc network egress set gw
+
+  # Route the outbound traffic of only the 'api' and 'worker' services through machine 'gw'.
+  uc network egress set gw --service api,worker`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.gateway = args[0]
+			return setEgress(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&opts.services, "service", "s", nil,
+		"Name of the service whose outbound traffic is routed through the gateway. Can be specified multiple times "+
+			"or as a comma-separated list of service names. (default is all services)")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newEgressShowCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the egress gateway machine and the services routed through it.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return showEgress(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func newEgressUnsetCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Remove the egress gateway so services access the internet from their own machines.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return updateEgress(cmd.Context(), uncli, contextName, nil)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func setEgress(ctx context.Context, uncli *cli.CLI, opts egressSetOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	machine, err := client.InspectMachine(ctx, opts.gateway)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("machine '%s' not found", opts.gateway)
+		}
+		return fmt.Errorf("inspect machine: %w", err)
+	}
+
+	policy := &api.EgressPolicy{
+		Gateway:  machine.Machine.Id,
+		Services: cli.ExpandCommaSeparatedValues(opts.services),
+	}
+	return updateEgressWithClient(ctx, client, policy)
+}
+
+func showEgress(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	if settings.Egress == nil {
+		fmt.Println("No egress gateway set. Services access the internet from their own machines.")
+		return nil
+	}
+
+	gateway := settings.Egress.Gateway
+	if machine, err := client.InspectMachine(ctx, gateway); err == nil {
+		gateway = fmt.Sprintf("%s (%s)", machine.Machine.Name, machine.Machine.Id)
+	} else if errors.Is(err, api.ErrNotFound) {
+		gateway += " (machine not found, the routed traffic is dropped)"
+	}
+	services := "all"
+	if len(settings.Egress.Services) > 0 {
+		services = strings.Join(settings.Egress.Services, ", ")
+	}
+
+	fmt.Printf("Gateway:  %s\n", gateway)
+	fmt.Printf("Services: %s\n", services)
+	return nil
+}
+
+func updateEgress(ctx context.Context, uncli *cli.CLI, contextName string, policy *api.EgressPolicy) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	return updateEgressWithClient(ctx, client, policy)
+}
+
+// updateEgressWithClient stores the egress policy in the cluster settings unless they have been changed
+// concurrently. A nil policy removes the egress gateway.
+func updateEgressWithClient(ctx context.Context, clusterClient *client.Client, policy *api.EgressPolicy) error {
+	settings, err := clusterClient.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster settings: %w", err)
+	}
+	settings.Egress = policy
+	if err = settings.Validate(); err != nil {
+		return err
+	}
+
+	if _, err = clusterClient.SetSettings(ctx, settings); err != nil {
+		return fmt.Errorf("set cluster settings: %w", err)
+	}
+	if policy == nil {
+		fmt.Println("Egress gateway removed.")
+	} else {
+		fmt.Println("Egress gateway updated. The machines apply the new routing within a few seconds.")
+	}
+	return nil
+}
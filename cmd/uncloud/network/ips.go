@@ -0,0 +1,125 @@
+package network
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/spf13/cobra"
+)
+
+// ipAllocation is an address or range in the cluster network and what it's allocated to.
+type ipAllocation struct {
+	prefix  netip.Prefix
+	kind    string
+	owner   string
+	details string
+}
+
+func NewIPsCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "ips",
+		Short: "Show the address plan of the cluster network and what the subnets and IPs are allocated to.",
+		Long: `Show the address plan of the cluster network and what the subnets and IPs are allocated to.
+Each machine is allocated a subnet from the cluster network. The machine and its service containers get IPs from
+the machine subnet. Reserved ranges aren't allocated to new machines.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return showIPs(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func showIPs(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	clusterNetwork, err := client.ClusterNetwork(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster network: %w", err)
+	}
+	machines, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	reservations, err := client.ListIPReservations(ctx)
+	if err != nil {
+		return fmt.Errorf("list IP reservations: %w", err)
+	}
+	services, err := client.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+
+	var allocations []ipAllocation
+	machineNames := make(map[string]string, len(machines))
+	for _, m := range machines {
+		machineNames[m.Machine.Id] = m.Machine.Name
+		subnet, err := m.Machine.Network.Subnet.ToPrefix()
+		if err != nil {
+			continue
+		}
+		allocations = append(allocations,
+			ipAllocation{prefix: subnet, kind: "subnet", owner: m.Machine.Name},
+			ipAllocation{
+				prefix: netip.PrefixFrom(network.MachineIP(subnet), 32),
+				kind:   "machine",
+				owner:  m.Machine.Name,
+			},
+		)
+	}
+	for _, svc := range services {
+		for _, ctr := range svc.Containers {
+			ip := ctr.Container.UncloudNetworkIP()
+			if !ip.IsValid() {
+				continue
+			}
+			allocations = append(allocations, ipAllocation{
+				prefix:  netip.PrefixFrom(ip, ip.BitLen()),
+				kind:    "container",
+				owner:   strings.TrimPrefix(ctr.Container.Name, "/"),
+				details: "machine " + cmp.Or(machineNames[ctr.MachineID], ctr.MachineID),
+			})
+		}
+	}
+	for _, r := range reservations {
+		allocations = append(allocations, ipAllocation{
+			prefix:  r.Prefix,
+			kind:    "reserved",
+			details: r.Description,
+		})
+	}
+	// Sort by address with the enclosing ranges before the addresses within them.
+	slices.SortFunc(allocations, func(a, b ipAllocation) int {
+		return cmp.Or(a.prefix.Addr().Compare(b.prefix.Addr()), cmp.Compare(a.prefix.Bits(), b.prefix.Bits()))
+	})
+
+	fmt.Printf("Cluster network: %s\n\n", clusterNetwork)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ADDRESS\tTYPE\tOWNER\tDETAILS")
+	for _, a := range allocations {
+		addr := a.prefix.String()
+		if a.prefix.IsSingleIP() {
+			addr = a.prefix.Addr().String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", addr, a.kind, a.owner, a.details)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,370 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/spf13/cobra"
+)
+
+// renumberTimeout is the maximum time to wait for a machine daemon to restart with the new subnet.
+const renumberTimeout = 3 * time.Minute
+
+type migrateOptions struct {
+	to       string
+	rollback bool
+	yes      bool
+	context  string
+}
+
+func NewMigrateCommand() *cobra.Command {
+	opts := migrateOptions{}
+	cmd := &cobra.Command{
+		Use:   "migrate --to CIDR",
+		Short: "Migrate the cluster network to a new IP range.",
+		Long: `Migrate the cluster network to a new IP range, e.g. when the current range collides with a VPN.
+
+The migration renumbers the machines one by one and then recreates the service containers:
+  1. Each machine subnet is moved to the same offset within the new network, e.g. 10.210.3.0/24 in 10.210.0.0/16
+     becomes 172.16.3.0/24 in 172.16.0.0/16. The machine daemon restarts to apply its new subnet and the containers
+     on the machine are reconnected to get new IPs. Other machines route the new subnet to it within seconds.
+  2. The service containers are recreated with a rolling update to use the new machine IPs for the internal DNS.
+  3. The cluster network is changed to the new one.
+
+The progress is recorded in the cluster after each step. If the migration is interrupted, run the same command again
+to resume it or run it with --rollback to renumber the migrated machines and containers back. Adding machines
+to the cluster is blocked while the migration is in progress. Expect brief connectivity interruptions between
+the containers on different machines while they are being renumbered.`,
+		Example: `  # Migrate the cluster network to 172.16.0.0/16.
+  uc network migrate --to 172.16.0.0/16
+
+  # Roll back the interrupted migration.
+  uc network migrate --rollback`,
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			if opts.rollback == (opts.to != "") {
+				return errors.New("either --to or --rollback must be specified")
+			}
+			return migrate(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.to, "to", "", "New IP range of the cluster network in CIDR notation.")
+	cmd.Flags().BoolVar(&opts.rollback, "rollback", false,
+		"Roll back the migration in progress by renumbering the migrated machines and containers back.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Auto-confirm the migration plan. Should be explicitly set when running non-interactively. "+
+			"[$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func migrate(ctx context.Context, uncli *cli.CLI, opts migrateOptions) error {
+	c, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	migration, err := c.GetNetworkMigration(ctx)
+	inProgress := err == nil
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("get network migration: %w", err)
+	}
+
+	if opts.rollback {
+		if !inProgress {
+			return errors.New("no network migration in progress to roll back")
+		}
+		return rollbackMigration(ctx, uncli, c, migration)
+	}
+
+	to, err := netip.ParsePrefix(opts.to)
+	if err != nil {
+		return fmt.Errorf("invalid network '%s': must be a CIDR, e.g. 172.16.0.0/16", opts.to)
+	}
+	if inProgress {
+		if migration.State == api.NetworkMigrationRollingBack {
+			return fmt.Errorf("network migration from %s to %s is being rolled back, "+
+				"run 'uc network migrate --rollback' to finish the rollback", migration.From, migration.To)
+		}
+		if migration.To != to {
+			return fmt.Errorf("network migration from %s to %s is in progress, resume it with --to %s "+
+				"or roll it back with --rollback", migration.From, migration.To, migration.To)
+		}
+		fmt.Printf("Resuming network migration from %s to %s.\n", migration.From, migration.To)
+	} else {
+		if migration, err = planMigration(ctx, c, to); err != nil {
+			return err
+		}
+		if migration.From == to {
+			fmt.Printf("Cluster network is already %s.\n", to)
+			return nil
+		}
+		printMigrationPlan(migration)
+
+		if !opts.yes {
+			if !cli.IsStdinTerminal() {
+				return errors.New("cannot ask to confirm migration plan in non-interactive mode, " +
+					"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+			}
+			confirmed, err := cli.Confirm()
+			if err != nil {
+				return fmt.Errorf("confirm migration: %w", err)
+			}
+			if !confirmed {
+				fmt.Println("Cancelled. No changes were made.")
+				return nil
+			}
+		}
+
+		migration.StartedAt = time.Now().UTC()
+		if err = c.SetNetworkMigration(ctx, migration); err != nil {
+			return fmt.Errorf("start network migration: %w", err)
+		}
+	}
+
+	if err = runMigration(ctx, uncli, c, &migration, false); err != nil {
+		return fmt.Errorf("%w\n\nRun the same command to resume the migration "+
+			"or 'uc network migrate --rollback' to roll it back", err)
+	}
+	fmt.Printf("Cluster network migrated from %s to %s.\n", migration.From, migration.To)
+	return nil
+}
+
+// planMigration plans the migration of all machines to the new network. The machine the client is connected to is
+// renumbered last so the connection is interrupted only once.
+func planMigration(ctx context.Context, c *client.Client, to netip.Prefix) (api.NetworkMigration, error) {
+	from, err := c.ClusterNetwork(ctx)
+	if err != nil {
+		return api.NetworkMigration{}, fmt.Errorf("get cluster network: %w", err)
+	}
+	if from == to {
+		return api.NetworkMigration{From: from, To: to}, nil
+	}
+	machines, err := c.ListMachines(ctx, nil)
+	if err != nil {
+		return api.NetworkMigration{}, fmt.Errorf("list machines: %w", err)
+	}
+	proxyMachine, err := c.MachineClient.Inspect(ctx, nil)
+	if err != nil {
+		return api.NetworkMigration{}, fmt.Errorf("inspect connected machine: %w", err)
+	}
+
+	renumberings := make([]api.MachineRenumbering, 0, len(machines))
+	for _, m := range machines {
+		if m.State == pb.MachineMember_DOWN {
+			return api.NetworkMigration{}, fmt.Errorf("machine '%s' is down, all machines must be up to migrate "+
+				"the cluster network", m.Machine.Name)
+		}
+		subnet, err := m.Machine.Network.Subnet.ToPrefix()
+		if err != nil {
+			return api.NetworkMigration{}, fmt.Errorf("invalid subnet of machine '%s': %w", m.Machine.Name, err)
+		}
+		renumberings = append(renumberings, api.MachineRenumbering{
+			MachineID:   m.Machine.Id,
+			MachineName: m.Machine.Name,
+			From:        subnet,
+		})
+	}
+	slices.SortStableFunc(renumberings, func(a, b api.MachineRenumbering) int {
+		if (a.MachineID == proxyMachine.Id) != (b.MachineID == proxyMachine.Id) {
+			if a.MachineID == proxyMachine.Id {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.MachineName, b.MachineName)
+	})
+
+	return api.PlanNetworkMigration(from, to, renumberings)
+}
+
+func printMigrationPlan(m api.NetworkMigration) {
+	fmt.Printf("Network migration plan from %s to %s:\n", m.From, m.To)
+	for i, r := range m.Machines {
+		fmt.Printf("  %d. Renumber machine '%s': %s → %s\n", i+1, r.MachineName, r.From, r.To)
+	}
+	fmt.Printf("  %d. Recreate service containers to use the new machine IPs\n", len(m.Machines)+1)
+	fmt.Printf("  %d. Change the cluster network to %s\n", len(m.Machines)+2, m.To)
+	fmt.Println()
+}
+
+func rollbackMigration(ctx context.Context, uncli *cli.CLI, c *client.Client, m api.NetworkMigration) error {
+	fmt.Printf("Rolling back network migration from %s to %s.\n", m.From, m.To)
+	if m.State != api.NetworkMigrationRollingBack {
+		m.State = api.NetworkMigrationRollingBack
+		// The containers need to be recreated again if any of them have been recreated or reconnected to a network
+		// with the new subnet.
+		m.ContainersRenumbered = false
+		if err := c.SetNetworkMigration(ctx, m); err != nil {
+			return fmt.Errorf("start network migration rollback: %w", err)
+		}
+	}
+
+	if err := runMigration(ctx, uncli, c, &m, true); err != nil {
+		return fmt.Errorf("%w\n\nRun 'uc network migrate --rollback' again to resume the rollback", err)
+	}
+	fmt.Printf("Network migration rolled back, the cluster network is %s.\n", m.From)
+	return nil
+}
+
+// runMigration renumbers the machines that haven't been renumbered yet one by one, recreates the service containers,
+// and finalises the migration recording the progress after each step. If rollback is true, the renumbered machines
+// are renumbered back to their original subnets in the reverse order instead.
+func runMigration(ctx context.Context, uncli *cli.CLI, c *client.Client, m *api.NetworkMigration, rollback bool) error {
+	order := make([]int, len(m.Machines))
+	for i := range order {
+		order[i] = i
+	}
+	if rollback {
+		slices.Reverse(order)
+	}
+
+	for _, i := range order {
+		r := &m.Machines[i]
+		// Machines not renumbered yet during the migration or already renumbered back during the rollback.
+		if r.Renumbered == rollback {
+			subnet := r.To
+			if rollback {
+				subnet = r.From
+			}
+			fmt.Printf("Renumbering machine '%s' to subnet %s...\n", r.MachineName, subnet)
+			if err := renumberMachine(ctx, c, r.MachineID, subnet); err != nil {
+				return fmt.Errorf("renumber machine '%s': %w", r.MachineName, err)
+			}
+			r.Renumbered = !rollback
+			if err := c.SetNetworkMigration(ctx, *m); err != nil {
+				return fmt.Errorf("record network migration progress: %w", err)
+			}
+			fmt.Printf("Machine '%s' renumbered to subnet %s.\n", r.MachineName, subnet)
+		}
+	}
+
+	if !m.ContainersRenumbered {
+		if err := recreateServiceContainers(ctx, uncli, c); err != nil {
+			return err
+		}
+		m.ContainersRenumbered = true
+		if err := c.SetNetworkMigration(ctx, *m); err != nil {
+			return fmt.Errorf("record network migration progress: %w", err)
+		}
+	}
+
+	m.State = api.NetworkMigrationCompleted
+	if rollback {
+		m.State = api.NetworkMigrationRolledBack
+	}
+	if err := c.SetNetworkMigration(ctx, *m); err != nil {
+		return fmt.Errorf("finalise network migration: %w", err)
+	}
+	return nil
+}
+
+// renumberMachine changes the subnet of the machine and waits until its daemon restarts to apply it.
+func renumberMachine(ctx context.Context, c *client.Client, machineID string, subnet netip.Prefix) error {
+	// The machine may have already been renumbered if the previous attempt was interrupted before recording it.
+	m, err := c.InspectMachine(ctx, machineID)
+	if err != nil {
+		return fmt.Errorf("inspect machine: %w", err)
+	}
+	if current, err := m.Machine.Network.Subnet.ToPrefix(); err == nil && current == subnet {
+		return nil
+	}
+
+	startedAt, err := daemonStartedAt(ctx, c, machineID)
+	if err != nil {
+		return err
+	}
+	if err = c.RenumberMachineSubnet(ctx, machineID, subnet); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, renumberTimeout)
+	defer cancel()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Errors are expected while the daemon is restarting.
+			if restartedAt, err := daemonStartedAt(ctx, c, machineID); err == nil && restartedAt.After(startedAt) {
+				return nil
+			}
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return errors.New("timed out waiting for the machine daemon to restart with the new subnet")
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+func daemonStartedAt(ctx context.Context, c *client.Client, machineID string) (time.Time, error) {
+	results, err := c.BatchInspectMachines(ctx, &api.MachineFilter{NamesOrIDs: []string{machineID}})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(results) != 1 {
+		return time.Time{}, api.ErrNotFound
+	}
+	if results[0].Err != nil {
+		return time.Time{}, results[0].Err
+	}
+	if results[0].Value.DaemonStartedAt.IsZero() {
+		return time.Time{}, errors.New("machine daemon doesn't support network migration, upgrade it first")
+	}
+	return results[0].Value.DaemonStartedAt, nil
+}
+
+// recreateServiceContainers recreates the containers of all services except the trashed ones with a rolling update
+// so they get IPs from the current machine subnets and use the current machine IPs as their DNS servers.
+func recreateServiceContainers(ctx context.Context, uncli *cli.CLI, c *client.Client) error {
+	services, err := c.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+	trashed, err := c.ListTrashedServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list trashed services: %w", err)
+	}
+
+	for _, svc := range services {
+		if slices.ContainsFunc(trashed, func(t api.TrashedService) bool { return t.ContainsService(svc) }) {
+			continue
+		}
+		spec, ok := svc.Spec()
+		if !ok {
+			continue
+		}
+
+		deployment := c.NewDeployment(spec, &deploy.RollingStrategy{ForceRecreate: true})
+		deployment.Service = &svc
+		title := fmt.Sprintf("Recreating containers of service %s", svc.Name)
+		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			_, err := deployment.Run(ctx)
+			return err
+		}, uncli.ProgressOut(), title)
+		if err != nil {
+			return fmt.Errorf("recreate containers of service '%s': %w", svc.Name, err)
+		}
+	}
+	return nil
+}
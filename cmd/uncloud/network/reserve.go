@@ -0,0 +1,115 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type reserveOptions struct {
+	description string
+	context     string
+}
+
+func NewReserveCommand() *cobra.Command {
+	opts := reserveOptions{}
+	cmd := &cobra.Command{
+		Use:   "reserve CIDR",
+		Short: "Reserve an IP range in the cluster network so it isn't allocated to new machines.",
+		Long: `Reserve an IP range in the cluster network so it isn't allocated to new machines, e.g. to avoid conflicts
+with an existing VPN that uses the same addresses. The range must not overlap the subnets already allocated
+to machines.`,
+		Example: `  # Reserve the addresses used by the office VPN.
+  uc network reserve 10.210.200.0/24 --description "office VPN"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return reserve(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.description, "description", "d", "",
+		"Description of why the range is reserved.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func NewReleaseCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "release CIDR",
+		Short: "Release a reserved IP range so it can be allocated to new machines.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return release(cmd.Context(), uncli, args[0], contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func reserve(ctx context.Context, uncli *cli.CLI, cidr string, opts reserveOptions) error {
+	prefix, err := parseIPRange(cidr)
+	if err != nil {
+		return err
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	reservation := api.IPReservation{Prefix: prefix, Description: opts.description}
+	if err = client.ReserveIPRange(ctx, reservation); err != nil {
+		return fmt.Errorf("reserve IP range: %w", err)
+	}
+	fmt.Printf("IP range %s reserved.\n", prefix)
+	return nil
+}
+
+func release(ctx context.Context, uncli *cli.CLI, cidr, contextName string) error {
+	prefix, err := parseIPRange(cidr)
+	if err != nil {
+		return err
+	}
+
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if err = client.ReleaseIPRange(ctx, prefix); err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("IP range %s is not reserved", prefix)
+		}
+		return fmt.Errorf("release IP range: %w", err)
+	}
+	fmt.Printf("IP range %s released.\n", prefix)
+	return nil
+}
+
+// parseIPRange parses an IP range in CIDR notation or a single IP address.
+func parseIPRange(s string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP range '%s': must be a CIDR, e.g. 10.210.200.0/24, "+
+			"or an IP address", s)
+	}
+	return prefix, nil
+}
@@ -0,0 +1,20 @@
+package network
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Manage the cluster network.",
+	}
+	cmd.AddCommand(
+		NewEgressCommand(),
+		NewIPsCommand(),
+		NewMigrateCommand(),
+		NewReleaseCommand(),
+		NewReserveCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	name           string
+	image          string
+	machines       []string
+	backup         bool
+	backupInterval time.Duration
+	overrideFreeze string
+	yes            bool
+	context        string
+}
+
+func NewCreateCommand() *cobra.Command {
+	opts := createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a replicated PostgreSQL database.",
+		Long: `Create a replicated PostgreSQL database on two machines.
+
+The primary server runs on the first machine and a hot standby on the second one. The data is stored
+in the '<name>-data' volume on each machine. Random passwords are generated for the 'postgres' superuser
+and the replication user.`,
+		Example: `  # Create the 'db' Postgres cluster with the primary on machine1 and the standby on machine2.
+  uc pg create db -m machine1,machine2
+
+  # Create the 'db' Postgres cluster backed up to the backup storage every 6 hours.
+  uc pg create db -m machine1,machine2 --backup --backup-interval 6h`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.name = args[0]
+			return create(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.image, "image", api.DefaultPostgresImage,
+		"Official PostgreSQL Docker image or an image based on it to run.")
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Names or IDs of the two machines to run the primary and the standby on. Can be specified "+
+			"multiple times or as a comma-separated list of machine names.")
+	cmd.Flags().BoolVar(&opts.backup, "backup", false,
+		"Back up the database to the backup storage configured with 'uc backup storage set'.")
+	cmd.Flags().DurationVar(&opts.backupInterval, "backup-interval", api.DefaultBackupInterval,
+		"Interval between the backups if --backup is set.")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Create the database even if deploys are frozen with 'uc cluster freeze'. "+
+			"The reason is recorded in the audit log.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before creating the database.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	_ = cmd.MarkFlagRequired("machine")
+
+	return cmd
+}
+
+func create(ctx context.Context, uncli *cli.CLI, opts createOptions) error {
+	machines := cli.ExpandCommaSeparatedValues(opts.machines)
+	if len(machines) != 2 {
+		return errors.New("exactly two machines must be specified with --machine")
+	}
+
+	createOpts := client.CreatePostgresOptions{
+		Name:     opts.name,
+		Image:    opts.image,
+		Machines: machines,
+	}
+	if opts.backup {
+		createOpts.Backup = &api.BackupSpec{
+			Engine:   api.BackupEnginePostgres,
+			Interval: opts.backupInterval,
+		}
+		if err := createOpts.Backup.Validate(); err != nil {
+			return fmt.Errorf("invalid backup: %w", err)
+		}
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	operation := "create postgres cluster " + opts.name
+	if ctx, err = clusterClient.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return err
+	}
+
+	if !opts.yes {
+		fmt.Printf("This will create the Postgres cluster '%s' (%s) with the primary on machine '%s' "+
+			"and the standby on machine '%s'.\n", opts.name, opts.image, machines[0], machines[1])
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm creation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	var c api.PostgresCluster
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		c, err = clusterClient.CreatePostgres(ctx, createOpts)
+		return err
+	}, uncli.ProgressOut(), "Creating Postgres cluster "+opts.name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("Postgres cluster '%s' is available at %s:%d within the cluster.\n", c.Name, c.Host(), api.PostgresPort)
+	fmt.Printf("Mount the external secret '%s' into services to connect to it. See 'uc pg --help'.\n",
+		c.SecretName())
+	return nil
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	context string
+}
+
+func NewListCommand() *cobra.Command {
+	opts := listOptions{}
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List replicated PostgreSQL databases.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	clusters, err := client.ListPostgresClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("list postgres clusters: %w", err)
+	}
+	if len(clusters) == 0 {
+		fmt.Println("No Postgres clusters found.")
+		return nil
+	}
+
+	machines, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	machineName := func(id string) string {
+		if m := machines.FindByNameOrID(id); m != nil {
+			return m.Machine.Name
+		}
+		return id
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err = fmt.Fprintln(tw, "NAME\tIMAGE\tPRIMARY\tSTANDBY\tLAST FAILOVER"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, c := range clusters {
+		failover := "-"
+		if !c.FailedOverAt.IsZero() {
+			failover = units.HumanDuration(time.Since(c.FailedOverAt)) + " ago"
+		}
+		if _, err = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			c.Name, c.Image, machineName(c.Primary), machineName(c.Standby()), failover); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	return tw.Flush()
+}
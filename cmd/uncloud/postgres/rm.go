@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type rmOptions struct {
+	name           string
+	overrideFreeze string
+	yes            bool
+	context        string
+}
+
+func NewRmCommand() *cobra.Command {
+	opts := rmOptions{}
+	cmd := &cobra.Command{
+		Use:     "rm NAME",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove a replicated PostgreSQL database.",
+		Long: "Remove the service and the connection secret of a replicated PostgreSQL database. " +
+			"The '<name>-data' volumes with the data are kept and can be removed with 'uc volume rm'.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.name = args[0]
+			return rm(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Remove the database even if deploys are frozen with 'uc cluster freeze'. "+
+			"The reason is recorded in the audit log.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before removing the database.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func rm(ctx context.Context, uncli *cli.CLI, opts rmOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if _, err = client.GetPostgresCluster(ctx, opts.name); err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("postgres cluster '%s' not found", opts.name)
+		}
+		return fmt.Errorf("get postgres cluster: %w", err)
+	}
+	if ctx, err = client.CheckDeployFreeze(ctx, "remove postgres cluster "+opts.name, opts.overrideFreeze); err != nil {
+		return err
+	}
+
+	if !opts.yes {
+		fmt.Printf("This will remove the Postgres cluster '%s'. Services using its secret will fail to deploy.\n",
+			opts.name)
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm removal: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	if err = client.RemovePostgres(ctx, opts.name); err != nil {
+		return err
+	}
+	fmt.Printf("Postgres cluster '%s' removed. Its data volumes '%s-data' are kept.\n", opts.name, opts.name)
+	return nil
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pg",
+		Aliases: []string{"postgres"},
+		Short:   "Manage replicated PostgreSQL databases running in the cluster.",
+		Long: `Manage replicated PostgreSQL databases running in the cluster.
+
+A managed Postgres cluster runs a primary server on one machine and a hot standby replicating from it
+with streaming replication on another. The internal DNS name <name>.internal always resolves to the primary.
+If the machine running the primary is down for 30 seconds, the standby is promoted to the primary
+and the DNS name is switched to it. The old primary rejoins as a standby when it's back and its previous
+data is kept in a pgdata.old-<time> directory in the data volume.
+
+To prevent two primaries from running at the same time, the standby is promoted only if its machine sees
+the majority of the cluster machines up, and the primary is stopped if its machine loses the majority.
+This requires at least three machines in the cluster for the automated failover.
+
+Services get the connection details from the external Compose secret 'uncloud-postgres-<name>' mounted as
+/run/secrets/uncloud-postgres-<name>. The secret contains the DATABASE_URL, PGHOST, PGPORT, PGUSER,
+and PGPASSWORD variables.`,
+		Example: `  # Connect the 'app' service to the 'db' Postgres cluster (compose.yaml).
+  services:
+    app:
+      image: app
+      secrets:
+        - uncloud-postgres-db
+  secrets:
+    uncloud-postgres-db:
+      external: true`,
+	}
+	cmd.AddCommand(
+		NewCreateCommand(),
+		NewListCommand(),
+		NewRmCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,140 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+type downOptions struct {
+	files   []string
+	project string
+	volumes bool
+	dryRun  bool
+	yes     bool
+	context string
+}
+
+func NewDownCommand() *cobra.Command {
+	opts := downOptions{}
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Remove the services deployed from a Compose file.",
+		Long: `Remove the services deployed from a Compose file.
+
+The services of the project and the services defined in the Compose file that were deployed without a project
+are removed in the reverse dependency order, so a service is removed before the services it depends on.
+The service networks are not separate cluster resources and go away with the services. Named volumes are kept
+unless --volumes is specified.
+
+If --project is specified without --file, the services of the project are removed without reading a Compose file.`,
+		Example: `  # Remove the services deployed from compose.yaml in the current directory.
+  uc project down
+
+  # Preview the removal of the services and volumes deployed from app.compose.yaml.
+  uc project down -f app.compose.yaml --volumes --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return down(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
+		"One or more Compose files the services were deployed from. (default compose.yaml)")
+	cmd.Flags().StringVar(&opts.project, "project", "",
+		"Project name of the services to remove. (default is the top-level 'name' in the Compose file\n"+
+			"or the project directory name)")
+	cmd.Flags().BoolVar(&opts.volumes, "volumes", false,
+		"Also remove the named volumes declared in the Compose file. External volumes are not removed.")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false,
+		"Print the removal plan without removing anything.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before removing the services. [$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+
+	return cmd
+}
+
+func down(ctx context.Context, uncli *cli.CLI, opts downOptions) error {
+	project, err := loadProject(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	plan, err := compose.PlanDown(ctx, client, project, compose.DownOptions{Volumes: opts.volumes})
+	if err != nil {
+		return fmt.Errorf("plan removal: %w", err)
+	}
+	if len(plan.Operations) == 0 {
+		fmt.Printf("No services of project '%s' found.\n", project.Name)
+		return nil
+	}
+
+	fmt.Printf("Removal plan for project '%s':\n", project.Name)
+	fmt.Fprintln(uncli.Output.Writer(), plan.Format(nil))
+	if opts.dryRun {
+		return nil
+	}
+	fmt.Println()
+
+	if !opts.yes {
+		if !cli.IsStdinTerminal() {
+			return errors.New("cannot ask to confirm removal plan in non-interactive mode, " +
+				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+		}
+
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm removal: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	return progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		if err := plan.Execute(ctx, client); err != nil {
+			return fmt.Errorf("remove services: %w", err)
+		}
+		return nil
+	}, uncli.ProgressOut(), "Removing project "+project.Name)
+}
+
+// loadProject loads the Compose project the services were deployed from. An empty project with the specified name
+// is returned if only the project name is specified.
+func loadProject(ctx context.Context, opts downOptions) (*types.Project, error) {
+	if opts.project != "" {
+		if err := api.ValidateProjectName(opts.project); err != nil {
+			return nil, err
+		}
+		if len(opts.files) == 0 {
+			return &types.Project{Name: opts.project}, nil
+		}
+	}
+
+	project, err := compose.LoadProject(ctx, opts.files)
+	if err != nil {
+		return nil, fmt.Errorf("load compose file(s): %w", err)
+	}
+	if opts.project != "" {
+		project.Name = opts.project
+	}
+	return project, nil
+}
@@ -0,0 +1,69 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List projects.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	services, err := client.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+
+	serviceNames := make(map[string][]string)
+	for _, s := range services {
+		if s.Project != "" {
+			serviceNames[s.Project] = append(serviceNames[s.Project], s.Name)
+		}
+	}
+	projects := make([]string, 0, len(serviceNames))
+	for p := range serviceNames {
+		projects = append(projects, p)
+	}
+	slices.Sort(projects)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err = fmt.Fprintln(tw, "NAME\tSERVICES"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, p := range projects {
+		names := serviceNames[p]
+		slices.Sort(names)
+		if _, err = fmt.Fprintf(tw, "%s\t%s\n", p, strings.Join(names, ", ")); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	return tw.Flush()
+}
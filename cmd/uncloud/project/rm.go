@@ -0,0 +1,87 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	yes     bool
+	context string
+}
+
+func NewRemoveCommand() *cobra.Command {
+	opts := removeOptions{}
+	cmd := &cobra.Command{
+		Use:     "rm PROJECT",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove all services of a project.",
+		Long: "Remove all services of a project. The volumes used by the services are not removed and can be " +
+			"removed with 'uc volume rm'.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return remove(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before removing the project services.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+
+	return cmd
+}
+
+func remove(ctx context.Context, uncli *cli.CLI, project string, opts removeOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	services, err := client.ListServicesFiltered(ctx, &api.ServiceFilter{Project: project})
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+	if len(services) == 0 {
+		return fmt.Errorf("project '%s' not found", project)
+	}
+
+	if !opts.yes {
+		fmt.Printf("The following services of project '%s' will be removed:\n", project)
+		for _, s := range services {
+			fmt.Printf(" • %s\n", s.Name)
+		}
+
+		fmt.Println()
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm removal: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No services were removed.")
+			return nil
+		}
+	}
+
+	for _, s := range services {
+		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			// Remove by ID as services of different projects may have the same name.
+			if err = client.RemoveService(ctx, s.ID); err != nil {
+				return fmt.Errorf("remove service '%s': %w", s.Name, err)
+			}
+			return nil
+		}, uncli.ProgressOut(), "Removing service "+s.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
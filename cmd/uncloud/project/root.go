@@ -0,0 +1,23 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Manage projects in an Uncloud cluster.",
+		Long: `Manage projects in an Uncloud cluster.
+
+A project groups the services deployed together from a Compose file with 'uc deploy'. The project name is taken
+from the --project flag, COMPOSE_PROJECT_NAME environment variable, the top-level 'name' in the Compose file,
+or the name of the project directory, in that order.`,
+	}
+	cmd.AddCommand(
+		NewDownCommand(),
+		NewListCommand(),
+		NewRemoveCommand(),
+	)
+	return cmd
+}
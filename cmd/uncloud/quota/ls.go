@@ -0,0 +1,82 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List the project quotas with the current usage.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	quotas, err := client.ListProjectQuotas(ctx)
+	if err != nil {
+		return fmt.Errorf("list project quotas: %w", err)
+	}
+	if len(quotas) == 0 {
+		fmt.Println("No project quotas found. Set one with 'uc quota set'.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "PROJECT\tSERVICES\tCPU\tMEMORY\tDOMAINS")
+	for _, q := range quotas {
+		services, err := client.ListServicesFiltered(ctx, &api.ServiceFilter{Project: q.Project})
+		if err != nil {
+			return fmt.Errorf("list services of project '%s': %w", q.Project, err)
+		}
+		var usage api.ProjectUsage
+		for _, s := range services {
+			usage.AddDeployedService(s)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			q.Project,
+			formatUsage(strconv.Itoa(usage.Services), q.Services > 0, strconv.Itoa(q.Services)),
+			formatUsage(formatCPU(usage.CPU), q.CPU > 0, formatCPU(q.CPU)),
+			formatUsage(units.BytesSize(float64(usage.Memory)), q.Memory > 0, units.BytesSize(float64(q.Memory))),
+			formatUsage(strconv.Itoa(len(usage.Domains)), q.Domains > 0, strconv.Itoa(q.Domains)),
+		)
+	}
+	return tw.Flush()
+}
+
+// formatUsage formats the used amount of a resource and its limit if set.
+func formatUsage(used string, limited bool, limit string) string {
+	if !limited {
+		return used + " / unlimited"
+	}
+	return used + " / " + limit
+}
+
+func formatCPU(nanoCPUs int64) string {
+	return fmt.Sprintf("%.2f", float64(nanoCPUs)/api.Core)
+}
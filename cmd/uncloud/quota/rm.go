@@ -0,0 +1,45 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewRmCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "rm PROJECT",
+		Aliases: []string{"remove"},
+		Short:   "Remove the resource quota of a project.",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return remove(cmd.Context(), uncli, args[0], contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func remove(ctx context.Context, uncli *cli.CLI, project, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if err = client.RemoveProjectQuota(ctx, project); err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("project '%s' has no quota", project)
+		}
+		return fmt.Errorf("remove project quota: %w", err)
+	}
+	fmt.Printf("Quota of project '%s' removed.\n", project)
+	return nil
+}
@@ -0,0 +1,25 @@
+package quota
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quota",
+		Short: "Manage the resource quotas of projects.",
+		Long: `Manage the resource quotas of projects.
+
+A project quota limits the CPU and memory the project services can reserve, the number of services, and the number
+of domains they can publish via the ingress so that the stack of one team can't starve the cluster. The quota is
+enforced when the project is deployed with 'uc deploy': the deployment fails before making any changes if the project
+would exceed its quota. The CPU reservation of a container is its 'cpus' limit and the memory reservation is its
+'mem_reservation'.`,
+	}
+	cmd.AddCommand(
+		NewListCommand(),
+		NewRmCommand(),
+		NewSetCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,98 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dockeropts "github.com/docker/cli/opts"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type setOptions struct {
+	cpu         dockeropts.NanoCPUs
+	memory      dockeropts.MemBytes
+	maxServices int
+	maxDomains  int
+	context     string
+}
+
+func NewSetCommand() *cobra.Command {
+	opts := setOptions{}
+	cmd := &cobra.Command{
+		Use:   "set PROJECT",
+		Short: "Set the resource quota of a project.",
+		Long: `Set the resource quota of a project. Only the specified limits are changed if the project already has
+a quota. Set a limit to 0 to remove it. Lowering a limit doesn't affect the deployed services but the next deployment
+of the project fails if it exceeds the quota.`,
+		Example: `  # Limit the 'shop' project to 5 services reserving at most 4 CPU cores and 8 GiB of memory.
+  uc quota set shop --cpu 4 --memory 8g --max-services 5
+
+  # Limit the number of domains the 'shop' project can publish.
+  uc quota set shop --max-domains 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return set(cmd.Context(), uncli, cmd, args[0], opts)
+		},
+	}
+	cmd.Flags().Var(&opts.cpu, "cpu",
+		"Maximum total CPU cores the project containers can reserve with their 'cpus' limits.\n"+
+			"Fractional values are allowed.")
+	cmd.Flags().Var(&opts.memory, "memory",
+		"Maximum total memory the project containers can reserve with their 'mem_reservation', e.g. 8g.")
+	cmd.Flags().IntVar(&opts.maxServices, "max-services", 0,
+		"Maximum number of the project services.")
+	cmd.Flags().IntVar(&opts.maxDomains, "max-domains", 0,
+		"Maximum number of distinct domains the project services can publish via the ingress.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func set(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, project string, opts setOptions) error {
+	flags := cmd.Flags()
+	if !flags.Changed("cpu") && !flags.Changed("memory") &&
+		!flags.Changed("max-services") && !flags.Changed("max-domains") {
+		return errors.New("no limits to set, specify at least one of --cpu, --memory, --max-services, " +
+			"or --max-domains")
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	quota, err := client.ProjectQuota(ctx, project)
+	if err != nil {
+		if !errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("get project quota: %w", err)
+		}
+		quota = api.ProjectQuota{Project: project}
+	}
+	if flags.Changed("cpu") {
+		quota.CPU = opts.cpu.Value()
+	}
+	if flags.Changed("memory") {
+		quota.Memory = opts.memory.Value()
+	}
+	if flags.Changed("max-services") {
+		quota.Services = opts.maxServices
+	}
+	if flags.Changed("max-domains") {
+		quota.Domains = opts.maxDomains
+	}
+	if quota.IsZero() {
+		return fmt.Errorf("quota of project '%s' doesn't limit anything, "+
+			"use 'uc quota rm %s' to remove it", project, project)
+	}
+
+	if err = client.SetProjectQuota(ctx, quota); err != nil {
+		return fmt.Errorf("set project quota: %w", err)
+	}
+	fmt.Printf("Quota of project '%s' set.\n", project)
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/selfupdate"
+	"github.com/psviderski/uncloud/internal/version"
+	"github.com/spf13/cobra"
+)
+
+type selfUpdateOptions struct {
+	channel string
+	version string
+	check   bool
+}
+
+// NewSelfUpdateCommand creates a new command to update the CLI binary to the latest release on a release channel.
+func NewSelfUpdateCommand() *cobra.Command {
+	opts := selfUpdateOptions{}
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update the CLI to the latest release on the release channel.",
+		Long: `Update the CLI to the latest release on the release channel.
+
+The downloaded binary is verified against the release checksums signed with the Uncloud release signing key.
+The channel set with --channel is saved to the Uncloud config and also used by 'uc machine upgrade' to keep
+the CLI and machine daemons on the same channel.`,
+		Example: `  # Update the CLI to the latest stable release.
+  uc self-update
+
+  # Switch to the beta channel and update the CLI to the latest pre-release.
+  uc self-update --channel beta
+
+  # Check if there is a newer release without updating.
+  uc self-update --check`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return selfUpdate(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.channel, "channel", "",
+		"Release channel to update from: 'stable' or 'beta'. (default is the saved channel or 'stable')")
+	cmd.Flags().StringVar(&opts.version, "version", "",
+		"Specific version to update to, e.g. 0.9.0. (default is the latest release on the channel)")
+	cmd.Flags().BoolVar(&opts.check, "check", false,
+		"Only check if there is a newer release without updating.")
+
+	return cmd
+}
+
+func selfUpdate(ctx context.Context, uncli *cli.CLI, opts selfUpdateOptions) error {
+	channel, err := uncli.ReleaseChannel(opts.channel)
+	if err != nil {
+		return err
+	}
+	updater, err := selfupdate.New()
+	if err != nil {
+		if errors.Is(err, selfupdate.ErrNoPublicKey) {
+			return fmt.Errorf("%w, reinstall the CLI with 'curl -fsS https://get.uncloud.run/install.sh | sh'", err)
+		}
+		return err
+	}
+
+	var rel selfupdate.Release
+	if opts.version != "" {
+		rel, err = updater.Release(ctx, opts.version)
+	} else {
+		rel, err = updater.Latest(ctx, channel)
+	}
+	if err != nil {
+		return err
+	}
+
+	current := version.String()
+	if rel.Version == current {
+		fmt.Printf("Uncloud CLI is up to date: %s (%s channel).\n", current, channel)
+	} else {
+		if opts.check {
+			fmt.Printf("A new release is available on the %s channel: %s (current: %s).\n", channel, rel.Version,
+				versionOrDash(current))
+			fmt.Println("Run 'uc self-update' to update.")
+			return nil
+		}
+		if err = replaceCLI(ctx, updater, rel); err != nil {
+			return err
+		}
+		fmt.Printf("Uncloud CLI updated from %s to %s (%s channel).\n", versionOrDash(current), rel.Version, channel)
+	}
+
+	// Save the explicitly selected channel to also use it for the following updates and machine upgrades.
+	if opts.channel != "" {
+		return uncli.SaveReleaseChannel(channel)
+	}
+	return nil
+}
+
+// replaceCLI downloads the CLI binary of the release and replaces the running executable with it.
+func replaceCLI(ctx context.Context, updater *selfupdate.Updater, rel selfupdate.Release) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("get CLI executable path: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil && strings.Contains(resolved, "/Cellar/") {
+		return fmt.Errorf("the CLI is installed with Homebrew, run 'brew upgrade uncloud' to update it")
+	}
+
+	binary, err := updater.Download(ctx, rel, selfupdate.ArchiveName("uncloud", runtime.GOOS, runtime.GOARCH),
+		"uncloud")
+	if err != nil {
+		return fmt.Errorf("download CLI %s: %w", rel.Version, err)
+	}
+	if err = selfupdate.ReplaceExecutable(exe, binary); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("%w. Rerun the command with sudo", err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type accessLogsOptions struct {
+	service string
+	api.AccessLogsOptions
+	context string
+}
+
+func NewAccessLogsCommand() *cobra.Command {
+	opts := accessLogsOptions{}
+	cmd := &cobra.Command{
+		Use:   "access-logs SERVICE",
+		Short: "Show the ingress access logs of a service.",
+		Long: "Show the requests to a service handled by the Caddy reverse proxy on all machines. The access logs\n" +
+			"are filtered by the hostnames of the HTTP(S) ports published by the service. Show the access logs for\n" +
+			"all hostnames, including ones from custom Caddy configs, by specifying the 'caddy' service.",
+		Example: `  # Show the access logs of the 'web' service.
+  uc service access-logs web
+
+  # Follow the failed requests to the API of the 'web' service.
+  uc service access-logs web -f --status 5xx --path /api/`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return accessLogs(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Follow, "follow", "f", false,
+		"Follow the access logs output.")
+	cmd.Flags().StringVar(&opts.Since, "since", "",
+		"Show access logs since a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes).")
+	cmd.Flags().StringVar(&opts.Until, "until", "",
+		"Show access logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes).")
+	cmd.Flags().StringSliceVar(&opts.Status, "status", nil,
+		"Show only requests with the response status codes (e.g. 404) or classes (e.g. 5xx).\n"+
+			"Can be specified multiple times or as a comma-separated list.")
+	cmd.Flags().StringVar(&opts.PathPrefix, "path", "",
+		"Show only requests with the path starting with the prefix (e.g. /api/).")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func accessLogs(ctx context.Context, uncli *cli.CLI, opts accessLogsOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	entries, err := client.ServiceAccessLogs(ctx, opts.service, opts.AccessLogsOptions)
+	if err != nil {
+		return err
+	}
+
+	for e := range entries {
+		if e.Err != nil {
+			fmt.Fprintf(os.Stderr, "Error streaming access logs from machine '%s': %v\n", e.MachineName, e.Err)
+			continue
+		}
+		fmt.Printf("%s  %s  %s  \"%s %s%s %s\"  %d  %dB  %s\n",
+			e.Time.Local().Format(time.RFC3339),
+			e.MachineName,
+			e.RemoteIP,
+			e.Method, e.Host, e.URI, e.Proto,
+			e.Status,
+			e.Size,
+			e.Duration.Round(time.Microsecond),
+		)
+	}
+
+	return nil
+}
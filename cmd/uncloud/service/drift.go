@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type driftOptions struct {
+	services    []string
+	autoCorrect bool
+	context     string
+}
+
+func NewDriftCommand() *cobra.Command {
+	opts := driftOptions{}
+	cmd := &cobra.Command{
+		Use:   "drift [SERVICE...]",
+		Short: "Show service containers whose configuration drifted from their spec.",
+		Long: `Show service containers whose configuration drifted from their spec, e.g. after a container was changed
+manually with 'docker update' on a machine. The image, environment variables, mounts, host ports, and resources
+of the containers are compared with their service spec. Environment variables are compared by a hash of their values.
+
+Machines check the containers for drift when syncing them to the cluster store and log the detected drift.
+Use --auto-correct to recreate the drifted containers from their spec with a rolling update.`,
+		Example: `  # Show the drifted containers of all services.
+  uc service drift
+
+  # Recreate the drifted containers of the 'web' service.
+  uc service drift web --auto-correct`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.services = args
+			return drift(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.autoCorrect, "auto-correct", false,
+		"Recreate the drifted containers from their spec.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func drift(ctx context.Context, uncli *cli.CLI, opts driftOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	var services []api.Service
+	if len(opts.services) == 0 {
+		if services, err = clusterClient.ListServices(ctx); err != nil {
+			return fmt.Errorf("list services: %w", err)
+		}
+	} else {
+		for _, nameOrID := range opts.services {
+			svc, err := clusterClient.InspectService(ctx, nameOrID)
+			if err != nil {
+				return fmt.Errorf("inspect service '%s': %w", nameOrID, err)
+			}
+			services = append(services, svc)
+		}
+	}
+
+	machines, err := clusterClient.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	machinesNamesByID := make(map[string]string)
+	for _, m := range machines {
+		machinesNamesByID[m.Machine.Id] = m.Machine.Name
+	}
+
+	var drifted []api.Service
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	for _, svc := range services {
+		serviceDrifted := false
+		for _, ctr := range svc.Containers {
+			machine := machinesNamesByID[ctr.MachineID]
+			if machine == "" {
+				machine = ctr.MachineID
+			}
+			for _, d := range ctr.Container.Drift {
+				if len(drifted) == 0 && !serviceDrifted {
+					fmt.Fprintln(tw, "SERVICE\tCONTAINER ID\tMACHINE\tFIELD\tEXPECTED\tACTUAL")
+				}
+				serviceDrifted = true
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", svc.Name, stringid.TruncateID(ctr.Container.ID),
+					machine, d.Field, d.Expected, d.Actual)
+			}
+		}
+		if serviceDrifted {
+			drifted = append(drifted, svc)
+		}
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(drifted) == 0 {
+		fmt.Println("No configuration drift found.")
+		return nil
+	}
+	if !opts.autoCorrect {
+		return nil
+	}
+
+	fmt.Println()
+	for _, svc := range drifted {
+		deployment, _, err := clusterClient.NewDriftCorrectionDeployment(ctx, svc.ID)
+		if err != nil {
+			return err
+		}
+		title := fmt.Sprintf("Recreating drifted containers of service %s", svc.Name)
+		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			if _, err := deployment.Run(ctx); err != nil {
+				return fmt.Errorf("correct drift of service '%s': %w", svc.Name, err)
+			}
+			return nil
+		}, uncli.ProgressOut(), title)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
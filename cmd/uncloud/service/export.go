@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+const exportFormatCompose = "compose"
+
+type exportOptions struct {
+	services []string
+	format   string
+	output   string
+	context  string
+}
+
+func NewExportCommand() *cobra.Command {
+	opts := exportOptions{}
+	cmd := &cobra.Command{
+		Use:   "export SERVICE [SERVICE...]",
+		Short: "Export services as a Compose file.",
+		Long: "Export services as a Compose file reconstructed from their deployed spec, including the Uncloud\n" +
+			"extensions such as x-ports and x-machines. This is useful for moving services created with 'uc run'\n" +
+			"to a Compose file that can be stored in version control and deployed again with 'uc deploy'.",
+		Example: `  # Print the Compose file for the 'web' service.
+  uc service export web
+
+  # Save the Compose file for the 'web' and 'db' services to a file.
+  uc service export web db -o compose.yaml`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.services = args
+			return export(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", exportFormatCompose,
+		"Output format. Only 'compose' is currently supported.")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"File to write the exported services to. (default is stdout)")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func export(ctx context.Context, uncli *cli.CLI, opts exportOptions) error {
+	if opts.format != exportFormatCompose {
+		return fmt.Errorf("unsupported format: '%s'", opts.format)
+	}
+
+	// Don't show the connection progress to not mix it with the exported services printed to stdout.
+	clusterClient, err := uncli.ConnectClusterWithOptions(ctx, opts.context, cli.ConnectOptions{})
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	specs := make([]api.ServiceSpec, 0, len(opts.services))
+	for _, nameOrID := range opts.services {
+		svc, err := clusterClient.InspectService(ctx, nameOrID)
+		if err != nil {
+			return fmt.Errorf("inspect service '%s': %w", nameOrID, err)
+		}
+		spec, ok := svc.Spec()
+		if !ok {
+			return fmt.Errorf("service '%s' has no containers to derive the configuration from", svc.Name)
+		}
+		specs = append(specs, spec)
+	}
+
+	project, err := compose.ProjectFromServiceSpecs(specs)
+	if err != nil {
+		return fmt.Errorf("convert services to Compose format: %w", err)
+	}
+	data, err := project.MarshalYAML()
+	if err != nil {
+		return fmt.Errorf("marshal Compose file: %w", err)
+	}
+
+	if opts.output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err = os.WriteFile(opts.output, data, 0o644); err != nil {
+		return fmt.Errorf("write Compose file: %w", err)
+	}
+	fmt.Printf("Services exported to %s\n", opts.output)
+	return nil
+}
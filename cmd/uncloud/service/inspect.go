@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/distribution/reference"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/go-units"
 	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
 
@@ -61,11 +65,52 @@ func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
 	fmt.Printf("ID:    %s\n", svc.ID)
 	fmt.Printf("Name:  %s\n", svc.Name)
 	fmt.Printf("Mode:  %s\n", svc.Mode)
+	if len(svc.Containers) > 0 {
+		spec := svc.Containers[0].Container.ServiceSpec
+		printImage(spec.Container.Image)
+		if spec.Protected {
+			fmt.Println("Protected:     true")
+		}
+		if spec.Priority != api.PriorityNormal {
+			fmt.Printf("Priority:      %s\n", api.FormatPriority(spec.Priority))
+		}
+		if sched := spec.ScaleSchedule; sched != nil {
+			tz := sched.Timezone
+			if tz == "" {
+				tz = "UTC"
+			}
+			fmt.Printf("Scaling:       %d replicas (%s)", sched.Replicas, tz)
+			for _, w := range sched.Windows {
+				fmt.Printf(", %d replicas %s", w.Replicas, w.Window)
+			}
+			fmt.Println()
+		}
+		if spec.NetworkMode != "" {
+			fmt.Printf("Network mode:  %s\n", spec.NetworkMode)
+		}
+		if spec.Macvlan != nil {
+			fmt.Printf("Macvlan:       parent=%s subnet=%s", spec.Macvlan.Parent, spec.Macvlan.Subnet)
+			if spec.Macvlan.Gateway.IsValid() {
+				fmt.Printf(" gateway=%s", spec.Macvlan.Gateway)
+			}
+			if spec.Macvlan.IPRange.IsValid() {
+				fmt.Printf(" ip-range=%s", spec.Macvlan.IPRange)
+			}
+			fmt.Println()
+		}
+		if len(spec.Networks) > 0 {
+			fmt.Printf("Networks:      %s\n", strings.Join(spec.ServiceNetworks(), ", "))
+		}
+		if spec.Container.RestartPolicy != nil {
+			fmt.Printf("Restart:       %s\n", spec.Container.RestartPolicy)
+		}
+		printSecurityOptions(spec.Container)
+	}
 	fmt.Println()
 
 	// Print the list of containers in a table format.
 	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	if _, err = fmt.Fprintln(tw, "CONTAINER ID\tIMAGE\tCREATED\tSTATUS\tMACHINE"); err != nil {
+	if _, err = fmt.Fprintln(tw, "CONTAINER ID\tIMAGE\tCREATED\tSTATUS\tRESTARTS\tLAST EXIT\tMACHINE"); err != nil {
 		return fmt.Errorf("write header: %w", err)
 	}
 
@@ -85,18 +130,119 @@ func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
 			return fmt.Errorf("get human state: %w", err)
 		}
 
+		if ctr.Container.CrashLooping() {
+			state += " (crash loop)"
+		}
+		lastExit := "-"
+		if exit := ctr.Container.Exit(); exit != nil {
+			lastExit = fmt.Sprintf("%s, %s ago", exit.Reason(),
+				units.HumanDuration(time.Now().UTC().Sub(exit.FinishedAt)))
+		}
+
 		_, err = fmt.Fprintf(
 			tw,
-			"%s\t%s\t%s\t%s\t%s\n",
+			"%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
 			stringid.TruncateID(ctr.Container.ID),
 			ctr.Container.Config.Image,
 			created,
 			state,
+			ctr.Container.RestartCount,
+			lastExit,
 			machine,
 		)
 		if err != nil {
 			return fmt.Errorf("write row: %w", err)
 		}
 	}
-	return tw.Flush()
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	printDrift(svc, machinesNamesByID)
+	return nil
+}
+
+// printDrift prints the configuration drift of the service containers from their spec if any.
+func printDrift(svc api.Service, machinesNamesByID map[string]string) {
+	drifted := false
+	for _, ctr := range svc.Containers {
+		if len(ctr.Container.Drift) == 0 {
+			continue
+		}
+		if !drifted {
+			fmt.Println()
+			fmt.Println("Configuration drift:")
+			drifted = true
+		}
+
+		machine := machinesNamesByID[ctr.MachineID]
+		if machine == "" {
+			machine = ctr.MachineID
+		}
+		for _, d := range ctr.Container.Drift {
+			fmt.Printf("  %s on %s: %s\n", stringid.TruncateID(ctr.Container.ID), machine, d)
+		}
+	}
+	if drifted {
+		fmt.Printf("\nRun 'uc service drift %s --auto-correct' to recreate the drifted containers from their spec.\n",
+			svc.Name)
+	}
+}
+
+// printImage prints the image of the service splitting out the digest the image was pinned to on deployment.
+func printImage(image string) {
+	ref, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		fmt.Printf("Image:         %s\n", image)
+		return
+	}
+	canonical, ok := ref.(reference.Canonical)
+	if !ok {
+		fmt.Printf("Image:         %s\n", image)
+		return
+	}
+
+	name := reference.FamiliarName(ref)
+	if tagged, ok := ref.(reference.Tagged); ok {
+		name += ":" + tagged.Tag()
+	}
+	fmt.Printf("Image:         %s\n", name)
+	fmt.Printf("Image digest:  %s\n", canonical.Digest())
+}
+
+// printSecurityOptions prints the privilege and security related options of the container spec if any are set.
+func printSecurityOptions(spec api.ContainerSpec) {
+	if spec.Privileged {
+		fmt.Println("Privileged:    true")
+	}
+	if spec.User != "" {
+		fmt.Printf("User:          %s\n", spec.User)
+	}
+	if spec.ReadOnly {
+		fmt.Println("Read-only:     true")
+	}
+	if len(spec.CapAdd) > 0 {
+		fmt.Printf("Cap add:       %s\n", strings.Join(spec.CapAdd, ", "))
+	}
+	if len(spec.CapDrop) > 0 {
+		fmt.Printf("Cap drop:      %s\n", strings.Join(spec.CapDrop, ", "))
+	}
+	if len(spec.Devices) > 0 {
+		devices := make([]string, len(spec.Devices))
+		for i, d := range spec.Devices {
+			devices[i] = d.String()
+		}
+		fmt.Printf("Devices:       %s\n", strings.Join(devices, ", "))
+	}
+	if len(spec.SecurityOpt) > 0 {
+		fmt.Printf("Security opt:  %s\n", strings.Join(spec.SecurityOpt, ", "))
+	}
+	if len(spec.Sysctls) > 0 {
+		sysctls := make([]string, 0, len(spec.Sysctls))
+		for k, v := range spec.Sysctls {
+			sysctls = append(sysctls, k+"="+v)
+		}
+		sort.Strings(sysctls)
+		fmt.Printf("Sysctls:       %s\n", strings.Join(sysctls, ", "))
+	}
 }
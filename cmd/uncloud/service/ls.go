@@ -2,80 +2,216 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/spf13/cobra"
 )
 
+type listOptions struct {
+	project string
+	trashed bool
+	context string
+}
+
 func NewListCommand() *cobra.Command {
-	var contextName string
+	opts := listOptions{}
 	cmd := &cobra.Command{
 		Use:     "ls",
 		Aliases: []string{"list"},
 		Short:   "List services.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
-			return list(cmd.Context(), uncli, contextName)
+			return list(cmd.Context(), uncli, opts)
 		},
 	}
+	cmd.Flags().StringVar(&opts.project, "project", "",
+		"List only the services of the project with the given name.")
+	cmd.Flags().BoolVar(&opts.trashed, "trashed", false,
+		"List the removed services in the trash that can be restored with 'uc service restore'.")
 	cmd.Flags().StringVarP(
-		&contextName, "context", "c", "",
+		&opts.context, "context", "c", "",
 		"Name of the cluster context. (default is the current context)",
 	)
 	return cmd
 }
 
-func list(ctx context.Context, uncli *cli.CLI, contextName string) error {
-	client, err := uncli.ConnectCluster(ctx, contextName)
+func list(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
+	if uncli.ContextGroup() != "" {
+		return listGroup(ctx, uncli, opts)
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
 	if err != nil {
 		return fmt.Errorf("connect to cluster: %w", err)
 	}
 	defer client.Close()
 
-	services, err := client.ListServices(ctx)
+	if opts.trashed {
+		trashed, err := client.ListTrashedServices(ctx)
+		if err != nil {
+			return fmt.Errorf("list trashed services: %w", err)
+		}
+		return listTrashed(trashed)
+	}
+
+	services, err := listServices(ctx, client, opts.project)
+	if err != nil {
+		return err
+	}
+	return printServices([]contextServices{{services: services}}, opts.project, false)
+}
+
+// listGroup lists the services in all clusters of the context group with the context column. The clusters that
+// failed to list the services are reported after the list.
+func listGroup(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
+	if opts.context != "" {
+		return errors.New("the '--context' and '--context-group' flags can't be used together")
+	}
+	if opts.trashed {
+		return errors.New("listing the trashed services is not supported with the '--context-group' flag")
+	}
+
+	results, err := cli.ForEachContext(ctx, uncli, func(ctx context.Context, c *client.Client) ([]api.Service, error) {
+		return listServices(ctx, c, opts.project)
+	})
 	if err != nil {
-		return fmt.Errorf("list services: %w", err)
+		return err
+	}
+
+	var groups []contextServices
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			continue
+		}
+		groups = append(groups, contextServices{context: r.Context, services: r.Value})
+	}
+	if err = printServices(groups, opts.project, true); err != nil {
+		return err
 	}
 
-	serviceNames := make(map[string]struct{}, len(services))
+	if failed == 0 {
+		return nil
+	}
+	fmt.Fprintln(os.Stderr)
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list services in context '%s': %v\n", r.Context, r.Err)
+		}
+	}
+	return fmt.Errorf("failed to list services in %d of %d contexts", failed, len(results))
+}
+
+// listServices returns the services in the cluster excluding the ones in the trash as they're considered removed.
+func listServices(ctx context.Context, clusterClient *client.Client, project string) ([]api.Service, error) {
+	trashed, err := clusterClient.ListTrashedServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list trashed services: %w", err)
+	}
+	services, err := clusterClient.ListServicesFiltered(ctx, &api.ServiceFilter{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("list services: %w", err)
+	}
+	return slices.DeleteFunc(services, func(s api.Service) bool {
+		return slices.ContainsFunc(trashed, func(t api.TrashedService) bool { return t.ContainsService(s) })
+	}), nil
+}
+
+// contextServices are the services in the cluster of a context.
+type contextServices struct {
+	context  string
+	services []api.Service
+}
+
+// printServices prints the services of the contexts in a table format. The context column is included
+// if showContext is set.
+func printServices(groups []contextServices, projectFilter string, showContext bool) error {
+	// Include the project column if any of the services belongs to a project unless filtered by project.
+	showProject := false
+	// Include the ID column if there are duplicate service names in a context to differentiate them.
 	haveDuplicateNames := false
-	for _, svc := range services {
-		if _, exists := serviceNames[svc.Name]; exists {
-			haveDuplicateNames = true
-			break
+	for _, g := range groups {
+		serviceNames := make(map[string]struct{}, len(g.services))
+		for _, svc := range g.services {
+			if svc.Project != "" && projectFilter == "" {
+				showProject = true
+			}
+			if _, exists := serviceNames[svc.Name]; exists {
+				haveDuplicateNames = true
+			}
+			serviceNames[svc.Name] = struct{}{}
 		}
-		serviceNames[svc.Name] = struct{}{}
 	}
 
 	// Print the list of services in a table format.
 	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 
-	// Include the ID column if there are duplicate service names to differentiate them.
+	header := ""
+	if showContext {
+		header += "CONTEXT\t"
+	}
 	if haveDuplicateNames {
-		if _, err = fmt.Fprintf(tw, "ID\t"); err != nil {
-			return fmt.Errorf("write header: %w", err)
-		}
+		header += "ID\t"
+	}
+	if showProject {
+		header += "PROJECT\t"
 	}
-	if _, err = fmt.Fprintln(tw, "NAME\tMODE\tREPLICAS\tIMAGE\tENDPOINTS"); err != nil {
+	if _, err := fmt.Fprintln(tw, header+"NAME\tMODE\tREPLICAS\tRESTARTS\tIMAGE\tENDPOINTS"); err != nil {
 		return fmt.Errorf("write header: %w", err)
 	}
-	for _, s := range services {
-		images := strings.Join(s.Images(), ", ")
-		endpoints := strings.Join(s.Endpoints(), ", ")
+	for _, g := range groups {
+		for _, s := range g.services {
+			images := strings.Join(s.Images(), ", ")
+			endpoints := strings.Join(s.Endpoints(), ", ")
+			restarts, crashLooping := s.Restarts()
+			restartsStr := strconv.Itoa(restarts)
+			if crashLooping > 0 {
+				restartsStr += fmt.Sprintf(" (%d crash-looping)", crashLooping)
+			}
 
-		if haveDuplicateNames {
-			if _, err = fmt.Fprintf(tw, "%s\t", s.ID); err != nil {
+			row := ""
+			if showContext {
+				row += g.context + "\t"
+			}
+			if haveDuplicateNames {
+				row += s.ID + "\t"
+			}
+			if showProject {
+				project := s.Project
+				if project == "" {
+					project = "-"
+				}
+				row += project + "\t"
+			}
+			if _, err := fmt.Fprintf(tw, "%s%s\t%s\t%d\t%s\t%s\t%s\n",
+				row, s.Name, s.Mode, len(s.Containers), restartsStr, images, endpoints); err != nil {
 				return fmt.Errorf("write row: %w", err)
 			}
 		}
-		if _, err = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
-			s.Name, s.Mode, len(s.Containers), images, endpoints); err != nil {
-			return fmt.Errorf("write row: %w", err)
+	}
+	return tw.Flush()
+}
+
+func listTrashed(services []api.TrashedService) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tREMOVED\tRESTORABLE UNTIL")
+	for _, s := range services {
+		until := s.ExpiresAt.Local().Format(time.DateTime)
+		if s.Expired(time.Now()) {
+			until = "expired"
 		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.ID, s.Name, s.TrashedAt.Local().Format(time.DateTime), until)
 	}
 	return tw.Flush()
 }
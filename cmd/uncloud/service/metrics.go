@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+type metricsOptions struct {
+	service string
+	period  time.Duration
+	context string
+}
+
+func NewMetricsCommand() *cobra.Command {
+	opts := metricsOptions{}
+	cmd := &cobra.Command{
+		Use:   "metrics SERVICE",
+		Short: "Display a summary of HTTP request metrics for a service.",
+		Long: "Display the request rate, latency percentiles, and response status classes for each hostname of\n" +
+			"a service. The metrics are calculated from the ingress access logs of the Caddy reverse proxy.\n\n" +
+			"Caddy also exposes Prometheus metrics for all HTTP requests at http://<machine-ip>/.uncloud-metrics\n" +
+			"that can be scraped from the cluster network.",
+		Example: `  # Show the request metrics of the 'web' service over the last hour.
+  uc service metrics web
+
+  # Show the request metrics of the 'web' service over the last 5 minutes.
+  uc service metrics web --period 5m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return metrics(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.period, "period", time.Hour,
+		"Period of time until now to calculate the metrics over.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func metrics(ctx context.Context, uncli *cli.CLI, opts metricsOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	hostMetrics, err := client.ServiceRequestMetrics(ctx, opts.service, opts.period)
+	if err != nil {
+		return fmt.Errorf("get request metrics: %w", err)
+	}
+	if len(hostMetrics) == 0 {
+		fmt.Printf("No requests to service '%s' in the last %s.\n", opts.service, opts.period)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err = fmt.Fprintln(tw, "HOST\tREQUESTS\tRATE\tP50\tP95\tP99\t2XX\t3XX\t4XX\t5XX"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, m := range hostMetrics {
+		_, err = fmt.Fprintf(tw, "%s\t%d\t%.2f/s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\n",
+			m.Host,
+			m.Requests,
+			m.Rate,
+			m.P50.Round(time.Microsecond),
+			m.P95.Round(time.Microsecond),
+			m.P99.Round(time.Microsecond),
+			m.StatusClasses[2],
+			m.StatusClasses[3],
+			m.StatusClasses[4],
+			m.StatusClasses[5],
+		)
+		if err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	return tw.Flush()
+}
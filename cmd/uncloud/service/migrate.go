@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/bwlimit"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// migrateHealthCheckInterval is how often the health of the migrated service is checked during the soak period.
+const migrateHealthCheckInterval = 15 * time.Second
+
+type migrateOptions struct {
+	service        string
+	toContext      string
+	toMachines     []string
+	syncVolumes    bool
+	bwLimit        string
+	dnsTTL         time.Duration
+	soak           time.Duration
+	keepSource     bool
+	overrideFreeze string
+	yes            bool
+	context        string
+}
+
+func NewMigrateCommand() *cobra.Command {
+	opts := migrateOptions{}
+	cmd := &cobra.Command{
+		Use:   "migrate SERVICE --to-context TARGET",
+		Short: "Move a service to another cluster.",
+		Long: `Move a service to the cluster of another context in the following steps:
+
+  1. Deploy the service with the same spec in the target cluster.
+  2. Optionally copy the service volumes to the target cluster with --sync-volumes. The source containers are
+     stopped during the copy to get a consistent copy of the data and are only started again if the migration
+     fails.
+  3. Point the service hostnames under the managed cluster domain of the source cluster to the ingress machines
+     of the target cluster. Use --dns-ttl to adjust how long resolvers may cache the records. The source cluster
+     keeps these records when it updates the records of its domain until they're pointed back to it.
+  4. Watch the health of the service in the target cluster during the soak period. If the service becomes
+     unhealthy, the hostnames are pointed back to the source cluster.
+  5. Remove the service from the source cluster. It's moved to the trash if the trash is enabled.
+
+Hostnames outside the managed cluster domain must be pointed to the target cluster manually.`,
+		Example: `  # Move the web service to the prod-eu cluster.
+  uc service migrate web --to-context prod-eu
+
+  # Move the db service with its volumes to machine-1 in the prod-eu cluster and keep the source service.
+  uc service migrate db --to-context prod-eu --to-machine machine-1 --sync-volumes --keep-source`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return migrate(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.toContext, "to-context", "",
+		"Name of the cluster context to move the service to.")
+	cmd.Flags().StringSliceVar(&opts.toMachines, "to-machine", nil,
+		"Machine names or IDs in the target cluster to deploy the service to. Can be specified multiple times "+
+			"or as a comma-separated list. (default is any machine)")
+	cmd.Flags().BoolVar(&opts.syncVolumes, "sync-volumes", false,
+		"Copy the service volumes to the first machine specified with --to-machine.")
+	cmd.Flags().StringVar(&opts.bwLimit, "bwlimit", "",
+		"Limit the bandwidth of copying the volumes with --sync-volumes through the client,\n"+
+			"e.g. 512K or 10M bytes per second. Units are 1024-based. (default unlimited)")
+	cmd.Flags().DurationVar(&opts.dnsTTL, "dns-ttl", time.Minute,
+		"TTL of the DNS records pointing the service hostnames to the target cluster.")
+	cmd.Flags().DurationVar(&opts.soak, "soak", 10*time.Minute,
+		"How long to watch the service in the target cluster before removing it from the source cluster.")
+	cmd.Flags().BoolVar(&opts.keepSource, "keep-source", false,
+		"Do not remove the service from the source cluster after the soak period.")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Migrate the service even if deploys are frozen with 'uc cluster freeze' in the source or target cluster. "+
+			"The reason is recorded in the audit log of the frozen clusters.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before migrating the service. [$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context to move the service from. (default is the current context)",
+	)
+	_ = cmd.MarkFlagRequired("to-context")
+	return cmd
+}
+
+func migrate(ctx context.Context, uncli *cli.CLI, opts migrateOptions) error {
+	if opts.syncVolumes && len(opts.toMachines) == 0 {
+		return errors.New("--sync-volumes requires --to-machine to select the machine to copy the volumes to")
+	}
+	if opts.dnsTTL < time.Second {
+		return errors.New("--dns-ttl must be at least 1s")
+	}
+	bwLimit, err := bwlimit.Parse(opts.bwLimit)
+	if err != nil {
+		return err
+	}
+
+	source, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to source cluster: %w", err)
+	}
+	defer source.Close()
+
+	target, err := uncli.ConnectCluster(ctx, opts.toContext)
+	if err != nil {
+		return fmt.Errorf("connect to target cluster '%s': %w", opts.toContext, err)
+	}
+	defer target.Close()
+
+	svc, err := source.InspectService(ctx, opts.service)
+	if err != nil {
+		return fmt.Errorf("inspect service '%s': %w", opts.service, err)
+	}
+	if svc.Name == client.CaddyServiceName {
+		return fmt.Errorf("service '%s' is managed by each cluster and can't be migrated", svc.Name)
+	}
+	spec, ok := svc.Spec()
+	if !ok {
+		return fmt.Errorf("service '%s' has no containers to migrate", svc.Name)
+	}
+	if len(opts.toMachines) > 0 {
+		spec.Placement.Machines = opts.toMachines
+	} else if len(spec.Placement.Machines) > 0 {
+		return fmt.Errorf("service '%s' is placed on specific machines %s of the source cluster, "+
+			"use --to-machine to place it in the target cluster", svc.Name, strings.Join(spec.Placement.Machines, ", "))
+	}
+
+	volumes := spec.MountedDockerVolumes()
+	if opts.syncVolumes {
+		if len(volumes) == 0 {
+			return fmt.Errorf("service '%s' has no volumes to sync", svc.Name)
+		}
+		if _, err = target.InspectService(ctx, svc.Name); err == nil {
+			return fmt.Errorf("service '%s' already exists in the target cluster, "+
+				"remove it first to sync the volumes or run the command without --sync-volumes", svc.Name)
+		} else if !errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("inspect service '%s' in the target cluster: %w", svc.Name, err)
+		}
+	}
+
+	// The migration deploys the service to the target cluster and removes it from the source cluster.
+	operation := fmt.Sprintf("migrate service %s to cluster %s", svc.Name, opts.toContext)
+	if ctx, err = source.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return fmt.Errorf("source cluster: %w", err)
+	}
+	if ctx, err = target.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return fmt.Errorf("target cluster '%s': %w", opts.toContext, err)
+	}
+
+	domain, err := source.GetDomain(ctx)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("get source cluster domain: %w", err)
+	}
+	managed, manual := splitHostnames(spec.Ports, domain)
+
+	fmt.Printf("Service '%s' will be moved to cluster '%s':\n", svc.Name, opts.toContext)
+	fmt.Printf("  - deploy %d replica(s) in cluster '%s'\n", len(svc.Containers), opts.toContext)
+	if opts.syncVolumes {
+		var names []string
+		for _, v := range volumes {
+			names = append(names, v.DockerVolumeName())
+		}
+		fmt.Printf("  - stop the source containers and copy volumes %s to machine '%s'\n",
+			strings.Join(names, ", "), opts.toMachines[0])
+	}
+	if len(managed) > 0 {
+		fmt.Printf("  - point %s to cluster '%s' with TTL %s\n",
+			strings.Join(managed, ", "), opts.toContext, opts.dnsTTL)
+	}
+	if opts.keepSource {
+		fmt.Printf("  - watch the service for %s and keep it in the source cluster\n", opts.soak)
+	} else {
+		fmt.Printf("  - watch the service for %s and remove it from the source cluster\n", opts.soak)
+	}
+	fmt.Println()
+
+	if !opts.yes {
+		if !cli.IsStdinTerminal() {
+			return errors.New("cannot ask to confirm migration in non-interactive mode, " +
+				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+		}
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm migration: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	if opts.syncVolumes {
+		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			return syncServiceVolumes(ctx, source, target, svc, volumes, opts.toMachines[0], bwLimit)
+		}, uncli.ProgressOut(), "Syncing volumes of service "+svc.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	// restoreSource starts the source containers stopped for syncing the volumes if the migration fails.
+	restoreSource := func(err error) error {
+		if !opts.syncVolumes {
+			return err
+		}
+		if startErr := startServiceContainers(ctx, source, svc); startErr != nil {
+			return errors.Join(err, fmt.Errorf("start source containers: %w", startErr))
+		}
+		return err
+	}
+
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		if _, err := target.NewDeployment(spec, nil).Run(ctx); err != nil {
+			return fmt.Errorf("deploy service '%s' in cluster '%s': %w", svc.Name, opts.toContext, err)
+		}
+		return nil
+	}, uncli.ProgressOut(), fmt.Sprintf("Deploying service %s in cluster %s", svc.Name, opts.toContext))
+	if err != nil {
+		return restoreSource(err)
+	}
+
+	if len(managed) > 0 {
+		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			return flipServiceRecords(ctx, source, target, domain, managed, opts.dnsTTL)
+		}, uncli.ProgressOut(), "Pointing service hostnames to cluster "+opts.toContext)
+		if err != nil {
+			return restoreSource(err)
+		}
+	}
+	fmt.Println()
+	if len(manual) > 0 {
+		fmt.Printf("Point the following hostnames to the ingress machines of cluster '%s' in your DNS provider: "+
+			"%s\n", opts.toContext, strings.Join(manual, ", "))
+	}
+
+	finishCmd := fmt.Sprintf("uc service rm %s -c %s", svc.Name, sourceContextName(uncli, opts.context))
+	fmt.Printf("Watching service '%s' in cluster '%s' for %s...\n", svc.Name, opts.toContext, opts.soak)
+	if err = soakService(ctx, target, svc.Name, opts.soak); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("soak period interrupted, service '%s' is kept in the source cluster. "+
+				"Run '%s' to finish the migration", svc.Name, finishCmd)
+		}
+		err = fmt.Errorf("%w. Service '%s' is kept in the source cluster", err, svc.Name)
+		if len(managed) > 0 {
+			if restoreErr := restoreServiceRecords(ctx, source, domain, managed); restoreErr != nil {
+				err = errors.Join(err, restoreErr)
+			}
+		}
+		return restoreSource(err)
+	}
+
+	if opts.keepSource {
+		fmt.Printf("Service '%s' has been migrated to cluster '%s'. Run '%s' to remove it from the source cluster.\n",
+			svc.Name, opts.toContext, finishCmd)
+		return nil
+	}
+	if err = decommissionService(ctx, uncli, source, svc.Name); err != nil {
+		return err
+	}
+	fmt.Printf("Service '%s' has been migrated to cluster '%s'.\n", svc.Name, opts.toContext)
+	return nil
+}
+
+// splitHostnames splits the ingress hostnames of the service ports into the names relative to the managed cluster
+// domain which DNS records can be updated automatically, and the other hostnames.
+func splitHostnames(ports []api.PortSpec, domain string) (managed, manual []string) {
+	seen := make(map[string]struct{})
+	for _, p := range ports {
+		if p.Mode != api.PortModeIngress || p.Hostname == "" {
+			continue
+		}
+		if _, ok := seen[p.Hostname]; ok {
+			continue
+		}
+		seen[p.Hostname] = struct{}{}
+
+		if domain != "" && strings.HasSuffix(p.Hostname, "."+domain) {
+			managed = append(managed, strings.TrimSuffix(p.Hostname, "."+domain))
+		} else {
+			manual = append(manual, p.Hostname)
+		}
+	}
+	return managed, manual
+}
+
+// syncServiceVolumes stops the containers of the service in the source cluster and copies its volumes to the target
+// machine in the target cluster at no more than bwLimit bytes per second. The source containers are started again
+// if the copy fails.
+func syncServiceVolumes(
+	ctx context.Context,
+	source, target *client.Client,
+	svc api.Service,
+	volumes []api.VolumeSpec,
+	targetMachine string,
+	bwLimit int64,
+) (err error) {
+	for _, ctr := range svc.Containers {
+		if err = source.StopContainer(ctx, svc.ID, ctr.Container.ID, container.StopOptions{}); err != nil {
+			err = fmt.Errorf("stop container '%s': %w", ctr.Container.Name, err)
+			break
+		}
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if startErr := startServiceContainers(ctx, source, svc); startErr != nil {
+			err = errors.Join(err, startErr)
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		name := v.DockerVolumeName()
+		machineVolumes, err := source.ListVolumes(ctx, &api.VolumeFilter{Names: []string{name}})
+		if err != nil {
+			return fmt.Errorf("list volumes: %w", err)
+		}
+		if len(machineVolumes) != 1 {
+			return fmt.Errorf("volume '%s' must exist on exactly one machine to be synced, found on %d",
+				name, len(machineVolumes))
+		}
+		if _, err = source.CopyVolume(ctx, name, machineVolumes[0].MachineID, targetMachine,
+			client.CopyVolumeOptions{TargetCluster: target, BandwidthLimit: bwLimit}); err != nil {
+			return fmt.Errorf("copy volume '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// startServiceContainers starts the containers of the service stopped for syncing the volumes.
+func startServiceContainers(ctx context.Context, c api.ContainerClient, svc api.Service) error {
+	var errs []error
+	for _, ctr := range svc.Containers {
+		if err := c.StartContainer(ctx, svc.ID, ctr.Container.ID); err != nil {
+			errs = append(errs, fmt.Errorf("start container '%s': %w", ctr.Container.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// flipServiceRecords points the names within the managed cluster domain of the source cluster to the ingress
+// machines of the target cluster. The wildcard record still points to the source cluster.
+func flipServiceRecords(
+	ctx context.Context, source, target *client.Client, domain string, names []string, ttl time.Duration,
+) error {
+	sourceIPs, err := source.IngressIPs(ctx, client.CaddyServiceName)
+	if err != nil {
+		return fmt.Errorf("get ingress IPs of the source cluster: %w", err)
+	}
+	targetIPs, err := target.IngressIPs(ctx, client.CaddyServiceName)
+	if err != nil {
+		return fmt.Errorf("get ingress IPs of the target cluster: %w", err)
+	}
+
+	req := flipRecordsRequest(sourceIPs, targetIPs, names, ttl)
+	if _, err = source.CreateDomainRecords(ctx, req); err != nil {
+		return fmt.Errorf("update records of domain '%s' in Uncloud DNS: %w", domain, err)
+	}
+	return nil
+}
+
+// flipRecordsRequest returns the request to point the wildcard record of the cluster domain to the source ingress IPs
+// and the names to the target ingress IPs. The cluster keeps the records for the names when it replaces the records
+// of its domain later.
+func flipRecordsRequest(sourceIPs, targetIPs, names []string, ttl time.Duration) *pb.CreateDomainRecordsRequest {
+	ttlSeconds := uint32(ttl / time.Second)
+	records := []*pb.DNSRecord{{Name: "*", Type: pb.DNSRecord_A, Values: sourceIPs, Ttl: ttlSeconds}}
+	for _, name := range names {
+		records = append(records, &pb.DNSRecord{Name: name, Type: pb.DNSRecord_A, Values: targetIPs, Ttl: ttlSeconds})
+	}
+	return &pb.CreateDomainRecordsRequest{Records: records}
+}
+
+// restoreServiceRecords points the names within the managed cluster domain of the source cluster back to the ingress
+// machines of the source cluster by removing the records kept for them.
+func restoreServiceRecords(ctx context.Context, source *client.Client, domain string, names []string) error {
+	sourceIPs, err := source.IngressIPs(ctx, client.CaddyServiceName)
+	if err != nil {
+		return fmt.Errorf("get ingress IPs of the source cluster to point %s back to it: %w",
+			strings.Join(names, ", "), err)
+	}
+
+	req := &pb.CreateDomainRecordsRequest{
+		Records:     []*pb.DNSRecord{{Name: "*", Type: pb.DNSRecord_A, Values: sourceIPs}},
+		RemoveNames: names,
+	}
+	if _, err = source.CreateDomainRecords(ctx, req); err != nil {
+		return fmt.Errorf("point %s of domain '%s' back to the source cluster: %w",
+			strings.Join(names, ", "), domain, err)
+	}
+	return nil
+}
+
+// soakService checks the health of the service containers periodically for the soak period. It returns an error
+// as soon as any of the containers is unhealthy.
+func soakService(ctx context.Context, c api.ServiceClient, serviceName string, soak time.Duration) error {
+	deadline := time.NewTimer(soak)
+	defer deadline.Stop()
+	ticker := time.NewTicker(migrateHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		svc, err := c.InspectService(ctx, serviceName)
+		if err != nil {
+			return fmt.Errorf("inspect service '%s' in the target cluster: %w", serviceName, err)
+		}
+		if len(svc.Containers) == 0 {
+			return fmt.Errorf("service '%s' has no containers in the target cluster", serviceName)
+		}
+		for _, ctr := range svc.Containers {
+			if !ctr.Container.Healthy() {
+				return fmt.Errorf("container '%s' of service '%s' is unhealthy in the target cluster",
+					ctr.Container.Name, serviceName)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// decommissionService removes the service from the source cluster or moves it to the trash if the trash is enabled.
+func decommissionService(ctx context.Context, uncli *cli.CLI, c *client.Client, serviceName string) error {
+	settings, err := c.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get source cluster settings: %w", err)
+	}
+
+	if settings.TrashRetention > 0 {
+		return progress.RunWithTitle(ctx, func(ctx context.Context) error {
+			if _, err := c.TrashService(ctx, serviceName, settings.TrashRetention); err != nil {
+				return fmt.Errorf("move service '%s' to trash in the source cluster: %w", serviceName, err)
+			}
+			return nil
+		}, uncli.ProgressOut(), "Moving service "+serviceName+" to trash in the source cluster")
+	}
+	return progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		if err := c.RemoveService(ctx, serviceName); err != nil {
+			return fmt.Errorf("remove service '%s' from the source cluster: %w", serviceName, err)
+		}
+		return nil
+	}, uncli.ProgressOut(), "Removing service "+serviceName+" from the source cluster")
+}
+
+// sourceContextName returns the name of the source context to suggest commands to run against it.
+func sourceContextName(uncli *cli.CLI, contextName string) string {
+	if contextName == "" && uncli.Config != nil {
+		return uncli.Config.CurrentContext
+	}
+	return contextName
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitHostnames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		ports       []api.PortSpec
+		domain      string
+		wantManaged []string
+		wantManual  []string
+	}{
+		{
+			name: "no ports",
+		},
+		{
+			name: "managed and manual hostnames",
+			ports: []api.PortSpec{
+				{Hostname: "web.abc.cluster.uncloud.run", ContainerPort: 80, Mode: api.PortModeIngress},
+				{Hostname: "example.com", ContainerPort: 80, Mode: api.PortModeIngress},
+			},
+			domain:      "abc.cluster.uncloud.run",
+			wantManaged: []string{"web"},
+			wantManual:  []string{"example.com"},
+		},
+		{
+			name: "duplicate hostnames",
+			ports: []api.PortSpec{
+				{Hostname: "web.abc.cluster.uncloud.run", ContainerPort: 80, Mode: api.PortModeIngress},
+				{Hostname: "web.abc.cluster.uncloud.run", ContainerPort: 8080, Mode: api.PortModeIngress},
+			},
+			domain:      "abc.cluster.uncloud.run",
+			wantManaged: []string{"web"},
+		},
+		{
+			name: "no domain",
+			ports: []api.PortSpec{
+				{Hostname: "web.abc.cluster.uncloud.run", ContainerPort: 80, Mode: api.PortModeIngress},
+			},
+			wantManual: []string{"web.abc.cluster.uncloud.run"},
+		},
+		{
+			name: "domain itself and similar suffix aren't managed",
+			ports: []api.PortSpec{
+				{Hostname: "abc.cluster.uncloud.run", ContainerPort: 80, Mode: api.PortModeIngress},
+				{Hostname: "xabc.cluster.uncloud.run", ContainerPort: 80, Mode: api.PortModeIngress},
+			},
+			domain:     "abc.cluster.uncloud.run",
+			wantManual: []string{"abc.cluster.uncloud.run", "xabc.cluster.uncloud.run"},
+		},
+		{
+			name: "host ports and ports without hostname are ignored",
+			ports: []api.PortSpec{
+				{PublishedPort: 8080, ContainerPort: 80, Mode: api.PortModeHost},
+				{ContainerPort: 80, Mode: api.PortModeIngress},
+			},
+			domain: "abc.cluster.uncloud.run",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			managed, manual := splitHostnames(tt.ports, tt.domain)
+			assert.Equal(t, tt.wantManaged, managed)
+			assert.Equal(t, tt.wantManual, manual)
+		})
+	}
+}
+
+func TestFlipRecordsRequest(t *testing.T) {
+	t.Parallel()
+
+	req := flipRecordsRequest(
+		[]string{"1.1.1.1", "2.2.2.2"}, []string{"3.3.3.3"}, []string{"web", "api"}, 90*time.Second,
+	)
+
+	assert.Equal(t, []*pb.DNSRecord{
+		{Name: "*", Type: pb.DNSRecord_A, Values: []string{"1.1.1.1", "2.2.2.2"}, Ttl: 90},
+		{Name: "web", Type: pb.DNSRecord_A, Values: []string{"3.3.3.3"}, Ttl: 90},
+		{Name: "api", Type: pb.DNSRecord_A, Values: []string{"3.3.3.3"}, Ttl: 90},
+	}, req.Records)
+	assert.Empty(t, req.RemoveNames)
+}
+
+func TestSoakService(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	spec := api.ServiceSpec{
+		Name:      "web",
+		Container: api.ContainerSpec{Image: "nginx"},
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		t.Parallel()
+
+		cli := clienttest.New()
+		cli.AddMachine("m1")
+		_, err := cli.AddService(spec, "m1")
+		require.NoError(t, err)
+
+		assert.NoError(t, soakService(ctx, cli, "web", 10*time.Millisecond))
+	})
+
+	t.Run("service not found", func(t *testing.T) {
+		t.Parallel()
+
+		cli := clienttest.New()
+		err := soakService(ctx, cli, "web", time.Minute)
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+
+	t.Run("inspect fails", func(t *testing.T) {
+		t.Parallel()
+
+		cli := clienttest.New()
+		cli.FailOn("InspectService", errors.New("connection lost"))
+		err := soakService(ctx, cli, "web", time.Minute)
+		assert.ErrorContains(t, err, "connection lost")
+	})
+
+	t.Run("container stopped", func(t *testing.T) {
+		t.Parallel()
+
+		cli := clienttest.New()
+		cli.AddMachine("m1")
+		svc, err := cli.AddService(spec, "m1")
+		require.NoError(t, err)
+		ctr := svc.Containers[0].Container
+		require.NoError(t, cli.StopContainer(ctx, svc.ID, ctr.ID, container.StopOptions{}))
+
+		err = soakService(ctx, cli, "web", time.Minute)
+		assert.ErrorContains(t, err, "container '"+ctr.Name+"' of service 'web' is unhealthy")
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		cli := clienttest.New()
+		cli.AddMachine("m1")
+		_, err := cli.AddService(spec, "m1")
+		require.NoError(t, err)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		err = soakService(cancelCtx, cli, "web", time.Minute)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestStartServiceContainers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cli := clienttest.New()
+	cli.AddMachine("m1")
+	cli.AddMachine("m2")
+	svc, err := cli.AddService(api.ServiceSpec{
+		Name:      "db",
+		Container: api.ContainerSpec{Image: "postgres"},
+	}, "m1", "m2")
+	require.NoError(t, err)
+	for _, ctr := range svc.Containers {
+		require.NoError(t, cli.StopContainer(ctx, svc.ID, ctr.Container.ID, container.StopOptions{}))
+	}
+
+	require.NoError(t, startServiceContainers(ctx, cli, svc))
+
+	svc, err = cli.InspectService(ctx, "db")
+	require.NoError(t, err)
+	for _, ctr := range svc.Containers {
+		assert.True(t, ctr.Container.State.Running)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+type restartOptions struct {
+	service        string
+	machines       []string
+	overrideFreeze string
+	context        string
+}
+
+func NewRestartCommand() *cobra.Command {
+	opts := restartOptions{}
+	cmd := &cobra.Command{
+		Use:   "restart SERVICE",
+		Short: "Restart the containers of a service with a rolling restart.",
+		Long: `Restart the containers of a service in place without changing their configuration, e.g. to pick up
+changes in mounted files or clear wedged processes.
+
+Containers are restarted one at a time by default. Each restarted container must become healthy before the next one
+is restarted if it has a health check configured. The parallelism, delay, monitor period, and failure action
+from the update config of the service are honoured. The 'rollback' failure action stops restarting the remaining
+containers as there is nothing to roll back to.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return restart(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Restart only the containers on the specified machines. Can be specified multiple times or as "+
+			"a comma-separated list of machine names. (default is all machines)")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Restart the service even if deploys are frozen with 'uc cluster freeze'. "+
+			"The reason is recorded in the audit log.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func restart(ctx context.Context, uncli *cli.CLI, opts restartOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	machines := cli.ExpandCommaSeparatedValues(opts.machines)
+	deployment, svc, err := clusterClient.NewRestartDeployment(ctx, opts.service, machines)
+	if err != nil {
+		return err
+	}
+
+	plan, err := deployment.Plan(ctx)
+	if err != nil {
+		return fmt.Errorf("plan restart: %w", err)
+	}
+	if len(plan.Operations) == 0 {
+		fmt.Printf("Service '%s' has no containers on the specified machines to restart.\n", svc.Name)
+		return nil
+	}
+
+	if ctx, err = clusterClient.CheckDeployFreeze(ctx, "restart service "+svc.Name, opts.overrideFreeze); err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("Restarting service %s", svc.Name)
+	return progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		if _, err = deployment.Run(ctx); err != nil {
+			return fmt.Errorf("restart service: %w", err)
+		}
+		return nil
+	}, uncli.ProgressOut(), title)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type restoreOptions struct {
+	service        string
+	overrideFreeze string
+	context        string
+}
+
+func NewRestoreCommand() *cobra.Command {
+	opts := restoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore SERVICE",
+		Short: "Restore a removed service from the trash.",
+		Long: `Restore a service removed with 'uc service rm' while the trash is enabled with the '` +
+			api.SettingTrashRetention + `' cluster setting.
+The stopped containers of the service are started again. A service can be restored until its retention period
+expires and the machines remove its containers. Use 'uc service ls --trashed' to list the services in the trash.`,
+		Example: `  # Keep removed services in the trash for 3 days.
+  uc cluster settings set trash.retention 72h
+
+  # Restore the accidentally removed 'db' service.
+  uc service restore db`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return restore(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Restore the service even if deploys are frozen with 'uc cluster freeze'. "+
+			"The reason is recorded in the audit log.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+	return cmd
+}
+
+func restore(ctx context.Context, uncli *cli.CLI, opts restoreOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if _, err = client.GetTrashedService(ctx, opts.service); err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("service '%s' not found in trash", opts.service)
+		}
+		return fmt.Errorf("get trashed service: %w", err)
+	}
+	if ctx, err = client.CheckDeployFreeze(ctx, "restore service "+opts.service, opts.overrideFreeze); err != nil {
+		return err
+	}
+
+	return progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		if _, err := client.RestoreService(ctx, opts.service); err != nil {
+			return fmt.Errorf("restore service '%s': %w", opts.service, err)
+		}
+		return nil
+	}, uncli.ProgressOut(), "Restoring service "+opts.service)
+}
@@ -2,16 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
 
 type rmOptions struct {
-	services []string
-	context  string
+	services       []string
+	forceUnprotect bool
+	overrideFreeze string
+	purge          bool
+	context        string
 }
 
 func NewRmCommand() *cobra.Command {
@@ -20,13 +27,27 @@ func NewRmCommand() *cobra.Command {
 		Use:     "rm SERVICE [SERVICE...]",
 		Aliases: []string{"remove", "delete"},
 		Short:   "Remove one or more services.",
-		Args:    cobra.MinimumNArgs(1),
+		Long: `Remove one or more services.
+
+Protected services, e.g. deployed with 'x-protected: true' in the Compose file, can only be removed with
+--force-unprotect after typing the service name to confirm.
+
+If the trash is enabled with the '` + api.SettingTrashRetention + `' cluster setting, the containers of removed services are
+stopped and kept for the retention period during which the services can be restored with 'uc service restore'.
+Use --purge to remove the services immediately.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 			opts.services = args
 			return rm(cmd.Context(), uncli, opts)
 		},
 	}
+	cmd.Flags().BoolVar(&opts.forceUnprotect, "force-unprotect", false,
+		"Allow removing protected services after typing the service name to confirm.")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Remove the services even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
+	cmd.Flags().BoolVar(&opts.purge, "purge", false,
+		"Remove the services immediately instead of moving them to the trash.")
 	cmd.Flags().StringVarP(
 		&opts.context, "context", "c", "",
 		"Name of the cluster context. (default is the current context)",
@@ -41,11 +62,77 @@ func rm(ctx context.Context, uncli *cli.CLI, opts rmOptions) error {
 	}
 	defer client.Close()
 
+	for _, s := range opts.services {
+		svc, err := client.InspectService(ctx, s)
+		if err != nil {
+			return fmt.Errorf("inspect service '%s': %w", s, err)
+		}
+		if !svc.Protected() {
+			continue
+		}
+		if !opts.forceUnprotect {
+			return fmt.Errorf("service '%s' is protected from removal, use --force-unprotect to remove it", svc.Name)
+		}
+
+		fmt.Printf("Service '%s' is protected from removal.\n", svc.Name)
+		confirmed, err := cli.ConfirmTyped(svc.Name)
+		if err != nil {
+			return fmt.Errorf("confirm removal: %w", err)
+		}
+		if !confirmed {
+			return fmt.Errorf("typed name doesn't match, service '%s' was not removed", svc.Name)
+		}
+	}
+
+	operation := "remove services " + strings.Join(opts.services, ", ")
+	if ctx, err = client.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return err
+	}
+
+	var retention time.Duration
+	if !opts.purge {
+		settings, err := client.GetSettings(ctx)
+		if err != nil {
+			return fmt.Errorf("get cluster settings: %w", err)
+		}
+		retention = settings.TrashRetention
+	}
+
+	if retention > 0 {
+		var trashed []api.TrashedService
+		for _, s := range opts.services {
+			err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+				svc, err := client.TrashService(ctx, s, retention)
+				if err != nil {
+					return fmt.Errorf("move service '%s' to trash: %w", s, err)
+				}
+				trashed = append(trashed, svc)
+				return nil
+			}, uncli.ProgressOut(), "Moving service "+s+" to trash")
+			if err != nil {
+				return err
+			}
+		}
+
+		fmt.Println()
+		for _, svc := range trashed {
+			fmt.Printf("Service '%s' can be restored with 'uc service restore %s' until %s.\n",
+				svc.Name, svc.Name, svc.ExpiresAt.Local().Format(time.DateTime))
+		}
+		return nil
+	}
+
 	for _, s := range opts.services {
 		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
 			if err = client.RemoveService(ctx, s); err != nil {
 				return fmt.Errorf("remove service '%s': %w", s, err)
 			}
+			// Remove the service from the trash if it was trashed before.
+			if trashed, err := client.GetTrashedService(ctx, s); err == nil {
+				if err = client.RemoveTrashedService(ctx, trashed.ID); err != nil && !errors.Is(err, api.ErrNotFound) {
+					return fmt.Errorf("remove service '%s' from trash: %w", s, err)
+				}
+			}
 			return nil
 		}, uncli.ProgressOut(), "Removing service "+s)
 	}
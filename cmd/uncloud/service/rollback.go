@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type rollbackOptions struct {
+	service        string
+	withData       bool
+	overrideFreeze string
+	yes            bool
+	context        string
+}
+
+func NewRollbackCommand() *cobra.Command {
+	opts := rollbackOptions{}
+	cmd := &cobra.Command{
+		Use:   "rollback SERVICE",
+		Short: "Roll back a service to its state before the last deployment.",
+		Long: `Roll back a service to its state before the last deployment. The configuration of the service
+is recorded every time 'uc deploy' updates it and the service is redeployed with the recorded configuration.
+
+With --with-data, the volumes of the service are also restored from the snapshots taken before the last deployment
+with 'uc deploy --snapshot-volumes'. The service containers on the machines with snapshots are stopped while their
+volumes are restored. Any data written to the volumes after the deployment is lost.`,
+		Example: `  # Deploy with volume snapshots and roll back both the code and data if the deployment goes wrong.
+  uc deploy --snapshot-volumes
+  uc service rollback db --with-data`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return rollback(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.withData, "with-data", false,
+		"Also restore the service volumes from the snapshots taken before the last deployment.")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Roll back the service even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before restoring the volumes.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+
+	return cmd
+}
+
+func rollback(ctx context.Context, uncli *cli.CLI, opts rollbackOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	operation := "roll back service " + opts.service
+	if ctx, err = clusterClient.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return err
+	}
+
+	if opts.withData && !opts.yes {
+		fmt.Printf("This will restore the volumes of service '%s' and overwrite the data written "+
+			"since the last deployment.\n", opts.service)
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm rollback: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	var rev api.ServiceRevision
+	title := fmt.Sprintf("Rolling back service %s", opts.service)
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		rev, err = clusterClient.RollbackService(ctx, opts.service, opts.withData)
+		return err
+	}, uncli.ProgressOut(), title)
+	if err != nil {
+		return fmt.Errorf("roll back service: %w", err)
+	}
+
+	fmt.Printf("Service '%s' rolled back to its state from %s.\n",
+		opts.service, rev.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	return nil
+}
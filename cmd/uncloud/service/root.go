@@ -11,11 +11,21 @@ func NewRootCommand() *cobra.Command {
 		Short:   "Manage services in an Uncloud cluster.",
 	}
 	cmd.AddCommand(
+		NewAccessLogsCommand(),
+		NewDriftCommand(),
+		NewExportCommand(),
 		NewInspectCommand(),
 		NewListCommand(),
+		NewMetricsCommand(),
+		NewMigrateCommand(),
+		NewRestartCommand(),
+		NewRestoreCommand(),
+		NewRollbackCommand(),
 		NewRmCommand(),
 		NewRunCommand(),
+		NewSBOMCommand(),
 		NewScaleCommand(),
+		NewStatusCommand(),
 	)
 	return cmd
 }
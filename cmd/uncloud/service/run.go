@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	dockeropts "github.com/docker/cli/opts"
 	"github.com/docker/compose/v2/pkg/progress"
@@ -17,23 +19,40 @@ import (
 )
 
 type runOptions struct {
-	caddyfile         string
-	command           []string
-	cpu               dockeropts.NanoCPUs
-	entrypoint        string
-	entrypointChanged bool
-	env               []string
-	image             string
-	machines          []string
-	memory            dockeropts.MemBytes
-	mode              string
-	name              string
-	privileged        bool
-	publish           []string
-	pull              string
-	replicas          uint
-	user              string
-	volumes           []string
+	aliases                []string
+	caddyfile              string
+	capAdd                 []string
+	capDrop                []string
+	command                []string
+	cpu                    dockeropts.NanoCPUs
+	devices                []string
+	entrypoint             string
+	entrypointChanged      bool
+	env                    []string
+	hostname               string
+	image                  string
+	machines               []string
+	memory                 dockeropts.MemBytes
+	mode                   string
+	name                   string
+	networks               []string
+	overrideFreeze         string
+	privileged             bool
+	priority               string
+	protected              bool
+	publish                []string
+	pull                   string
+	readOnly               bool
+	replicas               uint
+	restart                string
+	securityOpt            []string
+	stopGracePeriod        time.Duration
+	stopGracePeriodChanged bool
+	stopSignal             string
+	sysctls                []string
+	tenant                 string
+	user                   string
+	volumes                []string
 
 	context string
 }
@@ -49,6 +68,7 @@ func NewRunCommand() *cobra.Command {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 
 			opts.entrypointChanged = cmd.Flag("entrypoint").Changed
+			opts.stopGracePeriodChanged = cmd.Flag("stop-grace-period").Changed
 			opts.image = args[0]
 			if len(args) > 1 {
 				opts.command = args[1:]
@@ -58,17 +78,30 @@ func NewRunCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringSliceVar(&opts.aliases, "alias", nil,
+		"Additional DNS name the service can be discovered by in the cluster, in addition to its name. "+
+			"Can be specified multiple times or as a comma-separated list of names.")
 	cmd.Flags().StringVar(&opts.caddyfile, "caddyfile", "",
 		"Path to a custom Caddy config (Caddyfile) for the service. "+
 			"Cannot be used together with non-@host published ports.")
+	cmd.Flags().StringSliceVar(&opts.capAdd, "cap-add", nil,
+		"Add Linux kernel capabilities to service containers. Can be specified multiple times.")
+	cmd.Flags().StringSliceVar(&opts.capDrop, "cap-drop", nil,
+		"Drop Linux kernel capabilities from service containers. Can be specified multiple times.")
 	cmd.Flags().VarP(&opts.cpu, "cpu", "",
 		"Maximum number of CPU cores a service container can use. Fractional values are allowed: "+
 			"0.5 for half a core or 2.25 for two and a quarter cores.")
+	cmd.Flags().StringArrayVar(&opts.devices, "device", nil,
+		"Expose a host device to service containers. Can be specified multiple times.\n"+
+			"Format: /host/path[:/container/path[:permissions]] where permissions is a combination of r, w, m.")
 	cmd.Flags().StringVar(&opts.entrypoint, "entrypoint", "",
 		"Overwrite the default ENTRYPOINT of the image. Pass an empty string \"\" to reset it.")
 	cmd.Flags().StringSliceVarP(&opts.env, "env", "e", nil,
 		"Set an environment variable for service containers. Can be specified multiple times.\n"+
 			"Format: VAR=value or just VAR to use the value from the local environment.")
+	cmd.Flags().StringVar(&opts.hostname, "hostname", "",
+		"Hostname of the service containers that is also resolvable by other services in the cluster. "+
+			"(default is the container name)")
 	cmd.Flags().StringVar(&opts.mode, "mode", api.ServiceModeReplicated,
 		fmt.Sprintf("Replication mode of the service: either '%s' (a specified number of containers across "+
 			"the machines) or '%s' (one container on every machine).",
@@ -82,11 +115,24 @@ func NewRunCommand() *cobra.Command {
 			"Examples: 1073741824, 1024m, 1g (all equal 1 gibibyte)")
 	cmd.Flags().StringVarP(&opts.name, "name", "n", "",
 		"Assign a name to the service. A random name is generated if not specified.")
+	cmd.Flags().StringSliceVar(&opts.networks, "network", nil,
+		"Network to attach the service containers to. Containers can only discover and reach services attached to "+
+			"the same network. Can be specified multiple times or as a comma-separated list of network names. "+
+			"Use 'host' to run containers in the host network of the machine (at most one container per machine). "+
+			"(default is the 'default' network)")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Run the service even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
 	cmd.Flags().BoolVar(&opts.privileged, "privileged", false,
 		"Give extended privileges to service containers. This is a security risk and should be used with caution.")
+	cmd.Flags().StringVar(&opts.priority, "priority", "",
+		"Priority of the service: 'low', 'normal', 'high', 'critical', or an integer. A container of a higher priority "+
+			"service preempts the containers of lower priority services on a machine without enough unreserved CPU "+
+			"or memory. (default \"normal\")")
+	cmd.Flags().BoolVar(&opts.protected, "protected", false,
+		"Protect the service from accidental removal. Removing it requires --force-unprotect and typing its name.")
 	cmd.Flags().StringSliceVarP(&opts.publish, "publish", "p", nil,
 		"Publish a service port to make it accessible outside the cluster. Can be specified multiple times.\n"+
-			"Format: [hostname:]container_port[/protocol] or [host_ip:]host_port:container_port[/protocol]@host\n"+
+			"Format: [hostname:]container_port[/protocol] or [host_ip:]host_port[-end]:container_port[-end][/protocol]@host\n"+
 			"Supported protocols: tcp, udp, http, https (default is tcp). If a hostname for http(s) port is not specified\n"+
 			"and a cluster domain is reserved, service-name.cluster-domain will be used as the hostname.\n"+
 			"Examples:\n"+
@@ -94,12 +140,34 @@ func NewRunCommand() *cobra.Command {
 			"  -p app.example.com:8080/https  Publish port 8080 as HTTPS via reverse proxy with custom hostname\n"+
 			// TODO: add support for publishing L4 tcp/udp ports.
 			//"  -p 9000:8080                   Publish port 8080 as TCP port 9000 via reverse proxy\n"+
-			"  -p 53:5353/udp@host            Bind UDP port 5353 to host port 53")
+			"  -p 53:5353/udp@host            Bind UDP port 5353 to host port 53\n"+
+			"  -p 10000-10100:10000-10100/udp@host  Bind UDP ports 10000-10100 to the same host ports")
 	cmd.Flags().StringVar(&opts.pull, "pull", api.PullPolicyMissing,
 		fmt.Sprintf("Pull image from the registry before running service containers ('%s', '%s', '%s').",
 			api.PullPolicyAlways, api.PullPolicyMissing, api.PullPolicyNever))
+	cmd.Flags().BoolVar(&opts.readOnly, "read-only", false,
+		"Mount the root filesystem of service containers as read-only.")
 	cmd.Flags().UintVar(&opts.replicas, "replicas", 1,
 		"Number of containers to run for the service. Only valid for a replicated service.")
+	cmd.Flags().StringVar(&opts.restart, "restart", "",
+		"Restart policy of service containers when they exit ('no', 'always', 'on-failure[:max-retries]', "+
+			"'unless-stopped').\n"+
+			"Crash-looping containers are restarted with an exponential backoff delay up to 1 minute. "+
+			"(default \"unless-stopped\")")
+	cmd.Flags().StringArrayVar(&opts.securityOpt, "security-opt", nil,
+		"Security options for service containers such as seccomp and AppArmor profiles. Can be specified multiple times.\n"+
+			"Examples: seccomp=unconfined, apparmor=my-profile, no-new-privileges")
+	cmd.Flags().DurationVar(&opts.stopGracePeriod, "stop-grace-period", 0,
+		"Time to wait for a service container to exit after sending the stop signal before killing it with SIGKILL,\n"+
+			"e.g. 30s or 2m. (default 10s)")
+	cmd.Flags().StringVar(&opts.stopSignal, "stop-signal", "",
+		"Signal to stop service containers, e.g. SIGINT. (default is the STOPSIGNAL of the image or SIGTERM)")
+	cmd.Flags().StringArrayVar(&opts.sysctls, "sysctl", nil,
+		"Set a namespaced kernel parameter in service containers. Can be specified multiple times.\n"+
+			"Format: name=value, e.g. net.ipv4.ip_forward=1")
+	cmd.Flags().StringVar(&opts.tenant, "tenant", "",
+		"Tenant the service belongs to. The service is isolated from the services of other tenants and counts "+
+			"towards the tenant quota. Create tenants with 'uc tenant create'.")
 	cmd.Flags().StringVarP(&opts.user, "user", "u", "",
 		"User name or UID and optionally group name or GID used for running the command inside service containers.\n"+
 			"Format: USER[:GROUP] or UID[:GID]. If not specified, the user is set to the default user of the image.")
@@ -132,6 +200,14 @@ func run(ctx context.Context, uncli *cli.CLI, opts runOptions) error {
 	}
 	defer clusterClient.Close()
 
+	operation := "run service " + spec.Name
+	if spec.Name == "" {
+		operation = "run service from image " + spec.Container.Image
+	}
+	if ctx, err = clusterClient.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return err
+	}
+
 	var resp api.RunServiceResponse
 	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
 		resp, err = clusterClient.RunService(ctx, spec)
@@ -209,13 +285,50 @@ func prepareServiceSpec(opts runOptions) (api.ServiceSpec, error) {
 		Machines: cli.ExpandCommaSeparatedValues(opts.machines),
 	}
 
+	var devices []api.DeviceMapping
+	for _, d := range opts.devices {
+		device, err := api.ParseDeviceMapping(d)
+		if err != nil {
+			return spec, err
+		}
+		devices = append(devices, device)
+	}
+
+	var sysctls map[string]string
+	for _, s := range opts.sysctls {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			return spec, fmt.Errorf("invalid sysctl '%s': expected name=value format", s)
+		}
+		if sysctls == nil {
+			sysctls = make(map[string]string)
+		}
+		sysctls[name] = value
+	}
+
+	var priority int
+	if opts.priority != "" {
+		if priority, err = api.ParsePriority(opts.priority); err != nil {
+			return spec, err
+		}
+	}
+
 	spec = api.ServiceSpec{
+		Aliases: cli.ExpandCommaSeparatedValues(opts.aliases),
 		Container: api.ContainerSpec{
-			Command:    opts.command,
-			Env:        env,
-			Image:      opts.image,
-			Privileged: opts.privileged,
-			PullPolicy: opts.pull,
+			CapAdd:      opts.capAdd,
+			CapDrop:     opts.capDrop,
+			Command:     opts.command,
+			Devices:     devices,
+			Env:         env,
+			Hostname:    opts.hostname,
+			Image:       opts.image,
+			Privileged:  opts.privileged,
+			PullPolicy:  opts.pull,
+			ReadOnly:    opts.readOnly,
+			SecurityOpt: opts.securityOpt,
+			StopSignal:  opts.stopSignal,
+			Sysctls:     sysctls,
 			Resources: api.ContainerResources{
 				CPU:    opts.cpu.Value(),
 				Memory: opts.memory.Value(),
@@ -227,16 +340,37 @@ func prepareServiceSpec(opts runOptions) (api.ServiceSpec, error) {
 		Name:      opts.name,
 		Placement: placement,
 		Ports:     ports,
+		Priority:  priority,
+		Protected: opts.protected,
 		Replicas:  opts.replicas,
+		Tenant:    opts.tenant,
 		Volumes:   volumes,
 	}
 
+	networks := cli.ExpandCommaSeparatedValues(opts.networks)
+	if slices.Equal(networks, []string{api.NetworkModeHost}) {
+		spec.NetworkMode = api.NetworkModeHost
+	} else {
+		spec.Networks = networks
+	}
+
 	if caddyfile != "" {
 		spec.Caddy = &api.CaddySpec{
 			Config: caddyfile,
 		}
 	}
 
+	if opts.restart != "" {
+		restartPolicy, err := api.ParseRestartPolicy(opts.restart)
+		if err != nil {
+			return spec, err
+		}
+		spec.Container.RestartPolicy = &restartPolicy
+	}
+	if opts.stopGracePeriodChanged {
+		spec.Container.StopGracePeriod = &opts.stopGracePeriod
+	}
+
 	// Overwrite the default ENTRYPOINT of the image or reset it if an empty string is passed.
 	if opts.entrypoint != "" {
 		spec.Container.Entrypoint = []string{opts.entrypoint}
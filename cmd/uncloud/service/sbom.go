@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/sbom"
+	"github.com/spf13/cobra"
+)
+
+type sbomOptions struct {
+	service string
+	format  string
+	output  string
+	pkg     string
+	context string
+}
+
+func NewSBOMCommand() *cobra.Command {
+	opts := sbomOptions{}
+	cmd := &cobra.Command{
+		Use:   "sbom SERVICE",
+		Short: "Show or export the software bill of materials (SBOM) of the images running in a service.",
+		Long: `Show or export the software bill of materials (SBOM) of the images running in a service.
+
+The images are collected from the running containers of the service across all machines. For each image, the SPDX
+SBOM attestation stored with the image in the registry is used if available, e.g. built with
+'docker buildx build --sbom=true'. Otherwise, the SBOM is generated with Syft (https://github.com/anchore/syft)
+that must be installed locally.
+
+Without --output, a summary of the images and the number of packages in their SBOMs is printed. With --output,
+the SBOM documents are written to the file, or to the directory with one file per image if the service runs
+multiple images.`,
+		Example: `  # Show the images running in the 'web' service and where their SBOMs come from.
+  uc service sbom web
+
+  # Check which versions of openssl are running in the 'web' service.
+  uc service sbom web --package openssl
+
+  # Export the CycloneDX SBOM of the 'web' service.
+  uc service sbom web --format cyclonedx -o web.cdx.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return serviceSBOM(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", string(sbom.FormatSPDX),
+		"SBOM format: 'spdx' or 'cyclonedx'. SBOM attestations are only available in SPDX format.")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"File or directory to write the SBOM documents to.")
+	cmd.Flags().StringVar(&opts.pkg, "package", "",
+		"Show the versions of the package with this name found in the images instead of the summary.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+// serviceImage is an image running in service containers on one or more machines.
+type serviceImage struct {
+	image    string
+	machines []string
+}
+
+func serviceSBOM(ctx context.Context, uncli *cli.CLI, opts sbomOptions) error {
+	format, err := sbom.ParseFormat(opts.format)
+	if err != nil {
+		return err
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := client.InspectService(ctx, opts.service)
+	if err != nil {
+		return fmt.Errorf("inspect service: %w", err)
+	}
+	machines, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	machinesNamesByID := make(map[string]string)
+	for _, m := range machines {
+		machinesNamesByID[m.Machine.Id] = m.Machine.Name
+	}
+
+	var images []*serviceImage
+	for _, ctr := range svc.Containers {
+		if ctr.Container.State == nil || !ctr.Container.State.Running {
+			continue
+		}
+		machine := machinesNamesByID[ctr.MachineID]
+		if machine == "" {
+			machine = ctr.MachineID
+		}
+
+		i := slices.IndexFunc(images, func(img *serviceImage) bool {
+			return img.image == ctr.Container.Config.Image
+		})
+		if i == -1 {
+			images = append(images, &serviceImage{image: ctr.Container.Config.Image})
+			i = len(images) - 1
+		}
+		if !slices.Contains(images[i].machines, machine) {
+			images[i].machines = append(images[i].machines, machine)
+		}
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("service '%s' has no running containers", svc.Name)
+	}
+
+	docs := make([]sbom.Document, 0, len(images))
+	var errs []error
+	for _, img := range images {
+		doc, err := sbom.Get(ctx, img.image, format)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("get SBOM for image '%s': %w", img.image, err))
+			continue
+		}
+		docs = append(docs, doc)
+	}
+
+	if opts.output != "" {
+		if err = writeSBOMs(docs, opts.output); err != nil {
+			return err
+		}
+	} else if opts.pkg != "" {
+		if err = printSBOMPackage(docs, opts.pkg); err != nil {
+			return err
+		}
+	} else if err = printSBOMSummary(images, docs); err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+func printSBOMSummary(images []*serviceImage, docs []sbom.Document) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "IMAGE\tMACHINES\tSOURCE\tPACKAGES")
+	for _, img := range images {
+		source, packages := "-", "-"
+		i := slices.IndexFunc(docs, func(d sbom.Document) bool { return d.Image == img.image })
+		if i != -1 {
+			pkgs, err := docs[i].Packages()
+			if err != nil {
+				return err
+			}
+			source, packages = string(docs[i].Source), fmt.Sprintf("%d", len(pkgs))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", img.image, strings.Join(img.machines, ", "), source, packages)
+	}
+	return tw.Flush()
+}
+
+func printSBOMPackage(docs []sbom.Document, name string) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "IMAGE\tPACKAGE\tVERSION")
+	for _, doc := range docs {
+		pkgs, err := doc.Packages()
+		if err != nil {
+			return err
+		}
+		for _, p := range pkgs {
+			if p.Name == name {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", doc.Image, p.Name, p.Version)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// writeSBOMs writes the SBOM document to the file at path or multiple documents to the directory at path
+// with one file per image.
+func writeSBOMs(docs []sbom.Document, path string) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	if len(docs) == 1 {
+		if err := os.WriteFile(path, docs[0].Data, 0o644); err != nil {
+			return fmt.Errorf("write SBOM: %w", err)
+		}
+		fmt.Printf("SBOM for image '%s' written to %s\n", docs[0].Image, path)
+		return nil
+	}
+
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return fmt.Errorf("create SBOM directory: %w", err)
+	}
+	for _, doc := range docs {
+		filename := unsafeFilenameChars.ReplaceAllString(doc.Image, "_") + "." + string(doc.Format) + ".json"
+		filePath := filepath.Join(path, filename)
+		if err := os.WriteFile(filePath, doc.Data, 0o644); err != nil {
+			return fmt.Errorf("write SBOM: %w", err)
+		}
+		fmt.Printf("SBOM for image '%s' written to %s\n", doc.Image, filePath)
+	}
+	return nil
+}
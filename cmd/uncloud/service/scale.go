@@ -8,14 +8,14 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/internal/cli"
-	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
 
 type scaleOptions struct {
-	service  string
-	replicas uint
-	context  string
+	service        string
+	replicas       uint
+	overrideFreeze string
+	context        string
 }
 
 func NewScaleCommand() *cobra.Command {
@@ -39,6 +39,8 @@ func NewScaleCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Scale the service even if deploys are frozen with 'uc cluster freeze'. The reason is recorded in the audit log.")
 	cmd.Flags().StringVarP(
 		&opts.context, "context", "c", "",
 		"Name of the cluster context. (default is the current context)",
@@ -63,16 +65,10 @@ func scale(ctx context.Context, uncli *cli.CLI, opts scaleOptions) error {
 	}
 	defer clusterClient.Close()
 
-	svc, err := clusterClient.InspectService(ctx, opts.service)
+	deployment, svc, err := clusterClient.NewScaleDeployment(ctx, opts.service, opts.replicas)
 	if err != nil {
-		return fmt.Errorf("inspect service '%s': %w", opts.service, err)
-	}
-
-	if svc.Mode != api.ServiceModeReplicated {
-		return fmt.Errorf("scaling is only supported for services in %s mode, service '%s' is in %s mode",
-			api.ServiceModeReplicated, svc.Name, svc.Mode)
+		return err
 	}
-
 	currentReplicas := uint(len(svc.Containers))
 
 	if currentReplicas == opts.replicas {
@@ -80,11 +76,6 @@ func scale(ctx context.Context, uncli *cli.CLI, opts scaleOptions) error {
 		return nil
 	}
 
-	// TODO: Check if all containers have the same spec. If not, prompt user to choose which one to scale.
-	//  This can happen if a service deployment failed midway and some containers were not updated.
-	spec := svc.Containers[0].Container.ServiceSpec
-	spec.Replicas = opts.replicas
-	deployment := clusterClient.NewDeployment(spec, nil)
 	plan, err := deployment.Plan(ctx)
 	if err != nil {
 		return fmt.Errorf("plan deployment: %w", err)
@@ -95,6 +86,11 @@ func scale(ctx context.Context, uncli *cli.CLI, opts scaleOptions) error {
 		return nil
 	}
 
+	operation := fmt.Sprintf("scale service %s to %d replicas", svc.Name, opts.replicas)
+	if ctx, err = clusterClient.CheckDeployFreeze(ctx, operation, opts.overrideFreeze); err != nil {
+		return err
+	}
+
 	if opts.replicas < currentReplicas {
 		// Initialise a machine and container name resolver to properly format the plan output.
 		resolver, err := clusterClient.ServiceOperationNameResolver(ctx, svc)
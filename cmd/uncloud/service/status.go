@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type statusOptions struct {
+	service string
+	period  time.Duration
+	context string
+}
+
+func NewStatusCommand() *cobra.Command {
+	opts := statusOptions{}
+	cmd := &cobra.Command{
+		Use:   "status SERVICE",
+		Short: "Display the status and availability of a service.",
+		Long: "Display the number of running containers of a service and the availability and latency of the HTTPS\n" +
+			"endpoints published by the service. The endpoints are periodically probed by up to two cluster machines.",
+		Example: `  # Show the status of the 'web' service with the availability over the last 24 hours.
+  uc service status web
+
+  # Show the availability over the last 7 days.
+  uc service status web --period 168h`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+			return status(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.period, "period", 24*time.Hour,
+		"Period of time until now to calculate the availability over. Uptime checks are kept for 7 days.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func status(ctx context.Context, uncli *cli.CLI, opts statusOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := client.InspectService(ctx, opts.service)
+	if err != nil {
+		return fmt.Errorf("inspect service: %w", err)
+	}
+	running := 0
+	for _, c := range svc.Containers {
+		if c.Container.State.Running {
+			running++
+		}
+	}
+	fmt.Printf("Service:     %s\n", svc.Name)
+	fmt.Printf("Mode:        %s\n", svc.Mode)
+	fmt.Printf("Containers:  %d/%d running\n", running, len(svc.Containers))
+
+	checks, err := client.ServiceUptimeChecks(ctx, svc.Name, time.Now().Add(-opts.period))
+	if err != nil {
+		return fmt.Errorf("list uptime checks: %w", err)
+	}
+	if len(checks) == 0 {
+		fmt.Printf("\nNo uptime checks in the last %s. Only HTTPS endpoints with a hostname are checked.\n",
+			opts.period)
+		return nil
+	}
+
+	// Group the checks by URL preserving the order of the first check.
+	var urls []string
+	checksByURL := make(map[string][]api.UptimeCheck)
+	for _, c := range checks {
+		if _, ok := checksByURL[c.URL]; !ok {
+			urls = append(urls, c.URL)
+		}
+		checksByURL[c.URL] = append(checksByURL[c.URL], c)
+	}
+
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if _, err = fmt.Fprintln(tw, "ENDPOINT\tSTATUS\tLAST CHECK\tUPTIME\tAVG LATENCY\tCHECKS"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, url := range urls {
+		urlChecks := checksByURL[url]
+		up := 0
+		var latency time.Duration
+		for _, c := range urlChecks {
+			if c.Up() {
+				up++
+				latency += c.Latency
+			}
+		}
+		avgLatency := "-"
+		if up > 0 {
+			avgLatency = (latency / time.Duration(up)).Round(time.Millisecond).String()
+		}
+
+		last := urlChecks[len(urlChecks)-1]
+		state := "UP"
+		if !last.Up() {
+			state = "DOWN"
+			if last.StatusCode > 0 {
+				state = fmt.Sprintf("DOWN (%d)", last.StatusCode)
+			}
+		}
+
+		_, err = fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f%%\t%s\t%d\n",
+			url,
+			state,
+			units.HumanDuration(time.Since(last.CheckedAt))+" ago",
+			float64(up)/float64(len(urlChecks))*100,
+			avgLatency,
+			len(urlChecks),
+		)
+		if err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, url := range urls {
+		urlChecks := checksByURL[url]
+		if last := urlChecks[len(urlChecks)-1]; last.Error != "" {
+			fmt.Printf("\nLast check of %s failed: %s\n", url, last.Error)
+		}
+	}
+	return nil
+}
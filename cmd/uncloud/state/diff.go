@@ -0,0 +1,163 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	composecli "github.com/compose-spec/compose-go/v2/cli"
+	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/spf13/cobra"
+)
+
+// errDrift is returned when the cluster state differs from the cluster spec to exit with a non-zero code.
+var errDrift = errors.New("cluster state differs from the spec")
+
+type diffOptions struct {
+	files    []string
+	profiles []string
+	context  string
+}
+
+func NewDiffCommand() *cobra.Command {
+	opts := diffOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the differences between a cluster spec file and the cluster state.",
+		Long: "Show the changes that 'uc apply' would make to converge the cluster to the cluster spec file without\n" +
+			"applying them. The command exits with a non-zero code if the cluster state differs from the spec,\n" +
+			"so it can be used to detect drift, e.g. in CI/CD pipelines.",
+		Example: `  # Compare the cluster with the spec in cluster.yaml in the current directory.
+  uc state diff
+
+  # Compare the 'prod' cluster with the spec split into several files.
+  uc state diff -c prod -f cluster.yaml -f services.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return diff(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context to compare the spec with. Overrides the context declared in the spec.\n"+
+			"(default is the spec context or the current context)")
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", []string{"cluster.yaml"},
+		"One or more cluster spec files to compare.")
+	cmd.Flags().StringSliceVarP(&opts.profiles, "profile", "p", nil,
+		"One or more Compose profiles to enable.")
+
+	return cmd
+}
+
+func diff(ctx context.Context, uncli *cli.CLI, opts diffOptions) error {
+	var projectOpts []composecli.ProjectOptionsFn
+	if len(opts.profiles) > 0 {
+		projectOpts = append(projectOpts, composecli.WithDefaultProfiles(opts.profiles...))
+	}
+	project, err := compose.LoadProject(ctx, opts.files, projectOpts...)
+	if err != nil {
+		return fmt.Errorf("load cluster spec file(s): %w", err)
+	}
+	spec, err := cli.ClusterSpecFromProject(project)
+	if err != nil {
+		return err
+	}
+
+	contextName, exists := uncli.SpecContext(opts.context, spec)
+	if !exists {
+		plan, err := cli.PlanMachines(spec, nil)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Cluster context '%s' doesn't exist. Applying the spec will initialise a new cluster.\n",
+			contextName)
+		fmt.Println("Machines:")
+		cli.PrintMachinesPlan(uncli.Output.Writer(), plan)
+		if len(project.Services) > 0 {
+			fmt.Println("Services:")
+			for _, name := range project.ServiceNames() {
+				fmt.Printf("- Deploy service [name=%s]\n", name)
+			}
+		}
+		return errDrift
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	machines, err := clusterClient.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	machinesPlan, err := cli.PlanMachines(spec, machines)
+	if err != nil {
+		return err
+	}
+
+	var servicesPlan deploy.SequenceOperation
+	if spec.CaddyEnabled() {
+		d, err := caddy.NewClusterDeployment(ctx, clusterClient)
+		if err != nil {
+			return err
+		}
+		plan, err := d.Plan(ctx)
+		if err != nil {
+			return fmt.Errorf("plan caddy deployment: %w", err)
+		}
+		if len(plan.Operations) > 0 {
+			servicesPlan.Operations = append(servicesPlan.Operations, &plan)
+		}
+	}
+	if len(project.Services) > 0 {
+		composeDeploy, err := compose.NewDeployment(ctx, clusterClient, project)
+		if err != nil {
+			return fmt.Errorf("create compose deployment: %w", err)
+		}
+		plan, err := composeDeploy.Plan(ctx)
+		if err != nil {
+			return fmt.Errorf("plan deployment: %w", err)
+		}
+		servicesPlan.Operations = append(servicesPlan.Operations, plan.Operations...)
+	}
+
+	// Report the cluster machines and services that aren't declared in the spec. They're not considered drift
+	// as applying the spec leaves them untouched.
+	for _, m := range machinesPlan.Undeclared {
+		fmt.Printf("Machine '%s' is not declared in the cluster spec.\n", m.Machine.Name)
+	}
+	services, err := clusterClient.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+	for _, svc := range services {
+		if svc.Name != client.CaddyServiceName && !slices.Contains(project.ServiceNames(), svc.Name) {
+			fmt.Printf("Service '%s' is not declared in the cluster spec.\n", svc.Name)
+		}
+	}
+
+	if machinesPlan.Empty() && len(servicesPlan.Operations) == 0 {
+		fmt.Println("Cluster state matches the spec.")
+		return nil
+	}
+
+	if !machinesPlan.Empty() {
+		fmt.Println("Machines:")
+		cli.PrintMachinesPlan(uncli.Output.Writer(), machinesPlan)
+	}
+	if len(servicesPlan.Operations) > 0 {
+		fmt.Println("Services:")
+		if err = cli.PrintDeploymentPlan(ctx, uncli.Output.Writer(), clusterClient, servicesPlan); err != nil {
+			return fmt.Errorf("print deployment plan: %w", err)
+		}
+	}
+	return errDrift
+}
@@ -0,0 +1,129 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	output  string
+	context string
+}
+
+func NewExportCommand() *cobra.Command {
+	opts := exportOptions{}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the cluster machines and services as a cluster spec file.",
+		Long: "Export the cluster machines and services as a cluster spec file that can be applied with 'uc apply'.\n" +
+			"The SSH destinations of machines are taken from the context connections in the Uncloud config if they\n" +
+			"match the machine addresses. The Caddy service is declared with the 'caddy' option, not as a service.",
+		Example: `  # Print the cluster spec of the current cluster.
+  uc state export
+
+  # Save the cluster spec of the 'prod' cluster to a file.
+  uc state export -c prod -o cluster.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return export(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"File to write the cluster spec to. (default is stdout)")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+
+	return cmd
+}
+
+func export(ctx context.Context, uncli *cli.CLI, opts exportOptions) error {
+	// Don't show the connection progress to not mix it with the exported spec printed to stdout.
+	clusterClient, err := uncli.ConnectClusterWithOptions(ctx, opts.context, cli.ConnectOptions{})
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	var spec cli.ClusterSpec
+	var conns []config.MachineConnection
+	if uncli.Config != nil {
+		spec.Context = opts.context
+		if spec.Context == "" {
+			spec.Context = uncli.Config.CurrentContext
+		}
+		if c, ok := uncli.Config.Contexts[spec.Context]; ok {
+			conns = c.Connections
+		}
+	}
+
+	machines, err := clusterClient.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	slices.SortFunc(machines, func(a, b *pb.MachineMember) int {
+		return strings.Compare(a.Machine.Name, b.Machine.Name)
+	})
+	for _, m := range machines {
+		spec.Machines = append(spec.Machines, cli.MachineSpecFromMember(m, conns))
+	}
+
+	dns := true
+	if _, err = clusterClient.GetDomain(ctx); err != nil {
+		if !errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("get cluster domain: %w", err)
+		}
+		dns = false
+	}
+	spec.DNS = &dns
+
+	services, err := clusterClient.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+	caddy := false
+	var specs []api.ServiceSpec
+	for _, svc := range services {
+		if svc.Name == client.CaddyServiceName {
+			caddy = true
+			continue
+		}
+		if svcSpec, ok := svc.Spec(); ok {
+			specs = append(specs, svcSpec)
+		}
+	}
+	spec.Caddy = &caddy
+
+	project, err := compose.ProjectFromServiceSpecs(specs)
+	if err != nil {
+		return fmt.Errorf("convert services to Compose format: %w", err)
+	}
+	project.Extensions = map[string]any{cli.ClusterExtensionKey: spec}
+
+	data, err := project.MarshalYAML()
+	if err != nil {
+		return fmt.Errorf("marshal cluster spec: %w", err)
+	}
+	if opts.output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err = os.WriteFile(opts.output, data, 0o644); err != nil {
+		return fmt.Errorf("write cluster spec to file: %w", err)
+	}
+	fmt.Printf("Cluster spec saved to %s\n", opts.output)
+	return nil
+}
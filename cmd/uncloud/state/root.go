@@ -0,0 +1,17 @@
+package state
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Export the cluster state or compare it with a cluster spec file.",
+	}
+	cmd.AddCommand(
+		NewDiffCommand(),
+		NewExportCommand(),
+	)
+	return cmd
+}
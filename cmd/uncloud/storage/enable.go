@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type enableOptions struct {
+	image          string
+	machines       []string
+	overrideFreeze string
+	yes            bool
+	context        string
+}
+
+func NewEnableCommand() *cobra.Command {
+	opts := enableOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Deploy or upgrade the object storage in the cluster.",
+		Long: `Deploy or upgrade the S3-compatible object storage in the cluster.
+
+A MinIO container is run on each selected machine with the data stored in the 'uncloud-storage-data' volume.
+The data is erasure-coded across the machines if more than one is selected. With three or more machines,
+the storage remains readable and writable if one of them is down. With two machines, it remains readable
+but rejects writes while either of them is down as the write quorum requires both.
+Random credentials are generated when the storage is enabled for the first time. The machines can't be changed
+once the storage is enabled. Run the command again to upgrade the MinIO image.`,
+		Example: `  # Run the object storage on all machines.
+  uc storage enable
+
+  # Run the object storage replicated across three machines.
+  uc storage enable -m machine1,machine2,machine3`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return enable(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.image, "image", "",
+		"MinIO Docker image to run. (default is the current image or "+api.DefaultObjectStorageImage+")")
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Machine names or IDs to run the storage on. Can be specified multiple times or as a comma-separated "+
+			"list of machine names. (default is the current machines or all machines)")
+	cmd.Flags().StringVar(&opts.overrideFreeze, "override-freeze", "",
+		"Deploy the storage even if deploys are frozen with 'uc cluster freeze'. "+
+			"The reason is recorded in the audit log.")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before deploying the storage.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+
+	return cmd
+}
+
+func enable(ctx context.Context, uncli *cli.CLI, opts enableOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	_, err = clusterClient.GetObjectStorage(ctx)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("get object storage: %w", err)
+	}
+	enabled := err == nil
+
+	config, err := clusterClient.PrepareObjectStorage(ctx, client.ObjectStorageOptions{
+		Image:    opts.image,
+		Machines: cli.ExpandCommaSeparatedValues(opts.machines),
+	})
+	if err != nil {
+		return err
+	}
+	if ctx, err = clusterClient.CheckDeployFreeze(ctx, "enable object storage", opts.overrideFreeze); err != nil {
+		return err
+	}
+
+	if !opts.yes {
+		if enabled {
+			fmt.Printf("This will upgrade the object storage on %d machine(s) to image %s.\n",
+				len(config.Machines), config.Image)
+		} else {
+			fmt.Printf("This will run the object storage (%s) on %d machine(s).\n",
+				config.Image, len(config.Machines))
+		}
+		confirmed, err := cli.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm deployment: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled. No changes were made.")
+			return nil
+		}
+	}
+
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		return clusterClient.EnableObjectStorage(ctx, config)
+	}, uncli.ProgressOut(), "Deploying object storage")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("Object storage is available at %s within the cluster.\n", config.Endpoint)
+	fmt.Printf("Mount the external secret '%s' into services to access it. See 'uc storage --help'.\n",
+		api.ObjectStorageSecretName)
+	return nil
+}
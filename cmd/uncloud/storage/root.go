@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Manage the S3-compatible object storage running in the cluster.",
+		Long: `Manage the S3-compatible object storage running in the cluster.
+
+The object storage is a MinIO service that is only accessible from the cluster network. Services get its endpoint
+and credentials from the external Compose secret 'uncloud-storage' mounted as /run/secrets/uncloud-storage.
+The secret contains the AWS_ENDPOINT_URL, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_REGION variables.`,
+		Example: `  # Use the object storage in the 'app' service (compose.yaml).
+  services:
+    app:
+      image: app
+      secrets:
+        - uncloud-storage
+  secrets:
+    uncloud-storage:
+      external: true`,
+	}
+	cmd.AddCommand(
+		NewEnableCommand(),
+		NewShowCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type showOptions struct {
+	showSecret bool
+	context    string
+}
+
+func NewShowCommand() *cobra.Command {
+	opts := showOptions{}
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the object storage endpoint and credentials.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return show(cmd.Context(), uncli, opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.showSecret, "show-secret", false,
+		"Show the secret access key.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func show(ctx context.Context, uncli *cli.CLI, opts showOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	storage, err := client.GetObjectStorage(ctx)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			fmt.Println("Object storage not enabled.")
+			return nil
+		}
+		return fmt.Errorf("get object storage: %w", err)
+	}
+
+	machines, err := client.ListMachines(ctx, &api.MachineFilter{NamesOrIDs: storage.Machines})
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	machineNames := make([]string, 0, len(storage.Machines))
+	for _, id := range storage.Machines {
+		if m := machines.FindByNameOrID(id); m != nil {
+			machineNames = append(machineNames, m.Machine.Name)
+		} else {
+			machineNames = append(machineNames, id)
+		}
+	}
+
+	secretAccessKey := "********"
+	if opts.showSecret {
+		secretAccessKey = storage.SecretAccessKey
+	}
+	fmt.Printf("Endpoint:           %s\n", storage.Endpoint)
+	fmt.Printf("Image:              %s\n", storage.Image)
+	fmt.Printf("Machines:           %s\n", strings.Join(machineNames, ", "))
+	fmt.Printf("Access key ID:      %s\n", storage.AccessKeyID)
+	fmt.Printf("Secret access key:  %s\n", secretAccessKey)
+	fmt.Printf("Secret:             %s\n", api.ObjectStorageSecretName)
+	return nil
+}
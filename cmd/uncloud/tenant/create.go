@@ -0,0 +1,131 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dockeropts "github.com/docker/cli/opts"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type quotaOptions struct {
+	cpu           dockeropts.NanoCPUs
+	memory        dockeropts.MemBytes
+	maxContainers int
+	context       string
+}
+
+func NewCreateCommand() *cobra.Command {
+	opts := quotaOptions{}
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a tenant with optional resource quotas.",
+		Example: `  # Create a tenant that can run up to 10 containers using at most 4 CPU cores and 8 GiB of memory.
+  uc tenant create acme --cpu 4 --memory 8g --max-containers 10`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return create(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+	addQuotaFlags(cmd, &opts)
+	return cmd
+}
+
+func NewUpdateCommand() *cobra.Command {
+	opts := quotaOptions{}
+	cmd := &cobra.Command{
+		Use:   "update NAME",
+		Short: "Update the resource quotas of a tenant.",
+		Long: `Update the resource quotas of a tenant. Only the specified quotas are changed. Set a quota to 0 to remove it.
+Lowering a quota doesn't affect the running containers but new containers that exceed it are rejected.`,
+		Example: `  # Allow the tenant to use 8 CPU cores and remove its memory quota.
+  uc tenant update acme --cpu 8 --memory 0`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return update(cmd.Context(), uncli, cmd, args[0], opts)
+		},
+	}
+	addQuotaFlags(cmd, &opts)
+	return cmd
+}
+
+func addQuotaFlags(cmd *cobra.Command, opts *quotaOptions) {
+	cmd.Flags().Var(&opts.cpu, "cpu",
+		"Maximum total CPU cores the tenant containers can use as the sum of their CPU limits. Fractional values\n"+
+			"are allowed. The tenant containers must set a CPU limit if specified. (default is unlimited)")
+	cmd.Flags().Var(&opts.memory, "memory",
+		"Maximum total memory the tenant containers can use as the sum of their memory limits, e.g. 8g.\n"+
+			"The tenant containers must set a memory limit if specified. (default is unlimited)")
+	cmd.Flags().IntVar(&opts.maxContainers, "max-containers", 0,
+		"Maximum number of the tenant containers. (default is unlimited)")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+}
+
+func create(ctx context.Context, uncli *cli.CLI, name string, opts quotaOptions) error {
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if _, err = client.GetTenant(ctx, name); err == nil {
+		return fmt.Errorf("tenant '%s' already exists", name)
+	} else if !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("get tenant: %w", err)
+	}
+
+	tenant := api.Tenant{
+		Name: name,
+		Quota: api.TenantQuota{
+			CPU:        opts.cpu.Value(),
+			Memory:     opts.memory.Value(),
+			Containers: opts.maxContainers,
+		},
+	}
+	if err = client.SetTenant(ctx, tenant); err != nil {
+		return fmt.Errorf("create tenant: %w", err)
+	}
+	fmt.Printf("Tenant '%s' created.\n", name)
+	return nil
+}
+
+func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, name string, opts quotaOptions) error {
+	if !cmd.Flags().Changed("cpu") && !cmd.Flags().Changed("memory") && !cmd.Flags().Changed("max-containers") {
+		return errors.New("no quotas to update, specify at least one of --cpu, --memory, or --max-containers")
+	}
+
+	client, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	tenant, err := client.GetTenant(ctx, name)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("tenant '%s' not found", name)
+		}
+		return fmt.Errorf("get tenant: %w", err)
+	}
+
+	if cmd.Flags().Changed("cpu") {
+		tenant.Quota.CPU = opts.cpu.Value()
+	}
+	if cmd.Flags().Changed("memory") {
+		tenant.Quota.Memory = opts.memory.Value()
+	}
+	if cmd.Flags().Changed("max-containers") {
+		tenant.Quota.Containers = opts.maxContainers
+	}
+	if err = client.SetTenant(ctx, tenant); err != nil {
+		return fmt.Errorf("update tenant: %w", err)
+	}
+	fmt.Printf("Tenant '%s' updated.\n", name)
+	return nil
+}
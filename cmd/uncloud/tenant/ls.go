@@ -0,0 +1,97 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List the tenants with their resource usage and quotas.",
+		Long: `List the tenants with their resource usage and quotas. The usage is the number of the tenant containers
+and the sum of their CPU and memory limits.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli, contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	tenants, err := client.ListTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("list tenants: %w", err)
+	}
+	if len(tenants) == 0 {
+		fmt.Println("No tenants found. Create one with 'uc tenant create'.")
+		return nil
+	}
+	services, err := client.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+
+	usage := make(map[string]*api.TenantUsage)
+	serviceCount := make(map[string]int)
+	for _, t := range tenants {
+		usage[t.Name] = &api.TenantUsage{}
+	}
+	for _, s := range services {
+		u, ok := usage[s.Tenant()]
+		if !ok {
+			continue
+		}
+		serviceCount[s.Tenant()]++
+		for _, ctr := range s.Containers {
+			u.Add(ctr.Container.ServiceSpec.Container.Resources)
+		}
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSERVICES\tCONTAINERS\tCPU\tMEMORY\tTOKENS")
+	for _, t := range tenants {
+		u := usage[t.Name]
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%d\n",
+			t.Name,
+			serviceCount[t.Name],
+			formatUsage(fmt.Sprintf("%d", u.Containers), t.Quota.Containers > 0, fmt.Sprintf("%d", t.Quota.Containers)),
+			formatUsage(formatCPU(u.CPU), t.Quota.CPU > 0, formatCPU(t.Quota.CPU)),
+			formatUsage(units.BytesSize(float64(u.Memory)), t.Quota.Memory > 0,
+				units.BytesSize(float64(t.Quota.Memory))),
+			len(t.Tokens),
+		)
+	}
+	return tw.Flush()
+}
+
+// formatUsage formats the used amount of a resource and its quota if set.
+func formatUsage(used string, hasQuota bool, quota string) string {
+	if !hasQuota {
+		return used + " / unlimited"
+	}
+	return used + " / " + quota
+}
+
+func formatCPU(nanoCPUs int64) string {
+	return fmt.Sprintf("%.2f", float64(nanoCPUs)/api.Core)
+}
@@ -0,0 +1,47 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewRmCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "rm NAME",
+		Aliases: []string{"remove"},
+		Short:   "Remove a tenant.",
+		Long: `Remove a tenant and its API tokens. The tenant must not have any services, remove them first
+with 'uc service rm'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return remove(cmd.Context(), uncli, args[0], contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func remove(ctx context.Context, uncli *cli.CLI, name, contextName string) error {
+	client, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if err = client.RemoveTenant(ctx, name); err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("tenant '%s' not found", name)
+		}
+		return fmt.Errorf("remove tenant: %w", err)
+	}
+	fmt.Printf("Tenant '%s' removed.\n", name)
+	return nil
+}
@@ -0,0 +1,27 @@
+package tenant
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant",
+		Short: "Manage the tenants hosted on the cluster.",
+		Long: `Manage the tenants hosted on the cluster.
+
+A tenant is a customer sharing the cluster machines with other customers. Deploy the services of a tenant with
+'uc deploy --tenant' or the 'x-tenant' Compose extension. The services of a tenant only join the tenant networks so
+they can only discover the services of the same tenant via the internal DNS, and the machines drop the traffic
+between the containers of different tenants. The services of a tenant can't use the host network, host paths,
+or privileged containers, and their containers must fit within the tenant quota.`,
+	}
+	cmd.AddCommand(
+		NewCreateCommand(),
+		NewListCommand(),
+		NewRmCommand(),
+		NewTokenCommand(),
+		NewUpdateCommand(),
+	)
+	return cmd
+}
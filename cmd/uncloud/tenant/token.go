@@ -0,0 +1,180 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewTokenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage the API tokens of a tenant.",
+		Long: `Manage the API tokens of a tenant. A tenant token gives read access to the HTTP JSON API of the machines
+scoped to the services of the tenant, e.g. for a customer dashboard. It can't access the cluster machines.`,
+	}
+	cmd.AddCommand(
+		NewTokenCreateCommand(),
+		NewTokenListCommand(),
+		NewTokenRmCommand(),
+	)
+	return cmd
+}
+
+func NewTokenCreateCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:   "create TENANT",
+		Short: "Create an API token for a tenant.",
+		Long: `Create an API token for a tenant. The token is only printed once as the cluster only stores its hash.
+Use the token as the bearer token for the HTTP JSON API.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return createToken(cmd.Context(), uncli, args[0], contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func NewTokenListCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "ls TENANT",
+		Aliases: []string{"list"},
+		Short:   "List the API tokens of a tenant.",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listTokens(cmd.Context(), uncli, args[0], contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func NewTokenRmCommand() *cobra.Command {
+	var contextName string
+	cmd := &cobra.Command{
+		Use:     "rm TENANT TOKEN-ID",
+		Aliases: []string{"remove", "revoke"},
+		Short:   "Revoke an API token of a tenant.",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return removeToken(cmd.Context(), uncli, args[0], args[1], contextName)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	return cmd
+}
+
+func createToken(ctx context.Context, uncli *cli.CLI, name, contextName string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	tenant, err := getTenant(ctx, clusterClient, name)
+	if err != nil {
+		return err
+	}
+
+	id, err := secret.RandomAlphaNumeric(8)
+	if err != nil {
+		return fmt.Errorf("generate token ID: %w", err)
+	}
+	s, err := secret.New(32)
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+	token := api.TenantTokenPrefix + s.String()
+	tenant.Tokens = append(tenant.Tokens, api.TenantToken{
+		ID:        id,
+		Hash:      api.HashTenantToken(token),
+		CreatedAt: time.Now().UTC(),
+	})
+	if err = clusterClient.SetTenant(ctx, tenant); err != nil {
+		return fmt.Errorf("update tenant: %w", err)
+	}
+
+	fmt.Printf("Token '%s' created for tenant '%s'. Store it securely, it won't be shown again:\n", id, name)
+	fmt.Println(token)
+	return nil
+}
+
+func listTokens(ctx context.Context, uncli *cli.CLI, name, contextName string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	tenant, err := getTenant(ctx, clusterClient, name)
+	if err != nil {
+		return err
+	}
+	if len(tenant.Tokens) == 0 {
+		fmt.Printf("Tenant '%s' has no API tokens. Create one with 'uc tenant token create %s'.\n", name, name)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCREATED")
+	for _, t := range tenant.Tokens {
+		fmt.Fprintf(tw, "%s\t%s ago\n", t.ID, units.HumanDuration(time.Since(t.CreatedAt)))
+	}
+	return tw.Flush()
+}
+
+func removeToken(ctx context.Context, uncli *cli.CLI, name, id, contextName string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx, contextName)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	tenant, err := getTenant(ctx, clusterClient, name)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(tenant.Tokens, func(t api.TenantToken) bool {
+		return t.ID == id
+	})
+	if i == -1 {
+		return fmt.Errorf("token '%s' not found for tenant '%s'", id, name)
+	}
+	tenant.Tokens = slices.Delete(tenant.Tokens, i, i+1)
+	if err = clusterClient.SetTenant(ctx, tenant); err != nil {
+		return fmt.Errorf("update tenant: %w", err)
+	}
+	fmt.Printf("Token '%s' of tenant '%s' revoked.\n", id, name)
+	return nil
+}
+
+func getTenant(ctx context.Context, clusterClient *client.Client, name string) (api.Tenant, error) {
+	tenant, err := clusterClient.GetTenant(ctx, name)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return api.Tenant{}, fmt.Errorf("tenant '%s' not found", name)
+		}
+		return api.Tenant{}, fmt.Errorf("get tenant: %w", err)
+	}
+	return tenant, nil
+}
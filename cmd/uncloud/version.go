@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/version"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type versionOptions struct {
+	cluster bool
+	context string
+}
+
+// NewVersionCommand creates a new command to print the versions of the CLI and the cluster components.
+func NewVersionCommand() *cobra.Command {
+	opts := versionOptions{}
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version of the CLI and optionally of the cluster components.",
+		Long: `Print the version of the CLI.
+
+With --cluster, also collect the versions of the machine daemon, Docker, and Caddy from all machines in the cluster,
+report the version skews, and suggest the commands to resolve them. The CLI and all machine daemons must run
+the same major and minor version. The command fails if there is an unsupported version skew.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.cluster {
+				fmt.Println(versionOrDash(version.String()))
+				return nil
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return clusterVersion(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.cluster, "cluster", false,
+		"Collect the versions from all machines in the cluster and report the version skews.")
+	cmd.Flags().StringVarP(
+		&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)",
+	)
+
+	return cmd
+}
+
+func clusterVersion(ctx context.Context, uncli *cli.CLI, opts versionOptions) error {
+	c, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	machines, err := c.ClusterVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("CLI version: %s\n\n", versionOrDash(version.String()))
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "MACHINE\tDAEMON\tDOCKER\tCADDY")
+	for _, m := range machines {
+		if m.Err != nil {
+			fmt.Fprintf(tw, "%s\t(error: %v)\t\t\n", m.Machine.Name, m.Err)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", m.Machine.Name, versionOrDash(m.Daemon), versionOrDash(m.Docker),
+			versionOrDash(m.Caddy))
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	skews := client.CheckVersionSkew(version.String(), machines)
+	if len(skews) == 0 {
+		fmt.Println("\nNo version skew detected.")
+		return nil
+	}
+
+	unsupported := 0
+	fmt.Println()
+	for _, s := range skews {
+		if s.Unsupported {
+			unsupported++
+			client.PrintWarning("unsupported version skew: " + s.Message)
+		} else {
+			client.PrintWarning(s.Message)
+		}
+		for _, fix := range s.Fixes {
+			fmt.Fprintf(os.Stderr, "  %s\n", fix)
+		}
+	}
+	if unsupported > 0 {
+		return fmt.Errorf("%d unsupported version skew(s) detected", unsupported)
+	}
+	return nil
+}
+
+func versionOrDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
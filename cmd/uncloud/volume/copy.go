@@ -0,0 +1,128 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/bwlimit"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type copyOptions struct {
+	volume  string
+	from    string
+	to      string
+	name    string
+	bwLimit string
+	yes     bool
+	context string
+}
+
+func NewCopyCommand() *cobra.Command {
+	opts := copyOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "copy VOLUME_NAME --from MACHINE --to MACHINE",
+		Aliases: []string{"cp"},
+		Short:   "Copy a volume to another machine.",
+		Long: `Copy the data of a volume to a volume on another machine, e.g. to migrate a stateful service.
+The target volume is created if it doesn't exist. The data of an existing target volume is replaced so it must not
+be used by running containers. The source volume is kept intact.
+
+The data is transferred with native btrfs or ZFS send/receive if both volumes are btrfs subvolumes or ZFS datasets.
+Uncloud creates new volumes as subvolumes or datasets automatically on machines where the Docker volumes directory
+is on btrfs or ZFS. Otherwise, the data is transferred as a tar stream. Stop the containers writing to the source
+volume before copying it with tar to get a consistent copy.`,
+		Example: `  # Copy volume 'db-data' from machine1 to machine2.
+  uc volume copy db-data --from machine1 --to machine2
+
+  # Copy volume 'db-data' to volume 'db-data-copy' on the same machine.
+  uc volume copy db-data --from machine1 --to machine1 --name db-data-copy
+
+  # Copy volume 'db-data' over a slow uplink without saturating it.
+  uc volume copy db-data --from machine1 --to machine2 --bwlimit 5M`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.volume = args[0]
+			return copyVolume(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "",
+		"Name or ID of the machine to copy the volume from.")
+	cmd.Flags().StringVar(&opts.to, "to", "",
+		"Name or ID of the machine to copy the volume to.")
+	cmd.Flags().StringVar(&opts.name, "name", "",
+		"Name of the target volume. (default is the source volume name)")
+	cmd.Flags().StringVar(&opts.bwLimit, "bwlimit", "",
+		"Limit the bandwidth of the transfer through the client, e.g. 512K or 10M bytes per second.\n"+
+			"Units are 1024-based. (default unlimited)")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Do not prompt for confirmation before replacing the data of an existing target volume.")
+	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
+		"Name of the cluster context. (default is the current context)")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func copyVolume(ctx context.Context, uncli *cli.CLI, opts copyOptions) error {
+	bwLimit, err := bwlimit.Parse(opts.bwLimit)
+	if err != nil {
+		return err
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx, opts.context)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	targetName := opts.name
+	if targetName == "" {
+		targetName = opts.volume
+	}
+
+	if !opts.yes {
+		volumes, err := clusterClient.ListVolumes(ctx, &api.VolumeFilter{
+			Machines: []string{opts.to},
+			Names:    []string{targetName},
+		})
+		if err != nil {
+			return fmt.Errorf("list volumes: %w", err)
+		}
+		if len(volumes) > 0 {
+			fmt.Printf("Volume '%s' already exists on machine '%s' and its data will be replaced.\n",
+				targetName, opts.to)
+			confirmed, err := cli.Confirm()
+			if err != nil {
+				return fmt.Errorf("confirm copy: %w", err)
+			}
+			if !confirmed {
+				fmt.Println("Cancelled. Volume was not copied.")
+				return nil
+			}
+		}
+	}
+
+	var result client.CopyVolumeResult
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		result, err = clusterClient.CopyVolume(ctx, opts.volume, opts.from, opts.to,
+			client.CopyVolumeOptions{TargetName: targetName, BandwidthLimit: bwLimit})
+		return err
+	}, uncli.ProgressOut(), "Copying volume")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Volume '%s' copied from machine '%s' to '%s' on machine '%s' (%s transferred using %s).\n",
+		opts.volume, opts.from, targetName, opts.to, units.HumanSize(float64(result.Size)), result.Backend)
+	return nil
+}
@@ -19,6 +19,7 @@ type createOptions struct {
 	driverOpts []string
 	labels     []string
 	machine    string
+	protected  bool
 	context    string
 }
 
@@ -50,6 +51,9 @@ func NewCreateCommand() *cobra.Command {
 		"Labels to assign to the volume in the form of 'key=value' pairs. Can be specified multiple times.")
 	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
 		"Name or ID of the machine to create the volume on.")
+	cmd.Flags().BoolVar(&opts.protected, "protected", false,
+		"Protect the volume from accidental removal with the '"+api.LabelProtected+"=true' label. "+
+			"Removing it requires --force-unprotect and typing its name.")
 	cmd.Flags().StringVarP(&opts.context, "context", "c", "",
 		"Name of the cluster context. (default is the current context)")
 
@@ -82,6 +86,9 @@ func create(ctx context.Context, uncli *cli.CLI, name string, opts createOptions
 		}
 		labels[k] = v
 	}
+	if opts.protected {
+		labels[api.LabelProtected] = "true"
+	}
 
 	// List machines and filter by the specified machine name or ID.
 	// If no machine is specified, prompt the user to select one.
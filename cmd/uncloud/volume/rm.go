@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/pkg/api"
@@ -11,10 +12,11 @@ import (
 )
 
 type removeOptions struct {
-	force    bool
-	machines []string
-	yes      bool
-	context  string
+	force          bool
+	forceUnprotect bool
+	machines       []string
+	yes            bool
+	context        string
 }
 
 func NewRemoveCommand() *cobra.Command {
@@ -24,8 +26,10 @@ func NewRemoveCommand() *cobra.Command {
 		Use:     "rm VOLUME_NAME [VOLUME_NAME...]",
 		Aliases: []string{"remove", "delete"},
 		Short:   "Remove one or more volumes.",
-		Long:    "Remove one or more volumes. You cannot remove a volume that is in use by a container.",
-		Args:    cobra.MinimumNArgs(1),
+		Long: "Remove one or more volumes. You cannot remove a volume that is in use by a container.\n" +
+			"Protected volumes with the 'uncloud.protected=true' label can only be removed with --force-unprotect\n" +
+			"after typing the volume name to confirm, even with --yes.",
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 			return remove(cmd.Context(), uncli, args, opts)
@@ -34,6 +38,8 @@ func NewRemoveCommand() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false,
 		"Force the removal of one or more volumes.")
+	cmd.Flags().BoolVar(&opts.forceUnprotect, "force-unprotect", false,
+		"Allow removing protected volumes after typing the volume name to confirm.")
 	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
 		"Name or ID of the machine to remove one or more volumes from. "+
 			"Can be specified multiple times or as a comma-separated list.\n"+
@@ -75,6 +81,29 @@ func remove(ctx context.Context, uncli *cli.CLI, names []string, opts removeOpti
 		return fmt.Errorf("no volumes found matching the specified names")
 	}
 
+	// Protected volumes require a typed confirmation of each volume name even with --yes.
+	var protected []string
+	for _, v := range volumes {
+		if !v.Protected() || slices.Contains(protected, v.Volume.Name) {
+			continue
+		}
+		if !opts.forceUnprotect {
+			return fmt.Errorf("volume '%s' on machine '%s' is protected from removal, "+
+				"use --force-unprotect to remove it", v.Volume.Name, v.MachineName)
+		}
+		protected = append(protected, v.Volume.Name)
+	}
+	for _, name := range protected {
+		fmt.Printf("Volume '%s' is protected from removal.\n", name)
+		confirmed, err := cli.ConfirmTyped(name)
+		if err != nil {
+			return fmt.Errorf("confirm removal: %w", err)
+		}
+		if !confirmed {
+			return fmt.Errorf("typed name doesn't match, volume '%s' was not removed", name)
+		}
+	}
+
 	// Confirm removal if not using --yes flag.
 	if !opts.yes {
 		fmt.Println("The following volumes will be removed:")
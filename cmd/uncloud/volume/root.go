@@ -10,6 +10,7 @@ func NewRootCommand() *cobra.Command {
 		Short: "Manage volumes in an Uncloud cluster.",
 	}
 	cmd.AddCommand(
+		NewCopyCommand(),
 		NewCreateCommand(),
 		NewInspectCommand(),
 		NewListCommand(),
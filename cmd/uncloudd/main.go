@@ -2,14 +2,19 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
+	"github.com/docker/go-units"
 	"github.com/psviderski/uncloud/internal/daemon"
 	"github.com/psviderski/uncloud/internal/log"
 	"github.com/psviderski/uncloud/internal/machine"
+	"github.com/psviderski/uncloud/internal/machine/chaos"
 	"github.com/psviderski/uncloud/internal/version"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +25,13 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
-	var dataDir string
+	var (
+		dataDir          string
+		maxMessageSize   string
+		httpAPIAddr      string
+		httpAPITokenFile string
+		chaosOpts        string
+	)
 	cmd := &cobra.Command{
 		Use:           "uncloudd",
 		Short:         "Uncloud machine daemon.",
@@ -28,7 +39,41 @@ func main() {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			d, err := daemon.New(dataDir)
+			config := &machine.Config{
+				DataDir: dataDir,
+			}
+			if maxMessageSize != "" {
+				size, err := units.RAMInBytes(maxMessageSize)
+				if err != nil || size <= 0 || size > math.MaxInt32 {
+					return fmt.Errorf("invalid max gRPC message size '%s': must be between 1 and 2GiB", maxMessageSize)
+				}
+				config.GRPCMaxRecvMsgSize = int(size)
+				config.GRPCMaxSendMsgSize = int(size)
+			}
+			if httpAPIAddr != "" {
+				if httpAPITokenFile == "" {
+					return fmt.Errorf("--http-api-token-file is required when --http-api-addr is set")
+				}
+				token, err := os.ReadFile(httpAPITokenFile)
+				if err != nil {
+					return fmt.Errorf("read HTTP API token file: %w", err)
+				}
+				config.HTTPAPIAddr = httpAPIAddr
+				config.HTTPAPIToken = strings.TrimSpace(string(token))
+				if config.HTTPAPIToken == "" {
+					return fmt.Errorf("HTTP API token file '%s' is empty", httpAPITokenFile)
+				}
+			}
+
+			if cmd.Flags().Changed("chaos") {
+				chaosConfig, err := chaos.ParseConfig(chaosOpts)
+				if err != nil {
+					return err
+				}
+				config.Chaos = &chaosConfig
+			}
+
+			d, err := daemon.New(config)
 			if err != nil {
 				return err
 			}
@@ -41,6 +86,21 @@ func main() {
 	cmd.PersistentFlags().StringVarP(&dataDir, "data-dir", "d", machine.DefaultDataDir,
 		"Directory for storing persistent machine state")
 	_ = cmd.MarkFlagDirname("data-dir")
+	cmd.PersistentFlags().StringVar(&maxMessageSize, "grpc-max-message-size", "",
+		"Maximum size of a gRPC message the machine API sends or receives, e.g. 64MiB\n"+
+			"(default is the gRPC limit of 4MiB for received messages)")
+	cmd.PersistentFlags().StringVar(&httpAPIAddr, "http-api-addr", "",
+		"Address to serve the read-only HTTP JSON API with the cluster state on, e.g. 127.0.0.1:51010\n"+
+			"(default is disabled)")
+	cmd.PersistentFlags().StringVar(&httpAPITokenFile, "http-api-token-file", "",
+		"File containing the bearer token clients must provide to access the HTTP API")
+	_ = cmd.MarkFlagFilename("http-api-token-file")
+	// Chaos mode randomly injects faults into the machine to test the cluster resilience. Never enable it in production.
+	cmd.PersistentFlags().StringVar(&chaosOpts, "chaos", "",
+		"Enable the chaos mode that randomly injects faults into the machine. Optionally takes comma-separated\n"+
+			"options: interval, store-delay, peer-drop, peer-drop-duration, container-kill, seed")
+	cmd.PersistentFlags().Lookup("chaos").NoOptDefVal = "on"
+	_ = cmd.PersistentFlags().MarkHidden("chaos")
 
 	// ctx is canceled when the daemon command is interrupted.
 	ctx, cancel := context.WithCancel(context.Background())
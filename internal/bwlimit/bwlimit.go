@@ -0,0 +1,62 @@
+package bwlimit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/go-units"
+	"golang.org/x/time/rate"
+)
+
+// maxChunk is the maximum number of bytes read from the underlying reader at once so that the transfer is smooth
+// rather than bursty.
+const maxChunk = 32 * 1024
+
+// Parse parses a bandwidth limit in bytes per second with an optional 1024-based unit suffix and an optional "/s",
+// e.g. "512K", "10M", "1.5MiB", or "10MB/s". An empty string means no limit and is returned as 0.
+func Parse(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	limit, err := units.RAMInBytes(strings.TrimSuffix(s, "/s"))
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("invalid bandwidth limit '%s': expected a positive number of bytes per second "+
+			"with an optional unit, e.g. 512K or 10M", s)
+	}
+	return limit, nil
+}
+
+// NewReader returns a reader that reads from r at no more than limit bytes per second on average. r is returned
+// as is if limit is 0 or negative. Reading fails with the context error if ctx is cancelled while waiting.
+func NewReader(ctx context.Context, r io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	burst := int(min(limit, maxChunk))
+	return &reader{
+		ctx:     ctx,
+		r:       r,
+		limiter: rate.NewLimiter(rate.Limit(limit), burst),
+	}
+}
+
+type reader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := r.limiter.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
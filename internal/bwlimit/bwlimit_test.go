@@ -0,0 +1,70 @@
+package bwlimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s    string
+		want int64
+	}{
+		{"", 0},
+		{"1000", 1000},
+		{"512K", 512 * 1024},
+		{"10M", 10 * 1024 * 1024},
+		{"10MB/s", 10 * 1024 * 1024},
+		{"1.5MiB", 1536 * 1024},
+		{"1g", 1024 * 1024 * 1024},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.s)
+		require.NoError(t, err, tt.s)
+		assert.Equal(t, tt.want, got, tt.s)
+	}
+
+	for _, s := range []string{"fast", "-1M", "0", "10M/min"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("a"), 30*1024)
+
+	t.Run("limits rate", func(t *testing.T) {
+		t.Parallel()
+		// The first 10 KiB is the initial burst, the remaining 20 KiB takes at least 1 second at 20 KiB/s.
+		limit := int64(20 * 1024)
+		start := time.Now()
+		got, err := io.ReadAll(NewReader(t.Context(), bytes.NewReader(data), limit))
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+		assert.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		t.Parallel()
+		r := bytes.NewReader(data)
+		assert.Same(t, r, NewReader(t.Context(), r, 0))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := io.ReadAll(NewReader(ctx, bytes.NewReader(data), 1024))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	composetypes "github.com/compose-spec/compose-go/v2/types"
@@ -25,6 +26,8 @@ type BuildOptions struct {
 	Services []string
 	Push     bool
 	NoCache  bool
+	// Output configures how the build steps and the push progress are displayed.
+	Output OutputOptions
 }
 
 // GetServicesThatNeedBuild returns a map of services that require building
@@ -41,7 +44,7 @@ func GetServicesThatNeedBuild(project *composetypes.Project) map[string]composet
 
 // BuildServices builds the services defined in the provided map.
 func BuildServices(ctx context.Context, servicesToBuild map[string]composetypes.ServiceConfig, opts BuildOptions) error {
-	fmt.Println("Building services...")
+	opts.Output.Report(StepBuildImages, "Building services...")
 
 	// Init docker client (can be local or remote, depending on DOCKER_HOST environment variable)
 	dockerCli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
@@ -54,17 +57,17 @@ func BuildServices(ctx context.Context, servicesToBuild map[string]composetypes.
 
 	// Build the services using the local docker client and compose libraries
 	for _, service := range servicesToBuild {
-		fmt.Printf("Building service: %s\n", service.Name)
+		opts.Output.Report(StepBuildServiceImage, "Building service: %s", service.Name)
 		imageName, err := buildSingleService(ctx, dockerCli, service, opts)
 		if err != nil {
 			return fmt.Errorf("build service %s: %w", service.Name, err)
 		}
 		serviceImages[service.Name] = imageName
 	}
-	fmt.Printf("Service images are built.\n")
+	opts.Output.Report(StepBuildImages, "Service images are built.")
 
 	if opts.Push {
-		err = pushServiceImages(ctx, dockerCli, serviceImages)
+		err = pushServiceImages(ctx, dockerCli, serviceImages, opts.Output)
 	}
 
 	return err
@@ -104,8 +107,7 @@ func buildSingleService(ctx context.Context, dockerCli *dockerclient.Client, ser
 	defer buildResponse.Body.Close()
 
 	// Display the build response
-	fd, isTerminal := term.GetFdInfo(os.Stdout)
-	if err := jsonmessage.DisplayJSONMessagesStream(buildResponse.Body, os.Stdout, fd, isTerminal, nil); err != nil {
+	if err := displayJSONMessages(buildResponse.Body, opts.Output); err != nil {
 		return "", fmt.Errorf("failed to display build response for service %s: %w", service.Name, err)
 	}
 
@@ -113,7 +115,9 @@ func buildSingleService(ctx context.Context, dockerCli *dockerclient.Client, ser
 }
 
 // pushSingleServiceImage pushes a single service image.
-func pushSingleServiceImage(ctx context.Context, dockerCli *dockerclient.Client, serviceName string, imageName string) error {
+func pushSingleServiceImage(
+	ctx context.Context, dockerCli *dockerclient.Client, serviceName string, imageName string, output OutputOptions,
+) error {
 	ref, err := reference.ParseNormalizedNamed(imageName)
 	if err != nil {
 		return err
@@ -153,24 +157,33 @@ func pushSingleServiceImage(ctx context.Context, dockerCli *dockerclient.Client,
 	}
 	defer pushResponse.Close()
 
-	fmt.Printf("Pushing image %s for service %s...\n", imageName, serviceName)
+	output.Report(StepPushImage, "Pushing image %s for service %s...", imageName, serviceName)
 
-	fd, isTerminal := term.GetFdInfo(os.Stdout)
-	if err := jsonmessage.DisplayJSONMessagesStream(pushResponse, os.Stdout, fd, isTerminal, nil); err != nil {
+	if err := displayJSONMessages(pushResponse, output); err != nil {
 		return fmt.Errorf("failed to display push response for image %s: %w", imageName, err)
 	}
 
-	fmt.Printf("Image %s pushed successfully.\n", imageName)
+	output.Report(StepPushImage, "Image %s pushed successfully.", imageName)
 	return nil
 }
 
 // pushServiceImages pushes all built service images to the registry.
-func pushServiceImages(ctx context.Context, dockerCli *dockerclient.Client, serviceImages map[string]string) error {
-	fmt.Printf("Pushing images...\n")
+func pushServiceImages(
+	ctx context.Context, dockerCli *dockerclient.Client, serviceImages map[string]string, output OutputOptions,
+) error {
+	output.Report(StepPushImage, "Pushing images...")
 	for serviceName, imageName := range serviceImages {
-		if err := pushSingleServiceImage(ctx, dockerCli, serviceName, imageName); err != nil {
+		if err := pushSingleServiceImage(ctx, dockerCli, serviceName, imageName, output); err != nil {
 			return fmt.Errorf("push image for service %s: %w", serviceName, err)
 		}
 	}
 	return nil
 }
+
+// displayJSONMessages displays the stream of JSON messages from the Docker API, such as the build or push progress,
+// according to the output options. The stream is consumed even if it's not displayed to return the errors in it.
+func displayJSONMessages(in io.Reader, output OutputOptions) error {
+	out := output.Stream()
+	fd, isTerminal := term.GetFdInfo(out)
+	return jsonmessage.DisplayJSONMessagesStream(in, out, fd, isTerminal, nil)
+}
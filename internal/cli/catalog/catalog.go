@@ -0,0 +1,212 @@
+// Package catalog implements app catalogs: collections of parameterised Compose templates for common self-hosted
+// apps that can be installed to a cluster in one command.
+//
+// A catalog is a directory with an app template in each subdirectory. An app template consists of the app metadata
+// file AppFileName that describes the app and its parameters, and the Compose file ComposeFileName that references
+// the parameters as variables, e.g. ${PASSWORD} for the 'password' parameter.
+package catalog
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/psviderski/uncloud/internal/secret"
+)
+
+const (
+	// AppFileName is the name of the app metadata file in an app template directory.
+	AppFileName = "uncloud-app.yaml"
+	// ComposeFileName is the name of the Compose file in an app template directory.
+	ComposeFileName = "compose.yaml"
+
+	// generatedValueLength is the length of the random values generated for parameters.
+	generatedValueLength = 32
+)
+
+var (
+	//go:embed apps
+	builtinApps embed.FS
+
+	parameterNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
+// App is an app template in a catalog.
+type App struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description,omitempty"`
+	Version     string      `yaml:"version,omitempty"`
+	Parameters  []Parameter `yaml:"parameters,omitempty"`
+	// Dir is the directory of the app template.
+	Dir string `yaml:"-"`
+}
+
+// Parameter is a configurable value of an app template. It's available in the Compose file as the variable named
+// after the parameter, see Variable.
+type Parameter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Default is the value used if the parameter isn't set.
+	Default string `yaml:"default,omitempty"`
+	// Required parameters must be set if they don't have a default value and aren't generated.
+	Required bool `yaml:"required,omitempty"`
+	// Secret parameters hold sensitive values such as passwords.
+	Secret bool `yaml:"secret,omitempty"`
+	// Generate a random alphanumeric value for the parameter if it isn't set.
+	Generate bool `yaml:"generate,omitempty"`
+}
+
+// Variable returns the name of the Compose variable for the parameter: the upper-cased parameter name with dashes
+// replaced by underscores, e.g. ADMIN_PASSWORD for 'admin-password'.
+func (p *Parameter) Variable() string {
+	return strings.ToUpper(strings.ReplaceAll(p.Name, "-", "_"))
+}
+
+func (a *App) Validate() error {
+	if a.Name == "" {
+		return errors.New("app name must be set")
+	}
+	seen := make(map[string]bool)
+	for _, p := range a.Parameters {
+		if !parameterNameRegexp.MatchString(p.Name) {
+			return fmt.Errorf("invalid parameter name: %q", p.Name)
+		}
+		if seen[p.Variable()] {
+			return fmt.Errorf("duplicate parameter: %q", p.Name)
+		}
+		seen[p.Variable()] = true
+	}
+	return nil
+}
+
+// ComposePath returns the path to the Compose file of the app template.
+func (a *App) ComposePath() string {
+	return filepath.Join(a.Dir, ComposeFileName)
+}
+
+// Values resolves the values of the app parameters from the values set by the user. Unset parameters get their
+// default or generated values. It returns the resolved values by parameter name and the names of the parameters
+// with generated values.
+func (a *App) Values(set map[string]string) (map[string]string, []string, error) {
+	for name := range set {
+		if !slices.ContainsFunc(a.Parameters, func(p Parameter) bool { return p.Name == name }) {
+			return nil, nil, fmt.Errorf("unknown parameter '%s' for app '%s'", name, a.Name)
+		}
+	}
+
+	values := make(map[string]string, len(a.Parameters))
+	var generated []string
+	for _, p := range a.Parameters {
+		value, ok := set[p.Name]
+		switch {
+		case ok:
+		case p.Default != "":
+			value = p.Default
+		case p.Generate:
+			var err error
+			if value, err = secret.RandomAlphaNumeric(generatedValueLength); err != nil {
+				return nil, nil, fmt.Errorf("generate value for parameter '%s': %w", p.Name, err)
+			}
+			generated = append(generated, p.Name)
+		case p.Required:
+			return nil, nil, fmt.Errorf("parameter '%s' is required: %s", p.Name, p.Description)
+		}
+		values[p.Name] = value
+	}
+	return values, generated, nil
+}
+
+// Env returns the Compose variables for the resolved parameter values in the KEY=value format.
+func (a *App) Env(values map[string]string) []string {
+	env := make([]string, 0, len(a.Parameters))
+	for _, p := range a.Parameters {
+		env = append(env, p.Variable()+"="+values[p.Name])
+	}
+	return env
+}
+
+// Catalog is a local directory with app templates.
+type Catalog struct {
+	Dir string
+	// cleanup removes the temporary directory the catalog has been fetched or extracted to.
+	cleanup func() error
+}
+
+// Close removes the temporary catalog files if the catalog has been fetched from a remote source.
+func (c *Catalog) Close() error {
+	if c.cleanup == nil {
+		return nil
+	}
+	return c.cleanup()
+}
+
+// Apps returns the app templates in the catalog sorted by name.
+func (c *Catalog) Apps() ([]App, error) {
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("read catalog directory: %w", err)
+	}
+
+	var apps []App
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		app, err := c.loadApp(e.Name())
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// Not an app template directory.
+				continue
+			}
+			return nil, err
+		}
+		apps = append(apps, app)
+	}
+	slices.SortFunc(apps, func(a, b App) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return apps, nil
+}
+
+// App returns the app template with the given name.
+func (c *Catalog) App(name string) (App, error) {
+	apps, err := c.Apps()
+	if err != nil {
+		return App{}, err
+	}
+	for _, app := range apps {
+		if app.Name == name {
+			return app, nil
+		}
+	}
+	return App{}, fmt.Errorf("app '%s' not found in catalog", name)
+}
+
+func (c *Catalog) loadApp(dirName string) (App, error) {
+	var app App
+	dir := filepath.Join(c.Dir, dirName)
+
+	data, err := os.ReadFile(filepath.Join(dir, AppFileName))
+	if err != nil {
+		return app, err
+	}
+	if err = yaml.Unmarshal(data, &app); err != nil {
+		return app, fmt.Errorf("parse app file '%s': %w", filepath.Join(dirName, AppFileName), err)
+	}
+	if err = app.Validate(); err != nil {
+		return app, fmt.Errorf("invalid app '%s': %w", dirName, err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, ComposeFileName)); err != nil {
+		return app, fmt.Errorf("app '%s' is missing %s: %v", app.Name, ComposeFileName, err)
+	}
+	app.Dir = dir
+
+	return app, nil
+}
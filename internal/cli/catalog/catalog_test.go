@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	composecli "github.com/compose-spec/compose-go/v2/cli"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinCatalog(t *testing.T) {
+	t.Parallel()
+
+	c, err := Open(context.Background(), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+		assert.NoDirExists(t, c.Dir)
+	})
+
+	apps, err := c.Apps()
+	require.NoError(t, err)
+	require.NotEmpty(t, apps)
+
+	// Every built-in app template must produce a valid project with the default and generated values.
+	for _, app := range apps {
+		set := make(map[string]string)
+		for _, p := range app.Parameters {
+			if p.Required && p.Default == "" && !p.Generate {
+				set[p.Name] = "test.example.com"
+			}
+		}
+		values, _, err := app.Values(set)
+		require.NoError(t, err, app.Name)
+
+		project, err := compose.LoadProject(context.Background(), []string{app.ComposePath()},
+			composecli.WithEnv(app.Env(values)))
+		require.NoError(t, err, app.Name)
+		for _, svc := range project.Services {
+			_, err = compose.ServiceSpecFromCompose(project, svc.Name)
+			require.NoError(t, err, app.Name)
+		}
+	}
+}
+
+func TestApp_Values(t *testing.T) {
+	t.Parallel()
+
+	app := App{
+		Name: "db",
+		Parameters: []Parameter{
+			{Name: "version", Default: "17"},
+			{Name: "password", Secret: true, Generate: true},
+			{Name: "admin-email", Required: true},
+			{Name: "comment"},
+		},
+	}
+
+	_, _, err := app.Values(nil)
+	require.ErrorContains(t, err, "parameter 'admin-email' is required")
+
+	_, _, err = app.Values(map[string]string{"admin-email": "a@b.c", "unknown": "x"})
+	require.ErrorContains(t, err, "unknown parameter 'unknown'")
+
+	values, generated, err := app.Values(map[string]string{"admin-email": "a@b.c", "version": "16"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"password"}, generated)
+	assert.Len(t, values["password"], generatedValueLength)
+	assert.Equal(t, []string{
+		"VERSION=16",
+		"PASSWORD=" + values["password"],
+		"ADMIN_EMAIL=a@b.c",
+		"COMMENT=",
+	}, app.Env(values))
+}
+
+func TestOpen_Dir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "web"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "not-an-app"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "web", AppFileName), []byte("name: web\n"), 0o644))
+
+	c, err := Open(context.Background(), dir)
+	require.NoError(t, err)
+	_, err = c.App("web")
+	require.ErrorContains(t, err, "missing "+ComposeFileName)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "web", ComposeFileName),
+		[]byte("services:\n  web:\n    image: nginx\n"), 0o644))
+	app, err := c.App("web")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "web"), app.Dir)
+
+	_, err = c.App("db")
+	require.ErrorContains(t, err, "app 'db' not found")
+}
+
+func TestExtractTar(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	files := map[string]string{
+		"web/" + AppFileName: "name: web\n",
+		"../../escape.txt":   "contained",
+	}
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content)),
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	dir := t.TempDir()
+	require.NoError(t, extractTar(&buf, dir))
+	assert.FileExists(t, filepath.Join(dir, "web", AppFileName))
+	// Paths are confined to the target directory.
+	assert.FileExists(t, filepath.Join(dir, "escape.txt"))
+}
@@ -0,0 +1,177 @@
+package catalog
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	// GitSourcePrefix is the prefix of a catalog source that is a Git repository URL.
+	GitSourcePrefix = "git+"
+	// OCISourcePrefix is the prefix of a catalog source that is an OCI image reference.
+	OCISourcePrefix = "oci://"
+)
+
+// Open opens the app catalog from the source:
+//   - an empty string for the built-in catalog,
+//   - a path to a local catalog directory,
+//   - a Git repository URL prefixed with 'git+' and optionally suffixed with '#ref' to check out a branch or tag,
+//     e.g. git+https://github.com/user/apps.git#main,
+//   - an OCI image reference prefixed with 'oci://' whose filesystem is the catalog, e.g. oci://ghcr.io/user/apps:1.0.
+//
+// Remote catalogs are fetched to a temporary directory that is removed when the catalog is closed.
+func Open(ctx context.Context, source string) (*Catalog, error) {
+	switch {
+	case source == "":
+		return openBuiltin()
+	case strings.HasPrefix(source, GitSourcePrefix):
+		return openGit(ctx, strings.TrimPrefix(source, GitSourcePrefix))
+	case strings.HasPrefix(source, OCISourcePrefix):
+		return openOCI(ctx, strings.TrimPrefix(source, OCISourcePrefix))
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("open catalog directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("catalog '%s' is not a directory", source)
+	}
+	return &Catalog{Dir: source}, nil
+}
+
+// newTempCatalog creates a catalog in a new temporary directory that is removed when the catalog is closed.
+func newTempCatalog() (*Catalog, error) {
+	dir, err := os.MkdirTemp("", "uncloud-catalog-")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary directory: %w", err)
+	}
+	return &Catalog{
+		Dir: dir,
+		cleanup: func() error {
+			return os.RemoveAll(dir)
+		},
+	}, nil
+}
+
+// openBuiltin extracts the catalog embedded in the binary to a temporary directory.
+func openBuiltin() (*Catalog, error) {
+	c, err := newTempCatalog()
+	if err != nil {
+		return nil, err
+	}
+	apps, err := fs.Sub(builtinApps, "apps")
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("open built-in catalog: %w", err)
+	}
+	if err = os.CopyFS(c.Dir, apps); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("extract built-in catalog: %w", err)
+	}
+	return c, nil
+}
+
+// openGit shallow clones the Git repository with the catalog. The url can be suffixed with '#ref' to check out
+// a branch or tag.
+func openGit(ctx context.Context, url string) (*Catalog, error) {
+	url, ref, _ := strings.Cut(url, "#")
+	if url == "" {
+		return nil, errors.New("Git repository URL must be set")
+	}
+
+	c, err := newTempCatalog()
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--", url, c.Dir)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("clone Git repository '%s': %w: %s", url, err, strings.TrimSpace(stderr.String()))
+	}
+	return c, nil
+}
+
+// openOCI pulls the OCI image with the catalog and extracts its filesystem.
+func openOCI(ctx context.Context, image string) (*Catalog, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("parse image reference '%s': %w", image, err)
+	}
+	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("pull image '%s': %w", image, err)
+	}
+
+	c, err := newTempCatalog()
+	if err != nil {
+		return nil, err
+	}
+	rc := mutate.Extract(img)
+	defer rc.Close()
+	if err = extractTar(rc, c.Dir); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("extract image '%s': %w", image, err)
+	}
+	return c, nil
+}
+
+// extractTar extracts the regular files and directories from the tar stream to the directory. Other entry types
+// such as symlinks are skipped as app templates don't need them.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar: %w", err)
+		}
+
+		path := filepath.Join(dir, filepath.Clean("/"+hdr.Name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return fmt.Errorf("write file '%s': %w", hdr.Name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// checkpointsDir is the directory next to the config file where the progress of long operations is saved.
+const checkpointsDir = "checkpoints"
+
+// unsafeFileNameChars matches the characters that are replaced in the checkpoint file names.
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// addMachineCheckpoint is the progress of adding a machine to a cluster. It's saved after each step that changes
+// the machine or the cluster so that an interrupted 'machine add' can be resumed instead of leaving a partially
+// added machine behind.
+type addMachineCheckpoint struct {
+	// Context is the name of the cluster context the machine is added to.
+	Context string `json:"context"`
+	// Destination is the SSH destination of the machine in the [USER@]HOST[:PORT] format.
+	Destination string `json:"destination"`
+	// Provisioned is true if the Uncloud daemon has been installed and the provisioning scripts have run.
+	Provisioned bool `json:"provisioned,omitempty"`
+	// MachineID is the ID of the machine registered in the cluster. Empty if it hasn't been registered yet.
+	MachineID string `json:"machine_id,omitempty"`
+}
+
+// addMachineCheckpointPath returns the path to the checkpoint file for adding the machine with the SSH destination
+// to the cluster context.
+func (cli *CLI) addMachineCheckpointPath(contextName, destination string) string {
+	name := unsafeFileNameChars.ReplaceAllString(contextName+"_"+destination, "_")
+	return filepath.Join(filepath.Dir(cli.Config.Path()), checkpointsDir, "machine-add_"+name+".json")
+}
+
+// loadAddMachineCheckpoint reads the checkpoint from the file. It returns nil if the file doesn't exist.
+func loadAddMachineCheckpoint(path string) (*addMachineCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read checkpoint '%s': %w", path, err)
+	}
+
+	var cp addMachineCheckpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("parse checkpoint '%s': %w", path, err)
+	}
+	return &cp, nil
+}
+
+// save atomically writes the checkpoint to the file so that an interrupted write doesn't corrupt it.
+func (cp *addMachineCheckpoint) save(path string) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create checkpoints directory '%s': %w", dir, err)
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("write checkpoint '%s': %w", path, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write checkpoint '%s': %w", path, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("write checkpoint '%s': %w", path, err)
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("write checkpoint '%s': %w", path, err)
+	}
+	return nil
+}
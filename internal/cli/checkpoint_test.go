@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMachineCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg, err := config.Load(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+	uncli := &CLI{Config: cfg}
+
+	path := uncli.addMachineCheckpointPath("prod", "admin@10.0.0.1:2222")
+	assert.Equal(t, filepath.Join(dir, "checkpoints", "machine-add_prod_admin_10.0.0.1_2222.json"), path)
+
+	cp, err := loadAddMachineCheckpoint(path)
+	require.NoError(t, err)
+	assert.Nil(t, cp, "missing checkpoint")
+
+	want := &addMachineCheckpoint{
+		Context:     "prod",
+		Destination: "admin@10.0.0.1:2222",
+		Provisioned: true,
+		MachineID:   "a1b2c3",
+	}
+	require.NoError(t, want.save(path))
+	cp, err = loadAddMachineCheckpoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, cp)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file must be removed")
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = loadAddMachineCheckpoint(path)
+	assert.ErrorContains(t, err, "parse checkpoint")
+}
@@ -2,15 +2,21 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/netip"
 	"os"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/cli/cli/streams"
 	"github.com/psviderski/uncloud/internal/cli/config"
 	"github.com/psviderski/uncloud/internal/machine"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/network"
 	"github.com/psviderski/uncloud/internal/sshexec"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client"
@@ -30,6 +36,12 @@ const (
 type CLI struct {
 	Config *config.Config
 	conn   *config.MachineConnection
+	// Output configures how the progress and results of long operations are displayed. Use SetOutput to change it.
+	Output OutputOptions
+	// contextGroup is the context group to run the commands against. Use SetContextGroup to change it.
+	contextGroup string
+	// configMu serialises the config changes made when connecting to multiple contexts concurrently.
+	configMu sync.Mutex
 }
 
 // New creates a new CLI instance with the given config path or remote machine connection.
@@ -40,7 +52,7 @@ func New(configPath string, conn *config.MachineConnection) (*CLI, error) {
 		return &CLI{conn: conn}, nil
 	}
 
-	cfg, err := config.NewFromFile(configPath)
+	cfg, err := config.Load(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("read Uncloud config: %w", err)
 	}
@@ -64,23 +76,59 @@ func (cli *CLI) SetCurrentContext(name string) error {
 	if _, ok := cli.Config.Contexts[name]; !ok {
 		return api.ErrNotFound
 	}
+	if pinned := cli.Config.ProjectCurrentContext(); pinned != "" && pinned != name {
+		return fmt.Errorf("the current context is set to '%s' by the project config (%s) which takes precedence "+
+			"over the user config. Use the '--context' flag or update the project config instead",
+			pinned, cli.Config.ProjectPath())
+	}
 	cli.Config.CurrentContext = name
 	return cli.Config.Save()
 }
 
 // ConnectCluster connects to a cluster using the given context name or the current context if not specified.
 // If the CLI was initialised with a machine connection, the config is ignored and the connection is used instead.
+// The gRPC and SSH connections are configured with the UNCLOUD_GRPC_* and UNCLOUD_SSH_* environment variables,
+// see GRPCOptionsFromEnv and SSHOptionsFromEnv.
 func (cli *CLI) ConnectCluster(ctx context.Context, contextName string) (*client.Client, error) {
-	return cli.ConnectClusterWithOptions(ctx, contextName, ConnectOptions{
-		// Default to showing progress for CLI usage.
-		ShowProgress: true,
-	})
+	opts, err := cli.connectOptions()
+	if err != nil {
+		return nil, err
+	}
+	return cli.ConnectClusterWithOptions(ctx, contextName, opts)
+}
+
+// connectOptions returns the default connection options for CLI usage.
+func (cli *CLI) connectOptions() (ConnectOptions, error) {
+	grpcOpts, err := GRPCOptionsFromEnv()
+	if err != nil {
+		return ConnectOptions{}, err
+	}
+	sshOpts, err := SSHOptionsFromEnv()
+	if err != nil {
+		return ConnectOptions{}, err
+	}
+	return ConnectOptions{
+		// Default to showing progress for CLI usage unless quiet or the output is redirected.
+		ShowProgress: !cli.Output.Quiet && cli.Output.OnStep == nil && cli.Output.Writer() == os.Stdout,
+		NoColor:      cli.Output.NoColor,
+		GRPC:         grpcOpts,
+		SSH:          sshOpts,
+	}, nil
 }
 
 // ConnectClusterWithOptions connects to a cluster using the given context name and options.
 // If the CLI was initialised with a machine connection, the config is ignored and the connection is used instead.
 // Options are useful when using the CLI as a library where you may want to disable visual feedback.
 func (cli *CLI) ConnectClusterWithOptions(ctx context.Context, contextName string, opts ConnectOptions) (*client.Client, error) {
+	if cli.contextGroup != "" {
+		return nil, errors.New("the command doesn't support the '--context-group' flag, " +
+			"use the '--context' flag to run it against a single cluster")
+	}
+	return cli.connectContext(ctx, contextName, opts)
+}
+
+// connectContext connects to a cluster using the given context name and options ignoring the context group.
+func (cli *CLI) connectContext(ctx context.Context, contextName string, opts ConnectOptions) (*client.Client, error) {
 	if cli.conn != nil {
 		return ConnectCluster(ctx, *cli.conn, opts)
 	}
@@ -129,6 +177,10 @@ func (cli *CLI) ConnectClusterWithOptions(ctx context.Context, contextName strin
 	for _, conn := range cfg.Connections {
 		c, err := ConnectCluster(ctx, conn, opts)
 		if err == nil {
+			if err = cli.verifyClusterID(ctx, c, contextName, conn); err != nil {
+				c.Close()
+				return nil, err
+			}
 			return c, nil
 		}
 
@@ -140,6 +192,45 @@ func (cli *CLI) ConnectClusterWithOptions(ctx context.Context, contextName strin
 		contextName, len(cfg.Connections), cli.Config.Path(), lastErr)
 }
 
+// verifyClusterID verifies that the machine connected with conn belongs to the cluster of the context. It fails if
+// the machine belongs to a different cluster, for example, if its host was re-provisioned into another cluster.
+// The cluster ID is recorded in the context if the context was created before cluster IDs were introduced.
+func (cli *CLI) verifyClusterID(
+	ctx context.Context, c *client.Client, contextName string, conn config.MachineConnection,
+) error {
+	clusterID, err := c.ClusterID(ctx)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return fmt.Errorf("machine '%s' is not a member of cluster context '%s' or any other cluster. "+
+				"Run 'uc ctx check %s --prune' to remove the stale connections", conn, contextName, contextName)
+		}
+		return fmt.Errorf("get cluster ID: %w", err)
+	}
+	// The cluster was initialised by or the machine runs an older daemon version that doesn't support cluster IDs.
+	if clusterID == "" {
+		return nil
+	}
+
+	cli.configMu.Lock()
+	defer cli.configMu.Unlock()
+
+	cfg := cli.Config.Contexts[contextName]
+	if cfg.ClusterID == "" {
+		cfg.ClusterID = clusterID
+		if err = cli.Config.Save(); err != nil {
+			return fmt.Errorf("save cluster ID to config: %w", err)
+		}
+		return nil
+	}
+	if clusterID != cfg.ClusterID {
+		return fmt.Errorf("machine '%s' belongs to a different cluster (ID %s) than cluster context '%s' (ID %s). "+
+			"The machine may have been re-provisioned into another cluster. "+
+			"Run 'uc ctx check %s --prune' to remove the stale connections",
+			conn, clusterID, contextName, cfg.ClusterID, contextName)
+	}
+	return nil
+}
+
 type InitClusterOptions struct {
 	Context       string
 	MachineName   string
@@ -148,6 +239,16 @@ type InitClusterOptions struct {
 	RemoteMachine *RemoteMachine
 	SkipInstall   bool
 	Version       string
+	// PreScript is the path to a local script to run on the remote machine before installing the Uncloud daemon.
+	PreScript string
+	// PostScript is the path to a local script to run on the remote machine after installing the Uncloud daemon.
+	PostScript string
+	// DownloadLimit is the maximum number of bytes per second to download the Uncloud binaries at when installing
+	// them on the remote machine. Unlimited if 0.
+	DownloadLimit int64
+	// ProvisionRetries is the number of times to retry provisioning the remote machine after a failure.
+	// The completed provisioning steps are skipped on retry.
+	ProvisionRetries int
 }
 
 // InitCluster initialises a new cluster on a remote machine and returns a client to interact with the cluster.
@@ -166,7 +267,15 @@ func (cli *CLI) initRemoteMachine(ctx context.Context, opts InitClusterOptions)
 		return nil, err
 	}
 
-	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, opts.SkipInstall, opts.Version)
+	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, provisionOptions{
+		SkipInstall:   opts.SkipInstall,
+		Version:       opts.Version,
+		PreScript:     opts.PreScript,
+		PostScript:    opts.PostScript,
+		Retries:       opts.ProvisionRetries,
+		DownloadLimit: opts.DownloadLimit,
+		Output:        cli.Output,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +292,7 @@ func (cli *CLI) initRemoteMachine(ctx context.Context, opts InitClusterOptions)
 		return nil, fmt.Errorf("inspect machine: %w", err)
 	}
 	if minfo.Id != "" {
-		if err = promptResetMachine(ctx, machineClient.MachineClient); err != nil {
+		if err = promptResetMachine(ctx, machineClient.MachineClient, cli.Output); err != nil {
 			return nil, err
 		}
 	}
@@ -216,15 +325,26 @@ func (cli *CLI) initRemoteMachine(ctx context.Context, opts InitClusterOptions)
 	if err != nil {
 		return nil, fmt.Errorf("init cluster: %w", err)
 	}
-	fmt.Printf("Cluster initialised with machine '%s' and saved as context '%s' in your local config (%s)\n",
+	clusterID, err := machineClient.ClusterID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get cluster ID: %w", err)
+	}
+	cli.Output.Report(StepClusterInit,
+		"Cluster initialised with machine '%s' and saved as context '%s' in your local config (%s)",
 		resp.Machine.Name, contextName, cli.Config.Path())
 	if err = cli.CreateContext(contextName); err != nil {
 		return nil, fmt.Errorf("save cluster context to config: %w", err)
 	}
-	if err = cli.SetCurrentContext(contextName); err != nil {
-		return nil, fmt.Errorf("set current cluster context: %w", err)
+	cli.Config.Contexts[contextName].ClusterID = clusterID
+	if pinned := cli.Config.ProjectCurrentContext(); pinned != "" {
+		cli.Output.Report(StepContextSaved, "Current cluster context remains '%s' as set by the project config (%s).",
+			pinned, cli.Config.ProjectPath())
+	} else {
+		if err = cli.SetCurrentContext(contextName); err != nil {
+			return nil, fmt.Errorf("set current cluster context: %w", err)
+		}
+		cli.Output.Report(StepContextSaved, "Current cluster context is now '%s'.", contextName)
 	}
-	fmt.Printf("Current cluster context is now '%s'.\n", contextName)
 
 	// Save the machine's SSH connection details in the context config.
 	connCfg := config.MachineConnection{
@@ -271,17 +391,76 @@ type AddMachineOptions struct {
 	RemoteMachine *RemoteMachine
 	SkipInstall   bool
 	Version       string
+	// PreScript is the path to a local script to run on the remote machine before installing the Uncloud daemon.
+	PreScript string
+	// PostScript is the path to a local script to run on the remote machine after installing the Uncloud daemon.
+	PostScript string
+	// DownloadLimit is the maximum number of bytes per second to download the Uncloud binaries at when installing
+	// them on the remote machine. Unlimited if 0.
+	DownloadLimit int64
+	// ProvisionRetries is the number of times to retry provisioning the remote machine after a failure.
+	// The completed provisioning steps are skipped on retry.
+	ProvisionRetries int
+	// DNSEndpoints are additional WireGuard endpoints of the machine specified as DNS names in the host:port format.
+	DNSEndpoints []string
+	// Resume continues a previous attempt to add the machine that was interrupted or failed, skipping the steps
+	// that have already been completed.
+	Resume bool
 }
 
 // AddMachine provisions a remote machine and adds it to the cluster. It returns a cluster client and a machine client.
 // The cluster client is connected to the existing machine in the cluster. It was used to add the new machine to the
 // cluster. The machine client is connected to the new machine and can be used to interact with it.
 // Both client should be closed after use by the caller.
+//
+// The progress is saved to a checkpoint file after each step that changes the machine or the cluster. If adding
+// the machine fails or is interrupted, it can be resumed by calling AddMachine again with opts.Resume set.
 func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client.Client, *client.Client, error) {
 	contextName := opts.Context
 	if contextName == "" {
 		contextName = cli.Config.CurrentContext
 	}
+	destination := config.NewSSHDestination(opts.RemoteMachine.User, opts.RemoteMachine.Host, opts.RemoteMachine.Port)
+	cpPath := cli.addMachineCheckpointPath(contextName, string(destination))
+	cp, err := loadAddMachineCheckpoint(cpPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.Resume {
+		if cp == nil {
+			return nil, nil, fmt.Errorf("no interrupted attempt to add machine %s to cluster context '%s' "+
+				"to resume", destination, contextName)
+		}
+	} else {
+		if cp != nil && cp.MachineID != "" {
+			return nil, nil, fmt.Errorf("a previous attempt to add machine %s to cluster context '%s' was "+
+				"interrupted after registering the machine in the cluster. Use the '--resume' flag to continue it "+
+				"or remove the checkpoint file '%s' to start over", destination, contextName, cpPath)
+		}
+		cp = &addMachineCheckpoint{Context: contextName, Destination: string(destination)}
+	}
+
+	c, machineClient, err := cli.addMachine(ctx, contextName, opts, cp, cpPath)
+	if err != nil {
+		if _, statErr := os.Stat(cpPath); statErr == nil {
+			err = fmt.Errorf("%w\nThe progress has been saved, run the same command with '--resume' to continue "+
+				"adding the machine", err)
+		}
+		return nil, nil, err
+	}
+	if err = os.Remove(cpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		client.PrintWarning(fmt.Sprintf("failed to remove checkpoint file '%s': %v", cpPath, err))
+	}
+
+	return c, machineClient, nil
+}
+
+// addMachine adds the machine to the cluster and saves its connection in the cluster context. If the machine is
+// already a member of the cluster, it's adopted as is: the provisioning is skipped and only the missing connection
+// is saved so that re-running 'machine add' for the same machine succeeds.
+func (cli *CLI) addMachine(
+	ctx context.Context, contextName string, opts AddMachineOptions, cp *addMachineCheckpoint, cpPath string,
+) (_ *client.Client, _ *client.Client, err error) {
 	c, err := cli.ConnectCluster(ctx, contextName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("connect to cluster (context '%s'): %w", contextName, err)
@@ -292,7 +471,72 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 		}
 	}()
 
-	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, opts.SkipInstall, opts.Version)
+	machineClient, registered := connectClusterMember(ctx, c, opts.RemoteMachine)
+	if machineClient != nil {
+		cli.Output.Report(StepMachineAdded,
+			"Machine '%s' is already a member of the cluster (context '%s'), skipping provisioning.",
+			registered.Name, contextName)
+	} else {
+		machineClient, registered, err = cli.provisionAndJoin(ctx, c, contextName, opts, cp, cpPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		// TODO: fix empty context name when using the current context (contextName == "").
+		cli.Output.Report(StepMachineAdded, "Machine '%s' added to the cluster (context '%s').",
+			registered.Name, contextName)
+	}
+	defer func() {
+		if err != nil {
+			machineClient.Close()
+		}
+	}()
+
+	// Save the machine's SSH connection details in the context config.
+	connCfg := config.MachineConnection{
+		SSH:        config.NewSSHDestination(opts.RemoteMachine.User, opts.RemoteMachine.Host, opts.RemoteMachine.Port),
+		SSHKeyFile: opts.RemoteMachine.KeyPath,
+	}
+	if contextName == "" {
+		contextName = cli.Config.CurrentContext
+	}
+	if !slices.ContainsFunc(cli.Config.Contexts[contextName].Connections, func(conn config.MachineConnection) bool {
+		return conn.SSH == connCfg.SSH
+	}) {
+		cli.Config.Contexts[contextName].Connections = append(
+			cli.Config.Contexts[contextName].Connections, connCfg)
+		if err = cli.Config.Save(); err != nil {
+			return nil, nil, fmt.Errorf("save config: %w", err)
+		}
+	}
+
+	return c, machineClient, nil
+}
+
+// provisionAndJoin runs the steps of adding the machine to the cluster skipping the ones completed according
+// to the checkpoint. The checkpoint is updated and saved to cpPath after each step that changes the machine
+// or the cluster. It returns a client connected to the machine and the machine info registered in the cluster.
+func (cli *CLI) provisionAndJoin(
+	ctx context.Context,
+	c *client.Client,
+	contextName string,
+	opts AddMachineOptions,
+	cp *addMachineCheckpoint,
+	cpPath string,
+) (_ *client.Client, _ *pb.MachineInfo, err error) {
+	provisionOpts := provisionOptions{
+		SkipInstall:   opts.SkipInstall,
+		Version:       opts.Version,
+		PreScript:     opts.PreScript,
+		PostScript:    opts.PostScript,
+		Retries:       opts.ProvisionRetries,
+		DownloadLimit: opts.DownloadLimit,
+		Output:        cli.Output,
+	}
+	if cp.Provisioned {
+		cli.Output.Report(StepInstall, "Skipping provisioning as the machine has already been provisioned.")
+		provisionOpts = provisionOptions{SkipInstall: true, Output: cli.Output}
+	}
+	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, provisionOpts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -301,60 +545,250 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 			machineClient.Close()
 		}
 	}()
+	if !cp.Provisioned {
+		cp.Provisioned = true
+		if err = cp.save(cpPath); err != nil {
+			return nil, nil, err
+		}
+	}
 
-	// Check if the machine is already initialised as a cluster member and prompt the user to reset it first.
 	minfo, err := machineClient.Inspect(ctx, &emptypb.Empty{})
 	if err != nil {
 		return nil, nil, fmt.Errorf("inspect machine: %w", err)
 	}
-	if minfo.Id != "" {
-		// Check if the machine is already a member of this cluster.
-		machines, err := c.ListMachines(ctx, nil)
-		if err != nil {
-			return nil, nil, fmt.Errorf("list cluster machines: %w", err)
+	machines, err := c.ListMachines(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list cluster machines: %w", err)
+	}
+
+	// The machine registered in the cluster by the interrupted attempt if any.
+	var registered *pb.MachineInfo
+	if cp.MachineID != "" {
+		if m := machines.FindByNameOrID(cp.MachineID); m != nil {
+			registered = m.Machine
+		} else {
+			// The registration has been removed from the cluster since, e.g. with 'machine rm'. Register again.
+			cp.MachineID = ""
 		}
+	}
+	joined := registered != nil && minfo.Id == registered.Id
+
+	// Check if the machine is already initialised as a cluster member and prompt the user to reset it first.
+	if minfo.Id != "" && !joined {
+		// Check if the machine is already a member of this cluster.
 		if slices.ContainsFunc(machines, func(m *pb.MachineMember) bool {
 			return m.Machine.Id == minfo.Id
 		}) {
 			return nil, nil, fmt.Errorf("machine is already a member of this cluster (%s)", minfo.Name)
 		}
 
-		if err = promptResetMachine(ctx, machineClient.MachineClient); err != nil {
+		if err = promptResetMachine(ctx, machineClient.MachineClient, cli.Output); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if registered == nil {
+		if registered, err = registerMachine(ctx, c, machineClient, minfo, opts); err != nil {
+			return nil, nil, fmt.Errorf("add machine to cluster (context '%s'): %w", contextName, err)
+		}
+		cp.MachineID = registered.Id
+		if err = cp.save(cpPath); err != nil {
 			return nil, nil, err
 		}
 	}
 
+	if !joined {
+		// Get the most up-to-date list of other machines in the cluster to include them in the join request.
+		machines, err = c.ListMachines(ctx, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list cluster machines: %w", err)
+		}
+		otherMachines := make([]*pb.MachineInfo, 0, len(machines)-1)
+		for _, m := range machines {
+			if m.Machine.Id != registered.Id {
+				otherMachines = append(otherMachines, m.Machine)
+			}
+		}
+
+		// Configure the remote machine to join the cluster.
+		joinReq := &pb.JoinClusterRequest{
+			Machine:       registered,
+			OtherMachines: otherMachines,
+		}
+		if _, err = machineClient.JoinCluster(ctx, joinReq); err != nil {
+			err = fmt.Errorf("join cluster: %w", err)
+			// Remove the registration of the machine that failed to join so that it doesn't remain in the cluster
+			// as an unreachable member with the WireGuard peers configured on the other machines.
+			rolledBack, rbErr := rollbackRegistration(ctx, c, machineClient, registered)
+			if rbErr != nil {
+				return nil, nil, fmt.Errorf("%w\nFailed to remove machine '%s' from the cluster after the failed "+
+					"join: %v. Remove it manually with 'uc machine rm %s'", err, registered.Name, rbErr, registered.Name)
+			}
+			if rolledBack {
+				cp.MachineID = ""
+				if cpErr := cp.save(cpPath); cpErr != nil {
+					return nil, nil, errors.Join(err, cpErr)
+				}
+				return nil, nil, fmt.Errorf("%w\nMachine '%s' has been removed from the cluster", err, registered.Name)
+			}
+			// The machine has joined the cluster despite the error, e.g. the response was lost.
+			slog.Debug("Machine joined the cluster despite the join error.", "machine", registered.Name, "err", err)
+		}
+	}
+
+	return machineClient, registered, nil
+}
+
+// joinRollbackTimeout is the maximum time to remove the registration of a machine that failed to join the cluster.
+const joinRollbackTimeout = 30 * time.Second
+
+// rollbackRegistration removes the registered machine from the cluster after it failed to join the cluster.
+// The other machines remove their WireGuard peers for the machine once its registration is removed. It returns false
+// without removing the registration if the machine has actually joined the cluster, e.g. if the join response was
+// lost. The rollback isn't interrupted if ctx is cancelled, e.g. when the user presses Ctrl+C during the join.
+func rollbackRegistration(
+	ctx context.Context, c, machineClient *client.Client, registered *pb.MachineInfo,
+) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), joinRollbackTimeout)
+	defer cancel()
+
+	if minfo, err := machineClient.Inspect(ctx, &emptypb.Empty{}); err == nil && minfo.Id == registered.Id {
+		return false, nil
+	}
+	if _, err := c.RemoveMachine(ctx, &pb.RemoveMachineRequest{Id: registered.Id}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return true, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// connectClusterMember connects to the remote machine without provisioning it and returns a client connected
+// to the machine and its info registered in the cluster if the machine is already a member of the cluster. It returns
+// nil if the machine doesn't run the Uncloud daemon or is not a member of the cluster.
+func connectClusterMember(
+	ctx context.Context, c *client.Client, remoteMachine *RemoteMachine,
+) (*client.Client, *pb.MachineInfo) {
+	// Don't modify the key path of the remote machine that is set if the SSH agent authentication fails
+	// as the connection will be retried when provisioning the machine.
+	rm := *remoteMachine
+	machineClient, err := provisionOrConnectRemoteMachine(ctx, &rm, provisionOptions{SkipInstall: true})
+	if err != nil {
+		slog.Debug("Failed to connect to the remote machine to check if it's a cluster member.", "err", err)
+		return nil, nil
+	}
+
+	member, err := clusterMember(ctx, c, machineClient)
+	if err != nil || member == nil {
+		if err != nil {
+			slog.Debug("Failed to check if the remote machine is a cluster member.", "err", err)
+		}
+		machineClient.Close()
+		return nil, nil
+	}
+	*remoteMachine = rm
+	return machineClient, member
+}
+
+// clusterMember returns the machine info registered in the cluster if the machine the machineClient is connected to
+// is a member of the cluster the c client is connected to. It returns nil if the machine is not initialised,
+// not registered in the cluster, or belongs to a different cluster.
+func clusterMember(ctx context.Context, c, machineClient *client.Client) (*pb.MachineInfo, error) {
+	minfo, err := machineClient.Inspect(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("inspect machine: %w", err)
+	}
+	if minfo.Id == "" {
+		return nil, nil
+	}
+
+	machines, err := c.ListMachines(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list cluster machines: %w", err)
+	}
+	clusterID, err := c.ClusterID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get cluster ID: %w", err)
+	}
+	machineClusterID, err := machineClient.ClusterID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get machine cluster ID: %w", err)
+	}
+
+	return adoptableMember(minfo, machines, clusterID, machineClusterID), nil
+}
+
+// adoptableMember returns the machine info registered in the cluster with the machines and clusterID if the machine
+// described by minfo that belongs to machineClusterID is its member and can be adopted without provisioning.
+// It returns nil if the machine must be provisioned and joined to the cluster instead.
+func adoptableMember(
+	minfo *pb.MachineInfo, machines api.MachineMembersList, clusterID, machineClusterID string,
+) *pb.MachineInfo {
+	if minfo.Id == "" {
+		return nil
+	}
+	member := machines.FindByNameOrID(minfo.Id)
+	if member == nil || member.Machine.Id != minfo.Id {
+		return nil
+	}
+	// Compare the cluster IDs to not mistake a machine of another cluster with a colliding machine ID for a member.
+	// The IDs are empty if the cluster was initialised by or the machines run an older daemon version.
+	if clusterID != "" && machineClusterID != "" && clusterID != machineClusterID {
+		return nil
+	}
+	return member.Machine
+}
+
+// registerMachine checks the machine meets the requirements and registers it in the cluster using its public key
+// and endpoints from the machine token. It returns the machine info to join the cluster with.
+func registerMachine(
+	ctx context.Context, c, machineClient *client.Client, minfo *pb.MachineInfo, opts AddMachineOptions,
+) (*pb.MachineInfo, error) {
 	// Check machine meets all necessary system requirements before proceeding.
 	checkResp, err := machineClient.CheckPrerequisites(ctx, &emptypb.Empty{})
 	// TODO(lhf): remove Unimplemented check when v0.9.0 is released.
 	if err != nil {
 		if status.Convert(err).Code() != codes.Unimplemented {
-			return nil, nil, fmt.Errorf("check machine prerequisites: %w", err)
+			return nil, fmt.Errorf("check machine prerequisites: %w", err)
 		}
 	} else if !checkResp.Satisfied {
-		return nil, nil, fmt.Errorf("machine prerequisites not satisfied: %s", checkResp.Error)
+		return nil, fmt.Errorf("machine prerequisites not satisfied: %s", checkResp.Error)
 	}
 
 	tokenResp, err := machineClient.Token(ctx, &emptypb.Empty{})
 	if err != nil {
-		return nil, nil, fmt.Errorf("get remote machine token: %w", err)
+		return nil, fmt.Errorf("get remote machine token: %w", err)
 	}
 	token, err := machine.ParseToken(tokenResp.Token)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse remote machine token: %w", err)
+		return nil, fmt.Errorf("parse remote machine token: %w", err)
 	}
 
-	// Register the machine in the cluster using its public key and endpoints from the token.
 	endpoints := make([]*pb.IPPort, len(token.Endpoints))
 	for i, addrPort := range token.Endpoints {
 		endpoints[i] = pb.NewIPPort(addrPort)
 	}
+	dnsEndpoints := slices.Clone(token.DNSEndpoints)
+	for _, ep := range opts.DNSEndpoints {
+		if !slices.Contains(dnsEndpoints, ep) {
+			dnsEndpoints = append(dnsEndpoints, ep)
+		}
+	}
+	if err = checkEndpointsReachable(ctx, c, machineClient, endpoints, dnsEndpoints); err != nil {
+		return nil, err
+	}
+
 	addReq := &pb.AddMachineRequest{
 		Name: opts.MachineName,
 		Network: &pb.NetworkConfig{
-			Endpoints: endpoints,
-			PublicKey: token.PublicKey,
+			Endpoints:    endpoints,
+			DnsEndpoints: dnsEndpoints,
+			PublicKey:    token.PublicKey,
 		},
+		// Empty if the machine runs an older daemon version that doesn't report its architecture.
+		Arch:      minfo.Arch,
+		Resources: minfo.Resources,
 	}
 	if opts.PublicIP != nil {
 		if opts.PublicIP.IsValid() {
@@ -367,65 +801,90 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 
 	addResp, err := c.AddMachine(ctx, addReq)
 	if err != nil {
-		return nil, nil, fmt.Errorf("add machine to cluster (context '%s'): %w", contextName, err)
+		return nil, err
 	}
+	return addResp.Machine, nil
+}
 
-	// Get the most up-to-date list of other machines in the cluster to include them in the join request.
-	machines, err := c.ListMachines(ctx, nil)
-	if err != nil {
-		return nil, nil, fmt.Errorf("list cluster machines: %w", err)
-	}
-	otherMachines := make([]*pb.MachineInfo, 0, len(machines)-1)
-	for _, m := range machines {
-		if m.Machine.Id != addResp.Machine.Id {
-			otherMachines = append(otherMachines, m.Machine)
+// checkEndpointsReachable verifies that at least one WireGuard endpoint of the new machine is reachable from
+// the cluster machine the client c is connected to before the new machine is added to the cluster. Otherwise,
+// the new machine would be added but unable to join the cluster. The DNS endpoints are resolved locally.
+// The check is skipped if either machine runs an older daemon version that doesn't support the endpoint probes.
+func checkEndpointsReachable(
+	ctx context.Context, c, machineClient *client.Client, endpoints []*pb.IPPort, dnsEndpoints []string,
+) error {
+	probeEndpoints := slices.Clone(endpoints)
+	for _, ep := range dnsEndpoints {
+		addrPorts, err := network.ResolveDNSEndpoint(ctx, ep)
+		if err != nil {
+			slog.Debug("Failed to resolve DNS endpoint of the machine.", "endpoint", ep, "err", err)
+			continue
+		}
+		for _, ap := range addrPorts {
+			probeEndpoints = append(probeEndpoints, pb.NewIPPort(ap))
 		}
 	}
-
-	// Configure the remote machine to join the cluster.
-	joinReq := &pb.JoinClusterRequest{
-		Machine:       addResp.Machine,
-		OtherMachines: otherMachines,
-	}
-	if _, err = machineClient.JoinCluster(ctx, joinReq); err != nil {
-		return nil, nil, fmt.Errorf("join cluster: %w", err)
+	if len(probeEndpoints) == 0 {
+		return nil
 	}
 
-	// TODO: fix empty context name when using the current context (contextName == "").
-	fmt.Printf("Machine '%s' added to the cluster (context '%s').\n", addResp.Machine.Name, contextName)
-
-	// Save the machine's SSH connection details in the context config.
-	connCfg := config.MachineConnection{
-		SSH:        config.NewSSHDestination(opts.RemoteMachine.User, opts.RemoteMachine.Host, opts.RemoteMachine.Port),
-		SSHKeyFile: opts.RemoteMachine.KeyPath,
+	// An empty probe request checks if the new machine supports probes and thus answers them.
+	if _, err := machineClient.ProbeEndpoints(ctx, &pb.ProbeEndpointsRequest{}); err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return fmt.Errorf("check machine supports endpoint probes: %w", err)
 	}
-	if contextName == "" {
-		contextName = cli.Config.CurrentContext
+	resp, err := c.ProbeEndpoints(ctx, &pb.ProbeEndpointsRequest{Endpoints: probeEndpoints})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return fmt.Errorf("probe machine endpoints: %w", err)
 	}
-	cli.Config.Contexts[contextName].Connections = append(cli.Config.Contexts[contextName].Connections, connCfg)
-	if err = cli.Config.Save(); err != nil {
-		return nil, nil, fmt.Errorf("save config: %w", err)
+
+	var failures []string
+	for _, p := range resp.Probes {
+		if p.Reachable {
+			return nil
+		}
+		addrPort, _ := p.Endpoint.ToAddrPort()
+		failures = append(failures, fmt.Sprintf("  %s: %s", addrPort, p.Error))
 	}
 
-	return c, machineClient, nil
+	from := "the cluster"
+	if minfo, err := c.MachineClient.Inspect(ctx, &emptypb.Empty{}); err == nil {
+		from = fmt.Sprintf("cluster machine '%s'", minfo.Name)
+	}
+	return fmt.Errorf("none of the WireGuard endpoints of the machine is reachable from %s:\n%s\n"+
+		"Make sure UDP port %d on the machine is allowed by its firewall and cloud provider security rules, "+
+		"or specify a reachable endpoint with --dns-endpoint",
+		from, strings.Join(failures, "\n"), network.WireGuardPort)
 }
 
 // provisionOrConnectRemoteMachine installs the Uncloud daemon and dependencies on the remote machine over SSH and
 // returns a machine API client to interact with the machine. The client should be closed after use by the caller.
-// The version parameter specifies the version of the Uncloud daemon to install. If empty, the latest version is used.
-// If skipInstall is true, the installation step is skipped, and it is assumed that the Uncloud daemon and dependencies
-// are already installed and running.
+// The opts.Version specifies the version of the Uncloud daemon to install. If empty, the latest version is used.
+// If opts.SkipInstall is true, the installation step is skipped, and it is assumed that the Uncloud daemon and
+// dependencies are already installed and running. The pre- and post-provisioning scripts are run regardless.
 // The remoteMachine.SSHKeyPath could be updated to the default SSH key path if it is not set and the SSH agent
-// authentication fails.
+// authentication fails. The SSH connection is configured with the UNCLOUD_SSH_* environment variables,
+// see SSHOptionsFromEnv.
 func provisionOrConnectRemoteMachine(
-	ctx context.Context, remoteMachine *RemoteMachine, skipInstall bool, version string,
+	ctx context.Context, remoteMachine *RemoteMachine, opts provisionOptions,
 ) (*client.Client, error) {
-	sshClient, err := sshexec.Connect(remoteMachine.User, remoteMachine.Host, remoteMachine.Port, remoteMachine.KeyPath)
+	sshOpts, err := SSHOptionsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	sshClient, err := sshexec.Connect(
+		remoteMachine.User, remoteMachine.Host, remoteMachine.Port, remoteMachine.KeyPath, sshOpts,
+	)
 	// If the SSH connection using SSH agent fails and no key path is provided, try to use the default SSH key.
 	if err != nil && remoteMachine.KeyPath == "" {
 		remoteMachine.KeyPath = DefaultSSHKeyPath
 		sshClient, err = sshexec.Connect(
-			remoteMachine.User, remoteMachine.Host, remoteMachine.Port, remoteMachine.KeyPath,
+			remoteMachine.User, remoteMachine.Host, remoteMachine.Port, remoteMachine.KeyPath, sshOpts,
 		)
 	}
 	if err != nil {
@@ -435,26 +894,29 @@ func provisionOrConnectRemoteMachine(
 		)
 	}
 
-	if !skipInstall {
-		// Provision the remote machine by installing the Uncloud daemon and dependencies over SSH.
-		exec := sshexec.NewRemote(sshClient)
-		if err = provisionMachine(ctx, exec, version); err != nil {
-			return nil, fmt.Errorf("provision machine: %w", err)
-		}
+	// Provision the remote machine by installing the Uncloud daemon and dependencies over SSH.
+	exec := sshexec.NewRemote(sshClient)
+	if err = provisionMachine(ctx, exec, opts); err != nil {
+		_ = sshClient.Close()
+		return nil, fmt.Errorf("provision machine: %w", err)
 	}
 
 	var machineClient *client.Client
-	if remoteMachine.User == "root" || skipInstall {
-		// Create a machine API client over the established SSH connection to the remote machine.
+	if remoteMachine.User == "root" || opts.SkipInstall || sessionInGroup(ctx, exec, machine.DefaultSockGroup) {
+		// Create a machine API client over the established SSH connection to the remote machine to avoid
+		// another SSH handshake.
 		machineClient, err = client.New(ctx, connector.NewSSHConnectorFromClient(sshClient))
 	} else {
-		// Since the user is not root, we need to establish a new SSH connection to make the user's addition
-		// to the uncloud group effective, thus allowing access to the Uncloud daemon Unix socket.
+		// The user has just been added to the uncloud group during the installation. The group membership only
+		// becomes effective in a new login session, so we need to establish a new SSH connection to be able
+		// to access the Uncloud daemon Unix socket.
+		_ = sshClient.Close()
 		sshConfig := &connector.SSHConnectorConfig{
 			User:    remoteMachine.User,
 			Host:    remoteMachine.Host,
 			Port:    remoteMachine.Port,
 			KeyPath: remoteMachine.KeyPath,
+			SSH:     sshOpts,
 		}
 		machineClient, err = client.New(ctx, connector.NewSSHConnector(sshConfig))
 	}
@@ -464,7 +926,17 @@ func provisionOrConnectRemoteMachine(
 	return machineClient, nil
 }
 
+// sessionInGroup returns true if the remote SSH session runs with the given supplementary group, e.g. because
+// the user was already a member of the group when it logged in.
+func sessionInGroup(ctx context.Context, exec sshexec.Executor, group string) bool {
+	out, err := exec.Run(ctx, "id -nG")
+	if err != nil {
+		return false
+	}
+	return slices.Contains(strings.Fields(out), group)
+}
+
 // ProgressOut returns an output stream for progress writer.
 func (cli *CLI) ProgressOut() *streams.Out {
-	return streams.NewOut(os.Stdout)
+	return streams.NewOut(cli.Output.Writer())
 }
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdoptableMember(t *testing.T) {
+	t.Parallel()
+
+	member := func(id, name string) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{Id: id, Name: name}, State: pb.MachineMember_UP}
+	}
+	machines := api.MachineMembersList{member("id1", "vps1"), member("id2", "id3")}
+
+	tests := []struct {
+		name             string
+		minfo            *pb.MachineInfo
+		clusterID        string
+		machineClusterID string
+		// wantAdopted is the name of the adopted machine or empty if the machine must be provisioned.
+		wantAdopted string
+	}{
+		{
+			name:  "not initialised",
+			minfo: &pb.MachineInfo{},
+		},
+		{
+			name:             "member of cluster",
+			minfo:            &pb.MachineInfo{Id: "id1", Name: "vps1"},
+			clusterID:        "cluster1",
+			machineClusterID: "cluster1",
+			wantAdopted:      "vps1",
+		},
+		{
+			name:        "member of cluster without cluster IDs",
+			minfo:       &pb.MachineInfo{Id: "id1", Name: "vps1"},
+			wantAdopted: "vps1",
+		},
+		{
+			name:        "member of cluster with older machine daemon",
+			minfo:       &pb.MachineInfo{Id: "id1", Name: "vps1"},
+			clusterID:   "cluster1",
+			wantAdopted: "vps1",
+		},
+		{
+			name:             "colliding machine ID from another cluster",
+			minfo:            &pb.MachineInfo{Id: "id1", Name: "vps1"},
+			clusterID:        "cluster1",
+			machineClusterID: "cluster2",
+		},
+		{
+			name:  "initialised but not registered in cluster",
+			minfo: &pb.MachineInfo{Id: "id4", Name: "vps4"},
+		},
+		{
+			name:  "machine ID matches name of another machine",
+			minfo: &pb.MachineInfo{Id: "id3", Name: "vps3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			adopted := adoptableMember(tt.minfo, machines, tt.clusterID, tt.machineClusterID)
+			if tt.wantAdopted == "" {
+				assert.Nil(t, adopted)
+				return
+			}
+			if assert.NotNil(t, adopted) {
+				assert.Equal(t, tt.wantAdopted, adopted.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/goccy/go-yaml"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/cluster"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// ClusterExtensionKey is the top-level Compose extension that declares the cluster machines in a cluster spec
+	// file. The services of the cluster are declared in the same file using the regular Compose format.
+	ClusterExtensionKey = "x-cluster"
+
+	// PublicIPAuto is the public IP value for automatic detection of the machine's public IP.
+	PublicIPAuto = "auto"
+	// PublicIPNone is the public IP value that disables ingress on the machine.
+	PublicIPNone = "none"
+)
+
+// ClusterSpec declares the desired state of a cluster: its network and machines.
+type ClusterSpec struct {
+	// Context is the name of the cluster context in the Uncloud config. If empty, the current context is used,
+	// or DefaultContextName if there is no current context.
+	Context string `yaml:"context,omitempty"`
+	// Network is the IPv4 network CIDR to use for machines and services. It's only used when initialising
+	// a new cluster. Defaults to cluster.DefaultNetwork.
+	Network string `yaml:"network,omitempty"`
+	// Caddy deploys the Caddy reverse proxy service to all machines. Enabled by default.
+	Caddy *bool `yaml:"caddy,omitempty"`
+	// DNS reserves a cluster domain in Uncloud DNS when initialising a new cluster. Enabled by default.
+	DNS      *bool         `yaml:"dns,omitempty"`
+	Machines []MachineSpec `yaml:"machines,omitempty"`
+}
+
+// MachineSpec declares a machine in the cluster. Machines are identified by their names. The SSH destination is only
+// required to provision machines that are not members of the cluster yet.
+type MachineSpec struct {
+	Name string `yaml:"name"`
+	// SSH is the SSH destination of the machine in the [USER@]HOST[:PORT] format.
+	SSH config.SSHDestination `yaml:"ssh,omitempty"`
+	// SSHKey is the path to the SSH private key for remote login if not already added to SSH agent.
+	SSHKey string `yaml:"ssh_key,omitempty"`
+	// PublicIP is the public IP of the machine for ingress: PublicIPAuto (default), PublicIPNone, or an IP address.
+	PublicIP string `yaml:"public_ip,omitempty"`
+	// DNSEndpoints are additional WireGuard endpoints of the machine specified as DNS names in the HOST[:PORT] format.
+	DNSEndpoints []string `yaml:"dns_endpoints,omitempty"`
+	// Version of the Uncloud daemon to install on the machine. Defaults to the latest version.
+	Version string `yaml:"version,omitempty"`
+	// NoInstall skips the installation of Docker, Uncloud daemon, and dependencies on the machine.
+	NoInstall bool `yaml:"no_install,omitempty"`
+	// PreScript is the path to a local script to run on the machine before installing Uncloud.
+	PreScript string `yaml:"pre_script,omitempty"`
+	// PostScript is the path to a local script to run on the machine after installing Uncloud.
+	PostScript string `yaml:"post_script,omitempty"`
+}
+
+// ClusterSpecFromProject returns the cluster spec declared in the ClusterExtensionKey extension of the Compose project.
+func ClusterSpecFromProject(project *types.Project) (ClusterSpec, error) {
+	var spec ClusterSpec
+
+	ext, ok := project.Extensions[ClusterExtensionKey]
+	if !ok {
+		return spec, fmt.Errorf("'%s' extension not found", ClusterExtensionKey)
+	}
+	// Round-trip the extension through YAML to decode it into the typed spec and reject unknown fields.
+	data, err := yaml.Marshal(ext)
+	if err != nil {
+		return spec, fmt.Errorf("marshal '%s' extension: %w", ClusterExtensionKey, err)
+	}
+	if err = yaml.UnmarshalWithOptions(data, &spec, yaml.DisallowUnknownField()); err != nil {
+		return spec, fmt.Errorf("parse '%s' extension: %w", ClusterExtensionKey, err)
+	}
+
+	if err = spec.Validate(); err != nil {
+		return spec, fmt.Errorf("invalid '%s' extension: %w", ClusterExtensionKey, err)
+	}
+	return spec, nil
+}
+
+// SpecContext returns the name of the cluster context to apply the cluster spec to and whether the context exists.
+// The name argument overrides the context declared in the spec. If neither is set, the current context is used,
+// or DefaultContextName if there is no current context. The context is considered to exist if the CLI is connected
+// to a specific machine without using the config.
+func (cli *CLI) SpecContext(name string, spec ClusterSpec) (string, bool) {
+	if name == "" {
+		name = spec.Context
+	}
+	if cli.Config == nil {
+		return name, true
+	}
+
+	if name == "" {
+		name = cli.Config.CurrentContext
+	}
+	if name == "" {
+		name = DefaultContextName
+	}
+	_, ok := cli.Config.Contexts[name]
+	return name, ok
+}
+
+// Validate checks the cluster spec for errors.
+func (s *ClusterSpec) Validate() error {
+	if _, err := s.NetworkPrefix(); err != nil {
+		return err
+	}
+	if len(s.Machines) == 0 {
+		return errors.New("at least one machine must be declared")
+	}
+
+	names := make(map[string]struct{}, len(s.Machines))
+	for i, m := range s.Machines {
+		if m.Name == "" {
+			return fmt.Errorf("machine #%d: name is required", i+1)
+		}
+		if _, ok := names[m.Name]; ok {
+			return fmt.Errorf("machine '%s' is declared more than once", m.Name)
+		}
+		names[m.Name] = struct{}{}
+
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("machine '%s': %w", m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// NetworkPrefix returns the parsed cluster network or cluster.DefaultNetwork if not set.
+func (s *ClusterSpec) NetworkPrefix() (netip.Prefix, error) {
+	if s.Network == "" {
+		return cluster.DefaultNetwork, nil
+	}
+	prefix, err := netip.ParsePrefix(s.Network)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid network CIDR: %w", err)
+	}
+	return prefix, nil
+}
+
+// CaddyEnabled returns true if the Caddy reverse proxy should be deployed to the cluster.
+func (s *ClusterSpec) CaddyEnabled() bool {
+	return s.Caddy == nil || *s.Caddy
+}
+
+// DNSEnabled returns true if a cluster domain should be reserved in Uncloud DNS when initialising a new cluster.
+func (s *ClusterSpec) DNSEnabled() bool {
+	return s.DNS == nil || *s.DNS
+}
+
+// Validate checks the machine spec for errors.
+func (m *MachineSpec) Validate() error {
+	if m.SSH != "" {
+		if _, _, _, err := m.SSH.Parse(); err != nil {
+			return fmt.Errorf("invalid ssh destination: %w", err)
+		}
+	}
+	if _, err := m.PublicIPAddr(); err != nil {
+		return err
+	}
+	if _, err := m.ParsedDNSEndpoints(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoteMachine returns the SSH connection details of the machine.
+func (m *MachineSpec) RemoteMachine() (*RemoteMachine, error) {
+	if m.SSH == "" {
+		return nil, errors.New("ssh destination is required to provision the machine")
+	}
+	user, host, port, err := m.SSH.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("parse ssh destination: %w", err)
+	}
+	return &RemoteMachine{
+		User:    user,
+		Host:    host,
+		Port:    port,
+		KeyPath: m.SSHKey,
+	}, nil
+}
+
+// PublicIPAddr returns the public IP of the machine in the format used by InitClusterOptions and AddMachineOptions:
+// nil to disable ingress, a zero address for automatic detection, or a specific IP address.
+func (m *MachineSpec) PublicIPAddr() (*netip.Addr, error) {
+	switch m.PublicIP {
+	case "", PublicIPAuto:
+		return &netip.Addr{}, nil
+	case PublicIPNone:
+		return nil, nil
+	default:
+		ip, err := netip.ParseAddr(m.PublicIP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public IP: %w", err)
+		}
+		return &ip, nil
+	}
+}
+
+// ParsedDNSEndpoints returns the DNS endpoints of the machine in the host:port format using the default WireGuard
+// port for endpoints without a port.
+func (m *MachineSpec) ParsedDNSEndpoints() ([]string, error) {
+	endpoints := make([]string, len(m.DNSEndpoints))
+	for i, ep := range m.DNSEndpoints {
+		if !strings.Contains(ep, ":") {
+			ep = net.JoinHostPort(ep, strconv.Itoa(network.WireGuardPort))
+		}
+		host, port, err := network.ParseDNSEndpoint(ep)
+		if err != nil {
+			return nil, err
+		}
+		endpoints[i] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	return endpoints, nil
+}
+
+// MachineSpecFromMember returns the machine spec that declares the cluster machine as is. The SSH destination is taken
+// from the first connection whose host matches the machine's public IP, endpoint, or DNS endpoint. It's left empty
+// if there is no such connection.
+func MachineSpecFromMember(m *pb.MachineMember, conns []config.MachineConnection) MachineSpec {
+	spec := MachineSpec{
+		Name:     m.Machine.Name,
+		PublicIP: PublicIPNone,
+	}
+	hosts := make(map[string]struct{})
+	if m.Machine.PublicIp != nil {
+		if ip, err := m.Machine.PublicIp.ToAddr(); err == nil && ip.IsValid() {
+			spec.PublicIP = ip.String()
+			hosts[ip.String()] = struct{}{}
+		}
+	}
+
+	if m.Machine.Network != nil {
+		for _, ep := range m.Machine.Network.Endpoints {
+			if addrPort, err := ep.ToAddrPort(); err == nil {
+				hosts[addrPort.Addr().String()] = struct{}{}
+			}
+		}
+		for _, ep := range m.Machine.Network.DnsEndpoints {
+			spec.DNSEndpoints = append(spec.DNSEndpoints, ep)
+			if host, _, err := network.ParseDNSEndpoint(ep); err == nil {
+				hosts[host] = struct{}{}
+			}
+		}
+	}
+
+	for _, conn := range conns {
+		if conn.SSH == "" {
+			continue
+		}
+		if _, host, _, err := conn.SSH.Parse(); err == nil {
+			if _, ok := hosts[host]; ok {
+				spec.SSH = conn.SSH
+				spec.SSHKey = conn.SSHKeyFile
+				break
+			}
+		}
+	}
+
+	return spec
+}
+
+// MachinesPlan describes the changes required to converge the cluster machines to the declared cluster spec.
+type MachinesPlan struct {
+	// Add are the declared machines that are not members of the cluster yet.
+	Add []MachineSpec
+	// UpdatePublicIP are the cluster machines whose public IP differs from the declared one.
+	UpdatePublicIP []MachinePublicIPUpdate
+	// Undeclared are the cluster machines that are not declared in the spec. They're left untouched.
+	Undeclared []*pb.MachineMember
+}
+
+// MachinePublicIPUpdate is a change of the public IP of a cluster machine.
+type MachinePublicIPUpdate struct {
+	Machine *pb.MachineMember
+	// PublicIP is the new public IP of the machine. Invalid address removes the public IP.
+	PublicIP netip.Addr
+}
+
+// Empty returns true if no changes to the cluster machines are required.
+func (p MachinesPlan) Empty() bool {
+	return len(p.Add) == 0 && len(p.UpdatePublicIP) == 0
+}
+
+// PrintMachinesPlan prints the changes to the cluster machines in the machines plan to w.
+func PrintMachinesPlan(w io.Writer, plan MachinesPlan) {
+	for _, m := range plan.Add {
+		fmt.Fprintf(w, "- Add machine %s (%s)\n", m.Name, m.SSH)
+	}
+	for _, u := range plan.UpdatePublicIP {
+		publicIP := PublicIPNone
+		if u.PublicIP.IsValid() {
+			publicIP = u.PublicIP.String()
+		}
+		fmt.Fprintf(w, "- Update machine %s [public_ip=%s]\n", u.Machine.Machine.Name, publicIP)
+	}
+}
+
+// PlanMachines compares the declared machines with the cluster machines matching them by name and returns the changes
+// required to converge the cluster. Public IPs are only compared if they're explicitly declared, not auto-detected.
+func PlanMachines(spec ClusterSpec, machines api.MachineMembersList) (MachinesPlan, error) {
+	var plan MachinesPlan
+
+	for _, ms := range spec.Machines {
+		i := slices.IndexFunc(machines, func(m *pb.MachineMember) bool {
+			return m.Machine.Name == ms.Name
+		})
+		if i == -1 {
+			if ms.SSH == "" {
+				return plan, fmt.Errorf("machine '%s' is not a member of the cluster and has no ssh destination "+
+					"to provision it", ms.Name)
+			}
+			plan.Add = append(plan.Add, ms)
+			continue
+		}
+		m := machines[i]
+
+		if ms.PublicIP == "" || ms.PublicIP == PublicIPAuto {
+			continue
+		}
+		declared, err := ms.PublicIPAddr()
+		if err != nil {
+			return plan, fmt.Errorf("machine '%s': %w", ms.Name, err)
+		}
+		var current, want netip.Addr
+		if m.Machine.PublicIp != nil {
+			current, _ = m.Machine.PublicIp.ToAddr()
+		}
+		if declared != nil {
+			want = *declared
+		}
+		if current != want {
+			plan.UpdatePublicIP = append(plan.UpdatePublicIP, MachinePublicIPUpdate{Machine: m, PublicIP: want})
+		}
+	}
+
+	for _, m := range machines {
+		if !slices.ContainsFunc(spec.Machines, func(ms MachineSpec) bool {
+			return ms.Name == m.Machine.Name
+		}) {
+			plan.Undeclared = append(plan.Undeclared, m)
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterSpecFromProject(t *testing.T) {
+	t.Parallel()
+
+	project := func(ext any) *types.Project {
+		return &types.Project{Extensions: types.Extensions{ClusterExtensionKey: ext}}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		spec, err := ClusterSpecFromProject(project(map[string]any{
+			"context": "prod",
+			"network": "10.210.0.0/16",
+			"caddy":   false,
+			"machines": []any{
+				map[string]any{"name": "vps1", "ssh": "root@203.0.113.10"},
+				map[string]any{
+					"name":          "vps2",
+					"ssh":           "ubuntu@203.0.113.11:2222",
+					"ssh_key":       "~/.ssh/id_vps2",
+					"public_ip":     "none",
+					"dns_endpoints": []any{"vps2.example.com", "vps2.example.org:51821"},
+				},
+			},
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "prod", spec.Context)
+		network, err := spec.NetworkPrefix()
+		require.NoError(t, err)
+		assert.Equal(t, netip.MustParsePrefix("10.210.0.0/16"), network)
+		assert.False(t, spec.CaddyEnabled())
+		assert.True(t, spec.DNSEnabled())
+		require.Len(t, spec.Machines, 2)
+
+		ip, err := spec.Machines[0].PublicIPAddr()
+		require.NoError(t, err)
+		require.NotNil(t, ip)
+		assert.False(t, ip.IsValid(), "public IP should be detected automatically")
+
+		remote, err := spec.Machines[1].RemoteMachine()
+		require.NoError(t, err)
+		assert.Equal(t, &RemoteMachine{User: "ubuntu", Host: "203.0.113.11", Port: 2222, KeyPath: "~/.ssh/id_vps2"},
+			remote)
+		ip, err = spec.Machines[1].PublicIPAddr()
+		require.NoError(t, err)
+		assert.Nil(t, ip)
+		endpoints, err := spec.Machines[1].ParsedDNSEndpoints()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vps2.example.com:51820", "vps2.example.org:51821"}, endpoints)
+	})
+
+	tests := []struct {
+		name    string
+		ext     any
+		wantErr string
+	}{
+		{
+			name:    "no machines",
+			ext:     map[string]any{"network": "10.210.0.0/16"},
+			wantErr: "at least one machine must be declared",
+		},
+		{
+			name:    "unknown field",
+			ext:     map[string]any{"machines": []any{map[string]any{"name": "vps1", "host": "203.0.113.10"}}},
+			wantErr: `unknown field "host"`,
+		},
+		{
+			name: "duplicate machine",
+			ext: map[string]any{"machines": []any{
+				map[string]any{"name": "vps1", "ssh": "root@203.0.113.10"},
+				map[string]any{"name": "vps1", "ssh": "root@203.0.113.11"},
+			}},
+			wantErr: "machine 'vps1' is declared more than once",
+		},
+		{
+			name:    "invalid ssh",
+			ext:     map[string]any{"machines": []any{map[string]any{"name": "vps1", "ssh": "root@203.0.113.10:ssh"}}},
+			wantErr: "invalid ssh destination",
+		},
+		{
+			name: "invalid public IP",
+			ext: map[string]any{"machines": []any{
+				map[string]any{"name": "vps1", "ssh": "root@203.0.113.10", "public_ip": "invalid"},
+			}},
+			wantErr: "invalid public IP",
+		},
+		{
+			name: "invalid network",
+			ext: map[string]any{
+				"network":  "10.210.0.0",
+				"machines": []any{map[string]any{"name": "vps1", "ssh": "root@203.0.113.10"}},
+			},
+			wantErr: "invalid network CIDR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ClusterSpecFromProject(project(tt.ext))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("no extension", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ClusterSpecFromProject(&types.Project{})
+		assert.ErrorContains(t, err, "'x-cluster' extension not found")
+	})
+}
+
+func TestPlanMachines(t *testing.T) {
+	t.Parallel()
+
+	member := func(id, name, publicIP string) *pb.MachineMember {
+		m := &pb.MachineMember{Machine: &pb.MachineInfo{Id: id, Name: name}}
+		if publicIP != "" {
+			m.Machine.PublicIp = pb.NewIP(netip.MustParseAddr(publicIP))
+		}
+		return m
+	}
+	machines := api.MachineMembersList{
+		member("id1", "vps1", "203.0.113.10"),
+		member("id2", "vps2", "203.0.113.11"),
+		member("id3", "vps3", ""),
+		member("id4", "legacy", ""),
+	}
+
+	spec := ClusterSpec{Machines: []MachineSpec{
+		// Auto-detected public IP is not compared.
+		{Name: "vps1", SSH: "root@203.0.113.10"},
+		{Name: "vps2", SSH: "root@203.0.113.11", PublicIP: PublicIPNone},
+		{Name: "vps3", SSH: "root@203.0.113.12", PublicIP: "203.0.113.12"},
+		{Name: "vps4", SSH: "root@203.0.113.13"},
+	}}
+
+	plan, err := PlanMachines(spec, machines)
+	require.NoError(t, err)
+	assert.False(t, plan.Empty())
+
+	require.Len(t, plan.Add, 1)
+	assert.Equal(t, "vps4", plan.Add[0].Name)
+
+	require.Len(t, plan.UpdatePublicIP, 2)
+	assert.Equal(t, "id2", plan.UpdatePublicIP[0].Machine.Machine.Id)
+	assert.False(t, plan.UpdatePublicIP[0].PublicIP.IsValid())
+	assert.Equal(t, "id3", plan.UpdatePublicIP[1].Machine.Machine.Id)
+	assert.Equal(t, netip.MustParseAddr("203.0.113.12"), plan.UpdatePublicIP[1].PublicIP)
+
+	require.Len(t, plan.Undeclared, 1)
+	assert.Equal(t, "legacy", plan.Undeclared[0].Machine.Name)
+
+	// Machines without an SSH destination can't be provisioned.
+	_, err = PlanMachines(ClusterSpec{Machines: []MachineSpec{{Name: "vps5"}}}, machines)
+	assert.ErrorContains(t, err, "machine 'vps5' is not a member of the cluster and has no ssh destination")
+
+	// The plan is empty when the cluster matches the spec.
+	spec.Machines = spec.Machines[:1]
+	plan, err = PlanMachines(spec, machines[:1])
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+	assert.Empty(t, plan.Undeclared)
+}
+
+func TestMachineSpecFromMember(t *testing.T) {
+	t.Parallel()
+
+	conns := []config.MachineConnection{
+		{SSH: "root@198.51.100.1"},
+		{SSH: "ubuntu@home.example.com:2222", SSHKeyFile: "~/.ssh/id_home"},
+		{SSH: "root@203.0.113.10", SSHKeyFile: "~/.ssh/id_vps1"},
+	}
+
+	vps1 := &pb.MachineMember{Machine: &pb.MachineInfo{
+		Name:     "vps1",
+		PublicIp: pb.NewIP(netip.MustParseAddr("203.0.113.10")),
+	}}
+	assert.Equal(t, MachineSpec{
+		Name:     "vps1",
+		SSH:      "root@203.0.113.10",
+		SSHKey:   "~/.ssh/id_vps1",
+		PublicIP: "203.0.113.10",
+	}, MachineSpecFromMember(vps1, conns))
+
+	home := &pb.MachineMember{Machine: &pb.MachineInfo{
+		Name: "home",
+		Network: &pb.NetworkConfig{
+			DnsEndpoints: []string{"home.example.com:51820"},
+		},
+	}}
+	assert.Equal(t, MachineSpec{
+		Name:         "home",
+		SSH:          "ubuntu@home.example.com:2222",
+		SSHKey:       "~/.ssh/id_home",
+		PublicIP:     PublicIPNone,
+		DNSEndpoints: []string{"home.example.com:51820"},
+	}, MachineSpecFromMember(home, conns))
+
+	// The SSH destination is left empty if no connection matches the machine.
+	other := &pb.MachineMember{Machine: &pb.MachineInfo{Name: "other"}}
+	assert.Empty(t, MachineSpecFromMember(other, conns).SSH)
+}
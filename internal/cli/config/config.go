@@ -1,31 +1,105 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/goccy/go-yaml"
 )
 
+// ErrConflict is returned by Config.Save when the changes conflict with the changes made to the config file
+// by another process since it was read.
+var ErrConflict = errors.New("conflict with changes made by another process")
+
+// SystemConfigPath is the path to the system-wide config file shared by all users of the machine.
+const SystemConfigPath = "/etc/uncloud/config.yaml"
+
+// ProjectConfigPath is the path to the project config file relative to the project directory. The project config
+// is looked up in the current directory and its parents.
+const ProjectConfigPath = ".uncloud/config.yaml"
+
+// Config is the Uncloud configuration read from the user config file. If loaded with Load, the contexts and current
+// context of the system and project config files are merged into it. The user config takes precedence over
+// the system config and the project config takes precedence over both. The changes are always saved to the user
+// config file.
 type Config struct {
 	CurrentContext string              `yaml:"current_context"`
 	Contexts       map[string]*Context `yaml:"contexts"`
+	// ReleaseChannel is the release channel the CLI and machine daemons are upgraded from. Empty means stable.
+	ReleaseChannel string `yaml:"release_channel,omitempty"`
+	// ContextGroups are named groups of contexts to run commands against all clusters of a group at once with
+	// the --context-group flag.
+	ContextGroups map[string][]string `yaml:"context_groups,omitempty"`
 
 	// path is the file path config is read from.
 	path string
+	// base is a copy of the config as it was last read from or saved to the file including the merged system
+	// and project configs. It's used to determine the changes made to the config.
+	base *Config
+	// fileBase is a copy of the user config file as it was last read or saved. It's used to merge the changes
+	// with the concurrent changes made to the file by other processes.
+	fileBase *Config
+	// system and project are the system and project configs merged into the config. Nil if not loaded.
+	system, project *Config
 }
 
 func NewFromFile(path string) (*Config, error) {
+	return newLayered(path, nil, nil)
+}
+
+// Load reads the user config file at path and merges the system config file at SystemConfigPath and the project
+// config file found in the current directory or its parents into it if they exist.
+func Load(path string) (*Config, error) {
+	system, err := readIfExists(SystemConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var project *Config
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get current directory: %w", err)
+	}
+	if projectPath := FindProjectConfig(wd); projectPath != "" {
+		if project, err = readIfExists(projectPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return newLayered(path, system, project)
+}
+
+// FindProjectConfig returns the path to the project config file in dir or its closest parent. An empty string is
+// returned if there is no project config file.
+func FindProjectConfig(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectConfigPath)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func newLayered(path string, system, project *Config) (*Config, error) {
 	_, err := os.Stat(path)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("check file permissions '%s': %w", path, err)
 	}
 	c := &Config{
-		Contexts: map[string]*Context{},
-		path:     path,
+		path:    path,
+		system:  system,
+		project: project,
 	}
 	if os.IsNotExist(err) {
+		c.setFile(&Config{})
 		return c, nil
 	}
 
@@ -39,18 +113,91 @@ func (c *Config) Path() string {
 	return c.path
 }
 
+// ProjectPath returns the path to the project config file merged into the config or an empty string if there is
+// no project config.
+func (c *Config) ProjectPath() string {
+	if c.project == nil {
+		return ""
+	}
+	return c.project.path
+}
+
+// ProjectCurrentContext returns the current context set by the project config that takes precedence over
+// the current context set by the user config. An empty string is returned if the project config doesn't set it.
+func (c *Config) ProjectCurrentContext() string {
+	if c.project == nil {
+		return ""
+	}
+	return c.project.CurrentContext
+}
+
 func (c *Config) Read() error {
-	data, err := os.ReadFile(c.path)
+	file, err := readFile(c.path)
 	if err != nil {
-		return fmt.Errorf("read config file '%s': %w", c.path, err)
+		return err
 	}
+	c.setFile(file)
+
+	return nil
+}
+
+// readFile reads and parses the config file at path.
+func readFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file '%s': %w", path, err)
+	}
+	c := &Config{path: path}
 	if err = yaml.Unmarshal(data, c); err != nil {
-		return fmt.Errorf("parse config file '%s': %s", c.path, yaml.FormatError(err, true, true))
+		return nil, fmt.Errorf("parse config file '%s': %s", path, yaml.FormatError(err, true, true))
 	}
+	return c, nil
+}
 
-	return nil
+// readIfExists reads the config file at path or returns nil if it doesn't exist.
+func readIfExists(path string) (*Config, error) {
+	c, err := readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return c, err
+}
+
+// setFile sets the config to the user config file with the system and project configs merged into it.
+func (c *Config) setFile(file *Config) {
+	c.fileBase = file.clone()
+
+	c.CurrentContext = ""
+	c.ReleaseChannel = ""
+	c.Contexts = make(map[string]*Context)
+	c.ContextGroups = nil
+	for _, layer := range []*Config{c.system, file, c.project} {
+		if layer == nil {
+			continue
+		}
+		if layer.CurrentContext != "" {
+			c.CurrentContext = layer.CurrentContext
+		}
+		if layer.ReleaseChannel != "" {
+			c.ReleaseChannel = layer.ReleaseChannel
+		}
+		for name, ctx := range layer.Contexts {
+			c.Contexts[name] = ctx.clone()
+		}
+		for name, contexts := range layer.ContextGroups {
+			if c.ContextGroups == nil {
+				c.ContextGroups = make(map[string][]string)
+			}
+			c.ContextGroups[name] = slices.Clone(contexts)
+		}
+	}
+	c.base = c.clone()
 }
 
+// Save writes the config to the user config file. The file is locked while saving to serialise concurrent writes
+// from multiple processes. Only the changes made to the config since it was read are written so the contexts
+// of the system and project configs are not copied to the user config. If the file has been changed by another
+// process since it was read, the changes are merged. ErrConflict is returned if they can't be merged.
 func (c *Config) Save() error {
 	dir, _ := filepath.Split(c.path)
 	// If dir is empty (e.g., when path is just a filename), use current directory
@@ -61,15 +208,191 @@ func (c *Config) Save() error {
 		return fmt.Errorf("create config directory '%s': %w", dir, err)
 	}
 
-	f, err := os.OpenFile(c.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	unlock, err := lockFile(c.path + ".lock")
+	if err != nil {
+		return fmt.Errorf("lock config file '%s': %w", c.path, err)
+	}
+	defer unlock()
+
+	current, err := readFile(c.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		current = &Config{}
+	}
+	file, err := c.merge(current)
+	if err != nil {
+		return fmt.Errorf("save config file '%s': %w", c.path, err)
+	}
+
+	if err = c.write(dir, file); err != nil {
+		return err
+	}
+	c.setFile(file)
+	return nil
+}
+
+// write atomically writes the file config to the config file by writing it to a temporary file in dir
+// and renaming it.
+func (c *Config) write(dir string, file *Config) error {
+	path := c.path
+	// Replace the target file rather than the symlink if the config file is a symlink.
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+		dir = filepath.Dir(resolved)
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
 	if err != nil {
 		return fmt.Errorf("write config file '%s': %w", c.path, err)
 	}
+	defer os.Remove(f.Name())
 
 	encoder := yaml.NewEncoder(f, yaml.Indent(2), yaml.IndentSequence(true))
-	if err = encoder.Encode(c); err != nil {
+	if err = encoder.Encode(file); err != nil {
 		_ = f.Close()
 		return fmt.Errorf("encode config file '%s': %w", c.path, err)
 	}
-	return f.Close()
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("write config file '%s': %w", c.path, err)
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("write config file '%s': %w", c.path, err)
+	}
+	return nil
+}
+
+// merge applies the changes made to the config since it was read on top of the current config read from the file
+// and returns the resulting file config. Concurrent changes to the connections of the same context are merged.
+// ErrConflict is returned if both changed the current context or one removed a context the other changed.
+func (c *Config) merge(current *Config) (*Config, error) {
+	base, fileBase := c.base, c.fileBase
+	if base == nil {
+		base = &Config{}
+	}
+	if fileBase == nil {
+		fileBase = &Config{}
+	}
+	file := &Config{
+		CurrentContext: current.CurrentContext,
+		Contexts:       make(map[string]*Context),
+		ReleaseChannel: current.ReleaseChannel,
+		ContextGroups:  cloneGroups(current.ContextGroups),
+	}
+
+	if c.CurrentContext != base.CurrentContext {
+		if current.CurrentContext != fileBase.CurrentContext && current.CurrentContext != c.CurrentContext {
+			return nil, fmt.Errorf("current context '%s': %w", current.CurrentContext, ErrConflict)
+		}
+		file.CurrentContext = c.CurrentContext
+	}
+	if c.ReleaseChannel != base.ReleaseChannel {
+		if current.ReleaseChannel != fileBase.ReleaseChannel && current.ReleaseChannel != c.ReleaseChannel {
+			return nil, fmt.Errorf("release channel '%s': %w", current.ReleaseChannel, ErrConflict)
+		}
+		file.ReleaseChannel = c.ReleaseChannel
+	}
+	groups := make(map[string]struct{})
+	for _, m := range []map[string][]string{base.ContextGroups, c.ContextGroups} {
+		for name := range m {
+			groups[name] = struct{}{}
+		}
+	}
+	for name := range groups {
+		ours, orig := c.ContextGroups[name], base.ContextGroups[name]
+		if slices.Equal(ours, orig) {
+			continue
+		}
+		theirs := current.ContextGroups[name]
+		if !slices.Equal(theirs, fileBase.ContextGroups[name]) && !slices.Equal(theirs, ours) {
+			return nil, fmt.Errorf("context group '%s': %w", name, ErrConflict)
+		}
+		if ours == nil {
+			delete(file.ContextGroups, name)
+			continue
+		}
+		if file.ContextGroups == nil {
+			file.ContextGroups = make(map[string][]string)
+		}
+		file.ContextGroups[name] = slices.Clone(ours)
+	}
+
+	names := make(map[string]struct{})
+	for _, m := range []map[string]*Context{base.Contexts, c.Contexts, current.Contexts} {
+		for name := range m {
+			names[name] = struct{}{}
+		}
+	}
+	for name := range names {
+		ours, theirs, orig := c.Contexts[name], current.Contexts[name], base.Contexts[name]
+
+		var merged *Context
+		switch {
+		case ours.equal(orig):
+			merged = theirs
+		case theirs.equal(fileBase.Contexts[name]) || ours.equal(theirs):
+			merged = ours
+		case ours == nil || theirs == nil:
+			// One removed the context while the other changed it.
+			return nil, fmt.Errorf("context '%s': %w", name, ErrConflict)
+		default:
+			merged = ours.mergeConnections(theirs, orig)
+		}
+		if merged != nil {
+			file.Contexts[name] = merged.clone()
+		}
+	}
+
+	return file, nil
+}
+
+// clone returns a deep copy of the config without the path and base.
+func (c *Config) clone() *Config {
+	cp := &Config{
+		CurrentContext: c.CurrentContext,
+		Contexts:       make(map[string]*Context, len(c.Contexts)),
+		ReleaseChannel: c.ReleaseChannel,
+		ContextGroups:  cloneGroups(c.ContextGroups),
+	}
+	for name, ctx := range c.Contexts {
+		cp.Contexts[name] = ctx.clone()
+	}
+	return cp
+}
+
+// cloneGroups returns a deep copy of the context groups.
+func cloneGroups(groups map[string][]string) map[string][]string {
+	if groups == nil {
+		return nil
+	}
+	cp := make(map[string][]string, len(groups))
+	for name, contexts := range groups {
+		cp[name] = slices.Clone(contexts)
+	}
+	return cp
+}
+
+// ContextGroup returns the names of the contexts in the context group. It fails if the group doesn't exist, is empty,
+// or refers to a context that doesn't exist.
+func (c *Config) ContextGroup(name string) ([]string, error) {
+	contexts, ok := c.ContextGroups[name]
+	if !ok {
+		return nil, fmt.Errorf("context group '%s' not found in the Uncloud config (%s)", name, c.path)
+	}
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("context group '%s' has no contexts in the Uncloud config (%s)", name, c.path)
+	}
+	for _, ctx := range contexts {
+		if _, ok = c.Contexts[ctx]; !ok {
+			return nil, fmt.Errorf("context '%s' of context group '%s' not found in the Uncloud config (%s)",
+				ctx, name, c.path)
+		}
+	}
+	return slices.Clone(contexts), nil
+}
+
+// equalConnections returns true if the connection lists contain the same connections in the same order.
+func equalConnections(a, b []MachineConnection) bool {
+	return slices.EqualFunc(a, b, MachineConnection.Equal)
 }
@@ -1,9 +1,16 @@
 package config
 
 import (
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig_Save(t *testing.T) {
@@ -78,3 +85,210 @@ func TestConfig_Save(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_SaveConcurrentChanges(t *testing.T) {
+	t.Parallel()
+
+	conn := func(dest string) MachineConnection {
+		return MachineConnection{SSH: SSHDestination(dest)}
+	}
+	setup := func(t *testing.T) string {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		cfg, err := NewFromFile(path)
+		require.NoError(t, err)
+		cfg.CurrentContext = "default"
+		cfg.Contexts["default"] = &Context{Connections: []MachineConnection{conn("root@vps1")}}
+		cfg.Contexts["old"] = &Context{Connections: []MachineConnection{conn("root@vps2")}}
+		require.NoError(t, cfg.Save())
+		return path
+	}
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+		path := setup(t)
+
+		cfg1, err := NewFromFile(path)
+		require.NoError(t, err)
+		cfg2, err := NewFromFile(path)
+		require.NoError(t, err)
+
+		cfg1.Contexts["prod"] = &Context{Connections: []MachineConnection{conn("root@prod")}}
+		cfg1.ReleaseChannel = "beta"
+		cfg1.Contexts["default"].ClusterID = "c1"
+		cfg1.Contexts["default"].Connections = append(cfg1.Contexts["default"].Connections, conn("root@vps3"))
+		require.NoError(t, cfg1.Save())
+
+		delete(cfg2.Contexts, "old")
+		cfg2.CurrentContext = "old2"
+		cfg2.Contexts["old2"] = &Context{}
+		cfg2.Contexts["default"].Connections = append(cfg2.Contexts["default"].Connections, conn("root@vps4"))
+		require.NoError(t, cfg2.Save())
+
+		cfg, err := NewFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "old2", cfg.CurrentContext)
+		assert.Equal(t, "beta", cfg.ReleaseChannel)
+		assert.ElementsMatch(t, []string{"default", "prod", "old2"}, slices.Collect(maps.Keys(cfg.Contexts)))
+		assert.Equal(t, "c1", cfg.Contexts["default"].ClusterID)
+		assert.Equal(t, []MachineConnection{conn("root@vps1"), conn("root@vps3"), conn("root@vps4")},
+			cfg.Contexts["default"].Connections)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Parallel()
+		path := setup(t)
+
+		cfg1, err := NewFromFile(path)
+		require.NoError(t, err)
+		cfg2, err := NewFromFile(path)
+		require.NoError(t, err)
+
+		delete(cfg1.Contexts, "old")
+		require.NoError(t, cfg1.Save())
+
+		cfg2.Contexts["old"].Connections = append(cfg2.Contexts["old"].Connections, conn("root@vps5"))
+		assert.ErrorIs(t, cfg2.Save(), ErrConflict)
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		t.Parallel()
+		path := setup(t)
+
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cfg, err := NewFromFile(path)
+				if !assert.NoError(t, err) {
+					return
+				}
+				cfg.Contexts[fmt.Sprintf("ctx%d", i)] = &Context{}
+				assert.NoError(t, cfg.Save())
+			}()
+		}
+		wg.Wait()
+
+		cfg, err := NewFromFile(path)
+		require.NoError(t, err)
+		assert.Len(t, cfg.Contexts, 12)
+	})
+}
+
+func TestLoadLayered(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	systemPath := filepath.Join(dir, "etc", "config.yaml")
+	userPath := filepath.Join(dir, "home", "config.yaml")
+	projectDir := filepath.Join(dir, "project")
+	writeFile(systemPath, `current_context: shared
+contexts:
+  shared:
+    connections:
+      - ssh: root@shared
+  prod:
+    connections:
+      - ssh: root@system-prod
+`)
+	writeFile(userPath, `current_context: dev
+contexts:
+  dev:
+    connections:
+      - ssh: root@dev
+  prod:
+    connections:
+      - ssh: root@user-prod
+`)
+	writeFile(filepath.Join(projectDir, ProjectConfigPath), `current_context: prod
+contexts:
+  prod:
+    connections:
+      - ssh: root@project-prod
+`)
+
+	projectPath := FindProjectConfig(filepath.Join(projectDir, "sub", "dir"))
+	assert.Equal(t, filepath.Join(projectDir, ProjectConfigPath), projectPath)
+	assert.Empty(t, FindProjectConfig(filepath.Join(dir, "home")))
+
+	load := func() *Config {
+		system, err := readIfExists(systemPath)
+		require.NoError(t, err)
+		project, err := readIfExists(projectPath)
+		require.NoError(t, err)
+		cfg, err := newLayered(userPath, system, project)
+		require.NoError(t, err)
+		return cfg
+	}
+	cfg := load()
+
+	assert.Equal(t, "prod", cfg.CurrentContext)
+	assert.Equal(t, "prod", cfg.ProjectCurrentContext())
+	assert.ElementsMatch(t, []string{"shared", "dev", "prod"}, slices.Collect(maps.Keys(cfg.Contexts)))
+	assert.Equal(t, SSHDestination("root@project-prod"), cfg.Contexts["prod"].Connections[0].SSH)
+
+	// Only the changes are saved to the user config.
+	cfg.Contexts["new"] = &Context{}
+	cfg.Contexts["dev"].Connections = append(cfg.Contexts["dev"].Connections,
+		MachineConnection{SSH: "root@dev2"})
+	require.NoError(t, cfg.Save())
+	assert.Contains(t, cfg.Contexts, "shared", "merged contexts are kept after save")
+
+	user, err := readFile(userPath)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", user.CurrentContext)
+	assert.ElementsMatch(t, []string{"dev", "prod", "new"}, slices.Collect(maps.Keys(user.Contexts)))
+	assert.Equal(t, SSHDestination("root@user-prod"), user.Contexts["prod"].Connections[0].SSH)
+	assert.Len(t, user.Contexts["dev"].Connections, 2)
+
+	assert.Len(t, load().Contexts["dev"].Connections, 2)
+}
+
+func TestConfig_ContextGroup(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	systemPath := filepath.Join(dir, "system.yaml")
+	userPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(systemPath, []byte(`contexts:
+  eu:
+    connections:
+      - ssh: root@eu
+  us:
+    connections:
+      - ssh: root@us
+context_groups:
+  prod: [eu, us]
+  broken: [eu, missing]
+  empty: []
+`), 0o600))
+
+	system, err := readIfExists(systemPath)
+	require.NoError(t, err)
+	cfg, err := newLayered(userPath, system, nil)
+	require.NoError(t, err)
+
+	contexts, err := cfg.ContextGroup("prod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu", "us"}, contexts)
+
+	_, err = cfg.ContextGroup("unknown")
+	assert.ErrorContains(t, err, "context group 'unknown' not found")
+	_, err = cfg.ContextGroup("broken")
+	assert.ErrorContains(t, err, "context 'missing' of context group 'broken' not found")
+	_, err = cfg.ContextGroup("empty")
+	assert.ErrorContains(t, err, "has no contexts")
+
+	// Only the changed groups are saved to the user config.
+	cfg.ContextGroups["staging"] = []string{"eu"}
+	require.NoError(t, cfg.Save())
+
+	user, err := readFile(userPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"staging": {"eu"}}, user.ContextGroups)
+	assert.Contains(t, cfg.ContextGroups, "prod", "merged groups are kept after save")
+}
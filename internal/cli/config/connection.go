@@ -16,13 +16,25 @@ const (
 )
 
 type MachineConnection struct {
-	SSH        SSHDestination `yaml:"ssh,omitempty"`
-	SSHKeyFile string         `yaml:"ssh_key_file,omitempty"`
+	SSH SSHDestination `yaml:"ssh,omitempty"`
+	// SSHKeyFile is the path to the SSH private key or a secret reference URI to retrieve it at connect time
+	// with the 1Password, Bitwarden, or Vault CLI, e.g. op://vault/item/private-key or vault://kv/ssh.
+	SSHKeyFile string `yaml:"ssh_key_file,omitempty"`
 	// TCP is the address and port of the machine's API server.
 	// The pointer is used to omit the field when not set. Otherwise, yaml marshalling includes an empty object.
 	TCP       *netip.AddrPort `yaml:"tcp,omitempty"`
 	Host      string          `yaml:"host,omitempty"`
 	PublicKey secret.Secret   `yaml:"public_key,omitempty"`
+	// ReadOnly connects to the read-only API socket of the machine that denies the requests changing the cluster.
+	// The SSH user should only be a member of the uncloud-readonly group. Only supported for SSH connections.
+	ReadOnly bool `yaml:"read_only,omitempty"`
+}
+
+// Equal returns true if both connections have the same configuration.
+func (c MachineConnection) Equal(other MachineConnection) bool {
+	tcpEqual := c.TCP == other.TCP || (c.TCP != nil && other.TCP != nil && *c.TCP == *other.TCP)
+	return c.SSH == other.SSH && c.SSHKeyFile == other.SSHKeyFile && tcpEqual &&
+		c.Host == other.Host && c.PublicKey.Equal(other.PublicKey) && c.ReadOnly == other.ReadOnly
 }
 
 func (c MachineConnection) String() string {
@@ -1,6 +1,65 @@
 package config
 
+import "slices"
+
 type Context struct {
-	Name        string              `yaml:"-"`
+	Name string `yaml:"-"`
+	// ClusterID is the unique ID of the cluster the context connects to. It's used to verify that the connected
+	// machine belongs to the cluster. Empty if the cluster doesn't have an ID or it hasn't been recorded yet.
+	ClusterID   string              `yaml:"cluster_id,omitempty"`
 	Connections []MachineConnection `yaml:"connections"`
 }
+
+func (c *Context) clone() *Context {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	cp.Connections = make([]MachineConnection, len(c.Connections))
+	for i, conn := range c.Connections {
+		cp.Connections[i] = conn
+		if conn.TCP != nil {
+			tcp := *conn.TCP
+			cp.Connections[i].TCP = &tcp
+		}
+	}
+	return &cp
+}
+
+// equal returns true if both contexts are nil or have the same cluster ID and connections.
+func (c *Context) equal(other *Context) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.ClusterID == other.ClusterID && equalConnections(c.Connections, other.Connections)
+}
+
+// mergeConnections returns a copy of theirs with the connections added to and removed from c since orig applied.
+// The cluster ID of c is used if it was changed since orig.
+func (c *Context) mergeConnections(theirs, orig *Context) *Context {
+	merged := theirs.clone()
+	var origConns []MachineConnection
+	origClusterID := ""
+	if orig != nil {
+		origConns = orig.Connections
+		origClusterID = orig.ClusterID
+	}
+	if c.ClusterID != origClusterID {
+		merged.ClusterID = c.ClusterID
+	}
+	contains := func(conns []MachineConnection, conn MachineConnection) bool {
+		return slices.ContainsFunc(conns, conn.Equal)
+	}
+
+	for _, conn := range origConns {
+		if !contains(c.Connections, conn) {
+			merged.Connections = slices.DeleteFunc(merged.Connections, conn.Equal)
+		}
+	}
+	for _, conn := range c.Connections {
+		if !contains(origConns, conn) && !contains(merged.Connections, conn) {
+			merged.Connections = append(merged.Connections, conn)
+		}
+	}
+	return merged
+}
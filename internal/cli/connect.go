@@ -4,14 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
 	"github.com/psviderski/uncloud/internal/cli/config"
 	"github.com/psviderski/uncloud/internal/fs"
+	"github.com/psviderski/uncloud/internal/machine"
+	"github.com/psviderski/uncloud/internal/sshexec"
 	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/psviderski/uncloud/pkg/client/connector"
 )
@@ -20,22 +25,90 @@ import (
 type ConnectOptions struct {
 	// Whether to show connection progress spinner if stdout is a terminal or progress logs if not.
 	ShowProgress bool
+	// NoColor disables the colors of the connection progress spinner.
+	NoColor bool
+	// GRPC configures the compression and message size limits of the gRPC connection to the cluster.
+	GRPC connector.GRPCOptions
+	// SSH configures the connect timeout, retries, and keepalives of SSH connections to the cluster.
+	SSH sshexec.ConnectOptions
+}
+
+// GRPCOptionsFromEnv returns the gRPC connection options configured with the UNCLOUD_GRPC_COMPRESSION
+// and UNCLOUD_GRPC_MAX_MESSAGE_SIZE environment variables. The max message size, e.g. 64MiB, limits both
+// the sent and received messages.
+func GRPCOptionsFromEnv() (connector.GRPCOptions, error) {
+	opts := connector.GRPCOptions{
+		Compression: os.Getenv("UNCLOUD_GRPC_COMPRESSION"),
+	}
+	if size := os.Getenv("UNCLOUD_GRPC_MAX_MESSAGE_SIZE"); size != "" {
+		bytes, err := units.RAMInBytes(size)
+		if err != nil {
+			return opts, fmt.Errorf("parse UNCLOUD_GRPC_MAX_MESSAGE_SIZE: %w", err)
+		}
+		if bytes > math.MaxInt32 {
+			return opts, fmt.Errorf("UNCLOUD_GRPC_MAX_MESSAGE_SIZE must not exceed 2GiB: %s", size)
+		}
+		opts.MaxRecvMsgSize = int(bytes)
+		opts.MaxSendMsgSize = int(bytes)
+	}
+	if err := opts.Validate(); err != nil {
+		return opts, fmt.Errorf("invalid UNCLOUD_GRPC_* environment variables: %w", err)
+	}
+	return opts, nil
+}
+
+// SSHOptionsFromEnv returns the SSH connection options configured with the UNCLOUD_SSH_CONNECT_TIMEOUT,
+// UNCLOUD_SSH_CONNECT_RETRIES, UNCLOUD_SSH_KEEPALIVE_INTERVAL, and UNCLOUD_SSH_KEEPALIVE_COUNT_MAX environment
+// variables. The timeout and interval are durations, e.g. 10s. A keepalive interval of 0 disables keepalives.
+func SSHOptionsFromEnv() (sshexec.ConnectOptions, error) {
+	var opts sshexec.ConnectOptions
+	var err error
+	if v := os.Getenv("UNCLOUD_SSH_CONNECT_TIMEOUT"); v != "" {
+		if opts.ConnectTimeout, err = time.ParseDuration(v); err != nil {
+			return opts, fmt.Errorf("parse UNCLOUD_SSH_CONNECT_TIMEOUT: %w", err)
+		}
+	}
+	if v := os.Getenv("UNCLOUD_SSH_CONNECT_RETRIES"); v != "" {
+		if opts.ConnectRetries, err = strconv.Atoi(v); err != nil {
+			return opts, fmt.Errorf("parse UNCLOUD_SSH_CONNECT_RETRIES: %w", err)
+		}
+	}
+	if v := os.Getenv("UNCLOUD_SSH_KEEPALIVE_INTERVAL"); v != "" {
+		if opts.KeepaliveInterval, err = time.ParseDuration(v); err != nil {
+			return opts, fmt.Errorf("parse UNCLOUD_SSH_KEEPALIVE_INTERVAL: %w", err)
+		}
+		if opts.KeepaliveInterval == 0 {
+			// Zero means the default in the options so use a negative value to disable keepalives.
+			opts.KeepaliveInterval = -1
+		}
+	}
+	if v := os.Getenv("UNCLOUD_SSH_KEEPALIVE_COUNT_MAX"); v != "" {
+		if opts.KeepaliveCountMax, err = strconv.Atoi(v); err != nil {
+			return opts, fmt.Errorf("parse UNCLOUD_SSH_KEEPALIVE_COUNT_MAX: %w", err)
+		}
+	}
+	if err = opts.Validate(); err != nil {
+		return opts, fmt.Errorf("invalid UNCLOUD_SSH_* environment variables: %w", err)
+	}
+	return opts, nil
 }
 
 func ConnectCluster(ctx context.Context, conn config.MachineConnection, opts ConnectOptions) (*client.Client, error) {
 	if opts.ShowProgress {
-		return connectClusterWithProgress(ctx, conn)
+		return connectClusterWithProgress(ctx, conn, opts)
 	}
-	return connectCluster(ctx, conn)
+	return connectCluster(ctx, conn, opts)
 }
 
 // connectClusterWithProgress connects to the cluster while displaying a progress spinner.
 // If the stdout is not a terminal, it falls back to simple progress logs to stderr.
-func connectClusterWithProgress(ctx context.Context, conn config.MachineConnection) (*client.Client, error) {
+func connectClusterWithProgress(
+	ctx context.Context, conn config.MachineConnection, opts ConnectOptions,
+) (*client.Client, error) {
 	// If stdout is not a terminal, fall back to simple progress logs.
 	if !IsStdoutTerminal() {
 		fmt.Fprintln(os.Stderr, "Connecting to", conn.String())
-		cli, err := connectCluster(ctx, conn)
+		cli, err := connectCluster(ctx, conn, opts)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, "Connection failed:", err)
 		} else {
@@ -45,7 +118,7 @@ func connectClusterWithProgress(ctx context.Context, conn config.MachineConnecti
 	}
 
 	// Run the connection TUI model.
-	p := tea.NewProgram(newConnectModel(ctx, conn))
+	p := tea.NewProgram(newConnectModel(ctx, conn, opts))
 	model, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("run connection TUI: %w", err)
@@ -55,7 +128,7 @@ func connectClusterWithProgress(ctx context.Context, conn config.MachineConnecti
 	return m.result.client, m.result.err
 }
 
-func connectCluster(ctx context.Context, conn config.MachineConnection) (*client.Client, error) {
+func connectCluster(ctx context.Context, conn config.MachineConnection, opts ConnectOptions) (*client.Client, error) {
 	if conn.SSH != "" {
 		user, host, port, err := conn.SSH.Parse()
 		if err != nil {
@@ -69,10 +142,21 @@ func connectCluster(ctx context.Context, conn config.MachineConnection) (*client
 			Host:    host,
 			Port:    port,
 			KeyPath: keyPath,
+			SSH:     opts.SSH,
+			GRPC:    opts.GRPC,
+		}
+		if conn.ReadOnly {
+			sshConfig.SockPath = machine.DefaultReadOnlySockPath
 		}
 		return client.New(ctx, connector.NewSSHConnector(sshConfig))
 	} else if conn.TCP != nil && conn.TCP.IsValid() {
-		return client.New(ctx, connector.NewTCPConnector(*conn.TCP))
+		if conn.ReadOnly {
+			// The machine API on the TCP port doesn't distinguish clients so read-only can't be enforced.
+			return nil, errors.New("read-only connections are only supported over SSH")
+		}
+		tcpConnector := connector.NewTCPConnector(*conn.TCP)
+		tcpConnector.GRPC = opts.GRPC
+		return client.New(ctx, tcpConnector)
 	}
 
 	return nil, errors.New("connection configuration is invalid")
@@ -83,6 +167,10 @@ type connectModel struct {
 	ctx     context.Context
 	conn    config.MachineConnection
 	spinner spinner.Model
+	// noColor disables the colors of the spinner and the connection address.
+	noColor bool
+	// opts configures the gRPC and SSH connections to the cluster.
+	opts ConnectOptions
 	// showSpinner controls whether the spinner is visible (delayed to avoid flashing).
 	showSpinner bool
 	// done indicates whether the connection attempt has completed (successfully or with error).
@@ -99,15 +187,19 @@ type connectResultMsg struct {
 // showSpinnerMsg is sent after a delay to show the spinner.
 type showSpinnerMsg struct{}
 
-func newConnectModel(ctx context.Context, conn config.MachineConnection) connectModel {
+func newConnectModel(ctx context.Context, conn config.MachineConnection, opts ConnectOptions) connectModel {
 	s := spinner.New()
 	s.Spinner = spinner.MiniDot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("3")) // the same yellow as in compose progress
+	if !opts.NoColor {
+		s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("3")) // the same yellow as in compose progress
+	}
 
 	return connectModel{
 		ctx:     ctx,
 		conn:    conn,
 		spinner: s,
+		noColor: opts.NoColor,
+		opts:    opts,
 	}
 }
 
@@ -121,7 +213,7 @@ func (m connectModel) Init() tea.Cmd {
 
 func (m connectModel) connect() tea.Cmd {
 	return func() tea.Msg {
-		cli, err := connectCluster(m.ctx, m.conn)
+		cli, err := connectCluster(m.ctx, m.conn, m.opts)
 		return connectResultMsg{
 			client: cli,
 			err:    err,
@@ -181,7 +273,10 @@ func (m connectModel) View() string {
 		return ""
 	}
 
-	style := lipgloss.NewStyle().Foreground(lipgloss.Color("153"))
+	style := lipgloss.NewStyle()
+	if !m.noColor {
+		style = style.Foreground(lipgloss.Color("153"))
+	}
 	return fmt.Sprintf("%s %s\n",
 		m.spinner.View(),
 		fmt.Sprintf("Connecting to %s", style.Render(m.conn.String())),
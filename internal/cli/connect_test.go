@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/pkg/client/connector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCOptionsFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("UNCLOUD_GRPC_COMPRESSION", "")
+		t.Setenv("UNCLOUD_GRPC_MAX_MESSAGE_SIZE", "")
+
+		opts, err := GRPCOptionsFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, connector.GRPCOptions{}, opts)
+	})
+
+	t.Run("compression and max message size", func(t *testing.T) {
+		t.Setenv("UNCLOUD_GRPC_COMPRESSION", "zstd")
+		t.Setenv("UNCLOUD_GRPC_MAX_MESSAGE_SIZE", "64MiB")
+
+		opts, err := GRPCOptionsFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, connector.GRPCOptions{
+			Compression:    "zstd",
+			MaxRecvMsgSize: 64 << 20,
+			MaxSendMsgSize: 64 << 20,
+		}, opts)
+	})
+
+	t.Run("invalid compression", func(t *testing.T) {
+		t.Setenv("UNCLOUD_GRPC_COMPRESSION", "snappy")
+		t.Setenv("UNCLOUD_GRPC_MAX_MESSAGE_SIZE", "")
+
+		_, err := GRPCOptionsFromEnv()
+		assert.ErrorContains(t, err, "unsupported compression 'snappy'")
+	})
+
+	t.Run("invalid max message size", func(t *testing.T) {
+		t.Setenv("UNCLOUD_GRPC_COMPRESSION", "")
+
+		t.Setenv("UNCLOUD_GRPC_MAX_MESSAGE_SIZE", "big")
+		_, err := GRPCOptionsFromEnv()
+		assert.Error(t, err)
+
+		t.Setenv("UNCLOUD_GRPC_MAX_MESSAGE_SIZE", "4GiB")
+		_, err = GRPCOptionsFromEnv()
+		assert.ErrorContains(t, err, "must not exceed 2GiB")
+	})
+}
+
+func TestConnectCluster_ReadOnlyTCP(t *testing.T) {
+	t.Parallel()
+
+	addr := netip.MustParseAddrPort("10.210.0.1:51000")
+	_, err := connectCluster(context.Background(), config.MachineConnection{TCP: &addr, ReadOnly: true},
+		ConnectOptions{})
+	assert.ErrorContains(t, err, "read-only connections are only supported over SSH")
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// connectionCheckTimeout is the maximum time to check a single context connection.
+const connectionCheckTimeout = 15 * time.Second
+
+// ConnectionStatus is the health status of a context connection.
+type ConnectionStatus string
+
+const (
+	// ConnectionOK means the connection points to a member machine of the context cluster.
+	ConnectionOK ConnectionStatus = "ok"
+	// ConnectionUnreachable means the SSH or TCP connection to the machine failed.
+	ConnectionUnreachable ConnectionStatus = "unreachable"
+	// ConnectionAPIError means the machine is reachable but its API didn't respond.
+	ConnectionAPIError ConnectionStatus = "api error"
+	// ConnectionStale means the machine is not a member of the context cluster anymore, for example,
+	// it was removed from the cluster or re-imaged.
+	ConnectionStale ConnectionStatus = "stale"
+)
+
+// ConnectionCheck is the result of a health check of a context connection.
+type ConnectionCheck struct {
+	Connection config.MachineConnection
+	Status     ConnectionStatus
+	// Machine is the name of the machine the connection points to if its API responded.
+	Machine string
+	// Err describes why the connection is not healthy.
+	Err error
+
+	// machineID is the ID of the machine the connection points to. Empty if the machine is not a cluster member.
+	machineID string
+	// members are the IDs of the cluster members as seen by the machine.
+	members []string
+	// clusterID is the ID of the cluster the machine belongs to. Empty if the cluster doesn't have an ID.
+	clusterID string
+}
+
+// CheckContextConnections checks every connection of the cluster context with the given name or the current context
+// if not specified. A connection is checked by connecting to the machine over SSH or TCP, calling its API, and
+// verifying that the machine is a member of the same cluster as the machines of the other connections.
+func (cli *CLI) CheckContextConnections(ctx context.Context, contextName string) ([]ConnectionCheck, error) {
+	if cli.Config == nil {
+		return nil, errors.New("context management is not available: Uncloud configuration file is not being used")
+	}
+	if contextName == "" {
+		contextName = cli.Config.CurrentContext
+	}
+	cfg, ok := cli.Config.Contexts[contextName]
+	if !ok {
+		return nil, fmt.Errorf("cluster context '%s' not found in the Uncloud config (%s)",
+			contextName, cli.Config.Path())
+	}
+
+	checks := make([]ConnectionCheck, len(cfg.Connections))
+	var wg sync.WaitGroup
+	for i, conn := range cfg.Connections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks[i] = checkConnection(ctx, conn)
+		}()
+	}
+	wg.Wait()
+
+	markOtherClusterConnections(checks, cfg.ClusterID)
+	markStaleConnections(checks)
+	return checks, nil
+}
+
+func checkConnection(ctx context.Context, conn config.MachineConnection) ConnectionCheck {
+	ctx, cancel := context.WithTimeout(ctx, connectionCheckTimeout)
+	defer cancel()
+
+	check := ConnectionCheck{Connection: conn}
+	c, err := connectCluster(ctx, conn, ConnectOptions{})
+	if err != nil {
+		check.Status = ConnectionUnreachable
+		check.Err = err
+		return check
+	}
+	defer c.Close()
+
+	minfo, err := c.MachineClient.Inspect(ctx, &emptypb.Empty{})
+	if err != nil {
+		check.Status = ConnectionAPIError
+		check.Err = fmt.Errorf("inspect machine: %w", err)
+		return check
+	}
+	check.Machine = minfo.Name
+	check.machineID = minfo.Id
+	if minfo.Id == "" {
+		check.Status = ConnectionStale
+		check.Err = errors.New("machine is not a member of any cluster")
+		return check
+	}
+
+	resp, err := c.ClusterClient.ListMachines(ctx, &pb.ListMachinesRequest{})
+	if err != nil {
+		check.Status = ConnectionAPIError
+		check.Err = fmt.Errorf("list cluster machines: %w", err)
+		return check
+	}
+	for _, m := range resp.Machines {
+		check.members = append(check.members, m.Machine.Id)
+	}
+	if check.clusterID, err = c.ClusterID(ctx); err != nil {
+		check.Status = ConnectionAPIError
+		check.Err = fmt.Errorf("get cluster ID: %w", err)
+		return check
+	}
+	check.Status = ConnectionOK
+	return check
+}
+
+// markOtherClusterConnections marks the healthy connections that point to machines of a cluster with a different ID
+// than the context cluster ID as stale. Nothing is marked if the context cluster ID is unknown.
+func markOtherClusterConnections(checks []ConnectionCheck, clusterID string) {
+	if clusterID == "" {
+		return
+	}
+	for i, c := range checks {
+		if c.Status == ConnectionOK && c.clusterID != "" && c.clusterID != clusterID {
+			checks[i].Status = ConnectionStale
+			checks[i].Err = fmt.Errorf("machine belongs to a different cluster (ID %s)", c.clusterID)
+		}
+	}
+}
+
+// markStaleConnections marks the healthy connections that point to machines of a different cluster than the majority
+// of the connections as stale. The cluster of the context is the one reached by the most connections whose machines
+// see each other as members, preferring the earlier connections on ties.
+func markStaleConnections(checks []ConnectionCheck) {
+	ref := -1
+	refVotes := 0
+	for i, c := range checks {
+		if c.Status != ConnectionOK {
+			continue
+		}
+		votes := 0
+		for _, other := range checks {
+			if other.Status == ConnectionOK && slices.Contains(c.members, other.machineID) &&
+				slices.Contains(other.members, c.machineID) {
+				votes++
+			}
+		}
+		if votes > refVotes {
+			ref, refVotes = i, votes
+		}
+	}
+	if ref == -1 {
+		return
+	}
+
+	for i, c := range checks {
+		if c.Status == ConnectionOK && !slices.Contains(checks[ref].members, c.machineID) {
+			checks[i].Status = ConnectionStale
+			checks[i].Err = errors.New("machine is not a member of the context cluster")
+		}
+	}
+}
+
+// RemoveContextConnections removes the connections from the cluster context with the given name or the current
+// context if not specified and saves the config.
+func (cli *CLI) RemoveContextConnections(contextName string, conns []config.MachineConnection) error {
+	if contextName == "" {
+		contextName = cli.Config.CurrentContext
+	}
+	cfg, ok := cli.Config.Contexts[contextName]
+	if !ok {
+		return api.ErrNotFound
+	}
+
+	cfg.Connections = slices.DeleteFunc(cfg.Connections, func(c config.MachineConnection) bool {
+		return slices.ContainsFunc(conns, c.Equal)
+	})
+	return cli.Config.Save()
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkStaleConnections(t *testing.T) {
+	t.Parallel()
+
+	statuses := func(checks []ConnectionCheck) []ConnectionStatus {
+		var s []ConnectionStatus
+		for _, c := range checks {
+			s = append(s, c.Status)
+		}
+		return s
+	}
+
+	t.Run("majority cluster", func(t *testing.T) {
+		t.Parallel()
+
+		checks := []ConnectionCheck{
+			// Re-imaged machine that initialised a new cluster.
+			{Status: ConnectionOK, machineID: "new", members: []string{"new"}},
+			{Status: ConnectionOK, machineID: "m1", members: []string{"m1", "m2", "m3"}},
+			{Status: ConnectionUnreachable},
+			{Status: ConnectionOK, machineID: "m2", members: []string{"m1", "m2", "m3"}},
+		}
+		markStaleConnections(checks)
+
+		assert.Equal(t, []ConnectionStatus{
+			ConnectionStale, ConnectionOK, ConnectionUnreachable, ConnectionOK,
+		}, statuses(checks))
+	})
+
+	t.Run("removed machine", func(t *testing.T) {
+		t.Parallel()
+
+		checks := []ConnectionCheck{
+			{Status: ConnectionOK, machineID: "m1", members: []string{"m1"}},
+			// The machine was removed from the cluster but still has the stale cluster state.
+			{Status: ConnectionOK, machineID: "m2", members: []string{"m1", "m2"}},
+		}
+		markStaleConnections(checks)
+
+		assert.Equal(t, []ConnectionStatus{ConnectionOK, ConnectionStale}, statuses(checks))
+	})
+
+	t.Run("no healthy connections", func(t *testing.T) {
+		t.Parallel()
+
+		checks := []ConnectionCheck{{Status: ConnectionUnreachable}, {Status: ConnectionAPIError}}
+		markStaleConnections(checks)
+
+		assert.Equal(t, []ConnectionStatus{ConnectionUnreachable, ConnectionAPIError}, statuses(checks))
+	})
+}
+
+func TestMarkOtherClusterConnections(t *testing.T) {
+	t.Parallel()
+
+	checks := []ConnectionCheck{
+		{Status: ConnectionOK, clusterID: "c1"},
+		{Status: ConnectionOK, clusterID: "c2"},
+		// Older daemon version that doesn't support cluster IDs.
+		{Status: ConnectionOK},
+		{Status: ConnectionUnreachable},
+	}
+
+	markOtherClusterConnections(checks, "")
+	assert.Equal(t, ConnectionOK, checks[1].Status, "unknown context cluster ID")
+
+	markOtherClusterConnections(checks, "c1")
+	assert.Equal(t, ConnectionOK, checks[0].Status)
+	assert.Equal(t, ConnectionStale, checks[1].Status)
+	assert.Equal(t, ConnectionOK, checks[2].Status)
+	assert.Equal(t, ConnectionUnreachable, checks[3].Status)
+}
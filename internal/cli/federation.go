@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/psviderski/uncloud/pkg/client"
+)
+
+// SetContextGroup sets the context group to run the commands that support it against all clusters of the group.
+// The commands that don't support context groups fail to connect to a cluster when it's set.
+func (cli *CLI) SetContextGroup(name string) error {
+	if name != "" {
+		if cli.conn != nil {
+			return errors.New("the '--context-group' flag can't be used with the '--connect' flag")
+		}
+		if _, err := cli.Config.ContextGroup(name); err != nil {
+			return err
+		}
+	}
+	cli.contextGroup = name
+	return nil
+}
+
+// ContextGroup returns the context group set with SetContextGroup or an empty string if not set.
+func (cli *CLI) ContextGroup() string {
+	return cli.contextGroup
+}
+
+// ContextResult is the result of calling a function against the cluster of a context.
+type ContextResult[T any] struct {
+	Context string
+	Value   T
+	Err     error
+}
+
+// ForEachContext connects to the clusters of all contexts in the context group concurrently and calls fn with
+// the client for each of them. The results are returned in the order of the contexts in the group. A failure
+// to connect to a cluster is reported as the error of its result.
+func ForEachContext[T any](
+	ctx context.Context, cli *CLI, fn func(ctx context.Context, c *client.Client) (T, error),
+) ([]ContextResult[T], error) {
+	contexts, err := cli.Config.ContextGroup(cli.contextGroup)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := cli.connectOptions()
+	if err != nil {
+		return nil, err
+	}
+	// Concurrent connection spinners would garble the output.
+	opts.ShowProgress = false
+
+	results := make([]ContextResult[T], len(contexts))
+	var wg sync.WaitGroup
+	for i, name := range contexts {
+		results[i].Context = name
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			c, err := cli.connectContext(ctx, name, opts)
+			if err != nil {
+				results[i].Err = fmt.Errorf("connect to cluster: %w", err)
+				return
+			}
+			defer c.Close()
+
+			results[i].Value, results[i].Err = fn(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// ConnectContext connects to the cluster of the context in the context group. It's used to run the commands
+// against the clusters of the group one by one, e.g. to interactively confirm the changes for each of them.
+func (cli *CLI) ConnectContext(ctx context.Context, contextName string) (*client.Client, error) {
+	opts, err := cli.connectOptions()
+	if err != nil {
+		return nil, err
+	}
+	return cli.connectContext(ctx, contextName, opts)
+}
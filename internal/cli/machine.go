@@ -1,14 +1,21 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/charmbracelet/huh"
+	"github.com/psviderski/uncloud/internal/fs"
+	"github.com/psviderski/uncloud/internal/machine"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/internal/sshexec"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -18,6 +25,8 @@ const (
 	// TODO: support pinning the script version to the CLI version.
 	installScriptURL = "https://raw.githubusercontent.com/psviderski/uncloud/refs/heads/main/scripts/install.sh"
 	rootUser         = "root"
+	// provisionMarkersDir is the directory on the remote machine where the completed provisioning steps are recorded.
+	provisionMarkersDir = machine.DefaultDataDir + "/provision"
 )
 
 type RemoteMachine struct {
@@ -27,7 +36,7 @@ type RemoteMachine struct {
 	KeyPath string
 }
 
-func installCmd(user string, version string) string {
+func installCmd(user string, version string, downloadLimit int64) string {
 	sudoPrefix := ""
 	var env []string
 
@@ -39,19 +48,224 @@ func installCmd(user string, version string) string {
 	if version != "" {
 		env = append(env, "UNCLOUD_VERSION="+sshexec.Quote(version))
 	}
+	curlOpts := "-fsSL"
+	if downloadLimit > 0 {
+		// curl accepts the rate in bytes per second.
+		curlOpts += fmt.Sprintf(" --limit-rate %d", downloadLimit)
+		env = append(env, fmt.Sprintf("UNCLOUD_DOWNLOAD_LIMIT_RATE=%d", downloadLimit))
+	}
 
 	envCmd := strings.Join(env, " ")
-	curlBashCmd := fmt.Sprintf("curl -fsSL %s | %s %s bash", sshexec.Quote(installScriptURL), sudoPrefix, envCmd)
+	curlBashCmd := fmt.Sprintf("curl %s %s | %s %s bash", curlOpts, sshexec.Quote(installScriptURL), sudoPrefix, envCmd)
 
 	return curlBashCmd
 }
 
+// provisionRetryInterval is the initial delay before retrying the failed provisioning steps. It's a variable
+// to be able to shorten it in tests.
+var provisionRetryInterval = 2 * time.Second
+
+// provisionOptions configures how the remote machine is provisioned over SSH.
+type provisionOptions struct {
+	// SkipInstall skips the installation of the Uncloud daemon and dependencies.
+	SkipInstall bool
+	// Version of the Uncloud daemon to install. If empty, the latest version is used.
+	Version string
+	// PreScript is the path to a local script to run on the machine before installing the Uncloud daemon.
+	PreScript string
+	// PostScript is the path to a local script to run on the machine after installing the Uncloud daemon.
+	PostScript string
+	// DownloadLimit is the maximum number of bytes per second the machine downloads the install script
+	// and the Uncloud binaries at. Unlimited if 0.
+	DownloadLimit int64
+	// Retries is the number of times to retry the provisioning steps after a failure, e.g. a transient network
+	// error or a locked package manager. The completed steps are skipped on retry.
+	Retries int
+	// Output configures how the provisioning steps and the output of the scripts are displayed.
+	Output OutputOptions
+}
+
+// provisionStep is a step of provisioning a remote machine that runs a command over SSH.
+type provisionStep struct {
+	// kind is one of the Step* constants the progress of the step is reported with.
+	kind string
+	// name is a human-readable name of the step used in the progress messages and errors.
+	name string
+	// startMsg is the message reported before running the step.
+	startMsg string
+	// cmd is the command to run on the remote machine.
+	cmd string
+}
+
+// marker returns the name of the file that marks the step as completed on the remote machine. It includes a hash
+// of the command so that the step is run again if the command changes, e.g. a script is edited before a retry.
+func (s provisionStep) marker() string {
+	sum := sha256.Sum256([]byte(s.cmd))
+	return s.kind + "-" + hex.EncodeToString(sum[:8])
+}
+
 // provisionMachine provisions the remote machine by downloading the Uncloud install script from GitHub and running it.
 // If version is specified, it will be passed to the install script as UNCLOUD_VERSION environment variable.
-func provisionMachine(ctx context.Context, exec sshexec.Executor, version string) error {
+// The optional pre- and post-provisioning scripts are run with bash (as root) before and after the installation.
+//
+// Each completed step is recorded with a marker file on the machine so that a retry after a failure skips it.
+// The failed steps are retried up to opts.Retries times. The markers are removed once all the steps are completed.
+func provisionMachine(ctx context.Context, exec sshexec.Executor, opts provisionOptions) error {
+	if opts.SkipInstall && opts.PreScript == "" && opts.PostScript == "" {
+		return nil
+	}
+	if opts.Retries < 0 {
+		return fmt.Errorf("retries must be non-negative: %d", opts.Retries)
+	}
+
+	// Read the local scripts before making any changes to the remote machine to fail early if they're missing.
+	var preScript, postScript string
+	var err error
+	if opts.PreScript != "" {
+		if preScript, err = readScript(opts.PreScript); err != nil {
+			return err
+		}
+	}
+	if opts.PostScript != "" {
+		if postScript, err = readScript(opts.PostScript); err != nil {
+			return err
+		}
+	}
+
+	user, err := checkRemoteUser(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	var steps []provisionStep
+	if preScript != "" {
+		name := fmt.Sprintf("pre-provisioning script '%s'", opts.PreScript)
+		steps = append(steps, provisionStep{
+			kind:     StepProvisionScript,
+			name:     name,
+			startMsg: fmt.Sprintf("Running %s...", name),
+			cmd:      scriptCmd(user, preScript),
+		})
+	}
+	if !opts.SkipInstall {
+		steps = append(steps, provisionStep{
+			kind:     StepInstall,
+			name:     "install script",
+			startMsg: "Downloading Uncloud install script: " + installScriptURL,
+			cmd:      sshexec.QuoteCommand("bash", "-c", "set -o pipefail; "+installCmd(user, opts.Version, opts.DownloadLimit)),
+		})
+	}
+	if postScript != "" {
+		name := fmt.Sprintf("post-provisioning script '%s'", opts.PostScript)
+		steps = append(steps, provisionStep{
+			kind:     StepProvisionScript,
+			name:     name,
+			startMsg: fmt.Sprintf("Running %s...", name),
+			cmd:      scriptCmd(user, postScript),
+		})
+	}
+
+	boff := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(provisionRetryInterval),
+		backoff.WithMaxInterval(30*time.Second),
+		backoff.WithMaxElapsedTime(0),
+	), uint64(opts.Retries)), ctx)
+	run := func() error {
+		return runProvisionSteps(ctx, exec, user, steps, opts.Output)
+	}
+	notify := func(err error, delay time.Duration) {
+		opts.Output.Report(StepProvisionRetry, "Provisioning failed: %v\nRetrying in %s...",
+			err, delay.Round(time.Second))
+	}
+	if err = backoff.RetryNotify(run, boff, notify); err != nil {
+		return err
+	}
+
+	// Remove the markers so that provisioning the machine again, e.g. after uninstalling Uncloud, runs all the steps.
+	if _, err = exec.Run(ctx, sudoCmd(user, "rm -rf "+sshexec.Quote(provisionMarkersDir))); err != nil {
+		return fmt.Errorf("remove provisioning markers: %w", err)
+	}
+	return nil
+}
+
+// runProvisionSteps runs the provisioning steps in order skipping the ones that have already been completed
+// according to their markers on the remote machine.
+func runProvisionSteps(
+	ctx context.Context, exec sshexec.Executor, user string, steps []provisionStep, output OutputOptions,
+) error {
+	for _, s := range steps {
+		marker := sshexec.Quote(provisionMarkersDir + "/" + s.marker())
+		// The check echoes the result instead of relying on the exit code to distinguish a missing marker
+		// from a failure to run the command.
+		out, err := exec.Run(ctx, sudoCmd(user, "test -f "+marker+" && echo completed || true"))
+		if err != nil {
+			return fmt.Errorf("check if %s completed: %w", s.name, err)
+		}
+		if out == "completed" {
+			output.Report(s.kind, "Skipping %s as it has already completed.", s.name)
+			continue
+		}
+
+		output.Report(s.kind, "%s", s.startMsg)
+		if err = runProvisionStep(ctx, exec, output, s.kind, s.name, s.cmd); err != nil {
+			return err
+		}
+
+		markCmd := fmt.Sprintf("mkdir -p %s && touch %s", sshexec.Quote(provisionMarkersDir), marker)
+		if _, err = exec.Run(ctx, sudoCmd(user, markCmd)); err != nil {
+			return fmt.Errorf("mark %s as completed: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// runProvisionStep runs the command of a named provisioning step on the remote machine streaming its stdout and
+// stderr live to the output. The duration of the completed step is reported with the given step kind. If the
+// command fails, the returned error includes the full transcript of its output as it may not have been displayed,
+// e.g. when the output is quiet or reported with OnStep.
+func runProvisionStep(
+	ctx context.Context, exec sshexec.Executor, output OutputOptions, step, name, cmd string,
+) error {
+	w := &transcriptWriter{out: output.Stream()}
+	start := time.Now()
+	err := exec.Stream(ctx, cmd, w, w)
+	elapsed := time.Since(start).Round(100 * time.Millisecond)
+	if err != nil {
+		transcript := strings.TrimSpace(w.buf.String())
+		if transcript == "" {
+			return fmt.Errorf("run %s (failed after %s): %w", name, elapsed, err)
+		}
+		return fmt.Errorf("run %s (failed after %s): %w\n\nOutput:\n%s", name, elapsed, err, transcript)
+	}
+
+	output.Report(step, "Completed %s in %s.", name, elapsed)
+	return nil
+}
+
+// transcriptWriter writes the output of a remote command to the underlying writer while recording it. It's safe
+// to use the same writer for both stdout and stderr that are copied concurrently.
+type transcriptWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+	buf bytes.Buffer
+}
+
+func (w *transcriptWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	// Ignore the errors writing to the output to not interrupt the remote command, e.g. if the terminal is closed.
+	_, _ = w.out.Write(p)
+	return len(p), nil
+}
+
+// checkRemoteUser returns the SSH user on the remote machine and verifies that it's either root or has
+// passwordless sudo access required to provision the machine.
+func checkRemoteUser(ctx context.Context, exec sshexec.Executor) (string, error) {
 	user, err := exec.Run(ctx, "whoami")
 	if err != nil {
-		return fmt.Errorf("run whoami: %w", err)
+		return "", fmt.Errorf("run whoami: %w", err)
 	}
 
 	if user != rootUser {
@@ -60,7 +274,7 @@ func provisionMachine(ctx context.Context, exec sshexec.Executor, version string
 		out, err := exec.Run(ctx, "sudo true")
 		if err != nil {
 			if strings.Contains(out, "password is required") {
-				return fmt.Errorf(
+				return "", fmt.Errorf(
 					"user '%[1]s' requires a password for sudo, but Uncloud needs passwordless sudo or root access "+
 						"to install and configure the uncloudd daemon on the remote machine.\n\n"+
 						"Possible solutions:\n"+
@@ -69,23 +283,44 @@ func provisionMachine(ctx context.Context, exec sshexec.Executor, version string
 						"   echo '%[1]s ALL=(ALL) NOPASSWD:ALL' | sudo tee /etc/sudoers.d/%[1]s",
 					user)
 			}
-			return fmt.Errorf("sudo command failed for user '%s': %w. "+
+			return "", fmt.Errorf("sudo command failed for user '%s': %w. "+
 				"Please ensure the user has sudo privileges or use root user instead", user, err)
 		}
 	}
 
-	cmd := installCmd(user, version)
+	return user, nil
+}
+
+// readScript reads the content of a local provisioning script.
+func readScript(path string) (string, error) {
+	data, err := os.ReadFile(fs.ExpandHomeDir(path))
+	if err != nil {
+		return "", fmt.Errorf("read script '%s': %w", path, err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return "", fmt.Errorf("script '%s' is empty", path)
+	}
+	return string(data), nil
+}
 
-	fmt.Println("Downloading Uncloud install script:", installScriptURL)
+// scriptCmd returns a command that runs the script content with bash on the remote machine as root.
+func scriptCmd(user string, script string) string {
+	cmd := sshexec.QuoteCommand("bash", "-c", script)
+	if user != rootUser {
+		cmd = "sudo " + cmd
+	}
+	return cmd
+}
 
-	cmd = sshexec.QuoteCommand("bash", "-c", "set -o pipefail; "+cmd)
-	if err = exec.Stream(ctx, cmd, os.Stdout, os.Stderr); err != nil {
-		return fmt.Errorf("download and run install script: %w", err)
+// sudoCmd returns a command that runs the shell command on the remote machine as root.
+func sudoCmd(user string, cmd string) string {
+	if user != rootUser {
+		return "sudo " + sshexec.QuoteCommand("sh", "-c", cmd)
 	}
-	return nil
+	return cmd
 }
 
-func promptResetMachine(ctx context.Context, machineClient pb.MachineClient) error {
+func promptResetMachine(ctx context.Context, machineClient pb.MachineClient, output OutputOptions) error {
 	var confirm bool
 	form := huh.NewForm(
 		huh.NewGroup(
@@ -113,7 +348,7 @@ func promptResetMachine(ctx context.Context, machineClient pb.MachineClient) err
 		return fmt.Errorf("reset remote machine: %w. You can also manually run 'uncloud-uninstall' "+
 			"on the remote machine to fully uninstall Uncloud from it", err)
 	}
-	fmt.Println("Resetting the remote machine...")
+	output.Report(StepResetMachine, "Resetting the remote machine...")
 	if err := waitMachineReady(ctx, machineClient, 1*time.Minute); err != nil {
 		return fmt.Errorf("wait for machine to be ready after reset: %w", err)
 	}
@@ -1,36 +1,270 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInstallCmd(t *testing.T) {
 	t.Run("root", func(t *testing.T) {
-		cmd := installCmd("root", "")
+		cmd := installCmd("root", "", 0)
 		assert.NotContains(t, cmd, "sudo")
 		assert.NotContains(t, cmd, "UNCLOUD_GROUP_ADD_USER")
 	})
 
 	// Test with version
 	t.Run("root with version", func(t *testing.T) {
-		cmd := installCmd("root", "v1.2.3")
+		cmd := installCmd("root", "v1.2.3", 0)
 		assert.NotContains(t, cmd, "sudo")
 		assert.NotContains(t, cmd, "UNCLOUD_GROUP_ADD_USER")
 		assert.Contains(t, cmd, "UNCLOUD_VERSION=v1.2.3")
 	})
 
 	t.Run("nonroot", func(t *testing.T) {
-		cmd := installCmd("nonroot", "")
+		cmd := installCmd("nonroot", "", 0)
 		assert.Contains(t, cmd, "sudo")
 		assert.Contains(t, cmd, "UNCLOUD_GROUP_ADD_USER=nonroot")
 	})
 
 	t.Run("nonroot with version", func(t *testing.T) {
-		cmd := installCmd("nonroot", "v1.2.3")
+		cmd := installCmd("nonroot", "v1.2.3", 0)
 		assert.Contains(t, cmd, "sudo")
 		assert.Contains(t, cmd, "UNCLOUD_GROUP_ADD_USER=nonroot")
 		assert.Contains(t, cmd, "UNCLOUD_VERSION=v1.2.3")
 	})
+
+	t.Run("download limit", func(t *testing.T) {
+		cmd := installCmd("root", "", 1048576)
+		assert.Contains(t, cmd, "curl -fsSL --limit-rate 1048576 ")
+		assert.Contains(t, cmd, "UNCLOUD_DOWNLOAD_LIMIT_RATE=1048576")
+	})
+}
+
+func TestScriptCmd(t *testing.T) {
+	script := "#!/bin/bash\necho 'hello'\n"
+
+	t.Run("root", func(t *testing.T) {
+		cmd := scriptCmd("root", script)
+		assert.Equal(t, `bash -c '#!/bin/bash
+echo '"'"'hello'"'"'
+'`, cmd)
+	})
+
+	t.Run("nonroot", func(t *testing.T) {
+		cmd := scriptCmd("nonroot", script)
+		assert.True(t, strings.HasPrefix(cmd, "sudo bash -c "))
+	})
+}
+
+// fakeExecutor records the commands it's asked to run. Streamed commands write output to stdout and stderr
+// and the first failures of them fail with streamErr if set. The commands managing the provisioning markers
+// aren't recorded but update the markers.
+type fakeExecutor struct {
+	user      string
+	output    string
+	streamErr error
+	failures  int
+	commands  []string
+	markers   map[string]bool
+}
+
+var markerRegexp = regexp.MustCompile(regexp.QuoteMeta(provisionMarkersDir) + `/([\w-]+)`)
+
+func (e *fakeExecutor) Run(_ context.Context, cmd string) (string, error) {
+	if strings.Contains(cmd, provisionMarkersDir) {
+		return e.runMarkerCmd(cmd), nil
+	}
+	e.commands = append(e.commands, cmd)
+	if cmd == "whoami" {
+		return e.user, nil
+	}
+	return "", nil
+}
+
+func (e *fakeExecutor) runMarkerCmd(cmd string) string {
+	if e.markers == nil {
+		e.markers = make(map[string]bool)
+	}
+	m := markerRegexp.FindStringSubmatch(cmd)
+	switch {
+	case strings.Contains(cmd, "rm -rf"):
+		clear(e.markers)
+	case strings.Contains(cmd, "test -f") && e.markers[m[1]]:
+		return "completed"
+	case strings.Contains(cmd, "touch"):
+		e.markers[m[1]] = true
+	}
+	return ""
+}
+
+func (e *fakeExecutor) Stream(_ context.Context, cmd string, stdout, stderr io.Writer) error {
+	e.commands = append(e.commands, cmd)
+	if e.output != "" {
+		_, _ = io.WriteString(stdout, e.output+"\n")
+		_, _ = io.WriteString(stderr, "warning: "+e.output+"\n")
+	}
+	if e.streamErr != nil && e.failures > 0 {
+		e.failures--
+		return e.streamErr
+	}
+	return nil
+}
+
+func (e *fakeExecutor) Close() error {
+	return nil
+}
+
+func TestProvisionMachine_Scripts(t *testing.T) {
+	dir := t.TempDir()
+	pre := filepath.Join(dir, "pre.sh")
+	post := filepath.Join(dir, "post.sh")
+	require.NoError(t, os.WriteFile(pre, []byte("echo pre"), 0o644))
+	require.NoError(t, os.WriteFile(post, []byte("echo post"), 0o644))
+
+	t.Run("pre and post around install", func(t *testing.T) {
+		exec := &fakeExecutor{user: "root"}
+		err := provisionMachine(context.Background(), exec, provisionOptions{PreScript: pre, PostScript: post})
+		require.NoError(t, err)
+
+		require.Len(t, exec.commands, 4)
+		assert.Equal(t, "whoami", exec.commands[0])
+		assert.Equal(t, "bash -c 'echo pre'", exec.commands[1])
+		assert.Contains(t, exec.commands[2], installScriptURL)
+		assert.Equal(t, "bash -c 'echo post'", exec.commands[3])
+	})
+
+	t.Run("skip install runs scripts only", func(t *testing.T) {
+		exec := &fakeExecutor{user: "ubuntu"}
+		err := provisionMachine(context.Background(), exec, provisionOptions{SkipInstall: true, PostScript: post})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"whoami", "sudo true", "sudo bash -c 'echo post'"}, exec.commands)
+	})
+
+	t.Run("skip install without scripts", func(t *testing.T) {
+		exec := &fakeExecutor{user: "root"}
+		err := provisionMachine(context.Background(), exec, provisionOptions{SkipInstall: true})
+		require.NoError(t, err)
+
+		assert.Empty(t, exec.commands)
+	})
+
+	t.Run("missing script fails before running commands", func(t *testing.T) {
+		exec := &fakeExecutor{user: "root"}
+		err := provisionMachine(context.Background(), exec, provisionOptions{PreScript: filepath.Join(dir, "missing.sh")})
+		require.Error(t, err)
+
+		assert.Empty(t, exec.commands)
+	})
+}
+
+func TestProvisionMachine_StepOutput(t *testing.T) {
+	dir := t.TempDir()
+	pre := filepath.Join(dir, "pre.sh")
+	require.NoError(t, os.WriteFile(pre, []byte("echo pre"), 0o644))
+
+	t.Run("streams output and reports step durations", func(t *testing.T) {
+		var buf bytes.Buffer
+		exec := &fakeExecutor{user: "root", output: "installing"}
+		err := provisionMachine(context.Background(), exec, provisionOptions{
+			PreScript: pre,
+			Output:    OutputOptions{Out: &buf},
+		})
+		require.NoError(t, err)
+
+		out := buf.String()
+		assert.Contains(t, out, "Running pre-provisioning script '"+pre+"'...\n")
+		assert.Contains(t, out, "installing\nwarning: installing\n")
+		assert.Regexp(t, `Completed pre-provisioning script '.+' in \S+s\.`, out)
+		assert.Regexp(t, `Completed install script in \S+s\.`, out)
+	})
+
+	t.Run("failed step includes transcript", func(t *testing.T) {
+		var steps []StepResult
+		exec := &fakeExecutor{
+			user: "root", output: "no space left on device", streamErr: errors.New("exit status 1"), failures: 1,
+		}
+		err := provisionMachine(context.Background(), exec, provisionOptions{
+			Output: OutputOptions{OnStep: func(r StepResult) { steps = append(steps, r) }},
+		})
+		require.Error(t, err)
+
+		assert.ErrorContains(t, err, "run install script (failed after")
+		assert.ErrorContains(t, err, "exit status 1")
+		assert.ErrorContains(t, err, "Output:\nno space left on device\nwarning: no space left on device")
+		require.Len(t, steps, 1)
+		assert.Equal(t, StepInstall, steps[0].Step)
+	})
+}
+
+func TestProvisionMachine_Retries(t *testing.T) {
+	interval := provisionRetryInterval
+	provisionRetryInterval = time.Millisecond
+	t.Cleanup(func() { provisionRetryInterval = interval })
+
+	dir := t.TempDir()
+	pre := filepath.Join(dir, "pre.sh")
+	require.NoError(t, os.WriteFile(pre, []byte("echo pre"), 0o644))
+
+	t.Run("retry skips completed steps", func(t *testing.T) {
+		// The first attempt runs the pre-script and fails to run the install script. The retry skips the pre-script.
+		exec := &fakeExecutor{user: "ubuntu"}
+		failing := &failOnceExecutor{fakeExecutor: exec, failCmd: installScriptURL}
+		var steps []StepResult
+		err := provisionMachine(context.Background(), failing, provisionOptions{
+			PreScript: pre,
+			Retries:   1,
+			Output:    OutputOptions{OnStep: func(r StepResult) { steps = append(steps, r) }},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, exec.commands, 5)
+		assert.Equal(t, "sudo bash -c 'echo pre'", exec.commands[2])
+		assert.Contains(t, exec.commands[3], installScriptURL)
+		assert.Contains(t, exec.commands[4], installScriptURL)
+
+		var kinds []string
+		for _, s := range steps {
+			kinds = append(kinds, s.Step)
+		}
+		assert.Contains(t, kinds, StepProvisionRetry)
+		assert.Contains(t, steps[len(steps)-3].Message, "Skipping pre-provisioning script")
+		assert.Empty(t, exec.markers, "markers should be removed after provisioning completes")
+	})
+
+	t.Run("fails after retries exhausted", func(t *testing.T) {
+		exec := &fakeExecutor{user: "root", streamErr: errors.New("network unreachable"), failures: 3}
+		err := provisionMachine(context.Background(), exec, provisionOptions{Retries: 1})
+		require.ErrorContains(t, err, "network unreachable")
+
+		// whoami and two attempts of the install script.
+		assert.Len(t, exec.commands, 3)
+	})
+}
+
+// failOnceExecutor fails the first streamed command that contains failCmd.
+type failOnceExecutor struct {
+	*fakeExecutor
+	failCmd string
+	failed  bool
+}
+
+func (e *failOnceExecutor) Stream(ctx context.Context, cmd string, stdout, stderr io.Writer) error {
+	err := e.fakeExecutor.Stream(ctx, cmd, stdout, stderr)
+	if !e.failed && strings.Contains(cmd, e.failCmd) {
+		e.failed = true
+		return errors.New("apt lock")
+	}
+	return err
 }
@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine"
+	"github.com/psviderski/uncloud/internal/machine/dns"
+	"github.com/psviderski/uncloud/internal/sshexec"
+)
+
+// PrerequisiteStatus is the result status of a prerequisite check of a machine.
+type PrerequisiteStatus string
+
+const (
+	// PrerequisiteOK means the machine meets the prerequisite.
+	PrerequisiteOK PrerequisiteStatus = "ok"
+	// PrerequisiteWarning means the machine meets the prerequisite but something may need attention, or the
+	// prerequisite couldn't be verified.
+	PrerequisiteWarning PrerequisiteStatus = "warning"
+	// PrerequisiteFailed means the machine doesn't meet the prerequisite and can't be provisioned.
+	PrerequisiteFailed PrerequisiteStatus = "failed"
+)
+
+// PrerequisiteCheck is the result of a prerequisite check of a machine.
+type PrerequisiteCheck struct {
+	// Name is a short name of the checked prerequisite.
+	Name   string
+	Status PrerequisiteStatus
+	// Message describes what was found on the machine.
+	Message string
+}
+
+// CheckMachinePrerequisites connects to the remote machine over SSH and checks if it meets the prerequisites
+// to be provisioned and join a cluster. Nothing is installed or changed on the machine. The SSH connection is
+// configured with the UNCLOUD_SSH_* environment variables, see SSHOptionsFromEnv.
+func (cli *CLI) CheckMachinePrerequisites(
+	ctx context.Context, remoteMachine *RemoteMachine,
+) ([]PrerequisiteCheck, error) {
+	sshOpts, err := SSHOptionsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	sshClient, err := sshexec.Connect(
+		remoteMachine.User, remoteMachine.Host, remoteMachine.Port, remoteMachine.KeyPath, sshOpts,
+	)
+	// If the SSH connection using SSH agent fails and no key path is provided, try to use the default SSH key.
+	if err != nil && remoteMachine.KeyPath == "" {
+		sshClient, err = sshexec.Connect(
+			remoteMachine.User, remoteMachine.Host, remoteMachine.Port, DefaultSSHKeyPath, sshOpts,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SSH login to remote machine %s: %w",
+			config.NewSSHDestination(remoteMachine.User, remoteMachine.Host, remoteMachine.Port), err)
+	}
+	exec := sshexec.NewRemote(sshClient)
+	defer exec.Close()
+
+	return checkPrerequisites(ctx, exec), nil
+}
+
+// checkPrerequisites runs the prerequisite checks on the remote machine. The checks that require root privileges
+// are run with sudo if the user isn't root and has passwordless sudo access.
+func checkPrerequisites(ctx context.Context, exec sshexec.Executor) []PrerequisiteCheck {
+	var checks []PrerequisiteCheck
+
+	checks = append(checks, checkOS(ctx, exec))
+
+	user, userErr := checkRemoteUser(ctx, exec)
+	privileges := PrerequisiteCheck{Name: "privileges", Status: PrerequisiteOK}
+	switch {
+	case userErr != nil:
+		privileges.Status, privileges.Message = PrerequisiteFailed, userErr.Error()
+	case user == rootUser:
+		privileges.Message = "root user"
+	default:
+		privileges.Message = fmt.Sprintf("user '%s' has passwordless sudo", user)
+	}
+	checks = append(checks, privileges)
+
+	checks = append(checks,
+		checkCommand(ctx, exec, "systemd", "test -d /run/systemd/system",
+			"systemd is running", "systemd is not running but it's required to run the Uncloud daemon"),
+		checkCommand(ctx, exec, "curl", "command -v curl",
+			"curl is installed", "curl is not installed but it's required to download the Uncloud install script"),
+		checkMemory(ctx, exec),
+		checkDisk(ctx, exec),
+		checkDNSPort(ctx, exec),
+	)
+
+	// Checking Docker and Uncloud requires root privileges to access the Docker socket and the Uncloud data.
+	if userErr == nil {
+		checks = append(checks, checkDocker(ctx, exec, user), checkUncloud(ctx, exec))
+	}
+	return checks
+}
+
+// checkOS verifies that the machine runs Linux on a supported architecture.
+func checkOS(ctx context.Context, exec sshexec.Executor) PrerequisiteCheck {
+	check := PrerequisiteCheck{Name: "os"}
+	out, err := exec.Run(ctx, "uname -sm")
+	if err != nil {
+		check.Status, check.Message = PrerequisiteFailed, fmt.Sprintf("run uname: %v", err)
+		return check
+	}
+
+	system, arch, _ := strings.Cut(out, " ")
+	check.Message = fmt.Sprintf("%s %s", system, arch)
+	switch {
+	case system != "Linux":
+		check.Status = PrerequisiteFailed
+		check.Message = fmt.Sprintf("%s is not supported, Uncloud machine must be a Linux system", system)
+	case arch != "x86_64" && arch != "aarch64":
+		check.Status = PrerequisiteFailed
+		check.Message = fmt.Sprintf("%s architecture is not supported, Uncloud machine must have "+
+			"amd64 (x86_64) or arm64 (aarch64) architecture", arch)
+	default:
+		check.Status = PrerequisiteOK
+	}
+	return check
+}
+
+// checkCommand runs the command on the machine and reports the okMsg if it succeeds or the failMsg otherwise.
+func checkCommand(ctx context.Context, exec sshexec.Executor, name, cmd, okMsg, failMsg string) PrerequisiteCheck {
+	if _, err := exec.Run(ctx, cmd); err != nil {
+		return PrerequisiteCheck{Name: name, Status: PrerequisiteFailed, Message: failMsg}
+	}
+	return PrerequisiteCheck{Name: name, Status: PrerequisiteOK, Message: okMsg}
+}
+
+// checkMemory verifies that the machine has enough total memory.
+func checkMemory(ctx context.Context, exec sshexec.Executor) PrerequisiteCheck {
+	check := PrerequisiteCheck{Name: "memory"}
+	out, err := exec.Run(ctx, "awk '/^MemTotal:/ {print $2}' /proc/meminfo")
+	kb, parseErr := strconv.ParseInt(out, 10, 64)
+	if err != nil || parseErr != nil {
+		check.Status, check.Message = PrerequisiteWarning, "unable to determine the total memory"
+		return check
+	}
+
+	memory := kb * units.KiB
+	check.Message = units.BytesSize(float64(memory))
+	if memory < machine.MinMemory {
+		check.Status = PrerequisiteFailed
+		check.Message += " but at least 512MB is required"
+	} else {
+		check.Status = PrerequisiteOK
+	}
+	return check
+}
+
+// checkDisk verifies that there is enough available disk space for the Docker data root in /var/lib.
+func checkDisk(ctx context.Context, exec sshexec.Executor) PrerequisiteCheck {
+	check := PrerequisiteCheck{Name: "disk"}
+	out, err := exec.Run(ctx, "df -Pk /var/lib | awk 'NR==2 {print $4}'")
+	kb, parseErr := strconv.ParseInt(out, 10, 64)
+	if err != nil || parseErr != nil {
+		check.Status, check.Message = PrerequisiteWarning, "unable to determine the available disk space in /var/lib"
+		return check
+	}
+
+	available := kb * units.KiB
+	check.Message = units.BytesSize(float64(available)) + " available in /var/lib"
+	if available < machine.MinDiskAvailable {
+		check.Status = PrerequisiteFailed
+		check.Message += fmt.Sprintf(" but at least %s is required", units.BytesSize(machine.MinDiskAvailable))
+	} else {
+		check.Status = PrerequisiteOK
+	}
+	return check
+}
+
+// checkDNSPort verifies that no DNS server listens on the DNS port on all network interfaces which would prevent
+// Uncloud from running the embedded internal DNS service.
+func checkDNSPort(ctx context.Context, exec sshexec.Executor) PrerequisiteCheck {
+	check := PrerequisiteCheck{Name: "dns port"}
+	out, err := exec.Run(ctx, "ss -Hlun")
+	if err != nil {
+		check.Status, check.Message = PrerequisiteWarning, "unable to list listening UDP ports with 'ss'"
+		return check
+	}
+
+	wildcards := []string{"0.0.0.0", "*", "[::]", "::"}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		// The local address is the 4th column: State Recv-Q Send-Q Local-Address:Port Peer-Address:Port.
+		host, port, ok := cutLastColon(fields[3])
+		if !ok || port != strconv.Itoa(dns.Port) {
+			continue
+		}
+		// Strip the interface suffix, e.g. 0.0.0.0%lo.
+		host, _, _ = strings.Cut(host, "%")
+		for _, w := range wildcards {
+			if host == w {
+				check.Status = PrerequisiteFailed
+				check.Message = fmt.Sprintf("DNS port %d/udp is in use on all network interfaces (%s). Reconfigure "+
+					"the DNS server (like dnsmasq, systemd-resolved, or named) to not listen on all interfaces",
+					dns.Port, fields[3])
+				return check
+			}
+		}
+	}
+	check.Status, check.Message = PrerequisiteOK, fmt.Sprintf("DNS port %d/udp is available", dns.Port)
+	return check
+}
+
+// cutLastColon splits the address into the host and port at the last colon.
+func cutLastColon(addr string) (string, string, bool) {
+	i := strings.LastIndex(addr, ":")
+	if i < 0 {
+		return "", "", false
+	}
+	return addr[:i], addr[i+1:], true
+}
+
+// checkDocker reports whether Docker is already installed and running. It's not a failure if it's not installed
+// as it will be installed during provisioning.
+func checkDocker(ctx context.Context, exec sshexec.Executor, user string) PrerequisiteCheck {
+	check := PrerequisiteCheck{Name: "docker", Status: PrerequisiteOK}
+	if _, err := exec.Run(ctx, "command -v docker"); err != nil {
+		check.Message = "not installed, will be installed during provisioning"
+		return check
+	}
+
+	version, err := exec.Run(ctx, sudoCmd(user, "docker version --format '{{.Server.Version}}'"))
+	if err != nil {
+		check.Status = PrerequisiteWarning
+		check.Message = "installed but the Docker daemon isn't running"
+		return check
+	}
+	check.Message = fmt.Sprintf("Docker %s is running", version)
+	return check
+}
+
+// checkUncloud reports whether Uncloud is already installed on the machine.
+func checkUncloud(ctx context.Context, exec sshexec.Executor) PrerequisiteCheck {
+	check := PrerequisiteCheck{Name: "uncloud"}
+	if _, err := exec.Run(ctx, "command -v uncloudd"); err != nil {
+		check.Status, check.Message = PrerequisiteOK, "not installed"
+		return check
+	}
+	check.Status = PrerequisiteWarning
+	check.Message = "already installed. If the machine is a member of another cluster, it will need to be reset"
+	return check
+}
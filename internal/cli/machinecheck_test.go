@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedExecutor returns the scripted output for the known commands and fails the unknown ones.
+type scriptedExecutor struct {
+	outputs map[string]string
+}
+
+func (e *scriptedExecutor) Run(_ context.Context, cmd string) (string, error) {
+	out, ok := e.outputs[cmd]
+	if !ok {
+		return "", errors.New("exit status 1")
+	}
+	return out, nil
+}
+
+func (e *scriptedExecutor) Stream(context.Context, string, io.Writer, io.Writer) error {
+	return errors.New("not supported")
+}
+
+func (e *scriptedExecutor) Close() error {
+	return nil
+}
+
+func TestCheckPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	healthy := func() map[string]string {
+		return map[string]string{
+			"uname -sm":                   "Linux x86_64",
+			"whoami":                      "root",
+			"test -d /run/systemd/system": "",
+			"command -v curl":             "/usr/bin/curl",
+			"awk '/^MemTotal:/ {print $2}' /proc/meminfo": "2000000",
+			"df -Pk /var/lib | awk 'NR==2 {print $4}'":    "20000000",
+			"ss -Hlun": "UNCONN 0 0 127.0.0.53%lo:53 0.0.0.0:*\n" +
+				"UNCONN 0 0 0.0.0.0:68 0.0.0.0:*",
+		}
+	}
+	statuses := func(checks []PrerequisiteCheck) map[string]PrerequisiteStatus {
+		m := make(map[string]PrerequisiteStatus)
+		for _, c := range checks {
+			m[c.Name] = c.Status
+		}
+		return m
+	}
+
+	t.Run("healthy machine", func(t *testing.T) {
+		t.Parallel()
+
+		checks := checkPrerequisites(context.Background(), &scriptedExecutor{outputs: healthy()})
+		assert.Equal(t, map[string]PrerequisiteStatus{
+			"os":         PrerequisiteOK,
+			"privileges": PrerequisiteOK,
+			"systemd":    PrerequisiteOK,
+			"curl":       PrerequisiteOK,
+			"memory":     PrerequisiteOK,
+			"disk":       PrerequisiteOK,
+			"dns port":   PrerequisiteOK,
+			"docker":     PrerequisiteOK,
+			"uncloud":    PrerequisiteOK,
+		}, statuses(checks))
+	})
+
+	t.Run("unsuitable machine", func(t *testing.T) {
+		t.Parallel()
+
+		outputs := healthy()
+		outputs["uname -sm"] = "Linux armv7l"
+		outputs["awk '/^MemTotal:/ {print $2}' /proc/meminfo"] = "262144"
+		outputs["df -Pk /var/lib | awk 'NR==2 {print $4}'"] = "102400"
+		outputs["ss -Hlun"] = "UNCONN 0 0 *:53 *:*"
+		delete(outputs, "command -v curl")
+		outputs["command -v uncloudd"] = "/usr/local/bin/uncloudd"
+
+		got := statuses(checkPrerequisites(context.Background(), &scriptedExecutor{outputs: outputs}))
+		assert.Equal(t, PrerequisiteFailed, got["os"])
+		assert.Equal(t, PrerequisiteFailed, got["curl"])
+		assert.Equal(t, PrerequisiteFailed, got["memory"])
+		assert.Equal(t, PrerequisiteFailed, got["disk"])
+		assert.Equal(t, PrerequisiteFailed, got["dns port"])
+		assert.Equal(t, PrerequisiteWarning, got["uncloud"])
+	})
+
+	t.Run("user without sudo skips privileged checks", func(t *testing.T) {
+		t.Parallel()
+
+		outputs := healthy()
+		outputs["whoami"] = "ubuntu"
+
+		checks := checkPrerequisites(context.Background(), &scriptedExecutor{outputs: outputs})
+		got := statuses(checks)
+		assert.Equal(t, PrerequisiteFailed, got["privileges"])
+		assert.NotContains(t, got, "docker")
+		assert.NotContains(t, got, "uncloud")
+	})
+}
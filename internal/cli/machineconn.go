@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/client"
+)
+
+// hostLookupTimeout is the maximum time to resolve a DNS name of a connection host.
+const hostLookupTimeout = 3 * time.Second
+
+// MachineHosts returns the IP addresses and DNS names the machine can be reached at: its public IP, management IP,
+// and WireGuard endpoints.
+func MachineHosts(m *pb.MachineInfo) []string {
+	var hosts []string
+	for _, ip := range []*pb.IP{m.GetPublicIp(), m.GetNetwork().GetManagementIp()} {
+		if ip == nil {
+			continue
+		}
+		if addr, err := ip.ToAddr(); err == nil {
+			hosts = append(hosts, addr.String())
+		}
+	}
+	for _, ep := range m.GetNetwork().GetEndpoints() {
+		if addrPort, err := ep.ToAddrPort(); err == nil {
+			hosts = append(hosts, addrPort.Addr().String())
+		}
+	}
+	for _, ep := range m.GetNetwork().GetDnsEndpoints() {
+		if host, _, err := net.SplitHostPort(ep); err == nil {
+			hosts = append(hosts, host)
+		}
+	}
+
+	slices.Sort(hosts)
+	return slices.Compact(hosts)
+}
+
+// ConnectRemoteMachine connects to the Uncloud daemon on the remote machine over SSH without provisioning it.
+// The client should be closed after use by the caller.
+func ConnectRemoteMachine(ctx context.Context, remoteMachine *RemoteMachine) (*client.Client, error) {
+	return provisionOrConnectRemoteMachine(ctx, remoteMachine, provisionOptions{SkipInstall: true})
+}
+
+// RemoveMachineConnections removes the connections that point to any of the given hosts from all cluster contexts
+// and saves the config. A connection host that is a DNS name matches if it resolves to one of the hosts.
+// It returns the removed connections grouped by context name.
+func (cli *CLI) RemoveMachineConnections(
+	ctx context.Context, hosts []string,
+) (map[string][]config.MachineConnection, error) {
+	if cli.Config == nil {
+		return nil, errors.New("context management is not available: Uncloud configuration file is not being used")
+	}
+
+	lookup := func(host string) []string {
+		lctx, cancel := context.WithTimeout(ctx, hostLookupTimeout)
+		defer cancel()
+		addrs, _ := net.DefaultResolver.LookupHost(lctx, host)
+		return addrs
+	}
+
+	removed := make(map[string][]config.MachineConnection)
+	for name, c := range cli.Config.Contexts {
+		c.Connections = slices.DeleteFunc(c.Connections, func(conn config.MachineConnection) bool {
+			if connectionMatchesHosts(conn, hosts, lookup) {
+				removed[name] = append(removed[name], conn)
+				return true
+			}
+			return false
+		})
+	}
+	if len(removed) == 0 {
+		return removed, nil
+	}
+	return removed, cli.Config.Save()
+}
+
+// connectionMatchesHosts returns true if the SSH host or TCP address of the connection is one of the hosts.
+// If the SSH host is a DNS name that isn't in the hosts, it's resolved using the lookup function.
+func connectionMatchesHosts(conn config.MachineConnection, hosts []string, lookup func(string) []string) bool {
+	var host string
+	if conn.SSH != "" {
+		_, h, _, err := conn.SSH.Parse()
+		if err != nil {
+			return false
+		}
+		host = h
+	} else if conn.TCP != nil && conn.TCP.IsValid() {
+		host = conn.TCP.Addr().String()
+	} else {
+		return false
+	}
+
+	if slices.Contains(hosts, host) {
+		return true
+	}
+	if _, err := netip.ParseAddr(host); err == nil || lookup == nil {
+		return false
+	}
+	for _, addr := range lookup(host) {
+		if slices.Contains(hosts, addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachineHosts(t *testing.T) {
+	t.Parallel()
+
+	m := &pb.MachineInfo{
+		PublicIp: pb.NewIP(netip.MustParseAddr("203.0.113.10")),
+		Network: &pb.NetworkConfig{
+			ManagementIp: pb.NewIP(netip.MustParseAddr("fdcc::1")),
+			Endpoints: []*pb.IPPort{
+				pb.NewIPPort(netip.MustParseAddrPort("203.0.113.10:51820")),
+				pb.NewIPPort(netip.MustParseAddrPort("192.168.1.5:51820")),
+			},
+			DnsEndpoints: []string{"home.example.com:51820"},
+		},
+	}
+
+	assert.Equal(t, []string{"192.168.1.5", "203.0.113.10", "fdcc::1", "home.example.com"}, MachineHosts(m))
+	assert.Empty(t, MachineHosts(&pb.MachineInfo{}))
+}
+
+func TestConnectionMatchesHosts(t *testing.T) {
+	t.Parallel()
+
+	hosts := []string{"203.0.113.10", "fdcc::1"}
+	lookup := func(host string) []string {
+		if host == "machine.example.com" {
+			return []string{"203.0.113.10"}
+		}
+		return nil
+	}
+	tcp := netip.MustParseAddrPort("[fdcc::1]:51000")
+	otherTCP := netip.MustParseAddrPort("[fdcc::2]:51000")
+
+	tests := []struct {
+		name string
+		conn config.MachineConnection
+		want bool
+	}{
+		{"ssh ip", config.MachineConnection{SSH: "root@203.0.113.10"}, true},
+		{"ssh ip with port", config.MachineConnection{SSH: "ubuntu@203.0.113.10:2222"}, true},
+		{"ssh other ip", config.MachineConnection{SSH: "root@203.0.113.11"}, false},
+		{"ssh resolved name", config.MachineConnection{SSH: "root@machine.example.com"}, true},
+		{"ssh unresolved name", config.MachineConnection{SSH: "root@other.example.com"}, false},
+		{"tcp", config.MachineConnection{TCP: &tcp}, true},
+		{"other tcp", config.MachineConnection{TCP: &otherTCP}, false},
+		{"empty", config.MachineConnection{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, connectionMatchesHosts(tt.conn, hosts, lookup))
+		})
+	}
+}
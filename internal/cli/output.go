@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/muesli/termenv"
+)
+
+// Steps of long operations reported with StepResult.
+const (
+	StepProvisionScript   = "provision-script"
+	StepInstall           = "install"
+	StepProvisionRetry    = "provision-retry"
+	StepResetMachine      = "reset-machine"
+	StepClusterInit       = "cluster-init"
+	StepContextSaved      = "context-saved"
+	StepMachineAdded      = "machine-added"
+	StepBuildImages       = "build-images"
+	StepBuildServiceImage = "build-service-image"
+	StepPushImage         = "push-image"
+	StepServiceUpToDate   = "service-up-to-date"
+	StepDeploymentPlan    = "deployment-plan"
+	StepScanImage         = "scan-image"
+)
+
+// OutputOptions configures how the CLI displays the progress and results of long operations.
+type OutputOptions struct {
+	// Quiet suppresses progress and informational messages. Prompts, warnings, and errors are still displayed.
+	Quiet bool
+	// NoColor disables colored output.
+	NoColor bool
+	// Out is the writer for user-facing messages and progress. Defaults to os.Stdout if nil. Set it to capture
+	// or redirect the output when embedding the CLI.
+	Out io.Writer
+	// OnStep receives the results of the operation steps instead of printing them to stdout if set. This is useful
+	// when using the CLI as a library.
+	OnStep func(StepResult)
+}
+
+// StepResult is the result of a step of a long operation such as initialising a cluster or adding a machine.
+type StepResult struct {
+	// Step is one of the Step* constants.
+	Step string
+	// Message is a human-readable description of the result.
+	Message string
+}
+
+// SetOutput configures how the CLI displays the progress and results of long operations. The progress writer
+// and colors are configured globally for the process. The progress is displayed with spinners only if stdout
+// is a terminal and as plain log lines otherwise.
+func (cli *CLI) SetOutput(opts OutputOptions) {
+	cli.Output = opts
+
+	switch {
+	case opts.Quiet:
+		progress.Mode = progress.ModeQuiet
+	case opts.Out != nil && opts.Out != os.Stdout, !IsStdoutTerminal():
+		progress.Mode = progress.ModePlain
+	default:
+		progress.Mode = progress.ModeAuto
+	}
+	if opts.NoColor {
+		progress.NoColor()
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// Writer returns the writer for user-facing messages and progress.
+func (o OutputOptions) Writer() io.Writer {
+	if o.Out == nil {
+		return os.Stdout
+	}
+	return o.Out
+}
+
+// Report sends the step result to OnStep if set or prints the message to the output writer unless quiet.
+func (o OutputOptions) Report(step, format string, args ...any) {
+	result := StepResult{
+		Step:    step,
+		Message: fmt.Sprintf(format, args...),
+	}
+	if o.OnStep != nil {
+		o.OnStep(result)
+		return
+	}
+	if !o.Quiet {
+		fmt.Fprintln(o.Writer(), result.Message)
+	}
+}
+
+// Stream returns the writer for the output of external tools, such as image push progress, and other informational
+// output that is discarded if quiet or reported with OnStep.
+func (o OutputOptions) Stream() io.Writer {
+	if o.Quiet || o.OnStep != nil {
+		return io.Discard
+	}
+	return o.Writer()
+}
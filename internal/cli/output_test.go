@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputOptions_Report(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writer", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		OutputOptions{Out: &buf}.Report(StepClusterInit, "Cluster '%s' initialised.", "default")
+		assert.Equal(t, "Cluster 'default' initialised.\n", buf.String())
+	})
+
+	t.Run("quiet", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		OutputOptions{Out: &buf, Quiet: true}.Report(StepClusterInit, "Cluster initialised.")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("on step", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		var steps []StepResult
+		output := OutputOptions{
+			Out: &buf,
+			OnStep: func(r StepResult) {
+				steps = append(steps, r)
+			},
+		}
+		output.Report(StepContextSaved, "Current cluster context is now '%s'.", "prod")
+
+		assert.Empty(t, buf.String())
+		assert.Equal(t, []StepResult{
+			{Step: StepContextSaved, Message: "Current cluster context is now 'prod'."},
+		}, steps)
+	})
+}
+
+func TestPrintMachinesPlan(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	PrintMachinesPlan(&buf, MachinesPlan{
+		Add: []MachineSpec{{Name: "vps1", SSH: config.SSHDestination("root@203.0.113.10")}},
+		UpdatePublicIP: []MachinePublicIPUpdate{
+			{
+				Machine:  &pb.MachineMember{Machine: &pb.MachineInfo{Name: "vps2"}},
+				PublicIP: netip.MustParseAddr("203.0.113.20"),
+			},
+		},
+	})
+
+	assert.Equal(t, "- Add machine vps1 (root@203.0.113.10)\n"+
+		"- Update machine vps2 [public_ip=203.0.113.20]\n", buf.String())
+}
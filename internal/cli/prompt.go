@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"golang.org/x/term"
@@ -27,6 +29,24 @@ func Confirm() (bool, error) {
 	return confirmed, nil
 }
 
+// ConfirmTyped asks the user to type the expected value, e.g. the name of a resource, to confirm a destructive action.
+// It returns false if the typed value doesn't match.
+func ConfirmTyped(expected string) (bool, error) {
+	var value string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(fmt.Sprintf("Type '%s' to confirm:", expected)).
+				Value(&value),
+		),
+	).WithAccessible(true)
+	if err := form.Run(); err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(value) == expected, nil
+}
+
 // IsStdinTerminal checks if the standard input is a terminal (TTY).
 func IsStdinTerminal() bool {
 	return term.IsTerminal(int(os.Stdin.Fd()))
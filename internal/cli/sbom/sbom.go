@@ -0,0 +1,224 @@
+// Package sbom retrieves software bills of materials (SBOMs) of container images from the SBOM attestations
+// stored in their registries or generates them with Syft (https://github.com/anchore/syft) installed locally.
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Format is the format of an SBOM document.
+type Format string
+
+const (
+	FormatSPDX      Format = "spdx"
+	FormatCycloneDX Format = "cyclonedx"
+)
+
+// syftOutputs maps the SBOM formats to the Syft output formats.
+var syftOutputs = map[Format]string{
+	FormatSPDX:      "spdx-json",
+	FormatCycloneDX: "cyclonedx-json",
+}
+
+// Source describes where an SBOM document came from.
+type Source string
+
+const (
+	// SourceAttestation means the SBOM was retrieved from the attestation stored with the image in the registry,
+	// e.g. generated by BuildKit with 'docker buildx build --sbom=true'.
+	SourceAttestation Source = "attestation"
+	// SourceSyft means the SBOM was generated with Syft.
+	SourceSyft Source = "syft"
+)
+
+const (
+	// spdxPredicateType is the in-toto predicate type of the SPDX SBOM attestations.
+	spdxPredicateType = "https://spdx.dev/Document"
+	// attestationReferenceType is the value of the annotation that marks the attestation manifests in an image index.
+	attestationReferenceType = "attestation-manifest"
+)
+
+// ParseFormat parses the SBOM format name.
+func ParseFormat(s string) (Format, error) {
+	if _, ok := syftOutputs[Format(s)]; ok {
+		return Format(s), nil
+	}
+	return "", fmt.Errorf("invalid SBOM format '%s', must be one of: %s, %s", s, FormatSPDX, FormatCycloneDX)
+}
+
+// Document is an SBOM document of an image.
+type Document struct {
+	Image  string
+	Format Format
+	Source Source
+	// Data is the raw JSON document.
+	Data json.RawMessage
+}
+
+// Package is a software package listed in an SBOM document.
+type Package struct {
+	Name    string
+	Version string
+}
+
+// Get returns the SBOM document of the image in the given format. It retrieves the SPDX SBOM attestation stored
+// with the image in the registry if available and falls back to generating the document with Syft otherwise.
+func Get(ctx context.Context, image string, format Format) (Document, error) {
+	if format == FormatSPDX {
+		doc, err := Fetch(ctx, image)
+		if err == nil {
+			return doc, nil
+		}
+		if !errors.Is(err, ErrNoAttestation) {
+			return doc, err
+		}
+	}
+	return Generate(ctx, image, format)
+}
+
+// ErrNoAttestation is returned when the image has no SBOM attestation in the registry.
+var ErrNoAttestation = errors.New("no SBOM attestation found")
+
+// Fetch retrieves the SPDX SBOM attestation stored with the image in the registry. It returns ErrNoAttestation if
+// the image is not available in a registry or has no SBOM attestation.
+func Fetch(ctx context.Context, image string, opts ...remote.Option) (Document, error) {
+	doc := Document{Image: image, Format: FormatSPDX, Source: SourceAttestation}
+
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return doc, fmt.Errorf("parse image reference: %w", err)
+	}
+	opts = append(opts, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	idx, err := remote.Index(ref, opts...)
+	if err != nil {
+		// Single-platform images and images pushed directly to machines don't have attestations.
+		return doc, ErrNoAttestation
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return doc, fmt.Errorf("get image index manifest: %w", err)
+	}
+
+	for _, desc := range manifest.Manifests {
+		if desc.Annotations["vnd.docker.reference.type"] != attestationReferenceType {
+			continue
+		}
+		att, err := idx.Image(desc.Digest)
+		if err != nil {
+			return doc, fmt.Errorf("get attestation manifest: %w", err)
+		}
+		if doc.Data, err = spdxPredicate(att); err != nil {
+			return doc, err
+		}
+		if doc.Data != nil {
+			return doc, nil
+		}
+	}
+	return doc, ErrNoAttestation
+}
+
+// spdxPredicate returns the SPDX document from the in-toto statement in the attestation image if any.
+func spdxPredicate(att v1.Image) (json.RawMessage, error) {
+	manifest, err := att.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("get attestation manifest: %w", err)
+	}
+	for _, desc := range manifest.Layers {
+		if desc.Annotations["in-toto.io/predicate-type"] != spdxPredicateType {
+			continue
+		}
+		layer, err := att.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("get attestation layer: %w", err)
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("read attestation layer: %w", err)
+		}
+		var statement struct {
+			Predicate json.RawMessage `json:"predicate"`
+		}
+		err = json.NewDecoder(rc).Decode(&statement)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode in-toto statement: %w", err)
+		}
+		return statement.Predicate, nil
+	}
+	return nil, nil
+}
+
+// Generate generates the SBOM document of the image with Syft. Syft looks for the image in the local Docker image
+// store first and pulls it from the registry if it's not found.
+func Generate(ctx context.Context, image string, format Format) (Document, error) {
+	doc := Document{Image: image, Format: format, Source: SourceSyft}
+
+	path, err := exec.LookPath("syft")
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return doc, fmt.Errorf("no SBOM attestation found for image '%s' and syft not found in PATH, "+
+				"install syft to generate SBOMs", image)
+		}
+		return doc, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, "--quiet", "--output", syftOutputs[format], image)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return doc, fmt.Errorf("run syft: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if !json.Valid(stdout.Bytes()) {
+		return doc, errors.New("syft returned invalid JSON")
+	}
+	doc.Data = stdout.Bytes()
+	return doc, nil
+}
+
+// Packages returns the software packages listed in the SBOM document.
+func (d *Document) Packages() ([]Package, error) {
+	var pkgs []Package
+	switch d.Format {
+	case FormatSPDX:
+		var spdx struct {
+			Packages []struct {
+				Name        string `json:"name"`
+				VersionInfo string `json:"versionInfo"`
+			} `json:"packages"`
+		}
+		if err := json.Unmarshal(d.Data, &spdx); err != nil {
+			return nil, fmt.Errorf("parse SPDX document: %w", err)
+		}
+		for _, p := range spdx.Packages {
+			pkgs = append(pkgs, Package{Name: p.Name, Version: p.VersionInfo})
+		}
+	case FormatCycloneDX:
+		var cdx struct {
+			Components []struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"components"`
+		}
+		if err := json.Unmarshal(d.Data, &cdx); err != nil {
+			return nil, fmt.Errorf("parse CycloneDX document: %w", err)
+		}
+		for _, c := range cdx.Components {
+			pkgs = append(pkgs, Package{Name: c.Name, Version: c.Version})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format '%s'", d.Format)
+	}
+	return pkgs, nil
+}
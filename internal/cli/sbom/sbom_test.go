@@ -0,0 +1,123 @@
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spdxDoc = `{"spdxVersion":"SPDX-2.3","packages":[` +
+	`{"name":"openssl","versionInfo":"3.0.13"},{"name":"zlib","versionInfo":"1.3"}]}`
+
+// pushIndex pushes an image index with a random image and an optional SPDX attestation manifest like BuildKit does.
+func pushIndex(t *testing.T, ref string, withAttestation bool) {
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	idx := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add:        img,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+	})
+
+	if withAttestation {
+		statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"%s","predicate":%s}`,
+			spdxPredicateType, spdxDoc)
+		att, err := mutate.Append(empty.Image, mutate.Addendum{
+			Layer:       static.NewLayer([]byte(statement), "application/vnd.in-toto+json"),
+			Annotations: map[string]string{"in-toto.io/predicate-type": spdxPredicateType},
+		})
+		require.NoError(t, err)
+		digest, err := img.Digest()
+		require.NoError(t, err)
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add: att,
+			Descriptor: v1.Descriptor{
+				Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"},
+				Annotations: map[string]string{
+					"vnd.docker.reference.type":   attestationReferenceType,
+					"vnd.docker.reference.digest": digest.String(),
+				},
+			},
+		})
+	}
+	idx = mutate.IndexMediaType(idx, types.OCIImageIndex)
+
+	r, err := name.ParseReference(ref)
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteIndex(r, idx))
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	t.Run("attestation", func(t *testing.T) {
+		image := u.Host + "/app:1"
+		pushIndex(t, image, true)
+
+		doc, err := Fetch(context.Background(), image)
+		require.NoError(t, err)
+		assert.Equal(t, SourceAttestation, doc.Source)
+		assert.Equal(t, FormatSPDX, doc.Format)
+		assert.JSONEq(t, spdxDoc, string(doc.Data))
+
+		pkgs, err := doc.Packages()
+		require.NoError(t, err)
+		assert.Equal(t, []Package{{Name: "openssl", Version: "3.0.13"}, {Name: "zlib", Version: "1.3"}}, pkgs)
+	})
+
+	t.Run("no attestation", func(t *testing.T) {
+		image := u.Host + "/plain:1"
+		pushIndex(t, image, false)
+
+		_, err := Fetch(context.Background(), image)
+		assert.ErrorIs(t, err, ErrNoAttestation)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Fetch(context.Background(), u.Host+"/missing:1")
+		assert.ErrorIs(t, err, ErrNoAttestation)
+	})
+}
+
+func TestPackages_CycloneDX(t *testing.T) {
+	t.Parallel()
+
+	doc := Document{
+		Format: FormatCycloneDX,
+		Data: json.RawMessage(`{"bomFormat":"CycloneDX","specVersion":"1.5","components":[` +
+			`{"type":"library","name":"log4j-core","version":"2.17.1"}]}`),
+	}
+	pkgs, err := doc.Packages()
+	require.NoError(t, err)
+	assert.Equal(t, []Package{{Name: "log4j-core", Version: "2.17.1"}}, pkgs)
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	f, err := ParseFormat("cyclonedx")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCycloneDX, f)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// Grype scans images with Grype (https://github.com/anchore/grype).
+type Grype struct{}
+
+func (g *Grype) Name() string {
+	return api.ImageScannerGrype
+}
+
+func (g *Grype) Scan(ctx context.Context, image string) (api.ImageScanReport, error) {
+	out, err := run(ctx, "grype", "--quiet", "--output", "json", image)
+	if err != nil {
+		return api.ImageScanReport{}, err
+	}
+	return parseGrypeReport(image, out)
+}
+
+// grypeReport is the subset of the Grype JSON report with the found vulnerabilities.
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string `json:"id"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+func parseGrypeReport(image string, data []byte) (api.ImageScanReport, error) {
+	report := api.ImageScanReport{Image: image, Scanner: api.ImageScannerGrype}
+
+	var r grypeReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return report, fmt.Errorf("parse grype report: %w", err)
+	}
+	for _, m := range r.Matches {
+		report.Vulnerabilities = append(report.Vulnerabilities, api.Vulnerability{
+			ID:               m.Vulnerability.ID,
+			Severity:         severity(m.Vulnerability.Severity),
+			Package:          m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			FixedVersion:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Title:            m.Vulnerability.Description,
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,85 @@
+// Package scan scans container images for known vulnerabilities using an external scanner installed locally
+// and evaluates the results against the image scan policy of the cluster.
+package scan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// Scanner scans container images for known vulnerabilities.
+type Scanner interface {
+	// Name returns the name of the scanner, e.g. "trivy".
+	Name() string
+	// Scan scans the image in a registry or the local Docker image store and returns the found vulnerabilities.
+	Scan(ctx context.Context, image string) (api.ImageScanReport, error)
+}
+
+// New returns the scanner with the given name, one of api.ImageScanners.
+func New(name string) (Scanner, error) {
+	switch name {
+	case api.ImageScannerTrivy:
+		return &Trivy{}, nil
+	case api.ImageScannerGrype:
+		return &Grype{}, nil
+	}
+	return nil, fmt.Errorf("unsupported image scanner '%s', must be one of: %s",
+		name, strings.Join(api.ImageScanners, ", "))
+}
+
+// Policy decides which vulnerabilities found in an image block its deployment.
+type Policy struct {
+	// FailOn is the minimum severity of vulnerabilities that block the deployment.
+	FailOn api.VulnerabilitySeverity
+}
+
+// Blocking returns the vulnerabilities in the report that block the deployment of the image.
+func (p Policy) Blocking(report api.ImageScanReport) []api.Vulnerability {
+	var blocking []api.Vulnerability
+	for _, v := range report.Vulnerabilities {
+		if v.Severity.AtLeast(p.FailOn) {
+			blocking = append(blocking, v)
+		}
+	}
+	return blocking
+}
+
+// run runs the scanner binary and returns its stdout. The binary must be installed and available in PATH.
+func run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("image scanner '%s' not found in PATH, install it to scan images "+
+				"or use --skip-scan to deploy without scanning", name)
+		}
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run %s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// severity converts the severity reported by a scanner to a known severity.
+func severity(s string) api.VulnerabilitySeverity {
+	// Grype reports the lowest severity as "Negligible".
+	if strings.EqualFold(s, "negligible") {
+		return api.SeverityLow
+	}
+	sev, err := api.ParseVulnerabilitySeverity(s)
+	if err != nil {
+		return api.SeverityUnknown
+	}
+	return sev
+}
@@ -0,0 +1,93 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrivyReport(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+  "ArtifactName": "app:1",
+  "Results": [
+    {"Target": "app:1 (debian 12.5)", "Vulnerabilities": [
+      {"VulnerabilityID": "CVE-2024-0001", "PkgName": "openssl", "InstalledVersion": "3.0.11",
+       "FixedVersion": "3.0.13", "Severity": "CRITICAL", "Title": "openssl: remote code execution"},
+      {"VulnerabilityID": "CVE-2024-0002", "PkgName": "zlib", "InstalledVersion": "1.2.13", "Severity": "LOW"}
+    ]},
+    {"Target": "app.jar", "Class": "lang-pkgs"}
+  ]
+}`
+	report, err := parseTrivyReport("app:1", []byte(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, api.ImageScanReport{
+		Image:   "app:1",
+		Scanner: api.ImageScannerTrivy,
+		Vulnerabilities: []api.Vulnerability{
+			{
+				ID:               "CVE-2024-0001",
+				Severity:         api.SeverityCritical,
+				Package:          "openssl",
+				InstalledVersion: "3.0.11",
+				FixedVersion:     "3.0.13",
+				Title:            "openssl: remote code execution",
+			},
+			{ID: "CVE-2024-0002", Severity: api.SeverityLow, Package: "zlib", InstalledVersion: "1.2.13"},
+		},
+	}, report)
+	assert.Equal(t, "1 critical, 1 low", report.Summary())
+}
+
+func TestParseGrypeReport(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+  "matches": [
+    {"vulnerability": {"id": "CVE-2021-44228", "severity": "Critical", "description": "Log4Shell",
+      "fix": {"versions": ["2.15.0"], "state": "fixed"}},
+     "artifact": {"name": "log4j-core", "version": "2.14.1"}},
+    {"vulnerability": {"id": "CVE-2024-0003", "severity": "Negligible", "fix": {"versions": [], "state": "not-fixed"}},
+     "artifact": {"name": "bash", "version": "5.2"}}
+  ]
+}`
+	report, err := parseGrypeReport("app:1", []byte(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, []api.Vulnerability{
+		{
+			ID:               "CVE-2021-44228",
+			Severity:         api.SeverityCritical,
+			Package:          "log4j-core",
+			InstalledVersion: "2.14.1",
+			FixedVersion:     "2.15.0",
+			Title:            "Log4Shell",
+		},
+		{ID: "CVE-2024-0003", Severity: api.SeverityLow, Package: "bash", InstalledVersion: "5.2"},
+	}, report.Vulnerabilities)
+}
+
+func TestPolicy_Blocking(t *testing.T) {
+	t.Parallel()
+
+	report := api.ImageScanReport{
+		Vulnerabilities: []api.Vulnerability{
+			{ID: "CVE-1", Severity: api.SeverityCritical},
+			{ID: "CVE-2", Severity: api.SeverityHigh},
+			{ID: "CVE-3", Severity: api.SeverityMedium},
+			{ID: "CVE-4", Severity: api.SeverityUnknown},
+		},
+	}
+
+	blocking := Policy{FailOn: api.SeverityCritical}.Blocking(report)
+	assert.Equal(t, []api.Vulnerability{{ID: "CVE-1", Severity: api.SeverityCritical}}, blocking)
+
+	blocking = Policy{FailOn: api.SeverityHigh}.Blocking(report)
+	assert.Len(t, blocking, 2)
+
+	assert.Empty(t, Policy{FailOn: api.SeverityCritical}.Blocking(api.ImageScanReport{}))
+}
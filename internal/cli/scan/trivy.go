@@ -0,0 +1,60 @@
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// Trivy scans images with Trivy (https://trivy.dev).
+type Trivy struct{}
+
+func (t *Trivy) Name() string {
+	return api.ImageScannerTrivy
+}
+
+func (t *Trivy) Scan(ctx context.Context, image string) (api.ImageScanReport, error) {
+	out, err := run(ctx, "trivy", "image", "--quiet", "--scanners", "vuln", "--format", "json", image)
+	if err != nil {
+		return api.ImageScanReport{}, err
+	}
+	return parseTrivyReport(image, out)
+}
+
+// trivyReport is the subset of the Trivy JSON report with the found vulnerabilities.
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string
+			PkgName          string
+			InstalledVersion string
+			FixedVersion     string
+			Severity         string
+			Title            string
+		}
+	}
+}
+
+func parseTrivyReport(image string, data []byte) (api.ImageScanReport, error) {
+	report := api.ImageScanReport{Image: image, Scanner: api.ImageScannerTrivy}
+
+	var r trivyReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return report, fmt.Errorf("parse trivy report: %w", err)
+	}
+	for _, res := range r.Results {
+		for _, v := range res.Vulnerabilities {
+			report.Vulnerabilities = append(report.Vulnerabilities, api.Vulnerability{
+				ID:               v.VulnerabilityID,
+				Severity:         severity(v.Severity),
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Title:            v.Title,
+			})
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/selfupdate"
+)
+
+// ReleaseChannel returns the release channel to upgrade from. The channel name, if not empty, takes precedence over
+// the channel saved in the config. The stable channel is used if neither is set.
+func (cli *CLI) ReleaseChannel(name string) (selfupdate.Channel, error) {
+	if name == "" && cli.Config != nil {
+		name = cli.Config.ReleaseChannel
+	}
+	return selfupdate.ParseChannel(name)
+}
+
+// SaveReleaseChannel saves the release channel to the config to be used by the following upgrades.
+func (cli *CLI) SaveReleaseChannel(channel selfupdate.Channel) error {
+	if cli.Config == nil || cli.Config.ReleaseChannel == string(channel) {
+		return nil
+	}
+	cli.Config.ReleaseChannel = string(channel)
+	if err := cli.Config.Save(); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	return nil
+}
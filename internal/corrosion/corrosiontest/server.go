@@ -0,0 +1,154 @@
+// Package corrosiontest provides an in-memory fake of the Corrosion API backed by SQLite for unit testing code that
+// reads and writes the cluster store without running Corrosion.
+package corrosiontest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/corrosion"
+	_ "modernc.org/sqlite"
+)
+
+// NewAPIClient starts a fake Corrosion API server with an in-memory database created with the schema and returns
+// a client connected to it. The server only supports the transactions and queries endpoints, not subscriptions.
+// It's stopped when the test finishes.
+func NewAPIClient(t testing.TB, schema string) *corrosion.APIClient {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open SQLite database: %v", err)
+	}
+	// Each connection to an in-memory database has its own database.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	if _, err = db.Exec(schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	s := &server{db: db}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/transactions", s.handleTransactions)
+	mux.HandleFunc("POST /v1/queries", s.handleQueries)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	addr := netip.MustParseAddrPort(srv.Listener.Addr().String())
+	client, err := corrosion.NewAPIClient(addr, corrosion.WithHTTP2Client(srv.Client()))
+	if err != nil {
+		t.Fatalf("create Corrosion API client: %v", err)
+	}
+	return client
+}
+
+type server struct {
+	db *sql.DB
+}
+
+// handleTransactions executes the statements in a single transaction like Corrosion does.
+func (s *server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	var statements []corrosion.Statement
+	if err := json.NewDecoder(r.Body).Decode(&statements); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, err := s.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		writeExecError(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	var resp corrosion.ExecResponse
+	for _, st := range statements {
+		res, err := tx.ExecContext(r.Context(), st.Query, params(st.Params)...)
+		if err != nil {
+			writeExecError(w, err)
+			return
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			writeExecError(w, err)
+			return
+		}
+		resp.Results = append(resp.Results, corrosion.ExecResult{RowsAffected: uint(affected)})
+	}
+	if err = tx.Commit(); err != nil {
+		writeExecError(w, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func writeExecError(w http.ResponseWriter, err error) {
+	msg := err.Error()
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(corrosion.ExecResponse{Results: []corrosion.ExecResult{{Error: &msg}}})
+}
+
+// handleQueries streams the query result as the columns, row, and end of query events.
+func (s *server) handleQueries(w http.ResponseWriter, r *http.Request) {
+	var st corrosion.Statement
+	if err := json.NewDecoder(r.Body).Decode(&st); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows, err := s.db.QueryContext(r.Context(), st.Query, params(st.Params)...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	enc := json.NewEncoder(w)
+	_ = enc.Encode(map[string]any{"columns": columns})
+	for rowID := 1; rows.Next(); rowID++ {
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err = rows.Scan(ptrs...); err != nil {
+			_ = enc.Encode(map[string]any{"error": err.Error()})
+			return
+		}
+		for i, v := range values {
+			// Text values may be returned as bytes but Corrosion encodes them as JSON strings.
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		_ = enc.Encode(map[string]any{"row": []any{rowID, values}})
+	}
+	if err = rows.Err(); err != nil {
+		_ = enc.Encode(map[string]any{"error": err.Error()})
+		return
+	}
+	_ = enc.Encode(map[string]any{"eoq": corrosion.EndOfQuery{}})
+}
+
+// params converts the JSON-decoded statement parameters to the SQLite driver values. JSON numbers are decoded as
+// float64 so the integral ones are converted to int64.
+func params(values []any) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			v = int64(f)
+		}
+		args[i] = v
+	}
+	return args
+}
@@ -13,10 +13,7 @@ type Daemon struct {
 	machine *machine.Machine
 }
 
-func New(dataDir string) (*Daemon, error) {
-	config := &machine.Config{
-		DataDir: dataDir,
-	}
+func New(config *machine.Config) (*Daemon, error) {
 	mach, err := machine.NewMachine(config)
 	if err != nil {
 		return nil, fmt.Errorf("init machine: %w", err)
@@ -42,5 +39,14 @@ func (d *Daemon) Run(ctx context.Context) error {
 		}
 	}()
 
+	// Delay the system shutdown or reboot to gracefully stop the containers and notify other machines in the cluster
+	// so that a planned reboot doesn't look like a machine failure to them.
+	inhibitor, err := newShutdownInhibitor()
+	if err != nil {
+		slog.Warn("Graceful shutdown coordination with systemd-logind is unavailable.", "err", err)
+	} else {
+		go inhibitor.run(ctx, d.machine.PrepareShutdown, d.machine.CancelShutdown)
+	}
+
 	return d.machine.Run(ctx)
 }
@@ -0,0 +1,119 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	logindDest      = "org.freedesktop.login1"
+	logindPath      = dbus.ObjectPath("/org/freedesktop/login1")
+	logindInterface = "org.freedesktop.login1.Manager"
+)
+
+// shutdownInhibitor coordinates a graceful shutdown of the machine with systemd-logind. It holds a delay inhibitor
+// lock that postpones the system shutdown or reboot until the machine is prepared for it, or until logind's
+// InhibitDelayMaxSec timeout expires.
+type shutdownInhibitor struct {
+	conn *dbus.Conn
+	lock *os.File
+}
+
+func newShutdownInhibitor() (*shutdownInhibitor, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("connect to system D-Bus: %w", err)
+	}
+
+	if err = conn.AddMatchSignal(
+		dbus.WithMatchInterface(logindInterface),
+		dbus.WithMatchMember("PrepareForShutdown"),
+	); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("subscribe to logind shutdown signal: %w", err)
+	}
+
+	return &shutdownInhibitor{conn: conn}, nil
+}
+
+// acquire takes a delay inhibitor lock for the shutdown. It's a no-op if the lock is already held.
+func (i *shutdownInhibitor) acquire() error {
+	if i.lock != nil {
+		return nil
+	}
+
+	var fd dbus.UnixFD
+	err := i.conn.Object(logindDest, logindPath).Call(logindInterface+".Inhibit", 0,
+		"shutdown", "Uncloud", "Gracefully stopping containers and notifying cluster machines.", "delay",
+	).Store(&fd)
+	if err != nil {
+		return fmt.Errorf("take logind inhibitor lock: %w", err)
+	}
+	i.lock = os.NewFile(uintptr(fd), "uncloud-shutdown-inhibitor")
+	return nil
+}
+
+// release releases the inhibitor lock allowing the shutdown to proceed.
+func (i *shutdownInhibitor) release() {
+	if i.lock == nil {
+		return
+	}
+	if err := i.lock.Close(); err != nil {
+		slog.Error("Failed to release logind inhibitor lock.", "err", err)
+	}
+	i.lock = nil
+}
+
+// run takes the inhibitor lock and calls prepare when a shutdown is initiated and cancel if it's cancelled.
+// The lock is released after prepare returns and taken again after the shutdown is cancelled. It blocks until
+// the context is canceled.
+func (i *shutdownInhibitor) run(
+	ctx context.Context, prepare func(context.Context) error, cancel func(context.Context) error,
+) {
+	defer i.conn.Close()
+	defer i.release()
+
+	signals := make(chan *dbus.Signal, 10)
+	i.conn.Signal(signals)
+	defer i.conn.RemoveSignal(signals)
+
+	if err := i.acquire(); err != nil {
+		slog.Error("Failed to delay system shutdown to prepare the machine for it.", "err", err)
+		return
+	}
+
+	for {
+		select {
+		case sig, ok := <-signals:
+			if !ok {
+				return
+			}
+			if sig.Name != logindInterface+".PrepareForShutdown" || len(sig.Body) == 0 {
+				continue
+			}
+			start, _ := sig.Body[0].(bool)
+			if start {
+				slog.Info("System shutdown initiated, preparing machine for it.")
+				if err := prepare(ctx); err != nil {
+					slog.Error("Failed to prepare machine for shutdown.", "err", err)
+				}
+				i.release()
+				continue
+			}
+
+			slog.Info("System shutdown cancelled.")
+			if err := cancel(ctx); err != nil {
+				slog.Error("Failed to restore machine after cancelled shutdown.", "err", err)
+			}
+			if err := i.acquire(); err != nil {
+				slog.Error("Failed to delay system shutdown to prepare the machine for it.", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
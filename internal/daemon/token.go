@@ -40,3 +40,34 @@ func MachineToken(dataDir string) (machine.Token, error) {
 	}
 	return machine.NewToken(state.Network.PublicKey, publicIP, endpoints), nil
 }
+
+// RotateMachineToken generates a new WireGuard key pair for the local machine, saves it to the machine state and
+// returns the new machine token. Tokens issued before the rotation can no longer be used to add the machine to
+// a cluster. The keys of a machine that is already a cluster member can't be rotated as other machines rely on them.
+// It must only be used when the daemon isn't running, otherwise the daemon keeps using the old keys. Use
+// the RotateToken machine API of the running daemon instead.
+func RotateMachineToken(dataDir string) (machine.Token, error) {
+	state, err := machine.ParseState(machine.StatePath(dataDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return machine.Token{}, fmt.Errorf("load machine config (is uncloudd daemon running?): %w", err)
+		}
+		return machine.Token{}, fmt.Errorf("load machine config: %w", err)
+	}
+	if state.ID != "" {
+		return machine.Token{}, fmt.Errorf("machine is already a member of a cluster, its keys can't be rotated; " +
+			"reset the machine first if you want to add it to a cluster with a new token")
+	}
+
+	privKey, pubKey, err := network.NewMachineKeys()
+	if err != nil {
+		return machine.Token{}, fmt.Errorf("generate machine keys: %w", err)
+	}
+	state.Network.PrivateKey = privKey
+	state.Network.PublicKey = pubKey
+	if err = state.Save(); err != nil {
+		return machine.Token{}, fmt.Errorf("save machine config: %w", err)
+	}
+
+	return MachineToken(dataDir)
+}
@@ -0,0 +1,154 @@
+// Package dbbackup implements logical backups of the databases running in service containers and storing them
+// in an S3-compatible object storage.
+//
+// Backups are created by running the dump command of the database engine in a service container and uploading its
+// gzip-compressed output to the storage under the key <prefix>/<service>/<timestamp>.<engine>.<ext>.gz.
+// A backup is restored by piping the decompressed backup into the restore command of the engine.
+package dbbackup
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// keyTimeFormat is the format of the backup creation time in the backup keys. It sorts lexicographically.
+const keyTimeFormat = "20060102T150405Z"
+
+// Engine describes how to back up and restore a database by running commands in its container.
+type Engine struct {
+	Name string
+	// Extension is the file extension of the uncompressed backup.
+	Extension string
+	// DumpCmd writes the backup to stdout.
+	DumpCmd []string
+	// RestoreCmd reads the backup from stdin and restores it.
+	RestoreCmd []string
+	// PostRestoreCmd is an optional command run after RestoreCmd to apply the restored backup. Its exit code
+	// is ignored as it may stop the container.
+	PostRestoreCmd []string
+}
+
+var engines = map[string]Engine{
+	api.BackupEnginePostgres: {
+		Name:      api.BackupEnginePostgres,
+		Extension: "sql",
+		// The official image trusts local connections so no password is needed for the superuser.
+		DumpCmd: []string{"sh", "-c",
+			`exec pg_dumpall --clean --if-exists -U "${POSTGRES_USER:-postgres}"`},
+		// Errors such as dropping the connected superuser role are expected when restoring a pg_dumpall output
+		// with --clean so they don't stop the restore.
+		RestoreCmd: []string{"sh", "-c",
+			`exec psql --quiet --no-psqlrc -U "${POSTGRES_USER:-postgres}" -d postgres >/dev/null`},
+	},
+	api.BackupEngineMySQL: {
+		Name:      api.BackupEngineMySQL,
+		Extension: "sql",
+		// MariaDB images don't ship the mysql* client aliases in recent versions.
+		DumpCmd: []string{"sh", "-c",
+			`export MYSQL_PWD="${MYSQL_ROOT_PASSWORD:-$MARIADB_ROOT_PASSWORD}"; ` +
+				`exec "$(command -v mariadb-dump || command -v mysqldump)" -uroot ` +
+				`--all-databases --single-transaction --routines --events --triggers`},
+		RestoreCmd: []string{"sh", "-c",
+			`export MYSQL_PWD="${MYSQL_ROOT_PASSWORD:-$MARIADB_ROOT_PASSWORD}"; ` +
+				`exec "$(command -v mariadb || command -v mysql)" -uroot`},
+	},
+	api.BackupEngineRedis: {
+		Name:      api.BackupEngineRedis,
+		Extension: "rdb",
+		// redis-cli writes the transfer progress to stdout so the snapshot is saved to a file first.
+		DumpCmd: []string{"sh", "-c",
+			`export REDISCLI_AUTH="${REDISCLI_AUTH:-$REDIS_PASSWORD}"; f=/tmp/uncloud-backup.rdb; ` +
+				`redis-cli --no-auth-warning --rdb "$f" >&2 && cat "$f"; rc=$?; rm -f "$f"; exit $rc`},
+		// The snapshot replaces the RDB file of the server. It's loaded when the server is shut down without saving
+		// and restarted by the container restart policy.
+		RestoreCmd: []string{"sh", "-c",
+			`export REDISCLI_AUTH="${REDISCLI_AUTH:-$REDIS_PASSWORD}"; ` +
+				`dir=$(redis-cli --no-auth-warning --raw config get dir | tail -n 1) && ` +
+				`file=$(redis-cli --no-auth-warning --raw config get dbfilename | tail -n 1) && ` +
+				`cat > "$dir/$file"`},
+		PostRestoreCmd: []string{"sh", "-c",
+			`export REDISCLI_AUTH="${REDISCLI_AUTH:-$REDIS_PASSWORD}"; redis-cli --no-auth-warning shutdown nosave`},
+	},
+}
+
+// EngineByName returns the backup engine with the given name.
+func EngineByName(name string) (Engine, error) {
+	e, ok := engines[name]
+	if !ok {
+		return Engine{}, fmt.Errorf("unsupported backup engine: %q", name)
+	}
+	return e, nil
+}
+
+// Key returns the storage key for a backup of the service created at the given time.
+func Key(prefix, service string, engine Engine, createdAt time.Time) string {
+	name := fmt.Sprintf("%s.%s.%s.gz", createdAt.UTC().Format(keyTimeFormat), engine.Name, engine.Extension)
+	return path.Join(prefix, service, name)
+}
+
+// ServicePrefix returns the storage key prefix for the backups of the service.
+func ServicePrefix(prefix, service string) string {
+	return path.Join(prefix, service) + "/"
+}
+
+// ParseKey parses the service name, engine, and creation time of a backup from its storage key.
+func ParseKey(prefix, key string) (api.Backup, error) {
+	b := api.Backup{Key: key}
+
+	rel := strings.TrimPrefix(key, strings.TrimSuffix(prefix, "/")+"/")
+	if prefix == "" {
+		rel = key
+	}
+	service, name := path.Split(rel)
+	service = strings.TrimSuffix(service, "/")
+	if service == "" || strings.Contains(service, "/") {
+		return b, fmt.Errorf("invalid backup key: %q", key)
+	}
+
+	parts := strings.SplitN(name, ".", 3)
+	if len(parts) != 3 {
+		return b, fmt.Errorf("invalid backup key: %q", key)
+	}
+	createdAt, err := time.Parse(keyTimeFormat, parts[0])
+	if err != nil {
+		return b, fmt.Errorf("invalid backup key: %q: %w", key, err)
+	}
+	if _, err = EngineByName(parts[1]); err != nil {
+		return b, fmt.Errorf("invalid backup key: %q: %w", key, err)
+	}
+
+	b.Service = service
+	b.Engine = parts[1]
+	b.CreatedAt = createdAt
+	return b, nil
+}
+
+// List returns the backups of the service in the storage sorted by creation time, newest first.
+func List(objects []Object, prefix, service string) []api.Backup {
+	var backups []api.Backup
+	for _, o := range objects {
+		b, err := ParseKey(prefix, o.Key)
+		if err != nil || b.Service != service {
+			continue
+		}
+		b.Size = o.Size
+		backups = append(backups, b)
+	}
+	slices.SortFunc(backups, func(a, b api.Backup) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return backups
+}
+
+// Expired returns the backups that exceed the retention count. The backups must be sorted newest first.
+func Expired(backups []api.Backup, retention uint) []api.Backup {
+	if uint(len(backups)) <= retention {
+		return nil
+	}
+	return backups[retention:]
+}
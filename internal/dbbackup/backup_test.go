@@ -0,0 +1,64 @@
+package dbbackup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	engine, err := EngineByName(api.BackupEnginePostgres)
+	require.NoError(t, err)
+	createdAt := time.Date(2025, 3, 1, 4, 5, 6, 0, time.UTC)
+
+	key := Key("prod", "db", engine, createdAt)
+	assert.Equal(t, "prod/db/20250301T040506Z.postgres.sql.gz", key)
+	assert.Equal(t, "db/20250301T040506Z.postgres.sql.gz", Key("", "db", engine, createdAt))
+
+	b, err := ParseKey("prod", key)
+	require.NoError(t, err)
+	assert.Equal(t, api.Backup{
+		Key:       key,
+		Service:   "db",
+		Engine:    api.BackupEnginePostgres,
+		CreatedAt: createdAt,
+	}, b)
+
+	for _, invalid := range []string{
+		"prod/20250301T040506Z.postgres.sql.gz",
+		"prod/db/nested/20250301T040506Z.postgres.sql.gz",
+		"prod/db/latest.postgres.sql.gz",
+		"prod/db/20250301T040506Z.mongodb.gz",
+		"prod/db/notes.txt",
+	} {
+		_, err = ParseKey("prod", invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestListAndExpired(t *testing.T) {
+	t.Parallel()
+
+	objects := []Object{
+		{Key: "db/20250101T000000Z.postgres.sql.gz", Size: 10},
+		{Key: "db/20250103T000000Z.postgres.sql.gz", Size: 30},
+		{Key: "db/20250102T000000Z.postgres.sql.gz", Size: 20},
+		{Key: "cache/20250102T000000Z.redis.rdb.gz", Size: 5},
+		{Key: "db/README", Size: 1},
+	}
+
+	backups := List(objects, "", "db")
+	require.Len(t, backups, 3)
+	assert.Equal(t, int64(30), backups[0].Size, "newest backup must be first")
+	assert.Equal(t, int64(10), backups[2].Size)
+
+	expired := Expired(backups, 2)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "db/20250101T000000Z.postgres.sql.gz", expired[0].Key)
+	assert.Empty(t, Expired(backups, 3))
+}
@@ -0,0 +1,193 @@
+package dbbackup
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	defaultRegion = "us-east-1"
+	// emptyPayloadHash is the SHA-256 hash of an empty request body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// unsignedPayload is used instead of the body hash to stream uploads without hashing them in advance.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// Object is an object in the backup storage.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Storage is a minimal client for an S3-compatible object storage that uses path-style requests signed with
+// AWS Signature Version 4.
+type Storage struct {
+	config api.BackupStorage
+	client *http.Client
+	signer *v4.Signer
+}
+
+// NewStorage creates a client for the backup storage.
+func NewStorage(config api.BackupStorage) (*Storage, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid backup storage: %w", err)
+	}
+	if _, err := url.Parse(config.Endpoint); err != nil {
+		return nil, fmt.Errorf("invalid backup storage endpoint: %w", err)
+	}
+	if config.Region == "" {
+		config.Region = defaultRegion
+	}
+
+	return &Storage{
+		config: config,
+		client: http.DefaultClient,
+		signer: v4.NewSigner(func(o *v4.SignerOptions) {
+			// S3 doesn't normalise object keys so they must not be escaped twice.
+			o.DisableURIPathEscaping = true
+		}),
+	}, nil
+}
+
+// Prefix returns the key prefix for the backups within the bucket.
+func (s *Storage) Prefix() string {
+	return s.config.Prefix
+}
+
+// Put uploads the object of the given size from the reader.
+func (s *Storage) Put(ctx context.Context, key string, body io.Reader, size int64) error {
+	resp, err := s.do(ctx, http.MethodPut, key, nil, body, size)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get downloads the object. The caller must close the returned reader.
+func (s *Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete deletes the object.
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns all objects with keys starting with the prefix.
+func (s *Storage) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode list objects response: %w", err)
+		}
+
+		for _, c := range result.Contents {
+			objects = append(objects, Object{Key: c.Key, Size: c.Size, LastModified: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// errorResponse is the error returned by the S3 API.
+type errorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// do sends a signed request for the object with the key or for the bucket if the key is empty. It returns an error
+// if the response status is not successful.
+func (s *Storage) do(
+	ctx context.Context, method, key string, query url.Values, body io.Reader, size int64,
+) (*http.Response, error) {
+	u, err := url.Parse(s.config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse endpoint: %w", err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.config.Bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		req.ContentLength = size
+		payloadHash = unsignedPayload
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	creds := aws.Credentials{AccessKeyID: s.config.AccessKeyID, SecretAccessKey: s.config.SecretAccessKey}
+	if err = s.signer.SignHTTP(ctx, creds, req, payloadHash, "s3", s.config.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("sign request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	what := "bucket '" + s.config.Bucket + "'"
+	if key != "" {
+		what = "object '" + key + "'"
+	}
+	var errResp errorResponse
+	if err = xml.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Code == "" {
+		return nil, fmt.Errorf("%s %s: %s", method, what, resp.Status)
+	}
+	if errResp.Code == "NoSuchKey" {
+		return nil, fmt.Errorf("%s %s: %w", method, what, ErrObjectNotFound)
+	}
+	return nil, fmt.Errorf("%s %s: %s: %s", method, what, errResp.Code, errResp.Message)
+}
+
+// ErrObjectNotFound is returned when the requested object doesn't exist in the storage.
+var ErrObjectNotFound = errors.New("object not found")
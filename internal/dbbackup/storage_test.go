@@ -0,0 +1,108 @@
+package dbbackup
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is an in-memory S3 server that supports the requests used by Storage for a single bucket.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != "backups" {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "<Error><Code>NoSuchBucket</Code><Message>no bucket</Message></Error>")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+	case r.Method == http.MethodGet && key == "":
+		var result listBucketResult
+		for k, data := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				result.Contents = append(result.Contents, struct {
+					Key          string    `xml:"Key"`
+					Size         int64     `xml:"Size"`
+					LastModified time.Time `xml:"LastModified"`
+				}{Key: k, Size: int64(len(data))})
+			}
+		}
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>")
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestStorage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	server := httptest.NewServer(&fakeS3{objects: make(map[string][]byte)})
+	t.Cleanup(server.Close)
+
+	s, err := NewStorage(api.BackupStorage{
+		Endpoint:        server.URL,
+		Bucket:          "backups",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	})
+	require.NoError(t, err)
+
+	data := "backup data"
+	require.NoError(t, s.Put(ctx, "db/1.sql.gz", strings.NewReader(data), int64(len(data))))
+	require.NoError(t, s.Put(ctx, "cache/1.rdb.gz", strings.NewReader(data), int64(len(data))))
+
+	objects, err := s.List(ctx, "db/")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "db/1.sql.gz", objects[0].Key)
+	assert.Equal(t, int64(len(data)), objects[0].Size)
+
+	rc, err := s.Get(ctx, "db/1.sql.gz")
+	require.NoError(t, err)
+	got, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, data, string(got))
+
+	require.NoError(t, s.Delete(ctx, "db/1.sql.gz"))
+	_, err = s.Get(ctx, "db/1.sql.gz")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	s.config.Bucket = "missing"
+	_, err = s.List(ctx, "")
+	assert.ErrorContains(t, err, "NoSuchBucket")
+}
@@ -16,6 +16,8 @@ type RecordRequest struct {
 	Name   string     `json:"name,omitempty"`
 	Type   RecordType `json:"type,omitempty"`
 	Values []string   `json:"values,omitempty"`
+	// TTL is the time to live of the record in seconds. The default TTL of the service is used if zero.
+	TTL uint32 `json:"ttl,omitempty"`
 }
 
 type RecordResponse struct {
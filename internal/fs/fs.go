@@ -4,22 +4,23 @@ import (
 	"fmt"
 	"os"
 	"os/user"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
 
+// ExpandHomeDir replaces the leading "~" in the path with the current user's home directory. Only "~" and paths
+// starting with "~/" (or "~\" on Windows) are expanded, other paths such as "~user/path" are returned unchanged.
 func ExpandHomeDir(path string) string {
-	if len(path) == 0 {
+	if path != "~" && !strings.HasPrefix(path, "~/") &&
+		!(filepath.Separator == '\\' && strings.HasPrefix(path, `~\`)) {
 		return path
 	}
-	if path[0] == '~' {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return path
-		}
-		return strings.Replace(path, "~", home, 1)
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
 	}
-	return path
+	return filepath.Join(home, path[1:])
 }
 
 // LookupUIDGID returns the user and group IDs for the given username.
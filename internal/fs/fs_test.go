@@ -1,12 +1,18 @@
 package fs
 
 import (
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestExpandHomeDir(t *testing.T) {
+	home := filepath.FromSlash("/home/user")
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
 	t.Run("empty", func(t *testing.T) {
 		assert.Equal(t, "", ExpandHomeDir(""))
 	})
@@ -16,7 +22,22 @@ func TestExpandHomeDir(t *testing.T) {
 	})
 
 	t.Run("home", func(t *testing.T) {
-		t.Setenv("HOME", "/home/user")
-		assert.Equal(t, "/home/user/path", ExpandHomeDir("~/path"))
+		assert.Equal(t, filepath.Join(home, "path"), ExpandHomeDir("~/path"))
+	})
+
+	t.Run("home only", func(t *testing.T) {
+		assert.Equal(t, home, ExpandHomeDir("~"))
+	})
+
+	t.Run("other user home", func(t *testing.T) {
+		assert.Equal(t, "~user/path", ExpandHomeDir("~user/path"))
+	})
+
+	t.Run("backslash separator", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			assert.Equal(t, filepath.Join(home, "path"), ExpandHomeDir(`~\path`))
+		} else {
+			assert.Equal(t, `~\path`, ExpandHomeDir(`~\path`))
+		}
 	})
 }
@@ -0,0 +1,47 @@
+package compression
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestZstdCompressor(t *testing.T) {
+	t.Parallel()
+
+	c := encoding.GetCompressor(Zstd)
+	require.NotNil(t, c)
+
+	// Compress and decompress multiple times to reuse the pooled encoders and decoders.
+	for i := range 3 {
+		data := []byte(strings.Repeat("log line\n", 1000*(i+1)))
+
+		var buf bytes.Buffer
+		w, err := c.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Less(t, buf.Len(), len(data))
+
+		r, err := c.Decompress(&buf)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Validate(None))
+	assert.NoError(t, Validate(Gzip))
+	assert.NoError(t, Validate(Zstd))
+	assert.Error(t, Validate("snappy"))
+}
@@ -1,6 +1,9 @@
 package pb
 
 import (
+	"net"
+	"strconv"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -30,6 +33,18 @@ func (c *NetworkConfig) Validate() error {
 			return status.Errorf(codes.InvalidArgument, "invalid endpoint: %v", err)
 		}
 	}
+	for _, ep := range c.DnsEndpoints {
+		host, port, err := net.SplitHostPort(ep)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid DNS endpoint %q: %v", ep, err)
+		}
+		if host == "" {
+			return status.Errorf(codes.InvalidArgument, "invalid DNS endpoint %q: empty host", ep)
+		}
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return status.Errorf(codes.InvalidArgument, "invalid DNS endpoint %q: invalid port %q", ep, port)
+		}
+	}
 	if c.PublicKey == nil {
 		return status.Error(codes.InvalidArgument, "public key not set")
 	}
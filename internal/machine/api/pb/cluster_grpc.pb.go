@@ -20,28 +20,169 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Cluster_AddMachine_FullMethodName          = "/api.Cluster/AddMachine"
-	Cluster_ListMachines_FullMethodName        = "/api.Cluster/ListMachines"
-	Cluster_UpdateMachine_FullMethodName       = "/api.Cluster/UpdateMachine"
-	Cluster_RemoveMachine_FullMethodName       = "/api.Cluster/RemoveMachine"
-	Cluster_ReserveDomain_FullMethodName       = "/api.Cluster/ReserveDomain"
-	Cluster_GetDomain_FullMethodName           = "/api.Cluster/GetDomain"
-	Cluster_ReleaseDomain_FullMethodName       = "/api.Cluster/ReleaseDomain"
-	Cluster_CreateDomainRecords_FullMethodName = "/api.Cluster/CreateDomainRecords"
+	Cluster_GetCluster_FullMethodName            = "/api.Cluster/GetCluster"
+	Cluster_AddMachine_FullMethodName            = "/api.Cluster/AddMachine"
+	Cluster_ListMachines_FullMethodName          = "/api.Cluster/ListMachines"
+	Cluster_UpdateMachine_FullMethodName         = "/api.Cluster/UpdateMachine"
+	Cluster_RemoveMachine_FullMethodName         = "/api.Cluster/RemoveMachine"
+	Cluster_ReserveDomain_FullMethodName         = "/api.Cluster/ReserveDomain"
+	Cluster_GetDomain_FullMethodName             = "/api.Cluster/GetDomain"
+	Cluster_ReleaseDomain_FullMethodName         = "/api.Cluster/ReleaseDomain"
+	Cluster_CreateDomainRecords_FullMethodName   = "/api.Cluster/CreateDomainRecords"
+	Cluster_ListUptimeChecks_FullMethodName      = "/api.Cluster/ListUptimeChecks"
+	Cluster_GetAutoUpdate_FullMethodName         = "/api.Cluster/GetAutoUpdate"
+	Cluster_SetAutoUpdate_FullMethodName         = "/api.Cluster/SetAutoUpdate"
+	Cluster_GetBackupStorage_FullMethodName      = "/api.Cluster/GetBackupStorage"
+	Cluster_SetBackupStorage_FullMethodName      = "/api.Cluster/SetBackupStorage"
+	Cluster_ListBackups_FullMethodName           = "/api.Cluster/ListBackups"
+	Cluster_DownloadBackup_FullMethodName        = "/api.Cluster/DownloadBackup"
+	Cluster_GetServiceRevision_FullMethodName    = "/api.Cluster/GetServiceRevision"
+	Cluster_SetServiceRevision_FullMethodName    = "/api.Cluster/SetServiceRevision"
+	Cluster_GetObjectStorage_FullMethodName      = "/api.Cluster/GetObjectStorage"
+	Cluster_SetObjectStorage_FullMethodName      = "/api.Cluster/SetObjectStorage"
+	Cluster_ListPostgresClusters_FullMethodName  = "/api.Cluster/ListPostgresClusters"
+	Cluster_SetPostgresCluster_FullMethodName    = "/api.Cluster/SetPostgresCluster"
+	Cluster_RemovePostgresCluster_FullMethodName = "/api.Cluster/RemovePostgresCluster"
+	Cluster_ListTrashedServices_FullMethodName   = "/api.Cluster/ListTrashedServices"
+	Cluster_SetTrashedService_FullMethodName     = "/api.Cluster/SetTrashedService"
+	Cluster_RemoveTrashedService_FullMethodName  = "/api.Cluster/RemoveTrashedService"
+	Cluster_GetSettings_FullMethodName           = "/api.Cluster/GetSettings"
+	Cluster_SetSettings_FullMethodName           = "/api.Cluster/SetSettings"
+	Cluster_ListIPReservations_FullMethodName    = "/api.Cluster/ListIPReservations"
+	Cluster_ReserveIPRange_FullMethodName        = "/api.Cluster/ReserveIPRange"
+	Cluster_ReleaseIPRange_FullMethodName        = "/api.Cluster/ReleaseIPRange"
+	Cluster_GetNetworkMigration_FullMethodName   = "/api.Cluster/GetNetworkMigration"
+	Cluster_SetNetworkMigration_FullMethodName   = "/api.Cluster/SetNetworkMigration"
+	Cluster_ListClusterPeerings_FullMethodName   = "/api.Cluster/ListClusterPeerings"
+	Cluster_SetClusterPeering_FullMethodName     = "/api.Cluster/SetClusterPeering"
+	Cluster_RemoveClusterPeering_FullMethodName  = "/api.Cluster/RemoveClusterPeering"
+	Cluster_ListTenants_FullMethodName           = "/api.Cluster/ListTenants"
+	Cluster_SetTenant_FullMethodName             = "/api.Cluster/SetTenant"
+	Cluster_RemoveTenant_FullMethodName          = "/api.Cluster/RemoveTenant"
+	Cluster_ListProjectQuotas_FullMethodName     = "/api.Cluster/ListProjectQuotas"
+	Cluster_SetProjectQuota_FullMethodName       = "/api.Cluster/SetProjectQuota"
+	Cluster_RemoveProjectQuota_FullMethodName    = "/api.Cluster/RemoveProjectQuota"
+	Cluster_RecordPreemption_FullMethodName      = "/api.Cluster/RecordPreemption"
+	Cluster_ListPreemptions_FullMethodName       = "/api.Cluster/ListPreemptions"
+	Cluster_GetDeployFreeze_FullMethodName       = "/api.Cluster/GetDeployFreeze"
+	Cluster_SetDeployFreeze_FullMethodName       = "/api.Cluster/SetDeployFreeze"
+	Cluster_RemoveDeployFreeze_FullMethodName    = "/api.Cluster/RemoveDeployFreeze"
+	Cluster_RecordFreezeOverride_FullMethodName  = "/api.Cluster/RecordFreezeOverride"
+	Cluster_ListFreezeOverrides_FullMethodName   = "/api.Cluster/ListFreezeOverrides"
+	Cluster_CreateDeployRequest_FullMethodName   = "/api.Cluster/CreateDeployRequest"
+	Cluster_ListDeployRequests_FullMethodName    = "/api.Cluster/ListDeployRequests"
+	Cluster_ReviewDeployRequest_FullMethodName   = "/api.Cluster/ReviewDeployRequest"
 )
 
 // ClusterClient is the client API for Cluster service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ClusterClient interface {
+	// GetCluster returns the information about the cluster such as its ID.
+	GetCluster(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterInfo, error)
 	AddMachine(ctx context.Context, in *AddMachineRequest, opts ...grpc.CallOption) (*AddMachineResponse, error)
-	ListMachines(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListMachinesResponse, error)
+	// ListMachines lists the machines in the cluster matching the filters in the request sorted by name.
+	ListMachines(ctx context.Context, in *ListMachinesRequest, opts ...grpc.CallOption) (*ListMachinesResponse, error)
 	UpdateMachine(ctx context.Context, in *UpdateMachineRequest, opts ...grpc.CallOption) (*UpdateMachineResponse, error)
 	RemoveMachine(ctx context.Context, in *RemoveMachineRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ReserveDomain(ctx context.Context, in *ReserveDomainRequest, opts ...grpc.CallOption) (*Domain, error)
 	GetDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error)
 	ReleaseDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error)
 	CreateDomainRecords(ctx context.Context, in *CreateDomainRecordsRequest, opts ...grpc.CallOption) (*CreateDomainRecordsResponse, error)
+	// ListUptimeChecks lists the results of the periodic uptime checks of the published service endpoints.
+	ListUptimeChecks(ctx context.Context, in *ListUptimeChecksRequest, opts ...grpc.CallOption) (*ListUptimeChecksResponse, error)
+	// GetAutoUpdate returns the automatic OS updates configuration and the update statuses of the machines.
+	GetAutoUpdate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AutoUpdate, error)
+	// SetAutoUpdate enables or disables automatic OS updates.
+	SetAutoUpdate(ctx context.Context, in *AutoUpdateConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetBackupStorage returns the configuration of the S3-compatible object storage for service backups without
+	// the secret access key.
+	GetBackupStorage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BackupStorage, error)
+	// SetBackupStorage configures the S3-compatible object storage for service backups.
+	SetBackupStorage(ctx context.Context, in *BackupStorage, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListBackups lists the backups of a service in the backup storage. The machine accesses the storage so that
+	// the storage credentials aren't exposed to the clients.
+	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
+	// DownloadBackup streams the content of a backup from the backup storage.
+	DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BackupChunk], error)
+	// GetServiceRevision returns the state of a service before its last deployment.
+	GetServiceRevision(ctx context.Context, in *GetServiceRevisionRequest, opts ...grpc.CallOption) (*ServiceRevision, error)
+	// SetServiceRevision records the state of a service before a deployment so it can be rolled back.
+	SetServiceRevision(ctx context.Context, in *ServiceRevision, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetObjectStorage returns the configuration of the managed S3-compatible object storage.
+	GetObjectStorage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ObjectStorage, error)
+	// SetObjectStorage stores the configuration of the managed S3-compatible object storage.
+	SetObjectStorage(ctx context.Context, in *ObjectStorage, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListPostgresClusters lists the managed Postgres clusters.
+	ListPostgresClusters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PostgresClusters, error)
+	// SetPostgresCluster creates or updates a managed Postgres cluster and points its DNS name to the primary.
+	SetPostgresCluster(ctx context.Context, in *PostgresCluster, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RemovePostgresCluster removes a managed Postgres cluster and its DNS name. Its service must be removed separately.
+	RemovePostgresCluster(ctx context.Context, in *RemovePostgresClusterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListTrashedServices lists the removed services kept in the trash that can be restored.
+	ListTrashedServices(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TrashedServices, error)
+	// SetTrashedService moves a service to the trash or updates its trash record. Its containers must be stopped
+	// separately.
+	SetTrashedService(ctx context.Context, in *TrashedService, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RemoveTrashedService removes a service from the trash, e.g. when it's restored.
+	RemoveTrashedService(ctx context.Context, in *RemoveTrashedServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetSettings returns the cluster-wide settings.
+	GetSettings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterSettings, error)
+	// SetSettings replaces the cluster-wide settings if their version matches the current one and returns the stored
+	// settings with the incremented version.
+	SetSettings(ctx context.Context, in *ClusterSettings, opts ...grpc.CallOption) (*ClusterSettings, error)
+	// ListIPReservations lists the IP ranges reserved in the cluster network that aren't allocated to machines.
+	ListIPReservations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IPReservations, error)
+	// ReserveIPRange reserves an IP range in the cluster network so it isn't allocated to new machines, e.g. to avoid
+	// conflicts with an existing VPN. The range must not overlap the subnets already allocated to machines.
+	ReserveIPRange(ctx context.Context, in *IPReservation, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ReleaseIPRange removes an IP range reservation so it can be allocated to new machines.
+	ReleaseIPRange(ctx context.Context, in *ReleaseIPRangeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetNetworkMigration returns the cluster network migration in progress.
+	GetNetworkMigration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NetworkMigration, error)
+	// SetNetworkMigration starts or updates the cluster network migration. Setting a completed migration changes
+	// the cluster network to the new one, setting a completed or rolled back migration removes it.
+	SetNetworkMigration(ctx context.Context, in *NetworkMigration, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListClusterPeerings lists the other clusters peered with the cluster.
+	ListClusterPeerings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterPeerings, error)
+	// SetClusterPeering creates or updates the peering with another cluster. The machines of the cluster are
+	// configured as WireGuard peers of the machines of the peer cluster to route the allowed IPs between them.
+	SetClusterPeering(ctx context.Context, in *ClusterPeering, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RemoveClusterPeering removes the peering with another cluster.
+	RemoveClusterPeering(ctx context.Context, in *RemoveClusterPeeringRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListTenants lists the tenants hosted on the cluster.
+	ListTenants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Tenants, error)
+	// SetTenant creates or updates a tenant with its quota and API tokens.
+	SetTenant(ctx context.Context, in *Tenant, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RemoveTenant removes a tenant that has no services.
+	RemoveTenant(ctx context.Context, in *RemoveTenantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListProjectQuotas lists the resource quotas of the projects.
+	ListProjectQuotas(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProjectQuotas, error)
+	// SetProjectQuota creates or replaces the resource quota of a project.
+	SetProjectQuota(ctx context.Context, in *ProjectQuota, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RemoveProjectQuota removes the resource quota of a project.
+	RemoveProjectQuota(ctx context.Context, in *RemoveProjectQuotaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RecordPreemption records a container preempted by a container of a higher priority service.
+	RecordPreemption(ctx context.Context, in *Preemption, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListPreemptions lists the recent preemptions of containers.
+	ListPreemptions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Preemptions, error)
+	// GetDeployFreeze returns the deploy freeze of the cluster.
+	GetDeployFreeze(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeployFreeze, error)
+	// SetDeployFreeze freezes or extends the freeze of the mutating deploy operations in the cluster.
+	SetDeployFreeze(ctx context.Context, in *DeployFreeze, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RemoveDeployFreeze lifts the deploy freeze of the cluster.
+	RemoveDeployFreeze(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RecordFreezeOverride records a deploy operation that overrode the deploy freeze in the audit log.
+	RecordFreezeOverride(ctx context.Context, in *FreezeOverride, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListFreezeOverrides lists the deploy operations that overrode a deploy freeze.
+	ListFreezeOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FreezeOverrides, error)
+	// CreateDeployRequest stores a deployment that waits for another operator to approve it and returns the request
+	// with the generated ID.
+	CreateDeployRequest(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployRequest, error)
+	// ListDeployRequests lists the pending and recently reviewed deploy requests.
+	ListDeployRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeployRequests, error)
+	// ReviewDeployRequest approves or rejects a pending deploy request.
+	ReviewDeployRequest(ctx context.Context, in *ReviewDeployRequestRequest, opts ...grpc.CallOption) (*DeployRequest, error)
 }
 
 type clusterClient struct {
@@ -52,6 +193,16 @@ func NewClusterClient(cc grpc.ClientConnInterface) ClusterClient {
 	return &clusterClient{cc}
 }
 
+func (c *clusterClient) GetCluster(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterInfo)
+	err := c.cc.Invoke(ctx, Cluster_GetCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *clusterClient) AddMachine(ctx context.Context, in *AddMachineRequest, opts ...grpc.CallOption) (*AddMachineResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddMachineResponse)
@@ -62,7 +213,7 @@ func (c *clusterClient) AddMachine(ctx context.Context, in *AddMachineRequest, o
 	return out, nil
 }
 
-func (c *clusterClient) ListMachines(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListMachinesResponse, error) {
+func (c *clusterClient) ListMachines(ctx context.Context, in *ListMachinesRequest, opts ...grpc.CallOption) (*ListMachinesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListMachinesResponse)
 	err := c.cc.Invoke(ctx, Cluster_ListMachines_FullMethodName, in, out, cOpts...)
@@ -89,256 +240,1709 @@ func (c *clusterClient) RemoveMachine(ctx context.Context, in *RemoveMachineRequ
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *clusterClient) ReserveDomain(ctx context.Context, in *ReserveDomainRequest, opts ...grpc.CallOption) (*Domain, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Domain)
+	err := c.cc.Invoke(ctx, Cluster_ReserveDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Domain)
+	err := c.cc.Invoke(ctx, Cluster_GetDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ReleaseDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Domain)
+	err := c.cc.Invoke(ctx, Cluster_ReleaseDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) CreateDomainRecords(ctx context.Context, in *CreateDomainRecordsRequest, opts ...grpc.CallOption) (*CreateDomainRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateDomainRecordsResponse)
+	err := c.cc.Invoke(ctx, Cluster_CreateDomainRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListUptimeChecks(ctx context.Context, in *ListUptimeChecksRequest, opts ...grpc.CallOption) (*ListUptimeChecksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUptimeChecksResponse)
+	err := c.cc.Invoke(ctx, Cluster_ListUptimeChecks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetAutoUpdate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AutoUpdate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutoUpdate)
+	err := c.cc.Invoke(ctx, Cluster_GetAutoUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetAutoUpdate(ctx context.Context, in *AutoUpdateConfig, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetAutoUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetBackupStorage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BackupStorage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackupStorage)
+	err := c.cc.Invoke(ctx, Cluster_GetBackupStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetBackupStorage(ctx context.Context, in *BackupStorage, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetBackupStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, Cluster_ListBackups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BackupChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Cluster_ServiceDesc.Streams[0], Cluster_DownloadBackup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadBackupRequest, BackupChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Cluster_DownloadBackupClient = grpc.ServerStreamingClient[BackupChunk]
+
+func (c *clusterClient) GetServiceRevision(ctx context.Context, in *GetServiceRevisionRequest, opts ...grpc.CallOption) (*ServiceRevision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceRevision)
+	err := c.cc.Invoke(ctx, Cluster_GetServiceRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetServiceRevision(ctx context.Context, in *ServiceRevision, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetServiceRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetObjectStorage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ObjectStorage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ObjectStorage)
+	err := c.cc.Invoke(ctx, Cluster_GetObjectStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetObjectStorage(ctx context.Context, in *ObjectStorage, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetObjectStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListPostgresClusters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PostgresClusters, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostgresClusters)
+	err := c.cc.Invoke(ctx, Cluster_ListPostgresClusters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetPostgresCluster(ctx context.Context, in *PostgresCluster, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetPostgresCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemovePostgresCluster(ctx context.Context, in *RemovePostgresClusterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RemovePostgresCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListTrashedServices(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TrashedServices, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrashedServices)
+	err := c.cc.Invoke(ctx, Cluster_ListTrashedServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetTrashedService(ctx context.Context, in *TrashedService, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetTrashedService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemoveTrashedService(ctx context.Context, in *RemoveTrashedServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RemoveTrashedService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetSettings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterSettings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterSettings)
+	err := c.cc.Invoke(ctx, Cluster_GetSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetSettings(ctx context.Context, in *ClusterSettings, opts ...grpc.CallOption) (*ClusterSettings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterSettings)
+	err := c.cc.Invoke(ctx, Cluster_SetSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListIPReservations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IPReservations, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IPReservations)
+	err := c.cc.Invoke(ctx, Cluster_ListIPReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ReserveIPRange(ctx context.Context, in *IPReservation, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_ReserveIPRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ReleaseIPRange(ctx context.Context, in *ReleaseIPRangeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_ReleaseIPRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetNetworkMigration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NetworkMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkMigration)
+	err := c.cc.Invoke(ctx, Cluster_GetNetworkMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetNetworkMigration(ctx context.Context, in *NetworkMigration, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetNetworkMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListClusterPeerings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterPeerings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterPeerings)
+	err := c.cc.Invoke(ctx, Cluster_ListClusterPeerings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetClusterPeering(ctx context.Context, in *ClusterPeering, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetClusterPeering_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemoveClusterPeering(ctx context.Context, in *RemoveClusterPeeringRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RemoveClusterPeering_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListTenants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Tenants, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenants)
+	err := c.cc.Invoke(ctx, Cluster_ListTenants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetTenant(ctx context.Context, in *Tenant, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemoveTenant(ctx context.Context, in *RemoveTenantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RemoveTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListProjectQuotas(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProjectQuotas, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectQuotas)
+	err := c.cc.Invoke(ctx, Cluster_ListProjectQuotas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetProjectQuota(ctx context.Context, in *ProjectQuota, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetProjectQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemoveProjectQuota(ctx context.Context, in *RemoveProjectQuotaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RemoveProjectQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RecordPreemption(ctx context.Context, in *Preemption, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RecordPreemption_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListPreemptions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Preemptions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preemptions)
+	err := c.cc.Invoke(ctx, Cluster_ListPreemptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetDeployFreeze(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeployFreeze, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployFreeze)
+	err := c.cc.Invoke(ctx, Cluster_GetDeployFreeze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetDeployFreeze(ctx context.Context, in *DeployFreeze, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetDeployFreeze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemoveDeployFreeze(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RemoveDeployFreeze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RecordFreezeOverride(ctx context.Context, in *FreezeOverride, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RecordFreezeOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListFreezeOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FreezeOverrides, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreezeOverrides)
+	err := c.cc.Invoke(ctx, Cluster_ListFreezeOverrides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) CreateDeployRequest(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployRequest)
+	err := c.cc.Invoke(ctx, Cluster_CreateDeployRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListDeployRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeployRequests, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployRequests)
+	err := c.cc.Invoke(ctx, Cluster_ListDeployRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ReviewDeployRequest(ctx context.Context, in *ReviewDeployRequestRequest, opts ...grpc.CallOption) (*DeployRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployRequest)
+	err := c.cc.Invoke(ctx, Cluster_ReviewDeployRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClusterServer is the server API for Cluster service.
+// All implementations must embed UnimplementedClusterServer
+// for forward compatibility.
+type ClusterServer interface {
+	// GetCluster returns the information about the cluster such as its ID.
+	GetCluster(context.Context, *emptypb.Empty) (*ClusterInfo, error)
+	AddMachine(context.Context, *AddMachineRequest) (*AddMachineResponse, error)
+	// ListMachines lists the machines in the cluster matching the filters in the request sorted by name.
+	ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error)
+	UpdateMachine(context.Context, *UpdateMachineRequest) (*UpdateMachineResponse, error)
+	RemoveMachine(context.Context, *RemoveMachineRequest) (*emptypb.Empty, error)
+	ReserveDomain(context.Context, *ReserveDomainRequest) (*Domain, error)
+	GetDomain(context.Context, *emptypb.Empty) (*Domain, error)
+	ReleaseDomain(context.Context, *emptypb.Empty) (*Domain, error)
+	CreateDomainRecords(context.Context, *CreateDomainRecordsRequest) (*CreateDomainRecordsResponse, error)
+	// ListUptimeChecks lists the results of the periodic uptime checks of the published service endpoints.
+	ListUptimeChecks(context.Context, *ListUptimeChecksRequest) (*ListUptimeChecksResponse, error)
+	// GetAutoUpdate returns the automatic OS updates configuration and the update statuses of the machines.
+	GetAutoUpdate(context.Context, *emptypb.Empty) (*AutoUpdate, error)
+	// SetAutoUpdate enables or disables automatic OS updates.
+	SetAutoUpdate(context.Context, *AutoUpdateConfig) (*emptypb.Empty, error)
+	// GetBackupStorage returns the configuration of the S3-compatible object storage for service backups without
+	// the secret access key.
+	GetBackupStorage(context.Context, *emptypb.Empty) (*BackupStorage, error)
+	// SetBackupStorage configures the S3-compatible object storage for service backups.
+	SetBackupStorage(context.Context, *BackupStorage) (*emptypb.Empty, error)
+	// ListBackups lists the backups of a service in the backup storage. The machine accesses the storage so that
+	// the storage credentials aren't exposed to the clients.
+	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
+	// DownloadBackup streams the content of a backup from the backup storage.
+	DownloadBackup(*DownloadBackupRequest, grpc.ServerStreamingServer[BackupChunk]) error
+	// GetServiceRevision returns the state of a service before its last deployment.
+	GetServiceRevision(context.Context, *GetServiceRevisionRequest) (*ServiceRevision, error)
+	// SetServiceRevision records the state of a service before a deployment so it can be rolled back.
+	SetServiceRevision(context.Context, *ServiceRevision) (*emptypb.Empty, error)
+	// GetObjectStorage returns the configuration of the managed S3-compatible object storage.
+	GetObjectStorage(context.Context, *emptypb.Empty) (*ObjectStorage, error)
+	// SetObjectStorage stores the configuration of the managed S3-compatible object storage.
+	SetObjectStorage(context.Context, *ObjectStorage) (*emptypb.Empty, error)
+	// ListPostgresClusters lists the managed Postgres clusters.
+	ListPostgresClusters(context.Context, *emptypb.Empty) (*PostgresClusters, error)
+	// SetPostgresCluster creates or updates a managed Postgres cluster and points its DNS name to the primary.
+	SetPostgresCluster(context.Context, *PostgresCluster) (*emptypb.Empty, error)
+	// RemovePostgresCluster removes a managed Postgres cluster and its DNS name. Its service must be removed separately.
+	RemovePostgresCluster(context.Context, *RemovePostgresClusterRequest) (*emptypb.Empty, error)
+	// ListTrashedServices lists the removed services kept in the trash that can be restored.
+	ListTrashedServices(context.Context, *emptypb.Empty) (*TrashedServices, error)
+	// SetTrashedService moves a service to the trash or updates its trash record. Its containers must be stopped
+	// separately.
+	SetTrashedService(context.Context, *TrashedService) (*emptypb.Empty, error)
+	// RemoveTrashedService removes a service from the trash, e.g. when it's restored.
+	RemoveTrashedService(context.Context, *RemoveTrashedServiceRequest) (*emptypb.Empty, error)
+	// GetSettings returns the cluster-wide settings.
+	GetSettings(context.Context, *emptypb.Empty) (*ClusterSettings, error)
+	// SetSettings replaces the cluster-wide settings if their version matches the current one and returns the stored
+	// settings with the incremented version.
+	SetSettings(context.Context, *ClusterSettings) (*ClusterSettings, error)
+	// ListIPReservations lists the IP ranges reserved in the cluster network that aren't allocated to machines.
+	ListIPReservations(context.Context, *emptypb.Empty) (*IPReservations, error)
+	// ReserveIPRange reserves an IP range in the cluster network so it isn't allocated to new machines, e.g. to avoid
+	// conflicts with an existing VPN. The range must not overlap the subnets already allocated to machines.
+	ReserveIPRange(context.Context, *IPReservation) (*emptypb.Empty, error)
+	// ReleaseIPRange removes an IP range reservation so it can be allocated to new machines.
+	ReleaseIPRange(context.Context, *ReleaseIPRangeRequest) (*emptypb.Empty, error)
+	// GetNetworkMigration returns the cluster network migration in progress.
+	GetNetworkMigration(context.Context, *emptypb.Empty) (*NetworkMigration, error)
+	// SetNetworkMigration starts or updates the cluster network migration. Setting a completed migration changes
+	// the cluster network to the new one, setting a completed or rolled back migration removes it.
+	SetNetworkMigration(context.Context, *NetworkMigration) (*emptypb.Empty, error)
+	// ListClusterPeerings lists the other clusters peered with the cluster.
+	ListClusterPeerings(context.Context, *emptypb.Empty) (*ClusterPeerings, error)
+	// SetClusterPeering creates or updates the peering with another cluster. The machines of the cluster are
+	// configured as WireGuard peers of the machines of the peer cluster to route the allowed IPs between them.
+	SetClusterPeering(context.Context, *ClusterPeering) (*emptypb.Empty, error)
+	// RemoveClusterPeering removes the peering with another cluster.
+	RemoveClusterPeering(context.Context, *RemoveClusterPeeringRequest) (*emptypb.Empty, error)
+	// ListTenants lists the tenants hosted on the cluster.
+	ListTenants(context.Context, *emptypb.Empty) (*Tenants, error)
+	// SetTenant creates or updates a tenant with its quota and API tokens.
+	SetTenant(context.Context, *Tenant) (*emptypb.Empty, error)
+	// RemoveTenant removes a tenant that has no services.
+	RemoveTenant(context.Context, *RemoveTenantRequest) (*emptypb.Empty, error)
+	// ListProjectQuotas lists the resource quotas of the projects.
+	ListProjectQuotas(context.Context, *emptypb.Empty) (*ProjectQuotas, error)
+	// SetProjectQuota creates or replaces the resource quota of a project.
+	SetProjectQuota(context.Context, *ProjectQuota) (*emptypb.Empty, error)
+	// RemoveProjectQuota removes the resource quota of a project.
+	RemoveProjectQuota(context.Context, *RemoveProjectQuotaRequest) (*emptypb.Empty, error)
+	// RecordPreemption records a container preempted by a container of a higher priority service.
+	RecordPreemption(context.Context, *Preemption) (*emptypb.Empty, error)
+	// ListPreemptions lists the recent preemptions of containers.
+	ListPreemptions(context.Context, *emptypb.Empty) (*Preemptions, error)
+	// GetDeployFreeze returns the deploy freeze of the cluster.
+	GetDeployFreeze(context.Context, *emptypb.Empty) (*DeployFreeze, error)
+	// SetDeployFreeze freezes or extends the freeze of the mutating deploy operations in the cluster.
+	SetDeployFreeze(context.Context, *DeployFreeze) (*emptypb.Empty, error)
+	// RemoveDeployFreeze lifts the deploy freeze of the cluster.
+	RemoveDeployFreeze(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// RecordFreezeOverride records a deploy operation that overrode the deploy freeze in the audit log.
+	RecordFreezeOverride(context.Context, *FreezeOverride) (*emptypb.Empty, error)
+	// ListFreezeOverrides lists the deploy operations that overrode a deploy freeze.
+	ListFreezeOverrides(context.Context, *emptypb.Empty) (*FreezeOverrides, error)
+	// CreateDeployRequest stores a deployment that waits for another operator to approve it and returns the request
+	// with the generated ID.
+	CreateDeployRequest(context.Context, *DeployRequest) (*DeployRequest, error)
+	// ListDeployRequests lists the pending and recently reviewed deploy requests.
+	ListDeployRequests(context.Context, *emptypb.Empty) (*DeployRequests, error)
+	// ReviewDeployRequest approves or rejects a pending deploy request.
+	ReviewDeployRequest(context.Context, *ReviewDeployRequestRequest) (*DeployRequest, error)
+	mustEmbedUnimplementedClusterServer()
+}
+
+// UnimplementedClusterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClusterServer struct{}
+
+func (UnimplementedClusterServer) GetCluster(context.Context, *emptypb.Empty) (*ClusterInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCluster not implemented")
+}
+func (UnimplementedClusterServer) AddMachine(context.Context, *AddMachineRequest) (*AddMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMachine not implemented")
+}
+func (UnimplementedClusterServer) ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMachines not implemented")
+}
+func (UnimplementedClusterServer) UpdateMachine(context.Context, *UpdateMachineRequest) (*UpdateMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMachine not implemented")
+}
+func (UnimplementedClusterServer) RemoveMachine(context.Context, *RemoveMachineRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMachine not implemented")
+}
+func (UnimplementedClusterServer) ReserveDomain(context.Context, *ReserveDomainRequest) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveDomain not implemented")
+}
+func (UnimplementedClusterServer) GetDomain(context.Context, *emptypb.Empty) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDomain not implemented")
+}
+func (UnimplementedClusterServer) ReleaseDomain(context.Context, *emptypb.Empty) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDomain not implemented")
+}
+func (UnimplementedClusterServer) CreateDomainRecords(context.Context, *CreateDomainRecordsRequest) (*CreateDomainRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDomainRecords not implemented")
+}
+func (UnimplementedClusterServer) ListUptimeChecks(context.Context, *ListUptimeChecksRequest) (*ListUptimeChecksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUptimeChecks not implemented")
+}
+func (UnimplementedClusterServer) GetAutoUpdate(context.Context, *emptypb.Empty) (*AutoUpdate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAutoUpdate not implemented")
+}
+func (UnimplementedClusterServer) SetAutoUpdate(context.Context, *AutoUpdateConfig) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutoUpdate not implemented")
+}
+func (UnimplementedClusterServer) GetBackupStorage(context.Context, *emptypb.Empty) (*BackupStorage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackupStorage not implemented")
+}
+func (UnimplementedClusterServer) SetBackupStorage(context.Context, *BackupStorage) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBackupStorage not implemented")
+}
+func (UnimplementedClusterServer) ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackups not implemented")
+}
+func (UnimplementedClusterServer) DownloadBackup(*DownloadBackupRequest, grpc.ServerStreamingServer[BackupChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadBackup not implemented")
+}
+func (UnimplementedClusterServer) GetServiceRevision(context.Context, *GetServiceRevisionRequest) (*ServiceRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceRevision not implemented")
+}
+func (UnimplementedClusterServer) SetServiceRevision(context.Context, *ServiceRevision) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServiceRevision not implemented")
+}
+func (UnimplementedClusterServer) GetObjectStorage(context.Context, *emptypb.Empty) (*ObjectStorage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjectStorage not implemented")
+}
+func (UnimplementedClusterServer) SetObjectStorage(context.Context, *ObjectStorage) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetObjectStorage not implemented")
+}
+func (UnimplementedClusterServer) ListPostgresClusters(context.Context, *emptypb.Empty) (*PostgresClusters, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPostgresClusters not implemented")
+}
+func (UnimplementedClusterServer) SetPostgresCluster(context.Context, *PostgresCluster) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPostgresCluster not implemented")
+}
+func (UnimplementedClusterServer) RemovePostgresCluster(context.Context, *RemovePostgresClusterRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePostgresCluster not implemented")
+}
+func (UnimplementedClusterServer) ListTrashedServices(context.Context, *emptypb.Empty) (*TrashedServices, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrashedServices not implemented")
+}
+func (UnimplementedClusterServer) SetTrashedService(context.Context, *TrashedService) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTrashedService not implemented")
+}
+func (UnimplementedClusterServer) RemoveTrashedService(context.Context, *RemoveTrashedServiceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTrashedService not implemented")
+}
+func (UnimplementedClusterServer) GetSettings(context.Context, *emptypb.Empty) (*ClusterSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettings not implemented")
+}
+func (UnimplementedClusterServer) SetSettings(context.Context, *ClusterSettings) (*ClusterSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSettings not implemented")
+}
+func (UnimplementedClusterServer) ListIPReservations(context.Context, *emptypb.Empty) (*IPReservations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIPReservations not implemented")
+}
+func (UnimplementedClusterServer) ReserveIPRange(context.Context, *IPReservation) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveIPRange not implemented")
+}
+func (UnimplementedClusterServer) ReleaseIPRange(context.Context, *ReleaseIPRangeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseIPRange not implemented")
+}
+func (UnimplementedClusterServer) GetNetworkMigration(context.Context, *emptypb.Empty) (*NetworkMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkMigration not implemented")
+}
+func (UnimplementedClusterServer) SetNetworkMigration(context.Context, *NetworkMigration) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNetworkMigration not implemented")
+}
+func (UnimplementedClusterServer) ListClusterPeerings(context.Context, *emptypb.Empty) (*ClusterPeerings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClusterPeerings not implemented")
+}
+func (UnimplementedClusterServer) SetClusterPeering(context.Context, *ClusterPeering) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClusterPeering not implemented")
+}
+func (UnimplementedClusterServer) RemoveClusterPeering(context.Context, *RemoveClusterPeeringRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveClusterPeering not implemented")
+}
+func (UnimplementedClusterServer) ListTenants(context.Context, *emptypb.Empty) (*Tenants, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenants not implemented")
+}
+func (UnimplementedClusterServer) SetTenant(context.Context, *Tenant) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTenant not implemented")
+}
+func (UnimplementedClusterServer) RemoveTenant(context.Context, *RemoveTenantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTenant not implemented")
+}
+func (UnimplementedClusterServer) ListProjectQuotas(context.Context, *emptypb.Empty) (*ProjectQuotas, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjectQuotas not implemented")
+}
+func (UnimplementedClusterServer) SetProjectQuota(context.Context, *ProjectQuota) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectQuota not implemented")
+}
+func (UnimplementedClusterServer) RemoveProjectQuota(context.Context, *RemoveProjectQuotaRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveProjectQuota not implemented")
+}
+func (UnimplementedClusterServer) RecordPreemption(context.Context, *Preemption) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordPreemption not implemented")
+}
+func (UnimplementedClusterServer) ListPreemptions(context.Context, *emptypb.Empty) (*Preemptions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPreemptions not implemented")
+}
+func (UnimplementedClusterServer) GetDeployFreeze(context.Context, *emptypb.Empty) (*DeployFreeze, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeployFreeze not implemented")
+}
+func (UnimplementedClusterServer) SetDeployFreeze(context.Context, *DeployFreeze) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeployFreeze not implemented")
+}
+func (UnimplementedClusterServer) RemoveDeployFreeze(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDeployFreeze not implemented")
+}
+func (UnimplementedClusterServer) RecordFreezeOverride(context.Context, *FreezeOverride) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordFreezeOverride not implemented")
+}
+func (UnimplementedClusterServer) ListFreezeOverrides(context.Context, *emptypb.Empty) (*FreezeOverrides, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFreezeOverrides not implemented")
+}
+func (UnimplementedClusterServer) CreateDeployRequest(context.Context, *DeployRequest) (*DeployRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeployRequest not implemented")
+}
+func (UnimplementedClusterServer) ListDeployRequests(context.Context, *emptypb.Empty) (*DeployRequests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeployRequests not implemented")
+}
+func (UnimplementedClusterServer) ReviewDeployRequest(context.Context, *ReviewDeployRequestRequest) (*DeployRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewDeployRequest not implemented")
+}
+func (UnimplementedClusterServer) mustEmbedUnimplementedClusterServer() {}
+func (UnimplementedClusterServer) testEmbeddedByValue()                 {}
+
+// UnsafeClusterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClusterServer will
+// result in compilation errors.
+type UnsafeClusterServer interface {
+	mustEmbedUnimplementedClusterServer()
+}
+
+func RegisterClusterServer(s grpc.ServiceRegistrar, srv ClusterServer) {
+	// If the following call pancis, it indicates UnimplementedClusterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Cluster_ServiceDesc, srv)
+}
+
+func _Cluster_GetCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetCluster(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_AddMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).AddMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_AddMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).AddMachine(ctx, req.(*AddMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMachinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListMachines(ctx, req.(*ListMachinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_UpdateMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).UpdateMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_UpdateMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).UpdateMachine(ctx, req.(*UpdateMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemoveMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveMachine(ctx, req.(*RemoveMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReserveDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReserveDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReserveDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReserveDomain(ctx, req.(*ReserveDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetDomain(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReleaseDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReleaseDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReleaseDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReleaseDomain(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_CreateDomainRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDomainRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).CreateDomainRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_CreateDomainRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).CreateDomainRecords(ctx, req.(*CreateDomainRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListUptimeChecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUptimeChecksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListUptimeChecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListUptimeChecks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListUptimeChecks(ctx, req.(*ListUptimeChecksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetAutoUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetAutoUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetAutoUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetAutoUpdate(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetAutoUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutoUpdateConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetAutoUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetAutoUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetAutoUpdate(ctx, req.(*AutoUpdateConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetBackupStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetBackupStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetBackupStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetBackupStorage(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetBackupStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupStorage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetBackupStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetBackupStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetBackupStorage(ctx, req.(*BackupStorage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListBackups(ctx, req.(*ListBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_DownloadBackup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusterServer).DownloadBackup(m, &grpc.GenericServerStream[DownloadBackupRequest, BackupChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Cluster_DownloadBackupServer = grpc.ServerStreamingServer[BackupChunk]
+
+func _Cluster_GetServiceRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetServiceRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetServiceRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetServiceRevision(ctx, req.(*GetServiceRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetServiceRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRevision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetServiceRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetServiceRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetServiceRevision(ctx, req.(*ServiceRevision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetObjectStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetObjectStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetObjectStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetObjectStorage(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetObjectStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectStorage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetObjectStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetObjectStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetObjectStorage(ctx, req.(*ObjectStorage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListPostgresClusters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListPostgresClusters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListPostgresClusters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListPostgresClusters(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetPostgresCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostgresCluster)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetPostgresCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetPostgresCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetPostgresCluster(ctx, req.(*PostgresCluster))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemovePostgresCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePostgresClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemovePostgresCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemovePostgresCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemovePostgresCluster(ctx, req.(*RemovePostgresClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListTrashedServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListTrashedServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListTrashedServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListTrashedServices(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetTrashedService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashedService)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetTrashedService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetTrashedService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetTrashedService(ctx, req.(*TrashedService))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemoveTrashedService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTrashedServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveTrashedService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveTrashedService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveTrashedService(ctx, req.(*RemoveTrashedServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetSettings(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterSettings)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetSettings(ctx, req.(*ClusterSettings))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListIPReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListIPReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListIPReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListIPReservations(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReserveIPRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IPReservation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReserveIPRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReserveIPRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReserveIPRange(ctx, req.(*IPReservation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReleaseIPRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseIPRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReleaseIPRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReleaseIPRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReleaseIPRange(ctx, req.(*ReleaseIPRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetNetworkMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetNetworkMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetNetworkMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetNetworkMigration(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetNetworkMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkMigration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetNetworkMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetNetworkMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetNetworkMigration(ctx, req.(*NetworkMigration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListClusterPeerings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListClusterPeerings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListClusterPeerings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListClusterPeerings(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetClusterPeering_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterPeering)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetClusterPeering(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetClusterPeering_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetClusterPeering(ctx, req.(*ClusterPeering))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemoveClusterPeering_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveClusterPeeringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveClusterPeering(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveClusterPeering_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveClusterPeering(ctx, req.(*RemoveClusterPeeringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListTenants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListTenants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListTenants(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tenant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetTenant(ctx, req.(*Tenant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemoveTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveTenant(ctx, req.(*RemoveTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListProjectQuotas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListProjectQuotas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListProjectQuotas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListProjectQuotas(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *clusterClient) ReserveDomain(ctx context.Context, in *ReserveDomainRequest, opts ...grpc.CallOption) (*Domain, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Domain)
-	err := c.cc.Invoke(ctx, Cluster_ReserveDomain_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Cluster_SetProjectQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectQuota)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ClusterServer).SetProjectQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetProjectQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetProjectQuota(ctx, req.(*ProjectQuota))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *clusterClient) GetDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Domain)
-	err := c.cc.Invoke(ctx, Cluster_GetDomain_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Cluster_RemoveProjectQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveProjectQuotaRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveProjectQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveProjectQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveProjectQuota(ctx, req.(*RemoveProjectQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *clusterClient) ReleaseDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Domain)
-	err := c.cc.Invoke(ctx, Cluster_ReleaseDomain_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Cluster_RecordPreemption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Preemption)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ClusterServer).RecordPreemption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RecordPreemption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RecordPreemption(ctx, req.(*Preemption))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *clusterClient) CreateDomainRecords(ctx context.Context, in *CreateDomainRecordsRequest, opts ...grpc.CallOption) (*CreateDomainRecordsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CreateDomainRecordsResponse)
-	err := c.cc.Invoke(ctx, Cluster_CreateDomainRecords_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Cluster_ListPreemptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// ClusterServer is the server API for Cluster service.
-// All implementations must embed UnimplementedClusterServer
-// for forward compatibility.
-type ClusterServer interface {
-	AddMachine(context.Context, *AddMachineRequest) (*AddMachineResponse, error)
-	ListMachines(context.Context, *emptypb.Empty) (*ListMachinesResponse, error)
-	UpdateMachine(context.Context, *UpdateMachineRequest) (*UpdateMachineResponse, error)
-	RemoveMachine(context.Context, *RemoveMachineRequest) (*emptypb.Empty, error)
-	ReserveDomain(context.Context, *ReserveDomainRequest) (*Domain, error)
-	GetDomain(context.Context, *emptypb.Empty) (*Domain, error)
-	ReleaseDomain(context.Context, *emptypb.Empty) (*Domain, error)
-	CreateDomainRecords(context.Context, *CreateDomainRecordsRequest) (*CreateDomainRecordsResponse, error)
-	mustEmbedUnimplementedClusterServer()
-}
-
-// UnimplementedClusterServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedClusterServer struct{}
-
-func (UnimplementedClusterServer) AddMachine(context.Context, *AddMachineRequest) (*AddMachineResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddMachine not implemented")
-}
-func (UnimplementedClusterServer) ListMachines(context.Context, *emptypb.Empty) (*ListMachinesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListMachines not implemented")
-}
-func (UnimplementedClusterServer) UpdateMachine(context.Context, *UpdateMachineRequest) (*UpdateMachineResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateMachine not implemented")
-}
-func (UnimplementedClusterServer) RemoveMachine(context.Context, *RemoveMachineRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveMachine not implemented")
-}
-func (UnimplementedClusterServer) ReserveDomain(context.Context, *ReserveDomainRequest) (*Domain, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReserveDomain not implemented")
-}
-func (UnimplementedClusterServer) GetDomain(context.Context, *emptypb.Empty) (*Domain, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDomain not implemented")
-}
-func (UnimplementedClusterServer) ReleaseDomain(context.Context, *emptypb.Empty) (*Domain, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDomain not implemented")
-}
-func (UnimplementedClusterServer) CreateDomainRecords(context.Context, *CreateDomainRecordsRequest) (*CreateDomainRecordsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDomainRecords not implemented")
-}
-func (UnimplementedClusterServer) mustEmbedUnimplementedClusterServer() {}
-func (UnimplementedClusterServer) testEmbeddedByValue()                 {}
-
-// UnsafeClusterServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ClusterServer will
-// result in compilation errors.
-type UnsafeClusterServer interface {
-	mustEmbedUnimplementedClusterServer()
-}
-
-func RegisterClusterServer(s grpc.ServiceRegistrar, srv ClusterServer) {
-	// If the following call pancis, it indicates UnimplementedClusterServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+	if interceptor == nil {
+		return srv.(ClusterServer).ListPreemptions(ctx, in)
 	}
-	s.RegisterService(&Cluster_ServiceDesc, srv)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListPreemptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListPreemptions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_AddMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddMachineRequest)
+func _Cluster_GetDeployFreeze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).AddMachine(ctx, in)
+		return srv.(ClusterServer).GetDeployFreeze(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_AddMachine_FullMethodName,
+		FullMethod: Cluster_GetDeployFreeze_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).AddMachine(ctx, req.(*AddMachineRequest))
+		return srv.(ClusterServer).GetDeployFreeze(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_ListMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Cluster_SetDeployFreeze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployFreeze)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).ListMachines(ctx, in)
+		return srv.(ClusterServer).SetDeployFreeze(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_ListMachines_FullMethodName,
+		FullMethod: Cluster_SetDeployFreeze_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).ListMachines(ctx, req.(*emptypb.Empty))
+		return srv.(ClusterServer).SetDeployFreeze(ctx, req.(*DeployFreeze))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_UpdateMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateMachineRequest)
+func _Cluster_RemoveDeployFreeze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).UpdateMachine(ctx, in)
+		return srv.(ClusterServer).RemoveDeployFreeze(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_UpdateMachine_FullMethodName,
+		FullMethod: Cluster_RemoveDeployFreeze_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).UpdateMachine(ctx, req.(*UpdateMachineRequest))
+		return srv.(ClusterServer).RemoveDeployFreeze(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_RemoveMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveMachineRequest)
+func _Cluster_RecordFreezeOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeOverride)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).RemoveMachine(ctx, in)
+		return srv.(ClusterServer).RecordFreezeOverride(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_RemoveMachine_FullMethodName,
+		FullMethod: Cluster_RecordFreezeOverride_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).RemoveMachine(ctx, req.(*RemoveMachineRequest))
+		return srv.(ClusterServer).RecordFreezeOverride(ctx, req.(*FreezeOverride))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_ReserveDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReserveDomainRequest)
+func _Cluster_ListFreezeOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).ReserveDomain(ctx, in)
+		return srv.(ClusterServer).ListFreezeOverrides(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_ReserveDomain_FullMethodName,
+		FullMethod: Cluster_ListFreezeOverrides_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).ReserveDomain(ctx, req.(*ReserveDomainRequest))
+		return srv.(ClusterServer).ListFreezeOverrides(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Cluster_CreateDeployRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).GetDomain(ctx, in)
+		return srv.(ClusterServer).CreateDeployRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_GetDomain_FullMethodName,
+		FullMethod: Cluster_CreateDeployRequest_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).GetDomain(ctx, req.(*emptypb.Empty))
+		return srv.(ClusterServer).CreateDeployRequest(ctx, req.(*DeployRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_ReleaseDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Cluster_ListDeployRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).ReleaseDomain(ctx, in)
+		return srv.(ClusterServer).ListDeployRequests(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_ReleaseDomain_FullMethodName,
+		FullMethod: Cluster_ListDeployRequests_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).ReleaseDomain(ctx, req.(*emptypb.Empty))
+		return srv.(ClusterServer).ListDeployRequests(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_CreateDomainRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDomainRecordsRequest)
+func _Cluster_ReviewDeployRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewDeployRequestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).CreateDomainRecords(ctx, in)
+		return srv.(ClusterServer).ReviewDeployRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_CreateDomainRecords_FullMethodName,
+		FullMethod: Cluster_ReviewDeployRequest_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).CreateDomainRecords(ctx, req.(*CreateDomainRecordsRequest))
+		return srv.(ClusterServer).ReviewDeployRequest(ctx, req.(*ReviewDeployRequestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -350,6 +1954,10 @@ var Cluster_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "api.Cluster",
 	HandlerType: (*ClusterServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCluster",
+			Handler:    _Cluster_GetCluster_Handler,
+		},
 		{
 			MethodName: "AddMachine",
 			Handler:    _Cluster_AddMachine_Handler,
@@ -382,7 +1990,181 @@ var Cluster_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateDomainRecords",
 			Handler:    _Cluster_CreateDomainRecords_Handler,
 		},
+		{
+			MethodName: "ListUptimeChecks",
+			Handler:    _Cluster_ListUptimeChecks_Handler,
+		},
+		{
+			MethodName: "GetAutoUpdate",
+			Handler:    _Cluster_GetAutoUpdate_Handler,
+		},
+		{
+			MethodName: "SetAutoUpdate",
+			Handler:    _Cluster_SetAutoUpdate_Handler,
+		},
+		{
+			MethodName: "GetBackupStorage",
+			Handler:    _Cluster_GetBackupStorage_Handler,
+		},
+		{
+			MethodName: "SetBackupStorage",
+			Handler:    _Cluster_SetBackupStorage_Handler,
+		},
+		{
+			MethodName: "ListBackups",
+			Handler:    _Cluster_ListBackups_Handler,
+		},
+		{
+			MethodName: "GetServiceRevision",
+			Handler:    _Cluster_GetServiceRevision_Handler,
+		},
+		{
+			MethodName: "SetServiceRevision",
+			Handler:    _Cluster_SetServiceRevision_Handler,
+		},
+		{
+			MethodName: "GetObjectStorage",
+			Handler:    _Cluster_GetObjectStorage_Handler,
+		},
+		{
+			MethodName: "SetObjectStorage",
+			Handler:    _Cluster_SetObjectStorage_Handler,
+		},
+		{
+			MethodName: "ListPostgresClusters",
+			Handler:    _Cluster_ListPostgresClusters_Handler,
+		},
+		{
+			MethodName: "SetPostgresCluster",
+			Handler:    _Cluster_SetPostgresCluster_Handler,
+		},
+		{
+			MethodName: "RemovePostgresCluster",
+			Handler:    _Cluster_RemovePostgresCluster_Handler,
+		},
+		{
+			MethodName: "ListTrashedServices",
+			Handler:    _Cluster_ListTrashedServices_Handler,
+		},
+		{
+			MethodName: "SetTrashedService",
+			Handler:    _Cluster_SetTrashedService_Handler,
+		},
+		{
+			MethodName: "RemoveTrashedService",
+			Handler:    _Cluster_RemoveTrashedService_Handler,
+		},
+		{
+			MethodName: "GetSettings",
+			Handler:    _Cluster_GetSettings_Handler,
+		},
+		{
+			MethodName: "SetSettings",
+			Handler:    _Cluster_SetSettings_Handler,
+		},
+		{
+			MethodName: "ListIPReservations",
+			Handler:    _Cluster_ListIPReservations_Handler,
+		},
+		{
+			MethodName: "ReserveIPRange",
+			Handler:    _Cluster_ReserveIPRange_Handler,
+		},
+		{
+			MethodName: "ReleaseIPRange",
+			Handler:    _Cluster_ReleaseIPRange_Handler,
+		},
+		{
+			MethodName: "GetNetworkMigration",
+			Handler:    _Cluster_GetNetworkMigration_Handler,
+		},
+		{
+			MethodName: "SetNetworkMigration",
+			Handler:    _Cluster_SetNetworkMigration_Handler,
+		},
+		{
+			MethodName: "ListClusterPeerings",
+			Handler:    _Cluster_ListClusterPeerings_Handler,
+		},
+		{
+			MethodName: "SetClusterPeering",
+			Handler:    _Cluster_SetClusterPeering_Handler,
+		},
+		{
+			MethodName: "RemoveClusterPeering",
+			Handler:    _Cluster_RemoveClusterPeering_Handler,
+		},
+		{
+			MethodName: "ListTenants",
+			Handler:    _Cluster_ListTenants_Handler,
+		},
+		{
+			MethodName: "SetTenant",
+			Handler:    _Cluster_SetTenant_Handler,
+		},
+		{
+			MethodName: "RemoveTenant",
+			Handler:    _Cluster_RemoveTenant_Handler,
+		},
+		{
+			MethodName: "ListProjectQuotas",
+			Handler:    _Cluster_ListProjectQuotas_Handler,
+		},
+		{
+			MethodName: "SetProjectQuota",
+			Handler:    _Cluster_SetProjectQuota_Handler,
+		},
+		{
+			MethodName: "RemoveProjectQuota",
+			Handler:    _Cluster_RemoveProjectQuota_Handler,
+		},
+		{
+			MethodName: "RecordPreemption",
+			Handler:    _Cluster_RecordPreemption_Handler,
+		},
+		{
+			MethodName: "ListPreemptions",
+			Handler:    _Cluster_ListPreemptions_Handler,
+		},
+		{
+			MethodName: "GetDeployFreeze",
+			Handler:    _Cluster_GetDeployFreeze_Handler,
+		},
+		{
+			MethodName: "SetDeployFreeze",
+			Handler:    _Cluster_SetDeployFreeze_Handler,
+		},
+		{
+			MethodName: "RemoveDeployFreeze",
+			Handler:    _Cluster_RemoveDeployFreeze_Handler,
+		},
+		{
+			MethodName: "RecordFreezeOverride",
+			Handler:    _Cluster_RecordFreezeOverride_Handler,
+		},
+		{
+			MethodName: "ListFreezeOverrides",
+			Handler:    _Cluster_ListFreezeOverrides_Handler,
+		},
+		{
+			MethodName: "CreateDeployRequest",
+			Handler:    _Cluster_CreateDeployRequest_Handler,
+		},
+		{
+			MethodName: "ListDeployRequests",
+			Handler:    _Cluster_ListDeployRequests_Handler,
+		},
+		{
+			MethodName: "ReviewDeployRequest",
+			Handler:    _Cluster_ReviewDeployRequest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadBackup",
+			Handler:       _Cluster_DownloadBackup_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/machine/api/pb/cluster.proto",
 }
@@ -20,22 +20,36 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Docker_CreateContainer_FullMethodName         = "/api.Docker/CreateContainer"
-	Docker_InspectContainer_FullMethodName        = "/api.Docker/InspectContainer"
-	Docker_StartContainer_FullMethodName          = "/api.Docker/StartContainer"
-	Docker_StopContainer_FullMethodName           = "/api.Docker/StopContainer"
-	Docker_ListContainers_FullMethodName          = "/api.Docker/ListContainers"
-	Docker_RemoveContainer_FullMethodName         = "/api.Docker/RemoveContainer"
-	Docker_PullImage_FullMethodName               = "/api.Docker/PullImage"
-	Docker_InspectImage_FullMethodName            = "/api.Docker/InspectImage"
-	Docker_InspectRemoteImage_FullMethodName      = "/api.Docker/InspectRemoteImage"
-	Docker_CreateVolume_FullMethodName            = "/api.Docker/CreateVolume"
-	Docker_ListVolumes_FullMethodName             = "/api.Docker/ListVolumes"
-	Docker_RemoveVolume_FullMethodName            = "/api.Docker/RemoveVolume"
-	Docker_CreateServiceContainer_FullMethodName  = "/api.Docker/CreateServiceContainer"
-	Docker_InspectServiceContainer_FullMethodName = "/api.Docker/InspectServiceContainer"
-	Docker_ListServiceContainers_FullMethodName   = "/api.Docker/ListServiceContainers"
-	Docker_RemoveServiceContainer_FullMethodName  = "/api.Docker/RemoveServiceContainer"
+	Docker_CreateContainer_FullMethodName            = "/api.Docker/CreateContainer"
+	Docker_InspectContainer_FullMethodName           = "/api.Docker/InspectContainer"
+	Docker_StartContainer_FullMethodName             = "/api.Docker/StartContainer"
+	Docker_StopContainer_FullMethodName              = "/api.Docker/StopContainer"
+	Docker_ListContainers_FullMethodName             = "/api.Docker/ListContainers"
+	Docker_RemoveContainer_FullMethodName            = "/api.Docker/RemoveContainer"
+	Docker_ContainerLogs_FullMethodName              = "/api.Docker/ContainerLogs"
+	Docker_ExecContainer_FullMethodName              = "/api.Docker/ExecContainer"
+	Docker_ListExecSessions_FullMethodName           = "/api.Docker/ListExecSessions"
+	Docker_GetExecSessionRecording_FullMethodName    = "/api.Docker/GetExecSessionRecording"
+	Docker_PullImage_FullMethodName                  = "/api.Docker/PullImage"
+	Docker_InspectImage_FullMethodName               = "/api.Docker/InspectImage"
+	Docker_InspectRemoteImage_FullMethodName         = "/api.Docker/InspectRemoteImage"
+	Docker_CreateVolume_FullMethodName               = "/api.Docker/CreateVolume"
+	Docker_ListVolumes_FullMethodName                = "/api.Docker/ListVolumes"
+	Docker_RemoveVolume_FullMethodName               = "/api.Docker/RemoveVolume"
+	Docker_CreateVolumeSnapshot_FullMethodName       = "/api.Docker/CreateVolumeSnapshot"
+	Docker_RestoreVolumeSnapshot_FullMethodName      = "/api.Docker/RestoreVolumeSnapshot"
+	Docker_RemoveVolumeSnapshot_FullMethodName       = "/api.Docker/RemoveVolumeSnapshot"
+	Docker_GetVolumeBackend_FullMethodName           = "/api.Docker/GetVolumeBackend"
+	Docker_ExportVolume_FullMethodName               = "/api.Docker/ExportVolume"
+	Docker_ImportVolume_FullMethodName               = "/api.Docker/ImportVolume"
+	Docker_CreateServiceContainer_FullMethodName     = "/api.Docker/CreateServiceContainer"
+	Docker_InspectServiceContainer_FullMethodName    = "/api.Docker/InspectServiceContainer"
+	Docker_ListServiceContainers_FullMethodName      = "/api.Docker/ListServiceContainers"
+	Docker_RemoveServiceContainer_FullMethodName     = "/api.Docker/RemoveServiceContainer"
+	Docker_UpdateServiceContainerSpec_FullMethodName = "/api.Docker/UpdateServiceContainerSpec"
+	Docker_ExportImage_FullMethodName                = "/api.Docker/ExportImage"
+	Docker_LoadImage_FullMethodName                  = "/api.Docker/LoadImage"
+	Docker_MirrorImage_FullMethodName                = "/api.Docker/MirrorImage"
 )
 
 // DockerClient is the client API for Docker service.
@@ -48,6 +62,17 @@ type DockerClient interface {
 	StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
 	RemoveContainer(ctx context.Context, in *RemoveContainerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ContainerLogs streams the stdout and stderr logs of a container.
+	ContainerLogs(ctx context.Context, in *ContainerLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerLogsResponse], error)
+	// ExecContainer runs a command in a running container. The first request message must contain the exec config,
+	// subsequent messages contain stdin data or terminal resize events. The last response message contains
+	// the exit code of the command.
+	ExecContainer(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecContainerRequest, ExecContainerResponse], error)
+	// ListExecSessions returns the interactive exec sessions recorded on the machine when exec recording is enabled
+	// in the cluster settings.
+	ListExecSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListExecSessionsResponse, error)
+	// GetExecSessionRecording streams the asciinema v2 recording of an exec session recorded on the machine.
+	GetExecSessionRecording(ctx context.Context, in *GetExecSessionRecordingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecSessionRecordingChunk], error)
 	PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JSONMessage], error)
 	InspectImage(ctx context.Context, in *InspectImageRequest, opts ...grpc.CallOption) (*InspectImageResponse, error)
 	// InspectRemoteImage returns the image metadata for an image in a remote registry using the machine's
@@ -56,10 +81,33 @@ type DockerClient interface {
 	CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*CreateVolumeResponse, error)
 	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
 	RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// CreateVolumeSnapshot creates a snapshot of a local volume using a native filesystem snapshot if the volume
+	// is a btrfs subvolume or ZFS dataset, or a compressed tar archive of the volume data otherwise.
+	CreateVolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*CreateVolumeSnapshotResponse, error)
+	// RestoreVolumeSnapshot replaces the volume data with the snapshot. The volume must not be used by running containers.
+	RestoreVolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveVolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetVolumeBackend returns the backend managing the data of a local volume: tar, btrfs, or zfs.
+	GetVolumeBackend(ctx context.Context, in *GetVolumeBackendRequest, opts ...grpc.CallOption) (*GetVolumeBackendResponse, error)
+	// ExportVolume streams the data of a local volume in the format of the requested backend.
+	ExportVolume(ctx context.Context, in *ExportVolumeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeData], error)
+	// ImportVolume replaces the data of a local volume with a stream in the format of the backend specified in
+	// the first request message. The volume must not be used by running containers.
+	ImportVolume(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportVolumeRequest, emptypb.Empty], error)
 	CreateServiceContainer(ctx context.Context, in *CreateServiceContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error)
 	InspectServiceContainer(ctx context.Context, in *InspectContainerRequest, opts ...grpc.CallOption) (*ServiceContainer, error)
 	ListServiceContainers(ctx context.Context, in *ListServiceContainersRequest, opts ...grpc.CallOption) (*ListServiceContainersResponse, error)
 	RemoveServiceContainer(ctx context.Context, in *RemoveContainerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UpdateServiceContainerSpec updates the spec of a service container without recreating it. Only the metadata
+	// that doesn't affect the container can be changed, e.g. the DNS aliases or the backup schedule.
+	UpdateServiceContainerSpec(ctx context.Context, in *UpdateServiceContainerSpecRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ExportImage streams a local image in the 'docker save' tar format.
+	ExportImage(ctx context.Context, in *ExportImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ImageData], error)
+	// LoadImage loads an image from a stream in the 'docker save' tar format into the local image store.
+	LoadImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImageData, emptypb.Empty], error)
+	// MirrorImage copies an image from the image store of the nearest machine that has it over the cluster network
+	// into the local image store transferring only the layers missing locally.
+	MirrorImage(ctx context.Context, in *MirrorImageRequest, opts ...grpc.CallOption) (*MirrorImageResponse, error)
 }
 
 type dockerClient struct {
@@ -130,9 +178,70 @@ func (c *dockerClient) RemoveContainer(ctx context.Context, in *RemoveContainerR
 	return out, nil
 }
 
+func (c *dockerClient) ContainerLogs(ctx context.Context, in *ContainerLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[0], Docker_ContainerLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ContainerLogsRequest, ContainerLogsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ContainerLogsClient = grpc.ServerStreamingClient[ContainerLogsResponse]
+
+func (c *dockerClient) ExecContainer(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecContainerRequest, ExecContainerResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[1], Docker_ExecContainer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecContainerRequest, ExecContainerResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ExecContainerClient = grpc.BidiStreamingClient[ExecContainerRequest, ExecContainerResponse]
+
+func (c *dockerClient) ListExecSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListExecSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExecSessionsResponse)
+	err := c.cc.Invoke(ctx, Docker_ListExecSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerClient) GetExecSessionRecording(ctx context.Context, in *GetExecSessionRecordingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecSessionRecordingChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[2], Docker_GetExecSessionRecording_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetExecSessionRecordingRequest, ExecSessionRecordingChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_GetExecSessionRecordingClient = grpc.ServerStreamingClient[ExecSessionRecordingChunk]
+
 func (c *dockerClient) PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JSONMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[0], Docker_PullImage_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[3], Docker_PullImage_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -199,6 +308,78 @@ func (c *dockerClient) RemoveVolume(ctx context.Context, in *RemoveVolumeRequest
 	return out, nil
 }
 
+func (c *dockerClient) CreateVolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*CreateVolumeSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVolumeSnapshotResponse)
+	err := c.cc.Invoke(ctx, Docker_CreateVolumeSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerClient) RestoreVolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Docker_RestoreVolumeSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerClient) RemoveVolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Docker_RemoveVolumeSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerClient) GetVolumeBackend(ctx context.Context, in *GetVolumeBackendRequest, opts ...grpc.CallOption) (*GetVolumeBackendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVolumeBackendResponse)
+	err := c.cc.Invoke(ctx, Docker_GetVolumeBackend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerClient) ExportVolume(ctx context.Context, in *ExportVolumeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeData], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[4], Docker_ExportVolume_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportVolumeRequest, VolumeData]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ExportVolumeClient = grpc.ServerStreamingClient[VolumeData]
+
+func (c *dockerClient) ImportVolume(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportVolumeRequest, emptypb.Empty], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[5], Docker_ImportVolume_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportVolumeRequest, emptypb.Empty]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ImportVolumeClient = grpc.ClientStreamingClient[ImportVolumeRequest, emptypb.Empty]
+
 func (c *dockerClient) CreateServiceContainer(ctx context.Context, in *CreateServiceContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateContainerResponse)
@@ -239,6 +420,58 @@ func (c *dockerClient) RemoveServiceContainer(ctx context.Context, in *RemoveCon
 	return out, nil
 }
 
+func (c *dockerClient) UpdateServiceContainerSpec(ctx context.Context, in *UpdateServiceContainerSpecRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Docker_UpdateServiceContainerSpec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerClient) ExportImage(ctx context.Context, in *ExportImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ImageData], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[6], Docker_ExportImage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportImageRequest, ImageData]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ExportImageClient = grpc.ServerStreamingClient[ImageData]
+
+func (c *dockerClient) LoadImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImageData, emptypb.Empty], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Docker_ServiceDesc.Streams[7], Docker_LoadImage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImageData, emptypb.Empty]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_LoadImageClient = grpc.ClientStreamingClient[ImageData, emptypb.Empty]
+
+func (c *dockerClient) MirrorImage(ctx context.Context, in *MirrorImageRequest, opts ...grpc.CallOption) (*MirrorImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MirrorImageResponse)
+	err := c.cc.Invoke(ctx, Docker_MirrorImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DockerServer is the server API for Docker service.
 // All implementations must embed UnimplementedDockerServer
 // for forward compatibility.
@@ -249,6 +482,17 @@ type DockerServer interface {
 	StopContainer(context.Context, *StopContainerRequest) (*emptypb.Empty, error)
 	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
 	RemoveContainer(context.Context, *RemoveContainerRequest) (*emptypb.Empty, error)
+	// ContainerLogs streams the stdout and stderr logs of a container.
+	ContainerLogs(*ContainerLogsRequest, grpc.ServerStreamingServer[ContainerLogsResponse]) error
+	// ExecContainer runs a command in a running container. The first request message must contain the exec config,
+	// subsequent messages contain stdin data or terminal resize events. The last response message contains
+	// the exit code of the command.
+	ExecContainer(grpc.BidiStreamingServer[ExecContainerRequest, ExecContainerResponse]) error
+	// ListExecSessions returns the interactive exec sessions recorded on the machine when exec recording is enabled
+	// in the cluster settings.
+	ListExecSessions(context.Context, *emptypb.Empty) (*ListExecSessionsResponse, error)
+	// GetExecSessionRecording streams the asciinema v2 recording of an exec session recorded on the machine.
+	GetExecSessionRecording(*GetExecSessionRecordingRequest, grpc.ServerStreamingServer[ExecSessionRecordingChunk]) error
 	PullImage(*PullImageRequest, grpc.ServerStreamingServer[JSONMessage]) error
 	InspectImage(context.Context, *InspectImageRequest) (*InspectImageResponse, error)
 	// InspectRemoteImage returns the image metadata for an image in a remote registry using the machine's
@@ -257,10 +501,33 @@ type DockerServer interface {
 	CreateVolume(context.Context, *CreateVolumeRequest) (*CreateVolumeResponse, error)
 	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
 	RemoveVolume(context.Context, *RemoveVolumeRequest) (*emptypb.Empty, error)
+	// CreateVolumeSnapshot creates a snapshot of a local volume using a native filesystem snapshot if the volume
+	// is a btrfs subvolume or ZFS dataset, or a compressed tar archive of the volume data otherwise.
+	CreateVolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*CreateVolumeSnapshotResponse, error)
+	// RestoreVolumeSnapshot replaces the volume data with the snapshot. The volume must not be used by running containers.
+	RestoreVolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*emptypb.Empty, error)
+	RemoveVolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*emptypb.Empty, error)
+	// GetVolumeBackend returns the backend managing the data of a local volume: tar, btrfs, or zfs.
+	GetVolumeBackend(context.Context, *GetVolumeBackendRequest) (*GetVolumeBackendResponse, error)
+	// ExportVolume streams the data of a local volume in the format of the requested backend.
+	ExportVolume(*ExportVolumeRequest, grpc.ServerStreamingServer[VolumeData]) error
+	// ImportVolume replaces the data of a local volume with a stream in the format of the backend specified in
+	// the first request message. The volume must not be used by running containers.
+	ImportVolume(grpc.ClientStreamingServer[ImportVolumeRequest, emptypb.Empty]) error
 	CreateServiceContainer(context.Context, *CreateServiceContainerRequest) (*CreateContainerResponse, error)
 	InspectServiceContainer(context.Context, *InspectContainerRequest) (*ServiceContainer, error)
 	ListServiceContainers(context.Context, *ListServiceContainersRequest) (*ListServiceContainersResponse, error)
 	RemoveServiceContainer(context.Context, *RemoveContainerRequest) (*emptypb.Empty, error)
+	// UpdateServiceContainerSpec updates the spec of a service container without recreating it. Only the metadata
+	// that doesn't affect the container can be changed, e.g. the DNS aliases or the backup schedule.
+	UpdateServiceContainerSpec(context.Context, *UpdateServiceContainerSpecRequest) (*emptypb.Empty, error)
+	// ExportImage streams a local image in the 'docker save' tar format.
+	ExportImage(*ExportImageRequest, grpc.ServerStreamingServer[ImageData]) error
+	// LoadImage loads an image from a stream in the 'docker save' tar format into the local image store.
+	LoadImage(grpc.ClientStreamingServer[ImageData, emptypb.Empty]) error
+	// MirrorImage copies an image from the image store of the nearest machine that has it over the cluster network
+	// into the local image store transferring only the layers missing locally.
+	MirrorImage(context.Context, *MirrorImageRequest) (*MirrorImageResponse, error)
 	mustEmbedUnimplementedDockerServer()
 }
 
@@ -289,6 +556,18 @@ func (UnimplementedDockerServer) ListContainers(context.Context, *ListContainers
 func (UnimplementedDockerServer) RemoveContainer(context.Context, *RemoveContainerRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveContainer not implemented")
 }
+func (UnimplementedDockerServer) ContainerLogs(*ContainerLogsRequest, grpc.ServerStreamingServer[ContainerLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ContainerLogs not implemented")
+}
+func (UnimplementedDockerServer) ExecContainer(grpc.BidiStreamingServer[ExecContainerRequest, ExecContainerResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecContainer not implemented")
+}
+func (UnimplementedDockerServer) ListExecSessions(context.Context, *emptypb.Empty) (*ListExecSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExecSessions not implemented")
+}
+func (UnimplementedDockerServer) GetExecSessionRecording(*GetExecSessionRecordingRequest, grpc.ServerStreamingServer[ExecSessionRecordingChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetExecSessionRecording not implemented")
+}
 func (UnimplementedDockerServer) PullImage(*PullImageRequest, grpc.ServerStreamingServer[JSONMessage]) error {
 	return status.Errorf(codes.Unimplemented, "method PullImage not implemented")
 }
@@ -307,6 +586,24 @@ func (UnimplementedDockerServer) ListVolumes(context.Context, *ListVolumesReques
 func (UnimplementedDockerServer) RemoveVolume(context.Context, *RemoveVolumeRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveVolume not implemented")
 }
+func (UnimplementedDockerServer) CreateVolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*CreateVolumeSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVolumeSnapshot not implemented")
+}
+func (UnimplementedDockerServer) RestoreVolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVolumeSnapshot not implemented")
+}
+func (UnimplementedDockerServer) RemoveVolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVolumeSnapshot not implemented")
+}
+func (UnimplementedDockerServer) GetVolumeBackend(context.Context, *GetVolumeBackendRequest) (*GetVolumeBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolumeBackend not implemented")
+}
+func (UnimplementedDockerServer) ExportVolume(*ExportVolumeRequest, grpc.ServerStreamingServer[VolumeData]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportVolume not implemented")
+}
+func (UnimplementedDockerServer) ImportVolume(grpc.ClientStreamingServer[ImportVolumeRequest, emptypb.Empty]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportVolume not implemented")
+}
 func (UnimplementedDockerServer) CreateServiceContainer(context.Context, *CreateServiceContainerRequest) (*CreateContainerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceContainer not implemented")
 }
@@ -319,6 +616,18 @@ func (UnimplementedDockerServer) ListServiceContainers(context.Context, *ListSer
 func (UnimplementedDockerServer) RemoveServiceContainer(context.Context, *RemoveContainerRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveServiceContainer not implemented")
 }
+func (UnimplementedDockerServer) UpdateServiceContainerSpec(context.Context, *UpdateServiceContainerSpecRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServiceContainerSpec not implemented")
+}
+func (UnimplementedDockerServer) ExportImage(*ExportImageRequest, grpc.ServerStreamingServer[ImageData]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportImage not implemented")
+}
+func (UnimplementedDockerServer) LoadImage(grpc.ClientStreamingServer[ImageData, emptypb.Empty]) error {
+	return status.Errorf(codes.Unimplemented, "method LoadImage not implemented")
+}
+func (UnimplementedDockerServer) MirrorImage(context.Context, *MirrorImageRequest) (*MirrorImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MirrorImage not implemented")
+}
 func (UnimplementedDockerServer) mustEmbedUnimplementedDockerServer() {}
 func (UnimplementedDockerServer) testEmbeddedByValue()                {}
 
@@ -448,6 +757,53 @@ func _Docker_RemoveContainer_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Docker_ContainerLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContainerLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerServer).ContainerLogs(m, &grpc.GenericServerStream[ContainerLogsRequest, ContainerLogsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ContainerLogsServer = grpc.ServerStreamingServer[ContainerLogsResponse]
+
+func _Docker_ExecContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DockerServer).ExecContainer(&grpc.GenericServerStream[ExecContainerRequest, ExecContainerResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ExecContainerServer = grpc.BidiStreamingServer[ExecContainerRequest, ExecContainerResponse]
+
+func _Docker_ListExecSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServer).ListExecSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docker_ListExecSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServer).ListExecSessions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docker_GetExecSessionRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetExecSessionRecordingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerServer).GetExecSessionRecording(m, &grpc.GenericServerStream[GetExecSessionRecordingRequest, ExecSessionRecordingChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_GetExecSessionRecordingServer = grpc.ServerStreamingServer[ExecSessionRecordingChunk]
+
 func _Docker_PullImage_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(PullImageRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -549,6 +905,96 @@ func _Docker_RemoveVolume_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Docker_CreateVolumeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServer).CreateVolumeSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docker_CreateVolumeSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServer).CreateVolumeSnapshot(ctx, req.(*VolumeSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docker_RestoreVolumeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServer).RestoreVolumeSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docker_RestoreVolumeSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServer).RestoreVolumeSnapshot(ctx, req.(*VolumeSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docker_RemoveVolumeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServer).RemoveVolumeSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docker_RemoveVolumeSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServer).RemoveVolumeSnapshot(ctx, req.(*VolumeSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docker_GetVolumeBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServer).GetVolumeBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docker_GetVolumeBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServer).GetVolumeBackend(ctx, req.(*GetVolumeBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docker_ExportVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportVolumeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerServer).ExportVolume(m, &grpc.GenericServerStream[ExportVolumeRequest, VolumeData]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ExportVolumeServer = grpc.ServerStreamingServer[VolumeData]
+
+func _Docker_ImportVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DockerServer).ImportVolume(&grpc.GenericServerStream[ImportVolumeRequest, emptypb.Empty]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ImportVolumeServer = grpc.ClientStreamingServer[ImportVolumeRequest, emptypb.Empty]
+
 func _Docker_CreateServiceContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateServiceContainerRequest)
 	if err := dec(in); err != nil {
@@ -621,6 +1067,60 @@ func _Docker_RemoveServiceContainer_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Docker_UpdateServiceContainerSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceContainerSpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServer).UpdateServiceContainerSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docker_UpdateServiceContainerSpec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServer).UpdateServiceContainerSpec(ctx, req.(*UpdateServiceContainerSpecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docker_ExportImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerServer).ExportImage(m, &grpc.GenericServerStream[ExportImageRequest, ImageData]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_ExportImageServer = grpc.ServerStreamingServer[ImageData]
+
+func _Docker_LoadImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DockerServer).LoadImage(&grpc.GenericServerStream[ImageData, emptypb.Empty]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Docker_LoadImageServer = grpc.ClientStreamingServer[ImageData, emptypb.Empty]
+
+func _Docker_MirrorImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MirrorImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServer).MirrorImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docker_MirrorImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServer).MirrorImage(ctx, req.(*MirrorImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Docker_ServiceDesc is the grpc.ServiceDesc for Docker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -652,6 +1152,10 @@ var Docker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveContainer",
 			Handler:    _Docker_RemoveContainer_Handler,
 		},
+		{
+			MethodName: "ListExecSessions",
+			Handler:    _Docker_ListExecSessions_Handler,
+		},
 		{
 			MethodName: "InspectImage",
 			Handler:    _Docker_InspectImage_Handler,
@@ -672,6 +1176,22 @@ var Docker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveVolume",
 			Handler:    _Docker_RemoveVolume_Handler,
 		},
+		{
+			MethodName: "CreateVolumeSnapshot",
+			Handler:    _Docker_CreateVolumeSnapshot_Handler,
+		},
+		{
+			MethodName: "RestoreVolumeSnapshot",
+			Handler:    _Docker_RestoreVolumeSnapshot_Handler,
+		},
+		{
+			MethodName: "RemoveVolumeSnapshot",
+			Handler:    _Docker_RemoveVolumeSnapshot_Handler,
+		},
+		{
+			MethodName: "GetVolumeBackend",
+			Handler:    _Docker_GetVolumeBackend_Handler,
+		},
 		{
 			MethodName: "CreateServiceContainer",
 			Handler:    _Docker_CreateServiceContainer_Handler,
@@ -688,13 +1208,57 @@ var Docker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveServiceContainer",
 			Handler:    _Docker_RemoveServiceContainer_Handler,
 		},
+		{
+			MethodName: "UpdateServiceContainerSpec",
+			Handler:    _Docker_UpdateServiceContainerSpec_Handler,
+		},
+		{
+			MethodName: "MirrorImage",
+			Handler:    _Docker_MirrorImage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ContainerLogs",
+			Handler:       _Docker_ContainerLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExecContainer",
+			Handler:       _Docker_ExecContainer_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetExecSessionRecording",
+			Handler:       _Docker_GetExecSessionRecording_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "PullImage",
 			Handler:       _Docker_PullImage_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportVolume",
+			Handler:       _Docker_ExportVolume_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportVolume",
+			Handler:       _Docker_ImportVolume_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportImage",
+			Handler:       _Docker_ExportImage_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LoadImage",
+			Handler:       _Docker_LoadImage_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "internal/machine/api/pb/docker.proto",
 }
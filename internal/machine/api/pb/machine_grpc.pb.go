@@ -24,9 +24,16 @@ const (
 	Machine_InitCluster_FullMethodName        = "/api.Machine/InitCluster"
 	Machine_JoinCluster_FullMethodName        = "/api.Machine/JoinCluster"
 	Machine_Token_FullMethodName              = "/api.Machine/Token"
+	Machine_RotateToken_FullMethodName        = "/api.Machine/RotateToken"
 	Machine_Inspect_FullMethodName            = "/api.Machine/Inspect"
 	Machine_Reset_FullMethodName              = "/api.Machine/Reset"
 	Machine_InspectService_FullMethodName     = "/api.Machine/InspectService"
+	Machine_LastBootReport_FullMethodName     = "/api.Machine/LastBootReport"
+	Machine_Usage_FullMethodName              = "/api.Machine/Usage"
+	Machine_BatchInspect_FullMethodName       = "/api.Machine/BatchInspect"
+	Machine_Upgrade_FullMethodName            = "/api.Machine/Upgrade"
+	Machine_ProbeEndpoints_FullMethodName     = "/api.Machine/ProbeEndpoints"
+	Machine_RenumberSubnet_FullMethodName     = "/api.Machine/RenumberSubnet"
 )
 
 // MachineClient is the client API for Machine service.
@@ -38,10 +45,28 @@ type MachineClient interface {
 	InitCluster(ctx context.Context, in *InitClusterRequest, opts ...grpc.CallOption) (*InitClusterResponse, error)
 	JoinCluster(ctx context.Context, in *JoinClusterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Token(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TokenResponse, error)
+	// RotateToken generates a new WireGuard key pair for the machine that is not yet a member of a cluster and returns
+	// its new token. Tokens issued before the rotation can no longer be used to add the machine to a cluster.
+	RotateToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TokenResponse, error)
 	Inspect(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MachineInfo, error)
 	// Reset restores the machine to a clean state, removing all cluster-related configuration and data.
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	InspectService(ctx context.Context, in *InspectServiceRequest, opts ...grpc.CallOption) (*InspectServiceResponse, error)
+	// LastBootReport returns the report of the state recovery performed by the machine daemon after the last reboot.
+	LastBootReport(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BootReport, error)
+	// Usage returns the current CPU, memory, and disk usage of the machine.
+	Usage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MachineUsage, error)
+	// BatchInspect returns the machine info, usage, and daemon version. Unlike Inspect and Usage, it can be broadcast
+	// to multiple machines in a single call to inspect them concurrently through the proxy.
+	BatchInspect(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BatchInspectResponse, error)
+	// Upgrade downloads the verified machine daemon binary of the requested release and restarts the daemon to run it.
+	Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error)
+	// ProbeEndpoints checks if the WireGuard endpoints of a machine that is about to join the cluster are reachable
+	// from this machine. The joining machine answers the probes on its WireGuard port until it joins the cluster.
+	ProbeEndpoints(ctx context.Context, in *ProbeEndpointsRequest, opts ...grpc.CallOption) (*ProbeEndpointsResponse, error)
+	// RenumberSubnet changes the subnet of the machine as planned by the cluster network migration in progress and
+	// restarts the daemon to reconfigure the WireGuard and Docker networks with the new subnet.
+	RenumberSubnet(ctx context.Context, in *RenumberSubnetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type machineClient struct {
@@ -92,6 +117,16 @@ func (c *machineClient) Token(ctx context.Context, in *emptypb.Empty, opts ...gr
 	return out, nil
 }
 
+func (c *machineClient) RotateToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, Machine_RotateToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineClient) Inspect(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MachineInfo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MachineInfo)
@@ -122,6 +157,66 @@ func (c *machineClient) InspectService(ctx context.Context, in *InspectServiceRe
 	return out, nil
 }
 
+func (c *machineClient) LastBootReport(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BootReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BootReport)
+	err := c.cc.Invoke(ctx, Machine_LastBootReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) Usage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MachineUsage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MachineUsage)
+	err := c.cc.Invoke(ctx, Machine_Usage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) BatchInspect(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BatchInspectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchInspectResponse)
+	err := c.cc.Invoke(ctx, Machine_BatchInspect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpgradeResponse)
+	err := c.cc.Invoke(ctx, Machine_Upgrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) ProbeEndpoints(ctx context.Context, in *ProbeEndpointsRequest, opts ...grpc.CallOption) (*ProbeEndpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProbeEndpointsResponse)
+	err := c.cc.Invoke(ctx, Machine_ProbeEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) RenumberSubnet(ctx context.Context, in *RenumberSubnetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Machine_RenumberSubnet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MachineServer is the server API for Machine service.
 // All implementations must embed UnimplementedMachineServer
 // for forward compatibility.
@@ -131,10 +226,28 @@ type MachineServer interface {
 	InitCluster(context.Context, *InitClusterRequest) (*InitClusterResponse, error)
 	JoinCluster(context.Context, *JoinClusterRequest) (*emptypb.Empty, error)
 	Token(context.Context, *emptypb.Empty) (*TokenResponse, error)
+	// RotateToken generates a new WireGuard key pair for the machine that is not yet a member of a cluster and returns
+	// its new token. Tokens issued before the rotation can no longer be used to add the machine to a cluster.
+	RotateToken(context.Context, *emptypb.Empty) (*TokenResponse, error)
 	Inspect(context.Context, *emptypb.Empty) (*MachineInfo, error)
 	// Reset restores the machine to a clean state, removing all cluster-related configuration and data.
 	Reset(context.Context, *ResetRequest) (*emptypb.Empty, error)
 	InspectService(context.Context, *InspectServiceRequest) (*InspectServiceResponse, error)
+	// LastBootReport returns the report of the state recovery performed by the machine daemon after the last reboot.
+	LastBootReport(context.Context, *emptypb.Empty) (*BootReport, error)
+	// Usage returns the current CPU, memory, and disk usage of the machine.
+	Usage(context.Context, *emptypb.Empty) (*MachineUsage, error)
+	// BatchInspect returns the machine info, usage, and daemon version. Unlike Inspect and Usage, it can be broadcast
+	// to multiple machines in a single call to inspect them concurrently through the proxy.
+	BatchInspect(context.Context, *emptypb.Empty) (*BatchInspectResponse, error)
+	// Upgrade downloads the verified machine daemon binary of the requested release and restarts the daemon to run it.
+	Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error)
+	// ProbeEndpoints checks if the WireGuard endpoints of a machine that is about to join the cluster are reachable
+	// from this machine. The joining machine answers the probes on its WireGuard port until it joins the cluster.
+	ProbeEndpoints(context.Context, *ProbeEndpointsRequest) (*ProbeEndpointsResponse, error)
+	// RenumberSubnet changes the subnet of the machine as planned by the cluster network migration in progress and
+	// restarts the daemon to reconfigure the WireGuard and Docker networks with the new subnet.
+	RenumberSubnet(context.Context, *RenumberSubnetRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedMachineServer()
 }
 
@@ -157,6 +270,9 @@ func (UnimplementedMachineServer) JoinCluster(context.Context, *JoinClusterReque
 func (UnimplementedMachineServer) Token(context.Context, *emptypb.Empty) (*TokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Token not implemented")
 }
+func (UnimplementedMachineServer) RotateToken(context.Context, *emptypb.Empty) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateToken not implemented")
+}
 func (UnimplementedMachineServer) Inspect(context.Context, *emptypb.Empty) (*MachineInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
 }
@@ -166,6 +282,24 @@ func (UnimplementedMachineServer) Reset(context.Context, *ResetRequest) (*emptyp
 func (UnimplementedMachineServer) InspectService(context.Context, *InspectServiceRequest) (*InspectServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InspectService not implemented")
 }
+func (UnimplementedMachineServer) LastBootReport(context.Context, *emptypb.Empty) (*BootReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LastBootReport not implemented")
+}
+func (UnimplementedMachineServer) Usage(context.Context, *emptypb.Empty) (*MachineUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Usage not implemented")
+}
+func (UnimplementedMachineServer) BatchInspect(context.Context, *emptypb.Empty) (*BatchInspectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchInspect not implemented")
+}
+func (UnimplementedMachineServer) Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Upgrade not implemented")
+}
+func (UnimplementedMachineServer) ProbeEndpoints(context.Context, *ProbeEndpointsRequest) (*ProbeEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeEndpoints not implemented")
+}
+func (UnimplementedMachineServer) RenumberSubnet(context.Context, *RenumberSubnetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenumberSubnet not implemented")
+}
 func (UnimplementedMachineServer) mustEmbedUnimplementedMachineServer() {}
 func (UnimplementedMachineServer) testEmbeddedByValue()                 {}
 
@@ -259,6 +393,24 @@ func _Machine_Token_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Machine_RotateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).RotateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_RotateToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).RotateToken(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Machine_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -313,6 +465,114 @@ func _Machine_InspectService_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Machine_LastBootReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).LastBootReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_LastBootReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).LastBootReport(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_Usage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).Usage(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_BatchInspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).BatchInspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_BatchInspect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).BatchInspect(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_Upgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).Upgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_Upgrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).Upgrade(ctx, req.(*UpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_ProbeEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).ProbeEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_ProbeEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).ProbeEndpoints(ctx, req.(*ProbeEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_RenumberSubnet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenumberSubnetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).RenumberSubnet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_RenumberSubnet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).RenumberSubnet(ctx, req.(*RenumberSubnetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Machine_ServiceDesc is the grpc.ServiceDesc for Machine service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -336,6 +596,10 @@ var Machine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Token",
 			Handler:    _Machine_Token_Handler,
 		},
+		{
+			MethodName: "RotateToken",
+			Handler:    _Machine_RotateToken_Handler,
+		},
 		{
 			MethodName: "Inspect",
 			Handler:    _Machine_Inspect_Handler,
@@ -348,6 +612,30 @@ var Machine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InspectService",
 			Handler:    _Machine_InspectService_Handler,
 		},
+		{
+			MethodName: "LastBootReport",
+			Handler:    _Machine_LastBootReport_Handler,
+		},
+		{
+			MethodName: "Usage",
+			Handler:    _Machine_Usage_Handler,
+		},
+		{
+			MethodName: "BatchInspect",
+			Handler:    _Machine_BatchInspect_Handler,
+		},
+		{
+			MethodName: "Upgrade",
+			Handler:    _Machine_Upgrade_Handler,
+		},
+		{
+			MethodName: "ProbeEndpoints",
+			Handler:    _Machine_ProbeEndpoints_Handler,
+		},
+		{
+			MethodName: "RenumberSubnet",
+			Handler:    _Machine_RenumberSubnet_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/machine/api/pb/machine.proto",
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/siderolabs/grpc-proxy/proxy"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -18,12 +19,15 @@ type Director struct {
 	// mu synchronizes access to localAddress.
 	mu           sync.RWMutex
 	localAddress string
+	// callOpts are the additional options for the calls proxied to the backends, e.g. message size limits.
+	callOpts []grpc.CallOption
 }
 
-func NewDirector(localSockPath string, remotePort uint16) *Director {
+func NewDirector(localSockPath string, remotePort uint16, callOpts ...grpc.CallOption) *Director {
 	return &Director{
-		localBackend: NewLocalBackend(localSockPath, ""),
+		localBackend: NewLocalBackend(localSockPath, "", callOpts...),
 		remotePort:   remotePort,
+		callOpts:     callOpts,
 	}
 }
 
@@ -35,7 +39,7 @@ func (d *Director) UpdateLocalAddress(addr string) {
 
 	d.localAddress = addr
 	// Replace the local backend with the one that has local address set.
-	d.localBackend = NewLocalBackend(d.localBackend.sockPath, addr)
+	d.localBackend = NewLocalBackend(d.localBackend.sockPath, addr, d.callOpts...)
 }
 
 // Director implements proxy.StreamDirector for grpc-proxy, routing requests to local or remote backends based
@@ -91,7 +95,7 @@ func (d *Director) remoteBackend(addr string) (*RemoteBackend, error) {
 		return b.(*RemoteBackend), nil
 	}
 
-	backend, err := NewRemoteBackend(addr, d.remotePort)
+	backend, err := NewRemoteBackend(addr, d.remotePort, d.callOpts...)
 	if err != nil {
 		return nil, err
 	}
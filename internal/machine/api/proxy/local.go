@@ -14,6 +14,8 @@ import (
 type LocalBackend struct {
 	One2ManyResponder
 	sockPath string
+	// callOpts are the additional options for the calls proxied to the local server.
+	callOpts []grpc.CallOption
 
 	mu   sync.RWMutex
 	conn *grpc.ClientConn
@@ -23,13 +25,14 @@ var _ proxy.Backend = (*LocalBackend)(nil)
 
 // NewLocalBackend returns a new LocalBackend for the given Unix socket path. The addr parameter is the local address
 // of the current machine which could be empty if it's not known. The address is used to populate response metadata
-// in one2many mode.
-func NewLocalBackend(sockPath, addr string) *LocalBackend {
+// in one2many mode. The callOpts are applied to all calls proxied to the local server.
+func NewLocalBackend(sockPath, addr string, callOpts ...grpc.CallOption) *LocalBackend {
 	return &LocalBackend{
 		One2ManyResponder: One2ManyResponder{
 			machine: addr,
 		},
 		sockPath: sockPath,
+		callOpts: callOpts,
 	}
 }
 
@@ -57,7 +60,7 @@ func (b *LocalBackend) GetConnection(ctx context.Context, _ string) (context.Con
 		"unix://"+b.sockPath,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithDefaultCallOptions(
-			grpc.ForceCodecV2(proxy.Codec()),
+			append([]grpc.CallOption{grpc.ForceCodecV2(proxy.Codec())}, b.callOpts...)...,
 		),
 	)
 
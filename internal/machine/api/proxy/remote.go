@@ -22,6 +22,8 @@ import (
 type RemoteBackend struct {
 	One2ManyResponder
 	target string
+	// callOpts are the additional options for the calls proxied to the remote server.
+	callOpts []grpc.CallOption
 
 	mu   sync.RWMutex
 	conn *grpc.ClientConn
@@ -29,8 +31,9 @@ type RemoteBackend struct {
 
 var _ proxy.Backend = (*RemoteBackend)(nil)
 
-// NewRemoteBackend creates a new instance of RemoteBackend for the given IPv6 address and port.
-func NewRemoteBackend(addr string, port uint16) (*RemoteBackend, error) {
+// NewRemoteBackend creates a new instance of RemoteBackend for the given IPv6 address and port. The callOpts are
+// applied to all calls proxied to the remote server.
+func NewRemoteBackend(addr string, port uint16, callOpts ...grpc.CallOption) (*RemoteBackend, error) {
 	ip, err := netip.ParseAddr(addr)
 	if err != nil || !ip.Is6() {
 		return nil, fmt.Errorf("address must be a valid IPv6 address: %s", addr)
@@ -40,7 +43,8 @@ func NewRemoteBackend(addr string, port uint16) (*RemoteBackend, error) {
 		One2ManyResponder: One2ManyResponder{
 			machine: addr,
 		},
-		target: netip.AddrPortFrom(ip, port).String(),
+		target:   netip.AddrPortFrom(ip, port).String(),
+		callOpts: callOpts,
 	}, nil
 }
 
@@ -91,7 +95,7 @@ func (b *RemoteBackend) GetConnection(ctx context.Context, _ string) (context.Co
 			MinConnectTimeout: 20 * time.Second,
 		}),
 		grpc.WithDefaultCallOptions(
-			grpc.ForceCodecV2(proxy.Codec()),
+			append([]grpc.CallOption{grpc.ForceCodecV2(proxy.Codec())}, b.callOpts...)...,
 		),
 	)
 
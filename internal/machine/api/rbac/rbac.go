@@ -0,0 +1,126 @@
+// Package rbac restricts the machine API methods that clients can call depending on their role. The role
+// is determined by the API endpoint the client connects to, e.g. the read-only Unix socket, and passed to the machine
+// API servers in the request metadata so that they can redact the responses.
+package rbac
+
+import (
+	"context"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/siderolabs/grpc-proxy/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Role string
+
+const (
+	// RoleAdmin allows calling all machine API methods.
+	RoleAdmin Role = "admin"
+	// RoleReadOnly only allows calling the methods that neither change the cluster nor reveal credentials stored
+	// in the cluster, e.g. for monitoring dashboards.
+	RoleReadOnly Role = "read-only"
+
+	// RoleMetadataKey is the request metadata key with the role of the client set by Director.
+	RoleMetadataKey = "uncloud-role"
+)
+
+// readOnlyMethods are the full names of the machine API methods allowed for RoleReadOnly. New methods are denied
+// by default until they're added here. The methods returning service specs or container configs must redact
+// the environment variables and config contents if ReadOnly returns true for the request context.
+// Container logs are deliberately not allowed as applications often log credentials which can't be redacted.
+var readOnlyMethods = map[string]struct{}{
+	pb.Caddy_GetConfig_FullMethodName: {},
+
+	pb.Cluster_GetCluster_FullMethodName:          {},
+	pb.Cluster_ListMachines_FullMethodName:        {},
+	pb.Cluster_GetDomain_FullMethodName:           {},
+	pb.Cluster_ListUptimeChecks_FullMethodName:    {},
+	pb.Cluster_GetAutoUpdate_FullMethodName:       {},
+	pb.Cluster_GetServiceRevision_FullMethodName:  {},
+	pb.Cluster_ListTrashedServices_FullMethodName: {},
+	pb.Cluster_GetSettings_FullMethodName:         {},
+	pb.Cluster_ListIPReservations_FullMethodName:  {},
+	pb.Cluster_GetNetworkMigration_FullMethodName: {},
+	pb.Cluster_ListClusterPeerings_FullMethodName: {},
+	pb.Cluster_ListTenants_FullMethodName:         {},
+	pb.Cluster_ListProjectQuotas_FullMethodName:   {},
+	pb.Cluster_ListPreemptions_FullMethodName:     {},
+	pb.Cluster_GetDeployFreeze_FullMethodName:     {},
+	pb.Cluster_ListFreezeOverrides_FullMethodName: {},
+	pb.Cluster_ListDeployRequests_FullMethodName:  {},
+	pb.Cluster_ListBackups_FullMethodName:         {},
+
+	pb.Docker_InspectContainer_FullMethodName:        {},
+	pb.Docker_ListContainers_FullMethodName:          {},
+	pb.Docker_InspectImage_FullMethodName:            {},
+	pb.Docker_InspectRemoteImage_FullMethodName:      {},
+	pb.Docker_ListVolumes_FullMethodName:             {},
+	pb.Docker_GetVolumeBackend_FullMethodName:        {},
+	pb.Docker_InspectServiceContainer_FullMethodName: {},
+	pb.Docker_ListServiceContainers_FullMethodName:   {},
+
+	pb.Machine_CheckPrerequisites_FullMethodName: {},
+	pb.Machine_Inspect_FullMethodName:            {},
+	pb.Machine_InspectService_FullMethodName:     {},
+	pb.Machine_LastBootReport_FullMethodName:     {},
+	pb.Machine_Usage_FullMethodName:              {},
+	pb.Machine_BatchInspect_FullMethodName:       {},
+}
+
+// Allowed returns true if the role is allowed to call the machine API method with the full name
+// "/package.Service/Method".
+func Allowed(role Role, fullMethodName string) bool {
+	switch role {
+	case RoleAdmin:
+		return true
+	case RoleReadOnly:
+		_, ok := readOnlyMethods[fullMethodName]
+		return ok
+	default:
+		return false
+	}
+}
+
+// Director wraps the proxy director to deny the requests to the methods the role isn't allowed to call before
+// they're proxied to the local or remote machine API servers. The role is added to the metadata of the proxied
+// requests.
+func Director(role Role, director proxy.StreamDirector) proxy.StreamDirector {
+	return func(ctx context.Context, fullMethodName string) (proxy.Mode, []proxy.Backend, error) {
+		if !Allowed(role, fullMethodName) {
+			return proxy.One2One, nil, status.Errorf(
+				codes.PermissionDenied, "method '%s' is not allowed for %s connections", fullMethodName, role)
+		}
+		mode, backends, err := director(ctx, fullMethodName)
+		for i, b := range backends {
+			backends[i] = &roleBackend{Backend: b, role: role}
+		}
+		return mode, backends, err
+	}
+}
+
+// roleBackend adds the role to the metadata of the requests proxied to the backend.
+type roleBackend struct {
+	proxy.Backend
+	role Role
+}
+
+func (b *roleBackend) GetConnection(
+	ctx context.Context, fullMethodName string,
+) (context.Context, *grpc.ClientConn, error) {
+	outCtx, conn, err := b.Backend.GetConnection(ctx, fullMethodName)
+	if err != nil {
+		return outCtx, conn, err
+	}
+	return metadata.AppendToOutgoingContext(outCtx, RoleMetadataKey, string(b.role)), conn, nil
+}
+
+// ReadOnly returns true if the request has been proxied from a RoleReadOnly connection so the response must not
+// reveal the credentials that may be stored in the environment variables or config contents.
+func ReadOnly(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return slices.Contains(md.Get(RoleMetadataKey), string(RoleReadOnly))
+}
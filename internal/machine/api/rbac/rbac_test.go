@@ -0,0 +1,86 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/siderolabs/grpc-proxy/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		role   Role
+		method string
+		want   bool
+	}{
+		{role: RoleAdmin, method: pb.Cluster_SetSettings_FullMethodName, want: true},
+		{role: RoleReadOnly, method: pb.Cluster_GetSettings_FullMethodName, want: true},
+		{role: RoleReadOnly, method: pb.Machine_InspectService_FullMethodName, want: true},
+		{role: RoleReadOnly, method: pb.Cluster_SetSettings_FullMethodName, want: false},
+		{role: RoleReadOnly, method: pb.Docker_CreateServiceContainer_FullMethodName, want: false},
+		{role: RoleReadOnly, method: pb.Docker_ExecContainer_FullMethodName, want: false},
+		{role: RoleReadOnly, method: pb.Docker_ContainerLogs_FullMethodName, want: false},
+		{role: RoleReadOnly, method: pb.Cluster_ListPostgresClusters_FullMethodName, want: false},
+		{role: RoleReadOnly, method: "/api.Unknown/Get", want: false},
+		{role: "unknown", method: pb.Cluster_GetSettings_FullMethodName, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role)+tt.method, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Allowed(tt.role, tt.method))
+		})
+	}
+}
+
+func TestDirector(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	director := Director(RoleReadOnly, func(context.Context, string) (proxy.Mode, []proxy.Backend, error) {
+		called = true
+		return proxy.One2One, nil, nil
+	})
+
+	_, _, err := director(context.Background(), pb.Cluster_SetSettings_FullMethodName)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.False(t, called, "denied request must not be proxied")
+
+	_, _, err = director(context.Background(), pb.Cluster_GetSettings_FullMethodName)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+// stubBackend is a proxy backend that returns the context without a connection.
+type stubBackend struct {
+	proxy.Backend
+}
+
+func (stubBackend) GetConnection(ctx context.Context, _ string) (context.Context, *grpc.ClientConn, error) {
+	return ctx, nil, nil
+}
+
+func TestDirector_AddsRoleMetadata(t *testing.T) {
+	t.Parallel()
+
+	director := Director(RoleReadOnly, func(context.Context, string) (proxy.Mode, []proxy.Backend, error) {
+		return proxy.One2One, []proxy.Backend{stubBackend{}}, nil
+	})
+	_, backends, err := director(context.Background(), pb.Machine_InspectService_FullMethodName)
+	require.NoError(t, err)
+	require.Len(t, backends, 1)
+
+	outCtx, _, err := backends[0].GetConnection(context.Background(), pb.Machine_InspectService_FullMethodName)
+	require.NoError(t, err)
+	md, _ := metadata.FromOutgoingContext(outCtx)
+	assert.True(t, ReadOnly(metadata.NewIncomingContext(context.Background(), md)))
+	assert.False(t, ReadOnly(context.Background()))
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_Play(t *testing.T) {
+	t.Parallel()
+
+	var cast bytes.Buffer
+	rec, err := NewRecorder(&cast, Header{Command: "sh"})
+	require.NoError(t, err)
+	rec.Output([]byte("$ "))
+	rec.Input([]byte("ls\r"))
+	rec.Resize(120, 40)
+	rec.Output([]byte("ls\r\nfile\r\n"))
+	require.NoError(t, rec.Err())
+
+	lines := strings.Split(strings.TrimSpace(cast.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Contains(t, lines[0], `"version":2,"width":80,"height":24`)
+	assert.Contains(t, lines[2], `"i","ls\r"`)
+	assert.Contains(t, lines[3], `"r","120x40"`)
+
+	var out bytes.Buffer
+	var slept time.Duration
+	err = Play(&cast, &out, PlayOptions{Speed: 2, MaxIdle: time.Second, Sleep: func(d time.Duration) {
+		slept += d
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "$ ls\r\nfile\r\n", out.String(), "only output events must be replayed")
+	assert.Less(t, slept, time.Second)
+}
+
+func TestPlay_Invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorContains(t, Play(strings.NewReader(""), io.Discard, PlayOptions{}), "empty recording")
+	assert.ErrorContains(t, Play(strings.NewReader(`{"version":1}`), io.Discard, PlayOptions{}),
+		"unsupported recording version")
+	assert.ErrorContains(t, Play(strings.NewReader("{\"version\":2}\n[0.1,\"o\"]\n"), io.Discard, PlayOptions{}),
+		"invalid event")
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(t.TempDir())
+	sessions, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	session := api.ExecSession{
+		ID:          "0123456789abcdef0123456789abcdef",
+		ContainerID: "abc",
+		ServiceName: "web",
+		Command:     []string{"sh", "-l"},
+		StartedAt:   time.Now().UTC(),
+	}
+	rec, err := store.Record(session, Header{Width: 100, Height: 30})
+	require.NoError(t, err)
+	rec.Output([]byte("hello"))
+
+	sessions, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.False(t, sessions[0].Ended(), "running session must not be ended")
+
+	require.NoError(t, rec.Close(3))
+	sessions, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.True(t, sessions[0].Ended())
+	assert.Equal(t, 3, sessions[0].ExitCode)
+	assert.Equal(t, session.Command, sessions[0].Command)
+
+	f, err := store.Open(session.ID)
+	require.NoError(t, err)
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"command":"sh -l"`)
+	assert.Contains(t, string(data), `"o","hello"`)
+
+	_, err = store.Open("../../etc/passwd")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	_, err = store.Record(api.ExecSession{ID: "../x"}, Header{})
+	assert.ErrorContains(t, err, "invalid session ID")
+}
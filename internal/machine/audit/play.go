@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// PlayOptions configure the playback of a recording.
+type PlayOptions struct {
+	// Speed is the playback speed multiplier. 1 if zero.
+	Speed float64
+	// MaxIdle limits the pauses between the events. No limit if zero.
+	MaxIdle time.Duration
+	// Sleep is used to wait between the events. time.Sleep if nil.
+	Sleep func(time.Duration)
+}
+
+// Play writes the output events of the asciinema v2 recording read from r to w with the recorded timing.
+func Play(r io.Reader, w io.Writer, opts PlayOptions) error {
+	if opts.Speed <= 0 {
+		opts.Speed = 1
+	}
+	if opts.Sleep == nil {
+		opts.Sleep = time.Sleep
+	}
+
+	scanner := bufio.NewScanner(r)
+	// Events may contain large chunks of the output.
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("read header: %w", err)
+		}
+		return errors.New("empty recording")
+	}
+	var header Header
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return fmt.Errorf("parse header: %w", err)
+	}
+	if header.Version != 2 {
+		return fmt.Errorf("unsupported recording version %d, expected 2", header.Version)
+	}
+
+	var last float64
+	for scanner.Scan() {
+		var (
+			event     []json.RawMessage
+			elapsed   float64
+			eventType string
+			data      string
+		)
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || len(event) != 3 {
+			return fmt.Errorf("invalid event: %s", scanner.Text())
+		}
+		if json.Unmarshal(event[0], &elapsed) != nil || json.Unmarshal(event[1], &eventType) != nil ||
+			json.Unmarshal(event[2], &data) != nil {
+			return fmt.Errorf("invalid event: %s", scanner.Text())
+		}
+		if eventType != EventOutput {
+			continue
+		}
+
+		delay := time.Duration((elapsed - last) / opts.Speed * float64(time.Second))
+		if opts.MaxIdle > 0 {
+			delay = min(delay, opts.MaxIdle)
+		}
+		if delay > 0 {
+			opts.Sleep(delay)
+		}
+		last = elapsed
+
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
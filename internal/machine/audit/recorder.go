@@ -0,0 +1,105 @@
+// Package audit records the interactive exec sessions in service containers in the asciinema v2 format
+// (https://docs.asciinema.org/manual/asciicast/v2/) so they can be replayed for compliance audits.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types of the asciinema v2 format.
+const (
+	EventOutput = "o"
+	EventInput  = "i"
+	EventResize = "r"
+)
+
+// Header is the first line of an asciinema v2 recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     uint              `json:"width"`
+	Height    uint              `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder writes the timed output, input, and terminal resize events of a session in the asciinema v2 format.
+// It's safe for concurrent use.
+type Recorder struct {
+	w       io.Writer
+	started time.Time
+	mu      sync.Mutex
+	err     error
+}
+
+// NewRecorder writes the header and returns a recorder that writes the events of the session started at
+// the header timestamp to w.
+func NewRecorder(w io.Writer, header Header) (*Recorder, error) {
+	started := time.Now()
+	header.Version = 2
+	if header.Timestamp == 0 {
+		header.Timestamp = started.Unix()
+	}
+	// Players require a terminal size, use the common default if unknown.
+	if header.Width == 0 || header.Height == 0 {
+		header.Width, header.Height = 80, 24
+	}
+
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("marshal header: %w", err)
+	}
+	if _, err = w.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+	return &Recorder{w: w, started: started}, nil
+}
+
+// Output records the data written to the terminal.
+func (r *Recorder) Output(p []byte) {
+	r.record(EventOutput, string(p))
+}
+
+// Input records the data typed in the terminal.
+func (r *Recorder) Input(p []byte) {
+	r.record(EventInput, string(p))
+}
+
+// Resize records the change of the terminal size.
+func (r *Recorder) Resize(width, height uint) {
+	r.record(EventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Err returns the first error that occurred while writing the events. The events after the error are dropped.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) record(eventType, data string) {
+	if data == "" {
+		return
+	}
+	elapsed := time.Since(r.started).Seconds()
+	// Invalid UTF-8 sequences are replaced as JSON strings can't contain them.
+	event, err := json.Marshal([]any{elapsed, eventType, strings.ToValidUTF8(data, "�")})
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if _, err = r.w.Write(append(event, '\n')); err != nil {
+		r.err = err
+	}
+}
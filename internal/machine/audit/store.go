@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	recordingExt = ".cast"
+	sessionExt   = ".json"
+)
+
+// sessionIDRegexp matches the session IDs generated by secret.NewID to prevent path traversal.
+var sessionIDRegexp = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// ErrSessionNotFound is returned when a session with the given ID is not recorded on the machine.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Store stores the recordings of the exec sessions on the machine in a directory. Each session is stored
+// as an asciinema v2 recording <id>.cast and its metadata <id>.json.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// SessionRecording records the events of a session to its recording file.
+type SessionRecording struct {
+	*Recorder
+	store   *Store
+	file    *os.File
+	session api.ExecSession
+}
+
+// Record creates the recording and metadata files for the new session and returns its recording that must be
+// closed when the session ends.
+func (s *Store) Record(session api.ExecSession, header Header) (*SessionRecording, error) {
+	if !sessionIDRegexp.MatchString(session.ID) {
+		return nil, fmt.Errorf("invalid session ID: %q", session.ID)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create recordings directory: %w", err)
+	}
+	if err := s.saveSession(session); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(s.dir, session.ID+recordingExt)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("create recording file: %w", err)
+	}
+	header.Timestamp = session.StartedAt.Unix()
+	header.Command = strings.Join(session.Command, " ")
+	recorder, err := NewRecorder(file, header)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &SessionRecording{
+		Recorder: recorder,
+		store:    s,
+		file:     file,
+		session:  session,
+	}, nil
+}
+
+// Close closes the recording file and records the exit code of the ended session.
+func (r *SessionRecording) Close(exitCode int) error {
+	err := r.file.Close()
+	r.session.EndedAt = time.Now().UTC()
+	r.session.ExitCode = exitCode
+	return errors.Join(err, r.Err(), r.store.saveSession(r.session))
+}
+
+func (s *Store) saveSession(session api.ExecSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshal session: %w", err)
+	}
+	path := filepath.Join(s.dir, session.ID+sessionExt)
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write session metadata: %w", err)
+	}
+	return nil
+}
+
+// List returns the recorded sessions sorted by start time.
+func (s *Store) List() ([]api.ExecSession, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read recordings directory: %w", err)
+	}
+
+	var sessions []api.ExecSession
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != sessionExt {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read session metadata: %w", err)
+		}
+		var session api.ExecSession
+		if err = json.Unmarshal(data, &session); err != nil {
+			return nil, fmt.Errorf("parse session metadata '%s': %w", e.Name(), err)
+		}
+		sessions = append(sessions, session)
+	}
+	slices.SortFunc(sessions, func(a, b api.ExecSession) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return sessions, nil
+}
+
+// Open opens the recording of the session with the given ID for reading.
+func (s *Store) Open(id string) (*os.File, error) {
+	if !sessionIDRegexp.MatchString(id) {
+		return nil, ErrSessionNotFound
+	}
+	file, err := os.Open(filepath.Join(s.dir, id+recordingExt))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("open recording: %w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,341 @@
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/docker"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// DefaultInterval is the interval at which the controller checks if the machine should be updated.
+	DefaultInterval = time.Minute
+	// healthyTimeout is the maximum time to wait for the drained containers to become healthy after the update.
+	healthyTimeout = 5 * time.Minute
+)
+
+// MachineLister lists the cluster machines with their membership states.
+type MachineLister interface {
+	ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error)
+}
+
+// Controller orchestrates automatic OS updates of the machine within the configured maintenance window.
+// Machines are updated one at a time in the order of their IDs: the machine is cordoned and drained by stopping its
+// service containers, updated, rebooted if required, and its containers are started again. The next machine starts
+// updating only after the containers of the previous one are healthy. If the update of any machine fails,
+// the rollout is halted until the next window.
+type Controller struct {
+	machineID string
+	store     *store.Store
+	machines  MachineLister
+	docker    *docker.Service
+	updater   Updater
+	interval  time.Duration
+	// haltedWindow is the start of the window in which the halted rollout has been logged to avoid repeating it.
+	haltedWindow time.Time
+	log          *slog.Logger
+}
+
+func NewController(
+	machineID string, store *store.Store, machines MachineLister, dockerService *docker.Service,
+) *Controller {
+	return &Controller{
+		machineID: machineID,
+		store:     store,
+		machines:  machines,
+		docker:    dockerService,
+		updater:   UnattendedUpgrades{},
+		interval:  DefaultInterval,
+		log:       slog.With("component", "auto-update"),
+	}
+}
+
+// Run completes the update interrupted by a reboot or restart of the machine daemon and then periodically
+// checks if the machine should be updated until the context is canceled.
+func (c *Controller) Run(ctx context.Context) error {
+	if err := c.resume(ctx); err != nil {
+		c.log.Error("Failed to complete OS update after restart.", "err", err)
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.reconcile(ctx); err != nil {
+				c.log.Error("Failed to run automatic OS update.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// resume completes the update of the machine if it was in progress when the machine daemon stopped.
+func (c *Controller) resume(ctx context.Context) error {
+	records, err := c.store.ListMachineUpdates(ctx)
+	if err != nil {
+		return fmt.Errorf("list machine updates: %w", err)
+	}
+	i := slices.IndexFunc(records, func(r store.MachineUpdateRecord) bool {
+		return r.MachineID == c.machineID
+	})
+	if i == -1 {
+		return nil
+	}
+
+	rec := records[i]
+	switch rec.State {
+	case store.MachineUpdateRebooting:
+		c.log.Info("Machine rebooted after OS update, starting drained containers.")
+		return c.complete(ctx, rec)
+	case store.MachineUpdateDraining, store.MachineUpdateUpdating:
+		return c.fail(ctx, rec, errors.New("update interrupted by a restart of the machine daemon"))
+	}
+	return nil
+}
+
+func (c *Controller) reconcile(ctx context.Context) error {
+	config, err := c.store.GetAutoUpdateConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get auto-update config: %w", err)
+	}
+	if !config.Enabled {
+		return nil
+	}
+	window, err := ParseWindow(config.Window)
+	if err != nil {
+		return err
+	}
+	windowStart, ok := window.Active(time.Now())
+	if !ok {
+		return nil
+	}
+
+	resp, err := c.machines.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	records, err := c.store.ListMachineUpdates(ctx)
+	if err != nil {
+		return fmt.Errorf("list machine updates: %w", err)
+	}
+
+	next, err := NextMachine(resp.Machines, records, windowStart)
+	if err != nil {
+		if !c.haltedWindow.Equal(windowStart) {
+			c.log.Warn("Automatic OS updates halted until the next maintenance window.", "err", err)
+			c.haltedWindow = windowStart
+		}
+		return nil
+	}
+	if next != c.machineID {
+		return nil
+	}
+
+	return c.update(ctx, windowStart)
+}
+
+// NextMachine returns the ID of the machine that should be updated next in the window that started at windowStart.
+// It returns an empty ID if another machine is being updated or all UP machines have been updated in this window.
+// An error is returned if the update of any machine failed in this window.
+func NextMachine(
+	machines []*pb.MachineMember, records []store.MachineUpdateRecord, windowStart time.Time,
+) (string, error) {
+	done := make(map[string]struct{})
+	for _, r := range records {
+		if !r.WindowStart.Equal(windowStart) {
+			continue
+		}
+		switch r.State {
+		case store.MachineUpdateDone:
+			done[r.MachineID] = struct{}{}
+		case store.MachineUpdateFailed:
+			return "", fmt.Errorf("update of machine '%s' failed: %s", r.MachineID, r.Error)
+		default:
+			// Another machine is being updated.
+			return "", nil
+		}
+	}
+
+	var ids []string
+	for _, m := range machines {
+		if m.State == pb.MachineMember_UP {
+			ids = append(ids, m.Machine.Id)
+		}
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		if _, ok := done[id]; !ok {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// update drains the machine, installs the OS updates, and reboots the machine if required. If no reboot is
+// required, the drained containers are started again right away.
+func (c *Controller) update(ctx context.Context, windowStart time.Time) error {
+	rec := store.MachineUpdateRecord{
+		MachineID:   c.machineID,
+		WindowStart: windowStart,
+		State:       store.MachineUpdateDraining,
+	}
+	if err := c.store.PutMachineUpdate(ctx, rec); err != nil {
+		return fmt.Errorf("store machine update: %w", err)
+	}
+
+	// Cordon the machine first so that no new containers are scheduled on it while it's drained and updated.
+	if err := c.store.CordonMachine(ctx, c.machineID, api.CordonReasonAutoUpdate); err != nil {
+		return c.fail(ctx, rec, fmt.Errorf("cordon machine: %w", err))
+	}
+	c.log.Info("Draining machine before OS update.")
+	drained, err := c.drain(ctx)
+	rec.DrainedContainers = drained
+	if err != nil {
+		return c.fail(ctx, rec, fmt.Errorf("drain machine: %w", err))
+	}
+
+	rec.State = store.MachineUpdateUpdating
+	if err = c.store.PutMachineUpdate(ctx, rec); err != nil {
+		return c.fail(ctx, rec, fmt.Errorf("store machine update: %w", err))
+	}
+	c.log.Info("Installing OS updates.")
+	if err = c.updater.Upgrade(ctx); err != nil {
+		return c.fail(ctx, rec, fmt.Errorf("install updates: %w", err))
+	}
+
+	if !c.updater.RebootRequired() {
+		c.log.Info("OS updates installed, no reboot required.")
+		return c.complete(ctx, rec)
+	}
+
+	rec.State = store.MachineUpdateRebooting
+	if err = c.store.PutMachineUpdate(ctx, rec); err != nil {
+		return c.fail(ctx, rec, fmt.Errorf("store machine update: %w", err))
+	}
+	c.log.Info("OS updates installed, rebooting machine.")
+	if err = c.updater.Reboot(ctx); err != nil {
+		return c.fail(ctx, rec, fmt.Errorf("reboot machine: %w", err))
+	}
+	return nil
+}
+
+// drain stops the running service containers on the machine and returns their IDs. Stopped containers are
+// marked as unhealthy in the cluster store so that the ingress stops routing traffic to them.
+func (c *Controller) drain(ctx context.Context) ([]string, error) {
+	containers, err := c.docker.ListServiceContainers(ctx, "", container.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list service containers: %w", err)
+	}
+
+	var drained []string
+	for _, ctr := range containers {
+		// Stop the container using its configured stop signal and timeout.
+		if err = c.docker.Client.ContainerStop(ctx, ctr.ID, container.StopOptions{}); err != nil {
+			return drained, fmt.Errorf("stop container '%s': %w", ctr.Name, err)
+		}
+		drained = append(drained, ctr.ID)
+	}
+	return drained, nil
+}
+
+// complete starts the drained containers, waits for them to become healthy, uncordons the machine, and marks
+// the update as done allowing the next machine to start updating.
+func (c *Controller) complete(ctx context.Context, rec store.MachineUpdateRecord) error {
+	if err := c.startContainers(ctx, rec.DrainedContainers); err != nil {
+		return c.fail(ctx, rec, err)
+	}
+	if err := c.waitHealthy(ctx, rec.DrainedContainers); err != nil {
+		return c.fail(ctx, rec, err)
+	}
+	if err := c.store.UncordonMachine(ctx, c.machineID, api.CordonReasonAutoUpdate); err != nil {
+		return c.fail(ctx, rec, fmt.Errorf("uncordon machine: %w", err))
+	}
+
+	rec.State = store.MachineUpdateDone
+	rec.DrainedContainers = nil
+	if err := c.store.PutMachineUpdate(ctx, rec); err != nil {
+		return fmt.Errorf("store machine update: %w", err)
+	}
+	c.log.Info("OS update completed.")
+	return nil
+}
+
+// fail starts the drained containers, uncordons the machine, and marks the update as failed to halt the rollout.
+func (c *Controller) fail(ctx context.Context, rec store.MachineUpdateRecord, err error) error {
+	if startErr := c.startContainers(ctx, rec.DrainedContainers); startErr != nil {
+		err = errors.Join(err, startErr)
+	}
+	if uncordonErr := c.store.UncordonMachine(ctx, c.machineID, api.CordonReasonAutoUpdate); uncordonErr != nil {
+		err = errors.Join(err, fmt.Errorf("uncordon machine: %w", uncordonErr))
+	}
+
+	rec.State = store.MachineUpdateFailed
+	rec.Error = err.Error()
+	rec.DrainedContainers = nil
+	if putErr := c.store.PutMachineUpdate(ctx, rec); putErr != nil {
+		err = errors.Join(err, fmt.Errorf("store machine update: %w", putErr))
+	}
+	return fmt.Errorf("OS update failed: %w", err)
+}
+
+func (c *Controller) startContainers(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		err := c.docker.Client.ContainerStart(ctx, id, container.StartOptions{})
+		// The container could have been removed by a deployment while the machine was being updated.
+		if err != nil && !client.IsErrNotFound(err) {
+			return fmt.Errorf("start container '%s': %w", id, err)
+		}
+	}
+	return nil
+}
+
+// waitHealthy waits for the containers to become healthy. Removed containers are ignored.
+func (c *Controller) waitHealthy(ctx context.Context, ids []string) error {
+	ctx, cancel := context.WithTimeout(ctx, healthyTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	pending := slices.Clone(ids)
+	for {
+		var unhealthy []string
+		for _, id := range pending {
+			ctr, err := c.docker.Client.ContainerInspect(ctx, id)
+			if err != nil {
+				if client.IsErrNotFound(err) {
+					continue
+				}
+				if ctx.Err() == nil {
+					return fmt.Errorf("inspect container '%s': %w", id, err)
+				}
+			} else if (&api.Container{ContainerJSON: ctr}).Healthy() {
+				continue
+			}
+			unhealthy = append(unhealthy, id)
+		}
+		if len(unhealthy) == 0 {
+			return nil
+		}
+		pending = unhealthy
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("containers not healthy after %s: %v", healthyTimeout, pending)
+		}
+	}
+}
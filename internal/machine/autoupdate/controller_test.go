@@ -0,0 +1,81 @@
+package autoupdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextMachine(t *testing.T) {
+	t.Parallel()
+
+	windowStart := time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	prevWindowStart := windowStart.AddDate(0, 0, -7)
+	machines := []*pb.MachineMember{
+		{Machine: &pb.MachineInfo{Id: "c"}, State: pb.MachineMember_UP},
+		{Machine: &pb.MachineInfo{Id: "a"}, State: pb.MachineMember_UP},
+		{Machine: &pb.MachineInfo{Id: "b"}, State: pb.MachineMember_DOWN},
+	}
+	record := func(id string, start time.Time, state store.MachineUpdateState) store.MachineUpdateRecord {
+		return store.MachineUpdateRecord{MachineID: id, WindowStart: start, State: state}
+	}
+
+	tests := []struct {
+		name    string
+		records []store.MachineUpdateRecord
+		want    string
+		wantErr string
+	}{
+		{
+			name: "first UP machine by ID",
+			want: "a",
+		},
+		{
+			name: "updates from previous window are ignored",
+			records: []store.MachineUpdateRecord{
+				record("a", prevWindowStart, store.MachineUpdateDone),
+				record("c", prevWindowStart, store.MachineUpdateFailed),
+			},
+			want: "a",
+		},
+		{
+			name:    "next machine after done",
+			records: []store.MachineUpdateRecord{record("a", windowStart, store.MachineUpdateDone)},
+			want:    "c",
+		},
+		{
+			name:    "wait for machine being updated",
+			records: []store.MachineUpdateRecord{record("a", windowStart, store.MachineUpdateRebooting)},
+		},
+		{
+			name: "all UP machines done",
+			records: []store.MachineUpdateRecord{
+				record("a", windowStart, store.MachineUpdateDone),
+				record("c", windowStart, store.MachineUpdateDone),
+			},
+		},
+		{
+			name:    "halted after failure",
+			records: []store.MachineUpdateRecord{record("a", windowStart, store.MachineUpdateFailed)},
+			wantErr: "update of machine 'a' failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			next, err := NextMachine(machines, tt.records, windowStart)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
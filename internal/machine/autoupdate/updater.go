@@ -0,0 +1,52 @@
+package autoupdate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// rebootRequiredPath is the file created by Debian-based distributions when a reboot is required to complete
+// the installation of updated packages.
+const rebootRequiredPath = "/var/run/reboot-required"
+
+// Updater installs OS updates and reboots the machine.
+type Updater interface {
+	// Upgrade installs the pending OS security updates.
+	Upgrade(ctx context.Context) error
+	// RebootRequired returns true if the machine needs to be rebooted to complete the installation of updates.
+	RebootRequired() bool
+	// Reboot initiates a reboot of the machine.
+	Reboot(ctx context.Context) error
+}
+
+// UnattendedUpgrades is an Updater for Debian-based distributions that installs updates with unattended-upgrades.
+type UnattendedUpgrades struct{}
+
+func (UnattendedUpgrades) Upgrade(ctx context.Context) error {
+	path, err := exec.LookPath("unattended-upgrade")
+	if err != nil {
+		return fmt.Errorf("unattended-upgrades is not installed: %w", err)
+	}
+	// Refresh the package lists first as unattended-upgrade doesn't do it by itself when run manually.
+	if out, err := exec.CommandContext(ctx, "apt-get", "update").CombinedOutput(); err != nil {
+		return fmt.Errorf("apt-get update: %w: %s", err, out)
+	}
+	if out, err := exec.CommandContext(ctx, path).CombinedOutput(); err != nil {
+		return fmt.Errorf("unattended-upgrade: %w: %s", err, out)
+	}
+	return nil
+}
+
+func (UnattendedUpgrades) RebootRequired() bool {
+	_, err := os.Stat(rebootRequiredPath)
+	return err == nil
+}
+
+func (UnattendedUpgrades) Reboot(ctx context.Context) error {
+	if out, err := exec.CommandContext(ctx, "systemctl", "reboot").CombinedOutput(); err != nil {
+		return fmt.Errorf("systemctl reboot: %w: %s", err, out)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package autoupdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a recurring maintenance window in UTC during which machines are allowed to update and reboot.
+type Window struct {
+	// Days are the days of the week the window recurs on. Every day if empty.
+	Days []time.Weekday
+	// Start is the offset of the window start from midnight.
+	Start time.Duration
+	// Duration is the length of the window. A window can span midnight.
+	Duration time.Duration
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseWindow parses a maintenance window in the format "[DAYS ]HH:MM-HH:MM", where DAYS is an optional
+// comma-separated list of weekdays, e.g. "Sun 03:00-05:00", "Sat,Sun 22:00-02:00", or "04:00-05:00" for every day.
+func ParseWindow(s string) (Window, error) {
+	var w Window
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return w, fmt.Errorf("invalid window '%s': expected format '[DAYS ]HH:MM-HH:MM', e.g. 'Sun 03:00-05:00'", s)
+	}
+
+	if len(fields) == 2 {
+		for _, d := range strings.Split(fields[0], ",") {
+			day, ok := weekdays[strings.ToLower(d)]
+			if !ok {
+				return w, fmt.Errorf("invalid window '%s': unknown day '%s', expected one of: "+
+					"Mon, Tue, Wed, Thu, Fri, Sat, Sun", s, d)
+			}
+			w.Days = append(w.Days, day)
+		}
+	}
+
+	startStr, endStr, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return w, fmt.Errorf("invalid window '%s': expected time range in the format 'HH:MM-HH:MM'", s)
+	}
+	start, err := parseTimeOfDay(startStr)
+	if err != nil {
+		return w, fmt.Errorf("invalid window '%s': %w", s, err)
+	}
+	end, err := parseTimeOfDay(endStr)
+	if err != nil {
+		return w, fmt.Errorf("invalid window '%s': %w", s, err)
+	}
+	if start == end {
+		return w, fmt.Errorf("invalid window '%s': start and end times must differ", s)
+	}
+
+	w.Start = start
+	w.Duration = end - start
+	if end < start {
+		w.Duration += 24 * time.Hour
+	}
+	return w, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Active returns the start time of the window occurrence that contains t and true if t is within the window.
+func (w Window) Active(t time.Time) (time.Time, bool) {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// Check the occurrences starting today and yesterday as a window can span midnight.
+	for _, day := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		if len(w.Days) > 0 && !containsDay(w.Days, day.Weekday()) {
+			continue
+		}
+		start := day.Add(w.Start)
+		if !t.Before(start) && t.Before(start.Add(w.Duration)) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func containsDay(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package autoupdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindow(t *testing.T) {
+	t.Parallel()
+
+	w, err := ParseWindow("Sun 03:00-05:00")
+	require.NoError(t, err)
+	assert.Equal(t, Window{Days: []time.Weekday{time.Sunday}, Start: 3 * time.Hour, Duration: 2 * time.Hour}, w)
+
+	w, err = ParseWindow("sat,SUN 22:30-02:00")
+	require.NoError(t, err)
+	assert.Equal(t, Window{
+		Days:     []time.Weekday{time.Saturday, time.Sunday},
+		Start:    22*time.Hour + 30*time.Minute,
+		Duration: 3*time.Hour + 30*time.Minute,
+	}, w)
+
+	w, err = ParseWindow("04:00-05:00")
+	require.NoError(t, err)
+	assert.Equal(t, Window{Start: 4 * time.Hour, Duration: time.Hour}, w)
+
+	for _, s := range []string{"", "Sun", "Sun 03:00", "Sunday 03:00-05:00", "Sun 3am-5am", "Sun 03:00-03:00",
+		"Sun 25:00-05:00", "Sun 03:00-05:00 UTC"} {
+		_, err = ParseWindow(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestWindowActive(t *testing.T) {
+	t.Parallel()
+
+	// 2026-10-18 is a Sunday.
+	sunday := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	w, err := ParseWindow("Sun 03:00-05:00")
+	require.NoError(t, err)
+	start, ok := w.Active(sunday.Add(4 * time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, sunday.Add(3*time.Hour), start)
+	_, ok = w.Active(sunday.Add(5 * time.Hour))
+	assert.False(t, ok, "window end is exclusive")
+	_, ok = w.Active(sunday.AddDate(0, 0, 1).Add(4 * time.Hour))
+	assert.False(t, ok, "other day")
+
+	// The window that starts on Sunday and spans midnight.
+	w, err = ParseWindow("Sun 23:00-01:00")
+	require.NoError(t, err)
+	start, ok = w.Active(sunday.AddDate(0, 0, 1).Add(30 * time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, sunday.Add(23*time.Hour), start)
+	_, ok = w.Active(sunday.Add(30 * time.Minute))
+	assert.False(t, ok, "previous day is Saturday")
+
+	// Times in other time zones are converted to UTC.
+	w, err = ParseWindow("03:00-05:00")
+	require.NoError(t, err)
+	start, ok = w.Active(sunday.Add(4 * time.Hour).In(time.FixedZone("UTC+10", 10*60*60)))
+	assert.True(t, ok)
+	assert.Equal(t, sunday.Add(3*time.Hour), start)
+}
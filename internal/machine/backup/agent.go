@@ -0,0 +1,245 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/psviderski/uncloud/internal/dbbackup"
+	"github.com/psviderski/uncloud/internal/machine/docker"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// DefaultInterval is the interval at which the agent checks if any service backups are due.
+	DefaultInterval = time.Minute
+	// backupTimeout is the maximum time a single backup can take including the upload.
+	backupTimeout = 2 * time.Hour
+)
+
+// Agent runs scheduled backups of the services with a backup spec and uploads them to the cluster backup storage.
+// Each service is backed up from one running container: the one with the lowest ID across the cluster. Only
+// the machine running that container performs the backup so there is no need for coordination between machines.
+// The storage is the source of truth for when the last backup was created.
+type Agent struct {
+	machineID string
+	store     *store.Store
+	docker    *docker.Service
+	interval  time.Duration
+	log       *slog.Logger
+}
+
+func NewAgent(machineID string, store *store.Store, dockerService *docker.Service) *Agent {
+	return &Agent{
+		machineID: machineID,
+		store:     store,
+		docker:    dockerService,
+		interval:  DefaultInterval,
+		log:       slog.With("component", "backup-agent"),
+	}
+}
+
+// Run checks if any service backups are due every interval and runs them until the context is canceled.
+func (a *Agent) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.reconcile(ctx); err != nil {
+				a.log.Error("Failed to run scheduled backups.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (a *Agent) reconcile(ctx context.Context) error {
+	config, err := a.store.GetBackupStorage(ctx)
+	if err != nil {
+		return fmt.Errorf("get backup storage config: %w", err)
+	}
+	if config.Endpoint == "" {
+		// Backups are disabled until the storage is configured.
+		return nil
+	}
+
+	records, err := a.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+	targets := Targets(records)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	storage, err := dbbackup.NewStorage(config)
+	if err != nil {
+		return err
+	}
+	for _, r := range targets {
+		if r.MachineID != a.machineID {
+			continue
+		}
+		serviceName := r.Container.ServiceName()
+		if err = a.backupIfDue(ctx, storage, r.Container); err != nil {
+			a.log.Error("Failed to back up service.", "service", serviceName, "err", err)
+		}
+	}
+	return nil
+}
+
+// Targets returns the containers the services with a backup spec should be backed up from: the running container
+// with the lowest ID for each service.
+func Targets(records []store.ContainerRecord) []store.ContainerRecord {
+	byService := make(map[string]store.ContainerRecord)
+	var services []string
+	for _, r := range records {
+		if r.Container.ServiceSpec.Backup == nil || !r.Container.State.Running {
+			continue
+		}
+		serviceID := r.Container.ServiceID()
+		current, ok := byService[serviceID]
+		if !ok {
+			services = append(services, serviceID)
+		}
+		if !ok || r.Container.ID < current.Container.ID {
+			byService[serviceID] = r
+		}
+	}
+
+	targets := make([]store.ContainerRecord, len(services))
+	for i, id := range services {
+		targets[i] = byService[id]
+	}
+	return targets
+}
+
+// backupIfDue backs up the service from the container if the interval has passed since its last backup and deletes
+// the backups exceeding the retention.
+func (a *Agent) backupIfDue(ctx context.Context, storage *dbbackup.Storage, ctr api.ServiceContainer) error {
+	spec := ctr.ServiceSpec.Backup.SetDefaults()
+	serviceName := ctr.ServiceName()
+
+	objects, err := storage.List(ctx, dbbackup.ServicePrefix(storage.Prefix(), serviceName))
+	if err != nil {
+		return fmt.Errorf("list backups: %w", err)
+	}
+	backups := dbbackup.List(objects, storage.Prefix(), serviceName)
+	if len(backups) > 0 && time.Since(backups[0].CreatedAt) < spec.Interval {
+		return nil
+	}
+
+	a.log.Info("Backing up service.", "service", serviceName, "container", ctr.ID, "engine", spec.Engine)
+	start := time.Now()
+	b, err := a.runBackup(ctx, storage, ctr)
+	if err != nil {
+		return err
+	}
+	a.log.Info("Service backed up.", "service", serviceName, "key", b.Key, "size", b.Size,
+		"duration", time.Since(start).Round(time.Millisecond))
+
+	backups = append([]api.Backup{b}, backups...)
+	for _, expired := range dbbackup.Expired(backups, spec.Retention) {
+		if err = storage.Delete(ctx, expired.Key); err != nil {
+			return fmt.Errorf("delete expired backup '%s': %w", expired.Key, err)
+		}
+		a.log.Info("Deleted expired backup.", "service", serviceName, "key", expired.Key)
+	}
+	return nil
+}
+
+// runBackup runs the dump command of the backup engine in the container and uploads its compressed output
+// to the storage. The output is buffered in a temporary file as the storage requires the upload size in advance.
+func (a *Agent) runBackup(
+	ctx context.Context, storage *dbbackup.Storage, ctr api.ServiceContainer,
+) (api.Backup, error) {
+	ctx, cancel := context.WithTimeout(ctx, backupTimeout)
+	defer cancel()
+
+	engine, err := dbbackup.EngineByName(ctr.ServiceSpec.Backup.Engine)
+	if err != nil {
+		return api.Backup{}, err
+	}
+
+	f, err := os.CreateTemp("", "uncloud-backup-")
+	if err != nil {
+		return api.Backup{}, fmt.Errorf("create temporary file: %w", err)
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	createdAt := time.Now().UTC()
+	gz := gzip.NewWriter(f)
+	if err = a.exec(ctx, ctr.ID, engine.DumpCmd, gz); err != nil {
+		return api.Backup{}, fmt.Errorf("dump %s database: %w", engine.Name, err)
+	}
+	if err = gz.Close(); err != nil {
+		return api.Backup{}, fmt.Errorf("compress backup: %w", err)
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return api.Backup{}, fmt.Errorf("get backup size: %w", err)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return api.Backup{}, fmt.Errorf("rewind backup file: %w", err)
+	}
+
+	key := dbbackup.Key(storage.Prefix(), ctr.ServiceName(), engine, createdAt)
+	if err = storage.Put(ctx, key, f, size); err != nil {
+		return api.Backup{}, fmt.Errorf("upload backup: %w", err)
+	}
+
+	return api.Backup{
+		Key:       key,
+		Service:   ctr.ServiceName(),
+		Engine:    engine.Name,
+		Size:      size,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// exec runs the command in the container and writes its stdout to the writer. It returns an error with the stderr
+// output if the command exits with a non-zero code.
+func (a *Agent) exec(ctx context.Context, containerID string, cmd []string, stdout io.Writer) error {
+	execResp, err := a.docker.Client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("create exec: %w", err)
+	}
+	hijacked, err := a.docker.Client.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return fmt.Errorf("attach to exec: %w", err)
+	}
+	defer hijacked.Close()
+
+	var stderr strings.Builder
+	if _, err = stdcopy.StdCopy(stdout, &stderr, hijacked.Reader); err != nil {
+		return fmt.Errorf("read exec output: %w", err)
+	}
+
+	inspect, err := a.docker.Client.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return fmt.Errorf("inspect exec: %w", err)
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("command exited with code %d: %s", inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
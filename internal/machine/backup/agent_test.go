@@ -0,0 +1,49 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargets(t *testing.T) {
+	t.Parallel()
+
+	record := func(id, serviceID, machineID string, backup *api.BackupSpec, running bool) store.ContainerRecord {
+		return store.ContainerRecord{
+			MachineID: machineID,
+			Container: api.ServiceContainer{
+				Container: api.Container{ContainerJSON: types.ContainerJSON{
+					ContainerJSONBase: &types.ContainerJSONBase{
+						ID:    id,
+						State: &types.ContainerState{Running: running},
+					},
+					Config: &container.Config{Labels: map[string]string{api.LabelServiceID: serviceID}},
+				}},
+				ServiceSpec: api.ServiceSpec{Backup: backup},
+			},
+		}
+	}
+	postgres := &api.BackupSpec{Engine: api.BackupEnginePostgres}
+	redis := &api.BackupSpec{Engine: api.BackupEngineRedis}
+
+	targets := Targets([]store.ContainerRecord{
+		record("c3", "db", "m1", postgres, true),
+		record("c1", "db", "m2", postgres, true),
+		// The stopped container with the lowest ID is skipped.
+		record("c0", "cache", "m1", redis, false),
+		record("c2", "cache", "m2", redis, true),
+		record("a1", "web", "m1", nil, true),
+		record("c4", "stopped", "m1", postgres, false),
+	})
+
+	ids := make([]string, len(targets))
+	for i, r := range targets {
+		ids[i] = r.Container.ID
+	}
+	assert.Equal(t, []string{"c1", "c2"}, ids)
+}
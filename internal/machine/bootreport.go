@@ -0,0 +1,87 @@
+package machine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const BootReportFileName = "last-boot.json"
+
+// BootReport describes the state recovery performed by the machine daemon on its first start after a reboot.
+type BootReport struct {
+	// BootID is the kernel boot ID used to detect that the machine has been rebooted since the last daemon start.
+	BootID   string
+	BootTime time.Time
+	// RecoveredAt is the time when the recovery completed.
+	RecoveredAt time.Time
+	Actions     []RecoveryAction
+}
+
+// RecoveryAction is an action taken by the machine daemon to recover the state of a resource after a reboot.
+type RecoveryAction struct {
+	// Resource is a human-readable identifier of the recovered resource, e.g. "container web-x1y2".
+	Resource string
+	Action   string
+	Error    string `json:",omitempty"`
+}
+
+// BootReportPath returns the path to the boot report file within the given data directory.
+func BootReportPath(dataDir string) string {
+	return filepath.Join(dataDir, BootReportFileName)
+}
+
+// ParseBootReport reads and decodes a boot report from the file at the given path.
+func ParseBootReport(path string) (*BootReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read boot report file: %w", err)
+	}
+	var report BootReport
+	if err = json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse boot report file %q: %w", path, err)
+	}
+	return &report, nil
+}
+
+// Save writes the boot report to the file at the given path.
+func (r *BootReport) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal boot report: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write boot report file %q: %w", path, err)
+	}
+	return nil
+}
+
+// currentBoot returns the kernel boot ID and the boot time of the machine.
+func currentBoot() (string, time.Time, error) {
+	id, err := os.ReadFile("/proc/sys/kernel/random/boot_id")
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("read boot ID: %w", err)
+	}
+
+	stat, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("read /proc/stat: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(stat))
+	for scanner.Scan() {
+		if btime, ok := strings.CutPrefix(scanner.Text(), "btime "); ok {
+			sec, err := strconv.ParseInt(strings.TrimSpace(btime), 10, 64)
+			if err != nil {
+				return "", time.Time{}, fmt.Errorf("parse boot time: %w", err)
+			}
+			return strings.TrimSpace(string(id)), time.Unix(sec, 0).UTC(), nil
+		}
+	}
+	return "", time.Time{}, fmt.Errorf("boot time not found in /proc/stat")
+}
@@ -0,0 +1,32 @@
+package machine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootReportSaveParse(t *testing.T) {
+	t.Parallel()
+
+	path := BootReportPath(t.TempDir())
+	_, err := ParseBootReport(path)
+	require.Error(t, err)
+
+	report := BootReport{
+		BootID:      "8a8b2c1e-7d1b-4e5f-9a2c-3b4d5e6f7a8b",
+		BootTime:    time.Date(2026, 10, 15, 3, 0, 0, 0, time.UTC),
+		RecoveredAt: time.Date(2026, 10, 15, 3, 1, 0, 0, time.UTC),
+		Actions: []RecoveryAction{
+			{Resource: "WireGuard interface uncloud", Action: "create"},
+			{Resource: "container web-x1y2", Action: "start", Error: "port is already allocated"},
+		},
+	}
+	require.NoError(t, report.Save(path))
+
+	parsed, err := ParseBootReport(path)
+	require.NoError(t, err)
+	assert.Equal(t, report, *parsed)
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/caddyserver/caddy/v2/caddyconfig/caddyfile"
 	"github.com/psviderski/uncloud/internal/machine/store"
 	"github.com/psviderski/uncloud/pkg/api"
 )
@@ -21,11 +22,23 @@ const (
 	caddyfileHeader = `# This file is autogenerated by Uncloud based on the configuration of running services.
 # Do not edit manually. Any manual changes will be overwritten on the next update.
 `
+	// metricsServerOptions enables Prometheus metrics for the HTTP requests handled by all servers.
+	metricsServerOptions = `servers {
+		metrics
+	}`
 	caddyfileTemplate = `# Health check endpoint to verify Caddy reachability on this machine.
 http:// {
 	handle {{.VerifyPath}} {
 		respond "{{.VerifyResponse}}" 200
 	}
+	# Prometheus metrics endpoint only accessible from private networks, e.g. the cluster network.
+	@metrics {
+		path {{.MetricsPath}}
+		remote_ip private_ranges
+	}
+	handle @metrics {
+		metrics
+	}
 	log
 }
 
@@ -64,6 +77,9 @@ https://{{$hostname}} {
 type CaddyfileGenerator struct {
 	// machineID is the unique identifier of the machine where the controller is running.
 	machineID string
+	// ACMEEmail is the email address used to register an ACME account for obtaining TLS certificates.
+	// It's added to the global options unless the user-defined global config sets the email.
+	ACMEEmail string
 	validator CaddyfileValidator
 	log       *slog.Logger
 }
@@ -121,7 +137,8 @@ func (g *CaddyfileGenerator) Generate(
 	}
 
 	if !includeCustom {
-		return fmt.Sprintf("%s\n%s\n%s", caddyfileHeader, caddyfile, caddyfileUnavailabeFooter), nil
+		return fmt.Sprintf("%s\n%s\n%s", caddyfileHeader, withGlobalOptions(caddyfile, g.ACMEEmail),
+			caddyfileUnavailabeFooter), nil
 	}
 
 	upstreams := serviceUpstreams(containers)
@@ -229,7 +246,69 @@ func (g *CaddyfileGenerator) Generate(
 		caddyfile += "\n" + errorsComment
 	}
 
-	return caddyfileHeader + "\n" + caddyfile, nil
+	return caddyfileHeader + "\n" + withGlobalOptions(caddyfile, g.ACMEEmail), nil
+}
+
+// withGlobalOptions adds the global options required by Uncloud to the Caddyfile. The global options block must be
+// the first block in a Caddyfile, so if the user-defined global config already contains one, the options are merged
+// into it. The user-defined 'servers' and 'email' options take precedence, so metrics are not enabled if 'servers'
+// is specified and the acmeEmail is not set if 'email' is specified.
+func withGlobalOptions(config, acmeEmail string) string {
+	tokens, err := caddyfile.Tokenize([]byte(config), "Caddyfile")
+	// The Caddyfile has already been validated so tokenizing shouldn't fail.
+	hasBlock := err == nil && len(tokens) > 0 && tokens[0].Text == "{" && !tokens[0].Quoted()
+
+	// Look for the 'servers' and 'email' options in the user-defined global options block.
+	hasServers, hasEmail := false, false
+	depth := 0
+	for i, t := range tokens {
+		if !hasBlock {
+			break
+		}
+		if !t.Quoted() {
+			if t.Text == "{" {
+				depth++
+			} else if t.Text == "}" {
+				depth--
+			}
+		}
+		if depth == 0 {
+			break
+		}
+		if depth == 1 && i > 0 && t.Line != tokens[i-1].Line {
+			switch t.Text {
+			case "servers":
+				hasServers = true
+			case "email":
+				hasEmail = true
+			}
+		}
+	}
+
+	var options []string
+	if !hasServers {
+		options = append(options, metricsServerOptions)
+	}
+	if acmeEmail != "" && !hasEmail {
+		options = append(options, "email "+acmeEmail)
+	}
+	if len(options) == 0 {
+		return config
+	}
+
+	if !hasBlock {
+		return "# Global options.\n{\n\t" + strings.Join(options, "\n\t") + "\n}\n\n" + config
+	}
+
+	// Insert the options right after the opening brace of the global options block.
+	lines := strings.SplitAfter(config, "\n")
+	offset := 0
+	for _, l := range lines[:tokens[0].Line-1] {
+		offset += len(l)
+	}
+	offset += strings.Index(lines[tokens[0].Line-1], "{") + 1
+
+	return config[:offset] + "\n\t" + strings.Join(options, "\n\t") + config[offset:]
 }
 
 func (g *CaddyfileGenerator) generateBaseFromPorts(containers []api.ServiceContainer) (string, error) {
@@ -244,11 +323,13 @@ func (g *CaddyfileGenerator) generateBaseFromPorts(containers []api.ServiceConta
 	data := struct {
 		VerifyPath         string
 		VerifyResponse     string
+		MetricsPath        string
 		HTTPHostUpstreams  map[string][]string
 		HTTPSHostUpstreams map[string][]string
 	}{
 		VerifyPath:         VerifyPath,
 		VerifyResponse:     g.machineID,
+		MetricsPath:        MetricsPath,
 		HTTPHostUpstreams:  httpHostUpstreams,
 		HTTPSHostUpstreams: httpsHostUpstreams,
 	}
@@ -21,11 +21,26 @@ import (
 const testCaddyfileHeader = `# This file is autogenerated by Uncloud based on the configuration of running services.
 # Do not edit manually. Any manual changes will be overwritten on the next update.
 
+# Global options.
+{
+	servers {
+		metrics
+	}
+}
+
 # Health check endpoint to verify Caddy reachability on this machine.
 http:// {
 	handle /.uncloud-verify {
 		respond "test-machine-id" 200
 	}
+	# Prometheus metrics endpoint only accessible from private networks, e.g. the cluster network.
+	@metrics {
+		path /.uncloud-metrics
+		remote_ip private_ranges
+	}
+	handle @metrics {
+		metrics
+	}
 	log
 }
 
@@ -223,6 +238,9 @@ func TestCaddyfileGeneratorWithCustomConfigs(t *testing.T) {
 # User-defined global config from service 'caddy'.
 # Global Caddy configuration
 {
+	servers {
+		metrics
+	}
 	global directive
 }
 
@@ -231,6 +249,14 @@ http:// {
 	handle /.uncloud-verify {
 		respond "test-machine-id" 200
 	}
+	# Prometheus metrics endpoint only accessible from private networks, e.g. the cluster network.
+	@metrics {
+		path /.uncloud-metrics
+		remote_ip private_ranges
+	}
+	handle @metrics {
+		metrics
+	}
 	log
 }
 
@@ -419,6 +445,9 @@ web.example.com {
 # User-defined global config from service 'caddy'.
 # Global config
 {
+	servers {
+		metrics
+	}
 	global directive
 }
 
@@ -427,6 +456,14 @@ http:// {
 	handle /.uncloud-verify {
 		respond "test-machine-id" 200
 	}
+	# Prometheus metrics endpoint only accessible from private networks, e.g. the cluster network.
+	@metrics {
+		path /.uncloud-metrics
+		remote_ip private_ranges
+	}
+	handle @metrics {
+		metrics
+	}
 	log
 }
 
@@ -656,6 +693,9 @@ badconfig.com {
 # User-defined global config from service 'caddy'.
 # Global config from test machine
 {
+	servers {
+		metrics
+	}
 	admin off
 }
 
@@ -668,6 +708,14 @@ http:// {
 	handle /.uncloud-verify {
 		respond "test-machine-id" 200
 	}
+	# Prometheus metrics endpoint only accessible from private networks, e.g. the cluster network.
+	@metrics {
+		path /.uncloud-metrics
+		remote_ip private_ranges
+	}
+	handle @metrics {
+		metrics
+	}
 	log
 }
 
@@ -970,6 +1018,64 @@ http://api.example.com {
 	}
 }
 
+func TestWithGlobalOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		config    string
+		acmeEmail string
+		want      string
+	}{
+		{
+			name:   "no global options block",
+			config: "example.com {\n\trespond \"OK\"\n}\n",
+			want: "# Global options.\n{\n\tservers {\n\t\tmetrics\n\t}\n}\n\n" +
+				"example.com {\n\trespond \"OK\"\n}\n",
+		},
+		{
+			name:   "merged into user-defined global options block",
+			config: "# Global options.\n{\n\temail admin@example.com\n}\n",
+			want:   "# Global options.\n{\n\tservers {\n\t\tmetrics\n\t}\n\temail admin@example.com\n}\n",
+		},
+		{
+			name:   "user-defined servers option takes precedence",
+			config: "{\n\temail admin@example.com\n\tservers {\n\t\ttrusted_proxies static private_ranges\n\t}\n}\n",
+			want:   "{\n\temail admin@example.com\n\tservers {\n\t\ttrusted_proxies static private_ranges\n\t}\n}\n",
+		},
+		{
+			name:   "servers directive outside global options block is ignored",
+			config: "{\n\tdebug\n}\n\nexample.com {\n\tservers\n}\n",
+			want:   "{\n\tservers {\n\t\tmetrics\n\t}\n\tdebug\n}\n\nexample.com {\n\tservers\n}\n",
+		},
+		{
+			name:      "ACME email without global options block",
+			config:    "example.com {\n\trespond \"OK\"\n}\n",
+			acmeEmail: "ops@example.com",
+			want: "# Global options.\n{\n\tservers {\n\t\tmetrics\n\t}\n\temail ops@example.com\n}\n\n" +
+				"example.com {\n\trespond \"OK\"\n}\n",
+		},
+		{
+			name:      "ACME email merged into user-defined global options block",
+			config:    "{\n\tdebug\n}\n",
+			acmeEmail: "ops@example.com",
+			want:      "{\n\tservers {\n\t\tmetrics\n\t}\n\temail ops@example.com\n\tdebug\n}\n",
+		},
+		{
+			name:      "user-defined email option takes precedence",
+			config:    "{\n\temail admin@example.com\n\tservers {\n\t\tmetrics\n\t}\n}\n",
+			acmeEmail: "ops@example.com",
+			want:      "{\n\temail admin@example.com\n\tservers {\n\t\tmetrics\n\t}\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, withGlobalOptions(tt.config, tt.acmeEmail))
+		})
+	}
+}
+
 func newContainerRecordWithPorts(serviceName, ip string, ports []string, machineID string) store.ContainerRecord {
 	portsLabel := strings.Join(ports, ",")
 	return store.ContainerRecord{
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/psviderski/uncloud/internal/fs"
+	"github.com/psviderski/uncloud/internal/machine/settings"
 	"github.com/psviderski/uncloud/internal/machine/store"
 	"github.com/psviderski/uncloud/pkg/api"
 )
@@ -17,6 +18,8 @@ const (
 	CaddyServiceName = "caddy"
 	CaddyGroup       = "uncloud"
 	VerifyPath       = "/.uncloud-verify"
+	// MetricsPath is the path of the Prometheus metrics endpoint served by Caddy on port 80.
+	MetricsPath = "/.uncloud-metrics"
 )
 
 // Controller monitors container changes in the cluster store and generates a configuration file for Caddy reverse
@@ -28,10 +31,14 @@ type Controller struct {
 	generator     *CaddyfileGenerator
 	client        *CaddyAdminClient
 	store         *store.Store
-	log           *slog.Logger
+	// settings provides the cluster-wide settings such as the ACME email for the generated Caddyfile.
+	settings *settings.Watcher
+	log      *slog.Logger
 }
 
-func NewController(machineID, configDir, adminSock string, store *store.Store) (*Controller, error) {
+func NewController(
+	machineID, configDir, adminSock string, store *store.Store, settings *settings.Watcher,
+) (*Controller, error) {
 	if err := os.MkdirAll(configDir, 0o750); err != nil {
 		return nil, fmt.Errorf("create directory for Caddy configuration '%s': %w", configDir, err)
 	}
@@ -49,11 +56,13 @@ func NewController(machineID, configDir, adminSock string, store *store.Store) (
 		generator:     generator,
 		client:        client,
 		store:         store,
+		settings:      settings,
 		log:           log,
 	}, nil
 }
 
 func (c *Controller) Run(ctx context.Context) error {
+	settingsChanges := c.settings.Subscribe()
 	containers, changes, err := c.store.SubscribeContainers(ctx)
 	if err != nil {
 		return fmt.Errorf("subscribe to container changes: %w", err)
@@ -88,6 +97,12 @@ func (c *Controller) Run(ctx context.Context) error {
 			if err = c.generateJSONConfig(containers); err != nil {
 				c.log.Error("Failed to generate Caddy JSON configuration to disk.", "err", err)
 			}
+		case <-settingsChanges:
+			if c.settings.Get().ACMEEmail == c.generator.ACMEEmail {
+				continue
+			}
+			c.log.Info("ACME email in cluster settings changed, updating Caddy configuration.")
+			c.generateAndLoadCaddyfile(ctx, containers)
 		case <-ctx.Done():
 			return nil
 		}
@@ -109,6 +124,7 @@ func filterHealthyContainers(containers []store.ContainerRecord) []store.Contain
 }
 
 func (c *Controller) generateAndLoadCaddyfile(ctx context.Context, containers []store.ContainerRecord) {
+	c.generator.ACMEEmail = c.settings.Get().ACMEEmail
 	// Check if Caddy is available before attempting to generate and load config.
 	caddyAvailable := c.client.IsAvailable(ctx)
 	caddyfile, err := c.generator.Generate(ctx, containers, caddyAvailable)
@@ -0,0 +1,227 @@
+// Package chaos implements the chaos mode of the machine daemon that randomly injects faults to harden the cluster
+// reconciliation loops against real-world flakiness. It must only be enabled in test clusters.
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// Config configures the faults injected by the chaos mode. A zero value disables the corresponding fault.
+type Config struct {
+	// Interval is how often the peer and container faults are injected.
+	Interval time.Duration
+	// StoreDelay is the maximum random delay for delivering cluster store changes to the machine controllers
+	// which simulates a slow store replication.
+	StoreDelay time.Duration
+	// PeerDrop is the probability of dropping a random WireGuard peer every interval.
+	PeerDrop float64
+	// PeerDropDuration is how long a dropped peer stays unreachable.
+	PeerDropDuration time.Duration
+	// ContainerKill is the probability of killing a random service container on the machine every interval.
+	ContainerKill float64
+	// Seed is the seed for the random number generator to reproduce a sequence of faults. Zero means a random seed.
+	Seed uint64
+}
+
+// DefaultConfig returns the configuration used when the chaos mode is enabled without options.
+func DefaultConfig() Config {
+	return Config{
+		Interval:         30 * time.Second,
+		StoreDelay:       2 * time.Second,
+		PeerDrop:         0.1,
+		PeerDropDuration: 20 * time.Second,
+		ContainerKill:    0.1,
+	}
+}
+
+// ParseConfig parses the chaos mode options in the key=value[,key=value...] format overriding the defaults, e.g.
+// "store-delay=5s,container-kill=0.5". An empty string or "on" returns the default configuration.
+func ParseConfig(s string) (Config, error) {
+	cfg := DefaultConfig()
+	if s == "" || s == "on" {
+		return cfg, nil
+	}
+
+	for _, opt := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(opt), "=")
+		if !ok {
+			return cfg, fmt.Errorf("invalid chaos option '%s', expected KEY=VALUE", opt)
+		}
+
+		var err error
+		switch key {
+		case "interval":
+			cfg.Interval, err = parseDuration(value)
+		case "store-delay":
+			cfg.StoreDelay, err = parseDuration(value)
+		case "peer-drop":
+			cfg.PeerDrop, err = parseProbability(value)
+		case "peer-drop-duration":
+			cfg.PeerDropDuration, err = parseDuration(value)
+		case "container-kill":
+			cfg.ContainerKill, err = parseProbability(value)
+		case "seed":
+			cfg.Seed, err = strconv.ParseUint(value, 10, 64)
+		default:
+			return cfg, fmt.Errorf("unknown chaos option '%s'", key)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("invalid chaos option '%s': %w", opt, err)
+		}
+	}
+
+	if cfg.Interval <= 0 && (cfg.PeerDrop > 0 || cfg.ContainerKill > 0) {
+		return cfg, fmt.Errorf("interval must be positive to drop peers or kill containers")
+	}
+	return cfg, nil
+}
+
+func parseDuration(v string) (time.Duration, error) {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
+
+func parseProbability(v string) (float64, error) {
+	p, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, err
+	}
+	if p < 0 || p > 1 {
+		return 0, fmt.Errorf("must be between 0 and 1")
+	}
+	return p, nil
+}
+
+// Network is the WireGuard network of the machine which peers can be dropped.
+type Network interface {
+	Peers() []secret.Secret
+	DropPeer(publicKey secret.Secret, d time.Duration) error
+}
+
+// Containers lists and kills the service containers on the machine.
+type Containers interface {
+	ListServiceContainers(
+		ctx context.Context, serviceNameOrID string, opts container.ListOptions,
+	) ([]api.ServiceContainer, error)
+	KillContainer(ctx context.Context, id string) error
+}
+
+// Monkey randomly injects faults into the machine according to the configuration.
+type Monkey struct {
+	config Config
+	// mu protects rnd which is shared by Run and StoreDelay called from the store subscriptions.
+	mu  sync.Mutex
+	rnd *rand.Rand
+	log *slog.Logger
+}
+
+func NewMonkey(config Config) *Monkey {
+	seed := config.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Monkey{
+		config: config,
+		rnd:    rand.New(rand.NewPCG(seed, seed)),
+		log:    slog.With("component", "chaos", "seed", seed),
+	}
+}
+
+// StoreDelay returns a random delay for delivering a cluster store change. It's safe to call on a nil monkey which
+// returns zero.
+func (m *Monkey) StoreDelay() time.Duration {
+	if m == nil || m.config.StoreDelay <= 0 {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return time.Duration(m.rnd.Int64N(int64(m.config.StoreDelay)))
+}
+
+// chance returns true with the given probability.
+func (m *Monkey) chance(p float64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rnd.Float64() < p
+}
+
+// pick returns a random index in [0, n).
+func (m *Monkey) pick(n int) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rnd.IntN(n)
+}
+
+// Run injects the peer and container faults every interval until the context is canceled.
+func (m *Monkey) Run(ctx context.Context, network Network, containers Containers) error {
+	m.log.Warn("Chaos mode is enabled, faults will be randomly injected into the machine.",
+		"interval", m.config.Interval, "store_delay", m.config.StoreDelay, "peer_drop", m.config.PeerDrop,
+		"container_kill", m.config.ContainerKill)
+	if m.config.Interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if m.chance(m.config.PeerDrop) {
+				m.dropPeer(network)
+			}
+			if m.chance(m.config.ContainerKill) {
+				m.killContainer(ctx, containers)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *Monkey) dropPeer(network Network) {
+	peers := network.Peers()
+	if len(peers) == 0 {
+		return
+	}
+	peer := peers[m.pick(len(peers))]
+	if err := network.DropPeer(peer, m.config.PeerDropDuration); err != nil {
+		m.log.Error("Failed to drop WireGuard peer.", "public_key", peer, "err", err)
+		return
+	}
+	m.log.Info("Dropped WireGuard peer.", "public_key", peer, "duration", m.config.PeerDropDuration)
+}
+
+func (m *Monkey) killContainer(ctx context.Context, containers Containers) {
+	ctrs, err := containers.ListServiceContainers(ctx, "", container.ListOptions{})
+	if err != nil {
+		m.log.Error("Failed to list service containers.", "err", err)
+		return
+	}
+	if len(ctrs) == 0 {
+		return
+	}
+	ctr := ctrs[m.pick(len(ctrs))]
+	if err = containers.KillContainer(ctx, ctr.ID); err != nil {
+		m.log.Error("Failed to kill service container.", "id", ctr.ID, "name", ctr.Name, "err", err)
+		return
+	}
+	m.log.Info("Killed service container.", "id", ctr.ID, "name", ctr.Name, "service", ctr.ServiceName())
+}
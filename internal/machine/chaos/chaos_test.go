@@ -0,0 +1,135 @@
+package chaos
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ParseConfig("on")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), cfg)
+
+	cfg, err = ParseConfig("store-delay=5s, container-kill=0.5,peer-drop=0,seed=42")
+	require.NoError(t, err)
+	want := DefaultConfig()
+	want.StoreDelay = 5 * time.Second
+	want.ContainerKill = 0.5
+	want.PeerDrop = 0
+	want.Seed = 42
+	assert.Equal(t, want, cfg)
+
+	tests := []struct {
+		opts    string
+		wantErr string
+	}{
+		{opts: "store-delay", wantErr: "expected KEY=VALUE"},
+		{opts: "unknown=1", wantErr: "unknown chaos option"},
+		{opts: "store-delay=-1s", wantErr: "must not be negative"},
+		{opts: "container-kill=2", wantErr: "must be between 0 and 1"},
+		{opts: "interval=0", wantErr: "interval must be positive"},
+	}
+	for _, tt := range tests {
+		_, err = ParseConfig(tt.opts)
+		assert.ErrorContains(t, err, tt.wantErr, tt.opts)
+	}
+}
+
+func TestMonkey_StoreDelay(t *testing.T) {
+	t.Parallel()
+
+	var m *Monkey
+	assert.Zero(t, m.StoreDelay())
+
+	m = NewMonkey(Config{StoreDelay: time.Second, Seed: 1})
+	for range 100 {
+		d := m.StoreDelay()
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.Less(t, d, time.Second)
+	}
+
+	// The same seed reproduces the same sequence of delays.
+	m1 := NewMonkey(Config{StoreDelay: time.Second, Seed: 7})
+	m2 := NewMonkey(Config{StoreDelay: time.Second, Seed: 7})
+	for range 10 {
+		assert.Equal(t, m1.StoreDelay(), m2.StoreDelay())
+	}
+}
+
+type fakeNetwork struct {
+	mu      sync.Mutex
+	dropped []secret.Secret
+}
+
+func (n *fakeNetwork) Peers() []secret.Secret {
+	return []secret.Secret{secret.Secret("peer")}
+}
+
+func (n *fakeNetwork) DropPeer(publicKey secret.Secret, _ time.Duration) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.dropped = append(n.dropped, publicKey)
+	return nil
+}
+
+type fakeContainers struct {
+	mu     sync.Mutex
+	killed []string
+}
+
+func (c *fakeContainers) ListServiceContainers(
+	context.Context, string, container.ListOptions,
+) ([]api.ServiceContainer, error) {
+	return []api.ServiceContainer{{Container: api.Container{ContainerJSON: types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: "c1", Name: "web-1"},
+		Config:            &container.Config{},
+	}}}}, nil
+}
+
+func (c *fakeContainers) KillContainer(_ context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.killed = append(c.killed, id)
+	return nil
+}
+
+func TestMonkey_Run(t *testing.T) {
+	t.Parallel()
+
+	m := NewMonkey(Config{
+		Interval:      10 * time.Millisecond,
+		PeerDrop:      1,
+		ContainerKill: 1,
+	})
+	network := &fakeNetwork{}
+	containers := &fakeContainers{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- m.Run(ctx, network, containers)
+	}()
+
+	assert.Eventually(t, func() bool {
+		network.mu.Lock()
+		defer network.mu.Unlock()
+		containers.mu.Lock()
+		defer containers.mu.Unlock()
+		return len(network.dropped) > 0 && len(containers.killed) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, "c1", containers.killed[0])
+}
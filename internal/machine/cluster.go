@@ -4,26 +4,55 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"net"
 	"net/netip"
+	"path/filepath"
+	"runtime"
 	"slices"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/autoupdate"
+	"github.com/psviderski/uncloud/internal/machine/backup"
 	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/internal/machine/chaos"
 	"github.com/psviderski/uncloud/internal/machine/constants"
 	"github.com/psviderski/uncloud/internal/machine/corroservice"
 	"github.com/psviderski/uncloud/internal/machine/dns"
 	"github.com/psviderski/uncloud/internal/machine/docker"
 	"github.com/psviderski/uncloud/internal/machine/firewall"
+	"github.com/psviderski/uncloud/internal/machine/httpapi"
+	"github.com/psviderski/uncloud/internal/machine/ingress"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/postgres"
+	"github.com/psviderski/uncloud/internal/machine/scaling"
+	"github.com/psviderski/uncloud/internal/machine/settings"
 	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/internal/machine/uptime"
+	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/unregistry"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// endpointsCheckInterval is the interval at which the machine checks if its WireGuard endpoints have changed.
+	endpointsCheckInterval = time.Minute
+	// dnsEndpointsResolveInterval is the interval at which the DNS endpoints of other machines are re-resolved.
+	dnsEndpointsResolveInterval = time.Minute
+	// resourcesUpdateInterval is the default interval at which the machine refreshes its resource inventory
+	// in the cluster store, e.g. after Docker is upgraded or the machine is resized. It can be changed with
+	// the resources update interval in the cluster settings.
+	resourcesUpdateInterval = 10 * time.Minute
+	// daemonRegistriesStateFileName is the name of the file in the data directory that tracks the registry mirrors
+	// set in the Docker daemon config from the cluster settings.
+	daemonRegistriesStateFileName = "docker-registries.json"
 )
 
 // clusterController is the main controller for the machine that is a cluster member. It manages components such as
@@ -32,6 +61,10 @@ import (
 type clusterController struct {
 	state *State
 	store *store.Store
+	// settings keeps the cluster-wide settings up to date with the cluster store.
+	settings *settings.Watcher
+	// dataDir is the directory where the machine stores its persistent state, e.g. the boot report.
+	dataDir string
 
 	wgnet           *network.WireGuardNetwork
 	endpointChanges <-chan network.EndpointChangeEvent
@@ -39,16 +72,38 @@ type clusterController struct {
 	server       *grpc.Server
 	corroService corroservice.Service
 	dockerCtrl   *docker.Controller
+	// dockerService is used to collect the resource inventory of the machine.
+	dockerService *docker.Service
 	// dockerReady is signalled when Docker is configured and ready for containers.
 	dockerReady     chan<- struct{}
 	caddyconfigCtrl *caddyconfig.Controller
+	uptimeChecker   *uptime.Checker
+	autoUpdateCtrl  *autoupdate.Controller
+	backupAgent     *backup.Agent
+	postgresAgent   *postgres.Agent
+	scaleCtrl       *scaling.Controller
+	dnsFailover     *ingress.DNSFailover
+	storageSync     *ingress.StorageSync
 
 	// dnsServer is the embedded internal DNS server for the cluster listening on the machine IP.
 	dnsServer   *dns.Server
 	dnsResolver *dns.ClusterResolver
 	// unregistry is the embedded container registry that uses the local Docker (containerd) image store as its backend.
 	unregistry *unregistry.Registry
+	// httpAPI is the optional read-only HTTP JSON API server. Nil if the API is disabled.
+	httpAPI *httpapi.Server
+	// chaos randomly injects faults into the machine if the chaos mode is enabled. Nil otherwise.
+	chaos *chaos.Monkey
 
+	// dnsEndpoints caches the resolved IP endpoints for the DNS endpoints of other machines. It's only accessed from
+	// the goroutine handling machine changes.
+	dnsEndpoints map[string][]netip.AddrPort
+	// peerings are the peerings with other clusters whose machines are configured as network peers. It's only
+	// accessed from the goroutine handling machine changes.
+	peerings []api.ClusterPeering
+
+	// shuttingDown is set when the machine is prepared for a shutdown to prevent it from being uncordoned.
+	shuttingDown atomic.Bool
 	// stopped is a channel that is closed when the controller is stopped.
 	stopped chan struct{}
 }
@@ -56,14 +111,25 @@ type clusterController struct {
 func newClusterController(
 	state *State,
 	store *store.Store,
+	settings *settings.Watcher,
+	dataDir string,
 	server *grpc.Server,
 	corroService corroservice.Service,
 	dockerService *docker.Service,
 	dockerReady chan<- struct{},
 	caddyfileCtrl *caddyconfig.Controller,
+	uptimeChecker *uptime.Checker,
+	autoUpdateCtrl *autoupdate.Controller,
+	backupAgent *backup.Agent,
+	postgresAgent *postgres.Agent,
+	scaleCtrl *scaling.Controller,
+	dnsFailover *ingress.DNSFailover,
+	storageSync *ingress.StorageSync,
 	dnsServer *dns.Server,
 	dnsResolver *dns.ClusterResolver,
 	unregistry *unregistry.Registry,
+	httpAPI *httpapi.Server,
+	chaos *chaos.Monkey,
 ) (*clusterController, error) {
 	slog.Info("Starting WireGuard network.")
 	wgnet, err := network.NewWireGuardNetwork()
@@ -75,16 +141,29 @@ func newClusterController(
 	return &clusterController{
 		state:           state,
 		store:           store,
+		settings:        settings,
+		dataDir:         dataDir,
 		wgnet:           wgnet,
 		endpointChanges: endpointChanges,
 		server:          server,
 		corroService:    corroService,
-		dockerCtrl:      docker.NewController(state.ID, dockerService, store),
+		dockerCtrl:      docker.NewController(state.ID, dockerService, store, settings),
+		dockerService:   dockerService,
 		dockerReady:     dockerReady,
 		caddyconfigCtrl: caddyfileCtrl,
+		uptimeChecker:   uptimeChecker,
+		autoUpdateCtrl:  autoUpdateCtrl,
+		backupAgent:     backupAgent,
+		postgresAgent:   postgresAgent,
+		scaleCtrl:       scaleCtrl,
+		dnsFailover:     dnsFailover,
+		storageSync:     storageSync,
 		dnsServer:       dnsServer,
 		dnsResolver:     dnsResolver,
 		unregistry:      unregistry,
+		httpAPI:         httpAPI,
+		chaos:           chaos,
+		dnsEndpoints:    make(map[string][]netip.AddrPort),
 		stopped:         make(chan struct{}),
 	}, nil
 }
@@ -106,6 +185,11 @@ func (cc *clusterController) Run(ctx context.Context) error {
 	}
 	slog.Info("WireGuard network configured.")
 
+	if err := cc.recoverAfterReboot(ctx); err != nil {
+		// Recovery is best-effort and must not prevent the machine from running.
+		slog.Error("Failed to recover machine state after reboot.", "err", err)
+	}
+
 	if cc.corroService.Running() {
 		// Corrosion service was running before the WireGuard network was configured so we need to restart it.
 		slog.Info("Restarting corrosion service to apply new configuration with WireGuard network.")
@@ -154,12 +238,44 @@ func (cc *clusterController) Run(ctx context.Context) error {
 		return nil
 	})
 
+	errGroup.Go(func() error {
+		slog.Info("Watching cluster settings.")
+		return cc.settings.Run(ctx)
+	})
+
 	// Synchronise Docker containers to the cluster store.
 	errGroup.Go(func() error {
 		slog.Info("Watching Docker containers and syncing them to cluster store.")
 		return cc.syncDockerContainers(ctx)
 	})
 
+	errGroup.Go(func() error {
+		return cc.dockerCtrl.RunImageGC(ctx)
+	})
+
+	errGroup.Go(func() error {
+		return cc.dockerCtrl.RunTrashGC(ctx)
+	})
+
+	errGroup.Go(func() error {
+		return cc.dockerCtrl.RunDaemonConfig(ctx, filepath.Join(cc.dataDir, daemonRegistriesStateFileName))
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting egress routing controller.")
+		return cc.runEgress(ctx)
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting ingress HA controller.")
+		return cc.runIngressHA(ctx)
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting tenant isolation controller.")
+		return cc.runTenantIsolation(ctx)
+	})
+
 	// Handle machine changes in the cluster. Handling machine and endpoint changes should be done
 	// in separate goroutines to avoid a deadlock when reconfiguring the network.
 	errGroup.Go(func() error {
@@ -200,6 +316,12 @@ func (cc *clusterController) Run(ctx context.Context) error {
 		}
 	})
 
+	// Periodically detect changes of the machine IPs and announce the new endpoints to other machines.
+	errGroup.Go(func() error {
+		cc.watchOwnEndpoints(ctx)
+		return nil
+	})
+
 	errGroup.Go(func() error {
 		if err := cc.wgnet.Run(ctx); err != nil {
 			return fmt.Errorf("WireGuard network failed: %w", err)
@@ -215,6 +337,62 @@ func (cc *clusterController) Run(ctx context.Context) error {
 		return nil
 	})
 
+	errGroup.Go(func() error {
+		slog.Info("Starting uptime checker.")
+		if err := cc.uptimeChecker.Run(ctx); err != nil {
+			return fmt.Errorf("uptime checker failed: %w", err)
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting auto-update controller.")
+		if err := cc.autoUpdateCtrl.Run(ctx); err != nil {
+			return fmt.Errorf("auto-update controller failed: %w", err)
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting backup agent.")
+		if err := cc.backupAgent.Run(ctx); err != nil {
+			return fmt.Errorf("backup agent failed: %w", err)
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting Postgres failover agent.")
+		if err := cc.postgresAgent.Run(ctx); err != nil {
+			return fmt.Errorf("postgres failover agent failed: %w", err)
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting scale scheduler.")
+		if err := cc.scaleCtrl.Run(ctx); err != nil {
+			return fmt.Errorf("scale scheduler failed: %w", err)
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting DNS failover of ingress machines.")
+		if err := cc.dnsFailover.Run(ctx); err != nil {
+			return fmt.Errorf("DNS failover failed: %w", err)
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting Caddy storage sync.")
+		if err := cc.storageSync.Run(ctx); err != nil {
+			return fmt.Errorf("caddy storage sync failed: %w", err)
+		}
+		return nil
+	})
+
 	if cc.unregistry != nil {
 		errGroup.Go(func() error {
 			slog.Info("Starting unregistry server.")
@@ -225,6 +403,21 @@ func (cc *clusterController) Run(ctx context.Context) error {
 		})
 	}
 
+	if cc.httpAPI != nil {
+		errGroup.Go(func() error {
+			if err := cc.httpAPI.Run(ctx); err != nil {
+				return fmt.Errorf("HTTP API server failed: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if cc.chaos != nil {
+		errGroup.Go(func() error {
+			return cc.chaos.Run(ctx, cc.wgnet, cc.dockerService)
+		})
+	}
+
 	// Wait for the context to be done and stop the network API server.
 	<-ctx.Done()
 	slog.Info("Stopping network API server.")
@@ -281,6 +474,86 @@ func (cc *clusterController) ensureDockerNetwork(ctx context.Context) error {
 	return nil
 }
 
+// recoverAfterReboot reconciles the machine state on the first start of the daemon after a reboot and saves
+// the recovery report. It must be called after the WireGuard and Docker networks are configured.
+func (cc *clusterController) recoverAfterReboot(ctx context.Context) error {
+	bootID, bootTime, err := currentBoot()
+	if err != nil {
+		return err
+	}
+	last, err := ParseBootReport(BootReportPath(cc.dataDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		// The daemon is started for the first time or after an upgrade from a version without boot reports. It's
+		// unknown whether the machine has been rebooted so only record the current boot without recovering.
+		report := BootReport{
+			BootID:      bootID,
+			BootTime:    bootTime,
+			RecoveredAt: time.Now().UTC(),
+		}
+		if err = report.Save(BootReportPath(cc.dataDir)); err != nil {
+			return fmt.Errorf("save boot report: %w", err)
+		}
+		return nil
+	}
+	if err == nil && last.BootID == bootID {
+		// The daemon has been restarted without a reboot. Start the containers if it was restarted after preparing
+		// for a shutdown that didn't happen.
+		cc.startShutdownStoppedContainers(ctx)
+		return nil
+	}
+	slog.Info("Machine has been rebooted, recovering its state.", "boot_time", bootTime)
+
+	report := BootReport{
+		BootID:   bootID,
+		BootTime: bootTime,
+	}
+	if cc.wgnet.Created() {
+		report.Actions = append(report.Actions, RecoveryAction{
+			Resource: "WireGuard interface " + network.WireGuardInterfaceName,
+			Action:   "create",
+		})
+	}
+
+	ctrActions, err := cc.dockerCtrl.RecoverContainers(ctx, bootTime)
+	if err != nil {
+		report.Actions = append(report.Actions, RecoveryAction{
+			Resource: "containers",
+			Action:   "recover",
+			Error:    err.Error(),
+		})
+	}
+	for _, a := range ctrActions {
+		action := RecoveryAction{
+			Resource: "container " + a.Container,
+			Action:   a.Action,
+		}
+		if a.Err != nil {
+			action.Error = a.Err.Error()
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	// Start the containers gracefully stopped before the planned shutdown. They aren't started by Docker or
+	// RecoverContainers as they were stopped explicitly.
+	for _, a := range cc.startShutdownStoppedContainers(ctx) {
+		action := RecoveryAction{
+			Resource: "container " + a.Container,
+			Action:   "start (stopped on shutdown)",
+		}
+		if a.Err != nil {
+			action.Error = a.Err.Error()
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	report.RecoveredAt = time.Now().UTC()
+	if err = report.Save(BootReportPath(cc.dataDir)); err != nil {
+		return fmt.Errorf("save boot report: %w", err)
+	}
+	slog.Info("Machine state recovered after reboot.", "actions", len(report.Actions))
+	return nil
+}
+
 // syncDockerContainers watches local Docker containers and syncs them to the cluster store.
 // TODO: move this to the Docker controller.
 func (cc *clusterController) syncDockerContainers(ctx context.Context) error {
@@ -310,6 +583,7 @@ func (cc *clusterController) syncDockerContainers(ctx context.Context) error {
 // handleMachineChanges subscribes to machine changes in the cluster and reconfigures the network peers accordingly
 // when changes occur.
 func (cc *clusterController) handleMachineChanges(ctx context.Context) error {
+	settingsChanges := cc.settings.Subscribe()
 	for {
 		// Retry to subscribe to machine changes indefinitely until the context is done.
 		boff := backoff.WithContext(backoff.NewExponentialBackOff(
@@ -319,13 +593,18 @@ func (cc *clusterController) handleMachineChanges(ctx context.Context) error {
 		), ctx)
 
 		var (
-			machines []*pb.MachineInfo
-			changes  <-chan struct{}
-			err      error
+			machines        []*pb.MachineInfo
+			changes         <-chan struct{}
+			peeringsChanges <-chan struct{}
+			err             error
 		)
 		subscribe := func() error {
 			if machines, changes, err = cc.store.SubscribeMachines(ctx); err != nil {
 				slog.Info("Failed to subscribe to machine changes, retrying.", "err", err)
+				return err
+			}
+			if cc.peerings, peeringsChanges, err = cc.store.SubscribeClusterPeerings(ctx); err != nil {
+				slog.Info("Failed to subscribe to cluster peering changes, retrying.", "err", err)
 			}
 			return err
 		}
@@ -341,11 +620,21 @@ func (cc *clusterController) handleMachineChanges(ctx context.Context) error {
 		// The machine store may be empty when a machine first joins the cluster, before store synchronization
 		// completes. Skip configuration now and apply it when the store changes are received.
 		if len(machines) > 0 {
+			cc.updateMachineArch(ctx, machines)
+			cc.updateMachineResources(ctx, machines)
+			cc.uncordon(ctx, machines)
+			cc.resolveDNSEndpoints(ctx, machines)
+
 			slog.Info("Reconfiguring network peers with the current machines.", "machines", len(machines))
-			if err = cc.configurePeers(machines); err != nil {
+			if err = cc.configurePeers(ctx, machines); err != nil {
 				slog.Error("Failed to configure peers.", "err", err)
 			}
 		}
+
+		resolveTicker := time.NewTicker(dnsEndpointsResolveInterval)
+		egressGateway := cc.egressGateway()
+		resourcesInterval := cc.resourcesUpdateInterval()
+		resourcesTicker := time.NewTicker(resourcesInterval)
 		// For simplicity, reconfigure all peers on any change.
 		for {
 			select {
@@ -358,17 +647,280 @@ func (cc *clusterController) handleMachineChanges(ctx context.Context) error {
 					slog.Error("Failed to list machines.", "err", err)
 					continue
 				}
-				if err = cc.configurePeers(machines); err != nil {
+				cc.resolveDNSEndpoints(ctx, machines)
+				if err = cc.configurePeers(ctx, machines); err != nil {
+					slog.Error("Failed to configure peers.", "err", err)
+				}
+			case <-peeringsChanges:
+				slog.Info("Cluster peerings changed, reconfiguring network peers.")
+				if cc.peerings, err = cc.store.ListClusterPeerings(ctx); err != nil {
+					slog.Error("Failed to list cluster peerings.", "err", err)
+					continue
+				}
+				if machines, err = cc.store.ListMachines(ctx); err != nil {
+					slog.Error("Failed to list machines.", "err", err)
+					continue
+				}
+				if err = cc.configurePeers(ctx, machines); err != nil {
 					slog.Error("Failed to configure peers.", "err", err)
 				}
+			case <-resolveTicker.C:
+				if machines, err = cc.store.ListMachines(ctx); err != nil {
+					slog.Error("Failed to list machines.", "err", err)
+					continue
+				}
+				if !cc.resolveDNSEndpoints(ctx, machines) {
+					continue
+				}
+				slog.Info("DNS endpoints of machines resolved to new IPs, reconfiguring network peers.")
+				if err = cc.configurePeers(ctx, machines); err != nil {
+					slog.Error("Failed to configure peers.", "err", err)
+				}
+			case <-resourcesTicker.C:
+				if machines, err = cc.store.ListMachines(ctx); err != nil {
+					slog.Error("Failed to list machines.", "err", err)
+					continue
+				}
+				cc.updateMachineResources(ctx, machines)
+			case <-settingsChanges:
+				if interval := cc.resourcesUpdateInterval(); interval != resourcesInterval {
+					resourcesInterval = interval
+					resourcesTicker.Reset(resourcesInterval)
+					slog.Info("Changed interval to update machine resources.", "interval", resourcesInterval)
+				}
+				if gateway := cc.egressGateway(); gateway != egressGateway {
+					egressGateway = gateway
+					slog.Info("Egress gateway changed, reconfiguring network peers.", "gateway", egressGateway)
+					if machines, err = cc.store.ListMachines(ctx); err != nil {
+						slog.Error("Failed to list machines.", "err", err)
+						continue
+					}
+					if err = cc.configurePeers(ctx, machines); err != nil {
+						slog.Error("Failed to configure peers.", "err", err)
+					}
+				}
 			case <-ctx.Done():
+				resolveTicker.Stop()
+				resourcesTicker.Stop()
 				return nil
 			}
 		}
 	}
 }
 
-func (cc *clusterController) configurePeers(machines []*pb.MachineInfo) error {
+// resourcesUpdateInterval returns the interval to refresh the machine resources from the cluster settings or
+// the default resourcesUpdateInterval if not set.
+func (cc *clusterController) resourcesUpdateInterval() time.Duration {
+	if interval := cc.settings.Get().ResourcesUpdateInterval; interval > 0 {
+		return interval
+	}
+	return resourcesUpdateInterval
+}
+
+// resolveDNSEndpoints resolves the DNS endpoints of other machines and updates the cache of resolved endpoints.
+// It returns true if any of the resolved endpoints changed. If a DNS endpoint fails to resolve, its previously
+// resolved endpoints are kept.
+func (cc *clusterController) resolveDNSEndpoints(ctx context.Context, machines []*pb.MachineInfo) bool {
+	resolved := make(map[string][]netip.AddrPort)
+	for _, m := range machines {
+		if m.Id == cc.state.ID {
+			continue
+		}
+		for _, ep := range m.Network.DnsEndpoints {
+			if _, ok := resolved[ep]; ok {
+				continue
+			}
+			addrPorts, err := network.ResolveDNSEndpoint(ctx, ep)
+			if err != nil {
+				slog.Warn("Failed to resolve DNS endpoint of machine.", "machine", m.Name, "err", err)
+				addrPorts = cc.dnsEndpoints[ep]
+			}
+			resolved[ep] = addrPorts
+		}
+	}
+
+	changed := len(resolved) != len(cc.dnsEndpoints)
+	for ep, addrPorts := range resolved {
+		if !sameEndpoints(addrPorts, cc.dnsEndpoints[ep]) {
+			changed = true
+		}
+	}
+	cc.dnsEndpoints = resolved
+
+	return changed
+}
+
+// updateMachineArch records the CPU architecture of the current machine in the cluster store if it's missing or
+// outdated, for example, when the machine was added to the cluster by an older daemon version.
+func (cc *clusterController) updateMachineArch(ctx context.Context, machines []*pb.MachineInfo) {
+	for _, m := range machines {
+		if m.Id != cc.state.ID {
+			continue
+		}
+		if m.Arch == runtime.GOARCH {
+			return
+		}
+
+		updated := proto.Clone(m).(*pb.MachineInfo)
+		updated.Arch = runtime.GOARCH
+		if err := cc.store.UpdateMachine(ctx, updated); err != nil {
+			slog.Error("Failed to update machine architecture in cluster store.", "err", err)
+			return
+		}
+		slog.Info("Updated machine architecture in cluster store.", "arch", updated.Arch)
+		return
+	}
+}
+
+// updateMachineResources records the resource inventory of the current machine in the cluster store if it's missing
+// or changed.
+func (cc *clusterController) updateMachineResources(ctx context.Context, machines []*pb.MachineInfo) {
+	for _, m := range machines {
+		if m.Id != cc.state.ID {
+			continue
+		}
+
+		resources, _, err := collectResources(ctx, cc.dockerService.Client)
+		if err != nil {
+			slog.Error("Failed to collect machine resources.", "err", err)
+			return
+		}
+		if proto.Equal(m.Resources, resources) {
+			return
+		}
+
+		updated := proto.Clone(m).(*pb.MachineInfo)
+		updated.Resources = resources
+		if err = cc.store.UpdateMachine(ctx, updated); err != nil {
+			slog.Error("Failed to update machine resources in cluster store.", "err", err)
+			return
+		}
+		slog.Info("Updated machine resources in cluster store.", "cpus", resources.Cpus,
+			"memory", resources.Memory, "disk", resources.Disk, "docker_version", resources.DockerVersion)
+		return
+	}
+}
+
+// watchOwnEndpoints periodically detects the WireGuard endpoints of the current machine and updates them in the cluster
+// store if they changed, for example, when the ISP or cloud provider reassigned the public IP. Other machines then
+// reconfigure their peers with the new endpoints when they receive the machine change. The update is able to reach
+// them even if they only know the stale endpoints because WireGuard learns the new peer endpoint from the authenticated
+// packets sent from it (roaming).
+func (cc *clusterController) watchOwnEndpoints(ctx context.Context) {
+	ticker := time.NewTicker(endpointsCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := cc.updateOwnEndpoints(ctx); err != nil {
+			slog.Error("Failed to update machine endpoints in cluster store.", "err", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updateOwnEndpoints updates the endpoints of the current machine in the cluster store if the detected endpoints
+// differ from the announced ones. It's a no-op if the endpoints were set manually.
+func (cc *clusterController) updateOwnEndpoints(ctx context.Context) error {
+	m, err := cc.store.GetMachine(ctx, cc.state.ID)
+	if err != nil {
+		if errors.Is(err, store.ErrMachineNotFound) {
+			// The store may not be synchronised yet when the machine has just joined the cluster.
+			return nil
+		}
+		return fmt.Errorf("get machine: %w", err)
+	}
+	if m.ManualEndpoints {
+		return nil
+	}
+
+	current := make([]netip.AddrPort, 0, len(m.Network.Endpoints))
+	for _, ep := range m.Network.Endpoints {
+		if addrPort, err := ep.ToAddrPort(); err == nil {
+			current = append(current, addrPort)
+		}
+	}
+
+	detected, publicIP, err := detectEndpoints()
+	if err != nil {
+		return err
+	}
+	detected, changed := changedEndpoints(current, detected, publicIP.IsValid())
+	if !changed {
+		return nil
+	}
+
+	updated := proto.Clone(m).(*pb.MachineInfo)
+	updated.Network.Endpoints = make([]*pb.IPPort, len(detected))
+	for i, addrPort := range detected {
+		updated.Network.Endpoints[i] = pb.NewIPPort(addrPort)
+	}
+	if err = cc.store.UpdateMachine(ctx, updated); err != nil {
+		return fmt.Errorf("update machine: %w", err)
+	}
+	slog.Info("Machine endpoints changed, announced new endpoints to the cluster.",
+		"old", current, "new", detected)
+
+	return nil
+}
+
+// changedEndpoints returns the endpoints to announce instead of the current ones and true if they differ from
+// the current ones. The last known public endpoints are kept if the public IP lookup failed.
+func changedEndpoints(current, detected []netip.AddrPort, publicIPDetected bool) ([]netip.AddrPort, bool) {
+	if !publicIPDetected {
+		// The public IP lookup services may be temporarily unreachable. Keep announcing the last known public
+		// endpoints rather than dropping them and leaving the machine unreachable from other networks.
+		for _, addrPort := range current {
+			if !addrPort.Addr().IsPrivate() && !slices.Contains(detected, addrPort) {
+				detected = append(detected, addrPort)
+			}
+		}
+	}
+	if len(detected) == 0 {
+		// Keep announcing the last known endpoints rather than leaving the machine unreachable.
+		return current, false
+	}
+	return detected, !sameEndpoints(current, detected)
+}
+
+// peerEndpoints returns all IP endpoints of a peer machine. The resolved DNS endpoints are preferred over the IP
+// endpoints as they're explicitly configured to keep the machine reachable when its IP changes.
+func peerEndpoints(netConfig *pb.NetworkConfig, dnsEndpoints map[string][]netip.AddrPort) []netip.AddrPort {
+	endpoints := make([]netip.AddrPort, 0, len(netConfig.Endpoints))
+	for _, ep := range netConfig.DnsEndpoints {
+		for _, addrPort := range dnsEndpoints[ep] {
+			if !slices.Contains(endpoints, addrPort) {
+				endpoints = append(endpoints, addrPort)
+			}
+		}
+	}
+	for _, ep := range netConfig.Endpoints {
+		// Ignore errors as the network config is already validated.
+		addrPort, _ := ep.ToAddrPort()
+		if !slices.Contains(endpoints, addrPort) {
+			endpoints = append(endpoints, addrPort)
+		}
+	}
+	return endpoints
+}
+
+// sameEndpoints returns true if both lists contain the same endpoints regardless of their order.
+func sameEndpoints(a, b []netip.AddrPort) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.SortFunc(a, netip.AddrPort.Compare)
+	slices.SortFunc(b, netip.AddrPort.Compare)
+	return slices.Equal(a, b)
+}
+
+func (cc *clusterController) configurePeers(ctx context.Context, machines []*pb.MachineInfo) error {
 	if len(machines) == 0 {
 		return fmt.Errorf("no machines to configure peers")
 	}
@@ -380,6 +932,7 @@ func (cc *clusterController) configurePeers(machines []*pb.MachineInfo) error {
 	}
 	cc.state.mu.RUnlock()
 
+	egressGateway := cc.egressGateway()
 	// Construct the list of peers from the machine configurations ensuring that the current endpoint is preserved.
 	peers := make([]network.PeerConfig, 0, len(machines)-1)
 	for _, m := range machines {
@@ -394,16 +947,13 @@ func (cc *clusterController) configurePeers(machines []*pb.MachineInfo) error {
 		// Ignore errors as they are already validated.
 		subnet, _ := m.Network.Subnet.ToPrefix()
 		manageIP, _ := m.Network.ManagementIp.ToAddr()
-		endpoints := make([]netip.AddrPort, len(m.Network.Endpoints))
-		for i, ep := range m.Network.Endpoints {
-			addrPort, _ := ep.ToAddrPort()
-			endpoints[i] = addrPort
-		}
+		endpoints := peerEndpoints(m.Network, cc.dnsEndpoints)
 		peer := network.PeerConfig{
-			Subnet:       &subnet,
-			ManagementIP: manageIP,
-			AllEndpoints: endpoints,
-			PublicKey:    m.Network.PublicKey,
+			Subnet:        &subnet,
+			ManagementIP:  manageIP,
+			AllEndpoints:  endpoints,
+			PublicKey:     m.Network.PublicKey,
+			EgressGateway: m.Id == egressGateway,
 		}
 
 		currentEndpoint := currentPeerEndpoints[peer.PublicKey.String()]
@@ -415,6 +965,11 @@ func (cc *clusterController) configurePeers(machines []*pb.MachineInfo) error {
 
 		peers = append(peers, peer)
 	}
+	// Filter the traffic from the peered clusters before routing it to this machine.
+	if err := cc.configurePeeringFilters(ctx); err != nil {
+		return err
+	}
+	peers = append(peers, peeredClusterPeers(cc.peerings, currentPeerEndpoints)...)
 
 	// Preserve the new list of peers in the machine state.
 	cc.state.mu.Lock()
@@ -433,6 +988,63 @@ func (cc *clusterController) configurePeers(machines []*pb.MachineInfo) error {
 	return nil
 }
 
+// peeredClusterPeers returns the network peers for the machines of the peered clusters. Only the allowed IPs of
+// the peer machines are routed through the peers. The current endpoints of the existing peers are preserved.
+func peeredClusterPeers(
+	peerings []api.ClusterPeering, currentPeerEndpoints map[string]*netip.AddrPort,
+) []network.PeerConfig {
+	var peers []network.PeerConfig
+	for _, p := range peerings {
+		for _, m := range p.Machines {
+			prefixes := p.MachineAllowedIPs(m)
+			if len(prefixes) == 0 {
+				// None of the machine addresses are allowed to be reached.
+				continue
+			}
+			peer := network.PeerConfig{
+				AllEndpoints:   m.Endpoints,
+				PublicKey:      m.PublicKey,
+				PeeredPrefixes: prefixes,
+			}
+			currentEndpoint := currentPeerEndpoints[peer.PublicKey.String()]
+			if currentEndpoint != nil && slices.Contains(m.Endpoints, *currentEndpoint) {
+				peer.Endpoint = currentEndpoint
+			} else if len(m.Endpoints) > 0 {
+				peer.Endpoint = &m.Endpoints[0]
+			}
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+// configurePeeringFilters configures the firewall rules that drop the traffic from the peered clusters to the local
+// addresses outside the allowed IPs of the peerings.
+func (cc *clusterController) configurePeeringFilters(ctx context.Context) error {
+	var filters []firewall.PeeringFilter
+	if len(cc.peerings) > 0 {
+		clusterNetwork, err := cc.store.GetClusterNetwork(ctx)
+		if err != nil {
+			return fmt.Errorf("get cluster network: %w", err)
+		}
+		cc.state.mu.RLock()
+		subnet := cc.state.Network.Subnet
+		cc.state.mu.RUnlock()
+
+		for _, p := range cc.peerings {
+			filters = append(filters, firewall.PeeringFilter{
+				Network: p.Network,
+				Allowed: api.ScopePrefix(subnet, clusterNetwork, p.AllowedIPs),
+			})
+		}
+	}
+
+	if err := firewall.ConfigurePeeringFilters(filters); err != nil {
+		return fmt.Errorf("configure peering firewall rules: %w", err)
+	}
+	return nil
+}
+
 // Cleanup cleans up the cluster resources such as the WireGuard network, iptables rules, Docker network and containers.
 func (cc *clusterController) Cleanup() error {
 	// Wait for the controller to stop before cleaning up.
@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/autoupdate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetAutoUpdate returns the automatic OS updates configuration and the update statuses of the machines.
+func (c *Cluster) GetAutoUpdate(ctx context.Context, _ *emptypb.Empty) (*pb.AutoUpdate, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	config, err := c.store.GetAutoUpdateConfig(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get auto-update config: %v", err)
+	}
+	records, err := c.store.ListMachineUpdates(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list machine updates: %v", err)
+	}
+
+	machines := make([]*pb.MachineUpdate, len(records))
+	for i, r := range records {
+		machines[i] = &pb.MachineUpdate{
+			MachineId:   r.MachineID,
+			WindowStart: timestamppb.New(r.WindowStart),
+			State:       string(r.State),
+			Error:       r.Error,
+			UpdatedAt:   timestamppb.New(r.UpdatedAt),
+		}
+	}
+
+	return &pb.AutoUpdate{
+		Config: &pb.AutoUpdateConfig{
+			Enabled: config.Enabled,
+			Window:  config.Window,
+		},
+		Machines: machines,
+	}, nil
+}
+
+// SetAutoUpdate enables or disables automatic OS updates. The window is kept unchanged if not set.
+func (c *Cluster) SetAutoUpdate(ctx context.Context, req *pb.AutoUpdateConfig) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	config, err := c.store.GetAutoUpdateConfig(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get auto-update config: %v", err)
+	}
+	config.Enabled = req.Enabled
+	if req.Window != "" {
+		if _, err = autoupdate.ParseWindow(req.Window); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		config.Window = req.Window
+	}
+	if config.Enabled && config.Window == "" {
+		return nil, status.Error(codes.InvalidArgument, "maintenance window not set")
+	}
+
+	if err = c.store.PutAutoUpdateConfig(ctx, config); err != nil {
+		return nil, status.Errorf(codes.Internal, "store auto-update config: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
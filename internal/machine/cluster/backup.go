@@ -0,0 +1,154 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/dbbackup"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// backupChunkSize is the size of the chunks a backup is streamed in by DownloadBackup.
+const backupChunkSize = 64 * 1024
+
+// GetBackupStorage returns the configuration of the S3-compatible object storage for service backups. The secret
+// access key is omitted as the machines access the storage on behalf of the clients.
+func (c *Cluster) GetBackupStorage(ctx context.Context, _ *emptypb.Empty) (*pb.BackupStorage, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	config, err := c.store.GetBackupStorage(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get backup storage config: %v", err)
+	}
+	if config.Endpoint == "" {
+		return nil, status.Error(codes.NotFound, "backup storage not configured")
+	}
+
+	return &pb.BackupStorage{
+		Endpoint:    config.Endpoint,
+		Region:      config.Region,
+		Bucket:      config.Bucket,
+		Prefix:      config.Prefix,
+		AccessKeyId: config.AccessKeyID,
+	}, nil
+}
+
+// SetBackupStorage configures the S3-compatible object storage for service backups.
+func (c *Cluster) SetBackupStorage(ctx context.Context, req *pb.BackupStorage) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	config := api.BackupStorage{
+		Endpoint:        req.Endpoint,
+		Region:          req.Region,
+		Bucket:          req.Bucket,
+		Prefix:          req.Prefix,
+		AccessKeyID:     req.AccessKeyId,
+		SecretAccessKey: req.SecretAccessKey,
+	}
+	if err := config.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := c.store.PutBackupStorage(ctx, config); err != nil {
+		return nil, status.Errorf(codes.Internal, "store backup storage config: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListBackups lists the backups of a service in the backup storage sorted by creation time, newest first.
+func (c *Cluster) ListBackups(ctx context.Context, req *pb.ListBackupsRequest) (*pb.ListBackupsResponse, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+	if req.ServiceName == "" {
+		return nil, status.Error(codes.InvalidArgument, "service name must be set")
+	}
+
+	storage, err := c.backupStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := storage.List(ctx, dbbackup.ServicePrefix(storage.Prefix(), req.ServiceName))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list backups: %v", err)
+	}
+	backupsBytes, err := json.Marshal(dbbackup.List(objects, storage.Prefix(), req.ServiceName))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal backups: %v", err)
+	}
+	return &pb.ListBackupsResponse{Backups: backupsBytes}, nil
+}
+
+// DownloadBackup streams the content of a backup from the backup storage. Only the keys of the backups within
+// the storage prefix can be downloaded.
+func (c *Cluster) DownloadBackup(
+	req *pb.DownloadBackupRequest, stream grpc.ServerStreamingServer[pb.BackupChunk],
+) error {
+	ctx := stream.Context()
+	if err := c.checkInitialised(ctx); err != nil {
+		return err
+	}
+
+	storage, err := c.backupStorage(ctx)
+	if err != nil {
+		return err
+	}
+	b, err := dbbackup.ParseKey(storage.Prefix(), req.Key)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !strings.HasPrefix(req.Key, dbbackup.ServicePrefix(storage.Prefix(), b.Service)) {
+		return status.Errorf(codes.InvalidArgument, "backup key '%s' is outside the backup storage prefix", req.Key)
+	}
+
+	rc, err := storage.Get(ctx, req.Key)
+	if err != nil {
+		return status.Errorf(codes.Internal, "download backup: %v", err)
+	}
+	defer rc.Close()
+
+	buf := make([]byte, backupChunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			if sErr := stream.Send(&pb.BackupChunk{Data: buf[:n]}); sErr != nil {
+				return sErr
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return status.Errorf(codes.Internal, "download backup: %v", err)
+		}
+	}
+}
+
+// backupStorage returns a client for the configured backup storage.
+func (c *Cluster) backupStorage(ctx context.Context) (*dbbackup.Storage, error) {
+	config, err := c.store.GetBackupStorage(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get backup storage config: %v", err)
+	}
+	if config.Endpoint == "" {
+		return nil, status.Error(codes.FailedPrecondition,
+			"backup storage not configured, configure it with 'uc backup storage set'")
+	}
+	storage, err := dbbackup.NewStorage(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create backup storage client: %v", err)
+	}
+	return storage, nil
+}
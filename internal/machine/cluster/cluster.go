@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/netip"
+	"sync"
 	"time"
 
 	"github.com/psviderski/uncloud/internal/corrosion"
@@ -14,8 +16,10 @@ import (
 	"github.com/psviderski/uncloud/internal/machine/network"
 	"github.com/psviderski/uncloud/internal/machine/store"
 	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -26,6 +30,8 @@ type Cluster struct {
 	corroAdmin *corrosion.AdminClient
 	// machineID is the ID of the current machine that is running the cluster service.
 	machineID string
+	// membershipMu serialises the membership changes handled by this machine. See lockMembership.
+	membershipMu sync.Mutex
 }
 
 func NewCluster(store *store.Store, corroAdmin *corrosion.AdminClient) *Cluster {
@@ -49,6 +55,13 @@ func (c *Cluster) Init(ctx context.Context, network netip.Prefix) error {
 		return fmt.Errorf("cluster is already initialised")
 	}
 
+	id, err := secret.NewID()
+	if err != nil {
+		return fmt.Errorf("generate cluster ID: %w", err)
+	}
+	if err = c.store.Put(ctx, store.ClusterIDKey, id); err != nil {
+		return fmt.Errorf("put id to store: %w", err)
+	}
 	if err = c.store.Put(ctx, "network", network.String()); err != nil {
 		return fmt.Errorf("put network to store: %w", err)
 	}
@@ -80,18 +93,32 @@ func (c *Cluster) checkInitialised(ctx context.Context) error {
 	return nil
 }
 
+// GetCluster returns the information about the cluster such as its ID. The ID is generated on first access if
+// the cluster was initialised by an older daemon version that didn't generate it.
+func (c *Cluster) GetCluster(ctx context.Context, _ *emptypb.Empty) (*pb.ClusterInfo, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := c.store.GetClusterID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get cluster ID: %v", err)
+	}
+	network, err := c.Network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ClusterInfo{Id: id, Network: pb.NewIPPrefix(network)}, nil
+}
+
 func (c *Cluster) Network(ctx context.Context) (netip.Prefix, error) {
 	if err := c.checkInitialised(ctx); err != nil {
 		return netip.Prefix{}, err
 	}
 
-	var net string
-	if err := c.store.Get(ctx, "network", &net); err != nil {
-		return netip.Prefix{}, status.Errorf(codes.Internal, "get network from store: %v", err)
-	}
-	prefix, err := netip.ParsePrefix(net)
+	prefix, err := c.store.GetClusterNetwork(ctx)
 	if err != nil {
-		return netip.Prefix{}, status.Errorf(codes.Internal, "parse network prefix: %v", err)
+		return netip.Prefix{}, status.Errorf(codes.Internal, "get network from store: %v", err)
 	}
 	return prefix, nil
 }
@@ -108,7 +135,7 @@ func (c *Cluster) AddMachine(ctx context.Context, req *pb.AddMachineRequest) (*p
 	if err := req.Network.Validate(); err != nil {
 		return nil, err
 	}
-	if len(req.Network.Endpoints) == 0 {
+	if len(req.Network.Endpoints) == 0 && len(req.Network.DnsEndpoints) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "endpoints not set")
 	}
 	if req.PublicIp != nil {
@@ -121,6 +148,15 @@ func (c *Cluster) AddMachine(ctx context.Context, req *pb.AddMachineRequest) (*p
 		}
 	}
 
+	unlock, err := c.lockMembership(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err = c.checkNoNetworkMigration(ctx); err != nil {
+		return nil, err
+	}
 	machines, err := c.store.ListMachines(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "list machines: %v", err)
@@ -170,6 +206,13 @@ func (c *Cluster) AddMachine(ctx context.Context, req *pb.AddMachineRequest) (*p
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create IPAM manager: %v", err)
 	}
+	reservations, err := c.store.ListIPReservations(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list IP reservations: %v", err)
+	}
+	for _, r := range reservations {
+		ipam.Reserve(r.Prefix)
+	}
 	subnet, err := ipam.AllocateSubnetLen(DefaultSubnetBits)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "allocate subnet for machine: %v", err)
@@ -182,9 +225,12 @@ func (c *Cluster) AddMachine(ctx context.Context, req *pb.AddMachineRequest) (*p
 			Subnet:       pb.NewIPPrefix(subnet),
 			ManagementIp: manageIP,
 			Endpoints:    req.Network.Endpoints,
+			DnsEndpoints: req.Network.DnsEndpoints,
 			PublicKey:    req.Network.PublicKey,
 		},
-		PublicIp: req.PublicIp,
+		PublicIp:  req.PublicIp,
+		Arch:      req.Arch,
+		Resources: req.Resources,
 	}
 	// TODO: announce the new machine to the cluster members and achieve consensus.
 	//  We should perhaps not proceed if this machine is in a minority partition.
@@ -219,10 +265,16 @@ func (c *Cluster) UpdateMachine(ctx context.Context, req *pb.UpdateMachineReques
 
 	// Create a copy of the current machine for updating
 	updatedMachine := &pb.MachineInfo{
-		Id:       currentMachine.Id,
-		Name:     currentMachine.Name,
-		Network:  currentMachine.Network,
-		PublicIp: currentMachine.PublicIp,
+		Id:              currentMachine.Id,
+		Name:            currentMachine.Name,
+		Network:         currentMachine.Network,
+		PublicIp:        currentMachine.PublicIp,
+		Arch:            currentMachine.Arch,
+		ManualEndpoints: currentMachine.ManualEndpoints,
+		Cordoned:        currentMachine.Cordoned,
+		CordonReasons:   currentMachine.CordonReasons,
+		Resources:       currentMachine.Resources,
+		Cost:            currentMachine.Cost,
 	}
 
 	// Apply updates from the request
@@ -262,9 +314,37 @@ func (c *Cluster) UpdateMachine(ctx context.Context, req *pb.UpdateMachineReques
 			updatedMachine.PublicIp = req.PublicIp
 		}
 	}
-	if req.Endpoints != nil {
+	if req.ManualEndpoints != nil && *req.ManualEndpoints {
+		// Manually set endpoints replace both the IP and DNS endpoints.
+		if len(req.Endpoints) == 0 && len(req.DnsEndpoints) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "at least one endpoint must be specified")
+		}
+		updatedMachine.Network = proto.Clone(currentMachine.Network).(*pb.NetworkConfig)
+		updatedMachine.Network.Endpoints = req.Endpoints
+		updatedMachine.Network.DnsEndpoints = req.DnsEndpoints
+	} else if req.Endpoints != nil {
+		updatedMachine.Network = proto.Clone(currentMachine.Network).(*pb.NetworkConfig)
 		updatedMachine.Network.Endpoints = req.Endpoints
 	}
+	if err = updatedMachine.Network.Validate(); err != nil {
+		return nil, err
+	}
+	if req.ManualEndpoints != nil {
+		updatedMachine.ManualEndpoints = *req.ManualEndpoints
+	}
+	if req.Arch != nil {
+		updatedMachine.Arch = *req.Arch
+	}
+	if req.Cost != nil {
+		if req.Cost.HourlyPrice < 0 || math.IsInf(req.Cost.HourlyPrice, 0) || math.IsNaN(req.Cost.HourlyPrice) {
+			return nil, status.Error(codes.InvalidArgument, "hourly price must be a non-negative number")
+		}
+		updatedMachine.Cost = req.Cost
+		// An empty cost removes the cost metadata.
+		if req.Cost.HourlyPrice == 0 && req.Cost.Provider == "" {
+			updatedMachine.Cost = nil
+		}
+	}
 
 	// Update the machine in the store
 	if err = c.store.UpdateMachine(ctx, updatedMachine); err != nil {
@@ -281,11 +361,19 @@ func (c *Cluster) UpdateMachine(ctx context.Context, req *pb.UpdateMachineReques
 	return resp, nil
 }
 
-// ListMachines lists all machines in the cluster including their membership states.
-func (c *Cluster) ListMachines(ctx context.Context, _ *emptypb.Empty) (*pb.ListMachinesResponse, error) {
+// ListMachines lists the machines in the cluster matching the filters in the request including their membership
+// states. The machines are sorted by name and paginated if the page size is set.
+func (c *Cluster) ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error) {
 	if err := c.checkInitialised(ctx); err != nil {
 		return nil, err
 	}
+	filter := api.MachineFilterFromProto(req)
+	if err := filter.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size must not be negative")
+	}
 
 	machines, err := c.store.ListMachines(ctx)
 	if err != nil {
@@ -297,8 +385,8 @@ func (c *Cluster) ListMachines(ctx context.Context, _ *emptypb.Empty) (*pb.ListM
 		return nil, status.Errorf(codes.Internal, "get cluster membership states: %v", err)
 	}
 
-	members := make([]*pb.MachineMember, len(machines))
-	for i, m := range machines {
+	members := make(api.MachineMembersList, 0, len(machines))
+	for _, m := range machines {
 		// If the machine is not in the cluster membership states or its state is not ALIVE or SUSPECT, it is DOWN.
 		// The exception is the current machine which is always UP as it is serving this request.
 		state := pb.MachineMember_DOWN
@@ -318,13 +406,17 @@ func (c *Cluster) ListMachines(ctx context.Context, _ *emptypb.Empty) (*pb.ListM
 		if m.Id == c.machineID {
 			state = pb.MachineMember_UP
 		}
-		members[i] = &pb.MachineMember{
+		member := &pb.MachineMember{
 			Machine: m,
 			State:   state,
 		}
+		if filter.Matches(member) {
+			members = append(members, member)
+		}
 	}
 
-	return &pb.ListMachinesResponse{Machines: members}, nil
+	page, nextPageToken := members.Page(int(req.GetPageSize()), req.GetPageToken())
+	return &pb.ListMachinesResponse{Machines: page, NextPageToken: nextPageToken}, nil
 }
 
 // RemoveMachine removes a machine from the cluster.
@@ -337,7 +429,13 @@ func (c *Cluster) RemoveMachine(ctx context.Context, req *pb.RemoveMachineReques
 		return nil, status.Error(codes.InvalidArgument, "machine ID not set")
 	}
 
-	if err := c.store.DeleteMachine(ctx, req.Id); err != nil {
+	unlock, err := c.lockMembership(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err = c.store.DeleteMachine(ctx, req.Id); err != nil {
 		if errors.Is(err, store.ErrMachineNotFound) {
 			return nil, status.Errorf(codes.NotFound, "machine not found: %s", req.Id)
 		}
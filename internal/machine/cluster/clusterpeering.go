@@ -0,0 +1,106 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListClusterPeerings lists the other clusters peered with the cluster.
+func (c *Cluster) ListClusterPeerings(ctx context.Context, _ *emptypb.Empty) (*pb.ClusterPeerings, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	peerings, err := c.store.ListClusterPeerings(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list cluster peerings: %v", err)
+	}
+	peeringsBytes, err := json.Marshal(peerings)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal cluster peerings: %v", err)
+	}
+
+	return &pb.ClusterPeerings{Peerings: peeringsBytes}, nil
+}
+
+// SetClusterPeering creates or updates the peering with another cluster. The peer cluster network must not overlap
+// the cluster network and its machines must not be members of this cluster.
+func (c *Cluster) SetClusterPeering(ctx context.Context, req *pb.ClusterPeering) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var p api.ClusterPeering
+	if err := json.Unmarshal(req.Peering, &p); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal cluster peering: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	clusterNetwork, err := c.Network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if clusterNetwork.Overlaps(p.Network) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"peer cluster network %s overlaps the cluster network %s, renumber one of the clusters "+
+				"with 'uc network migrate' first", p.Network, clusterNetwork)
+	}
+
+	machines, err := c.store.ListMachines(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list machines: %v", err)
+	}
+	for _, m := range machines {
+		for _, pm := range p.Machines {
+			if bytes.Equal(m.Network.PublicKey, pm.PublicKey) {
+				return nil, status.Errorf(codes.InvalidArgument,
+					"peer machine '%s' has the same public key as machine '%s' in the cluster", pm.Name, m.Name)
+			}
+		}
+	}
+
+	if existing, err := c.store.GetClusterPeering(ctx, p.Name); err == nil {
+		p.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get cluster peering: %v", err)
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC()
+	}
+	if err = c.store.PutClusterPeering(ctx, p); err != nil {
+		return nil, status.Errorf(codes.Internal, "store cluster peering: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveClusterPeering removes the peering with another cluster.
+func (c *Cluster) RemoveClusterPeering(
+	ctx context.Context, req *pb.RemoveClusterPeeringRequest,
+) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.store.GetClusterPeering(ctx, req.Name); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "cluster peering '%s' not found", req.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "get cluster peering: %v", err)
+	}
+	if err := c.store.DeleteClusterPeering(ctx, req.Name); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete cluster peering: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
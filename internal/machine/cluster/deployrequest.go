@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateDeployRequest stores a deployment that waits for another operator to approve it and returns the request
+// with the generated ID.
+func (c *Cluster) CreateDeployRequest(ctx context.Context, req *pb.DeployRequest) (*pb.DeployRequest, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var r api.DeployRequest
+	if err := json.Unmarshal(req.Request, &r); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal deploy request: %v", err)
+	}
+	if r.Project == "" || len(r.Specs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "deploy request must set the project and service specs")
+	}
+	if r.RequestedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "deploy request must set the requester")
+	}
+	for _, spec := range r.Specs {
+		if err := spec.Validate(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid spec of service '%s': %v", spec.Name, err)
+		}
+	}
+
+	id, err := secret.RandomAlphaNumeric(8)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate deploy request ID: %v", err)
+	}
+	r.ID = id
+	r.Status = api.DeployRequestPending
+	r.CreatedAt = time.Now().UTC()
+	r.ReviewedBy = ""
+	r.ReviewedAt = time.Time{}
+	if err = c.store.PutDeployRequest(ctx, r); err != nil {
+		return nil, status.Errorf(codes.Internal, "store deploy request: %v", err)
+	}
+	return deployRequestProto(r)
+}
+
+// ListDeployRequests lists the pending and recently reviewed deploy requests.
+func (c *Cluster) ListDeployRequests(ctx context.Context, _ *emptypb.Empty) (*pb.DeployRequests, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	requests, err := c.store.ListDeployRequests(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list deploy requests: %v", err)
+	}
+	rBytes, err := json.Marshal(requests)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal deploy requests: %v", err)
+	}
+	return &pb.DeployRequests{Requests: rBytes}, nil
+}
+
+// ReviewDeployRequest approves or rejects a pending deploy request. The reviewer must differ from the requester.
+func (c *Cluster) ReviewDeployRequest(
+	ctx context.Context, req *pb.ReviewDeployRequestRequest,
+) (*pb.DeployRequest, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	r, err := c.store.GetDeployRequest(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "deploy request '%s' not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "get deploy request: %v", err)
+	}
+	if r.Status != api.DeployRequestPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "deploy request '%s' is already %s by %s",
+			r.ID, r.Status, r.ReviewedBy)
+	}
+	if req.Reviewer == "" {
+		return nil, status.Error(codes.InvalidArgument, "reviewer must be set")
+	}
+	if req.Reviewer == r.RequestedBy {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"deploy request '%s' must be reviewed by another operator than the requester %s", r.ID, r.RequestedBy)
+	}
+
+	r.Status = api.DeployRequestRejected
+	if req.Approve {
+		r.Status = api.DeployRequestApproved
+	}
+	r.ReviewedBy = req.Reviewer
+	r.ReviewedAt = time.Now().UTC()
+	if err = c.store.PutDeployRequest(ctx, r); err != nil {
+		return nil, status.Errorf(codes.Internal, "store deploy request: %v", err)
+	}
+	return deployRequestProto(r)
+}
+
+func deployRequestProto(r api.DeployRequest) (*pb.DeployRequest, error) {
+	rBytes, err := json.Marshal(r)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal deploy request: %v", err)
+	}
+	return &pb.DeployRequest{Request: rBytes}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"slices"
 
 	"github.com/psviderski/uncloud/internal/dns"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
@@ -13,8 +14,13 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// uncloudDNSKey is the key used to store the details of the reserved domain in the store.
-const uncloudDNSKey = "uncloud_dns"
+const (
+	// uncloudDNSKey is the key used to store the details of the reserved domain in the store.
+	uncloudDNSKey = "uncloud_dns"
+	// uncloudDNSKeptRecordsKey is the key used to store the records of the reserved domain with names other than
+	// the wildcard that must be kept when the records are replaced, e.g. when the ingress machines change.
+	uncloudDNSKeptRecordsKey = "uncloud_dns_kept_records"
+)
 
 type uncloudDNSDomain struct {
 	// Endpoint is the API endpoint of the Uncloud DNS service where the domain is reserved.
@@ -124,21 +130,42 @@ func (c *Cluster) CreateDomainRecords(
 		return nil, err
 	}
 
-	dnsClient := dns.NewClient()
 	recordsReq := make([]dns.RecordRequest, len(req.Records))
 	for i, r := range req.Records {
 		recordsReq[i] = dns.RecordRequest{
 			Name:   r.Name,
 			Type:   dns.RecordType(r.Type.String()),
 			Values: r.Values,
+			TTL:    r.Ttl,
+		}
+	}
+
+	// Uncloud DNS replaces all records of the domain so the kept records must be sent along with the requested ones.
+	var kept []dns.RecordRequest
+	var keptJSON []byte
+	if err = c.store.Get(ctx, uncloudDNSKeptRecordsKey, &keptJSON); err == nil {
+		if err = json.Unmarshal(keptJSON, &kept); err != nil {
+			return nil, status.Errorf(codes.Internal, "unmarshal kept domain records: %v", err)
 		}
+	} else if !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get kept domain records from store: %v", err)
 	}
+	recordsReq, kept = mergeKeptRecords(recordsReq, kept, req.RemoveNames)
 
+	dnsClient := dns.NewClient()
 	recordsResp, err := dnsClient.CreateRecords(domain.Endpoint, domain.Name, domain.Token, recordsReq)
 	if err != nil {
 		return nil, err
 	}
 
+	// Store the kept records only after they've been applied so that the store reflects the records of the domain.
+	if keptJSON, err = json.Marshal(kept); err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal kept domain records for store: %v", err)
+	}
+	if err = c.store.Put(ctx, uncloudDNSKeptRecordsKey, keptJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store kept domain records: %v", err)
+	}
+
 	resp := &pb.CreateDomainRecordsResponse{
 		Records: make([]*pb.DNSRecord, len(recordsResp)),
 	}
@@ -146,6 +173,7 @@ func (c *Cluster) CreateDomainRecords(
 		resp.Records[i] = &pb.DNSRecord{
 			Name:   r.FQDN,
 			Values: r.Values,
+			Ttl:    r.TTL,
 		}
 
 		switch r.Type {
@@ -158,3 +186,29 @@ func (c *Cluster) CreateDomainRecords(
 
 	return resp, nil
 }
+
+// mergeKeptRecords returns the records to replace the domain records with and the updated records to keep. The kept
+// records are the requested records with names other than the wildcard "*" and the previously kept records that
+// are neither requested nor removed. The previously kept records are added to the requested ones.
+func mergeKeptRecords(
+	records, kept []dns.RecordRequest, removeNames []string,
+) (merged, newKept []dns.RecordRequest) {
+	requested := make(map[string]struct{}, len(records))
+	merged = slices.Clone(records)
+	for _, r := range records {
+		requested[r.Name+"/"+string(r.Type)] = struct{}{}
+		if r.Name != "*" && !slices.Contains(removeNames, r.Name) {
+			newKept = append(newKept, r)
+		}
+	}
+
+	for _, r := range kept {
+		if _, ok := requested[r.Name+"/"+string(r.Type)]; ok || slices.Contains(removeNames, r.Name) {
+			continue
+		}
+		merged = append(merged, r)
+		newKept = append(newKept, r)
+	}
+
+	return merged, newKept
+}
@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/dns"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeKeptRecords(t *testing.T) {
+	t.Parallel()
+
+	wildcard := dns.RecordRequest{Name: "*", Type: dns.RecordTypeA, Values: []string{"1.1.1.1"}}
+	web := dns.RecordRequest{Name: "web", Type: dns.RecordTypeA, Values: []string{"2.2.2.2"}, TTL: 60}
+	api := dns.RecordRequest{Name: "api", Type: dns.RecordTypeA, Values: []string{"3.3.3.3"}, TTL: 60}
+
+	tests := []struct {
+		name        string
+		records     []dns.RecordRequest
+		kept        []dns.RecordRequest
+		removeNames []string
+		wantMerged  []dns.RecordRequest
+		wantKept    []dns.RecordRequest
+	}{
+		{
+			name:       "wildcard only",
+			records:    []dns.RecordRequest{wildcard},
+			wantMerged: []dns.RecordRequest{wildcard},
+		},
+		{
+			name:       "named records are kept",
+			records:    []dns.RecordRequest{wildcard, web},
+			wantMerged: []dns.RecordRequest{wildcard, web},
+			wantKept:   []dns.RecordRequest{web},
+		},
+		{
+			name:       "kept records are added to wildcard update",
+			records:    []dns.RecordRequest{{Name: "*", Type: dns.RecordTypeA, Values: []string{"4.4.4.4"}}},
+			kept:       []dns.RecordRequest{web, api},
+			wantMerged: []dns.RecordRequest{{Name: "*", Type: dns.RecordTypeA, Values: []string{"4.4.4.4"}}, web, api},
+			wantKept:   []dns.RecordRequest{web, api},
+		},
+		{
+			name: "requested record replaces kept one",
+			records: []dns.RecordRequest{
+				wildcard, {Name: "web", Type: dns.RecordTypeA, Values: []string{"5.5.5.5"}},
+			},
+			kept: []dns.RecordRequest{web, api},
+			wantMerged: []dns.RecordRequest{
+				wildcard, {Name: "web", Type: dns.RecordTypeA, Values: []string{"5.5.5.5"}}, api,
+			},
+			wantKept: []dns.RecordRequest{{Name: "web", Type: dns.RecordTypeA, Values: []string{"5.5.5.5"}}, api},
+		},
+		{
+			name:        "removed records",
+			records:     []dns.RecordRequest{wildcard},
+			kept:        []dns.RecordRequest{web, api},
+			removeNames: []string{"web"},
+			wantMerged:  []dns.RecordRequest{wildcard, api},
+			wantKept:    []dns.RecordRequest{api},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, kept := mergeKeptRecords(tt.records, tt.kept, tt.removeNames)
+			assert.Equal(t, tt.wantMerged, merged)
+			assert.Equal(t, tt.wantKept, kept)
+		})
+	}
+}
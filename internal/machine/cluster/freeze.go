@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetDeployFreeze returns the deploy freeze of the cluster. An expired freeze is returned as well so the client can
+// tell when it ended.
+func (c *Cluster) GetDeployFreeze(ctx context.Context, _ *emptypb.Empty) (*pb.DeployFreeze, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	f, err := c.store.GetDeployFreeze(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Error(codes.NotFound, "deploys are not frozen")
+		}
+		return nil, status.Errorf(codes.Internal, "get deploy freeze: %v", err)
+	}
+	fBytes, err := json.Marshal(f)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal deploy freeze: %v", err)
+	}
+	return &pb.DeployFreeze{Freeze: fBytes}, nil
+}
+
+// SetDeployFreeze freezes or extends the freeze of the mutating deploy operations in the cluster.
+func (c *Cluster) SetDeployFreeze(ctx context.Context, req *pb.DeployFreeze) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var f api.DeployFreeze
+	if err := json.Unmarshal(req.Freeze, &f); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal deploy freeze: %v", err)
+	}
+	if !f.Active(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "deploy freeze end time must be in the future")
+	}
+	f.CreatedAt = time.Now().UTC()
+	if err := c.store.PutDeployFreeze(ctx, f); err != nil {
+		return nil, status.Errorf(codes.Internal, "store deploy freeze: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveDeployFreeze lifts the deploy freeze of the cluster.
+func (c *Cluster) RemoveDeployFreeze(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.store.GetDeployFreeze(ctx); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Error(codes.NotFound, "deploys are not frozen")
+		}
+		return nil, status.Errorf(codes.Internal, "get deploy freeze: %v", err)
+	}
+	if err := c.store.DeleteDeployFreeze(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete deploy freeze: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RecordFreezeOverride records a deploy operation that overrode the deploy freeze in the audit log.
+func (c *Cluster) RecordFreezeOverride(ctx context.Context, req *pb.FreezeOverride) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var o api.FreezeOverride
+	if err := json.Unmarshal(req.Override, &o); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal freeze override: %v", err)
+	}
+	if strings.TrimSpace(o.Reason) == "" {
+		return nil, status.Error(codes.InvalidArgument, "freeze override reason must be set")
+	}
+	// Use the machine clock rather than the client one for the audit record.
+	o.Time = time.Now().UTC()
+	if err := c.store.PutFreezeOverride(ctx, o); err != nil {
+		return nil, status.Errorf(codes.Internal, "store freeze override: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListFreezeOverrides lists the deploy operations that overrode a deploy freeze.
+func (c *Cluster) ListFreezeOverrides(ctx context.Context, _ *emptypb.Empty) (*pb.FreezeOverrides, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	overrides, err := c.store.ListFreezeOverrides(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list freeze overrides: %v", err)
+	}
+	oBytes, err := json.Marshal(overrides)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal freeze overrides: %v", err)
+	}
+	return &pb.FreezeOverrides{Overrides: oBytes}, nil
+}
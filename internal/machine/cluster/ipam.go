@@ -79,3 +79,9 @@ func (ipam *IPAM) AllocateSubnet(subnet netip.Prefix) error {
 	ipam.allocated.AddPrefix(subnet)
 	return nil
 }
+
+// Reserve excludes the IP range from the subnet allocation. Unlike AllocateSubnet, the range may overlap
+// the allocated subnets or extend beyond the network.
+func (ipam *IPAM) Reserve(prefix netip.Prefix) {
+	ipam.allocated.AddPrefix(prefix.Masked())
+}
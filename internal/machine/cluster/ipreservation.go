@@ -0,0 +1,117 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListIPReservations lists the IP ranges reserved in the cluster network.
+func (c *Cluster) ListIPReservations(ctx context.Context, _ *emptypb.Empty) (*pb.IPReservations, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	reservations, err := c.store.ListIPReservations(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list IP reservations: %v", err)
+	}
+	reservationsBytes, err := json.Marshal(reservations)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal IP reservations: %v", err)
+	}
+
+	return &pb.IPReservations{Reservations: reservationsBytes}, nil
+}
+
+// ReserveIPRange reserves an IP range in the cluster network so it isn't allocated to new machines. The range must
+// overlap the cluster network but not the subnets already allocated to machines.
+func (c *Cluster) ReserveIPRange(ctx context.Context, req *pb.IPReservation) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var r api.IPReservation
+	if err := json.Unmarshal(req.Reservation, &r); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal IP reservation: %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	clusterNetwork, err := c.Network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !clusterNetwork.Overlaps(r.Prefix) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"IP range %s is outside the cluster network %s", r.Prefix, clusterNetwork)
+	}
+
+	// Prevent adding a machine concurrently that may be allocated a subnet overlapping the range.
+	unlock, err := c.lockMembership(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	machines, err := c.store.ListMachines(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list machines: %v", err)
+	}
+	for _, m := range machines {
+		subnet, err := m.Network.Subnet.ToPrefix()
+		if err != nil {
+			continue
+		}
+		if subnet.Overlaps(r.Prefix) {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"IP range %s overlaps subnet %s allocated to machine '%s'", r.Prefix, subnet, m.Name)
+		}
+	}
+
+	if _, err = c.store.GetIPReservation(ctx, r.Prefix); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "IP range %s is already reserved", r.Prefix)
+	} else if !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get IP reservation: %v", err)
+	}
+
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now().UTC()
+	}
+	if err = c.store.PutIPReservation(ctx, r); err != nil {
+		return nil, status.Errorf(codes.Internal, "store IP reservation: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ReleaseIPRange removes an IP range reservation.
+func (c *Cluster) ReleaseIPRange(ctx context.Context, req *pb.ReleaseIPRangeRequest) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	prefix, err := netip.ParsePrefix(req.Prefix)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid IP range: %v", err)
+	}
+	if _, err = c.store.GetIPReservation(ctx, prefix); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "IP range %s is not reserved", prefix)
+		}
+		return nil, status.Errorf(codes.Internal, "get IP reservation: %v", err)
+	}
+	if err = c.store.DeleteIPReservation(ctx, prefix); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete IP reservation: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/internal/secret"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// membershipLockTTL is the lease duration of the cluster membership change lock. It bounds how long a crashed
+	// machine can block the membership changes as the lock is normally released as soon as the change is stored.
+	membershipLockTTL = 30 * time.Second
+	// membershipLockReleaseTimeout is the timeout for releasing the lock after the request context is done.
+	membershipLockReleaseTimeout = 5 * time.Second
+)
+
+// membershipLockSettleDelay is the time to wait after writing the lock before reading it back. It must exceed
+// the time it takes for a write to propagate to all machines through the cluster store so that concurrent writes
+// from other machines have arrived and all machines agree on the same winning holder. It's shortened in tests.
+var membershipLockSettleDelay = 5 * time.Second
+
+// lockMembership serialises the cluster membership changes such as adding and removing machines so that concurrent
+// changes don't allocate the same subnet or miss each other's machines. The changes handled by this machine wait
+// for each other while a change in progress on another machine fails the request with codes.Aborted.
+// The returned function releases the lock.
+func (c *Cluster) lockMembership(ctx context.Context) (func(), error) {
+	c.membershipMu.Lock()
+
+	holder, err := secret.NewID()
+	if err != nil {
+		c.membershipMu.Unlock()
+		return nil, status.Errorf(codes.Internal, "generate membership lock holder ID: %v", err)
+	}
+	if err = c.acquireMembershipLease(ctx, holder); err != nil {
+		c.membershipMu.Unlock()
+		return nil, err
+	}
+
+	return func() {
+		defer c.membershipMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), membershipLockReleaseTimeout)
+		defer cancel()
+		if err := c.releaseMembershipLease(ctx, holder); err != nil {
+			slog.Warn("Failed to release cluster membership lock, it will expire.",
+				"ttl", membershipLockTTL, "err", err)
+		}
+	}, nil
+}
+
+// acquireMembershipLease stores the cluster-wide membership change lease for the holder unless another holder has
+// an unexpired lease. The store is eventually consistent and a local read right after the local write always returns
+// the own lease, so concurrent writes from other machines can't be detected immediately. Instead, the lease is read
+// back after membershipLockSettleDelay when concurrent writes have propagated and the conflict resolution has picked
+// the same winner on all machines. This relies on the propagation time being bounded and doesn't provide exclusion
+// if the machines can't reach each other for longer than that.
+func (c *Cluster) acquireMembershipLease(ctx context.Context, holder string) error {
+	current, err := c.store.GetMembershipLock(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "get membership lock: %v", err)
+	}
+	if err = checkMembershipLease(current, holder); err != nil {
+		return err
+	}
+
+	lock := store.MembershipLock{
+		Holder:    holder,
+		Machine:   c.machineID,
+		ExpiresAt: time.Now().Add(membershipLockTTL).UTC(),
+	}
+	if err = c.store.PutMembershipLock(ctx, lock); err != nil {
+		return status.Errorf(codes.Internal, "put membership lock: %v", err)
+	}
+
+	select {
+	case <-time.After(membershipLockSettleDelay):
+	case <-ctx.Done():
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), membershipLockReleaseTimeout)
+		defer cancel()
+		if err = c.releaseMembershipLease(releaseCtx, holder); err != nil {
+			slog.Warn("Failed to release cluster membership lock, it will expire.",
+				"ttl", membershipLockTTL, "err", err)
+		}
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	if current, err = c.store.GetMembershipLock(ctx); err != nil {
+		return status.Errorf(codes.Internal, "get membership lock: %v", err)
+	}
+	if current == nil {
+		return status.Error(codes.Aborted,
+			"another membership change is in progress: membership lock has been released concurrently, try again")
+	}
+	return checkMembershipLease(current, holder)
+}
+
+// checkMembershipLease returns a codes.Aborted error if the lease is held by another holder and hasn't expired.
+func checkMembershipLease(lock *store.MembershipLock, holder string) error {
+	if lock == nil || lock.Holder == holder || lock.Expired(time.Now()) {
+		return nil
+	}
+	machine := lock.Machine
+	if machine == "" {
+		machine = "unknown"
+	}
+	return status.Errorf(codes.Aborted,
+		"another membership change is in progress (started on machine %s), try again in a few seconds "+
+			"or after %s when the lock expires", machine, lock.ExpiresAt.Local().Format(time.TimeOnly))
+}
+
+// releaseMembershipLease deletes the cluster-wide membership change lease if it's still held by the holder.
+func (c *Cluster) releaseMembershipLease(ctx context.Context, holder string) error {
+	_, err := c.store.DeleteMembershipLock(ctx, holder)
+	return err
+}
@@ -0,0 +1,188 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/corrosion/corrosiontest"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckMembershipLease(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(time.Minute)
+	tests := []struct {
+		name    string
+		lock    *store.MembershipLock
+		wantErr string
+	}{
+		{
+			name: "no lock",
+		},
+		{
+			name: "own lock",
+			lock: &store.MembershipLock{Holder: "a", Machine: "m1", ExpiresAt: future},
+		},
+		{
+			name: "expired lock of another holder",
+			lock: &store.MembershipLock{Holder: "b", Machine: "m2", ExpiresAt: time.Now().Add(-time.Second)},
+		},
+		{
+			name:    "lock of another holder",
+			lock:    &store.MembershipLock{Holder: "b", Machine: "m2", ExpiresAt: future},
+			wantErr: "started on machine m2",
+		},
+		{
+			name:    "lock of another holder without machine",
+			lock:    &store.MembershipLock{Holder: "b", ExpiresAt: future},
+			wantErr: "started on machine unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkMembershipLease(tt.lock, "a")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, codes.Aborted, status.Code(err))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestMembershipLease tests the acquire, settle, and release sequence of the membership lease against the store.
+// The subtests aren't parallel as they shorten the settle delay.
+func TestMembershipLease(t *testing.T) {
+	settleDelay := membershipLockSettleDelay
+	membershipLockSettleDelay = 200 * time.Millisecond
+	t.Cleanup(func() { membershipLockSettleDelay = settleDelay })
+
+	newCluster := func(t *testing.T) (*Cluster, *store.Store) {
+		s := store.New(corrosiontest.NewAPIClient(t, store.Schema))
+		c := NewCluster(s, nil)
+		c.UpdateMachineID("m1")
+		return c, s
+	}
+	otherLock := func(ttl time.Duration) store.MembershipLock {
+		return store.MembershipLock{Holder: "b", Machine: "m2", ExpiresAt: time.Now().Add(ttl).UTC()}
+	}
+
+	t.Run("acquire and release", func(t *testing.T) {
+		c, s := newCluster(t)
+		ctx := context.Background()
+
+		require.NoError(t, c.acquireMembershipLease(ctx, "a"))
+		lock, err := s.GetMembershipLock(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, lock)
+		assert.Equal(t, "a", lock.Holder)
+		assert.Equal(t, "m1", lock.Machine)
+
+		err = c.acquireMembershipLease(ctx, "b")
+		assert.Equal(t, codes.Aborted, status.Code(err))
+
+		require.NoError(t, c.releaseMembershipLease(ctx, "a"))
+		lock, err = s.GetMembershipLock(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, lock)
+
+		require.NoError(t, c.acquireMembershipLease(ctx, "b"))
+	})
+
+	t.Run("take over expired lease", func(t *testing.T) {
+		c, s := newCluster(t)
+		ctx := context.Background()
+		require.NoError(t, s.PutMembershipLock(ctx, otherLock(-time.Second)))
+
+		require.NoError(t, c.acquireMembershipLease(ctx, "a"))
+		lock, err := s.GetMembershipLock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "a", lock.Holder)
+	})
+
+	t.Run("concurrent write wins while settling", func(t *testing.T) {
+		c, s := newCluster(t)
+		ctx := context.Background()
+
+		done := make(chan error)
+		go func() {
+			time.Sleep(membershipLockSettleDelay / 4)
+			done <- s.PutMembershipLock(ctx, otherLock(time.Minute))
+		}()
+		err := c.acquireMembershipLease(ctx, "a")
+		require.NoError(t, <-done)
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		assert.ErrorContains(t, err, "started on machine m2")
+	})
+
+	t.Run("concurrent release while settling", func(t *testing.T) {
+		c, s := newCluster(t)
+		ctx := context.Background()
+
+		done := make(chan error)
+		go func() {
+			time.Sleep(membershipLockSettleDelay / 4)
+			_, err := s.DeleteMembershipLock(ctx, "a")
+			done <- err
+		}()
+		err := c.acquireMembershipLease(ctx, "a")
+		require.NoError(t, <-done)
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		assert.ErrorContains(t, err, "released concurrently")
+	})
+
+	t.Run("cancelled while settling releases lease", func(t *testing.T) {
+		c, s := newCluster(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(membershipLockSettleDelay/4, cancel)
+
+		err := c.acquireMembershipLease(ctx, "a")
+		assert.Equal(t, codes.Canceled, status.Code(err))
+
+		lock, err := s.GetMembershipLock(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, lock)
+	})
+
+	t.Run("release keeps lease of another holder", func(t *testing.T) {
+		c, s := newCluster(t)
+		ctx := context.Background()
+
+		require.NoError(t, c.acquireMembershipLease(ctx, "a"))
+		require.NoError(t, s.PutMembershipLock(ctx, otherLock(time.Minute)))
+
+		require.NoError(t, c.releaseMembershipLease(ctx, "a"))
+		lock, err := s.GetMembershipLock(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, lock)
+		assert.Equal(t, "b", lock.Holder)
+	})
+
+	t.Run("lock and unlock", func(t *testing.T) {
+		c, s := newCluster(t)
+		ctx := context.Background()
+
+		unlock, err := c.lockMembership(ctx)
+		require.NoError(t, err)
+		lock, err := s.GetMembershipLock(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, lock)
+
+		unlock()
+		lock, err = s.GetMembershipLock(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, lock)
+	})
+}
@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetNetworkMigration returns the cluster network migration in progress.
+func (c *Cluster) GetNetworkMigration(ctx context.Context, _ *emptypb.Empty) (*pb.NetworkMigration, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	m, err := c.store.GetNetworkMigration(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Error(codes.NotFound, "no network migration in progress")
+		}
+		return nil, status.Errorf(codes.Internal, "get network migration: %v", err)
+	}
+	mBytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal network migration: %v", err)
+	}
+	return &pb.NetworkMigration{Migration: mBytes}, nil
+}
+
+// SetNetworkMigration starts or updates the cluster network migration. A completed migration changes the cluster
+// network to the new one. Completed and rolled back migrations are removed.
+func (c *Cluster) SetNetworkMigration(ctx context.Context, req *pb.NetworkMigration) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var m api.NetworkMigration
+	if err := json.Unmarshal(req.Migration, &m); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal network migration: %v", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Prevent machines from being added or removed while the migration plan is being changed.
+	unlock, err := c.lockMembership(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := c.store.GetNetworkMigration(ctx)
+	switch {
+	case err == nil:
+		if current.From != m.From || current.To != m.To {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"another network migration from %s to %s is in progress", current.From, current.To)
+		}
+	case errors.Is(err, store.ErrKeyNotFound):
+		if m.State != api.NetworkMigrationInProgress {
+			return nil, status.Error(codes.NotFound, "no network migration in progress")
+		}
+		clusterNetwork, err := c.Network(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if m.From != clusterNetwork {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"network migration must start from the current cluster network %s", clusterNetwork)
+		}
+	default:
+		return nil, status.Errorf(codes.Internal, "get network migration: %v", err)
+	}
+
+	switch m.State {
+	case api.NetworkMigrationCompleted:
+		if err = c.store.Put(ctx, "network", m.To.String()); err != nil {
+			return nil, status.Errorf(codes.Internal, "put network to store: %v", err)
+		}
+		slog.Info("Cluster network migrated.", "from", m.From, "to", m.To)
+		fallthrough
+	case api.NetworkMigrationRolledBack:
+		if err = c.store.DeleteNetworkMigration(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "delete network migration: %v", err)
+		}
+	default:
+		if err = c.store.PutNetworkMigration(ctx, m); err != nil {
+			return nil, status.Errorf(codes.Internal, "store network migration: %v", err)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// checkNoNetworkMigration returns a codes.FailedPrecondition error if a cluster network migration is in progress.
+func (c *Cluster) checkNoNetworkMigration(ctx context.Context) error {
+	m, err := c.store.GetNetworkMigration(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil
+		}
+		return status.Errorf(codes.Internal, "get network migration: %v", err)
+	}
+	return status.Errorf(codes.FailedPrecondition,
+		"cluster network migration from %s to %s is in progress, complete or roll it back first", m.From, m.To)
+}
@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListPostgresClusters lists the managed Postgres clusters.
+func (c *Cluster) ListPostgresClusters(ctx context.Context, _ *emptypb.Empty) (*pb.PostgresClusters, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	clusters, err := c.store.ListPostgresClusters(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list postgres clusters: %v", err)
+	}
+	clustersBytes, err := json.Marshal(clusters)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal postgres clusters: %v", err)
+	}
+
+	return &pb.PostgresClusters{Clusters: clustersBytes}, nil
+}
+
+// SetPostgresCluster creates or updates a managed Postgres cluster and points its DNS name to the primary.
+func (c *Cluster) SetPostgresCluster(ctx context.Context, req *pb.PostgresCluster) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var pg api.PostgresCluster
+	if err := json.Unmarshal(req.Cluster, &pg); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal postgres cluster: %v", err)
+	}
+	if err := pg.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := c.store.PutPostgresCluster(ctx, pg); err != nil {
+		return nil, status.Errorf(codes.Internal, "store postgres cluster: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemovePostgresCluster removes a managed Postgres cluster and its DNS name.
+func (c *Cluster) RemovePostgresCluster(
+	ctx context.Context, req *pb.RemovePostgresClusterRequest,
+) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.store.GetPostgresCluster(ctx, req.Name); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "postgres cluster '%s' not found", req.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "get postgres cluster: %v", err)
+	}
+	if err := c.store.DeletePostgresCluster(ctx, req.Name); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete postgres cluster: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,48 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RecordPreemption records a container preempted by a container of a higher priority service.
+func (c *Cluster) RecordPreemption(ctx context.Context, req *pb.Preemption) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var p api.Preemption
+	if err := json.Unmarshal(req.Preemption, &p); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal preemption: %v", err)
+	}
+	if p.ContainerID == "" {
+		return nil, status.Error(codes.InvalidArgument, "preempted container ID must be set")
+	}
+	if err := c.store.PutPreemption(ctx, p); err != nil {
+		return nil, status.Errorf(codes.Internal, "store preemption: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListPreemptions lists the recent preemptions of containers.
+func (c *Cluster) ListPreemptions(ctx context.Context, _ *emptypb.Empty) (*pb.Preemptions, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	preemptions, err := c.store.ListPreemptions(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list preemptions: %v", err)
+	}
+	pBytes, err := json.Marshal(preemptions)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal preemptions: %v", err)
+	}
+	return &pb.Preemptions{Preemptions: pBytes}, nil
+}
@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListProjectQuotas lists the resource quotas of the projects.
+func (c *Cluster) ListProjectQuotas(ctx context.Context, _ *emptypb.Empty) (*pb.ProjectQuotas, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	quotas, err := c.store.ListProjectQuotas(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list project quotas: %v", err)
+	}
+	quotasBytes, err := json.Marshal(quotas)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal project quotas: %v", err)
+	}
+
+	return &pb.ProjectQuotas{Quotas: quotasBytes}, nil
+}
+
+// SetProjectQuota creates or replaces the resource quota of a project.
+func (c *Cluster) SetProjectQuota(ctx context.Context, req *pb.ProjectQuota) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var q api.ProjectQuota
+	if err := json.Unmarshal(req.Quota, &q); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal project quota: %v", err)
+	}
+	if err := q.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := c.store.PutProjectQuota(ctx, q); err != nil {
+		return nil, status.Errorf(codes.Internal, "store project quota: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveProjectQuota removes the resource quota of a project.
+func (c *Cluster) RemoveProjectQuota(
+	ctx context.Context, req *pb.RemoveProjectQuotaRequest,
+) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.store.GetProjectQuota(ctx, req.Project); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "project '%s' has no quota", req.Project)
+		}
+		return nil, status.Errorf(codes.Internal, "get project quota: %v", err)
+	}
+	if err := c.store.DeleteProjectQuota(ctx, req.Project); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete project quota: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/api/rbac"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetServiceRevision returns the state of a service before its last deployment.
+func (c *Cluster) GetServiceRevision(
+	ctx context.Context, req *pb.GetServiceRevisionRequest,
+) (*pb.ServiceRevision, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+	if !api.ValidateServiceID(req.ServiceId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID: '%s'", req.ServiceId)
+	}
+
+	rev, err := c.store.GetServiceRevision(ctx, req.ServiceId)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Error(codes.NotFound, "service revision not found")
+		}
+		return nil, status.Errorf(codes.Internal, "get service revision: %v", err)
+	}
+	if rbac.ReadOnly(ctx) {
+		rev.Spec = rev.Spec.Redacted()
+	}
+	revBytes, err := json.Marshal(rev)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal service revision: %v", err)
+	}
+
+	return &pb.ServiceRevision{Revision: revBytes}, nil
+}
+
+// SetServiceRevision records the state of a service before a deployment so it can be rolled back.
+func (c *Cluster) SetServiceRevision(ctx context.Context, req *pb.ServiceRevision) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var rev api.ServiceRevision
+	if err := json.Unmarshal(req.Revision, &rev); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal service revision: %v", err)
+	}
+	if !api.ValidateServiceID(rev.ServiceID) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID: '%s'", rev.ServiceID)
+	}
+	if err := rev.Spec.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service spec: %v", err)
+	}
+
+	if err := c.store.PutServiceRevision(ctx, rev); err != nil {
+		return nil, status.Errorf(codes.Internal, "store service revision: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,48 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetSettings returns the cluster-wide settings.
+func (c *Cluster) GetSettings(ctx context.Context, _ *emptypb.Empty) (*pb.ClusterSettings, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	settings, err := c.store.GetSettings(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get settings: %v", err)
+	}
+	return settings.Proto(), nil
+}
+
+// SetSettings replaces the cluster-wide settings if their version matches the current one and returns the stored
+// settings with the incremented version. The version check is skipped if the requested version is zero.
+func (c *Cluster) SetSettings(ctx context.Context, req *pb.ClusterSettings) (*pb.ClusterSettings, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	settings := api.ClusterSettingsFromProto(req)
+	if err := settings.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	settings, err := c.store.PutSettings(ctx, settings, settings.Version)
+	if err != nil {
+		if errors.Is(err, store.ErrSettingsChanged) {
+			return nil, status.Errorf(codes.Aborted, "%v, get them and try again", err)
+		}
+		return nil, status.Errorf(codes.Internal, "store settings: %v", err)
+	}
+	return settings.Proto(), nil
+}
@@ -0,0 +1,55 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetObjectStorage returns the configuration of the managed S3-compatible object storage.
+func (c *Cluster) GetObjectStorage(ctx context.Context, _ *emptypb.Empty) (*pb.ObjectStorage, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	config, err := c.store.GetObjectStorage(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Error(codes.NotFound, "object storage not enabled")
+		}
+		return nil, status.Errorf(codes.Internal, "get object storage config: %v", err)
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal object storage config: %v", err)
+	}
+
+	return &pb.ObjectStorage{Config: configBytes}, nil
+}
+
+// SetObjectStorage stores the configuration of the managed S3-compatible object storage.
+func (c *Cluster) SetObjectStorage(ctx context.Context, req *pb.ObjectStorage) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var config api.ObjectStorage
+	if err := json.Unmarshal(req.Config, &config); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal object storage config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := c.store.PutObjectStorage(ctx, config); err != nil {
+		return nil, status.Errorf(codes.Internal, "store object storage config: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListTenants lists the tenants hosted on the cluster.
+func (c *Cluster) ListTenants(ctx context.Context, _ *emptypb.Empty) (*pb.Tenants, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	tenants, err := c.store.ListTenants(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list tenants: %v", err)
+	}
+	tenantsBytes, err := json.Marshal(tenants)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal tenants: %v", err)
+	}
+
+	return &pb.Tenants{Tenants: tenantsBytes}, nil
+}
+
+// SetTenant creates or updates a tenant. The creation time of an existing tenant is preserved.
+func (c *Cluster) SetTenant(ctx context.Context, req *pb.Tenant) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var t api.Tenant
+	if err := json.Unmarshal(req.Tenant, &t); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal tenant: %v", err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if existing, err := c.store.GetTenant(ctx, t.Name); err == nil {
+		t.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get tenant: %v", err)
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now().UTC()
+	}
+	if err := c.store.PutTenant(ctx, t); err != nil {
+		return nil, status.Errorf(codes.Internal, "store tenant: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveTenant removes a tenant. The tenant must not have any service containers left.
+func (c *Cluster) RemoveTenant(ctx context.Context, req *pb.RemoveTenantRequest) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.store.GetTenant(ctx, req.Name); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "tenant '%s' not found", req.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "get tenant: %v", err)
+	}
+
+	containers, err := c.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list containers: %v", err)
+	}
+	for _, r := range containers {
+		if r.Container.ServiceSpec.Tenant == req.Name {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"tenant '%s' has services, remove them first, e.g. service '%s'",
+				req.Name, r.Container.ServiceName())
+		}
+	}
+
+	if err = c.store.DeleteTenant(ctx, req.Name); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete tenant: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListTrashedServices lists the removed services kept in the trash that can be restored.
+func (c *Cluster) ListTrashedServices(ctx context.Context, _ *emptypb.Empty) (*pb.TrashedServices, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	services, err := c.store.ListTrashedServices(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list trashed services: %v", err)
+	}
+	servicesBytes, err := json.Marshal(services)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal trashed services: %v", err)
+	}
+
+	return &pb.TrashedServices{Services: servicesBytes}, nil
+}
+
+// SetTrashedService moves a service to the trash or updates its trash record.
+func (c *Cluster) SetTrashedService(ctx context.Context, req *pb.TrashedService) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	var svc api.TrashedService
+	if err := json.Unmarshal(req.Service, &svc); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal trashed service: %v", err)
+	}
+	if err := svc.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := c.store.PutTrashedService(ctx, svc); err != nil {
+		return nil, status.Errorf(codes.Internal, "store trashed service: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveTrashedService removes a service from the trash.
+func (c *Cluster) RemoveTrashedService(
+	ctx context.Context, req *pb.RemoveTrashedServiceRequest,
+) (*emptypb.Empty, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.store.GetTrashedService(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "service '%s' not found in trash", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "get trashed service: %v", err)
+	}
+	if err := c.store.DeleteTrashedService(ctx, req.Id); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete trashed service: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,45 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListUptimeChecks lists the results of the periodic uptime checks of the published service endpoints.
+func (c *Cluster) ListUptimeChecks(
+	ctx context.Context, req *pb.ListUptimeChecksRequest,
+) (*pb.ListUptimeChecksResponse, error) {
+	if err := c.checkInitialised(ctx); err != nil {
+		return nil, err
+	}
+
+	opts := store.UptimeCheckListOptions{ServiceName: req.ServiceName}
+	if req.Since != nil {
+		opts.Since = req.Since.AsTime()
+	}
+	records, err := c.store.ListUptimeChecks(ctx, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list uptime checks: %v", err)
+	}
+
+	checks := make([]*pb.UptimeCheck, len(records))
+	for i, r := range records {
+		checks[i] = &pb.UptimeCheck{
+			ServiceName: r.ServiceName,
+			Url:         r.URL,
+			MachineId:   r.MachineID,
+			CheckedAt:   timestamppb.New(r.CheckedAt),
+			StatusCode:  int32(r.StatusCode),
+			Latency:     durationpb.New(r.Latency),
+			Error:       r.Error,
+		}
+	}
+
+	return &pb.ListUptimeChecksResponse{Checks: checks}, nil
+}
@@ -0,0 +1,109 @@
+package machine
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedEndpoints(t *testing.T) {
+	t.Parallel()
+
+	private := netip.MustParseAddrPort("10.0.0.2:51820")
+	newPrivate := netip.MustParseAddrPort("10.0.0.3:51820")
+	public := netip.MustParseAddrPort("203.0.113.10:51820")
+	newPublic := netip.MustParseAddrPort("203.0.113.20:51820")
+
+	tests := []struct {
+		name             string
+		current          []netip.AddrPort
+		detected         []netip.AddrPort
+		publicIPDetected bool
+		want             []netip.AddrPort
+		wantChanged      bool
+	}{
+		{
+			name:             "same endpoints in different order",
+			current:          []netip.AddrPort{private, public},
+			detected:         []netip.AddrPort{public, private},
+			publicIPDetected: true,
+			want:             []netip.AddrPort{public, private},
+		},
+		{
+			name:             "public IP changed",
+			current:          []netip.AddrPort{private, public},
+			detected:         []netip.AddrPort{private, newPublic},
+			publicIPDetected: true,
+			want:             []netip.AddrPort{private, newPublic},
+			wantChanged:      true,
+		},
+		{
+			name:             "private IP removed",
+			current:          []netip.AddrPort{private, public},
+			detected:         []netip.AddrPort{public},
+			publicIPDetected: true,
+			want:             []netip.AddrPort{public},
+			wantChanged:      true,
+		},
+		{
+			name:     "public IP lookup failed keeps last known public endpoint",
+			current:  []netip.AddrPort{private, public},
+			detected: []netip.AddrPort{private},
+			want:     []netip.AddrPort{private, public},
+		},
+		{
+			// The stale private endpoint is dropped while the last known public one is kept.
+			name:        "public IP lookup failed with changed private IP",
+			current:     []netip.AddrPort{private, public},
+			detected:    []netip.AddrPort{newPrivate},
+			want:        []netip.AddrPort{newPrivate, public},
+			wantChanged: true,
+		},
+		{
+			name:             "nothing detected keeps current endpoints",
+			current:          []netip.AddrPort{private, public},
+			publicIPDetected: true,
+			want:             []netip.AddrPort{private, public},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, changed := changedEndpoints(tt.current, tt.detected, tt.publicIPDetected)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantChanged, changed)
+		})
+	}
+}
+
+func TestPeerEndpoints(t *testing.T) {
+	t.Parallel()
+
+	resolved := netip.MustParseAddrPort("198.51.100.5:51820")
+	stale := netip.MustParseAddrPort("203.0.113.10:51820")
+	private := netip.MustParseAddrPort("10.0.0.2:51820")
+	netConfig := &pb.NetworkConfig{
+		Endpoints:    []*pb.IPPort{pb.NewIPPort(private), pb.NewIPPort(stale), pb.NewIPPort(resolved)},
+		DnsEndpoints: []string{"home.example.com:51820", "unresolved.example.com:51820"},
+	}
+
+	t.Run("resolved DNS endpoints first", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := peerEndpoints(netConfig, map[string][]netip.AddrPort{
+			"home.example.com:51820": {resolved},
+		})
+		assert.Equal(t, []netip.AddrPort{resolved, private, stale}, endpoints)
+	})
+
+	t.Run("not resolved", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := peerEndpoints(netConfig, nil)
+		assert.Equal(t, []netip.AddrPort{private, stale, resolved}, endpoints)
+	})
+}
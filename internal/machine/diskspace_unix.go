@@ -0,0 +1,19 @@
+//go:build !windows
+
+package machine
+
+import "golang.org/x/sys/unix"
+
+// diskSpace returns the total, used, and available space on the filesystem with the given path.
+func diskSpace(path string) (fsSpace, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return fsSpace{}, err
+	}
+	bsize := int64(st.Bsize)
+	return fsSpace{
+		total:     int64(st.Blocks) * bsize,
+		used:      int64(st.Blocks-st.Bfree) * bsize,
+		available: int64(st.Bavail) * bsize,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package machine
+
+import "golang.org/x/sys/windows"
+
+// diskSpace returns the total, used, and available space on the volume with the given path.
+func diskSpace(path string) (fsSpace, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return fsSpace{}, err
+	}
+	var available, total, free uint64
+	if err = windows.GetDiskFreeSpaceEx(p, &available, &total, &free); err != nil {
+		return fsSpace{}, err
+	}
+	return fsSpace{
+		total:     int64(total),
+		used:      int64(total - free),
+		available: int64(available),
+	}, nil
+}
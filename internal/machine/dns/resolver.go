@@ -5,31 +5,66 @@ import (
 	"fmt"
 	"log/slog"
 	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
 )
 
+// caddyServiceName is the name of the Caddy reverse proxy service serving the published domains.
+const caddyServiceName = "caddy"
+
+// projectLabelRegexp matches a project name that is a valid DNS label and can be used as a service subdomain.
+var projectLabelRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
 // ClusterResolver implements Resolver by tracking containers in the cluster and resolving service names
 // to their IP addresses.
 type ClusterResolver struct {
 	store *store.Store
+	// settings returns the current cluster settings. The defaults are used if nil.
+	settings func() api.ClusterSettings
 	// serviceIPs maps service names to container IPs.
 	serviceIPs map[string][]netip.Addr
-	// mu protects the serviceIPs map.
+	// hostIPs maps the custom DNS names of services and containers, i.e. service aliases and container hostnames,
+	// to container IPs. Unlike service names, they can also be names outside the internal domain.
+	hostIPs map[string][]netip.Addr
+	// containerNetworks maps container IPs to the networks the containers are attached to.
+	containerNetworks map[netip.Addr][]string
+	// publishedIPs maps the domains published by services with HTTP(S) ingress ports to the service container IPs.
+	publishedIPs map[string][]netip.Addr
+	// proxyIPs maps machine IDs to the IPs of the Caddy containers running on them.
+	proxyIPs map[string][]netip.Addr
+	// containerMachines maps container IPs to the IDs of the machines running the containers.
+	containerMachines map[netip.Addr]string
+	// aliases maps names to the names they're resolved as instead, e.g. a service name to the service container
+	// on a particular machine.
+	aliases map[string]string
+	// mu protects the serviceIPs, hostIPs, containerNetworks, publishedIPs, proxyIPs, containerMachines,
+	// and aliases maps.
 	mu sync.RWMutex
 	// lastUpdate tracks when records were last updated.
 	lastUpdate time.Time
 	log        *slog.Logger
 }
 
-// NewClusterResolver creates a new cluster resolver using the cluster store.
-func NewClusterResolver(store *store.Store) *ClusterResolver {
+// NewClusterResolver creates a new cluster resolver using the cluster store. The settings function provides
+// the split-horizon DNS modes of the published domains.
+func NewClusterResolver(store *store.Store, settings func() api.ClusterSettings) *ClusterResolver {
 	return &ClusterResolver{
-		store:      store,
-		serviceIPs: make(map[string][]netip.Addr),
-		log:        slog.With("component", "dns-resolver"),
+		store:             store,
+		settings:          settings,
+		serviceIPs:        make(map[string][]netip.Addr),
+		hostIPs:           make(map[string][]netip.Addr),
+		containerNetworks: make(map[netip.Addr][]string),
+		publishedIPs:      make(map[string][]netip.Addr),
+		proxyIPs:          make(map[string][]netip.Addr),
+		containerMachines: make(map[netip.Addr]string),
+		aliases:           make(map[string]string),
+		log:               slog.With("component", "dns-resolver"),
 	}
 }
 
@@ -41,6 +76,12 @@ func (r *ClusterResolver) Run(ctx context.Context) error {
 	}
 	r.log.Info("Subscribed to container changes in the cluster to keep DNS records updated.")
 
+	aliases, aliasChanges, err := r.store.SubscribeDNSAliases(ctx)
+	if err != nil {
+		return fmt.Errorf("subscribe to DNS alias changes: %w", err)
+	}
+	r.setAliases(aliases)
+
 	// TODO: implement machine membership check using Corrossion Admin client to filter available containers.
 	r.updateServiceIPs(containers)
 
@@ -60,6 +101,18 @@ func (r *ClusterResolver) Run(ctx context.Context) error {
 
 			// TODO: implement machine membership check using Corrossion Admin client to filter available containers.
 			r.updateServiceIPs(containers)
+		case _, ok := <-aliasChanges:
+			if !ok {
+				return fmt.Errorf("DNS aliases subscription failed")
+			}
+			r.log.Debug("DNS aliases changed, updating DNS records.")
+
+			aliases, err = r.store.ListDNSAliases(ctx)
+			if err != nil {
+				r.log.Error("Failed to list DNS aliases.", "err", err)
+				continue
+			}
+			r.setAliases(aliases)
 		case <-ctx.Done():
 			return nil
 		}
@@ -69,6 +122,11 @@ func (r *ClusterResolver) Run(ctx context.Context) error {
 // updateServiceIPs processes container records and updates the serviceIPs map.
 func (r *ClusterResolver) updateServiceIPs(containers []store.ContainerRecord) {
 	newServiceIPs := make(map[string][]netip.Addr, len(r.serviceIPs))
+	newHostIPs := make(map[string][]netip.Addr, len(r.hostIPs))
+	newContainerNetworks := make(map[netip.Addr][]string, len(r.containerNetworks))
+	newPublishedIPs := make(map[string][]netip.Addr, len(r.publishedIPs))
+	newProxyIPs := make(map[string][]netip.Addr, len(r.proxyIPs))
+	newContainerMachines := make(map[netip.Addr]string, len(r.containerMachines))
 
 	containersCount := 0
 	for _, record := range containers {
@@ -96,30 +154,147 @@ func (r *ClusterResolver) updateServiceIPs(containers []store.ContainerRecord) {
 		serviceNameWithMachineID := record.MachineID + ".m." + ctr.ServiceName()
 		newServiceIPs[serviceNameWithMachineID] = append(newServiceIPs[serviceNameWithMachineID], ip)
 
+		// Add <service-name>.<project> as a lookup if the project name can be used as a DNS label.
+		if project := ctr.Project(); projectLabelRegexp.MatchString(project) && len(project) <= 63 {
+			serviceNameWithProject := ctr.ServiceName() + "." + project
+			newServiceIPs[serviceNameWithProject] = append(newServiceIPs[serviceNameWithProject], ip)
+		}
+
+		for _, alias := range ctr.ServiceSpec.Aliases {
+			name := hostName(alias)
+			newHostIPs[name] = append(newHostIPs[name], ip)
+		}
+		if ctr.ServiceSpec.Container.Hostname != "" {
+			name := hostName(ctr.ServiceSpec.Container.Hostname)
+			newHostIPs[name] = append(newHostIPs[name], ip)
+		}
+
+		for _, port := range ctr.ServiceSpec.Ports {
+			if (port.Mode == "" || port.Mode == api.PortModeIngress) && port.Hostname != "" {
+				domain := strings.ToLower(port.Hostname)
+				if !slices.Contains(newPublishedIPs[domain], ip) {
+					newPublishedIPs[domain] = append(newPublishedIPs[domain], ip)
+				}
+			}
+		}
+		if ctr.ServiceName() == caddyServiceName {
+			newProxyIPs[record.MachineID] = append(newProxyIPs[record.MachineID], ip)
+		}
+
+		newContainerNetworks[ip] = ctr.ServiceSpec.ServiceNetworks()
+		newContainerMachines[ip] = record.MachineID
 		containersCount++
 	}
 
 	// Update the serviceIPs map atomically.
 	r.mu.Lock()
 	r.serviceIPs = newServiceIPs
+	r.hostIPs = newHostIPs
+	r.containerNetworks = newContainerNetworks
+	r.publishedIPs = newPublishedIPs
+	r.proxyIPs = newProxyIPs
+	r.containerMachines = newContainerMachines
 	r.mu.Unlock()
 
 	r.log.Debug("DNS records updated.", "services", len(newServiceIPs)/3, "containers", containersCount)
 }
 
-// Resolve returns IP addresses of the service containers.
-func (r *ClusterResolver) Resolve(serviceName string) []netip.Addr {
+// setAliases atomically replaces the DNS aliases.
+func (r *ClusterResolver) setAliases(aliases map[string]string) {
+	r.mu.Lock()
+	r.aliases = aliases
+	r.mu.Unlock()
+}
+
+// hostName returns the custom DNS name in the form it's looked up by Resolve and ResolveHost. Names in the internal
+// domain are looked up without the domain suffix.
+func hostName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), "."+strings.TrimSuffix(InternalDomain, "."))
+}
+
+// Resolve returns IP addresses of the service containers. If the source address belongs to a known service
+// container, only the containers that share at least one network with it are returned. Queries from other sources,
+// e.g. the machine itself or containers not managed by uncloud, are resolved to all service containers.
+// If no service matches the name, it's resolved as a custom DNS name of services and containers.
+func (r *ClusterResolver) Resolve(serviceName string, source netip.Addr) []netip.Addr {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	if target, ok := r.aliases[serviceName]; ok {
+		serviceName = target
+	}
 	ips, ok := r.serviceIPs[serviceName]
 	if !ok || len(ips) == 0 {
+		ips = r.hostIPs[serviceName]
+	}
+	return r.visibleIPs(ips, source)
+}
+
+// ResolveHost returns IP addresses of the containers with the custom DNS name outside the internal domain, i.e.
+// a service alias or container hostname, or nil if no containers have it. The results are scoped to the networks
+// of the source container the same way as in Resolve. If no containers have the name but it's a domain published
+// by a service, it's resolved according to the split-horizon DNS mode of the domain.
+func (r *ClusterResolver) ResolveHost(name string, source netip.Addr) []netip.Addr {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if ips := r.visibleIPs(r.hostIPs[name], source); len(ips) > 0 {
+		return ips
+	}
+	return r.resolvePublishedDomain(name, source)
+}
+
+// resolvePublishedDomain returns IP addresses of the containers serving the domain published by a service inside
+// the cluster so the traffic to it doesn't hairpin through the public address of the cluster. It returns nil if
+// the domain isn't published or its split-horizon DNS mode is off. The caller must hold the read lock.
+func (r *ClusterResolver) resolvePublishedDomain(domain string, source netip.Addr) []netip.Addr {
+	ips, ok := r.publishedIPs[domain]
+	if !ok {
 		return nil
 	}
 
+	mode := api.SplitHorizonProxy
+	if r.settings != nil {
+		settings := r.settings()
+		mode = settings.SplitHorizonMode(domain)
+	}
+
+	switch mode {
+	case api.SplitHorizonDirect:
+		return slices.Clone(ips)
+	case api.SplitHorizonProxy:
+		// Prefer the Caddy container on the same machine as the source container to avoid an extra hop.
+		if local := r.proxyIPs[r.containerMachines[source]]; len(local) > 0 {
+			return slices.Clone(local)
+		}
+		var proxyIPs []netip.Addr
+		for _, machineIPs := range r.proxyIPs {
+			proxyIPs = append(proxyIPs, machineIPs...)
+		}
+		return proxyIPs
+	}
+	return nil
+}
+
+// visibleIPs returns a copy of the container IPs that share at least one network with the source container or
+// all IPs if the source is not a known service container. The caller must hold the read lock.
+func (r *ClusterResolver) visibleIPs(ips []netip.Addr, source netip.Addr) []netip.Addr {
+	if len(ips) == 0 {
+		return nil
+	}
+
+	sourceNetworks, scoped := r.containerNetworks[source]
 	// Return a copy of the IPs slice to prevent modification of the original.
-	ipsCopy := make([]netip.Addr, len(ips))
-	copy(ipsCopy, ips)
+	ipsCopy := make([]netip.Addr, 0, len(ips))
+	for _, ip := range ips {
+		if scoped && !api.NetworksIntersect(sourceNetworks, r.containerNetworks[ip]) {
+			continue
+		}
+		ipsCopy = append(ipsCopy, ip)
+	}
+	if len(ipsCopy) == 0 {
+		return nil
+	}
 
 	return ipsCopy
 }
@@ -0,0 +1,212 @@
+package dns
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func containerRecord(serviceName, ip string, networks ...string) store.ContainerRecord {
+	return store.ContainerRecord{
+		Container: api.ServiceContainer{
+			Container: api.Container{
+				ContainerJSON: types.ContainerJSON{
+					ContainerJSONBase: &types.ContainerJSONBase{
+						State: &types.ContainerState{Running: true},
+					},
+					Config: &container.Config{
+						Labels: map[string]string{
+							api.LabelServiceID:   serviceName + "-id",
+							api.LabelServiceName: serviceName,
+						},
+					},
+					NetworkSettings: &types.NetworkSettings{
+						Networks: map[string]*network.EndpointSettings{
+							api.DockerNetworkName: {IPAddress: ip},
+						},
+					},
+				},
+			},
+			ServiceSpec: api.ServiceSpec{
+				Name:     serviceName,
+				Networks: networks,
+			},
+		},
+		MachineID: "machine1",
+	}
+}
+
+func TestClusterResolver_Resolve_NetworkScoping(t *testing.T) {
+	t.Parallel()
+
+	web := netip.MustParseAddr("10.210.0.2")
+	apiIP := netip.MustParseAddr("10.210.0.3")
+	db := netip.MustParseAddr("10.210.0.4")
+	other := netip.MustParseAddr("10.210.0.5")
+
+	r := NewClusterResolver(nil, nil)
+	r.updateServiceIPs([]store.ContainerRecord{
+		containerRecord("web", web.String(), "frontend"),
+		containerRecord("api", apiIP.String(), "frontend", "backend"),
+		containerRecord("db", db.String(), "backend"),
+		containerRecord("other", other.String()),
+	})
+
+	tests := []struct {
+		name    string
+		service string
+		source  netip.Addr
+		want    []netip.Addr
+	}{
+		{
+			name:    "same network",
+			service: "api",
+			source:  web,
+			want:    []netip.Addr{apiIP},
+		},
+		{
+			name:    "different network",
+			service: "db",
+			source:  web,
+		},
+		{
+			name:    "multiple networks",
+			service: "db",
+			source:  apiIP,
+			want:    []netip.Addr{db},
+		},
+		{
+			name:    "default network",
+			service: "web",
+			source:  other,
+		},
+		{
+			name:    "unknown source resolves all",
+			service: "db",
+			source:  netip.MustParseAddr("10.210.0.1"),
+			want:    []netip.Addr{db},
+		},
+		{
+			name:    "invalid source resolves all",
+			service: "other",
+			want:    []netip.Addr{other},
+		},
+		{
+			name:    "unknown service",
+			service: "unknown",
+			source:  web,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.Resolve(tt.service, tt.source))
+		})
+	}
+}
+
+func TestClusterResolver_Resolve_Alias(t *testing.T) {
+	t.Parallel()
+
+	primary := netip.MustParseAddr("10.210.0.2")
+	standby := netip.MustParseAddr("10.210.1.2")
+
+	r := NewClusterResolver(nil, nil)
+	primaryRecord := containerRecord("db", primary.String())
+	standbyRecord := containerRecord("db", standby.String())
+	standbyRecord.MachineID = "machine2"
+	r.updateServiceIPs([]store.ContainerRecord{primaryRecord, standbyRecord})
+
+	assert.ElementsMatch(t, []netip.Addr{primary, standby}, r.Resolve("db", netip.Addr{}))
+
+	r.setAliases(map[string]string{"db": "machine2.m.db"})
+	assert.Equal(t, []netip.Addr{standby}, r.Resolve("db", netip.Addr{}))
+	assert.Equal(t, []netip.Addr{primary}, r.Resolve("machine1.m.db", netip.Addr{}))
+
+	r.setAliases(map[string]string{"db": "machine3.m.db"})
+	assert.Empty(t, r.Resolve("db", netip.Addr{}), "alias to a machine without containers must not resolve")
+}
+
+func TestClusterResolver_Resolve_Project(t *testing.T) {
+	t.Parallel()
+
+	web := netip.MustParseAddr("10.210.0.2")
+	legacy := netip.MustParseAddr("10.210.0.3")
+
+	webRecord := containerRecord("web", web.String())
+	webRecord.Container.Config.Labels[api.LabelProject] = "shop"
+	legacyRecord := containerRecord("legacy", legacy.String())
+	legacyRecord.Container.Config.Labels[api.LabelProject] = "old_shop"
+
+	r := NewClusterResolver(nil, nil)
+	r.updateServiceIPs([]store.ContainerRecord{webRecord, legacyRecord})
+
+	assert.Equal(t, []netip.Addr{web}, r.Resolve("web", netip.Addr{}))
+	assert.Equal(t, []netip.Addr{web}, r.Resolve("web.shop", netip.Addr{}))
+	assert.Equal(t, []netip.Addr{legacy}, r.Resolve("legacy", netip.Addr{}))
+	assert.Empty(t, r.Resolve("legacy.old_shop", netip.Addr{}),
+		"project name that is not a valid DNS label must not be used as a subdomain")
+}
+
+func TestClusterResolver_ResolveHost(t *testing.T) {
+	t.Parallel()
+
+	web := netip.MustParseAddr("10.210.0.2")
+	db := netip.MustParseAddr("10.210.0.3")
+
+	webRecord := containerRecord("web", web.String(), "frontend")
+	webRecord.Container.ServiceSpec.Aliases = []string{"www", "www.example.com"}
+	dbRecord := containerRecord("db", db.String(), "backend")
+	dbRecord.Container.ServiceSpec.Container.Hostname = "postgres"
+
+	r := NewClusterResolver(nil, nil)
+	r.updateServiceIPs([]store.ContainerRecord{webRecord, dbRecord})
+
+	assert.Equal(t, []netip.Addr{web}, r.Resolve("www", netip.Addr{}), "alias in internal domain")
+	assert.Equal(t, []netip.Addr{db}, r.Resolve("postgres", netip.Addr{}), "hostname in internal domain")
+	assert.Equal(t, []netip.Addr{web}, r.ResolveHost("www.example.com", netip.Addr{}))
+	assert.Empty(t, r.ResolveHost("example.com", netip.Addr{}))
+	assert.Empty(t, r.ResolveHost("postgres", web), "hostname in a different network")
+}
+
+func TestClusterResolver_ResolveHost_SplitHorizon(t *testing.T) {
+	t.Parallel()
+
+	web := netip.MustParseAddr("10.210.0.2")
+	caddy1 := netip.MustParseAddr("10.210.0.3")
+	caddy2 := netip.MustParseAddr("10.210.1.2")
+	client2 := netip.MustParseAddr("10.210.1.3")
+
+	webRecord := containerRecord("web", web.String())
+	webRecord.Container.ServiceSpec.Ports = []api.PortSpec{
+		{Hostname: "app.example.com", ContainerPort: 8080, Protocol: api.ProtocolHTTPS, Mode: api.PortModeIngress},
+		{Hostname: "www.example.com", ContainerPort: 8080, Protocol: api.ProtocolHTTPS},
+		{Hostname: "off.example.com", ContainerPort: 8080, Protocol: api.ProtocolHTTPS},
+	}
+	caddy2Record := containerRecord("caddy", caddy2.String())
+	caddy2Record.MachineID = "machine2"
+	client2Record := containerRecord("client", client2.String())
+	client2Record.MachineID = "machine2"
+
+	settings := api.ClusterSettings{SplitHorizon: map[string]api.SplitHorizonMode{
+		"www.example.com": api.SplitHorizonDirect,
+		"off.example.com": api.SplitHorizonOff,
+	}}
+	r := NewClusterResolver(nil, func() api.ClusterSettings { return settings })
+	r.updateServiceIPs([]store.ContainerRecord{
+		webRecord, containerRecord("caddy", caddy1.String()), caddy2Record, client2Record,
+	})
+
+	assert.Equal(t, []netip.Addr{caddy2}, r.ResolveHost("app.example.com", client2),
+		"proxy mode must prefer Caddy on the same machine")
+	assert.ElementsMatch(t, []netip.Addr{caddy1, caddy2}, r.ResolveHost("app.example.com", netip.Addr{}))
+	assert.Equal(t, []netip.Addr{web}, r.ResolveHost("www.example.com", client2))
+	assert.Empty(t, r.ResolveHost("off.example.com", client2))
+	assert.Empty(t, r.ResolveHost("example.com", client2), "domain not published")
+}
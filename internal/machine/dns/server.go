@@ -31,9 +31,13 @@ const (
 
 // Resolver is an interface for resolving service names to IP addresses.
 type Resolver interface {
-	// Resolve returns a list of IP addresses of the service containers.
-	// An empty list is returned if no service is found.
-	Resolve(serviceName string) []netip.Addr
+	// Resolve returns a list of IP addresses of the service containers visible from the source address
+	// of the query. An empty list is returned if no service is found.
+	Resolve(serviceName string, source netip.Addr) []netip.Addr
+	// ResolveHost returns a list of IP addresses of the containers with the custom DNS name outside the internal
+	// domain or serving the domain published by a service, visible from the source address of the query.
+	// An empty list is returned if the name should be resolved by the upstream DNS servers.
+	ResolveHost(name string, source netip.Addr) []netip.Addr
 }
 
 // Server is an embedded internal DNS server for service discovery and forwarding external queries
@@ -184,7 +188,15 @@ func (s *Server) handleRequest(w dns.ResponseWriter, req *dns.Msg) {
 	log := s.log.With("name", q.Name, "type", dns.TypeToString[q.Qtype])
 	log.Debug("Received DNS query.")
 
-	if !dns.IsSubDomain(InternalDomain, dns.CanonicalName(q.Name)) {
+	// Custom DNS names of services and containers outside the internal domain and the domains published by services
+	// (split-horizon DNS) take precedence over upstream servers.
+	internal := dns.IsSubDomain(InternalDomain, dns.CanonicalName(q.Name))
+	var hostIPs []netip.Addr
+	if !internal {
+		hostIPs = s.resolver.ResolveHost(strings.TrimSuffix(dns.CanonicalName(q.Name), "."), remoteAddr(w))
+	}
+
+	if !internal && len(hostIPs) == 0 {
 		log.Debug("Forwarding non-internal DNS query to upstream DNS servers.")
 
 		// Use the same transport for the forwarded request as the original request.
@@ -198,14 +210,20 @@ func (s *Server) handleRequest(w dns.ResponseWriter, req *dns.Msg) {
 		return
 	}
 
-	// Handle the query for the internal domain.
+	// Handle the query for the internal domain or a custom DNS name.
 	resp := new(dns.Msg).SetReply(req)
 	resp.Authoritative = true
 	resp.RecursionAvailable = true
 
 	switch q.Qtype {
 	case dns.TypeA:
-		records := s.handleAQuery(q.Name)
+		var records []dns.RR
+		if internal {
+			records = s.handleAQuery(q.Name, remoteAddr(w))
+		} else {
+			s.log.Debug("Resolved custom DNS name.", "name", q.Name, "ips", hostIPs)
+			records = aRecords(q.Name, hostIPs)
+		}
 		if len(records) > 0 {
 			log.Debug("Found A records for internal DNS query.", "count", len(records))
 			resp.Answer = append(resp.Answer, records...)
@@ -286,15 +304,20 @@ func (s *Server) forwardRequest(req *dns.Msg, proto string) (*dns.Msg, error) {
 
 // handleAQuery processes an A query for the internal domain and returns A records for the requested name.
 // The internal domain suffix is already stripped from the name. An empty list is returned if no records are found.
-func (s *Server) handleAQuery(name string) []dns.RR {
+func (s *Server) handleAQuery(name string, source netip.Addr) []dns.RR {
 	serviceName := trimInternalDomain(name)
-	ips := s.resolver.Resolve(serviceName)
+	ips := s.resolver.Resolve(serviceName, source)
 	if len(ips) == 0 {
 		s.log.Debug("Failed to resolve service name.", "service", serviceName)
 		return nil
 	}
 	s.log.Debug("Resolved service name.", "service", serviceName, "ips", ips)
 
+	return aRecords(name, ips)
+}
+
+// aRecords returns A records for the name with the given IPs in random order.
+func aRecords(name string, ips []netip.Addr) []dns.RR {
 	if len(ips) > 1 {
 		// TODO: sort by proximity to the requesting container/machine. For now, just shuffle the IPs.
 		rand.Shuffle(len(ips), func(i, j int) {
@@ -320,6 +343,15 @@ func (s *Server) handleAQuery(name string) []dns.RR {
 	return records
 }
 
+// remoteAddr returns the IP address of the client that sent the DNS query or an invalid address if it can't be parsed.
+func remoteAddr(w dns.ResponseWriter) netip.Addr {
+	addrPort, err := netip.ParseAddrPort(w.RemoteAddr().String())
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addrPort.Addr().Unmap()
+}
+
 // parseNameserversFromResolvConf parses the nameservers from /etc/resolv.conf.
 func parseNameserversFromResolvConf() ([]netip.Addr, error) {
 	config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
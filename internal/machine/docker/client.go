@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/netip"
+	"slices"
 
 	"github.com/distribution/reference"
 	"github.com/docker/docker/api/types"
@@ -26,21 +28,24 @@ import (
 
 // Client is a gRPC client for the Docker service that provides a similar interface to the Docker HTTP client.
 type Client struct {
-	conn       *grpc.ClientConn
+	conn       grpc.ClientConnInterface
 	grpcClient pb.DockerClient
 }
 
 // NewClient creates a new Docker gRPC client with the provided gRPC connection.
-func NewClient(conn *grpc.ClientConn) *Client {
+func NewClient(conn grpc.ClientConnInterface) *Client {
 	return &Client{
 		conn:       conn,
 		grpcClient: pb.NewDockerClient(conn),
 	}
 }
 
-// Close closes the gRPC connection.
+// Close closes the gRPC connection if it can be closed.
 func (c *Client) Close() error {
-	return c.conn.Close()
+	if closer, ok := c.conn.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
 }
 
 // CreateContainer creates a new container based on the given configuration.
@@ -197,6 +202,183 @@ func (c *Client) RemoveContainer(ctx context.Context, id string, opts container.
 	return err
 }
 
+// ContainerLogs streams the stdout and stderr logs of a container with the given ID. The returned channel is closed
+// when the logs stream ends or the context is canceled. If streaming fails, the last entry contains the error.
+func (c *Client) ContainerLogs(
+	ctx context.Context, id string, opts container.LogsOptions,
+) (<-chan api.ContainerLogEntry, error) {
+	optsBytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("marshal options: %w", err)
+	}
+
+	stream, err := c.grpcClient.ContainerLogs(ctx, &pb.ContainerLogsRequest{Id: id, Options: optsBytes})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan api.ContainerLogEntry)
+	go func() {
+		defer close(ch)
+
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if status.Convert(err).Code() == codes.NotFound {
+					err = errdefs.NotFound(err)
+				}
+				if ctx.Err() == nil {
+					ch <- api.ContainerLogEntry{Err: err}
+				}
+				return
+			}
+
+			entry := api.ContainerLogEntry{Stream: api.LogStreamStdout, Data: msg.Data}
+			if msg.Stream == pb.ContainerLogsResponse_STDERR {
+				entry.Stream = api.LogStreamStderr
+			}
+			select {
+			case ch <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// ExecContainer runs a command in a running container with the given ID, streams its input and output, and returns
+// the exit code of the command once it exits.
+func (c *Client) ExecContainer(ctx context.Context, id string, opts api.ExecOptions) (int, error) {
+	execOpts := container.ExecOptions{
+		User:         opts.User,
+		Privileged:   opts.Privileged,
+		Tty:          opts.Tty,
+		AttachStdin:  opts.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          opts.Env,
+		WorkingDir:   opts.WorkingDir,
+		Cmd:          opts.Cmd,
+	}
+	optsBytes, err := json.Marshal(execOpts)
+	if err != nil {
+		return 0, fmt.Errorf("marshal options: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.grpcClient.ExecContainer(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err = stream.Send(&pb.ExecContainerRequest{
+		Payload: &pb.ExecContainerRequest_Config{Config: &pb.ExecConfig{ContainerId: id, Options: optsBytes}},
+	}); err != nil {
+		return 0, fmt.Errorf("send exec config: %w", err)
+	}
+
+	// Read stdin in a separate goroutine as reads may block indefinitely, e.g. when reading from a terminal.
+	var stdinCh chan []byte
+	if opts.Stdin != nil {
+		stdinCh = make(chan []byte)
+		go func() {
+			defer close(stdinCh)
+			buf := make([]byte, 32*1024)
+			for {
+				n, err := opts.Stdin.Read(buf)
+				if n > 0 {
+					data := make([]byte, n)
+					copy(data, buf[:n])
+					select {
+					case stdinCh <- data:
+					case <-ctx.Done():
+						return
+					}
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+	resizeCh := opts.Resize
+	if !opts.Tty {
+		resizeCh = nil
+	}
+
+	// Send stdin data and terminal resize events from a single goroutine as gRPC streams don't support
+	// concurrent sends.
+	go func() {
+		for {
+			var req *pb.ExecContainerRequest
+			select {
+			case data, ok := <-stdinCh:
+				if !ok {
+					stdinCh = nil
+					req = &pb.ExecContainerRequest{Payload: &pb.ExecContainerRequest_CloseStdin{CloseStdin: true}}
+				} else {
+					req = &pb.ExecContainerRequest{Payload: &pb.ExecContainerRequest_Stdin{Stdin: data}}
+				}
+			case size, ok := <-resizeCh:
+				if !ok {
+					resizeCh = nil
+					continue
+				}
+				req = &pb.ExecContainerRequest{Payload: &pb.ExecContainerRequest_Resize{
+					Resize: &pb.TerminalSize{Height: uint32(size.Height), Width: uint32(size.Width)},
+				}}
+			case <-ctx.Done():
+				return
+			}
+			if err := stream.Send(req); err != nil {
+				return
+			}
+		}
+	}()
+
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return 0, errors.New("exec stream closed without exit code")
+		}
+		if err != nil {
+			switch status.Convert(err).Code() {
+			case codes.NotFound:
+				return 0, errdefs.NotFound(err)
+			case codes.FailedPrecondition:
+				return 0, errdefs.Conflict(err)
+			}
+			return 0, err
+		}
+
+		switch p := resp.Payload.(type) {
+		case *pb.ExecContainerResponse_Stdout:
+			if _, err = stdout.Write(p.Stdout); err != nil {
+				return 0, fmt.Errorf("write stdout: %w", err)
+			}
+		case *pb.ExecContainerResponse_Stderr:
+			if _, err = stderr.Write(p.Stderr); err != nil {
+				return 0, fmt.Errorf("write stderr: %w", err)
+			}
+		case *pb.ExecContainerResponse_ExitCode:
+			return int(p.ExitCode), nil
+		}
+	}
+}
+
 // PullOptions defines the options for pulling an image from a remote registry.
 // This is a copy of image.PullOptions from the Docker API without the PrivilegeFunc field that is non-serialisable.
 type PullOptions struct {
@@ -345,6 +527,13 @@ func parseRemoteImageMessage(msg *pb.RemoteImage) (api.MachineRemoteImage, error
 			return mri, fmt.Errorf("unmarshal image manifest: %w", err)
 		}
 		mri.Image.ImageManifest = image
+		if len(msg.Platform) > 0 {
+			platform := &ocispec.Platform{}
+			if err = json.Unmarshal(msg.Platform, platform); err != nil {
+				return mri, fmt.Errorf("unmarshal image platform: %w", err)
+			}
+			mri.Image.Platform = platform
+		}
 	default:
 		return mri, fmt.Errorf("unexpected manifest media type: %s", manifest.MediaType)
 	}
@@ -421,6 +610,175 @@ func (c *Client) RemoveVolume(ctx context.Context, id string, force bool) error
 	return err
 }
 
+// CreateVolumeSnapshot creates a snapshot of the local volume with the given ID.
+func (c *Client) CreateVolumeSnapshot(ctx context.Context, volumeName, id string) (api.VolumeSnapshot, error) {
+	var snap api.VolumeSnapshot
+
+	resp, err := c.grpcClient.CreateVolumeSnapshot(ctx, &pb.VolumeSnapshotRequest{VolumeName: volumeName, Id: id})
+	if err != nil {
+		return snap, err
+	}
+
+	if err = json.Unmarshal(resp.Snapshot, &snap); err != nil {
+		return snap, fmt.Errorf("unmarshal snapshot: %w", err)
+	}
+
+	return snap, nil
+}
+
+// RestoreVolumeSnapshot replaces the data of the local volume with the snapshot with the given ID.
+func (c *Client) RestoreVolumeSnapshot(ctx context.Context, volumeName, id string) error {
+	_, err := c.grpcClient.RestoreVolumeSnapshot(ctx, &pb.VolumeSnapshotRequest{VolumeName: volumeName, Id: id})
+	if err != nil && status.Convert(err).Code() == codes.NotFound {
+		return errdefs.NotFound(err)
+	}
+	return err
+}
+
+// RemoveVolumeSnapshot removes the snapshot of the local volume with the given ID.
+func (c *Client) RemoveVolumeSnapshot(ctx context.Context, volumeName, id string) error {
+	_, err := c.grpcClient.RemoveVolumeSnapshot(ctx, &pb.VolumeSnapshotRequest{VolumeName: volumeName, Id: id})
+	return err
+}
+
+// GetVolumeBackend returns the backend managing the data of the local volume.
+func (c *Client) GetVolumeBackend(ctx context.Context, volumeName string) (string, error) {
+	resp, err := c.grpcClient.GetVolumeBackend(ctx, &pb.GetVolumeBackendRequest{VolumeName: volumeName})
+	if err != nil {
+		return "", err
+	}
+	return resp.Backend, nil
+}
+
+// ExportVolume writes a stream of the local volume data in the format of the given backend to the writer.
+func (c *Client) ExportVolume(ctx context.Context, volumeName, backend string, w io.Writer) error {
+	stream, err := c.grpcClient.ExportVolume(ctx, &pb.ExportVolumeRequest{VolumeName: volumeName, Backend: backend})
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if _, err = w.Write(msg.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportVolume replaces the local volume data with a stream in the format of the given backend read from the reader.
+func (c *Client) ImportVolume(ctx context.Context, volumeName, backend string, r io.Reader) error {
+	stream, err := c.grpcClient.ImportVolume(ctx)
+	if err != nil {
+		return err
+	}
+	if err = stream.Send(&pb.ImportVolumeRequest{
+		Payload: &pb.ImportVolumeRequest_Header{
+			Header: &pb.ExportVolumeRequest{VolumeName: volumeName, Backend: backend},
+		},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			if err = stream.Send(&pb.ImportVolumeRequest{
+				Payload: &pb.ImportVolumeRequest_Data{Data: slices.Clone(buf[:n])},
+			}); err != nil {
+				// The server closed the stream, the actual error is returned by CloseAndRecv.
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+		}
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				break
+			}
+			return readErr
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// ExportImage writes the local image in the 'docker save' tar format to the writer.
+func (c *Client) ExportImage(ctx context.Context, image string, w io.Writer) error {
+	stream, err := c.grpcClient.ExportImage(ctx, &pb.ExportImageRequest{Image: image})
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if _, err = w.Write(msg.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// LoadImage loads an image in the 'docker save' tar format read from the reader into the local image store.
+func (c *Client) LoadImage(ctx context.Context, r io.Reader) error {
+	stream, err := c.grpcClient.LoadImage(ctx)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			if err = stream.Send(&pb.ImageData{Data: slices.Clone(buf[:n])}); err != nil {
+				// The server closed the stream, the actual error is returned by CloseAndRecv.
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+		}
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				break
+			}
+			return readErr
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// MirrorImage copies the image from the nearest of the machines with the given management IPs into the local
+// image store transferring only the missing layers.
+func (c *Client) MirrorImage(
+	ctx context.Context, image string, sourceMachineIPs []netip.Addr,
+) (*pb.MirrorImageResponse, error) {
+	req := &pb.MirrorImageRequest{Image: image}
+	for _, ip := range sourceMachineIPs {
+		req.SourceMachineIps = append(req.SourceMachineIps, pb.NewIP(ip))
+	}
+	// Set the single source for older machine daemons that don't support multiple sources.
+	if len(sourceMachineIPs) > 0 {
+		req.SourceMachineIp = req.SourceMachineIps[0]
+	}
+	return c.grpcClient.MirrorImage(ctx, req)
+}
+
 // CreateServiceContainer creates a new container for the service with the given specifications.
 func (c *Client) CreateServiceContainer(
 	ctx context.Context, serviceID string, spec api.ServiceSpec, containerName string,
@@ -449,6 +807,24 @@ func (c *Client) CreateServiceContainer(
 	return resp, nil
 }
 
+// UpdateServiceContainerSpec updates the spec of the service container with the given ID without recreating it.
+func (c *Client) UpdateServiceContainerSpec(ctx context.Context, id string, spec api.ServiceSpec) error {
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("marshal service spec: %w", err)
+	}
+	_, err = c.grpcClient.UpdateServiceContainerSpec(ctx, &pb.UpdateServiceContainerSpecRequest{
+		Id:          id,
+		ServiceSpec: specBytes,
+	})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return errdefs.NotFound(err)
+		}
+	}
+	return err
+}
+
 // InspectServiceContainer returns the container information and service specification that was used to create the
 // container with the given ID.
 func (c *Client) InspectServiceContainer(ctx context.Context, id string) (api.ServiceContainer, error) {
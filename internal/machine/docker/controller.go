@@ -5,21 +5,29 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/machine/settings"
 	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
 )
 
 const (
 	NetworkName = "uncloud"
+	// MacvlanNetworkPrefix is the prefix of the macvlan Docker networks created for services in the macvlan network
+	// mode. The full network name is the prefix followed by the parent interface name.
+	MacvlanNetworkPrefix = "uncloud-macvlan-"
 	// EventsDebounceInterval defines how long to wait before processing the next Docker event. Multiple events
 	// occurring within this window will be processed together as a single event to prevent system overload.
 	EventsDebounceInterval = 100 * time.Millisecond
-	// SyncInterval defines a regular interval to sync containers to the cluster store.
+	// SyncInterval defines the default regular interval to sync containers to the cluster store. It can be changed
+	// with the container sync interval in the cluster settings.
 	SyncInterval = 30 * time.Second
 )
 
@@ -30,14 +38,28 @@ type Controller struct {
 	client    *client.Client
 	service   *Service
 	store     *store.Store
+	// settings provides the cluster-wide settings such as the container sync interval and image GC policy.
+	settings *settings.Watcher
+	// lastExits tracks the last exit of service containers from Docker events by container ID. Docker resets the exit
+	// state of a container when it's restarted so this is the only way to retain the exit reason of a crash-looping
+	// container. It's only accessed by WatchAndSyncContainers.
+	lastExits map[string]*api.ContainerExit
+	// drifts tracks the configuration drift of service containers by container ID detected in the last sync to log
+	// when a container drifts from its spec or is back in sync. It's only accessed by WatchAndSyncContainers.
+	drifts map[string]string
 }
 
-func NewController(machineID string, service *Service, store *store.Store) *Controller {
+func NewController(
+	machineID string, service *Service, store *store.Store, settings *settings.Watcher,
+) *Controller {
 	return &Controller{
 		machineID: machineID,
 		client:    service.Client,
 		service:   service,
 		store:     store,
+		settings:  settings,
+		lastExits: make(map[string]*api.ContainerExit),
+		drifts:    make(map[string]string),
 	}
 }
 
@@ -80,6 +102,7 @@ func (c *Controller) WatchAndSyncContainers(ctx context.Context) error {
 		),
 	}
 
+	settingsChanges := c.settings.Subscribe()
 	// Subscribe to Docker events before running the initial sync to avoid missing any events.
 	eventCh, errCh := c.service.Client.Events(ctx, opts)
 	slog.Debug("Syncing containers to cluster store before processing Docker events.")
@@ -94,13 +117,16 @@ func (c *Controller) WatchAndSyncContainers(ctx context.Context) error {
 		debouncer   *time.Timer
 		debouncerCh = make(chan events.Message)
 		// ticker is used to trigger a regular sync of containers to the cluster store as a fallback.
-		ticker = time.NewTicker(SyncInterval)
+		syncInterval = c.syncInterval()
+		ticker       = time.NewTicker(syncInterval)
 	)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case e := <-eventCh:
+			c.trackExit(e)
+
 			switch e.Action {
 			// Actions that may trigger a container state change or creation/deletion of a container.
 			case events.ActionCreate,
@@ -112,6 +138,8 @@ func (c *Controller) WatchAndSyncContainers(ctx context.Context) error {
 				events.ActionDie,
 				events.ActionOOM,
 				events.ActionDestroy,
+				// A container updated with 'docker update' may drift from its spec.
+				events.ActionUpdate,
 				events.ActionHealthStatusHealthy,
 				events.ActionHealthStatusUnhealthy:
 
@@ -131,9 +159,20 @@ func (c *Controller) WatchAndSyncContainers(ctx context.Context) error {
 			if err := c.syncContainersToStore(ctx); err != nil {
 				return fmt.Errorf("sync containers to cluster store: %w", err)
 			}
+		case <-c.service.specUpdates:
+			slog.Debug("Syncing containers to cluster store triggered by a service container spec update.")
+			if err := c.syncContainersToStore(ctx); err != nil {
+				return fmt.Errorf("sync containers to cluster store: %w", err)
+			}
+		case <-settingsChanges:
+			if interval := c.syncInterval(); interval != syncInterval {
+				syncInterval = interval
+				ticker.Reset(syncInterval)
+				slog.Info("Changed interval to sync containers to cluster store.", "interval", syncInterval)
+			}
 		case <-ticker.C:
 			slog.Debug("Syncing containers to cluster store triggered by a regular interval.",
-				"interval", SyncInterval)
+				"interval", syncInterval)
 			if err := c.syncContainersToStore(ctx); err != nil {
 				return fmt.Errorf("sync containers to cluster store: %w", err)
 			}
@@ -146,6 +185,37 @@ func (c *Controller) WatchAndSyncContainers(ctx context.Context) error {
 	}
 }
 
+// syncInterval returns the interval to sync containers to the cluster store from the cluster settings or
+// the default SyncInterval if not set.
+func (c *Controller) syncInterval() time.Duration {
+	if interval := c.settings.Get().ContainerSyncInterval; interval > 0 {
+		return interval
+	}
+	return SyncInterval
+}
+
+// trackExit records the exit of a container from the Docker die and oom events. Docker emits the oom event before
+// the die event when a container is killed due to running out of memory.
+func (c *Controller) trackExit(e events.Message) {
+	switch e.Action {
+	case events.ActionOOM:
+		c.lastExits[e.Actor.ID] = &api.ContainerExit{OOMKilled: true}
+	case events.ActionDie:
+		exitCode, _ := strconv.Atoi(e.Actor.Attributes["exitCode"])
+		exit := &api.ContainerExit{
+			ExitCode:   exitCode,
+			FinishedAt: time.Unix(0, e.TimeNano).UTC(),
+		}
+		// Carry over the OOM kill recorded by the preceding oom event.
+		if prev := c.lastExits[e.Actor.ID]; prev != nil && prev.OOMKilled && prev.FinishedAt.IsZero() {
+			exit.OOMKilled = true
+		}
+		c.lastExits[e.Actor.ID] = exit
+	case events.ActionDestroy:
+		delete(c.lastExits, e.Actor.ID)
+	}
+}
+
 func (c *Controller) syncContainersToStore(ctx context.Context) error {
 	storeContainers, err := c.store.ListContainers(ctx, store.ListOptions{MachineIDs: []string{c.machineID}})
 	if err != nil {
@@ -180,11 +250,42 @@ func (c *Controller) syncContainersToStore(ctx context.Context) error {
 		}
 	}
 
+	c.logDrift(containers)
+
 	// Create or update the current Docker containers in the store.
 	for _, ctr := range containers {
+		if exit := c.lastExits[ctr.ID]; exit != nil && !exit.FinishedAt.IsZero() {
+			ctr.Container.LastExit = exit
+		}
 		if err = c.store.CreateOrUpdateContainer(ctx, ctr, c.machineID); err != nil {
 			storeErr = errors.Join(storeErr, fmt.Errorf("create or update container '%s': %w", ctr.ID, err))
 		}
 	}
 	return storeErr
 }
+
+// logDrift logs the service containers that have drifted from their spec or are back in sync since the last sync.
+func (c *Controller) logDrift(containers []api.ServiceContainer) {
+	current := make(map[string]string)
+	for _, ctr := range containers {
+		if len(ctr.Drift) == 0 {
+			if _, ok := c.drifts[ctr.ID]; ok {
+				slog.Info("Service container is back in sync with its spec.",
+					"service", ctr.ServiceName(), "container", strings.TrimPrefix(ctr.Name, "/"))
+			}
+			continue
+		}
+
+		fields := make([]string, len(ctr.Drift))
+		for i, d := range ctr.Drift {
+			fields[i] = d.String()
+		}
+		drift := strings.Join(fields, "; ")
+		if c.drifts[ctr.ID] != drift {
+			slog.Warn("Service container configuration drifted from its spec.",
+				"service", ctr.ServiceName(), "container", strings.TrimPrefix(ctr.Name, "/"), "drift", drift)
+		}
+		current[ctr.ID] = drift
+	}
+	c.drifts = current
+}
@@ -20,11 +20,14 @@ import (
 )
 
 // EnsureUncloudNetwork creates the Docker bridge network NetworkName with the provided machine subnet
-// if it doesn't exist. If the network exists but has a different subnet, it removes and recreates the network.
-// It also configures iptables to allow container access from the WireGuard network.
+// if it doesn't exist. If the network exists but has a different subnet, e.g. after the machine has been renumbered
+// by a cluster network migration, it disconnects the containers, recreates the network, and reconnects them so
+// they get new IPs from the subnet. It also configures iptables to allow container access from the WireGuard network.
 func (c *Controller) EnsureUncloudNetwork(ctx context.Context, subnet netip.Prefix, dnsServer netip.Addr) error {
 	// Ensure the Docker network 'uncloud' is created with the correct subnet.
 	needsCreation := false
+	// reconnect are the IDs of the containers to reconnect to the recreated network.
+	var reconnect []string
 	nw, err := c.client.NetworkInspect(ctx, NetworkName, dnetwork.InspectOptions{})
 	if err != nil {
 		if !client.IsErrNotFound(err) {
@@ -37,6 +40,12 @@ func (c *Controller) EnsureUncloudNetwork(ctx context.Context, subnet netip.Pref
 		slog.Info(
 			"Removing Docker network with old subnet.", "name", NetworkName, "subnet", nw.IPAM.Config[0].Subnet,
 		)
+		for id := range nw.Containers {
+			if err = c.client.NetworkDisconnect(ctx, NetworkName, id, true); err != nil {
+				return fmt.Errorf("disconnect container '%s' from Docker network '%s': %w", id, NetworkName, err)
+			}
+			reconnect = append(reconnect, id)
+		}
 		if err = c.client.NetworkRemove(ctx, NetworkName); err != nil {
 			// It can still fail if the network is in use by a container. Leave it to the user to resolve the issue.
 			return fmt.Errorf("remove Docker network '%s': %w", NetworkName, err)
@@ -70,6 +79,14 @@ func (c *Controller) EnsureUncloudNetwork(ctx context.Context, subnet netip.Pref
 		}
 		slog.Info("Docker network created.", "name", NetworkName, "subnet", subnet.String())
 
+		for _, id := range reconnect {
+			if err = c.client.NetworkConnect(ctx, NetworkName, id, nil); err != nil {
+				// Don't fail the network setup because of a single container. It can be recreated to fix it.
+				slog.Error("Failed to reconnect container to Docker network.",
+					"id", id, "network", NetworkName, "err", err)
+			}
+		}
+
 		if nw, err = c.client.NetworkInspect(ctx, NetworkName, dnetwork.InspectOptions{}); err != nil {
 			return fmt.Errorf("inspect Docker network '%s': %w", NetworkName, err)
 		}
@@ -0,0 +1,252 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// DaemonConfigPath is the default path to the Docker daemon configuration file.
+const DaemonConfigPath = "/etc/docker/daemon.json"
+
+// daemonProxies is the "proxies" section of the Docker daemon configuration used by the daemon to pull images.
+type daemonProxies struct {
+	HTTPProxy  string `json:"http-proxy,omitempty"`
+	HTTPSProxy string `json:"https-proxy,omitempty"`
+	NoProxy    string `json:"no-proxy,omitempty"`
+}
+
+// RunDaemonConfig keeps the outbound proxy and registry mirrors in the Docker daemon configuration in sync with
+// the cluster settings so that the daemon pulls images through them. The registry mirrors that were set from
+// the settings are tracked in the file at registryStatePath to remove them when they're unset without touching
+// the mirrors configured manually. The daemon is reloaded to apply the registry changes but isn't restarted
+// to apply the proxy changes to not interrupt the running containers, a warning is logged instead.
+func (c *Controller) RunDaemonConfig(ctx context.Context, registryStatePath string) error {
+	// The settings are zero until they're loaded from the store which notifies the subscribers if they aren't zero.
+	// Only apply the loaded settings to not remove the proxy and mirrors from the config on start.
+	changes := c.settings.Subscribe()
+	for {
+		select {
+		case <-changes:
+			settings := c.settings.Get()
+			changed, err := UpdateDaemonProxyConfig(DaemonConfigPath, settings)
+			if err != nil {
+				slog.Error("Failed to update proxy in Docker daemon config.", "path", DaemonConfigPath, "err", err)
+			} else if changed {
+				slog.Warn("Updated proxy in Docker daemon config. Restart Docker to apply the change, "+
+					"e.g. 'systemctl restart docker'.", "path", DaemonConfigPath)
+			}
+
+			changed, err = UpdateDaemonRegistryConfig(DaemonConfigPath, registryStatePath, settings)
+			if err != nil {
+				slog.Error("Failed to update registry mirrors in Docker daemon config.",
+					"path", DaemonConfigPath, "err", err)
+				continue
+			}
+			if changed {
+				// Docker reloads the registry mirrors and insecure registries on SIGHUP without restarting.
+				if out, err := exec.CommandContext(ctx, "systemctl", "reload", "docker").CombinedOutput(); err != nil {
+					slog.Warn("Updated registry mirrors in Docker daemon config but failed to reload Docker. "+
+						"Reload or restart Docker to apply the change, e.g. 'systemctl reload docker'.",
+						"path", DaemonConfigPath, "err", err, "output", strings.TrimSpace(string(out)))
+				} else {
+					slog.Info("Updated registry mirrors in Docker daemon config and reloaded Docker.",
+						"mirrors", settings.DaemonRegistryMirrors())
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// UpdateDaemonProxyConfig sets the outbound proxy from the cluster settings in the Docker daemon configuration file
+// at the given path or removes it if no proxy is configured. Proxies configured manually, i.e. without DefaultNoProxy
+// at the start of no-proxy, aren't removed. Other options in the file are preserved. It returns true if the file has been
+// changed, in which case the Docker daemon must be restarted to apply the change.
+func UpdateDaemonProxyConfig(path string, settings api.ClusterSettings) (bool, error) {
+	config, err := readDaemonConfig(path)
+	if err != nil {
+		return false, err
+	}
+
+	var want any
+	if settings.Proxy() {
+		proxiesJSON, err := json.Marshal(daemonProxies{
+			HTTPProxy:  settings.HTTPProxy,
+			HTTPSProxy: settings.HTTPSProxy,
+			NoProxy:    settings.NoProxyHosts(),
+		})
+		if err != nil {
+			return false, fmt.Errorf("marshal proxies: %w", err)
+		}
+		// Unmarshal back to compare with the generic value parsed from the file.
+		if err = json.Unmarshal(proxiesJSON, &want); err != nil {
+			return false, fmt.Errorf("unmarshal proxies: %w", err)
+		}
+	}
+
+	current, ok := config["proxies"]
+	if reflect.DeepEqual(current, want) || (!ok && want == nil) {
+		return false, nil
+	}
+	if want == nil && !managedProxies(current) {
+		return false, nil
+	}
+	if want == nil {
+		delete(config, "proxies")
+	} else {
+		config["proxies"] = want
+	}
+
+	if err = writeDaemonConfig(path, config); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// daemonRegistries are the registry options of the Docker daemon configuration set from the cluster settings.
+type daemonRegistries struct {
+	Mirrors  []string `json:"registry-mirrors,omitempty"`
+	Insecure []string `json:"insecure-registries,omitempty"`
+}
+
+// UpdateDaemonRegistryConfig sets the registry mirrors from the cluster settings in the Docker daemon configuration
+// file at the given path and allows the plain HTTP mirrors as insecure registries. The values previously set from
+// the settings are read from the state file at statePath and replaced, while the values configured manually are
+// preserved after the managed ones. It returns true if the config file has been changed, in which case the Docker
+// daemon must be reloaded to apply the change.
+func UpdateDaemonRegistryConfig(path, statePath string, settings api.ClusterSettings) (bool, error) {
+	config, err := readDaemonConfig(path)
+	if err != nil {
+		return false, err
+	}
+
+	var previous daemonRegistries
+	data, err := os.ReadFile(statePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("read registry mirrors state: %w", err)
+	}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &previous); err != nil {
+			return false, fmt.Errorf("parse registry mirrors state '%s': %w", statePath, err)
+		}
+	}
+	want := daemonRegistries{
+		Mirrors:  settings.DaemonRegistryMirrors(),
+		Insecure: settings.InsecureRegistries(),
+	}
+
+	changed := false
+	for key, values := range map[string][2][]string{
+		"registry-mirrors":    {previous.Mirrors, want.Mirrors},
+		"insecure-registries": {previous.Insecure, want.Insecure},
+	} {
+		current := stringList(config[key])
+		updated := slices.Clone(values[1])
+		for _, v := range current {
+			if !slices.Contains(values[0], v) && !slices.Contains(updated, v) {
+				updated = append(updated, v)
+			}
+		}
+		if slices.Equal(current, updated) {
+			continue
+		}
+		if len(updated) == 0 {
+			delete(config, key)
+		} else {
+			config[key] = updated
+		}
+		changed = true
+	}
+
+	if changed {
+		if err = writeDaemonConfig(path, config); err != nil {
+			return false, err
+		}
+	}
+	if !reflect.DeepEqual(previous, want) {
+		if data, err = json.Marshal(want); err != nil {
+			return changed, fmt.Errorf("marshal registry mirrors state: %w", err)
+		}
+		if err = os.WriteFile(statePath, data, 0o644); err != nil {
+			return changed, fmt.Errorf("write registry mirrors state: %w", err)
+		}
+	}
+	return changed, nil
+}
+
+// stringList returns the string elements of a list value parsed from the Docker daemon config.
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	var values []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// readDaemonConfig reads the Docker daemon configuration file at the given path. A missing or empty file is
+// returned as an empty config.
+func readDaemonConfig(path string) (map[string]any, error) {
+	config := make(map[string]any)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read Docker daemon config: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err = json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("parse Docker daemon config '%s': %w", path, err)
+		}
+	}
+	return config, nil
+}
+
+// writeDaemonConfig atomically replaces the Docker daemon configuration file at the given path with the config.
+func writeDaemonConfig(path string, config map[string]any) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal Docker daemon config: %w", err)
+	}
+	data = append(data, '\n')
+
+	// Write to a temporary file and rename it to atomically replace the config.
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create Docker config directory: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("write Docker daemon config: %w", err)
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace Docker daemon config: %w", err)
+	}
+	return nil
+}
+
+// managedProxies returns true if the proxies from the Docker daemon config have been set from the cluster settings,
+// i.e. no-proxy starts with DefaultNoProxy. The cluster network that follows them isn't matched as it may have been
+// changed by a network migration since the proxies were set.
+func managedProxies(proxies any) bool {
+	m, ok := proxies.(map[string]any)
+	if !ok {
+		return false
+	}
+	noProxy, _ := m["no-proxy"].(string)
+	hosts := strings.Split(noProxy, ",")
+	return len(hosts) >= len(api.DefaultNoProxy) && slices.Equal(hosts[:len(api.DefaultNoProxy)], api.DefaultNoProxy)
+}
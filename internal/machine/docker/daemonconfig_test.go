@@ -0,0 +1,107 @@
+package docker
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDaemonProxyConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "daemon.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"log-driver": "local"}`), 0o644))
+
+	// No proxy configured, the config is not changed.
+	changed, err := UpdateDaemonProxyConfig(path, api.ClusterSettings{})
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	settings := api.ClusterSettings{
+		HTTPProxy: "http://proxy:3128",
+		NoProxy:   "example.com",
+		Network:   netip.MustParsePrefix("10.210.0.0/16"),
+	}
+	changed, err = UpdateDaemonProxyConfig(path, settings)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"log-driver": "local",
+		"proxies": {
+			"http-proxy": "http://proxy:3128",
+			"no-proxy": "localhost,127.0.0.1,.internal,10.210.0.0/16,example.com"
+		}
+	}`, string(data))
+
+	changed, err = UpdateDaemonProxyConfig(path, settings)
+	require.NoError(t, err)
+	assert.False(t, changed, "unchanged proxy must not rewrite the config")
+
+	// The proxy set for the previous cluster network must be removed after a network migration.
+	changed, err = UpdateDaemonProxyConfig(path, api.ClusterSettings{Network: netip.MustParsePrefix("172.16.0.0/16")})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"log-driver": "local"}`, string(data))
+}
+
+func TestUpdateDaemonProxyConfig_KeepsManualProxies(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "daemon.json")
+	config := `{"proxies": {"http-proxy": "http://manual:3128"}}`
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o644))
+
+	changed, err := UpdateDaemonProxyConfig(path, api.ClusterSettings{})
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, config, string(data))
+}
+
+func TestUpdateDaemonRegistryConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "daemon.json")
+	statePath := filepath.Join(dir, "registries.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"registry-mirrors": ["https://manual.example.com"]}`), 0o644))
+
+	settings := api.ClusterSettings{
+		RegistryMirrors: []string{"https://mirror.gcr.io"},
+		RegistryCache:   "http://10.210.0.1:5000",
+	}
+	changed, err := UpdateDaemonRegistryConfig(path, statePath, settings)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"registry-mirrors": ["http://10.210.0.1:5000", "https://mirror.gcr.io", "https://manual.example.com"],
+		"insecure-registries": ["10.210.0.1:5000"]
+	}`, string(data))
+
+	changed, err = UpdateDaemonRegistryConfig(path, statePath, settings)
+	require.NoError(t, err)
+	assert.False(t, changed, "unchanged mirrors must not rewrite the config")
+
+	// Unsetting the mirrors removes only the ones set from the settings.
+	changed, err = UpdateDaemonRegistryConfig(path, statePath, api.ClusterSettings{})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"registry-mirrors": ["https://manual.example.com"]}`, string(data))
+}
@@ -0,0 +1,192 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// DetectDrift compares the actual configuration of the service container in Docker with the service spec it was
+// created from and returns the differences. Only the configuration that can be changed without recreating
+// the container, e.g. with 'docker update', or that uncloud derives from the spec when creating the container is
+// compared: image, environment variables, mounts, host ports, and resources.
+func DetectDrift(ctr api.ServiceContainer) []api.ContainerDrift {
+	var drift []api.ContainerDrift
+	spec := ctr.ServiceSpec
+
+	if ctr.Config != nil && ctr.Config.Image != spec.Container.Image {
+		drift = append(drift, api.ContainerDrift{
+			Field:    "image",
+			Expected: spec.Container.Image,
+			Actual:   ctr.Config.Image,
+		})
+	}
+
+	if ctr.Config != nil {
+		if expected, actual := envHashes(spec.Container.Env, ctr.Config.Env); expected != actual {
+			drift = append(drift, api.ContainerDrift{Field: "env", Expected: expected, Actual: actual})
+		}
+	}
+
+	if expected, actual := mountsDiff(ctr); expected != actual {
+		drift = append(drift, api.ContainerDrift{Field: "mounts", Expected: expected, Actual: actual})
+	}
+
+	if ctr.HostConfig != nil {
+		// Docker discards the port bindings of containers in the host network.
+		if spec.NetworkMode != api.NetworkModeHost {
+			if expected, actual := hostPorts(spec.Ports), actualHostPorts(ctr); expected != actual {
+				drift = append(drift, api.ContainerDrift{Field: "ports", Expected: expected, Actual: actual})
+			}
+		}
+
+		res := ctr.HostConfig.Resources
+		expected := formatResources(spec.Container.Resources.CPU, spec.Container.Resources.Memory,
+			spec.Container.Resources.MemoryReservation)
+		actual := formatResources(res.NanoCPUs, res.Memory, res.MemoryReservation)
+		if expected != actual {
+			drift = append(drift, api.ContainerDrift{Field: "resources", Expected: expected, Actual: actual})
+		}
+	}
+
+	return drift
+}
+
+// envHashes returns the hashes of the environment variables defined in the spec and their actual values
+// in the container. The container environment also includes the variables from the image that are ignored.
+func envHashes(expected api.EnvVars, actualEnv []string) (string, string) {
+	actual := make(map[string]string, len(actualEnv))
+	for _, kv := range actualEnv {
+		k, v, _ := strings.Cut(kv, "=")
+		actual[k] = v
+	}
+
+	keys := make([]string, 0, len(expected))
+	for k := range expected {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	expectedHash, actualHash := sha256.New(), sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(expectedHash, "%s=%s\n", k, expected[k])
+		if v, ok := actual[k]; ok {
+			fmt.Fprintf(actualHash, "%s=%s\n", k, v)
+		} else {
+			// Distinguish an unset variable from one set to an empty value.
+			fmt.Fprintf(actualHash, "%s\n", k)
+		}
+	}
+	return shortHash(expectedHash.Sum(nil)), shortHash(actualHash.Sum(nil))
+}
+
+func shortHash(sum []byte) string {
+	return "sha256:" + hex.EncodeToString(sum)[:12]
+}
+
+// mountsDiff returns the mount points defined in the spec and the matching actual mount points of the container.
+// The container may have other mounts, e.g. anonymous volumes declared in the image, that are ignored.
+func mountsDiff(ctr api.ServiceContainer) (string, string) {
+	spec := ctr.ServiceSpec
+	expected := make(map[string]bool)
+	if mounts, err := ToDockerMounts(spec.Volumes, spec.Container.VolumeMounts); err == nil {
+		for _, m := range mounts {
+			expected[m.Target] = m.ReadOnly
+		}
+	}
+	for _, bind := range spec.Container.Volumes {
+		parts := strings.Split(bind, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		expected[parts[1]] = len(parts) > 2 && slices.Contains(strings.Split(parts[2], ","), "ro")
+	}
+
+	actual := make(map[string]bool)
+	for _, m := range ctr.Mounts {
+		if _, ok := expected[m.Destination]; ok {
+			actual[m.Destination] = !m.RW
+		}
+	}
+	return formatMounts(expected), formatMounts(actual)
+}
+
+func formatMounts(mounts map[string]bool) string {
+	if len(mounts) == 0 {
+		return "none"
+	}
+	var formatted []string
+	for target, readOnly := range mounts {
+		if readOnly {
+			target += ":ro"
+		}
+		formatted = append(formatted, target)
+	}
+	slices.Sort(formatted)
+	return strings.Join(formatted, ", ")
+}
+
+// hostPorts returns the host port bindings for the ports in the host mode in the same format as actualHostPorts.
+func hostPorts(ports []api.PortSpec) string {
+	var bindings []string
+	for _, p := range ports {
+		if p.Mode != api.PortModeHost {
+			continue
+		}
+		hostIP := ""
+		if p.HostIP.IsValid() {
+			hostIP = p.HostIP.String()
+		}
+		for i := 0; i < p.Size(); i++ {
+			bindings = append(bindings, formatPortBinding(hostIP, fmt.Sprintf("%d", int(p.PublishedPort)+i),
+				fmt.Sprintf("%d/%s", int(p.ContainerPort)+i, p.Protocol)))
+		}
+	}
+	return formatPortBindings(bindings)
+}
+
+func actualHostPorts(ctr api.ServiceContainer) string {
+	var bindings []string
+	for port, pbs := range ctr.HostConfig.PortBindings {
+		for _, b := range pbs {
+			bindings = append(bindings, formatPortBinding(b.HostIP, b.HostPort, string(port)))
+		}
+	}
+	return formatPortBindings(bindings)
+}
+
+func formatPortBinding(hostIP, hostPort, containerPort string) string {
+	if hostIP != "" {
+		return fmt.Sprintf("%s:%s->%s", hostIP, hostPort, containerPort)
+	}
+	return fmt.Sprintf("%s->%s", hostPort, containerPort)
+}
+
+func formatPortBindings(bindings []string) string {
+	if len(bindings) == 0 {
+		return "none"
+	}
+	slices.Sort(bindings)
+	return strings.Join(bindings, ", ")
+}
+
+func formatResources(cpu, memory, memoryReservation int64) string {
+	cpus, mem, memReservation := "unlimited", "unlimited", "none"
+	if cpu > 0 {
+		cpus = fmt.Sprintf("%g", float64(cpu)/1e9)
+	}
+	if memory > 0 {
+		mem = units.BytesSize(float64(memory))
+	}
+	if memoryReservation > 0 {
+		memReservation = units.BytesSize(float64(memoryReservation))
+	}
+	return fmt.Sprintf("cpus=%s memory=%s memory-reservation=%s", cpus, mem, memReservation)
+}
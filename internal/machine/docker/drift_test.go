@@ -0,0 +1,78 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func driftTestContainer(spec api.ServiceSpec) api.ServiceContainer {
+	return api.ServiceContainer{
+		Container: api.Container{ContainerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				HostConfig: &container.HostConfig{
+					PortBindings: nat.PortMap{"80/tcp": {{HostPort: "8080"}}},
+				},
+			},
+			Config: &container.Config{
+				Image: spec.Container.Image,
+				Env:   []string{"PATH=/usr/bin", "TOKEN=secret"},
+			},
+			Mounts: []types.MountPoint{{Type: mount.TypeBind, Destination: "/data", RW: true}},
+		}},
+		ServiceSpec: spec,
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	t.Parallel()
+
+	port, err := api.ParsePortSpec("8080:80/tcp@host")
+	require.NoError(t, err)
+	spec := api.ServiceSpec{
+		Name: "web",
+		Container: api.ContainerSpec{
+			Image:   "nginx:1",
+			Env:     api.EnvVars{"TOKEN": "secret"},
+			Volumes: []string{"/srv/data:/data"},
+		},
+		Ports: []api.PortSpec{port},
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, DetectDrift(driftTestContainer(spec)))
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		t.Parallel()
+
+		ctr := driftTestContainer(spec)
+		ctr.Config.Image = "nginx:2"
+		ctr.Config.Env = []string{"TOKEN=changed"}
+		ctr.Mounts = nil
+		ctr.HostConfig.PortBindings = nil
+		ctr.HostConfig.Memory = 512 * 1024 * 1024
+
+		drift := DetectDrift(ctr)
+		fields := make([]string, len(drift))
+		for i, d := range drift {
+			fields[i] = d.Field
+			assert.NotContains(t, d.Actual, "changed", "env values must not be exposed")
+		}
+		assert.Equal(t, []string{"image", "env", "mounts", "ports", "resources"}, fields)
+		assert.Equal(t, api.ContainerDrift{Field: "mounts", Expected: "/data", Actual: "none"}, drift[2])
+		assert.Equal(t, api.ContainerDrift{Field: "ports", Expected: "8080->80/tcp", Actual: "none"}, drift[3])
+		assert.Equal(t, api.ContainerDrift{
+			Field:    "resources",
+			Expected: "cpus=unlimited memory=unlimited memory-reservation=none",
+			Actual:   "cpus=unlimited memory=512MiB memory-reservation=none",
+		}, drift[4])
+	})
+}
@@ -0,0 +1,130 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/audit"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recordingChunkSize is the size of the chunks the exec session recordings are streamed in.
+const recordingChunkSize = 64 * 1024
+
+// recordExec starts recording the exec session if it's interactive, i.e. has a TTY, and exec recording is enabled
+// in the cluster settings. It returns nil if the session isn't recorded. Failing to start the recording doesn't
+// prevent the exec but it's logged as an error.
+func (s *Server) recordExec(
+	ctx context.Context, containerID string, opts container.ExecOptions,
+) *audit.SessionRecording {
+	if s.execRecordings == nil || s.settings == nil || !s.settings().AuditRecordExec || !opts.Tty {
+		return nil
+	}
+
+	id, err := secret.NewID()
+	if err != nil {
+		slog.Error("Failed to generate exec session ID, the session isn't recorded.", "err", err)
+		return nil
+	}
+	session := api.ExecSession{
+		ID:          id,
+		ContainerID: containerID,
+		Command:     opts.Cmd,
+		User:        opts.User,
+		StartedAt:   time.Now().UTC(),
+	}
+	if s.machine != nil {
+		session.MachineID, _ = s.machine()
+	}
+	if ctr, err := s.client.ContainerInspect(ctx, containerID); err == nil {
+		session.ContainerID = ctr.ID
+		if ctr.Config != nil {
+			session.ServiceName = ctr.Config.Labels[api.LabelServiceName]
+		}
+	}
+
+	var header audit.Header
+	if opts.ConsoleSize != nil {
+		header.Height, header.Width = opts.ConsoleSize[0], opts.ConsoleSize[1]
+	}
+	rec, err := s.execRecordings.Record(session, header)
+	if err != nil {
+		slog.Error("Failed to start recording exec session, the session isn't recorded.",
+			"container", containerID, "err", err)
+		return nil
+	}
+	slog.Info("Recording exec session.", "id", id, "container", containerID, "cmd", opts.Cmd)
+	return rec
+}
+
+// ListExecSessions returns the interactive exec sessions recorded on the machine.
+func (s *Server) ListExecSessions(_ context.Context, _ *emptypb.Empty) (*pb.ListExecSessionsResponse, error) {
+	if s.execRecordings == nil {
+		return &pb.ListExecSessionsResponse{}, nil
+	}
+	sessions, err := s.execRecordings.List()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list exec sessions: %v", err)
+	}
+
+	resp := &pb.ListExecSessionsResponse{Sessions: make([]*pb.ExecSession, len(sessions))}
+	for i, session := range sessions {
+		resp.Sessions[i] = &pb.ExecSession{
+			Id:          session.ID,
+			ContainerId: session.ContainerID,
+			ServiceName: session.ServiceName,
+			Command:     session.Command,
+			User:        session.User,
+			StartedAt:   timestamppb.New(session.StartedAt),
+			ExitCode:    int32(session.ExitCode),
+		}
+		if session.Ended() {
+			resp.Sessions[i].EndedAt = timestamppb.New(session.EndedAt)
+		}
+	}
+	return resp, nil
+}
+
+// GetExecSessionRecording streams the asciinema v2 recording of an exec session recorded on the machine.
+func (s *Server) GetExecSessionRecording(
+	req *pb.GetExecSessionRecordingRequest, stream grpc.ServerStreamingServer[pb.ExecSessionRecordingChunk],
+) error {
+	if s.execRecordings == nil {
+		return status.Error(codes.NotFound, audit.ErrSessionNotFound.Error())
+	}
+	file, err := s.execRecordings.Open(req.Id)
+	if err != nil {
+		if errors.Is(err, audit.ErrSessionNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer file.Close()
+
+	buf := make([]byte, recordingChunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			if sErr := stream.Send(&pb.ExecSessionRecordingChunk{Data: buf[:n]}); sErr != nil {
+				return sErr
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return status.Errorf(codes.Internal, "read recording: %v", err)
+		}
+	}
+}
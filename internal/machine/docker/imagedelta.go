@@ -0,0 +1,170 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
+	"github.com/psviderski/uncloud/internal/machine/constants"
+)
+
+// layerChainIDs returns the chain IDs of the image layers with the given diff IDs in the same order.
+func layerChainIDs(diffIDs []string) []digest.Digest {
+	ids := make([]digest.Digest, len(diffIDs))
+	for i, id := range diffIDs {
+		ids[i] = digest.Digest(id)
+	}
+	return identity.ChainIDs(ids)
+}
+
+// localLayerChainIDs returns the chain IDs of the layers of all images in the local image store.
+func (s *Server) localLayerChainIDs(ctx context.Context) (map[digest.Digest]bool, error) {
+	images, err := s.client.ImageList(ctx, image.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("list images: %w", err)
+	}
+
+	chainIDs := make(map[digest.Digest]bool)
+	for _, img := range images {
+		inspect, _, err := s.client.ImageInspectWithRaw(ctx, img.ID)
+		if err != nil {
+			// The image may have been removed after listing.
+			continue
+		}
+		for _, id := range layerChainIDs(inspect.RootFS.Layers) {
+			chainIDs[id] = true
+		}
+	}
+	return chainIDs, nil
+}
+
+// containerdSnapshotterDriverType is the storage driver type reported by Docker that uses the containerd image store.
+const containerdSnapshotterDriverType = "io.containerd.snapshotter.v1"
+
+// checkLoadResolvesImage returns an error if the image reference can't be resolved after loading the image into
+// the local image store. Unlike the containerd image store, the classic graph driver store only restores the tags
+// of a loaded image but not its repository digests, so an image referenced by a digest must be pulled instead.
+func (s *Server) checkLoadResolvesImage(ctx context.Context, image string) error {
+	ref, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return fmt.Errorf("parse image reference: %w", err)
+	}
+	if _, ok := ref.(reference.Canonical); !ok {
+		return nil
+	}
+
+	info, err := s.client.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("get Docker info: %w", err)
+	}
+	for _, kv := range info.DriverStatus {
+		if kv[0] == "driver-type" && kv[1] == containerdSnapshotterDriverType {
+			return nil
+		}
+	}
+	return fmt.Errorf("image store with storage driver '%s' doesn't preserve digests of loaded images, "+
+		"image '%s' must be pulled from the registry", info.Driver, image)
+}
+
+// omittedLayers returns the diff IDs of the image layers which data can be omitted from the exported image as
+// the receiving machine already has all of their chains. A layer that occurs in the image multiple times is omitted
+// only if the receiver has all of its chains.
+func omittedLayers(diffIDs []string, present []string) map[digest.Digest]bool {
+	presentSet := make(map[digest.Digest]bool, len(present))
+	for _, id := range present {
+		presentSet[digest.Digest(id)] = true
+	}
+
+	omitted := make(map[digest.Digest]bool)
+	missing := make(map[digest.Digest]bool)
+	for i, chainID := range layerChainIDs(diffIDs) {
+		diffID := digest.Digest(diffIDs[i])
+		if presentSet[chainID] {
+			omitted[diffID] = true
+		} else {
+			missing[diffID] = true
+		}
+	}
+	for id := range missing {
+		delete(omitted, id)
+	}
+	return omitted
+}
+
+// copyImageOmittingLayers copies the image in the 'docker save' tar format from r to w omitting the data of
+// the layers with the given diff IDs. Docker loads an image without reading the data of the layers it already has.
+// Only the OCI layout produced by Docker 25+ stores the layers by their diff IDs in blobs/sha256/, so the layers of
+// an image exported by an older Docker are never omitted.
+func copyImageOmittingLayers(w io.Writer, r io.Reader, omit map[digest.Digest]bool) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read image tar: %w", err)
+		}
+		if hex, ok := strings.CutPrefix(hdr.Name, "blobs/sha256/"); ok && hdr.Typeflag == tar.TypeReg {
+			if omit[digest.NewDigestFromEncoded(digest.SHA256, hex)] {
+				continue
+			}
+		}
+
+		if err = tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("write image tar: %w", err)
+		}
+		if _, err = io.Copy(tw, tr); err != nil {
+			return fmt.Errorf("write image tar: %w", err)
+		}
+	}
+	return tw.Close()
+}
+
+// nearestMachine returns the management IP of the machine that accepts a connection to its Machine API first,
+// which is usually the one with the lowest network latency.
+func nearestMachine(ctx context.Context, ips []netip.Addr) (netip.Addr, error) {
+	if len(ips) == 1 {
+		return ips[0], nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		ip  netip.Addr
+		err error
+	}
+	results := make(chan result, len(ips))
+	for _, ip := range ips {
+		go func() {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "tcp",
+				net.JoinHostPort(ip.String(), strconv.Itoa(constants.MachineAPIPort)))
+			if err == nil {
+				conn.Close()
+			}
+			results <- result{ip: ip, err: err}
+		}()
+	}
+
+	var errs []error
+	for range ips {
+		r := <-results
+		if r.err == nil {
+			return r.ip, nil
+		}
+		errs = append(errs, r.err)
+	}
+	return netip.Addr{}, fmt.Errorf("connect to source machines: %w", errors.Join(errs...))
+}
@@ -0,0 +1,128 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/psviderski/uncloud/internal/machine/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOmittedLayers(t *testing.T) {
+	t.Parallel()
+
+	base := digest.FromString("base").String()
+	app := digest.FromString("app").String()
+	empty := digest.FromString("empty").String()
+	diffIDs := []string{base, empty, app, empty}
+	chainIDs := layerChainIDs(diffIDs)
+
+	t.Run("present chains", func(t *testing.T) {
+		t.Parallel()
+		omitted := omittedLayers(diffIDs, []string{chainIDs[0].String(), chainIDs[1].String()})
+		assert.Equal(t, map[digest.Digest]bool{digest.Digest(base): true}, omitted,
+			"a layer must not be omitted if it's missing in another position of the image")
+	})
+
+	t.Run("same layer on a different base", func(t *testing.T) {
+		t.Parallel()
+		// The receiver has the app layer applied on another base which isn't the same chain.
+		otherChain := layerChainIDs([]string{digest.FromString("other").String(), app})
+		omitted := omittedLayers(diffIDs, []string{otherChain[1].String()})
+		assert.Empty(t, omitted)
+	})
+
+	t.Run("all present", func(t *testing.T) {
+		t.Parallel()
+		var present []string
+		for _, id := range chainIDs {
+			present = append(present, id.String())
+		}
+		assert.Len(t, omittedLayers(diffIDs, present), 3)
+	})
+}
+
+func TestCopyImageOmittingLayers(t *testing.T) {
+	t.Parallel()
+
+	base := digest.FromString("base")
+	app := digest.FromString("app")
+	files := []struct {
+		name string
+		data string
+	}{
+		{"blobs/sha256/" + base.Encoded(), "base"},
+		{"blobs/sha256/" + app.Encoded(), "app"},
+		{"index.json", "{}"},
+		{"manifest.json", "[]"},
+	}
+	var in bytes.Buffer
+	tw := tar.NewWriter(&in)
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: f.name, Mode: 0o644, Size: int64(len(f.data)), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(f.data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	var out bytes.Buffer
+	require.NoError(t, copyImageOmittingLayers(&out, &in, map[digest.Digest]bool{base: true}))
+
+	var names []string
+	tr := tar.NewReader(&out)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	assert.Equal(t, []string{"blobs/sha256/" + app.Encoded(), "index.json", "manifest.json"}, names)
+}
+
+func TestNearestMachine(t *testing.T) {
+	t.Parallel()
+
+	reachable := netip.MustParseAddr("127.0.0.4")
+	unreachable := netip.MustParseAddr("127.0.0.5")
+
+	t.Run("single source isn't dialled", func(t *testing.T) {
+		t.Parallel()
+
+		ip, err := nearestMachine(context.Background(), []netip.Addr{unreachable})
+		require.NoError(t, err)
+		assert.Equal(t, unreachable, ip)
+	})
+
+	t.Run("reachable source", func(t *testing.T) {
+		t.Parallel()
+
+		l, err := net.Listen("tcp", net.JoinHostPort(reachable.String(), strconv.Itoa(constants.MachineAPIPort)))
+		if err != nil {
+			t.Skipf("listen on Machine API port: %v", err)
+		}
+		t.Cleanup(func() { _ = l.Close() })
+
+		ip, err := nearestMachine(context.Background(), []netip.Addr{unreachable, reachable})
+		require.NoError(t, err)
+		assert.Equal(t, reachable, ip)
+	})
+
+	t.Run("no reachable sources", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := nearestMachine(context.Background(), []netip.Addr{unreachable, netip.MustParseAddr("127.0.0.6")})
+		assert.ErrorContains(t, err, "connect to source machines")
+	})
+}
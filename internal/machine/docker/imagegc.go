@@ -0,0 +1,52 @@
+package docker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/go-units"
+)
+
+// ImageGCInterval is the interval at which the unused images are removed according to the image GC age
+// in the cluster settings.
+const ImageGCInterval = time.Hour
+
+// RunImageGC periodically removes the images that aren't used by any container and were created more than the image
+// GC age from the cluster settings ago. The image GC is disabled if the age is not set. Changes to the age are picked
+// up on the next run.
+func (c *Controller) RunImageGC(ctx context.Context) error {
+	ticker := time.NewTicker(ImageGCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			age := c.settings.Get().ImageGCAge
+			if age <= 0 {
+				continue
+			}
+			c.pruneImages(ctx, age)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// pruneImages removes the unused images created more than age ago.
+func (c *Controller) pruneImages(ctx context.Context, age time.Duration) {
+	report, err := c.client.ImagesPrune(ctx, filters.NewArgs(
+		// Remove all unused images, not only the dangling ones.
+		filters.Arg("dangling", "false"),
+		filters.Arg("until", age.String()),
+	))
+	if err != nil {
+		slog.Error("Failed to remove unused images.", "err", err)
+		return
+	}
+	if len(report.ImagesDeleted) > 0 {
+		slog.Info("Removed unused images.", "age", age, "deleted", len(report.ImagesDeleted),
+			"reclaimed", units.HumanSize(float64(report.SpaceReclaimed)))
+	}
+}
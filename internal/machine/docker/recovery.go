@@ -0,0 +1,196 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	dnetwork "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ContainerRecovery is an action taken to recover a service container after a machine reboot.
+type ContainerRecovery struct {
+	Container string
+	Action    string
+	Err       error
+}
+
+// RecoverContainers reconciles the service containers with the Docker network NetworkName and their restart
+// policies after a machine reboot. Containers attached to a stale network (e.g. if the network has been recreated)
+// are reconnected to the current one preserving their IP addresses. Containers with the 'always' or
+// 'unless-stopped' restart policy that have stopped since the machine booted, but haven't been restarted
+// by Docker (e.g. because their network was missing), are started. Containers stopped before the reboot are left
+// as is. It must be called after the network is ensured with EnsureUncloudNetwork.
+func (c *Controller) RecoverContainers(ctx context.Context, bootTime time.Time) ([]ContainerRecovery, error) {
+	nw, err := c.client.NetworkInspect(ctx, NetworkName, dnetwork.InspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("inspect Docker network '%s': %w", NetworkName, err)
+	}
+	var subnet netip.Prefix
+	if len(nw.IPAM.Config) > 0 {
+		subnet, _ = netip.ParsePrefix(nw.IPAM.Config[0].Subnet)
+	}
+
+	opts := container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", api.LabelManaged)),
+	}
+	summaries, err := c.client.ContainerList(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+
+	var actions []ContainerRecovery
+	for _, s := range summaries {
+		ctr, err := c.client.ContainerInspect(ctx, s.ID)
+		if err != nil {
+			actions = append(actions, ContainerRecovery{
+				Container: s.ID,
+				Action:    "inspect",
+				Err:       err,
+			})
+			continue
+		}
+		name := strings.TrimPrefix(ctr.Name, "/")
+
+		if ctr.HostConfig.NetworkMode.NetworkName() == NetworkName {
+			endpoint := ctr.NetworkSettings.Networks[NetworkName]
+			if endpoint == nil || endpoint.NetworkID != nw.ID {
+				err = c.reconnectContainer(ctx, ctr.ID, endpoint, subnet)
+				actions = append(actions, ContainerRecovery{
+					Container: name,
+					Action:    fmt.Sprintf("reconnect to network '%s'", NetworkName),
+					Err:       err,
+				})
+				if err != nil {
+					continue
+				}
+			}
+		}
+
+		if ctr.State.Running || !restartsAfterReboot(ctr.HostConfig.RestartPolicy) {
+			continue
+		}
+		finishedAt, err := time.Parse(time.RFC3339Nano, ctr.State.FinishedAt)
+		// A container that has never run or was stopped before the reboot shouldn't be started.
+		if err != nil || finishedAt.IsZero() || finishedAt.Before(bootTime) {
+			continue
+		}
+		err = c.client.ContainerStart(ctx, ctr.ID, container.StartOptions{})
+		actions = append(actions, ContainerRecovery{
+			Container: name,
+			Action:    "start",
+			Err:       err,
+		})
+	}
+
+	for _, a := range actions {
+		if a.Err != nil {
+			slog.Error("Failed to recover container after reboot.", "container", a.Container, "action", a.Action,
+				"err", a.Err)
+		} else {
+			slog.Info("Recovered container after reboot.", "container", a.Container, "action", a.Action)
+		}
+	}
+	return actions, nil
+}
+
+// reconnectContainer disconnects the container from a stale network endpoint and connects it to the current
+// Docker network NetworkName preserving its IP address if it's within the network subnet.
+func (c *Controller) reconnectContainer(
+	ctx context.Context, id string, endpoint *dnetwork.EndpointSettings, subnet netip.Prefix,
+) error {
+	settings := &dnetwork.EndpointSettings{}
+	if endpoint != nil {
+		// Force the disconnection as the stale network may no longer exist.
+		if err := c.client.NetworkDisconnect(ctx, NetworkName, id, true); err != nil {
+			slog.Debug("Failed to disconnect container from stale network.", "container", id, "err", err)
+		}
+		settings.Aliases = endpoint.Aliases
+		if ip, err := netip.ParseAddr(endpoint.IPAddress); err == nil && subnet.Contains(ip) {
+			settings.IPAMConfig = &dnetwork.EndpointIPAMConfig{IPv4Address: ip.String()}
+		}
+	}
+
+	if err := c.client.NetworkConnect(ctx, NetworkName, id, settings); err != nil {
+		return fmt.Errorf("connect to network '%s': %w", NetworkName, err)
+	}
+	return nil
+}
+
+// StopServiceContainers gracefully stops all running service containers concurrently before the machine shuts down
+// and returns the IDs of the stopped containers. Each container is given its configured stop timeout to exit.
+// Stopped containers are synced to the cluster store as not running, so the ingress on other machines stops routing
+// traffic to them.
+func (c *Controller) StopServiceContainers(ctx context.Context) ([]string, error) {
+	containers, err := c.service.ListServiceContainers(ctx, "", container.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list service containers: %w", err)
+	}
+
+	var (
+		mu      sync.Mutex
+		stopped []string
+		errs    []error
+		wg      sync.WaitGroup
+	)
+	for _, ctr := range containers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Nil timeout means the container's stop timeout or the Docker default is used.
+			err := c.client.ContainerStop(ctx, ctr.ID, container.StopOptions{})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("stop container '%s': %w", ctr.Name, err))
+				return
+			}
+			stopped = append(stopped, ctr.ID)
+		}()
+	}
+	wg.Wait()
+
+	return stopped, errors.Join(errs...)
+}
+
+// StartContainers starts the containers with the given IDs that were stopped by StopServiceContainers.
+// Removed containers are ignored.
+func (c *Controller) StartContainers(ctx context.Context, ids []string) []ContainerRecovery {
+	var actions []ContainerRecovery
+	for _, id := range ids {
+		ctr, err := c.client.ContainerInspect(ctx, id)
+		if err != nil {
+			if !client.IsErrNotFound(err) {
+				actions = append(actions, ContainerRecovery{Container: id, Action: "start", Err: err})
+			}
+			continue
+		}
+		if ctr.State.Running {
+			continue
+		}
+
+		err = c.client.ContainerStart(ctx, id, container.StartOptions{})
+		actions = append(actions, ContainerRecovery{
+			Container: strings.TrimPrefix(ctr.Name, "/"),
+			Action:    "start",
+			Err:       err,
+		})
+	}
+	return actions
+}
+
+// restartsAfterReboot returns true if Docker should start a container with the restart policy on daemon start.
+func restartsAfterReboot(policy container.RestartPolicy) bool {
+	return policy.Name == container.RestartPolicyAlways || policy.Name == container.RestartPolicyUnlessStopped
+}
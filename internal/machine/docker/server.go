@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"net"
 	"net/netip"
 	"os"
 	"path/filepath"
@@ -29,6 +31,9 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -37,11 +42,17 @@ import (
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/api/rbac"
+	"github.com/psviderski/uncloud/internal/machine/audit"
+	"github.com/psviderski/uncloud/internal/machine/constants"
 	"github.com/psviderski/uncloud/internal/machine/dns"
+	"github.com/psviderski/uncloud/internal/machine/imagesig"
+	"github.com/psviderski/uncloud/internal/machine/volumebackend"
 	"github.com/psviderski/uncloud/internal/secret"
 	"github.com/psviderski/uncloud/pkg/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -61,6 +72,19 @@ type Server struct {
 	networkReady func() bool
 	// waitForNetworkReady is a function that waits for the Docker network to be ready for containers.
 	waitForNetworkReady func(ctx context.Context) error
+	// volumes manages the data of local volumes using the native filesystem backends if available. Volume snapshots
+	// and transfers are unsupported if nil.
+	volumes *volumebackend.Manager
+	// settings is a function that returns the current cluster-wide settings. Zero settings are used if nil.
+	settings func() api.ClusterSettings
+	// machine is a function that returns the ID and name of the machine injected into the service containers.
+	// They may be empty if the machine is not initialised yet.
+	machine func() (id, name string)
+	// execRecordings stores the recordings of the interactive exec sessions. Exec sessions aren't recorded if nil.
+	execRecordings *audit.Store
+	// admitContainer checks if a service container with the spec can be created, e.g. within the tenant quota.
+	// It returns a gRPC status error if the container is rejected. All containers are admitted if nil.
+	admitContainer func(ctx context.Context, serviceID string, spec api.ServiceSpec) error
 }
 
 // ServerOption configures the Docker server.
@@ -80,6 +104,43 @@ func WithWaitForNetworkReady(waitForNetworkReady func(ctx context.Context) error
 	}
 }
 
+// WithVolumeManager sets the manager of local volume data for volume snapshots and transfers.
+func WithVolumeManager(volumes *volumebackend.Manager) ServerOption {
+	return func(s *Server) {
+		s.volumes = volumes
+	}
+}
+
+// WithSettings sets the function that returns the current cluster-wide settings.
+func WithSettings(settings func() api.ClusterSettings) ServerOption {
+	return func(s *Server) {
+		s.settings = settings
+	}
+}
+
+// WithMachine sets the function that returns the ID and name of the machine.
+func WithMachine(machine func() (id, name string)) ServerOption {
+	return func(s *Server) {
+		s.machine = machine
+	}
+}
+
+// WithExecRecordings sets the store for the recordings of the interactive exec sessions.
+func WithExecRecordings(store *audit.Store) ServerOption {
+	return func(s *Server) {
+		s.execRecordings = store
+	}
+}
+
+// WithContainerAdmission sets the function that checks if a service container can be created.
+func WithContainerAdmission(
+	admit func(ctx context.Context, serviceID string, spec api.ServiceSpec) error,
+) ServerOption {
+	return func(s *Server) {
+		s.admitContainer = admit
+	}
+}
+
 // NewServer creates a new Docker gRPC server with the provided Docker service.
 func NewServer(service *Service, db *sqlx.DB, internalDNSIP func() netip.Addr, opts ...ServerOption) *Server {
 	s := &Server{
@@ -116,6 +177,9 @@ func (s *Server) CreateContainer(ctx context.Context, req *pb.CreateContainerReq
 	if err := json.Unmarshal(req.Platform, &platform); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "unmarshal platform: %v", err)
 	}
+	if err := s.verifyContainerImage(ctx, config.Image); err != nil {
+		return nil, err
+	}
 
 	resp, err := s.client.ContainerCreate(ctx, &config, &hostConfig, &networkConfig, &platform, req.Name)
 	if err != nil {
@@ -142,6 +206,9 @@ func (s *Server) InspectContainer(ctx context.Context, req *pb.InspectContainerR
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if rbac.ReadOnly(ctx) {
+		resp = api.Container{ContainerJSON: resp}.Redacted().ContainerJSON
+	}
 
 	respBytes, err := json.Marshal(resp)
 	if err != nil {
@@ -270,6 +337,189 @@ func (s *Server) RemoveContainer(ctx context.Context, req *pb.RemoveContainerReq
 	return &emptypb.Empty{}, nil
 }
 
+// ContainerLogs streams the stdout and stderr logs of a container with the given ID.
+func (s *Server) ContainerLogs(
+	req *pb.ContainerLogsRequest, stream grpc.ServerStreamingServer[pb.ContainerLogsResponse],
+) error {
+	ctx := stream.Context()
+
+	var opts container.LogsOptions
+	if len(req.Options) > 0 {
+		if err := json.Unmarshal(req.Options, &opts); err != nil {
+			return status.Errorf(codes.InvalidArgument, "unmarshal options: %v", err)
+		}
+	}
+
+	ctr, err := s.client.ContainerInspect(ctx, req.Id)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	logs, err := s.client.ContainerLogs(ctx, req.Id, opts)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer logs.Close()
+
+	stdout := streamWriter(func(p []byte) error {
+		return stream.Send(&pb.ContainerLogsResponse{Stream: pb.ContainerLogsResponse_STDOUT, Data: p})
+	})
+	stderr := streamWriter(func(p []byte) error {
+		return stream.Send(&pb.ContainerLogsResponse{Stream: pb.ContainerLogsResponse_STDERR, Data: p})
+	})
+	// The logs of a container with a TTY are not multiplexed into separate streams.
+	if ctr.Config != nil && ctr.Config.Tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+	if err != nil && ctx.Err() == nil {
+		return status.Errorf(codes.Internal, "stream container logs: %v", err)
+	}
+
+	return nil
+}
+
+// ExecContainer runs a command in a running container and streams its input and output. The first request message
+// must contain the exec config. The last response message contains the exit code of the command.
+func (s *Server) ExecContainer(stream grpc.BidiStreamingServer[pb.ExecContainerRequest, pb.ExecContainerResponse]) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	config := req.GetConfig()
+	if config == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain exec config")
+	}
+	var opts container.ExecOptions
+	if len(config.Options) > 0 {
+		if err = json.Unmarshal(config.Options, &opts); err != nil {
+			return status.Errorf(codes.InvalidArgument, "unmarshal options: %v", err)
+		}
+	}
+	if len(opts.Cmd) == 0 {
+		return status.Error(codes.InvalidArgument, "command not specified")
+	}
+	opts.AttachStdout = true
+	opts.AttachStderr = true
+	opts.Detach = false
+
+	execResp, err := s.client.ContainerExecCreate(ctx, config.ContainerId, opts)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		if errdefs.IsConflict(err) {
+			// The container is not running.
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return status.Errorf(codes.Internal, "create exec: %v", err)
+	}
+
+	hijacked, err := s.client.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{
+		Tty:         opts.Tty,
+		ConsoleSize: opts.ConsoleSize,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "attach to exec: %v", err)
+	}
+	defer hijacked.Close()
+
+	// Record the interactive session if enabled. The exit code is unknown if the exec fails.
+	exitCode := -1
+	rec := s.recordExec(ctx, config.ContainerId, opts)
+	if rec != nil {
+		defer func() {
+			if err := rec.Close(exitCode); err != nil {
+				slog.Error("Failed to save exec session recording.", "container", config.ContainerId, "err", err)
+			}
+		}()
+	}
+
+	// Forward stdin data and terminal resize events from the client to the exec process.
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if opts.AttachStdin {
+					_ = hijacked.CloseWrite()
+				}
+				return
+			}
+
+			switch p := req.Payload.(type) {
+			case *pb.ExecContainerRequest_Stdin:
+				if rec != nil {
+					rec.Input(p.Stdin)
+				}
+				if _, err = hijacked.Conn.Write(p.Stdin); err != nil {
+					slog.Debug("Failed to write stdin to exec.", "id", execResp.ID, "err", err)
+					return
+				}
+			case *pb.ExecContainerRequest_CloseStdin:
+				_ = hijacked.CloseWrite()
+			case *pb.ExecContainerRequest_Resize:
+				if err = s.client.ContainerExecResize(ctx, execResp.ID, container.ResizeOptions{
+					Height: uint(p.Resize.Height),
+					Width:  uint(p.Resize.Width),
+				}); err != nil {
+					slog.Debug("Failed to resize exec TTY.", "id", execResp.ID, "err", err)
+				}
+				if rec != nil {
+					rec.Resize(uint(p.Resize.Width), uint(p.Resize.Height))
+				}
+			}
+		}
+	}()
+
+	stdout := streamWriter(func(p []byte) error {
+		return stream.Send(&pb.ExecContainerResponse{Payload: &pb.ExecContainerResponse_Stdout{Stdout: p}})
+	})
+	stderr := streamWriter(func(p []byte) error {
+		return stream.Send(&pb.ExecContainerResponse{Payload: &pb.ExecContainerResponse_Stderr{Stderr: p}})
+	})
+	// The output of a command with a TTY is not multiplexed into separate streams.
+	if opts.Tty {
+		var out io.Writer = stdout
+		if rec != nil {
+			out = io.MultiWriter(streamWriter(func(p []byte) error {
+				rec.Output(p)
+				return nil
+			}), stdout)
+		}
+		_, err = io.Copy(out, hijacked.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, hijacked.Reader)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "stream exec output: %v", err)
+	}
+
+	inspect, err := s.client.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "inspect exec: %v", err)
+	}
+	exitCode = inspect.ExitCode
+	return stream.Send(&pb.ExecContainerResponse{
+		Payload: &pb.ExecContainerResponse_ExitCode{ExitCode: int32(inspect.ExitCode)},
+	})
+}
+
+// streamWriter is an io.Writer that sends the written data as messages to a gRPC stream.
+type streamWriter func(p []byte) error
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	if err := w(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 func (s *Server) PullImage(req *pb.PullImageRequest, stream grpc.ServerStreamingServer[pb.JSONMessage]) error {
 	ctx := stream.Context()
 
@@ -280,6 +530,20 @@ func (s *Server) PullImage(req *pb.PullImageRequest, stream grpc.ServerStreaming
 		}
 	}
 
+	digest, err := s.verifyImageSignature(ctx, req.Image)
+	if err != nil {
+		return err
+	}
+	pullRef := req.Image
+	if digest != "" {
+		// Pull the verified image by its digest as the tag could have been moved to another image since.
+		named, err := reference.ParseNormalizedNamed(req.Image)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "parse image reference: %v", err)
+		}
+		pullRef = named.Name() + "@" + digest
+	}
+
 	if opts.RegistryAuth == "" {
 		// Try to retrieve the authentication token for the image from the default local Docker config file.
 		dockerConfig := dockerconfig.LoadDefaultConfigFile(os.Stderr)
@@ -288,7 +552,7 @@ func (s *Server) PullImage(req *pb.PullImageRequest, stream grpc.ServerStreaming
 		}
 	}
 
-	respBody, err := s.client.ImagePull(ctx, req.Image, opts)
+	respBody, err := s.client.ImagePull(ctx, pullRef, opts)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -319,13 +583,94 @@ func (s *Server) PullImage(req *pb.PullImageRequest, stream grpc.ServerStreaming
 	for {
 		select {
 		case err = <-errCh:
-			return err
+			if err != nil || pullRef == req.Image {
+				return err
+			}
+			// Tag the image pulled by digest with the requested reference to be used by the containers.
+			if err = s.client.ImageTag(ctx, pullRef, req.Image); err != nil {
+				return status.Errorf(codes.Internal, "tag image '%s' as '%s': %v", pullRef, req.Image, err)
+			}
+			return nil
 		case <-ctx.Done():
 			return status.Error(codes.Canceled, ctx.Err().Error())
 		}
 	}
 }
 
+// verifyImageSignature checks that the image is signed by a trusted key or identity in the registry if the cluster
+// image signing policy applies to it. It returns the verified image digest or an empty string if the policy doesn't
+// apply to the image.
+func (s *Server) verifyImageSignature(ctx context.Context, image string) (string, error) {
+	if s.settings == nil {
+		return "", nil
+	}
+	policy := s.settings().ImageSigning
+	if !policy.AppliesTo(image) {
+		return "", nil
+	}
+
+	signer, digest, err := imagesig.VerifyDigest(ctx, *policy, image,
+		remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		if errors.Is(err, imagesig.ErrNotSigned) {
+			return "", status.Errorf(codes.PermissionDenied,
+				"image '%s' rejected by the cluster image signing policy: %v", image, err)
+		}
+		return "", status.Errorf(codes.FailedPrecondition, "verify signature of image '%s': %v", image, err)
+	}
+	slog.Debug("Verified image signature.", "image", image, "signer", signer, "digest", digest)
+	return digest.String(), nil
+}
+
+// verifyContainerImage checks that the local image a container is about to be created from is signed if the cluster
+// image signing policy applies to it. The signature is verified even if the image has already been pulled as it could
+// have been loaded bypassing the policy, e.g. with LoadImage, MirrorImage, or before the policy was enabled.
+func (s *Server) verifyContainerImage(ctx context.Context, image string) error {
+	digest, err := s.verifyImageSignature(ctx, image)
+	if err != nil || digest == "" {
+		return err
+	}
+	return s.verifyLocalImageDigest(ctx, image, digest)
+}
+
+// verifyLocalImageDigest checks that the local image has been pulled by the verified digest. This rejects an image
+// loaded locally with the same tag as a signed image or a tag moved to an unsigned image after verification.
+func (s *Server) verifyLocalImageDigest(ctx context.Context, image, digest string) error {
+	img, _, err := s.client.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return status.Errorf(codes.NotFound, "image '%s' not found", image)
+		}
+		return status.Errorf(codes.Internal, "inspect image '%s': %v", image, err)
+	}
+	if !repoDigestsContain(img.RepoDigests, image, digest) {
+		return status.Errorf(codes.PermissionDenied,
+			"image '%s' rejected by the cluster image signing policy: local image doesn't match the verified "+
+				"digest %s, pull the image again", image, digest)
+	}
+	return nil
+}
+
+// repoDigestsContain returns true if the repo digests of a local image contain the digest in the repository
+// of the image reference.
+func repoDigestsContain(repoDigests []string, image, digest string) bool {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return false
+	}
+	for _, rd := range repoDigests {
+		ref, err := reference.ParseNormalizedNamed(rd)
+		if err != nil {
+			continue
+		}
+		canonical, ok := ref.(reference.Canonical)
+		if ok && canonical.Name() == named.Name() && canonical.Digest().String() == digest {
+			return true
+		}
+	}
+	return false
+}
+
 // InspectImage returns the image information for the given image ID.
 func (s *Server) InspectImage(ctx context.Context, req *pb.InspectImageRequest) (*pb.InspectImageResponse, error) {
 	resp, _, err := s.client.ImageInspectWithRaw(ctx, req.Id)
@@ -379,16 +724,42 @@ func (s *Server) InspectRemoteImage(
 		}
 	}
 
+	// The platform of a single-platform image is only specified in its config rather than the manifest.
+	var platform []byte
+	if desc.MediaType.IsImage() {
+		if platform, err = remoteImagePlatform(desc); err != nil {
+			slog.Warn("Failed to get the platform of remote image.", "image", req.Id, "err", err)
+		}
+	}
+
 	return &pb.InspectRemoteImageResponse{
 		Messages: []*pb.RemoteImage{
 			{
 				Reference: reference.FamiliarString(canonicalRef),
 				Manifest:  desc.Manifest,
+				Platform:  platform,
 			},
 		},
 	}, nil
 }
 
+// remoteImagePlatform returns the JSON serialised platform from the config of the single-platform image.
+func remoteImagePlatform(desc *remote.Descriptor) ([]byte, error) {
+	img, err := desc.Image()
+	if err != nil {
+		return nil, err
+	}
+	config, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("fetch image config: %w", err)
+	}
+	return json.Marshal(ocispec.Platform{
+		Architecture: config.Architecture,
+		OS:           config.OS,
+		Variant:      config.Variant,
+	})
+}
+
 // CreateVolume creates a new volume with the given options.
 func (s *Server) CreateVolume(ctx context.Context, req *pb.CreateVolumeRequest) (*pb.CreateVolumeResponse, error) {
 	var opts volume.CreateOptions
@@ -406,6 +777,10 @@ func (s *Server) CreateVolume(ctx context.Context, req *pb.CreateVolumeRequest)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	// Volumes with driver options, e.g. NFS mounts, are not provisioned as their data is not stored in the directory.
+	if s.volumes != nil && vol.Driver == api.VolumeDriverLocal && len(opts.DriverOpts) == 0 {
+		s.volumes.Provision(ctx, volumebackend.Volume{Name: vol.Name, Mountpoint: vol.Mountpoint})
+	}
 
 	volBytes, err := json.Marshal(vol)
 	if err != nil {
@@ -459,6 +834,10 @@ func (s *Server) ListVolumes(ctx context.Context, req *pb.ListVolumesRequest) (*
 
 // RemoveVolume removes a volume with the given ID.
 func (s *Server) RemoveVolume(ctx context.Context, req *pb.RemoveVolumeRequest) (*emptypb.Empty, error) {
+	if err := s.cleanupVolume(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
 	if err := s.client.VolumeRemove(ctx, req.Id, req.Force); err != nil {
 		if client.IsErrNotFound(err) {
 			return nil, status.Error(codes.NotFound, err.Error())
@@ -469,6 +848,412 @@ func (s *Server) RemoveVolume(ctx context.Context, req *pb.RemoveVolumeRequest)
 	return &emptypb.Empty{}, nil
 }
 
+// cleanupVolume removes the snapshots and native backend resources of the local volume before it's removed.
+// The volume is left intact if it's used by any containers so that Docker can reject its removal.
+func (s *Server) cleanupVolume(ctx context.Context, name string) error {
+	if s.volumes == nil {
+		return nil
+	}
+	vol, err := s.client.VolumeInspect(ctx, name)
+	if err != nil || vol.Driver != api.VolumeDriverLocal {
+		// Let Docker handle the removal errors.
+		return nil
+	}
+
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("volume", vol.Name)),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "list containers using volume: %v", err)
+	}
+	if len(containers) > 0 {
+		return nil
+	}
+
+	if err = s.volumes.Cleanup(ctx, volumebackend.Volume{Name: vol.Name, Mountpoint: vol.Mountpoint}); err != nil {
+		return status.Errorf(codes.Internal, "clean up volume '%s': %v", vol.Name, err)
+	}
+	return nil
+}
+
+// CreateVolumeSnapshot creates a snapshot of the local volume. The btrfs and ZFS snapshots are atomic so they're
+// taken while the containers using the volume keep running. A tar archive of a volume being written to, e.g. by
+// a database, can be inconsistent so the running containers using the volume are stopped while it's archived
+// and started again afterwards.
+func (s *Server) CreateVolumeSnapshot(
+	ctx context.Context, req *pb.VolumeSnapshotRequest,
+) (*pb.CreateVolumeSnapshotResponse, error) {
+	v, err := s.localVolume(ctx, req.VolumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.volumes.Backend(ctx, v)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get backend of volume '%s': %v", req.VolumeName, err)
+	}
+	if b.Name() == api.VolumeBackendTar {
+		containers, err := s.client.ContainerList(ctx, container.ListOptions{
+			Filters: filters.NewArgs(filters.Arg("volume", req.VolumeName), filters.Arg("status", "running")),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "list containers using volume: %v", err)
+		}
+		// Start the stopped containers even if the request is canceled.
+		defer func() {
+			for _, c := range containers {
+				if err := s.client.ContainerStart(context.WithoutCancel(ctx), c.ID,
+					container.StartOptions{}); err != nil {
+					slog.Error("Failed to start container after snapshotting its volume.",
+						"id", c.ID, "volume", req.VolumeName, "err", err)
+				}
+			}
+		}()
+		for _, c := range containers {
+			if err = s.client.ContainerStop(ctx, c.ID, container.StopOptions{}); err != nil {
+				return nil, status.Errorf(codes.Internal, "stop container '%s' using volume: %v", c.ID, err)
+			}
+		}
+	}
+
+	snap, err := s.volumes.CreateSnapshot(ctx, v, req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create snapshot of volume '%s': %v", req.VolumeName, err)
+	}
+	snapBytes, err := json.Marshal(snap)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal snapshot: %v", err)
+	}
+
+	return &pb.CreateVolumeSnapshotResponse{Snapshot: snapBytes}, nil
+}
+
+// RestoreVolumeSnapshot replaces the data of the local volume with the snapshot.
+func (s *Server) RestoreVolumeSnapshot(ctx context.Context, req *pb.VolumeSnapshotRequest) (*emptypb.Empty, error) {
+	v, err := s.localVolume(ctx, req.VolumeName)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.verifyVolumeNotInUse(ctx, req.VolumeName); err != nil {
+		return nil, err
+	}
+
+	if err = s.volumes.RestoreSnapshot(ctx, v, req.Id); err != nil {
+		if errors.Is(err, volumebackend.ErrSnapshotNotFound) {
+			return nil, status.Errorf(codes.NotFound, "snapshot '%s' of volume '%s' not found", req.Id, req.VolumeName)
+		}
+		return nil, status.Errorf(codes.Internal, "restore snapshot of volume '%s': %v", req.VolumeName, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveVolumeSnapshot removes the snapshot of the local volume if it exists.
+func (s *Server) RemoveVolumeSnapshot(ctx context.Context, req *pb.VolumeSnapshotRequest) (*emptypb.Empty, error) {
+	if s.volumes == nil {
+		return nil, status.Error(codes.Unimplemented, "volume snapshots are not supported")
+	}
+	if err := s.volumes.RemoveSnapshot(ctx, req.VolumeName, req.Id); err != nil {
+		return nil, status.Errorf(codes.Internal, "remove snapshot of volume '%s': %v", req.VolumeName, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetVolumeBackend returns the backend managing the data of the local volume.
+func (s *Server) GetVolumeBackend(
+	ctx context.Context, req *pb.GetVolumeBackendRequest,
+) (*pb.GetVolumeBackendResponse, error) {
+	v, err := s.localVolume(ctx, req.VolumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.volumes.Backend(ctx, v)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "detect backend of volume '%s': %v", req.VolumeName, err)
+	}
+	return &pb.GetVolumeBackendResponse{Backend: b.Name()}, nil
+}
+
+// ExportVolume streams the data of the local volume in the format of the requested backend.
+func (s *Server) ExportVolume(req *pb.ExportVolumeRequest, stream grpc.ServerStreamingServer[pb.VolumeData]) error {
+	ctx := stream.Context()
+	v, err := s.localVolume(ctx, req.VolumeName)
+	if err != nil {
+		return err
+	}
+
+	w := streamWriter(func(p []byte) error {
+		return stream.Send(&pb.VolumeData{Data: p})
+	})
+	if err = s.volumes.Send(ctx, v, req.Backend, w); err != nil {
+		return status.Errorf(codes.Internal, "export volume '%s': %v", req.VolumeName, err)
+	}
+	return nil
+}
+
+// ImportVolume replaces the data of the local volume with a stream in the format of the backend specified
+// in the first request message.
+func (s *Server) ImportVolume(stream grpc.ClientStreamingServer[pb.ImportVolumeRequest, emptypb.Empty]) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	header := req.GetHeader()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain import header")
+	}
+	v, err := s.localVolume(ctx, header.VolumeName)
+	if err != nil {
+		return err
+	}
+	if err = s.verifyVolumeNotInUse(ctx, header.VolumeName); err != nil {
+		return err
+	}
+
+	r := &streamReader{recv: func() ([]byte, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return req.GetData(), nil
+	}}
+	if err = s.volumes.Receive(ctx, v, header.Backend, r); err != nil {
+		return status.Errorf(codes.Internal, "import volume '%s': %v", header.VolumeName, err)
+	}
+
+	return stream.SendAndClose(&emptypb.Empty{})
+}
+
+// ExportImage streams the local image in the 'docker save' tar format omitting the data of the layers the receiving
+// machine already has.
+func (s *Server) ExportImage(req *pb.ExportImageRequest, stream grpc.ServerStreamingServer[pb.ImageData]) error {
+	ctx := stream.Context()
+	img, _, err := s.client.ImageInspectWithRaw(ctx, req.Image)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return status.Errorf(codes.NotFound, "image '%s' not found", req.Image)
+		}
+		return status.Errorf(codes.Internal, "inspect image '%s': %v", req.Image, err)
+	}
+
+	r, err := s.client.ImageSave(ctx, []string{req.Image})
+	if err != nil {
+		return status.Errorf(codes.Internal, "export image '%s': %v", req.Image, err)
+	}
+	defer r.Close()
+
+	w := streamWriter(func(p []byte) error {
+		return stream.Send(&pb.ImageData{Data: p})
+	})
+	if len(req.ExcludeLayerChainIds) > 0 {
+		err = copyImageOmittingLayers(w, r, omittedLayers(img.RootFS.Layers, req.ExcludeLayerChainIds))
+	} else {
+		_, err = io.Copy(w, r)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "export image '%s': %v", req.Image, err)
+	}
+	return nil
+}
+
+// LoadImage loads an image from a stream in the 'docker save' tar format into the local image store.
+func (s *Server) LoadImage(stream grpc.ClientStreamingServer[pb.ImageData, emptypb.Empty]) error {
+	r := &streamReader{recv: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return msg.Data, nil
+	}}
+	if err := s.loadImage(stream.Context(), r); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&emptypb.Empty{})
+}
+
+// MirrorImage copies the image from the image store of the nearest machine that has it by connecting to its Machine
+// API over the cluster network directly so that the image data doesn't pass through the client. Only the layers
+// missing in the local image store are transferred. An image referenced by a digest can only be mirrored if the local
+// image store preserves the digests of loaded images.
+func (s *Server) MirrorImage(ctx context.Context, req *pb.MirrorImageRequest) (*pb.MirrorImageResponse, error) {
+	if err := s.checkLoadResolvesImage(ctx, req.Image); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	sourceIPs := req.SourceMachineIps
+	if len(sourceIPs) == 0 && req.SourceMachineIp != nil {
+		sourceIPs = []*pb.IP{req.SourceMachineIp}
+	}
+	if len(sourceIPs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "source machine IP must be specified")
+	}
+	candidates := make([]netip.Addr, len(sourceIPs))
+	for i, ip := range sourceIPs {
+		addr, err := ip.ToAddr()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid source machine IP: %v", err)
+		}
+		candidates[i] = addr
+	}
+	sourceIP, err := nearestMachine(ctx, candidates)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	conn, err := grpc.NewClient(
+		net.JoinHostPort(sourceIP.String(), strconv.Itoa(constants.MachineAPIPort)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "connect to source machine: %v", err)
+	}
+	defer conn.Close()
+
+	present, err := s.localLayerChainIDs(ctx)
+	if err != nil {
+		slog.Warn("Failed to get local image layers, transferring all layers of the mirrored image.",
+			"image", req.Image, "err", err)
+	}
+	err = s.mirrorImageFrom(ctx, conn, req.Image, present)
+	if err != nil && len(present) > 0 {
+		// The image store may not support loading images with omitted layers, e.g. the containerd image store.
+		slog.Warn("Failed to mirror image transferring only missing layers, transferring all layers.",
+			"image", req.Image, "err", err)
+		present = nil
+		err = s.mirrorImageFrom(ctx, conn, req.Image, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.MirrorImageResponse{SourceMachineIp: pb.NewIP(sourceIP)}
+	if img, _, err := s.client.ImageInspectWithRaw(ctx, req.Image); err == nil {
+		for _, id := range layerChainIDs(img.RootFS.Layers) {
+			if present[id] {
+				resp.PresentLayers++
+			} else {
+				resp.TransferredLayers++
+			}
+		}
+	}
+	return resp, nil
+}
+
+// mirrorImageFrom loads the image exported by the source machine into the local image store. The source omits
+// the data of the layers with the present chain IDs.
+func (s *Server) mirrorImageFrom(
+	ctx context.Context, conn *grpc.ClientConn, image string, present map[digest.Digest]bool,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req := &pb.ExportImageRequest{Image: image}
+	for id := range present {
+		req.ExcludeLayerChainIds = append(req.ExcludeLayerChainIds, id.String())
+	}
+	stream, err := pb.NewDockerClient(conn).ExportImage(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// Keep the export error to return it instead of the less descriptive error from loading the truncated image.
+	var exportErr error
+	r := &streamReader{recv: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				exportErr = err
+			}
+			return nil, err
+		}
+		return msg.Data, nil
+	}}
+	if err = s.loadImage(ctx, r); err != nil {
+		if exportErr != nil {
+			return exportErr
+		}
+		return err
+	}
+	return nil
+}
+
+// loadImage loads an image from the reader in the 'docker save' tar format into the local image store.
+func (s *Server) loadImage(ctx context.Context, r io.Reader) error {
+	resp, err := s.client.ImageLoad(ctx, r, true)
+	if err != nil {
+		return status.Errorf(codes.Internal, "load image: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The load errors are reported in the JSON messages of the response body.
+	if err = jsonmessage.DisplayJSONMessagesStream(resp.Body, io.Discard, 0, false, nil); err != nil {
+		return status.Errorf(codes.Internal, "load image: %v", err)
+	}
+	return nil
+}
+
+// streamReader is an io.Reader that reads data from the messages received from a gRPC stream. recv returns io.EOF
+// when the stream is closed by the client.
+type streamReader struct {
+	recv func() ([]byte, error)
+	buf  []byte
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		data, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// localVolume returns the local volume with the given name. Only volumes of the local driver are supported
+// by snapshots and transfers as their data is stored on the machine.
+func (s *Server) localVolume(ctx context.Context, name string) (volumebackend.Volume, error) {
+	if s.volumes == nil {
+		return volumebackend.Volume{}, status.Error(codes.Unimplemented, "volume data management is not supported")
+	}
+
+	vol, err := s.client.VolumeInspect(ctx, name)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return volumebackend.Volume{}, status.Error(codes.NotFound, err.Error())
+		}
+		return volumebackend.Volume{}, status.Error(codes.Internal, err.Error())
+	}
+	if vol.Driver != api.VolumeDriverLocal {
+		return volumebackend.Volume{}, status.Errorf(codes.FailedPrecondition,
+			"volume '%s' uses '%s' driver, only local volumes are supported", name, vol.Driver)
+	}
+
+	return volumebackend.Volume{Name: vol.Name, Mountpoint: vol.Mountpoint}, nil
+}
+
+// verifyVolumeNotInUse returns an error if the volume is used by running containers. Replacing the data under
+// a running container would corrupt the state of the application.
+func (s *Server) verifyVolumeNotInUse(ctx context.Context, name string) error {
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("volume", name), filters.Arg("status", "running")),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "list containers using volume: %v", err)
+	}
+	if len(containers) > 0 {
+		return status.Errorf(codes.FailedPrecondition,
+			"volume '%s' is used by %d running container(s)", name, len(containers))
+	}
+	return nil
+}
+
 // CreateServiceContainer creates a new container for the service with the given specifications.
 // TODO: move the main logic to the Docker service and remove db dependency from the server.
 func (s *Server) CreateServiceContainer(
@@ -482,10 +1267,38 @@ func (s *Server) CreateServiceContainer(
 	if err := json.Unmarshal(req.ServiceSpec, &spec); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "unmarshal service spec: %v", err)
 	}
+	// The cluster default restart policy, proxy, and environment variables only apply to the container and aren't stored
+	// in its spec so that changing the defaults doesn't make the existing containers out of date.
+	restartPolicy := spec.Container.RestartPolicy
+	var defaultEnv api.EnvVars
+	if s.settings != nil {
+		settings := s.settings()
+		if restartPolicy == nil {
+			restartPolicy = settings.RestartPolicy()
+		}
+		// The proxy variables can be overridden by the default variables.
+		defaultEnv = settings.ProxyEnv()
+		if defaultEnv == nil {
+			defaultEnv = settings.DefaultEnv
+		} else {
+			maps.Copy(defaultEnv, settings.DefaultEnv)
+		}
+	}
 	spec = spec.SetDefaults()
 	if err := spec.Validate(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid service spec: %v", err)
 	}
+	if restartPolicy == nil {
+		restartPolicy = spec.Container.RestartPolicy
+	}
+	if s.admitContainer != nil {
+		if err := s.admitContainer(ctx, req.ServiceId, spec); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.verifyContainerImage(ctx, spec.Container.Image); err != nil {
+		return nil, err
+	}
 
 	containerName := req.ContainerName
 	if containerName == "" {
@@ -495,12 +1308,16 @@ func (s *Server) CreateServiceContainer(
 		}
 		containerName = fmt.Sprintf("%s-%s", spec.Name, suffix)
 	}
+	hostname := containerName
+	if spec.Container.Hostname != "" {
+		hostname = spec.Container.Hostname
+	}
 
 	config := &container.Config{
 		Cmd:        spec.Container.Command,
-		Env:        spec.Container.Env.ToSlice(),
+		Env:        s.containerEnv(req.ServiceId, containerName, spec, defaultEnv).ToSlice(),
 		Entrypoint: spec.Container.Entrypoint,
-		Hostname:   containerName,
+		Hostname:   hostname,
 		Image:      spec.Container.Image,
 		Labels: map[string]string{
 			api.LabelServiceID:   req.ServiceId,
@@ -508,11 +1325,16 @@ func (s *Server) CreateServiceContainer(
 			api.LabelServiceMode: spec.Mode,
 			api.LabelManaged:     "",
 		},
-		User: spec.Container.User,
+		StopSignal:  spec.Container.StopSignal,
+		StopTimeout: spec.Container.StopTimeoutSeconds(),
+		User:        spec.Container.User,
 	}
 	if spec.Mode == "" {
 		config.Labels[api.LabelServiceMode] = api.ServiceModeReplicated
 	}
+	if spec.Project != "" {
+		config.Labels[api.LabelProject] = spec.Project
+	}
 
 	// TODO: do not set the ports as container labels once migrated to retrieve them from the spec in DB.
 	var err error
@@ -536,42 +1358,71 @@ func (s *Server) CreateServiceContainer(
 		return nil, err
 	}
 
+	if err = s.verifyHostPortsAvailable(ctx, req.ServiceId, spec.Ports); err != nil {
+		return nil, err
+	}
+
+	// Docker programs the forwarding and NAT firewall rules for each bound host port including port ranges.
 	portBindings := make(nat.PortMap)
 	for _, p := range spec.Ports {
 		if p.Mode != api.PortModeHost {
 			continue
 		}
-		port := nat.Port(fmt.Sprintf("%d/%s", p.ContainerPort, p.Protocol))
-		portBindings[port] = []nat.PortBinding{
-			{
-				HostPort: strconv.Itoa(int(p.PublishedPort)),
-			},
+		// Bind each port in the range individually as Docker doesn't support port ranges in the API.
+		for i := 0; i < p.Size(); i++ {
+			port := nat.Port(fmt.Sprintf("%d/%s", int(p.ContainerPort)+i, p.Protocol))
+			binding := nat.PortBinding{
+				HostPort: strconv.Itoa(int(p.PublishedPort) + i),
+			}
+			if p.HostIP.IsValid() {
+				binding.HostIP = p.HostIP.String()
+			}
+			portBindings[port] = append(portBindings[port], binding)
+		}
+	}
+	devices := make([]container.DeviceMapping, len(spec.Container.Devices))
+	for i, d := range spec.Container.Devices {
+		devices[i] = container.DeviceMapping{
+			PathOnHost:        d.HostPath,
+			PathInContainer:   d.ContainerPath,
+			CgroupPermissions: d.CgroupPermissions,
 		}
-		if p.HostIP.IsValid() {
-			portBindings[port][0].HostIP = p.HostIP.String()
+		if devices[i].PathInContainer == "" {
+			devices[i].PathInContainer = d.HostPath
+		}
+		if devices[i].CgroupPermissions == "" {
+			devices[i].CgroupPermissions = "rwm"
 		}
 	}
 	hostConfig := &container.HostConfig{
-		Binds:        spec.Container.Volumes,
-		Init:         spec.Container.Init,
-		Mounts:       mounts,
-		PortBindings: portBindings,
-		Privileged:   spec.Container.Privileged,
+		Binds:          spec.Container.Volumes,
+		CapAdd:         spec.Container.CapAdd,
+		CapDrop:        spec.Container.CapDrop,
+		Init:           spec.Container.Init,
+		Mounts:         mounts,
+		PortBindings:   portBindings,
+		Privileged:     spec.Container.Privileged,
+		ReadonlyRootfs: spec.Container.ReadOnly,
 		Resources: container.Resources{
+			Devices:           devices,
 			NanoCPUs:          spec.Container.Resources.CPU,
 			Memory:            spec.Container.Resources.Memory,
 			MemoryReservation: spec.Container.Resources.MemoryReservation,
 		},
-		// Restart service containers if they exit or a machine restarts unless they are explicitly stopped.
-		// For one-off containers and batch jobs we plan to use a different service type/mode.
+		SecurityOpt: spec.Container.SecurityOpt,
+		Sysctls:     spec.Container.Sysctls,
+		// Docker restarts crash-looping containers with an exponential backoff delay. The default policy restarts
+		// service containers if they exit or a machine restarts unless they are explicitly stopped.
 		RestartPolicy: container.RestartPolicy{
-			Name: container.RestartPolicyUnlessStopped,
+			Name:              container.RestartPolicyMode(restartPolicy.Name),
+			MaximumRetryCount: int(restartPolicy.MaxRetries),
 		},
 	}
 
-	// Configure the container to use the internal DNS server if it's available.
+	// Configure the container to use the internal DNS server if it's available. Containers not connected to
+	// the cluster network use the DNS configuration of the machine or physical network instead.
 	dnsIP := s.internalDNSIP()
-	if dnsIP.IsValid() {
+	if dnsIP.IsValid() && spec.NetworkMode == "" {
 		hostConfig.DNS = []string{dnsIP.String()}
 		// Optimize DNS resolution for service discovery by appending the search domain to names without a dot.
 		// For example, the first attempt for "my-service" will be "my-service.internal".
@@ -586,9 +1437,22 @@ func (s *Server) CreateServiceContainer(
 		}
 	}
 
+	networkName := NetworkName
+	switch spec.NetworkMode {
+	case api.NetworkModeHost:
+		// The hostname of a container in the host network is the hostname of the machine.
+		config.Hostname = ""
+		networkName = network.NetworkHost
+		hostConfig.NetworkMode = network.NetworkHost
+	case api.NetworkModeMacvlan:
+		if networkName, err = s.ensureMacvlanNetwork(ctx, *spec.Macvlan); err != nil {
+			return nil, err
+		}
+		hostConfig.NetworkMode = container.NetworkMode(networkName)
+	}
 	networkConfig := &network.NetworkingConfig{
 		EndpointsConfig: map[string]*network.EndpointSettings{
-			NetworkName: {},
+			networkName: {},
 		},
 	}
 
@@ -632,6 +1496,83 @@ func (s *Server) CreateServiceContainer(
 	return &pb.CreateContainerResponse{Response: respBytes}, nil
 }
 
+// containerEnv returns the environment variables of a new service container: the cluster default variables,
+// the injected container metadata, and the variables from the service spec in the order of precedence.
+func (s *Server) containerEnv(
+	serviceID, containerName string, spec api.ServiceSpec, defaultEnv api.EnvVars,
+) api.EnvVars {
+	env := make(api.EnvVars, len(defaultEnv)+len(spec.Container.Env)+6)
+	maps.Copy(env, defaultEnv)
+
+	env[api.EnvService] = spec.Name
+	env[api.EnvServiceID] = serviceID
+	env[api.EnvReplica] = containerName
+	if s.machine != nil {
+		if id, name := s.machine(); id != "" {
+			env[api.EnvMachine] = name
+			env[api.EnvMachineID] = id
+		}
+	}
+	// The internal DNS server listens on the machine IP in the cluster network.
+	if ip := s.internalDNSIP(); ip.IsValid() {
+		env[api.EnvMachineIP] = ip.String()
+	}
+
+	maps.Copy(env, spec.Container.Env)
+	return env
+}
+
+// ensureMacvlanNetwork creates a macvlan Docker network for the parent interface with the given options if it doesn't
+// exist and returns its name. Only one macvlan network can be attached to a parent interface so an existing network
+// must have the same subnet configuration.
+func (s *Server) ensureMacvlanNetwork(ctx context.Context, opts api.MacvlanOptions) (string, error) {
+	name := MacvlanNetworkPrefix + opts.Parent
+	ipamConfig := network.IPAMConfig{
+		Subnet: opts.Subnet.String(),
+	}
+	if opts.Gateway.IsValid() {
+		ipamConfig.Gateway = opts.Gateway.String()
+	}
+	if opts.IPRange.IsValid() {
+		ipamConfig.IPRange = opts.IPRange.String()
+	}
+
+	nw, err := s.client.NetworkInspect(ctx, name, network.InspectOptions{})
+	if err == nil {
+		if len(nw.IPAM.Config) != 1 || nw.IPAM.Config[0].Subnet != ipamConfig.Subnet ||
+			(ipamConfig.Gateway != "" && nw.IPAM.Config[0].Gateway != ipamConfig.Gateway) ||
+			nw.IPAM.Config[0].IPRange != ipamConfig.IPRange {
+			return "", status.Errorf(codes.FailedPrecondition,
+				"macvlan network '%s' already exists on the machine with a different configuration: "+
+					"all services attached to the same parent interface must use the same subnet, gateway, "+
+					"and IP range", name)
+		}
+		return name, nil
+	}
+	if !client.IsErrNotFound(err) {
+		return "", status.Errorf(codes.Internal, "inspect Docker network '%s': %v", name, err)
+	}
+
+	if _, err = s.client.NetworkCreate(ctx, name, network.CreateOptions{
+		Driver: "macvlan",
+		Scope:  "local",
+		IPAM: &network.IPAM{
+			Config: []network.IPAMConfig{ipamConfig},
+		},
+		Labels: map[string]string{
+			api.LabelManaged: "",
+		},
+		Options: map[string]string{
+			"parent": opts.Parent,
+		},
+	}); err != nil {
+		return "", status.Errorf(codes.Internal, "create Docker network '%s': %v", name, err)
+	}
+	slog.Info("Docker macvlan network created.", "name", name, "parent", opts.Parent, "subnet", opts.Subnet)
+
+	return name, nil
+}
+
 func ToDockerMounts(volumes []api.VolumeSpec, mounts []api.VolumeMount) ([]mount.Mount, error) {
 	normalisedVolumes := make([]api.VolumeSpec, len(volumes))
 	for i, v := range volumes {
@@ -819,6 +1760,43 @@ func toDockerBindOptions(opts *api.BindOptions) *mount.BindOptions {
 	return dockerOpts
 }
 
+// verifyHostPortsAvailable checks that the host ports to be published by a container of the service don't conflict
+// with the host ports published by running containers of other services on the machine.
+func (s *Server) verifyHostPortsAvailable(ctx context.Context, serviceID string, ports []api.PortSpec) error {
+	if !slices.ContainsFunc(ports, func(p api.PortSpec) bool {
+		return p.Mode == api.PortModeHost
+	}) {
+		return nil
+	}
+
+	// Only running containers are listed by default.
+	containers, err := s.service.ListServiceContainers(ctx, "", container.ListOptions{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "list service containers: %v", err)
+	}
+
+	for _, ctr := range containers {
+		// Containers of the same service are replaced by the deployment so they're not considered as conflicting.
+		if ctr.ServiceID() == serviceID {
+			continue
+		}
+
+		conflicting, err := ctr.ConflictingServicePorts(ports)
+		if err != nil {
+			slog.Error("Failed to check conflicting ports for container.", "id", ctr.ID, "err", err)
+			continue
+		}
+		if len(conflicting) > 0 {
+			port, _ := conflicting[0].String()
+			return status.Errorf(codes.FailedPrecondition,
+				"host port '%s' conflicts with ports published by container '%s' of service '%s'",
+				port, ctr.Name, ctr.ServiceName())
+		}
+	}
+
+	return nil
+}
+
 // verifyDockerVolumesExist checks if the Docker named volumes referenced in the mounts exist on the machine.
 func (s *Server) verifyDockerVolumesExist(ctx context.Context, mounts []mount.Mount) error {
 	for _, m := range mounts {
@@ -853,6 +1831,9 @@ func (s *Server) InspectServiceContainer(
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if rbac.ReadOnly(ctx) {
+		serviceCtr = serviceCtr.Redacted()
+	}
 
 	ctrBytes, err := json.Marshal(serviceCtr.Container)
 	if err != nil {
@@ -902,8 +1883,12 @@ func (s *Server) ListServiceContainers(
 	}
 
 	// Convert to protobuf format.
+	readOnly := rbac.ReadOnly(ctx)
 	pbContainers := make([]*pb.ServiceContainer, 0, len(containers))
 	for _, ctr := range containers {
+		if readOnly {
+			ctr = ctr.Redacted()
+		}
 		ctrBytes, err := json.Marshal(ctr.Container)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "marshal container: %v", err)
@@ -929,6 +1914,31 @@ func (s *Server) ListServiceContainers(
 	}, nil
 }
 
+// UpdateServiceContainerSpec updates the spec of a service container in the machine database without recreating
+// the container. Only the metadata that doesn't affect the container can be changed.
+func (s *Server) UpdateServiceContainerSpec(
+	ctx context.Context, req *pb.UpdateServiceContainerSpecRequest,
+) (*emptypb.Empty, error) {
+	var spec api.ServiceSpec
+	if err := json.Unmarshal(req.ServiceSpec, &spec); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal service spec: %v", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service spec: %v", err)
+	}
+
+	if err := s.service.UpdateServiceContainerSpec(ctx, req.Id, spec); err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, ErrSpecChangeRequiresRecreate) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // RemoveServiceContainer stops (kills after grace period) and removes a service container with the given ID.
 // The difference between this method and RemoveContainer is that it also removes the container from the machine
 // database.
@@ -0,0 +1,126 @@
+package docker
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_containerEnv(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{
+		internalDNSIP: func() netip.Addr { return netip.MustParseAddr("10.210.1.1") },
+		machine:       func() (string, string) { return "machine-id", "machine-1" },
+	}
+	spec := api.ServiceSpec{
+		Name: "web",
+		Container: api.ContainerSpec{
+			Env: api.EnvVars{"TZ": "Europe/Berlin", "PORT": "8080"},
+		},
+	}
+	defaultEnv := api.EnvVars{"TZ": "UTC", "HTTP_PROXY": "http://proxy:3128"}
+
+	env := s.containerEnv("service-id", "web-abcd", spec, defaultEnv)
+
+	assert.Equal(t, api.EnvVars{
+		"TZ":             "Europe/Berlin",
+		"PORT":           "8080",
+		"HTTP_PROXY":     "http://proxy:3128",
+		api.EnvMachine:   "machine-1",
+		api.EnvMachineID: "machine-id",
+		api.EnvMachineIP: "10.210.1.1",
+		api.EnvService:   "web",
+		api.EnvServiceID: "service-id",
+		api.EnvReplica:   "web-abcd",
+	}, env)
+	assert.Equal(t, "UTC", defaultEnv["TZ"], "default env must not be modified")
+}
+
+func TestRepoDigestsContain(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	other := "sha256:" + strings.Repeat("b", 64)
+
+	assert.True(t, repoDigestsContain([]string{"nginx@" + digest}, "nginx:1", digest))
+	assert.True(t, repoDigestsContain([]string{"docker.io/library/nginx@" + digest}, "nginx", digest))
+	assert.True(t, repoDigestsContain([]string{"ghcr.io/org/app@" + other, "ghcr.io/org/app@" + digest},
+		"ghcr.io/org/app:v1", digest))
+	assert.False(t, repoDigestsContain(nil, "nginx:1", digest), "locally loaded image has no repo digests")
+	assert.False(t, repoDigestsContain([]string{"nginx@" + other}, "nginx:1", digest))
+	assert.False(t, repoDigestsContain([]string{"evil/nginx@" + digest}, "nginx:1", digest))
+}
+
+func TestServer_CreateContainer_ImageSigningPolicy(t *testing.T) {
+	t.Parallel()
+
+	// The server has no Docker client so the test fails if a container is created bypassing the policy.
+	s := &Server{settings: func() api.ClusterSettings {
+		return api.ClusterSettings{ImageSigning: &api.ImageSigningPolicy{
+			Images: []string{"127.0.0.1:1/*"},
+			Keys:   []api.SigningKey{{Name: "test", PublicKey: "key"}},
+		}}
+	}}
+	req := &pb.CreateContainerRequest{
+		Config:        []byte(`{"Image": "127.0.0.1:1/app:1"}`),
+		HostConfig:    []byte(`{}`),
+		NetworkConfig: []byte(`{}`),
+		Platform:      []byte(`{}`),
+	}
+
+	_, err := s.CreateContainer(context.Background(), req)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "signature of the image must be verified: %v", err)
+}
+
+func TestServer_MirrorImage_SourceMachines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      *pb.MirrorImageRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "no source",
+			req:      &pb.MirrorImageRequest{Image: "app:1"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid source IP",
+			req: &pb.MirrorImageRequest{
+				Image:            "app:1",
+				SourceMachineIps: []*pb.IP{pb.NewIP(netip.MustParseAddr("127.0.0.2")), {Ip: []byte{1, 2, 3}}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "unreachable sources",
+			req: &pb.MirrorImageRequest{
+				Image: "app:1",
+				SourceMachineIps: []*pb.IP{
+					pb.NewIP(netip.MustParseAddr("127.0.0.2")),
+					pb.NewIP(netip.MustParseAddr("127.0.0.3")),
+				},
+			},
+			wantCode: codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The server has no Docker client so the test fails if the image is mirrored from any source.
+			_, err := (&Server{}).MirrorImage(context.Background(), tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err), "%v", err)
+		})
+	}
+}
@@ -15,18 +15,26 @@ import (
 	"github.com/psviderski/uncloud/pkg/api"
 )
 
+// ErrSpecChangeRequiresRecreate is returned when the spec of a service container can't be updated in place because
+// the change requires recreating the container.
+var ErrSpecChangeRequiresRecreate = errors.New("spec change requires recreating the container")
+
 // Service provides higher-level Docker operations that extends Docker API with Uncloud-specific data
 // from the machine database.
 type Service struct {
 	Client *client.Client
 	db     *sqlx.DB
+	// specUpdates is notified when the spec of a service container is updated in place so that the controller
+	// syncs the container to the cluster store without waiting for the next regular sync.
+	specUpdates chan struct{}
 }
 
 // NewService creates a new Docker service instance.
 func NewService(client *client.Client, db *sqlx.DB) *Service {
 	return &Service{
-		Client: client,
-		db:     db,
+		Client:      client,
+		db:          db,
+		specUpdates: make(chan struct{}, 1),
 	}
 }
 
@@ -60,10 +68,50 @@ func (s *Service) InspectServiceContainer(ctx context.Context, nameOrID string)
 	if err = json.Unmarshal(specBytes, &serviceCtr.ServiceSpec); err != nil {
 		return serviceCtr, fmt.Errorf("unmarshal service spec for container '%s': %w", ctr.ID, err)
 	}
+	serviceCtr.Drift = DetectDrift(serviceCtr)
 
 	return serviceCtr, nil
 }
 
+// UpdateServiceContainerSpec replaces the spec of the service container with the given ID in the machine database
+// without changing the container. The new spec may only differ in the metadata, see api.ServiceSpec.WithMetadata.
+func (s *Service) UpdateServiceContainerSpec(ctx context.Context, id string, spec api.ServiceSpec) error {
+	ctr, err := s.InspectServiceContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	currentHash, err := ctr.ServiceSpec.Hash()
+	if err != nil {
+		return err
+	}
+	newSpec := spec.WithMetadata(ctr.ServiceSpec)
+	newHash, err := newSpec.Hash()
+	if err != nil {
+		return err
+	}
+	if currentHash != newHash {
+		return ErrSpecChangeRequiresRecreate
+	}
+
+	spec = spec.SetDefaults()
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("marshal service spec: %w", err)
+	}
+	if _, err = s.db.ExecContext(ctx, `UPDATE containers SET service_spec = $1 WHERE id = $2`,
+		string(specBytes), ctr.ID); err != nil {
+		return fmt.Errorf("update service spec for container '%s' in machine DB: %w", ctr.ID, err)
+	}
+
+	select {
+	case s.specUpdates <- struct{}{}:
+	default:
+		// A sync is already pending.
+	}
+	return nil
+}
+
 // ListServiceContainers lists Docker containers that belong to the service with the given name or ID.
 // If serviceIDOrName is empty, all service containers are returned. The opts parameter allows additional filtering.
 func (s *Service) ListServiceContainers(
@@ -101,3 +149,8 @@ func (s *Service) ListServiceContainers(
 
 	return containers, nil
 }
+
+// KillContainer kills the container with SIGKILL. Docker restarts it according to its restart policy.
+func (s *Service) KillContainer(ctx context.Context, id string) error {
+	return s.Client.ContainerKill(ctx, id, "KILL")
+}
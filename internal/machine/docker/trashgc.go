@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// TrashGCInterval is the interval at which the containers of the expired trashed services are removed.
+const TrashGCInterval = 5 * time.Minute
+
+// RunTrashGC periodically removes the containers of the trashed services on this machine whose retention period
+// has expired. A trashed service is removed from the trash once none of its trashed containers are left
+// in the cluster, so every machine has a chance to remove its containers first.
+func (c *Controller) RunTrashGC(ctx context.Context) error {
+	ticker := time.NewTicker(TrashGCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			services, err := c.store.ListTrashedServices(ctx)
+			if err != nil {
+				slog.Error("Failed to list trashed services.", "err", err)
+				continue
+			}
+			now := time.Now()
+			for _, svc := range services {
+				if svc.Expired(now) {
+					c.collectTrashedService(ctx, svc)
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// collectTrashedService removes the containers of the expired trashed service on this machine and removes
+// the service from the trash if no trashed containers are left on other machines.
+func (c *Controller) collectTrashedService(ctx context.Context, svc api.TrashedService) {
+	containers, err := c.service.ListServiceContainers(ctx, svc.ID, container.ListOptions{All: true})
+	if err != nil {
+		slog.Error("Failed to list containers of trashed service.", "service", svc.Name, "err", err)
+		return
+	}
+	for _, ctr := range containers {
+		if !svc.Contains(ctr.Container) {
+			continue
+		}
+		if err = c.removeServiceContainer(ctx, ctr.ID); err != nil {
+			slog.Error("Failed to remove container of trashed service.",
+				"service", svc.Name, "id", ctr.ID, "err", err)
+			return
+		}
+		slog.Info("Removed container of expired trashed service.", "service", svc.Name, "id", ctr.ID)
+	}
+
+	records, err := c.store.ListContainers(ctx, store.ListOptions{
+		ServiceIDOrName: store.ServiceIDOrNameOptions{ID: svc.ID},
+	})
+	if err != nil {
+		slog.Error("Failed to list containers of trashed service in cluster store.", "service", svc.Name, "err", err)
+		return
+	}
+	for _, r := range records {
+		if r.MachineID != c.machineID && svc.Contains(r.Container.Container) {
+			// Wait for the other machines to remove their containers.
+			return
+		}
+	}
+
+	if err = c.store.DeleteTrashedService(ctx, svc.ID); err != nil {
+		slog.Error("Failed to remove expired service from trash.", "service", svc.Name, "err", err)
+		return
+	}
+	slog.Info("Removed expired service from trash.", "service", svc.Name, "id", svc.ID)
+}
+
+// removeServiceContainer removes the service container along with its anonymous volumes and its record from
+// the machine database.
+func (c *Controller) removeServiceContainer(ctx context.Context, id string) error {
+	err := c.client.ContainerRemove(ctx, id, container.RemoveOptions{Force: true, RemoveVolumes: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		return err
+	}
+	if _, err = c.service.db.ExecContext(ctx, `DELETE FROM containers WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("remove container from machine database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/cluster"
+	"github.com/psviderski/uncloud/internal/machine/firewall"
+)
+
+// egressReconcileInterval is the interval at which the egress routing is reconciled with the local containers
+// in addition to reconciling it on cluster settings changes.
+const egressReconcileInterval = 10 * time.Second
+
+// egressGateway returns the ID of the machine that routes the outbound internet traffic of the containers on this
+// machine according to the egress policy or an empty string if the containers use this machine.
+func (cc *clusterController) egressGateway() string {
+	settings := cc.settings.Get()
+	if settings.Egress == nil || settings.Egress.Gateway == cc.state.ID {
+		return ""
+	}
+	return settings.Egress.Gateway
+}
+
+// runEgress keeps the egress routing of the machine in sync with the egress policy from the cluster settings.
+// The WireGuard peer of the gateway machine is configured in configurePeers.
+func (cc *clusterController) runEgress(ctx context.Context) error {
+	settingsChanges := cc.settings.Subscribe()
+	ticker := time.NewTicker(egressReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := cc.reconcileEgress(ctx); err != nil {
+			slog.Error("Failed to configure egress routing.", "err", err)
+		}
+
+		select {
+		case <-settingsChanges:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reconcileEgress configures the machine as the egress gateway if the policy selects it and routes the outbound
+// traffic of the local containers selected by the policy through the gateway machine otherwise.
+func (cc *clusterController) reconcileEgress(ctx context.Context) error {
+	policy := cc.settings.Get().Egress
+	isGateway := policy != nil && policy.Gateway == cc.state.ID
+	if err := firewall.ConfigureEgressGateway(isGateway, cluster.DefaultNetwork); err != nil {
+		return fmt.Errorf("configure egress gateway firewall: %w", err)
+	}
+
+	var sources []netip.Prefix
+	if cc.egressGateway() != "" {
+		if len(policy.Services) == 0 {
+			// Route the traffic of all containers on the machine.
+			cc.state.mu.RLock()
+			sources = []netip.Prefix{cc.state.Network.Subnet}
+			cc.state.mu.RUnlock()
+		} else {
+			containers, err := cc.dockerService.ListServiceContainers(ctx, "", container.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("list service containers: %w", err)
+			}
+			for _, ctr := range containers {
+				if !policy.Applies(ctr.ServiceName()) {
+					continue
+				}
+				if ip := ctr.UncloudNetworkIP(); ip.IsValid() {
+					sources = append(sources, netip.PrefixFrom(ip, ip.BitLen()))
+				}
+			}
+		}
+	}
+
+	if err := cc.wgnet.ConfigureEgress(sources); err != nil {
+		return fmt.Errorf("configure egress routing rules: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package firewall
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/docker/docker/libnetwork/iptables"
+	"github.com/psviderski/uncloud/internal/machine/network"
+)
+
+// egressGatewayRules returns the iptables rules that allow the machine to route the outbound internet traffic
+// of the containers on other machines in the cluster network and masquerade it as its own traffic.
+func egressGatewayRules(clusterNetwork netip.Prefix) map[iptables.Table][][]string {
+	return map[iptables.Table][][]string{
+		iptables.Filter: {
+			{
+				"--in-interface", network.WireGuardInterfaceName,
+				"--src", clusterNetwork.String(),
+				"!", "--dst", clusterNetwork.String(),
+				"-j", "ACCEPT",
+			},
+			{
+				"--out-interface", network.WireGuardInterfaceName,
+				"--dst", clusterNetwork.String(),
+				"-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED",
+				"-j", "ACCEPT",
+			},
+		},
+		iptables.Nat: {
+			{
+				"--src", clusterNetwork.String(),
+				"!", "--dst", clusterNetwork.String(),
+				"!", "--out-interface", network.WireGuardInterfaceName,
+				"-j", "MASQUERADE",
+			},
+		},
+	}
+}
+
+// ConfigureEgressGateway adds or removes the iptables rules that make the machine the egress gateway for
+// the containers on other machines in the cluster network.
+func ConfigureEgressGateway(enabled bool, clusterNetwork netip.Prefix) error {
+	ipt := iptables.GetIptable(iptables.IPv4)
+	chains := map[iptables.Table]string{
+		iptables.Filter: DockerUserChain,
+		iptables.Nat:    "POSTROUTING",
+	}
+
+	for table, rules := range egressGatewayRules(clusterNetwork) {
+		for _, rule := range rules {
+			action := iptables.Delete
+			if enabled {
+				action = iptables.Insert
+			}
+			if err := ipt.ProgramRule(table, chains[table], action, rule); err != nil {
+				return fmt.Errorf("program iptables rule '%s': %w", strings.Join(rule, " "), err)
+			}
+		}
+	}
+	return nil
+}
@@ -1,13 +0,0 @@
-package firewall
-
-import "fmt"
-
-// ConfigureIptablesChains is a stub for Darwin.
-func ConfigureIptablesChains() error {
-	return fmt.Errorf("not supported on Darwin")
-}
-
-// CleanupIptablesChains is a stub for Darwin.
-func CleanupIptablesChains() error {
-	return fmt.Errorf("not supported on Darwin")
-}
@@ -1,8 +1,11 @@
 package firewall
 
 import (
+	"bytes"
 	"fmt"
 	"log/slog"
+	"os"
+	"os/exec"
 	"strconv"
 	"strings"
 
@@ -55,9 +58,30 @@ func ConfigureIptablesChains() error {
 		}
 	}
 
+	// Local connections to Machine API never arrive via the WireGuard interface, so the rule above doesn't
+	// filter them. Machine API has no authentication, so reject local connections from any user other than
+	// the daemon, e.g. a read-only SSH user or a process in a container using the host network.
+	rule := rejectLocalMachineAPIRule(os.Getuid())
+	if err = ipt6.ProgramRule(iptables.Filter, "OUTPUT", iptables.Insert, rule); err != nil {
+		return fmt.Errorf("insert ip6tables rule '%s': %w", strings.Join(rule, " "), err)
+	}
+
 	return nil
 }
 
+// rejectLocalMachineAPIRule returns the ip6tables rule for the OUTPUT chain that rejects connections to Machine API
+// on the loopback interface from processes not owned by the given user.
+func rejectLocalMachineAPIRule(uid int) []string {
+	return []string{
+		"-o", "lo",
+		"-p", "tcp",
+		"--dport", strconv.Itoa(constants.MachineAPIPort),
+		"-m", "owner", "!", "--uid-owner", strconv.Itoa(uid),
+		"-m", "comment", "--comment", "Uncloud-managed",
+		"-j", "REJECT", "--reject-with", "tcp-reset",
+	}
+}
+
 // createIptablesChains ensures UNCLOUD-INPUT iptables and ip6tables chains exist and
 // there are jump rules from the main INPUT chains.
 func createIptablesChains() error {
@@ -128,6 +152,11 @@ func CleanupIptablesChains() error {
 	ipt4 := iptables.GetIptable(iptables.IPv4)
 	ipt6 := iptables.GetIptable(iptables.IPv6)
 
+	rule := rejectLocalMachineAPIRule(os.Getuid())
+	if err := ipt6.ProgramRule(iptables.Filter, "OUTPUT", iptables.Delete, rule); err != nil {
+		return fmt.Errorf("delete ip6tables rule '%s' from OUTPUT: %w", strings.Join(rule, " "), err)
+	}
+
 	for i, ipt := range []*iptables.IPTable{ipt4, ipt6} {
 		iptBin := "iptables"
 		if i == 1 {
@@ -161,3 +190,31 @@ func CleanupIptablesChains() error {
 
 	return nil
 }
+
+// replaceChainRules atomically replaces the rules in the iptables filter chain with the given rules creating
+// the chain if it doesn't exist. The rules are replaced in a single iptables-restore transaction so that there is
+// no window when the chain is partially populated.
+func replaceChainRules(chain string, rules [][]string) error {
+	ipt := iptables.GetIptable(iptables.IPv4)
+	if _, err := ipt.NewChain(chain, iptables.Filter); err != nil {
+		return fmt.Errorf("create iptables chain '%s': %w", chain, err)
+	}
+
+	var input strings.Builder
+	input.WriteString("*filter\n")
+	// Declaring an existing chain with --noflush flushes its rules.
+	fmt.Fprintf(&input, ":%s - [0:0]\n", chain)
+	for _, rule := range rules {
+		fmt.Fprintf(&input, "-A %s %s\n", chain, strings.Join(rule, " "))
+	}
+	input.WriteString("COMMIT\n")
+
+	restore := exec.Command("iptables-restore", "--wait", "--noflush")
+	restore.Stdin = strings.NewReader(input.String())
+	var stderr bytes.Buffer
+	restore.Stderr = &stderr
+	if err := restore.Run(); err != nil {
+		return fmt.Errorf("restore iptables chain '%s': %w: %s", chain, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
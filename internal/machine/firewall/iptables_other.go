@@ -0,0 +1,38 @@
+//go:build !linux
+
+package firewall
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// ConfigureIptablesChains is a stub for Darwin.
+func ConfigureIptablesChains() error {
+	return fmt.Errorf("not supported on Darwin")
+}
+
+// CleanupIptablesChains is a stub for Darwin.
+func CleanupIptablesChains() error {
+	return fmt.Errorf("not supported on Darwin")
+}
+
+// ConfigureEgressGateway is a stub for Darwin.
+func ConfigureEgressGateway(enabled bool, clusterNetwork netip.Prefix) error {
+	return fmt.Errorf("not supported on Darwin")
+}
+
+// ConfigureTenantIsolation is a stub for Darwin.
+func ConfigureTenantIsolation(isolation *TenantIsolation) error {
+	return fmt.Errorf("not supported on Darwin")
+}
+
+// ConfigurePeeringFilters is a stub for Darwin.
+func ConfigurePeeringFilters(filters []PeeringFilter) error {
+	return fmt.Errorf("not supported on Darwin")
+}
+
+// ConfigureVRRPFilter is a stub for Darwin.
+func ConfigureVRRPFilter(enabled bool, peers []netip.Addr) error {
+	return fmt.Errorf("not supported on Darwin")
+}
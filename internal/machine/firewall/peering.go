@@ -0,0 +1,15 @@
+package firewall
+
+import "net/netip"
+
+// UncloudPeeringsChain is the iptables chain with the rules that limit the traffic from the peered clusters
+// to the allowed local addresses.
+const UncloudPeeringsChain = "UNCLOUD-PEERINGS"
+
+// PeeringFilter limits the traffic from the network of a peered cluster to the allowed local addresses.
+type PeeringFilter struct {
+	// Network is the IP network of the peered cluster.
+	Network netip.Prefix
+	// Allowed are the local addresses the peered cluster is allowed to reach.
+	Allowed []netip.Prefix
+}
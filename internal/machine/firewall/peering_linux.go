@@ -0,0 +1,50 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/libnetwork/iptables"
+	"github.com/psviderski/uncloud/internal/machine/network"
+)
+
+// ConfigurePeeringFilters atomically replaces the rules in the UNCLOUD-PEERINGS chain with the rules of the given
+// filters and ensures there are jump rules to the chain from the DOCKER-USER and UNCLOUD-INPUT chains that filter
+// the traffic to the local containers and the machine itself. The WireGuard allowed IPs only limit what the peers
+// of this machine route to the peered clusters, so the traffic from the peered clusters must be filtered locally.
+func ConfigurePeeringFilters(filters []PeeringFilter) error {
+	if err := replaceChainRules(UncloudPeeringsChain, peeringFilterRules(filters)); err != nil {
+		return err
+	}
+
+	ipt := iptables.GetIptable(iptables.IPv4)
+	jumpRule := []string{
+		"-i", network.WireGuardInterfaceName,
+		"-m", "comment", "--comment", "Uncloud-managed",
+		"-j", UncloudPeeringsChain,
+	}
+	for _, chain := range []string{DockerUserChain, UncloudInputChain} {
+		if err := ipt.ProgramRule(iptables.Filter, chain, iptables.Insert, jumpRule); err != nil {
+			return fmt.Errorf("insert iptables rule '%s' to chain '%s': %w", strings.Join(jumpRule, " "), chain, err)
+		}
+	}
+	return nil
+}
+
+// peeringFilterRules returns the iptables rules of the filters. The replies to the connections initiated locally
+// and the traffic to the allowed addresses return to the calling chain and the rest of the traffic from the peered
+// clusters is dropped.
+func peeringFilterRules(filters []PeeringFilter) [][]string {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	rules := [][]string{{"-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "RETURN"}}
+	for _, f := range filters {
+		for _, allowed := range f.Allowed {
+			rules = append(rules, []string{"-s", f.Network.String(), "-d", allowed.String(), "-j", "RETURN"})
+		}
+		rules = append(rules, []string{"-s", f.Network.String(), "-j", "DROP"})
+	}
+	return rules
+}
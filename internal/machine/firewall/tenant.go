@@ -0,0 +1,42 @@
+package firewall
+
+import (
+	"net/netip"
+	"slices"
+)
+
+// UncloudTenantsChain is the iptables chain with the rules that isolate the traffic between the containers
+// of different tenants and networks.
+const UncloudTenantsChain = "UNCLOUD-TENANTS"
+
+// TenantIsolation is the default-deny firewall policy for the local containers. The traffic from the subnets
+// of the cluster machines to the local subnet is dropped unless it's explicitly allowed, so containers that haven't
+// been accounted for yet, e.g. just started, are isolated until the policy is updated.
+type TenantIsolation struct {
+	// Subnet is the subnet of the local machine with the containers to isolate.
+	Subnet netip.Prefix
+	// DenySources are the subnets of the cluster machines the traffic from is dropped unless allowed.
+	DenySources []netip.Prefix
+	// AllowSources are the addresses allowed to reach all local containers such as the machines and Caddy
+	// containers proxying the ingress traffic.
+	AllowSources []netip.Addr
+	// Allow are the rules that allow the traffic between the containers attached to the same network.
+	Allow []TenantIsolationRule
+}
+
+// TenantIsolationRule allows the traffic from a source container to a destination container.
+type TenantIsolationRule struct {
+	Src netip.Addr
+	Dst netip.Addr
+}
+
+// Equal returns true if the policies are the same.
+func (t *TenantIsolation) Equal(other *TenantIsolation) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.Subnet == other.Subnet &&
+		slices.Equal(t.DenySources, other.DenySources) &&
+		slices.Equal(t.AllowSources, other.AllowSources) &&
+		slices.Equal(t.Allow, other.Allow)
+}
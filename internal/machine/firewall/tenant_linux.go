@@ -0,0 +1,44 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/libnetwork/iptables"
+)
+
+// ConfigureTenantIsolation atomically replaces the rules in the UNCLOUD-TENANTS chain with the rules of the given
+// policy and ensures there is a jump rule to the chain from the DOCKER-USER chain that filters the forwarded container
+// traffic. A nil policy removes all rules from the chain disabling the isolation.
+func ConfigureTenantIsolation(isolation *TenantIsolation) error {
+	if err := replaceChainRules(UncloudTenantsChain, tenantIsolationRules(isolation)); err != nil {
+		return err
+	}
+
+	ipt := iptables.GetIptable(iptables.IPv4)
+	jumpRule := []string{"-m", "comment", "--comment", "Uncloud-managed", "-j", UncloudTenantsChain}
+	if err := ipt.ProgramRule(iptables.Filter, DockerUserChain, iptables.Insert, jumpRule); err != nil {
+		return fmt.Errorf("insert iptables rule '%s': %w", strings.Join(jumpRule, " "), err)
+	}
+	return nil
+}
+
+// tenantIsolationRules returns the iptables rules of the policy. The allowed traffic returns to the calling chain
+// and the rest of the traffic from the cluster machines to the local subnet is dropped.
+func tenantIsolationRules(isolation *TenantIsolation) [][]string {
+	if isolation == nil {
+		return nil
+	}
+
+	var rules [][]string
+	for _, src := range isolation.AllowSources {
+		rules = append(rules, []string{"-s", src.String(), "-d", isolation.Subnet.String(), "-j", "RETURN"})
+	}
+	for _, r := range isolation.Allow {
+		rules = append(rules, []string{"-s", r.Src.String(), "-d", r.Dst.String(), "-j", "RETURN"})
+	}
+	for _, src := range isolation.DenySources {
+		rules = append(rules, []string{"-s", src.String(), "-d", isolation.Subnet.String(), "-j", "DROP"})
+	}
+	return rules
+}
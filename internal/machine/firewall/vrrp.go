@@ -0,0 +1,5 @@
+package firewall
+
+// UncloudVRRPChain is the iptables chain with the rules that only accept the VRRP advertisements for the ingress
+// high availability from the other ingress HA machines.
+const UncloudVRRPChain = "UNCLOUD-VRRP"
@@ -0,0 +1,48 @@
+package firewall
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/docker/docker/libnetwork/iptables"
+)
+
+// vrrpProtocol is the IP protocol number of VRRP.
+const vrrpProtocol = "112"
+
+// ConfigureVRRPFilter atomically replaces the rules in the UNCLOUD-VRRP chain so that only the VRRP advertisements
+// from the given peers are accepted and ensures there is a jump rule to the chain for the VRRP traffic from
+// the UNCLOUD-INPUT chain. VRRP has no authentication so any host that can reach the machine could otherwise take
+// over the virtual IP by advertising a higher priority. If enabled is false, all rules are removed from the chain
+// allowing VRRP from any source, e.g. when the machine is not in the ingress HA policy.
+func ConfigureVRRPFilter(enabled bool, peers []netip.Addr) error {
+	var rules [][]string
+	if enabled {
+		rules = vrrpFilterRules(peers)
+	}
+	if err := replaceChainRules(UncloudVRRPChain, rules); err != nil {
+		return err
+	}
+
+	ipt := iptables.GetIptable(iptables.IPv4)
+	jumpRule := []string{
+		"-p", vrrpProtocol,
+		"-m", "comment", "--comment", "Uncloud-managed",
+		"-j", UncloudVRRPChain,
+	}
+	if err := ipt.ProgramRule(iptables.Filter, UncloudInputChain, iptables.Insert, jumpRule); err != nil {
+		return fmt.Errorf("insert iptables rule '%s': %w", strings.Join(jumpRule, " "), err)
+	}
+	return nil
+}
+
+// vrrpFilterRules returns the iptables rules that accept the VRRP traffic from the peers and drop it from any
+// other source.
+func vrrpFilterRules(peers []netip.Addr) [][]string {
+	var rules [][]string
+	for _, ip := range peers {
+		rules = append(rules, []string{"-s", ip.String(), "-j", "ACCEPT"})
+	}
+	return append(rules, []string{"-j", "DROP"})
+}
@@ -0,0 +1,39 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// checkDeployFreeze rejects creating a service container while the deploys are frozen in the cluster unless
+// the request is marked with the freeze override by the client that recorded the override in the audit log.
+// It enforces the freeze for clients that don't check it themselves.
+func (m *Machine) checkDeployFreeze(ctx context.Context) error {
+	if !m.Initialised() {
+		return nil
+	}
+
+	f, err := m.store.GetDeployFreeze(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil
+		}
+		return status.Errorf(codes.Internal, "get deploy freeze: %v", err)
+	}
+	if !f.Active(time.Now()) {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(api.FreezeOverrideMetadataKey)) > 0 {
+		return nil
+	}
+	return status.Errorf(codes.FailedPrecondition, "deploys are frozen until %s. Lift the freeze with "+
+		"'uc cluster unfreeze' or override it with '--override-freeze REASON'", f.Until.Format(time.RFC3339))
+}
@@ -0,0 +1,199 @@
+package httpapi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	httpv1 "github.com/psviderski/uncloud/pkg/httpapi"
+)
+
+func machinesFromProto(members []*pb.MachineMember) []httpv1.Machine {
+	machines := make([]httpv1.Machine, 0, len(members))
+	for _, member := range members {
+		m := member.Machine
+		if m == nil {
+			continue
+		}
+		machine := httpv1.Machine{
+			ID:       m.Id,
+			Name:     m.Name,
+			State:    member.State.String(),
+			Cordoned: m.Cordoned,
+			Arch:     m.Arch,
+		}
+		if m.PublicIp != nil {
+			if ip, err := m.PublicIp.ToAddr(); err == nil {
+				machine.PublicIP = ip.String()
+			}
+		}
+		if n := m.Network; n != nil {
+			if n.ManagementIp != nil {
+				if ip, err := n.ManagementIp.ToAddr(); err == nil {
+					machine.ManagementIP = ip.String()
+				}
+			}
+			if n.Subnet != nil && n.Subnet.Ip != nil {
+				if subnet, err := n.Subnet.ToPrefix(); err == nil {
+					machine.Subnet = subnet.String()
+				}
+			}
+			for _, ep := range n.Endpoints {
+				if ep.Ip == nil {
+					continue
+				}
+				if addrPort, err := ep.ToAddrPort(); err == nil {
+					machine.Endpoints = append(machine.Endpoints, addrPort.String())
+				}
+			}
+			machine.Endpoints = append(machine.Endpoints, n.DnsEndpoints...)
+		}
+		machines = append(machines, machine)
+	}
+
+	slices.SortFunc(machines, func(a, b httpv1.Machine) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return machines
+}
+
+func servicesFromContainers(records []store.ContainerRecord) []httpv1.Service {
+	servicesByID := make(map[string]*httpv1.Service)
+	for _, r := range records {
+		ctr := r.Container
+		id := ctr.ServiceID()
+		svc, ok := servicesByID[id]
+		if !ok {
+			svc = &httpv1.Service{
+				ID:         id,
+				Name:       ctr.ServiceName(),
+				Mode:       ctr.ServiceMode(),
+				Project:    ctr.Project(),
+				Containers: []httpv1.Container{},
+			}
+			servicesByID[id] = svc
+		}
+		svc.Containers = append(svc.Containers, containerFromRecord(r))
+	}
+
+	services := make([]httpv1.Service, 0, len(servicesByID))
+	for _, svc := range servicesByID {
+		slices.SortFunc(svc.Containers, func(a, b httpv1.Container) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		services = append(services, *svc)
+	}
+	slices.SortFunc(services, func(a, b httpv1.Service) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+	})
+	return services
+}
+
+func containerFromRecord(r store.ContainerRecord) httpv1.Container {
+	ctr := r.Container
+	c := httpv1.Container{
+		ID:        ctr.ID,
+		Name:      ctr.Name,
+		MachineID: r.MachineID,
+		Healthy:   ctr.Healthy(),
+		CreatedAt: ctr.CreatedTime(),
+		UpdatedAt: r.UpdatedAt,
+	}
+	if ctr.Config != nil {
+		c.Image = ctr.Config.Image
+	}
+	if ctr.State != nil {
+		c.State = ctr.State.Status
+	}
+	return c
+}
+
+func deploymentFromRevision(rev api.ServiceRevision) httpv1.Deployment {
+	return httpv1.Deployment{
+		ServiceID:     rev.ServiceID,
+		ServiceName:   rev.Spec.Name,
+		DeployedAt:    rev.CreatedAt,
+		PreviousImage: rev.Spec.Container.Image,
+		Snapshots:     len(rev.Snapshots),
+	}
+}
+
+// collectEvents derives the events that occurred after since from the container records, service revisions,
+// machine update records, and container preemptions. The events are ordered by time.
+func collectEvents(
+	records []store.ContainerRecord,
+	revs []api.ServiceRevision,
+	updates []store.MachineUpdateRecord,
+	preemptions []api.Preemption,
+	since time.Time,
+) []httpv1.Event {
+	events := []httpv1.Event{}
+	add := func(e httpv1.Event) {
+		if !e.Time.IsZero() && e.Time.After(since) {
+			events = append(events, e)
+		}
+	}
+
+	for _, r := range records {
+		ctr := r.Container
+		add(httpv1.Event{
+			Time:        ctr.CreatedTime(),
+			Type:        httpv1.EventContainerCreated,
+			MachineID:   r.MachineID,
+			ServiceName: ctr.ServiceName(),
+			ContainerID: ctr.ID,
+			Message:     fmt.Sprintf("Container %s created.", ctr.Name),
+		})
+		if exit := ctr.Exit(); exit != nil {
+			add(httpv1.Event{
+				Time:        exit.FinishedAt,
+				Type:        httpv1.EventContainerExited,
+				MachineID:   r.MachineID,
+				ServiceName: ctr.ServiceName(),
+				ContainerID: ctr.ID,
+				Message:     fmt.Sprintf("Container %s exited: %s.", ctr.Name, exit.Reason()),
+			})
+		}
+	}
+	for _, rev := range revs {
+		add(httpv1.Event{
+			Time:        rev.CreatedAt,
+			Type:        httpv1.EventServiceDeployed,
+			ServiceName: rev.Spec.Name,
+			Message:     fmt.Sprintf("Service %s deployed.", rev.Spec.Name),
+		})
+	}
+	for _, u := range updates {
+		msg := fmt.Sprintf("OS update %s.", u.State)
+		if u.Error != "" {
+			msg = fmt.Sprintf("OS update %s: %s.", u.State, u.Error)
+		}
+		add(httpv1.Event{
+			Time:      u.UpdatedAt,
+			Type:      httpv1.EventMachineUpdate,
+			MachineID: u.MachineID,
+			Message:   msg,
+		})
+	}
+
+	for _, p := range preemptions {
+		add(httpv1.Event{
+			Time:        p.Time,
+			Type:        httpv1.EventContainerPreempted,
+			MachineID:   p.MachineID,
+			ServiceName: p.ServiceName,
+			ContainerID: p.ContainerID,
+			Message:     p.Message(),
+		})
+	}
+
+	slices.SortStableFunc(events, func(a, b httpv1.Event) int {
+		return a.Time.Compare(b.Time)
+	})
+	return events
+}
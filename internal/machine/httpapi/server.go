@@ -0,0 +1,299 @@
+// Package httpapi serves a read-only HTTP JSON API exposing the cluster state from the cluster store. It allows
+// dashboards, Grafana JSON datasources, and scripts to consume the cluster state without a gRPC client.
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	httpv1 "github.com/psviderski/uncloud/pkg/httpapi"
+)
+
+// shutdownTimeout is the timeout for completing the in-flight requests when the server is stopped.
+const shutdownTimeout = 10 * time.Second
+
+// Store provides the cluster state served by the API.
+type Store interface {
+	ListContainers(ctx context.Context, opts store.ListOptions) ([]store.ContainerRecord, error)
+	ListServiceRevisions(ctx context.Context) ([]api.ServiceRevision, error)
+	ListMachineUpdates(ctx context.Context) ([]store.MachineUpdateRecord, error)
+	ListTenants(ctx context.Context) ([]api.Tenant, error)
+	ListPreemptions(ctx context.Context) ([]api.Preemption, error)
+}
+
+// tenantContextKey is the request context key for the name of the tenant authenticated with a tenant token.
+type tenantContextKey struct{}
+
+// requestTenant returns the name of the tenant that authenticated the request or an empty string if the request
+// was authenticated with the API token that gives access to the whole cluster.
+func requestTenant(r *http.Request) string {
+	tenant, _ := r.Context().Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// MachineLister lists the cluster machines with their membership states.
+type MachineLister interface {
+	ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error)
+}
+
+// Server is an HTTP server for the read-only JSON API. All requests must be authenticated with a bearer token.
+// The requests authenticated with a tenant token only get the state of the tenant services.
+type Server struct {
+	addr     string
+	token    string
+	store    Store
+	machines MachineLister
+	log      *slog.Logger
+}
+
+func NewServer(addr, token string, store Store, machines MachineLister) *Server {
+	return &Server{
+		addr:     addr,
+		token:    token,
+		store:    store,
+		machines: machines,
+		log:      slog.With("component", "http-api"),
+	}
+}
+
+// Handler returns the HTTP handler serving the API endpoints. The OpenAPI specification is served without
+// authentication as it doesn't contain any cluster state.
+func (s *Server) Handler() http.Handler {
+	protected := http.NewServeMux()
+	protected.HandleFunc("GET /v1/machines", s.handleMachines)
+	protected.HandleFunc("GET /v1/services", s.handleServices)
+	protected.HandleFunc("GET /v1/deployments", s.handleDeployments)
+	protected.HandleFunc("GET /v1/events", s.handleEvents)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+httpv1.OpenAPISpecPath, handleOpenAPISpec)
+	mux.Handle("/", s.authenticate(protected))
+	return mux
+}
+
+// Run serves the API on the configured address until the context is canceled.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen HTTP API address '%s': %w", s.addr, err)
+	}
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.log.Info("Starting HTTP API server.", "addr", s.addr)
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err = <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err = server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown HTTP API server: %w", err)
+	}
+	if err = <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	s.log.Info("HTTP API server stopped.")
+	return nil
+}
+
+// authenticate rejects requests that don't provide the configured bearer token or a tenant token
+// in the Authorization header.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		unauthorized := func() {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="uncloud"`)
+			writeError(w, http.StatusUnauthorized, errors.New("invalid or missing bearer token"))
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.token == "" {
+			unauthorized()
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !strings.HasPrefix(token, api.TenantTokenPrefix) {
+			unauthorized()
+			return
+		}
+
+		tenants, err := s.store.ListTenants(r.Context())
+		if err != nil {
+			s.internalError(w, fmt.Errorf("list tenants: %w", err))
+			return
+		}
+		for _, t := range tenants {
+			if t.TokenID(token) != "" {
+				ctx := context.WithValue(r.Context(), tenantContextKey{}, t.Name)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+		unauthorized()
+	})
+}
+
+func (s *Server) handleMachines(w http.ResponseWriter, r *http.Request) {
+	if requestTenant(r) != "" {
+		writeError(w, http.StatusForbidden, errors.New("tenant tokens can't access the cluster machines"))
+		return
+	}
+	resp, err := s.machines.ListMachines(r.Context(), nil)
+	if err != nil {
+		s.internalError(w, fmt.Errorf("list machines: %w", err))
+		return
+	}
+	writeJSON(w, machinesFromProto(resp.Machines))
+}
+
+func (s *Server) handleServices(w http.ResponseWriter, r *http.Request) {
+	records, err := s.store.ListContainers(r.Context(), store.ListOptions{})
+	if err != nil {
+		s.internalError(w, fmt.Errorf("list containers: %w", err))
+		return
+	}
+	writeJSON(w, servicesFromContainers(tenantContainers(records, requestTenant(r))))
+}
+
+func (s *Server) handleDeployments(w http.ResponseWriter, r *http.Request) {
+	revs, err := s.store.ListServiceRevisions(r.Context())
+	if err != nil {
+		s.internalError(w, fmt.Errorf("list service revisions: %w", err))
+		return
+	}
+	tenant := requestTenant(r)
+	deployments := make([]httpv1.Deployment, 0, len(revs))
+	for _, rev := range revs {
+		if tenant != "" && rev.Spec.Tenant != tenant {
+			continue
+		}
+		deployments = append(deployments, deploymentFromRevision(rev))
+	}
+	writeJSON(w, deployments)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if v := r.URL.Query().Get("since"); v != "" {
+		var err error
+		if since, err = parseSince(v, time.Now()); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	records, err := s.store.ListContainers(r.Context(), store.ListOptions{})
+	if err != nil {
+		s.internalError(w, fmt.Errorf("list containers: %w", err))
+		return
+	}
+	revs, err := s.store.ListServiceRevisions(r.Context())
+	if err != nil {
+		s.internalError(w, fmt.Errorf("list service revisions: %w", err))
+		return
+	}
+	updates, err := s.store.ListMachineUpdates(r.Context())
+	if err != nil {
+		s.internalError(w, fmt.Errorf("list machine updates: %w", err))
+		return
+	}
+	preemptions, err := s.store.ListPreemptions(r.Context())
+	if err != nil {
+		s.internalError(w, fmt.Errorf("list preemptions: %w", err))
+		return
+	}
+	if tenant := requestTenant(r); tenant != "" {
+		records = tenantContainers(records, tenant)
+		var tenantRevs []api.ServiceRevision
+		for _, rev := range revs {
+			if rev.Spec.Tenant == tenant {
+				tenantRevs = append(tenantRevs, rev)
+			}
+		}
+		revs = tenantRevs
+		var tenantPreemptions []api.Preemption
+		for _, p := range preemptions {
+			if p.Tenant == tenant {
+				tenantPreemptions = append(tenantPreemptions, p)
+			}
+		}
+		preemptions = tenantPreemptions
+		// The machine updates aren't related to the tenant services.
+		updates = nil
+	}
+	writeJSON(w, collectEvents(records, revs, updates, preemptions, since))
+}
+
+// tenantContainers returns the containers of the tenant services. All containers are returned if tenant is empty.
+func tenantContainers(records []store.ContainerRecord, tenant string) []store.ContainerRecord {
+	if tenant == "" {
+		return records
+	}
+	var filtered []store.ContainerRecord
+	for _, r := range records {
+		if r.Container.ServiceSpec.Tenant == tenant {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func handleOpenAPISpec(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	if _, err := w.Write(httpv1.OpenAPISpec); err != nil {
+		slog.Error("Failed to write OpenAPI specification.", "err", err)
+	}
+}
+
+func (s *Server) internalError(w http.ResponseWriter, err error) {
+	s.log.Error("Failed to handle HTTP API request.", "err", err)
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+// parseSince parses the since query parameter that is either an RFC 3339 timestamp or a duration relative to now.
+func parseSince(v string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid since '%s': expected an RFC 3339 timestamp or a duration", v)
+	}
+	return now.Add(-d), nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Failed to write HTTP API response.", "err", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(httpv1.Error{Message: err.Error()})
+}
@@ -0,0 +1,322 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	httpv1 "github.com/psviderski/uncloud/pkg/httpapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	containers  []store.ContainerRecord
+	revisions   []api.ServiceRevision
+	updates     []store.MachineUpdateRecord
+	tenants     []api.Tenant
+	preemptions []api.Preemption
+}
+
+func (s *fakeStore) ListContainers(context.Context, store.ListOptions) ([]store.ContainerRecord, error) {
+	return s.containers, nil
+}
+
+func (s *fakeStore) ListServiceRevisions(context.Context) ([]api.ServiceRevision, error) {
+	return s.revisions, nil
+}
+
+func (s *fakeStore) ListMachineUpdates(context.Context) ([]store.MachineUpdateRecord, error) {
+	return s.updates, nil
+}
+
+func (s *fakeStore) ListTenants(context.Context) ([]api.Tenant, error) {
+	return s.tenants, nil
+}
+
+func (s *fakeStore) ListPreemptions(context.Context) ([]api.Preemption, error) {
+	return s.preemptions, nil
+}
+
+type fakeMachines []*pb.MachineMember
+
+func (m fakeMachines) ListMachines(context.Context, *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error) {
+	return &pb.ListMachinesResponse{Machines: m}, nil
+}
+
+func serviceContainer(id, name, serviceName, status string, created time.Time) api.ServiceContainer {
+	return api.ServiceContainer{Container: api.Container{ContainerJSON: types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:      id,
+			Name:    name,
+			Created: created.Format(time.RFC3339Nano),
+			State:   &types.ContainerState{Status: status, Running: status == "running"},
+		},
+		Config: &container.Config{
+			Image: "nginx:1.27",
+			Labels: map[string]string{
+				api.LabelServiceID:   "svc-" + serviceName,
+				api.LabelServiceName: serviceName,
+				api.LabelServiceMode: api.ServiceModeReplicated,
+			},
+		},
+	}}}
+}
+
+func newTestServer() *Server {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	st := &fakeStore{
+		containers: []store.ContainerRecord{
+			{Container: serviceContainer("c2", "web-2", "web", "running", now.Add(-time.Minute)), MachineID: "m1"},
+			{Container: serviceContainer("c1", "web-1", "web", "running", now.Add(-time.Hour)), MachineID: "m2"},
+			{Container: serviceContainer("c3", "db-1", "db", "exited", now.Add(-2*time.Hour)), MachineID: "m1"},
+		},
+		revisions: []api.ServiceRevision{{
+			ServiceID: "svc-web",
+			Spec: api.ServiceSpec{
+				Name:      "web",
+				Container: api.ContainerSpec{Image: "nginx:1.26"},
+			},
+			CreatedAt: now.Add(-2 * time.Minute),
+		}},
+	}
+	machines := fakeMachines{{
+		Machine: &pb.MachineInfo{
+			Id:   "m1",
+			Name: "machine-1",
+			Network: &pb.NetworkConfig{
+				ManagementIp: pb.NewIP(netip.MustParseAddr("fdcc::1")),
+				Endpoints:    []*pb.IPPort{pb.NewIPPort(netip.MustParseAddrPort("1.2.3.4:51820"))},
+			},
+		},
+		State: pb.MachineMember_UP,
+	}}
+	return NewServer("", "secret", st, machines)
+}
+
+func get(t *testing.T, handler http.Handler, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Authentication(t *testing.T) {
+	t.Parallel()
+	handler := newTestServer().Handler()
+
+	rec := get(t, handler, "/v1/machines", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+
+	rec = get(t, handler, "/v1/machines", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = get(t, handler, "/v1/machines", "secret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// An empty token rejects all requests.
+	handler = NewServer("", "", &fakeStore{}, fakeMachines{}).Handler()
+	req := httptest.NewRequest(http.MethodGet, "/v1/machines", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServer_Machines(t *testing.T) {
+	t.Parallel()
+
+	rec := get(t, newTestServer().Handler(), "/v1/machines", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var machines []httpv1.Machine
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &machines))
+	assert.Equal(t, []httpv1.Machine{{
+		ID:           "m1",
+		Name:         "machine-1",
+		State:        "UP",
+		ManagementIP: "fdcc::1",
+		Endpoints:    []string{"1.2.3.4:51820"},
+	}}, machines)
+}
+
+func TestServer_Services(t *testing.T) {
+	t.Parallel()
+
+	rec := get(t, newTestServer().Handler(), "/v1/services", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var services []httpv1.Service
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &services))
+	require.Len(t, services, 2)
+	assert.Equal(t, "db", services[0].Name)
+	assert.Equal(t, "web", services[1].Name)
+	assert.Equal(t, api.ServiceModeReplicated, services[1].Mode)
+	require.Len(t, services[1].Containers, 2)
+	assert.Equal(t, "web-1", services[1].Containers[0].Name)
+	assert.Equal(t, "m2", services[1].Containers[0].MachineID)
+	assert.Equal(t, "nginx:1.27", services[1].Containers[0].Image)
+	assert.True(t, services[1].Containers[0].Healthy)
+	assert.False(t, services[0].Containers[0].Healthy)
+}
+
+func TestServer_Deployments(t *testing.T) {
+	t.Parallel()
+
+	rec := get(t, newTestServer().Handler(), "/v1/deployments", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var deployments []httpv1.Deployment
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &deployments))
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "web", deployments[0].ServiceName)
+	assert.Equal(t, "nginx:1.26", deployments[0].PreviousImage)
+}
+
+func TestServer_Events(t *testing.T) {
+	t.Parallel()
+	handler := newTestServer().Handler()
+
+	rec := get(t, handler, "/v1/events", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var events []httpv1.Event
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+	require.Len(t, events, 4)
+	assert.Equal(t, httpv1.EventContainerCreated, events[0].Type)
+	assert.Equal(t, "c3", events[0].ContainerID)
+	assert.Equal(t, httpv1.EventServiceDeployed, events[2].Type)
+
+	rec = get(t, handler, "/v1/events?since=2025-06-01T11:30:00Z", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	events = nil
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, httpv1.EventServiceDeployed, events[0].Type)
+	assert.Equal(t, "c2", events[1].ContainerID)
+
+	rec = get(t, handler, "/v1/events?since=yesterday", "secret")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_EventsPreemption(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer()
+	srv.store.(*fakeStore).preemptions = []api.Preemption{{
+		ContainerID:       "c2",
+		ContainerName:     "web-2",
+		ServiceName:       "web",
+		MachineID:         "m1",
+		Priority:          api.PriorityLow,
+		PreemptorService:  "db",
+		PreemptorPriority: api.PriorityCritical,
+		Time:              time.Date(2025, 6, 1, 11, 59, 30, 0, time.UTC),
+	}}
+
+	rec := get(t, srv.Handler(), "/v1/events?since=2025-06-01T11:59:10Z", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var events []httpv1.Event
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+	require.Len(t, events, 1)
+	assert.Equal(t, httpv1.EventContainerPreempted, events[0].Type)
+	assert.Equal(t, "c2", events[0].ContainerID)
+	assert.Equal(t, "Container web-2 of service web (priority low) preempted to make room for service db "+
+		"(priority critical).", events[0].Message)
+}
+
+func TestServer_TenantToken(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := api.TenantTokenPrefix + "acme-secret"
+
+	shop := serviceContainer("c4", "shop-1", "shop", "running", now.Add(-time.Minute))
+	shop.ServiceSpec.Tenant = "acme"
+	srv := newTestServer()
+	st := srv.store.(*fakeStore)
+	st.containers = append(st.containers, store.ContainerRecord{Container: shop, MachineID: "m1"})
+	st.revisions = append(st.revisions, api.ServiceRevision{
+		ServiceID: "svc-shop",
+		Spec:      api.ServiceSpec{Name: "shop", Tenant: "acme"},
+		CreatedAt: now.Add(-2 * time.Minute),
+	})
+	st.tenants = []api.Tenant{{
+		Name:   "acme",
+		Tokens: []api.TenantToken{{ID: "t1", Hash: api.HashTenantToken(token)}},
+	}}
+	handler := srv.Handler()
+
+	rec := get(t, handler, "/v1/services", api.TenantTokenPrefix+"wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = get(t, handler, "/v1/services", token)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var services []httpv1.Service
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &services))
+	require.Len(t, services, 1)
+	assert.Equal(t, "shop", services[0].Name)
+
+	rec = get(t, handler, "/v1/deployments", token)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var deployments []httpv1.Deployment
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &deployments))
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "shop", deployments[0].ServiceName)
+
+	rec = get(t, handler, "/v1/events", token)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var events []httpv1.Event
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+	for _, e := range events {
+		assert.Equal(t, "shop", e.ServiceName)
+	}
+	assert.Len(t, events, 2)
+
+	rec = get(t, handler, "/v1/machines", token)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// The cluster token still gets all services.
+	rec = get(t, handler, "/v1/services", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	services = nil
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &services))
+	assert.Len(t, services, 3)
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	since, err := parseSince("1h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), since)
+
+	since, err = parseSince("2025-06-01T10:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC), since)
+
+	_, err = parseSince("-1h", now)
+	assert.Error(t, err)
+}
+
+func TestServer_OpenAPISpec(t *testing.T) {
+	t.Parallel()
+
+	// The specification is served without authentication.
+	rec := get(t, newTestServer().Handler(), httpv1.OpenAPISpecPath, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+	assert.Equal(t, httpv1.OpenAPISpec, rec.Body.Bytes())
+}
@@ -0,0 +1,263 @@
+// Package imagesig verifies cosign signatures of container images stored in registries.
+//
+// Cosign stores the signatures of an image in the same repository as an OCI image tagged with the image digest,
+// e.g. sha256-<hex>.sig. Each layer of the signature image is a JSON payload that references the signed image digest
+// and has the base64-encoded signature of the payload in its annotations. For keyless signing, the annotations also
+// contain the short-lived signing certificate issued by Fulcio to the signer's OIDC identity.
+package imagesig
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// signatureAnnotation is the annotation of a signature layer with the base64-encoded signature of its payload.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+	// certificateAnnotation is the annotation of a signature layer with the PEM-encoded keyless signing certificate.
+	certificateAnnotation = "dev.sigstore.cosign/certificate"
+	// chainAnnotation is the annotation of a signature layer with the PEM-encoded intermediate certificates.
+	chainAnnotation = "dev.sigstore.cosign/chain"
+)
+
+var (
+	// oidIssuerV1 is the Fulcio certificate extension with the OIDC issuer as a raw string.
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// oidIssuerV2 is the Fulcio certificate extension with the OIDC issuer as a DER-encoded UTF8String.
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// ErrNotSigned is returned when the image has no signature made by a trusted key or identity.
+var ErrNotSigned = errors.New("image is not signed by a trusted key or identity")
+
+// payload is the cosign simple signing payload that references the signed image.
+type payload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// Verify checks that the image in the registry has a valid cosign signature made by one of the trusted keys or
+// identities of the policy. The image digest is resolved from the registry if the image reference doesn't include
+// it. It returns the name of the trusted key or identity that signed the image.
+//
+// The keyless signing certificates are verified against the trusted roots at the time they were issued because
+// the signing time isn't verified in a transparency log.
+func Verify(ctx context.Context, policy api.ImageSigningPolicy, image string, opts ...remote.Option) (string, error) {
+	signer, _, err := VerifyDigest(ctx, policy, image, opts...)
+	return signer, err
+}
+
+// VerifyDigest is like Verify but also returns the verified image digest. A tag can be moved to another image after
+// it's verified so the image must be pulled and used by the returned digest rather than by the tag.
+func VerifyDigest(
+	ctx context.Context, policy api.ImageSigningPolicy, image string, opts ...remote.Option,
+) (string, v1.Hash, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", v1.Hash{}, fmt.Errorf("parse image reference: %w", err)
+	}
+	opts = append(opts, remote.WithContext(ctx))
+
+	var digest v1.Hash
+	if d, ok := ref.(name.Digest); ok {
+		if digest, err = v1.NewHash(d.DigestStr()); err != nil {
+			return "", digest, fmt.Errorf("parse image digest: %w", err)
+		}
+	} else {
+		desc, err := remote.Head(ref, opts...)
+		if err != nil {
+			return "", digest, fmt.Errorf("resolve image digest: %w", err)
+		}
+		digest = desc.Digest
+	}
+
+	sigTag := ref.Context().Tag(fmt.Sprintf("%s-%s.sig", digest.Algorithm, digest.Hex))
+	sigImg, err := remote.Image(sigTag, opts...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return "", digest, ErrNotSigned
+		}
+		return "", digest, fmt.Errorf("fetch signatures '%s': %w", sigTag, err)
+	}
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return "", digest, fmt.Errorf("get signatures manifest: %w", err)
+	}
+
+	for _, desc := range manifest.Layers {
+		sig, err := base64.StdEncoding.DecodeString(desc.Annotations[signatureAnnotation])
+		if err != nil || len(sig) == 0 {
+			continue
+		}
+		layer, err := sigImg.LayerByDigest(desc.Digest)
+		if err != nil {
+			return "", digest, fmt.Errorf("get signature layer: %w", err)
+		}
+		data, err := readLayer(layer)
+		if err != nil {
+			return "", digest, fmt.Errorf("read signature payload: %w", err)
+		}
+		if !payloadMatches(data, digest) {
+			continue
+		}
+
+		if signer := verifySignature(policy, data, sig, desc.Annotations); signer != "" {
+			return signer, digest, nil
+		}
+	}
+
+	return "", digest, ErrNotSigned
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// payloadMatches returns true if the signature payload references the image digest.
+func payloadMatches(data []byte, digest v1.Hash) bool {
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return false
+	}
+	return p.Critical.Image.DockerManifestDigest == digest.String()
+}
+
+// verifySignature returns the name of the trusted key or identity that made the signature of the payload or
+// an empty string if none of them did.
+func verifySignature(policy api.ImageSigningPolicy, data, sig []byte, annotations map[string]string) string {
+	for _, k := range policy.Keys {
+		pub, err := api.ParsePublicKey(k.PublicKey)
+		if err != nil {
+			continue
+		}
+		if verifyWithKey(pub, data, sig) {
+			return k.Name
+		}
+	}
+
+	certPEM := annotations[certificateAnnotation]
+	if certPEM == "" || len(policy.Identities) == 0 {
+		return ""
+	}
+	certs, err := api.ParseCertificates(certPEM)
+	if err != nil {
+		return ""
+	}
+	cert := certs[0]
+	if !verifyWithKey(cert.PublicKey, data, sig) {
+		return ""
+	}
+
+	intermediates := x509.NewCertPool()
+	if chain, err := api.ParseCertificates(annotations[chainAnnotation]); err == nil {
+		for _, c := range chain {
+			intermediates.AddCert(c)
+		}
+	}
+	for _, id := range policy.Identities {
+		if verifyIdentity(id, cert, intermediates) {
+			return id.Name
+		}
+	}
+	return ""
+}
+
+// verifyWithKey verifies the signature of the data made with the private key of the public key.
+func verifyWithKey(pub any, data, sig []byte) bool {
+	digest := sha256.Sum256(data)
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, data, sig)
+	}
+	return false
+}
+
+// verifyIdentity checks that the signing certificate chains to the roots of the identity and was issued to its
+// subject by its OIDC issuer.
+func verifyIdentity(id api.SigningIdentity, cert *x509.Certificate, intermediates *x509.CertPool) bool {
+	roots, err := api.ParseCertificates(id.Roots)
+	if err != nil {
+		return false
+	}
+	pool := x509.NewCertPool()
+	for _, r := range roots {
+		pool.AddCert(r)
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		CurrentTime:   cert.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return false
+	}
+
+	return certIssuer(cert) == id.Issuer && certHasSubject(cert, id.Subject)
+}
+
+// certIssuer returns the OIDC issuer from the Fulcio certificate extensions.
+func certIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV1) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+// certHasSubject returns true if the subject is one of the email or URI subject alternative names of the certificate.
+func certHasSubject(cert *x509.Certificate, subject string) bool {
+	for _, email := range cert.EmailAddresses {
+		if strings.EqualFold(email, subject) {
+			return true
+		}
+	}
+	for _, uri := range cert.URIs {
+		if uri.String() == subject {
+			return true
+		}
+	}
+	return false
+}
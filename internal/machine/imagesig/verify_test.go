@@ -0,0 +1,224 @@
+package imagesig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushImage pushes a random image to the registry and returns its reference and digest.
+func pushImage(t *testing.T, host, repo string) (string, v1.Hash) {
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	ref, err := name.ParseReference(fmt.Sprintf("%s/%s:latest", host, repo))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return ref.String(), digest
+}
+
+// pushSignature signs the image digest with the key and pushes the cosign signature image to the registry.
+func pushSignature(
+	t *testing.T, host, repo string, digest v1.Hash, key *ecdsa.PrivateKey, annotations map[string]string,
+) {
+	data := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s/%s"},`+
+		`"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`,
+		host, repo, digest))
+	hash := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[signatureAnnotation] = base64.StdEncoding.EncodeToString(sig)
+	sigImg, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(data, "application/vnd.dev.cosign.simplesigning.v1+json"),
+		Annotations: annotations,
+		MediaType:   types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json"),
+	})
+	require.NoError(t, err)
+
+	tag, err := name.NewTag(fmt.Sprintf("%s/%s:%s-%s.sig", host, repo, digest.Algorithm, digest.Hex))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(tag, sigImg))
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func certPEM(der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func newRegistry(t *testing.T) string {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestVerify_Key(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	host := newRegistry(t)
+
+	trusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	untrusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	policy := api.ImageSigningPolicy{
+		Keys: []api.SigningKey{{Name: "release", PublicKey: publicKeyPEM(t, trusted)}},
+	}
+
+	t.Run("signed by trusted key", func(t *testing.T) {
+		image, digest := pushImage(t, host, "signed")
+		pushSignature(t, host, "signed", digest, trusted, nil)
+
+		signer, err := Verify(ctx, policy, image)
+		require.NoError(t, err)
+		assert.Equal(t, "release", signer)
+
+		// The image referenced by digest is verified without resolving the tag.
+		signer, err = Verify(ctx, policy, fmt.Sprintf("%s/signed@%s", host, digest))
+		require.NoError(t, err)
+		assert.Equal(t, "release", signer)
+	})
+
+	t.Run("signed by untrusted key", func(t *testing.T) {
+		image, digest := pushImage(t, host, "untrusted")
+		pushSignature(t, host, "untrusted", digest, untrusted, nil)
+
+		_, err := Verify(ctx, policy, image)
+		assert.ErrorIs(t, err, ErrNotSigned)
+	})
+
+	t.Run("not signed", func(t *testing.T) {
+		image, _ := pushImage(t, host, "unsigned")
+
+		_, err := Verify(ctx, policy, image)
+		assert.ErrorIs(t, err, ErrNotSigned)
+	})
+
+	t.Run("signature of another image", func(t *testing.T) {
+		image, digest := pushImage(t, host, "replayed")
+		_, otherDigest := pushImage(t, host, "other")
+		// Sign the other image digest but store the signature under the tag of the verified image.
+		pushSignature(t, host, "other", otherDigest, trusted, nil)
+		otherSig, err := name.NewTag(fmt.Sprintf("%s/other:%s-%s.sig", host, otherDigest.Algorithm, otherDigest.Hex))
+		require.NoError(t, err)
+		other, err := remote.Image(otherSig)
+		require.NoError(t, err)
+		sigTag, err := name.NewTag(fmt.Sprintf("%s/replayed:%s-%s.sig", host, digest.Algorithm, digest.Hex))
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(sigTag, other))
+
+		_, err = Verify(ctx, policy, image)
+		assert.ErrorIs(t, err, ErrNotSigned)
+	})
+}
+
+func TestVerify_Identity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	host := newRegistry(t)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	const issuer = "https://token.actions.githubusercontent.com"
+	subject, err := url.Parse("https://github.com/acme/app/.github/workflows/release.yaml@refs/heads/main")
+	require.NoError(t, err)
+	issuerExt, err := asn1.Marshal(issuer)
+	require.NoError(t, err)
+
+	// The short-lived signing certificate has already expired by the time the image is verified.
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-30 * time.Minute),
+		NotAfter:        time.Now().Add(-20 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{subject},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuerExt}},
+	}, ca, &signer.PublicKey, caKey)
+	require.NoError(t, err)
+
+	image, digest := pushImage(t, host, "keyless")
+	pushSignature(t, host, "keyless", digest, signer, map[string]string{certificateAnnotation: certPEM(leafDER)})
+
+	identity := api.SigningIdentity{
+		Name:    "ci",
+		Issuer:  issuer,
+		Subject: subject.String(),
+		Roots:   certPEM(caDER),
+	}
+	signerName, err := Verify(ctx, api.ImageSigningPolicy{Identities: []api.SigningIdentity{identity}}, image)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", signerName)
+
+	wrongSubject := identity
+	wrongSubject.Subject = "https://github.com/acme/other/.github/workflows/release.yaml@refs/heads/main"
+	_, err = Verify(ctx, api.ImageSigningPolicy{Identities: []api.SigningIdentity{wrongSubject}}, image)
+	assert.ErrorIs(t, err, ErrNotSigned)
+
+	wrongIssuer := identity
+	wrongIssuer.Issuer = "https://accounts.google.com"
+	_, err = Verify(ctx, api.ImageSigningPolicy{Identities: []api.SigningIdentity{wrongIssuer}}, image)
+	assert.ErrorIs(t, err, ErrNotSigned)
+
+	otherCAKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherCADER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &otherCAKey.PublicKey, otherCAKey)
+	require.NoError(t, err)
+	untrustedRoot := identity
+	untrustedRoot.Roots = certPEM(otherCADER)
+	_, err = Verify(ctx, api.ImageSigningPolicy{Identities: []api.SigningIdentity{untrustedRoot}}, image)
+	assert.ErrorIs(t, err, ErrNotSigned)
+}
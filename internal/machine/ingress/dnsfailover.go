@@ -0,0 +1,259 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/internal/machine/scaling"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// DefaultCheckInterval is the interval at which the public endpoints of the ingress machines are health-checked.
+	DefaultCheckInterval = 30 * time.Second
+	// FailureThreshold is the number of consecutive failed checks after which an ingress machine is considered
+	// unhealthy and its DNS records are withdrawn.
+	FailureThreshold = 3
+	// checkTimeout is the timeout for checking a single ingress machine.
+	checkTimeout = 5 * time.Second
+)
+
+// Cluster provides the cluster machines and manages the DNS records of the reserved cluster domain.
+type Cluster interface {
+	ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error)
+	GetDomain(ctx context.Context, req *emptypb.Empty) (*pb.Domain, error)
+	CreateDomainRecords(
+		ctx context.Context, req *pb.CreateDomainRecordsRequest,
+	) (*pb.CreateDomainRecordsResponse, error)
+}
+
+// Machine is a machine running the Caddy service that receives the inbound traffic on its public IP.
+type Machine struct {
+	ID       string
+	Name     string
+	PublicIP netip.Addr
+}
+
+// DNSFailover health-checks the public endpoints of the ingress machines, i.e. the machines with a public IP
+// running Caddy, and keeps only the healthy ones in the DNS records of the reserved cluster domain. A machine is
+// healthy once its Caddy responds with the machine ID on the verification path of its public IP and until
+// FailureThreshold consecutive checks fail. The records are never emptied: if none of the machines is healthy,
+// the current records are kept. Only the leader, the first UP machine ordered by ID, runs the checks.
+// Only the A records are managed as the ingress records don't include AAAA records yet.
+type DNSFailover struct {
+	machineID string
+	store     *store.Store
+	cluster   Cluster
+	client    *http.Client
+	interval  time.Duration
+	health    map[string]*machineHealth
+	// published are the IPs in the DNS records last published by this machine.
+	published []string
+	log       *slog.Logger
+}
+
+type machineHealth struct {
+	// succeeded is true if any check of the machine has succeeded.
+	succeeded bool
+	// failures is the number of consecutive failed checks.
+	failures int
+}
+
+// healthy returns true if the machine has passed a check and hasn't failed FailureThreshold checks in a row since.
+func (h *machineHealth) healthy() bool {
+	return h.succeeded && h.failures < FailureThreshold
+}
+
+// record records the result of a check.
+func (h *machineHealth) record(ok bool) {
+	if ok {
+		h.succeeded = true
+		h.failures = 0
+	} else {
+		h.failures++
+	}
+}
+
+func NewDNSFailover(machineID string, store *store.Store, cluster Cluster) *DNSFailover {
+	return &DNSFailover{
+		machineID: machineID,
+		store:     store,
+		cluster:   cluster,
+		client:    &http.Client{Timeout: checkTimeout},
+		interval:  DefaultCheckInterval,
+		health:    make(map[string]*machineHealth),
+		log:       slog.With("component", "dns-failover"),
+	}
+}
+
+// Run health-checks the ingress machines and updates the DNS records every interval until the context is canceled.
+func (f *DNSFailover) Run(ctx context.Context) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.reconcile(ctx); err != nil {
+				f.log.Error("Failed to update DNS records of ingress machines.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (f *DNSFailover) reconcile(ctx context.Context) error {
+	resp, err := f.cluster.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if scaling.Leader(resp.Machines) != f.machineID {
+		// Reset the state so that a stale state isn't used when this machine becomes the leader again.
+		clear(f.health)
+		f.published = nil
+		return nil
+	}
+	if _, err = f.cluster.GetDomain(ctx, nil); err != nil {
+		if status.Code(err) == codes.NotFound {
+			// No cluster domain is reserved so there are no records to update.
+			return nil
+		}
+		return fmt.Errorf("get cluster domain: %w", err)
+	}
+
+	records, err := f.store.ListContainers(ctx, store.ListOptions{
+		ServiceIDOrName: store.ServiceIDOrNameOptions{Name: client.CaddyServiceName},
+	})
+	if err != nil {
+		return fmt.Errorf("list caddy containers: %w", err)
+	}
+	machines := Machines(resp.Machines, records)
+
+	results := make([]bool, len(machines))
+	var wg sync.WaitGroup
+	for i, m := range machines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = f.check(ctx, m) == nil
+		}()
+	}
+	wg.Wait()
+
+	ips := f.observe(machines, results)
+	if len(ips) == 0 || slices.Equal(ips, f.published) {
+		return nil
+	}
+	req := &pb.CreateDomainRecordsRequest{
+		Records: []*pb.DNSRecord{
+			{
+				Name:   "*",
+				Type:   pb.DNSRecord_A,
+				Values: ips,
+			},
+		},
+	}
+	if _, err = f.cluster.CreateDomainRecords(ctx, req); err != nil {
+		return fmt.Errorf("create cluster domain records: %w", err)
+	}
+	f.log.Info("Updated DNS records of the cluster domain to point to the healthy ingress machines.", "ips", ips)
+	f.published = ips
+	return nil
+}
+
+// observe records the results of the checks of the machines and returns the public IPs of the healthy machines.
+// The machines that are no longer ingress machines are forgotten.
+func (f *DNSFailover) observe(machines []Machine, results []bool) []string {
+	current := make(map[string]*machineHealth, len(machines))
+	var ips []string
+	for i, m := range machines {
+		h, ok := f.health[m.ID]
+		if !ok {
+			h = &machineHealth{}
+		}
+		wasHealthy := h.healthy()
+		h.record(results[i])
+		current[m.ID] = h
+
+		if h.healthy() {
+			ips = append(ips, m.PublicIP.String())
+		}
+		if ok && wasHealthy != h.healthy() {
+			if h.healthy() {
+				f.log.Info("Ingress machine is healthy again.", "machine", m.Name, "ip", m.PublicIP)
+			} else {
+				f.log.Warn("Ingress machine is unhealthy, withdrawing its DNS records.",
+					"machine", m.Name, "ip", m.PublicIP, "failures", h.failures)
+			}
+		}
+	}
+	f.health = current
+	return ips
+}
+
+// check verifies that the Caddy container on the machine responds with the machine ID on its public IP.
+func (f *DNSFailover) check(ctx context.Context, m Machine) error {
+	url := fmt.Sprintf("http://%s%s", m.PublicIP, caddyconfig.VerifyPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP response status code: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return fmt.Errorf("read HTTP response body: %w", err)
+	}
+	if string(body) != m.ID {
+		return errors.New("unexpected HTTP response body")
+	}
+	return nil
+}
+
+// Machines returns the ingress machines: the machines that have a public IP and run a Caddy container from
+// the given container records. The machines are sorted by public IP. The membership state of the machines is
+// ignored as the health checks of their public endpoints determine whether they receive the traffic.
+func Machines(members []*pb.MachineMember, records []store.ContainerRecord) []Machine {
+	var machines []Machine
+	for _, m := range members {
+		if m.Machine.PublicIp == nil {
+			continue
+		}
+		ip, err := m.Machine.PublicIp.ToAddr()
+		if err != nil {
+			continue
+		}
+		runsCaddy := slices.ContainsFunc(records, func(r store.ContainerRecord) bool {
+			return r.MachineID == m.Machine.Id && r.Container.ServiceName() == client.CaddyServiceName
+		})
+		if runsCaddy {
+			machines = append(machines, Machine{ID: m.Machine.Id, Name: m.Machine.Name, PublicIP: ip})
+		}
+	}
+	slices.SortFunc(machines, func(a, b Machine) int {
+		return a.PublicIP.Compare(b.PublicIP)
+	})
+	return machines
+}
@@ -0,0 +1,66 @@
+package ingress
+
+import (
+	"log/slog"
+	"net/netip"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSFailover_Observe(t *testing.T) {
+	t.Parallel()
+
+	f := &DNSFailover{health: make(map[string]*machineHealth), log: slog.Default()}
+	m1 := Machine{ID: "m1", Name: "machine-1", PublicIP: netip.MustParseAddr("203.0.113.1")}
+	m2 := Machine{ID: "m2", Name: "machine-2", PublicIP: netip.MustParseAddr("203.0.113.2")}
+	machines := []Machine{m1, m2}
+
+	// A machine that has never passed a check, e.g. behind NAT, is not published.
+	assert.Equal(t, []string{"203.0.113.1"}, f.observe(machines, []bool{true, false}))
+
+	// A healthy machine is withdrawn only after FailureThreshold consecutive failures.
+	for range FailureThreshold - 1 {
+		assert.Equal(t, []string{"203.0.113.1"}, f.observe(machines, []bool{false, false}))
+	}
+	assert.Empty(t, f.observe(machines, []bool{false, false}))
+
+	// A machine is published again as soon as it passes a check.
+	assert.Equal(t, []string{"203.0.113.1", "203.0.113.2"}, f.observe(machines, []bool{true, true}))
+}
+
+func TestMachines(t *testing.T) {
+	t.Parallel()
+
+	member := func(id, publicIP string) *pb.MachineMember {
+		m := &pb.MachineMember{Machine: &pb.MachineInfo{Id: id, Name: id}, State: pb.MachineMember_UP}
+		if publicIP != "" {
+			m.Machine.PublicIp = pb.NewIP(netip.MustParseAddr(publicIP))
+		}
+		return m
+	}
+	caddy := func(machineID string) store.ContainerRecord {
+		var ctr api.ServiceContainer
+		ctr.Config = &container.Config{Labels: map[string]string{api.LabelServiceName: client.CaddyServiceName}}
+		return store.ContainerRecord{MachineID: machineID, Container: ctr}
+	}
+
+	members := []*pb.MachineMember{
+		member("m1", "203.0.113.2"),
+		member("m2", "203.0.113.1"),
+		member("m3", ""),
+		member("m4", "203.0.113.4"),
+	}
+	records := []store.ContainerRecord{caddy("m1"), caddy("m2"), caddy("m3")}
+
+	machines := Machines(members, records)
+	assert.Equal(t, []Machine{
+		{ID: "m2", Name: "m2", PublicIP: netip.MustParseAddr("203.0.113.1")},
+		{ID: "m1", Name: "m1", PublicIP: netip.MustParseAddr("203.0.113.2")},
+	}, machines)
+}
@@ -0,0 +1,117 @@
+package ingress
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// KeepalivedConfigFileName is the name of the keepalived configuration file in the directory shared with
+	// the keepalived container on the machine.
+	KeepalivedConfigFileName = "keepalived.conf"
+	// keepalivedMaxPriority is the VRRP priority of the most preferred machine in the ingress HA policy.
+	keepalivedMaxPriority = 150
+	// keepalivedPriorityStep is the difference between the VRRP priorities of the adjacent machines in the policy.
+	keepalivedPriorityStep = 10
+)
+
+// KeepalivedConfig generates the keepalived configuration for the machine from the ingress HA policy.
+// The machines holding the virtual IP are elected with VRRP by the priority decreasing in the policy order.
+// A machine gives up the virtual IP when its Caddy stops responding on the verification path. The VRRP
+// advertisements are sent unicast to the public and WireGuard endpoint IPs of the other machines in the policy
+// as multicast is often filtered by cloud networks. machines are the cluster machines to look up the peers in.
+// The advertisements from sources other than the peers are ignored by keepalived and should also be dropped
+// by the firewall as VRRP has no authentication.
+func KeepalivedConfig(policy *api.IngressHAPolicy, machineID string, machines []*pb.MachineInfo) (string, error) {
+	if err := policy.Validate(); err != nil {
+		return "", fmt.Errorf("invalid ingress HA policy: %w", err)
+	}
+	idx := slices.Index(policy.Machines, machineID)
+	if idx == -1 {
+		return "", fmt.Errorf("machine '%s' is not in the ingress HA policy", machineID)
+	}
+	priority := max(keepalivedMaxPriority-keepalivedPriorityStep*idx, 1)
+	peers := KeepalivedPeers(policy, machineID, machines)
+
+	var b strings.Builder
+	b.WriteString("# Generated by uncloud from the ingress HA policy. Do not edit.\n")
+	b.WriteString("global_defs {\n")
+	b.WriteString("  script_user root\n")
+	b.WriteString("  enable_script_security\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString("vrrp_script check_caddy {\n")
+	fmt.Fprintf(&b, "  script \"/usr/bin/wget -q -T 2 -O /dev/null http://127.0.0.1%s\"\n", caddyconfig.VerifyPath)
+	b.WriteString("  interval 2\n")
+	b.WriteString("  fall 3\n")
+	b.WriteString("  rise 2\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString("vrrp_instance ingress {\n")
+	// All machines start as backups and the one with the highest priority is elected to hold the virtual IP.
+	b.WriteString("  state BACKUP\n")
+	fmt.Fprintf(&b, "  interface %s\n", policy.Interface)
+	fmt.Fprintf(&b, "  virtual_router_id %d\n", policy.VRRPRouterID())
+	fmt.Fprintf(&b, "  priority %d\n", priority)
+	b.WriteString("  advert_int 1\n")
+	if len(peers) > 0 {
+		// Only accept the advertisements from the unicast peers.
+		b.WriteString("  check_unicast_src\n")
+		b.WriteString("  unicast_peer {\n")
+		for _, ip := range peers {
+			fmt.Fprintf(&b, "    %s\n", ip)
+		}
+		b.WriteString("  }\n")
+	}
+	b.WriteString("  virtual_ipaddress {\n")
+	fmt.Fprintf(&b, "    %s/32 dev %s\n", policy.VirtualIP, policy.Interface)
+	b.WriteString("  }\n")
+	b.WriteString("  track_script {\n")
+	b.WriteString("    check_caddy\n")
+	b.WriteString("  }\n")
+	if policy.NotifyMaster != "" {
+		fmt.Fprintf(&b, "  notify_master %s\n", strconv.Quote(policy.NotifyMaster))
+	}
+	b.WriteString("}\n")
+
+	return b.String(), nil
+}
+
+// KeepalivedPeers returns the IPv4 addresses of the other machines in the ingress HA policy the machine exchanges
+// the VRRP advertisements with.
+func KeepalivedPeers(policy *api.IngressHAPolicy, machineID string, machines []*pb.MachineInfo) []netip.Addr {
+	var peers []netip.Addr
+	for _, m := range machines {
+		if m.Id == machineID || !slices.Contains(policy.Machines, m.Id) {
+			continue
+		}
+		peers = append(peers, machinePeerIPs(m)...)
+	}
+	slices.SortFunc(peers, netip.Addr.Compare)
+	return slices.Compact(peers)
+}
+
+// machinePeerIPs returns the IPv4 addresses of the machine the VRRP advertisements can be sent to.
+func machinePeerIPs(m *pb.MachineInfo) []netip.Addr {
+	var ips []netip.Addr
+	if m.PublicIp != nil {
+		if ip, err := m.PublicIp.ToAddr(); err == nil && ip.Is4() {
+			ips = append(ips, ip)
+		}
+	}
+	if m.Network != nil {
+		for _, ep := range m.Network.Endpoints {
+			if addrPort, err := ep.ToAddrPort(); err == nil && addrPort.Addr().Is4() {
+				ips = append(ips, addrPort.Addr())
+			}
+		}
+	}
+	return ips
+}
@@ -0,0 +1,61 @@
+package ingress
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepalivedConfig(t *testing.T) {
+	t.Parallel()
+
+	machine := func(id, publicIP string, endpoints ...string) *pb.MachineInfo {
+		m := &pb.MachineInfo{Id: id, Name: id, Network: &pb.NetworkConfig{}}
+		if publicIP != "" {
+			m.PublicIp = pb.NewIP(netip.MustParseAddr(publicIP))
+		}
+		for _, ep := range endpoints {
+			m.Network.Endpoints = append(m.Network.Endpoints, pb.NewIPPort(netip.MustParseAddrPort(ep)))
+		}
+		return m
+	}
+	machines := []*pb.MachineInfo{
+		machine("m1", "203.0.113.1", "203.0.113.1:51820", "192.168.1.1:51820"),
+		machine("m2", "", "192.168.1.2:51820", "[2001:db8::2]:51820"),
+		machine("m3", "203.0.113.3", "192.168.1.3:51820"),
+	}
+	policy := &api.IngressHAPolicy{
+		VirtualIP:    netip.MustParseAddr("203.0.113.100"),
+		Interface:    "eth0",
+		Machines:     []string{"m2", "m1"},
+		NotifyMaster: "/etc/keepalived/assign-ip.sh",
+	}
+
+	conf, err := KeepalivedConfig(policy, "m1", machines)
+	require.NoError(t, err)
+	assert.Contains(t, conf, "  interface eth0\n  virtual_router_id 51\n  priority 140\n")
+	// Only the IPv4 addresses of the other policy machines are peers.
+	assert.Contains(t, conf, "  check_unicast_src\n  unicast_peer {\n    192.168.1.2\n  }\n")
+	assert.Contains(t, conf, "    203.0.113.100/32 dev eth0\n")
+	assert.Contains(t, conf, "  notify_master \"/etc/keepalived/assign-ip.sh\"\n")
+	assert.Contains(t, conf, "http://127.0.0.1/.uncloud-verify")
+
+	conf, err = KeepalivedConfig(policy, "m2", machines)
+	require.NoError(t, err)
+	assert.Contains(t, conf, "  priority 150\n")
+	assert.Contains(t, conf, "  unicast_peer {\n    192.168.1.1\n    203.0.113.1\n  }\n")
+
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("192.168.1.1"), netip.MustParseAddr("203.0.113.1")},
+		KeepalivedPeers(policy, "m2", machines))
+
+	_, err = KeepalivedConfig(policy, "m3", machines)
+	assert.Error(t, err)
+
+	policy.Interface = "eth0\n  notify_master \"/bin/sh\""
+	_, err = KeepalivedConfig(policy, "m1", machines)
+	assert.ErrorContains(t, err, "invalid network interface name")
+}
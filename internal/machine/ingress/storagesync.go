@@ -0,0 +1,480 @@
+package ingress
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/client"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	// StorageScanInterval is the interval at which the local Caddy storage is scanned for changes. It's short
+	// as an ACME challenge token written by one Caddy must reach the others before the CA validates it.
+	StorageScanInterval = time.Second
+	// maxStorageItemSize is the maximum size of a file in the Caddy storage that is replicated.
+	maxStorageItemSize = 1 << 20
+)
+
+// syncedStorageDirs are the top-level directories of the Caddy storage that are replicated: the certificates
+// and their private keys, ACME accounts, challenge tokens for solving challenges on any machine, locks for
+// coordinating the certificate issuance, OCSP staples, and the local CA issuing the internal certificates.
+var syncedStorageDirs = []string{"acme", "certificates", "challenge_tokens", "locks", "ocsp", "pki"}
+
+// bundledStorageDirs are the top-level directories of the Caddy storage whose subdirectories are replicated as a whole,
+// e.g. a certificate with its private key and metadata, so that their files always come from the same machine.
+var bundledStorageDirs = []string{"acme", "certificates", "pki"}
+
+// StorageSync replicates the file system storage of the local Caddy through the cluster store so that every
+// ingress machine, i.e. a machine running Caddy, shares the same certificates, ACME accounts, and challenge tokens.
+// Caddy obtains a certificate only if it's not in the storage and serves HTTP challenges for tokens found
+// in the storage, so any machine can serve every domain and complete the challenges initiated by another machine
+// receiving the validation request.
+//
+// The storage locks are replicated as well so that Caddy on other machines waits for the certificate being obtained
+// instead of obtaining it concurrently. As the replication takes up to a few seconds, machines may still obtain
+// the same certificate concurrently. The files of a certificate are replicated together so the most recently
+// obtained certificate wins with its own private key.
+//
+// The items are encrypted with a storage key that is sealed for each ingress machine with its WireGuard public key,
+// so the private keys are only readable by the ingress machines even though the store is replicated to all machines.
+type StorageSync struct {
+	machineID string
+	// privateKey and publicKey are the WireGuard keys of the machine used to open the sealed storage key.
+	privateKey secret.Secret
+	publicKey  secret.Secret
+	// dir is the root directory of the Caddy file system storage on the machine.
+	dir   string
+	store *store.Store
+	// synced are the keys that were present both locally and in the store at the last sync. A synced key missing
+	// on one side has been deleted there, e.g. a challenge token cleaned up after the challenge, so it's deleted on
+	// the other side. Other missing keys are copied from the side that has them.
+	synced map[string]struct{}
+	log    *slog.Logger
+}
+
+func NewStorageSync(
+	machineID string, privateKey, publicKey secret.Secret, dir string, store *store.Store,
+) *StorageSync {
+	return &StorageSync{
+		machineID:  machineID,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		dir:        dir,
+		store:      store,
+		synced:     make(map[string]struct{}),
+		log:        slog.With("component", "caddy-storage-sync"),
+	}
+}
+
+// Run syncs the local Caddy storage with the cluster store on changes to the store and every StorageScanInterval
+// until the context is canceled.
+func (s *StorageSync) Run(ctx context.Context) error {
+	items, changes, err := s.store.SubscribeCaddyStorage(ctx)
+	if err != nil {
+		return fmt.Errorf("subscribe to caddy storage changes: %w", err)
+	}
+	s.log.Info("Subscribed to Caddy storage changes in the cluster to replicate certificates.")
+
+	ticker := time.NewTicker(StorageScanInterval)
+	defer ticker.Stop()
+
+	for {
+		if err = s.sync(ctx, items); err != nil {
+			s.log.Error("Failed to sync Caddy storage with the cluster.", "err", err)
+		}
+
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return errors.New("caddy storage subscription failed")
+			}
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if items, err = s.store.ListCaddyStorageItems(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			s.log.Error("Failed to list Caddy storage items in the cluster.", "err", err)
+			items = nil
+		}
+	}
+}
+
+// sync applies the changes between the local storage and the items in the store to the other side. Only ingress
+// machines are synced.
+func (s *StorageSync) sync(ctx context.Context, items map[string]store.CaddyStorageItem) error {
+	if items == nil {
+		return nil
+	}
+	key, err := s.storageKey(ctx)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		// Not an ingress machine or the storage key hasn't been sealed for this machine yet.
+		return nil
+	}
+
+	files, err := scanStorage(s.dir)
+	if err != nil {
+		return fmt.Errorf("scan caddy storage '%s': %w", s.dir, err)
+	}
+	local := storageUnits(files)
+
+	// Items that can't be decrypted, e.g. stored with a storage key replaced concurrently, are treated as missing
+	// so that they're replaced with the local files if any.
+	opened := make(map[string]map[string][]byte, len(items))
+	for k, item := range items {
+		if opened[k], err = openStorageItem(key, item); err != nil {
+			s.log.Warn("Failed to decrypt Caddy storage item, ignoring it.", "key", k, "err", err)
+			delete(items, k)
+			delete(s.synced, k)
+		}
+	}
+
+	var errs []error
+	for _, a := range planStorageSync(local, items, s.synced) {
+		if err = s.apply(ctx, a, key, files, items[a.key], opened[a.key]); err != nil {
+			errs = append(errs, fmt.Errorf("%s '%s': %w", a.op, a.key, err))
+			continue
+		}
+		switch a.op {
+		case storageUpload, storageDownload:
+			s.synced[a.key] = struct{}{}
+		default:
+			delete(s.synced, a.key)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// storageKey returns the encryption key of the Caddy storage or nil if this machine isn't an ingress machine or
+// the key hasn't been sealed for it yet. The first ingress machine generates the key and every ingress machine
+// seals it for the other ingress machines that don't have it.
+func (s *StorageSync) storageKey(ctx context.Context) (*[32]byte, error) {
+	records, err := s.store.ListContainers(ctx, store.ListOptions{
+		ServiceIDOrName: store.ServiceIDOrNameOptions{Name: client.CaddyServiceName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list caddy containers: %w", err)
+	}
+	ingress := make(map[string]struct{})
+	for _, r := range records {
+		ingress[r.MachineID] = struct{}{}
+	}
+	if _, ok := ingress[s.machineID]; !ok {
+		return nil, nil
+	}
+
+	keys, err := s.store.GetCaddyStorageKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get caddy storage keys: %w", err)
+	}
+	var key *[32]byte
+	if own, ok := keys[s.machineID]; ok && bytes.Equal(own.PublicKey, s.publicKey) {
+		opened, ok := box.OpenAnonymous(nil, own.Sealed, (*[32]byte)(s.publicKey), (*[32]byte)(s.privateKey))
+		if !ok || len(opened) != 32 {
+			return nil, errors.New("open caddy storage key sealed for this machine")
+		}
+		key = (*[32]byte)(opened)
+	} else if len(keys) == 0 {
+		key = new([32]byte)
+		if _, err = rand.Read(key[:]); err != nil {
+			return nil, fmt.Errorf("generate caddy storage key: %w", err)
+		}
+		keys = make(map[string]store.CaddyStorageKey)
+	} else {
+		return nil, nil
+	}
+
+	machines, err := s.store.ListMachines(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+	changed := false
+	for _, m := range machines {
+		pub := m.Network.GetPublicKey()
+		if _, ok := ingress[m.Id]; !ok || len(pub) != 32 || bytes.Equal(keys[m.Id].PublicKey, pub) {
+			continue
+		}
+		sealed, err := box.SealAnonymous(nil, key[:], (*[32]byte)(pub), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("seal caddy storage key for machine '%s': %w", m.Name, err)
+		}
+		keys[m.Id] = store.CaddyStorageKey{PublicKey: pub, Sealed: sealed}
+		changed = true
+	}
+	if changed {
+		if err = s.store.PutCaddyStorageKeys(ctx, keys); err != nil {
+			return nil, fmt.Errorf("put caddy storage keys: %w", err)
+		}
+	}
+	return key, nil
+}
+
+// apply performs the action on the local storage or the store. files are the modification times of the local files
+// and item is the store item with its opened files for the action key.
+func (s *StorageSync) apply(
+	ctx context.Context,
+	a storageAction,
+	key *[32]byte,
+	files map[string]time.Time,
+	item store.CaddyStorageItem,
+	opened map[string][]byte,
+) error {
+	// The keys come from other machines so make sure they don't point outside the storage directory.
+	if !filepath.IsLocal(filepath.FromSlash(a.key)) {
+		return errors.New("key is not a local path")
+	}
+	dir := filepath.Join(s.dir, filepath.FromSlash(a.key))
+	if !isBundledStorageKey(a.key) {
+		dir = filepath.Dir(dir)
+	}
+
+	switch a.op {
+	case storageUpload:
+		upload := store.CaddyStorageItem{
+			Files:     make(map[string][]byte),
+			MachineID: s.machineID,
+		}
+		for fileKey, modified := range files {
+			if storageUnitKey(fileKey) != a.key {
+				continue
+			}
+			value, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(fileKey)))
+			if err != nil {
+				return err
+			}
+			sealed, err := sealStorageFile(key, value)
+			if err != nil {
+				return err
+			}
+			upload.Files[path.Base(fileKey)] = sealed
+			if modified.After(upload.Modified) {
+				upload.Modified = modified
+			}
+		}
+		return s.store.PutCaddyStorageItem(ctx, a.key, upload)
+	case storageDownload:
+		// Caddy creates the storage files and directories accessible only by the owner.
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+		for name, value := range opened {
+			if !filepath.IsLocal(name) || filepath.Base(name) != name {
+				return fmt.Errorf("invalid file name '%s'", name)
+			}
+			// Write to a temporary file and rename it so that Caddy never reads a partially written file.
+			filePath := filepath.Join(dir, name)
+			tmp := filePath + ".uncloud-sync"
+			if err := os.WriteFile(tmp, value, 0o600); err != nil {
+				return err
+			}
+			// Preserve the modification time to tell it apart from the local changes.
+			if err := os.Chtimes(tmp, item.Modified, item.Modified); err != nil {
+				_ = os.Remove(tmp)
+				return err
+			}
+			if err := os.Rename(tmp, filePath); err != nil {
+				return err
+			}
+		}
+		// Remove the local files of the directory that aren't in the item.
+		for fileKey := range files {
+			if storageUnitKey(fileKey) == a.key && isBundledStorageKey(a.key) {
+				if _, ok := opened[path.Base(fileKey)]; !ok {
+					_ = os.Remove(filepath.Join(s.dir, filepath.FromSlash(fileKey)))
+				}
+			}
+		}
+		return nil
+	case storageDeleteLocal:
+		for fileKey := range files {
+			if storageUnitKey(fileKey) != a.key {
+				continue
+			}
+			err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(fileKey)))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		return nil
+	case storageDeleteRemote:
+		return s.store.DeleteCaddyStorageItem(ctx, a.key)
+	}
+	return fmt.Errorf("unknown action: %s", a.op)
+}
+
+const (
+	storageUpload       = "upload"
+	storageDownload     = "download"
+	storageDeleteLocal  = "delete local"
+	storageDeleteRemote = "delete remote"
+)
+
+type storageAction struct {
+	op  string
+	key string
+}
+
+// planStorageSync returns the actions that make the local storage and the store items consistent. local are
+// the modification times of the local files by their keys and synced are the keys present on both sides at
+// the last sync. A file modified on both sides is resolved in favour of the most recently modified one.
+func planStorageSync(
+	local map[string]time.Time, items map[string]store.CaddyStorageItem, synced map[string]struct{},
+) []storageAction {
+	var actions []storageAction
+	for key, modified := range local {
+		item, ok := items[key]
+		switch {
+		case !ok:
+			if _, wasSynced := synced[key]; wasSynced {
+				actions = append(actions, storageAction{op: storageDeleteLocal, key: key})
+			} else {
+				actions = append(actions, storageAction{op: storageUpload, key: key})
+			}
+		case modified.After(item.Modified):
+			actions = append(actions, storageAction{op: storageUpload, key: key})
+		case modified.Before(item.Modified):
+			actions = append(actions, storageAction{op: storageDownload, key: key})
+		default:
+			// Mark the keys that are already in sync, e.g. after a restart.
+			synced[key] = struct{}{}
+		}
+	}
+	for key := range items {
+		if _, ok := local[key]; ok || !isSyncedStorageKey(key) {
+			continue
+		}
+		if _, wasSynced := synced[key]; wasSynced {
+			actions = append(actions, storageAction{op: storageDeleteRemote, key: key})
+		} else {
+			actions = append(actions, storageAction{op: storageDownload, key: key})
+		}
+	}
+	return actions
+}
+
+// storageUnitKey returns the key of the store item the file belongs to: the directory of a file
+// in the bundled directories or the file itself.
+func storageUnitKey(fileKey string) string {
+	dir, _, _ := strings.Cut(fileKey, "/")
+	if slices.Contains(bundledStorageDirs, dir) {
+		return path.Dir(fileKey)
+	}
+	return fileKey
+}
+
+// isBundledStorageKey returns true if the store item key is a directory in the bundled directories.
+func isBundledStorageKey(key string) bool {
+	dir, _, _ := strings.Cut(key, "/")
+	return slices.Contains(bundledStorageDirs, dir)
+}
+
+// storageUnits returns the latest modification times of the files by the keys of the store items they belong to.
+func storageUnits(files map[string]time.Time) map[string]time.Time {
+	units := make(map[string]time.Time, len(files))
+	for fileKey, modified := range files {
+		key := storageUnitKey(fileKey)
+		if modified.After(units[key]) {
+			units[key] = modified
+		}
+	}
+	return units
+}
+
+// sealStorageFile encrypts and authenticates the file content with the storage key.
+func sealStorageFile(key *[32]byte, value []byte) ([]byte, error) {
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return secretbox.Seal(nonce[:], value, &nonce, key), nil
+}
+
+// openStorageItem decrypts the files of the store item with the storage key.
+func openStorageItem(key *[32]byte, item store.CaddyStorageItem) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(item.Files))
+	for name, sealed := range item.Files {
+		if len(sealed) < 24 {
+			return nil, fmt.Errorf("invalid encrypted file '%s'", name)
+		}
+		nonce := (*[24]byte)(sealed[:24])
+		value, ok := secretbox.Open(nil, sealed[24:], nonce, key)
+		if !ok {
+			return nil, fmt.Errorf("decrypt file '%s'", name)
+		}
+		files[name] = value
+	}
+	return files, nil
+}
+
+// isSyncedStorageKey returns true if the key is in one of the replicated directories of the Caddy storage.
+func isSyncedStorageKey(key string) bool {
+	dir, _, ok := strings.Cut(key, "/")
+	return ok && slices.Contains(syncedStorageDirs, dir)
+}
+
+// scanStorage returns the modification times of the replicated files in the Caddy storage directory by their
+// slash-separated paths relative to the directory.
+func scanStorage(dir string) (map[string]time.Time, error) {
+	files := make(map[string]time.Time)
+	for _, d := range syncedStorageDirs {
+		root := filepath.Join(dir, d)
+		err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if !entry.Type().IsRegular() || strings.HasSuffix(p, ".uncloud-sync") {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if info.Size() > maxStorageItemSize {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			files[path.Clean(filepath.ToSlash(rel))] = modTime(info)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// modTime returns the modification time of the file truncated to milliseconds so that it compares equal to the time
+// set on another machine regardless of the timestamp precision of the file systems.
+func modTime(info fs.FileInfo) time.Time {
+	return info.ModTime().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,113 @@
+package ingress
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanStorageSync(t *testing.T) {
+	t.Parallel()
+
+	t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	local := map[string]time.Time{
+		"certificates/acme/a.com/a.com.crt":     t1,
+		"certificates/acme/b.com/b.com.crt":     t2,
+		"certificates/acme/c.com/c.com.crt":     t1,
+		"challenge_tokens/acme/new.com.json":    t1,
+		"challenge_tokens/acme/solved.com.json": t1,
+	}
+	items := map[string]store.CaddyStorageItem{
+		"certificates/acme/a.com/a.com.crt": {Modified: t1},
+		"certificates/acme/b.com/b.com.crt": {Modified: t1},
+		"certificates/acme/c.com/c.com.crt": {Modified: t2},
+		"certificates/acme/d.com/d.com.crt": {Modified: t1},
+		"pki/authorities/local/root.crt":    {Modified: t1},
+		"locks/issue_cert_e.com.lock":       {Modified: t1},
+		"unknown/e.com":                     {Modified: t1},
+	}
+	synced := map[string]struct{}{
+		"challenge_tokens/acme/solved.com.json": {},
+		"pki/authorities/local/root.crt":        {},
+	}
+
+	actions := planStorageSync(local, items, synced)
+	assert.ElementsMatch(t, []storageAction{
+		{op: storageUpload, key: "certificates/acme/b.com/b.com.crt"},
+		{op: storageDownload, key: "certificates/acme/c.com/c.com.crt"},
+		{op: storageDownload, key: "certificates/acme/d.com/d.com.crt"},
+		{op: storageUpload, key: "challenge_tokens/acme/new.com.json"},
+		// Locked by another machine obtaining the certificate.
+		{op: storageDownload, key: "locks/issue_cert_e.com.lock"},
+		// Deleted in the cluster after another machine solved the challenge.
+		{op: storageDeleteLocal, key: "challenge_tokens/acme/solved.com.json"},
+		// Deleted locally.
+		{op: storageDeleteRemote, key: "pki/authorities/local/root.crt"},
+	}, actions)
+	assert.Contains(t, synced, "certificates/acme/a.com/a.com.crt", "unchanged key must be marked as synced")
+}
+
+func TestScanStorage(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(key string, size int) {
+		path := filepath.Join(dir, filepath.FromSlash(key))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0o600))
+	}
+	write("certificates/acme/a.com/a.com.crt", 10)
+	write("certificates/acme/a.com/a.com.crt.uncloud-sync", 10)
+	write("certificates/acme/big.com/big.com.crt", maxStorageItemSize+1)
+	write("locks/issue_cert_a.com.lock", 10)
+	write("instance.uuid", 10)
+
+	files, err := scanStorage(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Contains(t, files, "certificates/acme/a.com/a.com.crt")
+	assert.Contains(t, files, "locks/issue_cert_a.com.lock")
+}
+
+func TestStorageUnits(t *testing.T) {
+	t.Parallel()
+
+	t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	files := map[string]time.Time{
+		"certificates/acme/a.com/a.com.crt":  t2,
+		"certificates/acme/a.com/a.com.key":  t1,
+		"certificates/acme/a.com/a.com.json": t1,
+		"challenge_tokens/acme/a.com.json":   t1,
+		"locks/issue_cert_a.com.lock":        t2,
+	}
+
+	assert.Equal(t, map[string]time.Time{
+		"certificates/acme/a.com":          t2,
+		"challenge_tokens/acme/a.com.json": t1,
+		"locks/issue_cert_a.com.lock":      t2,
+	}, storageUnits(files))
+}
+
+func TestSealOpenStorageItem(t *testing.T) {
+	t.Parallel()
+
+	key := &[32]byte{1, 2, 3}
+	sealed, err := sealStorageFile(key, []byte("private key"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "private key")
+
+	item := store.CaddyStorageItem{Files: map[string][]byte{"a.com.key": sealed}}
+	files, err := openStorageItem(key, item)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"a.com.key": []byte("private key")}, files)
+
+	_, err = openStorageItem(&[32]byte{4, 5, 6}, item)
+	assert.Error(t, err, "opening with another key must fail")
+}
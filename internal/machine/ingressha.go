@@ -0,0 +1,107 @@
+package machine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/firewall"
+	"github.com/psviderski/uncloud/internal/machine/ingress"
+	"github.com/psviderski/uncloud/pkg/client"
+)
+
+const (
+	// ingressHAReconcileInterval is the interval at which the keepalived configuration is reconciled with
+	// the cluster machines in addition to reconciling it on cluster settings changes.
+	ingressHAReconcileInterval = 30 * time.Second
+	// keepalivedDirName is the name of the directory in the machine data directory that is shared with
+	// the keepalived container.
+	keepalivedDirName = "keepalived"
+)
+
+// runIngressHA keeps the keepalived configuration of the machine in sync with the ingress HA policy from
+// the cluster settings. The keepalived service deployed by 'uc ingress ha enable' reads the configuration.
+func (cc *clusterController) runIngressHA(ctx context.Context) error {
+	settingsChanges := cc.settings.Subscribe()
+	ticker := time.NewTicker(ingressHAReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := cc.reconcileIngressHA(ctx); err != nil {
+			slog.Error("Failed to configure keepalived for ingress HA.", "err", err)
+		}
+
+		select {
+		case <-settingsChanges:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reconcileIngressHA writes the keepalived configuration if the ingress HA policy includes the machine and removes
+// it otherwise. The local keepalived container is signalled to reload the configuration when it changes.
+func (cc *clusterController) reconcileIngressHA(ctx context.Context) error {
+	path := filepath.Join(cc.dataDir, keepalivedDirName, ingress.KeepalivedConfigFileName)
+	policy := cc.settings.Get().IngressHA
+	if policy == nil || !slices.Contains(policy.Machines, cc.state.ID) {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove keepalived config: %w", err)
+		}
+		if err := firewall.ConfigureVRRPFilter(false, nil); err != nil {
+			return fmt.Errorf("remove VRRP firewall rules: %w", err)
+		}
+		return nil
+	}
+
+	machines, err := cc.store.ListMachines(ctx)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	conf, err := ingress.KeepalivedConfig(policy, cc.state.ID, machines)
+	if err != nil {
+		return err
+	}
+	// Configure the firewall before the keepalived config so that it never accepts advertisements from a machine
+	// removed from the policy.
+	peers := ingress.KeepalivedPeers(policy, cc.state.ID, machines)
+	if err = firewall.ConfigureVRRPFilter(true, peers); err != nil {
+		return fmt.Errorf("configure VRRP firewall rules: %w", err)
+	}
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, []byte(conf)) {
+		return nil
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create keepalived config directory: %w", err)
+	}
+	if err = os.WriteFile(path, []byte(conf), 0o644); err != nil {
+		return fmt.Errorf("write keepalived config: %w", err)
+	}
+	slog.Info("Updated keepalived config for ingress HA.", "path", path, "vip", policy.VirtualIP)
+
+	containers, err := cc.dockerService.ListServiceContainers(
+		ctx, client.KeepalivedServiceName, container.ListOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("list keepalived containers: %w", err)
+	}
+	for _, ctr := range containers {
+		if !ctr.State.Running {
+			continue
+		}
+		// SIGHUP makes keepalived reload the configuration without giving up the virtual IP.
+		if err = cc.dockerService.Client.ContainerKill(ctx, ctr.ID, "HUP"); err != nil {
+			return fmt.Errorf("reload keepalived container '%s': %w", ctr.ID, err)
+		}
+	}
+	return nil
+}
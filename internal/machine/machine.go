@@ -9,27 +9,41 @@ import (
 	"net"
 	"net/netip"
 	"os"
-	"os/user"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/client"
-	"github.com/docker/go-connections/sockets"
 	"github.com/psviderski/uncloud/internal/corrosion"
 	"github.com/psviderski/uncloud/internal/docker"
 	"github.com/psviderski/uncloud/internal/fs"
+	_ "github.com/psviderski/uncloud/internal/machine/api/compression"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	apiproxy "github.com/psviderski/uncloud/internal/machine/api/proxy"
+	"github.com/psviderski/uncloud/internal/machine/api/rbac"
+	"github.com/psviderski/uncloud/internal/machine/audit"
+	"github.com/psviderski/uncloud/internal/machine/autoupdate"
+	"github.com/psviderski/uncloud/internal/machine/backup"
 	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/internal/machine/chaos"
 	"github.com/psviderski/uncloud/internal/machine/cluster"
 	"github.com/psviderski/uncloud/internal/machine/constants"
 	"github.com/psviderski/uncloud/internal/machine/corroservice"
 	"github.com/psviderski/uncloud/internal/machine/dns"
 	machinedocker "github.com/psviderski/uncloud/internal/machine/docker"
+	"github.com/psviderski/uncloud/internal/machine/httpapi"
+	"github.com/psviderski/uncloud/internal/machine/ingress"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/postgres"
+	"github.com/psviderski/uncloud/internal/machine/scaling"
+	"github.com/psviderski/uncloud/internal/machine/settings"
 	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/internal/machine/uptime"
+	"github.com/psviderski/uncloud/internal/machine/volumebackend"
+	"github.com/psviderski/uncloud/internal/version"
 	"github.com/psviderski/unregistry"
 	"github.com/siderolabs/grpc-proxy/proxy"
 	"golang.org/x/sync/errgroup"
@@ -37,12 +51,17 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	DefaultMachineSockPath = "/run/uncloud/machine.sock"
 	DefaultUncloudSockPath = "/run/uncloud/uncloud.sock"
 	DefaultSockGroup       = "uncloud"
+	// DefaultReadOnlySockPath is the default path to the API proxy socket that only allows the read-only machine API
+	// methods. Its group is DefaultReadOnlySockGroup so that users in that group can't access the other sockets.
+	DefaultReadOnlySockPath  = "/run/uncloud-readonly/uncloud.sock"
+	DefaultReadOnlySockGroup = "uncloud-readonly"
 	// DefaultCaddyAdminSockPath is the default path to the Caddy admin socket for validating the generated Caddy
 	// reverse proxy configuration.
 	DefaultCaddyAdminSockPath = "/run/uncloud/caddy/admin.sock"
@@ -53,6 +72,8 @@ type Config struct {
 	DataDir         string
 	MachineSockPath string
 	UncloudSockPath string
+	// ReadOnlySockPath is the path to the API proxy socket for read-only connections, e.g. from monitoring hosts.
+	ReadOnlySockPath string
 
 	CorrosionDir           string
 	CorrosionAPIListenAddr netip.AddrPort
@@ -72,6 +93,48 @@ type Config struct {
 	CaddyConfigDir string
 	// DNSUpstreams specifies the upstream DNS servers for the embedded internal DNS server.
 	DNSUpstreams []netip.AddrPort
+
+	// GRPCMaxRecvMsgSize is the maximum size in bytes of a message the machine API servers and proxies receive.
+	// Zero means the gRPC default of 4 MiB.
+	GRPCMaxRecvMsgSize int
+	// GRPCMaxSendMsgSize is the maximum size in bytes of a message the machine API servers and proxies send.
+	// Zero means the gRPC default.
+	GRPCMaxSendMsgSize int
+
+	// HTTPAPIAddr is the address the read-only HTTP JSON API listens on, e.g. 127.0.0.1:51010. The API is disabled
+	// if empty.
+	HTTPAPIAddr string
+	// HTTPAPIToken is the bearer token clients must provide to access the HTTP API. Required if HTTPAPIAddr is set.
+	HTTPAPIToken string
+
+	// Chaos enables the chaos mode that randomly injects faults into the machine. Only for test clusters.
+	// Nil disables the chaos mode.
+	Chaos *chaos.Config
+}
+
+// grpcServerOptions returns the options for the machine API gRPC servers. The compressors supported by the servers
+// are registered by the compression package.
+func (c *Config) grpcServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if c.GRPCMaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(c.GRPCMaxRecvMsgSize))
+	}
+	if c.GRPCMaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(c.GRPCMaxSendMsgSize))
+	}
+	return opts
+}
+
+// grpcProxyCallOptions returns the options for the calls the machine API proxies make to the backend servers.
+func (c *Config) grpcProxyCallOptions() []grpc.CallOption {
+	var opts []grpc.CallOption
+	if c.GRPCMaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxCallRecvMsgSize(c.GRPCMaxRecvMsgSize))
+	}
+	if c.GRPCMaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxCallSendMsgSize(c.GRPCMaxSendMsgSize))
+	}
+	return opts
 }
 
 // SetDefaults returns a new Config with default values set where not provided.
@@ -88,6 +151,9 @@ func (c *Config) SetDefaults() (*Config, error) {
 	if cfg.UncloudSockPath == "" {
 		cfg.UncloudSockPath = DefaultUncloudSockPath
 	}
+	if cfg.ReadOnlySockPath == "" {
+		cfg.ReadOnlySockPath = DefaultReadOnlySockPath
+	}
 
 	if cfg.DockerClient == nil {
 		cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
@@ -182,11 +248,18 @@ type Machine struct {
 	resetting bool
 	// stop cancels the Run method context to stop the machine gracefully.
 	stop func()
+	// startedAt is the time when the machine daemon was started.
+	startedAt time.Time
 
 	clusterCtrl *clusterController
 	// store is the cluster store backed by a distributed Corrosion database.
-	store   *store.Store
-	cluster *cluster.Cluster
+	store *store.Store
+	// settings keeps the cluster-wide settings up to date with the cluster store when the machine is
+	// a cluster member.
+	settings *settings.Watcher
+	cluster  *cluster.Cluster
+	// chaos injects random faults into the machine if the chaos mode is enabled. Nil otherwise.
+	chaos *chaos.Monkey
 	// dockerService provides high-level operations for managing Docker containers.
 	dockerService *machinedocker.Service
 	dockerServer  *machinedocker.Server
@@ -199,6 +272,9 @@ type Machine struct {
 	// It proxies requests to the local or remote machine API servers depending on the request targets
 	// and aggregates responses.
 	localProxyServer *grpc.Server
+	// readOnlyProxyServer is the gRPC proxy server listening on the read-only Unix socket. It denies the requests
+	// to the machine API methods not allowed for the read-only role before proxying them.
+	readOnlyProxyServer *grpc.Server
 
 	// mu protects the Machine from concurrent reads and writes.
 	mu sync.RWMutex
@@ -242,6 +318,11 @@ func NewMachine(config *Config) (*Machine, error) {
 		return nil, fmt.Errorf("create corrosion API client: %w", err)
 	}
 	corroStore := store.New(corro)
+	var monkey *chaos.Monkey
+	if config.Chaos != nil {
+		monkey = chaos.NewMonkey(*config.Chaos)
+		corroStore.SetChangeDelay(monkey.StoreDelay)
+	}
 	corroAdmin, err := corrosion.NewAdminClient(config.CorrosionAdminSockPath)
 	if err != nil {
 		return nil, fmt.Errorf("create corrosion admin client: %w", err)
@@ -258,26 +339,39 @@ func NewMachine(config *Config) (*Machine, error) {
 	dockerService := machinedocker.NewService(config.DockerClient, db)
 
 	// Init a local gRPC proxy server that proxies requests to the local or remote machine API servers.
-	proxyDirector := apiproxy.NewDirector(config.MachineSockPath, constants.MachineAPIPort)
-	localProxyServer := grpc.NewServer(
+	proxyDirector := apiproxy.NewDirector(
+		config.MachineSockPath, constants.MachineAPIPort, config.grpcProxyCallOptions()...)
+	localProxyServer := grpc.NewServer(append([]grpc.ServerOption{
 		grpc.ForceServerCodecV2(proxy.Codec()),
 		grpc.UnknownServiceHandler(
 			proxy.TransparentHandler(proxyDirector.Director),
 		),
-	)
+	}, config.grpcServerOptions()...)...)
+	readOnlyProxyServer := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ForceServerCodecV2(proxy.Codec()),
+		grpc.UnknownServiceHandler(
+			proxy.TransparentHandler(rbac.Director(rbac.RoleReadOnly, proxyDirector.Director)),
+		),
+	}, config.grpcServerOptions()...)...)
 
 	m := &Machine{
-		config:           *config,
-		state:            state,
-		started:          make(chan struct{}),
-		initialised:      make(chan struct{}, 1),
-		networkReady:     make(chan struct{}),
-		store:            corroStore,
-		cluster:          c,
-		dockerService:    dockerService,
-		localProxyServer: localProxyServer,
-		proxyDirector:    proxyDirector,
-	}
+		config:              *config,
+		state:               state,
+		startedAt:           time.Now(),
+		started:             make(chan struct{}),
+		initialised:         make(chan struct{}, 1),
+		networkReady:        make(chan struct{}),
+		store:               corroStore,
+		settings:            settings.NewWatcher(corroStore),
+		cluster:             c,
+		chaos:               monkey,
+		dockerService:       dockerService,
+		localProxyServer:    localProxyServer,
+		readOnlyProxyServer: readOnlyProxyServer,
+		proxyDirector:       proxyDirector,
+	}
+
+	useClusterProxy(m.settings)
 
 	// Machine IP will only be available after the machine is initialised as a cluster member so wrap it in a function.
 	internalDNSIP := func() netip.Addr {
@@ -285,9 +379,18 @@ func NewMachine(config *Config) (*Machine, error) {
 	}
 	m.dockerServer = machinedocker.NewServer(dockerService, db, internalDNSIP,
 		machinedocker.WithNetworkReady(m.IsNetworkReady),
-		machinedocker.WithWaitForNetworkReady(m.WaitForNetworkReady))
+		machinedocker.WithWaitForNetworkReady(m.WaitForNetworkReady),
+		machinedocker.WithSettings(m.settings.Get),
+		machinedocker.WithMachine(func() (string, string) {
+			m.state.mu.RLock()
+			defer m.state.mu.RUnlock()
+			return m.state.ID, m.state.Name
+		}),
+		machinedocker.WithVolumeManager(volumebackend.NewManager(filepath.Join(config.DataDir, "snapshots"))),
+		machinedocker.WithExecRecordings(audit.NewStore(filepath.Join(config.DataDir, "audit", "exec"))),
+		machinedocker.WithContainerAdmission(m.admitContainer))
 	caddyServer := caddyconfig.NewServer(caddyconfig.NewService(config.CaddyConfigDir))
-	m.localMachineServer = newGRPCServer(m, c, m.dockerServer, caddyServer)
+	m.localMachineServer = newGRPCServer(m, c, m.dockerServer, caddyServer, config.grpcServerOptions()...)
 
 	if m.Initialised() {
 		m.initialised <- struct{}{}
@@ -296,8 +399,10 @@ func NewMachine(config *Config) (*Machine, error) {
 	return m, nil
 }
 
-func newGRPCServer(m pb.MachineServer, c pb.ClusterServer, d pb.DockerServer, caddy pb.CaddyServer) *grpc.Server {
-	s := grpc.NewServer()
+func newGRPCServer(
+	m pb.MachineServer, c pb.ClusterServer, d pb.DockerServer, caddy pb.CaddyServer, opts ...grpc.ServerOption,
+) *grpc.Server {
+	s := grpc.NewServer(opts...)
 	pb.RegisterMachineServer(s, m)
 	pb.RegisterClusterServer(s, c)
 	pb.RegisterDockerServer(s, d)
@@ -356,7 +461,7 @@ func (m *Machine) Run(ctx context.Context) error {
 	errGroup, ctx := errgroup.WithContext(ctx)
 
 	// Start the local machine API server.
-	machineListener, err := listenUnixSocket(m.config.MachineSockPath)
+	machineListener, err := listenUnixSocket(m.config.MachineSockPath, DefaultSockGroup)
 	if err != nil {
 		return fmt.Errorf("listen machine API unix socket %q: %w", m.config.MachineSockPath, err)
 	}
@@ -369,7 +474,7 @@ func (m *Machine) Run(ctx context.Context) error {
 	})
 
 	// Start the local API proxy server.
-	proxyListener, err := listenUnixSocket(m.config.UncloudSockPath)
+	proxyListener, err := listenUnixSocket(m.config.UncloudSockPath, DefaultSockGroup)
 	if err != nil {
 		return fmt.Errorf("listen API proxy unix socket %q: %w", m.config.UncloudSockPath, err)
 	}
@@ -380,6 +485,33 @@ func (m *Machine) Run(ctx context.Context) error {
 		}
 		return nil
 	})
+
+	// Start the read-only API proxy server.
+	readOnlyListener, err := listenUnixSocket(m.config.ReadOnlySockPath, DefaultReadOnlySockGroup)
+	if err != nil {
+		return fmt.Errorf("listen read-only API proxy unix socket %q: %w", m.config.ReadOnlySockPath, err)
+	}
+	errGroup.Go(func() error {
+		slog.Info("Starting read-only API proxy server.", "path", m.config.ReadOnlySockPath)
+		if err := m.readOnlyProxyServer.Serve(readOnlyListener); err != nil {
+			return fmt.Errorf("read-only API proxy server failed: %w", err)
+		}
+		return nil
+	})
+	// Answer the probes on the WireGuard port until the machine joins a cluster so that the cluster machines
+	// can verify they can reach it before adding it to the cluster.
+	var probes *network.ProbeResponder
+	if !m.Initialised() {
+		if probes, err = network.ListenProbes(network.WireGuardPort); err != nil {
+			slog.Warn("Failed to listen for endpoint probes.", "err", err)
+		}
+	}
+	closeProbes := func() {
+		if probes != nil {
+			_ = probes.Close()
+		}
+	}
+
 	// Signal that the machine is ready.
 	close(m.started)
 
@@ -393,6 +525,8 @@ func (m *Machine) Run(ctx context.Context) error {
 
 		select {
 		case <-m.initialised:
+			// Release the WireGuard port before the network controller configures the WireGuard interface.
+			closeProbes()
 			m.cluster.UpdateMachineID(m.state.ID)
 
 			// Ensure the corrosion config is up to date, including a new gossip address if the machine
@@ -406,12 +540,12 @@ func (m *Machine) Run(ctx context.Context) error {
 			// Update the proxy director's local address to the machine's management IP address, allowing
 			// the proxy to identify which requests should be proxied to the local machine API server.
 			m.proxyDirector.UpdateLocalAddress(m.state.Network.ManagementIP.String())
-			proxyServer := grpc.NewServer(
+			proxyServer := grpc.NewServer(append([]grpc.ServerOption{
 				grpc.ForceServerCodecV2(proxy.Codec()),
 				grpc.UnknownServiceHandler(
 					proxy.TransparentHandler(m.proxyDirector.Director),
 				),
-			)
+			}, m.config.grpcServerOptions()...)...)
 
 			// Create a new caddyconfig controller for managing the Caddy reverse proxy configuration.
 			// It will also serve the current machine ID at /.uncloud-verify to verify Caddy reachability.
@@ -420,12 +554,32 @@ func (m *Machine) Run(ctx context.Context) error {
 				m.config.CaddyConfigDir,
 				DefaultCaddyAdminSockPath,
 				m.store,
+				m.settings,
 			)
 			if err != nil {
 				return fmt.Errorf("create caddyconfig controller: %w", err)
 			}
 
-			dnsResolver := dns.NewClusterResolver(m.store)
+			// Create an uptime checker that probes the published service endpoints if the machine is a leader.
+			uptimeChecker := uptime.NewChecker(m.state.ID, m.store, m.cluster)
+			// Create an auto-update controller that installs OS updates within the configured maintenance window.
+			autoUpdateCtrl := autoupdate.NewController(m.state.ID, m.store, m.cluster, m.dockerService)
+			// Create a backup agent that runs the scheduled backups of the services with containers on this machine.
+			backupAgent := backup.NewAgent(m.state.ID, m.store, m.dockerService)
+			// Create a Postgres agent that fails over the managed Postgres clusters with a standby on this machine.
+			postgresAgent := postgres.NewAgent(m.state.ID, m.store, m.cluster, m.dockerService)
+			// Create a scale scheduler that scales the services with a scale schedule if the machine is the leader.
+			scaleCtrl := scaling.NewController(m.state.ID, m.store, m.cluster,
+				netip.AddrPortFrom(m.state.Network.ManagementIP, constants.MachineAPIPort))
+			// Create a DNS failover that withdraws the cluster domain records of unhealthy ingress machines
+			// if the machine is the leader.
+			dnsFailover := ingress.NewDNSFailover(m.state.ID, m.store, m.cluster)
+			// Create a Caddy storage sync that replicates the certificates and ACME state of the local Caddy
+			// encrypted through the cluster store so that every ingress machine can serve every domain.
+			storageSync := ingress.NewStorageSync(m.state.ID, m.state.Network.PrivateKey, m.state.Network.PublicKey,
+				filepath.Join(m.config.CaddyConfigDir, "caddy"), m.store)
+
+			dnsResolver := dns.NewClusterResolver(m.store, m.settings.Get)
 			dnsServer, err := dns.NewServer(m.IP(), dnsResolver, m.config.DNSUpstreams)
 			if err != nil {
 				return fmt.Errorf("create embedded DNS server: %w", err)
@@ -449,18 +603,35 @@ func (m *Machine) Run(ctx context.Context) error {
 				slog.Warn("Skipping embedded unregistry setup as the containerd socket path is not configured.")
 			}
 
+			var httpAPI *httpapi.Server
+			if m.config.HTTPAPIAddr != "" {
+				// Create a read-only HTTP JSON API exposing the cluster state to dashboards and scripts.
+				httpAPI = httpapi.NewServer(m.config.HTTPAPIAddr, m.config.HTTPAPIToken, m.store, m.cluster)
+			}
+
 			m.mu.Lock()
 			m.clusterCtrl, err = newClusterController(
 				m.state,
 				m.store,
+				m.settings,
+				m.config.DataDir,
 				proxyServer,
 				m.config.CorrosionService,
 				m.dockerService,
 				m.networkReady,
 				caddyconfigCtrl,
+				uptimeChecker,
+				autoUpdateCtrl,
+				backupAgent,
+				postgresAgent,
+				scaleCtrl,
+				dnsFailover,
+				storageSync,
 				dnsServer,
 				dnsResolver,
 				unreg,
+				httpAPI,
+				m.chaos,
 			)
 			m.mu.Unlock()
 			if err != nil {
@@ -474,6 +645,7 @@ func (m *Machine) Run(ctx context.Context) error {
 
 		case <-ctx.Done():
 			// The context was cancelled before the machine was initialised.
+			closeProbes()
 		}
 
 		return nil
@@ -492,6 +664,7 @@ func (m *Machine) Run(ctx context.Context) error {
 		slog.Info("Stopping local API proxy server.")
 		// TODO: implement timeout for graceful shutdown.
 		m.localProxyServer.GracefulStop()
+		m.readOnlyProxyServer.GracefulStop()
 		// Close the proxy director to close all backend connections.
 		m.proxyDirector.Close()
 		slog.Info("Local API proxy server stopped.")
@@ -511,40 +684,6 @@ func (m *Machine) Run(ctx context.Context) error {
 	return errGroup.Wait()
 }
 
-// listenUnixSocket creates a new Unix socket listener with the specified path. The socket file is created with 0660
-// access mode and uncloud group if the group is found, otherwise it falls back to the root group.
-func listenUnixSocket(path string) (net.Listener, error) {
-	gid := 0 // Fall back to the root group if the uncloud group is not found.
-	group, err := user.LookupGroup(DefaultSockGroup)
-	if err != nil {
-		//goland:noinspection GoTypeAssertionOnErrors
-		if _, ok := err.(user.UnknownGroupError); ok {
-			slog.Info(
-				"Specified group not found, using root group for the API socket.",
-				"group", DefaultSockGroup, "path", path,
-			)
-		} else {
-			return nil, fmt.Errorf("lookup %q group ID (GID): %w", DefaultSockGroup, err)
-		}
-	} else {
-		gid, err = strconv.Atoi(group.Gid)
-		if err != nil {
-			return nil, fmt.Errorf("parse %q group ID (GID) %q: %w", DefaultSockGroup, group.Gid, err)
-		}
-	}
-
-	// Ensure the parent directory exists and has the correct group permissions.
-	parent, _ := filepath.Split(path)
-	if err = os.MkdirAll(parent, 0o750); err != nil {
-		return nil, fmt.Errorf("create directory %q: %w", parent, err)
-	}
-	if err = os.Chown(parent, -1, gid); err != nil {
-		return nil, fmt.Errorf("chown directory %q: %w", parent, err)
-	}
-
-	return sockets.NewUnixSocket(path, gid)
-}
-
 func (m *Machine) configureCorrosion() error {
 	if err := corroservice.MkDataDir(m.config.CorrosionDir, m.config.CorrosionUser); err != nil {
 		return fmt.Errorf("create corrosion data directory: %w", err)
@@ -620,7 +759,7 @@ func (m *Machine) cleanup() error {
 }
 
 // CheckPrerequisites verifies if the machine meets all necessary system requirements to participate in the cluster.
-func (m *Machine) CheckPrerequisites(_ context.Context, _ *emptypb.Empty) (*pb.CheckPrerequisitesResponse, error) {
+func (m *Machine) CheckPrerequisites(ctx context.Context, _ *emptypb.Empty) (*pb.CheckPrerequisitesResponse, error) {
 	// Check DNS port (UDP) availability.
 	if err := checkDNSPortAvailable(); err != nil {
 		return &pb.CheckPrerequisitesResponse{
@@ -629,11 +768,55 @@ func (m *Machine) CheckPrerequisites(_ context.Context, _ *emptypb.Empty) (*pb.C
 		}, nil
 	}
 
+	// Check the machine has enough memory and disk space.
+	resources, diskAvailable, err := collectResources(ctx, m.dockerService.Client)
+	if err == nil {
+		err = checkResources(resources, diskAvailable)
+	}
+	if err != nil {
+		return &pb.CheckPrerequisitesResponse{
+			Satisfied: false,
+			Error:     err.Error(),
+		}, nil
+	}
+
 	return &pb.CheckPrerequisitesResponse{
 		Satisfied: true,
 	}, nil
 }
 
+// endpointProbeTimeout is the maximum time to wait for a reply to the probes sent to a machine endpoint.
+const endpointProbeTimeout = 3 * time.Second
+
+// ProbeEndpoints concurrently checks if the WireGuard endpoints of a machine that is about to join the cluster
+// answer the probes sent from this machine. An empty request can be used to check if the machine supports probes.
+func (m *Machine) ProbeEndpoints(
+	ctx context.Context, req *pb.ProbeEndpointsRequest,
+) (*pb.ProbeEndpointsResponse, error) {
+	probes := make([]*pb.EndpointProbe, len(req.Endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range req.Endpoints {
+		addrPort, err := ep.ToAddrPort()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid endpoint: %v", err)
+		}
+
+		probes[i] = &pb.EndpointProbe{Endpoint: ep}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := network.ProbeEndpoint(ctx, addrPort, endpointProbeTimeout); err != nil {
+				probes[i].Error = err.Error()
+				return
+			}
+			probes[i].Reachable = true
+		}()
+	}
+	wg.Wait()
+
+	return &pb.ProbeEndpointsResponse{Probes: probes}, nil
+}
+
 // checkDNSPortAvailable verifies that DNS port 53/udp is available for Uncloud's embedded DNS service.
 func checkDNSPortAvailable() error {
 	addr := &net.UDPAddr{
@@ -673,19 +856,12 @@ func (m *Machine) InitCluster(ctx context.Context, req *pb.InitClusterRequest) (
 			return nil, status.Errorf(codes.Internal, "generate machine name: %v", err)
 		}
 	}
-	// Use the public and all routable IPs as endpoints.
-	ips, err := network.ListRoutableIPs()
+	addrPorts, publicIP, err := detectEndpoints()
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "list routable IPs: %v", err)
-	}
-	publicIP, pubIPErr := network.GetPublicIP()
-	// Ignore the error if failed to get the public IP using API services.
-	if pubIPErr == nil && !slices.Contains(ips, publicIP) {
-		ips = append(ips, publicIP)
+		return nil, status.Error(codes.Internal, err.Error())
 	}
-	endpoints := make([]*pb.IPPort, len(ips))
-	for i, addr := range ips {
-		addrPort := netip.AddrPortFrom(addr, network.WireGuardPort)
+	endpoints := make([]*pb.IPPort, len(addrPorts))
+	for i, addrPort := range addrPorts {
 		endpoints[i] = pb.NewIPPort(addrPort)
 	}
 
@@ -697,10 +873,16 @@ func (m *Machine) InitCluster(ctx context.Context, req *pb.InitClusterRequest) (
 			Endpoints: endpoints,
 			PublicKey: m.state.Network.PublicKey,
 		},
+		Arch: runtime.GOARCH,
+	}
+	if resources, _, err := collectResources(ctx, m.dockerService.Client); err == nil {
+		addReq.Resources = resources
+	} else {
+		slog.Warn("Failed to collect machine resources.", "err", err)
 	}
 	if req.GetPublicIp() != nil {
 		addReq.PublicIp = req.GetPublicIp()
-	} else if req.GetPublicIpAuto() && pubIPErr == nil {
+	} else if req.GetPublicIpAuto() && publicIP.IsValid() {
 		addReq.PublicIp = pb.NewIP(publicIP)
 	}
 
@@ -740,7 +922,7 @@ func (m *Machine) InitCluster(ctx context.Context, req *pb.InitClusterRequest) (
 }
 
 // JoinCluster configures the local machine to join an existing cluster.
-func (m *Machine) JoinCluster(_ context.Context, req *pb.JoinClusterRequest) (*emptypb.Empty, error) {
+func (m *Machine) JoinCluster(ctx context.Context, req *pb.JoinClusterRequest) (*emptypb.Empty, error) {
 	if m.Initialised() {
 		return nil, status.Error(codes.FailedPrecondition, "machine is already configured as a cluster member")
 	}
@@ -783,11 +965,16 @@ func (m *Machine) JoinCluster(_ context.Context, req *pb.JoinClusterRequest) (*e
 		}
 		omSubnet, _ := om.Network.Subnet.ToPrefix()
 		omManageIP, _ := om.Network.ManagementIp.ToAddr()
-		omEndpoints := make([]netip.AddrPort, len(om.Network.Endpoints))
-		for i, ep := range om.Network.Endpoints {
-			addrPort, _ := ep.ToAddrPort()
-			omEndpoints[i] = addrPort
+		dnsEndpoints := make(map[string][]netip.AddrPort, len(om.Network.DnsEndpoints))
+		for _, ep := range om.Network.DnsEndpoints {
+			addrPorts, err := network.ResolveDNSEndpoint(ctx, ep)
+			if err != nil {
+				slog.Warn("Failed to resolve DNS endpoint of machine.", "machine", om.Name, "err", err)
+				continue
+			}
+			dnsEndpoints[ep] = addrPorts
 		}
+		omEndpoints := peerEndpoints(om.Network, dnsEndpoints)
 		peer := network.PeerConfig{
 			Subnet:       &omSubnet,
 			ManagementIP: omManageIP,
@@ -816,30 +1003,76 @@ func (m *Machine) Token(_ context.Context, _ *emptypb.Empty) (*pb.TokenResponse,
 		return nil, status.Error(codes.FailedPrecondition, "public key is not set in machine state")
 	}
 
+	endpoints, publicIP, err := detectEndpoints()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	token := NewToken(m.state.Network.PublicKey, publicIP, endpoints)
+	tokenStr, err := token.String()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.TokenResponse{Token: tokenStr}, nil
+}
+
+// RotateToken generates a new WireGuard key pair for the machine, saves it to the machine state, and returns the new
+// machine token. The keys of a cluster member can't be rotated as other machines rely on them.
+func (m *Machine) RotateToken(ctx context.Context, _ *emptypb.Empty) (*pb.TokenResponse, error) {
+	privKey, pubKey, err := network.NewMachineKeys()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate machine keys: %v", err)
+	}
+
+	m.state.mu.Lock()
+	if m.state.ID != "" {
+		m.state.mu.Unlock()
+		return nil, status.Error(codes.FailedPrecondition, "machine is already a member of a cluster, its keys "+
+			"can't be rotated; reset the machine first if you want to add it to a cluster with a new token")
+	}
+	prevPrivKey, prevPubKey := m.state.Network.PrivateKey, m.state.Network.PublicKey
+	m.state.Network.PrivateKey = privKey
+	m.state.Network.PublicKey = pubKey
+	if err = m.state.Save(); err != nil {
+		m.state.Network.PrivateKey = prevPrivKey
+		m.state.Network.PublicKey = prevPubKey
+		m.state.mu.Unlock()
+		return nil, status.Errorf(codes.Internal, "save machine state: %v", err)
+	}
+	m.state.mu.Unlock()
+	slog.Info("Rotated machine WireGuard key pair.")
+
+	return m.Token(ctx, nil)
+}
+
+// detectEndpoints returns the WireGuard endpoints of the local machine using all its routable IPs and the public IP.
+// The returned public IP is invalid if it failed to be determined.
+func detectEndpoints() ([]netip.AddrPort, netip.Addr, error) {
 	ips, err := network.ListRoutableIPs()
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "list routable IPs: %v", err)
+		return nil, netip.Addr{}, fmt.Errorf("list routable IPs: %w", err)
 	}
 	publicIP, err := network.GetPublicIP()
 	// Ignore the error if failed to get the public IP using API services.
 	if err == nil && !slices.Contains(ips, publicIP) {
 		ips = append(ips, publicIP)
 	}
+
 	endpoints := make([]netip.AddrPort, len(ips))
 	for i, ip := range ips {
 		endpoints[i] = netip.AddrPortFrom(ip, network.WireGuardPort)
 	}
+	return endpoints, publicIP, nil
+}
 
-	token := NewToken(m.state.Network.PublicKey, publicIP, endpoints)
-	tokenStr, err := token.String()
+func (m *Machine) Inspect(ctx context.Context, _ *emptypb.Empty) (*pb.MachineInfo, error) {
+	// The inventory is optional and missing if Docker is not running.
+	resources, _, err := collectResources(ctx, m.dockerService.Client)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		slog.Warn("Failed to collect machine resources.", "err", err)
 	}
 
-	return &pb.TokenResponse{Token: tokenStr}, nil
-}
-
-func (m *Machine) Inspect(_ context.Context, _ *emptypb.Empty) (*pb.MachineInfo, error) {
 	return &pb.MachineInfo{
 		Id:   m.state.ID,
 		Name: m.state.Name,
@@ -848,6 +1081,32 @@ func (m *Machine) Inspect(_ context.Context, _ *emptypb.Empty) (*pb.MachineInfo,
 			ManagementIp: pb.NewIP(m.state.Network.ManagementIP),
 			PublicKey:    m.state.Network.PublicKey,
 		},
+		Arch:      runtime.GOARCH,
+		Resources: resources,
+	}, nil
+}
+
+// BatchInspect returns the machine info, usage, and daemon version as a single message that the proxy can aggregate
+// from multiple machines. A failure to measure the usage is not an error so that the machine info is still returned.
+func (m *Machine) BatchInspect(ctx context.Context, _ *emptypb.Empty) (*pb.BatchInspectResponse, error) {
+	info, err := m.Inspect(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := m.Usage(ctx, nil)
+	if err != nil {
+		slog.Warn("Failed to measure machine usage.", "err", err)
+	}
+
+	return &pb.BatchInspectResponse{
+		Messages: []*pb.MachineInspection{
+			{
+				Machine:         info,
+				Usage:           usage,
+				DaemonVersion:   version.String(),
+				DaemonStartedAt: timestamppb.New(m.startedAt),
+			},
+		},
 	}, nil
 }
 
@@ -929,8 +1188,12 @@ func (m *Machine) InspectService(
 	// TODO: handle multiple services with the same name but different IDs. This can happen when two services
 	//  with the same name are created concurrently on different machines.
 
+	readOnly := rbac.ReadOnly(ctx)
 	containers := make([]*pb.Service_Container, len(records))
 	for i, r := range records {
+		if readOnly {
+			r.Container = r.Container.Redacted()
+		}
 		containerJSON, err := json.Marshal(r.Container)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "marshal container: %v", err)
@@ -950,3 +1213,29 @@ func (m *Machine) InspectService(
 	}
 	return &pb.InspectServiceResponse{Service: svc}, nil
 }
+
+// LastBootReport returns the report of the state recovery performed by the machine daemon after the last reboot.
+func (m *Machine) LastBootReport(_ context.Context, _ *emptypb.Empty) (*pb.BootReport, error) {
+	report, err := ParseBootReport(BootReportPath(m.config.DataDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, status.Error(codes.NotFound, "boot report not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	actions := make([]*pb.RecoveryAction, len(report.Actions))
+	for i, a := range report.Actions {
+		actions[i] = &pb.RecoveryAction{
+			Resource: a.Resource,
+			Action:   a.Action,
+			Error:    a.Error,
+		}
+	}
+	return &pb.BootReport{
+		BootId:      report.BootID,
+		BootTime:    timestamppb.New(report.BootTime),
+		RecoveredAt: timestamppb.New(report.RecoveredAt),
+		Actions:     actions,
+	}, nil
+}
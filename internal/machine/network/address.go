@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/netip"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -112,3 +113,44 @@ func queryIP(service string, parser func([]byte) (netip.Addr, error)) (netip.Add
 func parsePlaintextIP(data []byte) (netip.Addr, error) {
 	return netip.ParseAddr(string(data))
 }
+
+// ParseDNSEndpoint parses a WireGuard endpoint specified as a DNS name in the host:port format.
+func ParseDNSEndpoint(s string) (host string, port uint16, err error) {
+	host, portStr, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid DNS endpoint %q: %w", s, err)
+	}
+	if host == "" {
+		return "", 0, fmt.Errorf("invalid DNS endpoint %q: empty host", s)
+	}
+	if _, err = netip.ParseAddr(host); err == nil {
+		return "", 0, fmt.Errorf("invalid DNS endpoint %q: host must be a DNS name, not an IP address", s)
+	}
+	p, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || p == 0 {
+		return "", 0, fmt.Errorf("invalid DNS endpoint %q: invalid port %q", s, portStr)
+	}
+	return host, uint16(p), nil
+}
+
+// ResolveDNSEndpoint resolves a WireGuard endpoint specified as a DNS name in the host:port format to the list
+// of IP endpoints.
+func ResolveDNSEndpoint(ctx context.Context, endpoint string) ([]netip.AddrPort, error) {
+	host, port, err := ParseDNSEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, fmt.Errorf("resolve DNS endpoint %q: %w", endpoint, err)
+	}
+
+	addrPorts := make([]netip.AddrPort, len(ips))
+	for i, ip := range ips {
+		addrPorts[i] = netip.AddrPortFrom(ip.Unmap(), port)
+	}
+	return addrPorts, nil
+}
@@ -0,0 +1,78 @@
+package network
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDNSEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		endpoint string
+		wantHost string
+		wantPort uint16
+		wantErr  string
+	}{
+		{endpoint: "home.example.com:51820", wantHost: "home.example.com", wantPort: 51820},
+		{endpoint: "vpn.example.com:1", wantHost: "vpn.example.com", wantPort: 1},
+		{endpoint: "home.example.com", wantErr: "missing port"},
+		{endpoint: ":51820", wantErr: "empty host"},
+		{endpoint: "203.0.113.10:51820", wantErr: "host must be a DNS name"},
+		{endpoint: "[2001:db8::1]:51820", wantErr: "host must be a DNS name"},
+		{endpoint: "home.example.com:0", wantErr: "invalid port"},
+		{endpoint: "home.example.com:65536", wantErr: "invalid port"},
+		{endpoint: "home.example.com:wg", wantErr: "invalid port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			t.Parallel()
+
+			host, port, err := ParseDNSEndpoint(tt.endpoint)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, host)
+			assert.Equal(t, tt.wantPort, port)
+		})
+	}
+}
+
+func TestResolveDNSEndpoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolved", func(t *testing.T) {
+		t.Parallel()
+
+		addrPorts, err := ResolveDNSEndpoint(context.Background(), "localhost:51821")
+		require.NoError(t, err)
+		require.NotEmpty(t, addrPorts)
+		for _, ap := range addrPorts {
+			assert.True(t, ap.Addr().IsLoopback(), "%s must be a loopback address", ap)
+			assert.False(t, ap.Addr().Is4In6(), "%s must be unmapped", ap)
+			assert.EqualValues(t, 51821, ap.Port())
+		}
+		assert.Contains(t, addrPorts, netip.MustParseAddrPort("127.0.0.1:51821"))
+	})
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ResolveDNSEndpoint(context.Background(), "127.0.0.1:51820")
+		assert.ErrorContains(t, err, "host must be a DNS name")
+	})
+
+	t.Run("unresolvable", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ResolveDNSEndpoint(context.Background(), "uncloud.invalid:51820")
+		assert.ErrorContains(t, err, "resolve DNS endpoint")
+	})
+}
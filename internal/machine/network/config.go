@@ -29,6 +29,12 @@ type PeerConfig struct {
 	Endpoint     *netip.AddrPort  `json:",omitempty"`
 	AllEndpoints []netip.AddrPort `json:",omitempty"`
 	PublicKey    secret.Secret
+	// PeeredPrefixes are the IP ranges routed to the peer if it's a machine of a peered cluster. Such peers don't have
+	// the subnet and management IP set as the management network isn't routed between clusters.
+	PeeredPrefixes []netip.Prefix `json:",omitempty"`
+	// EgressGateway indicates that the peer routes the outbound internet traffic of the containers on this machine
+	// selected by the egress policy. Its allowed IPs include the default route. At most one peer can be the gateway.
+	EgressGateway bool `json:",omitempty"`
 }
 
 // IsConfigured returns true if the configuration is complete to establish a WireGuard network.
@@ -55,13 +61,13 @@ func (c Config) toDeviceConfig(currentPeers []wgtypes.Peer) (wgtypes.Config, err
 		if kErr != nil {
 			return wgtypes.Config{}, fmt.Errorf("parse peer public key: %w", kErr)
 		}
-		manageIP, mErr := addrToSingleIPPrefix(peerConfig.ManagementIP)
-		if mErr != nil {
-			return wgtypes.Config{}, fmt.Errorf("parse management IP: %w", mErr)
+		prefixes, pErr := peerConfig.allowedPrefixes()
+		if pErr != nil {
+			return wgtypes.Config{}, pErr
 		}
-		allowedIPs := []net.IPNet{prefixToIPNet(manageIP)}
-		if peerConfig.Subnet != nil {
-			allowedIPs = append(allowedIPs, prefixToIPNet(*peerConfig.Subnet))
+		allowedIPs := make([]net.IPNet, len(prefixes))
+		for j, prefix := range prefixes {
+			allowedIPs[j] = prefixToIPNet(prefix)
 		}
 		wgPeerConfigs[i] = wgtypes.PeerConfig{
 			PublicKey:                   peerPublicKey,
@@ -98,13 +104,30 @@ func (c Config) toDeviceConfig(currentPeers []wgtypes.Peer) (wgtypes.Config, err
 }
 
 func (p *PeerConfig) prefixes() ([]netip.Prefix, error) {
-	managePrefix, err := addrToSingleIPPrefix(p.ManagementIP)
-	if err != nil {
-		return nil, fmt.Errorf("parse management IP: %w", err)
+	var prefixes []netip.Prefix
+	// Machines of peered clusters don't have a management IP.
+	if p.ManagementIP.IsValid() {
+		managePrefix, err := addrToSingleIPPrefix(p.ManagementIP)
+		if err != nil {
+			return nil, fmt.Errorf("parse management IP: %w", err)
+		}
+		prefixes = append(prefixes, managePrefix)
 	}
-	prefixes := []netip.Prefix{managePrefix}
 	if p.Subnet != nil {
 		prefixes = append(prefixes, *p.Subnet)
 	}
+	return append(prefixes, p.PeeredPrefixes...), nil
+}
+
+// allowedPrefixes returns the IP ranges allowed to be sent to and received from the peer through the WireGuard
+// tunnel. Unlike prefixes, they include the default route if the peer is the egress gateway.
+func (p *PeerConfig) allowedPrefixes() ([]netip.Prefix, error) {
+	prefixes, err := p.prefixes()
+	if err != nil {
+		return nil, err
+	}
+	if p.EgressGateway {
+		prefixes = append(prefixes, netip.PrefixFrom(netip.IPv4Unspecified(), 0))
+	}
 	return prefixes, nil
 }
@@ -0,0 +1,111 @@
+//go:build linux
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// EgressRouteTable is the routing table with the default route via the WireGuard interface used for
+	// the outbound traffic of the containers routed through the egress gateway machine.
+	EgressRouteTable = 51820
+	// egressRulePriority is the priority of the routing rules that make the selected containers use the main
+	// routing table for all destinations except the default route. The rules that make them use EgressRouteTable
+	// for the rest of the traffic have the next priority.
+	egressRulePriority = 32000
+)
+
+// ConfigureEgress routes the outbound internet traffic from the source IP ranges, e.g. the machine subnet or
+// particular container IPs, through the WireGuard interface to the peer that is the egress gateway. The traffic
+// to other destinations in the main routing table, e.g. the cluster network, isn't affected. Routing rules for
+// sources that are no longer in the list are removed.
+func (n *WireGuardNetwork) ConfigureEgress(sources []netip.Prefix) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.configureEgress(sources)
+}
+
+// configureEgress implements ConfigureEgress. mu lock must be held before calling this method.
+func (n *WireGuardNetwork) configureEgress(sources []netip.Prefix) error {
+	defaultRoute := &netlink.Route{
+		LinkIndex: n.link.Attrs().Index,
+		Scope:     netlink.SCOPE_LINK,
+		Dst:       &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)},
+		Table:     EgressRouteTable,
+	}
+	if len(sources) > 0 {
+		if err := netlink.RouteReplace(defaultRoute); err != nil {
+			return fmt.Errorf("add default route to egress routing table: %w", err)
+		}
+	}
+
+	// Build the desired rules indexed by priority and source.
+	wantRules := make(map[string]*netlink.Rule, 2*len(sources))
+	for _, src := range sources {
+		srcNet := prefixToIPNet(src)
+
+		mainRule := netlink.NewRule()
+		mainRule.Priority = egressRulePriority
+		mainRule.Src = &srcNet
+		mainRule.Table = unix.RT_TABLE_MAIN
+		// Ignore the default route in the main table so only the more specific routes are used.
+		mainRule.SuppressPrefixlen = 0
+		wantRules[egressRuleKey(mainRule)] = mainRule
+
+		egressRule := netlink.NewRule()
+		egressRule.Priority = egressRulePriority + 1
+		egressRule.Src = &srcNet
+		egressRule.Table = EgressRouteTable
+		wantRules[egressRuleKey(egressRule)] = egressRule
+	}
+
+	rules, err := netlink.RuleList(netlink.FAMILY_V4)
+	if err != nil {
+		return fmt.Errorf("list routing rules: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Priority != egressRulePriority && rule.Priority != egressRulePriority+1 {
+			continue
+		}
+		key := egressRuleKey(&rule)
+		if _, ok := wantRules[key]; ok {
+			delete(wantRules, key)
+			continue
+		}
+		if err = netlink.RuleDel(&rule); err != nil {
+			return fmt.Errorf("remove egress routing rule '%s': %w", key, err)
+		}
+		slog.Debug("Removed egress routing rule.", "rule", key)
+	}
+	for key, rule := range wantRules {
+		if err = netlink.RuleAdd(rule); err != nil && !errors.Is(err, unix.EEXIST) {
+			return fmt.Errorf("add egress routing rule '%s': %w", key, err)
+		}
+		slog.Debug("Added egress routing rule.", "rule", key)
+	}
+
+	if len(sources) == 0 {
+		if err = netlink.RouteDel(defaultRoute); err != nil && !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("remove default route from egress routing table: %w", err)
+		}
+	}
+	return nil
+}
+
+// egressRuleKey returns a string that uniquely identifies the egress routing rule.
+func egressRuleKey(rule *netlink.Rule) string {
+	src := "all"
+	if rule.Src != nil {
+		src = rule.Src.String()
+	}
+	return fmt.Sprintf("%d from %s lookup %d", rule.Priority, src, rule.Table)
+}
@@ -0,0 +1,119 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"time"
+)
+
+const (
+	// probeAttempts is the number of probe packets sent to an endpoint before it's considered unreachable as UDP
+	// packets may be lost.
+	probeAttempts = 3
+	// probeNonceSize is the size of the random nonce the probe reply must echo.
+	probeNonceSize = 16
+)
+
+// probeMagic prefixes the probe packets to not mistake other traffic on the WireGuard port for a probe.
+var probeMagic = []byte("uncloud-probe\x00")
+
+// ProbeResponder answers the probes on the WireGuard port of a machine that hasn't joined a cluster yet so that
+// the cluster machines can verify they can reach its WireGuard endpoints before adding it to the cluster.
+// It must be closed before configuring the WireGuard interface to release the port.
+type ProbeResponder struct {
+	conn *net.UDPConn
+}
+
+// ListenProbes starts answering the probes on the UDP port on all interfaces.
+func ListenProbes(port int) (*ProbeResponder, error) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port})
+	if err != nil {
+		return nil, fmt.Errorf("listen UDP port %d: %w", port, err)
+	}
+
+	r := &ProbeResponder{conn: conn}
+	go r.serve()
+	return r, nil
+}
+
+func (r *ProbeResponder) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := r.conn.ReadFromUDPAddrPort(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				slog.Warn("Failed to read probe packet.", "err", err)
+			}
+			return
+		}
+		if n != len(probeMagic)+probeNonceSize || !bytes.HasPrefix(buf[:n], probeMagic) {
+			continue
+		}
+		// Echo the probe back to the sender.
+		if _, err = r.conn.WriteToUDPAddrPort(buf[:n], addr); err != nil {
+			slog.Debug("Failed to reply to probe packet.", "addr", addr, "err", err)
+		}
+	}
+}
+
+// Close stops answering the probes and releases the port.
+func (r *ProbeResponder) Close() error {
+	return r.conn.Close()
+}
+
+// ProbeEndpoint checks if the endpoint of a machine answers the probes within the timeout. The probe is sent from
+// an ephemeral port so it can be used on a machine with the WireGuard interface configured.
+func ProbeEndpoint(ctx context.Context, endpoint netip.AddrPort, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := net.DialUDP("udp", nil, net.UDPAddrFromAddrPort(endpoint))
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+
+	probe := make([]byte, len(probeMagic)+probeNonceSize)
+	copy(probe, probeMagic)
+	if _, err = rand.Read(probe[len(probeMagic):]); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+
+	deadline, _ := ctx.Deadline()
+	interval := timeout / probeAttempts
+	buf := make([]byte, len(probe))
+	for attempt := 0; attempt < probeAttempts; attempt++ {
+		if _, err = conn.Write(probe); err != nil {
+			return fmt.Errorf("send probe: %w", err)
+		}
+		// Wait for the reply until the next attempt or the overall deadline.
+		readDeadline := time.Now().Add(interval)
+		if readDeadline.After(deadline) {
+			readDeadline = deadline
+		}
+		if err = conn.SetReadDeadline(readDeadline); err != nil {
+			return fmt.Errorf("set read deadline: %w", err)
+		}
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				// Read timeout or ICMP port unreachable reported as connection refused. Retry either way
+				// as the refusal may be caused by a restarting responder.
+				break
+			}
+			if bytes.Equal(buf[:n], probe) {
+				return nil
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return fmt.Errorf("no reply to probes sent to UDP port %d within %s", endpoint.Port(), timeout)
+}
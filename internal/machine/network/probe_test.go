@@ -0,0 +1,71 @@
+package network
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// freeUDPPort returns a UDP port that is likely available to listen on.
+func freeUDPPort(t *testing.T) int {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, conn.Close())
+	return port
+}
+
+func TestProbeEndpoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reachable", func(t *testing.T) {
+		t.Parallel()
+
+		port := freeUDPPort(t)
+		r, err := ListenProbes(port)
+		require.NoError(t, err)
+		defer r.Close()
+
+		endpoint := netip.AddrPortFrom(netip.MustParseAddr("127.0.0.1"), uint16(port))
+		assert.NoError(t, ProbeEndpoint(context.Background(), endpoint, time.Second))
+	})
+
+	t.Run("unreachable after responder closed", func(t *testing.T) {
+		t.Parallel()
+
+		port := freeUDPPort(t)
+		r, err := ListenProbes(port)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+
+		endpoint := netip.AddrPortFrom(netip.MustParseAddr("127.0.0.1"), uint16(port))
+		err = ProbeEndpoint(context.Background(), endpoint, 300*time.Millisecond)
+		assert.ErrorContains(t, err, "no reply to probes")
+	})
+
+	t.Run("non-probe packets are ignored", func(t *testing.T) {
+		t.Parallel()
+
+		port := freeUDPPort(t)
+		r, err := ListenProbes(port)
+		require.NoError(t, err)
+		defer r.Close()
+
+		conn, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port})
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte("not a probe"))
+		require.NoError(t, err)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+		_, err = conn.Read(make([]byte, 64))
+		assert.Error(t, err)
+	})
+}
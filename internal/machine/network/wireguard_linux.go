@@ -23,6 +23,8 @@ import (
 
 type WireGuardNetwork struct {
 	link netlink.Link
+	// created indicates whether the WireGuard interface was missing and has been created.
+	created bool
 	// peers is a map of peers indexed by their public key.
 	peers map[string]*peer
 	// watchers is a list of channels that are notified when the endpoints of the peers change.
@@ -34,23 +36,29 @@ type WireGuardNetwork struct {
 }
 
 func NewWireGuardNetwork() (*WireGuardNetwork, error) {
-	link, err := createOrGetLink(WireGuardInterfaceName)
+	link, created, err := createOrGetLink(WireGuardInterfaceName)
 	if err != nil {
 		return nil, fmt.Errorf("create or get WireGuard link %q: %v", WireGuardInterfaceName, err)
 	}
-	return &WireGuardNetwork{link: link}, nil
+	return &WireGuardNetwork{link: link, created: created}, nil
 }
 
-// createOrGetLink creates a new WireGuard link with the given name if it doesn't already exist, otherwise it returns the existing link.
-func createOrGetLink(name string) (netlink.Link, error) {
+// Created returns true if the WireGuard interface didn't exist and has been created, e.g. after a reboot.
+func (n *WireGuardNetwork) Created() bool {
+	return n.created
+}
+
+// createOrGetLink creates a new WireGuard link with the given name if it doesn't already exist, otherwise it returns
+// the existing link. The returned bool indicates whether the link has been created.
+func createOrGetLink(name string) (netlink.Link, bool, error) {
 	link, err := netlink.LinkByName(name)
 	if err == nil {
 		slog.Info("Found existing WireGuard interface.", "name", name)
-		return link, nil
+		return link, false, nil
 	}
 	//goland:noinspection GoTypeAssertionOnErrors
 	if _, ok := err.(netlink.LinkNotFoundError); !ok {
-		return nil, fmt.Errorf("find WireGuard link %q: %v", name, err)
+		return nil, false, fmt.Errorf("find WireGuard link %q: %v", name, err)
 	}
 	link = &netlink.GenericLink{
 		// TODO: figure out how to set the most appropriate MTU.
@@ -58,16 +66,16 @@ func createOrGetLink(name string) (netlink.Link, error) {
 		LinkType:  "wireguard",
 	}
 	if err = netlink.LinkAdd(link); err != nil {
-		return nil, fmt.Errorf("create WireGuard link %q: %v", name, err)
+		return nil, false, fmt.Errorf("create WireGuard link %q: %v", name, err)
 	}
 	slog.Info("Created WireGuard interface.", "name", name)
 
 	// Refetch the link to get the most up-to-date information.
 	link, err = netlink.LinkByName(name)
 	if err != nil {
-		return nil, fmt.Errorf("find created WireGuard link %q: %v", name, err)
+		return nil, false, fmt.Errorf("find created WireGuard link %q: %v", name, err)
 	}
-	return link, nil
+	return link, true, nil
 }
 
 // Configure applies the given configuration to the WireGuard network interface.
@@ -314,6 +322,95 @@ func (n *WireGuardNetwork) Run(ctx context.Context) error {
 	}
 }
 
+// Peers returns the public keys of the configured WireGuard peers.
+func (n *WireGuardNetwork) Peers() []secret.Secret {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	keys := make([]secret.Secret, 0, len(n.peers))
+	for _, p := range n.peers {
+		keys = append(keys, p.config.PublicKey)
+	}
+	return keys
+}
+
+// DropPeer makes the peer with the given public key unreachable for the given duration by removing its allowed IPs
+// from the WireGuard device. It's used by the chaos mode to simulate network partitions. The allowed IPs are restored
+// after the duration or when the network is reconfigured.
+func (n *WireGuardNetwork) DropPeer(publicKey secret.Secret, d time.Duration) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.peers[publicKey.String()]; !ok {
+		return fmt.Errorf("peer not found: %s", publicKey)
+	}
+	key, err := wgtypes.NewKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("parse peer public key: %w", err)
+	}
+	peerConfig := wgtypes.PeerConfig{
+		PublicKey:         key,
+		UpdateOnly:        true,
+		ReplaceAllowedIPs: true,
+	}
+	if err = n.configurePeers([]wgtypes.PeerConfig{peerConfig}); err != nil {
+		return err
+	}
+
+	time.AfterFunc(d, func() {
+		if err := n.restorePeer(publicKey); err != nil {
+			slog.Error("Failed to restore dropped WireGuard peer.", "public_key", publicKey, "err", err)
+		}
+	})
+	return nil
+}
+
+// restorePeer restores the allowed IPs of the peer removed by DropPeer.
+func (n *WireGuardNetwork) restorePeer(publicKey secret.Secret) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, ok := n.peers[publicKey.String()]
+	if !ok {
+		// The peer has been removed from the configuration in the meantime.
+		return nil
+	}
+	key, err := wgtypes.NewKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("parse peer public key: %w", err)
+	}
+	prefixes, err := p.config.allowedPrefixes()
+	if err != nil {
+		return err
+	}
+	allowedIPs := make([]net.IPNet, len(prefixes))
+	for i, prefix := range prefixes {
+		allowedIPs[i] = prefixToIPNet(prefix)
+	}
+	peerConfig := wgtypes.PeerConfig{
+		PublicKey:         key,
+		UpdateOnly:        true,
+		ReplaceAllowedIPs: true,
+		AllowedIPs:        allowedIPs,
+	}
+	return n.configurePeers([]wgtypes.PeerConfig{peerConfig})
+}
+
+// configurePeers applies the given peer configs to the WireGuard device without replacing other peers.
+// mu lock must be held before calling this method.
+func (n *WireGuardNetwork) configurePeers(peers []wgtypes.PeerConfig) error {
+	wg, err := wgctrl.New()
+	if err != nil {
+		return fmt.Errorf("create WireGuard client: %w", err)
+	}
+	defer wg.Close()
+
+	if err = wg.ConfigureDevice(n.link.Attrs().Name, wgtypes.Config{Peers: peers}); err != nil {
+		return fmt.Errorf("configure WireGuard device %q: %w", n.link.Attrs().Name, err)
+	}
+	return nil
+}
+
 // WatchEndpoints returns a channel that receives endpoint change events for the WireGuard peers.
 func (n *WireGuardNetwork) WatchEndpoints() <-chan EndpointChangeEvent {
 	ch := make(chan EndpointChangeEvent)
@@ -456,6 +553,10 @@ func (n *WireGuardNetwork) Cleanup() error {
 		return errors.New("network is still running, stop it before cleanup")
 	}
 
+	if err := n.configureEgress(nil); err != nil {
+		return fmt.Errorf("remove egress routing rules: %w", err)
+	}
+
 	// Delete the WireGuard link.
 	name := n.link.Attrs().Name
 	if err := netlink.LinkDel(n.link); err != nil {
@@ -1,10 +1,14 @@
-//go:build darwin
+//go:build !linux
 
 package network
 
 import (
 	"context"
 	"errors"
+	"net/netip"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/secret"
 )
 
 type WireGuardNetwork struct{}
@@ -13,6 +17,10 @@ func NewWireGuardNetwork() (*WireGuardNetwork, error) {
 	return &WireGuardNetwork{}, nil
 }
 
+func (n *WireGuardNetwork) Created() bool {
+	return false
+}
+
 func (n *WireGuardNetwork) Configure(config Config) error {
 	return errors.New("not implemented on darwin")
 }
@@ -25,6 +33,18 @@ func (n *WireGuardNetwork) WatchEndpoints() <-chan EndpointChangeEvent {
 	return nil
 }
 
+func (n *WireGuardNetwork) Peers() []secret.Secret {
+	return nil
+}
+
+func (n *WireGuardNetwork) DropPeer(publicKey secret.Secret, d time.Duration) error {
+	return errors.New("not implemented on darwin")
+}
+
 func (n *WireGuardNetwork) Cleanup() error {
 	return errors.New("not implemented on darwin")
 }
+
+func (n *WireGuardNetwork) ConfigureEgress(sources []netip.Prefix) error {
+	return errors.New("not implemented on darwin")
+}
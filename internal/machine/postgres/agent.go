@@ -0,0 +1,370 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/docker"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// DefaultInterval is the interval at which the agent checks the availability of the primaries.
+	DefaultInterval = 5 * time.Second
+	// DefaultFailoverTimeout is how long the primary must be unavailable before the standby is promoted.
+	DefaultFailoverTimeout = 30 * time.Second
+	// DefaultFenceTimeout is how long the machine running the primary must be out of quorum before the primary
+	// is stopped. It's shorter than DefaultFailoverTimeout so that the primary is stopped before the standby
+	// on the other side of a network partition can be promoted.
+	DefaultFenceTimeout = 15 * time.Second
+	// queryTimeout is the maximum time a query to the local server can take. Promotion waits up to 60 seconds
+	// for the server to finish the recovery.
+	queryTimeout = 90 * time.Second
+)
+
+// MachineLister lists the cluster machines with their membership states.
+type MachineLister interface {
+	ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error)
+}
+
+// Agent performs automated failover of the managed Postgres clusters with a server on this machine. It writes the ID
+// of the primary machine from the store to the cluster directory in api.PostgresRunDir that the container entrypoints
+// use to choose their role. If the primary is unavailable for the failover timeout, the standby is promoted and
+// the cluster DNS name is switched to it. The old primary rejoins as a standby when it's back because its container
+// entrypoint sees another machine is the primary.
+//
+// Two primaries must never run at the same time so the failover is fenced with the cluster membership quorum:
+// the standby is promoted only if its machine sees the majority of the cluster machines up, and the primary is stopped
+// if its machine doesn't see the majority for the fence timeout that is shorter than the failover timeout. Therefore,
+// a primary cut off by a network partition is stopped before the standby on the majority side is promoted. A cluster
+// with only two machines has no majority when one of them is down so its standby is never promoted automatically.
+type Agent struct {
+	machineID       string
+	store           *store.Store
+	machines        MachineLister
+	docker          *docker.Service
+	runDir          string
+	interval        time.Duration
+	failoverTimeout time.Duration
+	fenceTimeout    time.Duration
+	// unavailableSince tracks when the primary of each cluster was first seen unavailable.
+	unavailableSince map[string]time.Time
+	// noQuorumSince is when the machine first saw no quorum.
+	noQuorumSince time.Time
+	// quorumSince is when the machine first saw the quorum again after losing it.
+	quorumSince time.Time
+	// fenced are the IDs of the primary containers stopped by the agent because the machine lost quorum
+	// by cluster name. They're started again when the quorum is restored.
+	fenced map[string]string
+	log    *slog.Logger
+}
+
+func NewAgent(machineID string, store *store.Store, machines MachineLister, dockerService *docker.Service) *Agent {
+	return &Agent{
+		machineID:        machineID,
+		store:            store,
+		machines:         machines,
+		docker:           dockerService,
+		runDir:           api.PostgresRunDir,
+		interval:         DefaultInterval,
+		failoverTimeout:  DefaultFailoverTimeout,
+		fenceTimeout:     DefaultFenceTimeout,
+		unavailableSince: make(map[string]time.Time),
+		fenced:           make(map[string]string),
+		log:              slog.With("component", "postgres-agent"),
+	}
+}
+
+// Run checks the availability of the primaries every interval and fails over to the local standbys until
+// the context is canceled.
+func (a *Agent) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.reconcile(ctx); err != nil {
+				a.log.Error("Failed to check managed Postgres clusters.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (a *Agent) reconcile(ctx context.Context) error {
+	clusters, err := a.store.ListPostgresClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("list postgres clusters: %w", err)
+	}
+	clusters = slices.DeleteFunc(clusters, func(c api.PostgresCluster) bool {
+		return !slices.Contains(c.Machines, a.machineID)
+	})
+	if err = a.writePrimaryFiles(clusters); err != nil {
+		return err
+	}
+	if len(clusters) == 0 {
+		clear(a.unavailableSince)
+		clear(a.fenced)
+		a.noQuorumSince = time.Time{}
+		a.quorumSince = time.Time{}
+		return nil
+	}
+
+	resp, err := a.machines.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	records, err := a.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+	quorum := HasQuorum(resp.Machines)
+	a.fence(ctx, clusters, records, quorum)
+
+	for _, c := range clusters {
+		if c.Primary == a.machineID {
+			delete(a.unavailableSince, c.Name)
+			continue
+		}
+		standby, ok := runningContainer(records, c.Name, a.machineID)
+		if !ok {
+			// Nothing to promote.
+			delete(a.unavailableSince, c.Name)
+			continue
+		}
+
+		if PrimaryAvailable(c, resp.Machines, records) {
+			delete(a.unavailableSince, c.Name)
+			if err = a.ensureStandby(ctx, c, standby); err != nil {
+				a.log.Error("Failed to ensure Postgres server is a standby.", "cluster", c.Name, "err", err)
+			}
+			continue
+		}
+
+		since, ok := a.unavailableSince[c.Name]
+		if !ok {
+			a.unavailableSince[c.Name] = time.Now()
+			a.log.Warn("Primary of Postgres cluster is unavailable.", "cluster", c.Name, "primary", c.Primary,
+				"failover_timeout", a.failoverTimeout)
+			continue
+		}
+		if time.Since(since) < a.failoverTimeout {
+			continue
+		}
+		if !quorum {
+			// The primary may be running on the other side of a network partition.
+			a.log.Warn("Not failing over Postgres cluster as the machine doesn't see the majority of machines up.",
+				"cluster", c.Name, "primary", c.Primary)
+			continue
+		}
+
+		if err = a.failover(ctx, c, standby); err != nil {
+			a.log.Error("Failed to fail over Postgres cluster.", "cluster", c.Name, "err", err)
+			continue
+		}
+		delete(a.unavailableSince, c.Name)
+	}
+	return nil
+}
+
+// writePrimaryFiles writes the ID of the primary machine of each cluster to its directory in the run directory
+// mounted into the cluster containers. The file is replaced atomically so the entrypoint never reads a partial ID.
+func (a *Agent) writePrimaryFiles(clusters []api.PostgresCluster) error {
+	for _, c := range clusters {
+		dir := filepath.Join(a.runDir, c.Name)
+		path := filepath.Join(dir, api.PostgresPrimaryFileName)
+		if current, err := os.ReadFile(path); err == nil && string(current) == c.Primary {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create directory for postgres cluster '%s': %w", c.Name, err)
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(c.Primary), 0o644); err != nil {
+			return fmt.Errorf("write primary machine of postgres cluster '%s': %w", c.Name, err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("write primary machine of postgres cluster '%s': %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// fence stops the local primaries if the machine has been out of quorum for the fence timeout and starts them
+// again when the quorum is restored. A started server rejoins as a standby if another machine has become
+// the primary in the meantime.
+func (a *Agent) fence(ctx context.Context, clusters []api.PostgresCluster, records []store.ContainerRecord, quorum bool) {
+	if quorum {
+		a.noQuorumSince = time.Time{}
+		if len(a.fenced) == 0 {
+			return
+		}
+		// Give the store time to sync the changes made by the majority, e.g. a failover, before starting
+		// the fenced servers so that they see the current primary.
+		if a.quorumSince.IsZero() {
+			a.quorumSince = time.Now()
+		}
+		if time.Since(a.quorumSince) < a.fenceTimeout {
+			return
+		}
+		for name, id := range a.fenced {
+			a.log.Info("Machine is back in quorum, starting fenced Postgres server.", "cluster", name)
+			if err := a.docker.Client.ContainerStart(ctx, id, container.StartOptions{}); err != nil {
+				a.log.Error("Failed to start fenced Postgres server.", "cluster", name, "err", err)
+				continue
+			}
+			delete(a.fenced, name)
+		}
+		return
+	}
+
+	a.quorumSince = time.Time{}
+	if a.noQuorumSince.IsZero() {
+		a.noQuorumSince = time.Now()
+	}
+	if time.Since(a.noQuorumSince) < a.fenceTimeout {
+		return
+	}
+	for _, c := range clusters {
+		if c.Primary != a.machineID {
+			continue
+		}
+		primary, ok := runningContainer(records, c.Name, a.machineID)
+		if !ok {
+			continue
+		}
+		a.log.Warn("Machine doesn't see the majority of machines up, stopping Postgres primary to prevent "+
+			"two primaries if the standby is promoted.", "cluster", c.Name, "fence_timeout", a.fenceTimeout)
+		if err := a.docker.Client.ContainerStop(ctx, primary.ID, container.StopOptions{}); err != nil {
+			a.log.Error("Failed to stop Postgres primary.", "cluster", c.Name, "err", err)
+			continue
+		}
+		a.fenced[c.Name] = primary.ID
+	}
+}
+
+// HasQuorum returns true if the majority of the cluster machines are not down as seen by this machine.
+func HasQuorum(machines []*pb.MachineMember) bool {
+	available := 0
+	for _, m := range machines {
+		if m.State != pb.MachineMember_DOWN {
+			available++
+		}
+	}
+	return available > len(machines)/2
+}
+
+// PrimaryAvailable returns true if the primary machine of the cluster is not down and runs the cluster container.
+func PrimaryAvailable(c api.PostgresCluster, machines []*pb.MachineMember, records []store.ContainerRecord) bool {
+	i := slices.IndexFunc(machines, func(m *pb.MachineMember) bool { return m.Machine.Id == c.Primary })
+	if i == -1 || machines[i].State == pb.MachineMember_DOWN {
+		return false
+	}
+	_, ok := runningContainer(records, c.Name, c.Primary)
+	return ok
+}
+
+// runningContainer returns the running container of the service on the machine.
+func runningContainer(records []store.ContainerRecord, serviceName, machineID string) (api.ServiceContainer, bool) {
+	for _, r := range records {
+		if r.MachineID == machineID && r.Container.ServiceName() == serviceName && r.Container.State.Running {
+			return r.Container, true
+		}
+	}
+	return api.ServiceContainer{}, false
+}
+
+// failover promotes the local standby to the primary and switches the cluster DNS name to it.
+func (a *Agent) failover(ctx context.Context, c api.PostgresCluster, standby api.ServiceContainer) error {
+	inRecovery, err := a.query(ctx, standby.ID, "SELECT pg_is_in_recovery()")
+	if err != nil {
+		return fmt.Errorf("check recovery status: %w", err)
+	}
+	if inRecovery == "t" {
+		a.log.Info("Promoting Postgres standby to primary.", "cluster", c.Name, "old_primary", c.Primary)
+		promoted, err := a.query(ctx, standby.ID, "SELECT pg_promote()")
+		if err != nil {
+			return fmt.Errorf("promote standby: %w", err)
+		}
+		if promoted != "t" {
+			return fmt.Errorf("standby not promoted within 60 seconds")
+		}
+	}
+
+	oldPrimary := c.Primary
+	c.Primary = a.machineID
+	c.FailedOverAt = time.Now().UTC()
+	if err = a.store.PutPostgresCluster(ctx, c); err != nil {
+		return fmt.Errorf("store postgres cluster: %w", err)
+	}
+	a.log.Info("Postgres cluster failed over.", "cluster", c.Name, "old_primary", oldPrimary, "primary", c.Primary)
+	return nil
+}
+
+// ensureStandby restarts the local server if it runs as a primary while the primary is another server. This may
+// happen if the old primary was unreachable, e.g. its machine was suspended, but kept running during the failover.
+// The restarted container rejoins the cluster as a standby.
+func (a *Agent) ensureStandby(ctx context.Context, c api.PostgresCluster, local api.ServiceContainer) error {
+	inRecovery, err := a.query(ctx, local.ID, "SELECT pg_is_in_recovery()")
+	if err != nil {
+		// The server may still be cloning the primary or starting up.
+		return nil
+	}
+	if inRecovery != "f" {
+		return nil
+	}
+
+	a.log.Warn("Local Postgres server runs as a primary while another server is the primary, "+
+		"restarting it to rejoin as a standby.", "cluster", c.Name, "primary", c.Primary)
+	if err = a.docker.Client.ContainerRestart(ctx, local.ID, container.StopOptions{}); err != nil {
+		return fmt.Errorf("restart container: %w", err)
+	}
+	return nil
+}
+
+// query runs the SQL query with psql as the superuser in the container and returns its trimmed output.
+// The official image trusts local connections so no password is needed.
+func (a *Agent) query(ctx context.Context, containerID, query string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	execResp, err := a.docker.Client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          []string{"psql", "-U", api.PostgresUser, "-tAXc", query},
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create exec: %w", err)
+	}
+	hijacked, err := a.docker.Client.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", fmt.Errorf("attach to exec: %w", err)
+	}
+	defer hijacked.Close()
+
+	var stdout, stderr strings.Builder
+	if _, err = stdcopy.StdCopy(&stdout, &stderr, hijacked.Reader); err != nil {
+		return "", fmt.Errorf("read exec output: %w", err)
+	}
+
+	inspect, err := a.docker.Client.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return "", fmt.Errorf("inspect exec: %w", err)
+	}
+	if inspect.ExitCode != 0 {
+		return "", fmt.Errorf("psql exited with code %d: %s", inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
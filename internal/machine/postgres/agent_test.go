@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimaryAvailable(t *testing.T) {
+	t.Parallel()
+
+	record := func(serviceName, machineID string, running bool) store.ContainerRecord {
+		return store.ContainerRecord{
+			MachineID: machineID,
+			Container: api.ServiceContainer{
+				Container: api.Container{ContainerJSON: types.ContainerJSON{
+					ContainerJSONBase: &types.ContainerJSONBase{
+						ID:    serviceName + "-" + machineID,
+						State: &types.ContainerState{Running: running},
+					},
+					Config: &container.Config{Labels: map[string]string{api.LabelServiceName: serviceName}},
+				}},
+			},
+		}
+	}
+	member := func(id string, state pb.MachineMember_MembershipState) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{Id: id}, State: state}
+	}
+	c := api.PostgresCluster{Name: "db", Machines: []string{"m1", "m2"}, Primary: "m1"}
+
+	tests := []struct {
+		name     string
+		machines []*pb.MachineMember
+		records  []store.ContainerRecord
+		want     bool
+	}{
+		{
+			name:     "primary running",
+			machines: []*pb.MachineMember{member("m1", pb.MachineMember_UP), member("m2", pb.MachineMember_UP)},
+			records:  []store.ContainerRecord{record("db", "m1", true), record("db", "m2", true)},
+			want:     true,
+		},
+		{
+			name:     "suspect machine is available",
+			machines: []*pb.MachineMember{member("m1", pb.MachineMember_SUSPECT)},
+			records:  []store.ContainerRecord{record("db", "m1", true)},
+			want:     true,
+		},
+		{
+			name:     "primary machine down",
+			machines: []*pb.MachineMember{member("m1", pb.MachineMember_DOWN), member("m2", pb.MachineMember_UP)},
+			// The record of the container on the down machine is stale.
+			records: []store.ContainerRecord{record("db", "m1", true), record("db", "m2", true)},
+		},
+		{
+			name:     "primary machine removed",
+			machines: []*pb.MachineMember{member("m2", pb.MachineMember_UP)},
+			records:  []store.ContainerRecord{record("db", "m2", true)},
+		},
+		{
+			name:     "primary container stopped",
+			machines: []*pb.MachineMember{member("m1", pb.MachineMember_UP), member("m2", pb.MachineMember_UP)},
+			records:  []store.ContainerRecord{record("db", "m1", false), record("db", "m2", true)},
+		},
+		{
+			name:     "other service on primary machine",
+			machines: []*pb.MachineMember{member("m1", pb.MachineMember_UP)},
+			records:  []store.ContainerRecord{record("web", "m1", true)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PrimaryAvailable(c, tt.machines, tt.records))
+		})
+	}
+}
+
+func TestHasQuorum(t *testing.T) {
+	t.Parallel()
+
+	member := func(state pb.MachineMember_MembershipState) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{}, State: state}
+	}
+	up, suspect, down := pb.MachineMember_UP, pb.MachineMember_SUSPECT, pb.MachineMember_DOWN
+
+	assert.True(t, HasQuorum([]*pb.MachineMember{member(up)}))
+	assert.True(t, HasQuorum([]*pb.MachineMember{member(up), member(up)}))
+	assert.False(t, HasQuorum([]*pb.MachineMember{member(up), member(down)}),
+		"two machines must not have quorum with one of them down")
+	assert.True(t, HasQuorum([]*pb.MachineMember{member(up), member(suspect), member(down)}))
+	assert.False(t, HasQuorum([]*pb.MachineMember{member(up), member(down), member(down)}))
+}
+
+func TestAgent_WritePrimaryFiles(t *testing.T) {
+	t.Parallel()
+
+	a := &Agent{runDir: t.TempDir()}
+	c := api.PostgresCluster{Name: "db", Machines: []string{"m1", "m2"}, Primary: "m1"}
+	require.NoError(t, a.writePrimaryFiles([]api.PostgresCluster{c}))
+
+	path := filepath.Join(a.runDir, "db", api.PostgresPrimaryFileName)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "m1", string(data))
+
+	c.Primary = "m2"
+	require.NoError(t, a.writePrimaryFiles([]api.PostgresCluster{c}))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "m2", string(data))
+}
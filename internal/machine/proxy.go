@@ -0,0 +1,31 @@
+package machine
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/psviderski/uncloud/internal/machine/settings"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// useClusterProxy makes the outbound HTTP requests of the machine daemon that use the default HTTP transport go
+// through the outbound proxy from the cluster settings. The proxy from the environment variables of the daemon
+// is used if the cluster settings don't configure one.
+func useClusterProxy(watcher *settings.Watcher) {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		s := watcher.Get()
+		if !s.Proxy() {
+			return http.ProxyFromEnvironment(req)
+		}
+		config := httpproxy.Config{
+			HTTPProxy:  s.HTTPProxy,
+			HTTPSProxy: s.HTTPSProxy,
+			NoProxy:    s.NoProxyHosts(),
+		}
+		return config.ProxyFunc()(req.URL)
+	}
+}
@@ -0,0 +1,77 @@
+package machine
+
+import (
+	"context"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkProjectQuota rejects a container of a project service if the project would exceed its quota once the service
+// is deployed. The usage is calculated from the containers of other project services in the cluster store and
+// the containers the service would have once deployed. The deployment checks the quota of the whole project before
+// creating containers. This check enforces it for the operations that create containers of a single service, such as
+// 'uc run' and 'uc scale', and for clients that don't check the quota themselves.
+func (m *Machine) checkProjectQuota(ctx context.Context, serviceID string, spec api.ServiceSpec) error {
+	if spec.Project == "" {
+		return nil
+	}
+
+	quota, err := m.store.GetProjectQuota(ctx, spec.Project)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil
+		}
+		return status.Errorf(codes.Internal, "get project quota: %v", err)
+	}
+
+	records, err := m.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "list containers: %v", err)
+	}
+	m.state.mu.RLock()
+	machineID := m.state.ID
+	m.state.mu.RUnlock()
+
+	// The number of containers the service would have. A global service has one container per machine and
+	// a replicated service has the specified number of replicas.
+	containers := max(int(spec.Replicas), 1)
+	if spec.Mode == api.ServiceModeGlobal {
+		containers = 1
+	}
+	services := make(map[string]*api.Service)
+	for _, r := range records {
+		if r.Container.ServiceSpec.Project != spec.Project {
+			continue
+		}
+		id := r.Container.ServiceID()
+		if id == serviceID {
+			if spec.Mode == api.ServiceModeGlobal && r.MachineID != machineID {
+				containers++
+			}
+			continue
+		}
+		if services[id] == nil {
+			services[id] = &api.Service{ID: id}
+		}
+		services[id].Containers = append(services[id].Containers, api.MachineServiceContainer{
+			MachineID: r.MachineID,
+			Container: r.Container,
+		})
+	}
+
+	var usage api.ProjectUsage
+	for _, s := range services {
+		usage.AddDeployedService(*s)
+	}
+	usage.AddService(spec, containers)
+
+	if err = quota.Check(usage); err != nil {
+		return status.Errorf(codes.ResourceExhausted, "%v. Scale down or remove services, or raise the quota "+
+			"with 'uc quota set %s'", err, spec.Project)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// renumberUnit is the transient systemd unit that restarts the daemon after renumbering the machine subnet.
+const renumberUnit = "uncloud-renumber"
+
+// RenumberSubnet changes the subnet of the machine to the one planned by the cluster network migration in progress.
+// It updates the machine info in the cluster store so that other machines route the new subnet to this machine and
+// restarts the daemon to reconfigure the WireGuard interface, Docker network, and internal DNS server with
+// the new machine IP. The containers are reconnected to the recreated Docker network and get new IPs.
+func (m *Machine) RenumberSubnet(ctx context.Context, req *pb.RenumberSubnetRequest) (*emptypb.Empty, error) {
+	if !m.Initialised() {
+		return nil, status.Error(codes.FailedPrecondition, "machine is not initialised as a cluster member")
+	}
+	if req.Subnet == nil {
+		return nil, status.Error(codes.InvalidArgument, "subnet not set")
+	}
+	subnet, err := req.Subnet.ToPrefix()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid subnet: %v", err)
+	}
+
+	m.state.mu.RLock()
+	id, current := m.state.ID, m.state.Network.Subnet
+	m.state.mu.RUnlock()
+	if subnet == current {
+		return &emptypb.Empty{}, nil
+	}
+
+	migration, err := m.store.GetNetworkMigration(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, "no network migration in progress")
+		}
+		return nil, status.Errorf(codes.Internal, "get network migration: %v", err)
+	}
+	r, ok := migration.Machine(id)
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "machine is not part of the network migration")
+	}
+	planned := r.To
+	if migration.State == api.NetworkMigrationRollingBack {
+		planned = r.From
+	}
+	if subnet != planned {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"subnet %s doesn't match the subnet %s planned by the network migration", subnet, planned)
+	}
+
+	info, err := m.store.GetMachine(ctx, id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get machine: %v", err)
+	}
+	info.Network.Subnet = pb.NewIPPrefix(subnet)
+	if err = m.store.UpdateMachine(ctx, info); err != nil {
+		return nil, status.Errorf(codes.Internal, "update machine: %v", err)
+	}
+
+	m.state.mu.Lock()
+	m.state.Network.Subnet = subnet
+	err = m.state.Save()
+	m.state.mu.Unlock()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "save machine state: %v", err)
+	}
+
+	if err = scheduleScript(ctx, renumberUnit, fmt.Sprintf("systemctl restart %s", daemonUnit)); err != nil {
+		return nil, status.Errorf(codes.Internal, "schedule machine daemon restart: %v", err)
+	}
+	slog.Info("Machine subnet renumbered, restarting the daemon to apply it.", "from", current, "to", subnet)
+
+	return &emptypb.Empty{}, nil
+}
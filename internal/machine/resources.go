@@ -0,0 +1,227 @@
+package machine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// MinMemory is the minimum total memory of a machine to join the cluster. It's lower than the documented
+	// 512 MB minimum because the kernel reserves some memory and machines sold as 512 MB report less.
+	MinMemory = 448 * units.MiB
+	// MinDiskAvailable is the minimum available space on the filesystem with the Docker data root to join
+	// the cluster. It's required to pull the images of the system services.
+	MinDiskAvailable = units.GiB
+	// cpuSampleInterval is the period over which the CPU usage is measured.
+	cpuSampleInterval = 500 * time.Millisecond
+)
+
+// collectResources returns the hardware and software inventory of the machine reported by Docker. The disk size
+// is the size of the filesystem with the Docker data root. It's left zero if the filesystem can't be inspected,
+// e.g. when Docker runs in a VM.
+func collectResources(ctx context.Context, dockerCli *client.Client) (*pb.MachineResources, int64, error) {
+	info, err := dockerCli.Info(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get Docker info: %w", err)
+	}
+
+	resources := &pb.MachineResources{
+		Cpus:          int32(info.NCPU),
+		Memory:        info.MemTotal,
+		Kernel:        info.KernelVersion,
+		Os:            info.OperatingSystem,
+		DockerVersion: info.ServerVersion,
+	}
+	var available int64
+	if info.DockerRootDir != "" {
+		if space, err := diskSpace(info.DockerRootDir); err == nil {
+			resources.Disk = space.total
+			available = space.available
+		}
+	}
+
+	return resources, available, nil
+}
+
+type fsSpace struct {
+	total     int64
+	used      int64
+	available int64
+}
+
+// Usage returns the current CPU, memory, and disk usage of the machine. The CPU usage is measured over
+// cpuSampleInterval. The disk usage is left zero if the filesystem with the Docker data root can't be inspected.
+func (m *Machine) Usage(ctx context.Context, _ *emptypb.Empty) (*pb.MachineUsage, error) {
+	cpu, err := cpuUsage(ctx, cpuSampleInterval)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "measure CPU usage: %v", err)
+	}
+	meminfo, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "read memory info: %v", err)
+	}
+	memory, err := parseMemoryUsage(meminfo)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "parse memory info: %v", err)
+	}
+	usage := &pb.MachineUsage{
+		Cpu:    cpu,
+		Memory: memory,
+	}
+
+	info, err := m.dockerService.Client.Info(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "get Docker info: %v", err)
+	}
+	if info.DockerRootDir != "" {
+		if space, err := diskSpace(info.DockerRootDir); err == nil {
+			usage.Disk = space.used
+		}
+	}
+
+	return usage, nil
+}
+
+// cpuUsage returns the number of CPU cores in use averaged over the given interval.
+func cpuUsage(ctx context.Context, interval time.Duration) (float64, error) {
+	before, err := readCPUStat()
+	if err != nil {
+		return 0, err
+	}
+	select {
+	case <-time.After(interval):
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	after, err := readCPUStat()
+	if err != nil {
+		return 0, err
+	}
+
+	total := after.total - before.total
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(after.busy-before.busy) / float64(total) * float64(after.cpus), nil
+}
+
+type cpuStat struct {
+	// busy and total are the cumulative busy and total CPU time of all CPUs in clock ticks.
+	busy  uint64
+	total uint64
+	cpus  int
+}
+
+func readCPUStat() (cpuStat, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return cpuStat{}, fmt.Errorf("read CPU stat: %w", err)
+	}
+	return parseCPUStat(data)
+}
+
+// parseCPUStat parses the aggregate CPU times and the number of CPUs from the content of /proc/stat.
+// The idle and iowait times are considered idle.
+func parseCPUStat(data []byte) (cpuStat, error) {
+	var stat cpuStat
+	found := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		if fields[0] != "cpu" {
+			stat.cpus++
+			continue
+		}
+		if len(fields) < 6 {
+			return stat, fmt.Errorf("invalid aggregate CPU line: %q", scanner.Text())
+		}
+		// Only the first 8 values (user to steal) are summed up. Guest times are already included in user and nice.
+		for i, f := range fields[1:min(len(fields), 9)] {
+			v, err := strconv.ParseUint(f, 10, 64)
+			if err != nil {
+				return stat, fmt.Errorf("parse CPU time %q: %w", f, err)
+			}
+			stat.total += v
+			// idle (4th) and iowait (5th) values.
+			if i != 3 && i != 4 {
+				stat.busy += v
+			}
+		}
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return stat, err
+	}
+	if !found {
+		return stat, errors.New("aggregate CPU line not found")
+	}
+	return stat, nil
+}
+
+// parseMemoryUsage returns the memory in use in bytes from the content of /proc/meminfo calculated as
+// MemTotal - MemAvailable.
+func parseMemoryUsage(data []byte) (int64, error) {
+	values := make(map[string]int64)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		key := strings.TrimSuffix(fields[0], ":")
+		if key != "MemTotal" && key != "MemAvailable" {
+			continue
+		}
+		v, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse %s: %w", key, err)
+		}
+		// The values are in kibibytes.
+		values[key] = v * units.KiB
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	total, ok := values["MemTotal"]
+	if !ok {
+		return 0, errors.New("MemTotal not found")
+	}
+	available, ok := values["MemAvailable"]
+	if !ok {
+		return 0, errors.New("MemAvailable not found")
+	}
+	return total - available, nil
+}
+
+// checkResources verifies the machine has enough memory and available disk space to run the cluster services.
+// The disk space is not checked if it's unknown.
+func checkResources(resources *pb.MachineResources, diskAvailable int64) error {
+	if resources.Memory < MinMemory {
+		return fmt.Errorf("machine has %s of memory but at least 512MB is required",
+			units.BytesSize(float64(resources.Memory)))
+	}
+	if resources.Disk > 0 && diskAvailable < MinDiskAvailable {
+		return fmt.Errorf("only %s of disk space is available for Docker but at least %s is required",
+			units.BytesSize(float64(diskAvailable)), units.BytesSize(MinDiskAvailable))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		resources     *pb.MachineResources
+		diskAvailable int64
+		wantErr       string
+	}{
+		{
+			name:          "sufficient",
+			resources:     &pb.MachineResources{Cpus: 1, Memory: 480 * units.MiB, Disk: 10 * units.GiB},
+			diskAvailable: 5 * units.GiB,
+		},
+		{
+			name:          "not enough memory",
+			resources:     &pb.MachineResources{Cpus: 1, Memory: 256 * units.MiB, Disk: 10 * units.GiB},
+			diskAvailable: 5 * units.GiB,
+			wantErr:       "machine has 256MiB of memory",
+		},
+		{
+			name:          "not enough disk space",
+			resources:     &pb.MachineResources{Cpus: 1, Memory: units.GiB, Disk: 10 * units.GiB},
+			diskAvailable: 100 * units.MiB,
+			wantErr:       "only 100MiB of disk space is available",
+		},
+		{
+			name:      "unknown disk",
+			resources: &pb.MachineResources{Cpus: 1, Memory: units.GiB},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkResources(tt.resources, tt.diskAvailable)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseCPUStat(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`cpu  100 10 50 800 40 0 0 0 0 0
+cpu0 50 5 25 400 20 0 0 0 0 0
+cpu1 50 5 25 400 20 0 0 0 0 0
+intr 12345
+ctxt 6789
+`)
+	stat, err := parseCPUStat(data)
+	require.NoError(t, err)
+	assert.Equal(t, cpuStat{busy: 160, total: 1000, cpus: 2}, stat)
+
+	_, err = parseCPUStat([]byte("intr 12345\n"))
+	assert.Error(t, err)
+}
+
+func TestParseMemoryUsage(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`MemTotal:        2048000 kB
+MemFree:          100000 kB
+MemAvailable:    1024000 kB
+Buffers:           50000 kB
+`)
+	used, err := parseMemoryUsage(data)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024000*1024), used)
+
+	_, err = parseMemoryUsage([]byte("MemTotal: 2048000 kB\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package scaling
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"golang.org/x/net/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultInterval is the interval at which the controller evaluates the scale schedules of the services.
+const DefaultInterval = time.Minute
+
+// MachineLister lists the cluster machines with their membership states.
+type MachineLister interface {
+	ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error)
+}
+
+// Controller scales the replicated services with a scale schedule to the number of replicas of the currently active
+// window. Only the leader, the first UP machine ordered by ID, evaluates the schedules. If the leader goes down,
+// the next UP machine takes over. A service is redeployed with its current spec when the number of its containers
+// differs from the number of replicas the schedule sets now.
+type Controller struct {
+	machineID string
+	store     *store.Store
+	machines  MachineLister
+	// apiAddr is the address of the machine API used to redeploy the services.
+	apiAddr  netip.AddrPort
+	interval time.Duration
+	log      *slog.Logger
+}
+
+func NewController(
+	machineID string, store *store.Store, machines MachineLister, apiAddr netip.AddrPort,
+) *Controller {
+	return &Controller{
+		machineID: machineID,
+		store:     store,
+		machines:  machines,
+		apiAddr:   apiAddr,
+		interval:  DefaultInterval,
+		log:       slog.With("component", "scale-scheduler"),
+	}
+}
+
+// Run evaluates the scale schedules every interval until the context is canceled.
+func (c *Controller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.reconcile(ctx); err != nil {
+				c.log.Error("Failed to scale services by schedule.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Controller) reconcile(ctx context.Context) error {
+	resp, err := c.machines.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if Leader(resp.Machines) != c.machineID {
+		return nil
+	}
+
+	if f, err := c.store.GetDeployFreeze(ctx); err == nil && f.Active(time.Now()) {
+		// Scaling by schedule is a deploy operation blocked by the freeze. The services are scaled once it's lifted.
+		return nil
+	}
+
+	records, err := c.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+	changes := ScheduledChanges(records, time.Now())
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Deploy the services through the machine API the same way as the CLI does.
+	cli, err := client.New(ctx, &apiConnector{addr: c.apiAddr})
+	if err != nil {
+		return fmt.Errorf("create cluster client: %w", err)
+	}
+	defer cli.Close()
+
+	for _, ch := range changes {
+		c.log.Info("Scaling service by schedule.", "service", ch.ServiceName,
+			"from", ch.Containers, "to", ch.Replicas)
+		if err = c.redeploy(ctx, cli, ch); err != nil {
+			c.log.Error("Failed to scale service by schedule.", "service", ch.ServiceName, "err", err)
+		}
+	}
+	return nil
+}
+
+// redeploy redeploys the service with its current spec. The deployment sets the number of replicas from
+// the schedule.
+func (c *Controller) redeploy(ctx context.Context, cli *client.Client, ch Change) error {
+	svc, err := cli.InspectService(ctx, ch.ServiceID)
+	if err != nil {
+		return fmt.Errorf("inspect service: %w", err)
+	}
+	deployment := cli.NewDeployment(ch.Spec, nil)
+	deployment.Service = &svc
+	if _, err = deployment.Run(ctx); err != nil {
+		return fmt.Errorf("deploy service: %w", err)
+	}
+	return nil
+}
+
+// Change is a service whose number of replicas has to be changed to match its scale schedule.
+type Change struct {
+	ServiceID   string
+	ServiceName string
+	// Spec is the current spec of the service.
+	Spec api.ServiceSpec
+	// Containers is the current number of containers of the service.
+	Containers uint
+	// Replicas is the number of replicas the schedule sets.
+	Replicas uint
+}
+
+// ScheduledChanges returns the replicated services with a scale schedule whose number of containers differs from
+// the number of replicas the schedule sets at the given time. Stopped containers are counted too so that crashed
+// containers don't trigger a redeployment. The spec of the most recently created container of a service is
+// considered its current spec. The changes are sorted by service name.
+func ScheduledChanges(records []store.ContainerRecord, now time.Time) []Change {
+	latest := make(map[string]api.ServiceContainer)
+	count := make(map[string]uint)
+	for _, r := range records {
+		id := r.Container.ServiceID()
+		count[id]++
+		if l, ok := latest[id]; !ok || r.Container.CreatedTime().After(l.CreatedTime()) {
+			latest[id] = r.Container
+		}
+	}
+
+	var changes []Change
+	for id, ctr := range latest {
+		spec := ctr.ServiceSpec.SetDefaults()
+		if spec.ScaleSchedule == nil || spec.Mode != api.ServiceModeReplicated {
+			continue
+		}
+		if replicas := spec.ScaleSchedule.ReplicasAt(now); replicas != count[id] {
+			changes = append(changes, Change{
+				ServiceID:   id,
+				ServiceName: ctr.ServiceName(),
+				Spec:        ctr.ServiceSpec,
+				Containers:  count[id],
+				Replicas:    replicas,
+			})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int {
+		return cmp.Compare(a.ServiceName, b.ServiceName)
+	})
+	return changes
+}
+
+// Leader returns the ID of the first UP machine ordered by ID or an empty string if no machine is UP.
+func Leader(machines []*pb.MachineMember) string {
+	var leader string
+	for _, m := range machines {
+		if m.State == pb.MachineMember_UP && (leader == "" || m.Machine.Id < leader) {
+			leader = m.Machine.Id
+		}
+	}
+	return leader
+}
+
+// apiConnector connects to the machine API over TCP. pkg/client/connector can't be used as it depends on
+// the machine package.
+type apiConnector struct {
+	addr netip.AddrPort
+}
+
+func (c *apiConnector) Connect(_ context.Context) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(c.addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("create machine API client: %w", err)
+	}
+	return conn, nil
+}
+
+func (c *apiConnector) Dialer() (proxy.ContextDialer, error) {
+	return nil, fmt.Errorf("proxy connections are not supported over a TCP connection")
+}
+
+func (c *apiConnector) Close() error {
+	return nil
+}
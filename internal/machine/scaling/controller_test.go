@@ -0,0 +1,87 @@
+package scaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledChanges(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC) // Monday.
+	record := func(id, service string, created time.Time, spec api.ServiceSpec) store.ContainerRecord {
+		spec.Name = service
+		return store.ContainerRecord{
+			MachineID: "m1",
+			Container: api.ServiceContainer{
+				Container: api.Container{ContainerJSON: types.ContainerJSON{
+					ContainerJSONBase: &types.ContainerJSONBase{
+						ID:      id,
+						Created: created.Format(time.RFC3339Nano),
+						State:   &types.ContainerState{Running: true},
+					},
+					Config: &container.Config{Labels: map[string]string{
+						api.LabelServiceID:   "svc-" + service,
+						api.LabelServiceName: service,
+					}},
+				}},
+				ServiceSpec: spec,
+			},
+		}
+	}
+	schedule := &api.ScaleSchedule{
+		Replicas: 1,
+		Windows:  []api.ScaleWindow{{Window: "Mon-Fri 08:00-20:00", Replicas: 2}},
+	}
+	oldSchedule := &api.ScaleSchedule{
+		Replicas: 1,
+		Windows:  []api.ScaleWindow{{Window: "Sat 08:00-20:00", Replicas: 2}},
+	}
+
+	changes := ScheduledChanges([]store.ContainerRecord{
+		// Scaled down by the schedule.
+		record("w1", "web", now.Add(-time.Hour), api.ServiceSpec{ScaleSchedule: oldSchedule}),
+		// The most recent container has the current spec.
+		record("w2", "web", now.Add(-time.Minute), api.ServiceSpec{ScaleSchedule: schedule}),
+		record("w3", "web", now.Add(-time.Minute), api.ServiceSpec{ScaleSchedule: schedule}),
+		// Already runs the scheduled number of replicas.
+		record("a1", "api", now, api.ServiceSpec{ScaleSchedule: schedule}),
+		record("a2", "api", now, api.ServiceSpec{ScaleSchedule: schedule}),
+		// Scaled up by the schedule.
+		record("b1", "batch", now, api.ServiceSpec{ScaleSchedule: schedule, Replicas: 1}),
+		// Without a schedule.
+		record("d1", "db", now, api.ServiceSpec{Replicas: 3}),
+	}, now)
+
+	require.Len(t, changes, 2)
+	assert.Equal(t, "batch", changes[0].ServiceName)
+	assert.Equal(t, uint(1), changes[0].Containers)
+	assert.Equal(t, uint(2), changes[0].Replicas)
+	assert.Equal(t, "web", changes[1].ServiceName)
+	assert.Equal(t, "svc-web", changes[1].ServiceID)
+	assert.Equal(t, uint(3), changes[1].Containers)
+	assert.Equal(t, uint(2), changes[1].Replicas)
+	assert.Equal(t, schedule, changes[1].Spec.ScaleSchedule)
+}
+
+func TestLeader(t *testing.T) {
+	t.Parallel()
+
+	member := func(id string, state pb.MachineMember_MembershipState) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{Id: id}, State: state}
+	}
+	assert.Equal(t, "b", Leader([]*pb.MachineMember{
+		member("c", pb.MachineMember_UP),
+		member("a", pb.MachineMember_DOWN),
+		member("b", pb.MachineMember_UP),
+	}))
+	assert.Empty(t, Leader([]*pb.MachineMember{member("a", pb.MachineMember_DOWN)}))
+}
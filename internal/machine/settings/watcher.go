@@ -0,0 +1,136 @@
+// Package settings keeps the cluster-wide settings of the machine up to date with the cluster store so that
+// the machine components can apply the changes without restarting the daemon.
+package settings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// Subscriber subscribes to the cluster-wide settings in the cluster store.
+type Subscriber interface {
+	SubscribeSettings(ctx context.Context) (api.ClusterSettings, <-chan struct{}, error)
+	GetSettings(ctx context.Context) (api.ClusterSettings, error)
+}
+
+// Watcher watches the cluster-wide settings in the cluster store and notifies the subscribed components when they
+// change. Zero settings are returned until the settings are loaded from the store.
+type Watcher struct {
+	store       Subscriber
+	mu          sync.RWMutex
+	settings    api.ClusterSettings
+	subscribers []chan struct{}
+}
+
+func NewWatcher(store Subscriber) *Watcher {
+	return &Watcher{store: store}
+}
+
+// Get returns the current cluster-wide settings. It's safe to call on a nil watcher which returns zero settings.
+func (w *Watcher) Get() api.ClusterSettings {
+	if w == nil {
+		return api.ClusterSettings{}
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.settings
+}
+
+// Subscribe returns a channel that receives a value when the settings change. Multiple changes that occur while
+// the value hasn't been received yet are coalesced into one. A nil watcher returns a nil channel that never
+// receives a value.
+func (w *Watcher) Subscribe() <-chan struct{} {
+	if w == nil {
+		return nil
+	}
+	ch := make(chan struct{}, 1)
+	w.mu.Lock()
+	w.subscribers = append(w.subscribers, ch)
+	w.mu.Unlock()
+	return ch
+}
+
+// Run subscribes to the settings in the cluster store and keeps them up to date until the context is canceled.
+func (w *Watcher) Run(ctx context.Context) error {
+	for {
+		// Retry to subscribe to settings changes indefinitely until the context is done.
+		boff := backoff.WithContext(backoff.NewExponentialBackOff(
+			backoff.WithInitialInterval(1*time.Second),
+			backoff.WithMaxInterval(60*time.Second),
+			backoff.WithMaxElapsedTime(0),
+		), ctx)
+
+		var (
+			settings api.ClusterSettings
+			changes  <-chan struct{}
+			err      error
+		)
+		subscribe := func() error {
+			if settings, changes, err = w.store.SubscribeSettings(ctx); err != nil {
+				slog.Info("Failed to subscribe to cluster settings changes, retrying.", "err", err)
+			}
+			return err
+		}
+		if err = backoff.Retry(subscribe, boff); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			slog.Error("Unexpected error while retrying to subscribe to cluster settings changes.", "err", err)
+			continue
+		}
+		w.update(settings)
+
+		if !w.watch(ctx, changes) {
+			return nil
+		}
+		// The subscription failed, resubscribe.
+	}
+}
+
+// watch updates the settings on every change until the context is canceled or the changes channel is closed.
+// It returns false if the context is canceled.
+func (w *Watcher) watch(ctx context.Context, changes <-chan struct{}) bool {
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return ctx.Err() == nil
+			}
+			settings, err := w.store.GetSettings(ctx)
+			if err != nil {
+				slog.Error("Failed to get cluster settings.", "err", err)
+				continue
+			}
+			w.update(settings)
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// update sets the current settings and notifies the subscribers if they changed.
+func (w *Watcher) update(settings api.ClusterSettings) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if reflect.DeepEqual(settings, w.settings) {
+		return
+	}
+	w.settings = settings
+	slog.Info("Cluster settings updated.", "version", settings.Version)
+
+	for _, ch := range w.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// The subscriber hasn't received the previous notification yet.
+		}
+	}
+}
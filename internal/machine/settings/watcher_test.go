@@ -0,0 +1,99 @@
+package settings
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	mu       sync.Mutex
+	settings api.ClusterSettings
+	changes  chan struct{}
+}
+
+func (s *fakeStore) SubscribeSettings(context.Context) (api.ClusterSettings, <-chan struct{}, error) {
+	return s.get(), s.changes, nil
+}
+
+func (s *fakeStore) GetSettings(context.Context) (api.ClusterSettings, error) {
+	return s.get(), nil
+}
+
+func (s *fakeStore) get() api.ClusterSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.settings
+}
+
+func (s *fakeStore) put(settings api.ClusterSettings) {
+	s.mu.Lock()
+	s.settings = settings
+	s.mu.Unlock()
+	s.changes <- struct{}{}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{
+		settings: api.ClusterSettings{Version: 1, Domain: "example.com"},
+		changes:  make(chan struct{}),
+	}
+	w := NewWatcher(store)
+	changes := w.Subscribe()
+	assert.Equal(t, api.ClusterSettings{}, w.Get())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the initial settings")
+	}
+	assert.Equal(t, "example.com", w.Get().Domain)
+
+	store.put(api.ClusterSettings{Version: 2, Domain: "example.org"})
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the updated settings")
+	}
+	assert.Equal(t, int64(2), w.Get().Version)
+	assert.Equal(t, "example.org", w.Get().Domain)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestWatcher_Update(t *testing.T) {
+	t.Parallel()
+
+	w := NewWatcher(nil)
+	changes := w.Subscribe()
+
+	// Unchanged settings don't notify the subscribers.
+	w.update(api.ClusterSettings{})
+	assert.Empty(t, changes)
+
+	// Multiple changes are coalesced into one notification.
+	w.update(api.ClusterSettings{Version: 1})
+	w.update(api.ClusterSettings{Version: 2})
+	assert.Len(t, changes, 1)
+	assert.Equal(t, int64(2), w.Get().Version)
+}
+
+func TestWatcher_GetNil(t *testing.T) {
+	t.Parallel()
+
+	var w *Watcher
+	assert.Equal(t, api.ClusterSettings{}, w.Get())
+}
@@ -0,0 +1,188 @@
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/docker"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	ShutdownStateFileName = "shutdown.json"
+	// shutdownPropagationDelay is the time to wait after stopping the containers for the cordoned machine and stopped
+	// containers to propagate to other machines through the cluster store before the machine goes down.
+	shutdownPropagationDelay = 3 * time.Second
+)
+
+// ShutdownState is the state of the machine saved when it's prepared for a shutdown. It's used to start the containers
+// stopped on shutdown when the machine boots again or the shutdown is cancelled.
+type ShutdownState struct {
+	// BootID is the kernel boot ID of the machine when it was prepared for the shutdown.
+	BootID string
+	// StoppedContainers is the list of IDs of the service containers stopped on shutdown.
+	StoppedContainers []string
+}
+
+// ShutdownStatePath returns the path to the shutdown state file within the given data directory.
+func ShutdownStatePath(dataDir string) string {
+	return filepath.Join(dataDir, ShutdownStateFileName)
+}
+
+// ParseShutdownState reads and decodes a shutdown state from the file at the given path.
+func ParseShutdownState(path string) (*ShutdownState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read shutdown state file: %w", err)
+	}
+	var state ShutdownState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse shutdown state file %q: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the shutdown state to the file at the given path.
+func (s *ShutdownState) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal shutdown state: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write shutdown state file %q: %w", path, err)
+	}
+	return nil
+}
+
+// PrepareShutdown prepares the machine for a planned shutdown or reboot: it cordons the machine in the cluster,
+// gracefully stops the service containers, and waits for the changes to propagate to other machines so that they
+// stop routing traffic to the machine before it goes down. It's a no-op if the machine is not a cluster member.
+func (m *Machine) PrepareShutdown(ctx context.Context) error {
+	m.mu.RLock()
+	clusterCtrl := m.clusterCtrl
+	m.mu.RUnlock()
+	if clusterCtrl == nil {
+		return nil
+	}
+	return clusterCtrl.prepareShutdown(ctx)
+}
+
+// CancelShutdown reverts PrepareShutdown when the planned shutdown has been cancelled: it starts the containers
+// stopped on shutdown and uncordons the machine.
+func (m *Machine) CancelShutdown(ctx context.Context) error {
+	m.mu.RLock()
+	clusterCtrl := m.clusterCtrl
+	m.mu.RUnlock()
+	if clusterCtrl == nil {
+		return nil
+	}
+	return clusterCtrl.cancelShutdown(ctx)
+}
+
+func (cc *clusterController) prepareShutdown(ctx context.Context) error {
+	cc.shuttingDown.Store(true)
+	slog.Info("Preparing machine for shutdown.")
+
+	var errs []error
+	if err := cc.store.CordonMachine(ctx, cc.state.ID, api.CordonReasonShutdown); err != nil {
+		errs = append(errs, fmt.Errorf("cordon machine: %w", err))
+	}
+
+	stopped, err := cc.dockerCtrl.StopServiceContainers(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("stop service containers: %w", err))
+	}
+	slog.Info("Stopped service containers before shutdown.", "containers", len(stopped))
+
+	state := ShutdownState{StoppedContainers: stopped}
+	if state.BootID, _, err = currentBoot(); err != nil {
+		errs = append(errs, err)
+	}
+	if err = state.Save(ShutdownStatePath(cc.dataDir)); err != nil {
+		errs = append(errs, fmt.Errorf("save shutdown state: %w", err))
+	}
+
+	select {
+	case <-time.After(shutdownPropagationDelay):
+	case <-ctx.Done():
+	}
+	slog.Info("Machine prepared for shutdown.")
+
+	return errors.Join(errs...)
+}
+
+func (cc *clusterController) cancelShutdown(ctx context.Context) error {
+	slog.Info("Machine shutdown cancelled, restoring service containers.")
+
+	var errs []error
+	for _, a := range cc.startShutdownStoppedContainers(ctx) {
+		if a.Err != nil {
+			errs = append(errs, fmt.Errorf("start container '%s': %w", a.Container, a.Err))
+		}
+	}
+
+	cc.shuttingDown.Store(false)
+	if err := cc.store.UncordonMachine(ctx, cc.state.ID, api.CordonReasonShutdown); err != nil {
+		errs = append(errs, fmt.Errorf("uncordon machine: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// startShutdownStoppedContainers starts the containers stopped by prepareShutdown if the shutdown state file exists
+// and removes the file.
+func (cc *clusterController) startShutdownStoppedContainers(ctx context.Context) []docker.ContainerRecovery {
+	path := ShutdownStatePath(cc.dataDir)
+	state, err := ParseShutdownState(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Error("Failed to read shutdown state.", "err", err)
+		}
+		return nil
+	}
+
+	actions := cc.dockerCtrl.StartContainers(ctx, state.StoppedContainers)
+	for _, a := range actions {
+		if a.Err != nil {
+			slog.Error("Failed to start container stopped on shutdown.", "container", a.Container, "err", a.Err)
+		} else {
+			slog.Info("Started container stopped on shutdown.", "container", a.Container)
+		}
+	}
+
+	if err = os.Remove(path); err != nil {
+		slog.Error("Failed to remove shutdown state file.", "path", path, "err", err)
+	}
+	return actions
+}
+
+// uncordon clears the cordon of the current machine in the cluster store set before the shutdown when the machine
+// has started again. Cordons set for other reasons, e.g. a drain, are kept.
+func (cc *clusterController) uncordon(ctx context.Context, machines []*pb.MachineInfo) {
+	if cc.shuttingDown.Load() {
+		return
+	}
+	for _, m := range machines {
+		if m.Id != cc.state.ID {
+			continue
+		}
+		if !m.Cordoned || (len(m.CordonReasons) > 0 && !slices.Contains(m.CordonReasons, api.CordonReasonShutdown)) {
+			return
+		}
+
+		if err := cc.store.UncordonMachine(ctx, m.Id, api.CordonReasonShutdown); err != nil {
+			slog.Error("Failed to uncordon machine in cluster store.", "err", err)
+			return
+		}
+		slog.Info("Uncordoned machine in cluster store.")
+		return
+	}
+}
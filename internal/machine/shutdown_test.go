@@ -0,0 +1,27 @@
+package machine
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownStateSaveParse(t *testing.T) {
+	t.Parallel()
+
+	path := ShutdownStatePath(t.TempDir())
+	_, err := ParseShutdownState(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	state := ShutdownState{
+		BootID:            "8a8b2c1e-7d1b-4e5f-9a2c-3b4d5e6f7a8b",
+		StoppedContainers: []string{"4f1c2d3e", "9a8b7c6d"},
+	}
+	require.NoError(t, state.Save(path))
+
+	parsed, err := ParseShutdownState(path)
+	require.NoError(t, err)
+	assert.Equal(t, state, *parsed)
+}
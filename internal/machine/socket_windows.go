@@ -0,0 +1,12 @@
+package machine
+
+import (
+	"errors"
+	"net"
+)
+
+// listenUnixSocket is not supported on Windows as the machine daemon only runs on Linux. The package still builds
+// on Windows because the CLI depends on its client-facing parts such as tokens and default paths.
+func listenUnixSocket(string, string) (net.Listener, error) {
+	return nil, errors.New("machine API Unix socket is not supported on Windows")
+}
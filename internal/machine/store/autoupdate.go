@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// autoUpdateKey is the key used to store the automatic OS updates configuration in the store.
+const autoUpdateKey = "auto_update"
+
+// AutoUpdateConfig is the cluster-wide configuration of the automatic OS updates.
+type AutoUpdateConfig struct {
+	Enabled bool
+	// Window is the recurring maintenance window in UTC, e.g. "Sun 03:00-05:00".
+	Window string
+}
+
+// MachineUpdateState is the state of the automatic OS update of a machine within a maintenance window.
+type MachineUpdateState string
+
+const (
+	MachineUpdateDraining  MachineUpdateState = "draining"
+	MachineUpdateUpdating  MachineUpdateState = "updating"
+	MachineUpdateRebooting MachineUpdateState = "rebooting"
+	MachineUpdateDone      MachineUpdateState = "done"
+	MachineUpdateFailed    MachineUpdateState = "failed"
+)
+
+// MachineUpdateRecord is the status of the last automatic OS update of a machine.
+type MachineUpdateRecord struct {
+	MachineID string
+	// WindowStart is the start of the maintenance window the update was run in.
+	WindowStart time.Time
+	State       MachineUpdateState
+	Error       string
+	// DrainedContainers are the IDs of the containers stopped to drain the machine that must be started again
+	// after the update.
+	DrainedContainers []string
+	UpdatedAt         time.Time
+}
+
+// GetAutoUpdateConfig returns the automatic OS updates configuration. A zero config is returned if it's not set.
+func (s *Store) GetAutoUpdateConfig(ctx context.Context) (AutoUpdateConfig, error) {
+	var config AutoUpdateConfig
+	var configJSON []byte
+	if err := s.Get(ctx, autoUpdateKey, &configJSON); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return config, nil
+		}
+		return config, err
+	}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return config, fmt.Errorf("unmarshal auto-update config: %w", err)
+	}
+	return config, nil
+}
+
+// PutAutoUpdateConfig stores the automatic OS updates configuration.
+func (s *Store) PutAutoUpdateConfig(ctx context.Context, config AutoUpdateConfig) error {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal auto-update config: %w", err)
+	}
+	return s.Put(ctx, autoUpdateKey, configJSON)
+}
+
+// PutMachineUpdate creates or replaces the automatic OS update status of a machine.
+func (s *Store) PutMachineUpdate(ctx context.Context, r MachineUpdateRecord) error {
+	drainedJSON, err := json.Marshal(r.DrainedContainers)
+	if err != nil {
+		return fmt.Errorf("marshal drained containers: %w", err)
+	}
+	if r.DrainedContainers == nil {
+		drainedJSON = []byte("[]")
+	}
+
+	_, err = s.corro.ExecContext(ctx, `
+		INSERT OR REPLACE INTO machine_updates (machine_id, window_start, state, error, drained_containers, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		r.MachineID, r.WindowStart.UTC().Format(time.DateTime), string(r.State), r.Error, string(drainedJSON),
+		time.Now().UTC().Format(time.DateTime))
+	if err != nil {
+		return fmt.Errorf("insert query: %w", err)
+	}
+	return nil
+}
+
+// ListMachineUpdates returns the automatic OS update statuses of all machines that have been updated at least once.
+func (s *Store) ListMachineUpdates(ctx context.Context) ([]MachineUpdateRecord, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT machine_id, window_start, state, error, drained_containers, updated_at FROM machine_updates "+
+			"ORDER BY machine_id")
+	if err != nil {
+		return nil, fmt.Errorf("select query: %w", err)
+	}
+	defer rows.Close()
+
+	var records []MachineUpdateRecord
+	var windowStartStr, updatedAtStr, state, drainedJSON string
+	for rows.Next() {
+		var r MachineUpdateRecord
+		if err = rows.Scan(&r.MachineID, &windowStartStr, &state, &r.Error, &drainedJSON, &updatedAtStr); err != nil {
+			return nil, fmt.Errorf("scan machine update record: %w", err)
+		}
+		if err = json.Unmarshal([]byte(drainedJSON), &r.DrainedContainers); err != nil {
+			return nil, fmt.Errorf("unmarshal drained containers: %w", err)
+		}
+		r.State = MachineUpdateState(state)
+		if r.WindowStart, err = time.Parse(time.DateTime, windowStartStr); err != nil {
+			return nil, fmt.Errorf("parse window_start: %w", err)
+		}
+		if r.UpdatedAt, err = time.Parse(time.DateTime, updatedAtStr); err != nil {
+			return nil, fmt.Errorf("parse updated_at: %w", err)
+		}
+		records = append(records, r)
+	}
+
+	return records, rows.Err()
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// backupStorageKey is the key used to store the backup storage configuration in the store.
+const backupStorageKey = "backup_storage"
+
+// GetBackupStorage returns the configuration of the object storage for service backups. A zero config is returned
+// if it's not set.
+func (s *Store) GetBackupStorage(ctx context.Context) (api.BackupStorage, error) {
+	var config api.BackupStorage
+	var configJSON []byte
+	if err := s.Get(ctx, backupStorageKey, &configJSON); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return config, nil
+		}
+		return config, err
+	}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return config, fmt.Errorf("unmarshal backup storage config: %w", err)
+	}
+	return config, nil
+}
+
+// PutBackupStorage stores the configuration of the object storage for service backups.
+func (s *Store) PutBackupStorage(ctx context.Context, config api.BackupStorage) error {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal backup storage config: %w", err)
+	}
+	return s.Put(ctx, backupStorageKey, configJSON)
+}
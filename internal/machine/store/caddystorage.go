@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// caddyStorageKeyPrefix is the prefix of the keys used to store the items of the Caddy storage in the store.
+const caddyStorageKeyPrefix = "caddy_storage/"
+
+// caddyStorageKeysKey is the key used to store the encryption key of the Caddy storage sealed for each ingress machine.
+// It must not match caddyStorageKeyPrefix.
+const caddyStorageKeysKey = "caddy_storage_keys"
+
+// CaddyStorageItem is a file or a directory of files in the Caddy storage, e.g. a certificate with its private key,
+// or an ACME challenge token, replicated to the ingress machines so that every Caddy can use it.
+type CaddyStorageItem struct {
+	// Files are the contents of the files by their names encrypted with the Caddy storage key. A directory item
+	// contains all its files so that they are always replaced together.
+	Files map[string][]byte
+	// Modified is the latest modification time of the files on the machine that stored the item.
+	Modified time.Time
+	// MachineID is the ID of the machine that stored the item.
+	MachineID string
+}
+
+// CaddyStorageKey is the encryption key of the Caddy storage sealed for a machine.
+type CaddyStorageKey struct {
+	// PublicKey is the WireGuard public key of the machine the key is sealed with.
+	PublicKey []byte
+	Sealed    []byte
+}
+
+// GetCaddyStorageKeys returns the encryption key of the Caddy storage sealed for each ingress machine by their IDs.
+func (s *Store) GetCaddyStorageKeys(ctx context.Context) (map[string]CaddyStorageKey, error) {
+	var keysJSON string
+	if err := s.Get(ctx, caddyStorageKeysKey, &keysJSON); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var keys map[string]CaddyStorageKey
+	if err := json.Unmarshal([]byte(keysJSON), &keys); err != nil {
+		return nil, fmt.Errorf("unmarshal caddy storage keys: %w", err)
+	}
+	return keys, nil
+}
+
+// PutCaddyStorageKeys stores the encryption key of the Caddy storage sealed for each ingress machine by their IDs.
+// All keys are stored in a single item so that concurrent updates converge to the keys written by one machine.
+func (s *Store) PutCaddyStorageKeys(ctx context.Context, keys map[string]CaddyStorageKey) error {
+	keysJSON, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("marshal caddy storage keys: %w", err)
+	}
+	return s.Put(ctx, caddyStorageKeysKey, string(keysJSON))
+}
+
+// PutCaddyStorageItem stores the item of the Caddy storage with the key that is the slash-separated path of the file
+// relative to the storage root.
+func (s *Store) PutCaddyStorageItem(ctx context.Context, key string, item CaddyStorageItem) error {
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("marshal caddy storage item: %w", err)
+	}
+	return s.Put(ctx, caddyStorageKeyPrefix+key, string(itemJSON))
+}
+
+func (s *Store) DeleteCaddyStorageItem(ctx context.Context, key string) error {
+	return s.Delete(ctx, caddyStorageKeyPrefix+key)
+}
+
+// ListCaddyStorageItems returns the items of the Caddy storage by their keys.
+func (s *Store) ListCaddyStorageItems(ctx context.Context) (map[string]CaddyStorageItem, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT key, value FROM cluster WHERE key LIKE ?", caddyStorageKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make(map[string]CaddyStorageItem)
+	for rows.Next() {
+		var key, itemJSON string
+		if err = rows.Scan(&key, &itemJSON); err != nil {
+			return nil, err
+		}
+		if err = addCaddyStorageItem(items, key, itemJSON); err != nil {
+			return nil, err
+		}
+	}
+	return items, rows.Err()
+}
+
+func addCaddyStorageItem(items map[string]CaddyStorageItem, key, itemJSON string) error {
+	var item CaddyStorageItem
+	if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
+		return fmt.Errorf("unmarshal caddy storage item '%s': %w", key, err)
+	}
+	items[strings.TrimPrefix(key, caddyStorageKeyPrefix)] = item
+	return nil
+}
+
+// SubscribeCaddyStorage returns the items of the Caddy storage by their keys and a channel that signals changes
+// to the items. The channel doesn't receive any values, it just signals when an item has been added, updated,
+// or deleted in the database.
+func (s *Store) SubscribeCaddyStorage(ctx context.Context) (map[string]CaddyStorageItem, <-chan struct{}, error) {
+	sub, err := s.corro.SubscribeContext(ctx,
+		"SELECT key, value FROM cluster WHERE key LIKE ?", []any{caddyStorageKeyPrefix + "%"}, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make(map[string]CaddyStorageItem)
+	rows := sub.Rows()
+	for rows.Next() {
+		var key, itemJSON string
+		if err = rows.Scan(&key, &itemJSON); err != nil {
+			return nil, nil, err
+		}
+		if err = addCaddyStorageItem(items, key, itemJSON); err != nil {
+			return nil, nil, err
+		}
+	}
+	events, err := sub.Changes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get subscription changes: %w", err)
+	}
+
+	changes := make(chan struct{})
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					// events channel has been closed.
+					if sub.Err() != nil {
+						slog.Error("Caddy storage subscription failed.", "id", sub.ID(), "err", sub.Err())
+					}
+					return
+				}
+				if !s.delayChange(ctx) {
+					return
+				}
+				// Just signal that there is a change in the items.
+				changes <- struct{}{}
+			}
+		}
+	}()
+
+	return items, changes, nil
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/secret"
+)
+
+// ClusterIDKey is the key used to store the unique ID of the cluster in the store.
+const ClusterIDKey = "id"
+
+// GetClusterID returns the unique ID of the cluster. The ID is generated and stored on first access if the cluster
+// was initialised by an older daemon version that didn't generate it. The ID isn't replaced if it has been stored
+// in the meantime.
+func (s *Store) GetClusterID(ctx context.Context) (string, error) {
+	var id string
+	err := s.Get(ctx, ClusterIDKey, &id)
+	if err == nil || !errors.Is(err, ErrKeyNotFound) {
+		return id, err
+	}
+
+	if id, err = secret.NewID(); err != nil {
+		return "", fmt.Errorf("generate cluster ID: %w", err)
+	}
+	if _, err = s.corro.ExecContext(ctx, "INSERT OR IGNORE INTO cluster (key, value) VALUES (?, ?)",
+		ClusterIDKey, id); err != nil {
+		return "", fmt.Errorf("insert query: %w", err)
+	}
+	if err = s.Get(ctx, ClusterIDKey, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// clusterPeeringKeyPrefix is the prefix of the keys used to store the peerings with other clusters in the store.
+const clusterPeeringKeyPrefix = "cluster_peering/"
+
+// GetClusterPeering returns the peering with the named cluster or ErrKeyNotFound if it doesn't exist.
+func (s *Store) GetClusterPeering(ctx context.Context, name string) (api.ClusterPeering, error) {
+	var p api.ClusterPeering
+	var pJSON []byte
+	if err := s.Get(ctx, clusterPeeringKeyPrefix+name, &pJSON); err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(pJSON, &p); err != nil {
+		return p, fmt.Errorf("unmarshal cluster peering: %w", err)
+	}
+	return p, nil
+}
+
+// ListClusterPeerings returns all peerings with other clusters.
+func (s *Store) ListClusterPeerings(ctx context.Context) ([]api.ClusterPeering, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ? ORDER BY key", clusterPeeringKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var peerings []api.ClusterPeering
+	for rows.Next() {
+		var pJSON []byte
+		if err = rows.Scan(&pJSON); err != nil {
+			return nil, err
+		}
+		var p api.ClusterPeering
+		if err = json.Unmarshal(pJSON, &p); err != nil {
+			return nil, fmt.Errorf("unmarshal cluster peering: %w", err)
+		}
+		peerings = append(peerings, p)
+	}
+	return peerings, nil
+}
+
+// PutClusterPeering stores the peering with another cluster or updates it if it already exists.
+func (s *Store) PutClusterPeering(ctx context.Context, p api.ClusterPeering) error {
+	pJSON, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal cluster peering: %w", err)
+	}
+	return s.Put(ctx, clusterPeeringKeyPrefix+p.Name, pJSON)
+}
+
+// DeleteClusterPeering removes the peering with the named cluster.
+func (s *Store) DeleteClusterPeering(ctx context.Context, name string) error {
+	return s.Delete(ctx, clusterPeeringKeyPrefix+name)
+}
+
+// SubscribeClusterPeerings returns the peerings with other clusters and a channel that signals changes to them.
+// The channel doesn't receive any values, it just signals when a peering has been added, updated, or removed
+// in the database.
+func (s *Store) SubscribeClusterPeerings(ctx context.Context) ([]api.ClusterPeering, <-chan struct{}, error) {
+	sub, err := s.corro.SubscribeContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ?", []any{clusterPeeringKeyPrefix + "%"}, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var peerings []api.ClusterPeering
+	rows := sub.Rows()
+	for rows.Next() {
+		var pJSON []byte
+		if err = rows.Scan(&pJSON); err != nil {
+			return nil, nil, err
+		}
+		var p api.ClusterPeering
+		if err = json.Unmarshal(pJSON, &p); err != nil {
+			return nil, nil, fmt.Errorf("unmarshal cluster peering: %w", err)
+		}
+		peerings = append(peerings, p)
+	}
+	events, err := sub.Changes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get subscription changes: %w", err)
+	}
+
+	changes := make(chan struct{})
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					if sub.Err() != nil {
+						slog.Error("Cluster peerings subscription failed.", "id", sub.ID(), "err", sub.Err())
+					}
+					return
+				}
+				if !s.delayChange(ctx) {
+					return
+				}
+				select {
+				case changes <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return peerings, changes, nil
+}
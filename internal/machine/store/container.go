@@ -216,6 +216,9 @@ func (s *Store) SubscribeContainers(ctx context.Context) ([]ContainerRecord, <-c
 					}
 					return
 				}
+				if !s.delayChange(ctx) {
+					return
+				}
 				// Just signal that there is a change in the containers list.
 				changes <- struct{}{}
 			}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// deployRequestKeyPrefix is the prefix of the keys used to store the deploy requests in the store.
+	deployRequestKeyPrefix = "deploy-request/"
+	// DeployRequestRetention is how long the reviewed deploy requests are kept in the store.
+	// Pending requests are kept until they're reviewed.
+	DeployRequestRetention = 30 * 24 * time.Hour
+)
+
+// GetDeployRequest returns the deploy request with the given ID or ErrKeyNotFound if it doesn't exist.
+func (s *Store) GetDeployRequest(ctx context.Context, id string) (api.DeployRequest, error) {
+	var r api.DeployRequest
+	var rJSON []byte
+	if err := s.Get(ctx, deployRequestKeyPrefix+id, &rJSON); err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(rJSON, &r); err != nil {
+		return r, fmt.Errorf("unmarshal deploy request: %w", err)
+	}
+	return r, nil
+}
+
+// ListDeployRequests returns the deploy requests sorted by creation time.
+func (s *Store) ListDeployRequests(ctx context.Context) ([]api.DeployRequest, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ?", deployRequestKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []api.DeployRequest
+	for rows.Next() {
+		var rJSON []byte
+		if err = rows.Scan(&rJSON); err != nil {
+			return nil, err
+		}
+		var r api.DeployRequest
+		if err = json.Unmarshal(rJSON, &r); err != nil {
+			return nil, fmt.Errorf("unmarshal deploy request: %w", err)
+		}
+		requests = append(requests, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(requests, func(a, b api.DeployRequest) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return requests, nil
+}
+
+// PutDeployRequest stores the deploy request or updates it if it already exists, and removes the requests reviewed
+// more than DeployRequestRetention ago.
+func (s *Store) PutDeployRequest(ctx context.Context, r api.DeployRequest) error {
+	rJSON, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshal deploy request: %w", err)
+	}
+	if err = s.Put(ctx, deployRequestKeyPrefix+r.ID, rJSON); err != nil {
+		return err
+	}
+
+	requests, err := s.ListDeployRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("list deploy requests: %w", err)
+	}
+	for _, old := range requests {
+		if old.Status != api.DeployRequestPending && time.Since(old.ReviewedAt) > DeployRequestRetention {
+			if err = s.Delete(ctx, deployRequestKeyPrefix+old.ID); err != nil {
+				return fmt.Errorf("delete expired deploy request: %w", err)
+			}
+		}
+	}
+	return nil
+}
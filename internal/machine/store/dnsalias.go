@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// dnsAliasKeyPrefix is the prefix of the keys used to store the internal DNS aliases in the store.
+const dnsAliasKeyPrefix = "dns_alias/"
+
+// PutDNSAlias makes the internal DNS name resolve to the addresses of the target name instead, e.g. a service name
+// to the service container on a particular machine <machine-id>.m.<service-name>.
+func (s *Store) PutDNSAlias(ctx context.Context, name, target string) error {
+	return s.Put(ctx, dnsAliasKeyPrefix+name, target)
+}
+
+func (s *Store) DeleteDNSAlias(ctx context.Context, name string) error {
+	return s.Delete(ctx, dnsAliasKeyPrefix+name)
+}
+
+// SubscribeDNSAliases returns a map of internal DNS aliases to their targets and a channel that signals changes
+// to the aliases. The channel doesn't receive any values, it just signals when an alias has been added, updated,
+// or deleted in the database.
+func (s *Store) SubscribeDNSAliases(ctx context.Context) (map[string]string, <-chan struct{}, error) {
+	sub, err := s.corro.SubscribeContext(ctx,
+		"SELECT key, value FROM cluster WHERE key LIKE ?", []any{dnsAliasKeyPrefix + "%"}, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aliases := make(map[string]string)
+	rows := sub.Rows()
+	for rows.Next() {
+		var key, target string
+		if err = rows.Scan(&key, &target); err != nil {
+			return nil, nil, err
+		}
+		aliases[strings.TrimPrefix(key, dnsAliasKeyPrefix)] = target
+	}
+	events, err := sub.Changes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get subscription changes: %w", err)
+	}
+
+	changes := make(chan struct{})
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					// events channel has been closed.
+					if sub.Err() != nil {
+						slog.Error("DNS aliases subscription failed.", "id", sub.ID(), "err", sub.Err())
+					}
+					return
+				}
+				if !s.delayChange(ctx) {
+					return
+				}
+				// Just signal that there is a change in the aliases.
+				changes <- struct{}{}
+			}
+		}
+	}()
+
+	return aliases, changes, nil
+}
+
+// ListDNSAliases returns a map of internal DNS aliases to their targets.
+func (s *Store) ListDNSAliases(ctx context.Context) (map[string]string, error) {
+	rows, err := s.corro.QueryContext(ctx, "SELECT key, value FROM cluster WHERE key LIKE ?", dnsAliasKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := make(map[string]string)
+	for rows.Next() {
+		var key, target string
+		if err = rows.Scan(&key, &target); err != nil {
+			return nil, err
+		}
+		aliases[strings.TrimPrefix(key, dnsAliasKeyPrefix)] = target
+	}
+	return aliases, nil
+}
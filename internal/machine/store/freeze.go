@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// deployFreezeKey is the key used to store the deploy freeze of the cluster in the store.
+	deployFreezeKey = "deploy-freeze"
+	// freezeOverrideKeyPrefix is the prefix of the keys used to store the freeze override audit records.
+	// The records are kept until the cluster is destroyed.
+	freezeOverrideKeyPrefix = "freeze-override/"
+)
+
+// GetDeployFreeze returns the deploy freeze of the cluster or ErrKeyNotFound if the deploys aren't frozen.
+func (s *Store) GetDeployFreeze(ctx context.Context) (api.DeployFreeze, error) {
+	var f api.DeployFreeze
+	var fJSON []byte
+	if err := s.Get(ctx, deployFreezeKey, &fJSON); err != nil {
+		return f, err
+	}
+	if err := json.Unmarshal(fJSON, &f); err != nil {
+		return f, fmt.Errorf("unmarshal deploy freeze: %w", err)
+	}
+	return f, nil
+}
+
+// PutDeployFreeze stores the deploy freeze of the cluster replacing the existing one.
+func (s *Store) PutDeployFreeze(ctx context.Context, f api.DeployFreeze) error {
+	fJSON, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("marshal deploy freeze: %w", err)
+	}
+	return s.Put(ctx, deployFreezeKey, fJSON)
+}
+
+// DeleteDeployFreeze removes the deploy freeze of the cluster.
+func (s *Store) DeleteDeployFreeze(ctx context.Context) error {
+	return s.Delete(ctx, deployFreezeKey)
+}
+
+// ListFreezeOverrides returns the recorded freeze overrides sorted by time.
+func (s *Store) ListFreezeOverrides(ctx context.Context) ([]api.FreezeOverride, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ?", freezeOverrideKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides []api.FreezeOverride
+	for rows.Next() {
+		var oJSON []byte
+		if err = rows.Scan(&oJSON); err != nil {
+			return nil, err
+		}
+		var o api.FreezeOverride
+		if err = json.Unmarshal(oJSON, &o); err != nil {
+			return nil, fmt.Errorf("unmarshal freeze override: %w", err)
+		}
+		overrides = append(overrides, o)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(overrides, func(a, b api.FreezeOverride) int {
+		return a.Time.Compare(b.Time)
+	})
+	return overrides, nil
+}
+
+// PutFreezeOverride stores the freeze override audit record.
+func (s *Store) PutFreezeOverride(ctx context.Context, o api.FreezeOverride) error {
+	oJSON, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("marshal freeze override: %w", err)
+	}
+	return s.Put(ctx, freezeOverrideKeyPrefix+strconv.FormatInt(o.Time.UnixNano(), 10), oJSON)
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ipReservationKeyPrefix is the prefix of the keys used to store the IP range reservations in the store.
+const ipReservationKeyPrefix = "ip_reservation/"
+
+// GetIPReservation returns the reservation of the IP range or ErrKeyNotFound if the range isn't reserved.
+func (s *Store) GetIPReservation(ctx context.Context, prefix netip.Prefix) (api.IPReservation, error) {
+	var r api.IPReservation
+	var rJSON []byte
+	if err := s.Get(ctx, ipReservationKeyPrefix+prefix.String(), &rJSON); err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(rJSON, &r); err != nil {
+		return r, fmt.Errorf("unmarshal IP reservation: %w", err)
+	}
+	return r, nil
+}
+
+// ListIPReservations returns all IP range reservations.
+func (s *Store) ListIPReservations(ctx context.Context) ([]api.IPReservation, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ? ORDER BY key", ipReservationKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []api.IPReservation
+	for rows.Next() {
+		var rJSON []byte
+		if err = rows.Scan(&rJSON); err != nil {
+			return nil, err
+		}
+		var r api.IPReservation
+		if err = json.Unmarshal(rJSON, &r); err != nil {
+			return nil, fmt.Errorf("unmarshal IP reservation: %w", err)
+		}
+		reservations = append(reservations, r)
+	}
+	return reservations, nil
+}
+
+// PutIPReservation stores the IP range reservation or updates it if the range is already reserved.
+func (s *Store) PutIPReservation(ctx context.Context, r api.IPReservation) error {
+	rJSON, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshal IP reservation: %w", err)
+	}
+	return s.Put(ctx, ipReservationKeyPrefix+r.Prefix.String(), rJSON)
+}
+
+// DeleteIPReservation removes the reservation of the IP range.
+func (s *Store) DeleteIPReservation(ctx context.Context, prefix netip.Prefix) error {
+	return s.Delete(ctx, ipReservationKeyPrefix+prefix.String())
+}
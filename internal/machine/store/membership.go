@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// membershipLockKey is the key used to store the cluster membership change lock in the store.
+const membershipLockKey = "membership_lock"
+
+// MembershipLock is a short-lived lease that serialises the cluster membership changes such as adding and removing
+// machines across the cluster machines.
+type MembershipLock struct {
+	// Holder identifies the operation holding the lock.
+	Holder string `json:"holder"`
+	// Machine is the ID of the machine that acquired the lock.
+	Machine   string    `json:"machine"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired returns true if the lock lease has expired at the given time.
+func (l MembershipLock) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// GetMembershipLock returns the current cluster membership change lock or nil if it's not set.
+func (s *Store) GetMembershipLock(ctx context.Context) (*MembershipLock, error) {
+	var lockJSON []byte
+	if err := s.Get(ctx, membershipLockKey, &lockJSON); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var lock MembershipLock
+	if err := json.Unmarshal(lockJSON, &lock); err != nil {
+		return nil, fmt.Errorf("unmarshal membership lock: %w", err)
+	}
+	return &lock, nil
+}
+
+// PutMembershipLock stores the cluster membership change lock replacing the current one.
+func (s *Store) PutMembershipLock(ctx context.Context, lock MembershipLock) error {
+	lockJSON, err := json.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshal membership lock: %w", err)
+	}
+	return s.Put(ctx, membershipLockKey, lockJSON)
+}
+
+// DeleteMembershipLock deletes the cluster membership change lock if it's held by the holder. The lock is deleted
+// only if it hasn't changed since it was read in the same statement, so a lock acquired concurrently by another
+// holder can't be deleted. It returns true if the lock has been deleted.
+func (s *Store) DeleteMembershipLock(ctx context.Context, holder string) (bool, error) {
+	var lockJSON []byte
+	if err := s.Get(ctx, membershipLockKey, &lockJSON); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	var lock MembershipLock
+	if err := json.Unmarshal(lockJSON, &lock); err != nil {
+		return false, fmt.Errorf("unmarshal membership lock: %w", err)
+	}
+	if lock.Holder != holder {
+		return false, nil
+	}
+
+	res, err := s.corro.ExecContext(ctx, "DELETE FROM cluster WHERE key = ? AND value = ?", membershipLockKey, lockJSON)
+	if err != nil {
+		return false, fmt.Errorf("delete membership lock: %w", err)
+	}
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+)
+
+// clusterNetworkKey is the key used to store the IP network of the cluster in the store.
+const clusterNetworkKey = "network"
+
+// GetClusterNetwork returns the IP network of the cluster the machine subnets are allocated from.
+func (s *Store) GetClusterNetwork(ctx context.Context) (netip.Prefix, error) {
+	var network string
+	if err := s.Get(ctx, clusterNetworkKey, &network); err != nil {
+		return netip.Prefix{}, err
+	}
+	prefix, err := netip.ParsePrefix(network)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("parse cluster network '%s': %w", network, err)
+	}
+	return prefix, nil
+}
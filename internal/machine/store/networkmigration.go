@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// networkMigrationKey is the key used to store the cluster network migration in progress in the store.
+const networkMigrationKey = "network_migration"
+
+// GetNetworkMigration returns the cluster network migration in progress or ErrKeyNotFound if there is none.
+func (s *Store) GetNetworkMigration(ctx context.Context) (api.NetworkMigration, error) {
+	var m api.NetworkMigration
+	var mJSON []byte
+	if err := s.Get(ctx, networkMigrationKey, &mJSON); err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(mJSON, &m); err != nil {
+		return m, fmt.Errorf("unmarshal network migration: %w", err)
+	}
+	return m, nil
+}
+
+// PutNetworkMigration stores the cluster network migration.
+func (s *Store) PutNetworkMigration(ctx context.Context, m api.NetworkMigration) error {
+	mJSON, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal network migration: %w", err)
+	}
+	return s.Put(ctx, networkMigrationKey, mJSON)
+}
+
+// DeleteNetworkMigration removes the cluster network migration.
+func (s *Store) DeleteNetworkMigration(ctx context.Context) error {
+	return s.Delete(ctx, networkMigrationKey)
+}
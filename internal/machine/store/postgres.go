@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// postgresClusterKeyPrefix is the prefix of the keys used to store the managed Postgres clusters in the store.
+const postgresClusterKeyPrefix = "postgres/"
+
+// GetPostgresCluster returns the managed Postgres cluster with the given name or ErrKeyNotFound if it doesn't exist.
+func (s *Store) GetPostgresCluster(ctx context.Context, name string) (api.PostgresCluster, error) {
+	var c api.PostgresCluster
+	var cJSON []byte
+	if err := s.Get(ctx, postgresClusterKeyPrefix+name, &cJSON); err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(cJSON, &c); err != nil {
+		return c, fmt.Errorf("unmarshal postgres cluster: %w", err)
+	}
+	return c, nil
+}
+
+// ListPostgresClusters returns all managed Postgres clusters ordered by name.
+func (s *Store) ListPostgresClusters(ctx context.Context) ([]api.PostgresCluster, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ? ORDER BY key", postgresClusterKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clusters []api.PostgresCluster
+	for rows.Next() {
+		var cJSON []byte
+		if err = rows.Scan(&cJSON); err != nil {
+			return nil, err
+		}
+		var c api.PostgresCluster
+		if err = json.Unmarshal(cJSON, &c); err != nil {
+			return nil, fmt.Errorf("unmarshal postgres cluster: %w", err)
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters, nil
+}
+
+// PutPostgresCluster stores the managed Postgres cluster and points its internal DNS name to the primary.
+func (s *Store) PutPostgresCluster(ctx context.Context, c api.PostgresCluster) error {
+	cJSON, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshal postgres cluster: %w", err)
+	}
+	if err = s.Put(ctx, postgresClusterKeyPrefix+c.Name, cJSON); err != nil {
+		return err
+	}
+	return s.PutDNSAlias(ctx, c.Name, c.PrimaryServiceName())
+}
+
+// DeletePostgresCluster deletes the managed Postgres cluster and its internal DNS alias.
+func (s *Store) DeletePostgresCluster(ctx context.Context, name string) error {
+	if err := s.Delete(ctx, postgresClusterKeyPrefix+name); err != nil {
+		return err
+	}
+	return s.DeleteDNSAlias(ctx, name)
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// preemptionKeyPrefix is the prefix of the keys used to store the preemption records in the store.
+	preemptionKeyPrefix = "preemption/"
+	// PreemptionRetention is how long the preemption records are kept in the store.
+	PreemptionRetention = 7 * 24 * time.Hour
+)
+
+// ListPreemptions returns the recorded preemptions sorted by time.
+func (s *Store) ListPreemptions(ctx context.Context) ([]api.Preemption, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ?", preemptionKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var preemptions []api.Preemption
+	for rows.Next() {
+		var pJSON []byte
+		if err = rows.Scan(&pJSON); err != nil {
+			return nil, err
+		}
+		var p api.Preemption
+		if err = json.Unmarshal(pJSON, &p); err != nil {
+			return nil, fmt.Errorf("unmarshal preemption: %w", err)
+		}
+		preemptions = append(preemptions, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(preemptions, func(a, b api.Preemption) int {
+		return a.Time.Compare(b.Time)
+	})
+	return preemptions, nil
+}
+
+// PutPreemption stores the preemption record and removes the records older than PreemptionRetention.
+func (s *Store) PutPreemption(ctx context.Context, p api.Preemption) error {
+	pJSON, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal preemption: %w", err)
+	}
+	if err = s.Put(ctx, preemptionKeyPrefix+p.ContainerID, pJSON); err != nil {
+		return err
+	}
+
+	preemptions, err := s.ListPreemptions(ctx)
+	if err != nil {
+		return fmt.Errorf("list preemptions: %w", err)
+	}
+	for _, old := range preemptions {
+		if time.Since(old.Time) > PreemptionRetention {
+			if err = s.Delete(ctx, preemptionKeyPrefix+old.ContainerID); err != nil {
+				return fmt.Errorf("delete expired preemption: %w", err)
+			}
+		}
+	}
+	return nil
+}
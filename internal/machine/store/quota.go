@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// quotaKeyPrefix is the prefix of the keys used to store the project quotas in the store.
+const quotaKeyPrefix = "quota/"
+
+// GetProjectQuota returns the quota of the project or ErrKeyNotFound if the project has no quota.
+func (s *Store) GetProjectQuota(ctx context.Context, project string) (api.ProjectQuota, error) {
+	var q api.ProjectQuota
+	var qJSON []byte
+	if err := s.Get(ctx, quotaKeyPrefix+project, &qJSON); err != nil {
+		return q, err
+	}
+	if err := json.Unmarshal(qJSON, &q); err != nil {
+		return q, fmt.Errorf("unmarshal project quota: %w", err)
+	}
+	return q, nil
+}
+
+// ListProjectQuotas returns the quotas of all projects sorted by project name.
+func (s *Store) ListProjectQuotas(ctx context.Context) ([]api.ProjectQuota, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ? ORDER BY key", quotaKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var quotas []api.ProjectQuota
+	for rows.Next() {
+		var qJSON []byte
+		if err = rows.Scan(&qJSON); err != nil {
+			return nil, err
+		}
+		var q api.ProjectQuota
+		if err = json.Unmarshal(qJSON, &q); err != nil {
+			return nil, fmt.Errorf("unmarshal project quota: %w", err)
+		}
+		quotas = append(quotas, q)
+	}
+	return quotas, nil
+}
+
+// PutProjectQuota stores the project quota or updates it if it already exists.
+func (s *Store) PutProjectQuota(ctx context.Context, q api.ProjectQuota) error {
+	qJSON, err := json.Marshal(q)
+	if err != nil {
+		return fmt.Errorf("marshal project quota: %w", err)
+	}
+	return s.Put(ctx, quotaKeyPrefix+q.Project, qJSON)
+}
+
+// DeleteProjectQuota removes the quota of the project.
+func (s *Store) DeleteProjectQuota(ctx context.Context, project string) error {
+	return s.Delete(ctx, quotaKeyPrefix+project)
+}
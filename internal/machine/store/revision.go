@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// serviceRevisionKeyPrefix is the prefix of the keys used to store the service revisions in the store.
+const serviceRevisionKeyPrefix = "service_revision/"
+
+// GetServiceRevision returns the state of the service before its last deployment or ErrKeyNotFound if it's not
+// recorded.
+func (s *Store) GetServiceRevision(ctx context.Context, serviceID string) (api.ServiceRevision, error) {
+	var rev api.ServiceRevision
+	var revJSON []byte
+	if err := s.Get(ctx, serviceRevisionKeyPrefix+serviceID, &revJSON); err != nil {
+		return rev, err
+	}
+	if err := json.Unmarshal(revJSON, &rev); err != nil {
+		return rev, fmt.Errorf("unmarshal service revision: %w", err)
+	}
+	return rev, nil
+}
+
+// PutServiceRevision stores the state of the service before a deployment replacing the previous revision.
+func (s *Store) PutServiceRevision(ctx context.Context, rev api.ServiceRevision) error {
+	revJSON, err := json.Marshal(rev)
+	if err != nil {
+		return fmt.Errorf("marshal service revision: %w", err)
+	}
+	return s.Put(ctx, serviceRevisionKeyPrefix+rev.ServiceID, revJSON)
+}
+
+// ListServiceRevisions returns the recorded revisions of all services ordered by the deployment time.
+func (s *Store) ListServiceRevisions(ctx context.Context) ([]api.ServiceRevision, error) {
+	rows, err := s.corro.QueryContext(ctx, "SELECT value FROM cluster WHERE key LIKE ?",
+		serviceRevisionKeyPrefix+"%")
+	if err != nil {
+		return nil, fmt.Errorf("select query: %w", err)
+	}
+	defer rows.Close()
+
+	var revs []api.ServiceRevision
+	for rows.Next() {
+		var revJSON []byte
+		if err = rows.Scan(&revJSON); err != nil {
+			return nil, fmt.Errorf("scan service revision: %w", err)
+		}
+		var rev api.ServiceRevision
+		if err = json.Unmarshal(revJSON, &rev); err != nil {
+			return nil, fmt.Errorf("unmarshal service revision: %w", err)
+		}
+		revs = append(revs, rev)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(revs, func(a, b api.ServiceRevision) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return revs, nil
+}
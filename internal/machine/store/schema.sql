@@ -30,8 +30,38 @@ CREATE TABLE containers
     updated_at   TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00'
 );
 
+-- uptime_checks table stores the results of the periodic uptime checks of the published service endpoints.
+CREATE TABLE uptime_checks
+(
+    url          TEXT      NOT NULL,
+    -- machine_id is the ID of the machine that probed the endpoint.
+    machine_id   TEXT      NOT NULL,
+    checked_at   TIMESTAMP NOT NULL,
+    service_name TEXT      NOT NULL DEFAULT '',
+    -- status_code is the HTTP status code of the response or 0 if the request failed.
+    status_code  INTEGER   NOT NULL DEFAULT 0,
+    latency_ms   INTEGER   NOT NULL DEFAULT 0,
+    error        TEXT      NOT NULL DEFAULT '',
+    PRIMARY KEY (url, machine_id, checked_at)
+);
+
+-- machine_updates table stores the status of the automatic OS updates of each machine.
+CREATE TABLE machine_updates
+(
+    machine_id         TEXT      NOT NULL PRIMARY KEY,
+    -- window_start is the start of the maintenance window the update was run in.
+    window_start       TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+    state              TEXT      NOT NULL DEFAULT '',
+    error              TEXT      NOT NULL DEFAULT '',
+    -- drained_containers is a JSON array of IDs of the containers stopped to drain the machine before the update.
+    drained_containers TEXT      NOT NULL DEFAULT '[]' CHECK (json_valid(drained_containers)),
+    updated_at         TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00'
+);
+
 CREATE INDEX idx_machines_name ON machines (name);
 
 CREATE INDEX idx_containers_machine_id ON containers (machine_id);
 CREATE INDEX idx_containers_service_id ON containers (service_id);
 CREATE INDEX idx_containers_service_name ON containers (service_name);
+
+CREATE INDEX idx_uptime_checks_service_name ON uptime_checks (service_name);
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/internal/corrosion"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// settingsKey is the key used to store the cluster-wide settings in the store.
+const settingsKey = "settings"
+
+// GetSettings returns the cluster-wide settings with the cluster network. Zero settings are returned if they're
+// not set.
+func (s *Store) GetSettings(ctx context.Context) (api.ClusterSettings, error) {
+	var settings api.ClusterSettings
+	var settingsJSON []byte
+	if err := s.Get(ctx, settingsKey, &settingsJSON); err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return settings, err
+	} else if err == nil {
+		if err = json.Unmarshal(settingsJSON, &settings); err != nil {
+			return settings, fmt.Errorf("unmarshal settings: %w", err)
+		}
+	}
+
+	network, err := s.GetClusterNetwork(ctx)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return settings, fmt.Errorf("get cluster network: %w", err)
+	}
+	settings.Network = network
+	return settings, nil
+}
+
+// PutSettings stores the cluster-wide settings with the incremented version if the stored settings have
+// the expected version. The version check is skipped if expected is zero. The stored settings are replaced only if
+// they haven't changed since they were read in the same statement, so concurrent updates can't overwrite each other.
+// It returns the stored settings or ErrSettingsChanged if the version doesn't match or the settings have been changed
+// concurrently.
+func (s *Store) PutSettings(
+	ctx context.Context, settings api.ClusterSettings, expected int64,
+) (api.ClusterSettings, error) {
+	var currentJSON []byte
+	if err := s.Get(ctx, settingsKey, &currentJSON); err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return settings, err
+	}
+	var current api.ClusterSettings
+	if currentJSON != nil {
+		if err := json.Unmarshal(currentJSON, &current); err != nil {
+			return settings, fmt.Errorf("unmarshal settings: %w", err)
+		}
+	}
+	if expected != 0 && expected != current.Version {
+		return settings, fmt.Errorf("%w (version %d, expected %d)", ErrSettingsChanged, current.Version, expected)
+	}
+
+	settings.Version = current.Version + 1
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return settings, fmt.Errorf("marshal settings: %w", err)
+	}
+
+	var res *corrosion.ExecResult
+	if currentJSON == nil {
+		res, err = s.corro.ExecContext(ctx, "INSERT OR IGNORE INTO cluster (key, value) VALUES (?, ?)",
+			settingsKey, settingsJSON)
+	} else {
+		res, err = s.corro.ExecContext(ctx, "UPDATE cluster SET value = ? WHERE key = ? AND value = ?",
+			settingsJSON, settingsKey, currentJSON)
+	}
+	if err != nil {
+		return settings, fmt.Errorf("put settings: %w", err)
+	}
+	if res.RowsAffected == 0 {
+		return settings, fmt.Errorf("%w (expected version %d)", ErrSettingsChanged, current.Version)
+	}
+	return settings, nil
+}
+
+// SubscribeSettings returns the cluster-wide settings with the cluster network and a channel that signals changes
+// to them. The channel doesn't receive any values, it just signals when the settings or the cluster network have
+// been updated in the database.
+func (s *Store) SubscribeSettings(ctx context.Context) (api.ClusterSettings, <-chan struct{}, error) {
+	sub, err := s.corro.SubscribeContext(ctx, "SELECT key, value FROM cluster WHERE key IN (?, ?)",
+		[]any{settingsKey, clusterNetworkKey}, false)
+	if err != nil {
+		return api.ClusterSettings{}, nil, err
+	}
+
+	var settings api.ClusterSettings
+	var network netip.Prefix
+	rows := sub.Rows()
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err = rows.Scan(&key, &value); err != nil {
+			return settings, nil, err
+		}
+		switch key {
+		case settingsKey:
+			if err = json.Unmarshal(value, &settings); err != nil {
+				return settings, nil, fmt.Errorf("unmarshal settings: %w", err)
+			}
+		case clusterNetworkKey:
+			if network, err = netip.ParsePrefix(string(value)); err != nil {
+				return settings, nil, fmt.Errorf("parse cluster network '%s': %w", value, err)
+			}
+		}
+	}
+	settings.Network = network
+	events, err := sub.Changes()
+	if err != nil {
+		return settings, nil, fmt.Errorf("get subscription changes: %w", err)
+	}
+
+	changes := make(chan struct{})
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					if sub.Err() != nil {
+						slog.Error("Settings subscription failed.", "id", sub.ID(), "err", sub.Err())
+					}
+					return
+				}
+				if !s.delayChange(ctx) {
+					return
+				}
+				select {
+				case changes <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return settings, changes, nil
+}
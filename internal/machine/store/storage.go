@@ -0,0 +1,34 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// objectStorageKey is the key used to store the managed object storage configuration in the store.
+const objectStorageKey = "object_storage"
+
+// GetObjectStorage returns the configuration of the managed object storage or ErrKeyNotFound if it's not enabled.
+func (s *Store) GetObjectStorage(ctx context.Context) (api.ObjectStorage, error) {
+	var config api.ObjectStorage
+	var configJSON []byte
+	if err := s.Get(ctx, objectStorageKey, &configJSON); err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return config, fmt.Errorf("unmarshal object storage config: %w", err)
+	}
+	return config, nil
+}
+
+// PutObjectStorage stores the configuration of the managed object storage.
+func (s *Store) PutObjectStorage(ctx context.Context, config api.ObjectStorage) error {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal object storage config: %w", err)
+	}
+	return s.Put(ctx, objectStorageKey, configJSON)
+}
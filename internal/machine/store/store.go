@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/psviderski/uncloud/internal/corrosion"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -18,17 +21,40 @@ var (
 
 	ErrKeyNotFound     = errors.New("key not found")
 	ErrMachineNotFound = errors.New("machine not found")
+	ErrSettingsChanged = errors.New("settings have been changed concurrently")
 )
 
 // Store is a cluster store backed by a distributed Corrosion database.
 type Store struct {
 	corro *corrosion.APIClient
+	// changeDelay returns a delay before signalling a change to the subscribers. It's set by the chaos mode
+	// to simulate a slow store replication. Nil means no delay.
+	changeDelay func() time.Duration
 }
 
 func New(corro *corrosion.APIClient) *Store {
 	return &Store{corro: corro}
 }
 
+// SetChangeDelay sets the function returning a delay before signalling a change to the subscribers. It must be called
+// before subscribing to changes.
+func (s *Store) SetChangeDelay(f func() time.Duration) {
+	s.changeDelay = f
+}
+
+// delayChange waits for the change delay if set. It returns false if the context is done while waiting.
+func (s *Store) delayChange(ctx context.Context) bool {
+	if s.changeDelay == nil {
+		return true
+	}
+	select {
+	case <-time.After(s.changeDelay()):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func (s *Store) Get(ctx context.Context, key string, value any) error {
 	rows, err := s.corro.QueryContext(ctx, "SELECT value FROM cluster WHERE key = ?", key)
 	if err != nil {
@@ -170,6 +196,48 @@ func (s *Store) UpdateMachine(ctx context.Context, m *pb.MachineInfo) error {
 	return nil
 }
 
+// CordonMachine cordons the machine for the given reason so that no new containers are scheduled on it.
+func (s *Store) CordonMachine(ctx context.Context, machineID, reason string) error {
+	m, err := s.GetMachine(ctx, machineID)
+	if err != nil {
+		return err
+	}
+	if m.Cordoned && slices.Contains(m.CordonReasons, reason) {
+		return nil
+	}
+
+	if !slices.Contains(m.CordonReasons, reason) {
+		m.CordonReasons = append(m.CordonReasons, reason)
+	}
+	m.Cordoned = true
+	return s.UpdateMachine(ctx, m)
+}
+
+// UncordonMachine clears the cordon set for the given reason. The machine stays cordoned if it's also cordoned for
+// other reasons. A machine cordoned without reasons by an older daemon version is uncordoned only for
+// api.CordonReasonShutdown as it's the only reason older versions cordoned machines for.
+func (s *Store) UncordonMachine(ctx context.Context, machineID, reason string) error {
+	m, err := s.GetMachine(ctx, machineID)
+	if err != nil {
+		return err
+	}
+	if !m.Cordoned {
+		return nil
+	}
+	if len(m.CordonReasons) == 0 && reason != api.CordonReasonShutdown {
+		return nil
+	}
+	if len(m.CordonReasons) > 0 && !slices.Contains(m.CordonReasons, reason) {
+		return nil
+	}
+
+	m.CordonReasons = slices.DeleteFunc(m.CordonReasons, func(r string) bool {
+		return r == reason
+	})
+	m.Cordoned = len(m.CordonReasons) > 0
+	return s.UpdateMachine(ctx, m)
+}
+
 func (s *Store) DeleteMachine(ctx context.Context, id string) error {
 	result, err := s.corro.ExecContext(ctx, "DELETE FROM machines WHERE id = ?", id)
 	if err != nil {
@@ -224,6 +292,9 @@ func (s *Store) SubscribeMachines(ctx context.Context) ([]*pb.MachineInfo, <-cha
 					}
 					return
 				}
+				if !s.delayChange(ctx) {
+					return
+				}
 				// Just signal that there is a change in the machines list.
 				changes <- struct{}{}
 			}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// tenantKeyPrefix is the prefix of the keys used to store the tenants in the store.
+const tenantKeyPrefix = "tenant/"
+
+// GetTenant returns the named tenant or ErrKeyNotFound if it doesn't exist.
+func (s *Store) GetTenant(ctx context.Context, name string) (api.Tenant, error) {
+	var t api.Tenant
+	var tJSON []byte
+	if err := s.Get(ctx, tenantKeyPrefix+name, &tJSON); err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(tJSON, &t); err != nil {
+		return t, fmt.Errorf("unmarshal tenant: %w", err)
+	}
+	return t, nil
+}
+
+// ListTenants returns all tenants sorted by name.
+func (s *Store) ListTenants(ctx context.Context) ([]api.Tenant, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ? ORDER BY key", tenantKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenants []api.Tenant
+	for rows.Next() {
+		var tJSON []byte
+		if err = rows.Scan(&tJSON); err != nil {
+			return nil, err
+		}
+		var t api.Tenant
+		if err = json.Unmarshal(tJSON, &t); err != nil {
+			return nil, fmt.Errorf("unmarshal tenant: %w", err)
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants, nil
+}
+
+// PutTenant stores the tenant or updates it if it already exists.
+func (s *Store) PutTenant(ctx context.Context, t api.Tenant) error {
+	tJSON, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("marshal tenant: %w", err)
+	}
+	return s.Put(ctx, tenantKeyPrefix+t.Name, tJSON)
+}
+
+// DeleteTenant removes the named tenant.
+func (s *Store) DeleteTenant(ctx context.Context, name string) error {
+	return s.Delete(ctx, tenantKeyPrefix+name)
+}
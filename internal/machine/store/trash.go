@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// trashKeyPrefix is the prefix of the keys used to store the trashed services in the store.
+const trashKeyPrefix = "trash/"
+
+// GetTrashedService returns the trashed service with the given ID or ErrKeyNotFound if it's not in the trash.
+func (s *Store) GetTrashedService(ctx context.Context, id string) (api.TrashedService, error) {
+	var svc api.TrashedService
+	var svcJSON []byte
+	if err := s.Get(ctx, trashKeyPrefix+id, &svcJSON); err != nil {
+		return svc, err
+	}
+	if err := json.Unmarshal(svcJSON, &svc); err != nil {
+		return svc, fmt.Errorf("unmarshal trashed service: %w", err)
+	}
+	return svc, nil
+}
+
+// ListTrashedServices returns all trashed services.
+func (s *Store) ListTrashedServices(ctx context.Context) ([]api.TrashedService, error) {
+	rows, err := s.corro.QueryContext(ctx,
+		"SELECT value FROM cluster WHERE key LIKE ? ORDER BY key", trashKeyPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var services []api.TrashedService
+	for rows.Next() {
+		var svcJSON []byte
+		if err = rows.Scan(&svcJSON); err != nil {
+			return nil, err
+		}
+		var svc api.TrashedService
+		if err = json.Unmarshal(svcJSON, &svc); err != nil {
+			return nil, fmt.Errorf("unmarshal trashed service: %w", err)
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// PutTrashedService stores the trashed service or updates it if it's already in the trash.
+func (s *Store) PutTrashedService(ctx context.Context, svc api.TrashedService) error {
+	svcJSON, err := json.Marshal(svc)
+	if err != nil {
+		return fmt.Errorf("marshal trashed service: %w", err)
+	}
+	return s.Put(ctx, trashKeyPrefix+svc.ID, svcJSON)
+}
+
+// DeleteTrashedService removes the service from the trash.
+func (s *Store) DeleteTrashedService(ctx context.Context, id string) error {
+	return s.Delete(ctx, trashKeyPrefix+id)
+}
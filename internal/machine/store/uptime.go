@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UptimeCheckRecord is the result of probing a published service endpoint.
+type UptimeCheckRecord struct {
+	URL         string
+	MachineID   string
+	CheckedAt   time.Time
+	ServiceName string
+	// StatusCode is the HTTP status code of the response or 0 if the request failed.
+	StatusCode int
+	Latency    time.Duration
+	Error      string
+}
+
+// UptimeCheckListOptions filters uptime check records.
+type UptimeCheckListOptions struct {
+	ServiceName string
+	// Since filters records checked after the given time.
+	Since time.Time
+}
+
+// CreateUptimeCheck stores the result of an uptime check in the store database.
+func (s *Store) CreateUptimeCheck(ctx context.Context, r UptimeCheckRecord) error {
+	_, err := s.corro.ExecContext(ctx, `
+		INSERT OR REPLACE INTO uptime_checks
+			(url, machine_id, checked_at, service_name, status_code, latency_ms, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		r.URL, r.MachineID, r.CheckedAt.UTC().Format(time.DateTime), r.ServiceName, r.StatusCode,
+		r.Latency.Milliseconds(), r.Error)
+	if err != nil {
+		return fmt.Errorf("insert query: %w", err)
+	}
+	return nil
+}
+
+// ListUptimeChecks returns the uptime check records that match the given options ordered by the check time.
+func (s *Store) ListUptimeChecks(ctx context.Context, opts UptimeCheckListOptions) ([]UptimeCheckRecord, error) {
+	q := sq.Select("url", "machine_id", "checked_at", "service_name", "status_code", "latency_ms", "error").
+		From("uptime_checks").OrderBy("checked_at")
+	if opts.ServiceName != "" {
+		q = q.Where(sq.Eq{"service_name": opts.ServiceName})
+	}
+	if !opts.Since.IsZero() {
+		q = q.Where(sq.Gt{"checked_at": opts.Since.UTC().Format(time.DateTime)})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+	rows, err := s.corro.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select query: %w", err)
+	}
+	defer rows.Close()
+
+	var records []UptimeCheckRecord
+	var checkedAtStr string
+	var latencyMs int64
+	for rows.Next() {
+		var r UptimeCheckRecord
+		if err = rows.Scan(&r.URL, &r.MachineID, &checkedAtStr, &r.ServiceName, &r.StatusCode, &latencyMs,
+			&r.Error); err != nil {
+			return nil, fmt.Errorf("scan uptime check record: %w", err)
+		}
+		if r.CheckedAt, err = time.Parse(time.DateTime, checkedAtStr); err != nil {
+			return nil, fmt.Errorf("parse checked_at: %w", err)
+		}
+		r.Latency = time.Duration(latencyMs) * time.Millisecond
+		records = append(records, r)
+	}
+
+	return records, rows.Err()
+}
+
+// DeleteUptimeChecks deletes the uptime check records probed by any machine before the given time. Records of
+// machines that have been removed from the cluster are cleaned up this way as well.
+func (s *Store) DeleteUptimeChecks(ctx context.Context, before time.Time) error {
+	res, err := s.corro.ExecContext(ctx, "DELETE FROM uptime_checks WHERE checked_at < ?",
+		before.UTC().Format(time.DateTime))
+	if err != nil {
+		return fmt.Errorf("delete query: %w", err)
+	}
+	if res.RowsAffected > 0 {
+		slog.Debug("Uptime check records deleted from store DB.", "count", res.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/internal/machine/firewall"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tenantIsolationReconcileInterval is the interval at which the firewall rules isolating the tenant containers
+// are reconciled with the containers in the cluster.
+const tenantIsolationReconcileInterval = 10 * time.Second
+
+// admitContainer rejects a service container while the deploys are frozen, if the service would exceed the quota
+// of its project, or a container of a tenant service if the tenant doesn't exist or the service would exceed
+// the tenant quota. The usage is calculated from the containers of other tenant services in the cluster store
+// and the containers the service would have once deployed.
+func (m *Machine) admitContainer(ctx context.Context, serviceID string, spec api.ServiceSpec) error {
+	if err := m.checkDeployFreeze(ctx); err != nil {
+		return err
+	}
+	if err := m.checkProjectQuota(ctx, serviceID, spec); err != nil {
+		return err
+	}
+	if spec.Tenant == "" {
+		return nil
+	}
+
+	tenant, err := m.store.GetTenant(ctx, spec.Tenant)
+	if err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return status.Errorf(codes.FailedPrecondition, "tenant '%s' not found", spec.Tenant)
+		}
+		return status.Errorf(codes.Internal, "get tenant: %v", err)
+	}
+	if err = tenant.Quota.CheckSpec(spec); err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	records, err := m.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "list containers: %v", err)
+	}
+	m.state.mu.RLock()
+	machineID := m.state.ID
+	m.state.mu.RUnlock()
+
+	var usage api.TenantUsage
+	// The number of containers the service would have. A global service has one container per machine and
+	// a replicated service has the specified number of replicas.
+	containers := max(int(spec.Replicas), 1)
+	if spec.Mode == api.ServiceModeGlobal {
+		containers = 1
+	}
+	for _, r := range records {
+		if r.Container.ServiceSpec.Tenant != spec.Tenant {
+			continue
+		}
+		if r.Container.ServiceID() == serviceID {
+			if spec.Mode == api.ServiceModeGlobal && r.MachineID != machineID {
+				containers++
+			}
+			continue
+		}
+		usage.Add(r.Container.ServiceSpec.Container.Resources)
+	}
+	for range containers {
+		usage.Add(spec.Container.Resources)
+	}
+
+	if err = tenant.Quota.Check(usage); err != nil {
+		return status.Errorf(codes.ResourceExhausted, "tenant '%s': %v", spec.Tenant, err)
+	}
+	return nil
+}
+
+// runTenantIsolation keeps the default-deny firewall policy that isolates the local containers from the containers
+// of other tenants and networks in sync with the containers in the cluster. The policy is updated as soon as
+// the containers change and periodically to pick up the machine changes and retry failed updates.
+func (cc *clusterController) runTenantIsolation(ctx context.Context) error {
+	_, changes, err := cc.store.SubscribeContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("subscribe to container changes: %w", err)
+	}
+	ticker := time.NewTicker(tenantIsolationReconcileInterval)
+	defer ticker.Stop()
+
+	var configured *firewall.TenantIsolation
+	first := true
+	for {
+		isolation, err := cc.tenantIsolation(ctx)
+		if err != nil {
+			slog.Error("Failed to get tenant isolation policy.", "err", err)
+		} else if first || !isolation.Equal(configured) {
+			if err = firewall.ConfigureTenantIsolation(isolation); err != nil {
+				slog.Error("Failed to configure tenant isolation firewall rules.", "err", err)
+			} else {
+				configured = isolation
+				first = false
+			}
+		}
+
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return fmt.Errorf("containers subscription failed")
+			}
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// tenantIsolation returns the firewall policy that only allows the traffic to the local containers from the containers
+// attached to at least one of the same networks. Tenant networks are namespaced with the tenant name so containers
+// of different tenants, or with and without a tenant, never share a network. The machines and Caddy containers are
+// allowed to reach all containers to proxy the ingress traffic. It returns nil if all containers in the cluster are
+// attached only to the default network without a tenant.
+func (cc *clusterController) tenantIsolation(ctx context.Context) (*firewall.TenantIsolation, error) {
+	records, err := cc.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+	machines, err := cc.store.ListMachines(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+
+	isolation := &firewall.TenantIsolation{Subnet: cc.state.Network.Subnet}
+	for _, m := range machines {
+		subnet, err := m.Network.Subnet.ToPrefix()
+		if err != nil {
+			continue
+		}
+		isolation.DenySources = append(isolation.DenySources, subnet)
+		isolation.AllowSources = append(isolation.AllowSources, network.MachineIP(subnet))
+	}
+
+	type networkContainer struct {
+		rec      store.ContainerRecord
+		networks []string
+	}
+	var ctrs []networkContainer
+	isolated := false
+	for _, r := range records {
+		ip := r.Container.UncloudNetworkIP()
+		if !ip.IsValid() {
+			continue
+		}
+		if r.Container.ServiceName() == caddyconfig.CaddyServiceName {
+			isolation.AllowSources = append(isolation.AllowSources, ip)
+			continue
+		}
+		networks := r.Container.ServiceSpec.ServiceNetworks()
+		ctrs = append(ctrs, networkContainer{rec: r, networks: networks})
+		if !slices.Equal(networks, []string{api.DefaultNetwork}) {
+			isolated = true
+		}
+	}
+	if !isolated {
+		return nil, nil
+	}
+
+	for _, dst := range ctrs {
+		if dst.rec.MachineID != cc.state.ID {
+			continue
+		}
+		for _, src := range ctrs {
+			if !api.NetworksIntersect(src.networks, dst.networks) {
+				continue
+			}
+			isolation.Allow = append(isolation.Allow, firewall.TenantIsolationRule{
+				Src: src.rec.Container.UncloudNetworkIP(),
+				Dst: dst.rec.Container.UncloudNetworkIP(),
+			})
+		}
+	}
+	slices.SortFunc(isolation.DenySources, func(a, b netip.Prefix) int {
+		return a.Addr().Compare(b.Addr())
+	})
+	slices.SortFunc(isolation.AllowSources, func(a, b netip.Addr) int {
+		return a.Compare(b)
+	})
+	slices.SortFunc(isolation.Allow, func(a, b firewall.TenantIsolationRule) int {
+		if c := a.Dst.Compare(b.Dst); c != 0 {
+			return c
+		}
+		return a.Src.Compare(b.Src)
+	})
+	return isolation, nil
+}
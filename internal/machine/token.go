@@ -7,23 +7,41 @@ import (
 	"net/netip"
 	"strings"
 
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/internal/secret"
 )
 
 const (
 	TokenPrefix = "mtkn:"
+	// TokenVersion is the current version of the machine token format.
+	TokenVersion = 1
 )
 
 // Token represents the machine's token for joining a cluster.
+//
+// The token is encoded as the "mtkn:" prefix followed by a standard base64-encoded JSON object with the fields:
+//   - Version: the token format version. Tokens without a version were created by older releases and are
+//     treated as version 1.
+//   - PublicKey: the hex-encoded WireGuard public key of the machine.
+//   - PublicIP: the public IP address of the machine, empty if unknown.
+//   - Endpoints: the list of WireGuard endpoints of the machine in the IP:PORT format.
+//   - DNSEndpoints: the optional list of WireGuard endpoints specified as DNS names in the HOST:PORT format.
+//
+// Optional fields that can be safely ignored by older releases may be added without changing the version.
+// Any other change to the format requires incrementing TokenVersion.
 type Token struct {
+	Version   int `json:",omitempty"`
 	PublicKey secret.Secret
 	PublicIP  netip.Addr
 	Endpoints []netip.AddrPort
+	// DNSEndpoints are endpoints specified as DNS names in the host:port format.
+	DNSEndpoints []string `json:",omitempty"`
 }
 
 // NewToken creates a new machine token with the given public key and endpoints.
 func NewToken(publicKey secret.Secret, publicIP netip.Addr, endpoints []netip.AddrPort) Token {
 	return Token{
+		Version:   TokenVersion,
 		PublicKey: publicKey,
 		PublicIP:  publicIP,
 		Endpoints: endpoints,
@@ -43,6 +61,17 @@ func ParseToken(s string) (Token, error) {
 	if err = json.Unmarshal(decoded, &token); err != nil {
 		return Token{}, fmt.Errorf("unmarshal token: %w", err)
 	}
+
+	if token.Version == 0 {
+		token.Version = 1
+	}
+	if token.Version > TokenVersion {
+		return Token{}, fmt.Errorf("unsupported token version %d (latest supported is %d), "+
+			"please upgrade uncloud", token.Version, TokenVersion)
+	}
+	if len(token.PublicKey) != pb.KeyLen {
+		return Token{}, fmt.Errorf("invalid public key length in token: %d", len(token.PublicKey))
+	}
 	return token, nil
 }
 
@@ -0,0 +1,65 @@
+package machine
+
+import (
+	"encoding/base64"
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken_StringParse(t *testing.T) {
+	t.Parallel()
+
+	_, pubKey, err := network.NewMachineKeys()
+	require.NoError(t, err)
+	token := NewToken(pubKey, netip.MustParseAddr("203.0.113.10"), []netip.AddrPort{
+		netip.MustParseAddrPort("10.0.0.2:51820"),
+		netip.MustParseAddrPort("203.0.113.10:51820"),
+	})
+	token.DNSEndpoints = []string{"home.example.com:51820"}
+
+	tokenStr, err := token.String()
+	require.NoError(t, err)
+	assert.Contains(t, tokenStr, TokenPrefix)
+
+	parsed, err := ParseToken(tokenStr)
+	require.NoError(t, err)
+	assert.Equal(t, token, parsed)
+	assert.Equal(t, TokenVersion, parsed.Version)
+}
+
+func TestParseToken_Version(t *testing.T) {
+	t.Parallel()
+
+	encode := func(json string) string {
+		return TokenPrefix + base64.StdEncoding.EncodeToString([]byte(json))
+	}
+	pubKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	t.Run("unversioned token is version 1", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := ParseToken(encode(`{"PublicKey":"` + pubKey + `","PublicIP":"",` +
+			`"Endpoints":["10.0.0.2:51820"]}`))
+		require.NoError(t, err)
+		assert.Equal(t, 1, token.Version)
+		assert.Equal(t, []netip.AddrPort{netip.MustParseAddrPort("10.0.0.2:51820")}, token.Endpoints)
+	})
+
+	t.Run("newer version is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseToken(encode(`{"Version":2,"PublicKey":"` + pubKey + `","Endpoints":[]}`))
+		require.ErrorContains(t, err, "unsupported token version 2")
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseToken(encode(`{"PublicKey":"0123","Endpoints":[]}`))
+		require.ErrorContains(t, err, "invalid public key length")
+	})
+}
@@ -0,0 +1,91 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/selfupdate"
+	"github.com/psviderski/uncloud/internal/version"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// upgradeUnit is the transient systemd unit that replaces the daemon binary and restarts the daemon.
+	upgradeUnit = "uncloud-upgrade"
+	// daemonUnit is the systemd unit of the machine daemon created by the install script.
+	daemonUnit = "uncloud.service"
+)
+
+// Upgrade downloads the machine daemon binary of the requested release verified against the signed release
+// checksums and restarts the daemon to run it. The daemon can't replace its own binary because the systemd unit
+// mounts the system directories read-only, so a delayed transient systemd unit replaces the binary and restarts
+// the daemon after the response is sent.
+func (m *Machine) Upgrade(ctx context.Context, req *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+	channel, err := selfupdate.ParseChannel(req.Channel)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	updater, err := selfupdate.New()
+	if err != nil {
+		if errors.Is(err, selfupdate.ErrNoPublicKey) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var rel selfupdate.Release
+	if req.Version != "" {
+		rel, err = updater.Release(ctx, req.Version)
+	} else {
+		rel, err = updater.Latest(ctx, channel)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "resolve release: %v", err)
+	}
+	if rel.Version == version.String() {
+		return &pb.UpgradeResponse{Version: rel.Version}, nil
+	}
+
+	binary, err := updater.Download(ctx, rel, selfupdate.ArchiveName("uncloudd", "linux", runtime.GOARCH),
+		"uncloudd")
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "download machine daemon %s: %v", rel.Version, err)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get daemon executable path: %v", err)
+	}
+	newPath := filepath.Join(m.config.DataDir, "uncloudd.new")
+	if err = os.WriteFile(newPath, binary, 0o755); err != nil {
+		return nil, status.Errorf(codes.Internal, "write machine daemon binary: %v", err)
+	}
+
+	script := fmt.Sprintf("install -m 0755 %s %s && rm -f %s && systemctl restart %s",
+		newPath, exe, newPath, daemonUnit)
+	if err = scheduleScript(ctx, upgradeUnit, script); err != nil {
+		return nil, status.Errorf(codes.Internal, "schedule machine daemon restart: %v", err)
+	}
+	slog.Info("Machine daemon is restarting to upgrade.", "from", version.String(), "to", rel.Version,
+		"channel", channel)
+
+	return &pb.UpgradeResponse{Version: rel.Version, Restarting: true}, nil
+}
+
+// scheduleScript runs the shell script in a transient systemd unit a couple of seconds later so that it can restart
+// the daemon after the response to the current request is sent.
+func scheduleScript(ctx context.Context, unit, script string) error {
+	out, err := exec.CommandContext(ctx, "systemd-run", "--unit="+unit, "--collect", "--quiet",
+		"--on-active=2", "sh", "-c", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, out)
+	}
+	return nil
+}
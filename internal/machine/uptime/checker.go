@@ -0,0 +1,207 @@
+package uptime
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// DefaultInterval is the interval at which the published endpoints are probed.
+	DefaultInterval = time.Minute
+	// Retention is how long the results of the uptime checks are kept in the cluster store.
+	Retention = 7 * 24 * time.Hour
+	// ProberCount is the maximum number of machines that probe the published endpoints.
+	ProberCount = 2
+	// requestTimeout is the timeout for probing a single endpoint.
+	requestTimeout = 10 * time.Second
+)
+
+// MachineLister lists the cluster machines with their membership states.
+type MachineLister interface {
+	ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error)
+}
+
+// Endpoint is a published service endpoint to probe.
+type Endpoint struct {
+	URL         string
+	ServiceName string
+}
+
+// Checker periodically probes the HTTPS endpoints published by services and records the results in the cluster
+// store. To avoid every machine probing every endpoint, only the first ProberCount UP machines ordered by ID
+// (the leaders) run the checks. If a leader goes down, the next UP machine takes over.
+type Checker struct {
+	machineID string
+	store     *store.Store
+	machines  MachineLister
+	client    *http.Client
+	interval  time.Duration
+	// up tracks the availability of the endpoints from the last check to detect changes.
+	up  map[string]bool
+	log *slog.Logger
+}
+
+func NewChecker(machineID string, store *store.Store, machines MachineLister) *Checker {
+	return &Checker{
+		machineID: machineID,
+		store:     store,
+		machines:  machines,
+		client:    &http.Client{Timeout: requestTimeout},
+		interval:  DefaultInterval,
+		up:        make(map[string]bool),
+		log:       slog.With("component", "uptime-checker"),
+	}
+}
+
+// Run probes the published endpoints every interval until the context is canceled.
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.check(ctx); err != nil {
+				c.log.Error("Failed to check uptime of published endpoints.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Checker) check(ctx context.Context) error {
+	resp, err := c.machines.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if !slices.Contains(Probers(resp.Machines, ProberCount), c.machineID) {
+		// Reset the state so that the changes are not reported when this machine becomes a prober again.
+		clear(c.up)
+		return nil
+	}
+
+	records, err := c.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+	endpoints := Endpoints(records)
+
+	results := make([]store.UptimeCheckRecord, len(endpoints))
+	wg := sync.WaitGroup{}
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.probe(ctx, e)
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		if err = c.store.CreateUptimeCheck(ctx, r); err != nil {
+			return fmt.Errorf("store uptime check: %w", err)
+		}
+
+		up := r.Error == "" && r.StatusCode < http.StatusInternalServerError
+		if prev, ok := c.up[r.URL]; ok && prev != up {
+			if up {
+				c.log.Info("Endpoint is up.", "service", r.ServiceName, "url", r.URL, "status", r.StatusCode)
+			} else {
+				c.log.Warn("Endpoint is down.", "service", r.ServiceName, "url", r.URL, "status", r.StatusCode,
+					"err", r.Error)
+			}
+		}
+		c.up[r.URL] = up
+	}
+
+	if err = c.store.DeleteUptimeChecks(ctx, time.Now().Add(-Retention)); err != nil {
+		return fmt.Errorf("delete old uptime checks: %w", err)
+	}
+	return nil
+}
+
+func (c *Checker) probe(ctx context.Context, e Endpoint) store.UptimeCheckRecord {
+	r := store.UptimeCheckRecord{
+		URL:         e.URL,
+		MachineID:   c.machineID,
+		CheckedAt:   time.Now(),
+		ServiceName: e.ServiceName,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.URL, nil)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	req.Header.Set("User-Agent", "uncloud-uptime-checker")
+
+	resp, err := c.client.Do(req)
+	r.Latency = time.Since(r.CheckedAt)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	resp.Body.Close()
+	r.StatusCode = resp.StatusCode
+
+	return r
+}
+
+// Probers returns the IDs of up to n UP machines ordered by ID that should probe the published endpoints.
+func Probers(machines []*pb.MachineMember, n int) []string {
+	var ids []string
+	for _, m := range machines {
+		if m.State == pb.MachineMember_UP {
+			ids = append(ids, m.Machine.Id)
+		}
+	}
+	slices.Sort(ids)
+
+	return ids[:min(n, len(ids))]
+}
+
+// Endpoints returns the HTTPS endpoints published by the healthy service containers sorted by URL.
+func Endpoints(records []store.ContainerRecord) []Endpoint {
+	seen := make(map[string]struct{})
+	var endpoints []Endpoint
+	for _, r := range records {
+		if !r.Container.Healthy() {
+			continue
+		}
+		ports, err := r.Container.ServicePorts()
+		if err != nil {
+			continue
+		}
+
+		for _, p := range ports {
+			// Wildcard hostnames can't be probed.
+			if p.Mode != api.PortModeIngress || p.Protocol != api.ProtocolHTTPS || p.Hostname == "" ||
+				strings.Contains(p.Hostname, "*") {
+				continue
+			}
+			url := "https://" + p.Hostname + "/"
+			if _, ok := seen[url]; ok {
+				continue
+			}
+			seen[url] = struct{}{}
+			endpoints = append(endpoints, Endpoint{URL: url, ServiceName: r.Container.ServiceName()})
+		}
+	}
+	slices.SortFunc(endpoints, func(a, b Endpoint) int {
+		return cmp.Compare(a.URL, b.URL)
+	})
+
+	return endpoints
+}
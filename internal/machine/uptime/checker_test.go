@@ -0,0 +1,60 @@
+package uptime
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbers(t *testing.T) {
+	t.Parallel()
+
+	member := func(id string, state pb.MachineMember_MembershipState) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{Id: id}, State: state}
+	}
+	machines := []*pb.MachineMember{
+		member("c", pb.MachineMember_UP),
+		member("a", pb.MachineMember_DOWN),
+		member("d", pb.MachineMember_UP),
+		member("b", pb.MachineMember_SUSPECT),
+	}
+
+	assert.Equal(t, []string{"c", "d"}, Probers(machines, 2))
+	assert.Equal(t, []string{"c"}, Probers(machines, 1))
+	assert.Empty(t, Probers(nil, 2))
+}
+
+func TestEndpoints(t *testing.T) {
+	t.Parallel()
+
+	record := func(service, ports string, running bool) store.ContainerRecord {
+		return store.ContainerRecord{Container: api.ServiceContainer{Container: api.Container{
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{Running: running}},
+				Config: &container.Config{Labels: map[string]string{
+					api.LabelServiceName:  service,
+					api.LabelServicePorts: ports,
+				}},
+			},
+		}}}
+	}
+	records := []store.ContainerRecord{
+		record("web", "web.example.com:8080/https,www.example.com:8080/https", true),
+		// The same endpoint is published by multiple containers.
+		record("web", "web.example.com:8080/https", true),
+		record("api", "api.example.com:8000/https,admin.example.com:8001/http", true),
+		record("stopped", "stopped.example.com:80/https", false),
+		record("db", "5432:5432/tcp@host", true),
+	}
+
+	assert.Equal(t, []Endpoint{
+		{URL: "https://api.example.com/", ServiceName: "api"},
+		{URL: "https://web.example.com/", ServiceName: "web"},
+		{URL: "https://www.example.com/", ServiceName: "web"},
+	}, Endpoints(records))
+}
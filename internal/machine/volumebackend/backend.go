@@ -0,0 +1,204 @@
+package volumebackend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Volume is a local Docker volume.
+type Volume struct {
+	Name string
+	// Mountpoint is the host directory with the volume data.
+	Mountpoint string
+}
+
+// Backend manages the data of local volumes on a particular filesystem. The native btrfs and ZFS backends use
+// copy-on-write snapshots and send/receive streams that are much faster than archiving the volume data with tar.
+type Backend interface {
+	// Name returns the name of the backend: api.VolumeBackendTar, api.VolumeBackendBtrfs, or api.VolumeBackendZFS.
+	Name() string
+	// Snapshot creates a snapshot of the volume data and returns its location.
+	Snapshot(ctx context.Context, v Volume, id string) (string, error)
+	// Restore replaces the volume data with the snapshot at the location.
+	Restore(ctx context.Context, v Volume, location string) error
+	// RemoveSnapshot removes the snapshot at the location.
+	RemoveSnapshot(ctx context.Context, location string) error
+	// Send writes a stream of the volume data in the backend format to the writer.
+	Send(ctx context.Context, v Volume, w io.Writer) error
+	// Receive replaces the volume data with a stream in the backend format read from the reader.
+	Receive(ctx context.Context, v Volume, r io.Reader) error
+}
+
+// Manager detects the backends of local volumes and snapshots and transfers the volume data using them.
+type Manager struct {
+	// dir is the directory where the snapshot metadata and tar archives are stored.
+	dir string
+}
+
+func NewManager(dir string) *Manager {
+	return &Manager{dir: dir}
+}
+
+// Backend returns the native backend if the volume directory is a btrfs subvolume or the mountpoint of a ZFS dataset,
+// or the tar backend otherwise.
+func (m *Manager) Backend(ctx context.Context, v Volume) (Backend, error) {
+	switch {
+	case isBtrfsSubvolume(v.Mountpoint):
+		return btrfsBackend{}, nil
+	case isZFS(v.Mountpoint):
+		dataset, err := zfsDataset(ctx, v.Mountpoint)
+		if err != nil {
+			return nil, err
+		}
+		if dataset != "" {
+			return zfsBackend{dataset: dataset}, nil
+		}
+	}
+	return tarBackend{dir: m.dir}, nil
+}
+
+// transferBackend returns the backend to send or receive the volume data in the format of the backend with the given
+// name. Any volume can be transferred with tar while native formats require the volume to use the same backend.
+func (m *Manager) transferBackend(ctx context.Context, v Volume, name string) (Backend, error) {
+	if name == api.VolumeBackendTar {
+		return tarBackend{dir: m.dir}, nil
+	}
+	b, err := m.Backend(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	if b.Name() != name {
+		return nil, fmt.Errorf("volume '%s' uses %s backend, cannot transfer it in %s format", v.Name, b.Name(), name)
+	}
+	return b, nil
+}
+
+// Send writes a stream of the volume data in the format of the backend with the given name to the writer.
+func (m *Manager) Send(ctx context.Context, v Volume, backend string, w io.Writer) error {
+	b, err := m.transferBackend(ctx, v, backend)
+	if err != nil {
+		return err
+	}
+	return b.Send(ctx, v, w)
+}
+
+// Receive replaces the volume data with a stream in the format of the backend with the given name read from
+// the reader. The caller must ensure the volume is not used by running containers.
+func (m *Manager) Receive(ctx context.Context, v Volume, backend string, r io.Reader) error {
+	b, err := m.transferBackend(ctx, v, backend)
+	if err != nil {
+		return err
+	}
+	return b.Receive(ctx, v, r)
+}
+
+// Provision converts the directory of a new empty volume into a btrfs subvolume or a ZFS dataset if it's on btrfs
+// or ZFS so the native backend can be used for the volume. The volume remains a plain directory managed by
+// the tar backend if the filesystem doesn't support snapshots or the conversion fails.
+func (m *Manager) Provision(ctx context.Context, v Volume) {
+	if b, err := m.Backend(ctx, v); err != nil || b.Name() != api.VolumeBackendTar {
+		return
+	}
+	// Only new volumes without data are provisioned.
+	if empty, err := isEmptyDir(v.Mountpoint); err != nil || !empty {
+		return
+	}
+
+	var backend string
+	var err error
+	switch {
+	case isBtrfs(v.Mountpoint):
+		backend = api.VolumeBackendBtrfs
+		err = provisionBtrfs(ctx, v)
+	case isZFS(v.Mountpoint):
+		backend = api.VolumeBackendZFS
+		err = provisionZFS(ctx, v)
+	default:
+		return
+	}
+	if err != nil {
+		slog.Warn("Failed to provision volume with native backend, falling back to tar.",
+			"volume", v.Name, "backend", backend, "err", err)
+		return
+	}
+	slog.Info("Provisioned volume with native backend.", "volume", v.Name, "backend", backend)
+}
+
+// Cleanup removes the snapshots of the volume and the ZFS dataset provisioned for it before the volume is removed.
+// The caller must ensure the volume is not used by any containers.
+func (m *Manager) Cleanup(ctx context.Context, v Volume) error {
+	if err := validateName(v.Name); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case isBtrfsSubvolume(v.Mountpoint):
+		err = cleanupBtrfs(ctx, v)
+	case isZFS(v.Mountpoint):
+		err = cleanupZFS(ctx, v)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(filepath.Join(m.dir, v.Name)); err != nil {
+		return fmt.Errorf("remove volume snapshots: %w", err)
+	}
+	return nil
+}
+
+func validateName(volumeName string) error {
+	if !nameRegexp.MatchString(volumeName) {
+		return fmt.Errorf("invalid volume name: %q", volumeName)
+	}
+	return nil
+}
+
+// isEmptyDir returns true if the directory has no entries.
+func isEmptyDir(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if _, err = f.Readdirnames(1); errors.Is(err, io.EOF) {
+		return true, nil
+	}
+	return false, err
+}
+
+func run(ctx context.Context, name string, args ...string) error {
+	if out, err := exec.CommandContext(ctx, name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// runStream runs the command with the given stdin and stdout. Either of them can be nil.
+func runStream(ctx context.Context, stdin io.Reader, stdout io.Writer, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package volumebackend
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_TarSendReceive(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(t.TempDir())
+	src := Volume{Name: "src", Mountpoint: t.TempDir()}
+	dst := Volume{Name: "dst", Mountpoint: t.TempDir()}
+
+	require.NoError(t, os.WriteFile(filepath.Join(src.Mountpoint, "data.txt"), []byte("data"), 0o640))
+	require.NoError(t, os.Symlink("data.txt", filepath.Join(src.Mountpoint, "link")))
+	require.NoError(t, os.WriteFile(filepath.Join(dst.Mountpoint, "stale.txt"), []byte("stale"), 0o644))
+
+	var stream bytes.Buffer
+	require.NoError(t, m.Send(ctx, src, api.VolumeBackendTar, &stream))
+	require.NoError(t, m.Receive(ctx, dst, api.VolumeBackendTar, &stream))
+
+	data, err := os.ReadFile(filepath.Join(dst.Mountpoint, "data.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+	target, err := os.Readlink(filepath.Join(dst.Mountpoint, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "data.txt", target)
+	assert.NoFileExists(t, filepath.Join(dst.Mountpoint, "stale.txt"))
+}
+
+func TestManager_SendNativeFormatMismatch(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(t.TempDir())
+	v := Volume{Name: "data", Mountpoint: t.TempDir()}
+
+	b, err := m.Backend(ctx, v)
+	require.NoError(t, err)
+	if b.Name() != api.VolumeBackendTar {
+		t.Skipf("temporary directory is on %s filesystem", b.Name())
+	}
+
+	err = m.Send(ctx, v, api.VolumeBackendBtrfs, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "volume 'data' uses tar backend, cannot transfer it in btrfs format")
+}
@@ -0,0 +1,141 @@
+package volumebackend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// btrfsBackend works with volumes which directory is a btrfs subvolume. Snapshots are created next to the volume
+// directory as a btrfs snapshot must be on the same filesystem as its subvolume.
+type btrfsBackend struct{}
+
+func (btrfsBackend) Name() string {
+	return api.VolumeBackendBtrfs
+}
+
+func (btrfsBackend) Snapshot(ctx context.Context, v Volume, id string) (string, error) {
+	location := filepath.Join(filepath.Dir(v.Mountpoint), "uncloud-snapshot-"+id)
+	if err := run(ctx, "btrfs", "subvolume", "snapshot", v.Mountpoint, location); err != nil {
+		return "", err
+	}
+	return location, nil
+}
+
+func (btrfsBackend) Restore(ctx context.Context, v Volume, location string) error {
+	// Restore from a snapshot of the snapshot to be able to restore it again.
+	return replaceSubvolume(ctx, v.Mountpoint, location)
+}
+
+func (btrfsBackend) RemoveSnapshot(ctx context.Context, location string) error {
+	return run(ctx, "btrfs", "subvolume", "delete", location)
+}
+
+func (btrfsBackend) Send(ctx context.Context, v Volume, w io.Writer) error {
+	// Only read-only snapshots can be sent.
+	snapshot := filepath.Join(filepath.Dir(v.Mountpoint), "uncloud-send-"+strconv.FormatInt(time.Now().UnixNano(), 10))
+	if err := run(ctx, "btrfs", "subvolume", "snapshot", "-r", v.Mountpoint, snapshot); err != nil {
+		return err
+	}
+	defer func() {
+		_ = run(context.Background(), "btrfs", "subvolume", "delete", snapshot)
+	}()
+
+	return runStream(ctx, nil, w, "btrfs", "send", snapshot)
+}
+
+func (btrfsBackend) Receive(ctx context.Context, v Volume, r io.Reader) error {
+	// The stream is received as a read-only subvolume named after the sent snapshot into a temporary directory.
+	tmpDir, err := os.MkdirTemp(filepath.Dir(v.Mountpoint), "uncloud-receive-")
+	if err != nil {
+		return fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer os.Remove(tmpDir)
+
+	if err = runStream(ctx, r, nil, "btrfs", "receive", tmpDir); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return fmt.Errorf("read received subvolume: %w", err)
+	}
+	if len(entries) != 1 {
+		return fmt.Errorf("expected one received subvolume, got %d", len(entries))
+	}
+	received := filepath.Join(tmpDir, entries[0].Name())
+	defer func() {
+		_ = run(context.Background(), "btrfs", "subvolume", "delete", received)
+	}()
+
+	return replaceSubvolume(ctx, v.Mountpoint, received)
+}
+
+// replaceSubvolume replaces the subvolume at the path with a writable snapshot of the source subvolume. The snapshot
+// is created next to the path and swapped in by renaming, and the replaced subvolume is deleted last so the volume
+// is left intact if any step before the swap fails.
+func replaceSubvolume(ctx context.Context, path, source string) error {
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	tmp := filepath.Join(filepath.Dir(path), "uncloud-replace-"+suffix)
+	if err := run(ctx, "btrfs", "subvolume", "snapshot", source, tmp); err != nil {
+		return fmt.Errorf("snapshot source subvolume: %w", err)
+	}
+
+	old := filepath.Join(filepath.Dir(path), "uncloud-replaced-"+suffix)
+	if err := os.Rename(path, old); err != nil {
+		_ = run(context.Background(), "btrfs", "subvolume", "delete", tmp)
+		return fmt.Errorf("move volume subvolume aside: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		if rerr := os.Rename(old, path); rerr != nil {
+			return fmt.Errorf("move snapshot to volume: %w (restore volume subvolume from '%s': %v)", err, old, rerr)
+		}
+		_ = run(context.Background(), "btrfs", "subvolume", "delete", tmp)
+		return fmt.Errorf("move snapshot to volume: %w", err)
+	}
+
+	if err := run(ctx, "btrfs", "subvolume", "delete", old); err != nil {
+		// The volume has been replaced, the leftover subvolume is deleted with the volume snapshots.
+		slog.Warn("Failed to delete replaced volume subvolume.", "path", old, "err", err)
+	}
+	return nil
+}
+
+// provisionBtrfs replaces the empty volume directory with a subvolume preserving its ownership and permissions.
+func provisionBtrfs(ctx context.Context, v Volume) error {
+	info, err := os.Stat(v.Mountpoint)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(v.Mountpoint); err != nil {
+		return fmt.Errorf("remove volume directory: %w", err)
+	}
+	if err = run(ctx, "btrfs", "subvolume", "create", v.Mountpoint); err != nil {
+		// Recreate the plain directory so the volume remains usable.
+		_ = os.Mkdir(v.Mountpoint, info.Mode().Perm())
+		return err
+	}
+
+	return restoreOwnership(v.Mountpoint, info)
+}
+
+// cleanupBtrfs deletes the snapshot subvolumes next to the volume directory.
+func cleanupBtrfs(ctx context.Context, v Volume) error {
+	snapshots, err := filepath.Glob(filepath.Join(filepath.Dir(v.Mountpoint), "uncloud-*"))
+	if err != nil {
+		return err
+	}
+	for _, s := range snapshots {
+		if err = run(ctx, "btrfs", "subvolume", "delete", s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package volumebackend
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	btrfsSuperMagic = 0x9123683e
+	zfsSuperMagic   = 0x2fc12fc1
+	// btrfsSubvolumeIno is the inode number of the root directory of every btrfs subvolume.
+	btrfsSubvolumeIno = 256
+)
+
+func fsType(path string) int64 {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0
+	}
+	return st.Type
+}
+
+func isBtrfs(path string) bool {
+	return fsType(path) == btrfsSuperMagic
+}
+
+func isBtrfsSubvolume(path string) bool {
+	if !isBtrfs(path) {
+		return false
+	}
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return false
+	}
+	return st.Ino == btrfsSubvolumeIno
+}
+
+func isZFS(path string) bool {
+	return fsType(path) == zfsSuperMagic
+}
+
+// restoreOwnership sets the ownership and permissions of the path to the ones in the file info.
+func restoreOwnership(path string, info os.FileInfo) error {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		if err := os.Lchown(path, int(st.Uid), int(st.Gid)); err != nil {
+			return fmt.Errorf("chown volume directory: %w", err)
+		}
+	}
+	if err := os.Chmod(path, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("chmod volume directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package volumebackend
+
+import "os"
+
+// Native filesystem backends are only supported on Linux, the tar backend is used on other platforms.
+
+func isBtrfs(string) bool {
+	return false
+}
+
+func isBtrfsSubvolume(string) bool {
+	return false
+}
+
+func isZFS(string) bool {
+	return false
+}
+
+func restoreOwnership(string, os.FileInfo) error {
+	return nil
+}
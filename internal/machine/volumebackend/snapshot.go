@@ -0,0 +1,191 @@
+package volumebackend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ErrSnapshotNotFound is returned when a snapshot doesn't exist.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// snapshotMetadata is stored alongside each snapshot to know how to restore and remove it.
+type snapshotMetadata struct {
+	Snapshot api.VolumeSnapshot
+	// Location is the path to the tar archive or btrfs snapshot, or the name of the ZFS snapshot.
+	Location string
+}
+
+// CreateSnapshot snapshots the volume data using the volume backend.
+func (m *Manager) CreateSnapshot(ctx context.Context, v Volume, id string) (api.VolumeSnapshot, error) {
+	if err := validateNames(v.Name, id); err != nil {
+		return api.VolumeSnapshot{}, err
+	}
+	if _, err := os.Stat(m.metadataPath(v.Name, id)); err == nil {
+		return api.VolumeSnapshot{}, fmt.Errorf("snapshot '%s' of volume '%s' already exists", id, v.Name)
+	}
+	if err := os.MkdirAll(filepath.Join(m.dir, v.Name), 0o700); err != nil {
+		return api.VolumeSnapshot{}, fmt.Errorf("create snapshot directory: %w", err)
+	}
+
+	b, err := m.Backend(ctx, v)
+	if err != nil {
+		return api.VolumeSnapshot{}, err
+	}
+	meta := snapshotMetadata{
+		Snapshot: api.VolumeSnapshot{
+			ID:         id,
+			VolumeName: v.Name,
+			Backend:    b.Name(),
+			CreatedAt:  time.Now().UTC(),
+		},
+	}
+	if meta.Location, err = b.Snapshot(ctx, v, id); err != nil {
+		return api.VolumeSnapshot{}, fmt.Errorf("create %s snapshot: %w", b.Name(), err)
+	}
+	if b.Name() == api.VolumeBackendTar {
+		info, err := os.Stat(meta.Location)
+		if err != nil {
+			return api.VolumeSnapshot{}, fmt.Errorf("stat archive: %w", err)
+		}
+		meta.Snapshot.Size = info.Size()
+	}
+
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return api.VolumeSnapshot{}, fmt.Errorf("marshal snapshot metadata: %w", err)
+	}
+	if err = os.WriteFile(m.metadataPath(v.Name, id), metaJSON, 0o600); err != nil {
+		return api.VolumeSnapshot{}, fmt.Errorf("write snapshot metadata: %w", err)
+	}
+
+	return meta.Snapshot, nil
+}
+
+// RestoreSnapshot replaces the volume data with the snapshot. The caller must ensure the volume is not used
+// by running containers.
+func (m *Manager) RestoreSnapshot(ctx context.Context, v Volume, id string) error {
+	meta, err := m.snapshotMetadata(v.Name, id)
+	if err != nil {
+		return err
+	}
+	b, err := m.snapshotBackend(meta)
+	if err != nil {
+		return err
+	}
+	if err = b.Restore(ctx, v, meta.Location); err != nil {
+		return err
+	}
+
+	if b.Name() == api.VolumeBackendZFS {
+		// The ZFS rollback destroys the snapshots newer than the restored one so remove their metadata as well.
+		if err = m.removeDestroyedZFSSnapshots(ctx, v.Name, meta.Location); err != nil {
+			return fmt.Errorf("remove metadata of destroyed snapshots: %w", err)
+		}
+	}
+	return nil
+}
+
+// removeDestroyedZFSSnapshots removes the metadata of the ZFS snapshots of the volume that no longer exist in
+// the dataset of the given snapshot location.
+func (m *Manager) removeDestroyedZFSSnapshots(ctx context.Context, volumeName, location string) error {
+	dataset, _, _ := strings.Cut(location, "@")
+	existing, err := zfsSnapshots(ctx, dataset)
+	if err != nil {
+		return err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(m.dir, volumeName, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		meta, err := m.snapshotMetadata(volumeName, strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			return err
+		}
+		if meta.Snapshot.Backend != api.VolumeBackendZFS || slices.Contains(existing, meta.Location) {
+			continue
+		}
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveSnapshot deletes the snapshot. It's a no-op if the snapshot doesn't exist.
+func (m *Manager) RemoveSnapshot(ctx context.Context, volumeName, id string) error {
+	meta, err := m.snapshotMetadata(volumeName, id)
+	if err != nil {
+		if errors.Is(err, ErrSnapshotNotFound) {
+			return nil
+		}
+		return err
+	}
+	b, err := m.snapshotBackend(meta)
+	if err != nil {
+		return err
+	}
+
+	if err = b.RemoveSnapshot(ctx, meta.Location); err != nil {
+		return fmt.Errorf("remove %s snapshot: %w", b.Name(), err)
+	}
+	return os.Remove(m.metadataPath(volumeName, id))
+}
+
+// snapshotBackend returns the backend that created the snapshot. The snapshot location is sufficient for the backends
+// to restore and remove it.
+func (m *Manager) snapshotBackend(meta snapshotMetadata) (Backend, error) {
+	switch meta.Snapshot.Backend {
+	case api.VolumeBackendTar:
+		return tarBackend{dir: m.dir}, nil
+	case api.VolumeBackendBtrfs:
+		return btrfsBackend{}, nil
+	case api.VolumeBackendZFS:
+		return zfsBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown snapshot backend: %q", meta.Snapshot.Backend)
+	}
+}
+
+func (m *Manager) snapshotMetadata(volumeName, id string) (snapshotMetadata, error) {
+	var meta snapshotMetadata
+	if err := validateNames(volumeName, id); err != nil {
+		return meta, err
+	}
+
+	data, err := os.ReadFile(m.metadataPath(volumeName, id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return meta, ErrSnapshotNotFound
+		}
+		return meta, fmt.Errorf("read snapshot metadata: %w", err)
+	}
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("unmarshal snapshot metadata: %w", err)
+	}
+	return meta, nil
+}
+
+func (m *Manager) metadataPath(volumeName, id string) string {
+	return filepath.Join(m.dir, volumeName, id+".json")
+}
+
+func validateNames(volumeName, id string) error {
+	if err := validateName(volumeName); err != nil {
+		return err
+	}
+	if !nameRegexp.MatchString(id) {
+		return fmt.Errorf("invalid snapshot ID: %q", id)
+	}
+	return nil
+}
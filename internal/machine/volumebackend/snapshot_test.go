@@ -0,0 +1,66 @@
+package volumebackend
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_TarSnapshot(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(t.TempDir())
+	volume := t.TempDir()
+	v := Volume{Name: "db-data", Mountpoint: volume}
+
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "data.txt"), []byte("before"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(volume, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "dir", "nested.txt"), []byte("nested"), 0o600))
+
+	snap, err := m.CreateSnapshot(ctx, v, "20250101T000000Z")
+	require.NoError(t, err)
+	assert.Equal(t, "20250101T000000Z", snap.ID)
+	assert.Equal(t, "db-data", snap.VolumeName)
+	assert.Equal(t, api.VolumeBackendTar, snap.Backend)
+	assert.Positive(t, snap.Size)
+
+	_, err = m.CreateSnapshot(ctx, v, "20250101T000000Z")
+	assert.ErrorContains(t, err, "already exists")
+
+	// Modify the volume after the snapshot.
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "data.txt"), []byte("after"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "new.txt"), []byte("new"), 0o644))
+	require.NoError(t, os.RemoveAll(filepath.Join(volume, "dir")))
+
+	require.NoError(t, m.RestoreSnapshot(ctx, v, "20250101T000000Z"))
+
+	data, err := os.ReadFile(filepath.Join(volume, "data.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "before", string(data))
+	data, err = os.ReadFile(filepath.Join(volume, "dir", "nested.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "nested", string(data))
+	info, err := os.Stat(filepath.Join(volume, "dir", "nested.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	assert.NoFileExists(t, filepath.Join(volume, "new.txt"))
+
+	require.NoError(t, m.RemoveSnapshot(ctx, "db-data", "20250101T000000Z"))
+	assert.ErrorIs(t, m.RestoreSnapshot(ctx, v, "20250101T000000Z"), ErrSnapshotNotFound)
+	// Removing a non-existent snapshot is a no-op.
+	assert.NoError(t, m.RemoveSnapshot(ctx, "db-data", "20250101T000000Z"))
+}
+
+func TestManager_SnapshotInvalidNames(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(t.TempDir())
+
+	_, err := m.CreateSnapshot(ctx, Volume{Name: "../etc", Mountpoint: t.TempDir()}, "id")
+	assert.ErrorContains(t, err, "invalid volume name")
+	_, err = m.CreateSnapshot(ctx, Volume{Name: "data", Mountpoint: t.TempDir()}, "../id")
+	assert.ErrorContains(t, err, "invalid snapshot ID")
+}
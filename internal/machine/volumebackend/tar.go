@@ -0,0 +1,76 @@
+package volumebackend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// tarBackend works with volume data in any directory. Snapshots are stored as gzip-compressed tar archives
+// in the snapshot directory and transfers are tar streams.
+type tarBackend struct {
+	dir string
+}
+
+func (tarBackend) Name() string {
+	return api.VolumeBackendTar
+}
+
+func (b tarBackend) Snapshot(ctx context.Context, v Volume, id string) (string, error) {
+	path := filepath.Join(b.dir, v.Name, id+".tar.gz")
+	tmpPath := path + ".tmp"
+	if err := run(ctx, "tar", "-czf", tmpPath, "--numeric-owner", "-C", v.Mountpoint, "."); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", fmt.Errorf("rename archive: %w", err)
+	}
+	return path, nil
+}
+
+func (tarBackend) Restore(ctx context.Context, v Volume, location string) error {
+	if _, err := os.Stat(location); err != nil {
+		return fmt.Errorf("stat archive: %w", err)
+	}
+	if err := clearDir(v.Mountpoint); err != nil {
+		return err
+	}
+	return run(ctx, "tar", "-xzpf", location, "--numeric-owner", "-C", v.Mountpoint)
+}
+
+func (tarBackend) RemoveSnapshot(_ context.Context, location string) error {
+	if err := os.Remove(location); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (tarBackend) Send(ctx context.Context, v Volume, w io.Writer) error {
+	return runStream(ctx, nil, w, "tar", "-cz", "--numeric-owner", "-C", v.Mountpoint, ".")
+}
+
+func (tarBackend) Receive(ctx context.Context, v Volume, r io.Reader) error {
+	if err := clearDir(v.Mountpoint); err != nil {
+		return err
+	}
+	return runStream(ctx, r, nil, "tar", "-xzp", "--numeric-owner", "-C", v.Mountpoint)
+}
+
+// clearDir removes the contents of the directory.
+func clearDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read volume directory: %w", err)
+	}
+	for _, e := range entries {
+		if err = os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return fmt.Errorf("clear volume directory: %w", err)
+		}
+	}
+	return nil
+}
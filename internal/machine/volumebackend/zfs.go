@@ -0,0 +1,173 @@
+package volumebackend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// zfsVolumesDataset is the name of the dataset under which the datasets for new volumes are provisioned. It's created
+// as a child of the dataset containing the Docker volumes directory.
+const zfsVolumesDataset = "uncloud-volumes"
+
+// zfsBackend works with volumes which directory is the mountpoint of a ZFS dataset.
+type zfsBackend struct {
+	dataset string
+}
+
+func (zfsBackend) Name() string {
+	return api.VolumeBackendZFS
+}
+
+func (b zfsBackend) Snapshot(ctx context.Context, _ Volume, id string) (string, error) {
+	location := b.dataset + "@uncloud-" + id
+	if err := run(ctx, "zfs", "snapshot", location); err != nil {
+		return "", err
+	}
+	return location, nil
+}
+
+func (zfsBackend) Restore(ctx context.Context, _ Volume, location string) error {
+	// -r destroys the snapshots newer than the one being restored.
+	return run(ctx, "zfs", "rollback", "-r", location)
+}
+
+func (zfsBackend) RemoveSnapshot(ctx context.Context, location string) error {
+	return run(ctx, "zfs", "destroy", location)
+}
+
+func (b zfsBackend) Send(ctx context.Context, _ Volume, w io.Writer) error {
+	snapshot := b.dataset + "@uncloud-send-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := run(ctx, "zfs", "snapshot", snapshot); err != nil {
+		return err
+	}
+	defer func() {
+		_ = run(context.Background(), "zfs", "destroy", snapshot)
+	}()
+
+	return runStream(ctx, nil, w, "zfs", "send", snapshot)
+}
+
+func (b zfsBackend) Receive(ctx context.Context, v Volume, r io.Reader) error {
+	// -F overwrites the existing dataset with the full stream destroying its snapshots. The mountpoint is set
+	// explicitly as the local property is replaced by the received dataset.
+	err := runStream(ctx, r, nil, "zfs", "receive", "-F", "-o", "mountpoint="+v.Mountpoint, b.dataset)
+	if err != nil {
+		return err
+	}
+
+	// Remove the transfer snapshot received with the stream.
+	return destroySnapshots(ctx, b.dataset, "@uncloud-send-")
+}
+
+// zfsDataset returns the name of the ZFS dataset mounted exactly at the mountpoint or an empty string if
+// the mountpoint is just a directory within a dataset.
+func zfsDataset(ctx context.Context, mountpoint string) (string, error) {
+	datasets, err := zfsMountpoints(ctx)
+	if err != nil {
+		return "", err
+	}
+	return datasets[filepath.Clean(mountpoint)], nil
+}
+
+// zfsMountpoints returns a map of mountpoints to the names of ZFS filesystem datasets mounted at them.
+func zfsMountpoints(ctx context.Context) (map[string]string, error) {
+	out, err := exec.CommandContext(ctx, "zfs", "list", "-H", "-o", "name,mountpoint", "-t", "filesystem").Output()
+	if err != nil {
+		return nil, fmt.Errorf("list ZFS datasets: %w", err)
+	}
+
+	datasets := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		name, mnt, ok := strings.Cut(line, "\t")
+		if ok && filepath.IsAbs(mnt) {
+			datasets[filepath.Clean(mnt)] = name
+		}
+	}
+	return datasets, nil
+}
+
+// provisionZFS creates a dataset for the empty volume directory under the zfsVolumesDataset of the dataset containing
+// the directory and mounts it at the directory preserving its ownership and permissions.
+func provisionZFS(ctx context.Context, v Volume) error {
+	info, err := os.Stat(v.Mountpoint)
+	if err != nil {
+		return err
+	}
+
+	datasets, err := zfsMountpoints(ctx)
+	if err != nil {
+		return err
+	}
+	// Find the dataset containing the volume directory by walking up the directory tree.
+	var parent string
+	for dir := filepath.Dir(v.Mountpoint); parent == ""; dir = filepath.Dir(dir) {
+		parent = datasets[dir]
+		if dir == "/" {
+			break
+		}
+	}
+	if parent == "" {
+		return fmt.Errorf("dataset containing volume directory not found: %s", v.Mountpoint)
+	}
+
+	volumesDataset := parent + "/" + zfsVolumesDataset
+	if err = run(ctx, "zfs", "list", "-H", "-o", "name", volumesDataset); err != nil {
+		// The parent dataset for volumes is not mounted, it only groups the volume datasets.
+		if err = run(ctx, "zfs", "create", "-o", "canmount=off", "-o", "mountpoint=none", volumesDataset); err != nil {
+			return err
+		}
+	}
+	if err = run(ctx, "zfs", "create", "-o", "mountpoint="+v.Mountpoint, volumesDataset+"/"+v.Name); err != nil {
+		return err
+	}
+
+	return restoreOwnership(v.Mountpoint, info)
+}
+
+// cleanupZFS destroys the dataset provisioned for the volume with its snapshots. Only the snapshots are destroyed
+// for datasets that were not provisioned by Uncloud.
+func cleanupZFS(ctx context.Context, v Volume) error {
+	dataset, err := zfsDataset(ctx, v.Mountpoint)
+	if err != nil || dataset == "" {
+		return err
+	}
+	if strings.HasSuffix(dataset, "/"+zfsVolumesDataset+"/"+v.Name) {
+		return run(ctx, "zfs", "destroy", "-r", dataset)
+	}
+	return destroySnapshots(ctx, dataset, "@uncloud-")
+}
+
+// zfsSnapshots returns the names of the snapshots of the dataset.
+func zfsSnapshots(ctx context.Context, dataset string) ([]string, error) {
+	out, err := exec.CommandContext(ctx, "zfs", "list", "-H", "-o", "name", "-t", "snapshot", dataset).Output()
+	if err != nil {
+		return nil, fmt.Errorf("list ZFS snapshots: %w", err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// destroySnapshots destroys the snapshots of the dataset which names contain the substring.
+func destroySnapshots(ctx context.Context, dataset, substr string) error {
+	snapshots, err := zfsSnapshots(ctx, dataset)
+	if err != nil {
+		return err
+	}
+	for _, name := range snapshots {
+		if !strings.Contains(name, substr) {
+			continue
+		}
+		if err := run(ctx, "zfs", "destroy", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+// Package selfupdate resolves Uncloud releases on a release channel and downloads their binaries verified against
+// the signed release checksums.
+package selfupdate
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// Channel is a release channel the binaries are updated from.
+type Channel string
+
+const (
+	// ChannelStable includes only stable releases.
+	ChannelStable Channel = "stable"
+	// ChannelBeta includes pre-releases and stable releases, whichever is newer.
+	ChannelBeta Channel = "beta"
+)
+
+// ParseChannel parses the release channel name. An empty string defaults to the stable channel.
+func ParseChannel(s string) (Channel, error) {
+	switch c := Channel(s); c {
+	case "":
+		return ChannelStable, nil
+	case ChannelStable, ChannelBeta:
+		return c, nil
+	default:
+		return "", fmt.Errorf("invalid release channel '%s', expected '%s' or '%s'", s, ChannelStable, ChannelBeta)
+	}
+}
+
+const (
+	// DefaultReleasesURL is the GitHub API endpoint listing the Uncloud releases.
+	DefaultReleasesURL = "https://api.github.com/repos/psviderski/uncloud/releases"
+	// ChecksumsFile is the release asset with the SHA-256 checksums of all other release assets.
+	ChecksumsFile = "checksums.txt"
+	// SignatureFile is the release asset with the Ed25519 signature of ChecksumsFile.
+	SignatureFile = ChecksumsFile + ".sig"
+	// maxAssetSize limits the size of a downloaded release asset.
+	maxAssetSize = 256 << 20
+)
+
+// publicKey is the base64-encoded Ed25519 public key the release checksums are signed with. It's set at build time
+// with -ldflags "-X github.com/psviderski/uncloud/internal/selfupdate.publicKey=...".
+var publicKey string
+
+// ErrNoPublicKey is returned by New if the binary is built without the release signing key, e.g. a development
+// build, so the downloaded binaries can't be verified.
+var ErrNoPublicKey = errors.New("the binary is built without the release signing key to verify the downloads")
+
+// Release is a published Uncloud release.
+type Release struct {
+	// Version is the release version without the "v" prefix, e.g. 0.9.0.
+	Version    string
+	Prerelease bool
+	// assets maps the asset names to their download URLs.
+	assets map[string]string
+}
+
+// ArchiveName returns the name of the release archive with the binary for the OS and architecture,
+// e.g. uncloud_macos_arm64.tar.gz.
+func ArchiveName(binary, goos, goarch string) string {
+	if goos == "darwin" {
+		goos = "macos"
+	}
+	return fmt.Sprintf("%s_%s_%s.tar.gz", binary, goos, goarch)
+}
+
+// Updater resolves the releases and downloads their verified binaries.
+type Updater struct {
+	// ReleasesURL is the GitHub API endpoint listing the releases. Default is DefaultReleasesURL.
+	ReleasesURL string
+	Client      *http.Client
+	// PublicKey verifies the signature of the release checksums.
+	PublicKey ed25519.PublicKey
+}
+
+// New creates an updater that verifies the downloads with the release signing key embedded at build time.
+func New() (*Updater, error) {
+	if publicKey == "" {
+		return nil, ErrNoPublicKey
+	}
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid release signing key embedded in the binary")
+	}
+	return &Updater{
+		ReleasesURL: DefaultReleasesURL,
+		Client:      http.DefaultClient,
+		PublicKey:   key,
+	}, nil
+}
+
+// githubRelease is a release in the GitHub API response.
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func (r githubRelease) release() Release {
+	rel := Release{
+		Version:    strings.TrimPrefix(r.TagName, "v"),
+		Prerelease: r.Prerelease,
+		assets:     make(map[string]string, len(r.Assets)),
+	}
+	for _, a := range r.Assets {
+		rel.assets[a.Name] = a.URL
+	}
+	return rel
+}
+
+// Latest returns the newest release on the channel.
+func (u *Updater) Latest(ctx context.Context, channel Channel) (Release, error) {
+	var releases []githubRelease
+	if err := u.getJSON(ctx, u.ReleasesURL+"?per_page=30", &releases); err != nil {
+		return Release{}, fmt.Errorf("list releases: %w", err)
+	}
+
+	var latest *githubRelease
+	var latestVersion *semver.Version
+	for i, r := range releases {
+		if r.Draft || (r.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+		v, err := semver.NewVersion(r.TagName)
+		if err != nil {
+			continue
+		}
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latest, latestVersion = &releases[i], v
+		}
+	}
+	if latest == nil {
+		return Release{}, fmt.Errorf("no releases found on the '%s' channel", channel)
+	}
+	return latest.release(), nil
+}
+
+// Release returns the release with the given version, e.g. 0.9.0.
+func (u *Updater) Release(ctx context.Context, version string) (Release, error) {
+	var r githubRelease
+	if err := u.getJSON(ctx, u.ReleasesURL+"/tags/v"+strings.TrimPrefix(version, "v"), &r); err != nil {
+		return Release{}, fmt.Errorf("get release '%s': %w", version, err)
+	}
+	return r.release(), nil
+}
+
+// Download downloads the release archive, verifies its checksum against the signed release checksums, and returns
+// the binary extracted from it.
+func (u *Updater) Download(ctx context.Context, rel Release, archive, binary string) ([]byte, error) {
+	checksums, err := u.asset(ctx, rel, ChecksumsFile)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := u.asset(ctx, rel, SignatureFile)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(u.PublicKey, signedPayload(rel.Version, checksums), sig) {
+		return nil, fmt.Errorf("invalid signature of the checksums of release %s", rel.Version)
+	}
+
+	want, err := findChecksum(checksums, archive)
+	if err != nil {
+		return nil, err
+	}
+	data, err := u.asset(ctx, rel, archive)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != want {
+		return nil, fmt.Errorf("checksum mismatch for '%s' of release %s", archive, rel.Version)
+	}
+
+	return extractBinary(data, binary)
+}
+
+func (u *Updater) asset(ctx context.Context, rel Release, name string) ([]byte, error) {
+	url, ok := rel.assets[name]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no asset '%s'", rel.Version, name)
+	}
+	resp, err := u.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("download '%s': %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetSize))
+	if err != nil {
+		return nil, fmt.Errorf("download '%s': %w", name, err)
+	}
+	return data, nil
+}
+
+func (u *Updater) getJSON(ctx context.Context, url string, v any) error {
+	resp, err := u.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (u *Updater) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// signedPayload returns the payload the release signature is made over: the release version followed by the release
+// checksums. Binding the version prevents replaying the signed checksums of an older release as a newer one.
+func signedPayload(version string, checksums []byte) []byte {
+	return append([]byte("uncloud "+version+"\n"), checksums...)
+}
+
+// findChecksum returns the hex-encoded SHA-256 checksum of the file from the checksums in the sha256sum format.
+func findChecksum(checksums []byte, file string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == file {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("checksum for '%s' not found in %s", file, ChecksumsFile)
+}
+
+// extractBinary returns the content of the binary file from the tar.gz archive.
+func extractBinary(archive []byte, binary string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("binary '%s' not found in archive", binary)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == binary {
+			return io.ReadAll(io.LimitReader(tr, maxAssetSize))
+		}
+	}
+}
+
+// ReplaceExecutable atomically replaces the executable at path with the binary by writing it to a temporary file
+// in the same directory and renaming it.
+func ReplaceExecutable(path string, binary []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(binary); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+	if err = os.Chmod(f.Name(), 0o755); err != nil {
+		return fmt.Errorf("make temporary file executable: %w", err)
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("replace executable '%s': %w", path, err)
+	}
+	return nil
+}
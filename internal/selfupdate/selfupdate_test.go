@@ -0,0 +1,171 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChannel(t *testing.T) {
+	t.Parallel()
+
+	c, err := ParseChannel("")
+	require.NoError(t, err)
+	assert.Equal(t, ChannelStable, c)
+
+	c, err = ParseChannel("beta")
+	require.NoError(t, err)
+	assert.Equal(t, ChannelBeta, c)
+
+	_, err = ParseChannel("nightly")
+	assert.ErrorContains(t, err, "invalid release channel 'nightly'")
+}
+
+func TestArchiveName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "uncloud_macos_arm64.tar.gz", ArchiveName("uncloud", "darwin", "arm64"))
+	assert.Equal(t, "uncloudd_linux_amd64.tar.gz", ArchiveName("uncloudd", "linux", "amd64"))
+}
+
+func tarGz(t *testing.T, name string, content []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg,
+	}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// releaseServer serves the GitHub API release list and the assets of release 0.9.0 signed with the private key.
+func releaseServer(t *testing.T, priv ed25519.PrivateKey, archive []byte) *httptest.Server {
+	sum := sha256.Sum256(archive)
+	checksums := []byte(fmt.Sprintf("%s  uncloud_linux_amd64.tar.gz\n", hex.EncodeToString(sum[:])))
+	assets := map[string][]byte{
+		"uncloud_linux_amd64.tar.gz": archive,
+		ChecksumsFile:                checksums,
+		SignatureFile:                ed25519.Sign(priv, signedPayload("0.9.0", checksums)),
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		release := func(tag string, prerelease, draft bool) map[string]any {
+			var list []map[string]string
+			for name := range assets {
+				list = append(list, map[string]string{
+					"name":                 name,
+					"browser_download_url": server.URL + "/download/" + name,
+				})
+			}
+			return map[string]any{"tag_name": tag, "prerelease": prerelease, "draft": draft, "assets": list}
+		}
+
+		switch r.URL.Path {
+		case "/releases":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				release("v0.10.0", false, true),
+				release("v0.10.0-beta.1", true, false),
+				release("v0.9.0", false, false),
+				release("v0.8.3", false, false),
+			})
+		case "/releases/tags/v0.9.0":
+			_ = json.NewEncoder(w).Encode(release("v0.9.0", false, false))
+		default:
+			name := filepath.Base(r.URL.Path)
+			if data, ok := assets[name]; ok {
+				_, _ = w.Write(data)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpdater(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	binary := []byte("#!/bin/sh\necho uncloud\n")
+	server := releaseServer(t, priv, tarGz(t, "uncloud", binary))
+	ctx := context.Background()
+
+	u := &Updater{ReleasesURL: server.URL + "/releases", Client: server.Client(), PublicKey: pub}
+
+	rel, err := u.Latest(ctx, ChannelStable)
+	require.NoError(t, err)
+	assert.Equal(t, "0.9.0", rel.Version)
+
+	beta, err := u.Latest(ctx, ChannelBeta)
+	require.NoError(t, err)
+	assert.Equal(t, "0.10.0-beta.1", beta.Version)
+	assert.True(t, beta.Prerelease)
+
+	rel, err = u.Release(ctx, "v0.9.0")
+	require.NoError(t, err)
+	assert.Equal(t, "0.9.0", rel.Version)
+
+	data, err := u.Download(ctx, rel, "uncloud_linux_amd64.tar.gz", "uncloud")
+	require.NoError(t, err)
+	assert.Equal(t, binary, data)
+
+	_, err = u.Download(ctx, rel, "uncloud_linux_arm64.tar.gz", "uncloud")
+	assert.ErrorContains(t, err, "checksum for 'uncloud_linux_arm64.tar.gz' not found")
+
+	// The signed checksums of a release served as another release are rejected.
+	replayed := rel
+	replayed.Version = "0.10.0"
+	_, err = u.Download(ctx, replayed, "uncloud_linux_amd64.tar.gz", "uncloud")
+	assert.ErrorContains(t, err, "invalid signature")
+
+	// A binary signed with another key is rejected.
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	u.PublicKey = otherPub
+	_, err = u.Download(ctx, rel, "uncloud_linux_amd64.tar.gz", "uncloud")
+	assert.ErrorContains(t, err, "invalid signature")
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "uncloud")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o755))
+	link := filepath.Join(dir, "uc")
+	require.NoError(t, os.Symlink(path, link))
+
+	require.NoError(t, ReplaceExecutable(link, []byte("new")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	target, err := os.Readlink(link)
+	require.NoError(t, err)
+	assert.Equal(t, path, target, "symlink must be preserved")
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package sshexec
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// dialAgent connects to the SSH agent listening on the Unix socket from the SSH_AUTH_SOCK environment variable.
+func dialAgent() (net.Conn, error) {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, fmt.Errorf("SSH_AUTH_SOCK is not set")
+	}
+	return net.Dial("unix", sock)
+}
@@ -0,0 +1,28 @@
+package sshexec
+
+import (
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// windowsAgentPipe is the named pipe of the OpenSSH for Windows ssh-agent service.
+const windowsAgentPipe = `\\.\pipe\openssh-ssh-agent`
+
+// dialAgent connects to the SSH agent. If SSH_AUTH_SOCK is set, it's used as a named pipe path or a Unix socket path
+// (e.g. for agents such as the one from Git for Windows that support AF_UNIX). Otherwise, it connects to the named pipe
+// of the OpenSSH for Windows agent.
+func dialAgent() (net.Conn, error) {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		sock = windowsAgentPipe
+	}
+	if strings.HasPrefix(sock, `\\.\pipe\`) {
+		timeout := 5 * time.Second
+		return winio.DialPipe(sock, &timeout)
+	}
+	return net.Dial("unix", sock)
+}
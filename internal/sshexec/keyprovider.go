@@ -0,0 +1,171 @@
+package sshexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/fs"
+)
+
+// keyProviderTimeout limits the time to retrieve a private key from a secret backend, e.g. if its CLI waits
+// for an interactive sign-in.
+const keyProviderTimeout = 30 * time.Second
+
+// KeyProvider retrieves SSH private keys from a secret backend so that they don't have to be stored unencrypted
+// on disk, e.g. on CI runners. A provider is selected by the scheme of the key URI used instead of a key file path.
+type KeyProvider interface {
+	// PrivateKey returns the PEM-encoded private key referenced by the URI.
+	PrivateKey(ctx context.Context, uri *url.URL) ([]byte, error)
+}
+
+var (
+	keyProvidersMu sync.RWMutex
+	// keyProviders are the registered key providers indexed by the URI scheme.
+	keyProviders = map[string]KeyProvider{
+		"op":    onePasswordProvider{run: runCommand},
+		"bw":    bitwardenProvider{run: runCommand},
+		"vault": vaultProvider{run: runCommand},
+	}
+)
+
+// RegisterKeyProvider registers the provider for the key URIs with the scheme, replacing the existing one.
+func RegisterKeyProvider(scheme string, provider KeyProvider) {
+	keyProvidersMu.Lock()
+	defer keyProvidersMu.Unlock()
+	keyProviders[scheme] = provider
+}
+
+// keyProviderFor returns the provider and the parsed URI if the key reference is a URI with a scheme
+// of a registered provider. Otherwise, the key reference is a file path.
+func keyProviderFor(key string) (KeyProvider, *url.URL) {
+	scheme, _, ok := strings.Cut(key, "://")
+	if !ok {
+		return nil, nil
+	}
+	keyProvidersMu.RLock()
+	provider, ok := keyProviders[scheme]
+	keyProvidersMu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	uri, err := url.Parse(key)
+	if err != nil {
+		return nil, nil
+	}
+	return provider, uri
+}
+
+// readPrivateKey reads the private key from the file or retrieves it from a secret backend if the key reference
+// is a URI with a registered provider scheme, e.g. op://vault/item/private-key.
+func readPrivateKey(key string) ([]byte, error) {
+	provider, uri := keyProviderFor(key)
+	if provider == nil {
+		path := fs.ExpandHomeDir(key)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read private key file %q: %w", path, err)
+		}
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), keyProviderTimeout)
+	defer cancel()
+	data, err := provider.PrivateKey(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve private key %q: %w", key, err)
+	}
+	return data, nil
+}
+
+// commandRunner runs a command and returns its stdout.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("'%s' CLI not found in PATH: %w", name, err)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// onePasswordProvider reads the key with the 1Password CLI 'op' using a secret reference URI, e.g.
+// op://vault/item/private-key. The CLI must be signed in, e.g. with OP_SERVICE_ACCOUNT_TOKEN on CI runners.
+type onePasswordProvider struct {
+	run commandRunner
+}
+
+func (p onePasswordProvider) PrivateKey(ctx context.Context, uri *url.URL) ([]byte, error) {
+	return p.run(ctx, "op", "read", "--no-newline", uri.String())
+}
+
+// bitwardenProvider reads the key with the Bitwarden CLI 'bw' from the SSH key item with the name or ID in the URI,
+// e.g. bw://prod-ssh-key. The private key of an SSH key item or the notes of a secure note item are used.
+// The vault must be unlocked with BW_SESSION set.
+type bitwardenProvider struct {
+	run commandRunner
+}
+
+func (p bitwardenProvider) PrivateKey(ctx context.Context, uri *url.URL) ([]byte, error) {
+	item := strings.Trim(uri.Host+uri.Path, "/")
+	if item == "" {
+		return nil, errors.New("item name or ID not specified, expected bw://ITEM")
+	}
+	out, err := p.run(ctx, "bw", "get", "item", item)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Notes  string `json:"notes"`
+		SSHKey *struct {
+			PrivateKey string `json:"privateKey"`
+		} `json:"sshKey"`
+	}
+	if err = json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("parse Bitwarden item: %w", err)
+	}
+	if resp.SSHKey != nil && resp.SSHKey.PrivateKey != "" {
+		return []byte(resp.SSHKey.PrivateKey), nil
+	}
+	if resp.Notes != "" {
+		return []byte(resp.Notes), nil
+	}
+	return nil, fmt.Errorf("item '%s' contains neither an SSH key nor notes", item)
+}
+
+// vaultProvider reads the key with the HashiCorp Vault CLI 'vault' from a KV secret, e.g. vault://kv/ssh reads
+// the field 'private_key' of the secret 'ssh' in the KV mount 'kv'. Another field can be specified with the 'field'
+// query parameter, e.g. vault://kv/ssh?field=key. The CLI uses VAULT_ADDR and VAULT_TOKEN from the environment.
+type vaultProvider struct {
+	run commandRunner
+}
+
+func (p vaultProvider) PrivateKey(ctx context.Context, uri *url.URL) ([]byte, error) {
+	path := strings.Trim(uri.Host+uri.Path, "/")
+	if !strings.Contains(path, "/") {
+		return nil, errors.New("secret path not specified, expected vault://MOUNT/PATH")
+	}
+	field := uri.Query().Get("field")
+	if field == "" {
+		field = "private_key"
+	}
+	return p.run(ctx, "vault", "kv", "get", "-field="+field, path)
+}
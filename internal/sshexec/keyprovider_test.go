@@ -0,0 +1,122 @@
+package sshexec
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordRunner returns a commandRunner that records the command and returns the output.
+func recordRunner(cmd *[]string, out string) commandRunner {
+	return func(_ context.Context, name string, args ...string) ([]byte, error) {
+		*cmd = append([]string{name}, args...)
+		return []byte(out), nil
+	}
+}
+
+func TestKeyProviders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		uri     string
+		out     string
+		wantCmd []string
+		wantKey string
+		wantErr string
+	}{
+		{
+			name:    "1password",
+			uri:     "op://prod/ssh/private key",
+			out:     "KEY",
+			wantCmd: []string{"op", "read", "--no-newline", "op://prod/ssh/private%20key"},
+			wantKey: "KEY",
+		},
+		{
+			name:    "bitwarden ssh key",
+			uri:     "bw://prod-ssh",
+			out:     `{"notes":"","sshKey":{"privateKey":"KEY"}}`,
+			wantCmd: []string{"bw", "get", "item", "prod-ssh"},
+			wantKey: "KEY",
+		},
+		{
+			name:    "bitwarden notes",
+			uri:     "bw://prod-ssh",
+			out:     `{"notes":"KEY"}`,
+			wantCmd: []string{"bw", "get", "item", "prod-ssh"},
+			wantKey: "KEY",
+		},
+		{
+			name:    "bitwarden empty item",
+			uri:     "bw://prod-ssh",
+			out:     `{"notes":""}`,
+			wantErr: "neither an SSH key nor notes",
+		},
+		{
+			name:    "vault default field",
+			uri:     "vault://kv/ssh",
+			out:     "KEY",
+			wantCmd: []string{"vault", "kv", "get", "-field=private_key", "kv/ssh"},
+			wantKey: "KEY",
+		},
+		{
+			name:    "vault custom field",
+			uri:     "vault://secret/ci/deploy?field=key",
+			out:     "KEY",
+			wantCmd: []string{"vault", "kv", "get", "-field=key", "secret/ci/deploy"},
+			wantKey: "KEY",
+		},
+		{
+			name:    "vault without path",
+			uri:     "vault://kv",
+			wantErr: "secret path not specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cmd []string
+			run := recordRunner(&cmd, tt.out)
+			providers := map[string]KeyProvider{
+				"op":    onePasswordProvider{run: run},
+				"bw":    bitwardenProvider{run: run},
+				"vault": vaultProvider{run: run},
+			}
+			uri, err := url.Parse(tt.uri)
+			require.NoError(t, err)
+
+			key, err := providers[uri.Scheme].PrivateKey(context.Background(), uri)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKey, string(key))
+			assert.Equal(t, tt.wantCmd, cmd)
+		})
+	}
+}
+
+func TestReadPrivateKey_File(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, os.WriteFile(path, []byte("KEY"), 0o600))
+
+	key, err := readPrivateKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, "KEY", string(key))
+
+	provider, _ := keyProviderFor("unknown://key")
+	assert.Nil(t, provider, "unknown scheme must be treated as a file path")
+	provider, uri := keyProviderFor("op://vault/item/field")
+	assert.NotNil(t, provider)
+	assert.Equal(t, "vault", uri.Host)
+}
@@ -1,18 +1,82 @@
 package sshexec
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net"
-	"os"
 	"strconv"
 	"time"
 
-	"github.com/psviderski/uncloud/internal/fs"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 )
 
-func Connect(user, host string, port int, sshKeyPath string) (*ssh.Client, error) {
+const (
+	// DefaultConnectTimeout is the default timeout for establishing a TCP connection and completing the SSH handshake.
+	DefaultConnectTimeout = 5 * time.Second
+	// DefaultKeepaliveInterval is the default interval between keepalive requests sent to the SSH server.
+	DefaultKeepaliveInterval = 15 * time.Second
+	// DefaultKeepaliveCountMax is the default number of keepalive requests that can be left unanswered before
+	// the connection is considered dead and closed.
+	DefaultKeepaliveCountMax = 3
+	// retryDelay is the initial delay between connection attempts. It's doubled after each failed attempt.
+	retryDelay = time.Second
+)
+
+// ConnectOptions configures how the SSH connection is established and kept alive. The zero value uses the defaults.
+type ConnectOptions struct {
+	// ConnectTimeout is the timeout for establishing a TCP connection and completing the SSH handshake
+	// (ConnectTimeout in ssh_config). Zero means DefaultConnectTimeout.
+	ConnectTimeout time.Duration
+	// KeepaliveInterval is the interval between keepalive requests sent to the server through the encrypted channel
+	// (ServerAliveInterval in ssh_config). They prevent NAT and firewalls from dropping idle connections and detect
+	// dead connections. Zero means DefaultKeepaliveInterval, a negative value disables keepalives.
+	KeepaliveInterval time.Duration
+	// KeepaliveCountMax is the number of keepalive requests that can be left unanswered before the connection is
+	// closed (ServerAliveCountMax in ssh_config). Zero means DefaultKeepaliveCountMax.
+	KeepaliveCountMax int
+	// ConnectRetries is the number of times to retry the connection if the host is unreachable or the connection
+	// is dropped during the handshake (ConnectionAttempts - 1 in ssh_config). Authentication failures aren't retried.
+	ConnectRetries int
+}
+
+// Validate returns an error if the options are invalid.
+func (o ConnectOptions) Validate() error {
+	if o.ConnectTimeout < 0 {
+		return fmt.Errorf("connect timeout must be non-negative: %s", o.ConnectTimeout)
+	}
+	if o.KeepaliveCountMax < 0 {
+		return fmt.Errorf("keepalive count max must be non-negative: %d", o.KeepaliveCountMax)
+	}
+	if o.ConnectRetries < 0 {
+		return fmt.Errorf("connect retries must be non-negative: %d", o.ConnectRetries)
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the options with the zero values replaced by the defaults.
+func (o ConnectOptions) withDefaults() ConnectOptions {
+	if o.ConnectTimeout == 0 {
+		o.ConnectTimeout = DefaultConnectTimeout
+	}
+	if o.KeepaliveInterval == 0 {
+		o.KeepaliveInterval = DefaultKeepaliveInterval
+	}
+	if o.KeepaliveCountMax == 0 {
+		o.KeepaliveCountMax = DefaultKeepaliveCountMax
+	}
+	return o
+}
+
+// Connect establishes an SSH connection to the host using the SSH agent or the private key at sshKeyPath if the agent
+// authentication fails. Keepalive requests are sent over the returned connection until it's closed.
+func Connect(user, host string, port int, sshKeyPath string, opts ConnectOptions) (*ssh.Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid SSH connect options: %w", err)
+	}
+	opts = opts.withDefaults()
+
 	addr := net.JoinHostPort(host, strconv.Itoa(port))
 	// Try to connect using SSH agent only.
 	agentAuth, agentClose, agentErr := sshAgentAuth()
@@ -22,12 +86,16 @@ func Connect(user, host string, port int, sshKeyPath string) (*ssh.Client, error
 			User:            user,
 			Auth:            []ssh.AuthMethod{agentAuth},
 			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-			Timeout:         5 * time.Second,
+			Timeout:         opts.ConnectTimeout,
 		}
 		var client *ssh.Client
-		if client, agentErr = ssh.Dial("tcp", addr, config); agentErr == nil {
+		if client, agentErr = dial(addr, config, opts); agentErr == nil {
 			return client, nil
 		}
+		// Don't retry the unreachable host once again with the private key.
+		if isRetryable(agentErr) {
+			return nil, fmt.Errorf("connect using SSH agent: %w", agentErr)
+		}
 	}
 	// Fall back to using private key as the connection attempt using SSH agent failed.
 	if sshKeyPath == "" {
@@ -43,9 +111,9 @@ func Connect(user, host string, port int, sshKeyPath string) (*ssh.Client, error
 		User:            user,
 		Auth:            []ssh.AuthMethod{keyAuth},
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-		Timeout:         5 * time.Second,
+		Timeout:         opts.ConnectTimeout,
 	}
-	client, err := ssh.Dial("tcp", addr, config)
+	client, err := dial(addr, config, opts)
 	if err != nil {
 		return nil, fmt.Errorf("connect using private key %q: %w", sshKeyPath, err)
 	}
@@ -53,8 +121,80 @@ func Connect(user, host string, port int, sshKeyPath string) (*ssh.Client, error
 	return client, nil
 }
 
+// dial connects to the SSH server retrying up to opts.ConnectRetries times with an exponential backoff if the error
+// is retryable. It starts sending keepalive requests over the established connection.
+func dial(addr string, config *ssh.ClientConfig, opts ConnectOptions) (*ssh.Client, error) {
+	delay := retryDelay
+	for attempt := 0; ; attempt++ {
+		client, err := ssh.Dial("tcp", addr, config)
+		if err == nil {
+			if opts.KeepaliveInterval > 0 {
+				go keepalive(client, opts.KeepaliveInterval, opts.KeepaliveCountMax)
+			}
+			return client, nil
+		}
+		if attempt >= opts.ConnectRetries || !isRetryable(err) {
+			return nil, err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isRetryable returns true if the connection error is caused by the network rather than by the authentication,
+// e.g. the host is unreachable, the connection is refused, or it's dropped or timed out during the handshake.
+func isRetryable(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// keepalive sends keepalive requests to the server every interval until the connection is closed. If countMax
+// consecutive requests are left unanswered, the connection is closed so that the pending and subsequent operations
+// fail instead of hanging on a dead connection.
+func keepalive(client *ssh.Client, interval time.Duration, countMax int) {
+	done := make(chan struct{})
+	go func() {
+		_ = client.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	// replied receives the result of the in-flight keepalive request. It's buffered so that the request goroutine
+	// doesn't leak if this loop returns before it gets a reply.
+	replied := make(chan bool, 1)
+	inflight, missed := false, 0
+	for {
+		select {
+		case <-done:
+			return
+		case ok := <-replied:
+			inflight = false
+			if ok {
+				missed = 0
+			}
+		case <-ticker.C:
+			if inflight {
+				// The previous request hasn't been answered within the interval.
+				missed++
+				if missed >= countMax {
+					_ = client.Close()
+					return
+				}
+				continue
+			}
+			inflight = true
+			go func() {
+				// Any reply, even a failure for the unknown request type, means the server is alive.
+				_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+				replied <- err == nil
+			}()
+		}
+	}
+}
+
 func sshAgentAuth() (ssh.AuthMethod, func(), error) {
-	conn, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK"))
+	conn, err := dialAgent()
 	if err != nil {
 		return nil, func() {}, fmt.Errorf("connect to SSH agent: %w", err)
 	}
@@ -63,11 +203,12 @@ func sshAgentAuth() (ssh.AuthMethod, func(), error) {
 	return auth, connClose, nil
 }
 
+// privateKeyAuth returns the auth method using the private key from the file at the path or from a secret backend
+// if the path is a key URI, see KeyProvider.
 func privateKeyAuth(path string) (ssh.AuthMethod, error) {
-	path = fs.ExpandHomeDir(path)
-	key, err := os.ReadFile(path)
+	key, err := readPrivateKey(path)
 	if err != nil {
-		return nil, fmt.Errorf("read private key file %q: %w", path, err)
+		return nil, err
 	}
 	signer, err := ssh.ParsePrivateKey(key)
 	if err != nil {
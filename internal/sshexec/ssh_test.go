@@ -0,0 +1,172 @@
+package sshexec
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer is an SSH server that accepts any public key if auth is true and optionally replies to the keepalive
+// requests.
+type testServer struct {
+	addr       *net.TCPAddr
+	auth       bool
+	replies    bool
+	keepalives atomic.Int32
+}
+
+func startTestServer(t *testing.T, auth, replies bool) *testServer {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+
+	s := &testServer{auth: auth, replies: replies}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+			if !s.auth {
+				return nil, errors.New("denied")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	s.addr = l.Addr().(*net.TCPAddr)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				defer sconn.Close()
+				go func() {
+					for ch := range chans {
+						_ = ch.Reject(ssh.Prohibited, "no channels")
+					}
+				}()
+				for req := range reqs {
+					if req.Type == "keepalive@openssh.com" {
+						s.keepalives.Add(1)
+						if s.replies {
+							_ = req.Reply(false, nil)
+						}
+					}
+				}
+			}()
+		}
+	}()
+	return s
+}
+
+// writeTestKey writes a new private key in the OpenSSH format to a file and returns the file path.
+func writeTestKey(t *testing.T) string {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(key, "")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+	return path
+}
+
+func TestConnect(t *testing.T) {
+	// Disable the SSH agent to authenticate with the private key only.
+	t.Setenv("SSH_AUTH_SOCK", "")
+	keyPath := writeTestKey(t)
+
+	t.Run("keepalives", func(t *testing.T) {
+		s := startTestServer(t, true, true)
+		client, err := Connect("root", "127.0.0.1", s.addr.Port, keyPath, ConnectOptions{
+			KeepaliveInterval: 10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.Eventually(t, func() bool { return s.keepalives.Load() >= 3 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("keepalives disabled", func(t *testing.T) {
+		s := startTestServer(t, true, true)
+		client, err := Connect("root", "127.0.0.1", s.addr.Port, keyPath, ConnectOptions{
+			KeepaliveInterval: -1,
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Zero(t, s.keepalives.Load())
+	})
+
+	t.Run("unanswered keepalives close connection", func(t *testing.T) {
+		s := startTestServer(t, true, false)
+		client, err := Connect("root", "127.0.0.1", s.addr.Port, keyPath, ConnectOptions{
+			KeepaliveInterval: 10 * time.Millisecond,
+			KeepaliveCountMax: 2,
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		closed := make(chan struct{})
+		go func() {
+			_ = client.Wait()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("connection wasn't closed after unanswered keepalives")
+		}
+	})
+
+	t.Run("authentication failure isn't retried", func(t *testing.T) {
+		s := startTestServer(t, false, true)
+		start := time.Now()
+		_, err := Connect("root", "127.0.0.1", s.addr.Port, keyPath, ConnectOptions{ConnectRetries: 3})
+		require.Error(t, err)
+		assert.False(t, isRetryable(err))
+		assert.Less(t, time.Since(start), retryDelay)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := Connect("root", "127.0.0.1", 22, keyPath, ConnectOptions{ConnectRetries: -1})
+		assert.ErrorContains(t, err, "connect retries must be non-negative")
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	_, err = ssh.Dial("tcp", addr, &ssh.ClientConfig{HostKeyCallback: ssh.InsecureIgnoreHostKey()})
+	require.Error(t, err)
+	assert.True(t, isRetryable(err), "connection refused should be retryable")
+}
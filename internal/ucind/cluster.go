@@ -33,6 +33,8 @@ type CreateClusterOptions struct {
 	Machines int
 	// Ports to forward from the cluster machines to the host.
 	PortMap nat.PortMap
+	// DaemonArgs are additional command line arguments for the machine daemons.
+	DaemonArgs []string
 }
 
 func (c *Cluster) PopulateMachineIDs(ctx context.Context) error {
@@ -84,8 +86,9 @@ func (p *Provisioner) CreateCluster(ctx context.Context, name string, opts Creat
 	// Create machines (containers) in the created cluster network.
 	for i := 1; i < opts.Machines+1; i++ {
 		mopts := CreateMachineOptions{
-			Name:    fmt.Sprintf("machine-%d", i),
-			PortMap: opts.PortMap,
+			Name:       fmt.Sprintf("machine-%d", i),
+			PortMap:    opts.PortMap,
+			DaemonArgs: opts.DaemonArgs,
 		}
 		m, err := p.CreateMachine(ctx, name, mopts)
 		if err != nil {
@@ -170,8 +173,9 @@ func (p *Provisioner) initCluster(ctx context.Context, machines []Machine) error
 		addReq := &pb.AddMachineRequest{
 			Name: m.Name,
 			Network: &pb.NetworkConfig{
-				Endpoints: endpoints,
-				PublicKey: token.PublicKey,
+				Endpoints:    endpoints,
+				DnsEndpoints: token.DNSEndpoints,
+				PublicKey:    token.PublicKey,
 			},
 		}
 		addResp, err := initClient.AddMachine(ctx, addReq)
@@ -44,6 +44,8 @@ type CreateMachineOptions struct {
 	Image string
 	// Ports to forward from the machine to the host.
 	PortMap nat.PortMap
+	// DaemonArgs are additional command line arguments for the machine daemon, e.g. to enable the chaos mode.
+	DaemonArgs []string
 }
 
 func (p *Provisioner) CreateMachine(ctx context.Context, clusterName string, opts CreateMachineOptions) (Machine, error) {
@@ -75,6 +77,9 @@ func (p *Provisioner) CreateMachine(ctx context.Context, clusterName string, opt
 			apiPort: struct{}{},
 		},
 	}
+	if len(opts.DaemonArgs) > 0 {
+		config.Cmd = append([]string{"uncloudd"}, opts.DaemonArgs...)
+	}
 	hostConfig := &container.HostConfig{
 		NetworkMode: container.NetworkMode(clusterName),
 		PortBindings: nat.PortMap{
@@ -126,6 +131,36 @@ func (p *Provisioner) CreateMachine(ctx context.Context, clusterName string, opt
 	return m, nil
 }
 
+// StopMachine stops the machine container to simulate a machine failure. The machine keeps its state and can be
+// started again with StartMachine.
+func (p *Provisioner) StopMachine(ctx context.Context, m Machine) error {
+	if err := p.dockerCli.ContainerStop(ctx, m.ContainerName, container.StopOptions{}); err != nil {
+		return fmt.Errorf("stop Docker container '%s': %w", m.ContainerName, err)
+	}
+	return nil
+}
+
+// StartMachine starts the stopped machine container. It returns the machine with the updated API address as Docker
+// may publish the API port on a different host port after the restart.
+func (p *Provisioner) StartMachine(ctx context.Context, m Machine) (Machine, error) {
+	if err := p.dockerCli.ContainerStart(ctx, m.ContainerName, container.StartOptions{}); err != nil {
+		return m, fmt.Errorf("start Docker container '%s': %w", m.ContainerName, err)
+	}
+
+	apiPort := nat.Port(fmt.Sprintf("%d/tcp", UncloudAPIPort))
+	apiPortBindings, err := p.waitPortPublished(ctx, m.ContainerName, apiPort)
+	if err != nil {
+		return m, fmt.Errorf("wait for machine API port '%s' to be published: %w", apiPort, err)
+	}
+	apiAddr, err := netip.ParseAddrPort(net.JoinHostPort(apiPortBindings[0].HostIP, apiPortBindings[0].HostPort))
+	if err != nil {
+		return m, fmt.Errorf("parse machine API port binding: %w", err)
+	}
+	m.APIAddress = apiAddr
+
+	return m, nil
+}
+
 // createContainerWithImagePull creates a Docker container. If the image is missing, it pulls the image first.
 func (p *Provisioner) createContainerWithImagePull(
 	ctx context.Context, name string, config *container.Config, hostConfig *container.HostConfig,
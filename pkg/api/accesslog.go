@@ -0,0 +1,50 @@
+package api
+
+import "time"
+
+// AccessLogsOptions defines the options for streaming the ingress access logs of a service.
+type AccessLogsOptions struct {
+	// Follow keeps streaming new access logs until the context is canceled.
+	Follow bool
+	// Since shows access logs since a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes).
+	Since string
+	// Until shows access logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes).
+	Until string
+	// Status filters the access logs by response status codes (e.g. 404) or classes (e.g. 5xx). All statuses are
+	// shown if empty.
+	Status []string
+	// PathPrefix filters the access logs by the request path prefix (e.g. /api/).
+	PathPrefix string
+}
+
+// AccessLogEntry is a request handled by the Caddy reverse proxy.
+type AccessLogEntry struct {
+	Time time.Time
+	// MachineName is the name of the machine running the Caddy container that handled the request.
+	MachineName string
+	RemoteIP    string
+	Proto       string
+	Method      string
+	Host        string
+	URI         string
+	Status      int
+	// Size is the number of bytes written to the response body.
+	Size     int
+	Duration time.Duration
+	// Err is set if streaming the access logs from a Caddy container failed.
+	Err error
+}
+
+// RequestMetrics summarises the requests to a hostname handled by the Caddy reverse proxy over a period of time.
+type RequestMetrics struct {
+	Host     string
+	Requests int
+	// Rate is the average number of requests per second over the period.
+	Rate float64
+	// P50, P95, and P99 are the percentiles of the request durations.
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	// StatusClasses is the number of responses by status class, e.g. StatusClasses[5] is the number of 5xx responses.
+	StatusClasses [6]int
+}
@@ -0,0 +1,26 @@
+package api
+
+import "time"
+
+// ExecSession describes a recorded interactive exec session in a service container.
+type ExecSession struct {
+	ID string
+	// MachineID is the ID of the machine that ran the session and stores its recording.
+	MachineID   string
+	ContainerID string
+	// ServiceName is the name of the service of the container. Empty if the container isn't a service container.
+	ServiceName string
+	Command     []string
+	// User is the user the command ran as in the container. Empty if the container's default user was used.
+	User      string
+	StartedAt time.Time
+	// EndedAt is the time the session ended. Zero if the session is still running or the machine daemon stopped
+	// before it ended.
+	EndedAt  time.Time
+	ExitCode int
+}
+
+// Ended returns true if the session ended and its exit code is known.
+func (s ExecSession) Ended() bool {
+	return !s.EndedAt.IsZero()
+}
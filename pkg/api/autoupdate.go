@@ -0,0 +1,24 @@
+package api
+
+import "time"
+
+// AutoUpdate is the configuration and status of the automatic OS updates of the cluster machines.
+type AutoUpdate struct {
+	Enabled bool
+	// Window is the recurring maintenance window in UTC during which machines are updated, e.g. "Sun 03:00-05:00".
+	Window string
+	// Machines are the statuses of the last automatic OS update of the machines that have been updated at least once.
+	Machines []MachineUpdate
+}
+
+// MachineUpdate is the status of the last automatic OS update of a machine.
+type MachineUpdate struct {
+	MachineID string
+	// WindowStart is the start of the maintenance window the update was run in.
+	WindowStart time.Time
+	// State is one of: draining, updating, rebooting, done, or failed.
+	State string
+	// Error is the error message if the update failed.
+	Error     string
+	UpdatedAt time.Time
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// BackupEnginePostgres backs up all databases of a PostgreSQL server with pg_dumpall.
+	BackupEnginePostgres = "postgres"
+	// BackupEngineMySQL backs up all databases of a MySQL or MariaDB server with mysqldump.
+	BackupEngineMySQL = "mysql"
+	// BackupEngineRedis backs up a Redis server as an RDB snapshot.
+	BackupEngineRedis = "redis"
+
+	// DefaultBackupInterval is the default interval between scheduled backups of a service.
+	DefaultBackupInterval = 24 * time.Hour
+	// DefaultBackupRetention is the default number of the most recent backups of a service to keep.
+	DefaultBackupRetention = 7
+	// MinBackupInterval is the minimum interval between scheduled backups of a service.
+	MinBackupInterval = 5 * time.Minute
+)
+
+// BackupSpec enables scheduled logical backups of the database running in the service containers. Backups are
+// uploaded to the cluster backup storage.
+type BackupSpec struct {
+	// Engine is the type of the database to back up: BackupEnginePostgres, BackupEngineMySQL, or BackupEngineRedis.
+	Engine string
+	// Interval is the time between backups. Default is DefaultBackupInterval if zero.
+	Interval time.Duration `json:",omitempty"`
+	// Retention is the number of the most recent backups to keep. Older backups are deleted after each backup.
+	// Default is DefaultBackupRetention if zero.
+	Retention uint `json:",omitempty"`
+}
+
+// SetDefaults returns a copy of the backup spec with default values set.
+func (s *BackupSpec) SetDefaults() BackupSpec {
+	spec := *s
+	if spec.Interval == 0 {
+		spec.Interval = DefaultBackupInterval
+	}
+	if spec.Retention == 0 {
+		spec.Retention = DefaultBackupRetention
+	}
+	return spec
+}
+
+func (s *BackupSpec) Validate() error {
+	switch s.Engine {
+	case BackupEnginePostgres, BackupEngineMySQL, BackupEngineRedis:
+	case "":
+		return errors.New("backup engine must be set")
+	default:
+		return fmt.Errorf("unsupported backup engine: %q, supported engines: %s, %s, %s",
+			s.Engine, BackupEnginePostgres, BackupEngineMySQL, BackupEngineRedis)
+	}
+	if s.Interval != 0 && s.Interval < MinBackupInterval {
+		return fmt.Errorf("backup interval must be at least %s: %s", MinBackupInterval, s.Interval)
+	}
+	return nil
+}
+
+// BackupStorage is an S3-compatible object storage the service backups are uploaded to.
+type BackupStorage struct {
+	// Endpoint is the URL of the S3 API, e.g. https://s3.eu-central-1.amazonaws.com.
+	Endpoint string
+	// Region is the region of the bucket used to sign requests. Default is us-east-1 if empty.
+	Region string
+	Bucket string
+	// Prefix is the optional key prefix for the backups within the bucket.
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func (s *BackupStorage) Validate() error {
+	if s.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	if s.Bucket == "" {
+		return errors.New("bucket must be set")
+	}
+	if s.AccessKeyID == "" || s.SecretAccessKey == "" {
+		return errors.New("access key ID and secret access key must be set")
+	}
+	return nil
+}
+
+// Backup is a backup of a service stored in the backup storage.
+type Backup struct {
+	// Key is the object key of the backup in the backup storage bucket.
+	Key       string
+	Service   string
+	Engine    string
+	Size      int64
+	CreatedAt time.Time
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupSpec_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    BackupSpec
+		wantErr string
+	}{
+		{
+			name: "postgres with defaults",
+			spec: BackupSpec{Engine: BackupEnginePostgres},
+		},
+		{
+			name: "redis with interval and retention",
+			spec: BackupSpec{Engine: BackupEngineRedis, Interval: time.Hour, Retention: 24},
+		},
+		{
+			name:    "engine not set",
+			spec:    BackupSpec{},
+			wantErr: "backup engine must be set",
+		},
+		{
+			name:    "unsupported engine",
+			spec:    BackupSpec{Engine: "mongodb"},
+			wantErr: "unsupported backup engine",
+		},
+		{
+			name:    "interval too short",
+			spec:    BackupSpec{Engine: BackupEngineMySQL, Interval: time.Minute},
+			wantErr: "backup interval must be at least",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBackupSpec_SetDefaults(t *testing.T) {
+	t.Parallel()
+
+	spec := BackupSpec{Engine: BackupEnginePostgres}
+	assert.Equal(t, BackupSpec{
+		Engine:    BackupEnginePostgres,
+		Interval:  DefaultBackupInterval,
+		Retention: DefaultBackupRetention,
+	}, spec.SetDefaults())
+
+	spec = BackupSpec{Engine: BackupEnginePostgres, Interval: time.Hour, Retention: 3}
+	assert.Equal(t, spec, spec.SetDefaults())
+}
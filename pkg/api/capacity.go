@@ -0,0 +1,90 @@
+package api
+
+import (
+	"slices"
+)
+
+// MachineUsage is the current CPU, memory, and disk usage of a machine.
+type MachineUsage struct {
+	// CPU is the number of CPU cores in use.
+	CPU float64
+	// Memory is the memory in use in bytes excluding the reclaimable page cache.
+	Memory int64
+	// Disk is the used space in bytes on the filesystem with the Docker data root.
+	Disk int64
+}
+
+// MachineCapacity is the total, reserved, and used resources of a machine.
+type MachineCapacity struct {
+	Machine Machine
+	// Usage is nil if the machine is unavailable or runs an older daemon version that doesn't report it.
+	Usage *MachineUsage
+	// ReservedCPU is the sum of the CPU limits of the running containers on the machine in nanocores.
+	ReservedCPU int64
+	// ReservedMemory is the sum of the memory reservations of the running containers on the machine in bytes.
+	ReservedMemory int64
+}
+
+// Overcommitted returns true if the reserved CPU or memory exceeds the total CPU or memory of the machine.
+func (m MachineCapacity) Overcommitted() bool {
+	r := m.Machine.Resources
+	if r == nil {
+		return false
+	}
+	return m.ReservedCPU > int64(r.CPUs)*Core || m.ReservedMemory > r.Memory
+}
+
+// ServiceReservation is the resources reserved by the running containers of a service.
+type ServiceReservation struct {
+	Service string
+	// Replicas is the number of running containers of the service.
+	Replicas int
+	// CPU is the CPU limit of each container in nanocores.
+	CPU int64
+	// Memory is the memory reservation of each container in bytes.
+	Memory int64
+}
+
+// CapacityReport summarises the resources of the cluster machines and the reservations of the services.
+type CapacityReport struct {
+	// Machines are sorted by name.
+	Machines []MachineCapacity
+	// Services are sorted by name.
+	Services []ServiceReservation
+}
+
+// Headroom returns the number of additional containers with the given CPU limit (in nanocores) and memory
+// reservation (in bytes) that fit on each machine within its unreserved resources. Only the available uncordoned
+// machines with known resources are considered. If machines is not empty, only the machines with these names
+// or IDs are considered. The result is nil if the container doesn't reserve any resources as the number of
+// containers is not limited by the reservations.
+func (r CapacityReport) Headroom(cpu, memory int64, machines []string) map[string]int {
+	if cpu <= 0 && memory <= 0 {
+		return nil
+	}
+
+	headroom := make(map[string]int)
+	for _, m := range r.Machines {
+		res := m.Machine.Resources
+		if res == nil || !m.Machine.Available() || m.Machine.Cordoned {
+			continue
+		}
+		if len(machines) > 0 && !slices.Contains(machines, m.Machine.ID) &&
+			!slices.Contains(machines, m.Machine.Name) {
+			continue
+		}
+
+		fit := -1
+		if cpu > 0 {
+			fit = int(max(int64(res.CPUs)*Core-m.ReservedCPU, 0) / cpu)
+		}
+		if memory > 0 {
+			memFit := int(max(res.Memory-m.ReservedMemory, 0) / memory)
+			if fit == -1 || memFit < fit {
+				fit = memFit
+			}
+		}
+		headroom[m.Machine.Name] = fit
+	}
+	return headroom
+}
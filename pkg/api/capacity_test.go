@@ -0,0 +1,80 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachineCapacity_Overcommitted(t *testing.T) {
+	t.Parallel()
+
+	resources := &MachineResources{CPUs: 2, Memory: 4 << 30}
+
+	assert.False(t, MachineCapacity{
+		Machine:        Machine{Resources: resources},
+		ReservedCPU:    2 * Core,
+		ReservedMemory: 4 << 30,
+	}.Overcommitted())
+	assert.True(t, MachineCapacity{
+		Machine:     Machine{Resources: resources},
+		ReservedCPU: 3 * Core,
+	}.Overcommitted())
+	assert.True(t, MachineCapacity{
+		Machine:        Machine{Resources: resources},
+		ReservedMemory: 5 << 30,
+	}.Overcommitted())
+	assert.False(t, MachineCapacity{
+		ReservedMemory: 5 << 30,
+	}.Overcommitted(), "unknown resources")
+}
+
+func TestCapacityReport_Headroom(t *testing.T) {
+	t.Parallel()
+
+	report := CapacityReport{
+		Machines: []MachineCapacity{
+			{
+				Machine: Machine{ID: "id1", Name: "m1", State: "UP",
+					Resources: &MachineResources{CPUs: 4, Memory: 8 << 30}},
+				ReservedCPU:    1 * Core,
+				ReservedMemory: 2 << 30,
+			},
+			{
+				Machine: Machine{ID: "id2", Name: "m2", State: "UP",
+					Resources: &MachineResources{CPUs: 2, Memory: 2 << 30}},
+				ReservedMemory: 3 << 30,
+			},
+			{
+				Machine: Machine{ID: "id3", Name: "m3", State: "DOWN",
+					Resources: &MachineResources{CPUs: 4, Memory: 8 << 30}},
+			},
+			{
+				Machine: Machine{ID: "id4", Name: "m4", State: "UP", Cordoned: true,
+					Resources: &MachineResources{CPUs: 4, Memory: 8 << 30}},
+			},
+			{
+				Machine: Machine{ID: "id5", Name: "m5", State: "UP"},
+			},
+		},
+	}
+
+	t.Run("memory and CPU", func(t *testing.T) {
+		headroom := report.Headroom(Core, 1<<30, nil)
+		assert.Equal(t, map[string]int{"m1": 3, "m2": 0}, headroom)
+	})
+
+	t.Run("memory only", func(t *testing.T) {
+		headroom := report.Headroom(0, 1<<30, nil)
+		assert.Equal(t, map[string]int{"m1": 6, "m2": 0}, headroom)
+	})
+
+	t.Run("placement", func(t *testing.T) {
+		headroom := report.Headroom(0, 1<<30, []string{"id1"})
+		assert.Equal(t, map[string]int{"m1": 6}, headroom)
+	})
+
+	t.Run("no reservations", func(t *testing.T) {
+		assert.Nil(t, report.Headroom(0, 0, nil))
+	})
+}
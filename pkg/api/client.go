@@ -28,10 +28,16 @@ type ContainerClient interface {
 	RemoveContainer(ctx context.Context, serviceNameOrID, containerNameOrID string, opts container.RemoveOptions) error
 	StartContainer(ctx context.Context, serviceNameOrID, containerNameOrID string) error
 	StopContainer(ctx context.Context, serviceNameOrID, containerNameOrID string, opts container.StopOptions) error
+	// UpdateContainerSpec updates the spec of the container without recreating it. Only the metadata can be changed,
+	// see ServiceSpec.WithMetadata.
+	UpdateContainerSpec(ctx context.Context, serviceNameOrID, containerNameOrID string, spec ServiceSpec) error
 }
 
 type DNSClient interface {
 	GetDomain(ctx context.Context) (string, error)
+	// ServiceDomain returns the domain for the service hostnames: the reserved cluster domain or the default domain
+	// from the cluster settings. It returns an empty string if neither is set.
+	ServiceDomain(ctx context.Context) (string, error)
 }
 
 type ImageClient interface {
@@ -49,6 +55,7 @@ type MachineClient interface {
 type ServiceClient interface {
 	RunService(ctx context.Context, spec ServiceSpec) (RunServiceResponse, error)
 	InspectService(ctx context.Context, id string) (Service, error)
+	ListServices(ctx context.Context) ([]Service, error)
 	RemoveService(ctx context.Context, id string) error
 }
 
@@ -59,7 +66,8 @@ type VolumeClient interface {
 }
 
 // ProxyMachinesContext returns a new context that proxies gRPC requests to the specified machines.
-// If namesOrIDs is nil, all machines are included.
+// If namesOrIDs is nil, all machines are included. The other outgoing metadata, such as the deploy freeze override,
+// is preserved.
 func ProxyMachinesContext(
 	ctx context.Context, cli MachineClient, namesOrIDs []string,
 ) (context.Context, MachineMembersList, error) {
@@ -69,7 +77,9 @@ func ProxyMachinesContext(
 	}
 
 	var proxiedMachines MachineMembersList
-	md := metadata.New(nil)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Delete("machines")
 	for _, m := range machines {
 		if len(namesOrIDs) == 0 ||
 			slices.Contains(namesOrIDs, m.Machine.Name) || slices.Contains(namesOrIDs, m.Machine.Id) {
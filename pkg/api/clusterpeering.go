@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"time"
+)
+
+// peeringNameRegexp matches valid cluster peering names, e.g. context names like "prod-eu".
+var peeringNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,62}$`)
+
+// ClusterPeering is a peering with another cluster stored in the cluster. The machines of the cluster are configured
+// as WireGuard peers of the peer cluster machines so the containers in both clusters can reach each other over
+// encrypted tunnels. Only the allowed IPs are routed between the clusters, the management network is never routed.
+type ClusterPeering struct {
+	// Name identifies the peer cluster, e.g. its context name.
+	Name string
+	// Network is the IP network of the peer cluster. It must not overlap the network of this cluster.
+	Network netip.Prefix
+	// Machines are the machines of the peer cluster at the time of peering.
+	Machines []PeerMachine
+	// AllowedIPs scope the addresses routed between the clusters. The allowed IPs within the network of each cluster
+	// limit the addresses of that cluster reachable from the other one. If none of them are within the network
+	// of a cluster, its whole network is reachable.
+	AllowedIPs []netip.Prefix `json:",omitempty"`
+	CreatedAt  time.Time
+}
+
+// PeerMachine is a machine of a peer cluster.
+type PeerMachine struct {
+	Name string
+	// Subnet is the IP range allocated to the machine in the peer cluster network.
+	Subnet    netip.Prefix
+	PublicKey []byte
+	// Endpoints are the WireGuard endpoints of the machine.
+	Endpoints []netip.AddrPort
+}
+
+func (p *ClusterPeering) Validate() error {
+	if !peeringNameRegexp.MatchString(p.Name) {
+		return fmt.Errorf("invalid peer cluster name '%s': must be 1-63 characters long and contain only "+
+			"letters, digits, '_', '.', and '-'", p.Name)
+	}
+	if !p.Network.IsValid() || !p.Network.Addr().Is4() {
+		return errors.New("peer cluster network must be a valid IPv4 CIDR")
+	}
+	if len(p.Machines) == 0 {
+		return errors.New("peer cluster must have at least one machine")
+	}
+	for _, m := range p.Machines {
+		if !p.Network.Contains(m.Subnet.Addr()) || m.Subnet.Bits() < p.Network.Bits() {
+			return fmt.Errorf("subnet %s of peer machine '%s' is outside the peer cluster network %s",
+				m.Subnet, m.Name, p.Network)
+		}
+		if len(m.PublicKey) != 32 {
+			return fmt.Errorf("invalid public key of peer machine '%s'", m.Name)
+		}
+	}
+	for _, prefix := range p.AllowedIPs {
+		if !prefix.IsValid() || prefix != prefix.Masked() {
+			return fmt.Errorf("invalid allowed IP range '%s'", prefix)
+		}
+	}
+	return nil
+}
+
+// MachineAllowedIPs returns the IP ranges of the peer machine routed to this cluster: the machine subnet limited
+// by the allowed IPs within the peer cluster network. An empty result means the machine isn't reachable.
+func (p *ClusterPeering) MachineAllowedIPs(m PeerMachine) []netip.Prefix {
+	return ScopePrefix(m.Subnet, p.Network, p.AllowedIPs)
+}
+
+// ScopePrefix returns the parts of prefix allowed by the allowed IPs within network. The whole prefix is allowed
+// if none of the allowed IPs are within network.
+func ScopePrefix(prefix, network netip.Prefix, allowedIPs []netip.Prefix) []netip.Prefix {
+	var scoped []netip.Prefix
+	scopedNetwork := false
+	for _, allowed := range allowedIPs {
+		if !network.Overlaps(allowed) {
+			continue
+		}
+		scopedNetwork = true
+		switch {
+		case allowed.Bits() <= prefix.Bits() && allowed.Contains(prefix.Addr()):
+			return []netip.Prefix{prefix}
+		case prefix.Bits() < allowed.Bits() && prefix.Contains(allowed.Addr()):
+			scoped = append(scoped, allowed)
+		}
+	}
+	if !scopedNetwork {
+		return []netip.Prefix{prefix}
+	}
+	return scoped
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopePrefix(t *testing.T) {
+	t.Parallel()
+
+	network := netip.MustParsePrefix("10.211.0.0/16")
+	subnet := netip.MustParsePrefix("10.211.1.0/24")
+	p := netip.MustParsePrefix
+
+	tests := []struct {
+		name    string
+		allowed []netip.Prefix
+		want    []netip.Prefix
+	}{
+		{
+			name: "no allowed IPs",
+			want: []netip.Prefix{subnet},
+		},
+		{
+			name:    "allowed IPs only in other network",
+			allowed: []netip.Prefix{p("10.210.0.0/24")},
+			want:    []netip.Prefix{subnet},
+		},
+		{
+			name:    "allowed range contains subnet",
+			allowed: []netip.Prefix{p("10.211.0.0/20")},
+			want:    []netip.Prefix{subnet},
+		},
+		{
+			name:    "allowed ranges within subnet",
+			allowed: []netip.Prefix{p("10.211.1.16/28"), p("10.211.1.100/32"), p("10.211.2.0/24")},
+			want:    []netip.Prefix{p("10.211.1.16/28"), p("10.211.1.100/32")},
+		},
+		{
+			name:    "subnet outside allowed ranges",
+			allowed: []netip.Prefix{p("10.211.2.0/24")},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ScopePrefix(subnet, network, tt.allowed))
+		})
+	}
+}
+
+func TestClusterPeering_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() ClusterPeering {
+		return ClusterPeering{
+			Name:    "prod-eu",
+			Network: netip.MustParsePrefix("10.211.0.0/16"),
+			Machines: []PeerMachine{{
+				Name:      "machine-1",
+				Subnet:    netip.MustParsePrefix("10.211.1.0/24"),
+				PublicKey: make([]byte, 32),
+			}},
+		}
+	}
+
+	p := valid()
+	assert.NoError(t, p.Validate())
+
+	p = valid()
+	p.Name = "prod eu"
+	assert.ErrorContains(t, p.Validate(), "invalid peer cluster name")
+
+	p = valid()
+	p.Machines[0].Subnet = netip.MustParsePrefix("10.210.1.0/24")
+	assert.ErrorContains(t, p.Validate(), "outside the peer cluster network")
+
+	p = valid()
+	p.Machines = nil
+	assert.ErrorContains(t, p.Validate(), "at least one machine")
+
+	p = valid()
+	p.AllowedIPs = []netip.Prefix{netip.MustParsePrefix("10.211.1.1/24")}
+	assert.ErrorContains(t, p.Validate(), "invalid allowed IP range")
+}
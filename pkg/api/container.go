@@ -20,14 +20,79 @@ const (
 	LabelServiceName  = "uncloud.service.name"
 	LabelServiceMode  = "uncloud.service.mode"
 	LabelServicePorts = "uncloud.service.ports"
+	// LabelProject is the name of the project, e.g. a Compose project, the service belongs to.
+	LabelProject = "uncloud.project"
+	// LabelProtected set to "true" on a volume protects it from removal.
+	LabelProtected = "uncloud.protected"
 )
 
+// Environment variables with the metadata of the service container injected into every service container.
+// Variables with the same names in the service spec take precedence.
+const (
+	// EnvPrefix is the prefix of the injected environment variables reserved for Uncloud.
+	EnvPrefix    = "UNCLOUD_"
+	EnvMachine   = "UNCLOUD_MACHINE"
+	EnvMachineID = "UNCLOUD_MACHINE_ID"
+	// EnvMachineIP is the IP address of the machine in the cluster overlay network.
+	EnvMachineIP = "UNCLOUD_MACHINE_IP"
+	EnvService   = "UNCLOUD_SERVICE"
+	EnvServiceID = "UNCLOUD_SERVICE_ID"
+	// EnvReplica is the name of the container which uniquely identifies the replica of the service.
+	EnvReplica = "UNCLOUD_REPLICA"
+)
+
+// CrashLoopResetPeriod is the time a restarted container has to keep running to no longer be considered
+// crash-looping. It matches the period after which Docker resets the restart backoff delay.
+const CrashLoopResetPeriod = 10 * time.Second
+
 type Container struct {
 	types.ContainerJSON
+	// LastExit describes the last time the container exited. It's tracked by the machine daemon from Docker events
+	// as Docker resets the exit state of the container when it's restarted. Nil if the container hasn't exited since
+	// the daemon started.
+	LastExit *ContainerExit `json:",omitempty"`
+	// Drift lists the differences between the actual configuration of the container and the service spec it was
+	// created from, e.g. after the container was changed manually with 'docker update'. It's detected by the machine
+	// daemon when the container is inspected or synced to the cluster store.
+	Drift []ContainerDrift `json:",omitempty"`
 	// created caches the parsed creation time by CreatedTime.
 	created time.Time
 }
 
+// ContainerExit describes how a container exited.
+type ContainerExit struct {
+	ExitCode   int
+	OOMKilled  bool   `json:",omitempty"`
+	Error      string `json:",omitempty"`
+	FinishedAt time.Time
+}
+
+// ContainerDrift describes a configuration field of a container that differs from its service spec.
+type ContainerDrift struct {
+	// Field is the drifted configuration field, e.g. "image", "env", "mounts", "ports", or "resources".
+	Field string
+	// Expected is the value according to the service spec. Environment variables are only compared by a hash
+	// of their values to avoid leaking secrets.
+	Expected string
+	Actual   string
+}
+
+func (d ContainerDrift) String() string {
+	return fmt.Sprintf("%s: expected %s, actual %s", d.Field, d.Expected, d.Actual)
+}
+
+// Reason returns a human-readable reason why the container exited.
+func (e *ContainerExit) Reason() string {
+	switch {
+	case e.OOMKilled:
+		return fmt.Sprintf("OOM killed (%d)", e.ExitCode)
+	case e.Error != "":
+		return fmt.Sprintf("error (%d): %s", e.ExitCode, e.Error)
+	default:
+		return fmt.Sprintf("exit code %d", e.ExitCode)
+	}
+}
+
 // CreatedTime returns the time when the container was created parsed from the Created field.
 func (c *Container) CreatedTime() time.Time {
 	if c.created.IsZero() && c.Created != "" {
@@ -55,6 +120,43 @@ func (c *Container) Healthy() bool {
 	return c.State.Health.Status == types.Healthy
 }
 
+// CrashLooping determines if the container keeps exiting and being restarted by Docker. It's the case when Docker is
+// waiting for the restart backoff delay or the container has been running less than CrashLoopResetPeriod since
+// it was restarted.
+func (c *Container) CrashLooping() bool {
+	if c.State == nil {
+		return false
+	}
+	if c.State.Restarting {
+		return true
+	}
+	if !c.State.Running || c.RestartCount == 0 {
+		return false
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, c.State.StartedAt)
+	if err != nil {
+		return false
+	}
+	return time.Since(startedAt) < CrashLoopResetPeriod
+}
+
+// Exit returns how the container last exited or nil if it hasn't exited. It uses the exit state of the container if
+// it's not running and falls back to LastExit tracked by the machine daemon otherwise.
+func (c *Container) Exit() *ContainerExit {
+	if c.State != nil && (!c.State.Running || c.State.Restarting) {
+		finishedAt, err := time.Parse(time.RFC3339Nano, c.State.FinishedAt)
+		if err == nil && !finishedAt.IsZero() {
+			return &ContainerExit{
+				ExitCode:   c.State.ExitCode,
+				OOMKilled:  c.State.OOMKilled,
+				Error:      c.State.Error,
+				FinishedAt: finishedAt,
+			}
+		}
+	}
+	return c.LastExit
+}
+
 // HumanState returns a human-readable description of the container's state. Based on the Docker implementation:
 // https://github.com/moby/moby/blob/b343d235a0a1f30c8f05b1d651238e72158dc25d/container/state.go#L79-L113
 func (c *Container) HumanState() (string, error) {
@@ -159,6 +261,16 @@ func (c *ServiceContainer) ServiceMode() string {
 	return c.Config.Labels[LabelServiceMode]
 }
 
+// Project returns the name of the project the service of this container belongs to or an empty string
+// if the service doesn't belong to a project. The project is read from the service spec as the containers created
+// before the service was adopted by a project don't have the project label.
+func (c *ServiceContainer) Project() string {
+	if c.ServiceSpec.Project != "" {
+		return c.ServiceSpec.Project
+	}
+	return c.Config.Labels[LabelProject]
+}
+
 // ServicePorts returns the ports this container publishes as part of its service.
 func (c *ServiceContainer) ServicePorts() ([]PortSpec, error) {
 	encoded, ok := c.Config.Labels[LabelServicePorts]
@@ -195,12 +307,12 @@ func (c *ServiceContainer) ConflictingServicePorts(ports []PortSpec) ([]PortSpec
 			continue
 		}
 
-		// Two host ports conflict if they have the same published port number and protocol, and either:
+		// Two host ports conflict if they have overlapping published ports and the same protocol, and either:
 		//   * At least one host IP is not set (meaning it uses all interfaces)
 		//   * Both host IPs are identical
 		for _, svcPort := range svcPorts {
 			if svcPort.Mode != PortModeHost ||
-				svcPort.PublishedPort != p.PublishedPort ||
+				!svcPort.publishedPortsOverlap(p) ||
 				svcPort.Protocol != p.Protocol {
 				continue
 			}
@@ -3,6 +3,7 @@ package api
 import (
 	"net/netip"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -243,6 +244,58 @@ func TestContainer_ConflictingServicePorts(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:           "host mode port in published port range conflicts",
+			containerPorts: "10000-10100:10000-10100/udp@host",
+			checkPorts: []PortSpec{
+				{Mode: PortModeHost, PublishedPort: 10050, ContainerPort: 53, Protocol: ProtocolUDP},
+			},
+			want: []PortSpec{
+				{Mode: PortModeHost, PublishedPort: 10050, ContainerPort: 53, Protocol: ProtocolUDP},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "overlapping host mode port ranges conflict",
+			containerPorts: "10000-10100:10000-10100/udp@host",
+			checkPorts: []PortSpec{
+				{
+					Mode:             PortModeHost,
+					PublishedPort:    10100,
+					PublishedPortEnd: 10200,
+					ContainerPort:    10100,
+					ContainerPortEnd: 10200,
+					Protocol:         ProtocolUDP,
+				},
+			},
+			want: []PortSpec{
+				{
+					Mode:             PortModeHost,
+					PublishedPort:    10100,
+					PublishedPortEnd: 10200,
+					ContainerPort:    10100,
+					ContainerPortEnd: 10200,
+					Protocol:         ProtocolUDP,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "adjacent host mode port ranges don't conflict",
+			containerPorts: "10000-10100:10000-10100/udp@host",
+			checkPorts: []PortSpec{
+				{
+					Mode:             PortModeHost,
+					PublishedPort:    10101,
+					PublishedPortEnd: 10200,
+					ContainerPort:    10101,
+					ContainerPortEnd: 10200,
+					Protocol:         ProtocolUDP,
+				},
+			},
+			want:    nil,
+			wantErr: false,
+		},
 		{
 			name:           "host mode port with no host IP doesn't conflict with different protocol",
 			containerPorts: "8080:80/tcp@host",
@@ -299,3 +352,107 @@ func TestContainer_ConflictingServicePorts(t *testing.T) {
 		})
 	}
 }
+
+func TestContainer_CrashLooping(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	tests := []struct {
+		name         string
+		state        types.ContainerState
+		restartCount int
+		want         bool
+	}{
+		{
+			name:  "running",
+			state: types.ContainerState{Running: true, StartedAt: now.Format(time.RFC3339Nano)},
+		},
+		{
+			name:  "restarting",
+			state: types.ContainerState{Running: true, Restarting: true},
+			want:  true,
+		},
+		{
+			name:         "recently restarted",
+			state:        types.ContainerState{Running: true, StartedAt: now.Add(-time.Second).Format(time.RFC3339Nano)},
+			restartCount: 3,
+			want:         true,
+		},
+		{
+			name:         "running after restart longer than reset period",
+			state:        types.ContainerState{Running: true, StartedAt: now.Add(-time.Minute).Format(time.RFC3339Nano)},
+			restartCount: 3,
+		},
+		{
+			name:         "exited after max retries",
+			state:        types.ContainerState{ExitCode: 1},
+			restartCount: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &Container{ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					RestartCount: tt.restartCount,
+					State:        &tt.state,
+				},
+			}}
+			assert.Equal(t, tt.want, c.CrashLooping())
+		})
+	}
+}
+
+func TestContainer_Exit(t *testing.T) {
+	t.Parallel()
+
+	finishedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastExit := &ContainerExit{ExitCode: 2, FinishedAt: finishedAt.Add(-time.Minute)}
+
+	t.Run("never exited", func(t *testing.T) {
+		t.Parallel()
+		c := &Container{ContainerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{Running: true, FinishedAt: "0001-01-01T00:00:00Z"},
+			},
+		}}
+		assert.Nil(t, c.Exit())
+	})
+
+	t.Run("restarting uses container state", func(t *testing.T) {
+		t.Parallel()
+		c := &Container{
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					State: &types.ContainerState{
+						Running:    true,
+						Restarting: true,
+						ExitCode:   137,
+						OOMKilled:  true,
+						FinishedAt: finishedAt.Format(time.RFC3339Nano),
+					},
+				},
+			},
+			LastExit: lastExit,
+		}
+		exit := c.Exit()
+		require.NotNil(t, exit)
+		assert.Equal(t, ContainerExit{ExitCode: 137, OOMKilled: true, FinishedAt: finishedAt}, *exit)
+		assert.Equal(t, "OOM killed (137)", exit.Reason())
+	})
+
+	t.Run("running falls back to last exit", func(t *testing.T) {
+		t.Parallel()
+		c := &Container{
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					State: &types.ContainerState{Running: true, FinishedAt: finishedAt.Format(time.RFC3339Nano)},
+				},
+			},
+			LastExit: lastExit,
+		}
+		assert.Equal(t, lastExit, c.Exit())
+		assert.Equal(t, "exit code 2", c.Exit().Reason())
+	})
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// HoursPerMonth is the average number of hours in a month used to estimate the monthly cost of the machines.
+const HoursPerMonth = 730
+
+const (
+	CostByService = "service"
+	CostByOwner   = "owner"
+	CostByMachine = "machine"
+)
+
+// ContainerCost is the estimated monthly cost of a machine attributed to a running service container.
+type ContainerCost struct {
+	Service string
+	// Owner is the owner of the service. Empty if the service doesn't specify one.
+	Owner   string
+	Machine string
+	Monthly float64
+}
+
+// CostReport is the estimated monthly cost of the cluster machines attributed to the running service containers.
+type CostReport struct {
+	Containers []ContainerCost
+	// Unallocated is the monthly cost of each machine not attributed to any container by machine name.
+	Unallocated map[string]float64
+	// Providers are the providers of the priced machines by machine name.
+	Providers map[string]string
+	// Unpriced are the names of the machines without an hourly price. They are excluded from the report.
+	Unpriced []string
+}
+
+// CostItem is the estimated monthly cost of a service, owner, or machine.
+type CostItem struct {
+	Name string
+	// Containers is the number of running containers the cost is attributed to.
+	Containers int
+	Monthly    float64
+}
+
+// EstimateCosts attributes the monthly cost of the priced machines to the running service containers on them.
+// Each container is attributed the share of the machine cost equal to the average of its shares of the CPU and
+// memory of the machine it reserves. The cost of the unreserved resources is split equally between the containers
+// without reservations or is left unallocated if all containers reserve resources. The cost of a machine with unknown
+// resources is split equally between all its containers.
+func EstimateCosts(machines []Machine, services []Service) CostReport {
+	report := CostReport{
+		Unallocated: make(map[string]float64),
+		Providers:   make(map[string]string),
+	}
+
+	type machineContainer struct {
+		service   *Service
+		resources ContainerResources
+	}
+	containers := make(map[string][]machineContainer)
+	for i := range services {
+		for _, mc := range services[i].Containers {
+			if mc.Container.State == nil || !mc.Container.State.Running {
+				continue
+			}
+			containers[mc.MachineID] = append(containers[mc.MachineID], machineContainer{
+				service:   &services[i],
+				resources: mc.Container.ServiceSpec.Container.Resources,
+			})
+		}
+	}
+
+	for _, m := range machines {
+		if m.Cost == nil || m.Cost.HourlyPrice <= 0 {
+			report.Unpriced = append(report.Unpriced, m.Name)
+			continue
+		}
+		report.Providers[m.Name] = m.Cost.Provider
+		monthly := m.Cost.HourlyPrice * HoursPerMonth
+
+		ctrs := containers[m.ID]
+		shares := make([]float64, len(ctrs))
+		reserved, unreserved := 0.0, 0
+		for i, c := range ctrs {
+			if m.Resources == nil {
+				continue
+			}
+			var cpuShare, memShare float64
+			if m.Resources.CPUs > 0 {
+				cpuShare = float64(c.resources.CPU) / float64(int64(m.Resources.CPUs)*Core)
+			}
+			if m.Resources.Memory > 0 {
+				memShare = float64(c.resources.MemoryReservation) / float64(m.Resources.Memory)
+			}
+			shares[i] = (cpuShare + memShare) / 2
+			reserved += shares[i]
+			if shares[i] == 0 {
+				unreserved++
+			}
+		}
+		if m.Resources == nil {
+			unreserved = len(ctrs)
+		}
+		// Scale down the shares of an overcommitted machine so that they don't exceed the machine cost.
+		if reserved > 1 {
+			for i := range shares {
+				shares[i] /= reserved
+			}
+			reserved = 1
+		}
+		if unreserved > 0 {
+			for i := range shares {
+				if shares[i] == 0 {
+					shares[i] = (1 - reserved) / float64(unreserved)
+				}
+			}
+			reserved = 1
+		}
+
+		for i, c := range ctrs {
+			report.Containers = append(report.Containers, ContainerCost{
+				Service: c.service.Name,
+				Owner:   c.service.Owner(),
+				Machine: m.Name,
+				Monthly: monthly * shares[i],
+			})
+		}
+		if reserved < 1 {
+			report.Unallocated[m.Name] = monthly * (1 - reserved)
+		}
+	}
+
+	slices.Sort(report.Unpriced)
+	return report
+}
+
+// Total returns the total estimated monthly cost of the priced machines.
+func (r CostReport) Total() float64 {
+	total := r.UnallocatedTotal()
+	for _, c := range r.Containers {
+		total += c.Monthly
+	}
+	return total
+}
+
+// UnallocatedTotal returns the monthly cost of the machines not attributed to any container.
+func (r CostReport) UnallocatedTotal() float64 {
+	var total float64
+	for _, cost := range r.Unallocated {
+		total += cost
+	}
+	return total
+}
+
+// By groups the container costs by service, owner, or machine. The cost of a machine includes its unallocated
+// cost when grouping by machine. The items are sorted by cost in descending order, then by name.
+func (r CostReport) By(grouping string) ([]CostItem, error) {
+	var key func(ContainerCost) string
+	switch grouping {
+	case CostByService:
+		key = func(c ContainerCost) string { return c.Service }
+	case CostByOwner:
+		key = func(c ContainerCost) string { return c.Owner }
+	case CostByMachine:
+		key = func(c ContainerCost) string { return c.Machine }
+	default:
+		return nil, fmt.Errorf("invalid cost grouping '%s': must be one of '%s', '%s', '%s'",
+			grouping, CostByService, CostByOwner, CostByMachine)
+	}
+
+	items := make(map[string]*CostItem)
+	item := func(name string) *CostItem {
+		if _, ok := items[name]; !ok {
+			items[name] = &CostItem{Name: name}
+		}
+		return items[name]
+	}
+	for _, c := range r.Containers {
+		i := item(key(c))
+		i.Containers++
+		i.Monthly += c.Monthly
+	}
+	if grouping == CostByMachine {
+		for name, cost := range r.Unallocated {
+			item(name).Monthly += cost
+		}
+	}
+
+	result := make([]CostItem, 0, len(items))
+	for _, i := range items {
+		result = append(result, *i)
+	}
+	slices.SortFunc(result, func(a, b CostItem) int {
+		return cmp.Or(cmp.Compare(b.Monthly, a.Monthly), cmp.Compare(a.Name, b.Name))
+	})
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCosts(t *testing.T) {
+	t.Parallel()
+
+	machines := []Machine{
+		{ID: "id1", Name: "m1", Cost: &MachineCost{HourlyPrice: 0.1, Provider: "hetzner"},
+			Resources: &MachineResources{CPUs: 4, Memory: 8 << 30}},
+		{ID: "id2", Name: "m2", Cost: &MachineCost{HourlyPrice: 0.2}},
+		{ID: "id3", Name: "m3"},
+		{ID: "id4", Name: "m4", Cost: &MachineCost{HourlyPrice: 0.05},
+			Resources: &MachineResources{CPUs: 2, Memory: 4 << 30}},
+	}
+	container := func(machineID string, running bool, owner string, res ContainerResources) MachineServiceContainer {
+		ctr := ServiceContainer{
+			Container: Container{ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{Running: running}},
+			}},
+			ServiceSpec: ServiceSpec{Owner: owner, Container: ContainerSpec{Resources: res}},
+		}
+		return MachineServiceContainer{MachineID: machineID, Container: ctr}
+	}
+	services := []Service{
+		{Name: "web", Containers: []MachineServiceContainer{
+			container("id1", true, "acme", ContainerResources{CPU: Core, MemoryReservation: 2 << 30}),
+		}},
+		{Name: "worker", Containers: []MachineServiceContainer{
+			container("id1", true, "", ContainerResources{}),
+		}},
+		{Name: "db", Containers: []MachineServiceContainer{
+			container("id2", true, "acme", ContainerResources{}),
+			container("id2", false, "acme", ContainerResources{}),
+		}},
+		{Name: "api", Containers: []MachineServiceContainer{
+			container("id4", true, "", ContainerResources{CPU: Core}),
+		}},
+		{Name: "cron", Containers: []MachineServiceContainer{
+			container("id3", true, "", ContainerResources{}),
+		}},
+	}
+
+	report := EstimateCosts(machines, services)
+	assert.Equal(t, []string{"m3"}, report.Unpriced)
+	assert.Equal(t, "hetzner", report.Providers["m1"])
+	assert.InDelta(t, 255.5, report.Total(), 1e-9)
+	assert.InDelta(t, 27.375, report.UnallocatedTotal(), 1e-9)
+
+	assertItems := func(want []CostItem, grouping string) {
+		items, err := report.By(grouping)
+		require.NoError(t, err)
+		require.Len(t, items, len(want))
+		for i := range want {
+			assert.Equal(t, want[i].Name, items[i].Name)
+			assert.Equal(t, want[i].Containers, items[i].Containers, want[i].Name)
+			assert.InDelta(t, want[i].Monthly, items[i].Monthly, 1e-9, want[i].Name)
+		}
+	}
+	assertItems([]CostItem{
+		{Name: "db", Containers: 1, Monthly: 146},
+		{Name: "worker", Containers: 1, Monthly: 54.75},
+		{Name: "web", Containers: 1, Monthly: 18.25},
+		{Name: "api", Containers: 1, Monthly: 9.125},
+	}, CostByService)
+	assertItems([]CostItem{
+		{Name: "acme", Containers: 2, Monthly: 164.25},
+		{Name: "", Containers: 2, Monthly: 63.875},
+	}, CostByOwner)
+	assertItems([]CostItem{
+		{Name: "m2", Containers: 1, Monthly: 146},
+		{Name: "m1", Containers: 2, Monthly: 73},
+		{Name: "m4", Containers: 1, Monthly: 36.5},
+	}, CostByMachine)
+
+	_, err := report.By("project")
+	assert.ErrorContains(t, err, "invalid cost grouping")
+}
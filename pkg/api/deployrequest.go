@@ -0,0 +1,45 @@
+package api
+
+import "time"
+
+// DeployRequestStatus is the review status of a deploy request.
+type DeployRequestStatus string
+
+const (
+	DeployRequestPending  DeployRequestStatus = "pending"
+	DeployRequestApproved DeployRequestStatus = "approved"
+	DeployRequestRejected DeployRequestStatus = "rejected"
+)
+
+// DeployRequest is a deployment of Compose project services that waits in the cluster for another operator
+// to approve it before it's executed. It enables four-eyes review of deployments without external tooling.
+// The requester and reviewer are identified by the local user and host that ran the CLI, so the review is a process
+// safeguard rather than a security boundary.
+type DeployRequest struct {
+	ID      string
+	Project string
+	// Specs are the service specs generated from the Compose file of the requester. The deployment is planned
+	// against the cluster state at the time of approval.
+	Specs []ServiceSpec
+	// ExternalVolumes are the names of the external volumes of the project that must exist in the cluster.
+	ExternalVolumes []string `json:",omitempty"`
+	// Recreate recreates the containers even if their configuration and image haven't changed.
+	Recreate bool `json:",omitempty"`
+	// SnapshotVolumes snapshots the volumes of the updated services before deploying them.
+	SnapshotVolumes bool `json:",omitempty"`
+	RequestedBy     string
+	CreatedAt       time.Time
+	Status          DeployRequestStatus
+	ReviewedBy      string `json:",omitempty"`
+	// ReviewedAt is the time the request was approved or rejected. Zero if it's pending.
+	ReviewedAt time.Time
+}
+
+// ServiceNames returns the names of the services deployed by the request.
+func (r *DeployRequest) ServiceNames() []string {
+	names := make([]string, len(r.Specs))
+	for i, s := range r.Specs {
+		names[i] = s.Name
+	}
+	return names
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// EgressPolicy routes the outbound internet traffic of service containers through a gateway machine over
+// the cluster network, e.g. the machine with a static public IP allowlisted by a third party. The traffic appears
+// to come from the public IP of the gateway machine. It's dropped while the gateway machine is unavailable.
+type EgressPolicy struct {
+	// Gateway is the ID of the machine that routes the outbound internet traffic.
+	Gateway string
+	// Services are the names of the services whose containers route their outbound traffic through the gateway.
+	// All services in the cluster if empty.
+	Services []string `json:",omitempty"`
+}
+
+// Applies returns true if the outbound traffic of the service containers is routed through the gateway.
+func (p *EgressPolicy) Applies(serviceName string) bool {
+	if p == nil {
+		return false
+	}
+	return len(p.Services) == 0 || slices.Contains(p.Services, serviceName)
+}
+
+func (p *EgressPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Gateway == "" {
+		return errors.New("gateway machine must not be empty")
+	}
+	for _, name := range p.Services {
+		if name == "" {
+			return errors.New("service name must not be empty")
+		}
+	}
+	if len(slices.Compact(slices.Sorted(slices.Values(p.Services)))) != len(p.Services) {
+		return fmt.Errorf("duplicate service names: %v", p.Services)
+	}
+	return nil
+}
+
+// EgressPolicyFromProto converts the egress policy message to EgressPolicy. It returns nil if the message is nil.
+func EgressPolicyFromProto(p *pb.EgressPolicy) *EgressPolicy {
+	if p == nil {
+		return nil
+	}
+	return &EgressPolicy{
+		Gateway:  p.Gateway,
+		Services: p.Services,
+	}
+}
+
+// Proto returns the egress policy message or nil if the policy is nil.
+func (p *EgressPolicy) Proto() *pb.EgressPolicy {
+	if p == nil {
+		return nil
+	}
+	return &pb.EgressPolicy{
+		Gateway:  p.Gateway,
+		Services: p.Services,
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEgressPolicy_Applies(t *testing.T) {
+	t.Parallel()
+
+	var policy *EgressPolicy
+	assert.False(t, policy.Applies("web"))
+
+	policy = &EgressPolicy{Gateway: "machine1"}
+	assert.True(t, policy.Applies("web"), "policy without services must apply to all services")
+
+	policy.Services = []string{"api", "worker"}
+	assert.True(t, policy.Applies("worker"))
+	assert.False(t, policy.Applies("web"))
+}
+
+func TestEgressPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	var policy *EgressPolicy
+	assert.NoError(t, policy.Validate())
+
+	assert.NoError(t, (&EgressPolicy{Gateway: "machine1", Services: []string{"api", "web"}}).Validate())
+	assert.ErrorContains(t, (&EgressPolicy{Services: []string{"web"}}).Validate(), "gateway machine must not be empty")
+	assert.ErrorContains(t, (&EgressPolicy{Gateway: "machine1", Services: []string{"web", "web"}}).Validate(),
+		"duplicate service names")
+}
@@ -0,0 +1,67 @@
+package api
+
+import "io"
+
+const (
+	// LogStreamStdout is the stream name for the standard output of a container.
+	LogStreamStdout = "stdout"
+	// LogStreamStderr is the stream name for the standard error of a container.
+	LogStreamStderr = "stderr"
+)
+
+// ServiceLogsOptions defines the options for streaming logs of service containers.
+type ServiceLogsOptions struct {
+	// Follow keeps streaming new logs until the context is canceled.
+	Follow bool
+	// Tail is the number of lines to show from the end of the logs of each container. All lines are shown if empty.
+	Tail string
+	// Since shows logs since a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes).
+	Since string
+	// Until shows logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes).
+	Until string
+	// Timestamps prefixes each log line with its timestamp.
+	Timestamps bool
+}
+
+// ContainerLogEntry is a chunk of logs written by a container to one of its output streams.
+type ContainerLogEntry struct {
+	// Stream is the output stream the data was written to: LogStreamStdout or LogStreamStderr.
+	Stream string
+	Data   []byte
+	// Err is set if streaming the logs failed. It's always the last entry.
+	Err error
+}
+
+// ServiceLogEntry is a chunk of logs written by a service container.
+type ServiceLogEntry struct {
+	ContainerLogEntry
+	MachineID     string
+	MachineName   string
+	ContainerID   string
+	ContainerName string
+}
+
+// ExecOptions defines the options for running a command in a container.
+type ExecOptions struct {
+	Cmd        []string
+	Env        []string
+	User       string
+	WorkingDir string
+	Privileged bool
+	// Tty allocates a pseudo-TTY for the command. The stderr of the command is written to Stdout if enabled.
+	Tty bool
+	// Stdin is the standard input of the command. The command doesn't receive any input if nil.
+	Stdin io.Reader
+	// Stdout is the writer for the standard output of the command. The output is discarded if nil.
+	Stdout io.Writer
+	// Stderr is the writer for the standard error of the command. The output is discarded if nil.
+	Stderr io.Writer
+	// Resize receives terminal size changes to apply to the pseudo-TTY. Only used if Tty is enabled.
+	Resize <-chan TerminalSize
+}
+
+// TerminalSize is the size of a terminal in characters.
+type TerminalSize struct {
+	Height uint
+	Width  uint
+}
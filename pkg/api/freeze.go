@@ -0,0 +1,93 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FreezeOverrideMetadataKey is the request metadata key set by the client on the requests of an operation that
+// overrode an active deploy freeze. Machines reject creating service containers during a freeze without it.
+const FreezeOverrideMetadataKey = "uncloud-freeze-override"
+
+// DeployFreeze blocks the mutating deploy operations in the cluster, such as deploying, running, scaling, rolling
+// back, or removing services, until the specified time. An operation can override the freeze with a reason that is
+// recorded as a FreezeOverride in the cluster. The CLI checks the freeze before every mutating operation and
+// the machines reject creating service containers during the freeze without an override.
+type DeployFreeze struct {
+	Until     time.Time
+	Reason    string `json:",omitempty"`
+	CreatedAt time.Time
+}
+
+// FreezeOverride is an audit record of a deploy operation that overrode an active deploy freeze.
+type FreezeOverride struct {
+	// Operation describes the overridden operation, e.g. "deploy web, db" or "scale web".
+	Operation string
+	// Reason is the justification given for overriding the freeze.
+	Reason string
+	// User is the local user on the client that ran the operation, e.g. "alice@laptop".
+	User string `json:",omitempty"`
+	// FreezeUntil and FreezeReason describe the overridden freeze.
+	FreezeUntil  time.Time
+	FreezeReason string `json:",omitempty"`
+	Time         time.Time
+}
+
+// Active returns true if the freeze blocks deploy operations at the given time.
+func (f *DeployFreeze) Active(t time.Time) bool {
+	return t.Before(f.Until)
+}
+
+// ParseFreezeUntil parses the end time of a deploy freeze relative to now. The supported formats are a duration,
+// e.g. "12h", an RFC 3339 timestamp, a date with an optional time, e.g. "2026-01-05" or "2026-01-05 09:00",
+// and a time with an optional weekday, e.g. "09:00" or "Mon 09:00", meaning its next occurrence. Dates and times
+// without a time zone are in the location of now.
+func ParseFreezeUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("freeze duration must be positive: %s", s)
+		}
+		return now.Add(d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return time.Time{}, freezeUntilFormatError(s)
+	}
+	clock, err := time.Parse("15:04", fields[len(fields)-1])
+	if err != nil {
+		return time.Time{}, freezeUntilFormatError(s)
+	}
+	weekday := -1
+	if len(fields) == 2 {
+		if weekday = slices.Index(scheduleWeekdays, strings.ToLower(fields[0])); weekday == -1 {
+			return time.Time{}, fmt.Errorf("invalid freeze end time '%s': unknown day '%s', expected one of: "+
+				"Mon, Tue, Wed, Thu, Fri, Sat, Sun", s, fields[0])
+		}
+	}
+
+	for days := 0; days <= 7; days++ {
+		t := time.Date(now.Year(), now.Month(), now.Day()+days, clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if t.After(now) && (weekday == -1 || t.Weekday() == time.Weekday(weekday)) {
+			return t, nil
+		}
+	}
+	// Unreachable as the weekday repeats within a week.
+	return time.Time{}, freezeUntilFormatError(s)
+}
+
+func freezeUntilFormatError(s string) error {
+	return fmt.Errorf("invalid freeze end time '%s': expected a duration (12h), a weekday and time (Mon 09:00), "+
+		"a time (09:00), a date (2026-01-05 09:00), or an RFC 3339 timestamp", s)
+}
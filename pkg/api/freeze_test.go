@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFreezeUntil(t *testing.T) {
+	t.Parallel()
+
+	// Friday evening.
+	now := time.Date(2025, 6, 6, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		until string
+		want  time.Time
+	}{
+		{"duration", "12h", time.Date(2025, 6, 7, 6, 30, 0, 0, time.UTC)},
+		{"weekday and time", "Mon 09:00", time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)},
+		{"same weekday later today", "fri 20:00", time.Date(2025, 6, 6, 20, 0, 0, 0, time.UTC)},
+		{"same weekday earlier today", "Fri 09:00", time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)},
+		{"time later today", "22:00", time.Date(2025, 6, 6, 22, 0, 0, 0, time.UTC)},
+		{"time tomorrow", "09:00", time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)},
+		{"date", "2025-06-10", time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{"date and time", "2025-06-10 09:30", time.Date(2025, 6, 10, 9, 30, 0, 0, time.UTC)},
+		{"RFC 3339", "2025-06-10T09:30:00+02:00", time.Date(2025, 6, 10, 7, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFreezeUntil(tt.until, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+
+	for _, until := range []string{"", "-1h", "Someday 09:00", "Mon", "25:00", "Mon 09:00 UTC"} {
+		_, err := ParseFreezeUntil(until, now)
+		assert.Error(t, err, until)
+	}
+}
+
+func TestDeployFreeze_Active(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	f := DeployFreeze{Until: now.Add(time.Hour)}
+	assert.True(t, f.Active(now))
+	assert.False(t, f.Active(now.Add(time.Hour)))
+}
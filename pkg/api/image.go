@@ -25,4 +25,7 @@ type RemoteImage struct {
 	Reference     reference.Canonical
 	IndexManifest *v1.Index
 	ImageManifest *v1.Manifest
+	// Platform is the platform of a single-platform image from its config. It may be nil if the config couldn't
+	// be fetched.
+	Platform *v1.Platform
 }
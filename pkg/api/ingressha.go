@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// DefaultVRRPRouterID is the VRRP virtual router ID used by the ingress high availability if not set.
+const DefaultVRRPRouterID = 51
+
+// interfaceNameRegexp matches a Linux network interface name. It's written unescaped to the keepalived configuration
+// so it must not contain whitespace or other characters with a special meaning to keepalived.
+var interfaceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+
+// IngressHAPolicy configures a virtual IP for the inbound traffic that is held by one ingress machine at a time
+// and automatically moves to another healthy machine when the current one or its Caddy fails. The machines elect
+// the holder using VRRP managed by keepalived. The virtual IP must be routable to all the machines, e.g. they share
+// a network segment, or NotifyMaster must move it, e.g. a floating IP assigned with the cloud provider API.
+type IngressHAPolicy struct {
+	// VirtualIP is the IPv4 address that moves between the machines.
+	VirtualIP netip.Addr
+	// Interface is the name of the network interface the virtual IP is assigned to on the machines, e.g. eth0.
+	Interface string
+	// Machines are the IDs of the machines that can hold the virtual IP in the order of preference.
+	Machines []string
+	// RouterID is the VRRP virtual router ID that must be unique among the VRRP routers in the network segment.
+	// DefaultVRRPRouterID is used if zero.
+	RouterID uint32 `json:",omitempty"`
+	// NotifyMaster is a shell command run when a machine becomes the holder of the virtual IP, e.g. to assign
+	// a cloud floating IP to the machine. It runs in the keepalived container on the machine.
+	NotifyMaster string `json:",omitempty"`
+}
+
+// VRRPRouterID returns the VRRP virtual router ID or DefaultVRRPRouterID if it's not set.
+func (p *IngressHAPolicy) VRRPRouterID() uint32 {
+	if p.RouterID == 0 {
+		return DefaultVRRPRouterID
+	}
+	return p.RouterID
+}
+
+func (p *IngressHAPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if !p.VirtualIP.Is4() {
+		return fmt.Errorf("virtual IP must be an IPv4 address: '%s'", p.VirtualIP)
+	}
+	if p.Interface == "" {
+		return errors.New("network interface must not be empty")
+	}
+	if !interfaceNameRegexp.MatchString(p.Interface) {
+		return fmt.Errorf("invalid network interface name '%s': must be 1-15 characters long and contain only "+
+			"letters, digits, '_', '.', and '-'", p.Interface)
+	}
+	if len(p.Machines) == 0 {
+		return errors.New("machines must not be empty")
+	}
+	if len(slices.Compact(slices.Sorted(slices.Values(p.Machines)))) != len(p.Machines) {
+		return fmt.Errorf("duplicate machines: %v", p.Machines)
+	}
+	if p.RouterID > 255 {
+		return fmt.Errorf("VRRP router ID must be between 1 and 255: %d", p.RouterID)
+	}
+	return nil
+}
+
+// IngressHAPolicyFromProto converts the ingress HA policy message to IngressHAPolicy. It returns nil if the message
+// is nil.
+func IngressHAPolicyFromProto(p *pb.IngressHAPolicy) *IngressHAPolicy {
+	if p == nil {
+		return nil
+	}
+	vip, _ := netip.ParseAddr(p.VirtualIp)
+	return &IngressHAPolicy{
+		VirtualIP:    vip,
+		Interface:    p.Interface,
+		Machines:     p.Machines,
+		RouterID:     p.RouterId,
+		NotifyMaster: p.NotifyMaster,
+	}
+}
+
+// Proto returns the ingress HA policy message or nil if the policy is nil.
+func (p *IngressHAPolicy) Proto() *pb.IngressHAPolicy {
+	if p == nil {
+		return nil
+	}
+	return &pb.IngressHAPolicy{
+		VirtualIp:    p.VirtualIP.String(),
+		Interface:    p.Interface,
+		Machines:     p.Machines,
+		RouterId:     p.RouterID,
+		NotifyMaster: p.NotifyMaster,
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngressHAPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	var policy *IngressHAPolicy
+	assert.NoError(t, policy.Validate())
+
+	valid := func() *IngressHAPolicy {
+		return &IngressHAPolicy{
+			VirtualIP: netip.MustParseAddr("192.168.1.100"),
+			Interface: "eth0",
+			Machines:  []string{"m2", "m1"},
+		}
+	}
+	assert.NoError(t, valid().Validate())
+	assert.Equal(t, uint32(DefaultVRRPRouterID), valid().VRRPRouterID())
+
+	policy = valid()
+	policy.VirtualIP = netip.MustParseAddr("2001:db8::1")
+	assert.ErrorContains(t, policy.Validate(), "must be an IPv4 address")
+
+	policy = valid()
+	policy.Interface = ""
+	assert.ErrorContains(t, policy.Validate(), "network interface must not be empty")
+
+	for _, name := range []string{"eth0\n}\nglobal_defs {", "eth0 dev", "veryveryverylong0", "eth0;"} {
+		policy = valid()
+		policy.Interface = name
+		assert.ErrorContains(t, policy.Validate(), "invalid network interface name", name)
+	}
+	policy = valid()
+	policy.Interface = "enp0s3.100"
+	assert.NoError(t, policy.Validate())
+
+	policy = valid()
+	policy.Machines = []string{"m1", "m1"}
+	assert.ErrorContains(t, policy.Validate(), "duplicate machines")
+
+	policy = valid()
+	policy.RouterID = 256
+	assert.ErrorContains(t, policy.Validate(), "VRRP router ID")
+
+	policy = valid()
+	policy.RouterID = 10
+	policy.NotifyMaster = "assign-ip.sh"
+	assert.Equal(t, policy, IngressHAPolicyFromProto(policy.Proto()))
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+// maxIPReservationDescriptionLen is the maximum length of the description of an IP range reservation.
+const maxIPReservationDescriptionLen = 256
+
+// IPReservation is an IP range in the cluster network that is excluded from the subnet allocation for new machines,
+// e.g. to avoid conflicts with an existing VPN using the same addresses.
+type IPReservation struct {
+	Prefix netip.Prefix
+	// Description explains why the range is reserved.
+	Description string `json:",omitempty"`
+	CreatedAt   time.Time
+}
+
+func (r *IPReservation) Validate() error {
+	if !r.Prefix.IsValid() {
+		return errors.New("reserved IP range must be a valid CIDR, e.g. 10.210.200.0/24")
+	}
+	if r.Prefix != r.Prefix.Masked() {
+		return fmt.Errorf("reserved IP range '%s' has host bits set, did you mean '%s'?", r.Prefix, r.Prefix.Masked())
+	}
+	if len(r.Description) > maxIPReservationDescriptionLen {
+		return fmt.Errorf("description must not be longer than %d characters", maxIPReservationDescriptionLen)
+	}
+	return nil
+}
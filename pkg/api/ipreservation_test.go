@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPReservation_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		reservation IPReservation
+		wantErr     string
+	}{
+		{
+			name:        "valid",
+			reservation: IPReservation{Prefix: netip.MustParsePrefix("10.210.200.0/24"), Description: "office VPN"},
+		},
+		{
+			name:        "single IP",
+			reservation: IPReservation{Prefix: netip.MustParsePrefix("10.210.0.53/32")},
+		},
+		{
+			name:        "invalid prefix",
+			reservation: IPReservation{},
+			wantErr:     "must be a valid CIDR",
+		},
+		{
+			name:        "host bits set",
+			reservation: IPReservation{Prefix: netip.MustParsePrefix("10.210.200.1/24")},
+			wantErr:     "did you mean '10.210.200.0/24'",
+		},
+		{
+			name: "description too long",
+			reservation: IPReservation{
+				Prefix:      netip.MustParsePrefix("10.210.200.0/24"),
+				Description: strings.Repeat("a", 257),
+			},
+			wantErr: "description must not be longer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.reservation.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,17 +1,216 @@
 package api
 
-import "github.com/psviderski/uncloud/internal/machine/api/pb"
+import (
+	"fmt"
+	"net/netip"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+const (
+	// CordonReasonShutdown is the reason a machine is cordoned by its daemon while it's shutting down for a planned
+	// reboot.
+	CordonReasonShutdown = "shutdown"
+	// CordonReasonAutoUpdate is the reason a machine is cordoned while it's drained for an automatic OS update.
+	CordonReasonAutoUpdate = "auto-update"
+)
+
+// Machine is a machine in the cluster.
+type Machine struct {
+	ID   string
+	Name string
+	// State is the membership state of the machine in the cluster: UP, SUSPECT, DOWN, or UNKNOWN.
+	State string
+	// Cordoned indicates that no new containers should be scheduled on the machine, e.g. while it's shutting down
+	// for a planned reboot.
+	Cordoned bool
+	// Arch is the CPU architecture of the machine in the Go/OCI format, e.g. amd64 or arm64.
+	// Empty if the machine runs an older daemon version that doesn't report it.
+	Arch string
+	// Subnet is the IPv4 subnet allocated to the machine for its containers.
+	Subnet       netip.Prefix
+	ManagementIP netip.Addr
+	// PublicIP is the public IP of the machine used for ingress. Invalid if the machine doesn't have one.
+	PublicIP netip.Addr
+	// Endpoints are the IP addresses other machines use to establish WireGuard tunnels to the machine.
+	Endpoints []netip.AddrPort
+	// DNSEndpoints are the WireGuard endpoints specified as DNS names in the host:port format.
+	DNSEndpoints []string
+	// Resources is the hardware and software inventory of the machine. Nil if the machine runs an older daemon
+	// version that doesn't report it.
+	Resources *MachineResources
+	// Cost is the cost metadata of the machine set by the user. Nil if not specified.
+	Cost *MachineCost
+}
+
+// MachineCost is the cost metadata of a machine used to estimate the cost of the services running on it.
+type MachineCost struct {
+	// HourlyPrice is the price of running the machine per hour.
+	HourlyPrice float64
+	// Provider is the name of the cloud or hosting provider of the machine.
+	Provider string
+}
+
+// MachineResources is the hardware and software inventory of a machine.
+type MachineResources struct {
+	CPUs int
+	// Memory is the total memory in bytes.
+	Memory int64
+	// Disk is the total size in bytes of the filesystem with the Docker data root. Zero if unknown.
+	Disk          int64
+	Kernel        string
+	OS            string
+	DockerVersion string
+}
+
+// Available returns true if the machine is not DOWN.
+func (m Machine) Available() bool {
+	return m.State != pb.MachineMember_DOWN.String()
+}
+
+// MachineResult is the result of an operation performed on a machine as part of an operation on multiple machines.
+type MachineResult[T any] struct {
+	Machine Machine
+	Value   T
+	// Err is set if the operation failed on the machine. Value is undefined in this case.
+	Err error
+}
+
+// MachineInspection is the live state of a machine reported by the machine daemon.
+type MachineInspection struct {
+	// Resources is the current hardware and software inventory of the machine. Nil if the machine failed
+	// to collect it, e.g. if Docker is not running.
+	Resources *MachineResources
+	// Usage is nil if the machine failed to measure it, e.g. if Docker is not running.
+	Usage *MachineUsage
+	// DaemonVersion is the version of the machine daemon (uncloudd).
+	DaemonVersion string
+	// DaemonStartedAt is the time when the machine daemon was started. Zero if not reported by an older daemon.
+	DaemonStartedAt time.Time
+}
+
+// BootReport describes the state recovery performed by the machine daemon on its first start after a reboot.
+type BootReport struct {
+	BootID   string
+	BootTime time.Time
+	// RecoveredAt is the time when the recovery completed.
+	RecoveredAt time.Time
+	Actions     []RecoveryAction
+}
+
+// RecoveryAction is an action taken by the machine daemon to recover the state of a resource after a reboot.
+type RecoveryAction struct {
+	// Resource is a human-readable identifier of the recovered resource, e.g. "container web-x1y2".
+	Resource string
+	Action   string
+	// Error is the error message if the action failed.
+	Error string
+}
 
 // MachineFilter defines criteria to filter machines in ListMachines.
 type MachineFilter struct {
 	// Available filters machines that are not DOWN.
 	Available bool
+	// NamePattern filters machines whose names match the glob pattern, e.g. "web-*". See path.Match for the syntax.
+	NamePattern string
 	// NamesOrIDs filters machines by their names or IDs.
 	NamesOrIDs []string
+	// States filters machines by their membership states.
+	States []pb.MachineMember_MembershipState
+}
+
+// MachineFilterFromProto returns the machine filter defined by the list machines request.
+func MachineFilterFromProto(req *pb.ListMachinesRequest) *MachineFilter {
+	return &MachineFilter{
+		Available:   req.GetAvailable(),
+		NamePattern: req.GetNamePattern(),
+		NamesOrIDs:  req.GetNamesOrIds(),
+		States:      req.GetStates(),
+	}
+}
+
+// Proto returns the list machines request with the filter criteria. A nil filter returns an empty request.
+func (f *MachineFilter) Proto() *pb.ListMachinesRequest {
+	if f == nil {
+		return &pb.ListMachinesRequest{}
+	}
+	return &pb.ListMachinesRequest{
+		Available:   f.Available,
+		NamePattern: f.NamePattern,
+		NamesOrIds:  f.NamesOrIDs,
+		States:      f.States,
+	}
+}
+
+// Validate checks that the filter criteria are valid.
+func (f *MachineFilter) Validate() error {
+	if f == nil || f.NamePattern == "" {
+		return nil
+	}
+	if _, err := path.Match(f.NamePattern, ""); err != nil {
+		return fmt.Errorf("invalid machine name pattern '%s': %w", f.NamePattern, err)
+	}
+	return nil
+}
+
+// Matches returns true if the machine matches all criteria of the filter. A nil filter matches all machines.
+func (f *MachineFilter) Matches(m *pb.MachineMember) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Available && m.State == pb.MachineMember_DOWN {
+		return false
+	}
+	if len(f.States) > 0 && !slices.Contains(f.States, m.State) {
+		return false
+	}
+	if f.NamePattern != "" {
+		if ok, _ := path.Match(f.NamePattern, m.Machine.Name); !ok {
+			return false
+		}
+	}
+	if len(f.NamesOrIDs) > 0 {
+		if !slices.ContainsFunc(f.NamesOrIDs, func(nameOrID string) bool {
+			return m.Machine.Id == nameOrID || m.Machine.Name == nameOrID
+		}) {
+			return false
+		}
+	}
+
+	return true
 }
 
 type MachineMembersList []*pb.MachineMember
 
+// Page returns at most pageSize machines sorted by name that come after the machine named pageToken and the token
+// for the next page. The next page token is empty if there are no more machines. All machines after the page token
+// are returned if pageSize is 0.
+func (m MachineMembersList) Page(pageSize int, pageToken string) (MachineMembersList, string) {
+	sorted := slices.Clone(m)
+	slices.SortFunc(sorted, func(a, b *pb.MachineMember) int {
+		return strings.Compare(a.Machine.Name, b.Machine.Name)
+	})
+
+	start, _ := slices.BinarySearchFunc(sorted, pageToken, func(mm *pb.MachineMember, name string) int {
+		return strings.Compare(mm.Machine.Name, name)
+	})
+	if pageToken != "" && start < len(sorted) && sorted[start].Machine.Name == pageToken {
+		start++
+	}
+	sorted = sorted[start:]
+
+	if pageSize <= 0 || len(sorted) <= pageSize {
+		return sorted, ""
+	}
+	page := sorted[:pageSize]
+	return page, page[len(page)-1].Machine.Name
+}
+
 func (m MachineMembersList) FindByManagementIP(ip string) *pb.MachineMember {
 	for _, machine := range m {
 		addr, err := machine.Machine.Network.ManagementIp.ToAddr()
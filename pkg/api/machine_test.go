@@ -0,0 +1,97 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func machineMember(name string, state pb.MachineMember_MembershipState) *pb.MachineMember {
+	return &pb.MachineMember{
+		Machine: &pb.MachineInfo{Id: name + "-id", Name: name},
+		State:   state,
+	}
+}
+
+func TestMachineFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	web1 := machineMember("web-1", pb.MachineMember_UP)
+	web2 := machineMember("web-2", pb.MachineMember_DOWN)
+	db := machineMember("db", pb.MachineMember_SUSPECT)
+	machines := MachineMembersList{web1, web2, db}
+
+	tests := []struct {
+		name   string
+		filter *MachineFilter
+		want   MachineMembersList
+	}{
+		{
+			name: "nil",
+			want: machines,
+		},
+		{
+			name:   "available",
+			filter: &MachineFilter{Available: true},
+			want:   MachineMembersList{web1, db},
+		},
+		{
+			name:   "name pattern",
+			filter: &MachineFilter{NamePattern: "web-*"},
+			want:   MachineMembersList{web1, web2},
+		},
+		{
+			name:   "states",
+			filter: &MachineFilter{States: []pb.MachineMember_MembershipState{pb.MachineMember_DOWN}},
+			want:   MachineMembersList{web2},
+		},
+		{
+			name:   "name pattern and names or IDs",
+			filter: &MachineFilter{NamePattern: "web-*", NamesOrIDs: []string{"web-2-id", "db"}},
+			want:   MachineMembersList{web2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.filter.Validate())
+
+			var got MachineMembersList
+			for _, m := range machines {
+				if tt.filter.Matches(m) {
+					got = append(got, m)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.ErrorContains(t, (&MachineFilter{NamePattern: "web-["}).Validate(), "invalid machine name pattern")
+}
+
+func TestMachineMembersList_Page(t *testing.T) {
+	t.Parallel()
+
+	a := machineMember("a", pb.MachineMember_UP)
+	b := machineMember("b", pb.MachineMember_UP)
+	c := machineMember("c", pb.MachineMember_UP)
+	machines := MachineMembersList{c, a, b}
+
+	page, next := machines.Page(2, "")
+	assert.Equal(t, MachineMembersList{a, b}, page)
+	assert.Equal(t, "b", next)
+
+	page, next = machines.Page(2, next)
+	assert.Equal(t, MachineMembersList{c}, page)
+	assert.Empty(t, next)
+
+	page, next = machines.Page(0, "a")
+	assert.Equal(t, MachineMembersList{b, c}, page)
+	assert.Empty(t, next)
+
+	// The page token of a removed machine continues from the next machine by name.
+	page, _ = MachineMembersList{a, c}.Page(1, "b")
+	assert.Equal(t, MachineMembersList{c}, page)
+}
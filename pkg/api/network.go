@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+)
+
+// DefaultNetwork is the name of the network a service is attached to if no networks are specified in its spec.
+const DefaultNetwork = "default"
+
+var networkNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ServiceNetworks returns the sorted list of networks the service containers are attached to.
+// It returns a list with only DefaultNetwork if no networks are specified. The networks of a tenant service are
+// namespaced with the tenant name, see TenantNetwork.
+func (s *ServiceSpec) ServiceNetworks() []string {
+	networks := []string{DefaultNetwork}
+	if len(s.Networks) > 0 {
+		networks = slices.Clone(s.Networks)
+	}
+	if s.Tenant != "" {
+		for i, n := range networks {
+			networks[i] = TenantNetwork(s.Tenant, n)
+		}
+	}
+
+	slices.Sort(networks)
+	return slices.Compact(networks)
+}
+
+// NetworksIntersect returns true if the two lists of networks have at least one network in common.
+func NetworksIntersect(a, b []string) bool {
+	for _, n := range a {
+		if slices.Contains(b, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateNetworks(networks []string) error {
+	seen := make(map[string]struct{}, len(networks))
+	for _, n := range networks {
+		if len(n) > 63 || !networkNameRegexp.MatchString(n) {
+			return fmt.Errorf("invalid network name: %q. must be 1-63 characters, letters, numbers, "+
+				"underscores, dots, and dashes only; must start with a letter or number", n)
+		}
+		if n == NetworkModeHost {
+			return fmt.Errorf("network name '%s' is reserved for the host network mode", n)
+		}
+		if _, ok := seen[n]; ok {
+			return fmt.Errorf("duplicate network: '%s'", n)
+		}
+		seen[n] = struct{}{}
+	}
+	return nil
+}
+
+const (
+	// NetworkModeHost runs the service containers in the host network namespace of the machine.
+	NetworkModeHost = "host"
+	// NetworkModeMacvlan attaches the service containers directly to a physical network of the machine using
+	// a macvlan Docker network so that they appear as separate devices on the network with their own MAC addresses.
+	NetworkModeMacvlan = "macvlan"
+)
+
+// MacvlanOptions configures the macvlan network service containers are attached to in NetworkModeMacvlan.
+// Each machine allocates container addresses from the subnet (or IPRange) independently, so a service that runs
+// on multiple machines attached to the same physical network should be given non-overlapping ranges via separate
+// services or placed on a single machine.
+type MacvlanOptions struct {
+	// Parent is the name of the machine network interface to attach the macvlan network to, e.g. eth0.
+	Parent string
+	// Subnet is the subnet of the physical network, e.g. 192.168.1.0/24.
+	Subnet netip.Prefix
+	// Gateway is the gateway of the physical network, e.g. 192.168.1.1.
+	Gateway netip.Addr `json:",omitempty"`
+	// IPRange restricts the addresses allocated to containers to a range within the subnet, e.g. 192.168.1.240/28.
+	// It's recommended to use a range that is excluded from the DHCP pool of the physical network.
+	IPRange netip.Prefix `json:",omitempty"`
+}
+
+func (o *MacvlanOptions) Validate() error {
+	if o.Parent == "" {
+		return fmt.Errorf("macvlan parent interface must be specified")
+	}
+	if !o.Subnet.IsValid() {
+		return fmt.Errorf("macvlan subnet must be specified")
+	}
+	if o.Gateway.IsValid() && !o.Subnet.Contains(o.Gateway) {
+		return fmt.Errorf("macvlan gateway %s is not in subnet %s", o.Gateway, o.Subnet)
+	}
+	if o.IPRange.IsValid() && (o.IPRange.Bits() < o.Subnet.Bits() || !o.Subnet.Contains(o.IPRange.Addr())) {
+		return fmt.Errorf("macvlan IP range %s is not in subnet %s", o.IPRange, o.Subnet)
+	}
+	return nil
+}
+
+// OneContainerPerMachine returns true if at most one service container can run on a machine because containers
+// don't use the cluster network, e.g. they bind to ports on the host network directly.
+func (s *ServiceSpec) OneContainerPerMachine() bool {
+	return s.NetworkMode == NetworkModeHost || s.NetworkMode == NetworkModeMacvlan
+}
+
+// validateNetworkMode validates the network mode of the service and its compatibility with other service options.
+func (s *ServiceSpec) validateNetworkMode() error {
+	switch s.NetworkMode {
+	case "":
+		if s.Macvlan != nil {
+			return fmt.Errorf("macvlan options can only be specified for '%s' network mode", NetworkModeMacvlan)
+		}
+		return nil
+	case NetworkModeHost:
+		if s.Macvlan != nil {
+			return fmt.Errorf("macvlan options can only be specified for '%s' network mode", NetworkModeMacvlan)
+		}
+	case NetworkModeMacvlan:
+		if s.Macvlan == nil {
+			return fmt.Errorf("macvlan options must be specified for '%s' network mode", NetworkModeMacvlan)
+		}
+		if err := s.Macvlan.Validate(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid network mode: %q", s.NetworkMode)
+	}
+
+	// Containers not connected to the cluster network can't be discovered by other services or reached by Caddy.
+	if len(s.Networks) > 0 {
+		return fmt.Errorf("networks cannot be specified for '%s' network mode", s.NetworkMode)
+	}
+	if len(s.Ports) > 0 {
+		return fmt.Errorf("ports cannot be published in '%s' network mode: containers listen on the network "+
+			"directly and can't be exposed via ingress", s.NetworkMode)
+	}
+	if s.CaddyConfig() != "" {
+		return fmt.Errorf("custom Caddy configuration cannot be specified in '%s' network mode: containers are not "+
+			"reachable from the cluster network", s.NetworkMode)
+	}
+	return nil
+}
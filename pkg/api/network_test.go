@@ -0,0 +1,143 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceSpec_Validate_Networks(t *testing.T) {
+	t.Parallel()
+
+	container := ContainerSpec{Image: "nginx:latest"}
+	macvlan := &MacvlanOptions{
+		Parent:  "eth0",
+		Subnet:  netip.MustParsePrefix("192.168.1.0/24"),
+		Gateway: netip.MustParseAddr("192.168.1.1"),
+		IPRange: netip.MustParsePrefix("192.168.1.240/28"),
+	}
+
+	tests := []struct {
+		name    string
+		spec    ServiceSpec
+		wantErr string
+	}{
+		{
+			name: "named networks",
+			spec: ServiceSpec{Container: container, Networks: []string{"frontend", "back_end.1"}},
+		},
+		{
+			name:    "invalid network name",
+			spec:    ServiceSpec{Container: container, Networks: []string{"-frontend"}},
+			wantErr: "invalid network name",
+		},
+		{
+			name:    "duplicate network",
+			spec:    ServiceSpec{Container: container, Networks: []string{"frontend", "frontend"}},
+			wantErr: "duplicate network",
+		},
+		{
+			name:    "reserved network name",
+			spec:    ServiceSpec{Container: container, Networks: []string{NetworkModeHost}},
+			wantErr: "reserved",
+		},
+		{
+			name: "host network mode",
+			spec: ServiceSpec{Container: container, NetworkMode: NetworkModeHost},
+		},
+		{
+			name: "macvlan network mode",
+			spec: ServiceSpec{Container: container, NetworkMode: NetworkModeMacvlan, Macvlan: macvlan},
+		},
+		{
+			name:    "invalid network mode",
+			spec:    ServiceSpec{Container: container, NetworkMode: "bridge"},
+			wantErr: "invalid network mode",
+		},
+		{
+			name:    "macvlan without options",
+			spec:    ServiceSpec{Container: container, NetworkMode: NetworkModeMacvlan},
+			wantErr: "macvlan options must be specified",
+		},
+		{
+			name:    "macvlan options without macvlan mode",
+			spec:    ServiceSpec{Container: container, NetworkMode: NetworkModeHost, Macvlan: macvlan},
+			wantErr: "macvlan options can only be specified",
+		},
+		{
+			name: "macvlan gateway outside subnet",
+			spec: ServiceSpec{Container: container, NetworkMode: NetworkModeMacvlan, Macvlan: &MacvlanOptions{
+				Parent:  "eth0",
+				Subnet:  netip.MustParsePrefix("192.168.1.0/24"),
+				Gateway: netip.MustParseAddr("192.168.2.1"),
+			}},
+			wantErr: "gateway 192.168.2.1 is not in subnet",
+		},
+		{
+			name: "macvlan IP range outside subnet",
+			spec: ServiceSpec{Container: container, NetworkMode: NetworkModeMacvlan, Macvlan: &MacvlanOptions{
+				Parent:  "eth0",
+				Subnet:  netip.MustParsePrefix("192.168.1.0/24"),
+				IPRange: netip.MustParsePrefix("192.168.0.0/16"),
+			}},
+			wantErr: "IP range 192.168.0.0/16 is not in subnet",
+		},
+		{
+			name: "host network mode with networks",
+			spec: ServiceSpec{
+				Container:   container,
+				NetworkMode: NetworkModeHost,
+				Networks:    []string{"frontend"},
+			},
+			wantErr: "networks cannot be specified",
+		},
+		{
+			name: "host network mode with ports",
+			spec: ServiceSpec{
+				Container:   container,
+				NetworkMode: NetworkModeHost,
+				Ports: []PortSpec{
+					{ContainerPort: 80, Protocol: ProtocolHTTP, Mode: PortModeIngress},
+				},
+			},
+			wantErr: "ports cannot be published",
+		},
+		{
+			name: "macvlan network mode with Caddy config",
+			spec: ServiceSpec{
+				Container:   container,
+				NetworkMode: NetworkModeMacvlan,
+				Macvlan:     macvlan,
+				Caddy:       &CaddySpec{Config: "example.com {\n  reverse_proxy :8080\n}"},
+			},
+			wantErr: "Caddy configuration cannot be specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.spec.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceSpec_ServiceNetworks(t *testing.T) {
+	t.Parallel()
+
+	spec := ServiceSpec{}
+	assert.Equal(t, []string{DefaultNetwork}, spec.ServiceNetworks())
+
+	spec.Networks = []string{"frontend", "backend", "frontend"}
+	assert.Equal(t, []string{"backend", "frontend"}, spec.ServiceNetworks())
+	// The original slice must not be modified.
+	assert.Equal(t, []string{"frontend", "backend", "frontend"}, spec.Networks)
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+type NetworkMigrationState string
+
+const (
+	// NetworkMigrationInProgress means the machines and containers are being renumbered to the new network.
+	NetworkMigrationInProgress NetworkMigrationState = "in-progress"
+	// NetworkMigrationRollingBack means the renumbered machines and containers are being renumbered back to
+	// the original network.
+	NetworkMigrationRollingBack NetworkMigrationState = "rolling-back"
+	// NetworkMigrationCompleted means all machines and containers have been renumbered to the new network.
+	NetworkMigrationCompleted NetworkMigrationState = "completed"
+	// NetworkMigrationRolledBack means all machines and containers have been renumbered back to the original network.
+	NetworkMigrationRolledBack NetworkMigrationState = "rolled-back"
+)
+
+// NetworkMigration is a phased renumbering of the cluster network to a new IP range: first the machines one by one,
+// then the service containers. The progress is recorded after each step so an interrupted migration can be resumed
+// or rolled back from the last completed step.
+type NetworkMigration struct {
+	// From is the original cluster network.
+	From netip.Prefix
+	// To is the new cluster network.
+	To       netip.Prefix
+	State    NetworkMigrationState
+	Machines []MachineRenumbering
+	// ContainersRenumbered is true when the service containers have been recreated to get IPs from the current
+	// machine subnets and use the current machine IPs as their DNS servers.
+	ContainersRenumbered bool
+	StartedAt            time.Time
+}
+
+// MachineRenumbering is the planned change of the machine subnet in a NetworkMigration.
+type MachineRenumbering struct {
+	MachineID   string
+	MachineName string
+	From        netip.Prefix
+	To          netip.Prefix
+	// Renumbered is true when the machine has been renumbered to the To subnet.
+	Renumbered bool
+}
+
+// PlanNetworkMigration plans the migration of the cluster network to a new IP range. Each machine subnet keeps its
+// offset within the network, e.g. 10.210.3.0/24 in 10.210.0.0/16 is renumbered to 172.16.3.0/24 in 172.16.0.0/16.
+func PlanNetworkMigration(from, to netip.Prefix, machines []MachineRenumbering) (NetworkMigration, error) {
+	if !to.IsValid() || !to.Addr().Is4() {
+		return NetworkMigration{}, errors.New("new network must be a valid IPv4 CIDR, e.g. 172.16.0.0/16")
+	}
+	if to != to.Masked() {
+		return NetworkMigration{}, fmt.Errorf("new network '%s' has host bits set, did you mean '%s'?",
+			to, to.Masked())
+	}
+	if to.Overlaps(from) {
+		return NetworkMigration{}, fmt.Errorf("new network %s must not overlap the current network %s "+
+			"so that the machines can be renumbered one by one", to, from)
+	}
+
+	m := NetworkMigration{
+		From:     from,
+		To:       to,
+		State:    NetworkMigrationInProgress,
+		Machines: make([]MachineRenumbering, len(machines)),
+	}
+	base := binary.BigEndian.Uint32(from.Addr().AsSlice())
+	newBase := binary.BigEndian.Uint32(to.Addr().AsSlice())
+	for i, r := range machines {
+		if !from.Contains(r.From.Addr()) {
+			return m, fmt.Errorf("subnet %s of machine '%s' is outside the current network %s",
+				r.From, r.MachineName, from)
+		}
+		var addr [4]byte
+		binary.BigEndian.PutUint32(addr[:], newBase+binary.BigEndian.Uint32(r.From.Addr().AsSlice())-base)
+		r.To = netip.PrefixFrom(netip.AddrFrom4(addr), r.From.Bits())
+		if r.To.Bits() < to.Bits() || !to.Contains(r.To.Addr()) {
+			return m, fmt.Errorf("new network %s is too small to fit subnet %s of machine '%s', "+
+				"use a /%d or larger network", to, r.To, r.MachineName, from.Bits())
+		}
+		r.Renumbered = false
+		m.Machines[i] = r
+	}
+	return m, nil
+}
+
+func (m *NetworkMigration) Validate() error {
+	if !m.From.IsValid() || !m.To.IsValid() {
+		return errors.New("both the current and new networks must be set")
+	}
+	switch m.State {
+	case NetworkMigrationInProgress, NetworkMigrationRollingBack, NetworkMigrationCompleted,
+		NetworkMigrationRolledBack:
+	default:
+		return fmt.Errorf("invalid network migration state: '%s'", m.State)
+	}
+	for _, r := range m.Machines {
+		if r.MachineID == "" {
+			return errors.New("machine ID must not be empty")
+		}
+		if !m.From.Contains(r.From.Addr()) || !m.To.Contains(r.To.Addr()) {
+			return fmt.Errorf("subnets of machine '%s' must be within the current and new networks", r.MachineName)
+		}
+	}
+	if m.State == NetworkMigrationCompleted {
+		for _, r := range m.Machines {
+			if !r.Renumbered {
+				return fmt.Errorf("machine '%s' hasn't been renumbered yet", r.MachineName)
+			}
+		}
+		if !m.ContainersRenumbered {
+			return errors.New("containers haven't been renumbered yet")
+		}
+	}
+	return nil
+}
+
+// Machine returns the planned renumbering of the machine with the given ID.
+func (m *NetworkMigration) Machine(id string) (MachineRenumbering, bool) {
+	for _, r := range m.Machines {
+		if r.MachineID == id {
+			return r, true
+		}
+	}
+	return MachineRenumbering{}, false
+}
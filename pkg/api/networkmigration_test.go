@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanNetworkMigration(t *testing.T) {
+	t.Parallel()
+
+	from := netip.MustParsePrefix("10.210.0.0/16")
+	machines := []MachineRenumbering{
+		{MachineID: "m1", MachineName: "one", From: netip.MustParsePrefix("10.210.0.0/24")},
+		{MachineID: "m2", MachineName: "two", From: netip.MustParsePrefix("10.210.3.0/24"), Renumbered: true},
+	}
+
+	t.Run("keeps subnet offsets", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := PlanNetworkMigration(from, netip.MustParsePrefix("172.16.0.0/12"), machines)
+		require.NoError(t, err)
+		assert.Equal(t, NetworkMigrationInProgress, m.State)
+		assert.Equal(t, netip.MustParsePrefix("172.16.0.0/24"), m.Machines[0].To)
+		assert.Equal(t, netip.MustParsePrefix("172.16.3.0/24"), m.Machines[1].To)
+		assert.False(t, m.Machines[1].Renumbered)
+		assert.NoError(t, m.Validate())
+	})
+
+	t.Run("network too small", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PlanNetworkMigration(from, netip.MustParsePrefix("172.16.0.0/23"), machines)
+		assert.ErrorContains(t, err, "too small to fit subnet 172.16.3.0/24 of machine 'two'")
+	})
+
+	t.Run("smaller network fitting all subnets", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := PlanNetworkMigration(from, netip.MustParsePrefix("172.16.0.0/22"), machines)
+		require.NoError(t, err)
+		assert.Equal(t, netip.MustParsePrefix("172.16.3.0/24"), m.Machines[1].To)
+	})
+
+	t.Run("overlapping networks", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PlanNetworkMigration(from, netip.MustParsePrefix("10.0.0.0/8"), machines)
+		assert.ErrorContains(t, err, "must not overlap")
+	})
+
+	t.Run("host bits set", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PlanNetworkMigration(from, netip.MustParsePrefix("172.16.0.1/16"), machines)
+		assert.ErrorContains(t, err, "did you mean '172.16.0.0/16'")
+	})
+}
+
+func TestNetworkMigration_Validate(t *testing.T) {
+	t.Parallel()
+
+	m, err := PlanNetworkMigration(netip.MustParsePrefix("10.210.0.0/16"), netip.MustParsePrefix("172.16.0.0/16"),
+		[]MachineRenumbering{{MachineID: "m1", MachineName: "one", From: netip.MustParsePrefix("10.210.0.0/24")}})
+	require.NoError(t, err)
+
+	m.State = NetworkMigrationCompleted
+	assert.ErrorContains(t, m.Validate(), "machine 'one' hasn't been renumbered")
+
+	m.Machines[0].Renumbered = true
+	assert.ErrorContains(t, m.Validate(), "containers haven't been renumbered")
+
+	m.ContainersRenumbered = true
+	assert.NoError(t, m.Validate())
+
+	m.State = "unknown"
+	assert.ErrorContains(t, m.Validate(), "invalid network migration state")
+}
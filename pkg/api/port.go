@@ -26,8 +26,14 @@ type PortSpec struct {
 	// PublishedPort is the port number exposed outside the container.
 	// In ingress mode, this is the load balancer port. In host mode, this is the port bound on the host.
 	PublishedPort uint16
+	// PublishedPortEnd is the last port of the published port range PublishedPort-PublishedPortEnd if the port spec
+	// defines a range. Only valid in host mode.
+	PublishedPortEnd uint16 `json:",omitempty"`
 	// ContainerPort is the port inside the container that the service listens on.
 	ContainerPort uint16
+	// ContainerPortEnd is the last port of the container port range ContainerPort-ContainerPortEnd if the port spec
+	// defines a range. The published and container port ranges must have the same size. Only valid in host mode.
+	ContainerPortEnd uint16 `json:",omitempty"`
 	// Protocol specifies the network protocol.
 	Protocol string
 	// Mode specifies how the port is published.
@@ -78,9 +84,53 @@ func (p *PortSpec) Validate() error {
 		return fmt.Errorf("invalid mode: '%s'", p.Mode)
 	}
 
+	if p.IsRange() {
+		if p.Mode != PortModeHost {
+			return fmt.Errorf("port ranges are only supported in %s mode", PortModeHost)
+		}
+		if p.PublishedPortEnd == 0 {
+			return fmt.Errorf("container port range %d-%d must be published to a host port range of the same size",
+				p.ContainerPort, p.ContainerPortEnd)
+		}
+		if p.ContainerPortEnd == 0 {
+			return fmt.Errorf("published port range %d-%d must be mapped to a container port range of the same size",
+				p.PublishedPort, p.PublishedPortEnd)
+		}
+		if p.ContainerPortEnd <= p.ContainerPort {
+			return fmt.Errorf("invalid container port range %d-%d", p.ContainerPort, p.ContainerPortEnd)
+		}
+		if p.PublishedPortEnd <= p.PublishedPort {
+			return fmt.Errorf("invalid published port range %d-%d", p.PublishedPort, p.PublishedPortEnd)
+		}
+		if p.PublishedPortEnd-p.PublishedPort != p.ContainerPortEnd-p.ContainerPort {
+			return fmt.Errorf("published port range %d-%d and container port range %d-%d must have the same size",
+				p.PublishedPort, p.PublishedPortEnd, p.ContainerPort, p.ContainerPortEnd)
+		}
+	}
+
 	return nil
 }
 
+// IsRange returns true if the port spec defines a range of ports rather than a single port.
+func (p *PortSpec) IsRange() bool {
+	return p.ContainerPortEnd != 0 || p.PublishedPortEnd != 0
+}
+
+// Size returns the number of ports in the port spec.
+func (p *PortSpec) Size() int {
+	if p.ContainerPortEnd == 0 {
+		return 1
+	}
+	return int(p.ContainerPortEnd) - int(p.ContainerPort) + 1
+}
+
+// publishedPortsOverlap returns true if the published ports of the two port specs overlap.
+func (p *PortSpec) publishedPortsOverlap(other PortSpec) bool {
+	last := int(p.PublishedPort) + p.Size() - 1
+	otherLast := int(other.PublishedPort) + other.Size() - 1
+	return int(p.PublishedPort) <= otherLast && int(other.PublishedPort) <= last
+}
+
 // String returns the port specification in the -p/--publish flag format.
 // Format:
 // [hostname:][load_balancer_port:]container_port/protocol for ingress mode (default) or
@@ -111,8 +161,8 @@ func (p *PortSpec) String() (string, error) {
 				parts = append(parts, p.HostIP.String())
 			}
 		}
-		parts = append(parts, fmt.Sprint(p.PublishedPort))
-		parts = append(parts, fmt.Sprint(p.ContainerPort))
+		parts = append(parts, formatPortRange(p.PublishedPort, p.PublishedPortEnd))
+		parts = append(parts, formatPortRange(p.ContainerPort, p.ContainerPortEnd))
 
 		return fmt.Sprintf("%s/%s@host", strings.Join(parts, ":"), p.Protocol), nil
 	default:
@@ -163,12 +213,12 @@ func ParsePortSpec(port string) (PortSpec, error) {
 
 	switch len(parts) {
 	case 1: // Just container port.
-		if spec.ContainerPort, err = parsePort(parts[0]); err != nil {
+		if spec.ContainerPort, spec.ContainerPortEnd, err = parsePortRange(parts[0]); err != nil {
 			return spec, fmt.Errorf("invalid container port '%s': %w", parts[0], err)
 		}
 
 	case 2: // hostname:container_port or [load_balancer_port|host_port]:container_port
-		if spec.ContainerPort, err = parsePort(parts[1]); err != nil {
+		if spec.ContainerPort, spec.ContainerPortEnd, err = parsePortRange(parts[1]); err != nil {
 			return spec, fmt.Errorf("invalid container port '%s': %w", parts[1], err)
 		}
 
@@ -177,8 +227,9 @@ func ParsePortSpec(port string) (PortSpec, error) {
 				"hostname:container_port or published_port:container_port")
 		}
 		// Try to parse the first part as port.
-		if publishedPort, err := parsePort(parts[0]); err == nil {
+		if publishedPort, publishedPortEnd, err := parsePortRange(parts[0]); err == nil {
 			spec.PublishedPort = publishedPort
+			spec.PublishedPortEnd = publishedPortEnd
 		} else {
 			// It's a hostname.
 			if spec.Mode == PortModeHost {
@@ -188,10 +239,10 @@ func ParsePortSpec(port string) (PortSpec, error) {
 		}
 
 	case 3: // hostname:load_balancer_port:container_port or host_ip:host_port:container_port
-		if spec.ContainerPort, err = parsePort(parts[2]); err != nil {
+		if spec.ContainerPort, spec.ContainerPortEnd, err = parsePortRange(parts[2]); err != nil {
 			return spec, fmt.Errorf("invalid container port '%s': %w", parts[2], err)
 		}
-		if spec.PublishedPort, err = parsePort(parts[1]); err != nil {
+		if spec.PublishedPort, spec.PublishedPortEnd, err = parsePortRange(parts[1]); err != nil {
 			return spec, fmt.Errorf("invalid published port '%s': %w", parts[1], err)
 		}
 
@@ -253,6 +304,34 @@ func parsePort(s string) (uint16, error) {
 	return uint16(port), nil
 }
 
+// parsePortRange parses a single port or a port range in the format start-end. The end port is zero for a single port.
+func parsePortRange(s string) (uint16, uint16, error) {
+	startStr, endStr, isRange := strings.Cut(s, "-")
+	start, err := parsePort(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, 0, nil
+	}
+
+	end, err := parsePort(endStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end <= start {
+		return 0, 0, fmt.Errorf("end port must be greater than start port")
+	}
+	return start, end, nil
+}
+
+func formatPortRange(start, end uint16) string {
+	if end == 0 {
+		return strconv.Itoa(int(start))
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
 func validateHostname(hostname string) error {
 	if hostname == "" {
 		return fmt.Errorf("must not be empty")
@@ -231,6 +231,65 @@ func TestPortSpec_Validate(t *testing.T) {
 			},
 			wantErr: "unsupported protocol 'https' in host mode",
 		},
+
+		// Port ranges.
+		{
+			name: "host mode udp port range",
+			spec: PortSpec{
+				PublishedPort:    10000,
+				PublishedPortEnd: 10100,
+				ContainerPort:    20000,
+				ContainerPortEnd: 20100,
+				Protocol:         ProtocolUDP,
+				Mode:             PortModeHost,
+			},
+		},
+		{
+			name: "ingress mode port range",
+			spec: PortSpec{
+				PublishedPort:    10000,
+				PublishedPortEnd: 10100,
+				ContainerPort:    10000,
+				ContainerPortEnd: 10100,
+				Protocol:         ProtocolHTTP,
+				Mode:             PortModeIngress,
+			},
+			wantErr: "port ranges are only supported in host mode",
+		},
+		{
+			name: "port ranges of different size",
+			spec: PortSpec{
+				PublishedPort:    10000,
+				PublishedPortEnd: 10100,
+				ContainerPort:    10000,
+				ContainerPortEnd: 10050,
+				Protocol:         ProtocolUDP,
+				Mode:             PortModeHost,
+			},
+			wantErr: "must have the same size",
+		},
+		{
+			name: "container port range missing",
+			spec: PortSpec{
+				PublishedPort:    10000,
+				PublishedPortEnd: 10100,
+				ContainerPort:    10000,
+				Protocol:         ProtocolUDP,
+				Mode:             PortModeHost,
+			},
+			wantErr: "must be mapped to a container port range",
+		},
+		{
+			name: "published port range missing",
+			spec: PortSpec{
+				PublishedPort:    10000,
+				ContainerPort:    10000,
+				ContainerPortEnd: 10100,
+				Protocol:         ProtocolUDP,
+				Mode:             PortModeHost,
+			},
+			wantErr: "must be published to a host port range",
+		},
 	}
 
 	for _, tt := range tests {
@@ -380,6 +439,19 @@ func TestPortSpec_String(t *testing.T) {
 			},
 			expected: "[2001:db8::1234:5678]:80:8080/tcp@host",
 		},
+		{
+			name: "host mode port range",
+			spec: PortSpec{
+				HostIP:           netip.MustParseAddr("127.0.0.1"),
+				PublishedPort:    10000,
+				PublishedPortEnd: 10100,
+				ContainerPort:    20000,
+				ContainerPortEnd: 20100,
+				Protocol:         ProtocolUDP,
+				Mode:             PortModeHost,
+			},
+			expected: "127.0.0.1:10000-10100:20000-20100/udp@host",
+		},
 	}
 
 	for _, tt := range tests {
@@ -553,6 +625,31 @@ func TestParsePortSpec(t *testing.T) {
 				Mode:          PortModeHost,
 			},
 		},
+		{
+			name: "host mode port range",
+			port: "10000-10100:10000-10100/udp@host",
+			expected: PortSpec{
+				PublishedPort:    10000,
+				PublishedPortEnd: 10100,
+				ContainerPort:    10000,
+				ContainerPortEnd: 10100,
+				Protocol:         ProtocolUDP,
+				Mode:             PortModeHost,
+			},
+		},
+		{
+			name: "host mode port range with IP",
+			port: "127.0.0.1:5060-5061:5060-5061/udp@host",
+			expected: PortSpec{
+				HostIP:           netip.MustParseAddr("127.0.0.1"),
+				PublishedPort:    5060,
+				PublishedPortEnd: 5061,
+				ContainerPort:    5060,
+				ContainerPortEnd: 5061,
+				Protocol:         ProtocolUDP,
+				Mode:             PortModeHost,
+			},
+		},
 
 		// Error cases.
 		{
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPostgresImage is the official PostgreSQL image managed Postgres clusters run by default.
+	DefaultPostgresImage = "postgres:17"
+	PostgresPort         = 5432
+	// PostgresUser is the superuser of managed Postgres clusters.
+	PostgresUser = "postgres"
+	// PostgresReplicationUser is the role the standby uses to stream the WAL from the primary.
+	PostgresReplicationUser = "replicator"
+	// PostgresSecretPrefix is the prefix of the external Compose secrets with the connection details of managed
+	// Postgres clusters: uncloud-postgres-<name>.
+	PostgresSecretPrefix = "uncloud-postgres-"
+	// PostgresRunDir is the directory on the machines where the machine daemon writes the ID of the primary machine
+	// of each managed Postgres cluster from the store: <PostgresRunDir>/<name>/primary. The directory of a cluster
+	// is mounted into its containers to let them choose their role.
+	PostgresRunDir = "/run/uncloud/postgres"
+	// PostgresPrimaryFileName is the name of the file with the ID of the primary machine in the cluster directory.
+	PostgresPrimaryFileName = "primary"
+)
+
+// PostgresCluster is a managed PostgreSQL database that runs as a global service on two machines: the primary
+// and a hot standby replicating from it with streaming replication. The internal DNS name of the service resolves
+// to the primary only. If the primary goes down, the standby is promoted and the DNS name is switched to it.
+type PostgresCluster struct {
+	// Name is the name of the service running the cluster.
+	Name  string
+	Image string
+	// Machines are the IDs of the two machines running the cluster.
+	Machines []string
+	// Primary is the ID of the machine running the primary server.
+	Primary             string
+	Password            string
+	ReplicationPassword string
+	// FailedOverAt is the time of the last automated failover.
+	FailedOverAt time.Time `json:",omitempty"`
+}
+
+func (c *PostgresCluster) Validate() error {
+	if !dnsLabelRegexp.MatchString(c.Name) || len(c.Name) > 63 {
+		return fmt.Errorf("invalid name: %q. must be 1-63 characters, lowercase letters, numbers, "+
+			"and dashes only; must start and end with a letter or number", c.Name)
+	}
+	if c.Image == "" {
+		return errors.New("image must be set")
+	}
+	if len(c.Machines) != 2 || c.Machines[0] == c.Machines[1] {
+		return errors.New("exactly two different machines must be set")
+	}
+	if !slices.Contains(c.Machines, c.Primary) {
+		return fmt.Errorf("primary machine must be one of the cluster machines: %q", c.Primary)
+	}
+	if c.Password == "" || c.ReplicationPassword == "" {
+		return errors.New("password and replication password must be set")
+	}
+	return nil
+}
+
+// Standby returns the ID of the machine running the standby server.
+func (c *PostgresCluster) Standby() string {
+	if c.Machines[0] == c.Primary {
+		return c.Machines[1]
+	}
+	return c.Machines[0]
+}
+
+// Host returns the internal DNS name that resolves to the primary server.
+func (c *PostgresCluster) Host() string {
+	return c.Name + ".internal"
+}
+
+// PrimaryServiceName returns the name of the service container on the primary machine that the cluster name
+// is an internal DNS alias for.
+func (c *PostgresCluster) PrimaryServiceName() string {
+	return c.Primary + ".m." + c.Name
+}
+
+// URL returns the connection URL of the primary server for the superuser.
+func (c *PostgresCluster) URL() string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(PostgresUser, c.Password),
+		Host:   c.Host() + ":" + strconv.Itoa(PostgresPort),
+		Path:   "/postgres",
+	}
+	return u.String()
+}
+
+// SecretName returns the name of the external Compose secret with the connection details of the cluster.
+func (c *PostgresCluster) SecretName() string {
+	return PostgresSecretPrefix + c.Name
+}
+
+// Secret returns the content of the cluster secret: the connection details as environment variables
+// in the KEY=value format recognised by libpq and most PostgreSQL clients.
+func (c *PostgresCluster) Secret() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "DATABASE_URL=%s\n", c.URL())
+	fmt.Fprintf(&b, "PGHOST=%s\n", c.Host())
+	fmt.Fprintf(&b, "PGPORT=%d\n", PostgresPort)
+	fmt.Fprintf(&b, "PGUSER=%s\n", PostgresUser)
+	fmt.Fprintf(&b, "PGPASSWORD=%s\n", c.Password)
+	return []byte(b.String())
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Priority classes of services. A higher priority service can preempt the containers of lower priority services
+// when a machine doesn't have enough unreserved resources to run its container.
+const (
+	PriorityLow      = -100
+	PriorityNormal   = 0
+	PriorityHigh     = 100
+	PriorityCritical = 1000
+)
+
+// PriorityClasses maps the names of the priority classes to their priorities.
+var PriorityClasses = map[string]int{
+	"low":      PriorityLow,
+	"normal":   PriorityNormal,
+	"high":     PriorityHigh,
+	"critical": PriorityCritical,
+}
+
+// ParsePriority parses a priority class name, e.g. high, or an integer priority.
+func ParsePriority(s string) (int, error) {
+	if p, ok := PriorityClasses[s]; ok {
+		return p, nil
+	}
+	p, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid priority '%s': must be an integer or one of the priority classes: "+
+			"low, normal, high, critical", s)
+	}
+	return p, nil
+}
+
+// FormatPriority returns the name of the priority class with the priority or the priority as a string
+// if it doesn't match any class.
+func FormatPriority(p int) string {
+	for name, cp := range PriorityClasses {
+		if cp == p {
+			return name
+		}
+	}
+	return strconv.Itoa(p)
+}
+
+// Preemption is a record of a container stopped to make room for a container of a higher priority service
+// on a machine without enough unreserved resources.
+type Preemption struct {
+	ContainerID   string
+	ContainerName string
+	ServiceName   string
+	// Tenant is the tenant of the preempted service. Empty if the service doesn't belong to a tenant.
+	Tenant    string `json:",omitempty"`
+	MachineID string
+	Priority  int
+	// PreemptorService is the name of the higher priority service the container was preempted for.
+	PreemptorService  string
+	PreemptorPriority int
+	Time              time.Time
+}
+
+// Message returns a human-readable explanation of the preemption.
+func (p Preemption) Message() string {
+	return fmt.Sprintf("Container %s of service %s (priority %s) preempted to make room for service %s "+
+		"(priority %s).", p.ContainerName, p.ServiceName, FormatPriority(p.Priority),
+		p.PreemptorService, FormatPriority(p.PreemptorPriority))
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParsePriority("high")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityHigh, p)
+
+	p, err = ParsePriority("-5")
+	require.NoError(t, err)
+	assert.Equal(t, -5, p)
+
+	_, err = ParsePriority("urgent")
+	assert.ErrorContains(t, err, "invalid priority 'urgent'")
+}
+
+func TestFormatPriority(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "low", FormatPriority(PriorityLow))
+	assert.Equal(t, "critical", FormatPriority(PriorityCritical))
+	assert.Equal(t, "42", FormatPriority(42))
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/docker/go-units"
+)
+
+// ProjectQuota limits the resources a project can reserve in the cluster so that the services of one project
+// can't starve the others. A zero value means no limit. The quota is enforced when the project is deployed and by
+// the machines when they create the project service containers.
+type ProjectQuota struct {
+	Project string
+	// CPU is the maximum sum of the CPU reservations (limits) of the project containers in nanocores.
+	CPU int64 `json:",omitempty"`
+	// Memory is the maximum sum of the memory reservations of the project containers in bytes.
+	Memory int64 `json:",omitempty"`
+	// Services is the maximum number of the project services.
+	Services int `json:",omitempty"`
+	// Domains is the maximum number of distinct domains the project services publish via the ingress.
+	Domains int `json:",omitempty"`
+}
+
+// ProjectUsage is the resources reserved by the services of a project.
+type ProjectUsage struct {
+	CPU      int64
+	Memory   int64
+	Services int
+	// Domains are the distinct domains published by the project services.
+	Domains []string
+}
+
+func (q *ProjectQuota) Validate() error {
+	if err := ValidateProjectName(q.Project); err != nil {
+		return err
+	}
+	if q.CPU < 0 || q.Memory < 0 || q.Services < 0 || q.Domains < 0 {
+		return errors.New("project quota must not be negative")
+	}
+	return nil
+}
+
+// IsZero returns true if the quota doesn't limit anything.
+func (q ProjectQuota) IsZero() bool {
+	return q.CPU == 0 && q.Memory == 0 && q.Services == 0 && q.Domains == 0
+}
+
+// AddService adds a service with the number of containers to the usage. The spec must be resolved so that
+// the ingress ports have their hostnames set.
+func (u *ProjectUsage) AddService(spec ServiceSpec, containers int) {
+	u.Services++
+	u.CPU += spec.Container.Resources.CPU * int64(containers)
+	u.Memory += spec.Container.Resources.MemoryReservation * int64(containers)
+	u.addDomains(spec)
+}
+
+// AddDeployedService adds a deployed service with its containers to the usage.
+func (u *ProjectUsage) AddDeployedService(s Service) {
+	u.Services++
+	for _, ctr := range s.Containers {
+		spec := ctr.Container.ServiceSpec
+		u.CPU += spec.Container.Resources.CPU
+		u.Memory += spec.Container.Resources.MemoryReservation
+		u.addDomains(spec)
+	}
+}
+
+func (u *ProjectUsage) addDomains(spec ServiceSpec) {
+	for _, p := range spec.Ports {
+		if p.Mode == PortModeIngress && p.Hostname != "" && !slices.Contains(u.Domains, p.Hostname) {
+			u.Domains = append(u.Domains, p.Hostname)
+		}
+	}
+}
+
+// Check returns an error if the usage exceeds the quota.
+func (q ProjectQuota) Check(u ProjectUsage) error {
+	if q.Services > 0 && u.Services > q.Services {
+		return fmt.Errorf("project '%s' quota exceeded: %d services (quota %d)", q.Project, u.Services, q.Services)
+	}
+	if q.CPU > 0 && u.CPU > q.CPU {
+		return fmt.Errorf("project '%s' quota exceeded: %.2f reserved CPUs (quota %.2f)",
+			q.Project, float64(u.CPU)/Core, float64(q.CPU)/Core)
+	}
+	if q.Memory > 0 && u.Memory > q.Memory {
+		return fmt.Errorf("project '%s' quota exceeded: %s reserved memory (quota %s)",
+			q.Project, units.BytesSize(float64(u.Memory)), units.BytesSize(float64(q.Memory)))
+	}
+	if q.Domains > 0 && len(u.Domains) > q.Domains {
+		return fmt.Errorf("project '%s' quota exceeded: %d published domains (quota %d)",
+			q.Project, len(u.Domains), q.Domains)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectQuota_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&ProjectQuota{Project: "shop", Services: 5}).Validate())
+	assert.Error(t, (&ProjectQuota{Project: "Shop!"}).Validate())
+	assert.ErrorContains(t, (&ProjectQuota{Project: "shop", CPU: -1}).Validate(), "negative")
+}
+
+func TestProjectQuota_Check(t *testing.T) {
+	t.Parallel()
+
+	web := ServiceSpec{
+		Name: "web",
+		Container: ContainerSpec{
+			Resources: ContainerResources{CPU: Core / 2, MemoryReservation: 256 << 20},
+		},
+		Ports: []PortSpec{
+			{Hostname: "shop.example.com", ContainerPort: 80, Protocol: ProtocolHTTPS, Mode: PortModeIngress},
+			{Hostname: "www.shop.example.com", ContainerPort: 80, Protocol: ProtocolHTTPS, Mode: PortModeIngress},
+		},
+	}
+	apiSpec := ServiceSpec{
+		Name:  "api",
+		Ports: []PortSpec{{Hostname: "shop.example.com", ContainerPort: 8080, Mode: PortModeIngress}},
+	}
+	deployed := Service{Name: "db", Containers: []MachineServiceContainer{{Container: ServiceContainer{
+		Container: Container{ContainerJSON: types.ContainerJSON{}},
+		ServiceSpec: ServiceSpec{
+			Container: ContainerSpec{Resources: ContainerResources{CPU: Core, MemoryReservation: 1 << 30}},
+		},
+	}}}}
+
+	var usage ProjectUsage
+	usage.AddService(web, 3)
+	usage.AddService(apiSpec, 1)
+	usage.AddDeployedService(deployed)
+	assert.Equal(t, 3, usage.Services)
+	assert.Equal(t, int64(5*Core/2), usage.CPU)
+	assert.Equal(t, int64(1<<30+768<<20), usage.Memory)
+	assert.Equal(t, []string{"shop.example.com", "www.shop.example.com"}, usage.Domains)
+
+	require.NoError(t, ProjectQuota{Project: "shop", CPU: 3 * Core, Memory: 2 << 30, Services: 3, Domains: 2}.
+		Check(usage))
+	assert.NoError(t, ProjectQuota{Project: "shop"}.Check(usage), "zero quota is unlimited")
+
+	err := ProjectQuota{Project: "shop", Services: 2}.Check(usage)
+	assert.EqualError(t, err, "project 'shop' quota exceeded: 3 services (quota 2)")
+	err = ProjectQuota{Project: "shop", CPU: 2 * Core}.Check(usage)
+	assert.EqualError(t, err, "project 'shop' quota exceeded: 2.50 reserved CPUs (quota 2.00)")
+	err = ProjectQuota{Project: "shop", Memory: 1 << 30}.Check(usage)
+	assert.ErrorContains(t, err, "reserved memory")
+	err = ProjectQuota{Project: "shop", Domains: 1}.Check(usage)
+	assert.EqualError(t, err, "project 'shop' quota exceeded: 2 published domains (quota 1)")
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RedactedValue replaces the values that may contain credentials in the responses for read-only clients.
+const RedactedValue = "[redacted]"
+
+// Redacted returns a copy of the service spec with the values of the environment variables and the contents
+// of the configs replaced with RedactedValue.
+func (s ServiceSpec) Redacted() ServiceSpec {
+	if s.Container.Env != nil {
+		env := maps.Clone(s.Container.Env)
+		for k := range env {
+			env[k] = RedactedValue
+		}
+		s.Container.Env = env
+	}
+	s.Configs = slices.Clone(s.Configs)
+	for i := range s.Configs {
+		if s.Configs[i].Content != nil {
+			s.Configs[i].Content = []byte(RedactedValue)
+		}
+	}
+	return s
+}
+
+// Redacted returns a copy of the container with the values of the environment variables in its config replaced
+// with RedactedValue.
+func (c Container) Redacted() Container {
+	if c.Config == nil {
+		return c
+	}
+	config := *c.Config
+	config.Env = make([]string, len(c.Config.Env))
+	for i, kv := range c.Config.Env {
+		k, _, _ := strings.Cut(kv, "=")
+		config.Env[i] = k + "=" + RedactedValue
+	}
+	c.Config = &config
+	return c
+}
+
+// Redacted returns a copy of the service container with its config and service spec redacted.
+func (c ServiceContainer) Redacted() ServiceContainer {
+	c.Container = c.Container.Redacted()
+	c.ServiceSpec = c.ServiceSpec.Redacted()
+	return c
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceContainer_Redacted(t *testing.T) {
+	t.Parallel()
+
+	ctr := ServiceContainer{
+		Container: Container{ContainerJSON: types.ContainerJSON{
+			Config: &container.Config{Env: []string{"PASSWORD=secret", "EMPTY"}},
+		}},
+		ServiceSpec: ServiceSpec{
+			Name:      "db",
+			Container: ContainerSpec{Env: EnvVars{"PASSWORD": "secret"}},
+			Configs:   []ConfigSpec{{Name: "conf", Content: []byte("token: secret")}},
+		},
+	}
+
+	redacted := ctr.Redacted()
+	assert.Equal(t, []string{"PASSWORD=[redacted]", "EMPTY=[redacted]"}, redacted.Config.Env)
+	assert.Equal(t, EnvVars{"PASSWORD": RedactedValue}, redacted.ServiceSpec.Container.Env)
+	assert.Equal(t, []byte(RedactedValue), redacted.ServiceSpec.Configs[0].Content)
+	assert.Equal(t, "db", redacted.ServiceSpec.Name)
+
+	// The original container must not be changed.
+	assert.Equal(t, []string{"PASSWORD=secret", "EMPTY"}, ctr.Config.Env)
+	assert.Equal(t, "secret", ctr.ServiceSpec.Container.Env["PASSWORD"])
+	assert.Equal(t, []byte("token: secret"), ctr.ServiceSpec.Configs[0].Content)
+}
@@ -11,6 +11,7 @@ type ContainerResources struct {
 	// Memory is the maximum amount of memory (in bytes) the container can use.
 	Memory int64
 	// MemoryReservation is the minimum amount of memory (in bytes) the container needs to run efficiently.
-	// TODO: implement a placement constraint that checks available memory on machines.
+	// The container is only scheduled on machines with at least this amount of total memory.
+	// TODO: check the available memory on machines taking into account the reservations of other containers.
 	MemoryReservation int64
 }
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// RestartPolicyNo never restarts a container when it exits.
+	RestartPolicyNo = "no"
+	// RestartPolicyAlways always restarts a container when it exits, including after it's explicitly stopped
+	// and the machine or Docker daemon restarts.
+	RestartPolicyAlways = "always"
+	// RestartPolicyOnFailure restarts a container only when it exits with a non-zero exit code.
+	RestartPolicyOnFailure = "on-failure"
+	// RestartPolicyUnlessStopped always restarts a container when it exits unless it's explicitly stopped.
+	RestartPolicyUnlessStopped = "unless-stopped"
+)
+
+// RestartPolicy defines when the Docker daemon restarts a container after it exits. Crash-looping containers are
+// restarted with an exponential backoff delay that starts at 100ms and doubles after each restart up to 1 minute.
+// The delay is reset once a container has been running for at least 10 seconds.
+type RestartPolicy struct {
+	// Name is the restart policy: RestartPolicyNo, RestartPolicyAlways, RestartPolicyOnFailure,
+	// or RestartPolicyUnlessStopped.
+	Name string
+	// MaxRetries is the maximum number of restart attempts for the RestartPolicyOnFailure policy.
+	// Zero means unlimited.
+	MaxRetries uint `json:",omitempty"`
+}
+
+// ParseRestartPolicy parses a restart policy in the format no|always|unless-stopped|on-failure[:max-retries].
+func ParseRestartPolicy(s string) (RestartPolicy, error) {
+	name, retries, hasRetries := strings.Cut(s, ":")
+	policy := RestartPolicy{Name: name}
+	if hasRetries {
+		if name != RestartPolicyOnFailure {
+			return policy, fmt.Errorf("maximum retry count can only be used with the '%s' restart policy: %q",
+				RestartPolicyOnFailure, s)
+		}
+		maxRetries, err := strconv.ParseUint(retries, 10, 32)
+		if err != nil {
+			return policy, fmt.Errorf("invalid maximum retry count in restart policy %q: %w", s, err)
+		}
+		policy.MaxRetries = uint(maxRetries)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return policy, err
+	}
+	return policy, nil
+}
+
+func (p *RestartPolicy) Validate() error {
+	switch p.Name {
+	case RestartPolicyNo, RestartPolicyAlways, RestartPolicyUnlessStopped:
+		if p.MaxRetries > 0 {
+			return fmt.Errorf("maximum retry count can only be used with the '%s' restart policy",
+				RestartPolicyOnFailure)
+		}
+	case RestartPolicyOnFailure:
+	default:
+		return fmt.Errorf("invalid restart policy: %q", p.Name)
+	}
+	return nil
+}
+
+// String returns the restart policy in the format accepted by ParseRestartPolicy.
+func (p *RestartPolicy) String() string {
+	if p.Name == RestartPolicyOnFailure && p.MaxRetries > 0 {
+		return fmt.Sprintf("%s:%d", p.Name, p.MaxRetries)
+	}
+	return p.Name
+}
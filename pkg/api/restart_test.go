@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    RestartPolicy
+		wantErr string
+	}{
+		{input: "no", want: RestartPolicy{Name: RestartPolicyNo}},
+		{input: "always", want: RestartPolicy{Name: RestartPolicyAlways}},
+		{input: "unless-stopped", want: RestartPolicy{Name: RestartPolicyUnlessStopped}},
+		{input: "on-failure", want: RestartPolicy{Name: RestartPolicyOnFailure}},
+		{input: "on-failure:5", want: RestartPolicy{Name: RestartPolicyOnFailure, MaxRetries: 5}},
+		{input: "", wantErr: "invalid restart policy"},
+		{input: "never", wantErr: "invalid restart policy"},
+		{input: "always:3", wantErr: "maximum retry count can only be used"},
+		{input: "on-failure:-1", wantErr: "invalid maximum retry count"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := ParseRestartPolicy(tt.input)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+			assert.Equal(t, tt.input, policy.String())
+		})
+	}
+}
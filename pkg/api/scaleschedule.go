@@ -0,0 +1,174 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ScaleSchedule scales a replicated service to a different number of replicas during recurring time windows, e.g.
+// 6 replicas on weekdays from 08:00 to 20:00 and 2 otherwise. It suits predictable traffic patterns where metric-based
+// autoscaling is overkill. The leader machine of the cluster redeploys the service when the active window changes.
+type ScaleSchedule struct {
+	// Timezone is the IANA name of the time zone the windows are evaluated in, e.g. Europe/Berlin. Default is UTC.
+	Timezone string `json:",omitempty"`
+	// Replicas is the number of replicas outside all windows.
+	Replicas uint
+	// Windows are the recurring time windows with their number of replicas. The first window that contains
+	// the current time wins if the windows overlap.
+	Windows []ScaleWindow
+}
+
+// ScaleWindow is a recurring time window in which a service runs a specific number of replicas.
+type ScaleWindow struct {
+	// Window is the recurring window in the format "[DAYS ]HH:MM-HH:MM", where DAYS is an optional comma-separated
+	// list of weekdays or weekday ranges, e.g. "Mon-Fri 08:00-20:00", "Sat,Sun 22:00-02:00", or "12:00-14:00"
+	// for every day. A window can span midnight.
+	Window   string
+	Replicas uint
+}
+
+var scheduleWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// timeWindow is a parsed ScaleWindow.Window.
+type timeWindow struct {
+	// days are the weekdays the window starts on. Every day if empty.
+	days []time.Weekday
+	// start is the offset of the window start from midnight.
+	start time.Duration
+	// duration is the length of the window.
+	duration time.Duration
+}
+
+func (s *ScaleSchedule) Validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone '%s': %w", s.Timezone, err)
+	}
+	if s.Replicas == 0 {
+		return errors.New("replicas outside the scale windows must be greater than zero")
+	}
+	if len(s.Windows) == 0 {
+		return errors.New("at least one scale window must be specified")
+	}
+	for _, w := range s.Windows {
+		if _, err := parseTimeWindow(w.Window); err != nil {
+			return err
+		}
+		if w.Replicas == 0 {
+			return fmt.Errorf("replicas of scale window '%s' must be greater than zero", w.Window)
+		}
+	}
+	return nil
+}
+
+// ReplicasAt returns the number of replicas the schedule sets at the given time. The schedule must be valid.
+func (s *ScaleSchedule) ReplicasAt(t time.Time) uint {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	for _, w := range s.Windows {
+		tw, err := parseTimeWindow(w.Window)
+		if err != nil {
+			continue
+		}
+		if tw.contains(t) {
+			return w.Replicas
+		}
+	}
+	return s.Replicas
+}
+
+func (s *ScaleSchedule) Clone() *ScaleSchedule {
+	if s == nil {
+		return nil
+	}
+	schedule := *s
+	schedule.Windows = slices.Clone(s.Windows)
+	return &schedule
+}
+
+func parseTimeWindow(s string) (timeWindow, error) {
+	var w timeWindow
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return w, fmt.Errorf("invalid scale window '%s': expected format '[DAYS ]HH:MM-HH:MM', "+
+			"e.g. 'Mon-Fri 08:00-20:00'", s)
+	}
+
+	if len(fields) == 2 {
+		for _, d := range strings.Split(fields[0], ",") {
+			days, err := parseWeekdays(d)
+			if err != nil {
+				return w, fmt.Errorf("invalid scale window '%s': %w", s, err)
+			}
+			w.days = append(w.days, days...)
+		}
+	}
+
+	startStr, endStr, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return w, fmt.Errorf("invalid scale window '%s': expected time range in the format 'HH:MM-HH:MM'", s)
+	}
+	start, err := time.Parse("15:04", startStr)
+	if err != nil {
+		return w, fmt.Errorf("invalid scale window '%s': invalid time '%s', expected HH:MM", s, startStr)
+	}
+	end, err := time.Parse("15:04", endStr)
+	if err != nil {
+		return w, fmt.Errorf("invalid scale window '%s': invalid time '%s', expected HH:MM", s, endStr)
+	}
+	if start.Equal(end) {
+		return w, fmt.Errorf("invalid scale window '%s': start and end times must differ", s)
+	}
+
+	w.start = time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+	w.duration = end.Sub(start)
+	if w.duration < 0 {
+		w.duration += 24 * time.Hour
+	}
+	return w, nil
+}
+
+// parseWeekdays parses a weekday, e.g. Mon, or a range of weekdays, e.g. Mon-Fri or Fri-Mon.
+func parseWeekdays(s string) ([]time.Weekday, error) {
+	first, last, isRange := strings.Cut(strings.ToLower(s), "-")
+	if !isRange {
+		last = first
+	}
+	from, to := slices.Index(scheduleWeekdays, first), slices.Index(scheduleWeekdays, last)
+	if from == -1 || to == -1 {
+		return nil, fmt.Errorf("unknown day '%s', expected one of: Mon, Tue, Wed, Thu, Fri, Sat, Sun "+
+			"or a range, e.g. Mon-Fri", s)
+	}
+
+	var days []time.Weekday
+	for d := from; ; d = (d + 1) % 7 {
+		days = append(days, time.Weekday(d))
+		if d == to {
+			break
+		}
+	}
+	return days, nil
+}
+
+// contains returns true if t is within an occurrence of the window in the location of t.
+func (w timeWindow) contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	// Check the occurrences starting today and yesterday as a window can span midnight.
+	for _, day := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		if len(w.days) > 0 && !slices.Contains(w.days, day.Weekday()) {
+			continue
+		}
+		// Use the wall clock time of the window start to account for daylight saving time transitions.
+		start := time.Date(day.Year(), day.Month(), day.Day(),
+			int(w.start/time.Hour), int(w.start%time.Hour/time.Minute), 0, 0, t.Location())
+		if !t.Before(start) && t.Before(start.Add(w.duration)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaleSchedule_ReplicasAt(t *testing.T) {
+	t.Parallel()
+
+	s := ScaleSchedule{
+		Timezone: "Europe/Berlin",
+		Replicas: 2,
+		Windows: []ScaleWindow{
+			{Window: "Mon-Fri 08:00-20:00", Replicas: 6},
+			{Window: "Fri-Sat 22:00-02:00", Replicas: 4},
+		},
+	}
+	require.NoError(t, s.Validate())
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	tests := []struct {
+		name string
+		time time.Time
+		want uint
+	}{
+		{"weekday in window", time.Date(2025, 6, 2, 8, 0, 0, 0, berlin), 6},
+		{"weekday after window", time.Date(2025, 6, 2, 20, 0, 0, 0, berlin), 2},
+		{"weekday in window in UTC", time.Date(2025, 6, 4, 17, 30, 0, 0, time.UTC), 6},
+		{"weekday outside window in UTC", time.Date(2025, 6, 4, 18, 30, 0, 0, time.UTC), 2},
+		{"weekend", time.Date(2025, 6, 8, 12, 0, 0, 0, berlin), 2},
+		{"window spanning midnight", time.Date(2025, 6, 7, 1, 0, 0, 0, berlin), 4},
+		{"window spanning midnight not started", time.Date(2025, 6, 5, 1, 0, 0, 0, berlin), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, s.ReplicasAt(tt.time))
+		})
+	}
+}
+
+func TestScaleSchedule_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule ScaleSchedule
+		wantErr  string
+	}{
+		{
+			name:     "invalid timezone",
+			schedule: ScaleSchedule{Timezone: "Mars/Olympus", Replicas: 1, Windows: []ScaleWindow{{"09:00-17:00", 2}}},
+			wantErr:  "invalid timezone",
+		},
+		{
+			name:     "zero replicas",
+			schedule: ScaleSchedule{Windows: []ScaleWindow{{"09:00-17:00", 2}}},
+			wantErr:  "must be greater than zero",
+		},
+		{
+			name:     "no windows",
+			schedule: ScaleSchedule{Replicas: 1},
+			wantErr:  "at least one scale window",
+		},
+		{
+			name:     "invalid time",
+			schedule: ScaleSchedule{Replicas: 1, Windows: []ScaleWindow{{"09:00-25:00", 2}}},
+			wantErr:  "invalid time '25:00'",
+		},
+		{
+			name:     "empty window",
+			schedule: ScaleSchedule{Replicas: 1, Windows: []ScaleWindow{{"Mon 09:00-09:00", 2}}},
+			wantErr:  "start and end times must differ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.ErrorContains(t, tt.schedule.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ImageScannerTrivy scans images with Trivy (https://trivy.dev).
+	ImageScannerTrivy = "trivy"
+	// ImageScannerGrype scans images with Grype (https://github.com/anchore/grype).
+	ImageScannerGrype = "grype"
+)
+
+// ImageScanners are the supported vulnerability scanners.
+var ImageScanners = []string{ImageScannerTrivy, ImageScannerGrype}
+
+// VulnerabilitySeverity is the severity of a vulnerability found in an image.
+type VulnerabilitySeverity string
+
+const (
+	SeverityUnknown  VulnerabilitySeverity = "UNKNOWN"
+	SeverityLow      VulnerabilitySeverity = "LOW"
+	SeverityMedium   VulnerabilitySeverity = "MEDIUM"
+	SeverityHigh     VulnerabilitySeverity = "HIGH"
+	SeverityCritical VulnerabilitySeverity = "CRITICAL"
+)
+
+// Severities are the known vulnerability severities from the highest to the lowest.
+var Severities = []VulnerabilitySeverity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityUnknown}
+
+// severityRanks orders the severities from the lowest to the highest.
+var severityRanks = map[VulnerabilitySeverity]int{
+	SeverityUnknown:  0,
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// ParseVulnerabilitySeverity parses a case-insensitive severity name, e.g. "critical" or "High".
+func ParseVulnerabilitySeverity(s string) (VulnerabilitySeverity, error) {
+	severity := VulnerabilitySeverity(strings.ToUpper(s))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("invalid severity '%s', must be one of: critical, high, medium, low, unknown", s)
+	}
+	return severity, nil
+}
+
+// AtLeast returns true if the severity is the same as or higher than the other one.
+func (s VulnerabilitySeverity) AtLeast(other VulnerabilitySeverity) bool {
+	return severityRanks[s] >= severityRanks[other]
+}
+
+// Vulnerability is a known vulnerability of a package found in an image.
+type Vulnerability struct {
+	// ID is the vulnerability identifier, e.g. CVE-2021-44228.
+	ID               string
+	Severity         VulnerabilitySeverity
+	Package          string
+	InstalledVersion string
+	// FixedVersion is the package version that fixes the vulnerability. Empty if there is no fix yet.
+	FixedVersion string `json:",omitempty"`
+	Title        string `json:",omitempty"`
+}
+
+// ImageScanReport is the result of scanning an image for vulnerabilities.
+type ImageScanReport struct {
+	Image string
+	// Scanner is the name of the scanner that produced the report.
+	Scanner         string
+	Vulnerabilities []Vulnerability
+}
+
+// CountBySeverity returns the number of vulnerabilities found for each severity.
+func (r *ImageScanReport) CountBySeverity() map[VulnerabilitySeverity]int {
+	counts := make(map[VulnerabilitySeverity]int)
+	for _, v := range r.Vulnerabilities {
+		counts[v.Severity]++
+	}
+	return counts
+}
+
+// Summary returns a human-readable summary of the number of vulnerabilities by severity, e.g. "1 critical, 3 high".
+func (r *ImageScanReport) Summary() string {
+	if len(r.Vulnerabilities) == 0 {
+		return "no vulnerabilities"
+	}
+
+	counts := r.CountBySeverity()
+	var parts []string
+	for _, s := range Severities {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], strings.ToLower(string(s))))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DeviceMapping represents a host device exposed to the container.
+type DeviceMapping struct {
+	// HostPath is the path to the device on the host, e.g. /dev/ttyUSB0.
+	HostPath string
+	// ContainerPath is the path where the device is exposed in the container. Defaults to HostPath if empty.
+	ContainerPath string `json:",omitempty"`
+	// CgroupPermissions is a combination of r (read), w (write), and m (mknod) permissions. Default is "rwm".
+	CgroupPermissions string `json:",omitempty"`
+}
+
+// ParseDeviceMapping parses a device mapping in the format host_path[:container_path[:permissions]].
+func ParseDeviceMapping(s string) (DeviceMapping, error) {
+	var d DeviceMapping
+	parts := strings.Split(s, ":")
+	switch len(parts) {
+	case 3:
+		d.CgroupPermissions = parts[2]
+		fallthrough
+	case 2:
+		if len(parts) == 2 && validDevicePermissions(parts[1]) {
+			// host_path:permissions
+			d.CgroupPermissions = parts[1]
+		} else {
+			d.ContainerPath = parts[1]
+		}
+		fallthrough
+	case 1:
+		d.HostPath = parts[0]
+	default:
+		return d, fmt.Errorf("invalid device mapping '%s'", s)
+	}
+
+	return d, d.Validate()
+}
+
+func (d *DeviceMapping) Validate() error {
+	if !filepath.IsAbs(d.HostPath) {
+		return fmt.Errorf("device host path must be absolute: '%s'", d.HostPath)
+	}
+	if d.ContainerPath != "" && !filepath.IsAbs(d.ContainerPath) {
+		return fmt.Errorf("device container path must be absolute: '%s'", d.ContainerPath)
+	}
+	if d.CgroupPermissions != "" && !validDevicePermissions(d.CgroupPermissions) {
+		return fmt.Errorf("invalid device permissions '%s': must be a combination of 'r', 'w', and 'm'",
+			d.CgroupPermissions)
+	}
+	return nil
+}
+
+// String returns the device mapping in the format host_path:container_path:permissions.
+func (d DeviceMapping) String() string {
+	containerPath := d.ContainerPath
+	if containerPath == "" {
+		containerPath = d.HostPath
+	}
+	perms := d.CgroupPermissions
+	if perms == "" {
+		perms = "rwm"
+	}
+	return d.HostPath + ":" + containerPath + ":" + perms
+}
+
+func validDevicePermissions(perms string) bool {
+	if perms == "" || len(perms) > 3 {
+		return false
+	}
+	for _, c := range perms {
+		if c != 'r' && c != 'w' && c != 'm' {
+			return false
+		}
+	}
+	return true
+}
+
+// validateSecurityOpt validates a security option in the format accepted by Docker, e.g. "no-new-privileges",
+// "seccomp=unconfined", "apparmor=my-profile", "label=disable".
+func validateSecurityOpt(opt string) error {
+	if opt == "no-new-privileges" {
+		return nil
+	}
+
+	key, value, ok := strings.Cut(opt, "=")
+	if !ok {
+		key, value, ok = strings.Cut(opt, ":")
+	}
+	if !ok || value == "" {
+		return fmt.Errorf("invalid security option '%s': expected key=value format", opt)
+	}
+	switch key {
+	case "seccomp", "apparmor", "label", "no-new-privileges", "systempaths":
+		return nil
+	default:
+		return fmt.Errorf("invalid security option '%s': unsupported key '%s'", opt, key)
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeviceMapping(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    DeviceMapping
+		wantErr string
+	}{
+		{
+			input: "/dev/ttyUSB0",
+			want:  DeviceMapping{HostPath: "/dev/ttyUSB0"},
+		},
+		{
+			input: "/dev/ttyUSB0:/dev/ttyUSB1",
+			want:  DeviceMapping{HostPath: "/dev/ttyUSB0", ContainerPath: "/dev/ttyUSB1"},
+		},
+		{
+			input: "/dev/ttyUSB0:rw",
+			want:  DeviceMapping{HostPath: "/dev/ttyUSB0", CgroupPermissions: "rw"},
+		},
+		{
+			input: "/dev/ttyUSB0:/dev/ttyUSB1:r",
+			want:  DeviceMapping{HostPath: "/dev/ttyUSB0", ContainerPath: "/dev/ttyUSB1", CgroupPermissions: "r"},
+		},
+		{
+			input:   "ttyUSB0",
+			wantErr: "device host path must be absolute",
+		},
+		{
+			input:   "/dev/ttyUSB0:ttyUSB1",
+			wantErr: "device container path must be absolute",
+		},
+		{
+			input:   "/dev/ttyUSB0:/dev/ttyUSB1:rx",
+			wantErr: "invalid device permissions",
+		},
+		{
+			input:   "/dev/a:/dev/b:r:w",
+			wantErr: "invalid device mapping",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseDeviceMapping(tt.input)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeviceMapping_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/dev/fuse:/dev/fuse:rwm", DeviceMapping{HostPath: "/dev/fuse"}.String())
+	assert.Equal(t, "/dev/a:/dev/b:r", DeviceMapping{
+		HostPath:          "/dev/a",
+		ContainerPath:     "/dev/b",
+		CgroupPermissions: "r",
+	}.String())
+}
+
+func TestValidateSecurityOpt(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{
+		"no-new-privileges",
+		"no-new-privileges=true",
+		"no-new-privileges:true",
+		"seccomp=unconfined",
+		"apparmor=my-profile",
+		"label=disable",
+		"systempaths=unconfined",
+	}
+	for _, opt := range valid {
+		assert.NoError(t, validateSecurityOpt(opt), opt)
+	}
+
+	invalid := []string{
+		"",
+		"seccomp",
+		"seccomp=",
+		"unknown=value",
+	}
+	for _, opt := range invalid {
+		assert.Error(t, validateSecurityOpt(opt), opt)
+	}
+}
@@ -1,14 +1,19 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"maps"
+	"path"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
@@ -34,30 +39,86 @@ const (
 var (
 	serviceIDRegexp = regexp.MustCompile("^[0-9a-f]{32}$")
 	dnsLabelRegexp  = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	// projectNameRegexp matches a valid Compose project name.
+	projectNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	// stopSignalRegexp matches a signal number or name, e.g. 15, SIGTERM, TERM, or SIGRTMIN+3.
+	stopSignalRegexp = regexp.MustCompile(`^([0-9]+|(SIG)?[A-Z][A-Z0-9]*([+-][0-9]+)?)$`)
 )
 
 func ValidateServiceID(id string) bool {
 	return serviceIDRegexp.MatchString(id)
 }
 
+// ValidateDNSName checks that the name is a valid lowercase DNS name without the trailing dot, e.g. "db" or
+// "db.internal.company".
+func ValidateDNSName(name string) error {
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("invalid DNS name: %q. must be 1-253 characters", name)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > 63 || !dnsLabelRegexp.MatchString(label) {
+			return fmt.Errorf("invalid DNS name: %q. each dot-separated label must be 1-63 characters, "+
+				"lowercase letters, numbers, and dashes only; must start and end with a letter or number", name)
+		}
+	}
+	return nil
+}
+
 // ServiceSpec defines the desired state of a service.
 // ATTENTION: after changing this struct, verify if deploy.EvalContainerSpecChange needs to be updated.
 type ServiceSpec struct {
+	// Aliases are the extra DNS names resolved by the cluster DNS to the service containers, e.g. a short name like
+	// "database" resolved as database.internal or a name outside the internal domain like "db.internal.company".
+	Aliases []string `json:",omitempty"`
+	// Backup enables scheduled backups of the database running in the service containers.
+	Backup *BackupSpec `json:",omitempty"`
 	// Caddy is the optional Caddy reverse proxy configuration for the service.
 	// Caddy and Ports cannot be specified simultaneously.
 	Caddy *CaddySpec `json:",omitempty"`
 	// Container defines the desired state of each container in the service.
 	Container ContainerSpec
+	// Macvlan configures the macvlan network for NetworkModeMacvlan. Required for and only valid in this mode.
+	Macvlan *MacvlanOptions `json:",omitempty"`
 	// Mode is the replication mode of the service. Default is ServiceModeReplicated if empty.
 	Mode string
 	Name string
+	// NetworkMode is the networking mode of the service containers: NetworkModeHost or NetworkModeMacvlan.
+	// Default is the cluster network if empty. Containers in other modes can't be discovered by other services
+	// or exposed via ingress, and at most one container runs on each machine.
+	NetworkMode string `json:",omitempty"`
+	// Networks is a list of networks the service containers are attached to. Containers can only discover
+	// (resolve via the internal DNS) and reach services that share at least one network with them.
+	// Default is a single DefaultNetwork if empty.
+	Networks []string `json:",omitempty"`
+	// Owner is the team or client the service belongs to, e.g. to attribute the cost of the service.
+	Owner string `json:",omitempty"`
 	// Placement defines the placement constraints for the service.
 	Placement Placement
 	// Ports defines what service ports to publish to make the service accessible outside the cluster.
 	// Caddy and Ports cannot be specified simultaneously.
 	Ports []PortSpec
+	// Priority is the scheduling priority of the service, e.g. PriorityHigh. When a machine doesn't have enough
+	// unreserved CPU or memory for a service container, the containers of lower priority services are stopped
+	// to make room for it. Default is PriorityNormal.
+	Priority int `json:",omitempty"`
+	// Protected prevents the service from being removed accidentally. Removing a protected service requires
+	// an explicit --force-unprotect flag and typing the service name to confirm.
+	Protected bool `json:",omitempty"`
+	// Project is the name of the project, e.g. a Compose project, the service belongs to. The services of a project
+	// can be listed and removed together and resolved as <service>.<project>.internal by the internal DNS if
+	// the project name is a valid DNS label.
+	Project string `json:",omitempty"`
 	// Replicas is the number of containers to run for the service. Only valid for a replicated service.
 	Replicas uint `json:",omitempty"`
+	// ScaleSchedule sets the number of replicas of a replicated service depending on the time of day and week.
+	// It overrides Replicas when set.
+	ScaleSchedule *ScaleSchedule `json:",omitempty"`
+	// Tenant is the name of the tenant the service belongs to on a cluster shared by multiple customers. The service
+	// is isolated from the services of other tenants and its containers count towards the tenant quota.
+	Tenant string `json:",omitempty"`
+	// UpdateConfig defines how the service containers are updated by a rolling deployment. Containers are updated
+	// one at a time starting a new container before removing the old one if nil.
+	UpdateConfig *UpdateConfig `json:",omitempty"`
 	// Volumes is list of data volumes that can be mounted into the container.
 	Volumes []VolumeSpec
 	// Configs is list of configuration objects that can be mounted into the container.
@@ -113,6 +174,10 @@ func (s *ServiceSpec) SetDefaults() ServiceSpec {
 		spec.Replicas = 1
 	}
 	spec.Container = spec.Container.SetDefaults()
+	if spec.Backup != nil {
+		backup := spec.Backup.SetDefaults()
+		spec.Backup = &backup
+	}
 
 	for i, v := range spec.Volumes {
 		spec.Volumes[i] = v.SetDefaults()
@@ -121,6 +186,15 @@ func (s *ServiceSpec) SetDefaults() ServiceSpec {
 	return spec
 }
 
+// ValidateProjectName checks that the name is a valid Compose project name.
+func ValidateProjectName(name string) error {
+	if !projectNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid project name: %q. must contain only lowercase letters, numbers, dashes, "+
+			"and underscores; must start with a letter or number", name)
+	}
+	return nil
+}
+
 func (s *ServiceSpec) Validate() error {
 	if err := s.Container.Validate(); err != nil {
 		return err
@@ -142,6 +216,27 @@ func (s *ServiceSpec) Validate() error {
 		}
 	}
 
+	if s.Project != "" {
+		if err := ValidateProjectName(s.Project); err != nil {
+			return err
+		}
+	}
+	if err := s.validateTenant(); err != nil {
+		return err
+	}
+
+	for _, alias := range s.Aliases {
+		if err := ValidateDNSName(alias); err != nil {
+			return fmt.Errorf("invalid alias: %w", err)
+		}
+	}
+	if err := validateNetworks(s.Networks); err != nil {
+		return err
+	}
+	if err := s.validateNetworkMode(); err != nil {
+		return err
+	}
+
 	for _, p := range s.Ports {
 		if (p.Mode == "" || p.Mode == PortModeIngress) &&
 			p.Protocol != ProtocolHTTP && p.Protocol != ProtocolHTTPS {
@@ -151,6 +246,25 @@ func (s *ServiceSpec) Validate() error {
 
 	// TODO: validate there is no conflict between ports.
 
+	if s.UpdateConfig != nil {
+		if err := s.UpdateConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.Backup != nil {
+		if err := s.Backup.Validate(); err != nil {
+			return fmt.Errorf("invalid backup: %w", err)
+		}
+	}
+	if s.ScaleSchedule != nil {
+		if s.Mode != "" && s.Mode != ServiceModeReplicated {
+			return fmt.Errorf("scale schedule is only supported for services in %s mode", ServiceModeReplicated)
+		}
+		if err := s.ScaleSchedule.Validate(); err != nil {
+			return fmt.Errorf("invalid scale schedule: %w", err)
+		}
+	}
+
 	// Validate that Caddy and Ports are not used together, unless all ports are host mode.
 	if s.Caddy != nil && strings.TrimSpace(s.Caddy.Config) != "" && len(s.Ports) > 0 {
 		// Check if all ports are in host mode.
@@ -197,14 +311,56 @@ func (s *ServiceSpec) Validate() error {
 	return nil
 }
 
+// Hash returns the hex-encoded SHA-256 hash of the spec with the defaults set.
+func (s *ServiceSpec) Hash() (string, error) {
+	spec := s.SetDefaults()
+	// The JSON encoding is deterministic as the map keys are sorted.
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("marshal service spec: %w", err)
+	}
+	hash := sha256.Sum256(specJSON)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// WithMetadata returns a copy of the spec with the metadata of the other spec. The metadata doesn't affect
+// the containers as it's only read from the stored spec, e.g. by the cluster DNS or the backup agent, so it can be
+// updated without recreating the containers.
+func (s *ServiceSpec) WithMetadata(other ServiceSpec) ServiceSpec {
+	spec := s.Clone()
+	other = other.Clone()
+
+	spec.Aliases = other.Aliases
+	spec.Backup = other.Backup
+	spec.Owner = other.Owner
+	spec.Priority = other.Priority
+	spec.Project = other.Project
+	spec.Protected = other.Protected
+	spec.ScaleSchedule = other.ScaleSchedule
+
+	return spec
+}
+
 func (s *ServiceSpec) Clone() ServiceSpec {
 	spec := *s
 
+	if s.Backup != nil {
+		backupCopy := *s.Backup
+		spec.Backup = &backupCopy
+	}
 	if s.Caddy != nil {
 		caddyCopy := *s.Caddy
 		spec.Caddy = &caddyCopy
 	}
 	spec.Container = s.Container.Clone()
+	spec.Aliases = slices.Clone(s.Aliases)
+	spec.Networks = slices.Clone(s.Networks)
+	if s.Macvlan != nil {
+		macvlanCopy := *s.Macvlan
+		spec.Macvlan = &macvlanCopy
+	}
+	spec.ScaleSchedule = s.ScaleSchedule.Clone()
+	spec.UpdateConfig = s.UpdateConfig.Clone()
 
 	if s.Ports != nil {
 		spec.Ports = make([]PortSpec, len(s.Ports))
@@ -224,10 +380,19 @@ func (s *ServiceSpec) Clone() ServiceSpec {
 // ContainerSpec defines the desired state of a container in a service.
 // ATTENTION: after changing this struct, verify if deploy.EvalContainerSpecChange needs to be updated.
 type ContainerSpec struct {
+	// CapAdd is a list of kernel capabilities to add to the container, e.g. NET_ADMIN.
+	CapAdd []string `json:",omitempty"`
+	// CapDrop is a list of kernel capabilities to drop from the container, e.g. ALL.
+	CapDrop []string `json:",omitempty"`
 	// Command overrides the default CMD of the image to be executed when running a container.
 	Command []string
+	// Devices is a list of host devices to expose to the container.
+	Devices []DeviceMapping `json:",omitempty"`
 	// Entrypoint overrides the default ENTRYPOINT of the image.
 	Entrypoint []string
+	// Hostname overrides the hostname of the containers which is the container name by default. It's also resolved
+	// by the cluster DNS to the containers with this hostname.
+	Hostname string `json:",omitempty"`
 	// Env defines the environment variables to set inside the container.
 	Env   EnvVars
 	Image string
@@ -240,8 +405,24 @@ type ContainerSpec struct {
 	// PullPolicy determines when to pull the image from the registry or use the image already available in the cluster.
 	// Default is PullPolicyMissing if empty.
 	PullPolicy string
+	// ReadOnly mounts the container's root filesystem as read-only.
+	ReadOnly bool `json:",omitempty"`
 	// Resource allocation for the container.
 	Resources ContainerResources
+	// RestartPolicy defines when the container is restarted after it exits. Default is RestartPolicyUnlessStopped
+	// if nil.
+	RestartPolicy *RestartPolicy `json:",omitempty"`
+	// SecurityOpt is a list of security options for the container such as seccomp and AppArmor profiles,
+	// e.g. "seccomp=unconfined", "apparmor=my-profile", or "no-new-privileges".
+	SecurityOpt []string `json:",omitempty"`
+	// StopGracePeriod is the time to wait for the container to exit after sending the StopSignal before killing it
+	// with SIGKILL. Default is 10 seconds (Docker default) if nil.
+	StopGracePeriod *time.Duration `json:",omitempty"`
+	// StopSignal is the signal sent to the container to stop it, e.g. SIGINT or 2. Default is the STOPSIGNAL of
+	// the image or SIGTERM if empty.
+	StopSignal string `json:",omitempty"`
+	// Sysctls sets namespaced kernel parameters in the container, e.g. net.ipv4.ip_forward=1.
+	Sysctls map[string]string `json:",omitempty"`
 	// User overrides the default user of the image used to run the container. Format: user|UID[:group|GID].
 	User string
 	// VolumeMounts specifies how volumes are mounted into the container filesystem.
@@ -267,6 +448,9 @@ func (s *ContainerSpec) SetDefaults() ContainerSpec {
 	if spec.PullPolicy == "" {
 		spec.PullPolicy = PullPolicyMissing
 	}
+	if spec.RestartPolicy == nil {
+		spec.RestartPolicy = &RestartPolicy{Name: RestartPolicyUnlessStopped}
+	}
 
 	return spec
 }
@@ -282,15 +466,64 @@ func (s *ContainerSpec) Validate() error {
 		}
 	}
 
+	for _, d := range s.Devices {
+		if err := d.Validate(); err != nil {
+			return fmt.Errorf("invalid device: %w", err)
+		}
+	}
+	if s.Hostname != "" {
+		// Linux limits the hostname to 64 characters.
+		if err := ValidateDNSName(s.Hostname); err != nil || len(s.Hostname) > 63 {
+			return fmt.Errorf("invalid hostname: %q. must be a valid DNS name up to 63 characters", s.Hostname)
+		}
+	}
+	for _, opt := range s.SecurityOpt {
+		if err := validateSecurityOpt(opt); err != nil {
+			return err
+		}
+	}
+	for k := range s.Sysctls {
+		if k == "" {
+			return fmt.Errorf("sysctl name cannot be empty")
+		}
+	}
+	if s.RestartPolicy != nil {
+		if err := s.RestartPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.StopGracePeriod != nil && *s.StopGracePeriod < 0 {
+		return fmt.Errorf("stop grace period cannot be negative: %s", *s.StopGracePeriod)
+	}
+	if s.StopSignal != "" && !stopSignalRegexp.MatchString(s.StopSignal) {
+		return fmt.Errorf("invalid stop signal: %q", s.StopSignal)
+	}
+
 	return nil
 }
 
+// StopTimeoutSeconds returns the stop grace period rounded up to whole seconds as expected by Docker
+// or nil if it's not set.
+func (s *ContainerSpec) StopTimeoutSeconds() *int {
+	if s.StopGracePeriod == nil {
+		return nil
+	}
+	seconds := int((*s.StopGracePeriod + time.Second - 1) / time.Second)
+	return &seconds
+}
+
 func (s *ContainerSpec) Equals(spec ContainerSpec) bool {
 	orig := s.SetDefaults()
 	spec = spec.SetDefaults()
 
 	slices.Sort(orig.Volumes)
 	slices.Sort(spec.Volumes)
+	slices.Sort(orig.CapAdd)
+	slices.Sort(spec.CapAdd)
+	slices.Sort(orig.CapDrop)
+	slices.Sort(spec.CapDrop)
+	slices.Sort(orig.SecurityOpt)
+	slices.Sort(spec.SecurityOpt)
 
 	sortVolumeMounts(orig.VolumeMounts)
 	sortVolumeMounts(spec.VolumeMounts)
@@ -301,6 +534,19 @@ func (s *ContainerSpec) Equals(spec ContainerSpec) bool {
 func (s *ContainerSpec) Clone() ContainerSpec {
 	spec := *s
 
+	spec.CapAdd = slices.Clone(s.CapAdd)
+	spec.CapDrop = slices.Clone(s.CapDrop)
+	spec.Devices = slices.Clone(s.Devices)
+	spec.SecurityOpt = slices.Clone(s.SecurityOpt)
+	spec.Sysctls = maps.Clone(s.Sysctls)
+	if s.RestartPolicy != nil {
+		restartPolicy := *s.RestartPolicy
+		spec.RestartPolicy = &restartPolicy
+	}
+	if s.StopGracePeriod != nil {
+		stopGracePeriod := *s.StopGracePeriod
+		spec.StopGracePeriod = &stopGracePeriod
+	}
 	if s.Command != nil {
 		spec.Command = make([]string, len(s.Command))
 		copy(spec.Command, s.Command)
@@ -354,10 +600,76 @@ type RunServiceResponse struct {
 	Name string
 }
 
+// ServiceFilter defines criteria to filter services in ListServices.
+type ServiceFilter struct {
+	// NamePattern filters services whose names match the glob pattern, e.g. "web-*". See path.Match for the syntax.
+	NamePattern string
+	// Project filters services that belong to the project.
+	Project string
+	// Labels filters services whose containers have all the labels. A label with an empty value matches
+	// any value of the label.
+	Labels map[string]string
+}
+
+// Validate checks that the filter criteria are valid.
+func (f *ServiceFilter) Validate() error {
+	if f == nil || f.NamePattern == "" {
+		return nil
+	}
+	if _, err := path.Match(f.NamePattern, ""); err != nil {
+		return fmt.Errorf("invalid service name pattern '%s': %w", f.NamePattern, err)
+	}
+	return nil
+}
+
+// DockerFilters returns the Docker container list filters that select the containers of the services with
+// the labels of the filter. The name pattern and project can't be expressed as Docker filters as the containers
+// of a service adopted by a project don't have the project label.
+func (f *ServiceFilter) DockerFilters() filters.Args {
+	args := filters.NewArgs()
+	if f == nil {
+		return args
+	}
+	for k, v := range f.Labels {
+		if v == "" {
+			args.Add("label", k)
+		} else {
+			args.Add("label", k+"="+v)
+		}
+	}
+	return args
+}
+
+// Matches returns true if the service container matches all criteria of the filter. A nil filter matches
+// all containers.
+func (f *ServiceFilter) Matches(ctr ServiceContainer) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.NamePattern != "" {
+		if ok, _ := path.Match(f.NamePattern, ctr.ServiceName()); !ok {
+			return false
+		}
+	}
+	if f.Project != "" && ctr.Project() != f.Project {
+		return false
+	}
+	for k, v := range f.Labels {
+		if actual, ok := ctr.Config.Labels[k]; !ok || (v != "" && actual != v) {
+			return false
+		}
+	}
+
+	return true
+}
+
 type Service struct {
-	ID         string
-	Name       string
-	Mode       string
+	ID   string
+	Name string
+	Mode string
+	// Project is the name of the project the service belongs to. Empty if the service doesn't belong to a project.
+	Project    string
 	Containers []MachineServiceContainer
 }
 
@@ -375,6 +687,59 @@ func (s *Service) Images() []string {
 	return slices.Sorted(maps.Keys(images))
 }
 
+// Owner returns the owner of the service from the spec of its containers. It returns an empty string if none of
+// the containers specifies an owner.
+func (s *Service) Owner() string {
+	for _, ctr := range s.Containers {
+		if owner := ctr.Container.ServiceSpec.Owner; owner != "" {
+			return owner
+		}
+	}
+	return ""
+}
+
+// Protected returns true if the service is protected from removal. Containers may have different specs during
+// a rolling update so the service is protected if any of its containers is.
+func (s *Service) Protected() bool {
+	for _, ctr := range s.Containers {
+		if ctr.Container.ServiceSpec.Protected {
+			return true
+		}
+	}
+	return false
+}
+
+// Restarts returns the total number of times the service containers have been restarted by Docker and the number
+// of crash-looping containers.
+func (s *Service) Restarts() (restarts int, crashLooping int) {
+	for _, ctr := range s.Containers {
+		restarts += ctr.Container.RestartCount
+		if ctr.Container.CrashLooping() {
+			crashLooping++
+		}
+	}
+	return restarts, crashLooping
+}
+
+// Spec reconstructs the service spec from the spec of the service containers. The number of running containers is
+// used as the number of replicas as scaling doesn't update the spec of the existing containers. It returns false
+// if the service has no containers to derive the spec from.
+func (s *Service) Spec() (ServiceSpec, bool) {
+	if len(s.Containers) == 0 {
+		return ServiceSpec{}, false
+	}
+
+	// TODO: Check if all containers have the same spec. This can happen if a service deployment failed midway
+	//  and some containers were not updated.
+	spec := s.Containers[0].Container.ServiceSpec.Clone()
+	spec.Name = s.Name
+	spec.Mode = s.Mode
+	if spec.Mode == ServiceModeReplicated {
+		spec.Replicas = uint(len(s.Containers))
+	}
+	return spec, true
+}
+
 // Endpoints returns the exposed HTTP and HTTPS endpoints of the service.
 func (s *Service) Endpoints() []string {
 	endpoints := make(map[string]struct{})
@@ -1,8 +1,14 @@
 package api
 
 import (
+	"maps"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -197,3 +203,228 @@ func TestServiceSpec_Validate_CaddyAndPorts(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerSpec_Validate_Stop(t *testing.T) {
+	t.Parallel()
+
+	negative := -time.Second
+	tests := []struct {
+		name    string
+		spec    ContainerSpec
+		wantErr string
+	}{
+		{
+			name: "signal name",
+			spec: ContainerSpec{Image: "postgres", StopSignal: "SIGINT"},
+		},
+		{
+			name: "signal name without prefix",
+			spec: ContainerSpec{Image: "postgres", StopSignal: "QUIT"},
+		},
+		{
+			name: "signal number",
+			spec: ContainerSpec{Image: "postgres", StopSignal: "2"},
+		},
+		{
+			name: "realtime signal",
+			spec: ContainerSpec{Image: "postgres", StopSignal: "SIGRTMIN+3"},
+		},
+		{
+			name:    "invalid signal",
+			spec:    ContainerSpec{Image: "postgres", StopSignal: "sig int"},
+			wantErr: "invalid stop signal",
+		},
+		{
+			name:    "negative grace period",
+			spec:    ContainerSpec{Image: "postgres", StopGracePeriod: &negative},
+			wantErr: "stop grace period cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceSpec_Validate_Project(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		project string
+		wantErr bool
+	}{
+		{project: ""},
+		{project: "shop"},
+		{project: "my_shop-2"},
+		{project: "-shop", wantErr: true},
+		{project: "Shop", wantErr: true},
+		{project: "my.shop", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			spec := ServiceSpec{
+				Name:      "web",
+				Container: ContainerSpec{Image: "nginx"},
+				Project:   tt.project,
+			}
+			err := spec.Validate()
+			if tt.wantErr {
+				require.ErrorContains(t, err, "invalid project name")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServiceSpec_Validate_AliasesAndHostname(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		aliases  []string
+		hostname string
+		wantErr  string
+	}{
+		{name: "empty"},
+		{name: "short alias", aliases: []string{"database"}},
+		{name: "external alias", aliases: []string{"db.internal.company"}},
+		{name: "uppercase alias", aliases: []string{"Database"}, wantErr: "invalid alias"},
+		{name: "empty label", aliases: []string{"db..company"}, wantErr: "invalid alias"},
+		{name: "trailing dot", aliases: []string{"db.company."}, wantErr: "invalid alias"},
+		{name: "hostname", hostname: "db-primary"},
+		{name: "invalid hostname", hostname: "db_primary", wantErr: "invalid hostname"},
+		{name: "long hostname", hostname: strings.Repeat("a", 64), wantErr: "invalid hostname"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ServiceSpec{
+				Name:      "db",
+				Aliases:   tt.aliases,
+				Container: ContainerSpec{Image: "postgres", Hostname: tt.hostname},
+			}
+			err := spec.Validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServiceFilter(t *testing.T) {
+	t.Parallel()
+
+	ctr := func(name, project string, labels map[string]string) ServiceContainer {
+		c := ServiceContainer{Container: Container{ContainerJSON: types.ContainerJSON{
+			Config: &container.Config{Labels: map[string]string{LabelServiceName: name}},
+		}}}
+		if project != "" {
+			c.Config.Labels[LabelProject] = project
+		}
+		maps.Copy(c.Config.Labels, labels)
+		return c
+	}
+
+	filter := &ServiceFilter{
+		NamePattern: "web*",
+		Project:     "shop",
+		Labels:      map[string]string{"tier": "frontend", "team": ""},
+	}
+	require.NoError(t, filter.Validate())
+	assert.ElementsMatch(t, []string{"tier=frontend", "team"}, filter.DockerFilters().Get("label"))
+
+	labels := map[string]string{"tier": "frontend", "team": "a"}
+	assert.True(t, filter.Matches(ctr("web", "shop", labels)))
+	assert.False(t, filter.Matches(ctr("api", "shop", labels)), "name doesn't match pattern")
+	assert.False(t, filter.Matches(ctr("web", "blog", labels)), "different project")
+	adopted := ctr("web", "", labels)
+	adopted.ServiceSpec.Project = "shop"
+	assert.True(t, filter.Matches(adopted), "project adopted without the label")
+	assert.False(t, filter.Matches(ctr("web", "shop", map[string]string{"tier": "frontend"})), "missing label")
+	assert.False(t, filter.Matches(ctr("web", "shop", map[string]string{"tier": "backend", "team": "a"})),
+		"different label value")
+
+	var nilFilter *ServiceFilter
+	assert.True(t, nilFilter.Matches(ctr("web", "", nil)))
+	assert.Equal(t, 0, nilFilter.DockerFilters().Len())
+	assert.ErrorContains(t, (&ServiceFilter{NamePattern: "["}).Validate(), "invalid service name pattern")
+}
+
+func TestContainerSpec_StopTimeoutSeconds(t *testing.T) {
+	t.Parallel()
+
+	spec := ContainerSpec{}
+	assert.Nil(t, spec.StopTimeoutSeconds())
+
+	period := 1500 * time.Millisecond
+	spec.StopGracePeriod = &period
+	require.NotNil(t, spec.StopTimeoutSeconds())
+	assert.Equal(t, 2, *spec.StopTimeoutSeconds(), "must be rounded up to whole seconds")
+}
+
+func TestService_Spec(t *testing.T) {
+	t.Parallel()
+
+	svc := Service{Name: "web", Mode: ServiceModeReplicated}
+	_, ok := svc.Spec()
+	assert.False(t, ok, "service without containers")
+
+	ctrSpec := ServiceSpec{
+		Name:      "old-name",
+		Mode:      ServiceModeReplicated,
+		Replicas:  1,
+		Container: ContainerSpec{Image: "nginx"},
+	}
+	for _, machineID := range []string{"m1", "m2", "m3"} {
+		svc.Containers = append(svc.Containers, MachineServiceContainer{
+			MachineID: machineID,
+			Container: ServiceContainer{ServiceSpec: ctrSpec},
+		})
+	}
+
+	spec, ok := svc.Spec()
+	require.True(t, ok)
+	assert.Equal(t, "web", spec.Name)
+	assert.Equal(t, uint(3), spec.Replicas, "replicas must match the number of containers")
+	assert.Equal(t, "nginx", spec.Container.Image)
+
+	svc.Mode = ServiceModeGlobal
+	spec, ok = svc.Spec()
+	require.True(t, ok)
+	assert.Equal(t, ServiceModeGlobal, spec.Mode)
+	assert.Equal(t, uint(1), spec.Replicas, "replicas must not change for global services")
+}
+
+func TestServiceSpec_Hash(t *testing.T) {
+	t.Parallel()
+
+	spec := ServiceSpec{
+		Name:      "web",
+		Container: ContainerSpec{Image: "nginx"},
+	}
+	hash, err := spec.Hash()
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	withDefaults := spec.SetDefaults()
+	got, err := withDefaults.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, got, "defaults must not change the hash")
+
+	changed := spec.Clone()
+	changed.Container.Image = "nginx:alpine"
+	got, err = changed.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, got)
+}
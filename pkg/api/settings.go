@@ -0,0 +1,553 @@
+package api
+
+import (
+	"fmt"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Keys of the cluster settings used to get and set them individually.
+const (
+	SettingDomain                  = "domain"
+	SettingACMEEmail               = "acme-email"
+	SettingDefaultRestartPolicy    = "default-restart-policy"
+	SettingImageGCAge              = "gc.image-age"
+	SettingContainerSyncInterval   = "heartbeat.container-sync"
+	SettingResourcesUpdateInterval = "heartbeat.resources-update"
+	SettingImageScanner            = "image-scan.scanner"
+	SettingImageScanFailOn         = "image-scan.fail-on"
+	SettingTrashRetention          = "trash.retention"
+	SettingHTTPProxy               = "proxy.http"
+	SettingHTTPSProxy              = "proxy.https"
+	SettingNoProxy                 = "proxy.no-proxy"
+	SettingAuditRecordExec         = "audit.record-exec"
+	SettingRegistryMirrors         = "registry.mirrors"
+)
+
+// SettingKeys are the keys of all cluster settings in the display order.
+var SettingKeys = []string{
+	SettingDomain,
+	SettingACMEEmail,
+	SettingDefaultRestartPolicy,
+	SettingImageGCAge,
+	SettingContainerSyncInterval,
+	SettingResourcesUpdateInterval,
+	SettingImageScanner,
+	SettingImageScanFailOn,
+	SettingTrashRetention,
+	SettingHTTPProxy,
+	SettingHTTPSProxy,
+	SettingNoProxy,
+	SettingAuditRecordExec,
+	SettingRegistryMirrors,
+}
+
+// minHeartbeatInterval is the minimum allowed value of the heartbeat intervals to prevent overloading the cluster.
+const minHeartbeatInterval = 5 * time.Second
+
+// ClusterSettings are the cluster-wide settings stored in the cluster store. Machines apply changes to the settings
+// without restarting. A zero value of a setting means the built-in default is used.
+type ClusterSettings struct {
+	// Version is incremented on every update of the settings. It's used to detect concurrent updates.
+	Version int64
+	// Domain is the default domain for the service hostnames used when no Uncloud DNS domain is reserved.
+	Domain string `json:",omitempty"`
+	// ACMEEmail is the email address used by Caddy to register an ACME account for obtaining TLS certificates.
+	ACMEEmail string `json:",omitempty"`
+	// DefaultRestartPolicy is the restart policy for the service containers that don't specify one in the format
+	// accepted by ParseRestartPolicy. RestartPolicyUnlessStopped is used if empty.
+	DefaultRestartPolicy string `json:",omitempty"`
+	// ImageGCAge is the age after which unused images are removed from the machines. Zero disables the image GC.
+	ImageGCAge time.Duration `json:",omitempty"`
+	// ContainerSyncInterval is the interval at which the machines sync their containers to the cluster store
+	// in addition to syncing them on Docker events.
+	ContainerSyncInterval time.Duration `json:",omitempty"`
+	// ResourcesUpdateInterval is the interval at which the machines refresh their resource inventory
+	// in the cluster store.
+	ResourcesUpdateInterval time.Duration `json:",omitempty"`
+	// ImageScanner is the vulnerability scanner used to scan the service images before deploying them, one of
+	// ImageScanners. Empty disables the image scanning.
+	ImageScanner string `json:",omitempty"`
+	// ImageScanFailOn is the minimum severity of vulnerabilities found in an image that blocks its deployment.
+	// Vulnerabilities with a lower severity are reported as warnings. SeverityCritical is used if empty.
+	ImageScanFailOn VulnerabilitySeverity `json:",omitempty"`
+	// ImageSigning is the policy that requires the service images to be signed by trusted keys or identities.
+	// It's managed with dedicated commands rather than as a single key-value setting. Not enforced if nil.
+	ImageSigning *ImageSigningPolicy `json:",omitempty"`
+	// TrashRetention is the period for which removed services are kept stopped in the trash and can be restored
+	// before their containers are removed. Zero disables the trash and services are removed immediately.
+	TrashRetention time.Duration `json:",omitempty"`
+	// DefaultEnv are the environment variables set in all service containers, e.g. TZ or HTTP_PROXY, unless
+	// the service spec sets them. They're managed with dedicated commands and apply to new containers.
+	DefaultEnv EnvVars `json:",omitempty"`
+	// SplitHorizon maps the published service domains to the split-horizon DNS modes used to resolve them inside
+	// the cluster. Domains not listed use SplitHorizonProxy. It's managed with dedicated commands.
+	SplitHorizon map[string]SplitHorizonMode `json:",omitempty"`
+	// Egress is the policy that routes the outbound internet traffic of service containers through a gateway
+	// machine. It's managed with dedicated commands. Containers use their own machines if nil.
+	Egress *EgressPolicy `json:",omitempty"`
+	// HTTPProxy is the URL of the proxy for the outbound HTTP requests of the machine daemons, Docker daemons,
+	// and service containers, e.g. "http://proxy.corp:3128".
+	HTTPProxy string `json:",omitempty"`
+	// HTTPSProxy is the URL of the proxy for the outbound HTTPS requests. HTTPProxy is not used for HTTPS requests.
+	HTTPSProxy string `json:",omitempty"`
+	// NoProxy is a comma-separated list of hosts, domains, and IP ranges accessed directly, bypassing the proxy,
+	// in addition to DefaultNoProxy.
+	NoProxy string `json:",omitempty"`
+	// AuditRecordExec enables recording the interactive exec sessions in service containers on the machines
+	// that run them so they can be replayed with 'uc audit replay'.
+	AuditRecordExec bool `json:",omitempty"`
+	// RegistryMirrors are the URLs of the registry mirrors the Docker daemons of the machines pull Docker Hub
+	// images through before falling back to Docker Hub, e.g. "https://mirror.gcr.io". Changes are applied
+	// by reloading the Docker daemons.
+	RegistryMirrors []string `json:",omitempty"`
+	// RegistryCache is the URL of the built-in pull-through cache of Docker Hub running in the cluster which is
+	// used as the first registry mirror. It's managed with the 'uc image cache' commands. Unused if empty.
+	RegistryCache string `json:",omitempty"`
+	// IngressHA is the policy that moves a virtual IP for the inbound traffic to a healthy ingress machine.
+	// It's managed with the 'uc ingress ha' commands. Disabled if nil.
+	IngressHA *IngressHAPolicy `json:",omitempty"`
+
+	// Network is the IP network of the cluster. It isn't stored with the settings but loaded from the cluster
+	// network in the store so that the settings derived from it follow the network migrations.
+	Network netip.Prefix `json:"-"`
+}
+
+// DefaultNoProxy are the hosts that are always accessed directly, bypassing the outbound proxy: the loopback
+// addresses and the internal cluster domain. The cluster network is added to them by NoProxyHosts.
+var DefaultNoProxy = []string{"localhost", "127.0.0.1", ".internal"}
+
+// SplitHorizonMode defines how the cluster DNS resolves a domain published by a service for the containers
+// in the cluster instead of forwarding the query to the upstream DNS servers that return the public address.
+type SplitHorizonMode string
+
+const (
+	// SplitHorizonProxy resolves the domain to the Caddy container on the same machine as the querying container
+	// or all Caddy containers if there is none. The traffic goes through the reverse proxy as from the internet
+	// but doesn't leave the cluster network.
+	SplitHorizonProxy SplitHorizonMode = "proxy"
+	// SplitHorizonDirect resolves the domain to the containers of the service that publishes it, bypassing
+	// the reverse proxy. The clients must connect to the container port.
+	SplitHorizonDirect SplitHorizonMode = "direct"
+	// SplitHorizonOff forwards the queries for the domain to the upstream DNS servers.
+	SplitHorizonOff SplitHorizonMode = "off"
+)
+
+// SplitHorizonModes are all valid split-horizon DNS modes.
+var SplitHorizonModes = []SplitHorizonMode{SplitHorizonProxy, SplitHorizonDirect, SplitHorizonOff}
+
+// SplitHorizonMode returns the split-horizon DNS mode of the published domain.
+func (s *ClusterSettings) SplitHorizonMode(domain string) SplitHorizonMode {
+	if mode, ok := s.SplitHorizon[domain]; ok {
+		return mode
+	}
+	return SplitHorizonProxy
+}
+
+// ClusterSettingsFromProto converts the cluster settings message to ClusterSettings.
+func ClusterSettingsFromProto(s *pb.ClusterSettings) ClusterSettings {
+	return ClusterSettings{
+		Version:                 s.GetVersion(),
+		Domain:                  s.GetDomain(),
+		ACMEEmail:               s.GetAcmeEmail(),
+		DefaultRestartPolicy:    s.GetDefaultRestartPolicy(),
+		ImageGCAge:              s.GetImageGcAge().AsDuration(),
+		ContainerSyncInterval:   s.GetContainerSyncInterval().AsDuration(),
+		ResourcesUpdateInterval: s.GetResourcesUpdateInterval().AsDuration(),
+		ImageScanner:            s.GetImageScanner(),
+		ImageScanFailOn:         VulnerabilitySeverity(s.GetImageScanFailOn()),
+		ImageSigning:            ImageSigningPolicyFromProto(s.GetImageSigning()),
+		TrashRetention:          s.GetTrashRetention().AsDuration(),
+		DefaultEnv:              s.GetDefaultEnv(),
+		SplitHorizon:            splitHorizonFromProto(s.GetSplitHorizon()),
+		Egress:                  EgressPolicyFromProto(s.GetEgress()),
+		HTTPProxy:               s.GetHttpProxy(),
+		HTTPSProxy:              s.GetHttpsProxy(),
+		NoProxy:                 s.GetNoProxy(),
+		AuditRecordExec:         s.GetAuditRecordExec(),
+		RegistryMirrors:         s.GetRegistryMirrors(),
+		RegistryCache:           s.GetRegistryCache(),
+		IngressHA:               IngressHAPolicyFromProto(s.GetIngressHa()),
+	}
+}
+
+// Proto returns the cluster settings message.
+func (s *ClusterSettings) Proto() *pb.ClusterSettings {
+	return &pb.ClusterSettings{
+		Version:                 s.Version,
+		Domain:                  s.Domain,
+		AcmeEmail:               s.ACMEEmail,
+		DefaultRestartPolicy:    s.DefaultRestartPolicy,
+		ImageGcAge:              durationpb.New(s.ImageGCAge),
+		ContainerSyncInterval:   durationpb.New(s.ContainerSyncInterval),
+		ResourcesUpdateInterval: durationpb.New(s.ResourcesUpdateInterval),
+		ImageScanner:            s.ImageScanner,
+		ImageScanFailOn:         string(s.ImageScanFailOn),
+		ImageSigning:            s.ImageSigning.Proto(),
+		TrashRetention:          durationpb.New(s.TrashRetention),
+		DefaultEnv:              s.DefaultEnv,
+		SplitHorizon:            splitHorizonProto(s.SplitHorizon),
+		Egress:                  s.Egress.Proto(),
+		HttpProxy:               s.HTTPProxy,
+		HttpsProxy:              s.HTTPSProxy,
+		NoProxy:                 s.NoProxy,
+		AuditRecordExec:         s.AuditRecordExec,
+		RegistryMirrors:         s.RegistryMirrors,
+		RegistryCache:           s.RegistryCache,
+		IngressHa:               s.IngressHA.Proto(),
+	}
+}
+
+func splitHorizonFromProto(m map[string]string) map[string]SplitHorizonMode {
+	if len(m) == 0 {
+		return nil
+	}
+	modes := make(map[string]SplitHorizonMode, len(m))
+	for domain, mode := range m {
+		modes[domain] = SplitHorizonMode(mode)
+	}
+	return modes
+}
+
+func splitHorizonProto(modes map[string]SplitHorizonMode) map[string]string {
+	if len(modes) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(modes))
+	for domain, mode := range modes {
+		m[domain] = string(mode)
+	}
+	return m
+}
+
+// Get returns the value of the setting with the given key formatted as a string. Unset settings are returned
+// as empty strings.
+func (s *ClusterSettings) Get(key string) (string, error) {
+	formatDuration := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+		return d.String()
+	}
+
+	switch key {
+	case SettingDomain:
+		return s.Domain, nil
+	case SettingACMEEmail:
+		return s.ACMEEmail, nil
+	case SettingDefaultRestartPolicy:
+		return s.DefaultRestartPolicy, nil
+	case SettingImageGCAge:
+		return formatDuration(s.ImageGCAge), nil
+	case SettingContainerSyncInterval:
+		return formatDuration(s.ContainerSyncInterval), nil
+	case SettingResourcesUpdateInterval:
+		return formatDuration(s.ResourcesUpdateInterval), nil
+	case SettingImageScanner:
+		return s.ImageScanner, nil
+	case SettingImageScanFailOn:
+		return strings.ToLower(string(s.ImageScanFailOn)), nil
+	case SettingTrashRetention:
+		return formatDuration(s.TrashRetention), nil
+	case SettingHTTPProxy:
+		return s.HTTPProxy, nil
+	case SettingHTTPSProxy:
+		return s.HTTPSProxy, nil
+	case SettingNoProxy:
+		return s.NoProxy, nil
+	case SettingAuditRecordExec:
+		if !s.AuditRecordExec {
+			return "", nil
+		}
+		return strconv.FormatBool(s.AuditRecordExec), nil
+	case SettingRegistryMirrors:
+		return strings.Join(s.RegistryMirrors, ","), nil
+	}
+	return "", fmt.Errorf("unknown setting '%s', must be one of: %s", key, strings.Join(SettingKeys, ", "))
+}
+
+// Set parses and sets the value of the setting with the given key. An empty value unsets the setting.
+func (s *ClusterSettings) Set(key, value string) error {
+	parseDuration := func(v string) (time.Duration, error) {
+		if v == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration for setting '%s': %w", key, err)
+		}
+		return d, nil
+	}
+
+	var err error
+	switch key {
+	case SettingDomain:
+		s.Domain = strings.TrimSuffix(strings.ToLower(value), ".")
+	case SettingACMEEmail:
+		s.ACMEEmail = value
+	case SettingDefaultRestartPolicy:
+		s.DefaultRestartPolicy = value
+	case SettingImageGCAge:
+		s.ImageGCAge, err = parseDuration(value)
+	case SettingContainerSyncInterval:
+		s.ContainerSyncInterval, err = parseDuration(value)
+	case SettingResourcesUpdateInterval:
+		s.ResourcesUpdateInterval, err = parseDuration(value)
+	case SettingImageScanner:
+		s.ImageScanner = strings.ToLower(value)
+	case SettingImageScanFailOn:
+		s.ImageScanFailOn = ""
+		if value != "" {
+			s.ImageScanFailOn, err = ParseVulnerabilitySeverity(value)
+		}
+	case SettingTrashRetention:
+		s.TrashRetention, err = parseDuration(value)
+	case SettingHTTPProxy:
+		s.HTTPProxy = value
+	case SettingHTTPSProxy:
+		s.HTTPSProxy = value
+	case SettingNoProxy:
+		s.NoProxy = strings.ReplaceAll(value, " ", "")
+	case SettingAuditRecordExec:
+		s.AuditRecordExec = false
+		if value != "" {
+			if s.AuditRecordExec, err = strconv.ParseBool(value); err != nil {
+				err = fmt.Errorf("invalid value for setting '%s', must be true or false", key)
+			}
+		}
+	case SettingRegistryMirrors:
+		s.RegistryMirrors = nil
+		for _, m := range strings.Split(value, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				s.RegistryMirrors = append(s.RegistryMirrors, m)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown setting '%s', must be one of: %s", key, strings.Join(SettingKeys, ", "))
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.Validate()
+}
+
+// Validate checks that the values of the settings are valid.
+func (s *ClusterSettings) Validate() error {
+	if s.Domain != "" && (strings.ContainsAny(s.Domain, " /:@") || !strings.Contains(s.Domain, ".")) {
+		return fmt.Errorf("invalid domain: %q", s.Domain)
+	}
+	if s.ACMEEmail != "" {
+		if addr, err := mail.ParseAddress(s.ACMEEmail); err != nil || addr.Address != s.ACMEEmail {
+			return fmt.Errorf("invalid ACME email: %q", s.ACMEEmail)
+		}
+	}
+	if s.DefaultRestartPolicy != "" {
+		if _, err := ParseRestartPolicy(s.DefaultRestartPolicy); err != nil {
+			return fmt.Errorf("invalid default restart policy: %w", err)
+		}
+	}
+	if s.ImageGCAge < 0 {
+		return fmt.Errorf("image GC age must not be negative: %s", s.ImageGCAge)
+	}
+	if s.ContainerSyncInterval != 0 && s.ContainerSyncInterval < minHeartbeatInterval {
+		return fmt.Errorf("container sync interval must be at least %s: %s",
+			minHeartbeatInterval, s.ContainerSyncInterval)
+	}
+	if s.ResourcesUpdateInterval != 0 && s.ResourcesUpdateInterval < minHeartbeatInterval {
+		return fmt.Errorf("resources update interval must be at least %s: %s",
+			minHeartbeatInterval, s.ResourcesUpdateInterval)
+	}
+	if s.ImageScanner != "" && !slices.Contains(ImageScanners, s.ImageScanner) {
+		return fmt.Errorf("invalid image scanner '%s', must be one of: %s",
+			s.ImageScanner, strings.Join(ImageScanners, ", "))
+	}
+	if s.ImageScanFailOn != "" {
+		if _, err := ParseVulnerabilitySeverity(string(s.ImageScanFailOn)); err != nil {
+			return fmt.Errorf("invalid image scan fail-on severity: %w", err)
+		}
+	}
+	if s.TrashRetention < 0 {
+		return fmt.Errorf("trash retention must not be negative: %s", s.TrashRetention)
+	}
+	for k := range s.DefaultEnv {
+		if err := ValidateDefaultEnvKey(k); err != nil {
+			return fmt.Errorf("invalid default environment variable: %w", err)
+		}
+	}
+	for domain, mode := range s.SplitHorizon {
+		if err := ValidateDNSName(domain); err != nil {
+			return fmt.Errorf("invalid split-horizon domain: %w", err)
+		}
+		if !slices.Contains(SplitHorizonModes, mode) {
+			return fmt.Errorf("invalid split-horizon mode '%s' for domain '%s', must be one of: %s, %s, %s",
+				mode, domain, SplitHorizonProxy, SplitHorizonDirect, SplitHorizonOff)
+		}
+	}
+	if err := s.ImageSigning.Validate(); err != nil {
+		return fmt.Errorf("invalid image signing policy: %w", err)
+	}
+	if err := s.Egress.Validate(); err != nil {
+		return fmt.Errorf("invalid egress policy: %w", err)
+	}
+	if err := s.IngressHA.Validate(); err != nil {
+		return fmt.Errorf("invalid ingress HA policy: %w", err)
+	}
+	for _, proxy := range []string{s.HTTPProxy, s.HTTPSProxy} {
+		if err := validateProxyURL(proxy); err != nil {
+			return err
+		}
+	}
+	for _, mirror := range s.RegistryMirrors {
+		if err := validateRegistryMirrorURL(mirror); err != nil {
+			return err
+		}
+	}
+	if s.RegistryCache != "" {
+		if err := validateRegistryMirrorURL(s.RegistryCache); err != nil {
+			return fmt.Errorf("invalid registry cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateDefaultEnvKey checks that the name of a default environment variable is valid and not reserved for
+// the injected metadata variables.
+func ValidateDefaultEnvKey(key string) error {
+	if key == "" || strings.ContainsAny(key, "= \t\n") {
+		return fmt.Errorf("invalid name: %q", key)
+	}
+	if strings.HasPrefix(key, EnvPrefix) {
+		return fmt.Errorf("name '%s' is reserved, the %s prefix is used for the injected variables", key, EnvPrefix)
+	}
+	return nil
+}
+
+// validateProxyURL checks that the proxy URL is empty or an absolute URL with a supported scheme.
+func validateProxyURL(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL '%s', expected scheme://host[:port]", proxy)
+	}
+	if !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) {
+		return fmt.Errorf("invalid proxy URL scheme '%s', must be one of: http, https, socks5", u.Scheme)
+	}
+	return nil
+}
+
+// validateRegistryMirrorURL checks that the registry mirror URL is an absolute http or https URL without a path
+// as required by Docker.
+func validateRegistryMirrorURL(mirror string) error {
+	u, err := url.Parse(mirror)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid registry mirror URL '%s', expected scheme://host[:port]", mirror)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid registry mirror URL scheme '%s', must be one of: http, https", u.Scheme)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid registry mirror URL '%s', must not contain a path, query, or fragment", mirror)
+	}
+	return nil
+}
+
+// DaemonRegistryMirrors returns the registry mirrors used by the Docker daemons in the order of preference:
+// the built-in registry cache if enabled followed by RegistryMirrors.
+func (s *ClusterSettings) DaemonRegistryMirrors() []string {
+	var mirrors []string
+	if s.RegistryCache != "" {
+		mirrors = append(mirrors, s.RegistryCache)
+	}
+	for _, m := range s.RegistryMirrors {
+		if !slices.Contains(mirrors, m) {
+			mirrors = append(mirrors, m)
+		}
+	}
+	return mirrors
+}
+
+// InsecureRegistries returns the hosts of the plain HTTP registry mirrors which Docker must be allowed to connect
+// to without TLS.
+func (s *ClusterSettings) InsecureRegistries() []string {
+	var hosts []string
+	for _, m := range s.DaemonRegistryMirrors() {
+		if u, err := url.Parse(m); err == nil && u.Scheme == "http" && !slices.Contains(hosts, u.Host) {
+			hosts = append(hosts, u.Host)
+		}
+	}
+	return hosts
+}
+
+// Proxy returns true if an outbound proxy is configured.
+func (s *ClusterSettings) Proxy() bool {
+	return s.HTTPProxy != "" || s.HTTPSProxy != ""
+}
+
+// NoProxyHosts returns the comma-separated list of DefaultNoProxy, the cluster network, and NoProxy hosts
+// without duplicates.
+func (s *ClusterSettings) NoProxyHosts() string {
+	hosts := slices.Clone(DefaultNoProxy)
+	if s.Network.IsValid() {
+		hosts = append(hosts, s.Network.String())
+	}
+	for _, h := range strings.Split(s.NoProxy, ",") {
+		if h = strings.TrimSpace(h); h != "" && !slices.Contains(hosts, h) {
+			hosts = append(hosts, h)
+		}
+	}
+	return strings.Join(hosts, ",")
+}
+
+// ProxyEnv returns the environment variables that configure the outbound proxy in the service containers.
+// Both the upper and lower case variants are set as programs differ in which ones they read. It returns nil
+// if no proxy is configured.
+func (s *ClusterSettings) ProxyEnv() EnvVars {
+	if !s.Proxy() {
+		return nil
+	}
+
+	env := EnvVars{}
+	if s.HTTPProxy != "" {
+		env["HTTP_PROXY"] = s.HTTPProxy
+		env["http_proxy"] = s.HTTPProxy
+	}
+	if s.HTTPSProxy != "" {
+		env["HTTPS_PROXY"] = s.HTTPSProxy
+		env["https_proxy"] = s.HTTPSProxy
+	}
+	noProxy := s.NoProxyHosts()
+	env["NO_PROXY"] = noProxy
+	env["no_proxy"] = noProxy
+	return env
+}
+
+// ScanFailOn returns the minimum severity of vulnerabilities that blocks a deployment.
+func (s *ClusterSettings) ScanFailOn() VulnerabilitySeverity {
+	if s.ImageScanFailOn == "" {
+		return SeverityCritical
+	}
+	return s.ImageScanFailOn
+}
+
+// RestartPolicy returns the parsed default restart policy or nil if it's not set.
+func (s *ClusterSettings) RestartPolicy() *RestartPolicy {
+	if s.DefaultRestartPolicy == "" {
+		return nil
+	}
+	policy, err := ParseRestartPolicy(s.DefaultRestartPolicy)
+	if err != nil {
+		return nil
+	}
+	return &policy
+}
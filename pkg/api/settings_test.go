@@ -0,0 +1,179 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterSettings_SetGet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key     string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{key: SettingDomain, value: "Example.COM.", want: "example.com"},
+		{key: SettingDomain, value: "localhost", wantErr: "invalid domain"},
+		{key: SettingACMEEmail, value: "admin@example.com", want: "admin@example.com"},
+		{key: SettingACMEEmail, value: "Admin <admin@example.com>", wantErr: "invalid ACME email"},
+		{key: SettingDefaultRestartPolicy, value: "on-failure:3", want: "on-failure:3"},
+		{key: SettingDefaultRestartPolicy, value: "never", wantErr: "invalid default restart policy"},
+		{key: SettingImageGCAge, value: "168h", want: "168h0m0s"},
+		{key: SettingImageGCAge, value: "-1h", wantErr: "must not be negative"},
+		{key: SettingContainerSyncInterval, value: "1m", want: "1m0s"},
+		{key: SettingContainerSyncInterval, value: "1s", wantErr: "must be at least 5s"},
+		{key: SettingResourcesUpdateInterval, value: "soon", wantErr: "invalid duration"},
+		{key: SettingImageScanner, value: "Trivy", want: "trivy"},
+		{key: SettingImageScanner, value: "clair", wantErr: "invalid image scanner"},
+		{key: SettingImageScanFailOn, value: "High", want: "high"},
+		{key: SettingImageScanFailOn, value: "severe", wantErr: "invalid severity"},
+		{key: SettingTrashRetention, value: "72h", want: "72h0m0s"},
+		{key: SettingTrashRetention, value: "-1h", wantErr: "must not be negative"},
+		{key: SettingHTTPProxy, value: "http://proxy.corp:3128", want: "http://proxy.corp:3128"},
+		{key: SettingHTTPProxy, value: "proxy.corp:3128", wantErr: "invalid proxy URL"},
+		{key: SettingHTTPSProxy, value: "ftp://proxy.corp", wantErr: "invalid proxy URL scheme"},
+		{key: SettingNoProxy, value: "example.com, 192.168.0.0/16", want: "example.com,192.168.0.0/16"},
+		{key: SettingAuditRecordExec, value: "true", want: "true"},
+		{key: SettingAuditRecordExec, value: "yes", wantErr: "must be true or false"},
+		{
+			key:   SettingRegistryMirrors,
+			value: "https://mirror.gcr.io, http://10.210.0.1:5000",
+			want:  "https://mirror.gcr.io,http://10.210.0.1:5000",
+		},
+		{key: SettingRegistryMirrors, value: "mirror.gcr.io", wantErr: "invalid registry mirror URL"},
+		{key: SettingRegistryMirrors, value: "https://mirror.example.com/v2", wantErr: "must not contain a path"},
+		{key: "unknown", value: "value", wantErr: "unknown setting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			var s ClusterSettings
+			err := s.Set(tt.key, tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := s.Get(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// An empty value unsets the setting.
+			require.NoError(t, s.Set(tt.key, ""))
+			assert.Equal(t, ClusterSettings{}, s)
+		})
+	}
+}
+
+func TestClusterSettings_Proto(t *testing.T) {
+	t.Parallel()
+
+	s := ClusterSettings{
+		Version:                 3,
+		Domain:                  "example.com",
+		ACMEEmail:               "admin@example.com",
+		DefaultRestartPolicy:    "always",
+		ImageGCAge:              24 * time.Hour,
+		ContainerSyncInterval:   time.Minute,
+		ResourcesUpdateInterval: 5 * time.Minute,
+		ImageScanner:            ImageScannerGrype,
+		ImageScanFailOn:         SeverityHigh,
+		ImageSigning: &ImageSigningPolicy{
+			Images: []string{"ghcr.io/acme/*"},
+			Keys:   []SigningKey{{Name: "release", PublicKey: testPublicKey}},
+			Identities: []SigningIdentity{{
+				Name:    "ci",
+				Issuer:  "https://token.actions.githubusercontent.com",
+				Subject: "https://github.com/acme/app/.github/workflows/release.yaml@refs/heads/main",
+				Roots:   "roots",
+			}},
+		},
+		TrashRetention: 72 * time.Hour,
+		DefaultEnv:     EnvVars{"TZ": "UTC"},
+		SplitHorizon:   map[string]SplitHorizonMode{"app.example.com": SplitHorizonDirect},
+		Egress:         &EgressPolicy{Gateway: "machine1", Services: []string{"web"}},
+	}
+	assert.Equal(t, s, ClusterSettingsFromProto(s.Proto()))
+	assert.Equal(t, ClusterSettings{}, ClusterSettingsFromProto(nil))
+}
+
+func TestClusterSettings_ValidateDefaultEnv(t *testing.T) {
+	t.Parallel()
+
+	s := ClusterSettings{DefaultEnv: EnvVars{"TZ": "UTC", "HTTP_PROXY": ""}}
+	assert.NoError(t, s.Validate())
+
+	s.DefaultEnv = EnvVars{"UNCLOUD_MACHINE": "custom"}
+	assert.ErrorContains(t, s.Validate(), "reserved")
+
+	s.DefaultEnv = EnvVars{"A=B": "value"}
+	assert.ErrorContains(t, s.Validate(), "invalid name")
+}
+
+func TestClusterSettings_ProxyEnv(t *testing.T) {
+	t.Parallel()
+
+	var s ClusterSettings
+	assert.Nil(t, s.ProxyEnv())
+
+	s = ClusterSettings{
+		HTTPSProxy: "http://proxy:3128",
+		NoProxy:    "example.com,localhost",
+		Network:    netip.MustParsePrefix("172.16.0.0/16"),
+	}
+	noProxy := "localhost,127.0.0.1,.internal,172.16.0.0/16,example.com"
+	assert.Equal(t, EnvVars{
+		"HTTPS_PROXY": "http://proxy:3128",
+		"https_proxy": "http://proxy:3128",
+		"NO_PROXY":    noProxy,
+		"no_proxy":    noProxy,
+	}, s.ProxyEnv())
+}
+
+func TestClusterSettings_DaemonRegistryMirrors(t *testing.T) {
+	t.Parallel()
+
+	s := ClusterSettings{
+		RegistryMirrors: []string{"https://mirror.gcr.io", "http://10.210.0.1:5000"},
+		RegistryCache:   "http://10.210.0.1:5000",
+	}
+	assert.Equal(t, []string{"http://10.210.0.1:5000", "https://mirror.gcr.io"}, s.DaemonRegistryMirrors())
+	assert.Equal(t, []string{"10.210.0.1:5000"}, s.InsecureRegistries())
+}
+
+func TestClusterSettings_SplitHorizon(t *testing.T) {
+	t.Parallel()
+
+	s := ClusterSettings{SplitHorizon: map[string]SplitHorizonMode{
+		"app.example.com": SplitHorizonDirect,
+		"www.example.com": SplitHorizonOff,
+	}}
+	assert.NoError(t, s.Validate())
+	assert.Equal(t, SplitHorizonDirect, s.SplitHorizonMode("app.example.com"))
+	assert.Equal(t, SplitHorizonProxy, s.SplitHorizonMode("api.example.com"))
+
+	s.SplitHorizon = map[string]SplitHorizonMode{"app.example.com": "public"}
+	assert.ErrorContains(t, s.Validate(), "invalid split-horizon mode")
+
+	s.SplitHorizon = map[string]SplitHorizonMode{"App.example.com": SplitHorizonOff}
+	assert.ErrorContains(t, s.Validate(), "invalid split-horizon domain")
+}
+
+func TestClusterSettings_RestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	s := ClusterSettings{}
+	assert.Nil(t, s.RestartPolicy())
+
+	s.DefaultRestartPolicy = "on-failure:3"
+	assert.Equal(t, &RestartPolicy{Name: RestartPolicyOnFailure, MaxRetries: 3}, s.RestartPolicy())
+}
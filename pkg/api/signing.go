@@ -0,0 +1,197 @@
+package api
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/distribution/reference"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// ImageSigningPolicy requires the service images to be signed with cosign (https://github.com/sigstore/cosign)
+// by one of the trusted keys or identities. Machines verify the image signatures before pulling images and creating
+// containers and reject images without a valid signature.
+type ImageSigningPolicy struct {
+	// Images are the patterns of the image repositories the policy applies to, e.g. "ghcr.io/acme/*", matched
+	// with path.Match against the normalised repository name without the tag and digest. The policy applies to all
+	// images if empty.
+	Images []string `json:",omitempty"`
+	// Keys are the trusted public keys that sign the images.
+	Keys []SigningKey `json:",omitempty"`
+	// Identities are the trusted keyless signing identities whose certificates are issued by a Fulcio CA.
+	Identities []SigningIdentity `json:",omitempty"`
+}
+
+// SigningKey is a public key trusted to sign images, e.g. generated with 'cosign generate-key-pair'.
+type SigningKey struct {
+	Name string
+	// PublicKey is the PEM-encoded ECDSA, RSA, or Ed25519 public key.
+	PublicKey string
+}
+
+// SigningIdentity is an identity trusted to sign images with keyless signing, e.g. a CI workflow or an email.
+type SigningIdentity struct {
+	Name string
+	// Issuer is the OIDC issuer that authenticated the signer, e.g. https://token.actions.githubusercontent.com.
+	Issuer string
+	// Subject is the email or URI subject alternative name of the signing certificate, e.g.
+	// https://github.com/acme/app/.github/workflows/release.yaml@refs/heads/main.
+	Subject string
+	// Roots are the PEM-encoded root CA certificates the signing certificates must chain to, e.g. the Sigstore
+	// public good Fulcio root.
+	Roots string
+}
+
+// Enabled returns true if the policy has at least one trusted key or identity.
+func (p *ImageSigningPolicy) Enabled() bool {
+	return p != nil && len(p.Keys)+len(p.Identities) > 0
+}
+
+// AppliesTo returns true if the image must be signed according to the policy.
+func (p *ImageSigningPolicy) AppliesTo(image string) bool {
+	if !p.Enabled() {
+		return false
+	}
+	if len(p.Images) == 0 {
+		return true
+	}
+	ref, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		// Let the signature verification reject the invalid image.
+		return true
+	}
+	// Match both the normalised and familiar names, e.g. docker.io/library/nginx and nginx.
+	for _, name := range []string{ref.Name(), reference.FamiliarName(ref)} {
+		for _, pattern := range p.Images {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Names returns the names of the trusted keys and identities.
+func (p *ImageSigningPolicy) Names() []string {
+	var names []string
+	for _, k := range p.Keys {
+		names = append(names, k.Name)
+	}
+	for _, id := range p.Identities {
+		names = append(names, id.Name)
+	}
+	return names
+}
+
+// Validate checks that the trusted keys and identities are valid and have unique names.
+func (p *ImageSigningPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for _, pattern := range p.Images {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid image pattern '%s': %w", pattern, err)
+		}
+	}
+
+	var names []string
+	for _, name := range p.Names() {
+		if name == "" {
+			return errors.New("trusted key or identity name must not be empty")
+		}
+		if slices.Contains(names, name) {
+			return fmt.Errorf("duplicate trusted key or identity name '%s'", name)
+		}
+		names = append(names, name)
+	}
+
+	for _, k := range p.Keys {
+		if _, err := ParsePublicKey(k.PublicKey); err != nil {
+			return fmt.Errorf("invalid public key '%s': %w", k.Name, err)
+		}
+	}
+	for _, id := range p.Identities {
+		if id.Issuer == "" || id.Subject == "" {
+			return fmt.Errorf("identity '%s' must have an issuer and subject", id.Name)
+		}
+		if _, err := ParseCertificates(id.Roots); err != nil {
+			return fmt.Errorf("invalid root certificates of identity '%s': %w", id.Name, err)
+		}
+	}
+	return nil
+}
+
+// ParsePublicKey parses a PEM-encoded PKIX public key.
+func ParsePublicKey(keyPEM string) (any, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// ParseCertificates parses one or more PEM-encoded certificates.
+func ParseCertificates(certsPEM string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(certsPEM)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM certificates found")
+	}
+	return certs, nil
+}
+
+// ImageSigningPolicyFromProto converts the image signing policy message to ImageSigningPolicy.
+// It returns nil if the message is nil.
+func ImageSigningPolicyFromProto(p *pb.ImageSigningPolicy) *ImageSigningPolicy {
+	if p == nil {
+		return nil
+	}
+	policy := &ImageSigningPolicy{Images: p.Images}
+	for _, k := range p.Keys {
+		policy.Keys = append(policy.Keys, SigningKey{Name: k.Name, PublicKey: k.PublicKey})
+	}
+	for _, id := range p.Identities {
+		policy.Identities = append(policy.Identities, SigningIdentity{
+			Name:    id.Name,
+			Issuer:  id.Issuer,
+			Subject: id.Subject,
+			Roots:   id.Roots,
+		})
+	}
+	return policy
+}
+
+// Proto returns the image signing policy message or nil if the policy is nil.
+func (p *ImageSigningPolicy) Proto() *pb.ImageSigningPolicy {
+	if p == nil {
+		return nil
+	}
+	msg := &pb.ImageSigningPolicy{Images: p.Images}
+	for _, k := range p.Keys {
+		msg.Keys = append(msg.Keys, &pb.SigningKey{Name: k.Name, PublicKey: k.PublicKey})
+	}
+	for _, id := range p.Identities {
+		msg.Identities = append(msg.Identities, &pb.SigningIdentity{
+			Name:    id.Name,
+			Issuer:  id.Issuer,
+			Subject: id.Subject,
+			Roots:   id.Roots,
+		})
+	}
+	return msg
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAExkNmb4lHPBEJ5ra7Ed9KivQ4ZOY7
+4foajBRoFMLRUoeg7+DZ7wx47XrJKouSfYLcn1R70Unk/sl0wewhpzJz+Q==
+-----END PUBLIC KEY-----
+`
+
+func TestImageSigningPolicy_AppliesTo(t *testing.T) {
+	t.Parallel()
+
+	var disabled *ImageSigningPolicy
+	assert.False(t, disabled.AppliesTo("nginx"))
+	assert.False(t, (&ImageSigningPolicy{Images: []string{"*"}}).AppliesTo("nginx"))
+
+	all := &ImageSigningPolicy{Keys: []SigningKey{{Name: "release", PublicKey: testPublicKey}}}
+	assert.True(t, all.AppliesTo("nginx"))
+	assert.True(t, all.AppliesTo("ghcr.io/acme/app:1@sha256:0000000000000000000000000000000000000000000000000000000000000001"))
+
+	scoped := &ImageSigningPolicy{
+		Images: []string{"ghcr.io/acme/*", "nginx"},
+		Keys:   []SigningKey{{Name: "release", PublicKey: testPublicKey}},
+	}
+	assert.True(t, scoped.AppliesTo("ghcr.io/acme/app:1"))
+	assert.True(t, scoped.AppliesTo("nginx:1.27"))
+	assert.True(t, scoped.AppliesTo("docker.io/library/nginx"))
+	assert.False(t, scoped.AppliesTo("ghcr.io/acme/team/app:1"))
+	assert.False(t, scoped.AppliesTo("caddy:2"))
+}
+
+func TestImageSigningPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  ImageSigningPolicy
+		wantErr string
+	}{
+		{
+			name:   "valid key",
+			policy: ImageSigningPolicy{Keys: []SigningKey{{Name: "release", PublicKey: testPublicKey}}},
+		},
+		{
+			name:    "invalid key",
+			policy:  ImageSigningPolicy{Keys: []SigningKey{{Name: "release", PublicKey: "key"}}},
+			wantErr: "invalid public key 'release'",
+		},
+		{
+			name: "duplicate name",
+			policy: ImageSigningPolicy{
+				Keys: []SigningKey{{Name: "release", PublicKey: testPublicKey}},
+				Identities: []SigningIdentity{
+					{Name: "release", Issuer: "https://accounts.google.com", Subject: "ops@acme.com"},
+				},
+			},
+			wantErr: "duplicate trusted key or identity name 'release'",
+		},
+		{
+			name: "identity without roots",
+			policy: ImageSigningPolicy{Identities: []SigningIdentity{
+				{Name: "ci", Issuer: "https://accounts.google.com", Subject: "ops@acme.com"},
+			}},
+			wantErr: "invalid root certificates of identity 'ci'",
+		},
+		{
+			name:    "invalid pattern",
+			policy:  ImageSigningPolicy{Images: []string{"ghcr.io/[acme"}},
+			wantErr: "invalid image pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package api
+
+import "time"
+
+// VolumeSnapshot is a point-in-time copy of the data of a local Docker volume on a machine.
+type VolumeSnapshot struct {
+	ID         string
+	MachineID  string
+	VolumeName string
+	// Backend is the volume backend that created the snapshot: VolumeBackendTar, VolumeBackendBtrfs,
+	// or VolumeBackendZFS.
+	Backend string
+	// Size is the size of the tar archive in bytes. It's zero for filesystem snapshots.
+	Size      int64 `json:",omitempty"`
+	CreatedAt time.Time
+}
+
+// ServiceRevision is the state of a service before its last deployment that the service can be rolled back to.
+type ServiceRevision struct {
+	ServiceID string
+	Spec      ServiceSpec
+	// Snapshots are the snapshots of the service volumes taken before the deployment if requested.
+	Snapshots []VolumeSnapshot `json:",omitempty"`
+	CreatedAt time.Time
+}
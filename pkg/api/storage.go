@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ObjectStorageServiceName is the name of the managed S3-compatible object storage service. It has the uncloud-
+	// prefix to not clash with a user service named "storage".
+	ObjectStorageServiceName = "uncloud-storage"
+	// ObjectStorageSecretName is the name of the external Compose secret with the object storage endpoint and
+	// credentials that services can use to access the managed object storage.
+	ObjectStorageSecretName = "uncloud-storage"
+	// ObjectStorageVolumeName is the name of the Docker volume the object storage data is stored in on each machine.
+	ObjectStorageVolumeName = "uncloud-storage-data"
+	// ObjectStoragePort is the container port of the S3 API.
+	ObjectStoragePort = 9000
+	// DefaultObjectStorageImage is the MinIO image the object storage service runs by default. It's pinned to
+	// a release so that redeploying the storage doesn't upgrade MinIO unexpectedly.
+	DefaultObjectStorageImage = "minio/minio:RELEASE.2025-04-22T22-12-26Z"
+)
+
+// ObjectStorage is the configuration of the managed S3-compatible object storage running as a cluster service.
+// It's only accessible from the cluster network.
+type ObjectStorage struct {
+	Image string
+	// Machines are the IDs of the machines the storage runs on. The data is erasure-coded across the machines
+	// if there are more than one.
+	Machines []string
+	// Endpoint is the URL of the S3 API within the cluster network, e.g. http://uncloud-storage.internal:9000.
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func (s *ObjectStorage) Validate() error {
+	if s.Image == "" {
+		return errors.New("image must be set")
+	}
+	if len(s.Machines) == 0 {
+		return errors.New("at least one machine must be set")
+	}
+	if s.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	if s.AccessKeyID == "" || s.SecretAccessKey == "" {
+		return errors.New("access key ID and secret access key must be set")
+	}
+	return nil
+}
+
+// Secret returns the content of the ObjectStorageSecretName secret: the endpoint and credentials as environment
+// variables in the KEY=value format recognised by the AWS SDKs and most S3 clients.
+func (s *ObjectStorage) Secret() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "AWS_ENDPOINT_URL=%s\n", s.Endpoint)
+	fmt.Fprintf(&b, "AWS_ACCESS_KEY_ID=%s\n", s.AccessKeyID)
+	fmt.Fprintf(&b, "AWS_SECRET_ACCESS_KEY=%s\n", s.SecretAccessKey)
+	fmt.Fprintf(&b, "AWS_REGION=us-east-1\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-units"
+)
+
+// TenantTokenPrefix is the prefix of the tenant API tokens that tells them apart from other tokens.
+const TenantTokenPrefix = "uct_"
+
+// Tenant is a customer hosted on a shared cluster. The services of a tenant are isolated from the services of other
+// tenants and the services without a tenant: they can only discover each other via the internal DNS and the machines
+// drop the traffic between the containers of different tenants. Only the ingress traffic proxied by Caddy is allowed.
+type Tenant struct {
+	Name  string
+	Quota TenantQuota
+	// Tokens are the API tokens that give read access to the cluster state scoped to the tenant services.
+	Tokens    []TenantToken `json:",omitempty"`
+	CreatedAt time.Time
+}
+
+// TenantQuota limits the resources the containers of a tenant can use. A zero value means no limit. The containers
+// of a tenant with a CPU or memory quota must set the corresponding limit.
+type TenantQuota struct {
+	// CPU is the maximum sum of the CPU limits of the tenant containers in nanocores.
+	CPU int64 `json:",omitempty"`
+	// Memory is the maximum sum of the memory limits of the tenant containers in bytes.
+	Memory int64 `json:",omitempty"`
+	// Containers is the maximum number of the tenant containers.
+	Containers int `json:",omitempty"`
+}
+
+// TenantToken is an API token of a tenant. Only the hash of the token is stored.
+type TenantToken struct {
+	ID string
+	// Hash is the hex-encoded SHA-256 hash of the token.
+	Hash      string
+	CreatedAt time.Time
+}
+
+// TenantUsage is the resources used by the containers of a tenant.
+type TenantUsage struct {
+	CPU        int64
+	Memory     int64
+	Containers int
+}
+
+func (t *Tenant) Validate() error {
+	if len(t.Name) > 32 || !dnsLabelRegexp.MatchString(t.Name) {
+		return fmt.Errorf("invalid tenant name '%s': must be 1-32 characters, lowercase letters, numbers, "+
+			"and dashes only; must start and end with a letter or number", t.Name)
+	}
+	if t.Quota.CPU < 0 || t.Quota.Memory < 0 || t.Quota.Containers < 0 {
+		return errors.New("tenant quota must not be negative")
+	}
+	return nil
+}
+
+// TokenID returns the ID of the tenant token that matches the token or an empty string if none matches.
+func (t *Tenant) TokenID(token string) string {
+	hash := HashTenantToken(token)
+	for _, tt := range t.Tokens {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(tt.Hash)) == 1 {
+			return tt.ID
+		}
+	}
+	return ""
+}
+
+// HashTenantToken returns the hex-encoded SHA-256 hash of the tenant token stored in the cluster.
+func HashTenantToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Add adds a container with the resources to the usage.
+func (u *TenantUsage) Add(res ContainerResources) {
+	u.CPU += res.CPU
+	u.Memory += res.Memory
+	u.Containers++
+}
+
+// CheckSpec returns an error if the service containers don't set the resource limits required by the quota.
+func (q TenantQuota) CheckSpec(spec ServiceSpec) error {
+	if q.CPU > 0 && spec.Container.Resources.CPU <= 0 {
+		return errors.New("tenant has a CPU quota, the service containers must set a CPU limit")
+	}
+	if q.Memory > 0 && spec.Container.Resources.Memory <= 0 {
+		return errors.New("tenant has a memory quota, the service containers must set a memory limit")
+	}
+	return nil
+}
+
+// Check returns an error if the usage exceeds the quota.
+func (q TenantQuota) Check(u TenantUsage) error {
+	if q.Containers > 0 && u.Containers > q.Containers {
+		return fmt.Errorf("tenant quota exceeded: %d containers (quota %d)", u.Containers, q.Containers)
+	}
+	if q.CPU > 0 && u.CPU > q.CPU {
+		return fmt.Errorf("tenant quota exceeded: %.2f CPUs (quota %.2f)",
+			float64(u.CPU)/Core, float64(q.CPU)/Core)
+	}
+	if q.Memory > 0 && u.Memory > q.Memory {
+		return fmt.Errorf("tenant quota exceeded: %s memory (quota %s)",
+			units.BytesSize(float64(u.Memory)), units.BytesSize(float64(q.Memory)))
+	}
+	return nil
+}
+
+// TenantNetwork returns the name of the network of the tenant. Tenant networks are namespaced with a separator
+// that isn't allowed in network names so services without a tenant or with another tenant can't join them.
+func TenantNetwork(tenant, network string) string {
+	return tenant + "/" + network
+}
+
+// Tenant returns the tenant of the service from the spec of its containers. It returns an empty string if the
+// service doesn't belong to a tenant.
+func (s *Service) Tenant() string {
+	for _, ctr := range s.Containers {
+		if tenant := ctr.Container.ServiceSpec.Tenant; tenant != "" {
+			return tenant
+		}
+	}
+	return ""
+}
+
+// validateTenant checks that the service of a tenant doesn't use the features that would break out of the tenant
+// isolation, such as the host network or host paths.
+func (s *ServiceSpec) validateTenant() error {
+	if s.Tenant == "" {
+		return nil
+	}
+	t := Tenant{Name: s.Tenant}
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if s.NetworkMode != "" {
+		return fmt.Errorf("service of tenant '%s' can't use the '%s' network mode", s.Tenant, s.NetworkMode)
+	}
+	if s.Container.Privileged || len(s.Container.CapAdd) > 0 || len(s.Container.Devices) > 0 {
+		return fmt.Errorf("service of tenant '%s' can't run privileged containers, add capabilities, "+
+			"or access host devices", s.Tenant)
+	}
+	for _, v := range s.Volumes {
+		if v.Type == VolumeTypeBind {
+			return fmt.Errorf("service of tenant '%s' can't mount host paths: volume '%s'", s.Tenant, v.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenant_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&Tenant{Name: "acme-1"}).Validate())
+	assert.ErrorContains(t, (&Tenant{Name: "Acme"}).Validate(), "invalid tenant name")
+	assert.ErrorContains(t, (&Tenant{Name: "a/b"}).Validate(), "invalid tenant name")
+	assert.ErrorContains(t, (&Tenant{Name: "acme", Quota: TenantQuota{Containers: -1}}).Validate(), "negative")
+}
+
+func TestTenant_TokenID(t *testing.T) {
+	t.Parallel()
+
+	tenant := Tenant{Name: "acme", Tokens: []TenantToken{
+		{ID: "t1", Hash: HashTenantToken("uct_one")},
+		{ID: "t2", Hash: HashTenantToken("uct_two")},
+	}}
+	assert.Equal(t, "t2", tenant.TokenID("uct_two"))
+	assert.Empty(t, tenant.TokenID("uct_three"))
+	assert.Empty(t, tenant.TokenID(""))
+}
+
+func TestTenantQuota_Check(t *testing.T) {
+	t.Parallel()
+
+	quota := TenantQuota{CPU: 2 * Core, Memory: 1 << 30, Containers: 3}
+	var usage TenantUsage
+	usage.Add(ContainerResources{CPU: Core, Memory: 512 << 20})
+	usage.Add(ContainerResources{CPU: Core / 2, Memory: 256 << 20})
+	require.NoError(t, quota.Check(usage))
+
+	usage.Add(ContainerResources{CPU: Core, Memory: 128 << 20})
+	assert.ErrorContains(t, quota.Check(usage), "2.50 CPUs (quota 2.00)")
+
+	usage.Add(ContainerResources{})
+	assert.ErrorContains(t, quota.Check(usage), "4 containers (quota 3)")
+
+	assert.NoError(t, TenantQuota{}.Check(usage), "zero quota is unlimited")
+}
+
+func TestTenantQuota_CheckSpec(t *testing.T) {
+	t.Parallel()
+
+	spec := ServiceSpec{Container: ContainerSpec{Resources: ContainerResources{CPU: Core}}}
+	assert.NoError(t, TenantQuota{CPU: Core}.CheckSpec(spec))
+	assert.ErrorContains(t, TenantQuota{Memory: 1 << 30}.CheckSpec(spec), "must set a memory limit")
+	assert.NoError(t, TenantQuota{Containers: 1}.CheckSpec(ServiceSpec{}))
+}
+
+func TestServiceSpec_ValidateTenant(t *testing.T) {
+	t.Parallel()
+
+	base := func() ServiceSpec {
+		return ServiceSpec{
+			Name:      "web",
+			Tenant:    "acme",
+			Container: ContainerSpec{Image: "nginx"},
+		}
+	}
+
+	spec := base()
+	assert.NoError(t, spec.Validate())
+
+	spec = base()
+	spec.NetworkMode = NetworkModeHost
+	assert.ErrorContains(t, spec.Validate(), "network mode")
+
+	spec = base()
+	spec.Container.Privileged = true
+	assert.ErrorContains(t, spec.Validate(), "privileged")
+
+	spec = base()
+	spec.Volumes = []VolumeSpec{{Name: "data", Type: VolumeTypeBind, BindOptions: &BindOptions{HostPath: "/etc"}}}
+	spec.Container.VolumeMounts = []VolumeMount{{VolumeName: "data", ContainerPath: "/data"}}
+	assert.ErrorContains(t, spec.Validate(), "can't mount host paths")
+
+	spec = base()
+	spec.Tenant = "Acme"
+	assert.ErrorContains(t, spec.Validate(), "invalid tenant name")
+}
+
+func TestServiceSpec_ServiceNetworksTenant(t *testing.T) {
+	t.Parallel()
+
+	spec := ServiceSpec{Tenant: "acme"}
+	assert.Equal(t, []string{"acme/default"}, spec.ServiceNetworks())
+
+	spec.Networks = []string{"backend", "frontend"}
+	assert.Equal(t, []string{"acme/backend", "acme/frontend"}, spec.ServiceNetworks())
+	assert.False(t, NetworksIntersect(spec.ServiceNetworks(), (&ServiceSpec{Networks: spec.Networks}).ServiceNetworks()))
+}
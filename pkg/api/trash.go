@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"time"
+)
+
+// TrashedService is a removed service whose containers are stopped but kept for the trash retention period from
+// the cluster settings. It can be restored until it expires. Then the machines garbage collect its containers.
+type TrashedService struct {
+	// ID is the ID of the trashed service.
+	ID   string
+	Name string
+	// TrashedAt is the time when the service was removed. Only the containers created before this time are
+	// garbage collected, so a service redeployed with the same ID isn't affected.
+	TrashedAt time.Time
+	// ExpiresAt is the time after which the service can no longer be restored.
+	ExpiresAt time.Time
+}
+
+// Expired returns true if the retention period of the trashed service has passed at the given time.
+func (s *TrashedService) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// Contains returns true if the container was created before the service was trashed. Containers created later
+// belong to the service redeployed after it was trashed and aren't part of the trash.
+func (s *TrashedService) Contains(ctr Container) bool {
+	created, err := time.Parse(time.RFC3339Nano, ctr.Created)
+	if err != nil {
+		return false
+	}
+	return created.Before(s.TrashedAt)
+}
+
+// ContainsService returns true if the service is in the trash, that is, all its containers are in the trash.
+func (s *TrashedService) ContainsService(svc Service) bool {
+	if svc.ID != s.ID {
+		return false
+	}
+	for _, ctr := range svc.Containers {
+		if !s.Contains(ctr.Container.Container) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TrashedService) Validate() error {
+	if s.ID == "" {
+		return errors.New("service ID must not be empty")
+	}
+	if s.Name == "" {
+		return errors.New("service name must not be empty")
+	}
+	if s.ExpiresAt.Before(s.TrashedAt) {
+		return errors.New("expiration time must not be before the time the service was trashed")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashedService_ContainsService(t *testing.T) {
+	t.Parallel()
+
+	trashedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	trashed := TrashedService{ID: "svc-id", Name: "web", TrashedAt: trashedAt, ExpiresAt: trashedAt.Add(time.Hour)}
+
+	serviceWithContainers := func(id string, created ...time.Time) Service {
+		svc := Service{ID: id, Name: "web"}
+		for _, c := range created {
+			ctr := Container{ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{Created: c.Format(time.RFC3339Nano)},
+			}}
+			svc.Containers = append(svc.Containers, MachineServiceContainer{
+				Container: ServiceContainer{Container: ctr},
+			})
+		}
+		return svc
+	}
+
+	before, after := trashedAt.Add(-time.Minute), trashedAt.Add(time.Minute)
+	assert.True(t, trashed.ContainsService(serviceWithContainers("svc-id", before, before)))
+	assert.False(t, trashed.ContainsService(serviceWithContainers("other-id", before)),
+		"different service ID")
+	assert.False(t, trashed.ContainsService(serviceWithContainers("svc-id", before, after)),
+		"service redeployed after it was trashed")
+
+	assert.False(t, trashed.Expired(trashedAt.Add(30*time.Minute)))
+	assert.True(t, trashed.Expired(trashedAt.Add(time.Hour)))
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// UpdateOrderStartFirst starts a new container before stopping the old one it replaces.
+	UpdateOrderStartFirst = "start-first"
+	// UpdateOrderStopFirst stops the old container before starting a new one to replace it.
+	UpdateOrderStopFirst = "stop-first"
+
+	// UpdateFailureActionPause stops updating the remaining containers when the failure ratio is exceeded.
+	UpdateFailureActionPause = "pause"
+	// UpdateFailureActionContinue continues updating the remaining containers regardless of failures.
+	UpdateFailureActionContinue = "continue"
+	// UpdateFailureActionRollback reverts all the updated containers to the previous service spec when the failure
+	// ratio is exceeded.
+	UpdateFailureActionRollback = "rollback"
+)
+
+// UpdateConfig defines how the containers of a service are updated by a rolling deployment. It mirrors the semantics
+// of deploy.update_config in the Compose specification.
+type UpdateConfig struct {
+	// Parallelism is the maximum number of containers updated simultaneously. Default is 1 if nil.
+	// Zero means all containers are updated at once.
+	Parallelism *uint `json:",omitempty"`
+	// Delay is the time to wait between updating batches of containers.
+	Delay time.Duration `json:",omitempty"`
+	// FailureAction is the action to take when the failure ratio is exceeded: UpdateFailureActionPause,
+	// UpdateFailureActionContinue, or UpdateFailureActionRollback. Default is UpdateFailureActionPause if empty.
+	FailureAction string `json:",omitempty"`
+	// Monitor is the time to monitor each new container after it's started. The container update fails if it exits
+	// or becomes unhealthy within this time. If zero, only the failures to create or start a container are detected.
+	Monitor time.Duration `json:",omitempty"`
+	// MaxFailureRatio is the fraction of failed container updates (from 0 to 1) tolerated before the FailureAction
+	// is taken. Default is 0 meaning any failure triggers the FailureAction.
+	MaxFailureRatio float64 `json:",omitempty"`
+	// Order is the order of operations when replacing a container: UpdateOrderStartFirst or UpdateOrderStopFirst.
+	// Default is UpdateOrderStartFirst if empty. The old container is always stopped first if it binds to the same
+	// host ports as the new one.
+	Order string `json:",omitempty"`
+}
+
+// BatchSize returns the number of containers to update simultaneously out of total container updates.
+func (c *UpdateConfig) BatchSize(total int) int {
+	if c.Parallelism == nil {
+		return 1
+	}
+	if *c.Parallelism == 0 || int(*c.Parallelism) > total {
+		return max(total, 1)
+	}
+	return int(*c.Parallelism)
+}
+
+func (c *UpdateConfig) Validate() error {
+	switch c.Order {
+	case "", UpdateOrderStartFirst, UpdateOrderStopFirst:
+	default:
+		return fmt.Errorf("invalid update order: %q", c.Order)
+	}
+	switch c.FailureAction {
+	case "", UpdateFailureActionPause, UpdateFailureActionContinue, UpdateFailureActionRollback:
+	default:
+		return fmt.Errorf("invalid update failure action: %q", c.FailureAction)
+	}
+	if c.MaxFailureRatio < 0 || c.MaxFailureRatio > 1 {
+		return fmt.Errorf("invalid update max failure ratio: %v, must be between 0 and 1", c.MaxFailureRatio)
+	}
+	if c.Delay < 0 {
+		return fmt.Errorf("update delay cannot be negative: %s", c.Delay)
+	}
+	if c.Monitor < 0 {
+		return fmt.Errorf("update monitor period cannot be negative: %s", c.Monitor)
+	}
+	return nil
+}
+
+func (c *UpdateConfig) Clone() *UpdateConfig {
+	if c == nil {
+		return nil
+	}
+	cfg := *c
+	if c.Parallelism != nil {
+		parallelism := *c.Parallelism
+		cfg.Parallelism = &parallelism
+	}
+	return &cfg
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// UptimeCheck is the result of probing a published service endpoint by the uptime checker running on the cluster
+// machines.
+type UptimeCheck struct {
+	ServiceName string
+	URL         string
+	// MachineID is the ID of the machine that probed the endpoint.
+	MachineID string
+	CheckedAt time.Time
+	// StatusCode is the HTTP status code of the response. Zero if the request failed.
+	StatusCode int
+	Latency    time.Duration
+	// Error is the error message if the request failed.
+	Error string
+}
+
+// Up returns true if the endpoint responded with a non-5xx status code.
+func (c UptimeCheck) Up() bool {
+	return c.Error == "" && c.StatusCode > 0 && c.StatusCode < http.StatusInternalServerError
+}
@@ -23,6 +23,15 @@ const (
 
 	// VolumeDriverLocal is the default volume driver for local named Docker volumes.
 	VolumeDriverLocal = "local"
+
+	// VolumeBackendTar manages the data of a local volume as a plain directory snapshotted and transferred as
+	// a compressed tar archive.
+	VolumeBackendTar = "tar"
+	// VolumeBackendBtrfs manages the data of a local volume as a btrfs subvolume using native snapshots
+	// and send/receive.
+	VolumeBackendBtrfs = "btrfs"
+	// VolumeBackendZFS manages the data of a local volume as a ZFS dataset using native snapshots and send/receive.
+	VolumeBackendZFS = "zfs"
 )
 
 // VolumeSpec defines a volume mount specification. As of April 2025, the volume must be created before deploying
@@ -242,6 +251,11 @@ type MachineVolume struct {
 	Volume volume.Volume
 }
 
+// Protected returns true if the volume is protected from removal with the LabelProtected label.
+func (v *MachineVolume) Protected() bool {
+	return v.Volume.Labels[LabelProtected] == "true"
+}
+
 // VolumeFilter defines criteria to filter volumes in ListVolumes.
 type VolumeFilter struct {
 	// Driver filters volumes by storage driver name.
@@ -0,0 +1,303 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// caddyAccessLogger is the name prefix of the Caddy loggers that write access logs enabled with the 'log' directive.
+const caddyAccessLogger = "http.log.access"
+
+// caddyAccessLog is an access log entry written by Caddy in the JSON format.
+type caddyAccessLog struct {
+	Logger  string  `json:"logger"`
+	TS      float64 `json:"ts"`
+	Request struct {
+		RemoteIP string `json:"remote_ip"`
+		ClientIP string `json:"client_ip"`
+		Proto    string `json:"proto"`
+		Method   string `json:"method"`
+		Host     string `json:"host"`
+		URI      string `json:"uri"`
+	} `json:"request"`
+	Status   int     `json:"status"`
+	Size     int     `json:"size"`
+	Duration float64 `json:"duration"`
+}
+
+// ServiceAccessLogs streams the ingress access logs of the service from the Caddy containers on all machines.
+// The access logs are filtered by the hostnames of the HTTP(S) ports published by the service. If the service is
+// the Caddy service itself, the access logs for all hostnames are streamed. The returned channel is closed when
+// the log streams of all Caddy containers end or the context is canceled.
+func (cli *Client) ServiceAccessLogs(
+	ctx context.Context, serviceNameOrID string, opts api.AccessLogsOptions,
+) (<-chan api.AccessLogEntry, error) {
+	statusFilter, err := parseStatusFilter(opts.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := cli.InspectService(ctx, serviceNameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect service: %w", err)
+	}
+	var hosts []string
+	if svc.Name != CaddyServiceName {
+		if len(svc.Containers) == 0 {
+			return nil, fmt.Errorf("service '%s' has no containers", svc.Name)
+		}
+		// TODO: take into account hostnames from the custom Caddy config (x-caddy) of the service.
+		for _, p := range svc.Containers[0].Container.ServiceSpec.Ports {
+			if p.Mode == api.PortModeIngress && p.Hostname != "" {
+				hosts = append(hosts, strings.ToLower(p.Hostname))
+			}
+		}
+		if len(hosts) == 0 {
+			return nil, fmt.Errorf("service '%s' doesn't publish any HTTP(S) ports with a hostname", svc.Name)
+		}
+	}
+
+	logsCh, err := cli.ServiceLogs(ctx, CaddyServiceName, api.ServiceLogsOptions{
+		Follow: opts.Follow,
+		Since:  opts.Since,
+		Until:  opts.Until,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stream logs of service '%s': %w", CaddyServiceName, err)
+	}
+
+	filter := accessLogFilter{
+		hosts:      hosts,
+		statuses:   statusFilter,
+		pathPrefix: opts.PathPrefix,
+	}
+	ch := make(chan api.AccessLogEntry)
+	go func() {
+		defer close(ch)
+
+		// Log entries are chunks of the container output that may contain incomplete lines, so buffer the output
+		// of each container until a full line is received.
+		buffers := make(map[string]*bytes.Buffer)
+		for e := range logsCh {
+			if e.Err != nil {
+				if !sendAccessLogEntry(ctx, ch, api.AccessLogEntry{MachineName: e.MachineName, Err: e.Err}) {
+					return
+				}
+				continue
+			}
+
+			buf, ok := buffers[e.ContainerID]
+			if !ok {
+				buf = &bytes.Buffer{}
+				buffers[e.ContainerID] = buf
+			}
+			buf.Write(e.Data)
+			for {
+				i := bytes.IndexByte(buf.Bytes(), '\n')
+				if i < 0 {
+					break
+				}
+				line := buf.Next(i + 1)
+
+				entry, ok := parseAccessLog(line)
+				if !ok || !filter.match(entry) {
+					continue
+				}
+				entry.MachineName = e.MachineName
+				if !sendAccessLogEntry(ctx, ch, entry) {
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// ServiceRequestMetrics returns the request metrics of the service for each hostname calculated from the ingress
+// access logs over the period until now.
+func (cli *Client) ServiceRequestMetrics(
+	ctx context.Context, serviceNameOrID string, period time.Duration,
+) ([]api.RequestMetrics, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("period must be positive")
+	}
+
+	entries, err := cli.ServiceAccessLogs(ctx, serviceNameOrID, api.AccessLogsOptions{Since: period.String()})
+	if err != nil {
+		return nil, err
+	}
+	var logs []api.AccessLogEntry
+	// Drain the channel even if streaming from a Caddy container fails to not leak the streaming goroutine.
+	for e := range entries {
+		if e.Err != nil {
+			err = errors.Join(err, e.Err)
+			continue
+		}
+		logs = append(logs, e)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return requestMetrics(logs, period), nil
+}
+
+// requestMetrics aggregates the access log entries by hostname and calculates the request metrics over the period.
+// The returned metrics are sorted by hostname.
+func requestMetrics(entries []api.AccessLogEntry, period time.Duration) []api.RequestMetrics {
+	durations := make(map[string][]time.Duration)
+	metrics := make(map[string]*api.RequestMetrics)
+	for _, e := range entries {
+		host := e.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.ToLower(host)
+
+		m, ok := metrics[host]
+		if !ok {
+			m = &api.RequestMetrics{Host: host}
+			metrics[host] = m
+		}
+		m.Requests++
+		if class := e.Status / 100; class > 0 && class < len(m.StatusClasses) {
+			m.StatusClasses[class]++
+		}
+		durations[host] = append(durations[host], e.Duration)
+	}
+
+	result := make([]api.RequestMetrics, 0, len(metrics))
+	for _, host := range slices.Sorted(maps.Keys(metrics)) {
+		m := metrics[host]
+		m.Rate = float64(m.Requests) / period.Seconds()
+
+		d := durations[host]
+		slices.Sort(d)
+		m.P50, m.P95, m.P99 = percentile(d, 50), percentile(d, 95), percentile(d, 99)
+
+		result = append(result, *m)
+	}
+
+	return result
+}
+
+// percentile returns the p-th percentile of the sorted durations using the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// parseAccessLog parses a Caddy log line and returns the access log entry. It returns false if the line is not
+// a valid access log entry.
+func parseAccessLog(line []byte) (api.AccessLogEntry, bool) {
+	var log caddyAccessLog
+	if err := json.Unmarshal(line, &log); err != nil {
+		return api.AccessLogEntry{}, false
+	}
+	if log.Logger != caddyAccessLogger && !strings.HasPrefix(log.Logger, caddyAccessLogger+".") {
+		return api.AccessLogEntry{}, false
+	}
+
+	sec, frac := math.Modf(log.TS)
+	entry := api.AccessLogEntry{
+		Time:     time.Unix(int64(sec), int64(frac*1e9)).UTC(),
+		RemoteIP: log.Request.ClientIP,
+		Proto:    log.Request.Proto,
+		Method:   log.Request.Method,
+		Host:     log.Request.Host,
+		URI:      log.Request.URI,
+		Status:   log.Status,
+		Size:     log.Size,
+		Duration: time.Duration(log.Duration * float64(time.Second)),
+	}
+	// The client IP is only set by newer Caddy versions.
+	if entry.RemoteIP == "" {
+		entry.RemoteIP = log.Request.RemoteIP
+	}
+
+	return entry, true
+}
+
+// accessLogFilter filters access log entries by hostnames, response statuses, and request path prefix.
+// Empty filters match all entries.
+type accessLogFilter struct {
+	hosts []string
+	// statuses contains status codes (e.g. 404) or classes (e.g. 5 for 5xx) if less than 10.
+	statuses   []int
+	pathPrefix string
+}
+
+func (f accessLogFilter) match(entry api.AccessLogEntry) bool {
+	if len(f.hosts) > 0 {
+		host := entry.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !slices.Contains(f.hosts, strings.ToLower(host)) {
+			return false
+		}
+	}
+
+	if len(f.statuses) > 0 && !slices.ContainsFunc(f.statuses, func(s int) bool {
+		return s == entry.Status || s == entry.Status/100
+	}) {
+		return false
+	}
+
+	if f.pathPrefix != "" {
+		path, _, _ := strings.Cut(entry.URI, "?")
+		if !strings.HasPrefix(path, f.pathPrefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseStatusFilter parses the status codes (e.g. 404) and classes (e.g. 5xx) to filter access logs by.
+func parseStatusFilter(statuses []string) ([]int, error) {
+	filter := make([]int, 0, len(statuses))
+	for _, s := range statuses {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if len(s) == 3 && strings.HasSuffix(s, "xx") && s[0] >= '1' && s[0] <= '5' {
+			filter = append(filter, int(s[0]-'0'))
+			continue
+		}
+
+		code, err := strconv.Atoi(s)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid status '%s': expected a status code (e.g. 404) or class (e.g. 5xx)", s)
+		}
+		filter = append(filter, code)
+	}
+
+	return filter, nil
+}
+
+func sendAccessLogEntry(ctx context.Context, ch chan<- api.AccessLogEntry, entry api.AccessLogEntry) bool {
+	select {
+	case ch <- entry:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
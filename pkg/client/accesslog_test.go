@@ -0,0 +1,107 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccessLog(t *testing.T) {
+	t.Parallel()
+
+	line := `{"level":"info","ts":1760522400.5,"logger":"http.log.access.log1","msg":"handled request",` +
+		`"request":{"remote_ip":"10.210.0.1","remote_port":"41342","client_ip":"203.0.113.5","proto":"HTTP/2.0",` +
+		`"method":"GET","host":"app.example.com","uri":"/api/users?page=2"},"bytes_read":0,"duration":0.0125,` +
+		`"size":512,"status":404}`
+	entry, ok := parseAccessLog([]byte(line))
+	require.True(t, ok)
+	assert.Equal(t, api.AccessLogEntry{
+		Time:     time.Unix(1760522400, 500_000_000).UTC(),
+		RemoteIP: "203.0.113.5",
+		Proto:    "HTTP/2.0",
+		Method:   "GET",
+		Host:     "app.example.com",
+		URI:      "/api/users?page=2",
+		Status:   404,
+		Size:     512,
+		Duration: 12500 * time.Microsecond,
+	}, entry)
+
+	_, ok = parseAccessLog([]byte(`{"level":"info","ts":1760522400.5,"logger":"tls","msg":"certificate obtained"}`))
+	assert.False(t, ok, "non-access log entry")
+	_, ok = parseAccessLog([]byte("not a json line\n"))
+	assert.False(t, ok, "non-JSON line")
+}
+
+func TestAccessLogFilter(t *testing.T) {
+	t.Parallel()
+
+	statuses, err := parseStatusFilter([]string{"404", "5xx"})
+	require.NoError(t, err)
+	filter := accessLogFilter{
+		hosts:      []string{"app.example.com"},
+		statuses:   statuses,
+		pathPrefix: "/api/",
+	}
+
+	entry := func(host string, status int, uri string) api.AccessLogEntry {
+		return api.AccessLogEntry{Host: host, Status: status, URI: uri}
+	}
+	assert.True(t, filter.match(entry("app.example.com", 404, "/api/users")))
+	assert.True(t, filter.match(entry("APP.example.com:443", 503, "/api/users?page=2")))
+	assert.False(t, filter.match(entry("other.example.com", 404, "/api/users")), "other host")
+	assert.False(t, filter.match(entry("app.example.com", 200, "/api/users")), "other status")
+	assert.False(t, filter.match(entry("app.example.com", 404, "/static/app.js")), "other path")
+	assert.False(t, filter.match(entry("app.example.com", 404, "/static?p=/api/")), "path in query")
+
+	assert.True(t, accessLogFilter{}.match(entry("any.example.com", 200, "/")), "empty filter")
+
+	for _, s := range []string{"abc", "600", "6xx", "x04"} {
+		_, err = parseStatusFilter([]string{s})
+		assert.ErrorContains(t, err, "invalid status", s)
+	}
+}
+
+func TestRequestMetrics(t *testing.T) {
+	t.Parallel()
+
+	var entries []api.AccessLogEntry
+	for i := 1; i <= 100; i++ {
+		status := 200
+		if i%10 == 0 {
+			status = 503
+		}
+		entries = append(entries, api.AccessLogEntry{
+			Host:     "app.example.com",
+			Status:   status,
+			Duration: time.Duration(i) * time.Millisecond,
+		})
+	}
+	entries = append(entries, api.AccessLogEntry{Host: "API.example.com:443", Status: 404, Duration: time.Second})
+
+	metrics := requestMetrics(entries, time.Minute)
+	require.Len(t, metrics, 2)
+	assert.Equal(t, api.RequestMetrics{
+		Host:          "api.example.com",
+		Requests:      1,
+		Rate:          1.0 / 60,
+		P50:           time.Second,
+		P95:           time.Second,
+		P99:           time.Second,
+		StatusClasses: [6]int{4: 1},
+	}, metrics[0])
+	assert.Equal(t, api.RequestMetrics{
+		Host:          "app.example.com",
+		Requests:      100,
+		Rate:          100.0 / 60,
+		P50:           50 * time.Millisecond,
+		P95:           95 * time.Millisecond,
+		P99:           99 * time.Millisecond,
+		StatusClasses: [6]int{2: 90, 5: 10},
+	}, metrics[1])
+
+	assert.Empty(t, requestMetrics(nil, time.Minute))
+}
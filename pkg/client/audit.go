@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListExecSessions returns the interactive exec sessions recorded on each available machine sorted by start time.
+// The results contain an error for the machines that are DOWN or failed to respond.
+func (cli *Client) ListExecSessions(ctx context.Context) ([]api.MachineResult[[]api.ExecSession], error) {
+	machines, err := cli.ListMachines(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+	dockerClient := pb.NewDockerClient(cli.conn)
+
+	return ForEachMachine(ctx, machines,
+		func(ctx context.Context, m *pb.MachineMember) ([]api.ExecSession, error) {
+			resp, err := dockerClient.ListExecSessions(ctx, &emptypb.Empty{})
+			if err != nil {
+				return nil, err
+			}
+			sessions := make([]api.ExecSession, len(resp.Sessions))
+			for i, s := range resp.Sessions {
+				sessions[i] = api.ExecSession{
+					ID:          s.Id,
+					MachineID:   m.Machine.Id,
+					ContainerID: s.ContainerId,
+					ServiceName: s.ServiceName,
+					Command:     s.Command,
+					User:        s.User,
+					StartedAt:   s.StartedAt.AsTime(),
+					ExitCode:    int(s.ExitCode),
+				}
+				if s.EndedAt != nil {
+					sessions[i].EndedAt = s.EndedAt.AsTime()
+				}
+			}
+			return sessions, nil
+		}), nil
+}
+
+// GetExecSession returns the recorded exec session with the given ID or ErrNotFound if no available machine
+// has recorded it.
+func (cli *Client) GetExecSession(ctx context.Context, id string) (api.ExecSession, error) {
+	results, err := cli.ListExecSessions(ctx)
+	if err != nil {
+		return api.ExecSession{}, err
+	}
+	for _, r := range results {
+		if i := slices.IndexFunc(r.Value, func(s api.ExecSession) bool { return s.ID == id }); i != -1 {
+			return r.Value[i], nil
+		}
+	}
+	return api.ExecSession{}, api.ErrNotFound
+}
+
+// ExecSessionRecording writes the asciinema v2 recording of the exec session to w. The recording is streamed
+// from the machine that recorded the session.
+func (cli *Client) ExecSessionRecording(ctx context.Context, session api.ExecSession, w io.Writer) error {
+	machine, err := cli.InspectMachine(ctx, session.MachineID)
+	if err != nil {
+		return fmt.Errorf("inspect machine '%s': %w", session.MachineID, err)
+	}
+	ctx = proxyToMachine(ctx, machine.Machine)
+
+	stream, err := pb.NewDockerClient(cli.conn).GetExecSessionRecording(
+		ctx, &pb.GetExecSessionRecordingRequest{Id: session.ID})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if _, err = w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetAutoUpdate returns the automatic OS updates configuration and the update statuses of the machines.
+func (cli *Client) GetAutoUpdate(ctx context.Context) (api.AutoUpdate, error) {
+	resp, err := cli.ClusterClient.GetAutoUpdate(ctx, &emptypb.Empty{})
+	if err != nil {
+		return api.AutoUpdate{}, err
+	}
+
+	au := api.AutoUpdate{
+		Enabled:  resp.Config.GetEnabled(),
+		Window:   resp.Config.GetWindow(),
+		Machines: make([]api.MachineUpdate, len(resp.Machines)),
+	}
+	for i, m := range resp.Machines {
+		au.Machines[i] = api.MachineUpdate{
+			MachineID:   m.MachineId,
+			WindowStart: m.WindowStart.AsTime(),
+			State:       m.State,
+			Error:       m.Error,
+			UpdatedAt:   m.UpdatedAt.AsTime(),
+		}
+	}
+
+	return au, nil
+}
+
+// SetAutoUpdate enables or disables automatic OS updates of the cluster machines within the maintenance window.
+// The window is kept unchanged if empty.
+func (cli *Client) SetAutoUpdate(ctx context.Context, enabled bool, window string) error {
+	_, err := cli.ClusterClient.SetAutoUpdate(ctx, &pb.AutoUpdateConfig{
+		Enabled: enabled,
+		Window:  window,
+	})
+	return err
+}
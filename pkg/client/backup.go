@@ -0,0 +1,164 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/dbbackup"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetBackupStorage returns the configuration of the object storage for service backups without the secret access key
+// or ErrNotFound if it hasn't been configured yet.
+func (cli *Client) GetBackupStorage(ctx context.Context) (api.BackupStorage, error) {
+	resp, err := cli.ClusterClient.GetBackupStorage(ctx, &emptypb.Empty{})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.BackupStorage{}, api.ErrNotFound
+		}
+		return api.BackupStorage{}, err
+	}
+
+	return api.BackupStorage{
+		Endpoint:    resp.Endpoint,
+		Region:      resp.Region,
+		Bucket:      resp.Bucket,
+		Prefix:      resp.Prefix,
+		AccessKeyID: resp.AccessKeyId,
+	}, nil
+}
+
+// SetBackupStorage configures the object storage for service backups.
+func (cli *Client) SetBackupStorage(ctx context.Context, config api.BackupStorage) error {
+	_, err := cli.ClusterClient.SetBackupStorage(ctx, &pb.BackupStorage{
+		Endpoint:        config.Endpoint,
+		Region:          config.Region,
+		Bucket:          config.Bucket,
+		Prefix:          config.Prefix,
+		AccessKeyId:     config.AccessKeyID,
+		SecretAccessKey: config.SecretAccessKey,
+	})
+	return err
+}
+
+// ListBackups returns the backups of the service with the given name in the backup storage sorted by creation time,
+// newest first. The service doesn't have to exist in the cluster, e.g. to restore it after it has been removed.
+// The storage is accessed by the machine the client is connected to.
+func (cli *Client) ListBackups(ctx context.Context, serviceName string) ([]api.Backup, error) {
+	resp, err := cli.ClusterClient.ListBackups(ctx, &pb.ListBackupsRequest{ServiceName: serviceName})
+	if err != nil {
+		return nil, fmt.Errorf("list backups: %w", err)
+	}
+
+	var backups []api.Backup
+	if err = json.Unmarshal(resp.Backups, &backups); err != nil {
+		return nil, fmt.Errorf("unmarshal backups: %w", err)
+	}
+	return backups, nil
+}
+
+// RestoreBackup restores the backup with the given key into a running container of the service by piping
+// the backup into the restore command of the backup engine. The latest backup of the service is restored if the key
+// is empty. It returns the restored backup.
+func (cli *Client) RestoreBackup(ctx context.Context, serviceNameOrID, key string) (api.Backup, error) {
+	svc, err := cli.InspectService(ctx, serviceNameOrID)
+	if err != nil {
+		return api.Backup{}, fmt.Errorf("inspect service '%s': %w", serviceNameOrID, err)
+	}
+	storage, err := cli.GetBackupStorage(ctx)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return api.Backup{}, errors.New("backup storage not configured, configure it with 'uc backup storage set'")
+		}
+		return api.Backup{}, fmt.Errorf("get backup storage config: %w", err)
+	}
+
+	var b api.Backup
+	if key == "" {
+		backups, err := cli.ListBackups(ctx, svc.Name)
+		if err != nil {
+			return b, err
+		}
+		if len(backups) == 0 {
+			return b, fmt.Errorf("no backups found for service '%s'", svc.Name)
+		}
+		b = backups[0]
+	} else if b, err = dbbackup.ParseKey(storage.Prefix, key); err != nil {
+		return b, err
+	}
+	engine, err := dbbackup.EngineByName(b.Engine)
+	if err != nil {
+		return b, err
+	}
+
+	// Restore into the same container the backups are created from if the service has multiple containers.
+	running := slices.DeleteFunc(slices.Clone(svc.Containers), func(c api.MachineServiceContainer) bool {
+		return !c.Container.State.Running
+	})
+	if len(running) == 0 {
+		return b, fmt.Errorf("service '%s' has no running containers to restore the backup to", svc.Name)
+	}
+	ctr := slices.MinFunc(running, func(a, b api.MachineServiceContainer) int {
+		return strings.Compare(a.Container.ID, b.Container.ID)
+	})
+
+	// Cancel the download if the restore command fails before reading the whole backup.
+	downloadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := cli.ClusterClient.DownloadBackup(downloadCtx, &pb.DownloadBackupRequest{Key: b.Key})
+	if err != nil {
+		return b, fmt.Errorf("download backup: %w", err)
+	}
+	gz, err := gzip.NewReader(&backupReader{stream: stream})
+	if err != nil {
+		return b, fmt.Errorf("decompress backup: %w", err)
+	}
+
+	var stderr strings.Builder
+	exitCode, err := cli.ExecContainer(ctx, svc.ID, ctr.Container.ID, api.ExecOptions{
+		Cmd:    engine.RestoreCmd,
+		Stdin:  gz,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return b, fmt.Errorf("run restore command: %w", err)
+	}
+	if exitCode != 0 {
+		return b, fmt.Errorf("restore command exited with code %d: %s", exitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	if len(engine.PostRestoreCmd) > 0 {
+		// The command may stop the container so its result is ignored.
+		_, _ = cli.ExecContainer(ctx, svc.ID, ctr.Container.ID, api.ExecOptions{Cmd: engine.PostRestoreCmd})
+	}
+	return b, nil
+}
+
+// backupReader reads the content of a backup streamed by DownloadBackup.
+type backupReader struct {
+	stream grpc.ServerStreamingClient[pb.BackupChunk]
+	buf    []byte
+}
+
+func (r *backupReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
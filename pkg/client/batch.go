@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxMachineConcurrency is the maximum number of machines ForEachMachine runs the operation on concurrently.
+const maxMachineConcurrency = 16
+
+// errMachineDown is returned for the machines that are DOWN and therefore not contacted by the operations
+// on multiple machines.
+var errMachineDown = errors.New("machine is down")
+
+// ForEachMachine runs fn concurrently for each machine with a context that proxies gRPC requests to the machine
+// through the machine the client is connected to, so the operation doesn't require a separate connection to each
+// machine. The results are returned in the order of the machines. fn is not called for the machines that are DOWN
+// and their results contain an error instead.
+func ForEachMachine[T any](
+	ctx context.Context,
+	machines api.MachineMembersList,
+	fn func(ctx context.Context, m *pb.MachineMember) (T, error),
+) []api.MachineResult[T] {
+	results := make([]api.MachineResult[T], len(machines))
+	sem := make(chan struct{}, maxMachineConcurrency)
+	var wg sync.WaitGroup
+
+	for i, m := range machines {
+		results[i].Machine = toMachine(m)
+		if !results[i].Machine.Available() {
+			results[i].Err = errMachineDown
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i].Value, results[i].Err = fn(proxyToMachine(ctx, m.Machine), m)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// BatchInspectMachines returns the live state of the machines that match the filter. All available machines are
+// inspected concurrently with a single request broadcast through the machine the client is connected to.
+// The results are sorted by machine name and contain an error for the machines that are DOWN or failed to respond,
+// e.g. because they run an older daemon version that doesn't support the request.
+func (cli *Client) BatchInspectMachines(
+	ctx context.Context, filter *api.MachineFilter,
+) ([]api.MachineResult[api.MachineInspection], error) {
+	machines, err := cli.ListMachines(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+
+	results := make([]api.MachineResult[api.MachineInspection], len(machines))
+	var available []string
+	for i, m := range machines {
+		results[i].Machine = toMachine(m)
+		if results[i].Machine.Available() {
+			available = append(available, m.Machine.Id)
+		} else {
+			results[i].Err = errMachineDown
+		}
+	}
+	slices.SortFunc(results, func(a, b api.MachineResult[api.MachineInspection]) int {
+		return strings.Compare(a.Machine.Name, b.Machine.Name)
+	})
+	if len(available) == 0 {
+		return results, nil
+	}
+
+	batchCtx, proxiedMachines, err := api.ProxyMachinesContext(ctx, cli, available)
+	if err != nil {
+		return nil, fmt.Errorf("create request context to broadcast to machines: %w", err)
+	}
+	resp, err := cli.MachineClient.BatchInspect(batchCtx, &emptypb.Empty{})
+	if err != nil {
+		if len(proxiedMachines) > 1 {
+			return nil, err
+		}
+		// The request was proxied to only one machine so its error is returned as is rather than in the metadata.
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = batchInspectError(err)
+			}
+		}
+		return results, nil
+	}
+
+	inspections := make(map[string]*pb.MachineInspection, len(resp.Messages))
+	for _, msg := range resp.Messages {
+		id := proxiedMachines[0].Machine.Id
+		if msg.Metadata != nil && msg.Metadata.Machine != "" {
+			m := proxiedMachines.FindByManagementIP(msg.Metadata.Machine)
+			if m == nil {
+				return nil, fmt.Errorf("machine not found by management IP: %s", msg.Metadata.Machine)
+			}
+			id = m.Machine.Id
+		}
+		inspections[id] = msg
+	}
+
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		msg, ok := inspections[results[i].Machine.ID]
+		if !ok {
+			results[i].Err = errors.New("no response from machine")
+			continue
+		}
+		if md := msg.Metadata; md != nil && md.Error != "" {
+			if md.Status != nil {
+				results[i].Err = batchInspectError(status.ErrorProto(md.Status))
+			} else {
+				results[i].Err = errors.New(md.Error)
+			}
+			continue
+		}
+
+		results[i].Value = api.MachineInspection{
+			Resources:     toMachineResources(msg.Machine.GetResources()),
+			DaemonVersion: msg.DaemonVersion,
+		}
+		if msg.DaemonStartedAt != nil {
+			results[i].Value.DaemonStartedAt = msg.DaemonStartedAt.AsTime()
+		}
+		if msg.Usage != nil {
+			results[i].Value.Usage = &api.MachineUsage{
+				CPU:    msg.Usage.Cpu,
+				Memory: msg.Usage.Memory,
+				Disk:   msg.Usage.Disk,
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// batchInspectError explains the error returned by a machine for the BatchInspect request.
+func batchInspectError(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("machine runs an older daemon version that doesn't support batch inspection: %w", err)
+	}
+	return err
+}
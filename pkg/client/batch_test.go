@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestForEachMachine(t *testing.T) {
+	t.Parallel()
+
+	member := func(name, ip string, state pb.MachineMember_MembershipState) *pb.MachineMember {
+		return &pb.MachineMember{
+			Machine: &pb.MachineInfo{
+				Id:   name + "-id",
+				Name: name,
+				Network: &pb.NetworkConfig{
+					ManagementIp: pb.NewIP(netip.MustParseAddr(ip)),
+				},
+			},
+			State: state,
+		}
+	}
+	machines := api.MachineMembersList{
+		member("m1", "fdcc::1", pb.MachineMember_UP),
+		member("m2", "fdcc::2", pb.MachineMember_DOWN),
+		member("m3", "fdcc::3", pb.MachineMember_SUSPECT),
+		member("m4", "fdcc::4", pb.MachineMember_UP),
+	}
+
+	var calls atomic.Int32
+	results := ForEachMachine(context.Background(), machines,
+		func(ctx context.Context, m *pb.MachineMember) (string, error) {
+			calls.Add(1)
+			md, _ := metadata.FromOutgoingContext(ctx)
+			if m.Machine.Name == "m4" {
+				return "", errors.New("boom")
+			}
+			return m.Machine.Name + "@" + md.Get("machines")[0], nil
+		})
+
+	require.Len(t, results, 4)
+	assert.EqualValues(t, 3, calls.Load(), "DOWN machine must not be contacted")
+
+	assert.Equal(t, "m1", results[0].Machine.Name)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "m1@fdcc::1", results[0].Value)
+
+	assert.Equal(t, "m2", results[1].Machine.Name)
+	assert.ErrorIs(t, results[1].Err, errMachineDown)
+
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, "m3@fdcc::3", results[2].Value)
+
+	assert.EqualError(t, results[3].Err, "boom")
+}
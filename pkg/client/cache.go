@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// cacheTTL is how long the responses of the cached calls are reused. It's short as the cache is only meant
+// to deduplicate the identical calls made by composite operations, e.g. listing machines multiple times while
+// adding a machine, rather than to serve long-lived clients.
+const cacheTTL = 2 * time.Second
+
+// cachedMethods are the read-only gRPC methods which responses are cached.
+var cachedMethods = []string{
+	pb.Cluster_GetDomain_FullMethodName,
+	pb.Cluster_ListMachines_FullMethodName,
+	pb.Docker_ListServiceContainers_FullMethodName,
+}
+
+// readOnlyMethodPrefixes are the prefixes of the gRPC method names that don't change the cluster state and therefore
+// don't invalidate the cache.
+var readOnlyMethodPrefixes = []string{"Check", "Get", "Inspect", "List"}
+
+// cachingConn is a gRPC client connection that caches the successful responses of the calls to cachedMethods
+// for cacheTTL. Any other call or stream that is not read-only is treated as a possible mutation and invalidates
+// the cache.
+type cachingConn struct {
+	grpc.ClientConnInterface
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// generation is incremented on every invalidation to not cache the responses of the calls that were in flight
+	// while the cache was invalidated.
+	generation uint64
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	resp    proto.Message
+	expires time.Time
+}
+
+func newCachingConn(conn grpc.ClientConnInterface) *cachingConn {
+	return &cachingConn{
+		ClientConnInterface: conn,
+		entries:             make(map[string]cacheEntry),
+		now:                 time.Now,
+	}
+}
+
+func (c *cachingConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	req, reqOK := args.(proto.Message)
+	resp, respOK := reply.(proto.Message)
+	if !slices.Contains(cachedMethods, method) || !reqOK || !respOK {
+		if !isReadOnly(method) {
+			c.Invalidate()
+			defer c.Invalidate()
+		}
+		return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	}
+
+	key, ok := cacheKey(ctx, method, req)
+	if !ok {
+		return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	}
+
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if found && c.now().Before(entry.expires) {
+		proto.Reset(resp)
+		proto.Merge(resp, entry.resp)
+		return nil
+	}
+
+	if err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[key] = cacheEntry{resp: proto.Clone(resp), expires: c.now().Add(cacheTTL)}
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *cachingConn) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	if !isReadOnly(method) {
+		c.Invalidate()
+	}
+	return c.ClientConnInterface.NewStream(ctx, desc, method, opts...)
+}
+
+// Close closes the underlying connection if it can be closed.
+func (c *cachingConn) Close() error {
+	if closer, ok := c.ClientConnInterface.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Invalidate drops all cached responses.
+func (c *cachingConn) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.generation++
+}
+
+// isReadOnly returns true if the full gRPC method name, e.g. "/api.Cluster/ListMachines", denotes a method that
+// doesn't change the cluster state.
+func isReadOnly(method string) bool {
+	name := path.Base(method)
+	return slices.ContainsFunc(readOnlyMethodPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(name, prefix)
+	})
+}
+
+// cacheKey returns the cache key for the call that includes the outgoing metadata as it determines the machines
+// the call is proxied to. It returns false if the request can't be serialised.
+func cacheKey(ctx context.Context, method string, req proto.Message) (string, bool) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", false
+	}
+
+	var b strings.Builder
+	b.WriteString(method)
+	b.WriteByte(0)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(strings.Join(md[k], ","))
+		b.WriteByte(0)
+	}
+	b.Write(data)
+	return b.String(), true
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// countingConn is a fake gRPC connection that replies to ListMachines with a single machine named after the number
+// of calls made so far.
+type countingConn struct {
+	grpc.ClientConnInterface
+	calls map[string]int
+}
+
+func (c *countingConn) Invoke(_ context.Context, method string, _, reply any, _ ...grpc.CallOption) error {
+	c.calls[method]++
+	if resp, ok := reply.(*pb.ListMachinesResponse); ok {
+		resp.Machines = []*pb.MachineMember{
+			{Machine: &pb.MachineInfo{Name: fmt.Sprintf("m%d", c.calls[method])}},
+		}
+	}
+	return nil
+}
+
+func TestCachingConn(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	listMachines := func(t *testing.T, conn *cachingConn, ctx context.Context) string {
+		resp := &pb.ListMachinesResponse{}
+		require.NoError(t, conn.Invoke(ctx, pb.Cluster_ListMachines_FullMethodName, &pb.ListMachinesRequest{}, resp))
+		require.Len(t, resp.Machines, 1)
+		return resp.Machines[0].Machine.Name
+	}
+
+	t.Run("repeated calls are cached", func(t *testing.T) {
+		t.Parallel()
+		fake := &countingConn{calls: map[string]int{}}
+		conn := newCachingConn(fake)
+
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+		assert.Equal(t, 1, fake.calls[pb.Cluster_ListMachines_FullMethodName])
+	})
+
+	t.Run("cached response is a copy", func(t *testing.T) {
+		t.Parallel()
+		fake := &countingConn{calls: map[string]int{}}
+		conn := newCachingConn(fake)
+
+		resp := &pb.ListMachinesResponse{}
+		require.NoError(t, conn.Invoke(ctx, pb.Cluster_ListMachines_FullMethodName, &pb.ListMachinesRequest{}, resp))
+		resp.Machines[0].Machine.Name = "modified"
+
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+	})
+
+	t.Run("different requests and metadata are cached separately", func(t *testing.T) {
+		t.Parallel()
+		fake := &countingConn{calls: map[string]int{}}
+		conn := newCachingConn(fake)
+
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+		mdCtx := metadata.AppendToOutgoingContext(ctx, "machines", "10.210.0.1")
+		assert.Equal(t, "m2", listMachines(t, conn, mdCtx))
+		assert.Equal(t, "m2", listMachines(t, conn, mdCtx))
+
+		resp := &pb.ListMachinesResponse{}
+		req := &pb.ListMachinesRequest{NamesOrIds: []string{"m1"}}
+		require.NoError(t, conn.Invoke(ctx, pb.Cluster_ListMachines_FullMethodName, req, resp))
+		assert.Equal(t, "m3", resp.Machines[0].Machine.Name)
+	})
+
+	t.Run("expired responses are not reused", func(t *testing.T) {
+		t.Parallel()
+		fake := &countingConn{calls: map[string]int{}}
+		conn := newCachingConn(fake)
+		now := time.Now()
+		conn.now = func() time.Time { return now }
+
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+		now = now.Add(cacheTTL)
+		assert.Equal(t, "m2", listMachines(t, conn, ctx))
+	})
+
+	t.Run("mutations invalidate the cache", func(t *testing.T) {
+		t.Parallel()
+		fake := &countingConn{calls: map[string]int{}}
+		conn := newCachingConn(fake)
+
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+		require.NoError(t, conn.Invoke(ctx, pb.Cluster_AddMachine_FullMethodName,
+			&pb.AddMachineRequest{}, &pb.AddMachineResponse{}))
+		assert.Equal(t, "m2", listMachines(t, conn, ctx))
+
+		conn.Invalidate()
+		assert.Equal(t, "m3", listMachines(t, conn, ctx))
+	})
+
+	t.Run("read-only calls don't invalidate the cache", func(t *testing.T) {
+		t.Parallel()
+		fake := &countingConn{calls: map[string]int{}}
+		conn := newCachingConn(fake)
+
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+		require.NoError(t, conn.Invoke(ctx, pb.Cluster_GetCluster_FullMethodName,
+			&emptypb.Empty{}, &pb.ClusterInfo{}))
+		assert.Equal(t, "m1", listMachines(t, conn, ctx))
+	})
+}
+
+func TestIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isReadOnly(pb.Cluster_ListMachines_FullMethodName))
+	assert.True(t, isReadOnly(pb.Machine_Inspect_FullMethodName))
+	assert.True(t, isReadOnly(pb.Docker_InspectServiceContainer_FullMethodName))
+	assert.False(t, isReadOnly(pb.Cluster_AddMachine_FullMethodName))
+	assert.False(t, isReadOnly(pb.Docker_RemoveServiceContainer_FullMethodName))
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MachineUsage returns the current CPU, memory, and disk usage of the machine.
+func (cli *Client) MachineUsage(ctx context.Context, nameOrID string) (api.MachineUsage, error) {
+	machine, err := cli.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return api.MachineUsage{}, err
+	}
+
+	ctx = proxyToMachine(ctx, machine.Machine)
+	resp, err := cli.MachineClient.Usage(ctx, &emptypb.Empty{})
+	if err != nil {
+		return api.MachineUsage{}, err
+	}
+	return api.MachineUsage{
+		CPU:    resp.Cpu,
+		Memory: resp.Memory,
+		Disk:   resp.Disk,
+	}, nil
+}
+
+// ClusterCapacity returns the total, reserved, and used resources of the cluster machines and the reservations
+// of the services. The usage of the unavailable machines or machines running an older daemon version is not
+// reported.
+func (cli *Client) ClusterCapacity(ctx context.Context) (api.CapacityReport, error) {
+	var report api.CapacityReport
+
+	machines, err := cli.ListMachines(ctx, nil)
+	if err != nil {
+		return report, fmt.Errorf("list machines: %w", err)
+	}
+	services, err := cli.ListServices(ctx)
+	if err != nil {
+		return report, fmt.Errorf("list services: %w", err)
+	}
+
+	usages := ForEachMachine(ctx, machines,
+		func(ctx context.Context, _ *pb.MachineMember) (*api.MachineUsage, error) {
+			resp, err := cli.MachineClient.Usage(ctx, &emptypb.Empty{})
+			if err != nil {
+				return nil, err
+			}
+			return &api.MachineUsage{
+				CPU:    resp.Cpu,
+				Memory: resp.Memory,
+				Disk:   resp.Disk,
+			}, nil
+		})
+	report.Machines = make([]api.MachineCapacity, len(usages))
+	for i, u := range usages {
+		report.Machines[i].Machine = u.Machine
+		if u.Err != nil {
+			slog.Debug("Failed to get machine usage.", "machine", u.Machine.Name, "err", u.Err)
+			continue
+		}
+		report.Machines[i].Usage = u.Value
+	}
+
+	machineIndex := make(map[string]int, len(report.Machines))
+	for i, m := range report.Machines {
+		machineIndex[m.Machine.ID] = i
+	}
+	for _, svc := range services {
+		reservation := api.ServiceReservation{Service: svc.Name}
+		for _, mc := range svc.Containers {
+			if mc.Container.State == nil || !mc.Container.State.Running {
+				continue
+			}
+			res := mc.Container.ServiceSpec.Container.Resources
+			reservation.Replicas++
+			// The containers may have different reservations during a rolling update. Report the largest ones.
+			reservation.CPU = max(reservation.CPU, res.CPU)
+			reservation.Memory = max(reservation.Memory, res.MemoryReservation)
+
+			if i, ok := machineIndex[mc.MachineID]; ok {
+				report.Machines[i].ReservedCPU += res.CPU
+				report.Machines[i].ReservedMemory += res.MemoryReservation
+			}
+		}
+		report.Services = append(report.Services, reservation)
+	}
+
+	slices.SortFunc(report.Machines, func(a, b api.MachineCapacity) int {
+		return strings.Compare(a.Machine.Name, b.Machine.Name)
+	})
+	slices.SortFunc(report.Services, func(a, b api.ServiceReservation) int {
+		return strings.Compare(a.Service, b.Service)
+	})
+	return report, nil
+}
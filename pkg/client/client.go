@@ -18,7 +18,8 @@ import (
 // Client is a client for the machine API.
 type Client struct {
 	connector Connector
-	conn      *grpc.ClientConn
+	// conn caches the responses of the repeated read-only calls made within a short period of time.
+	conn *cachingConn
 
 	// TODO: refactor to not embed MachineClient and instead expose only required methods.
 	//  Methods such as Reset or Inspect are ambiguous in the context of a machine+cluster client.
@@ -47,11 +48,11 @@ func New(ctx context.Context, connector Connector) (*Client, error) {
 	c := &Client{
 		connector: connector,
 	}
-	var err error
-	c.conn, err = connector.Connect(ctx)
+	conn, err := connector.Connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("connect to machine: %w", err)
 	}
+	c.conn = newCachingConn(conn)
 
 	c.MachineClient = pb.NewMachineClient(c.conn)
 	c.ClusterClient = pb.NewClusterClient(c.conn)
@@ -60,6 +61,13 @@ func New(ctx context.Context, connector Connector) (*Client, error) {
 	return c, nil
 }
 
+// InvalidateCache drops the cached responses of the read-only calls. The cache is invalidated automatically
+// on any call that may change the cluster state made by this client, so it only needs to be called explicitly
+// to observe the changes made by other clients without waiting for the cached responses to expire.
+func (cli *Client) InvalidateCache() {
+	cli.conn.Invalidate()
+}
+
 func (cli *Client) Close() error {
 	return errors.Join(cli.conn.Close(), cli.connector.Close())
 }
@@ -69,9 +77,12 @@ func (cli *Client) progressOut() *streams.Out {
 	return streams.NewOut(os.Stdout)
 }
 
-// proxyToMachine returns a new context that proxies gRPC requests to the specified machine.
+// proxyToMachine returns a new context that proxies gRPC requests to the specified machine. The other outgoing
+// metadata, such as the deploy freeze override, is preserved.
 func proxyToMachine(ctx context.Context, machine *pb.MachineInfo) context.Context {
 	machineIP, _ := machine.Network.ManagementIp.ToAddr()
-	md := metadata.Pairs("machines", machineIP.String())
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set("machines", machineIP.String())
 	return metadata.NewOutgoingContext(ctx, md)
 }
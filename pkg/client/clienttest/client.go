@@ -0,0 +1,866 @@
+// Package clienttest provides an in-memory fake of the cluster client for unit testing code that embeds Uncloud
+// as a library without a live cluster.
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"google.golang.org/protobuf/proto"
+)
+
+// Client is an in-memory fake of the cluster client. It keeps machines, service containers, volumes, and images
+// in memory and applies the operations of deployments to them as a real cluster would. The behaviour of any method
+// can be scripted with On or FailOn, and all method calls are recorded for assertions. It's safe for concurrent use.
+type Client struct {
+	mu         sync.Mutex
+	machines   []*pb.MachineMember
+	containers []api.MachineServiceContainer
+	volumes    []api.MachineVolume
+	images     []api.MachineImage
+	// remoteImages maps image references to images in remote registries.
+	remoteImages map[string]api.RemoteImage
+	domain       string
+	preemptions  []api.Preemption
+	calls        []Call
+	reactors     map[string]Reactor
+}
+
+var (
+	_ api.Client    = (*Client)(nil)
+	_ deploy.Client = (*Client)(nil)
+)
+
+// Call is a recorded method call of the fake client.
+type Call struct {
+	// Method is the name of the called method, e.g. "CreateContainer".
+	Method string
+	// Args are the arguments of the call without the context.
+	Args []any
+}
+
+// Reactor is a function that is called before the fake behaviour of a method. If it returns a non-nil error,
+// the method returns the error without applying its fake behaviour.
+type Reactor func(call Call) error
+
+// New creates a new fake client with an empty cluster.
+func New() *Client {
+	return &Client{
+		remoteImages: make(map[string]api.RemoteImage),
+		reactors:     make(map[string]Reactor),
+	}
+}
+
+// On sets the reactor for the method with the given name, e.g. "CreateContainer". A nil reactor removes it.
+func (c *Client) On(method string, reactor Reactor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if reactor == nil {
+		delete(c.reactors, method)
+		return
+	}
+	c.reactors[method] = reactor
+}
+
+// FailOn makes all subsequent calls to the method with the given name return err. A nil err removes the failure.
+func (c *Client) FailOn(method string, err error) {
+	if err == nil {
+		c.On(method, nil)
+		return
+	}
+	c.On(method, func(Call) error {
+		return err
+	})
+}
+
+// Calls returns the recorded method calls in the order they were made. If methods are specified, only calls
+// to these methods are returned. Calls made internally, e.g. CreateContainer when running a deployment, are included.
+func (c *Client) Calls(methods ...string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var calls []Call
+	for _, call := range c.calls {
+		if len(methods) == 0 || slices.Contains(methods, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// call records the method call and runs its reactor if set.
+func (c *Client) call(method string, args ...any) error {
+	call := Call{Method: method, Args: args}
+
+	c.mu.Lock()
+	c.calls = append(c.calls, call)
+	reactor := c.reactors[method]
+	c.mu.Unlock()
+
+	if reactor != nil {
+		return reactor(call)
+	}
+	return nil
+}
+
+// AddMachine adds a new machine that is UP to the cluster and returns it.
+func (c *Client) AddMachine(name string) *pb.MachineMember {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id, err := secret.NewID()
+	if err != nil {
+		panic(fmt.Sprintf("generate machine ID: %v", err))
+	}
+	n := len(c.machines) + 1
+	subnet := netip.PrefixFrom(netip.AddrFrom4([4]byte{10, 210, byte(n), 0}), 24)
+	managementIP := netip.AddrFrom16([16]byte{0: 0xfd, 1: 0xcc, 14: byte(n >> 8), 15: byte(n)})
+
+	m := &pb.MachineMember{
+		Machine: &pb.MachineInfo{
+			Id:   id,
+			Name: name,
+			Network: &pb.NetworkConfig{
+				Subnet:       pb.NewIPPrefix(subnet),
+				ManagementIp: pb.NewIP(managementIP),
+			},
+		},
+		State: pb.MachineMember_UP,
+	}
+	c.machines = append(c.machines, m)
+
+	return m
+}
+
+// SetMachineState changes the membership state of the machine, e.g. to DOWN to simulate an unavailable machine.
+func (c *Client) SetMachineState(nameOrID string, state pb.MachineMember_MembershipState) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.machines, func(m *pb.MachineMember) bool {
+		return m.Machine.Id == nameOrID || m.Machine.Name == nameOrID
+	})
+	if i == -1 {
+		return api.ErrNotFound
+	}
+	m := proto.Clone(c.machines[i]).(*pb.MachineMember)
+	m.State = state
+	c.machines[i] = m
+
+	return nil
+}
+
+// SetDomain sets the cluster domain. GetDomain returns api.ErrNotFound if the domain is empty.
+func (c *Client) SetDomain(domain string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.domain = domain
+}
+
+// AddImage adds an image present on the machine.
+func (c *Client) AddImage(machineNameOrID string, img types.ImageInspect) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := api.MachineMembersList(c.machines).FindByNameOrID(machineNameOrID)
+	if m == nil {
+		return fmt.Errorf("machine '%s': %w", machineNameOrID, api.ErrNotFound)
+	}
+	c.images = append(c.images, api.MachineImage{Metadata: machineMetadata(m), Image: img})
+	return nil
+}
+
+// SetRemoteImage sets the image returned by InspectRemoteImage for the image reference.
+func (c *Client) SetRemoteImage(ref string, img api.RemoteImage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remoteImages[ref] = img
+}
+
+// AddService adds a service with running containers created from the spec on each of the specified machines
+// bypassing the deployment planning. It's useful for setting up an existing service before the test.
+func (c *Client) AddService(spec api.ServiceSpec, machineNamesOrIDs ...string) (api.Service, error) {
+	spec = spec.SetDefaults()
+	if err := spec.Validate(); err != nil {
+		return api.Service{}, fmt.Errorf("invalid service spec: %w", err)
+	}
+	serviceID, err := secret.NewID()
+	if err != nil {
+		return api.Service{}, fmt.Errorf("generate service ID: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	containers := make([]api.MachineServiceContainer, len(machineNamesOrIDs))
+	for i, nameOrID := range machineNamesOrIDs {
+		if containers[i], err = c.newContainer(serviceID, spec, nameOrID); err != nil {
+			return api.Service{}, err
+		}
+		state := containers[i].Container.State
+		state.Running = true
+		state.Status = "running"
+		state.StartedAt = containers[i].Container.Created
+	}
+	c.containers = append(c.containers, containers...)
+
+	return c.service(serviceID)
+}
+
+// newContainer creates a new service container on the machine without adding it to the cluster.
+// The caller must hold the lock.
+func (c *Client) newContainer(
+	serviceID string, spec api.ServiceSpec, machineNameOrID string,
+) (api.MachineServiceContainer, error) {
+	m := api.MachineMembersList(c.machines).FindByNameOrID(machineNameOrID)
+	if m == nil {
+		return api.MachineServiceContainer{}, fmt.Errorf("machine '%s': %w", machineNameOrID, api.ErrNotFound)
+	}
+
+	id, err := secret.NewID()
+	if err != nil {
+		return api.MachineServiceContainer{}, fmt.Errorf("generate container ID: %w", err)
+	}
+	suffix, err := secret.RandomAlphaNumeric(4)
+	if err != nil {
+		return api.MachineServiceContainer{}, fmt.Errorf("generate random suffix: %w", err)
+	}
+
+	labels := map[string]string{
+		api.LabelServiceID:   serviceID,
+		api.LabelServiceName: spec.Name,
+		api.LabelServiceMode: spec.Mode,
+		api.LabelManaged:     "",
+	}
+	if spec.Project != "" {
+		labels[api.LabelProject] = spec.Project
+	}
+	if len(spec.Ports) > 0 {
+		encodedPorts := make([]string, len(spec.Ports))
+		for i, p := range spec.Ports {
+			if encodedPorts[i], err = p.String(); err != nil {
+				return api.MachineServiceContainer{}, fmt.Errorf("encode service port spec: %w", err)
+			}
+		}
+		labels[api.LabelServicePorts] = strings.Join(encodedPorts, ",")
+	}
+
+	// Zero time in the format used by Docker for containers that haven't been started or finished yet.
+	zeroTime := time.Time{}.Format(time.RFC3339Nano)
+	return api.MachineServiceContainer{
+		MachineID: m.Machine.Id,
+		Container: api.ServiceContainer{
+			Container: api.Container{
+				ContainerJSON: types.ContainerJSON{
+					ContainerJSONBase: &types.ContainerJSONBase{
+						ID:      id,
+						Name:    fmt.Sprintf("%s-%s", spec.Name, suffix),
+						Created: time.Now().UTC().Format(time.RFC3339Nano),
+						Image:   spec.Container.Image,
+						State: &types.ContainerState{
+							Status:     "created",
+							StartedAt:  zeroTime,
+							FinishedAt: zeroTime,
+						},
+					},
+					Config: &container.Config{
+						Image:  spec.Container.Image,
+						Labels: labels,
+					},
+				},
+			},
+			ServiceSpec: spec,
+		},
+	}, nil
+}
+
+// findContainer returns the index of the container with the given name or ID within the service.
+// The caller must hold the lock.
+func (c *Client) findContainer(serviceNameOrID, containerNameOrID string) (int, error) {
+	for i, mc := range c.containers {
+		ctr := mc.Container
+		if ctr.ServiceID() != serviceNameOrID && ctr.ServiceName() != serviceNameOrID {
+			continue
+		}
+		if ctr.ID == containerNameOrID || ctr.Name == containerNameOrID {
+			return i, nil
+		}
+	}
+	return -1, api.ErrNotFound
+}
+
+// updateContainerState updates the state of the container at the given index. The state is copied before updating
+// to not modify containers previously returned to the caller. The caller must hold the lock.
+func (c *Client) updateContainerState(i int, update func(state *types.ContainerState)) {
+	base := *c.containers[i].Container.ContainerJSONBase
+	state := *base.State
+	update(&state)
+	base.State = &state
+	c.containers[i].Container.ContainerJSONBase = &base
+}
+
+func (c *Client) CreateContainer(
+	_ context.Context, serviceID string, spec api.ServiceSpec, machineID string,
+) (container.CreateResponse, error) {
+	if err := c.call("CreateContainer", serviceID, spec, machineID); err != nil {
+		return container.CreateResponse{}, err
+	}
+
+	spec = spec.SetDefaults()
+	if err := spec.Validate(); err != nil {
+		return container.CreateResponse{}, fmt.Errorf("invalid service spec: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctr, err := c.newContainer(serviceID, spec, machineID)
+	if err != nil {
+		return container.CreateResponse{}, err
+	}
+	c.containers = append(c.containers, ctr)
+
+	return container.CreateResponse{ID: ctr.Container.ID}, nil
+}
+
+func (c *Client) InspectContainer(
+	_ context.Context, serviceNameOrID, containerNameOrID string,
+) (api.MachineServiceContainer, error) {
+	if err := c.call("InspectContainer", serviceNameOrID, containerNameOrID); err != nil {
+		return api.MachineServiceContainer{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.findContainer(serviceNameOrID, containerNameOrID)
+	if err != nil {
+		return api.MachineServiceContainer{}, err
+	}
+	return c.containers[i], nil
+}
+
+func (c *Client) RemoveContainer(
+	_ context.Context, serviceNameOrID, containerNameOrID string, opts container.RemoveOptions,
+) error {
+	if err := c.call("RemoveContainer", serviceNameOrID, containerNameOrID, opts); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.findContainer(serviceNameOrID, containerNameOrID)
+	if err != nil {
+		return err
+	}
+	if c.containers[i].Container.State.Running && !opts.Force {
+		return fmt.Errorf("container '%s' is running: stop the container before removing or force remove",
+			c.containers[i].Container.Name)
+	}
+	c.containers = slices.Delete(c.containers, i, i+1)
+
+	return nil
+}
+
+func (c *Client) StartContainer(_ context.Context, serviceNameOrID, containerNameOrID string) error {
+	if err := c.call("StartContainer", serviceNameOrID, containerNameOrID); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.findContainer(serviceNameOrID, containerNameOrID)
+	if err != nil {
+		return err
+	}
+	c.updateContainerState(i, func(state *types.ContainerState) {
+		if !state.Running {
+			state.Running = true
+			state.Status = "running"
+			state.StartedAt = time.Now().UTC().Format(time.RFC3339Nano)
+		}
+	})
+
+	return nil
+}
+
+func (c *Client) StopContainer(
+	_ context.Context, serviceNameOrID, containerNameOrID string, opts container.StopOptions,
+) error {
+	if err := c.call("StopContainer", serviceNameOrID, containerNameOrID, opts); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.findContainer(serviceNameOrID, containerNameOrID)
+	if err != nil {
+		return err
+	}
+	c.updateContainerState(i, func(state *types.ContainerState) {
+		if state.Running {
+			state.Running = false
+			state.Status = "exited"
+			state.FinishedAt = time.Now().UTC().Format(time.RFC3339Nano)
+		}
+	})
+
+	return nil
+}
+
+func (c *Client) UpdateContainerSpec(
+	_ context.Context, serviceNameOrID, containerNameOrID string, spec api.ServiceSpec,
+) error {
+	if err := c.call("UpdateContainerSpec", serviceNameOrID, containerNameOrID, spec); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.findContainer(serviceNameOrID, containerNameOrID)
+	if err != nil {
+		return err
+	}
+	c.containers[i].Container.ServiceSpec = spec.SetDefaults()
+
+	return nil
+}
+
+func (c *Client) GetDomain(_ context.Context) (string, error) {
+	if err := c.call("GetDomain"); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.domain == "" {
+		return "", api.ErrNotFound
+	}
+	return c.domain, nil
+}
+
+func (c *Client) ServiceDomain(_ context.Context) (string, error) {
+	if err := c.call("ServiceDomain"); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.domain, nil
+}
+
+func (c *Client) InspectImage(_ context.Context, id string) ([]api.MachineImage, error) {
+	if err := c.call("InspectImage", id); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var images []api.MachineImage
+	for _, img := range c.images {
+		if img.Image.ID == id || slices.Contains(img.Image.RepoTags, id) {
+			images = append(images, img)
+		}
+	}
+	if len(images) == 0 {
+		return nil, api.ErrNotFound
+	}
+	return images, nil
+}
+
+// SeedImage adds the image to the machines that don't have it.
+func (c *Client) SeedImage(_ context.Context, image string, machineIDs []string) error {
+	if err := c.call("SeedImage", image, machineIDs); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range machineIDs {
+		m := api.MachineMembersList(c.machines).FindByNameOrID(id)
+		if m == nil {
+			return fmt.Errorf("machine '%s': %w", id, api.ErrNotFound)
+		}
+		md := machineMetadata(m)
+		present := slices.ContainsFunc(c.images, func(img api.MachineImage) bool {
+			return img.Metadata.Machine == md.Machine && slices.Contains(img.Image.RepoTags, image)
+		})
+		if !present {
+			c.images = append(c.images, api.MachineImage{
+				Metadata: md,
+				Image:    types.ImageInspect{ID: image, RepoTags: []string{image}},
+			})
+		}
+	}
+	return nil
+}
+
+func (c *Client) InspectRemoteImage(_ context.Context, id string) ([]api.MachineRemoteImage, error) {
+	if err := c.call("InspectRemoteImage", id); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	img, ok := c.remoteImages[id]
+	if !ok {
+		return nil, api.ErrNotFound
+	}
+	return []api.MachineRemoteImage{{Image: img}}, nil
+}
+
+func (c *Client) InspectMachine(_ context.Context, id string) (*pb.MachineMember, error) {
+	if err := c.call("InspectMachine", id); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := api.MachineMembersList(c.machines).FindByNameOrID(id)
+	if m == nil {
+		return nil, api.ErrNotFound
+	}
+	return m, nil
+}
+
+func (c *Client) ListMachines(_ context.Context, filter *api.MachineFilter) (api.MachineMembersList, error) {
+	if err := c.call("ListMachines", filter); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var machines api.MachineMembersList
+	for _, m := range c.machines {
+		if client.MachineMatchesFilter(m, filter) {
+			machines = append(machines, m)
+		}
+	}
+	return machines, nil
+}
+
+func (c *Client) UpdateMachine(_ context.Context, req *pb.UpdateMachineRequest) (*pb.MachineInfo, error) {
+	if err := c.call("UpdateMachine", req); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.machines, func(m *pb.MachineMember) bool {
+		return m.Machine.Id == req.MachineId
+	})
+	if i == -1 {
+		return nil, api.ErrNotFound
+	}
+	// Update a copy of the machine to not modify machines previously returned to the caller.
+	m := proto.Clone(c.machines[i]).(*pb.MachineMember)
+
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, errors.New("machine name cannot be empty")
+		}
+		if other := api.MachineMembersList(c.machines).FindByNameOrID(*req.Name); other != nil &&
+			other.Machine.Id != m.Machine.Id {
+			return nil, fmt.Errorf("machine with name '%s' already exists", *req.Name)
+		}
+		m.Machine.Name = *req.Name
+	}
+	if req.PublicIp != nil {
+		// An empty IP removes the public IP.
+		if len(req.PublicIp.Ip) == 0 {
+			m.Machine.PublicIp = nil
+		} else {
+			m.Machine.PublicIp = req.PublicIp
+		}
+	}
+	if req.ManualEndpoints != nil && *req.ManualEndpoints {
+		m.Machine.Network.Endpoints = req.Endpoints
+		m.Machine.Network.DnsEndpoints = req.DnsEndpoints
+	} else if req.Endpoints != nil {
+		m.Machine.Network.Endpoints = req.Endpoints
+	}
+	if req.ManualEndpoints != nil {
+		m.Machine.ManualEndpoints = *req.ManualEndpoints
+	}
+	if req.Arch != nil {
+		m.Machine.Arch = *req.Arch
+	}
+	c.machines[i] = m
+
+	return m.Machine, nil
+}
+
+func (c *Client) RenameMachine(ctx context.Context, nameOrID, newName string) (*pb.MachineInfo, error) {
+	if err := c.call("RenameMachine", nameOrID, newName); err != nil {
+		return nil, err
+	}
+
+	m, err := c.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return c.UpdateMachine(ctx, &pb.UpdateMachineRequest{MachineId: m.Machine.Id, Name: &newName})
+}
+
+// RunService deploys a new service using the default deployment strategy.
+func (c *Client) RunService(ctx context.Context, spec api.ServiceSpec) (api.RunServiceResponse, error) {
+	if err := c.call("RunService", spec); err != nil {
+		return api.RunServiceResponse{}, err
+	}
+
+	if err := spec.Validate(); err != nil {
+		return api.RunServiceResponse{}, fmt.Errorf("invalid service spec: %w", err)
+	}
+	if spec.Name != "" {
+		_, err := c.InspectService(ctx, spec.Name)
+		if err == nil {
+			return api.RunServiceResponse{}, fmt.Errorf("service with name '%s' already exists", spec.Name)
+		}
+		if !errors.Is(err, api.ErrNotFound) {
+			return api.RunServiceResponse{}, fmt.Errorf("inspect service: %w", err)
+		}
+	}
+
+	plan, err := deploy.NewDeployment(c, spec, nil).Run(ctx)
+	if err != nil {
+		return api.RunServiceResponse{}, err
+	}
+	return api.RunServiceResponse{ID: plan.ServiceID, Name: plan.ServiceName}, nil
+}
+
+func (c *Client) InspectService(_ context.Context, id string) (api.Service, error) {
+	if err := c.call("InspectService", id); err != nil {
+		return api.Service{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.service(id)
+}
+
+// ListServices returns all services in the cluster ordered by the time their first container was added.
+func (c *Client) ListServices(ctx context.Context) ([]api.Service, error) {
+	return c.ListServicesFiltered(ctx, nil)
+}
+
+// ListServicesFiltered returns the services in the cluster that match the filter ordered by the time their first
+// container was added.
+func (c *Client) ListServicesFiltered(_ context.Context, filter *api.ServiceFilter) ([]api.Service, error) {
+	if err := c.call("ListServices", filter); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var services []api.Service
+	for _, mc := range c.containers {
+		id := mc.Container.ServiceID()
+		if !filter.Matches(mc.Container) || slices.ContainsFunc(services, func(s api.Service) bool { return s.ID == id }) {
+			continue
+		}
+		svc, err := c.service(id)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// service returns the service with the given name or ID. The caller must hold the lock.
+func (c *Client) service(id string) (api.Service, error) {
+	// Matching by ID takes priority over matching by name as in the real client.
+	var byID, byName []api.MachineServiceContainer
+	for _, mc := range c.containers {
+		if mc.Container.ServiceID() == id {
+			byID = append(byID, mc)
+		} else if mc.Container.ServiceName() == id {
+			byName = append(byName, mc)
+		}
+	}
+
+	containers := byID
+	if len(containers) == 0 {
+		containers = byName
+	}
+	if len(containers) == 0 {
+		return api.Service{}, api.ErrNotFound
+	}
+	for _, mc := range containers[1:] {
+		if mc.Container.ServiceID() != containers[0].Container.ServiceID() {
+			return api.Service{}, fmt.Errorf("multiple services found with name '%s', use the service ID instead", id)
+		}
+	}
+
+	svc := api.Service{
+		ID:         containers[0].Container.ServiceID(),
+		Name:       containers[0].Container.ServiceName(),
+		Mode:       containers[0].Container.ServiceMode(),
+		Project:    containers[0].Container.Project(),
+		Containers: containers,
+	}
+	if svc.Mode == "" {
+		svc.Mode = api.ServiceModeReplicated
+	}
+	return svc, nil
+}
+
+func (c *Client) RemoveService(_ context.Context, id string) error {
+	if err := c.call("RemoveService", id); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := len(c.containers)
+	c.containers = slices.DeleteFunc(c.containers, func(mc api.MachineServiceContainer) bool {
+		return mc.Container.ServiceID() == id || mc.Container.ServiceName() == id
+	})
+	if len(c.containers) == n {
+		return api.ErrNotFound
+	}
+	return nil
+}
+
+func (c *Client) CreateVolume(
+	_ context.Context, machineNameOrID string, opts volume.CreateOptions,
+) (api.MachineVolume, error) {
+	if err := c.call("CreateVolume", machineNameOrID, opts); err != nil {
+		return api.MachineVolume{}, err
+	}
+	if opts.Name == "" {
+		return api.MachineVolume{}, fmt.Errorf("volume name is required (anonymous volumes are not supported)")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := api.MachineMembersList(c.machines).FindByNameOrID(machineNameOrID)
+	if m == nil {
+		return api.MachineVolume{}, fmt.Errorf("inspect machine '%s': %w", machineNameOrID, api.ErrNotFound)
+	}
+	// Creating an existing volume is a no-op as in Docker.
+	for _, v := range c.volumes {
+		if v.MachineID == m.Machine.Id && v.Volume.Name == opts.Name {
+			return v, nil
+		}
+	}
+
+	driver := opts.Driver
+	if driver == "" {
+		driver = api.VolumeDriverLocal
+	}
+	vol := api.MachineVolume{
+		MachineID:   m.Machine.Id,
+		MachineName: m.Machine.Name,
+		Volume: volume.Volume{
+			Name:      opts.Name,
+			Driver:    driver,
+			Labels:    opts.Labels,
+			Options:   opts.DriverOpts,
+			Scope:     "local",
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	c.volumes = append(c.volumes, vol)
+
+	return vol, nil
+}
+
+func (c *Client) ListVolumes(_ context.Context, filter *api.VolumeFilter) ([]api.MachineVolume, error) {
+	if err := c.call("ListVolumes", filter); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var volumes []api.MachineVolume
+	for _, v := range c.volumes {
+		if filter == nil || v.MatchesFilter(filter) {
+			volumes = append(volumes, v)
+		}
+	}
+	return volumes, nil
+}
+
+func (c *Client) RemoveVolume(_ context.Context, machineNameOrID, volumeName string, force bool) error {
+	if err := c.call("RemoveVolume", machineNameOrID, volumeName, force); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := api.MachineMembersList(c.machines).FindByNameOrID(machineNameOrID)
+	if m == nil {
+		return fmt.Errorf("inspect machine '%s': %w", machineNameOrID, api.ErrNotFound)
+	}
+	i := slices.IndexFunc(c.volumes, func(v api.MachineVolume) bool {
+		return v.MachineID == m.Machine.Id && v.Volume.Name == volumeName
+	})
+	if i == -1 {
+		return api.ErrNotFound
+	}
+	c.volumes = slices.Delete(c.volumes, i, i+1)
+
+	return nil
+}
+
+func (c *Client) RecordPreemption(_ context.Context, p api.Preemption) error {
+	if err := c.call("RecordPreemption", p); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.preemptions = append(c.preemptions, p)
+	return nil
+}
+
+// Preemptions returns the preemptions recorded by the deployments.
+func (c *Client) Preemptions() []api.Preemption {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.preemptions)
+}
+
+// machineMetadata returns the gRPC proxy metadata for a response from the machine.
+func machineMetadata(m *pb.MachineMember) *pb.Metadata {
+	ip, _ := m.Machine.Network.ManagementIp.ToAddr()
+	return &pb.Metadata{Machine: ip.String()}
+}
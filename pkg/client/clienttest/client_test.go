@@ -0,0 +1,146 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Deploy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cli := New()
+	cli.AddMachine("m1")
+	cli.AddMachine("m2")
+
+	spec := api.ServiceSpec{
+		Name:     "web",
+		Mode:     api.ServiceModeReplicated,
+		Replicas: 3,
+		Container: api.ContainerSpec{
+			Image: "nginx:1",
+		},
+	}
+	resp, err := cli.RunService(ctx, spec)
+	require.NoError(t, err)
+	assert.Equal(t, "web", resp.Name)
+
+	svc, err := cli.InspectService(ctx, "web")
+	require.NoError(t, err)
+	assert.Equal(t, resp.ID, svc.ID)
+	assert.Equal(t, api.ServiceModeReplicated, svc.Mode)
+	require.Len(t, svc.Containers, 3)
+	for _, c := range svc.Containers {
+		assert.True(t, c.Container.State.Running)
+		assert.Equal(t, "nginx:1", c.Container.ServiceSpec.Container.Image)
+	}
+	assert.Len(t, cli.Calls("CreateContainer"), 3)
+
+	_, err = cli.RunService(ctx, spec)
+	assert.ErrorContains(t, err, "already exists")
+
+	// Update the image and scale down the service.
+	spec.Container.Image = "nginx:2"
+	spec.Replicas = 2
+	_, err = deploy.NewDeployment(cli, spec, nil).Run(ctx)
+	require.NoError(t, err)
+
+	svc, err = cli.InspectService(ctx, resp.ID)
+	require.NoError(t, err)
+	require.Len(t, svc.Containers, 2)
+	for _, c := range svc.Containers {
+		assert.True(t, c.Container.State.Running)
+		assert.Equal(t, "nginx:2", c.Container.ServiceSpec.Container.Image)
+	}
+
+	require.NoError(t, cli.RemoveService(ctx, "web"))
+	_, err = cli.InspectService(ctx, "web")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestClient_AddService(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cli := New()
+	cli.AddMachine("m1")
+	cli.AddMachine("m2")
+
+	svc, err := cli.AddService(api.ServiceSpec{
+		Name:      "web",
+		Replicas:  2,
+		Container: api.ContainerSpec{Image: "nginx"},
+	}, "m1", "m2")
+	require.NoError(t, err)
+	require.Len(t, svc.Containers, 2)
+	assert.Empty(t, cli.Calls(), "setup helpers must not be recorded")
+
+	// Redeploying the same spec is a no-op.
+	plan, err := deploy.NewDeployment(cli, svc.Containers[0].Container.ServiceSpec, nil).Plan(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Operations)
+
+	_, err = cli.AddService(api.ServiceSpec{
+		Name:      "db",
+		Container: api.ContainerSpec{Image: "postgres"},
+	}, "unknown")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestClient_FailOn(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cli := New()
+	cli.AddMachine("m1")
+
+	errCreate := errors.New("no space left on device")
+	cli.FailOn("CreateContainer", errCreate)
+
+	_, err := cli.RunService(ctx, api.ServiceSpec{
+		Name:      "web",
+		Container: api.ContainerSpec{Image: "nginx"},
+	})
+	require.ErrorIs(t, err, errCreate)
+	_, err = cli.InspectService(ctx, "web")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+
+	cli.FailOn("CreateContainer", nil)
+	_, err = cli.RunService(ctx, api.ServiceSpec{
+		Name:      "web",
+		Container: api.ContainerSpec{Image: "nginx"},
+	})
+	require.NoError(t, err)
+}
+
+func TestClient_Machines(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cli := New()
+	m1 := cli.AddMachine("m1")
+	cli.AddMachine("m2")
+	require.NoError(t, cli.SetMachineState("m2", pb.MachineMember_DOWN))
+
+	machines, err := cli.ListMachines(ctx, &api.MachineFilter{Available: true})
+	require.NoError(t, err)
+	require.Len(t, machines, 1)
+	assert.Equal(t, m1.Machine.Id, machines[0].Machine.Id)
+
+	_, err = cli.RenameMachine(ctx, "m1", "m2")
+	assert.ErrorContains(t, err, "already exists")
+	info, err := cli.RenameMachine(ctx, "m1", "web1")
+	require.NoError(t, err)
+	assert.Equal(t, "web1", info.Name)
+	assert.Equal(t, "m1", m1.Machine.Name, "previously returned machine must not be modified")
+
+	_, err = cli.InspectMachine(ctx, "m1")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ClusterID returns the unique ID of the cluster. The ID is empty if the connected machine runs an older daemon version
+// that doesn't support cluster IDs.
+func (cli *Client) ClusterID(ctx context.Context) (string, error) {
+	info, err := cli.ClusterClient.GetCluster(ctx, &emptypb.Empty{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return "", nil
+		}
+		return "", err
+	}
+	return info.Id, nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListClusterPeerings returns the other clusters peered with the cluster.
+func (cli *Client) ListClusterPeerings(ctx context.Context) ([]api.ClusterPeering, error) {
+	resp, err := cli.ClusterClient.ListClusterPeerings(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var peerings []api.ClusterPeering
+	if err = json.Unmarshal(resp.Peerings, &peerings); err != nil {
+		return nil, fmt.Errorf("unmarshal cluster peerings: %w", err)
+	}
+	return peerings, nil
+}
+
+// SetClusterPeering creates or updates the peering with another cluster.
+func (cli *Client) SetClusterPeering(ctx context.Context, peering api.ClusterPeering) error {
+	if err := peering.Validate(); err != nil {
+		return err
+	}
+	pBytes, err := json.Marshal(peering)
+	if err != nil {
+		return fmt.Errorf("marshal cluster peering: %w", err)
+	}
+	_, err = cli.ClusterClient.SetClusterPeering(ctx, &pb.ClusterPeering{Peering: pBytes})
+	return err
+}
+
+// RemoveClusterPeering removes the peering with the named cluster. It returns ErrNotFound if it doesn't exist.
+func (cli *Client) RemoveClusterPeering(ctx context.Context, name string) error {
+	_, err := cli.ClusterClient.RemoveClusterPeering(ctx, &pb.RemoveClusterPeeringRequest{Name: name})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
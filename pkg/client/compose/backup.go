@@ -0,0 +1,80 @@
+package compose
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const BackupExtensionKey = "x-backup"
+
+// Backup represents the x-backup extension that enables scheduled backups of the database running in the service.
+type Backup struct {
+	// Engine is the type of the database: postgres, mysql, or redis.
+	Engine string `yaml:"engine" json:"engine" mapstructure:"engine"`
+	// Interval is the time between backups as a Go duration, e.g. 6h.
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty" mapstructure:"interval"`
+	// Retention is the number of the most recent backups to keep.
+	Retention uint `yaml:"retention,omitempty" json:"retention,omitempty" mapstructure:"retention"`
+}
+
+// DecodeMapstructure decodes x-backup extension from either a string or an object.
+// When x-backup is a string, it's mapped directly to the Engine field.
+func (b *Backup) DecodeMapstructure(value any) error {
+	switch v := value.(type) {
+	case *Backup:
+		// Already decoded, happens when mapstructure is called after initial parsing.
+		*b = *v
+		return nil
+	case string:
+		// Handle x-backup: postgres
+		*b = Backup{Engine: v}
+	case map[string]any:
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:      b,
+			ErrorUnused: true,
+		})
+		if err != nil {
+			return fmt.Errorf("create decoder for x-backup extension: %w", err)
+		}
+		if err = decoder.Decode(v); err != nil {
+			return fmt.Errorf("decode x-backup extension: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid type %T for x-backup extension: expected string or object", value)
+	}
+	return nil
+}
+
+// backupSpecFromCompose converts the x-backup extension to the service backup spec.
+func backupSpecFromCompose(b Backup) (*api.BackupSpec, error) {
+	spec := &api.BackupSpec{
+		Engine:    b.Engine,
+		Retention: b.Retention,
+	}
+	if b.Interval != "" {
+		interval, err := time.ParseDuration(b.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x-backup interval '%s': %w", b.Interval, err)
+		}
+		spec.Interval = interval
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid x-backup: %w", err)
+	}
+	return spec, nil
+}
+
+// backupFromSpec converts the service backup spec to the x-backup extension.
+func backupFromSpec(spec api.BackupSpec) Backup {
+	b := Backup{
+		Engine:    spec.Engine,
+		Retention: spec.Retention,
+	}
+	if spec.Interval != 0 {
+		b.Interval = spec.Interval.String()
+	}
+	return b
+}
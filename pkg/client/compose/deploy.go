@@ -2,8 +2,8 @@ package compose
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -14,11 +14,19 @@ import (
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client/deploy"
 	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+	"golang.org/x/sync/errgroup"
 )
 
 type Client interface {
 	api.DNSClient
 	deploy.Client
+	// ManagedSecret returns the content of the external secret managed by the cluster or api.ErrNotFound
+	// if the secret is not managed by the cluster.
+	ManagedSecret(ctx context.Context, name string) ([]byte, error)
+	// ProjectQuota returns the resource quota of the project or api.ErrNotFound if the project has no quota.
+	ProjectQuota(ctx context.Context, project string) (api.ProjectQuota, error)
+	// ListServicesFiltered returns the services in the cluster that match the filter.
+	ListServicesFiltered(ctx context.Context, filter *api.ServiceFilter) ([]api.Service, error)
 }
 
 type Deployment struct {
@@ -28,30 +36,77 @@ type Deployment struct {
 	Strategy     deploy.Strategy
 	state        *scheduler.ClusterState
 	plan         *deploy.SequenceOperation
+	// specs are the service specs generated earlier from the project to deploy instead of the project services.
+	specs []api.ServiceSpec
+	// externalVolumes are the names of the external volumes of the project used with specs.
+	externalVolumes []string
+	// upToDate are the names of the services that don't need to be updated.
+	upToDate []string
 }
 
+// prefetchConcurrency is the maximum number of services inspected concurrently before planning the deployment.
+const prefetchConcurrency = 8
+
 func NewDeployment(ctx context.Context, cli Client, project *types.Project) (*Deployment, error) {
 	return NewDeploymentWithStrategy(ctx, cli, project, nil)
 }
 
 func NewDeploymentWithStrategy(ctx context.Context, cli Client, project *types.Project, strategy deploy.Strategy) (*Deployment, error) {
+	d, err := newDeployment(ctx, cli, project, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ResolveExternalSecrets(project, func(name string) ([]byte, error) {
+		return cli.ManagedSecret(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewDeploymentFromSpecs creates a deployment of the service specs generated earlier from a Compose project with
+// ServiceSpecs, e.g. for a deploy request approved by another operator. externalVolumes are the names of
+// the external volumes of the project that must exist in the cluster.
+func NewDeploymentFromSpecs(
+	ctx context.Context,
+	cli Client,
+	projectName string,
+	specs []api.ServiceSpec,
+	externalVolumes []string,
+	strategy deploy.Strategy,
+) (*Deployment, error) {
+	d, err := newDeployment(ctx, cli, &types.Project{Name: projectName}, strategy)
+	if err != nil {
+		return nil, err
+	}
+	d.specs = specs
+	d.externalVolumes = externalVolumes
+	return d, nil
+}
+
+func newDeployment(ctx context.Context, cli Client, project *types.Project, strategy deploy.Strategy) (*Deployment, error) {
 	state, err := scheduler.InspectClusterState(ctx, cli)
 	if err != nil {
 		return nil, fmt.Errorf("inspect cluster state: %w", err)
 	}
 
-	domain, err := cli.GetDomain(ctx)
-	if err != nil && !errors.Is(err, api.ErrNotFound) {
+	domain, err := cli.ServiceDomain(ctx)
+	if err != nil {
 		return nil, fmt.Errorf("get cluster domain: %w", err)
 	}
 	resolver := &deploy.ServiceSpecResolver{
-		// If the domain is not found (not reserved), an empty domain is used for the resolver.
+		// If no domain is reserved or set in the cluster settings, an empty domain is used for the resolver.
 		ClusterDomain: domain,
 	}
 
 	if strategy == nil {
 		strategy = &deploy.RollingStrategy{State: state}
 	}
+	if rolling, ok := strategy.(*deploy.RollingStrategy); ok && rolling.Images == nil {
+		rolling.Images = &deploy.RemoteImageCache{}
+	}
 
 	return &Deployment{
 		Client:       cli,
@@ -68,24 +123,13 @@ func (d *Deployment) Plan(ctx context.Context) (deploy.SequenceOperation, error)
 	}
 	plan := deploy.SequenceOperation{}
 
-	// Generate service specs for all services in the project.
-	var serviceSpecs []api.ServiceSpec
-	var mu sync.Mutex
-	err := graph.InDependencyOrder(ctx, d.Project,
-		func(ctx context.Context, name string, _ types.ServiceConfig) error {
-			spec, err := d.ServiceSpec(name)
-			if err != nil {
-				return err
-			}
-			// The graph is traversed concurrently, so we need to use a mutex to protect the shared slice.
-			mu.Lock()
-			serviceSpecs = append(serviceSpecs, spec)
-			mu.Unlock()
-			return nil
-		})
+	serviceSpecs, err := d.ServiceSpecs(ctx)
 	if err != nil {
 		return plan, err
 	}
+	if err = d.checkProjectQuota(ctx, serviceSpecs); err != nil {
+		return plan, err
+	}
 
 	// Check external volumes and plan the creation of missing volumes before deploying services.
 	volumeOps, err := d.planVolumes(serviceSpecs)
@@ -96,10 +140,14 @@ func (d *Deployment) Plan(ctx context.Context) (deploy.SequenceOperation, error)
 		plan.Operations = append(plan.Operations, op)
 	}
 
+	services := d.prefetch(ctx, serviceSpecs)
+	d.upToDate = nil
+	var servicePlans []*deploy.Plan
 	for _, spec := range serviceSpecs {
 		// TODO: properly handle depends_on conditions in the service deployment plan as the first operation.
 		// Pass the update cluster state with scheduled volumes to the deployment.
 		deployment := deploy.NewDeployment(d.Client, spec, d.Strategy)
+		deployment.Service = services[spec.Name]
 		servicePlan, err := deployment.Plan(ctx)
 		if err != nil {
 			return plan, fmt.Errorf("create deployment plan for service '%s': %w", spec.Name, err)
@@ -107,14 +155,137 @@ func (d *Deployment) Plan(ctx context.Context) (deploy.SequenceOperation, error)
 
 		// Skip no-op (up-to-date) service plans.
 		if len(servicePlan.Operations) > 0 {
+			servicePlans = append(servicePlans, &servicePlan)
 			plan.Operations = append(plan.Operations, &servicePlan)
+		} else {
+			d.upToDate = append(d.upToDate, spec.Name)
 		}
 	}
+	for _, p := range servicePlans {
+		p.DependsOn = d.dependsOn(serviceSpecs, p.ServiceName)
+	}
 
 	d.plan = &plan
 	return plan, nil
 }
 
+// dependsOn returns the names of the services with a plan the named service depends on. The dependencies on
+// up-to-date services that are skipped are replaced with their own dependencies so that the service still waits
+// for the services it transitively depends on. The specs generated earlier don't retain the dependencies, so each
+// service depends on all the preceding ones to deploy them in order.
+func (d *Deployment) dependsOn(specs []api.ServiceSpec, name string) []string {
+	direct := func(name string) []string {
+		if d.specs == nil {
+			return slices.Sorted(maps.Keys(d.Project.Services[name].DependsOn))
+		}
+		var names []string
+		for _, s := range specs {
+			if s.Name == name {
+				break
+			}
+			names = append(names, s.Name)
+		}
+		return names
+	}
+
+	var deps []string
+	visited := map[string]bool{name: true}
+	queue := direct(name)
+	for len(queue) > 0 {
+		dep := queue[0]
+		queue = queue[1:]
+		if visited[dep] {
+			continue
+		}
+		visited[dep] = true
+
+		if slices.Contains(d.upToDate, dep) {
+			queue = append(queue, direct(dep)...)
+		} else {
+			deps = append(deps, dep)
+		}
+	}
+	slices.Sort(deps)
+	return deps
+}
+
+// UpToDate returns the names of the project services that don't need to be updated according to the plan.
+func (d *Deployment) UpToDate() []string {
+	return d.upToDate
+}
+
+// prefetch concurrently inspects the existing services and their images in the registries that are otherwise
+// inspected one by one when planning the deployment of each service. It returns the existing services by name.
+// Services that fail to be inspected are omitted and inspected again when planning to report the error.
+func (d *Deployment) prefetch(ctx context.Context, specs []api.ServiceSpec) map[string]*api.Service {
+	var images *deploy.RemoteImageCache
+	if rolling, ok := d.Strategy.(*deploy.RollingStrategy); ok {
+		images = rolling.Images
+	}
+
+	services := make(map[string]*api.Service, len(specs))
+	var mu sync.Mutex
+	var wg errgroup.Group
+	wg.SetLimit(prefetchConcurrency)
+	for _, spec := range specs {
+		wg.Go(func() error {
+			if svc, err := d.Client.InspectService(ctx, spec.Name); err == nil {
+				mu.Lock()
+				services[spec.Name] = &svc
+				mu.Unlock()
+			}
+			if images != nil && spec.Container.PullPolicy != api.PullPolicyNever {
+				images.Inspect(ctx, d.Client, spec.Container.Image)
+			}
+			return nil
+		})
+	}
+	_ = wg.Wait()
+	return services
+}
+
+// ServiceSpecs returns the service specifications for all services in the project in dependency order.
+func (d *Deployment) ServiceSpecs(ctx context.Context) ([]api.ServiceSpec, error) {
+	if d.specs != nil {
+		return d.specs, nil
+	}
+
+	var serviceSpecs []api.ServiceSpec
+	var mu sync.Mutex
+	err := graph.InDependencyOrder(ctx, d.Project,
+		func(ctx context.Context, name string, _ types.ServiceConfig) error {
+			spec, err := d.ServiceSpec(name)
+			if err != nil {
+				return err
+			}
+			// The graph is traversed concurrently, so we need to use a mutex to protect the shared slice.
+			mu.Lock()
+			serviceSpecs = append(serviceSpecs, spec)
+			mu.Unlock()
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return serviceSpecs, nil
+}
+
+// ExternalVolumes returns the names of the external volumes of the project.
+func (d *Deployment) ExternalVolumes() []string {
+	if d.specs != nil {
+		return d.externalVolumes
+	}
+
+	var names []string
+	for _, v := range d.Project.Volumes {
+		if v.External {
+			names = append(names, v.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 // ServiceSpec returns the service specification for the given compose service that is ready for deployment.
 func (d *Deployment) ServiceSpec(name string) (api.ServiceSpec, error) {
 	spec, err := ServiceSpecFromCompose(d.Project, name)
@@ -127,7 +298,7 @@ func (d *Deployment) ServiceSpec(name string) (api.ServiceSpec, error) {
 
 // PlanVolumes checks if the external volumes exist and plans the creation of missing volumes.
 func (d *Deployment) planVolumes(serviceSpecs []api.ServiceSpec) ([]*deploy.CreateVolumeOperation, error) {
-	if len(d.Project.Volumes) == 0 {
+	if d.specs == nil && len(d.Project.Volumes) == 0 {
 		// No volumes to check or create.
 		return nil, nil
 	}
@@ -169,15 +340,8 @@ func (d *Deployment) planVolumes(serviceSpecs []api.ServiceSpec) ([]*deploy.Crea
 
 // checkExternalVolumesExist checks that all external volumes exist in the cluster.
 func (d *Deployment) checkExternalVolumesExist() error {
-	var externalNames []string
-	for _, v := range d.Project.Volumes {
-		if v.External {
-			externalNames = append(externalNames, v.Name)
-		}
-	}
-
 	var notFound []string
-	for _, name := range externalNames {
+	for _, name := range d.ExternalVolumes() {
 		if !slices.ContainsFunc(d.state.Machines, func(m *scheduler.Machine) bool {
 			return slices.ContainsFunc(m.Volumes, func(vol volume.Volume) bool {
 				return vol.Name == name
@@ -199,5 +363,5 @@ func (d *Deployment) Run(ctx context.Context) error {
 		return fmt.Errorf("create plan: %w", err)
 	}
 
-	return plan.Execute(ctx, d.Client)
+	return plan.ExecuteConcurrently(ctx, d.Client, deploy.DefaultConcurrency)
 }
@@ -0,0 +1,82 @@
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/clienttest"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// composeClient is a fake cluster client without managed secrets and project quotas.
+type composeClient struct {
+	*clienttest.Client
+}
+
+func (c composeClient) ManagedSecret(context.Context, string) ([]byte, error) {
+	return nil, api.ErrNotFound
+}
+
+func (c composeClient) ProjectQuota(context.Context, string) (api.ProjectQuota, error) {
+	return api.ProjectQuota{}, api.ErrNotFound
+}
+
+func TestNewDeploymentFromSpecs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cli := composeClient{clienttest.New()}
+	cli.AddMachine("m1")
+	specs := []api.ServiceSpec{{
+		Name:     "db",
+		Project:  "shop",
+		Mode:     api.ServiceModeReplicated,
+		Replicas: 1,
+		Container: api.ContainerSpec{
+			Image:        "postgres",
+			VolumeMounts: []api.VolumeMount{{VolumeName: "data", ContainerPath: "/data"}},
+		},
+		Volumes: []api.VolumeSpec{{Name: "data", Type: api.VolumeTypeVolume, VolumeOptions: &api.VolumeOptions{}}},
+	}}
+
+	d, err := NewDeploymentFromSpecs(ctx, cli, "shop", specs, nil, nil)
+	require.NoError(t, err)
+	gotSpecs, err := d.ServiceSpecs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, specs, gotSpecs)
+
+	plan, err := d.Plan(ctx)
+	require.NoError(t, err)
+	require.Len(t, plan.Operations, 2)
+	assert.IsType(t, &deploy.CreateVolumeOperation{}, plan.Operations[0])
+	assert.IsType(t, &deploy.Plan{}, plan.Operations[1])
+
+	d, err = NewDeploymentFromSpecs(ctx, cli, "shop", specs, []string{"data"}, nil)
+	require.NoError(t, err)
+	_, err = d.Plan(ctx)
+	assert.ErrorContains(t, err, "external volumes not found: 'data'")
+}
+
+func TestDeployment_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	// web -> api -> (cache, db), cache -> db. The api and cache services are up to date and skipped.
+	project := &types.Project{Services: types.Services{
+		"web":   {Name: "web", DependsOn: types.DependsOnConfig{"api": {}}},
+		"api":   {Name: "api", DependsOn: types.DependsOnConfig{"cache": {}, "db": {}}},
+		"cache": {Name: "cache", DependsOn: types.DependsOnConfig{"db": {}}},
+		"db":    {Name: "db"},
+	}}
+	d := &Deployment{Project: project, upToDate: []string{"api", "cache"}}
+
+	assert.Equal(t, []string{"db"}, d.dependsOn(nil, "web"))
+	assert.Empty(t, d.dependsOn(nil, "db"))
+
+	d.upToDate = nil
+	assert.Equal(t, []string{"api"}, d.dependsOn(nil, "web"))
+	assert.Equal(t, []string{"cache", "db"}, d.dependsOn(nil, "api"))
+}
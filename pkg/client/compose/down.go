@@ -0,0 +1,148 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+)
+
+// DownClient is the client used to tear down the services of a Compose project.
+type DownClient interface {
+	deploy.Client
+	ListServices(ctx context.Context) ([]api.Service, error)
+}
+
+// DownOptions configures the teardown of a Compose project.
+type DownOptions struct {
+	// Volumes removes the named volumes declared in the Compose file after removing the services.
+	// External volumes are never removed.
+	Volumes bool
+}
+
+// PlanDown returns a plan to remove the services of the project from the cluster. The services that belong to
+// the project and the services defined in the Compose file that were deployed without a project are removed.
+// The services that are no longer defined in the Compose file are removed first, then the services are removed
+// in the reverse dependency order so that a service is removed before the services it depends on. The named volumes
+// declared in the Compose file are removed last if opts.Volumes is set. It returns an error if any of the services
+// or volumes to remove is protected as they can only be removed explicitly.
+func PlanDown(
+	ctx context.Context, cli DownClient, project *types.Project, opts DownOptions,
+) (deploy.SequenceOperation, error) {
+	plan := deploy.SequenceOperation{}
+
+	services, err := cli.ListServices(ctx)
+	if err != nil {
+		return plan, fmt.Errorf("list services: %w", err)
+	}
+	services = slices.DeleteFunc(services, func(s api.Service) bool {
+		if s.Project != "" {
+			return s.Project != project.Name
+		}
+		_, ok := project.Services[s.Name]
+		return !ok
+	})
+
+	order := reverseDependencyOrder(project)
+	slices.SortStableFunc(services, func(a, b api.Service) int {
+		// Services not defined in the Compose file have index -1 and are removed first.
+		if c := slices.Index(order, a.Name) - slices.Index(order, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, s := range services {
+		if s.Protected() {
+			return plan, fmt.Errorf("service '%s' is protected from removal, remove it explicitly with "+
+				"'uc service rm %s --force-unprotect'", s.Name, s.Name)
+		}
+		plan.Operations = append(plan.Operations, &deploy.RemoveServiceOperation{
+			ServiceID:   s.ID,
+			ServiceName: s.Name,
+		})
+	}
+
+	if opts.Volumes {
+		volumeOps, err := planRemoveVolumes(ctx, cli, project)
+		if err != nil {
+			return plan, err
+		}
+		for _, op := range volumeOps {
+			plan.Operations = append(plan.Operations, op)
+		}
+	}
+
+	return plan, nil
+}
+
+// reverseDependencyOrder returns the names of the project services ordered so that each service comes before
+// the services it depends on. Independent services are ordered by name.
+func reverseDependencyOrder(project *types.Project) []string {
+	remaining := project.ServiceNames()
+	slices.Sort(remaining)
+
+	var order []string
+	for len(remaining) > 0 {
+		// Pick the first service no remaining service depends on. If there is a dependency cycle, pick the first
+		// remaining service to make progress.
+		next := 0
+		for i, name := range remaining {
+			if !slices.ContainsFunc(remaining, func(other string) bool {
+				_, ok := project.Services[other].DependsOn[name]
+				return ok
+			}) {
+				next = i
+				break
+			}
+		}
+		order = append(order, remaining[next])
+		remaining = slices.Delete(remaining, next, next+1)
+	}
+
+	return order
+}
+
+// planRemoveVolumes returns the operations to remove the named non-external volumes declared in the project
+// from all machines they exist on.
+func planRemoveVolumes(
+	ctx context.Context, cli DownClient, project *types.Project,
+) ([]*deploy.RemoveVolumeOperation, error) {
+	var names []string
+	for _, v := range project.Volumes {
+		if !v.External {
+			names = append(names, v.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	volumes, err := cli.ListVolumes(ctx, &api.VolumeFilter{Names: names})
+	if err != nil {
+		return nil, fmt.Errorf("list volumes: %w", err)
+	}
+	slices.SortFunc(volumes, func(a, b api.MachineVolume) int {
+		if c := strings.Compare(a.Volume.Name, b.Volume.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.MachineName, b.MachineName)
+	})
+
+	ops := make([]*deploy.RemoveVolumeOperation, 0, len(volumes))
+	for _, v := range volumes {
+		if v.Protected() {
+			return nil, fmt.Errorf("volume '%s' on machine '%s' is protected from removal, remove it explicitly "+
+				"with 'uc volume rm %s --force-unprotect'", v.Volume.Name, v.MachineName, v.Volume.Name)
+		}
+		ops = append(ops, &deploy.RemoveVolumeOperation{
+			VolumeName:  v.Volume.Name,
+			MachineID:   v.MachineID,
+			MachineName: v.MachineName,
+		})
+	}
+	return ops, nil
+}
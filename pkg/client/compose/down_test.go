@@ -0,0 +1,135 @@
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/clienttest"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanDown(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "compose.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+name: shop
+services:
+  web:
+    image: nginx
+    depends_on: [api]
+  api:
+    image: api
+    depends_on: [db]
+    volumes:
+      - data:/data
+  db:
+    image: postgres
+    volumes:
+      - ext:/ext
+volumes:
+  data:
+  ext:
+    external: true
+`), 0o644))
+	project, err := LoadProject(ctx, []string{path})
+	require.NoError(t, err)
+
+	cli := clienttest.New()
+	cli.AddMachine("m1")
+	addService := func(name, project string) api.Service {
+		svc, err := cli.AddService(api.ServiceSpec{
+			Name:      name,
+			Project:   project,
+			Container: api.ContainerSpec{Image: "nginx"},
+		}, "m1")
+		require.NoError(t, err)
+		return svc
+	}
+	db := addService("db", "")
+	web := addService("web", "shop")
+	apiSvc := addService("api", "shop")
+	orphan := addService("worker", "shop")
+	addService("cache", "")
+	addService("web2", "blog")
+	for _, name := range []string{"data", "ext"} {
+		_, err = cli.CreateVolume(ctx, "m1", volume.CreateOptions{Name: name})
+		require.NoError(t, err)
+	}
+
+	plan, err := PlanDown(ctx, cli, project, DownOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []deploy.Operation{
+		&deploy.RemoveServiceOperation{ServiceID: orphan.ID, ServiceName: "worker"},
+		&deploy.RemoveServiceOperation{ServiceID: web.ID, ServiceName: "web"},
+		&deploy.RemoveServiceOperation{ServiceID: apiSvc.ID, ServiceName: "api"},
+		&deploy.RemoveServiceOperation{ServiceID: db.ID, ServiceName: "db"},
+	}, plan.Operations)
+
+	plan, err = PlanDown(ctx, cli, project, DownOptions{Volumes: true})
+	require.NoError(t, err)
+	require.Len(t, plan.Operations, 5)
+	assert.Equal(t, "m1: Remove volume [name=data]", plan.Operations[4].Format(nil))
+
+	require.NoError(t, plan.Execute(ctx, cli))
+	services, err := cli.ListServices(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	assert.ElementsMatch(t, []string{"cache", "web2"}, names)
+	volumes, err := cli.ListVolumes(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, volumes, 1)
+	assert.Equal(t, "ext", volumes[0].Volume.Name)
+}
+
+func TestPlanDown_Protected(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "compose.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+name: shop
+services:
+  db:
+    image: postgres
+    volumes:
+      - data:/data
+volumes:
+  data:
+`), 0o644))
+	project, err := LoadProject(ctx, []string{path})
+	require.NoError(t, err)
+
+	cli := clienttest.New()
+	cli.AddMachine("m1")
+	_, err = cli.AddService(api.ServiceSpec{
+		Name:      "db",
+		Project:   "shop",
+		Container: api.ContainerSpec{Image: "postgres"},
+		Protected: true,
+	}, "m1")
+	require.NoError(t, err)
+
+	_, err = PlanDown(ctx, cli, project, DownOptions{})
+	require.ErrorContains(t, err, "service 'db' is protected from removal")
+
+	_, err = planRemoveVolumes(ctx, cli, project)
+	require.NoError(t, err)
+	_, err = cli.CreateVolume(ctx, "m1", volume.CreateOptions{
+		Name:   "data",
+		Labels: map[string]string{api.LabelProtected: "true"},
+	})
+	require.NoError(t, err)
+	_, err = planRemoveVolumes(ctx, cli, project)
+	require.ErrorContains(t, err, "volume 'data' on machine 'm1' is protected from removal")
+}
@@ -0,0 +1,337 @@
+package compose
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ProjectFromServiceSpecs returns a Compose project with the services defined by the service specs. It's the inverse
+// of ServiceSpecFromCompose and is used to export the services deployed to a cluster as a Compose file that can be
+// deployed again to get the same services.
+func ProjectFromServiceSpecs(specs []api.ServiceSpec) (*types.Project, error) {
+	project := &types.Project{
+		Services: types.Services{},
+		Networks: types.Networks{},
+		Volumes:  types.Volumes{},
+		Configs:  types.Configs{},
+	}
+
+	for i, spec := range specs {
+		// The project name is only set if all services belong to the same project.
+		if i == 0 {
+			project.Name = spec.Project
+		} else if spec.Project != project.Name {
+			project.Name = ""
+		}
+
+		service, err := serviceConfigFromSpec(spec, project)
+		if err != nil {
+			return nil, fmt.Errorf("service '%s': %w", spec.Name, err)
+		}
+		project.Services[spec.Name] = service
+	}
+
+	return project, nil
+}
+
+// serviceConfigFromSpec converts the service spec to a Compose service config and adds the networks, volumes,
+// and configs used by the service to the project.
+func serviceConfigFromSpec(spec api.ServiceSpec, project *types.Project) (types.ServiceConfig, error) {
+	ctr := spec.Container
+	service := types.ServiceConfig{
+		Name:        spec.Name,
+		CapAdd:      ctr.CapAdd,
+		CapDrop:     ctr.CapDrop,
+		Command:     ctr.Command,
+		CPUS:        float32(ctr.Resources.CPU) / 1e9,
+		Entrypoint:  ctr.Entrypoint,
+		Hostname:    ctr.Hostname,
+		Image:       ctr.Image,
+		Init:        ctr.Init,
+		MemLimit:    types.UnitBytes(ctr.Resources.Memory),
+		Privileged:  ctr.Privileged,
+		ReadOnly:    ctr.ReadOnly,
+		SecurityOpt: ctr.SecurityOpt,
+		StopSignal:  ctr.StopSignal,
+		Sysctls:     ctr.Sysctls,
+		User:        ctr.User,
+		Extensions:  types.Extensions{},
+	}
+	service.MemReservation = types.UnitBytes(ctr.Resources.MemoryReservation)
+	if ctr.StopGracePeriod != nil {
+		stopGracePeriod := types.Duration(*ctr.StopGracePeriod)
+		service.StopGracePeriod = &stopGracePeriod
+	}
+
+	// The default pull policy is omitted to keep the exported service concise.
+	if ctr.PullPolicy != "" && ctr.PullPolicy != api.PullPolicyMissing {
+		service.PullPolicy = ctr.PullPolicy
+	}
+	// The default restart policy is omitted as well.
+	if ctr.RestartPolicy != nil && ctr.RestartPolicy.Name != api.RestartPolicyUnlessStopped {
+		service.Restart = ctr.RestartPolicy.String()
+	}
+	if len(ctr.Env) > 0 {
+		service.Environment = types.MappingWithEquals{}
+		for k, v := range ctr.Env {
+			service.Environment[k] = &v
+		}
+	}
+	for _, d := range ctr.Devices {
+		service.Devices = append(service.Devices, types.DeviceMapping{
+			Source:      d.HostPath,
+			Target:      d.ContainerPath,
+			Permissions: d.CgroupPermissions,
+		})
+	}
+	// The default local log driver without options is omitted as well.
+	if ctr.LogDriver != nil && (ctr.LogDriver.Name != "local" || len(ctr.LogDriver.Options) > 0) {
+		service.Logging = &types.LoggingConfig{
+			Driver:  ctr.LogDriver.Name,
+			Options: ctr.LogDriver.Options,
+		}
+	}
+
+	switch spec.Mode {
+	case api.ServiceModeGlobal:
+		service.Deploy = &types.DeployConfig{Mode: "global"}
+	case "", api.ServiceModeReplicated:
+		if spec.Replicas > 1 {
+			replicas := int(spec.Replicas)
+			service.Deploy = &types.DeployConfig{Replicas: &replicas}
+		}
+	default:
+		return service, fmt.Errorf("unsupported mode: '%s'", spec.Mode)
+	}
+	if c := spec.UpdateConfig; c != nil {
+		if service.Deploy == nil {
+			service.Deploy = &types.DeployConfig{}
+		}
+		service.Deploy.UpdateConfig = &types.UpdateConfig{
+			Delay:           types.Duration(c.Delay),
+			FailureAction:   c.FailureAction,
+			Monitor:         types.Duration(c.Monitor),
+			MaxFailureRatio: float32(c.MaxFailureRatio),
+			Order:           c.Order,
+		}
+		if c.Parallelism != nil {
+			parallelism := uint64(*c.Parallelism)
+			service.Deploy.UpdateConfig.Parallelism = &parallelism
+		}
+	}
+
+	if err := networkConfigFromSpec(spec, &service, project); err != nil {
+		return service, err
+	}
+
+	if caddyConfig := spec.CaddyConfig(); caddyConfig != "" {
+		service.Extensions[CaddyExtensionKey] = caddyConfig
+	}
+	if len(spec.Ports) > 0 {
+		ports := make([]string, len(spec.Ports))
+		for i, p := range spec.Ports {
+			port, err := p.String()
+			if err != nil {
+				return service, fmt.Errorf("invalid port: %w", err)
+			}
+			ports[i] = port
+		}
+		service.Extensions[PortsExtensionKey] = ports
+	}
+	if len(spec.Placement.Machines) > 0 {
+		service.Extensions[MachinesExtensionKey] = spec.Placement.Machines
+	}
+	if spec.Backup != nil {
+		service.Extensions[BackupExtensionKey] = backupFromSpec(*spec.Backup)
+	}
+	if spec.Protected {
+		service.Extensions[ProtectedExtensionKey] = true
+	}
+	if spec.Owner != "" {
+		service.Extensions[OwnerExtensionKey] = spec.Owner
+	}
+	if spec.Priority != api.PriorityNormal {
+		service.Extensions[PriorityExtensionKey] = api.FormatPriority(spec.Priority)
+	}
+	if spec.ScaleSchedule != nil {
+		service.Extensions[ScaleScheduleExtensionKey] = scaleScheduleFromSpec(*spec.ScaleSchedule)
+	}
+	if spec.Tenant != "" {
+		service.Extensions[TenantExtensionKey] = spec.Tenant
+	}
+
+	if err := volumesFromSpec(spec, &service, project); err != nil {
+		return service, err
+	}
+	if err := configsFromSpec(spec, &service, project); err != nil {
+		return service, err
+	}
+
+	return service, nil
+}
+
+func networkConfigFromSpec(spec api.ServiceSpec, service *types.ServiceConfig, project *types.Project) error {
+	switch spec.NetworkMode {
+	case "":
+		names := spec.Networks
+		if len(names) == 0 && len(spec.Aliases) > 0 {
+			// Compose only supports aliases on the service networks.
+			names = []string{api.DefaultNetwork}
+		}
+		for _, name := range names {
+			if service.Networks == nil {
+				service.Networks = map[string]*types.ServiceNetworkConfig{}
+			}
+			service.Networks[name] = nil
+			if len(spec.Aliases) > 0 {
+				service.Networks[name] = &types.ServiceNetworkConfig{Aliases: spec.Aliases}
+			}
+			if name != api.DefaultNetwork {
+				project.Networks[name] = types.NetworkConfig{}
+			}
+		}
+	case api.NetworkModeHost:
+		service.NetworkMode = api.NetworkModeHost
+	case api.NetworkModeMacvlan:
+		if spec.Macvlan == nil {
+			return fmt.Errorf("macvlan options are required in %s network mode", api.NetworkModeMacvlan)
+		}
+		// Each macvlan service gets its own network as there is no way to tell if services share the same network.
+		name := spec.Name + "-" + api.NetworkModeMacvlan
+		pool := &types.IPAMPool{Subnet: spec.Macvlan.Subnet.String()}
+		if spec.Macvlan.Gateway.IsValid() {
+			pool.Gateway = spec.Macvlan.Gateway.String()
+		}
+		if spec.Macvlan.IPRange.IsValid() {
+			pool.IPRange = spec.Macvlan.IPRange.String()
+		}
+		nw := types.NetworkConfig{
+			Driver: api.NetworkModeMacvlan,
+			Ipam:   types.IPAMConfig{Config: []*types.IPAMPool{pool}},
+		}
+		if spec.Macvlan.Parent != "" {
+			nw.DriverOpts = types.Options{"parent": spec.Macvlan.Parent}
+		}
+		project.Networks[name] = nw
+		service.Networks = map[string]*types.ServiceNetworkConfig{name: nil}
+	default:
+		return fmt.Errorf("unsupported network mode: '%s'", spec.NetworkMode)
+	}
+
+	return nil
+}
+
+func volumesFromSpec(spec api.ServiceSpec, service *types.ServiceConfig, project *types.Project) error {
+	for _, m := range spec.Container.VolumeMounts {
+		v, ok := spec.Volume(m.VolumeName)
+		if !ok {
+			return fmt.Errorf("volume '%s' not found in service spec", m.VolumeName)
+		}
+
+		serviceVolume := types.ServiceVolumeConfig{
+			Target:   m.ContainerPath,
+			ReadOnly: m.ReadOnly,
+		}
+		switch v.Type {
+		case api.VolumeTypeBind:
+			serviceVolume.Type = types.VolumeTypeBind
+			if v.BindOptions != nil {
+				serviceVolume.Source = v.BindOptions.HostPath
+				serviceVolume.Bind = &types.ServiceVolumeBind{
+					CreateHostPath: v.BindOptions.CreateHostPath,
+					Propagation:    string(v.BindOptions.Propagation),
+					Recursive:      v.BindOptions.Recursive,
+				}
+			}
+		case api.VolumeTypeVolume:
+			serviceVolume.Type = types.VolumeTypeVolume
+			serviceVolume.Source = v.Name
+
+			volume := dockerVolumeConfigFromSpec(v)
+			if existing, ok := project.Volumes[v.Name]; ok && !volumeConfigEqual(existing, volume) {
+				return fmt.Errorf("volume '%s' is used by multiple services with different options", v.Name)
+			}
+			project.Volumes[v.Name] = volume
+
+			if v.VolumeOptions != nil && (v.VolumeOptions.NoCopy || v.VolumeOptions.SubPath != "") {
+				serviceVolume.Volume = &types.ServiceVolumeVolume{
+					NoCopy:  v.VolumeOptions.NoCopy,
+					Subpath: v.VolumeOptions.SubPath,
+				}
+			}
+		case api.VolumeTypeTmpfs:
+			serviceVolume.Type = types.VolumeTypeTmpfs
+			if v.TmpfsOptions != nil {
+				serviceVolume.Tmpfs = &types.ServiceVolumeTmpfs{
+					Size: types.UnitBytes(v.TmpfsOptions.SizeBytes),
+					Mode: uint32(v.TmpfsOptions.Mode),
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported volume type: '%s'", v.Type)
+		}
+
+		service.Volumes = append(service.Volumes, serviceVolume)
+	}
+
+	return nil
+}
+
+func dockerVolumeConfigFromSpec(v api.VolumeSpec) types.VolumeConfig {
+	var volume types.VolumeConfig
+	if v.VolumeOptions == nil {
+		return volume
+	}
+
+	// The volume name defaults to the volume key when the Compose file is loaded.
+	if v.VolumeOptions.Name != "" && v.VolumeOptions.Name != v.Name {
+		volume.Name = v.VolumeOptions.Name
+	}
+	if v.VolumeOptions.Driver != nil {
+		volume.Driver = v.VolumeOptions.Driver.Name
+		volume.DriverOpts = v.VolumeOptions.Driver.Options
+	}
+	if len(v.VolumeOptions.Labels) > 0 {
+		volume.Labels = v.VolumeOptions.Labels
+	}
+
+	return volume
+}
+
+func volumeConfigEqual(a, b types.VolumeConfig) bool {
+	return a.Name == b.Name && a.Driver == b.Driver &&
+		maps.Equal(a.DriverOpts, b.DriverOpts) && maps.Equal(a.Labels, b.Labels)
+}
+
+func configsFromSpec(spec api.ServiceSpec, service *types.ServiceConfig, project *types.Project) error {
+	for _, m := range spec.Container.ConfigMounts {
+		c, ok := spec.Config(m.ConfigName)
+		if !ok {
+			return fmt.Errorf("config '%s' not found in service spec", m.ConfigName)
+		}
+
+		config := types.ConfigObjConfig{Content: string(c.Content)}
+		if existing, ok := project.Configs[c.Name]; ok && existing.Content != config.Content {
+			return fmt.Errorf("config '%s' is used by multiple services with different content", c.Name)
+		}
+		project.Configs[c.Name] = config
+
+		serviceConfig := types.ServiceConfigObjConfig{
+			Source: c.Name,
+			Target: m.ContainerPath,
+			UID:    m.Uid,
+			GID:    m.Gid,
+		}
+		if m.Mode != nil {
+			mode := uint32(*m.Mode)
+			serviceConfig.Mode = &mode
+		}
+		service.Configs = append(service.Configs, serviceConfig)
+	}
+
+	return nil
+}
+
@@ -0,0 +1,177 @@
+package compose
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectFromServiceSpecs_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	initTrue := true
+	configMode := os.FileMode(0o600)
+	stopGracePeriod := time.Minute
+	specs := []api.ServiceSpec{
+		{
+			Name:     "web",
+			Mode:     api.ServiceModeReplicated,
+			Replicas: 3,
+			Container: api.ContainerSpec{
+				CapAdd:     []string{"NET_ADMIN"},
+				Command:    []string{"nginx", "-g", "daemon off;"},
+				Env:        api.EnvVars{"FOO": "bar", "EMPTY": ""},
+				Image:      "nginx:1.27",
+				Init:       &initTrue,
+				PullPolicy: api.PullPolicyAlways,
+				Resources: api.ContainerResources{
+					CPU:    500_000_000,
+					Memory: 256 * 1024 * 1024,
+				},
+				User: "1000:1000",
+				VolumeMounts: []api.VolumeMount{
+					{VolumeName: "data", ContainerPath: "/data"},
+					{
+						VolumeName:    "bind-nginx-conf",
+						ContainerPath: "/etc/nginx/conf.d",
+						ReadOnly:      true,
+					},
+				},
+				ConfigMounts: []api.ConfigMount{
+					{ConfigName: "index", ContainerPath: "/usr/share/nginx/html/index.html", Mode: &configMode},
+				},
+			},
+			Placement: api.Placement{Machines: []string{"vps1", "vps2"}},
+			Ports: []api.PortSpec{
+				{Hostname: "app.example.com", ContainerPort: 80, Protocol: api.ProtocolHTTPS, Mode: api.PortModeIngress},
+				{PublishedPort: 8080, ContainerPort: 8080, Protocol: api.ProtocolTCP, Mode: api.PortModeHost},
+			},
+			Volumes: []api.VolumeSpec{
+				{
+					Name: "data",
+					Type: api.VolumeTypeVolume,
+					VolumeOptions: &api.VolumeOptions{
+						Name:   "web-data",
+						Driver: &mount.Driver{Name: "local", Options: map[string]string{"type": "nfs"}},
+					},
+				},
+				{
+					Name: "bind-nginx-conf",
+					Type: api.VolumeTypeBind,
+					BindOptions: &api.BindOptions{
+						HostPath:       "/srv/nginx",
+						CreateHostPath: true,
+					},
+				},
+			},
+			Configs: []api.ConfigSpec{
+				{Name: "index", Content: []byte("<h1>Hello</h1>\n")},
+			},
+		},
+		{
+			Name: "proxy",
+			Mode: api.ServiceModeGlobal,
+			Caddy: &api.CaddySpec{
+				Config: "proxy.example.com {\n  reverse_proxy web:80\n}",
+			},
+			Container: api.ContainerSpec{
+				Image: "caddy:2",
+				LogDriver: &api.LogDriver{
+					Name:    "json-file",
+					Options: map[string]string{"max-size": "10m"},
+				},
+			},
+			NetworkMode: api.NetworkModeHost,
+		},
+		{
+			Name:   "db",
+			Backup: &api.BackupSpec{Engine: api.BackupEnginePostgres, Interval: 6 * time.Hour, Retention: 14},
+			Container: api.ContainerSpec{
+				Image: "postgres:17",
+			},
+		},
+		{
+			Name: "lan",
+			Container: api.ContainerSpec{
+				Image:           "alpine",
+				RestartPolicy:   &api.RestartPolicy{Name: api.RestartPolicyOnFailure, MaxRetries: 3},
+				StopGracePeriod: &stopGracePeriod,
+				StopSignal:      "SIGINT",
+			},
+			NetworkMode: api.NetworkModeMacvlan,
+			Macvlan: &api.MacvlanOptions{
+				Parent:  "eth0",
+				Subnet:  netip.MustParsePrefix("192.168.1.0/24"),
+				Gateway: netip.MustParseAddr("192.168.1.1"),
+			},
+		},
+	}
+
+	for i := range specs {
+		specs[i].Project = "shop"
+	}
+
+	project, err := ProjectFromServiceSpecs(specs)
+	require.NoError(t, err)
+	data, err := project.MarshalYAML()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "compose.yaml")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	loaded, err := LoadProject(context.Background(), []string{path})
+	require.NoError(t, err, string(data))
+
+	for _, want := range specs {
+		got, err := ServiceSpecFromCompose(loaded, want.Name)
+		require.NoError(t, err)
+
+		// The bind volume name is derived from the container path when loading the Compose file.
+		for i, v := range got.Volumes {
+			if v.Type == api.VolumeTypeBind {
+				for j, m := range got.Container.VolumeMounts {
+					if m.VolumeName == v.Name {
+						got.Container.VolumeMounts[j].VolumeName = want.Volumes[1].Name
+					}
+				}
+				got.Volumes[i].Name = want.Volumes[1].Name
+			}
+		}
+		sortVolumes := func(a, b api.VolumeSpec) int { return strings.Compare(a.Name, b.Name) }
+		slices.SortFunc(got.Volumes, sortVolumes)
+		slices.SortFunc(want.Volumes, sortVolumes)
+
+		want, got = want.SetDefaults(), got.SetDefaults()
+		cmpOpts := cmp.Options{cmpopts.EquateEmpty(), cmpopts.EquateComparable(netip.Addr{}, netip.Prefix{})}
+		assert.True(t, cmp.Equal(got, want, cmpOpts...), cmp.Diff(got, want, cmpOpts...))
+	}
+}
+
+func TestProjectFromServiceSpecs_Conflicts(t *testing.T) {
+	t.Parallel()
+
+	service := func(name, content string) api.ServiceSpec {
+		return api.ServiceSpec{
+			Name:      name,
+			Container: api.ContainerSpec{Image: "nginx", ConfigMounts: []api.ConfigMount{{ConfigName: "conf"}}},
+			Configs:   []api.ConfigSpec{{Name: "conf", Content: []byte(content)}},
+		}
+	}
+
+	_, err := ProjectFromServiceSpecs([]api.ServiceSpec{service("a", "same"), service("b", "same")})
+	require.NoError(t, err)
+
+	_, err = ProjectFromServiceSpecs([]api.ServiceSpec{service("a", "one"), service("b", "two")})
+	assert.ErrorContains(t, err, "config 'conf' is used by multiple services with different content")
+}
@@ -0,0 +1,23 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OwnerExtensionKey is the service extension that sets the team or client the service belongs to, e.g.
+// x-owner: acme to attribute the cost of the service to the client with 'uc cost report --by owner'.
+const OwnerExtensionKey = "x-owner"
+
+// ownerFromCompose parses the x-owner extension value that must be a non-empty string.
+func ownerFromCompose(value any) (string, error) {
+	owner, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid type %T for x-owner extension: expected string", value)
+	}
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return "", fmt.Errorf("x-owner extension must not be empty")
+	}
+	return owner, nil
+}
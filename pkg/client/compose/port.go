@@ -96,17 +96,29 @@ func convertServicePortConfigToPortSpec(port types.ServicePortConfig) (api.PortS
 
 	// Set published port if specified
 	if port.Published != "" {
-		if strings.Contains(port.Published, "-") {
-			// 'a-b:x' format is not automatically expanded by the compose parser and our PortSpec does not support port
-			// ranges for now.
-			return spec, fmt.Errorf("port range '%s' for published port is not supported, use a single port",
-				port.Published)
-		}
-		publishedPort, err := strconv.ParseUint(port.Published, 10, 16)
-		if err != nil {
-			return spec, fmt.Errorf("invalid published port %q: %w", port.Published, err)
+		if start, end, isRange := strings.Cut(port.Published, "-"); isRange {
+			// 'a-b:x' format is not automatically expanded by the compose parser. Treat it as a range of the same size
+			// starting from the target port as PortSpec doesn't support publishing to any port in a range.
+			publishedPort, err := strconv.ParseUint(start, 10, 16)
+			if err != nil {
+				return spec, fmt.Errorf("invalid published port range %q: %w", port.Published, err)
+			}
+			publishedPortEnd, err := strconv.ParseUint(end, 10, 16)
+			if err != nil {
+				return spec, fmt.Errorf("invalid published port range %q: %w", port.Published, err)
+			}
+			if publishedPortEnd > publishedPort {
+				spec.PublishedPortEnd = uint16(publishedPortEnd)
+				spec.ContainerPortEnd = spec.ContainerPort + uint16(publishedPortEnd-publishedPort)
+			}
+			spec.PublishedPort = uint16(publishedPort)
+		} else {
+			publishedPort, err := strconv.ParseUint(port.Published, 10, 16)
+			if err != nil {
+				return spec, fmt.Errorf("invalid published port %q: %w", port.Published, err)
+			}
+			spec.PublishedPort = uint16(publishedPort)
 		}
-		spec.PublishedPort = uint16(publishedPort)
 	}
 
 	// Set host IP if specified
@@ -127,6 +139,8 @@ func convertServicePortConfigToPortSpec(port types.ServicePortConfig) (api.PortS
 }
 
 // convertStandardPortsToPortSpecs converts []types.ServicePortConfig directly to api.PortSpecs.
+// The compose parser expands port ranges in the short syntax such as '10000-10100:10000-10100' into individual ports
+// that are kept as is. A host mode port range can be published using the long syntax with a published port range.
 func convertStandardPortsToPortSpecs(ports []types.ServicePortConfig) ([]api.PortSpec, error) {
 	specs := make([]api.PortSpec, 0, len(ports))
 
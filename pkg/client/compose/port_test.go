@@ -34,6 +34,26 @@ func TestConvertStandardPortsToPortSpecs(t *testing.T) {
 				{ContainerPort: 2222, PublishedPort: 22, Protocol: "tcp", Mode: "ingress"},
 			},
 		},
+		{
+			name: "consecutive ports are not merged",
+			ports: []types.ServicePortConfig{
+				{Target: 10000, Published: "10000", Protocol: "udp", Mode: "host"},
+				{Target: 10001, Published: "10001", Protocol: "udp", Mode: "host"},
+				{Target: 10002, Published: "10000-10001", Protocol: "tcp", Mode: "host"},
+			},
+			expected: []api.PortSpec{
+				{ContainerPort: 10000, PublishedPort: 10000, Protocol: "udp", Mode: "host"},
+				{ContainerPort: 10001, PublishedPort: 10001, Protocol: "udp", Mode: "host"},
+				{
+					ContainerPort:    10002,
+					ContainerPortEnd: 10003,
+					PublishedPort:    10000,
+					PublishedPortEnd: 10001,
+					Protocol:         "tcp",
+					Mode:             "host",
+				},
+			},
+		},
 		{
 			name:     "empty ports",
 			ports:    []types.ServicePortConfig{},
@@ -196,6 +216,23 @@ func TestConvertServicePortConfigToPortSpec(t *testing.T) {
 				Mode:          "ingress",
 			},
 		},
+		{
+			name: "published port range in host mode",
+			port: types.ServicePortConfig{
+				Target:    10000,
+				Published: "20000-20100",
+				Protocol:  "udp",
+				Mode:      "host",
+			},
+			expected: api.PortSpec{
+				PublishedPort:    20000,
+				PublishedPortEnd: 20100,
+				ContainerPort:    10000,
+				ContainerPortEnd: 10100,
+				Protocol:         "udp",
+				Mode:             "host",
+			},
+		},
 		// Error cases
 		{
 			name: "invalid published port",
@@ -222,11 +259,23 @@ func TestConvertServicePortConfigToPortSpec(t *testing.T) {
 			wantErr: "container port must be non-zero",
 		},
 		{
-			name: "missing container port",
+			name: "published port range in ingress mode",
 			port: types.ServicePortConfig{
+				Target:    8000,
 				Published: "8000-9000",
+				Protocol:  "udp",
+				Mode:      "ingress",
+			},
+			wantErr: "port ranges are only supported in host mode",
+		},
+		{
+			name: "invalid published port range",
+			port: types.ServicePortConfig{
+				Target:    8000,
+				Published: "8000-x",
+				Mode:      "host",
 			},
-			wantErr: "port range '8000-9000' for published port is not supported",
+			wantErr: "invalid published port range",
 		},
 	}
 
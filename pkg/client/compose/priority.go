@@ -0,0 +1,33 @@
+package compose
+
+import (
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// PriorityExtensionKey is the service extension that sets the scheduling priority of the service, either as
+// a priority class name or an integer, e.g. x-priority: high. A higher priority service can preempt the containers
+// of lower priority services on machines without enough unreserved resources.
+const PriorityExtensionKey = "x-priority"
+
+// priorityFromCompose parses the x-priority extension value that must be a priority class name or an integer.
+func priorityFromCompose(value any) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case uint64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("invalid x-priority extension %v: must be an integer", v)
+		}
+		return int(v), nil
+	case string:
+		return api.ParsePriority(v)
+	default:
+		return 0, fmt.Errorf("invalid type %T for x-priority extension: expected string or integer", value)
+	}
+}
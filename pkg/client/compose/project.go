@@ -10,12 +10,8 @@ import (
 	"github.com/compose-spec/compose-go/v2/types"
 )
 
-// FakeProjectName is a placeholder name for the project to be able to strip it from the resource names used as prefix.
-const FakeProjectName = "f-a-k-e"
-
 func LoadProject(ctx context.Context, paths []string, opts ...composecli.ProjectOptionsFn) (*types.Project, error) {
 	defaultOpts := []composecli.ProjectOptionsFn{
-		composecli.WithName(FakeProjectName),
 		// First apply os.Environment, always wins.
 		composecli.WithOsEnv,
 		// Read dot env file to populate project environment.
@@ -24,9 +20,11 @@ func LoadProject(ctx context.Context, paths []string, opts ...composecli.Project
 		composecli.WithConfigFileEnv,
 		// If none was selected, get default Compose file names from current or parent folders.
 		composecli.WithDefaultConfigPath,
+		composecli.WithExtension(BackupExtensionKey, Backup{}),
 		composecli.WithExtension(CaddyExtensionKey, Caddy{}),
 		composecli.WithExtension(MachinesExtensionKey, MachinesSource{}),
 		composecli.WithExtension(PortsExtensionKey, PortsSource{}),
+		composecli.WithExtension(ScaleScheduleExtensionKey, ScaleSchedule{}),
 	}
 
 	options, err := composecli.NewProjectOptions(
@@ -42,6 +40,8 @@ func LoadProject(ctx context.Context, paths []string, opts ...composecli.Project
 		return nil, err
 	}
 
+	trimVolumeNamesPrefix(project)
+
 	if project, err = transformServicesCaddyExtension(project); err != nil {
 		return nil, err
 	}
@@ -56,3 +56,14 @@ func LoadProject(ctx context.Context, paths []string, opts ...composecli.Project
 
 	return project, nil
 }
+
+// trimVolumeNamesPrefix removes the project name prefix the loader adds to the names of the volumes without
+// an explicit name as Docker Compose does. Uncloud volumes are not namespaced by the project.
+func trimVolumeNamesPrefix(project *types.Project) {
+	for key, v := range project.Volumes {
+		if !v.External && v.Name == project.Name+"_"+key {
+			v.Name = key
+			project.Volumes[key] = v
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProject_Name(t *testing.T) {
+	writeCompose := func(t *testing.T, content string) string {
+		dir := filepath.Join(t.TempDir(), "My Shop")
+		require.NoError(t, os.Mkdir(dir, 0o755))
+		path := filepath.Join(dir, "compose.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	t.Run("directory name", func(t *testing.T) {
+		path := writeCompose(t, "services:\n  web:\n    image: nginx\n")
+
+		project, err := LoadProject(context.Background(), []string{path})
+		require.NoError(t, err)
+		assert.Equal(t, "myshop", project.Name)
+
+		spec, err := ServiceSpecFromCompose(project, "web")
+		require.NoError(t, err)
+		assert.Equal(t, "myshop", spec.Project)
+	})
+
+	t.Run("name in compose file", func(t *testing.T) {
+		path := writeCompose(t, "name: shop-${ENV:-prod}\nservices:\n  web:\n    image: nginx\n")
+
+		project, err := LoadProject(context.Background(), []string{path})
+		require.NoError(t, err)
+		assert.Equal(t, "shop-prod", project.Name)
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		t.Setenv("COMPOSE_PROJECT_NAME", "staging")
+		path := writeCompose(t, "name: shop\nservices:\n  web:\n    image: nginx\n")
+
+		project, err := LoadProject(context.Background(), []string{path})
+		require.NoError(t, err)
+		assert.Equal(t, "staging", project.Name)
+	})
+
+	t.Run("volume names are not prefixed", func(t *testing.T) {
+		path := writeCompose(t, "name: shop\nservices:\n  web:\n    image: nginx\n"+
+			"volumes:\n  data:\n  logs:\n    name: shop-logs\n")
+
+		project, err := LoadProject(context.Background(), []string{path})
+		require.NoError(t, err)
+		assert.Equal(t, "data", project.Volumes["data"].Name)
+		assert.Equal(t, "shop-logs", project.Volumes["logs"].Name)
+	})
+}
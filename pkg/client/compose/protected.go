@@ -0,0 +1,26 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ProtectedExtensionKey is the service extension that protects the service from accidental removal, e.g.
+// x-protected: true for a production database.
+const ProtectedExtensionKey = "x-protected"
+
+// protectedFromCompose parses the x-protected extension value that is a boolean or a string if interpolated.
+func protectedFromCompose(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		protected, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("invalid x-protected value '%s': expected true or false", v)
+		}
+		return protected, nil
+	default:
+		return false, fmt.Errorf("invalid type %T for x-protected extension: expected boolean", value)
+	}
+}
@@ -0,0 +1,55 @@
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// checkProjectQuota returns an error if deploying the service specs would exceed the resource quota of the project.
+// The usage includes the deployed project services that are not being redeployed.
+func (d *Deployment) checkProjectQuota(ctx context.Context, specs []api.ServiceSpec) error {
+	quota, err := d.Client.ProjectQuota(ctx, d.Project.Name)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get project quota: %w", err)
+	}
+
+	services, err := d.Client.ListServicesFiltered(ctx, &api.ServiceFilter{Project: d.Project.Name})
+	if err != nil {
+		return fmt.Errorf("list project services: %w", err)
+	}
+
+	var usage api.ProjectUsage
+	for _, s := range services {
+		if !slices.ContainsFunc(specs, func(spec api.ServiceSpec) bool { return spec.Name == s.Name }) {
+			usage.AddDeployedService(s)
+		}
+	}
+	for _, spec := range specs {
+		resolved, err := d.SpecResolver.Resolve(spec)
+		if err != nil {
+			return err
+		}
+		containers := int(resolved.Replicas)
+		if resolved.Mode == api.ServiceModeGlobal {
+			// A global service runs a container on every machine that matches the placement constraints.
+			containers = len(d.state.Machines)
+			if len(resolved.Placement.Machines) > 0 {
+				containers = min(containers, len(resolved.Placement.Machines))
+			}
+		}
+		usage.AddService(resolved, containers)
+	}
+
+	if err = quota.Check(usage); err != nil {
+		return fmt.Errorf("%w. Scale down or remove services, or raise the quota with 'uc quota set %s'",
+			err, d.Project.Name)
+	}
+	return nil
+}
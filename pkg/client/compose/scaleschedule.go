@@ -0,0 +1,79 @@
+package compose
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const ScaleScheduleExtensionKey = "x-scale-schedule"
+
+// ScaleSchedule represents the x-scale-schedule extension that sets the number of replicas of the service depending
+// on the time of day and week.
+type ScaleSchedule struct {
+	// Timezone is the IANA name of the time zone the windows are evaluated in, e.g. Europe/Berlin. Default is UTC.
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty" mapstructure:"timezone"`
+	// Replicas is the number of replicas outside all windows.
+	Replicas uint `yaml:"replicas" json:"replicas" mapstructure:"replicas"`
+	// Windows are the recurring time windows with their number of replicas.
+	Windows []ScaleWindow `yaml:"windows" json:"windows" mapstructure:"windows"`
+}
+
+// ScaleWindow is a recurring time window of the x-scale-schedule extension, e.g. "Mon-Fri 08:00-20:00".
+type ScaleWindow struct {
+	Window   string `yaml:"window" json:"window" mapstructure:"window"`
+	Replicas uint   `yaml:"replicas" json:"replicas" mapstructure:"replicas"`
+}
+
+// DecodeMapstructure decodes x-scale-schedule extension from an object.
+func (s *ScaleSchedule) DecodeMapstructure(value any) error {
+	switch v := value.(type) {
+	case *ScaleSchedule:
+		// Already decoded, happens when mapstructure is called after initial parsing.
+		*s = *v
+		return nil
+	case map[string]any:
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:           s,
+			ErrorUnused:      true,
+			WeaklyTypedInput: true,
+		})
+		if err != nil {
+			return fmt.Errorf("create decoder for x-scale-schedule extension: %w", err)
+		}
+		if err = decoder.Decode(v); err != nil {
+			return fmt.Errorf("decode x-scale-schedule extension: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid type %T for x-scale-schedule extension: expected object", value)
+	}
+	return nil
+}
+
+// scaleScheduleSpecFromCompose converts the x-scale-schedule extension to the service scale schedule.
+func scaleScheduleSpecFromCompose(s ScaleSchedule) (*api.ScaleSchedule, error) {
+	spec := &api.ScaleSchedule{
+		Timezone: s.Timezone,
+		Replicas: s.Replicas,
+	}
+	for _, w := range s.Windows {
+		spec.Windows = append(spec.Windows, api.ScaleWindow{Window: w.Window, Replicas: w.Replicas})
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid x-scale-schedule: %w", err)
+	}
+	return spec, nil
+}
+
+// scaleScheduleFromSpec converts the service scale schedule to the x-scale-schedule extension.
+func scaleScheduleFromSpec(spec api.ScaleSchedule) ScaleSchedule {
+	s := ScaleSchedule{
+		Timezone: spec.Timezone,
+		Replicas: spec.Replicas,
+	}
+	for _, w := range spec.Windows {
+		s.Windows = append(s.Windows, ScaleWindow{Window: w.Window, Replicas: w.Replicas})
+	}
+	return s
+}
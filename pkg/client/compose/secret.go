@@ -0,0 +1,103 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// secretsDir is the directory in the container where secrets are mounted by default like in Docker Compose.
+const secretsDir = "/run/secrets"
+
+// secretSpecsFromCompose converts the service secrets to configs mounted as files into the container. External
+// secrets must be resolved with ResolveExternalSecrets before the conversion.
+func secretSpecsFromCompose(
+	secrets types.Secrets, serviceSecrets []types.ServiceSecretConfig, workingDir string,
+) ([]api.ConfigSpec, []api.ConfigMount, error) {
+	var configSpecs []api.ConfigSpec
+	var configMounts []api.ConfigMount
+
+	for _, serviceSecret := range serviceSecrets {
+		projectSecret, ok := secrets[serviceSecret.Source]
+		if !ok {
+			return nil, nil, fmt.Errorf("secret '%s' not found in project secrets", serviceSecret.Source)
+		}
+		if projectSecret.External {
+			return nil, nil, fmt.Errorf("external secrets are not supported except '%s' and '%s<name>': %s",
+				api.ObjectStorageSecretName, api.PostgresSecretPrefix, serviceSecret.Source)
+		}
+
+		// The content of a secret from an environment variable is resolved by the compose loader.
+		spec := api.ConfigSpec{
+			Name:    serviceSecret.Source,
+			Content: []byte(projectSecret.Content),
+		}
+		if projectSecret.File != "" {
+			secretPath := projectSecret.File
+			if !filepath.IsAbs(secretPath) {
+				secretPath = filepath.Join(workingDir, secretPath)
+			}
+			content, err := os.ReadFile(secretPath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("read secret from file '%s': %w", projectSecret.File, err)
+			}
+			spec.Content = content
+		}
+		configSpecs = append(configSpecs, spec)
+
+		target := serviceSecret.Target
+		if target == "" {
+			target = serviceSecret.Source
+		}
+		if !path.IsAbs(target) {
+			target = path.Join(secretsDir, target)
+		}
+
+		mount := api.ConfigMount{
+			ConfigName:    spec.Name,
+			ContainerPath: target,
+			Uid:           serviceSecret.UID,
+			Gid:           serviceSecret.GID,
+		}
+		if serviceSecret.Mode != nil {
+			mode := os.FileMode(*serviceSecret.Mode)
+			mount.Mode = &mode
+		}
+		configMounts = append(configMounts, mount)
+	}
+
+	return configSpecs, configMounts, nil
+}
+
+// ResolveExternalSecrets replaces the external secrets in the project that are managed by the cluster with
+// their content: the ObjectStorageSecretName secret with the managed object storage credentials and the secrets
+// of managed Postgres clusters. The content is fetched with getSecret that returns api.ErrNotFound for secrets
+// not managed by the cluster. Such secrets are left as is.
+func ResolveExternalSecrets(project *types.Project, getSecret func(name string) ([]byte, error)) error {
+	for key, s := range project.Secrets {
+		if !s.External {
+			continue
+		}
+		name := s.Name
+		if name == "" {
+			name = key
+		}
+
+		content, err := getSecret(name)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("resolve external secret '%s': %w", key, err)
+		}
+		s.External = false
+		s.Content = string(content)
+		project.Secrets[key] = s
+	}
+	return nil
+}
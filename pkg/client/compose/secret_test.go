@@ -0,0 +1,179 @@
+package compose
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretSpecsFromCompose(t *testing.T) {
+	tests := []struct {
+		name           string
+		secrets        types.Secrets
+		serviceSecrets []types.ServiceSecretConfig
+		expectedSpecs  []api.ConfigSpec
+		expectedMounts []api.ConfigMount
+		expectError    bool
+	}{
+		{
+			name: "secret from file mounted to default path",
+			secrets: types.Secrets{
+				"db-password": types.SecretConfig{
+					File: "testdata/config1.txt",
+				},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "db-password"},
+			},
+			expectedSpecs: []api.ConfigSpec{
+				{
+					Name:    "db-password",
+					Content: []byte("test config content\n"),
+				},
+			},
+			expectedMounts: []api.ConfigMount{
+				{
+					ConfigName:    "db-password",
+					ContainerPath: "/run/secrets/db-password",
+				},
+			},
+		},
+		{
+			name: "secret with content and relative target",
+			secrets: types.Secrets{
+				"token": types.SecretConfig{
+					Environment: "TOKEN",
+					Content:     "secret-token",
+				},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "token", Target: "api-token", UID: "1000"},
+			},
+			expectedSpecs: []api.ConfigSpec{
+				{
+					Name:    "token",
+					Content: []byte("secret-token"),
+				},
+			},
+			expectedMounts: []api.ConfigMount{
+				{
+					ConfigName:    "token",
+					ContainerPath: "/run/secrets/api-token",
+					Uid:           "1000",
+				},
+			},
+		},
+		{
+			name: "unresolved external secret",
+			secrets: types.Secrets{
+				"storage": types.SecretConfig{
+					External: true,
+				},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "storage"},
+			},
+			expectError: true,
+		},
+		{
+			name:    "missing secret",
+			secrets: types.Secrets{},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "missing"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, mounts, err := secretSpecsFromCompose(tt.secrets, tt.serviceSecrets, ".")
+
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expectedSpecs, specs)
+			assert.Equal(t, tt.expectedMounts, mounts)
+		})
+	}
+}
+
+func TestResolveExternalSecrets(t *testing.T) {
+	storage := api.ObjectStorage{
+		Endpoint:        "http://uncloud-storage.internal:9000",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	}
+	getSecret := func(name string) ([]byte, error) {
+		switch name {
+		case api.ObjectStorageSecretName:
+			return storage.Secret(), nil
+		case api.PostgresSecretPrefix + "db":
+			return []byte("PGHOST=db.internal\n"), nil
+		}
+		return nil, api.ErrNotFound
+	}
+
+	t.Run("managed secrets", func(t *testing.T) {
+		project := &types.Project{
+			Secrets: types.Secrets{
+				"s3": types.SecretConfig{
+					Name:     api.ObjectStorageSecretName,
+					External: true,
+				},
+				"uncloud-postgres-db": types.SecretConfig{
+					External: true,
+				},
+				"other": types.SecretConfig{
+					External: true,
+				},
+			},
+		}
+
+		err := ResolveExternalSecrets(project, getSecret)
+		require.NoError(t, err)
+
+		assert.False(t, bool(project.Secrets["s3"].External))
+		assert.Equal(t, string(storage.Secret()), project.Secrets["s3"].Content)
+		assert.Contains(t, project.Secrets["s3"].Content, "AWS_ENDPOINT_URL=http://uncloud-storage.internal:9000\n")
+		assert.False(t, bool(project.Secrets["uncloud-postgres-db"].External))
+		assert.Equal(t, "PGHOST=db.internal\n", project.Secrets["uncloud-postgres-db"].Content)
+		assert.True(t, bool(project.Secrets["other"].External), "unmanaged external secret must be left as is")
+	})
+
+	t.Run("secret not fetched if not external", func(t *testing.T) {
+		project := &types.Project{
+			Secrets: types.Secrets{
+				"token": types.SecretConfig{Content: "token"},
+			},
+		}
+
+		err := ResolveExternalSecrets(project, func(string) ([]byte, error) {
+			return nil, errors.New("must not be called")
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		project := &types.Project{
+			Secrets: types.Secrets{
+				"s3": types.SecretConfig{
+					Name:     api.ObjectStorageSecretName,
+					External: true,
+				},
+			},
+		}
+
+		err := ResolveExternalSecrets(project, func(string) ([]byte, error) {
+			return nil, errors.New("object storage not enabled")
+		})
+		require.ErrorContains(t, err, "object storage not enabled")
+	})
+}
@@ -3,9 +3,10 @@ package compose
 import (
 	"fmt"
 	"maps"
+	"net/netip"
 	"os"
 	"slices"
-	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/docker/api/types/mount"
@@ -41,21 +42,52 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 		env[k] = *v
 	}
 
+	var devices []api.DeviceMapping
+	for _, d := range service.Devices {
+		devices = append(devices, api.DeviceMapping{
+			HostPath:          d.Source,
+			ContainerPath:     d.Target,
+			CgroupPermissions: d.Permissions,
+		})
+	}
+
 	spec := api.ServiceSpec{
 		Container: api.ContainerSpec{
-			Command:    service.Command,
-			Entrypoint: service.Entrypoint,
-			Env:        env,
-			Image:      service.Image,
-			Init:       service.Init,
-			Privileged: service.Privileged,
-			PullPolicy: pullPolicy,
-			Resources:  resourcesFromCompose(service),
-			User:       service.User,
+			CapAdd:      service.CapAdd,
+			CapDrop:     service.CapDrop,
+			Command:     service.Command,
+			Devices:     devices,
+			Entrypoint:  service.Entrypoint,
+			Env:         env,
+			Hostname:    service.Hostname,
+			Image:       service.Image,
+			Init:        service.Init,
+			Privileged:  service.Privileged,
+			PullPolicy:  pullPolicy,
+			ReadOnly:    service.ReadOnly,
+			Resources:   resourcesFromCompose(service),
+			SecurityOpt: service.SecurityOpt,
+			StopSignal:  service.StopSignal,
+			Sysctls:     service.Sysctls,
+			User:        service.User,
 		},
 		Name: serviceName,
 		Mode: api.ServiceModeReplicated,
 	}
+	spec.Project = project.Name
+
+	if spec.Container.RestartPolicy, err = restartPolicyFromCompose(service); err != nil {
+		return spec, err
+	}
+	if service.StopGracePeriod != nil {
+		stopGracePeriod := time.Duration(*service.StopGracePeriod)
+		spec.Container.StopGracePeriod = &stopGracePeriod
+	}
+
+	if err = networkConfigFromCompose(project.Networks, service, &spec); err != nil {
+		return spec, err
+	}
+	spec.Aliases = aliasesFromCompose(service)
 
 	// Map x-caddy extension to spec.Caddy if specified.
 	if caddy, ok := service.Extensions[CaddyExtensionKey].(Caddy); ok && caddy.Config != "" {
@@ -70,6 +102,36 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 	if machines, ok := service.Extensions[MachinesExtensionKey].(MachinesSource); ok {
 		spec.Placement.Machines = []string(machines)
 	}
+	if backup, ok := service.Extensions[BackupExtensionKey].(Backup); ok {
+		if spec.Backup, err = backupSpecFromCompose(backup); err != nil {
+			return spec, err
+		}
+	}
+	if protected, ok := service.Extensions[ProtectedExtensionKey]; ok {
+		if spec.Protected, err = protectedFromCompose(protected); err != nil {
+			return spec, err
+		}
+	}
+	if owner, ok := service.Extensions[OwnerExtensionKey]; ok {
+		if spec.Owner, err = ownerFromCompose(owner); err != nil {
+			return spec, err
+		}
+	}
+	if priority, ok := service.Extensions[PriorityExtensionKey]; ok {
+		if spec.Priority, err = priorityFromCompose(priority); err != nil {
+			return spec, err
+		}
+	}
+	if schedule, ok := service.Extensions[ScaleScheduleExtensionKey].(ScaleSchedule); ok {
+		if spec.ScaleSchedule, err = scaleScheduleSpecFromCompose(schedule); err != nil {
+			return spec, err
+		}
+	}
+	if tenant, ok := service.Extensions[TenantExtensionKey]; ok {
+		if spec.Tenant, err = tenantFromCompose(tenant); err != nil {
+			return spec, err
+		}
+	}
 
 	// Map LogDriver if specified
 	if service.Logging != nil && service.Logging.Driver != "" {
@@ -94,6 +156,10 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 		default:
 			return spec, fmt.Errorf("unsupported deploy mode: '%s'", service.Deploy.Mode)
 		}
+
+		if service.Deploy.UpdateConfig != nil {
+			spec.UpdateConfig = updateConfigFromCompose(service.Deploy.UpdateConfig)
+		}
 	}
 
 	// TODO: can service.tmpfs be handled as tmpfs volume mounts as well?
@@ -114,9 +180,150 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 	spec.Configs = configSpecs
 	spec.Container.ConfigMounts = configMounts
 
+	// Secrets are mounted into the container as configs.
+	secretSpecs, secretMounts, err := secretSpecsFromCompose(project.Secrets, service.Secrets, project.WorkingDir)
+	if err != nil {
+		return spec, err
+	}
+	for _, s := range secretSpecs {
+		if _, ok := spec.Config(s.Name); ok {
+			return spec, fmt.Errorf("secret '%s' has the same name as a config", s.Name)
+		}
+	}
+	spec.Configs = append(spec.Configs, secretSpecs...)
+	spec.Container.ConfigMounts = append(spec.Container.ConfigMounts, secretMounts...)
+
 	return spec, nil
 }
 
+// restartPolicyFromCompose converts the compose restart or deploy.restart_policy (takes precedence) to the container
+// restart policy. Returns nil if neither is specified to use the default policy.
+func restartPolicyFromCompose(service types.ServiceConfig) (*api.RestartPolicy, error) {
+	if service.Deploy != nil && service.Deploy.RestartPolicy != nil {
+		policy := service.Deploy.RestartPolicy
+		// Map the deploy.restart_policy conditions to the Docker restart policies the same way as Docker Compose.
+		restartPolicy := &api.RestartPolicy{Name: policy.Condition}
+		switch policy.Condition {
+		case "none":
+			restartPolicy.Name = api.RestartPolicyNo
+		case "any":
+			restartPolicy.Name = api.RestartPolicyAlways
+		case api.RestartPolicyOnFailure:
+			if policy.MaxAttempts != nil {
+				restartPolicy.MaxRetries = uint(*policy.MaxAttempts)
+			}
+		}
+		if err := restartPolicy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid restart_policy: %w", err)
+		}
+		return restartPolicy, nil
+	}
+
+	if service.Restart == "" {
+		return nil, nil
+	}
+	restartPolicy, err := api.ParseRestartPolicy(service.Restart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid restart: %w", err)
+	}
+	return &restartPolicy, nil
+}
+
+// updateConfigFromCompose converts the compose deploy.update_config to the service update config.
+func updateConfigFromCompose(config *types.UpdateConfig) *api.UpdateConfig {
+	updateConfig := &api.UpdateConfig{
+		Delay:           time.Duration(config.Delay),
+		FailureAction:   config.FailureAction,
+		Monitor:         time.Duration(config.Monitor),
+		MaxFailureRatio: float64(config.MaxFailureRatio),
+		Order:           config.Order,
+	}
+	if config.Parallelism != nil {
+		parallelism := uint(*config.Parallelism)
+		updateConfig.Parallelism = &parallelism
+	}
+	return updateConfig
+}
+
+// networkConfigFromCompose sets the network mode and networks of the service spec from the compose service config.
+// The service is attached to a macvlan network if it uses a compose network with the macvlan driver. The implicit
+// compose default network is omitted as it's equivalent to not specifying any networks.
+func networkConfigFromCompose(networks types.Networks, service types.ServiceConfig, spec *api.ServiceSpec) error {
+	switch service.NetworkMode {
+	case "":
+	case api.NetworkModeHost:
+		spec.NetworkMode = api.NetworkModeHost
+		return nil
+	default:
+		return fmt.Errorf("unsupported network mode: '%s'", service.NetworkMode)
+	}
+
+	names := slices.Sorted(maps.Keys(service.Networks))
+	for _, name := range names {
+		nw := networks[name]
+		if nw.Driver != api.NetworkModeMacvlan {
+			continue
+		}
+		if len(names) > 1 {
+			return fmt.Errorf("macvlan network '%s' cannot be combined with other networks", name)
+		}
+
+		macvlan, err := macvlanOptionsFromCompose(nw)
+		if err != nil {
+			return fmt.Errorf("macvlan network '%s': %w", name, err)
+		}
+		spec.NetworkMode = api.NetworkModeMacvlan
+		spec.Macvlan = macvlan
+		return nil
+	}
+
+	if len(names) == 1 && names[0] == api.DefaultNetwork {
+		return nil
+	}
+	spec.Networks = names
+	return nil
+}
+
+// aliasesFromCompose returns the sorted unique aliases of the service across all its networks. Aliases resolve
+// in all networks of the service as the networks only scope the service discovery.
+func aliasesFromCompose(service types.ServiceConfig) []string {
+	var aliases []string
+	for _, nw := range service.Networks {
+		if nw != nil {
+			aliases = append(aliases, nw.Aliases...)
+		}
+	}
+	slices.Sort(aliases)
+	return slices.Compact(aliases)
+}
+
+func macvlanOptionsFromCompose(nw types.NetworkConfig) (*api.MacvlanOptions, error) {
+	opts := &api.MacvlanOptions{
+		Parent: nw.DriverOpts["parent"],
+	}
+	if len(nw.Ipam.Config) != 1 {
+		return nil, fmt.Errorf("exactly one IPAM config with the subnet must be specified")
+	}
+	pool := nw.Ipam.Config[0]
+
+	var err error
+	if opts.Subnet, err = netip.ParsePrefix(pool.Subnet); err != nil {
+		return nil, fmt.Errorf("invalid subnet: %w", err)
+	}
+	if pool.Gateway != "" {
+		if opts.Gateway, err = netip.ParseAddr(pool.Gateway); err != nil {
+			return nil, fmt.Errorf("invalid gateway: %w", err)
+		}
+	}
+	if pool.IPRange != "" {
+		if opts.IPRange, err = netip.ParsePrefix(pool.IPRange); err != nil {
+			return nil, fmt.Errorf("invalid IP range: %w", err)
+		}
+	}
+
+	return opts, opts.Validate()
+}
+
 func resourcesFromCompose(service types.ServiceConfig) api.ContainerResources {
 	resources := api.ContainerResources{
 		CPU:               int64(service.CPUS * 1e9),
@@ -207,7 +414,7 @@ func dockerVolumeSpecFromCompose(serviceVolume types.ServiceVolumeConfig, volume
 		Name: serviceVolume.Source,
 		Type: api.VolumeTypeVolume,
 		VolumeOptions: &api.VolumeOptions{
-			Name: strings.TrimPrefix(volume.Name, FakeProjectName+"_"),
+			Name: volume.Name,
 		},
 	}
 
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/loader"
 	"github.com/compose-spec/compose-go/v2/types"
@@ -39,9 +40,11 @@ func loadProjectFromContent(t *testing.T, content string) (*types.Project, error
 		if o.KnownExtensions == nil {
 			o.KnownExtensions = map[string]any{}
 		}
+		o.KnownExtensions[BackupExtensionKey] = Backup{}
 		o.KnownExtensions[CaddyExtensionKey] = Caddy{}
 		o.KnownExtensions[PortsExtensionKey] = PortsSource{}
 		o.KnownExtensions[MachinesExtensionKey] = MachinesSource{}
+		o.KnownExtensions[ScaleScheduleExtensionKey] = ScaleSchedule{}
 	})
 	if err != nil {
 		return nil, err
@@ -135,6 +138,77 @@ func TestServiceSpecFromCompose(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "networks",
+			filename: "compose-networks.yaml",
+			want: map[string]api.ServiceSpec{
+				"web": {
+					Name: "web",
+					Mode: api.ServiceModeReplicated,
+					Container: api.ContainerSpec{
+						Image:      "nginx:latest",
+						PullPolicy: api.PullPolicyMissing,
+					},
+					Networks: []string{"frontend"},
+				},
+				"api": {
+					Name: "api",
+					Mode: api.ServiceModeReplicated,
+					Container: api.ContainerSpec{
+						Image:      "myapp:latest",
+						PullPolicy: api.PullPolicyMissing,
+					},
+					Networks: []string{"backend", "frontend"},
+				},
+				"db": {
+					Name: "db",
+					Mode: api.ServiceModeReplicated,
+					Container: api.ContainerSpec{
+						Image:      "postgres:latest",
+						PullPolicy: api.PullPolicyMissing,
+					},
+					Networks: []string{"backend"},
+				},
+				"default-network": {
+					Name: "default-network",
+					Mode: api.ServiceModeReplicated,
+					Container: api.ContainerSpec{
+						Image:      "nginx:latest",
+						PullPolicy: api.PullPolicyMissing,
+					},
+				},
+			},
+		},
+		{
+			name:     "network-mode",
+			filename: "compose-network-mode.yaml",
+			want: map[string]api.ServiceSpec{
+				"dhcp": {
+					Name: "dhcp",
+					Mode: api.ServiceModeReplicated,
+					Container: api.ContainerSpec{
+						Image:      "networkboot/dhcpd",
+						PullPolicy: api.PullPolicyMissing,
+					},
+					NetworkMode: api.NetworkModeHost,
+				},
+				"home-assistant": {
+					Name: "home-assistant",
+					Mode: api.ServiceModeReplicated,
+					Container: api.ContainerSpec{
+						Image:      "homeassistant/home-assistant:stable",
+						PullPolicy: api.PullPolicyMissing,
+					},
+					NetworkMode: api.NetworkModeMacvlan,
+					Macvlan: &api.MacvlanOptions{
+						Parent:  "eth0",
+						Subnet:  netip.MustParsePrefix("192.168.1.0/24"),
+						Gateway: netip.MustParseAddr("192.168.1.1"),
+						IPRange: netip.MustParsePrefix("192.168.1.240/28"),
+					},
+				},
+			},
+		},
 		{
 			name:     "full-spec",
 			filename: "compose-full-spec.yaml",
@@ -143,7 +217,21 @@ func TestServiceSpecFromCompose(t *testing.T) {
 					Name: "test",
 					Mode: api.ServiceModeReplicated,
 					Container: api.ContainerSpec{
-						Command:    []string{"nginx", "updated", "command"},
+						CapAdd:  []string{"NET_ADMIN"},
+						CapDrop: []string{"ALL"},
+						Command: []string{"nginx", "updated", "command"},
+						Devices: []api.DeviceMapping{
+							{
+								HostPath:          "/dev/ttyUSB0",
+								ContainerPath:     "/dev/ttyUSB1",
+								CgroupPermissions: "rw",
+							},
+							{
+								HostPath:          "/dev/fuse",
+								ContainerPath:     "/dev/fuse",
+								CgroupPermissions: "rwm",
+							},
+						},
 						Entrypoint: []string{"/updated-docker-entrypoint.sh"},
 						Env: map[string]string{
 							"BOOL":  "true",
@@ -161,12 +249,15 @@ func TestServiceSpecFromCompose(t *testing.T) {
 						},
 						Privileged: true,
 						PullPolicy: api.PullPolicyAlways,
+						ReadOnly:   true,
 						Resources: api.ContainerResources{
 							CPU:               0.5 * api.Core,
 							Memory:            100 * units.MiB,
 							MemoryReservation: 50 * units.MiB,
 						},
-						User: "nginx:nginx",
+						SecurityOpt: []string{"no-new-privileges", "seccomp=unconfined"},
+						Sysctls:     map[string]string{"net.core.somaxconn": "1024"},
+						User:        "nginx:nginx",
 						VolumeMounts: []api.VolumeMount{
 							{
 								VolumeName:    "bind-bb6aed1683cea1e0a1ae5cd227aacd0734f2f87f7a78fcf1baeff978ce300b90",
@@ -287,7 +378,10 @@ func TestServiceSpecFromCompose(t *testing.T) {
 					return strings.Compare(a.Name, b.Name)
 				})
 
-				cmpOpts := cmp.Options{cmpopts.EquateEmpty(), cmpopts.EquateComparable(netip.Addr{})}
+				// The project name defaults to the name of the directory with the Compose file.
+				expectedSpec.Project = "testdata"
+
+				cmpOpts := cmp.Options{cmpopts.EquateEmpty(), cmpopts.EquateComparable(netip.Addr{}, netip.Prefix{})}
 				assert.True(t, cmp.Equal(spec, expectedSpec, cmpOpts...), cmp.Diff(spec, expectedSpec, cmpOpts...))
 			}
 		})
@@ -591,3 +685,440 @@ services:
 		})
 	}
 }
+
+func TestServiceSpecFromCompose_UpdateConfig(t *testing.T) {
+	t.Parallel()
+
+	project, err := loadProjectFromContent(t, `
+services:
+  test:
+    image: nginx
+    deploy:
+      replicas: 4
+      update_config:
+        parallelism: 2
+        delay: 10s
+        failure_action: rollback
+        monitor: 30s
+        max_failure_ratio: 0.25
+        order: stop-first
+`)
+	require.NoError(t, err)
+
+	spec, err := ServiceSpecFromCompose(project, "test")
+	require.NoError(t, err)
+
+	parallelism := uint(2)
+	assert.Equal(t, &api.UpdateConfig{
+		Parallelism:     &parallelism,
+		Delay:           10 * time.Second,
+		FailureAction:   api.UpdateFailureActionRollback,
+		Monitor:         30 * time.Second,
+		MaxFailureRatio: 0.25,
+		Order:           api.UpdateOrderStopFirst,
+	}, spec.UpdateConfig)
+	require.NoError(t, spec.Validate())
+}
+
+func TestServiceSpecFromCompose_RestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		want    *api.RestartPolicy
+		wantErr string
+	}{
+		{
+			name:    "default",
+			service: "image: nginx",
+		},
+		{
+			name:    "restart",
+			service: "image: nginx\n    restart: on-failure:5",
+			want:    &api.RestartPolicy{Name: api.RestartPolicyOnFailure, MaxRetries: 5},
+		},
+		{
+			name: "deploy restart_policy takes precedence",
+			service: `image: nginx
+    restart: always
+    deploy:
+      restart_policy:
+        condition: none`,
+			want: &api.RestartPolicy{Name: api.RestartPolicyNo},
+		},
+		{
+			name: "deploy restart_policy any",
+			service: `image: nginx
+    deploy:
+      restart_policy:
+        condition: any
+        max_attempts: 3`,
+			want: &api.RestartPolicy{Name: api.RestartPolicyAlways},
+		},
+		{
+			name: "deploy restart_policy on-failure",
+			service: `image: nginx
+    deploy:
+      restart_policy:
+        condition: on-failure
+        max_attempts: 3`,
+			want: &api.RestartPolicy{Name: api.RestartPolicyOnFailure, MaxRetries: 3},
+		},
+		{
+			name:    "invalid",
+			service: "image: nginx\n    restart: always:3",
+			wantErr: "maximum retry count can only be used",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := loadProjectFromContent(t, "services:\n  test:\n    "+tt.service+"\n")
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Container.RestartPolicy)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_Backup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		want    *api.BackupSpec
+		wantErr string
+	}{
+		{
+			name:    "no backup",
+			service: "image: postgres",
+		},
+		{
+			name:    "engine only",
+			service: "image: postgres\n    x-backup: postgres",
+			want:    &api.BackupSpec{Engine: api.BackupEnginePostgres},
+		},
+		{
+			name: "interval and retention",
+			service: `image: mysql
+    x-backup:
+      engine: mysql
+      interval: 6h
+      retention: 28`,
+			want: &api.BackupSpec{Engine: api.BackupEngineMySQL, Interval: 6 * time.Hour, Retention: 28},
+		},
+		{
+			name: "invalid interval",
+			service: `image: redis
+    x-backup:
+      engine: redis
+      interval: daily`,
+			wantErr: "invalid x-backup interval",
+		},
+		{
+			name:    "unsupported engine",
+			service: "image: mongo\n    x-backup: mongodb",
+			wantErr: "unsupported backup engine",
+		},
+		{
+			name: "unknown field",
+			service: `image: postgres
+    x-backup:
+      engine: postgres
+      schedule: daily`,
+			wantErr: "schedule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := loadProjectFromContent(t, "services:\n  test:\n    "+tt.service+"\n")
+			if err == nil {
+				_, err = ServiceSpecFromCompose(project, "test")
+			}
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Backup)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_Protected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		want    bool
+		wantErr string
+	}{
+		{
+			name:    "not protected",
+			service: "image: postgres",
+		},
+		{
+			name:    "protected",
+			service: "image: postgres\n    x-protected: true",
+			want:    true,
+		},
+		{
+			name:    "string value",
+			service: "image: postgres\n    x-protected: \"true\"",
+			want:    true,
+		},
+		{
+			name:    "invalid value",
+			service: "image: postgres\n    x-protected: always",
+			wantErr: "invalid x-protected value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := loadProjectFromContent(t, "services:\n  test:\n    "+tt.service+"\n")
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Protected)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_AliasesAndHostname(t *testing.T) {
+	t.Parallel()
+
+	project, err := loadProjectFromContent(t, `
+services:
+  db:
+    image: postgres
+    hostname: db-primary
+    networks:
+      backend:
+        aliases:
+          - database
+          - db.internal.company
+      frontend:
+        aliases:
+          - database
+networks:
+  backend:
+  frontend:
+`)
+	require.NoError(t, err)
+
+	spec, err := ServiceSpecFromCompose(project, "db")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"database", "db.internal.company"}, spec.Aliases)
+	assert.Equal(t, "db-primary", spec.Container.Hostname)
+}
+
+func TestServiceSpecFromCompose_Owner(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "no owner",
+			service: "image: nginx",
+		},
+		{
+			name:    "owner",
+			service: "image: nginx\n    x-owner: acme",
+			want:    "acme",
+		},
+		{
+			name:    "empty owner",
+			service: "image: nginx\n    x-owner: \" \"",
+			wantErr: "must not be empty",
+		},
+		{
+			name:    "invalid type",
+			service: "image: nginx\n    x-owner: [acme]",
+			wantErr: "invalid type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := loadProjectFromContent(t, "services:\n  test:\n    "+tt.service+"\n")
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Owner)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_Tenant(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "no tenant",
+			service: "image: nginx",
+		},
+		{
+			name:    "tenant",
+			service: "image: nginx\n    x-tenant: acme",
+			want:    "acme",
+		},
+		{
+			name:    "invalid type",
+			service: "image: nginx\n    x-tenant: [acme]",
+			wantErr: "invalid type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := loadProjectFromContent(t, "services:\n  test:\n    "+tt.service+"\n")
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Tenant)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_Priority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		service string
+		want    int
+		wantErr string
+	}{
+		{
+			name:    "default priority",
+			service: "image: nginx",
+			want:    api.PriorityNormal,
+		},
+		{
+			name:    "priority class",
+			service: "image: nginx\n    x-priority: critical",
+			want:    api.PriorityCritical,
+		},
+		{
+			name:    "integer priority",
+			service: "image: nginx\n    x-priority: -50",
+			want:    -50,
+		},
+		{
+			name:    "unknown priority class",
+			service: "image: nginx\n    x-priority: urgent",
+			wantErr: "invalid priority 'urgent'",
+		},
+		{
+			name:    "invalid type",
+			service: "image: nginx\n    x-priority: [high]",
+			wantErr: "invalid type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := loadProjectFromContent(t, "services:\n  test:\n    "+tt.service+"\n")
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Priority)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_ScaleSchedule(t *testing.T) {
+	t.Parallel()
+
+	content := `
+services:
+  web:
+    image: nginx
+    x-scale-schedule:
+      timezone: Europe/Berlin
+      replicas: 2
+      windows:
+        - window: Mon-Fri 08:00-20:00
+          replicas: 6
+`
+	project, err := loadProjectFromContent(t, content)
+	require.NoError(t, err)
+
+	spec, err := ServiceSpecFromCompose(project, "web")
+	require.NoError(t, err)
+	assert.Equal(t, &api.ScaleSchedule{
+		Timezone: "Europe/Berlin",
+		Replicas: 2,
+		Windows:  []api.ScaleWindow{{Window: "Mon-Fri 08:00-20:00", Replicas: 6}},
+	}, spec.ScaleSchedule)
+
+	content = `
+services:
+  web:
+    image: nginx
+    x-scale-schedule:
+      replicas: 2
+      windows:
+        - window: Someday 08:00-20:00
+          replicas: 6
+`
+	project, err = loadProjectFromContent(t, content)
+	require.NoError(t, err)
+	_, err = ServiceSpecFromCompose(project, "web")
+	assert.ErrorContains(t, err, "unknown day 'Someday'")
+}
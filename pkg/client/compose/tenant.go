@@ -0,0 +1,19 @@
+package compose
+
+import (
+	"fmt"
+)
+
+// TenantExtensionKey is the service extension that sets the tenant the service belongs to on a cluster shared by
+// multiple customers, e.g. x-tenant: acme. It's set for all services with 'uc deploy --tenant'.
+const TenantExtensionKey = "x-tenant"
+
+// tenantFromCompose parses the x-tenant extension value that must be a string. The tenant name is validated
+// with the service spec.
+func tenantFromCompose(value any) (string, error) {
+	tenant, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid type %T for x-tenant extension: expected string", value)
+	}
+	return tenant, nil
+}
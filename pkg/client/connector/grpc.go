@@ -0,0 +1,57 @@
+package connector
+
+import (
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/compression"
+	"google.golang.org/grpc"
+)
+
+// GRPCOptions configures the gRPC connection to the machine API. The zero value uses the gRPC defaults:
+// no compression and a 4 MiB limit on received messages.
+type GRPCOptions struct {
+	// Compression is the name of the compressor for requests and responses: "gzip" or "zstd". Compression reduces
+	// the transferred data for large responses such as logs and image metadata over slow links at the cost of CPU.
+	// The machine daemon must support the compressor. Empty disables compression.
+	Compression string
+	// MaxRecvMsgSize is the maximum size of a received message in bytes. Zero means the gRPC default.
+	MaxRecvMsgSize int
+	// MaxSendMsgSize is the maximum size of a sent message in bytes. Zero means the gRPC default.
+	MaxSendMsgSize int
+}
+
+// Validate returns an error if the options are invalid.
+func (o GRPCOptions) Validate() error {
+	if err := compression.Validate(o.Compression); err != nil {
+		return err
+	}
+	if o.MaxRecvMsgSize < 0 {
+		return fmt.Errorf("max receive message size must be non-negative: %d", o.MaxRecvMsgSize)
+	}
+	if o.MaxSendMsgSize < 0 {
+		return fmt.Errorf("max send message size must be non-negative: %d", o.MaxSendMsgSize)
+	}
+	return nil
+}
+
+// dialOptions returns the gRPC dial options that apply the options to all calls made over the connection.
+func (o GRPCOptions) dialOptions() ([]grpc.DialOption, error) {
+	if err := o.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid gRPC options: %w", err)
+	}
+
+	var callOpts []grpc.CallOption
+	if o.Compression != compression.None {
+		callOpts = append(callOpts, grpc.UseCompressor(o.Compression))
+	}
+	if o.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(o.MaxRecvMsgSize))
+	}
+	if o.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(o.MaxSendMsgSize))
+	}
+	if len(callOpts) == 0 {
+		return nil, nil
+	}
+	return []grpc.DialOption{grpc.WithDefaultCallOptions(callOpts...)}, nil
+}
@@ -22,6 +22,10 @@ type SSHConnectorConfig struct {
 	KeyPath string
 
 	SockPath string
+	// SSH configures the connect timeout, retries, and keepalives of the SSH connection.
+	SSH sshexec.ConnectOptions
+	// GRPC configures the gRPC connection to the machine API through the SSH tunnel.
+	GRPC GRPCOptions
 }
 
 // SSHConnector establishes a connection to the machine API through an SSH tunnel to the machine.
@@ -40,13 +44,17 @@ func NewSSHConnectorFromClient(client *ssh.Client) *SSHConnector {
 
 // TODO: handle context cancelation.
 func (c *SSHConnector) Connect(ctx context.Context) (*grpc.ClientConn, error) {
+	grpcOpts, err := c.config.GRPC.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	if c.client == nil {
 		// Establish an SSH connection if the SSH client is not provided.
-		if c.config == (SSHConnectorConfig{}) {
+		if c.config == (SSHConnectorConfig{SSH: c.config.SSH, GRPC: c.config.GRPC}) {
 			return nil, fmt.Errorf("SSH connector not configured")
 		}
-		var err error
-		c.client, err = sshexec.Connect(c.config.User, c.config.Host, c.config.Port, c.config.KeyPath)
+		c.client, err = sshexec.Connect(c.config.User, c.config.Host, c.config.Port, c.config.KeyPath, c.config.SSH)
 		if err != nil {
 			return nil, fmt.Errorf("SSH login to %s@%s:%d: %w", c.config.User, c.config.Host, c.config.Port, err)
 		}
@@ -56,8 +64,7 @@ func (c *SSHConnector) Connect(ctx context.Context) (*grpc.ClientConn, error) {
 	if sockPath == "" {
 		sockPath = machine.DefaultUncloudSockPath
 	}
-	conn, err := grpc.NewClient(
-		"unix://"+sockPath,
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithContextDialer(
 			func(ctx context.Context, addr string) (net.Conn, error) {
@@ -74,7 +81,8 @@ func (c *SSHConnector) Connect(ctx context.Context) (*grpc.ClientConn, error) {
 				return conn, nil
 			},
 		),
-	)
+	}, grpcOpts...)
+	conn, err := grpc.NewClient("unix://"+sockPath, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("create machine API client: %w", err)
 	}
@@ -13,6 +13,8 @@ import (
 // TCPConnector establishes a connection to the machine API through a direct TCP connection to an API endpoint.
 type TCPConnector struct {
 	apiAddr netip.AddrPort
+	// GRPC configures the gRPC connection to the machine API.
+	GRPC GRPCOptions
 }
 
 func NewTCPConnector(apiAddr netip.AddrPort) *TCPConnector {
@@ -20,10 +22,12 @@ func NewTCPConnector(apiAddr netip.AddrPort) *TCPConnector {
 }
 
 func (c *TCPConnector) Connect(_ context.Context) (*grpc.ClientConn, error) {
-	conn, err := grpc.NewClient(
-		c.apiAddr.String(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	grpcOpts, err := c.GRPC.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, grpcOpts...)
+	conn, err := grpc.NewClient(c.apiAddr.String(), dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("create machine API client: %w", err)
 	}
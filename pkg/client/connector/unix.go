@@ -0,0 +1,42 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/net/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// UnixConnector establishes a connection to the machine API through the Unix socket of the local machine daemon.
+type UnixConnector struct {
+	sockPath string
+	// GRPC configures the gRPC connection to the machine API.
+	GRPC GRPCOptions
+}
+
+func NewUnixConnector(sockPath string) *UnixConnector {
+	return &UnixConnector{sockPath: sockPath}
+}
+
+func (c *UnixConnector) Connect(_ context.Context) (*grpc.ClientConn, error) {
+	grpcOpts, err := c.GRPC.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, grpcOpts...)
+	conn, err := grpc.NewClient("unix://"+c.sockPath, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("create machine API client: %w", err)
+	}
+	return conn, nil
+}
+
+func (c *UnixConnector) Dialer() (proxy.ContextDialer, error) {
+	return nil, fmt.Errorf("proxy connections are not supported over a Unix socket connection")
+}
+
+func (c *UnixConnector) Close() error {
+	return nil
+}
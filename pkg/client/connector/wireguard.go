@@ -23,6 +23,8 @@ type WireGuardConnector struct {
 	user     *client.User
 	machines []config.MachineConnection
 	tun      *tunnel.Tunnel
+	// GRPC configures the gRPC connection to the cluster API through the WireGuard tunnel.
+	GRPC GRPCOptions
 }
 
 func NewWireGuardConnector(user *client.User, machines []config.MachineConnection) *WireGuardConnector {
@@ -40,6 +42,10 @@ func (c *WireGuardConnector) Connect(ctx context.Context) (*grpc.ClientConn, err
 	// TODO: iterate over machines and try to connect to each one until successful.
 	//  For now, try to connect to only the first machine.
 	machine := c.machines[0]
+	grpcOpts, err := c.GRPC.dialOptions()
+	if err != nil {
+		return nil, err
+	}
 	endpointIPs, err := net.LookupIP(machine.Host)
 	if err != nil {
 		return nil, fmt.Errorf("resolve IP for %q: %w", machine.Host, err)
@@ -63,13 +69,13 @@ func (c *WireGuardConnector) Connect(ctx context.Context) (*grpc.ClientConn, err
 		return nil, fmt.Errorf("establish WireGuard tunnel to %q: %w", endpoint, err)
 	}
 
-	conn, err := grpc.NewClient(
-		machineAPIAddr,
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
 			return c.tun.DialContext(ctx, "tcp", addr)
 		}),
-	)
+	}, grpcOpts...)
+	conn, err := grpc.NewClient(machineAPIAddr, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("connect to machine API through WireGuard tunnel: %w", err)
 	}
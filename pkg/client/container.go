@@ -268,6 +268,34 @@ func (cli *Client) StopContainer(
 	return nil
 }
 
+// UpdateContainerSpec updates the spec of the specified container within the service without recreating it.
+// Only the metadata that doesn't affect the container can be changed, see api.ServiceSpec.WithMetadata.
+func (cli *Client) UpdateContainerSpec(
+	ctx context.Context, serviceNameOrID, containerNameOrID string, spec api.ServiceSpec,
+) error {
+	ctr, err := cli.InspectContainer(ctx, serviceNameOrID, containerNameOrID)
+	if err != nil {
+		return err
+	}
+
+	machine, err := cli.InspectMachine(ctx, ctr.MachineID)
+	if err != nil {
+		return fmt.Errorf("inspect machine '%s': %w", ctr.MachineID, err)
+	}
+	ctx = proxyToMachine(ctx, machine.Machine)
+
+	pw := progress.ContextWriter(ctx)
+	eventID := fmt.Sprintf("Container %s on %s", ctr.Container.Name, machine.Machine.Name)
+
+	pw.Event(progress.NewEvent(eventID, progress.Working, "Updating"))
+	if err = cli.Docker.UpdateServiceContainerSpec(ctx, ctr.Container.ID, spec); err != nil {
+		return err
+	}
+	pw.Event(progress.NewEvent(eventID, progress.Done, "Updated"))
+
+	return nil
+}
+
 // RemoveContainer removes the specified container within the service.
 func (cli *Client) RemoveContainer(
 	ctx context.Context, serviceNameOrID, containerNameOrID string, opts container.RemoveOptions,
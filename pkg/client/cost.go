@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ClusterCosts estimates the monthly cost of the cluster machines with the cost metadata and attributes it to
+// the running service containers according to their resource reservations.
+func (cli *Client) ClusterCosts(ctx context.Context) (api.CostReport, error) {
+	machines, err := cli.Machines(ctx, nil)
+	if err != nil {
+		return api.CostReport{}, fmt.Errorf("list machines: %w", err)
+	}
+	services, err := cli.ListServices(ctx)
+	if err != nil {
+		return api.CostReport{}, fmt.Errorf("list services: %w", err)
+	}
+	return api.EstimateCosts(machines, services), nil
+}
@@ -1,12 +1,140 @@
 package client
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client/deploy"
 )
 
+// DeployOptions defines the options for deploying a service.
+type DeployOptions struct {
+	// Strategy is the deployment strategy. A default deploy.RollingStrategy is used if nil.
+	Strategy deploy.Strategy
+	// DryRun only plans the deployment without executing it.
+	DryRun bool
+}
+
 // NewDeployment creates a new deployment for the given service specification.
 // If strategy is nil, a default deploy.RollingStrategy will be used.
 func (cli *Client) NewDeployment(spec api.ServiceSpec, strategy deploy.Strategy) *deploy.Deployment {
 	return deploy.NewDeployment(cli, spec, strategy)
 }
+
+// Deploy creates a new service or updates the existing one to match the given service specification.
+// It returns the plan of operations that have been executed or would be executed if opts.DryRun is set.
+func (cli *Client) Deploy(ctx context.Context, spec api.ServiceSpec, opts DeployOptions) (deploy.Plan, error) {
+	deployment := cli.NewDeployment(spec, opts.Strategy)
+	if opts.DryRun {
+		return deployment.Plan(ctx)
+	}
+	return deployment.Run(ctx)
+}
+
+// NewScaleDeployment creates a new deployment that scales the replicated service to the given number of replicas.
+// It also returns the service before scaling.
+func (cli *Client) NewScaleDeployment(
+	ctx context.Context, serviceNameOrID string, replicas uint,
+) (*deploy.Deployment, api.Service, error) {
+	if replicas == 0 {
+		return nil, api.Service{}, fmt.Errorf(
+			"scaling to zero replicas is not supported. This would effectively remove the service without preserving "+
+				"its configuration, making it impossible to scale back up. Uncloud derives the service configuration "+
+				"from existing containers. Remove the service '%s' instead if you don't need it",
+			serviceNameOrID,
+		)
+	}
+
+	svc, err := cli.InspectService(ctx, serviceNameOrID)
+	if err != nil {
+		return nil, svc, fmt.Errorf("inspect service '%s': %w", serviceNameOrID, err)
+	}
+	if svc.Mode != api.ServiceModeReplicated {
+		return nil, svc, fmt.Errorf("scaling is only supported for services in %s mode, service '%s' is in %s mode",
+			api.ServiceModeReplicated, svc.Name, svc.Mode)
+	}
+	if len(svc.Containers) == 0 {
+		return nil, svc, fmt.Errorf("service '%s' has no containers to derive the configuration from", svc.Name)
+	}
+
+	// TODO: Check if all containers have the same spec. If not, prompt user to choose which one to scale.
+	//  This can happen if a service deployment failed midway and some containers were not updated.
+	spec := svc.Containers[0].Container.ServiceSpec
+	if spec.ScaleSchedule != nil {
+		return nil, svc, fmt.Errorf("service '%s' has a scale schedule that sets its number of replicas, "+
+			"change the schedule and redeploy the service instead", svc.Name)
+	}
+	spec.Replicas = replicas
+	deployment := cli.NewDeployment(spec, nil)
+	deployment.Service = &svc
+
+	return deployment, svc, nil
+}
+
+// Scale changes the number of replicas of the replicated service. It returns the plan of operations that have been
+// executed or would be executed if opts.DryRun is set.
+func (cli *Client) Scale(
+	ctx context.Context, serviceNameOrID string, replicas uint, opts DeployOptions,
+) (deploy.Plan, error) {
+	deployment, _, err := cli.NewScaleDeployment(ctx, serviceNameOrID, replicas)
+	if err != nil {
+		return deploy.Plan{}, err
+	}
+	if opts.Strategy != nil {
+		deployment.Strategy = opts.Strategy
+	}
+	if opts.DryRun {
+		return deployment.Plan(ctx)
+	}
+	return deployment.Run(ctx)
+}
+
+// NewRestartDeployment creates a new deployment that restarts the containers of the service in place with a rolling
+// restart. Only containers on the given machines (names or IDs) are restarted if any are specified. It also returns
+// the service being restarted.
+func (cli *Client) NewRestartDeployment(
+	ctx context.Context, serviceNameOrID string, machines []string,
+) (*deploy.Deployment, api.Service, error) {
+	svc, err := cli.InspectService(ctx, serviceNameOrID)
+	if err != nil {
+		return nil, svc, fmt.Errorf("inspect service '%s': %w", serviceNameOrID, err)
+	}
+	if len(svc.Containers) == 0 {
+		return nil, svc, fmt.Errorf("service '%s' has no containers to restart", svc.Name)
+	}
+
+	strategy := &deploy.RestartStrategy{}
+	for _, nameOrID := range machines {
+		m, err := cli.Machine(ctx, nameOrID)
+		if err != nil {
+			return nil, svc, fmt.Errorf("inspect machine '%s': %w", nameOrID, err)
+		}
+		strategy.Machines = append(strategy.Machines, m.ID)
+	}
+
+	// The spec is only used for the update config to control the pace of the restart.
+	spec := svc.Containers[0].Container.ServiceSpec
+	deployment := cli.NewDeployment(spec, strategy)
+	deployment.Service = &svc
+
+	return deployment, svc, nil
+}
+
+// NewDriftCorrectionDeployment creates a new deployment that recreates the containers of the service that drifted
+// from their spec. It also returns the service being corrected.
+func (cli *Client) NewDriftCorrectionDeployment(
+	ctx context.Context, serviceNameOrID string,
+) (*deploy.Deployment, api.Service, error) {
+	svc, err := cli.InspectService(ctx, serviceNameOrID)
+	if err != nil {
+		return nil, svc, fmt.Errorf("inspect service '%s': %w", serviceNameOrID, err)
+	}
+
+	// The spec is only used for the update config to control the pace of the correction.
+	spec := svc.Containers[0].Container.ServiceSpec
+	deployment := cli.NewDeployment(spec, &deploy.DriftCorrectionStrategy{})
+	deployment.Service = &svc
+
+	return deployment, svc, nil
+}
@@ -0,0 +1,102 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/docker/compose/v2/pkg/progress"
+)
+
+// DefaultConcurrency is the default maximum number of service plans executed concurrently by ExecuteConcurrently.
+const DefaultConcurrency = 4
+
+// ExecuteConcurrently executes the operations of the sequence running up to limit service plans concurrently.
+// A service plan starts once the plans of the services in its DependsOn that precede it in the sequence have
+// completed. Other operations, such as creating volumes, are executed in order after all preceding operations have
+// completed. Once a plan fails, no new operations are started and the errors of the already running plans are joined.
+// The operations are executed one by one if limit is less than 2.
+func (o *SequenceOperation) ExecuteConcurrently(ctx context.Context, cli Client, limit int) error {
+	if limit < 2 {
+		return o.Execute(ctx, cli)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}
+	sem := make(chan struct{}, limit)
+	// done maps the service names to the channels closed when their plans complete.
+	done := make(map[string]chan struct{})
+
+	for _, op := range o.Operations {
+		plan, ok := op.(*Plan)
+		if !ok {
+			wg.Wait()
+			if failed() {
+				break
+			}
+			if err := op.Execute(ctx, cli); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var deps []chan struct{}
+		for _, name := range plan.DependsOn {
+			if ch, ok := done[name]; ok {
+				deps = append(deps, ch)
+			}
+		}
+		planDone := make(chan struct{})
+		done[plan.ServiceName] = planDone
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(planDone)
+			for _, ch := range deps {
+				<-ch
+			}
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if failed() || ctx.Err() != nil {
+				return
+			}
+			if err := executeServicePlan(ctx, cli, plan); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("deploy service '%s': %w", plan.ServiceName, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// executeServicePlan executes the service plan reporting its progress as a separate event to attribute
+// the interleaved progress of the concurrently deployed services.
+func executeServicePlan(ctx context.Context, cli Client, plan *Plan) error {
+	pw := progress.ContextWriter(ctx)
+	eventID := "Service " + plan.ServiceName
+
+	pw.Event(progress.NewEvent(eventID, progress.Working, "Deploying"))
+	if err := plan.Execute(ctx, cli); err != nil {
+		pw.Event(progress.NewEvent(eventID, progress.Error, err.Error()))
+		return err
+	}
+	pw.Event(progress.NewEvent(eventID, progress.Done, "Deployed"))
+	return nil
+}
@@ -0,0 +1,100 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// funcOperation is an operation that executes a function for testing.
+type funcOperation func() error
+
+func (o funcOperation) Execute(context.Context, Client) error { return o() }
+func (o funcOperation) Format(NameResolver) string            { return "func" }
+func (o funcOperation) String() string                        { return "funcOperation" }
+
+func TestSequenceOperation_ExecuteConcurrently(t *testing.T) {
+	t.Parallel()
+
+	t.Run("independent services run concurrently after dependencies", func(t *testing.T) {
+		t.Parallel()
+
+		var mu sync.Mutex
+		var events []string
+		record := func(e string) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		}
+		// db and cache block until both are running so the test hangs if they run one by one.
+		var started sync.WaitGroup
+		started.Add(2)
+		independent := func(name string) *Plan {
+			return &Plan{ServiceName: name, SequenceOperation: SequenceOperation{Operations: []Operation{
+				funcOperation(func() error {
+					started.Done()
+					started.Wait()
+					record(name)
+					return nil
+				}),
+			}}}
+		}
+		web := &Plan{ServiceName: "web", DependsOn: []string{"db", "cache", "up-to-date"},
+			SequenceOperation: SequenceOperation{Operations: []Operation{
+				funcOperation(func() error {
+					record("web")
+					return nil
+				}),
+			}}}
+		seq := SequenceOperation{Operations: []Operation{
+			funcOperation(func() error {
+				record("volume")
+				return nil
+			}),
+			independent("db"),
+			independent("cache"),
+			web,
+		}}
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- seq.ExecuteConcurrently(t.Context(), nil, 4) }()
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("independent services were not deployed concurrently")
+		}
+
+		require.Len(t, events, 4)
+		assert.Equal(t, "volume", events[0])
+		assert.ElementsMatch(t, []string{"db", "cache"}, events[1:3])
+		assert.Equal(t, "web", events[3])
+	})
+
+	t.Run("failed dependency skips dependent services", func(t *testing.T) {
+		t.Parallel()
+
+		webDeployed := false
+		seq := SequenceOperation{Operations: []Operation{
+			&Plan{ServiceName: "db", SequenceOperation: SequenceOperation{Operations: []Operation{
+				funcOperation(func() error { return errors.New("boom") }),
+			}}},
+			&Plan{ServiceName: "web", DependsOn: []string{"db"},
+				SequenceOperation: SequenceOperation{Operations: []Operation{
+					funcOperation(func() error {
+						webDeployed = true
+						return nil
+					}),
+				}}},
+		}}
+
+		err := seq.ExecuteConcurrently(t.Context(), nil, 4)
+		require.ErrorContains(t, err, "deploy service 'db': boom")
+		assert.False(t, webDeployed)
+	})
+}
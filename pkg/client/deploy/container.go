@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"reflect"
+	"slices"
 	"sort"
 
 	"github.com/google/go-cmp/cmp"
@@ -12,9 +13,12 @@ import (
 type ContainerSpecStatus string
 
 const (
-	ContainerUpToDate      ContainerSpecStatus = "up-to-date"
-	ContainerNeedsUpdate   ContainerSpecStatus = "needs-update"
-	ContainerNeedsRecreate ContainerSpecStatus = "needs-recreate"
+	ContainerUpToDate ContainerSpecStatus = "up-to-date"
+	// ContainerNeedsSpecUpdate means only the metadata in the spec changed so the spec can be updated in place
+	// without recreating the container.
+	ContainerNeedsSpecUpdate ContainerSpecStatus = "needs-spec-update"
+	ContainerNeedsUpdate     ContainerSpecStatus = "needs-update"
+	ContainerNeedsRecreate   ContainerSpecStatus = "needs-recreate"
 )
 
 func EvalContainerSpecChange(current api.ServiceSpec, new api.ServiceSpec) ContainerSpecStatus {
@@ -49,6 +53,14 @@ func EvalContainerSpecChange(current api.ServiceSpec, new api.ServiceSpec) Conta
 		return ContainerNeedsRecreate
 	}
 
+	if current.NetworkMode != new.NetworkMode || !reflect.DeepEqual(current.Macvlan, new.Macvlan) {
+		return ContainerNeedsRecreate
+	}
+	// TODO: this could be just an in-place spec update when available as networks only scope the service discovery.
+	if !slices.Equal(current.ServiceNetworks(), new.ServiceNetworks()) {
+		return ContainerNeedsRecreate
+	}
+
 	// TODO: change ports check to ContainerNeedsUpdate when ingress ports are stored only in the machine DB instead
 	//  of as labels ans synced to the cluster store. Host ports changes should be handled as ContainerNeedsRecreate.
 	if !api.PortsEqual(current.Ports, new.Ports) {
@@ -91,10 +103,25 @@ func EvalContainerSpecChange(current api.ServiceSpec, new api.ServiceSpec) Conta
 	if !reflect.DeepEqual(current.Container.Resources, newResources) {
 		return ContainerNeedsUpdate
 	}
+	if !metadataEqual(current, new) {
+		return ContainerNeedsSpecUpdate
+	}
 
 	return ContainerUpToDate
 }
 
+// metadataEqual returns true if the specs have the same metadata that doesn't affect the container,
+// see api.ServiceSpec.WithMetadata.
+func metadataEqual(current, new api.ServiceSpec) bool {
+	return slices.Equal(current.Aliases, new.Aliases) &&
+		reflect.DeepEqual(current.Backup, new.Backup) &&
+		current.Owner == new.Owner &&
+		current.Priority == new.Priority &&
+		current.Project == new.Project &&
+		current.Protected == new.Protected &&
+		reflect.DeepEqual(current.ScaleSchedule, new.ScaleSchedule)
+}
+
 func sortVolumes(volumes []api.VolumeSpec) {
 	sort.Slice(volumes, func(i, j int) bool {
 		return volumes[i].Name < volumes[j].Name
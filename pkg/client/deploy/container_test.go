@@ -191,6 +191,22 @@ func TestEvalContainerSpecChange_ContainerImage(t *testing.T) {
 	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(currentSpec, newSpec))
 }
 
+func TestEvalContainerSpecChange_Project(t *testing.T) {
+	t.Parallel()
+
+	currentSpec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Image: "nginx:latest",
+		},
+	}
+	newSpec := currentSpec
+	assert.Equal(t, ContainerUpToDate, EvalContainerSpecChange(currentSpec, newSpec))
+
+	// A service deployed before projects were supported adopts the project without recreating its containers.
+	newSpec.Project = "shop"
+	assert.Equal(t, ContainerNeedsSpecUpdate, EvalContainerSpecChange(currentSpec, newSpec))
+}
+
 func TestEvalContainerSpecChange_ContainerLogDriver(t *testing.T) {
 	t.Parallel()
 
@@ -538,6 +554,66 @@ func TestEvalContainerSpecChange_Placement(t *testing.T) {
 	}
 }
 
+func TestEvalContainerSpecChange_Networks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current []string
+		new     []string
+		want    ContainerSpecStatus
+	}{
+		{
+			name: "empty",
+			want: ContainerUpToDate,
+		},
+		{
+			name:    "explicit default network",
+			current: nil,
+			new:     []string{api.DefaultNetwork},
+			want:    ContainerUpToDate,
+		},
+		{
+			name:    "different order",
+			current: []string{"frontend", "backend"},
+			new:     []string{"backend", "frontend"},
+			want:    ContainerUpToDate,
+		},
+		{
+			name:    "set networks",
+			current: nil,
+			new:     []string{"backend"},
+			want:    ContainerNeedsRecreate,
+		},
+		{
+			name:    "add network",
+			current: []string{"backend"},
+			new:     []string{"backend", "frontend"},
+			want:    ContainerNeedsRecreate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentSpec := api.ServiceSpec{
+				Container: api.ContainerSpec{
+					Image: "nginx:latest",
+				},
+				Networks: tt.current,
+			}
+			newSpec := api.ServiceSpec{
+				Container: api.ContainerSpec{
+					Image: "nginx:latest",
+				},
+				Networks: tt.new,
+			}
+
+			result := EvalContainerSpecChange(currentSpec, newSpec)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
 func TestEvalContainerSpecChange_Volumes(t *testing.T) {
 	t.Parallel()
 
@@ -1408,3 +1484,100 @@ func TestEvalContainerSpecChange_Mixed(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalContainerSpecChange_Backup(t *testing.T) {
+	t.Parallel()
+
+	currentSpec := api.ServiceSpec{
+		Backup: &api.BackupSpec{Engine: api.BackupEnginePostgres},
+		Container: api.ContainerSpec{
+			Image: "postgres:17",
+		},
+	}
+	sameSpec := currentSpec.Clone()
+	sameSpec.Backup.Interval = api.DefaultBackupInterval
+	assert.Equal(t, ContainerUpToDate, EvalContainerSpecChange(currentSpec, sameSpec),
+		"explicit default interval must not change the spec")
+
+	newSpec := currentSpec.Clone()
+	newSpec.Backup.Retention = 30
+	assert.Equal(t, ContainerNeedsSpecUpdate, EvalContainerSpecChange(currentSpec, newSpec))
+
+	newSpec.Backup = nil
+	assert.Equal(t, ContainerNeedsSpecUpdate, EvalContainerSpecChange(currentSpec, newSpec))
+}
+
+func TestEvalContainerSpecChange_Metadata(t *testing.T) {
+	t.Parallel()
+
+	currentSpec := api.ServiceSpec{
+		Name: "web",
+		Container: api.ContainerSpec{
+			Image: "nginx:latest",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		update func(spec *api.ServiceSpec)
+		want   ContainerSpecStatus
+	}{
+		{
+			name:   "aliases",
+			update: func(spec *api.ServiceSpec) { spec.Aliases = []string{"www"} },
+			want:   ContainerNeedsSpecUpdate,
+		},
+		{
+			name:   "owner",
+			update: func(spec *api.ServiceSpec) { spec.Owner = "team-a" },
+			want:   ContainerNeedsSpecUpdate,
+		},
+		{
+			name:   "priority",
+			update: func(spec *api.ServiceSpec) { spec.Priority = api.PriorityHigh },
+			want:   ContainerNeedsSpecUpdate,
+		},
+		{
+			name:   "protected",
+			update: func(spec *api.ServiceSpec) { spec.Protected = true },
+			want:   ContainerNeedsSpecUpdate,
+		},
+		{
+			name: "scale schedule",
+			update: func(spec *api.ServiceSpec) {
+				spec.ScaleSchedule = &api.ScaleSchedule{}
+			},
+			want: ContainerNeedsSpecUpdate,
+		},
+		{
+			name: "metadata and image",
+			update: func(spec *api.ServiceSpec) {
+				spec.Owner = "team-a"
+				spec.Container.Image = "nginx:1.27"
+			},
+			want: ContainerNeedsRecreate,
+		},
+		{
+			name: "metadata and resources",
+			update: func(spec *api.ServiceSpec) {
+				spec.Owner = "team-a"
+				spec.Container.Resources.MemoryReservation = 64 * 1024 * 1024
+			},
+			want: ContainerNeedsUpdate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newSpec := currentSpec.Clone()
+			tt.update(&newSpec)
+			assert.Equal(t, tt.want, EvalContainerSpecChange(currentSpec, newSpec))
+			if tt.want == ContainerNeedsSpecUpdate {
+				assert.Equal(t, ContainerUpToDate, EvalContainerSpecChange(currentSpec.WithMetadata(newSpec), newSpec),
+					"spec updated in place must be up to date")
+			}
+		})
+	}
+}
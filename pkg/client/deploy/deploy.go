@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/psviderski/uncloud/pkg/api"
 )
@@ -15,6 +16,11 @@ type Client interface {
 	api.MachineClient
 	api.ServiceClient
 	api.VolumeClient
+	// RecordPreemption records a container stopped to make room for a container of a higher priority service.
+	RecordPreemption(ctx context.Context, p api.Preemption) error
+	// SeedImage makes the image available on the machines pulling it from the registry at most once. Failures to copy
+	// the image between the machines are not returned as the machines can pull it themselves.
+	SeedImage(ctx context.Context, image string, machineIDs []string) error
 }
 
 // Deployment manages the process of creating or updating a service to match a desired state.
@@ -30,6 +36,10 @@ type Deployment struct {
 type Plan struct {
 	ServiceID   string
 	ServiceName string
+	// DependsOn are the names of the services whose plans must complete before this plan is executed
+	// when executing multiple plans concurrently. The dependencies on services without a plan must be resolved
+	// to their own dependencies.
+	DependsOn []string
 	SequenceOperation
 }
 
@@ -59,16 +69,21 @@ func (d *Deployment) Plan(ctx context.Context) (Plan, error) {
 		return Plan{}, fmt.Errorf("invalid deployment: %w", err)
 	}
 
-	clusterDomain, err := d.cli.GetDomain(ctx)
-	if err != nil && !errors.Is(err, api.ErrNotFound) {
+	clusterDomain, err := d.cli.ServiceDomain(ctx)
+	if err != nil {
 		return Plan{}, fmt.Errorf("get cluster domain: %w", err)
 	}
 	specResolver := &ServiceSpecResolver{
-		// If the domain is not found (not reserved), an empty domain is used for the resolver.
+		// If no domain is reserved or set in the cluster settings, an empty domain is used for the resolver.
 		ClusterDomain: clusterDomain,
 	}
 
-	resolvedSpec, err := specResolver.Resolve(d.Spec)
+	spec := d.Spec
+	if spec.ScaleSchedule != nil {
+		// The number of replicas of a service with a scale schedule is set by the window active now.
+		spec.Replicas = spec.ScaleSchedule.ReplicasAt(time.Now())
+	}
+	resolvedSpec, err := specResolver.Resolve(spec)
 	if err != nil {
 		return Plan{}, fmt.Errorf("resolve service spec: %w", err)
 	}
@@ -114,6 +129,19 @@ func (d *Deployment) Validate(ctx context.Context) error {
 	if mode != d.Service.Mode {
 		return errors.New("service mode cannot be changed")
 	}
+	// Prevent a project from taking over a service of another project with the same name. Services deployed without
+	// a project can be adopted by a project.
+	if d.Spec.Project != "" && d.Service.Project != "" && d.Spec.Project != d.Service.Project {
+		return fmt.Errorf("service '%s' belongs to another project '%s'", d.Service.Name, d.Service.Project)
+	}
+	// Service names are unique in the cluster so prevent a tenant from taking over a service of another tenant
+	// or a service without a tenant and vice versa.
+	if tenant := d.Service.Tenant(); d.Spec.Tenant != tenant {
+		if tenant == "" {
+			return fmt.Errorf("service '%s' already exists and doesn't belong to a tenant", d.Service.Name)
+		}
+		return fmt.Errorf("service '%s' belongs to another tenant", d.Service.Name)
+	}
 
 	return nil
 }
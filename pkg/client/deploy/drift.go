@@ -0,0 +1,69 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+)
+
+// DriftCorrectionStrategy replaces the running containers of a service that drifted from their spec, e.g. after
+// being changed manually with 'docker update', with new containers created from the same spec on the same machines.
+// Containers are replaced in batches according to the update config of the service, one at a time by default.
+type DriftCorrectionStrategy struct{}
+
+func (s *DriftCorrectionStrategy) Type() string {
+	return "drift-correction"
+}
+
+func (s *DriftCorrectionStrategy) Plan(
+	_ context.Context, _ scheduler.Client, svc *api.Service, spec api.ServiceSpec,
+) (Plan, error) {
+	if svc == nil {
+		return Plan{}, fmt.Errorf("service '%s' not found", spec.Name)
+	}
+	plan, err := newEmptyPlan(svc, spec)
+	if err != nil {
+		return plan, err
+	}
+
+	var updates []*ContainerUpdateOperation
+	for _, c := range svc.Containers {
+		if len(c.Container.Drift) == 0 || !c.Container.State.Running {
+			continue
+		}
+
+		// Each container is recreated with its own spec as the containers may have different specs during
+		// a paused update.
+		ctrSpec := c.Container.ServiceSpec
+		update := &ContainerUpdateOperation{OldSpec: &ctrSpec}
+		conflictingPorts, err := c.Container.ConflictingServicePorts(ctrSpec.Ports)
+		if err != nil {
+			return plan, fmt.Errorf("check conflicting ports: %w", err)
+		}
+		if len(conflictingPorts) > 0 || usesHostNetwork(ctrSpec, ctrSpec) || stopFirst(ctrSpec) {
+			update.Operations = append(update.Operations, &StopContainerOperation{
+				ServiceID:   svc.ID,
+				ContainerID: c.Container.ID,
+				MachineID:   c.MachineID,
+			})
+		}
+		update.Operations = append(update.Operations,
+			&RunContainerOperation{
+				ServiceID: svc.ID,
+				Spec:      ctrSpec,
+				MachineID: c.MachineID,
+			},
+			&RemoveContainerOperation{
+				ServiceID:   svc.ID,
+				ContainerID: c.Container.ID,
+				MachineID:   c.MachineID,
+			},
+		)
+		updates = append(updates, update)
+	}
+
+	plan.Operations = rollingUpdate(spec, updates)
+	return plan, nil
+}
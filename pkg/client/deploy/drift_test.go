@@ -0,0 +1,48 @@
+package deploy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftCorrectionStrategy(t *testing.T) {
+	t.Parallel()
+
+	cli, svc := newRestartTestCluster(t)
+	spec := svc.Containers[0].Container.ServiceSpec
+	drifted := svc.Containers[1]
+	svc.Containers[1].Container.Drift = []api.ContainerDrift{
+		{Field: "resources", Expected: "cpus=unlimited", Actual: "cpus=0.5"},
+	}
+
+	d := deploy.NewDeployment(cli, spec, &deploy.DriftCorrectionStrategy{})
+	d.Service = &svc
+	plan, err := d.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan.Operations, 2, "only the drifted container must be replaced")
+
+	_, err = d.Run(context.Background())
+	require.NoError(t, err)
+
+	removed := cli.Calls("RemoveContainer")
+	require.Len(t, removed, 1)
+	assert.Equal(t, drifted.Container.ID, removed[0].Args[1])
+	assert.Equal(t, map[string]int{"nginx:1": 3}, serviceImages(t, cli))
+}
+
+func TestDriftCorrectionStrategy_NoDrift(t *testing.T) {
+	t.Parallel()
+
+	cli, svc := newRestartTestCluster(t)
+	d := deploy.NewDeployment(cli, svc.Containers[0].Container.ServiceSpec, &deploy.DriftCorrectionStrategy{})
+	d.Service = &svc
+
+	plan, err := d.Plan(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, plan.Operations)
+}
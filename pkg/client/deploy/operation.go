@@ -3,7 +3,9 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/volume"
@@ -34,6 +36,8 @@ type RunContainerOperation struct {
 	ServiceID string
 	Spec      api.ServiceSpec
 	MachineID string
+	// containerID is the ID of the created container set when the operation is executed.
+	containerID string
 }
 
 func (o *RunContainerOperation) Execute(ctx context.Context, cli Client) error {
@@ -41,6 +45,7 @@ func (o *RunContainerOperation) Execute(ctx context.Context, cli Client) error {
 	if err != nil {
 		return fmt.Errorf("create container: %w", err)
 	}
+	o.containerID = resp.ID
 	if err = cli.StartContainer(ctx, o.ServiceID, resp.ID); err != nil {
 		return fmt.Errorf("start container: %w", err)
 	}
@@ -84,6 +89,31 @@ func (o *StopContainerOperation) String() string {
 		o.ServiceID, o.ContainerID, o.MachineID)
 }
 
+// UpdateContainerSpecOperation updates the spec of a container on a specific machine without recreating it.
+type UpdateContainerSpecOperation struct {
+	ServiceID   string
+	ContainerID string
+	MachineID   string
+	Spec        api.ServiceSpec
+}
+
+func (o *UpdateContainerSpecOperation) Execute(ctx context.Context, cli Client) error {
+	if err := cli.UpdateContainerSpec(ctx, o.ServiceID, o.ContainerID, o.Spec); err != nil {
+		return fmt.Errorf("update container spec: %w", err)
+	}
+	return nil
+}
+
+func (o *UpdateContainerSpecOperation) Format(resolver NameResolver) string {
+	machineName := resolver.MachineName(o.MachineID)
+	return fmt.Sprintf("%s: Update container spec [name=%s]", machineName, resolver.ContainerName(o.ContainerID))
+}
+
+func (o *UpdateContainerSpecOperation) String() string {
+	return fmt.Sprintf("UpdateContainerSpecOperation[service_id=%s, container_id=%s, machine_id=%s]",
+		o.ServiceID, o.ContainerID, o.MachineID)
+}
+
 // RemoveContainerOperation stops and removes a container from a specific machine.
 type RemoveContainerOperation struct {
 	ServiceID   string
@@ -115,6 +145,41 @@ func (o *RemoveContainerOperation) String() string {
 		o.ServiceID, o.ContainerID, o.MachineID)
 }
 
+// PreemptContainerOperation stops a container of a lower priority service to make room for a container of a higher
+// priority service on a machine without enough unreserved resources, and records the preemption in the cluster.
+type PreemptContainerOperation struct {
+	// ServiceID is the ID of the service the preempted container belongs to.
+	ServiceID  string
+	Preemption api.Preemption
+}
+
+func (o *PreemptContainerOperation) Execute(ctx context.Context, cli Client) error {
+	if err := cli.StopContainer(
+		ctx, o.ServiceID, o.Preemption.ContainerID, container.StopOptions{},
+	); err != nil {
+		return fmt.Errorf("stop container: %w", err)
+	}
+
+	p := o.Preemption
+	p.Time = time.Now().UTC()
+	if err := cli.RecordPreemption(ctx, p); err != nil {
+		return fmt.Errorf("record preemption: %w", err)
+	}
+	return nil
+}
+
+func (o *PreemptContainerOperation) Format(resolver NameResolver) string {
+	p := o.Preemption
+	return fmt.Sprintf("%s: Preempt container [name=%s] of service %s (priority %s) for service %s (priority %s)",
+		resolver.MachineName(p.MachineID), p.ContainerName, p.ServiceName, api.FormatPriority(p.Priority),
+		p.PreemptorService, api.FormatPriority(p.PreemptorPriority))
+}
+
+func (o *PreemptContainerOperation) String() string {
+	return fmt.Sprintf("PreemptContainerOperation[service_id=%s, container_id=%s, machine_id=%s]",
+		o.ServiceID, o.Preemption.ContainerID, o.Preemption.MachineID)
+}
+
 // CreateVolumeOperation creates a volume on a specific machine.
 type CreateVolumeOperation struct {
 	VolumeSpec api.VolumeSpec
@@ -155,6 +220,50 @@ func (o *CreateVolumeOperation) String() string {
 		o.VolumeSpec.DockerVolumeName(), o.MachineID)
 }
 
+// RemoveServiceOperation removes all containers of a service on all machines.
+type RemoveServiceOperation struct {
+	ServiceID   string
+	ServiceName string
+}
+
+func (o *RemoveServiceOperation) Execute(ctx context.Context, cli Client) error {
+	if err := cli.RemoveService(ctx, o.ServiceID); err != nil {
+		return fmt.Errorf("remove service '%s': %w", o.ServiceName, err)
+	}
+	return nil
+}
+
+func (o *RemoveServiceOperation) Format(_ NameResolver) string {
+	return fmt.Sprintf("Remove service [name=%s]", o.ServiceName)
+}
+
+func (o *RemoveServiceOperation) String() string {
+	return fmt.Sprintf("RemoveServiceOperation[service_id=%s, service_name=%s]", o.ServiceID, o.ServiceName)
+}
+
+// RemoveVolumeOperation removes a volume from a specific machine.
+type RemoveVolumeOperation struct {
+	VolumeName string
+	MachineID  string
+	// MachineName is used for formatting the operation output only.
+	MachineName string
+}
+
+func (o *RemoveVolumeOperation) Execute(ctx context.Context, cli Client) error {
+	if err := cli.RemoveVolume(ctx, o.MachineID, o.VolumeName, false); err != nil {
+		return fmt.Errorf("remove volume '%s' on machine '%s': %w", o.VolumeName, o.MachineName, err)
+	}
+	return nil
+}
+
+func (o *RemoveVolumeOperation) Format(_ NameResolver) string {
+	return fmt.Sprintf("%s: Remove volume [name=%s]", o.MachineName, o.VolumeName)
+}
+
+func (o *RemoveVolumeOperation) String() string {
+	return fmt.Sprintf("RemoveVolumeOperation[volume=%s, machine_id=%s]", o.VolumeName, o.MachineID)
+}
+
 // SequenceOperation is a composite operation that executes a sequence of operations in order.
 type SequenceOperation struct {
 	Operations []Operation
@@ -186,3 +295,33 @@ func (o *SequenceOperation) String() string {
 
 	return fmt.Sprintf("SequenceOperation[%s]", strings.Join(ops, ", "))
 }
+
+// OperationImages returns the unique images of the containers run by the operation including its nested operations
+// in the order they are run.
+func OperationImages(op Operation) []string {
+	var images []string
+	var walk func(op Operation)
+	walk = func(op Operation) {
+		switch o := op.(type) {
+		case *RunContainerOperation:
+			if !slices.Contains(images, o.Spec.Container.Image) {
+				images = append(images, o.Spec.Container.Image)
+			}
+		case *SequenceOperation:
+			for _, nested := range o.Operations {
+				walk(nested)
+			}
+		case *Plan:
+			walk(&o.SequenceOperation)
+		case *ContainerUpdateOperation:
+			walk(&o.SequenceOperation)
+		case *RollingUpdateOperation:
+			for _, u := range o.Updates {
+				walk(u)
+			}
+		}
+	}
+	walk(op)
+
+	return images
+}
@@ -0,0 +1,140 @@
+package deploy
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/distribution/reference"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// RemoteImageCache caches the images inspected in registries by reference so that the services using the same image
+// are planned with the same digest and the image is only inspected once. It's safe for concurrent use.
+type RemoteImageCache struct {
+	mu     sync.Mutex
+	images map[string]remoteImage
+}
+
+type remoteImage struct {
+	image api.RemoteImage
+	ok    bool
+}
+
+// Inspect returns the cached image or inspects it in a registry with inspectRemoteImage and caches the result.
+func (c *RemoteImageCache) Inspect(ctx context.Context, cli api.ImageClient, image string) (api.RemoteImage, bool) {
+	c.mu.Lock()
+	cached, found := c.images[image]
+	c.mu.Unlock()
+	if found {
+		return cached.image, cached.ok
+	}
+
+	img, ok := inspectRemoteImage(ctx, cli, image)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.images == nil {
+		c.images = make(map[string]remoteImage)
+	}
+	c.images[image] = remoteImage{image: img, ok: ok}
+	return img, ok
+}
+
+// inspectRemoteImage returns the image in a registry as seen by the machine the client is connected to. It returns
+// false if the image is not available in a registry, for example, when it was pushed directly to the machines.
+func inspectRemoteImage(ctx context.Context, cli api.ImageClient, image string) (api.RemoteImage, bool) {
+	images, err := cli.InspectRemoteImage(ctx, image)
+	if err != nil && len(images) == 0 {
+		return api.RemoteImage{}, false
+	}
+
+	for _, img := range images {
+		if img.Metadata != nil && img.Metadata.Error != "" {
+			continue
+		}
+		return img.Image, true
+	}
+	return api.RemoteImage{}, false
+}
+
+// pinImageDigest returns the image reference pinned to the digest of the image in the registry preserving the tag,
+// e.g. nginx:1.27@sha256:..., so that containers created from it run exactly the same image even if the tag is moved
+// later. The image is returned as is if it already includes a digest.
+func pinImageDigest(image string, img api.RemoteImage) string {
+	ref, err := reference.ParseDockerRef(image)
+	if err != nil || img.Reference == nil {
+		return image
+	}
+	if _, ok := ref.(reference.Canonical); ok {
+		return image
+	}
+
+	pinned, err := reference.WithDigest(ref, img.Reference.Digest())
+	if err != nil {
+		return image
+	}
+	return reference.FamiliarString(pinned)
+}
+
+// currentPinnedImage returns the image reference pinned to a digest that the service containers run for the same
+// tag as the image, e.g. nginx:1.27@sha256:... for nginx:1.27. It's used to keep running the same image when the image
+// can't be inspected in a registry. It returns an empty string if no container runs the image pinned to a digest.
+func currentPinnedImage(svc *api.Service, image string) string {
+	if svc == nil {
+		return ""
+	}
+	ref, err := reference.ParseDockerRef(image)
+	if err != nil {
+		return ""
+	}
+	if _, ok := ref.(reference.Canonical); ok {
+		return ""
+	}
+
+	for _, c := range svc.Containers {
+		pinned := c.Container.ServiceSpec.Container.Image
+		// ParseDockerRef drops the tag from a reference with a digest so parse the reference as is.
+		named, err := reference.ParseNormalizedNamed(pinned)
+		if err != nil {
+			continue
+		}
+		canonical, ok := named.(reference.Canonical)
+		if !ok {
+			continue
+		}
+		tagged, ok := named.(reference.Tagged)
+		if !ok {
+			continue
+		}
+		unpinned, err := reference.WithTag(reference.TrimNamed(canonical), tagged.Tag())
+		if err == nil && unpinned.String() == ref.String() {
+			return pinned
+		}
+	}
+	return ""
+}
+
+// remoteImageArchitectures returns the Linux CPU architectures listed in the index manifest of a multi-platform image
+// or the architecture from the config of a single-platform image in the format used by Go and OCI, e.g. amd64
+// or arm64. It returns nil if the architectures can't be determined, for example, when the config of
+// a single-platform image couldn't be fetched.
+func remoteImageArchitectures(img api.RemoteImage) []string {
+	if img.IndexManifest == nil {
+		if img.Platform != nil && img.Platform.OS == "linux" && img.Platform.Architecture != "" {
+			return []string{img.Platform.Architecture}
+		}
+		return nil
+	}
+
+	var archs []string
+	for _, m := range img.IndexManifest.Manifests {
+		// Skip attestation manifests that have the "unknown" platform and non-Linux images.
+		if m.Platform == nil || m.Platform.OS != "linux" || m.Platform.Architecture == "unknown" {
+			continue
+		}
+		if !slices.Contains(archs, m.Platform.Architecture) {
+			archs = append(archs, m.Platform.Architecture)
+		}
+	}
+	return archs
+}
@@ -0,0 +1,100 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/distribution/reference"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteImageArchitectures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single-platform image", func(t *testing.T) {
+		img := api.RemoteImage{
+			ImageManifest: &v1.Manifest{},
+			Platform:      &v1.Platform{OS: "linux", Architecture: "arm64"},
+		}
+		assert.Equal(t, []string{"arm64"}, remoteImageArchitectures(img))
+	})
+
+	t.Run("single-platform image without config", func(t *testing.T) {
+		assert.Nil(t, remoteImageArchitectures(api.RemoteImage{ImageManifest: &v1.Manifest{}}))
+	})
+
+	t.Run("multi-platform image", func(t *testing.T) {
+		img := api.RemoteImage{
+			IndexManifest: &v1.Index{
+				Manifests: []v1.Descriptor{
+					{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+					{Platform: &v1.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+					{Platform: &v1.Platform{OS: "linux", Architecture: "arm", Variant: "v6"}},
+					{Platform: &v1.Platform{OS: "windows", Architecture: "amd64"}},
+					{Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"}},
+					{},
+				},
+			},
+		}
+		assert.Equal(t, []string{"amd64", "arm"}, remoteImageArchitectures(img))
+	})
+}
+
+func TestPinImageDigest(t *testing.T) {
+	t.Parallel()
+
+	const dgst = "sha256:0000000000000000000000000000000000000000000000000000000000000001"
+	named, err := reference.ParseNormalizedNamed("nginx:1.27@" + dgst)
+	require.NoError(t, err)
+	img := api.RemoteImage{Reference: named.(reference.Canonical)}
+
+	tests := []struct {
+		name  string
+		image string
+		want  string
+	}{
+		{name: "tag", image: "nginx:1.27", want: "nginx:1.27@" + dgst},
+		{name: "implicit latest tag", image: "nginx", want: "nginx:latest@" + dgst},
+		{name: "registry", image: "ghcr.io/org/app:v1", want: "ghcr.io/org/app:v1@" + dgst},
+		{
+			name:  "already pinned",
+			image: "nginx:1.27@sha256:0000000000000000000000000000000000000000000000000000000000000002",
+			want:  "nginx:1.27@sha256:0000000000000000000000000000000000000000000000000000000000000002",
+		},
+		{name: "invalid", image: "Nginx", want: "Nginx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pinImageDigest(tt.image, img))
+		})
+	}
+
+	t.Run("unknown digest", func(t *testing.T) {
+		assert.Equal(t, "nginx:1.27", pinImageDigest("nginx:1.27", api.RemoteImage{}))
+	})
+}
+
+func TestCurrentPinnedImage(t *testing.T) {
+	t.Parallel()
+
+	const pinned = "nginx:1.27@sha256:0000000000000000000000000000000000000000000000000000000000000001"
+	svc := &api.Service{
+		Containers: []api.MachineServiceContainer{
+			{Container: api.ServiceContainer{ServiceSpec: api.ServiceSpec{
+				Container: api.ContainerSpec{Image: "nginx:1.26"},
+			}}},
+			{Container: api.ServiceContainer{ServiceSpec: api.ServiceSpec{
+				Container: api.ContainerSpec{Image: pinned},
+			}}},
+		},
+	}
+
+	assert.Equal(t, pinned, currentPinnedImage(svc, "nginx:1.27"))
+	assert.Equal(t, pinned, currentPinnedImage(svc, "docker.io/library/nginx:1.27"))
+	assert.Empty(t, currentPinnedImage(svc, "nginx:1.28"), "different tag")
+	assert.Empty(t, currentPinnedImage(svc, "nginx:1.26"), "container image not pinned")
+	assert.Empty(t, currentPinnedImage(svc, pinned), "image already pinned")
+	assert.Empty(t, currentPinnedImage(nil, "nginx:1.27"), "new service")
+}
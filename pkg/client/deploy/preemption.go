@@ -0,0 +1,139 @@
+package deploy
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+)
+
+// planPreemptions inserts the operations to preempt the containers of lower priority services before the new
+// containers in the updates that don't fit into the unreserved resources of their machines. The CPU limits and memory
+// reservations of the running containers count as reserved resources except for the containers that are stopped or
+// removed by the updates or removals. If preempting all eligible containers still doesn't free enough resources,
+// nothing is preempted on the machine and the container overcommits it as before.
+func (s *RollingStrategy) planPreemptions(
+	spec api.ServiceSpec, updates []*ContainerUpdateOperation, removals []Operation,
+) {
+	res := spec.Container.Resources
+	if res.CPU == 0 && res.MemoryReservation == 0 {
+		return
+	}
+
+	gone := make(map[string]bool)
+	collectGone := func(ops []Operation) {
+		for _, op := range ops {
+			switch o := op.(type) {
+			case *StopContainerOperation:
+				gone[o.ContainerID] = true
+			case *RemoveContainerOperation:
+				gone[o.ContainerID] = true
+			}
+		}
+	}
+	for _, u := range updates {
+		collectGone(u.Operations)
+	}
+	collectGone(removals)
+
+	for _, u := range updates {
+		var ops []Operation
+		for _, op := range u.Operations {
+			if run, ok := op.(*RunContainerOperation); ok {
+				if m := s.stateMachine(run.MachineID); m != nil && m.Info.Resources != nil {
+					ops = append(ops, preemptFor(m, run, gone)...)
+					m.ScheduledResources.CPU += res.CPU
+					m.ScheduledResources.MemoryReservation += res.MemoryReservation
+				}
+			}
+			ops = append(ops, op)
+		}
+		u.Operations = ops
+	}
+}
+
+// stateMachine returns the machine with the given ID from the cluster state or nil if it's not found.
+func (s *RollingStrategy) stateMachine(id string) *scheduler.Machine {
+	for _, m := range s.State.Machines {
+		if m.Info.Id == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// preemptFor returns the operations to preempt the lower priority containers on the machine to free enough resources
+// for the new container. The containers of the lowest priority services are preempted first, and among them
+// the containers with the largest reservations. The preempted containers are removed from the machine state.
+func preemptFor(m *scheduler.Machine, run *RunContainerOperation, gone map[string]bool) []Operation {
+	res := run.Spec.Container.Resources
+	cpuCapacity := int64(m.Info.Resources.Cpus) * api.Core
+	memCapacity := m.Info.Resources.Memory
+
+	reserved := m.ScheduledResources
+	var candidates []api.ServiceContainer
+	for _, c := range m.Containers {
+		if gone[c.ID] {
+			continue
+		}
+		cres := c.ServiceSpec.Container.Resources
+		reserved.CPU += cres.CPU
+		reserved.MemoryReservation += cres.MemoryReservation
+
+		if c.ServiceID() != run.ServiceID && c.ServiceSpec.Priority < run.Spec.Priority &&
+			(cres.CPU > 0 || cres.MemoryReservation > 0) {
+			candidates = append(candidates, c)
+		}
+	}
+	fits := func(r api.ContainerResources) bool {
+		return (res.CPU == 0 || r.CPU+res.CPU <= cpuCapacity) &&
+			(res.MemoryReservation == 0 || r.MemoryReservation+res.MemoryReservation <= memCapacity)
+	}
+	if fits(reserved) {
+		return nil
+	}
+
+	slices.SortFunc(candidates, func(a, b api.ServiceContainer) int {
+		ares, bres := a.ServiceSpec.Container.Resources, b.ServiceSpec.Container.Resources
+		return cmp.Or(
+			cmp.Compare(a.ServiceSpec.Priority, b.ServiceSpec.Priority),
+			cmp.Compare(bres.CPU, ares.CPU),
+			cmp.Compare(bres.MemoryReservation, ares.MemoryReservation),
+		)
+	})
+	var victims []api.ServiceContainer
+	for _, c := range candidates {
+		if fits(reserved) {
+			break
+		}
+		victims = append(victims, c)
+		reserved.CPU -= c.ServiceSpec.Container.Resources.CPU
+		reserved.MemoryReservation -= c.ServiceSpec.Container.Resources.MemoryReservation
+	}
+	if !fits(reserved) {
+		return nil
+	}
+
+	ops := make([]Operation, 0, len(victims))
+	for _, c := range victims {
+		gone[c.ID] = true
+		ops = append(ops, &PreemptContainerOperation{
+			ServiceID: c.ServiceID(),
+			Preemption: api.Preemption{
+				ContainerID:       c.ID,
+				ContainerName:     c.Name,
+				ServiceName:       c.ServiceName(),
+				Tenant:            c.ServiceSpec.Tenant,
+				MachineID:         m.Info.Id,
+				Priority:          c.ServiceSpec.Priority,
+				PreemptorService:  run.Spec.Name,
+				PreemptorPriority: run.Spec.Priority,
+			},
+		})
+	}
+	m.Containers = slices.DeleteFunc(m.Containers, func(c api.ServiceContainer) bool {
+		return slices.ContainsFunc(victims, func(v api.ServiceContainer) bool { return v.ID == c.ID })
+	})
+	return ops
+}
@@ -0,0 +1,122 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runningContainer(id, serviceName string, priority int, cpus int64) api.ServiceContainer {
+	return api.ServiceContainer{
+		Container: api.Container{
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					ID:    id,
+					Name:  serviceName + "-" + id,
+					State: &types.ContainerState{Running: true},
+				},
+				Config: &container.Config{Labels: map[string]string{
+					api.LabelServiceID:   "svc-" + serviceName,
+					api.LabelServiceName: serviceName,
+				}},
+			},
+		},
+		ServiceSpec: api.ServiceSpec{
+			Name:      serviceName,
+			Priority:  priority,
+			Container: api.ContainerSpec{Resources: api.ContainerResources{CPU: cpus * api.Core}},
+		},
+	}
+}
+
+func TestRollingStrategy_Preemption(t *testing.T) {
+	t.Parallel()
+
+	newState := func() *scheduler.ClusterState {
+		return &scheduler.ClusterState{
+			Machines: []*scheduler.Machine{{
+				Info: &pb.MachineInfo{Id: "m1", Name: "m1", Resources: &pb.MachineResources{Cpus: 5}},
+				Containers: []api.ServiceContainer{
+					runningContainer("batch1", "batch", api.PriorityLow, 1),
+					runningContainer("batch2", "batch", api.PriorityLow, 2),
+					runningContainer("web1", "web", api.PriorityNormal, 1),
+				},
+			}},
+		}
+	}
+	spec := api.ServiceSpec{
+		Name:     "api",
+		Mode:     api.ServiceModeReplicated,
+		Replicas: 1,
+		Priority: api.PriorityHigh,
+		Container: api.ContainerSpec{
+			Image:     "api:1",
+			Resources: api.ContainerResources{CPU: 2 * api.Core},
+		},
+	}
+
+	t.Run("preempts lowest priority largest container", func(t *testing.T) {
+		t.Parallel()
+		state := newState()
+		s := &RollingStrategy{State: state}
+
+		plan, err := s.planReplicated(nil, spec)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 2)
+
+		preempt, ok := plan.Operations[0].(*PreemptContainerOperation)
+		require.True(t, ok)
+		assert.Equal(t, "svc-batch", preempt.ServiceID)
+		assert.Equal(t, "batch2", preempt.Preemption.ContainerID)
+		assert.Equal(t, "batch", preempt.Preemption.ServiceName)
+		assert.Equal(t, "api", preempt.Preemption.PreemptorService)
+		assert.Equal(t, api.PriorityHigh, preempt.Preemption.PreemptorPriority)
+		assert.IsType(t, &RunContainerOperation{}, plan.Operations[1])
+
+		assert.Len(t, state.Machines[0].Containers, 2)
+		assert.Equal(t, int64(2*api.Core), state.Machines[0].ScheduledResources.CPU)
+	})
+
+	t.Run("no preemption if container fits", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: newState()}
+		spec := spec.Clone()
+		spec.Container.Resources.CPU = api.Core / 2
+
+		plan, err := s.planReplicated(nil, spec)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 1)
+		assert.IsType(t, &RunContainerOperation{}, plan.Operations[0])
+	})
+
+	t.Run("no preemption if not enough resources can be freed", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: newState()}
+		spec := spec.Clone()
+		// Preempting both batch containers leaves only 4 CPUs as the web container has the same priority.
+		spec.Priority = api.PriorityNormal
+		spec.Container.Resources.CPU = 5 * api.Core
+
+		plan, err := s.planReplicated(nil, spec)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 1)
+		assert.IsType(t, &RunContainerOperation{}, plan.Operations[0])
+	})
+
+	t.Run("no preemption without reservations", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: newState()}
+		spec := spec.Clone()
+		spec.Container.Resources = api.ContainerResources{}
+
+		plan, err := s.planReplicated(nil, spec)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 1)
+	})
+}
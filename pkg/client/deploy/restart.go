@@ -0,0 +1,184 @@
+package deploy
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+)
+
+// RestartContainerOperation restarts a container in place by stopping and starting it again. It waits for
+// the restarted container to become healthy if it has a health check configured.
+type RestartContainerOperation struct {
+	ServiceID   string
+	ContainerID string
+	MachineID   string
+}
+
+func (o *RestartContainerOperation) Execute(ctx context.Context, cli Client) error {
+	ctr, err := cli.InspectContainer(ctx, o.ServiceID, o.ContainerID)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+	// The container state in the cluster store is updated asynchronously so the start time is used to tell
+	// the state of the restarted container from the state before the restart.
+	startedAt := ctr.Container.State.StartedAt
+
+	if err = cli.StopContainer(ctx, o.ServiceID, o.ContainerID, container.StopOptions{}); err != nil {
+		return fmt.Errorf("stop container: %w", err)
+	}
+	if err = cli.StartContainer(ctx, o.ServiceID, o.ContainerID); err != nil {
+		return fmt.Errorf("start container: %w", err)
+	}
+
+	var healthcheck *container.HealthConfig
+	if ctr.Container.Config != nil {
+		healthcheck = ctr.Container.Config.Healthcheck
+	}
+	timeout := healthyTimeout(healthcheck)
+	if err = waitContainerHealthy(ctx, cli, o.ServiceID, o.ContainerID, startedAt, timeout); err != nil {
+		return fmt.Errorf("wait for container to become healthy: %w", err)
+	}
+	return nil
+}
+
+func (o *RestartContainerOperation) Format(resolver NameResolver) string {
+	machineName := resolver.MachineName(o.MachineID)
+	return fmt.Sprintf("%s: Restart container [name=%s]", machineName, resolver.ContainerName(o.ContainerID))
+}
+
+func (o *RestartContainerOperation) String() string {
+	return fmt.Sprintf("RestartContainerOperation[service_id=%s, container_id=%s, machine_id=%s]",
+		o.ServiceID, o.ContainerID, o.MachineID)
+}
+
+// Docker defaults for the health check settings that are not specified.
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 30 * time.Second
+	defaultHealthRetries  = 3
+)
+
+// runningTimeout is the time to wait for a restarted container without a health check to be reported as running.
+const runningTimeout = 30 * time.Second
+
+// healthyTimeout returns the time to wait for a restarted container with the given health check to become healthy.
+// It's the time Docker needs at most to mark the container unhealthy: the start period followed by the retries
+// of the failing probes, plus one more probe.
+func healthyTimeout(hc *container.HealthConfig) time.Duration {
+	if hc == nil || len(hc.Test) == 0 || hc.Test[0] == "NONE" {
+		return runningTimeout
+	}
+
+	interval := cmp.Or(hc.Interval, defaultHealthInterval)
+	timeout := cmp.Or(hc.Timeout, defaultHealthTimeout)
+	retries := cmp.Or(hc.Retries, defaultHealthRetries)
+	return hc.StartPeriod + time.Duration(retries+1)*(interval+timeout)
+}
+
+// waitContainerHealthy waits up to the timeout for the container started after prevStartedAt to become healthy.
+// A container without a health check is considered healthy as soon as it's running. It returns an error if
+// the container exits, becomes unhealthy, or doesn't become healthy in time.
+func waitContainerHealthy(
+	ctx context.Context, cli Client, serviceID, containerID, prevStartedAt string, timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout,
+		fmt.Errorf("container didn't become healthy within %s", timeout))
+	defer cancel()
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+
+	for {
+		ctr, err := cli.InspectContainer(ctx, serviceID, containerID)
+		if err != nil {
+			return fmt.Errorf("inspect container: %w", err)
+		}
+
+		state := ctr.Container.State
+		if state.StartedAt != prevStartedAt {
+			if !state.Running {
+				return fmt.Errorf("container exited with code %d after start", state.ExitCode)
+			}
+			if state.Health != nil && state.Health.Status == types.Unhealthy {
+				return errors.New("container became unhealthy after start")
+			}
+			if ctr.Container.Healthy() {
+				return nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// RestartStrategy restarts the existing containers of a service in place without changing their spec, e.g. to pick
+// up changes in mounted configs or clear wedged processes. Containers are restarted in batches according to
+// the update config of the service, one at a time by default.
+type RestartStrategy struct {
+	// Machines restricts the restart to containers on the machines with the given IDs. All containers of the service
+	// are restarted if empty.
+	Machines []string
+}
+
+func (s *RestartStrategy) Type() string {
+	return "restart"
+}
+
+func (s *RestartStrategy) Plan(
+	_ context.Context, _ scheduler.Client, svc *api.Service, spec api.ServiceSpec,
+) (Plan, error) {
+	if svc == nil {
+		return Plan{}, fmt.Errorf("service '%s' not found", spec.Name)
+	}
+	plan, err := newEmptyPlan(svc, spec)
+	if err != nil {
+		return plan, err
+	}
+
+	var config api.UpdateConfig
+	if spec.UpdateConfig != nil {
+		config = *spec.UpdateConfig.Clone()
+	}
+	// The restarted containers keep their spec so there is nothing to roll back to. Stop restarting the remaining
+	// containers instead.
+	if config.FailureAction == api.UpdateFailureActionRollback {
+		config.FailureAction = api.UpdateFailureActionPause
+	}
+
+	var updates []*ContainerUpdateOperation
+	for _, ctr := range svc.Containers {
+		if len(s.Machines) > 0 && !slices.Contains(s.Machines, ctr.MachineID) {
+			continue
+		}
+		updates = append(updates, &ContainerUpdateOperation{
+			SequenceOperation: SequenceOperation{Operations: []Operation{
+				&RestartContainerOperation{
+					ServiceID:   svc.ID,
+					ContainerID: ctr.Container.ID,
+					MachineID:   ctr.MachineID,
+				},
+			}},
+			Monitor: config.Monitor,
+		})
+	}
+	if len(updates) == 0 {
+		return plan, nil
+	}
+
+	plan.Operations = []Operation{&RollingUpdateOperation{
+		Config:  config,
+		Updates: updates,
+	}}
+	return plan, nil
+}
@@ -0,0 +1,88 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/clienttest"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRestartTestCluster(t *testing.T) (*clienttest.Client, api.Service) {
+	cli := clienttest.New()
+	cli.AddMachine("m1")
+	cli.AddMachine("m2")
+	cli.AddMachine("m3")
+
+	svc, err := cli.AddService(api.ServiceSpec{
+		Name:      "web",
+		Mode:      api.ServiceModeReplicated,
+		Replicas:  3,
+		Container: api.ContainerSpec{Image: "nginx:1"},
+	}, "m1", "m2", "m3")
+	require.NoError(t, err)
+	return cli, svc
+}
+
+func restartedContainers(cli *clienttest.Client) []string {
+	var ids []string
+	for _, call := range cli.Calls("StartContainer") {
+		ids = append(ids, call.Args[1].(string))
+	}
+	return ids
+}
+
+func TestRestartStrategy(t *testing.T) {
+	t.Parallel()
+
+	cli, svc := newRestartTestCluster(t)
+	spec := svc.Containers[0].Container.ServiceSpec
+
+	d := deploy.NewDeployment(cli, spec, &deploy.RestartStrategy{})
+	d.Service = &svc
+	_, err := d.Run(context.Background())
+	require.NoError(t, err)
+
+	var want []string
+	for _, ctr := range svc.Containers {
+		want = append(want, ctr.Container.ID)
+	}
+	assert.Equal(t, want, restartedContainers(cli))
+	assert.Len(t, cli.Calls("CreateContainer", "RemoveContainer"), 0, "containers must be restarted in place")
+	assert.Equal(t, map[string]int{"nginx:1": 3}, serviceImages(t, cli))
+}
+
+func TestRestartStrategy_Machines(t *testing.T) {
+	t.Parallel()
+
+	cli, svc := newRestartTestCluster(t)
+	spec := svc.Containers[0].Container.ServiceSpec
+	ctr := svc.Containers[1]
+
+	d := deploy.NewDeployment(cli, spec, &deploy.RestartStrategy{Machines: []string{ctr.MachineID}})
+	d.Service = &svc
+	_, err := d.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{ctr.Container.ID}, restartedContainers(cli))
+}
+
+func TestRestartStrategy_FailurePauses(t *testing.T) {
+	t.Parallel()
+
+	cli, svc := newRestartTestCluster(t)
+	spec := svc.Containers[0].Container.ServiceSpec
+	spec.UpdateConfig = &api.UpdateConfig{FailureAction: api.UpdateFailureActionRollback}
+	cli.FailOn("StartContainer", errors.New("start failed"))
+
+	d := deploy.NewDeployment(cli, spec, &deploy.RestartStrategy{})
+	d.Service = &svc
+	_, err := d.Run(context.Background())
+	require.ErrorContains(t, err, "update paused")
+
+	assert.Len(t, cli.Calls("StopContainer"), 1, "remaining containers must not be restarted")
+}
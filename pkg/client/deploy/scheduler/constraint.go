@@ -3,10 +3,12 @@ package scheduler
 import (
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/go-units"
 	"github.com/psviderski/uncloud/pkg/api"
 )
 
@@ -23,7 +25,6 @@ type Constraint interface {
 func constraintsFromSpec(spec api.ServiceSpec) []Constraint {
 	var constraints []Constraint
 
-	// TODO: add placement constraint based on the supported platforms of the image.
 	// TODO: add placement constraint to limit machines with the image if pull policy is never.
 
 	if len(spec.Placement.Machines) > 0 {
@@ -45,6 +46,14 @@ func constraintsFromSpec(spec api.ServiceSpec) []Constraint {
 		})
 	}
 
+	res := spec.Container.Resources
+	if res.CPU > 0 || res.MemoryReservation > 0 {
+		constraints = append(constraints, &ResourcesConstraint{
+			CPU:    res.CPU,
+			Memory: res.MemoryReservation,
+		})
+	}
+
 	return constraints
 }
 
@@ -138,3 +147,53 @@ func (c *VolumesConstraint) Description() string {
 
 	return "Volumes: " + strings.Join(volumeNames, ", ")
 }
+
+// ArchitectureConstraint restricts container placement to machines with a CPU architecture supported by the image.
+type ArchitectureConstraint struct {
+	// Architectures is a list of CPU architectures in the Go/OCI format (e.g. amd64, arm64) supported by the image.
+	Architectures []string
+}
+
+// Evaluate determines if the machine architecture is supported by the image.
+// Machines with an unknown architecture (running an older daemon version) are considered eligible.
+func (c *ArchitectureConstraint) Evaluate(machine *Machine) bool {
+	if machine.Info.Arch == "" {
+		return true
+	}
+	return slices.Contains(c.Architectures, machine.Info.Arch)
+}
+
+func (c *ArchitectureConstraint) Description() string {
+	archs := slices.Sorted(slices.Values(c.Architectures))
+	return "Image architectures: " + strings.Join(archs, ", ")
+}
+
+// ResourcesConstraint restricts container placement to machines with enough CPUs and total memory to run
+// the container.
+type ResourcesConstraint struct {
+	// CPU is the CPU limit of the container in nanocores.
+	CPU int64
+	// Memory is the memory reservation of the container in bytes.
+	Memory int64
+}
+
+// Evaluate determines if the machine has enough CPUs and memory for the container.
+// Machines with unknown resources (running an older daemon version) are considered eligible.
+func (c *ResourcesConstraint) Evaluate(machine *Machine) bool {
+	r := machine.Info.Resources
+	if r == nil {
+		return true
+	}
+	return int64(r.Cpus)*api.Core >= c.CPU && r.Memory >= c.Memory
+}
+
+func (c *ResourcesConstraint) Description() string {
+	var reqs []string
+	if c.CPU > 0 {
+		reqs = append(reqs, "CPUs >= "+strconv.FormatFloat(float64(c.CPU)/api.Core, 'f', -1, 64))
+	}
+	if c.Memory > 0 {
+		reqs = append(reqs, "memory >= "+units.BytesSize(float64(c.Memory)))
+	}
+	return "Resources: " + strings.Join(reqs, ", ")
+}
@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/pkg/api"
@@ -14,8 +16,10 @@ type ServiceScheduler struct {
 }
 
 // NewServiceScheduler creates a new ServiceScheduler with the given cluster state and service specification.
-func NewServiceScheduler(state *ClusterState, spec api.ServiceSpec) *ServiceScheduler {
-	constraints := constraintsFromSpec(spec)
+// Additional constraints that can't be derived from the spec, e.g. the image architectures, can be provided
+// with extraConstraints.
+func NewServiceScheduler(state *ClusterState, spec api.ServiceSpec, extraConstraints ...Constraint) *ServiceScheduler {
+	constraints := append(constraintsFromSpec(spec), extraConstraints...)
 
 	return &ServiceScheduler{
 		state:       state,
@@ -28,12 +32,24 @@ func NewServiceScheduler(state *ClusterState, spec api.ServiceSpec) *ServiceSche
 func (s *ServiceScheduler) EligibleMachines() ([]*Machine, error) {
 	var available []*Machine
 	for _, machine := range s.state.Machines {
+		// Cordoned machines, e.g. the ones shutting down for a planned reboot, can't accept new containers.
+		if machine.Info.Cordoned {
+			continue
+		}
 		if s.evaluateConstraints(machine) {
 			available = append(available, machine)
 		}
 	}
 	if len(available) == 0 {
-		return nil, errors.New("no machines available that satisfy all constraints")
+		if len(s.constraints) == 0 {
+			return nil, errors.New("no machines available that satisfy all constraints")
+		}
+		descriptions := make([]string, len(s.constraints))
+		for i, c := range s.constraints {
+			descriptions[i] = c.Description()
+		}
+		return nil, fmt.Errorf("no machines available that satisfy all constraints: %s",
+			strings.Join(descriptions, "; "))
 	}
 	return available, nil
 }
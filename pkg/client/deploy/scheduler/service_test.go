@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceScheduler_EligibleMachines_Architecture(t *testing.T) {
+	t.Parallel()
+
+	state := &ClusterState{
+		Machines: []*Machine{
+			{Info: &pb.MachineInfo{Id: "amd", Name: "amd", Arch: "amd64"}},
+			{Info: &pb.MachineInfo{Id: "arm", Name: "arm", Arch: "arm64"}},
+			{Info: &pb.MachineInfo{Id: "unknown", Name: "unknown"}},
+		},
+	}
+	spec := api.ServiceSpec{
+		Name: "svc",
+		Container: api.ContainerSpec{
+			Image: "nginx",
+		},
+	}
+
+	t.Run("filters machines by image architectures", func(t *testing.T) {
+		sched := NewServiceScheduler(state, spec, &ArchitectureConstraint{Architectures: []string{"arm64"}})
+		machines, err := sched.EligibleMachines()
+		require.NoError(t, err)
+
+		var ids []string
+		for _, m := range machines {
+			ids = append(ids, m.Info.Id)
+		}
+		assert.Equal(t, []string{"arm", "unknown"}, ids)
+	})
+
+	t.Run("no machines with supported architecture", func(t *testing.T) {
+		placed := spec.Clone()
+		placed.Placement.Machines = []string{"amd"}
+
+		sched := NewServiceScheduler(state, placed, &ArchitectureConstraint{Architectures: []string{"arm64"}})
+		_, err := sched.EligibleMachines()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no machines available")
+		assert.Contains(t, err.Error(), "Image architectures: arm64")
+	})
+
+	t.Run("description doesn't reorder architectures", func(t *testing.T) {
+		c := &ArchitectureConstraint{Architectures: []string{"arm64", "amd64"}}
+		assert.Equal(t, "Image architectures: amd64, arm64", c.Description())
+		assert.Equal(t, []string{"arm64", "amd64"}, c.Architectures)
+	})
+}
+
+func TestServiceScheduler_EligibleMachines_Resources(t *testing.T) {
+	t.Parallel()
+
+	state := &ClusterState{
+		Machines: []*Machine{
+			{Info: &pb.MachineInfo{Id: "small", Name: "small", Resources: &pb.MachineResources{
+				Cpus: 1, Memory: 1 << 30,
+			}}},
+			{Info: &pb.MachineInfo{Id: "large", Name: "large", Resources: &pb.MachineResources{
+				Cpus: 4, Memory: 8 << 30,
+			}}},
+			{Info: &pb.MachineInfo{Id: "unknown", Name: "unknown"}},
+		},
+	}
+	spec := api.ServiceSpec{
+		Name: "svc",
+		Container: api.ContainerSpec{
+			Image: "postgres",
+			Resources: api.ContainerResources{
+				CPU:               2 * api.Core,
+				MemoryReservation: 2 << 30,
+			},
+		},
+	}
+
+	t.Run("filters machines by resources", func(t *testing.T) {
+		sched := NewServiceScheduler(state, spec)
+		machines, err := sched.EligibleMachines()
+		require.NoError(t, err)
+
+		var ids []string
+		for _, m := range machines {
+			ids = append(ids, m.Info.Id)
+		}
+		assert.Equal(t, []string{"large", "unknown"}, ids)
+	})
+
+	t.Run("no machines with enough resources", func(t *testing.T) {
+		placed := spec.Clone()
+		placed.Placement.Machines = []string{"small"}
+
+		sched := NewServiceScheduler(state, placed)
+		_, err := sched.EligibleMachines()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Resources: CPUs >= 2, memory >= 2GiB")
+	})
+}
@@ -18,10 +18,17 @@ type Machine struct {
 	Info             *pb.MachineInfo
 	Volumes          []volume.Volume
 	ScheduledVolumes []api.VolumeSpec
+	// Containers are the running service containers on the machine. They reserve the machine resources and can be
+	// preempted by the containers of higher priority services.
+	Containers []api.ServiceContainer
+	// ScheduledResources are the resources reserved by the containers planned to run on the machine.
+	ScheduledResources api.ContainerResources
 }
 
 type Client interface {
+	api.ImageClient
 	api.MachineClient
+	api.ServiceClient
 	api.VolumeClient
 }
 
@@ -37,9 +44,15 @@ func InspectClusterState(ctx context.Context, cli Client) (*ClusterState, error)
 	if err != nil {
 		return nil, fmt.Errorf("list volumes: %w", err)
 	}
+	services, err := cli.ListServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list services: %w", err)
+	}
 
 	var machines []*Machine
 	for _, m := range machineMembers {
+		// Cordoned machines are kept in the state to keep their containers but they are excluded from new
+		// placements by the ServiceScheduler.
 		machine := &Machine{
 			Info: m.Machine,
 		}
@@ -49,6 +62,13 @@ func InspectClusterState(ctx context.Context, cli Client) (*ClusterState, error)
 				machine.Volumes = append(machine.Volumes, v.Volume)
 			}
 		}
+		for _, svc := range services {
+			for _, c := range svc.Containers {
+				if c.MachineID == m.Machine.Id && c.Container.State != nil && c.Container.State.Running {
+					machine.Containers = append(machine.Containers, c.Container)
+				}
+			}
+		}
 
 		machines = append(machines, machine)
 	}
@@ -0,0 +1,68 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// Execute seeds the images of the containers the plan runs on multiple machines and executes the plan operations.
+func (p *Plan) Execute(ctx context.Context, cli Client) error {
+	for _, s := range planImageSeeds(&p.SequenceOperation) {
+		if err := cli.SeedImage(ctx, s.image, s.machineIDs); err != nil {
+			return fmt.Errorf("seed image '%s': %w", s.image, err)
+		}
+	}
+	return p.SequenceOperation.Execute(ctx, cli)
+}
+
+type imageSeed struct {
+	image      string
+	machineIDs []string
+}
+
+// planImageSeeds returns the images with the missing pull policy that the operation runs containers from on at least
+// two machines. Seeding such an image makes only one machine pull it from the registry while the rest copy it from
+// a machine that already has it over the cluster network. Images with the always pull policy are always pulled from
+// the registry and images with the never pull policy are never pulled so they aren't seeded.
+func planImageSeeds(op Operation) []imageSeed {
+	var seeds []imageSeed
+	var walk func(op Operation)
+	walk = func(op Operation) {
+		switch o := op.(type) {
+		case *RunContainerOperation:
+			if o.Spec.Container.PullPolicy != "" && o.Spec.Container.PullPolicy != api.PullPolicyMissing {
+				return
+			}
+			i := slices.IndexFunc(seeds, func(s imageSeed) bool {
+				return s.image == o.Spec.Container.Image
+			})
+			if i == -1 {
+				seeds = append(seeds, imageSeed{image: o.Spec.Container.Image})
+				i = len(seeds) - 1
+			}
+			if !slices.Contains(seeds[i].machineIDs, o.MachineID) {
+				seeds[i].machineIDs = append(seeds[i].machineIDs, o.MachineID)
+			}
+		case *SequenceOperation:
+			for _, nested := range o.Operations {
+				walk(nested)
+			}
+		case *Plan:
+			walk(&o.SequenceOperation)
+		case *ContainerUpdateOperation:
+			walk(&o.SequenceOperation)
+		case *RollingUpdateOperation:
+			for _, u := range o.Updates {
+				walk(u)
+			}
+		}
+	}
+	walk(op)
+
+	return slices.DeleteFunc(seeds, func(s imageSeed) bool {
+		return len(s.machineIDs) < 2
+	})
+}
@@ -0,0 +1,60 @@
+package deploy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/clienttest"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan_Execute_SeedsImages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		pullPolicy string
+		machines   []string
+		wantSeeded bool
+	}{
+		{name: "missing pull policy on multiple machines", machines: []string{"m1", "m2", "m3"}, wantSeeded: true},
+		{name: "single machine", machines: []string{"m1"}},
+		{name: "always pull policy", pullPolicy: api.PullPolicyAlways, machines: []string{"m1", "m2", "m3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cli := clienttest.New()
+			var machineIDs []string
+			for _, name := range tt.machines {
+				machineIDs = append(machineIDs, cli.AddMachine(name).Machine.Id)
+			}
+
+			spec := api.ServiceSpec{
+				Name: "web",
+				Mode: api.ServiceModeGlobal,
+				Container: api.ContainerSpec{
+					Image:      "nginx:1",
+					PullPolicy: tt.pullPolicy,
+				},
+			}
+			_, err := deploy.NewDeployment(cli, spec, nil).Run(context.Background())
+			require.NoError(t, err)
+
+			calls := cli.Calls("SeedImage", "CreateContainer")
+			if !tt.wantSeeded {
+				assert.Empty(t, cli.Calls("SeedImage"))
+				return
+			}
+			require.NotEmpty(t, calls)
+			assert.Equal(t, "SeedImage", calls[0].Method, "image must be seeded before creating containers")
+			assert.Equal(t, "nginx:1", calls[0].Args[0])
+			assert.ElementsMatch(t, machineIDs, calls[0].Args[1])
+			assert.Len(t, cli.Calls("SeedImage"), 1)
+		})
+	}
+}
@@ -27,6 +27,9 @@ type Strategy interface {
 type RollingStrategy struct {
 	State         *scheduler.ClusterState
 	ForceRecreate bool
+	// Images caches the images inspected in registries when planning multiple services. Images are inspected
+	// for every service if nil.
+	Images *RemoteImageCache
 }
 
 func (s *RollingStrategy) Type() string {
@@ -44,12 +47,48 @@ func (s *RollingStrategy) Plan(
 		s.State = state
 	}
 
+	var constraints []scheduler.Constraint
+	// The image with the 'never' pull policy is expected to be present on machines so it's not checked in a registry.
+	if spec.Container.PullPolicy != api.PullPolicyNever {
+		var img api.RemoteImage
+		var ok bool
+		if s.Images != nil {
+			img, ok = s.Images.Inspect(ctx, cli, spec.Container.Image)
+		} else {
+			img, ok = inspectRemoteImage(ctx, cli, spec.Container.Image)
+		}
+		if ok {
+			// Run containers by the digest the tag currently points to so that all replicas run the same image and
+			// the digest is recorded in the service spec to restore exactly the same image on rollback.
+			spec.Container.Image = pinImageDigest(spec.Container.Image, img)
+			// Restrict the placement to machines with an architecture supported by the image if it can be determined.
+			if archs := remoteImageArchitectures(img); len(archs) > 0 {
+				constraints = append(constraints, &scheduler.ArchitectureConstraint{Architectures: archs})
+			}
+		} else if pinned := currentPinnedImage(svc, spec.Container.Image); pinned != "" {
+			// The registry may be temporarily unavailable. Keep running the digest the containers already run for
+			// the same tag rather than recreating them with the unpinned tag.
+			spec.Container.Image = pinned
+		}
+	}
+
+	// Skip planning the service in detail if neither the spec nor the image the tag points to have changed.
+	if !s.ForceRecreate {
+		upToDate, err := containersUpToDate(svc, spec)
+		if err != nil {
+			return Plan{}, err
+		}
+		if upToDate {
+			return newEmptyPlan(svc, spec)
+		}
+	}
+
 	// We can assume that the spec is valid at this point because it has been validated by the deployment.
 	switch spec.Mode {
 	case api.ServiceModeReplicated:
-		return s.planReplicated(svc, spec)
+		return s.planReplicated(svc, spec, constraints...)
 	case api.ServiceModeGlobal:
-		return s.planGlobal(svc, spec)
+		return s.planGlobal(svc, spec, constraints...)
 	default:
 		return Plan{}, fmt.Errorf("unsupported service mode: '%s'", spec.Mode)
 	}
@@ -59,13 +98,15 @@ func (s *RollingStrategy) Plan(
 // For replicated services, we want to maintain a specific number of containers (replicas) across the available machines
 // in the cluster.
 // TODO: schedule containers only on machines that contain the image if pull policy is set to 'never'.
-func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec) (Plan, error) {
+func (s *RollingStrategy) planReplicated(
+	svc *api.Service, spec api.ServiceSpec, constraints ...scheduler.Constraint,
+) (Plan, error) {
 	plan, err := newEmptyPlan(svc, spec)
 	if err != nil {
 		return plan, err
 	}
 
-	sched := scheduler.NewServiceScheduler(s.State, spec)
+	sched := scheduler.NewServiceScheduler(s.State, spec, constraints...)
 	// TODO: return a detailed report on required constraints and which ones are satisfied?
 	availableMachines, err := sched.EligibleMachines()
 	if err != nil {
@@ -76,6 +117,11 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 	for _, m := range availableMachines {
 		matchedMachines = append(matchedMachines, m.Info)
 	}
+	if spec.OneContainerPerMachine() && int(spec.Replicas) > len(matchedMachines) {
+		return plan, fmt.Errorf("service in '%s' network mode can run at most one container per machine: "+
+			"%d replicas requested but only %d eligible machines available",
+			spec.NetworkMode, spec.Replicas, len(matchedMachines))
+	}
 
 	// Randomise the order of machines to avoid always deploying to the same machines first.
 	rand.Shuffle(len(matchedMachines), func(i, j int) {
@@ -101,17 +147,17 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 			}
 			containerSpecStatuses[c.Container.ID] = status
 
-			if status == ContainerUpToDate {
+			if keepContainer(status) {
 				upToDateContainersOnMachine[c.MachineID] += 1
 			}
 		}
 
 		// Sort containers such that running containers with the desired spec are first.
 		slices.SortFunc(svc.Containers, func(c1, c2 api.MachineServiceContainer) int {
-			if status, ok := containerSpecStatuses[c1.Container.ID]; ok && status == ContainerUpToDate {
+			if status, ok := containerSpecStatuses[c1.Container.ID]; ok && keepContainer(status) {
 				return -1
 			}
-			if status, ok := containerSpecStatuses[c2.Container.ID]; ok && status == ContainerUpToDate {
+			if status, ok := containerSpecStatuses[c2.Container.ID]; ok && keepContainer(status) {
 				return 1
 			}
 			return 0
@@ -139,16 +185,24 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 
 	// Spread the containers across the available machines evenly using a simple round-robin approach, starting with
 	// machines that already have containers and prioritising machines with containers that match the desired spec.
+	var (
+		updates     []*ContainerUpdateOperation
+		specUpdates []Operation
+	)
 	for i := 0; i < int(spec.Replicas); i++ {
 		m := matchedMachines[i%len(matchedMachines)]
 		containers := containersOnMachine[m.Id]
 
 		if len(containers) == 0 {
 			// No more existing containers on this machine, create a new one.
-			plan.Operations = append(plan.Operations, &RunContainerOperation{
-				ServiceID: plan.ServiceID,
-				Spec:      spec,
-				MachineID: m.Id,
+			updates = append(updates, &ContainerUpdateOperation{
+				SequenceOperation: SequenceOperation{Operations: []Operation{
+					&RunContainerOperation{
+						ServiceID: plan.ServiceID,
+						Spec:      spec,
+						MachineID: m.Id,
+					},
+				}},
 			})
 			continue
 		}
@@ -156,42 +210,63 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 		ctr := containers[0]
 		containersOnMachine[m.Id] = containers[1:]
 
+		update := &ContainerUpdateOperation{}
 		if status, ok := containerSpecStatuses[ctr.ID]; ok { // Contains statuses for only running containers.
 			if status == ContainerUpToDate {
 				continue
 			}
+			if status == ContainerNeedsSpecUpdate {
+				specUpdates = append(specUpdates, &UpdateContainerSpecOperation{
+					ServiceID:   plan.ServiceID,
+					ContainerID: ctr.ID,
+					MachineID:   m.Id,
+					Spec:        ctr.ServiceSpec.WithMetadata(spec),
+				})
+				continue
+			}
 			// TODO: handle ContainerNeedsUpdate when update of mutable fields on a container is supported.
 
 			conflictingPorts, portsErr := ctr.ConflictingServicePorts(spec.Ports)
-			if portsErr != nil || len(conflictingPorts) > 0 {
+			if portsErr != nil || len(conflictingPorts) > 0 || usesHostNetwork(ctr.ServiceSpec, spec) ||
+				stopFirst(spec) {
 				// Stop the malformed container or the container with conflicting ports.
-				plan.Operations = append(plan.Operations, &StopContainerOperation{
+				update.Operations = append(update.Operations, &StopContainerOperation{
 					ServiceID:   plan.ServiceID,
 					ContainerID: ctr.ID,
 					MachineID:   m.Id,
 				})
 			}
+			// Only a running container can be restored when rolling back the update.
+			oldSpec := ctr.ServiceSpec
+			update.OldSpec = &oldSpec
 		}
 
 		// Run a new container.
-		plan.Operations = append(plan.Operations, &RunContainerOperation{
+		update.Operations = append(update.Operations, &RunContainerOperation{
 			ServiceID: plan.ServiceID,
 			Spec:      spec,
 			MachineID: m.Id,
 		})
 
 		// Remove the old container.
-		plan.Operations = append(plan.Operations, &RemoveContainerOperation{
+		update.Operations = append(update.Operations, &RemoveContainerOperation{
 			ServiceID:   plan.ServiceID,
 			ContainerID: ctr.ID,
 			MachineID:   m.Id,
 		})
+		updates = append(updates, update)
 	}
-
 	// Remove any remaining containers that are not needed.
+	var removals []Operation
 	for mid, containers := range containersOnMachine {
 		for _, c := range containers {
-			plan.Operations = append(plan.Operations, &RemoveContainerOperation{
+			if op, keep := s.keepOnCordonedMachine(c, spec, plan.ServiceID, mid); keep {
+				if op != nil {
+					specUpdates = append(specUpdates, op)
+				}
+				continue
+			}
+			removals = append(removals, &RemoveContainerOperation{
 				ServiceID:   plan.ServiceID,
 				ContainerID: c.ID,
 				MachineID:   mid,
@@ -199,6 +274,11 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 		}
 	}
 
+	s.planPreemptions(spec, updates, removals)
+	plan.Operations = append(plan.Operations, specUpdates...)
+	plan.Operations = append(plan.Operations, rollingUpdate(spec, updates)...)
+	plan.Operations = append(plan.Operations, removals...)
+
 	return plan, nil
 }
 
@@ -207,7 +287,9 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 // possible. If the new container would have port conflicts with the existing one, the old container is removed first.
 // It handles multiple containers per machine (though this should not occur in normal operation) and skips machines
 // that are down.
-func (s *RollingStrategy) planGlobal(svc *api.Service, spec api.ServiceSpec) (Plan, error) {
+func (s *RollingStrategy) planGlobal(
+	svc *api.Service, spec api.ServiceSpec, constraints ...scheduler.Constraint,
+) (Plan, error) {
 	plan, err := newEmptyPlan(svc, spec)
 	if err != nil {
 		return plan, err
@@ -223,27 +305,37 @@ func (s *RollingStrategy) planGlobal(svc *api.Service, spec api.ServiceSpec) (Pl
 		}
 	}
 
-	sched := scheduler.NewServiceScheduler(s.State, spec)
+	sched := scheduler.NewServiceScheduler(s.State, spec, constraints...)
 	availableMachines, err := sched.EligibleMachines()
 	if err != nil {
 		return plan, err
 	}
 
+	var updates []*ContainerUpdateOperation
 	for _, m := range availableMachines {
 		containers := containersOnMachine[m.Info.Id]
-		ops, err := reconcileGlobalContainer(containers, spec, plan.ServiceID, m.Info.Id, s.ForceRecreate)
+		update, ops, err := reconcileGlobalContainer(containers, spec, plan.ServiceID, m.Info.Id, s.ForceRecreate)
 		if err != nil {
 			return plan, err
 		}
+		if update != nil {
+			updates = append(updates, update)
+		}
 		plan.Operations = append(plan.Operations, ops...)
 
 		delete(containersOnMachine, m.Info.Id)
 	}
-
 	// Remove any remaining containers on machines that don't match the new placement constraints.
+	var removals []Operation
 	for _, containers := range containersOnMachine {
 		for _, c := range containers {
-			plan.Operations = append(plan.Operations, &RemoveContainerOperation{
+			if op, keep := s.keepOnCordonedMachine(c.Container, spec, plan.ServiceID, c.MachineID); keep {
+				if op != nil {
+					plan.Operations = append(plan.Operations, op)
+				}
+				continue
+			}
+			removals = append(removals, &RemoveContainerOperation{
 				ServiceID:   plan.ServiceID,
 				ContainerID: c.Container.ID,
 				MachineID:   c.MachineID,
@@ -251,29 +343,34 @@ func (s *RollingStrategy) planGlobal(svc *api.Service, spec api.ServiceSpec) (Pl
 		}
 	}
 
+	s.planPreemptions(spec, updates, append(slices.Clone(plan.Operations), removals...))
+	plan.Operations = append(plan.Operations, rollingUpdate(spec, updates)...)
+	plan.Operations = append(plan.Operations, removals...)
+
 	return plan, nil
 }
 
-// reconcileGlobalContainer returns a sequence of operations to reconcile containers on a machine for a global service.
-// It ensures exactly one container with the desired spec is running on the machine by creating a new container and
-// removing old ones. If there is a host port conflict, it stops the old container before starting a new one.
+// reconcileGlobalContainer returns an update to reconcile containers on a machine for a global service and
+// the operations to remove redundant containers. It ensures exactly one container with the desired spec is running
+// on the machine by creating a new container and removing old ones. If there is a host port conflict, it stops the old
+// container before starting a new one. The returned update is nil if the machine already runs an up-to-date container.
 func reconcileGlobalContainer(
 	containers []api.MachineServiceContainer, spec api.ServiceSpec, serviceID, machineID string, forceRecreate bool,
-) ([]Operation, error) {
-	var ops []Operation
-
+) (*ContainerUpdateOperation, []Operation, error) {
 	if len(containers) == 0 {
 		// No containers on this machine, create a new one.
-		ops = append(ops, &RunContainerOperation{
-			ServiceID: serviceID,
-			Spec:      spec,
-			MachineID: machineID,
-		})
-		return ops, nil
+		return &ContainerUpdateOperation{
+			SequenceOperation: SequenceOperation{Operations: []Operation{
+				&RunContainerOperation{
+					ServiceID: serviceID,
+					Spec:      spec,
+					MachineID: machineID,
+				},
+			}},
+		}, nil, nil
 	}
 
 	// Check if there is a container with the same spec already running. If so, remove the rest.
-	upToDate := false
 	for i, c := range containers {
 		if !c.Container.State.Running || c.Container.State.Paused {
 			// Skip containers that are not running.
@@ -287,9 +384,17 @@ func reconcileGlobalContainer(
 			status = EvalContainerSpecChange(c.Container.ServiceSpec, spec)
 		}
 
-		if status == ContainerUpToDate {
-			// The container is already running with the same spec.
-			upToDate = true
+		if keepContainer(status) {
+			// The container is already running with the same spec or only its metadata needs to be updated.
+			var ops []Operation
+			if status == ContainerNeedsSpecUpdate {
+				ops = append(ops, &UpdateContainerSpecOperation{
+					ServiceID:   serviceID,
+					ContainerID: c.Container.ID,
+					MachineID:   c.MachineID,
+					Spec:        c.Container.ServiceSpec.WithMetadata(spec),
+				})
+			}
 			for j, old := range containers {
 				if i == j {
 					continue
@@ -300,36 +405,39 @@ func reconcileGlobalContainer(
 					MachineID:   old.MachineID,
 				})
 			}
-			break
+			return nil, ops, nil
 		}
 		// TODO: handle ContainerNeedsUpdate when update of mutable fields on a container is supported.
 	}
-	if upToDate {
-		return ops, nil
-	}
 
 	// The machine has containers but none of them match the new spec.
 	// Stop the old running containers that have conflicting ports with the new spec before running a new one.
+	update := &ContainerUpdateOperation{}
 	for _, c := range containers {
 		if c.Container.State.Running {
 			conflictingPorts, err := c.Container.ConflictingServicePorts(spec.Ports)
 			if err != nil {
-				return nil, fmt.Errorf("check conflicting ports: %w", err)
+				return nil, nil, fmt.Errorf("check conflicting ports: %w", err)
 			}
 
-			if len(conflictingPorts) > 0 {
+			if len(conflictingPorts) > 0 || usesHostNetwork(c.Container.ServiceSpec, spec) || stopFirst(spec) {
 				// Stop the running container with conflicting ports.
-				ops = append(ops, &StopContainerOperation{
+				update.Operations = append(update.Operations, &StopContainerOperation{
 					ServiceID:   serviceID,
 					ContainerID: c.Container.ID,
 					MachineID:   c.MachineID,
 				})
 			}
+			if update.OldSpec == nil {
+				// Only a running container can be restored when rolling back the update.
+				oldSpec := c.Container.ServiceSpec
+				update.OldSpec = &oldSpec
+			}
 		}
 	}
 
 	// Run a new container.
-	ops = append(ops, &RunContainerOperation{
+	update.Operations = append(update.Operations, &RunContainerOperation{
 		ServiceID: serviceID,
 		Spec:      spec,
 		MachineID: machineID,
@@ -337,14 +445,120 @@ func reconcileGlobalContainer(
 
 	// Remove the old containers.
 	for _, c := range containers {
-		ops = append(ops, &RemoveContainerOperation{
+		update.Operations = append(update.Operations, &RemoveContainerOperation{
 			ServiceID:   serviceID,
 			ContainerID: c.Container.ID,
 			MachineID:   c.MachineID,
 		})
 	}
 
-	return ops, nil
+	return update, nil, nil
+}
+
+// keepOnCordonedMachine returns true if the container on a cordoned machine should be kept. Containers on cordoned
+// machines can't be replaced as no new containers can be scheduled there, but the ones with the desired spec are kept,
+// e.g. the containers stopped for a planned reboot that are started again when the machine is back. The returned
+// operation updates the container spec in place if only its metadata changed and is nil otherwise.
+func (s *RollingStrategy) keepOnCordonedMachine(
+	ctr api.ServiceContainer, spec api.ServiceSpec, serviceID, machineID string,
+) (Operation, bool) {
+	m, ok := s.State.Machine(machineID)
+	if !ok || !m.Info.Cordoned || s.ForceRecreate {
+		return nil, false
+	}
+
+	switch EvalContainerSpecChange(ctr.ServiceSpec, spec) {
+	case ContainerUpToDate:
+		return nil, true
+	case ContainerNeedsSpecUpdate:
+		return &UpdateContainerSpecOperation{
+			ServiceID:   serviceID,
+			ContainerID: ctr.ID,
+			MachineID:   machineID,
+			Spec:        ctr.ServiceSpec.WithMetadata(spec),
+		}, true
+	}
+	return nil, false
+}
+
+// keepContainer returns true if the running container with the spec status can be kept as it either has the desired
+// spec or only its metadata needs to be updated in place.
+func keepContainer(status ContainerSpecStatus) bool {
+	return status == ContainerUpToDate || status == ContainerNeedsSpecUpdate
+}
+
+// rollingUpdate returns the operations to execute the container updates according to the update config of the spec.
+// Without the update config, the updates are executed one at a time as a plain sequence of operations.
+func rollingUpdate(spec api.ServiceSpec, updates []*ContainerUpdateOperation) []Operation {
+	if len(updates) == 0 {
+		return nil
+	}
+	if spec.UpdateConfig == nil {
+		var ops []Operation
+		for _, u := range updates {
+			ops = append(ops, u.Operations...)
+		}
+		return ops
+	}
+
+	for _, u := range updates {
+		u.Monitor = spec.UpdateConfig.Monitor
+	}
+	return []Operation{&RollingUpdateOperation{
+		Config:  *spec.UpdateConfig,
+		Updates: updates,
+	}}
+}
+
+// stopFirst returns true if the update config of the spec requires to stop the old container before starting a new one.
+func stopFirst(spec api.ServiceSpec) bool {
+	return spec.UpdateConfig != nil && spec.UpdateConfig.Order == api.UpdateOrderStopFirst
+}
+
+// usesHostNetwork returns true if either the current or new spec uses the host network. In this case, the old container
+// should be stopped before running a new one on the same machine as they likely bind to the same host ports.
+func usesHostNetwork(current, new api.ServiceSpec) bool {
+	return current.NetworkMode == api.NetworkModeHost || new.NetworkMode == api.NetworkModeHost
+}
+
+// containersUpToDate returns true if the replicated service runs the desired number of containers and all of them
+// have been deployed with a spec of the same hash as the effective spec, so the service doesn't need to be planned
+// in detail. The hashes are computed from the container specs rather than recorded at deployment time so that they
+// always reflect the current spec format and defaults. Services with stopped containers or the 'always' pull policy,
+// and global services that depend on the available machines always need to be planned.
+func containersUpToDate(svc *api.Service, spec api.ServiceSpec) (bool, error) {
+	if svc == nil || spec.Mode != api.ServiceModeReplicated || spec.Container.PullPolicy == api.PullPolicyAlways {
+		return false, nil
+	}
+	if len(svc.Containers) != int(spec.Replicas) {
+		return false, nil
+	}
+
+	hash, err := containersHash(spec)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range svc.Containers {
+		state := c.Container.State
+		if !state.Running || state.Paused {
+			return false, nil
+		}
+		ctrHash, err := containersHash(c.Container.ServiceSpec)
+		if err != nil {
+			return false, err
+		}
+		if ctrHash != hash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// containersHash returns the hash of the spec that determines the service containers. The number of replicas is
+// excluded from the hash as scaling the service doesn't change its containers.
+func containersHash(spec api.ServiceSpec) (string, error) {
+	spec.Replicas = 0
+	return spec.Hash()
 }
 
 // newEmptyPlan creates a new empty plan for a service deployment with initialised service ID and name.
@@ -0,0 +1,252 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollingStrategy_PlanReplicated_HostNetwork(t *testing.T) {
+	t.Parallel()
+
+	state := &scheduler.ClusterState{
+		Machines: []*scheduler.Machine{
+			{Info: &pb.MachineInfo{Id: "m1", Name: "m1"}},
+			{Info: &pb.MachineInfo{Id: "m2", Name: "m2"}},
+		},
+	}
+	spec := api.ServiceSpec{
+		Name: "dhcp",
+		Mode: api.ServiceModeReplicated,
+		Container: api.ContainerSpec{
+			Image: "networkboot/dhcpd",
+		},
+		NetworkMode: api.NetworkModeHost,
+	}
+
+	t.Run("one container per machine", func(t *testing.T) {
+		s := &RollingStrategy{State: state}
+		spec := spec.Clone()
+		spec.Replicas = 2
+
+		plan, err := s.planReplicated(nil, spec)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 2)
+
+		machines := make(map[string]struct{})
+		for _, op := range plan.Operations {
+			run, ok := op.(*RunContainerOperation)
+			require.True(t, ok)
+			machines[run.MachineID] = struct{}{}
+		}
+		assert.Len(t, machines, 2)
+	})
+
+	t.Run("more replicas than machines", func(t *testing.T) {
+		s := &RollingStrategy{State: state}
+		spec := spec.Clone()
+		spec.Replicas = 3
+
+		_, err := s.planReplicated(nil, spec)
+		require.ErrorContains(t, err, "at most one container per machine")
+	})
+}
+
+func TestReconcileGlobalContainer_HostNetwork(t *testing.T) {
+	t.Parallel()
+
+	spec := api.ServiceSpec{
+		Name: "dhcp",
+		Mode: api.ServiceModeGlobal,
+		Container: api.ContainerSpec{
+			Image: "networkboot/dhcpd:2",
+		},
+		NetworkMode: api.NetworkModeHost,
+	}
+	oldSpec := spec.Clone()
+	oldSpec.Container.Image = "networkboot/dhcpd:1"
+
+	containers := []api.MachineServiceContainer{
+		{
+			MachineID: "m1",
+			Container: api.ServiceContainer{
+				Container: api.Container{
+					ContainerJSON: types.ContainerJSON{
+						ContainerJSONBase: &types.ContainerJSONBase{
+							ID:    "old",
+							State: &types.ContainerState{Running: true},
+						},
+						Config: &container.Config{},
+					},
+				},
+				ServiceSpec: oldSpec,
+			},
+		},
+	}
+
+	update, ops, err := reconcileGlobalContainer(containers, spec, "service-id", "m1", false)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+	require.NotNil(t, update)
+	ops = update.Operations
+	require.Len(t, ops, 3)
+
+	// The old container must be stopped before running a new one as they bind to the same host ports.
+	assert.IsType(t, &StopContainerOperation{}, ops[0])
+	assert.IsType(t, &RunContainerOperation{}, ops[1])
+	assert.IsType(t, &RemoveContainerOperation{}, ops[2])
+}
+
+func TestRollingStrategy_Plan_UpToDate(t *testing.T) {
+	t.Parallel()
+
+	state := &scheduler.ClusterState{
+		Machines: []*scheduler.Machine{{Info: &pb.MachineInfo{Id: "m1", Name: "m1"}}},
+	}
+	spec := api.ServiceSpec{
+		Name:     "web",
+		Mode:     api.ServiceModeReplicated,
+		Replicas: 2,
+		Container: api.ContainerSpec{
+			Image:      "web:1",
+			PullPolicy: api.PullPolicyNever,
+		},
+	}
+
+	newService := func(images ...string) *api.Service {
+		svc := &api.Service{ID: "svc-web", Name: "web", Mode: api.ServiceModeReplicated}
+		for i, image := range images {
+			ctrSpec := spec.Clone()
+			ctrSpec.Container.Image = image
+			ctr := runningContainer(string(rune('a'+i)), "web", api.PriorityNormal, 0)
+			ctr.ServiceSpec = ctrSpec
+			svc.Containers = append(svc.Containers, api.MachineServiceContainer{MachineID: "m1", Container: ctr})
+		}
+		return svc
+	}
+
+	t.Run("matching specs", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: state}
+
+		plan, err := s.Plan(t.Context(), nil, newService("web:1", "web:1"), spec)
+		require.NoError(t, err)
+		assert.Equal(t, "svc-web", plan.ServiceID)
+		assert.Empty(t, plan.Operations)
+	})
+
+	t.Run("force recreate", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: state, ForceRecreate: true}
+
+		plan, err := s.Plan(t.Context(), nil, newService("web:1", "web:1"), spec)
+		require.NoError(t, err)
+		assert.NotEmpty(t, plan.Operations)
+	})
+
+	t.Run("changed metadata", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: state}
+		newSpec := spec.Clone()
+		newSpec.Aliases = []string{"www"}
+
+		plan, err := s.Plan(t.Context(), nil, newService("web:1", "web:1"), newSpec)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 2)
+		for _, op := range plan.Operations {
+			update, ok := op.(*UpdateContainerSpecOperation)
+			require.True(t, ok, "metadata must be updated in place: %s", op)
+			assert.Equal(t, []string{"www"}, update.Spec.Aliases)
+			assert.Equal(t, "web:1", update.Spec.Container.Image)
+		}
+	})
+
+	t.Run("outdated spec", func(t *testing.T) {
+		t.Parallel()
+		upToDate, err := containersUpToDate(newService("web:1", "web:0"), spec)
+		require.NoError(t, err)
+		assert.False(t, upToDate)
+	})
+
+	t.Run("different replicas", func(t *testing.T) {
+		t.Parallel()
+		upToDate, err := containersUpToDate(newService("web:1"), spec)
+		require.NoError(t, err)
+		assert.False(t, upToDate)
+	})
+}
+
+func TestRollingStrategy_Plan_CordonedMachine(t *testing.T) {
+	t.Parallel()
+
+	state := &scheduler.ClusterState{
+		Machines: []*scheduler.Machine{
+			{Info: &pb.MachineInfo{Id: "m1", Name: "m1"}},
+			{Info: &pb.MachineInfo{Id: "m2", Name: "m2", Cordoned: true}},
+		},
+	}
+	spec := api.ServiceSpec{
+		Name:     "web",
+		Mode:     api.ServiceModeReplicated,
+		Replicas: 2,
+		Container: api.ContainerSpec{
+			Image:      "web:1",
+			PullPolicy: api.PullPolicyNever,
+		},
+	}
+
+	newService := func(image string) *api.Service {
+		svc := &api.Service{ID: "svc-web", Name: "web", Mode: api.ServiceModeReplicated}
+		for i, machineID := range []string{"m1", "m2"} {
+			ctrSpec := spec.Clone()
+			ctrSpec.Container.Image = image
+			ctr := runningContainer(string(rune('a'+i)), "web", api.PriorityNormal, 0)
+			ctr.ServiceSpec = ctrSpec
+			svc.Containers = append(svc.Containers, api.MachineServiceContainer{MachineID: machineID, Container: ctr})
+		}
+		return svc
+	}
+
+	t.Run("keeps up-to-date container", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: state}
+		newSpec := spec.Clone()
+		newSpec.Replicas = 3
+
+		plan, err := s.Plan(t.Context(), nil, newService("web:1"), newSpec)
+		require.NoError(t, err)
+		for _, op := range plan.Operations {
+			switch o := op.(type) {
+			case *RunContainerOperation:
+				assert.Equal(t, "m1", o.MachineID, "no new containers must be placed on a cordoned machine")
+			case *RemoveContainerOperation:
+				assert.NotEqual(t, "m2", o.MachineID, "containers on a cordoned machine must be kept")
+			}
+		}
+	})
+
+	t.Run("removes outdated container", func(t *testing.T) {
+		t.Parallel()
+		s := &RollingStrategy{State: state}
+
+		plan, err := s.Plan(t.Context(), nil, newService("web:0"), spec)
+		require.NoError(t, err)
+
+		var removedOnM2 bool
+		for _, op := range plan.Operations {
+			switch o := op.(type) {
+			case *RunContainerOperation:
+				assert.Equal(t, "m1", o.MachineID, "no new containers must be placed on a cordoned machine")
+			case *RemoveContainerOperation:
+				removedOnM2 = removedOnM2 || o.MachineID == "m2"
+			}
+		}
+		assert.True(t, removedOnM2)
+	})
+}
@@ -0,0 +1,294 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// monitorInterval is the interval at which a new container is checked while it's monitored after an update.
+const monitorInterval = time.Second
+
+// ContainerUpdateOperation replaces old service containers on a machine with a new one or runs a new container if
+// there are no old ones. It's a sequence of an optional stop of the old container, run of the new container, and
+// removal of the old containers, or a single restart of a container in place. It's the unit of a
+// RollingUpdateOperation that can be rolled back.
+type ContainerUpdateOperation struct {
+	SequenceOperation
+	// OldSpec is the service spec of the replaced running container used to restore it when the update is rolled back.
+	// Nil if no running container is replaced.
+	OldSpec *api.ServiceSpec
+	// Monitor is the time to monitor the new container after it's started before removing the old containers.
+	Monitor time.Duration
+
+	// executed is the number of operations that have been successfully executed.
+	executed int
+}
+
+func (o *ContainerUpdateOperation) Execute(ctx context.Context, cli Client) error {
+	for _, op := range o.Operations {
+		if err := op.Execute(ctx, cli); err != nil {
+			return err
+		}
+		o.executed++
+
+		if o.Monitor == 0 {
+			continue
+		}
+		var serviceID, containerID string
+		switch op := op.(type) {
+		case *RunContainerOperation:
+			serviceID, containerID = op.ServiceID, op.containerID
+		case *RestartContainerOperation:
+			serviceID, containerID = op.ServiceID, op.ContainerID
+		default:
+			continue
+		}
+		if err := monitorContainer(ctx, cli, serviceID, containerID, o.Monitor); err != nil {
+			return fmt.Errorf("monitor container: %w", err)
+		}
+	}
+	return nil
+}
+
+// Rollback reverts the executed part of the update: it removes the new container and restores the old one by starting
+// it if it was only stopped or by running a container with the old spec if it was removed.
+func (o *ContainerUpdateOperation) Rollback(ctx context.Context, cli Client) error {
+	var (
+		run                *RunContainerOperation
+		oldID              string
+		stopped, removed   bool
+		serviceID, machine string
+	)
+	for i, op := range o.Operations {
+		// The operation at the index o.executed is the one that failed (if any), it could have partially executed.
+		attempted := i <= o.executed
+		switch op := op.(type) {
+		case *StopContainerOperation:
+			oldID, serviceID, machine = op.ContainerID, op.ServiceID, op.MachineID
+			stopped = stopped || attempted
+		case *RunContainerOperation:
+			run = op
+		case *RemoveContainerOperation:
+			if oldID == "" {
+				oldID, serviceID, machine = op.ContainerID, op.ServiceID, op.MachineID
+			}
+			if i < o.executed {
+				removed = true
+			} else if attempted {
+				// The container could have been stopped before the removal failed.
+				stopped = true
+			}
+		}
+	}
+
+	var errs []error
+	if run != nil && run.containerID != "" {
+		remove := &RemoveContainerOperation{
+			ServiceID:   run.ServiceID,
+			ContainerID: run.containerID,
+			MachineID:   run.MachineID,
+		}
+		if err := remove.Execute(ctx, cli); err != nil {
+			errs = append(errs, fmt.Errorf("remove new container: %w", err))
+		}
+	}
+
+	if o.OldSpec == nil || oldID == "" {
+		return errors.Join(errs...)
+	}
+	if removed {
+		restore := &RunContainerOperation{
+			ServiceID: serviceID,
+			Spec:      *o.OldSpec,
+			MachineID: machine,
+		}
+		if err := restore.Execute(ctx, cli); err != nil {
+			errs = append(errs, fmt.Errorf("run container with previous spec: %w", err))
+		}
+	} else if stopped {
+		if err := cli.StartContainer(ctx, serviceID, oldID); err != nil {
+			errs = append(errs, fmt.Errorf("start old container: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (o *ContainerUpdateOperation) String() string {
+	ops := make([]string, len(o.Operations))
+	for i, op := range o.Operations {
+		ops[i] = op.String()
+	}
+
+	return fmt.Sprintf("ContainerUpdateOperation[monitor=%s, operations=[%s]]", o.Monitor, strings.Join(ops, ", "))
+}
+
+// monitorContainer checks the container for the monitor duration and returns an error if it stops running or becomes
+// unhealthy.
+func monitorContainer(ctx context.Context, cli Client, serviceID, containerID string, monitor time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, monitor)
+	defer cancel()
+
+	ticker := time.NewTicker(min(monitorInterval, monitor))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil
+			}
+			return ctx.Err()
+		}
+
+		ctr, err := cli.InspectContainer(ctx, serviceID, containerID)
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil
+			}
+			return fmt.Errorf("inspect container: %w", err)
+		}
+		state := ctr.Container.State
+		if !state.Running {
+			return fmt.Errorf("container exited with code %d within %s after start", state.ExitCode, monitor)
+		}
+		if state.Health != nil && state.Health.Status == types.Unhealthy {
+			return fmt.Errorf("container became unhealthy within %s after start", monitor)
+		}
+	}
+}
+
+// RollingUpdateOperation executes container updates of a service in batches according to the update config.
+// Updates within a batch are executed concurrently. When the ratio of failed updates exceeds the configured maximum,
+// the failure action is taken: the remaining updates are skipped (pause), executed anyway (continue), or all executed
+// updates are reverted (rollback).
+type RollingUpdateOperation struct {
+	Config  api.UpdateConfig
+	Updates []*ContainerUpdateOperation
+}
+
+func (o *RollingUpdateOperation) Execute(ctx context.Context, cli Client) error {
+	total := len(o.Updates)
+	batchSize := o.Config.BatchSize(total)
+
+	var failures []error
+	exceeded := false
+	for start := 0; start < total; start += batchSize {
+		if start > 0 && o.Config.Delay > 0 {
+			select {
+			case <-time.After(o.Config.Delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		end := min(start+batchSize, total)
+		batch := o.Updates[start:end]
+		errs := make([]error, len(batch))
+		var wg sync.WaitGroup
+		for i, u := range batch {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = u.Execute(ctx, cli)
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				failures = append(failures, err)
+			}
+		}
+		if float64(len(failures))/float64(total) <= o.Config.MaxFailureRatio {
+			continue
+		}
+		exceeded = true
+
+		switch o.Config.FailureAction {
+		case api.UpdateFailureActionContinue:
+			continue
+		case api.UpdateFailureActionRollback:
+			return o.rollback(ctx, cli, o.Updates[:end], failures)
+		default:
+			return fmt.Errorf("update paused after %d of %d container updates failed: %w",
+				len(failures), total, errors.Join(failures...))
+		}
+	}
+
+	if exceeded {
+		return fmt.Errorf("%d of %d container updates failed: %w", len(failures), total, errors.Join(failures...))
+	}
+	return nil
+}
+
+// rollback reverts the executed updates in the reverse order.
+func (o *RollingUpdateOperation) rollback(
+	ctx context.Context, cli Client, executed []*ContainerUpdateOperation, failures []error,
+) error {
+	err := fmt.Errorf("%d of %d container updates failed: %w",
+		len(failures), len(o.Updates), errors.Join(failures...))
+
+	var rollbackErrs []error
+	for i := len(executed) - 1; i >= 0; i-- {
+		if rbErr := executed[i].Rollback(ctx, cli); rbErr != nil {
+			rollbackErrs = append(rollbackErrs, rbErr)
+		}
+	}
+	if len(rollbackErrs) > 0 {
+		return fmt.Errorf("%w; failed to roll back: %w", err, errors.Join(rollbackErrs...))
+	}
+	return fmt.Errorf("rolled back the update: %w", err)
+}
+
+func (o *RollingUpdateOperation) Format(resolver NameResolver) string {
+	lines := []string{"Rolling update " + o.formatConfig()}
+	for _, u := range o.Updates {
+		for _, op := range u.Operations {
+			lines = append(lines, "  - "+op.Format(resolver))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (o *RollingUpdateOperation) formatConfig() string {
+	order := o.Config.Order
+	if order == "" {
+		order = api.UpdateOrderStartFirst
+	}
+	failureAction := o.Config.FailureAction
+	if failureAction == "" {
+		failureAction = api.UpdateFailureActionPause
+	}
+
+	params := []string{fmt.Sprintf("parallelism=%d", o.Config.BatchSize(len(o.Updates)))}
+	if o.Config.Delay > 0 {
+		params = append(params, "delay="+o.Config.Delay.String())
+	}
+	if o.Config.Monitor > 0 {
+		params = append(params, "monitor="+o.Config.Monitor.String())
+	}
+	params = append(params, "order="+order, "failure_action="+failureAction)
+	if o.Config.MaxFailureRatio > 0 {
+		params = append(params, fmt.Sprintf("max_failure_ratio=%g", o.Config.MaxFailureRatio))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+func (o *RollingUpdateOperation) String() string {
+	updates := make([]string, len(o.Updates))
+	for i, u := range o.Updates {
+		updates[i] = u.String()
+	}
+
+	return fmt.Sprintf("RollingUpdateOperation[config=%s, updates=[%s]]",
+		o.formatConfig(), strings.Join(updates, ", "))
+}
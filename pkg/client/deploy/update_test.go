@@ -0,0 +1,153 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/distribution/reference"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/clienttest"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failNthCreate makes the nth creation of a container with the image fail.
+func failNthCreate(cli *clienttest.Client, image string, n int32) {
+	var created atomic.Int32
+	cli.On("CreateContainer", func(call clienttest.Call) error {
+		spec := call.Args[1].(api.ServiceSpec)
+		if spec.Container.Image == image && created.Add(1) == n {
+			return errors.New("image pull failed")
+		}
+		return nil
+	})
+}
+
+func newRollingUpdateTestCluster(t *testing.T) (*clienttest.Client, api.ServiceSpec) {
+	cli := clienttest.New()
+	cli.AddMachine("m1")
+	cli.AddMachine("m2")
+	cli.AddMachine("m3")
+
+	spec := api.ServiceSpec{
+		Name:      "web",
+		Mode:      api.ServiceModeReplicated,
+		Replicas:  3,
+		Container: api.ContainerSpec{Image: "nginx:1"},
+	}
+	_, err := cli.AddService(spec, "m1", "m2", "m3")
+	require.NoError(t, err)
+
+	spec.Container.Image = "nginx:2"
+	return cli, spec
+}
+
+func serviceImages(t *testing.T, cli *clienttest.Client) map[string]int {
+	svc, err := cli.InspectService(context.Background(), "web")
+	require.NoError(t, err)
+
+	images := make(map[string]int)
+	for _, c := range svc.Containers {
+		assert.True(t, c.Container.State.Running)
+		images[c.Container.ServiceSpec.Container.Image]++
+	}
+	return images
+}
+
+func TestRollingUpdate_Parallelism(t *testing.T) {
+	t.Parallel()
+
+	cli, spec := newRollingUpdateTestCluster(t)
+	parallelism := uint(2)
+	spec.UpdateConfig = &api.UpdateConfig{Parallelism: &parallelism}
+
+	d := deploy.NewDeployment(cli, spec, nil)
+	plan, err := d.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan.Operations, 1)
+	update, ok := plan.Operations[0].(*deploy.RollingUpdateOperation)
+	require.True(t, ok)
+	assert.Len(t, update.Updates, 3)
+
+	_, err = d.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"nginx:2": 3}, serviceImages(t, cli))
+}
+
+func TestRollingUpdate_RollbackStopFirst(t *testing.T) {
+	t.Parallel()
+
+	cli, spec := newRollingUpdateTestCluster(t)
+	spec.UpdateConfig = &api.UpdateConfig{
+		FailureAction: api.UpdateFailureActionRollback,
+		Order:         api.UpdateOrderStopFirst,
+	}
+	failNthCreate(cli, "nginx:2", 2)
+
+	_, err := deploy.NewDeployment(cli, spec, nil).Run(context.Background())
+	require.ErrorContains(t, err, "rolled back the update")
+
+	// The updated container is replaced with the previous spec and the stopped one is started again.
+	assert.Equal(t, map[string]int{"nginx:1": 3}, serviceImages(t, cli))
+}
+
+func TestRollingUpdate_MaxFailureRatio(t *testing.T) {
+	t.Parallel()
+
+	cli, spec := newRollingUpdateTestCluster(t)
+	spec.UpdateConfig = &api.UpdateConfig{MaxFailureRatio: 0.5}
+	failNthCreate(cli, "nginx:2", 2)
+
+	// One of three failed updates is tolerated and the old container keeps running.
+	_, err := deploy.NewDeployment(cli, spec, nil).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"nginx:1": 1, "nginx:2": 2}, serviceImages(t, cli))
+}
+
+func TestRollingUpdate_Pause(t *testing.T) {
+	t.Parallel()
+
+	cli, spec := newRollingUpdateTestCluster(t)
+	spec.UpdateConfig = &api.UpdateConfig{}
+	failNthCreate(cli, "nginx:2", 1)
+
+	_, err := deploy.NewDeployment(cli, spec, nil).Run(context.Background())
+	require.ErrorContains(t, err, "update paused")
+	assert.Equal(t, map[string]int{"nginx:1": 3}, serviceImages(t, cli))
+	assert.Len(t, cli.Calls("CreateContainer"), 1, "remaining updates must be skipped")
+}
+
+func TestRollingUpdate_PinsImageDigest(t *testing.T) {
+	t.Parallel()
+
+	cli, spec := newRollingUpdateTestCluster(t)
+	named, err := reference.ParseNormalizedNamed(
+		"nginx:2@sha256:0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	cli.SetRemoteImage("nginx:2", api.RemoteImage{Reference: named.(reference.Canonical)})
+
+	_, err = deploy.NewDeployment(cli, spec, nil).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"nginx:2@sha256:0000000000000000000000000000000000000000000000000000000000000001": 3,
+	}, serviceImages(t, cli))
+
+	// Redeploying the same tag that still points to the same digest doesn't recreate the containers.
+	plan, err := deploy.NewDeployment(cli, spec, nil).Plan(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, plan.Operations)
+}
+
+func TestOperationImages(t *testing.T) {
+	t.Parallel()
+
+	cli, spec := newRollingUpdateTestCluster(t)
+	plan, err := deploy.NewDeployment(cli, spec, nil).Plan(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"nginx:2"}, deploy.OperationImages(&plan))
+	assert.Empty(t, deploy.OperationImages(&deploy.SequenceOperation{}))
+}
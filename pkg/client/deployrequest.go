@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateDeployRequest stores a deployment in the cluster that waits for another operator to approve it.
+// It returns the stored request with the generated ID.
+func (cli *Client) CreateDeployRequest(ctx context.Context, r api.DeployRequest) (api.DeployRequest, error) {
+	rBytes, err := json.Marshal(r)
+	if err != nil {
+		return r, fmt.Errorf("marshal deploy request: %w", err)
+	}
+	resp, err := cli.ClusterClient.CreateDeployRequest(ctx, &pb.DeployRequest{Request: rBytes})
+	if err != nil {
+		return r, err
+	}
+	return deployRequestFromProto(resp)
+}
+
+// ListDeployRequests returns the pending and recently reviewed deploy requests sorted by creation time.
+func (cli *Client) ListDeployRequests(ctx context.Context) ([]api.DeployRequest, error) {
+	resp, err := cli.ClusterClient.ListDeployRequests(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []api.DeployRequest
+	if err = json.Unmarshal(resp.Requests, &requests); err != nil {
+		return nil, fmt.Errorf("unmarshal deploy requests: %w", err)
+	}
+	return requests, nil
+}
+
+// DeployRequest returns the deploy request with the given ID or ErrNotFound if it doesn't exist.
+func (cli *Client) DeployRequest(ctx context.Context, id string) (api.DeployRequest, error) {
+	requests, err := cli.ListDeployRequests(ctx)
+	if err != nil {
+		return api.DeployRequest{}, err
+	}
+	for _, r := range requests {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return api.DeployRequest{}, api.ErrNotFound
+}
+
+// ReviewDeployRequest approves or rejects a pending deploy request on behalf of the reviewer and returns
+// the reviewed request. It returns ErrNotFound if the request doesn't exist.
+func (cli *Client) ReviewDeployRequest(
+	ctx context.Context, id string, approve bool, reviewer string,
+) (api.DeployRequest, error) {
+	resp, err := cli.ClusterClient.ReviewDeployRequest(ctx, &pb.ReviewDeployRequestRequest{
+		Id:       id,
+		Approve:  approve,
+		Reviewer: reviewer,
+	})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.DeployRequest{}, api.ErrNotFound
+		}
+		return api.DeployRequest{}, err
+	}
+	return deployRequestFromProto(resp)
+}
+
+func deployRequestFromProto(resp *pb.DeployRequest) (api.DeployRequest, error) {
+	var r api.DeployRequest
+	if err := json.Unmarshal(resp.Request, &r); err != nil {
+		return r, fmt.Errorf("unmarshal deploy request: %w", err)
+	}
+	return r, nil
+}
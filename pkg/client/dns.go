@@ -31,6 +31,28 @@ func (cli *Client) GetDomain(ctx context.Context) (string, error) {
 	return domain.Name, nil
 }
 
+// ServiceDomain returns the domain for the service hostnames: the reserved cluster domain or the default domain
+// from the cluster settings. It returns an empty string if neither is set.
+func (cli *Client) ServiceDomain(ctx context.Context) (string, error) {
+	domain, err := cli.GetDomain(ctx)
+	if err == nil {
+		return domain, nil
+	}
+	if !errors.Is(err, api.ErrNotFound) {
+		return "", err
+	}
+
+	settings, err := cli.GetSettings(ctx)
+	if err != nil {
+		// Older daemons don't support cluster settings.
+		if status.Code(err) == codes.Unimplemented {
+			return "", nil
+		}
+		return "", fmt.Errorf("get cluster settings: %w", err)
+	}
+	return settings.Domain, nil
+}
+
 var ErrNoReachableMachines = errors.New("no internet-reachable machines running service containers")
 
 // CreateIngressRecords verifies which machines running the specified service (typically Caddy) are reachable from
@@ -38,6 +60,32 @@ var ErrNoReachableMachines = errors.New("no internet-reachable machines running
 // by sending HTTP requests to their public IPs. Only machines that respond correctly with their machine ID are included
 // in the resulting DNS configuration. Returns the created DNS records or an error.
 func (cli *Client) CreateIngressRecords(ctx context.Context, serviceID string) ([]*pb.DNSRecord, error) {
+	ingressIPs, err := cli.IngressIPs(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.CreateDomainRecordsRequest{
+		Records: []*pb.DNSRecord{
+			{
+				Name:   "*",
+				Type:   pb.DNSRecord_A,
+				Values: ingressIPs,
+			},
+			// TODO: Add AAAA record with routable IPv6 addresses of machines running Caddy containers.
+		},
+	}
+	resp, err := cli.CreateDomainRecords(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("create cluster domain records in Uncloud DNS: %w", err)
+	}
+
+	return resp.Records, nil
+}
+
+// IngressIPs returns the public IPs of the machines running the specified service (typically Caddy) that are
+// reachable from the internet. It returns ErrNoReachableMachines if none of them are reachable.
+func (cli *Client) IngressIPs(ctx context.Context, serviceID string) ([]string, error) {
 	svc, err := cli.InspectService(ctx, serviceID)
 	if err != nil {
 		return nil, fmt.Errorf("inspect service '%s': %w", serviceID, err)
@@ -85,22 +133,7 @@ func (cli *Client) CreateIngressRecords(ctx context.Context, serviceID string) (
 		return nil, ErrNoReachableMachines
 	}
 
-	req := &pb.CreateDomainRecordsRequest{
-		Records: []*pb.DNSRecord{
-			{
-				Name:   "*",
-				Type:   pb.DNSRecord_A,
-				Values: ingressIPs,
-			},
-			// TODO: Add AAAA record with routable IPv6 addresses of machines running Caddy containers.
-		},
-	}
-	resp, err := cli.CreateDomainRecords(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("create cluster domain records in Uncloud DNS: %w", err)
-	}
-
-	return resp.Records, nil
+	return ingressIPs, nil
 }
 
 // verifyCaddyReachable verifies that the Caddy service is reachable on the machine by its public IP.
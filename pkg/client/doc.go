@@ -0,0 +1,27 @@
+// Package client provides a Go SDK for managing an Uncloud cluster. It's used by the uc CLI and can be embedded
+// in other tools to deploy and operate services without importing internal packages.
+//
+// Connect to a cluster by creating a Client with one of the connectors from the connector package, for example,
+// connector.NewSSHConnector for a machine reachable over SSH.
+//
+// Code that depends on the api.Client interface instead of *Client can be unit tested without a live cluster using
+// the in-memory fake from the clienttest package.
+//
+// # Stability
+//
+// The following high-level methods of Client follow semantic versioning and are not changed in a backward
+// incompatible way within a major version:
+//
+//   - Deploy, Scale, and NewDeployment for creating and updating services.
+//   - RunService, InspectService, ListServices, and RemoveService for managing services.
+//   - ServiceLogs for streaming service logs.
+//   - ExecContainer for running commands in service containers.
+//   - Machines and Machine for inspecting cluster machines.
+//   - CreateVolume, ListVolumes, and RemoveVolume for managing volumes.
+//
+// The options and result types of these methods in the api package have the same guarantees. New fields may be
+// added to them, so initialise them with field names.
+//
+// The embedded gRPC clients (MachineClient, ClusterClient), the Caddy and Docker fields, and methods that accept
+// or return types from internal packages are low-level APIs that may change in any release.
+package client
@@ -0,0 +1,112 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/connector"
+)
+
+func connect(ctx context.Context) *client.Client {
+	conn := connector.NewSSHConnector(&connector.SSHConnectorConfig{
+		User:    "root",
+		Host:    "203.0.113.10",
+		Port:    22,
+		KeyPath: "~/.ssh/id_ed25519",
+	})
+	cli, err := client.New(ctx, conn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return cli
+}
+
+func ExampleClient_Deploy() {
+	ctx := context.Background()
+	cli := connect(ctx)
+	defer cli.Close()
+
+	spec := api.ServiceSpec{
+		Name:     "web",
+		Mode:     api.ServiceModeReplicated,
+		Replicas: 2,
+		Container: api.ContainerSpec{
+			Image: "nginx:1.27",
+		},
+	}
+	plan, err := cli.Deploy(ctx, spec, client.DeployOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Deployed service %s (%s)\n", plan.ServiceName, plan.ServiceID)
+}
+
+func ExampleClient_Scale() {
+	ctx := context.Background()
+	cli := connect(ctx)
+	defer cli.Close()
+
+	// Preview the operations required to scale the service without executing them.
+	plan, err := cli.Scale(ctx, "web", 3, client.DeployOptions{DryRun: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Scaling requires %d operations\n", len(plan.Operations))
+}
+
+func ExampleClient_ServiceLogs() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli := connect(ctx)
+	defer cli.Close()
+
+	logs, err := cli.ServiceLogs(ctx, "web", api.ServiceLogsOptions{Follow: true, Tail: "100"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for entry := range logs {
+		if entry.Err != nil {
+			log.Printf("%s: %v", entry.ContainerName, entry.Err)
+			continue
+		}
+		fmt.Printf("%s | %s", entry.ContainerName, entry.Data)
+	}
+}
+
+func ExampleClient_ExecContainer() {
+	ctx := context.Background()
+	cli := connect(ctx)
+	defer cli.Close()
+
+	svc, err := cli.InspectService(ctx, "web")
+	if err != nil {
+		log.Fatal(err)
+	}
+	exitCode, err := cli.ExecContainer(ctx, svc.ID, svc.Containers[0].Container.ID, api.ExecOptions{
+		Cmd:    []string{"nginx", "-t"},
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Exit code:", exitCode)
+}
+
+func ExampleClient_Machines() {
+	ctx := context.Background()
+	cli := connect(ctx)
+	defer cli.Close()
+
+	machines, err := cli.Machines(ctx, &api.MachineFilter{Available: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, m := range machines {
+		fmt.Println(m.Name, m.State, m.ManagementIP)
+	}
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ExecContainer runs a command in the specified running container within the service. It streams the input and
+// output of the command according to the options and returns its exit code once it exits.
+func (cli *Client) ExecContainer(
+	ctx context.Context, serviceNameOrID, containerNameOrID string, opts api.ExecOptions,
+) (int, error) {
+	if len(opts.Cmd) == 0 {
+		return 0, fmt.Errorf("command not specified")
+	}
+
+	ctr, err := cli.InspectContainer(ctx, serviceNameOrID, containerNameOrID)
+	if err != nil {
+		return 0, err
+	}
+
+	machine, err := cli.InspectMachine(ctx, ctr.MachineID)
+	if err != nil {
+		return 0, fmt.Errorf("inspect machine '%s': %w", ctr.MachineID, err)
+	}
+	ctx = proxyToMachine(ctx, machine.Machine)
+
+	return cli.Docker.ExecContainer(ctx, ctr.Container.ID, opts)
+}
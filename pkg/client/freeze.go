@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DeployFreeze returns the deploy freeze of the cluster or ErrNotFound if the deploys aren't frozen.
+// The returned freeze may have already expired.
+func (cli *Client) DeployFreeze(ctx context.Context) (api.DeployFreeze, error) {
+	var f api.DeployFreeze
+	resp, err := cli.ClusterClient.GetDeployFreeze(ctx, &emptypb.Empty{})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return f, api.ErrNotFound
+		}
+		return f, err
+	}
+	if err = json.Unmarshal(resp.Freeze, &f); err != nil {
+		return f, fmt.Errorf("unmarshal deploy freeze: %w", err)
+	}
+	return f, nil
+}
+
+// SetDeployFreeze freezes the mutating deploy operations in the cluster replacing the existing freeze.
+func (cli *Client) SetDeployFreeze(ctx context.Context, f api.DeployFreeze) error {
+	fBytes, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("marshal deploy freeze: %w", err)
+	}
+	_, err = cli.ClusterClient.SetDeployFreeze(ctx, &pb.DeployFreeze{Freeze: fBytes})
+	return err
+}
+
+// RemoveDeployFreeze lifts the deploy freeze of the cluster. It returns ErrNotFound if the deploys aren't frozen.
+func (cli *Client) RemoveDeployFreeze(ctx context.Context) error {
+	_, err := cli.ClusterClient.RemoveDeployFreeze(ctx, &emptypb.Empty{})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// RecordFreezeOverride records a deploy operation that overrode the deploy freeze in the audit log of the cluster.
+func (cli *Client) RecordFreezeOverride(ctx context.Context, o api.FreezeOverride) error {
+	oBytes, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("marshal freeze override: %w", err)
+	}
+	_, err = cli.ClusterClient.RecordFreezeOverride(ctx, &pb.FreezeOverride{Override: oBytes})
+	return err
+}
+
+// ListFreezeOverrides returns the deploy operations that overrode a deploy freeze sorted by time.
+func (cli *Client) ListFreezeOverrides(ctx context.Context) ([]api.FreezeOverride, error) {
+	resp, err := cli.ClusterClient.ListFreezeOverrides(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []api.FreezeOverride
+	if err = json.Unmarshal(resp.Overrides, &overrides); err != nil {
+		return nil, fmt.Errorf("unmarshal freeze overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// CheckDeployFreeze returns an error if the deploys are frozen in the cluster unless the override reason is set.
+// An overridden freeze is recorded in the audit log of the cluster along with the operation and reason.
+// The operation must be run with the returned context that marks its requests with the override as the machines
+// reject creating service containers during a freeze otherwise.
+func (cli *Client) CheckDeployFreeze(ctx context.Context, operation, overrideReason string) (context.Context, error) {
+	f, err := cli.DeployFreeze(ctx)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return ctx, nil
+		}
+		return ctx, fmt.Errorf("get deploy freeze: %w", err)
+	}
+	if !f.Active(time.Now()) {
+		return ctx, nil
+	}
+
+	frozen := "deploys are frozen until " + f.Until.Local().Format(time.RFC1123)
+	if f.Reason != "" {
+		frozen += fmt.Sprintf(" (%s)", f.Reason)
+	}
+	if strings.TrimSpace(overrideReason) == "" {
+		return ctx, fmt.Errorf("%s. Lift the freeze with 'uc cluster unfreeze' or override it with "+
+			"'--override-freeze REASON', which is recorded in the audit log", frozen)
+	}
+
+	err = cli.RecordFreezeOverride(ctx, api.FreezeOverride{
+		Operation:    operation,
+		Reason:       overrideReason,
+		User:         LocalUser(),
+		FreezeUntil:  f.Until,
+		FreezeReason: f.Reason,
+		Time:         time.Now().UTC(),
+	})
+	if err != nil {
+		return ctx, fmt.Errorf("record freeze override: %w", err)
+	}
+	PrintWarning(fmt.Sprintf("%s, overriding the freeze: %s", frozen, overrideReason))
+	return metadata.AppendToOutgoingContext(ctx, api.FreezeOverrideMetadataKey, "true"), nil
+}
+
+// LocalUser returns the name of the local user and host running the client in the format "user@host".
+func LocalUser() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		name += "@" + host
+	}
+	return name
+}
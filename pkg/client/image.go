@@ -2,8 +2,16 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sync"
 
+	"github.com/docker/compose/v2/pkg/progress"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/bwlimit"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/pkg/api"
 )
 
@@ -19,3 +27,280 @@ func (cli *Client) InspectImage(ctx context.Context, id string) ([]api.MachineIm
 func (cli *Client) InspectRemoteImage(ctx context.Context, id string) ([]api.MachineRemoteImage, error) {
 	return cli.Docker.InspectRemoteImage(ctx, id)
 }
+
+// MirrorImageOptions configures how MirrorImage distributes an image to the machines.
+type MirrorImageOptions struct {
+	// Machines are the names or IDs of the machines to mirror the image to. All available machines if empty.
+	Machines []string
+	// Source is the name or ID of the machine that fetches the image if none of the machines has it.
+	// Default is the first target machine.
+	Source string
+	// Local is the local Docker client to upload the image from instead of pulling it from the registry
+	// on the source machine, e.g. for air-gapped clusters without access to the registry.
+	Local *dockerclient.Client
+	// BandwidthLimit is the maximum number of bytes per second to upload the image from the local Docker at.
+	// Unlimited if 0.
+	BandwidthLimit int64
+	// BestEffort reports the target machines the image failed to be copied to as warnings instead of failing.
+	// It's useful when the target machines are able to pull the image from the registry themselves.
+	BestEffort bool
+}
+
+// MirrorImageResult describes how the image was distributed to the machines.
+type MirrorImageResult struct {
+	// Source is the name of the machine that had or fetched the image first. The other machines copy the image
+	// from the nearest machine that already has it.
+	Source string
+	// Fetched is true if the source machine pulled the image from the registry or received it from the local
+	// Docker, false if it already had the image.
+	Fetched bool
+	// Copied are the names of the machines the image was copied to.
+	Copied []string
+}
+
+// MirrorImage makes the image available on the target machines fetching it from the registry or the local Docker
+// at most once. The image is copied to the target machines missing it from the nearest machine that already has it
+// directly over the cluster network transferring only the layers missing on the target. If none of the machines has
+// the image, the source machine fetches it first.
+func (cli *Client) MirrorImage(ctx context.Context, image string, opts MirrorImageOptions) (MirrorImageResult, error) {
+	var result MirrorImageResult
+
+	machines, err := cli.ListMachines(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("list machines: %w", err)
+	}
+	var available api.MachineMembersList
+	for _, m := range machines {
+		if m.State != pb.MachineMember_DOWN {
+			available = append(available, m)
+		}
+	}
+	if len(available) == 0 {
+		return result, errors.New("no available machines")
+	}
+
+	targets := available
+	if len(opts.Machines) > 0 {
+		targets = nil
+		for _, nameOrID := range opts.Machines {
+			m := machines.FindByNameOrID(nameOrID)
+			if m == nil {
+				return result, fmt.Errorf("machine '%s' not found", nameOrID)
+			}
+			if m.State == pb.MachineMember_DOWN {
+				return result, fmt.Errorf("machine '%s' is down", m.Machine.Name)
+			}
+			targets = append(targets, m)
+		}
+	}
+
+	have, err := cli.machinesWithImage(ctx, image, available)
+	if err != nil {
+		return result, err
+	}
+
+	source, fetch, err := mirrorSource(opts.Source, targets, available, have)
+	if err != nil {
+		return result, err
+	}
+	if fetch {
+		if err = cli.fetchImage(ctx, image, source, opts.Local, opts.BandwidthLimit); err != nil {
+			return result, err
+		}
+		result.Fetched = true
+	}
+	result.Source = source.Machine.Name
+	have[source.Machine.Id] = true
+
+	// The machines that have the image serve as the sources for the rest of the targets that copy it from
+	// the nearest source. The targets copy the image in waves of at most as many targets as there are sources
+	// so that the number of sources grows with each wave.
+	var sources, pending []*pb.MachineMember
+	for _, m := range available {
+		if have[m.Machine.Id] {
+			sources = append(sources, m)
+		}
+	}
+	for _, m := range targets {
+		if !have[m.Machine.Id] {
+			pending = append(pending, m)
+		}
+	}
+	for len(pending) > 0 {
+		sourceIPs := make([]netip.Addr, len(sources))
+		for i, m := range sources {
+			if sourceIPs[i], err = m.Machine.Network.ManagementIp.ToAddr(); err != nil {
+				return result, fmt.Errorf("parse management IP of machine '%s': %w", m.Machine.Name, err)
+			}
+		}
+
+		wave := pending[:min(len(sources), len(pending))]
+		pending = pending[len(wave):]
+		errs := make([]error, len(wave))
+		var wg sync.WaitGroup
+		for i, m := range wave {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = cli.copyImage(ctx, image, m, sourceIPs, available, opts.BestEffort)
+			}()
+		}
+		wg.Wait()
+
+		for i, m := range wave {
+			if errs[i] == nil {
+				sources = append(sources, m)
+				result.Copied = append(result.Copied, m.Machine.Name)
+			}
+		}
+		if err = errors.Join(errs...); err != nil && !opts.BestEffort {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// mirrorSource returns the machine the image is mirrored from and whether the image must be fetched to it first
+// because none of the available machines have it. The requested source machine is used only if it has the image or
+// no machine has it. Otherwise, a target machine that has the image is preferred to not transfer it to other machines.
+func mirrorSource(
+	requested string, targets, available api.MachineMembersList, have map[string]bool,
+) (*pb.MachineMember, bool, error) {
+	var source *pb.MachineMember
+	if requested != "" {
+		if source = available.FindByNameOrID(requested); source == nil {
+			return nil, false, fmt.Errorf("source machine '%s' not found or down", requested)
+		}
+	}
+	if len(have) == 0 {
+		if source == nil {
+			source = targets[0]
+		}
+		return source, true, nil
+	}
+
+	if source != nil && have[source.Machine.Id] {
+		return source, false, nil
+	}
+	for _, m := range slices.Concat(targets, available) {
+		if have[m.Machine.Id] {
+			return m, false, nil
+		}
+	}
+	return nil, false, errors.New("none of the available machines have the image")
+}
+
+// copyImage copies the image to the target machine from the nearest of the source machines transferring only
+// the layers missing on the target. A failure is reported as a warning if bestEffort is true.
+func (cli *Client) copyImage(
+	ctx context.Context, image string, target *pb.MachineMember, sourceIPs []netip.Addr,
+	machines api.MachineMembersList, bestEffort bool,
+) error {
+	pw := progress.ContextWriter(ctx)
+	eventID := fmt.Sprintf("Image %s on %s", image, target.Machine.Name)
+	pw.Event(progress.Event{
+		ID:         eventID,
+		Status:     progress.Working,
+		StatusText: "Copying from nearest machine",
+	})
+
+	resp, err := cli.Docker.MirrorImage(proxyToMachine(ctx, target.Machine), image, sourceIPs)
+	if err != nil {
+		if bestEffort {
+			pw.Event(progress.Event{
+				ID:         eventID,
+				Status:     progress.Warning,
+				StatusText: "Not copied, will pull from registry",
+			})
+		} else {
+			pw.Event(progress.ErrorEvent(eventID))
+		}
+		return fmt.Errorf("copy image '%s' to machine '%s': %w", image, target.Machine.Name, err)
+	}
+
+	statusText := "Copied"
+	if sourceIP, err := resp.SourceMachineIp.ToAddr(); err == nil {
+		if m := machines.FindByManagementIP(sourceIP.String()); m != nil {
+			statusText += " from " + m.Machine.Name
+		}
+	}
+	if total := resp.TransferredLayers + resp.PresentLayers; total > 0 {
+		statusText += fmt.Sprintf(" (%d of %d layers transferred)", resp.TransferredLayers, total)
+	}
+	pw.Event(progress.Event{ID: eventID, Status: progress.Done, StatusText: statusText})
+	return nil
+}
+
+// SeedImage makes the image available on the machines pulling it from the registry only once on one of them.
+// The rest of the machines copy the image from the nearest machine that already has it over the cluster network
+// to reduce the registry egress and speed up the rollout. The machines the image failed to be copied to are left
+// to pull it from the registry themselves when creating containers.
+func (cli *Client) SeedImage(ctx context.Context, image string, machineIDs []string) error {
+	_, err := cli.MirrorImage(ctx, image, MirrorImageOptions{Machines: machineIDs, BestEffort: true})
+	return err
+}
+
+// machinesWithImage returns the IDs of the machines that have the image in their local image stores.
+func (cli *Client) machinesWithImage(
+	ctx context.Context, image string, machines api.MachineMembersList,
+) (map[string]bool, error) {
+	ids := make([]string, len(machines))
+	for i, m := range machines {
+		ids[i] = m.Machine.Id
+	}
+	inspectCtx, proxied, err := api.ProxyMachinesContext(ctx, cli, ids)
+	if err != nil {
+		return nil, fmt.Errorf("create request context to broadcast to machines: %w", err)
+	}
+
+	have := make(map[string]bool)
+	images, err := cli.Docker.InspectImage(inspectCtx, image)
+	if err != nil {
+		if dockerclient.IsErrNotFound(err) {
+			return have, nil
+		}
+		return nil, fmt.Errorf("inspect image '%s': %w", image, err)
+	}
+	for _, img := range images {
+		md := img.Metadata
+		if md != nil && md.Error != "" {
+			continue
+		}
+		m := proxied[0]
+		if md != nil && md.Machine != "" {
+			if m = proxied.FindByManagementIP(md.Machine); m == nil {
+				continue
+			}
+		}
+		have[m.Machine.Id] = true
+	}
+	return have, nil
+}
+
+// fetchImage pulls the image from the registry on the machine or uploads it from the local Docker if provided
+// at no more than bwLimit bytes per second.
+func (cli *Client) fetchImage(
+	ctx context.Context, image string, machine *pb.MachineMember, local *dockerclient.Client, bwLimit int64,
+) error {
+	ctx = proxyToMachine(ctx, machine.Machine)
+	if local == nil {
+		return cli.pullImageWithProgress(ctx, image, machine.Machine.Name, "")
+	}
+
+	pw := progress.ContextWriter(ctx)
+	eventID := fmt.Sprintf("Image %s on %s", image, machine.Machine.Name)
+	pw.Event(progress.Event{ID: eventID, Status: progress.Working, StatusText: "Uploading from local Docker"})
+
+	r, err := local.ImageSave(ctx, []string{image})
+	if err == nil {
+		err = cli.Docker.LoadImage(ctx, bwlimit.NewReader(ctx, r, bwLimit))
+		r.Close()
+	}
+	if err != nil {
+		pw.Event(progress.ErrorEvent(eventID))
+		return fmt.Errorf("upload image '%s' to machine '%s': %w", image, machine.Machine.Name, err)
+	}
+	pw.Event(progress.Event{ID: eventID, Status: progress.Done, StatusText: "Uploaded"})
+	return nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorSource(t *testing.T) {
+	t.Parallel()
+
+	member := func(name string) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{Id: name + "-id", Name: name}}
+	}
+	m1, m2, m3 := member("m1"), member("m2"), member("m3")
+	available := api.MachineMembersList{m1, m2, m3}
+
+	tests := []struct {
+		name      string
+		requested string
+		targets   api.MachineMembersList
+		have      []string
+		want      string
+		wantFetch bool
+		wantErr   string
+	}{
+		{
+			name:      "requested source has image",
+			requested: "m3",
+			targets:   api.MachineMembersList{m1, m2},
+			have:      []string{"m2", "m3"},
+			want:      "m3",
+		},
+		{
+			name:      "requested source missing image falls back to target that has it",
+			requested: "m1",
+			targets:   api.MachineMembersList{m1, m2},
+			have:      []string{"m3", "m2"},
+			want:      "m2",
+		},
+		{
+			name:      "requested source missing image falls back to other machine that has it",
+			requested: "m1",
+			targets:   api.MachineMembersList{m1, m2},
+			have:      []string{"m3"},
+			want:      "m3",
+		},
+		{
+			name:    "target that has image preferred",
+			targets: api.MachineMembersList{m3, m2},
+			have:    []string{"m1", "m2"},
+			want:    "m2",
+		},
+		{
+			name:      "fetch to requested source if no machine has image",
+			requested: "m2",
+			targets:   available,
+			want:      "m2",
+			wantFetch: true,
+		},
+		{
+			name:      "fetch to first target if no machine has image",
+			targets:   api.MachineMembersList{m3, m1},
+			want:      "m3",
+			wantFetch: true,
+		},
+		{
+			name:      "requested source not available",
+			requested: "m4",
+			targets:   available,
+			wantErr:   "source machine 'm4' not found or down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			have := make(map[string]bool)
+			for _, name := range tt.have {
+				have[name+"-id"] = true
+			}
+			source, fetch, err := mirrorSource(tt.requested, tt.targets, available, have)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, source.Machine.Name)
+			assert.Equal(t, tt.wantFetch, fetch)
+		})
+	}
+}
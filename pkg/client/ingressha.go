@@ -0,0 +1,62 @@
+package client
+
+import (
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+)
+
+const (
+	KeepalivedServiceName = "keepalived"
+	// KeepalivedImage is the official Alpine image on Docker Hub that keepalived is installed in on start
+	// unless the image already includes it: https://hub.docker.com/_/alpine
+	KeepalivedImage = "alpine:3"
+	// keepalivedConfigDir is the directory on the machines where the machine daemon writes the keepalived
+	// configuration generated from the ingress HA policy.
+	keepalivedConfigDir = "/var/lib/uncloud/keepalived"
+)
+
+// keepalivedCommand waits for the machine daemon to write the configuration, installs keepalived if the image
+// doesn't include it, and runs keepalived in the foreground.
+const keepalivedCommand = `until [ -f /etc/keepalived/keepalived.conf ]; do sleep 1; done
+command -v keepalived >/dev/null || apk add --no-cache keepalived || exit 1
+exec keepalived --dont-fork --log-console --log-detail --use-file /etc/keepalived/keepalived.conf`
+
+// NewKeepalivedDeployment creates a new deployment for the keepalived service that moves the virtual IP of
+// the ingress HA policy between the policy machines. The service runs in the host network namespace of the machines
+// to manage the virtual IP on their network interface. If the image is not provided, KeepalivedImage is used.
+func (cli *Client) NewKeepalivedDeployment(image string, policy *api.IngressHAPolicy) *deploy.Deployment {
+	if image == "" {
+		image = KeepalivedImage
+	}
+	spec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			CapAdd:  []string{"NET_ADMIN", "NET_BROADCAST", "NET_RAW"},
+			Command: []string{"sh", "-c", keepalivedCommand},
+			Image:   image,
+			VolumeMounts: []api.VolumeMount{
+				{
+					VolumeName:    "config",
+					ContainerPath: "/etc/keepalived",
+				},
+			},
+		},
+		Mode:        api.ServiceModeGlobal,
+		Name:        KeepalivedServiceName,
+		NetworkMode: api.NetworkModeHost,
+		Placement: api.Placement{
+			Machines: policy.Machines,
+		},
+		Volumes: []api.VolumeSpec{
+			{
+				Name: "config",
+				Type: api.VolumeTypeBind,
+				BindOptions: &api.BindOptions{
+					HostPath:       keepalivedConfigDir,
+					CreateHostPath: true,
+				},
+			},
+		},
+	}
+
+	return cli.NewDeployment(spec, nil)
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ClusterNetwork returns the IP network of the cluster the machine subnets are allocated from.
+func (cli *Client) ClusterNetwork(ctx context.Context) (netip.Prefix, error) {
+	info, err := cli.ClusterClient.GetCluster(ctx, &emptypb.Empty{})
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if info.Network == nil {
+		return netip.Prefix{}, errors.New("cluster network not reported, the machine daemon may need to be upgraded")
+	}
+	return info.Network.ToPrefix()
+}
+
+// ListIPReservations returns the IP ranges reserved in the cluster network.
+func (cli *Client) ListIPReservations(ctx context.Context) ([]api.IPReservation, error) {
+	resp, err := cli.ClusterClient.ListIPReservations(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var reservations []api.IPReservation
+	if err = json.Unmarshal(resp.Reservations, &reservations); err != nil {
+		return nil, fmt.Errorf("unmarshal IP reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// ReserveIPRange reserves an IP range in the cluster network so it isn't allocated to new machines.
+func (cli *Client) ReserveIPRange(ctx context.Context, reservation api.IPReservation) error {
+	if err := reservation.Validate(); err != nil {
+		return err
+	}
+	rBytes, err := json.Marshal(reservation)
+	if err != nil {
+		return fmt.Errorf("marshal IP reservation: %w", err)
+	}
+	_, err = cli.ClusterClient.ReserveIPRange(ctx, &pb.IPReservation{Reservation: rBytes})
+	return err
+}
+
+// ReleaseIPRange removes the reservation of an IP range. It returns ErrNotFound if the range isn't reserved.
+func (cli *Client) ReleaseIPRange(ctx context.Context, prefix netip.Prefix) error {
+	_, err := cli.ClusterClient.ReleaseIPRange(ctx, &pb.ReleaseIPRangeRequest{Prefix: prefix.String()})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ServiceLogs streams the logs of all containers of the service. Log entries from different containers are
+// interleaved in the order they are received. The returned channel is closed when the log streams of all containers
+// end or the context is canceled. If streaming the logs of a container fails, the last entry for that container
+// contains the error.
+func (cli *Client) ServiceLogs(
+	ctx context.Context, serviceNameOrID string, opts api.ServiceLogsOptions,
+) (<-chan api.ServiceLogEntry, error) {
+	svc, err := cli.InspectService(ctx, serviceNameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect service: %w", err)
+	}
+	machines, err := cli.ListMachines(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+
+	logsOpts := container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Tail:       opts.Tail,
+		Since:      opts.Since,
+		Until:      opts.Until,
+		Timestamps: opts.Timestamps,
+	}
+
+	ch := make(chan api.ServiceLogEntry)
+	wg := sync.WaitGroup{}
+	for _, c := range svc.Containers {
+		entry := api.ServiceLogEntry{
+			MachineID:     c.MachineID,
+			ContainerID:   c.Container.ID,
+			ContainerName: c.Container.Name,
+		}
+		var logsCh <-chan api.ContainerLogEntry
+		if machine := machines.FindByNameOrID(c.MachineID); machine != nil {
+			entry.MachineName = machine.Machine.Name
+			// Proxy Docker gRPC requests to the machine running the container.
+			machineCtx := proxyToMachine(ctx, machine.Machine)
+			if logsCh, err = cli.Docker.ContainerLogs(machineCtx, c.Container.ID, logsOpts); err != nil {
+				entry.Err = fmt.Errorf("stream logs of container '%s': %w", c.Container.Name, err)
+			}
+		} else {
+			entry.Err = fmt.Errorf("machine '%s' not found", c.MachineID)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if logsCh == nil {
+				sendLogEntry(ctx, ch, entry)
+				return
+			}
+			for e := range logsCh {
+				entry.ContainerLogEntry = e
+				if !sendLogEntry(ctx, ch, entry) {
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// sendLogEntry sends the entry to the channel unless the context is canceled. It returns false if the context
+// is canceled.
+func sendLogEntry(ctx context.Context, ch chan<- api.ServiceLogEntry, entry api.ServiceLogEntry) bool {
+	select {
+	case ch <- entry:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
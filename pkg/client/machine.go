@@ -28,24 +28,43 @@ func (cli *Client) InspectMachine(ctx context.Context, nameOrID string) (*pb.Mac
 
 // ListMachines returns a list of all machines registered in the cluster that match the filter.
 func (cli *Client) ListMachines(ctx context.Context, filter *api.MachineFilter) (api.MachineMembersList, error) {
-	resp, err := cli.ClusterClient.ListMachines(ctx, &emptypb.Empty{})
-	if err != nil {
-		return nil, err
+	machines, _, err := cli.ListMachinesPage(ctx, filter, 0, "")
+	return machines, err
+}
+
+// ListMachinesPage returns at most pageSize machines sorted by name that match the filter and come after
+// the machine named pageToken, and the token for the next page. The next page token is empty if there are no more
+// machines. All matching machines are returned if pageSize is 0. The machines are filtered and paginated by
+// the cluster so only the requested page is transferred.
+func (cli *Client) ListMachinesPage(
+	ctx context.Context, filter *api.MachineFilter, pageSize int, pageToken string,
+) (api.MachineMembersList, string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, "", err
 	}
 
-	machines := resp.Machines
+	req := filter.Proto()
+	req.PageSize = int32(pageSize)
+	req.PageToken = pageToken
+	resp, err := cli.ClusterClient.ListMachines(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
 
+	machines := api.MachineMembersList(resp.Machines)
+	nextPageToken := resp.NextPageToken
+	// Older daemons ignore the filter and pagination and return all machines, so filter and paginate them here
+	// as well. This is a no-op for the machines that have already been filtered and paginated by the cluster.
 	if filter != nil {
-		var matchedMachines api.MachineMembersList
-		for _, m := range machines {
-			if MachineMatchesFilter(m, filter) {
-				matchedMachines = append(matchedMachines, m)
-			}
-		}
-		machines = matchedMachines
+		machines = slices.DeleteFunc(machines, func(m *pb.MachineMember) bool {
+			return !filter.Matches(m)
+		})
+	}
+	if nextPageToken == "" && (pageSize > 0 || pageToken != "") {
+		machines, nextPageToken = machines.Page(pageSize, pageToken)
 	}
 
-	return machines, nil
+	return machines, nextPageToken, nil
 }
 
 // UpdateMachine updates machine configuration in the cluster.
@@ -60,6 +79,84 @@ func (cli *Client) UpdateMachine(ctx context.Context, req *pb.UpdateMachineReque
 	return resp.Machine, nil
 }
 
+// MachineToken returns the token of the machine in the cluster that can be used for adding it to another cluster.
+func (cli *Client) MachineToken(ctx context.Context, nameOrID string) (string, error) {
+	machine, err := cli.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return "", err
+	}
+
+	ctx = proxyToMachine(ctx, machine.Machine)
+	resp, err := cli.MachineClient.Token(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+// RotateMachineToken generates a new key pair for the machine with the given name or ID and returns its new token.
+// If nameOrID is empty, the token of the machine the client is connected to is rotated, e.g. a machine that is not
+// yet a member of a cluster. Only the token of a machine that is not a cluster member can be rotated.
+func (cli *Client) RotateMachineToken(ctx context.Context, nameOrID string) (string, error) {
+	if nameOrID != "" {
+		machine, err := cli.InspectMachine(ctx, nameOrID)
+		if err != nil {
+			return "", err
+		}
+		ctx = proxyToMachine(ctx, machine.Machine)
+	}
+
+	resp, err := cli.MachineClient.RotateToken(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+// MachineLastBootReport returns the report of the state recovery performed by the machine daemon after the last
+// reboot of the machine with the given name or ID.
+func (cli *Client) MachineLastBootReport(ctx context.Context, nameOrID string) (api.BootReport, error) {
+	machine, err := cli.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return api.BootReport{}, err
+	}
+
+	ctx = proxyToMachine(ctx, machine.Machine)
+	resp, err := cli.MachineClient.LastBootReport(ctx, &emptypb.Empty{})
+	if err != nil {
+		return api.BootReport{}, err
+	}
+
+	report := api.BootReport{
+		BootID:      resp.BootId,
+		BootTime:    resp.BootTime.AsTime(),
+		RecoveredAt: resp.RecoveredAt.AsTime(),
+		Actions:     make([]api.RecoveryAction, len(resp.Actions)),
+	}
+	for i, a := range resp.Actions {
+		report.Actions[i] = api.RecoveryAction{
+			Resource: a.Resource,
+			Action:   a.Action,
+			Error:    a.Error,
+		}
+	}
+	return report, nil
+}
+
+// UpgradeMachine upgrades the machine daemon on the machine with the given name or ID to the version or the latest
+// release on the channel if the version is empty. The daemon restarts shortly after the response if upgraded.
+func (cli *Client) UpgradeMachine(
+	ctx context.Context, nameOrID, channel, version string,
+) (*pb.UpgradeResponse, error) {
+	machine, err := cli.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = proxyToMachine(ctx, machine.Machine)
+	return cli.MachineClient.Upgrade(ctx, &pb.UpgradeRequest{Channel: channel, Version: version})
+}
+
 // RenameMachine renames an existing machine in the cluster.
 func (cli *Client) RenameMachine(ctx context.Context, nameOrID, newName string) (*pb.MachineInfo, error) {
 	// First, resolve the machine to get its ID
@@ -77,22 +174,78 @@ func (cli *Client) RenameMachine(ctx context.Context, nameOrID, newName string)
 	return cli.UpdateMachine(ctx, req)
 }
 
-func MachineMatchesFilter(machine *pb.MachineMember, filter *api.MachineFilter) bool {
-	if filter == nil {
-		return true
+// Machines returns a list of all machines registered in the cluster that match the filter.
+func (cli *Client) Machines(ctx context.Context, filter *api.MachineFilter) ([]api.Machine, error) {
+	members, err := cli.ListMachines(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := make([]api.Machine, len(members))
+	for i, m := range members {
+		machines[i] = toMachine(m)
 	}
+	return machines, nil
+}
 
-	if filter.Available && machine.State == pb.MachineMember_DOWN {
-		return false
+// Machine returns the machine in the cluster with the given name or ID.
+func (cli *Client) Machine(ctx context.Context, nameOrID string) (api.Machine, error) {
+	member, err := cli.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return api.Machine{}, err
 	}
+	return toMachine(member), nil
+}
 
-	if len(filter.NamesOrIDs) > 0 {
-		if !slices.ContainsFunc(filter.NamesOrIDs, func(nameOrID string) bool {
-			return machine.Machine.Id == nameOrID || machine.Machine.Name == nameOrID
-		}) {
-			return false
+// toMachine converts a cluster machine member to the API machine type.
+func toMachine(m *pb.MachineMember) api.Machine {
+	machine := api.Machine{
+		ID:       m.Machine.Id,
+		Name:     m.Machine.Name,
+		State:    m.State.String(),
+		Cordoned: m.Machine.Cordoned,
+		Arch:     m.Machine.Arch,
+	}
+	if m.Machine.PublicIp != nil {
+		machine.PublicIP, _ = m.Machine.PublicIp.ToAddr()
+	}
+	if network := m.Machine.Network; network != nil {
+		if network.Subnet != nil {
+			machine.Subnet, _ = network.Subnet.ToPrefix()
+		}
+		if network.ManagementIp != nil {
+			machine.ManagementIP, _ = network.ManagementIp.ToAddr()
+		}
+		for _, ep := range network.Endpoints {
+			if addrPort, err := ep.ToAddrPort(); err == nil {
+				machine.Endpoints = append(machine.Endpoints, addrPort)
+			}
 		}
+		machine.DNSEndpoints = slices.Clone(network.DnsEndpoints)
+	}
+	machine.Resources = toMachineResources(m.Machine.Resources)
+	if c := m.Machine.Cost; c != nil {
+		machine.Cost = &api.MachineCost{HourlyPrice: c.HourlyPrice, Provider: c.Provider}
+	}
+	return machine
+}
+
+// toMachineResources converts the machine resources to the API type. It returns nil if r is nil.
+func toMachineResources(r *pb.MachineResources) *api.MachineResources {
+	if r == nil {
+		return nil
+	}
+	return &api.MachineResources{
+		CPUs:          int(r.Cpus),
+		Memory:        r.Memory,
+		Disk:          r.Disk,
+		Kernel:        r.Kernel,
+		OS:            r.Os,
+		DockerVersion: r.DockerVersion,
 	}
+}
 
-	return true
+// MachineMatchesFilter returns true if the machine matches all criteria of the filter.
+func MachineMatchesFilter(machine *pb.MachineMember, filter *api.MachineFilter) bool {
+	return filter.Matches(machine)
 }
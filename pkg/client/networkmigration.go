@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetNetworkMigration returns the cluster network migration in progress or ErrNotFound if there is none.
+func (cli *Client) GetNetworkMigration(ctx context.Context) (api.NetworkMigration, error) {
+	var m api.NetworkMigration
+	resp, err := cli.ClusterClient.GetNetworkMigration(ctx, &emptypb.Empty{})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return m, api.ErrNotFound
+		}
+		return m, err
+	}
+	if err = json.Unmarshal(resp.Migration, &m); err != nil {
+		return m, fmt.Errorf("unmarshal network migration: %w", err)
+	}
+	return m, nil
+}
+
+// SetNetworkMigration records the progress of the cluster network migration. Setting a completed migration changes
+// the cluster network to the new one.
+func (cli *Client) SetNetworkMigration(ctx context.Context, m api.NetworkMigration) error {
+	mBytes, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal network migration: %w", err)
+	}
+	_, err = cli.ClusterClient.SetNetworkMigration(ctx, &pb.NetworkMigration{Migration: mBytes})
+	return err
+}
+
+// RenumberMachineSubnet changes the subnet of the machine with the given name or ID as planned by the cluster
+// network migration in progress. The machine daemon restarts shortly after the response to apply the new subnet.
+func (cli *Client) RenumberMachineSubnet(ctx context.Context, nameOrID string, subnet netip.Prefix) error {
+	machine, err := cli.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	ctx = proxyToMachine(ctx, machine.Machine)
+	_, err = cli.MachineClient.RenumberSubnet(ctx, &pb.RenumberSubnetRequest{Subnet: pb.NewIPPrefix(subnet)})
+	return err
+}
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// postgresDataPath is the path in the Postgres containers where the data volume is mounted. The data directory
+	// is a subdirectory of the volume so the entrypoint can move it aside when the server rejoins as a standby.
+	postgresDataPath = "/var/lib/postgresql/data"
+	// postgresUID is the ID of the postgres user and group in the official Postgres image.
+	postgresUID = "999"
+	// postgresPasswordLength is the length of the generated passwords.
+	postgresPasswordLength = 32
+)
+
+// postgresEntrypoint starts the server of a managed Postgres cluster as the primary or a standby. The machine daemon
+// writes the ID of the primary machine from the store to a file in the mounted cluster directory and the server is
+// the primary if it's the ID of its own machine. A standby is cloned from the primary with pg_basebackup that also
+// configures it to stream from the primary. The old primary that is back after a failover moves its data aside and
+// rejoins as a standby. The moved data is never deleted automatically so it can be recovered if needed.
+const postgresEntrypoint = `#!/bin/sh
+set -eu
+
+until primary=$(cat "$PG_PRIMARY_MACHINE_FILE" 2>/dev/null) && [ -n "$primary" ]; do
+	echo "Waiting for the machine daemon to write the primary machine ID to $PG_PRIMARY_MACHINE_FILE..."
+	sleep 2
+done
+
+clone_primary() {
+	until pg_isready -q -h "$PG_PRIMARY_HOST"; do
+		echo "Waiting for the primary $PG_PRIMARY_HOST to accept connections..."
+		sleep 2
+	done
+	mkdir -p "$PGDATA"
+	chown postgres:postgres "$PGDATA"
+	chmod 700 "$PGDATA"
+	echo "Cloning the primary $PG_PRIMARY_HOST..."
+	PGPASSWORD="$(cat "$PG_REPLICATION_PASSWORD_FILE")" gosu postgres \
+		pg_basebackup -h "$PG_PRIMARY_HOST" -U replicator -D "$PGDATA" -R -X stream
+}
+
+if [ "$primary" != "$UNCLOUD_MACHINE_ID" ]; then
+	if [ ! -s "$PGDATA/PG_VERSION" ]; then
+		clone_primary
+	elif [ ! -f "$PGDATA/standby.signal" ]; then
+		old="$PGDATA.old-$(date -u +%Y%m%dT%H%M%SZ)"
+		echo "Machine $primary runs the primary, rejoining as a standby. The previous data is moved to $old."
+		mv "$PGDATA" "$old"
+		clone_primary
+	fi
+fi
+
+exec docker-entrypoint.sh postgres -c wal_keep_size=1GB
+`
+
+// postgresInitReplication creates the role the standby uses to replicate from the primary when the primary
+// database is initialised.
+const postgresInitReplication = `#!/bin/sh
+set -eu
+
+psql -v ON_ERROR_STOP=1 -U "$POSTGRES_USER" -d postgres \
+	-c "CREATE ROLE replicator WITH REPLICATION LOGIN PASSWORD '$(cat "$PG_REPLICATION_PASSWORD_FILE")'"
+echo "host replication replicator all scram-sha-256" >> "$PGDATA/pg_hba.conf"
+`
+
+// ListPostgresClusters returns the managed Postgres clusters ordered by name.
+func (cli *Client) ListPostgresClusters(ctx context.Context) ([]api.PostgresCluster, error) {
+	resp, err := cli.ClusterClient.ListPostgresClusters(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []api.PostgresCluster
+	if err = json.Unmarshal(resp.Clusters, &clusters); err != nil {
+		return nil, fmt.Errorf("unmarshal postgres clusters: %w", err)
+	}
+	return clusters, nil
+}
+
+// GetPostgresCluster returns the managed Postgres cluster with the given name or ErrNotFound if it doesn't exist.
+func (cli *Client) GetPostgresCluster(ctx context.Context, name string) (api.PostgresCluster, error) {
+	clusters, err := cli.ListPostgresClusters(ctx)
+	if err != nil {
+		return api.PostgresCluster{}, err
+	}
+	i := slices.IndexFunc(clusters, func(c api.PostgresCluster) bool { return c.Name == name })
+	if i == -1 {
+		return api.PostgresCluster{}, api.ErrNotFound
+	}
+	return clusters[i], nil
+}
+
+type CreatePostgresOptions struct {
+	Name string
+	// Image is the official Postgres image or an image based on it. Default is DefaultPostgresImage.
+	Image string
+	// Machines are the names or IDs of the two machines to run the cluster on. The first one runs the primary.
+	Machines []string
+	// Backup enables scheduled backups of the cluster to the backup storage if set.
+	Backup *api.BackupSpec
+}
+
+// CreatePostgres creates a managed Postgres cluster with generated passwords and deploys its service. The service
+// runs the primary on the first machine and a standby replicating from it on the second one.
+func (cli *Client) CreatePostgres(ctx context.Context, opts CreatePostgresOptions) (api.PostgresCluster, error) {
+	c := api.PostgresCluster{
+		Name:  opts.Name,
+		Image: opts.Image,
+	}
+	if c.Image == "" {
+		c.Image = api.DefaultPostgresImage
+	}
+
+	if _, err := cli.GetPostgresCluster(ctx, c.Name); err == nil {
+		return c, fmt.Errorf("postgres cluster '%s' already exists", c.Name)
+	} else if !errors.Is(err, api.ErrNotFound) {
+		return c, fmt.Errorf("get postgres cluster: %w", err)
+	}
+	if _, err := cli.InspectService(ctx, c.Name); err == nil {
+		return c, fmt.Errorf("service with name '%s' already exists", c.Name)
+	} else if !errors.Is(err, api.ErrNotFound) {
+		return c, fmt.Errorf("inspect service: %w", err)
+	}
+
+	for _, nameOrID := range opts.Machines {
+		m, err := cli.InspectMachine(ctx, nameOrID)
+		if err != nil {
+			return c, fmt.Errorf("inspect machine '%s': %w", nameOrID, err)
+		}
+		c.Machines = append(c.Machines, m.Machine.Id)
+	}
+	if len(c.Machines) > 0 {
+		c.Primary = c.Machines[0]
+	}
+
+	var err error
+	if c.Password, err = secret.RandomAlphaNumeric(postgresPasswordLength); err != nil {
+		return c, fmt.Errorf("generate password: %w", err)
+	}
+	if c.ReplicationPassword, err = secret.RandomAlphaNumeric(postgresPasswordLength); err != nil {
+		return c, fmt.Errorf("generate replication password: %w", err)
+	}
+	if err = c.Validate(); err != nil {
+		return c, fmt.Errorf("invalid postgres cluster: %w", err)
+	}
+
+	spec := PostgresServiceSpec(c, opts.Backup)
+	if err = spec.Validate(); err != nil {
+		return c, fmt.Errorf("invalid service spec: %w", err)
+	}
+
+	// Store the cluster first so that the machines write the primary machine ID the entrypoints wait for.
+	cBytes, err := json.Marshal(c)
+	if err != nil {
+		return c, fmt.Errorf("marshal postgres cluster: %w", err)
+	}
+	if _, err = cli.ClusterClient.SetPostgresCluster(ctx, &pb.PostgresCluster{Cluster: cBytes}); err != nil {
+		return c, fmt.Errorf("store postgres cluster: %w", err)
+	}
+
+	for _, machineID := range c.Machines {
+		_, err = cli.CreateVolume(ctx, machineID, volume.CreateOptions{Name: postgresVolumeName(c.Name)})
+		if err != nil {
+			return c, fmt.Errorf("create volume '%s' on machine '%s': %w", postgresVolumeName(c.Name), machineID, err)
+		}
+	}
+
+	if _, err = cli.NewDeployment(spec, nil).Run(ctx); err != nil {
+		return c, fmt.Errorf("deploy postgres service: %w", err)
+	}
+	return c, nil
+}
+
+// RemovePostgres removes the service and the record of the managed Postgres cluster. The data volumes are kept.
+func (cli *Client) RemovePostgres(ctx context.Context, name string) error {
+	if err := cli.RemoveService(ctx, name); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("remove service: %w", err)
+	}
+
+	_, err := cli.ClusterClient.RemovePostgresCluster(ctx, &pb.RemovePostgresClusterRequest{Name: name})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// PostgresServiceSpec returns the spec of the global service running the managed Postgres cluster on its machines.
+// The passwords are mounted as secret files and the service is backed up according to the backup spec if set.
+func PostgresServiceSpec(c api.PostgresCluster, backup *api.BackupSpec) api.ServiceSpec {
+	scriptMode := os.FileMode(0o755)
+	secretMode := os.FileMode(0o400)
+
+	return api.ServiceSpec{
+		Backup: backup,
+		Configs: []api.ConfigSpec{
+			{Name: "entrypoint", Content: []byte(postgresEntrypoint)},
+			{Name: "init-replication", Content: []byte(postgresInitReplication)},
+			{Name: "password", Content: []byte(c.Password)},
+			{Name: "replication-password", Content: []byte(c.ReplicationPassword)},
+		},
+		Container: api.ContainerSpec{
+			Command: []string{"/usr/local/bin/uncloud-postgres.sh"},
+			ConfigMounts: []api.ConfigMount{
+				{
+					ConfigName:    "entrypoint",
+					ContainerPath: "/usr/local/bin/uncloud-postgres.sh",
+					Mode:          &scriptMode,
+				},
+				{
+					ConfigName:    "init-replication",
+					ContainerPath: "/docker-entrypoint-initdb.d/uncloud-replication.sh",
+					Mode:          &scriptMode,
+				},
+				{
+					ConfigName:    "password",
+					ContainerPath: "/run/secrets/postgres-password",
+					Uid:           postgresUID,
+					Gid:           postgresUID,
+					Mode:          &secretMode,
+				},
+				{
+					ConfigName:    "replication-password",
+					ContainerPath: "/run/secrets/replication-password",
+					Uid:           postgresUID,
+					Gid:           postgresUID,
+					Mode:          &secretMode,
+				},
+			},
+			Env: map[string]string{
+				"PGDATA":                       postgresDataPath + "/pgdata",
+				"PG_PRIMARY_HOST":              c.Host(),
+				"PG_PRIMARY_MACHINE_FILE":      "/run/uncloud-postgres/" + api.PostgresPrimaryFileName,
+				"PG_REPLICATION_PASSWORD_FILE": "/run/secrets/replication-password",
+				"POSTGRES_PASSWORD_FILE":       "/run/secrets/postgres-password",
+			},
+			Image: c.Image,
+			VolumeMounts: []api.VolumeMount{
+				{
+					VolumeName:    postgresVolumeName(c.Name),
+					ContainerPath: postgresDataPath,
+				},
+				{
+					VolumeName:    "run",
+					ContainerPath: "/run/uncloud-postgres",
+					ReadOnly:      true,
+				},
+			},
+		},
+		Mode: api.ServiceModeGlobal,
+		Name: c.Name,
+		Placement: api.Placement{
+			Machines: c.Machines,
+		},
+		// Two servers must never use the same data directory.
+		UpdateConfig: &api.UpdateConfig{
+			Order: api.UpdateOrderStopFirst,
+		},
+		Volumes: []api.VolumeSpec{
+			{
+				Name: postgresVolumeName(c.Name),
+				Type: api.VolumeTypeVolume,
+			},
+			{
+				Name: "run",
+				Type: api.VolumeTypeBind,
+				BindOptions: &api.BindOptions{
+					HostPath:       api.PostgresRunDir + "/" + c.Name,
+					CreateHostPath: true,
+				},
+			},
+		},
+	}
+}
+
+func postgresVolumeName(clusterName string) string {
+	return clusterName + "-data"
+}
+
+// ManagedSecret returns the content of the external Compose secret managed by the cluster with the given name:
+// ObjectStorageSecretName or the secret of a managed Postgres cluster. It returns ErrNotFound if there is no such
+// secret.
+func (cli *Client) ManagedSecret(ctx context.Context, name string) ([]byte, error) {
+	switch {
+	case name == api.ObjectStorageSecretName:
+		storage, err := cli.GetObjectStorage(ctx)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return nil, errors.New("object storage not enabled, enable it with 'uc storage enable'")
+			}
+			return nil, fmt.Errorf("get object storage: %w", err)
+		}
+		return storage.Secret(), nil
+	case strings.HasPrefix(name, api.PostgresSecretPrefix):
+		c, err := cli.GetPostgresCluster(ctx, strings.TrimPrefix(name, api.PostgresSecretPrefix))
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return nil, fmt.Errorf("postgres cluster '%s' not found",
+					strings.TrimPrefix(name, api.PostgresSecretPrefix))
+			}
+			return nil, fmt.Errorf("get postgres cluster: %w", err)
+		}
+		return c.Secret(), nil
+	}
+	return nil, api.ErrNotFound
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresServiceSpec(t *testing.T) {
+	c := api.PostgresCluster{
+		Name:                "db",
+		Image:               api.DefaultPostgresImage,
+		Machines:            []string{"m1", "m2"},
+		Primary:             "m1",
+		Password:            "password",
+		ReplicationPassword: "replication",
+	}
+
+	t.Run("without backup", func(t *testing.T) {
+		spec := PostgresServiceSpec(c, nil)
+
+		require.NoError(t, spec.Validate())
+		assert.Equal(t, "db", spec.Name)
+		assert.Equal(t, api.ServiceModeGlobal, spec.Mode)
+		assert.Equal(t, []string{"m1", "m2"}, spec.Placement.Machines)
+		assert.Equal(t, api.UpdateOrderStopFirst, spec.UpdateConfig.Order)
+		assert.Equal(t, "db.internal", spec.Container.Env["PG_PRIMARY_HOST"])
+		assert.Equal(t, "/var/lib/postgresql/data/pgdata", spec.Container.Env["PGDATA"])
+		assert.Equal(t, "/run/uncloud-postgres/primary", spec.Container.Env["PG_PRIMARY_MACHINE_FILE"])
+		assert.Equal(t, []api.VolumeMount{
+			{VolumeName: "db-data", ContainerPath: "/var/lib/postgresql/data"},
+			{VolumeName: "run", ContainerPath: "/run/uncloud-postgres", ReadOnly: true},
+		}, spec.Container.VolumeMounts)
+		assert.Equal(t, "/run/uncloud/postgres/db", spec.Volumes[1].BindOptions.HostPath)
+		assert.Nil(t, spec.Backup)
+
+		for _, cfg := range spec.Configs {
+			switch cfg.Name {
+			case "password":
+				assert.Equal(t, "password", string(cfg.Content))
+			case "replication-password":
+				assert.Equal(t, "replication", string(cfg.Content))
+			}
+		}
+		assert.Len(t, spec.Container.ConfigMounts, len(spec.Configs))
+	})
+
+	t.Run("with backup", func(t *testing.T) {
+		backup := &api.BackupSpec{Engine: api.BackupEnginePostgres, Interval: time.Hour}
+		spec := PostgresServiceSpec(c, backup)
+
+		require.NoError(t, spec.Validate())
+		assert.Equal(t, backup, spec.Backup)
+	})
+}
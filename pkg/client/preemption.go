@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RecordPreemption records a container stopped to make room for a container of a higher priority service.
+func (cli *Client) RecordPreemption(ctx context.Context, p api.Preemption) error {
+	pBytes, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal preemption: %w", err)
+	}
+	_, err = cli.ClusterClient.RecordPreemption(ctx, &pb.Preemption{Preemption: pBytes})
+	return err
+}
+
+// ListPreemptions returns the recent preemptions of containers sorted by time.
+func (cli *Client) ListPreemptions(ctx context.Context) ([]api.Preemption, error) {
+	resp, err := cli.ClusterClient.ListPreemptions(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var preemptions []api.Preemption
+	if err = json.Unmarshal(resp.Preemptions, &preemptions); err != nil {
+		return nil, fmt.Errorf("unmarshal preemptions: %w", err)
+	}
+	return preemptions, nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListProjectQuotas returns the resource quotas of the projects sorted by project name.
+func (cli *Client) ListProjectQuotas(ctx context.Context) ([]api.ProjectQuota, error) {
+	resp, err := cli.ClusterClient.ListProjectQuotas(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var quotas []api.ProjectQuota
+	if err = json.Unmarshal(resp.Quotas, &quotas); err != nil {
+		return nil, fmt.Errorf("unmarshal project quotas: %w", err)
+	}
+	return quotas, nil
+}
+
+// ProjectQuota returns the resource quota of the project or ErrNotFound if the project has no quota.
+func (cli *Client) ProjectQuota(ctx context.Context, project string) (api.ProjectQuota, error) {
+	quotas, err := cli.ListProjectQuotas(ctx)
+	if err != nil {
+		return api.ProjectQuota{}, err
+	}
+	for _, q := range quotas {
+		if q.Project == project {
+			return q, nil
+		}
+	}
+	return api.ProjectQuota{}, api.ErrNotFound
+}
+
+// SetProjectQuota creates or replaces the resource quota of a project.
+func (cli *Client) SetProjectQuota(ctx context.Context, quota api.ProjectQuota) error {
+	if err := quota.Validate(); err != nil {
+		return err
+	}
+	qBytes, err := json.Marshal(quota)
+	if err != nil {
+		return fmt.Errorf("marshal project quota: %w", err)
+	}
+	_, err = cli.ClusterClient.SetProjectQuota(ctx, &pb.ProjectQuota{Quota: qBytes})
+	return err
+}
+
+// RemoveProjectQuota removes the resource quota of a project. It returns ErrNotFound if the project has no quota.
+func (cli *Client) RemoveProjectQuota(ctx context.Context, project string) error {
+	_, err := cli.ClusterClient.RemoveProjectQuota(ctx, &pb.RemoveProjectQuotaRequest{Project: project})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
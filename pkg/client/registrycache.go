@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+)
+
+const (
+	RegistryCacheServiceName = "registry-cache"
+	// RegistryCacheImage is the official Distribution registry image on Docker Hub that can run as a pull-through
+	// cache: https://hub.docker.com/_/registry
+	RegistryCacheImage = "registry:2"
+	// RegistryCachePort is the port the registry cache is published on the machine IP in the cluster network.
+	RegistryCachePort = 5000
+	// registryCacheVolumeName is the name of the volume that stores the cached images.
+	registryCacheVolumeName = "registry-cache"
+	// dockerHubRegistryURL is the URL of the Docker Hub registry the cache pulls the images from.
+	dockerHubRegistryURL = "https://registry-1.docker.io"
+)
+
+// NewRegistryCacheDeployment creates a new deployment for a pull-through cache of Docker Hub on the machine.
+// It returns the deployment and the URL of the registry mirror the Docker daemons of the machines should use
+// to pull through the cache. The cache is published on the machine IP in the cluster network so that it's
+// reachable from all machines but not from the internet. If the image is not provided, RegistryCacheImage is used.
+func (cli *Client) NewRegistryCacheDeployment(
+	ctx context.Context, machineNameOrID, image string,
+) (*deploy.Deployment, string, error) {
+	machine, err := cli.InspectMachine(ctx, machineNameOrID)
+	if err != nil {
+		return nil, "", fmt.Errorf("inspect machine '%s': %w", machineNameOrID, err)
+	}
+	subnet, err := machine.Machine.Network.Subnet.ToPrefix()
+	if err != nil {
+		return nil, "", fmt.Errorf("parse subnet of machine '%s': %w", machine.Machine.Name, err)
+	}
+	ip := network.MachineIP(subnet)
+
+	if image == "" {
+		image = RegistryCacheImage
+	}
+	spec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Env: map[string]string{
+				"REGISTRY_PROXY_REMOTEURL": dockerHubRegistryURL,
+			},
+			Image: image,
+			VolumeMounts: []api.VolumeMount{
+				{
+					VolumeName:    registryCacheVolumeName,
+					ContainerPath: "/var/lib/registry",
+				},
+			},
+		},
+		Mode: api.ServiceModeReplicated,
+		Name: RegistryCacheServiceName,
+		Placement: api.Placement{
+			Machines: []string{machine.Machine.Id},
+		},
+		Ports: []api.PortSpec{
+			{
+				HostIP:        ip,
+				PublishedPort: RegistryCachePort,
+				ContainerPort: RegistryCachePort,
+				Protocol:      api.ProtocolTCP,
+				Mode:          api.PortModeHost,
+			},
+		},
+		Replicas: 1,
+		Volumes: []api.VolumeSpec{
+			{
+				Name: registryCacheVolumeName,
+				Type: api.VolumeTypeVolume,
+			},
+		},
+	}
+
+	mirror := "http://" + netip.AddrPortFrom(ip, RegistryCachePort).String()
+	return cli.NewDeployment(spec, nil), mirror, nil
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetServiceRevision returns the state of the service before its last deployment or ErrNotFound if it hasn't been
+// recorded.
+func (cli *Client) GetServiceRevision(ctx context.Context, serviceID string) (api.ServiceRevision, error) {
+	var rev api.ServiceRevision
+
+	resp, err := cli.ClusterClient.GetServiceRevision(ctx, &pb.GetServiceRevisionRequest{ServiceId: serviceID})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return rev, api.ErrNotFound
+		}
+		return rev, err
+	}
+	if err = json.Unmarshal(resp.Revision, &rev); err != nil {
+		return rev, fmt.Errorf("unmarshal service revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+// SaveServiceRevision records the current state of the service so it can be rolled back after the upcoming
+// deployment. If snapshotVolumes is true, the Docker volumes mounted into the service containers are snapshotted
+// on their machines. The snapshots of the previous revision are removed as it's replaced by the new one.
+func (cli *Client) SaveServiceRevision(
+	ctx context.Context, svc api.Service, snapshotVolumes bool,
+) (api.ServiceRevision, error) {
+	spec, ok := svc.Spec()
+	if !ok {
+		return api.ServiceRevision{}, fmt.Errorf("service '%s' has no containers", svc.Name)
+	}
+	rev := api.ServiceRevision{
+		ServiceID: svc.ID,
+		Spec:      spec,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	prev, err := cli.GetServiceRevision(ctx, svc.ID)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return rev, fmt.Errorf("get previous service revision: %w", err)
+	}
+
+	if snapshotVolumes {
+		snapshotID := rev.CreatedAt.Format("20060102T150405Z")
+		if rev.Snapshots, err = cli.snapshotServiceVolumes(ctx, svc, snapshotID); err != nil {
+			return rev, err
+		}
+	}
+
+	revBytes, err := json.Marshal(rev)
+	if err != nil {
+		return rev, fmt.Errorf("marshal service revision: %w", err)
+	}
+	if _, err = cli.ClusterClient.SetServiceRevision(ctx, &pb.ServiceRevision{Revision: revBytes}); err != nil {
+		return rev, fmt.Errorf("save service revision: %w", err)
+	}
+
+	for _, snap := range prev.Snapshots {
+		if err = cli.removeVolumeSnapshot(ctx, snap); err != nil {
+			PrintWarning(fmt.Sprintf("failed to remove snapshot '%s' of volume '%s' on machine '%s': %v",
+				snap.ID, snap.VolumeName, snap.MachineID, err))
+		}
+	}
+
+	return rev, nil
+}
+
+// snapshotServiceVolumes snapshots the Docker volumes mounted into the service containers on each machine.
+func (cli *Client) snapshotServiceVolumes(
+	ctx context.Context, svc api.Service, id string,
+) ([]api.VolumeSnapshot, error) {
+	var snapshots []api.VolumeSnapshot
+	seen := make(map[string]struct{})
+
+	for _, c := range svc.Containers {
+		machine, err := cli.InspectMachine(ctx, c.MachineID)
+		if err != nil {
+			return snapshots, fmt.Errorf("inspect machine '%s': %w", c.MachineID, err)
+		}
+		machineCtx := proxyToMachine(ctx, machine.Machine)
+		pw := progress.ContextWriter(ctx)
+
+		for _, v := range c.Container.ServiceSpec.MountedDockerVolumes() {
+			volumeName := v.DockerVolumeName()
+			key := c.MachineID + "/" + volumeName
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			eventID := fmt.Sprintf("Volume %s on %s", volumeName, machine.Machine.Name)
+			pw.Event(progress.Event{ID: eventID, Status: progress.Working, StatusText: "Snapshotting"})
+
+			snap, err := cli.Docker.CreateVolumeSnapshot(machineCtx, volumeName, id)
+			if err != nil {
+				pw.Event(progress.ErrorEvent(eventID))
+				return snapshots, fmt.Errorf("snapshot volume '%s' on machine '%s': %w",
+					volumeName, machine.Machine.Name, err)
+			}
+			snap.MachineID = c.MachineID
+			snapshots = append(snapshots, snap)
+
+			pw.Event(progress.Event{ID: eventID, Status: progress.Done, StatusText: "Snapshotted"})
+		}
+	}
+
+	return snapshots, nil
+}
+
+func (cli *Client) removeVolumeSnapshot(ctx context.Context, snap api.VolumeSnapshot) error {
+	machine, err := cli.InspectMachine(ctx, snap.MachineID)
+	if err != nil {
+		return fmt.Errorf("inspect machine: %w", err)
+	}
+	return cli.Docker.RemoveVolumeSnapshot(proxyToMachine(ctx, machine.Machine), snap.VolumeName, snap.ID)
+}
+
+// RollbackService redeploys the service with its spec before the last deployment. If withData is true, the service
+// volumes are also restored from the snapshots taken before the deployment. The service containers on the machines
+// with snapshots are stopped while their volumes are restored. It returns the revision the service was rolled back to.
+func (cli *Client) RollbackService(
+	ctx context.Context, serviceNameOrID string, withData bool,
+) (api.ServiceRevision, error) {
+	svc, err := cli.InspectService(ctx, serviceNameOrID)
+	if err != nil {
+		return api.ServiceRevision{}, fmt.Errorf("inspect service '%s': %w", serviceNameOrID, err)
+	}
+
+	rev, err := cli.GetServiceRevision(ctx, svc.ID)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return rev, fmt.Errorf("no previous revision recorded for service '%s', "+
+				"revisions are recorded when an existing service is redeployed", svc.Name)
+		}
+		return rev, fmt.Errorf("get service revision: %w", err)
+	}
+	if withData && len(rev.Snapshots) == 0 {
+		return rev, fmt.Errorf("no volume snapshots were taken before the last deployment of service '%s', "+
+			"deploy with --snapshot-volumes to snapshot the service volumes", svc.Name)
+	}
+
+	var stopped []api.MachineServiceContainer
+	if withData {
+		machines := make(map[string]struct{})
+		for _, snap := range rev.Snapshots {
+			machines[snap.MachineID] = struct{}{}
+		}
+		for _, c := range svc.Containers {
+			if _, ok := machines[c.MachineID]; !ok || !c.Container.State.Running {
+				continue
+			}
+			if err = cli.StopContainer(ctx, svc.ID, c.Container.ID, container.StopOptions{}); err != nil {
+				return rev, fmt.Errorf("stop container '%s': %w", c.Container.Name, err)
+			}
+			stopped = append(stopped, c)
+		}
+
+		if err = cli.restoreVolumeSnapshots(ctx, rev.Snapshots); err != nil {
+			// Bring the service back with the current data if the restore failed.
+			return rev, errors.Join(err, cli.startContainers(ctx, svc.ID, stopped))
+		}
+	}
+
+	if _, err = cli.NewDeployment(rev.Spec, nil).Run(ctx); err != nil {
+		return rev, errors.Join(fmt.Errorf("deploy previous service spec: %w", err),
+			cli.startContainers(ctx, svc.ID, stopped))
+	}
+
+	// Start the stopped containers that haven't been replaced by the deployment.
+	return rev, cli.startContainers(ctx, svc.ID, stopped)
+}
+
+func (cli *Client) restoreVolumeSnapshots(ctx context.Context, snapshots []api.VolumeSnapshot) error {
+	pw := progress.ContextWriter(ctx)
+
+	for _, snap := range snapshots {
+		machine, err := cli.InspectMachine(ctx, snap.MachineID)
+		if err != nil {
+			return fmt.Errorf("inspect machine '%s': %w", snap.MachineID, err)
+		}
+
+		eventID := fmt.Sprintf("Volume %s on %s", snap.VolumeName, machine.Machine.Name)
+		pw.Event(progress.Event{ID: eventID, Status: progress.Working, StatusText: "Restoring"})
+
+		err = cli.Docker.RestoreVolumeSnapshot(proxyToMachine(ctx, machine.Machine), snap.VolumeName, snap.ID)
+		if err != nil {
+			pw.Event(progress.ErrorEvent(eventID))
+			return fmt.Errorf("restore snapshot '%s' of volume '%s' on machine '%s': %w",
+				snap.ID, snap.VolumeName, machine.Machine.Name, err)
+		}
+		pw.Event(progress.Event{ID: eventID, Status: progress.Done, StatusText: "Restored"})
+	}
+
+	return nil
+}
+
+// startContainers starts the containers of the service that still exist and are not running.
+func (cli *Client) startContainers(
+	ctx context.Context, serviceID string, containers []api.MachineServiceContainer,
+) error {
+	if len(containers) == 0 {
+		return nil
+	}
+	svc, err := cli.InspectService(ctx, serviceID)
+	if err != nil {
+		return fmt.Errorf("inspect service: %w", err)
+	}
+
+	var errs []error
+	for _, c := range svc.Containers {
+		if c.Container.State.Running || !slices.ContainsFunc(containers, func(s api.MachineServiceContainer) bool {
+			return s.Container.ID == c.Container.ID
+		}) {
+			continue
+		}
+		if err = cli.StartContainer(ctx, serviceID, c.Container.ID); err != nil {
+			errs = append(errs, fmt.Errorf("start container '%s': %w", c.Container.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/docker/docker/api/types/container"
@@ -126,7 +127,7 @@ func (cli *Client) InspectService(ctx context.Context, nameOrID string) (api.Ser
 		}
 		if mc.Metadata != nil && mc.Metadata.Error != "" {
 			// TODO: return failed machines in the response.
-			fmt.Printf("WARNING: failed to list containers on machine '%s': %s\n",
+			fmt.Fprintf(os.Stderr, "WARNING: failed to list containers on machine '%s': %s\n",
 				mc.Metadata.Machine, mc.Metadata.Error)
 			continue
 		}
@@ -181,17 +182,22 @@ func (cli *Client) InspectService(ctx context.Context, nameOrID string) (api.Ser
 		}
 	}
 
-	svc = api.Service{
+	return newService(containers), nil
+}
+
+// newService returns the service the containers belong to. All containers must belong to the same service.
+func newService(containers []api.MachineServiceContainer) api.Service {
+	svc := api.Service{
 		ID:         containers[0].Container.ServiceID(),
 		Name:       containers[0].Container.ServiceName(),
 		Mode:       containers[0].Container.ServiceMode(),
+		Project:    containers[0].Container.Project(),
 		Containers: containers,
 	}
 	if svc.Mode == "" {
 		svc.Mode = api.ServiceModeReplicated
 	}
-
-	return svc, nil
+	return svc
 }
 
 // InspectServiceFromStore returns detailed information about a service and its containers from the distributed store.
@@ -273,34 +279,46 @@ func (cli *Client) RemoveService(ctx context.Context, id string) error {
 	return err
 }
 
-// ListServices returns a list of all services and their containers.
+// ListServices returns a list of all services and their containers sorted by name.
 func (cli *Client) ListServices(ctx context.Context) ([]api.Service, error) {
+	return cli.ListServicesFiltered(ctx, nil)
+}
+
+// ListServicesFiltered returns a list of services and their containers that match the filter sorted by name.
+// The project and label criteria of the filter are applied by the machines so only the matching containers
+// are transferred.
+func (cli *Client) ListServicesFiltered(ctx context.Context, filter *api.ServiceFilter) ([]api.Service, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
 	machines, err := cli.ListMachines(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("list machines: %w", err)
 	}
 
 	// Broadcast the container list request to all available machines.
+	machineIDByManagementIP := make(map[string]string)
 	md := metadata.New(nil)
 	for _, m := range machines {
 		if m.State == pb.MachineMember_UP || m.State == pb.MachineMember_SUSPECT {
 			machineIP, _ := m.Machine.Network.ManagementIp.ToAddr()
 			md.Append("machines", machineIP.String())
+
+			machineIDByManagementIP[machineIP.String()] = m.Machine.Id
 		}
 		// TODO: warning about machines that are DOWN.
 	}
 	listCtx := metadata.NewOutgoingContext(ctx, md)
 
 	// List all containers including stopped ones.
-	opts := container.ListOptions{All: true}
+	opts := container.ListOptions{All: true, Filters: filter.DockerFilters()}
 	machineContainers, err := cli.Docker.ListServiceContainers(listCtx, "", opts)
 	if err != nil {
 		return nil, fmt.Errorf("list containers: %w", err)
 	}
 
-	// TODO: optimise by extracting services from the list of all containers instead of inspecting each service.
-	//  Most of the code can be reused in both InspectService and ListServices.
-	servicesByID := make(map[string]api.Service)
+	containersByServiceID := make(map[string][]api.MachineServiceContainer)
 	for _, mc := range machineContainers {
 		if mc.Metadata != nil && mc.Metadata.Error != "" {
 			// TODO: return failed machines in the response.
@@ -309,26 +327,40 @@ func (cli *Client) ListServices(ctx context.Context) ([]api.Service, error) {
 			continue
 		}
 
-		for _, ctr := range mc.Containers {
-			if _, ok := servicesByID[ctr.ServiceID()]; ok {
-				continue
+		machineID := ""
+		if mc.Metadata == nil {
+			// ListContainers was proxied to only one machine.
+			for _, v := range machineIDByManagementIP {
+				machineID = v
+				break
 			}
-
-			svc, err := cli.InspectService(ctx, ctr.ServiceID())
-			if err != nil {
-				if errors.Is(err, api.ErrNotFound) {
-					continue
-				}
-				return nil, fmt.Errorf("inspect service: %w", err)
+		} else {
+			var ok bool
+			machineID, ok = machineIDByManagementIP[mc.Metadata.Machine]
+			if !ok {
+				return nil, fmt.Errorf("machine name not found for management IP: %s", mc.Metadata.Machine)
 			}
+		}
 
-			servicesByID[ctr.ServiceID()] = svc
+		for _, ctr := range mc.Containers {
+			// Older daemons may ignore some of the filters so check all the criteria.
+			if !filter.Matches(ctr) {
+				continue
+			}
+			containersByServiceID[ctr.ServiceID()] = append(containersByServiceID[ctr.ServiceID()],
+				api.MachineServiceContainer{MachineID: machineID, Container: ctr})
 		}
 	}
 
-	services := make([]api.Service, 0, len(servicesByID))
-	for _, svc := range servicesByID {
-		services = append(services, svc)
+	services := make([]api.Service, 0, len(containersByServiceID))
+	for _, containers := range containersByServiceID {
+		services = append(services, newService(containers))
 	}
+	slices.SortFunc(services, func(a, b api.Service) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
 	return services, nil
 }
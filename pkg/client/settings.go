@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetSettings returns the cluster-wide settings.
+func (cli *Client) GetSettings(ctx context.Context) (api.ClusterSettings, error) {
+	resp, err := cli.ClusterClient.GetSettings(ctx, &emptypb.Empty{})
+	if err != nil {
+		return api.ClusterSettings{}, err
+	}
+	return api.ClusterSettingsFromProto(resp), nil
+}
+
+// SetSettings replaces the cluster-wide settings and returns the stored settings with the incremented version.
+// It fails with the Aborted status code if the settings have been changed since their version was read.
+// The version check is skipped if the version of the settings is zero.
+func (cli *Client) SetSettings(ctx context.Context, settings api.ClusterSettings) (api.ClusterSettings, error) {
+	resp, err := cli.ClusterClient.SetSettings(ctx, settings.Proto())
+	if err != nil {
+		return api.ClusterSettings{}, err
+	}
+	return api.ClusterSettingsFromProto(resp), nil
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// objectStorageDataPath is the path in the object storage containers where the data volume is mounted.
+const objectStorageDataPath = "/data"
+
+// GetObjectStorage returns the configuration of the managed object storage or ErrNotFound if it hasn't been
+// enabled yet.
+func (cli *Client) GetObjectStorage(ctx context.Context) (api.ObjectStorage, error) {
+	var config api.ObjectStorage
+
+	resp, err := cli.ClusterClient.GetObjectStorage(ctx, &emptypb.Empty{})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return config, api.ErrNotFound
+		}
+		return config, err
+	}
+	if err = json.Unmarshal(resp.Config, &config); err != nil {
+		return config, fmt.Errorf("unmarshal object storage config: %w", err)
+	}
+
+	return config, nil
+}
+
+type ObjectStorageOptions struct {
+	// Image is the MinIO image to run. Default is the image of the enabled storage or DefaultObjectStorageImage.
+	Image string
+	// Machines are the names or IDs of the machines to run the storage on. Default is the machines of the enabled
+	// storage or all machines in the cluster.
+	Machines []string
+}
+
+// PrepareObjectStorage returns the configuration of the managed object storage to enable with the given options.
+// The credentials of the already enabled storage are preserved, otherwise new random credentials are generated.
+// The machines of the enabled storage can't be changed as the data is erasure-coded across all of them.
+func (cli *Client) PrepareObjectStorage(ctx context.Context, opts ObjectStorageOptions) (api.ObjectStorage, error) {
+	config, err := cli.GetObjectStorage(ctx)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return config, fmt.Errorf("get object storage config: %w", err)
+	}
+	enabled := err == nil
+
+	if opts.Image != "" {
+		config.Image = opts.Image
+	} else if config.Image == "" {
+		config.Image = api.DefaultObjectStorageImage
+	}
+
+	if len(opts.Machines) > 0 || !enabled {
+		filter := &api.MachineFilter{NamesOrIDs: opts.Machines}
+		machines, err := cli.ListMachines(ctx, filter)
+		if err != nil {
+			return config, fmt.Errorf("list machines: %w", err)
+		}
+		if len(opts.Machines) > 0 && len(machines) != len(opts.Machines) {
+			return config, fmt.Errorf("some of the machines not found: %v", opts.Machines)
+		}
+
+		machineIDs := make([]string, 0, len(machines))
+		for _, m := range machines {
+			machineIDs = append(machineIDs, m.Machine.Id)
+		}
+		slices.Sort(machineIDs)
+
+		if enabled && !slices.Equal(machineIDs, config.Machines) {
+			return config, errors.New("changing the machines of the enabled object storage is not supported")
+		}
+		config.Machines = machineIDs
+	}
+
+	if !enabled {
+		config.Endpoint = "http://" + api.ObjectStorageServiceName + ".internal:" + strconv.Itoa(api.ObjectStoragePort)
+		if config.AccessKeyID, err = secret.RandomAlphaNumeric(20); err != nil {
+			return config, fmt.Errorf("generate access key ID: %w", err)
+		}
+		if config.SecretAccessKey, err = secret.RandomAlphaNumeric(40); err != nil {
+			return config, fmt.Errorf("generate secret access key: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+// EnableObjectStorage stores the object storage configuration and deploys the MinIO service that runs the storage
+// on the configured machines. A data volume is created on each machine if it doesn't exist.
+func (cli *Client) EnableObjectStorage(ctx context.Context, config api.ObjectStorage) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid object storage config: %w", err)
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal object storage config: %w", err)
+	}
+	// Store the config with the credentials first so they're not lost if the deployment fails.
+	if _, err = cli.ClusterClient.SetObjectStorage(ctx, &pb.ObjectStorage{Config: configBytes}); err != nil {
+		return fmt.Errorf("store object storage config: %w", err)
+	}
+
+	volumes, err := cli.ListVolumes(ctx, &api.VolumeFilter{
+		Machines: config.Machines,
+		Names:    []string{api.ObjectStorageVolumeName},
+	})
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", err)
+	}
+	for _, machineID := range config.Machines {
+		if slices.ContainsFunc(volumes, func(v api.MachineVolume) bool { return v.MachineID == machineID }) {
+			continue
+		}
+		_, err = cli.CreateVolume(ctx, machineID, volume.CreateOptions{Name: api.ObjectStorageVolumeName})
+		if err != nil {
+			return fmt.Errorf("create volume '%s' on machine '%s': %w", api.ObjectStorageVolumeName, machineID, err)
+		}
+	}
+
+	if _, err = cli.NewDeployment(ObjectStorageServiceSpec(config), nil).Run(ctx); err != nil {
+		return fmt.Errorf("deploy object storage service: %w", err)
+	}
+	return nil
+}
+
+// ObjectStorageServiceSpec returns the spec of the global MinIO service running the object storage. The storage runs
+// in single-node mode on one machine and in distributed mode on multiple machines where each node addresses
+// its peers via the internal DNS name of the service container on their machine.
+func ObjectStorageServiceSpec(config api.ObjectStorage) api.ServiceSpec {
+	command := []string{"server", "--address", ":" + strconv.Itoa(api.ObjectStoragePort)}
+	if len(config.Machines) == 1 {
+		command = append(command, objectStorageDataPath)
+	} else {
+		for _, machineID := range config.Machines {
+			command = append(command, fmt.Sprintf("http://%s.m.%s.internal:%d%s",
+				machineID, api.ObjectStorageServiceName, api.ObjectStoragePort, objectStorageDataPath))
+		}
+	}
+
+	return api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Command: command,
+			Env: map[string]string{
+				"MINIO_ROOT_USER":     config.AccessKeyID,
+				"MINIO_ROOT_PASSWORD": config.SecretAccessKey,
+			},
+			Image: config.Image,
+			VolumeMounts: []api.VolumeMount{
+				{
+					VolumeName:    api.ObjectStorageVolumeName,
+					ContainerPath: objectStorageDataPath,
+				},
+			},
+		},
+		Mode: api.ServiceModeGlobal,
+		Name: api.ObjectStorageServiceName,
+		Placement: api.Placement{
+			Machines: config.Machines,
+		},
+		Volumes: []api.VolumeSpec{
+			{
+				Name: api.ObjectStorageVolumeName,
+				Type: api.VolumeTypeVolume,
+			},
+		},
+	}
+}
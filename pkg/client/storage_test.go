@@ -0,0 +1,43 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStorageServiceSpec(t *testing.T) {
+	config := api.ObjectStorage{
+		Image:           api.DefaultObjectStorageImage,
+		Machines:        []string{"m1"},
+		Endpoint:        "http://uncloud-storage.internal:9000",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	}
+
+	t.Run("single machine", func(t *testing.T) {
+		spec := ObjectStorageServiceSpec(config)
+
+		require.NoError(t, spec.Validate())
+		assert.Equal(t, api.ServiceModeGlobal, spec.Mode)
+		assert.Equal(t, []string{"m1"}, spec.Placement.Machines)
+		assert.Equal(t, []string{"server", "--address", ":9000", "/data"}, spec.Container.Command)
+		assert.Equal(t, "access", spec.Container.Env["MINIO_ROOT_USER"])
+		assert.Equal(t, "secret", spec.Container.Env["MINIO_ROOT_PASSWORD"])
+	})
+
+	t.Run("distributed", func(t *testing.T) {
+		config.Machines = []string{"m1", "m2", "m3"}
+		spec := ObjectStorageServiceSpec(config)
+
+		require.NoError(t, spec.Validate())
+		assert.Equal(t, []string{
+			"server", "--address", ":9000",
+			"http://m1.m.uncloud-storage.internal:9000/data",
+			"http://m2.m.uncloud-storage.internal:9000/data",
+			"http://m3.m.uncloud-storage.internal:9000/data",
+		}, spec.Container.Command)
+	})
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListTenants returns the tenants hosted on the cluster sorted by name.
+func (cli *Client) ListTenants(ctx context.Context) ([]api.Tenant, error) {
+	resp, err := cli.ClusterClient.ListTenants(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var tenants []api.Tenant
+	if err = json.Unmarshal(resp.Tenants, &tenants); err != nil {
+		return nil, fmt.Errorf("unmarshal tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// GetTenant returns the named tenant or ErrNotFound if it doesn't exist.
+func (cli *Client) GetTenant(ctx context.Context, name string) (api.Tenant, error) {
+	tenants, err := cli.ListTenants(ctx)
+	if err != nil {
+		return api.Tenant{}, err
+	}
+	for _, t := range tenants {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return api.Tenant{}, api.ErrNotFound
+}
+
+// SetTenant creates or updates a tenant.
+func (cli *Client) SetTenant(ctx context.Context, tenant api.Tenant) error {
+	if err := tenant.Validate(); err != nil {
+		return err
+	}
+	tBytes, err := json.Marshal(tenant)
+	if err != nil {
+		return fmt.Errorf("marshal tenant: %w", err)
+	}
+	_, err = cli.ClusterClient.SetTenant(ctx, &pb.Tenant{Tenant: tBytes})
+	return err
+}
+
+// RemoveTenant removes the named tenant. It returns ErrNotFound if it doesn't exist.
+func (cli *Client) RemoveTenant(ctx context.Context, name string) error {
+	_, err := cli.ClusterClient.RemoveTenant(ctx, &pb.RemoveTenantRequest{Name: name})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
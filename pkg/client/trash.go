@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListTrashedServices returns the removed services kept in the trash that can be restored.
+func (cli *Client) ListTrashedServices(ctx context.Context) ([]api.TrashedService, error) {
+	resp, err := cli.ClusterClient.ListTrashedServices(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var services []api.TrashedService
+	if err = json.Unmarshal(resp.Services, &services); err != nil {
+		return nil, fmt.Errorf("unmarshal trashed services: %w", err)
+	}
+	return services, nil
+}
+
+// GetTrashedService returns the trashed service with the given name or ID or ErrNotFound if it's not in the trash.
+func (cli *Client) GetTrashedService(ctx context.Context, nameOrID string) (api.TrashedService, error) {
+	services, err := cli.ListTrashedServices(ctx)
+	if err != nil {
+		return api.TrashedService{}, err
+	}
+
+	var found []api.TrashedService
+	for _, svc := range services {
+		if svc.ID == nameOrID {
+			return svc, nil
+		}
+		if svc.Name == nameOrID {
+			found = append(found, svc)
+		}
+	}
+	if len(found) == 0 {
+		return api.TrashedService{}, api.ErrNotFound
+	}
+	if len(found) > 1 {
+		return api.TrashedService{}, fmt.Errorf("multiple trashed services found with name '%s', use the service ID",
+			nameOrID)
+	}
+	return found[0], nil
+}
+
+// TrashService moves the service to the trash for the retention period instead of removing it. Its containers are
+// stopped and kept so the service can be restored with RestoreService until the retention period expires. Then
+// the machines remove the containers.
+func (cli *Client) TrashService(
+	ctx context.Context, nameOrID string, retention time.Duration,
+) (api.TrashedService, error) {
+	svc, err := cli.InspectService(ctx, nameOrID)
+	if err != nil {
+		return api.TrashedService{}, err
+	}
+
+	now := time.Now().UTC()
+	trashed := api.TrashedService{
+		ID:        svc.ID,
+		Name:      svc.Name,
+		TrashedAt: now,
+		ExpiresAt: now.Add(retention),
+	}
+	// Store the trash record first so the service can be restored if stopping some containers fails.
+	trashedBytes, err := json.Marshal(trashed)
+	if err != nil {
+		return trashed, fmt.Errorf("marshal trashed service: %w", err)
+	}
+	if _, err = cli.ClusterClient.SetTrashedService(ctx, &pb.TrashedService{Service: trashedBytes}); err != nil {
+		return trashed, fmt.Errorf("move service to trash: %w", err)
+	}
+
+	wg := sync.WaitGroup{}
+	errCh := make(chan error)
+
+	for _, mc := range svc.Containers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := cli.StopContainer(ctx, svc.ID, mc.Container.ID, container.StopOptions{})
+			if err != nil && !errors.Is(err, api.ErrNotFound) {
+				errCh <- fmt.Errorf("stop container '%s': %w", mc.Container.ID, err)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(errCh)
+	}()
+
+	err = nil
+	for e := range errCh {
+		err = errors.Join(err, e)
+	}
+	return trashed, err
+}
+
+// RestoreService restores the trashed service with the given name or ID by starting its containers and removing
+// it from the trash. Only the containers that existed when the service was trashed are started.
+func (cli *Client) RestoreService(ctx context.Context, nameOrID string) (api.TrashedService, error) {
+	trashed, err := cli.GetTrashedService(ctx, nameOrID)
+	if err != nil {
+		return trashed, err
+	}
+
+	svc, err := cli.InspectService(ctx, trashed.ID)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return trashed, fmt.Errorf("containers of trashed service '%s' have already been removed", trashed.Name)
+		}
+		return trashed, err
+	}
+
+	wg := sync.WaitGroup{}
+	errCh := make(chan error)
+
+	for _, mc := range svc.Containers {
+		if !trashed.Contains(mc.Container.Container) || (mc.Container.State != nil && mc.Container.State.Running) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := cli.StartContainer(ctx, svc.ID, mc.Container.ID); err != nil {
+				errCh <- fmt.Errorf("start container '%s': %w", mc.Container.ID, err)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(errCh)
+	}()
+
+	err = nil
+	for e := range errCh {
+		err = errors.Join(err, e)
+	}
+	if err != nil {
+		// Keep the service in the trash so the restore can be retried.
+		return trashed, err
+	}
+
+	return trashed, cli.RemoveTrashedService(ctx, trashed.ID)
+}
+
+// RemoveTrashedService removes the service with the given ID from the trash without starting or removing
+// its containers.
+func (cli *Client) RemoveTrashedService(ctx context.Context, id string) error {
+	_, err := cli.ClusterClient.RemoveTrashedService(ctx, &pb.RemoveTrashedServiceRequest{Id: id})
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ServiceUptimeChecks returns the results of the uptime checks of the HTTPS endpoints published by the service
+// performed since the given time, ordered by the check time.
+func (cli *Client) ServiceUptimeChecks(
+	ctx context.Context, serviceName string, since time.Time,
+) ([]api.UptimeCheck, error) {
+	req := &pb.ListUptimeChecksRequest{ServiceName: serviceName}
+	if !since.IsZero() {
+		req.Since = timestamppb.New(since)
+	}
+	resp, err := cli.ClusterClient.ListUptimeChecks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]api.UptimeCheck, len(resp.Checks))
+	for i, c := range resp.Checks {
+		checks[i] = api.UptimeCheck{
+			ServiceName: c.ServiceName,
+			URL:         c.Url,
+			MachineID:   c.MachineId,
+			CheckedAt:   c.CheckedAt.AsTime(),
+			StatusCode:  int(c.StatusCode),
+			Latency:     c.Latency.AsDuration(),
+			Error:       c.Error,
+		}
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// UpgradeCLICommand is the command to upgrade the uncloud CLI to the latest version.
+	UpgradeCLICommand = "curl -fsS https://get.uncloud.run/install.sh | sh  # or 'brew upgrade uncloud'"
+	// upgradeDaemonCommandFmt is the command to run on a machine to upgrade the machine daemon to the given version.
+	// The install script doesn't replace the uncloudd binary if it's already installed so it's replaced manually.
+	upgradeDaemonCommandFmt = "curl -fsSL https://github.com/psviderski/uncloud/releases/download/v%s/" +
+		"uncloudd_linux_$(uname -m | sed 's/x86_64/amd64/;s/aarch64/arm64/').tar.gz | " +
+		"sudo tar -xz -C /usr/local/bin uncloudd && sudo systemctl restart uncloud"
+	// UpgradeCaddyCommand is the command to deploy the same Caddy image to all machines.
+	UpgradeCaddyCommand = "uc caddy deploy"
+)
+
+// MachineVersions is the versions of the software components running on a machine.
+type MachineVersions struct {
+	Machine api.Machine
+	// Daemon is the version of the machine daemon (uncloudd). Empty if unknown.
+	Daemon string
+	// Docker is the version of Docker Engine. Empty if unknown.
+	Docker string
+	// Caddy is the image of the Caddy container running on the machine. Empty if Caddy isn't running on it.
+	Caddy string
+	// Err is set if the machine failed to report its versions, e.g. because it's DOWN.
+	Err error
+}
+
+// ClusterVersions returns the versions of the machine daemon, Docker, and Caddy on all machines in the cluster
+// sorted by machine name.
+func (cli *Client) ClusterVersions(ctx context.Context) ([]MachineVersions, error) {
+	results, err := cli.BatchInspectMachines(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("inspect machines: %w", err)
+	}
+
+	caddyImages := make(map[string]string)
+	caddy, err := cli.InspectService(ctx, CaddyServiceName)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return nil, fmt.Errorf("inspect caddy service: %w", err)
+	}
+	for _, ctr := range caddy.Containers {
+		caddyImages[ctr.MachineID] = ctr.Container.Config.Image
+	}
+
+	versions := make([]MachineVersions, len(results))
+	for i, r := range results {
+		versions[i] = MachineVersions{
+			Machine: r.Machine,
+			Daemon:  r.Value.DaemonVersion,
+			Caddy:   caddyImages[r.Machine.ID],
+			Err:     r.Err,
+		}
+		if r.Value.Resources != nil {
+			versions[i].Docker = r.Value.Resources.DockerVersion
+		}
+	}
+	return versions, nil
+}
+
+// VersionSkew is a mismatch between the versions of the CLI and the software running on the machines.
+type VersionSkew struct {
+	// Unsupported is true if the skew is outside the supported range and may break the cluster operations.
+	// Otherwise, the skew is supported but it's recommended to upgrade.
+	Unsupported bool
+	Message     string
+	// Fixes are the commands that resolve the skew.
+	Fixes []string
+}
+
+// CheckVersionSkew compares the CLI version with the versions reported by the machines. The CLI and all machine
+// daemons must run the same major and minor version, patch versions may differ. Docker and Caddy are only expected
+// to run the same version on all machines. Development builds with non-semver versions are not compared.
+func CheckVersionSkew(cliVersion string, machines []MachineVersions) []VersionSkew {
+	var skews []VersionSkew
+
+	cliVer, _ := semver.NewVersion(cliVersion)
+	var newest *semver.Version
+	var daemonVersions []string
+	for _, m := range machines {
+		if m.Err != nil || m.Daemon == "" {
+			continue
+		}
+		if !slices.Contains(daemonVersions, m.Daemon) {
+			daemonVersions = append(daemonVersions, m.Daemon)
+		}
+		if v, err := semver.NewVersion(m.Daemon); err == nil && (newest == nil || v.GreaterThan(newest)) {
+			newest = v
+		}
+	}
+	if cliVer != nil && (newest == nil || cliVer.GreaterThan(newest)) {
+		newest = cliVer
+	}
+
+	// Machines should be upgraded to the newest version of the daemon or CLI.
+	if newest != nil {
+		var outdated []string
+		unsupported := false
+		for _, m := range machines {
+			if m.Err != nil || m.Daemon == "" {
+				continue
+			}
+			v, err := semver.NewVersion(m.Daemon)
+			if err != nil || !v.LessThan(newest) {
+				continue
+			}
+			outdated = append(outdated, fmt.Sprintf("%s (%s)", m.Machine.Name, m.Daemon))
+			unsupported = unsupported || !sameMinor(v, newest)
+		}
+		if len(outdated) > 0 {
+			skews = append(skews, VersionSkew{
+				Unsupported: unsupported,
+				Message: fmt.Sprintf("Machine daemons are older than version %s: %s.",
+					newest, strings.Join(outdated, ", ")),
+				Fixes: []string{
+					"Run on each outdated machine: " + fmt.Sprintf(upgradeDaemonCommandFmt, newest),
+				},
+			})
+		}
+	}
+
+	if cliVer != nil && newest != nil && cliVer.LessThan(newest) {
+		skews = append(skews, VersionSkew{
+			Unsupported: !sameMinor(cliVer, newest),
+			Message:     fmt.Sprintf("CLI version %s is older than machine daemon version %s.", cliVer, newest),
+			Fixes:       []string{UpgradeCLICommand},
+		})
+	}
+
+	if len(daemonVersions) > 1 && newest == nil {
+		// None of the daemon versions could be compared, e.g. development builds.
+		skews = append(skews, VersionSkew{
+			Message: fmt.Sprintf("Machines run different daemon versions: %s.", strings.Join(daemonVersions, ", ")),
+		})
+	}
+
+	if dockerMajors := distinct(machines, func(m MachineVersions) string {
+		major, _, _ := strings.Cut(m.Docker, ".")
+		return major
+	}); len(dockerMajors) > 1 {
+		skews = append(skews, VersionSkew{
+			Message: fmt.Sprintf("Machines run different major versions of Docker: %s.",
+				strings.Join(distinct(machines, func(m MachineVersions) string { return m.Docker }), ", ")),
+			Fixes: []string{"Upgrade Docker on the outdated machines with the system package manager."},
+		})
+	}
+
+	if caddyImages := distinct(machines, func(m MachineVersions) string { return m.Caddy }); len(caddyImages) > 1 {
+		skews = append(skews, VersionSkew{
+			Message: fmt.Sprintf("Machines run different Caddy images: %s.", strings.Join(caddyImages, ", ")),
+			Fixes:   []string{UpgradeCaddyCommand},
+		})
+	}
+
+	return skews
+}
+
+func sameMinor(a, b *semver.Version) bool {
+	return a.Major() == b.Major() && a.Minor() == b.Minor()
+}
+
+// distinct returns the distinct non-empty values of the field of the machines that reported their versions.
+func distinct(machines []MachineVersions, field func(MachineVersions) string) []string {
+	var values []string
+	for _, m := range machines {
+		if v := field(m); m.Err == nil && v != "" && !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	slices.Sort(values)
+	return values
+}
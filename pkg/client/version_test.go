@@ -0,0 +1,92 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVersionSkew(t *testing.T) {
+	t.Parallel()
+
+	machine := func(name, daemon, docker, caddy string) MachineVersions {
+		return MachineVersions{
+			Machine: api.Machine{Name: name},
+			Daemon:  daemon,
+			Docker:  docker,
+			Caddy:   caddy,
+		}
+	}
+
+	t.Run("no skew", func(t *testing.T) {
+		t.Parallel()
+		skews := CheckVersionSkew("0.9.1", []MachineVersions{
+			machine("m1", "0.9.1", "28.1.1", "caddy:2.10.0"),
+			machine("m2", "0.9.1", "28.1.1", "caddy:2.10.0"),
+		})
+		assert.Empty(t, skews)
+	})
+
+	t.Run("outdated daemon patch", func(t *testing.T) {
+		t.Parallel()
+		skews := CheckVersionSkew("0.9.1", []MachineVersions{
+			machine("m1", "0.9.1", "28.1.1", ""),
+			machine("m2", "0.9.0", "28.1.1", ""),
+		})
+		require.Len(t, skews, 1)
+		assert.False(t, skews[0].Unsupported)
+		assert.Equal(t, "Machine daemons are older than version 0.9.1: m2 (0.9.0).", skews[0].Message)
+		assert.Contains(t, skews[0].Fixes[0], "releases/download/v0.9.1/")
+	})
+
+	t.Run("outdated CLI minor", func(t *testing.T) {
+		t.Parallel()
+		skews := CheckVersionSkew("0.8.0", []MachineVersions{
+			machine("m1", "0.9.0", "28.1.1", ""),
+		})
+		require.Len(t, skews, 1)
+		assert.True(t, skews[0].Unsupported)
+		assert.Equal(t, "CLI version 0.8.0 is older than machine daemon version 0.9.0.", skews[0].Message)
+		assert.Equal(t, []string{UpgradeCLICommand}, skews[0].Fixes)
+	})
+
+	t.Run("outdated daemon minor", func(t *testing.T) {
+		t.Parallel()
+		skews := CheckVersionSkew("0.10.0", []MachineVersions{
+			machine("m1", "0.9.2", "28.1.1", ""),
+			machine("m2", "0.10.0", "28.1.1", ""),
+		})
+		require.Len(t, skews, 1)
+		assert.True(t, skews[0].Unsupported)
+	})
+
+	t.Run("docker and caddy drift", func(t *testing.T) {
+		t.Parallel()
+		skews := CheckVersionSkew("0.9.1", []MachineVersions{
+			machine("m1", "0.9.1", "27.5.1", "caddy:2.9.1"),
+			machine("m2", "0.9.1", "28.1.1", "caddy:2.10.0"),
+			machine("m3", "0.9.1", "28.0.0", "caddy:2.10.0"),
+		})
+		require.Len(t, skews, 2)
+		assert.Equal(t, "Machines run different major versions of Docker: 27.5.1, 28.0.0, 28.1.1.", skews[0].Message)
+		assert.Equal(t, "Machines run different Caddy images: caddy:2.10.0, caddy:2.9.1.", skews[1].Message)
+		assert.Equal(t, []string{UpgradeCaddyCommand}, skews[1].Fixes)
+	})
+
+	t.Run("development builds and unavailable machines", func(t *testing.T) {
+		t.Parallel()
+		down := machine("m3", "", "", "")
+		down.Err = errors.New("machine is down")
+		skews := CheckVersionSkew("dev", []MachineVersions{
+			machine("m1", "dev", "28.1.1", ""),
+			machine("m2", "dev-abc", "28.1.1", ""),
+			down,
+		})
+		require.Len(t, skews, 1)
+		assert.False(t, skews[0].Unsupported)
+		assert.Equal(t, "Machines run different daemon versions: dev, dev-abc.", skews[0].Message)
+	})
+}
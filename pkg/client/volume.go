@@ -2,13 +2,18 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/bwlimit"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // CreateVolume creates a new volume on the specified machine.
@@ -132,3 +137,133 @@ func (cli *Client) RemoveVolume(ctx context.Context, machineNameOrID, volumeName
 
 	return nil
 }
+
+// CopyVolumeOptions defines the options for copying a volume between machines.
+type CopyVolumeOptions struct {
+	// TargetName is the name of the volume on the target machine. Default is the source volume name if empty.
+	TargetName string
+	// TargetCluster is the client of another cluster the target machine belongs to, e.g. to migrate a service
+	// between clusters. Default is the same cluster if nil.
+	TargetCluster *Client
+	// BandwidthLimit is the maximum number of bytes per second to transfer the data through the client at.
+	// Unlimited if 0.
+	BandwidthLimit int64
+}
+
+// CopyVolumeResult describes a completed volume copy.
+type CopyVolumeResult struct {
+	// Backend is the volume backend which format was used to transfer the data.
+	Backend string
+	// Size is the number of bytes transferred.
+	Size int64
+}
+
+// CopyVolume copies the data of a local volume on the source machine to a volume on the target machine creating it
+// if it doesn't exist. The data of an existing target volume is replaced so it must not be used by running containers.
+// The data is transferred with native send/receive if both volumes use the same btrfs or ZFS backend, or as a tar
+// stream otherwise.
+func (cli *Client) CopyVolume(
+	ctx context.Context, volumeName, sourceMachine, targetMachine string, opts CopyVolumeOptions,
+) (CopyVolumeResult, error) {
+	var result CopyVolumeResult
+	targetName := opts.TargetName
+	if targetName == "" {
+		targetName = volumeName
+	}
+
+	source, err := cli.InspectMachine(ctx, sourceMachine)
+	if err != nil {
+		return result, fmt.Errorf("inspect machine '%s': %w", sourceMachine, err)
+	}
+	targetCli := cli
+	if opts.TargetCluster != nil {
+		targetCli = opts.TargetCluster
+	}
+	target, err := targetCli.InspectMachine(ctx, targetMachine)
+	if err != nil {
+		return result, fmt.Errorf("inspect machine '%s': %w", targetMachine, err)
+	}
+	if targetCli == cli && source.Machine.Id == target.Machine.Id && volumeName == targetName {
+		return result, errors.New("source and target volumes are the same")
+	}
+	sourceCtx := proxyToMachine(ctx, source.Machine)
+	targetCtx := proxyToMachine(ctx, target.Machine)
+
+	sourceBackend, err := cli.Docker.GetVolumeBackend(sourceCtx, volumeName)
+	if err != nil {
+		if status.Convert(err).Code() == codes.NotFound {
+			return result, fmt.Errorf("volume '%s' not found on machine '%s'", volumeName, source.Machine.Name)
+		}
+		return result, fmt.Errorf("get backend of volume '%s': %w", volumeName, err)
+	}
+
+	volumes, err := targetCli.ListVolumes(ctx, &api.VolumeFilter{
+		Machines: []string{target.Machine.Id},
+		Names:    []string{targetName},
+	})
+	if err != nil {
+		return result, fmt.Errorf("list volumes: %w", err)
+	}
+	if len(volumes) == 0 {
+		if _, err = targetCli.CreateVolume(ctx, target.Machine.Id, volume.CreateOptions{Name: targetName}); err != nil {
+			return result, fmt.Errorf("create volume '%s' on machine '%s': %w", targetName, target.Machine.Name, err)
+		}
+	}
+	targetBackend, err := targetCli.Docker.GetVolumeBackend(targetCtx, targetName)
+	if err != nil {
+		return result, fmt.Errorf("get backend of volume '%s': %w", targetName, err)
+	}
+
+	// Native streams can only be received by the same backend, any backend can receive a tar stream.
+	result.Backend = api.VolumeBackendTar
+	if sourceBackend == targetBackend {
+		result.Backend = sourceBackend
+	}
+
+	pw := progress.ContextWriter(ctx)
+	eventID := fmt.Sprintf("Volume %s on %s", targetName, target.Machine.Name)
+	pw.Event(progress.Event{
+		ID:         eventID,
+		Status:     progress.Working,
+		StatusText: fmt.Sprintf("Copying from %s using %s", source.Machine.Name, result.Backend),
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pr, pwr := io.Pipe()
+	exportErr := make(chan error, 1)
+	go func() {
+		err := cli.Docker.ExportVolume(proxyToMachine(ctx, source.Machine), volumeName, result.Backend, pwr)
+		pwr.CloseWithError(err)
+		exportErr <- err
+	}()
+
+	counter := &countingReader{r: bwlimit.NewReader(ctx, pr, opts.BandwidthLimit)}
+	err = targetCli.Docker.ImportVolume(proxyToMachine(ctx, target.Machine), targetName, result.Backend, counter)
+	// Unblock the export if the import failed before consuming the whole stream.
+	pr.Close()
+	cancel()
+	if expErr := <-exportErr; err == nil && expErr != nil {
+		err = fmt.Errorf("export volume '%s' from machine '%s': %w", volumeName, source.Machine.Name, expErr)
+	}
+	if err != nil {
+		pw.Event(progress.ErrorEvent(eventID))
+		return result, fmt.Errorf("copy volume '%s' to machine '%s': %w", volumeName, target.Machine.Name, err)
+	}
+	result.Size = counter.n
+	pw.Event(progress.Event{ID: eventID, Status: progress.Done, StatusText: "Copied"})
+
+	return result, nil
+}
+
+// countingReader counts the number of bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
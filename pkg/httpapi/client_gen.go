@@ -0,0 +1,38 @@
+// Code generated by 'go generate ./pkg/httpapi'. DO NOT EDIT.
+
+package httpapi
+
+import (
+	"context"
+	"net/url"
+)
+
+// ListMachines sends GET /v1/machines to list the cluster machines with their membership states.
+func (c *Client) ListMachines(ctx context.Context) ([]Machine, error) {
+	var result []Machine
+	return result, c.get(ctx, "/v1/machines", nil, &result)
+}
+
+// ListServices sends GET /v1/services to list the services with their containers.
+func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
+	var result []Service
+	return result, c.get(ctx, "/v1/services", nil, &result)
+}
+
+// ListDeployments sends GET /v1/deployments to list the last deployment of each service.
+func (c *Client) ListDeployments(ctx context.Context) ([]Deployment, error) {
+	var result []Deployment
+	return result, c.get(ctx, "/v1/deployments", nil, &result)
+}
+
+// ListEvents sends GET /v1/events to list the events derived from the current cluster state.
+// The since parameter is optional. Only return events that occurred after the RFC 3339 timestamp or duration ago,
+// e.g. 1h.
+func (c *Client) ListEvents(ctx context.Context, since string) ([]Event, error) {
+	query := url.Values{}
+	if since != "" {
+		query.Set("since", since)
+	}
+	var result []Event
+	return result, c.get(ctx, "/v1/events", query, &result)
+}
@@ -0,0 +1,98 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(Error{Message: "invalid or missing bearer token"})
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/machines":
+			_ = json.NewEncoder(w).Encode([]Machine{{ID: "m1", Name: "machine-1", State: "UP"}})
+		case "/v1/events":
+			gotQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode([]Event{{Type: EventServiceDeployed, Message: "Service web deployed."}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+
+	client := NewClient(server.URL+"/", "secret", nil)
+	machines, err := client.ListMachines(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Machine{{ID: "m1", Name: "machine-1", State: "UP"}}, machines)
+
+	events, err := client.ListEvents(ctx, "2025-06-01T12:00:00Z")
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "since=2025-06-01T12%3A00%3A00Z", gotQuery)
+
+	_, err = client.ListServices(ctx)
+	assert.ErrorContains(t, err, "unexpected response status: 404")
+
+	_, err = NewClient(server.URL, "wrong", nil).ListMachines(ctx)
+	assert.ErrorContains(t, err, "invalid or missing bearer token")
+}
+
+// TestOpenAPISpec verifies that the schemas in the OpenAPI specification match the JSON encoding of the Go types.
+func TestOpenAPISpec(t *testing.T) {
+	t.Parallel()
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string       `yaml:"required"`
+				Properties map[string]any `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	require.NoError(t, yaml.Unmarshal(OpenAPISpec, &spec))
+
+	types := []any{Machine{}, Service{}, Container{}, Deployment{}, Event{}, Error{}}
+	require.Len(t, spec.Components.Schemas, len(types))
+
+	for _, v := range types {
+		typ := reflect.TypeOf(v)
+		schema, ok := spec.Components.Schemas[typ.Name()]
+		require.True(t, ok, "schema for %s not found", typ.Name())
+
+		var properties, required []string
+		for i := range typ.NumField() {
+			name, opts, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			properties = append(properties, name)
+			if opts != "omitempty" {
+				required = append(required, name)
+			}
+		}
+
+		var specProperties []string
+		for name := range schema.Properties {
+			specProperties = append(specProperties, name)
+		}
+		slices.Sort(properties)
+		slices.Sort(specProperties)
+		assert.Equal(t, properties, specProperties, "properties of %s", typ.Name())
+		assert.ElementsMatch(t, required, schema.Required, "required properties of %s", typ.Name())
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+var funcs = template.FuncMap{
+	"header":      func() string { return header },
+	"quote":       strconv.Quote,
+	"upper":       upperFirst,
+	"lower":       lowerFirst,
+	"tsType":      tsType,
+	"goSignature": goSignature,
+	"optional":    optional,
+	"splitLine":   func(s string) []string { return wrap(s, 112) },
+}
+
+// goClientTemplate generates the methods of the Go client in the httpapi package. The Client type and its get method
+// that sends the requests are defined in client.go.
+var goClientTemplate = template.Must(template.New("go").Funcs(funcs).Parse(`// {{header}}
+
+package httpapi
+
+import (
+	"context"
+{{- if .HasParameters}}
+	"net/url"
+{{- end}}
+)
+{{range .Operations}}
+{{- range splitLine (print (upper .ID) " sends GET " .Path " to " (lower .Summary))}}
+// {{.}}
+{{- end}}
+{{- range .Parameters}}
+{{- range splitLine (print "The " .Name " parameter is optional. " .Description)}}
+// {{.}}
+{{- end}}
+{{- end}}
+{{goSignature .}} {
+	{{- if .Parameters}}
+	query := url.Values{}
+	{{- range .Parameters}}
+	if {{.Name}} != "" {
+		query.Set({{quote .Name}}, {{.Name}})
+	}
+	{{- end}}
+	{{- end}}
+	var result []{{.Result}}
+	return result, c.get(ctx, {{quote .Path}}, {{if .Parameters}}query{{else}}nil{{end}}, &result)
+}
+{{end}}`))
+
+func generateGoClient(doc *document) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := goClientTemplate.Execute(&buf, doc); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// tsClientTemplate generates the TypeScript client with the interfaces of the component schemas.
+var tsClientTemplate = template.Must(template.New("ts").Funcs(funcs).Parse(`// {{header}}
+// TypeScript client for the read-only Uncloud HTTP API described by ../openapi.yaml.
+{{range .Schemas}}
+export interface {{.Name}} {
+{{- range .Properties}}
+{{- range splitLine .Schema.Description}}
+  // {{.}}
+{{- end}}
+  {{.Name}}{{optional .}}: {{tsType .Schema}};
+{{- end}}
+}
+{{end}}
+export class UncloudAPIError extends globalThis.Error {
+  constructor(
+    readonly status: number,
+    message: string,
+  ) {
+    super(message);
+  }
+}
+
+// Client for the read-only Uncloud HTTP API, e.g. new Client("http://127.0.0.1:51010", token).
+export class Client {
+  private readonly baseURL: string;
+
+  constructor(
+    baseURL: string,
+    private readonly token: string,
+  ) {
+    this.baseURL = baseURL.replace(/\/$/, "");
+  }
+{{range .Operations}}
+{{- range splitLine .Summary}}
+  // {{.}}
+{{- end}}
+{{- range .Parameters}}
+{{- range splitLine (print .Name ": " .Description)}}
+  // {{.}}
+{{- end}}
+{{- end}}
+  {{.ID}}({{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{$p.Name}}?: string{{end}}): Promise<{{.Result}}[]> {
+    {{- if .Parameters}}
+    const query = new URLSearchParams();
+    {{- range .Parameters}}
+    if ({{.Name}} !== undefined && {{.Name}} !== "") {
+      query.set({{quote .Name}}, {{.Name}});
+    }
+    {{- end}}
+    return this.get({{quote .Path}}, query);
+    {{- else}}
+    return this.get({{quote .Path}});
+    {{- end}}
+  }
+{{end}}
+  private async get<T>(path: string, query?: URLSearchParams): Promise<T> {
+    const qs = query && query.size > 0 ? ` + "`?${query}`" + ` : "";
+    const resp = await fetch(` + "`${this.baseURL}${path}${qs}`" + `, {
+      headers: {
+        Authorization: ` + "`Bearer ${this.token}`" + `,
+        Accept: "application/json",
+      },
+    });
+    if (!resp.ok) {
+      let message = ` + "`unexpected response status: ${resp.status}`" + `;
+      try {
+        message = ((await resp.json()) as Error).error || message;
+      } catch {
+        // The response body is not a JSON error.
+      }
+      throw new UncloudAPIError(resp.status, message);
+    }
+    return (await resp.json()) as T;
+  }
+}
+`))
+
+// goSignature returns the signature of the Go client method for the operation. The results are wrapped to the next
+// line if the signature is too long.
+func goSignature(op operation) string {
+	params := "ctx context.Context"
+	for _, p := range op.Parameters {
+		params += ", " + p.Name + " string"
+	}
+	sig := fmt.Sprintf("func (c *Client) %s(%s) ([]%s, error)", upperFirst(op.ID), params, op.Result)
+	if len(sig) > 110 {
+		sig = fmt.Sprintf("func (c *Client) %s(\n\t%s,\n) ([]%s, error)", upperFirst(op.ID), params, op.Result)
+	}
+	return sig
+}
+
+// tsType returns the TypeScript type for the schema.
+func tsType(s *schema) string {
+	switch {
+	case s.Ref != "":
+		return s.Ref
+	case len(s.Enum) > 0:
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = strconv.Quote(v)
+		}
+		return strings.Join(values, " | ")
+	case s.Type == "array":
+		return tsType(s.Items) + "[]"
+	case s.Type == "integer":
+		return "number"
+	}
+	return s.Type
+}
+
+// optional returns the TypeScript optional property marker if the property isn't required.
+func optional(p property) string {
+	if p.Required {
+		return ""
+	}
+	return "?"
+}
+
+func upperFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// wrap splits the text into lines of at most width characters at word boundaries.
+func wrap(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,113 @@
+// Command gen generates the OpenAPI specification of the HTTP API from the Go types in types.go and the operations
+// below, and then generates the Go and TypeScript clients from the specification. It's run by go generate
+// in the httpapi package directory:
+//
+//	go generate ./pkg/httpapi
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// header is the comment that marks the generated files.
+const header = "Code generated by 'go generate ./pkg/httpapi'. DO NOT EDIT."
+
+// Generated file paths relative to the httpapi package directory.
+const (
+	specFile     = "openapi.yaml"
+	goClientFile = "client_gen.go"
+	tsClientFile = "typescript/client.ts"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "Path to the httpapi package directory.")
+	flag.Parse()
+
+	files, err := generate(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(*dir, name), content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the contents of the generated files by their paths relative to the httpapi package directory.
+func generate(dir string) (map[string][]byte, error) {
+	schemas, err := parseSchemas(filepath.Join(dir, "types.go"))
+	if err != nil {
+		return nil, fmt.Errorf("parse schemas: %w", err)
+	}
+	doc := newDocument(schemas)
+
+	spec, err := doc.marshal()
+	if err != nil {
+		return nil, fmt.Errorf("marshal OpenAPI specification: %w", err)
+	}
+	goClient, err := generateGoClient(doc)
+	if err != nil {
+		return nil, fmt.Errorf("generate Go client: %w", err)
+	}
+	var tsClient bytes.Buffer
+	if err = tsClientTemplate.Execute(&tsClient, doc); err != nil {
+		return nil, fmt.Errorf("generate TypeScript client: %w", err)
+	}
+
+	return map[string][]byte{
+		specFile:     spec,
+		goClientFile: goClient,
+		tsClientFile: tsClient.Bytes(),
+	}, nil
+}
+
+// operations are the operations of the HTTP API. Each operation returns a JSON array of the result schema.
+var operations = []operation{
+	{
+		Path:              "/v1/machines",
+		ID:                "listMachines",
+		Summary:           "List the cluster machines with their membership states.",
+		Result:            "Machine",
+		ResultDescription: "Cluster machines ordered by name.",
+		Errors: []statusResponse{
+			{Code: "403", Response: errorResponse("Tenant API tokens can't list the cluster machines.")},
+		},
+	},
+	{
+		Path:              "/v1/services",
+		ID:                "listServices",
+		Summary:           "List the services with their containers.",
+		Result:            "Service",
+		ResultDescription: "Services ordered by name.",
+	},
+	{
+		Path:              "/v1/deployments",
+		ID:                "listDeployments",
+		Summary:           "List the last deployment of each service.",
+		Result:            "Deployment",
+		ResultDescription: "Deployments ordered by the deployment time.",
+	},
+	{
+		Path:    "/v1/events",
+		ID:      "listEvents",
+		Summary: "List the events derived from the current cluster state.",
+		Parameters: []parameter{
+			{
+				Name: "since",
+				Description: "Only return events that occurred after the RFC 3339 timestamp or duration ago, " +
+					"e.g. 1h.",
+			},
+		},
+		Result:            "Event",
+		ResultDescription: "Events ordered by time.",
+		Errors: []statusResponse{
+			{Code: "400", Response: errorResponse("Invalid since parameter.")},
+		},
+	},
+}
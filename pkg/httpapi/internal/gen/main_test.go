@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerate verifies that the generated files are up to date with the Go types and operations.
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("..", "..")
+	files, err := generate(dir)
+	require.NoError(t, err)
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "%s is out of date, run 'go generate ./pkg/httpapi'", name)
+	}
+}
+
+func TestParseSchemas(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "types.go")
+	require.NoError(t, os.WriteFile(path, []byte(`package httpapi
+
+type Item struct {
+	Parent *Item `+"`json:\"parent\"`"+`
+}
+`), 0o644))
+
+	_, err := parseSchemas(path)
+	assert.ErrorContains(t, err, "field Item.Parent: unsupported type *ast.StarExpr")
+
+	require.NoError(t, os.WriteFile(path, []byte(`package httpapi
+
+import "time"
+
+type Kind string
+
+const (
+	KindA Kind = "a"
+	KindB Kind = "b"
+)
+
+type Item struct {
+	// Name is the item name.
+	Name     string    `+"`json:\"name\"`"+`
+	Kind     Kind      `+"`json:\"kind\"`"+`
+	Mode     string    `+"`json:\"mode,omitempty\" enum:\"x,y\"`"+`
+	Tags     []string  `+"`json:\"tags,omitempty\"`"+`
+	Created  time.Time `+"`json:\"created\"`"+`
+	Count    int       `+"`json:\"count\"`"+`
+	Children []Item    `+"`json:\"children\"`"+`
+}
+`), 0o644))
+
+	schemas, err := parseSchemas(path)
+	require.NoError(t, err)
+	assert.Equal(t, []*schema{{
+		Name: "Item",
+		Type: "object",
+		Properties: []property{
+			{Name: "name", Required: true, Schema: &schema{Type: "string", Description: "Name is the item name."}},
+			{Name: "kind", Required: true, Schema: &schema{Type: "string", Enum: []string{"a", "b"}}},
+			{Name: "mode", Schema: &schema{Type: "string", Enum: []string{"x", "y"}}},
+			{Name: "tags", Schema: &schema{Type: "array", Items: &schema{Type: "string"}}},
+			{Name: "created", Required: true, Schema: &schema{Type: "string", Format: "date-time"}},
+			{Name: "count", Required: true, Schema: &schema{Type: "integer"}},
+			{Name: "children", Required: true, Schema: &schema{Type: "array", Items: &schema{Ref: "Item"}}},
+		},
+	}}, schemas)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/goccy/go-yaml"
+)
+
+const apiDescription = "Read-only HTTP JSON API exposing the cluster state from the cluster store. " +
+	"The API is served by the machine\n" +
+	"daemon when it's started with the --http-api-addr and --http-api-token-file flags. " +
+	"A tenant API token created\n" +
+	"with 'uc tenant token create' can be used instead of the API token to only get the state of the tenant " +
+	"services.\n"
+
+// document is the OpenAPI 3 specification of the HTTP API.
+type document struct {
+	Operations []operation
+	Schemas    []*schema
+}
+
+// operation is a GET operation that returns a JSON array of the Result component schema.
+type operation struct {
+	Path       string
+	ID         string
+	Summary    string
+	Parameters []parameter
+	// Result is the name of the component schema of the items in the response array.
+	Result            string
+	ResultDescription string
+	// Errors are the error responses specific to the operation in addition to the common ones.
+	Errors []statusResponse
+}
+
+// parameter is a string query parameter.
+type parameter struct {
+	Name        string
+	Description string
+}
+
+type statusResponse struct {
+	Code     string
+	Response yaml.MapSlice
+}
+
+func newDocument(schemas []*schema) *document {
+	return &document{Operations: operations, Schemas: schemas}
+}
+
+// HasParameters returns true if any of the operations has parameters.
+func (d *document) HasParameters() bool {
+	return slices.ContainsFunc(d.Operations, func(op operation) bool { return len(op.Parameters) > 0 })
+}
+
+// errorResponse returns a response with the Error schema.
+func errorResponse(description string) yaml.MapSlice {
+	return yaml.MapSlice{
+		{Key: "description", Value: description},
+		{Key: "content", Value: jsonContent(refSchema("Error"))},
+	}
+}
+
+func jsonContent(s yaml.MapSlice) yaml.MapSlice {
+	return yaml.MapSlice{{Key: "application/json", Value: yaml.MapSlice{{Key: "schema", Value: s}}}}
+}
+
+func refSchema(name string) yaml.MapSlice {
+	return yaml.MapSlice{{Key: "$ref", Value: "#/components/schemas/" + name}}
+}
+
+// marshal returns the specification in YAML format.
+func (d *document) marshal() ([]byte, error) {
+	paths := yaml.MapSlice{}
+	for _, op := range d.Operations {
+		paths = append(paths, yaml.MapItem{Key: op.Path, Value: yaml.MapSlice{{Key: "get", Value: op.spec()}}})
+	}
+	// The specification itself is served without authentication.
+	specOperation := yaml.MapSlice{
+		{Key: "operationId", Value: "getOpenAPISpec"},
+		{Key: "summary", Value: "Get this OpenAPI specification."},
+		{Key: "security", Value: []any{}},
+		{Key: "responses", Value: yaml.MapSlice{{Key: "200", Value: yaml.MapSlice{
+			{Key: "description", Value: "OpenAPI specification of the API."},
+			{Key: "content", Value: yaml.MapSlice{{Key: "application/yaml", Value: yaml.MapSlice{
+				{Key: "schema", Value: yaml.MapSlice{{Key: "type", Value: "string"}}},
+			}}}},
+		}}}},
+	}
+	paths = append(paths, yaml.MapItem{Key: "/v1/openapi.yaml", Value: yaml.MapSlice{{Key: "get", Value: specOperation}}})
+
+	schemas := yaml.MapSlice{}
+	for _, s := range d.Schemas {
+		schemas = append(schemas, yaml.MapItem{Key: s.Name, Value: s.spec()})
+	}
+
+	spec := yaml.MapSlice{
+		{Key: "openapi", Value: "3.0.3"},
+		{Key: "info", Value: yaml.MapSlice{
+			{Key: "title", Value: "Uncloud HTTP API"},
+			{Key: "description", Value: apiDescription},
+			{Key: "version", Value: "v1"},
+		}},
+		{Key: "servers", Value: []any{yaml.MapSlice{{Key: "url", Value: "http://127.0.0.1:51010"}}}},
+		{Key: "security", Value: []any{yaml.MapSlice{{Key: "bearerAuth", Value: []any{}}}}},
+		{Key: "paths", Value: paths},
+		{Key: "components", Value: yaml.MapSlice{
+			{Key: "securitySchemes", Value: yaml.MapSlice{{Key: "bearerAuth", Value: yaml.MapSlice{
+				{Key: "type", Value: "http"},
+				{Key: "scheme", Value: "bearer"},
+			}}}},
+			{Key: "responses", Value: yaml.MapSlice{
+				{Key: "Unauthorized", Value: errorResponse("Invalid or missing bearer token.")},
+				{Key: "InternalError", Value: errorResponse("Failed to read the cluster state.")},
+			}},
+			{Key: "schemas", Value: schemas},
+		}},
+	}
+
+	out, err := yaml.MarshalWithOptions(spec, yaml.UseLiteralStyleIfMultiline(true))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("# "+header+"\n"), out...), nil
+}
+
+func (op operation) spec() yaml.MapSlice {
+	responses := yaml.MapSlice{
+		{Key: "200", Value: yaml.MapSlice{
+			{Key: "description", Value: op.ResultDescription},
+			{Key: "content", Value: jsonContent(yaml.MapSlice{
+				{Key: "type", Value: "array"},
+				{Key: "items", Value: refSchema(op.Result)},
+			})},
+		}},
+		{Key: "401", Value: yaml.MapSlice{{Key: "$ref", Value: "#/components/responses/Unauthorized"}}},
+	}
+	for _, r := range op.Errors {
+		responses = append(responses, yaml.MapItem{Key: r.Code, Value: r.Response})
+	}
+	responses = append(responses,
+		yaml.MapItem{Key: "500", Value: yaml.MapSlice{{Key: "$ref", Value: "#/components/responses/InternalError"}}})
+	slices.SortStableFunc(responses, func(a, b yaml.MapItem) int {
+		return cmp.Compare(a.Key.(string), b.Key.(string))
+	})
+
+	spec := yaml.MapSlice{
+		{Key: "operationId", Value: op.ID},
+		{Key: "summary", Value: op.Summary},
+	}
+	if len(op.Parameters) > 0 {
+		var params []any
+		for _, p := range op.Parameters {
+			params = append(params, yaml.MapSlice{
+				{Key: "name", Value: p.Name},
+				{Key: "in", Value: "query"},
+				{Key: "description", Value: p.Description},
+				{Key: "schema", Value: yaml.MapSlice{{Key: "type", Value: "string"}}},
+			})
+		}
+		spec = append(spec, yaml.MapItem{Key: "parameters", Value: params})
+	}
+	return append(spec, yaml.MapItem{Key: "responses", Value: responses})
+}
+
+func (s *schema) spec() yaml.MapSlice {
+	if s.Ref != "" {
+		return refSchema(s.Ref)
+	}
+
+	spec := yaml.MapSlice{{Key: "type", Value: s.Type}}
+	if s.Format != "" {
+		spec = append(spec, yaml.MapItem{Key: "format", Value: s.Format})
+	}
+	if s.Description != "" {
+		spec = append(spec, yaml.MapItem{Key: "description", Value: s.Description})
+	}
+	if len(s.Enum) > 0 {
+		spec = append(spec, yaml.MapItem{Key: "enum", Value: s.Enum})
+	}
+	if s.Items != nil {
+		spec = append(spec, yaml.MapItem{Key: "items", Value: s.Items.spec()})
+	}
+	if len(s.Properties) > 0 {
+		var required []string
+		properties := yaml.MapSlice{}
+		for _, p := range s.Properties {
+			if p.Required {
+				required = append(required, p.Name)
+			}
+			properties = append(properties, yaml.MapItem{Key: p.Name, Value: p.Schema.spec()})
+		}
+		if len(required) > 0 {
+			spec = append(spec, yaml.MapItem{Key: "required", Value: required})
+		}
+		spec = append(spec, yaml.MapItem{Key: "properties", Value: properties})
+	}
+	return spec
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// schema is an OpenAPI schema object. Only the subset used by the HTTP API types is supported.
+type schema struct {
+	Name string
+	// Ref is the name of the component schema this schema refers to.
+	Ref         string
+	Type        string
+	Format      string
+	Description string
+	Enum        []string
+	Items       *schema
+	Properties  []property
+}
+
+type property struct {
+	Name     string
+	Required bool
+	Schema   *schema
+}
+
+// parseSchemas returns the schemas of the exported struct types declared in the Go file in the declaration order.
+// The JSON field names are taken from the json tags, the fields without omitempty are required. The doc comments
+// of the fields become the descriptions. The allowed values of a string field are listed in its enum tag or are
+// the typed constants declared in the file if the field has a named string type.
+func parseSchemas(path string) ([]*schema, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	p := schemaParser{enums: make(map[string][]string)}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			typ, ok := valueSpec.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			for _, v := range valueSpec.Values {
+				if lit, ok := v.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					value, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						return nil, fmt.Errorf("unquote constant value: %w", err)
+					}
+					p.enums[typ.Name] = append(p.enums[typ.Name], value)
+				}
+			}
+		}
+	}
+
+	var schemas []*schema
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			st, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || !typeSpec.Name.IsExported() {
+				continue
+			}
+			s := &schema{Name: typeSpec.Name.Name, Type: "object"}
+			for _, field := range st.Fields.List {
+				prop, err := p.parseProperty(field)
+				if err != nil {
+					return nil, fmt.Errorf("field %s.%s: %w", s.Name, field.Names[0].Name, err)
+				}
+				s.Properties = append(s.Properties, prop)
+			}
+			schemas = append(schemas, s)
+		}
+	}
+	return schemas, nil
+}
+
+// schemaParser converts the Go struct fields to the schema properties.
+type schemaParser struct {
+	// enums are the values of the typed string constants by their type name.
+	enums map[string][]string
+}
+
+func (p schemaParser) parseProperty(field *ast.Field) (property, error) {
+	if len(field.Names) != 1 || field.Tag == nil {
+		return property{}, fmt.Errorf("expected a single named field with a json tag")
+	}
+	tagValue, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return property{}, fmt.Errorf("unquote tag: %w", err)
+	}
+	tag := reflect.StructTag(tagValue)
+	name, opts, _ := strings.Cut(tag.Get("json"), ",")
+	if name == "" {
+		return property{}, fmt.Errorf("json tag without name")
+	}
+
+	s, err := p.parseType(field.Type)
+	if err != nil {
+		return property{}, err
+	}
+	if enum := tag.Get("enum"); enum != "" {
+		s.Enum = strings.Split(enum, ",")
+	}
+	if field.Doc != nil {
+		s.Description = strings.Join(strings.Fields(field.Doc.Text()), " ")
+	}
+
+	return property{Name: name, Required: opts != "omitempty", Schema: s}, nil
+}
+
+func (p schemaParser) parseType(expr ast.Expr) (*schema, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return &schema{Type: "string"}, nil
+		case "bool":
+			return &schema{Type: "boolean"}, nil
+		case "int", "int64":
+			return &schema{Type: "integer"}, nil
+		}
+		if enum, ok := p.enums[t.Name]; ok {
+			return &schema{Type: "string", Enum: enum}, nil
+		}
+		if t.IsExported() {
+			return &schema{Ref: t.Name}, nil
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return &schema{Type: "string", Format: "date-time"}, nil
+		}
+	case *ast.ArrayType:
+		items, err := p.parseType(t.Elt)
+		if err != nil {
+			return nil, err
+		}
+		return &schema{Type: "array", Items: items}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", expr)
+}
@@ -0,0 +1,13 @@
+package httpapi
+
+import _ "embed"
+
+//go:generate go run ./internal/gen
+
+// OpenAPISpec is the OpenAPI 3 specification of the HTTP API in YAML format.
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
+
+// OpenAPISpecPath is the path the API serves its OpenAPI specification on without authentication.
+const OpenAPISpecPath = "/v1/openapi.yaml"
@@ -0,0 +1,83 @@
+// Package httpapi defines the types of the read-only HTTP JSON API served by the machine daemon and a client for it.
+// The API contract is described by the OpenAPI specification in openapi.yaml that is also served by the API. The
+// specification is generated from the types in this file and the operations in internal/gen, and the Go and
+// TypeScript clients are generated from the specification with 'go generate ./pkg/httpapi'. The enum tags list
+// the allowed values of the string fields in the specification.
+package httpapi
+
+import "time"
+
+// Machine is a cluster machine returned by the /v1/machines endpoint.
+type Machine struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// State is the membership state of the machine: UP, SUSPECT, DOWN, or UNKNOWN.
+	State        string   `json:"state" enum:"UNKNOWN,UP,SUSPECT,DOWN"`
+	Cordoned     bool     `json:"cordoned"`
+	Arch         string   `json:"arch,omitempty"`
+	PublicIP     string   `json:"public_ip,omitempty"`
+	ManagementIP string   `json:"management_ip,omitempty"`
+	Subnet       string   `json:"subnet,omitempty"`
+	Endpoints    []string `json:"endpoints,omitempty"`
+}
+
+// Service is a service with its containers returned by the /v1/services endpoint.
+type Service struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Mode       string      `json:"mode" enum:"replicated,global"`
+	Project    string      `json:"project,omitempty"`
+	Containers []Container `json:"containers"`
+}
+
+// Container is a service container running on a machine.
+type Container struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	MachineID string `json:"machine_id"`
+	Image     string `json:"image"`
+	// State is the Docker container state, e.g. running, exited, or restarting.
+	State     string    `json:"state"`
+	Healthy   bool      `json:"healthy"`
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the time the container record was last updated in the cluster store.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Deployment is the last deployment of a service returned by the /v1/deployments endpoint.
+type Deployment struct {
+	ServiceID   string    `json:"service_id"`
+	ServiceName string    `json:"service_name"`
+	DeployedAt  time.Time `json:"deployed_at"`
+	// PreviousImage is the image the service was running before the deployment.
+	PreviousImage string `json:"previous_image"`
+	// Snapshots is the number of volume snapshots taken before the deployment.
+	Snapshots int `json:"snapshots"`
+}
+
+// EventType is the type of event returned by the /v1/events endpoint.
+type EventType string
+
+const (
+	EventContainerCreated   EventType = "container.created"
+	EventContainerExited    EventType = "container.exited"
+	EventContainerPreempted EventType = "container.preempted"
+	EventServiceDeployed    EventType = "service.deployed"
+	EventMachineUpdate      EventType = "machine.update"
+)
+
+// Event is a notable change in the cluster returned by the /v1/events endpoint. Events are derived from the current
+// cluster state so only the most recent event of each kind is available for a container, service, or machine.
+type Event struct {
+	Time        time.Time `json:"time"`
+	Type        EventType `json:"type"`
+	MachineID   string    `json:"machine_id,omitempty"`
+	ServiceName string    `json:"service_name,omitempty"`
+	ContainerID string    `json:"container_id,omitempty"`
+	Message     string    `json:"message"`
+}
+
+// Error is the response body returned when a request fails.
+type Error struct {
+	Message string `json:"error"`
+}
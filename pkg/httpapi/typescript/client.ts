@@ -0,0 +1,125 @@
+// Code generated by 'go generate ./pkg/httpapi'. DO NOT EDIT.
+// TypeScript client for the read-only Uncloud HTTP API described by ../openapi.yaml.
+
+export interface Machine {
+  id: string;
+  name: string;
+  // State is the membership state of the machine: UP, SUSPECT, DOWN, or UNKNOWN.
+  state: "UNKNOWN" | "UP" | "SUSPECT" | "DOWN";
+  cordoned: boolean;
+  arch?: string;
+  public_ip?: string;
+  management_ip?: string;
+  subnet?: string;
+  endpoints?: string[];
+}
+
+export interface Service {
+  id: string;
+  name: string;
+  mode: "replicated" | "global";
+  project?: string;
+  containers: Container[];
+}
+
+export interface Container {
+  id: string;
+  name: string;
+  machine_id: string;
+  image: string;
+  // State is the Docker container state, e.g. running, exited, or restarting.
+  state: string;
+  healthy: boolean;
+  created_at: string;
+  // UpdatedAt is the time the container record was last updated in the cluster store.
+  updated_at: string;
+}
+
+export interface Deployment {
+  service_id: string;
+  service_name: string;
+  deployed_at: string;
+  // PreviousImage is the image the service was running before the deployment.
+  previous_image: string;
+  // Snapshots is the number of volume snapshots taken before the deployment.
+  snapshots: number;
+}
+
+export interface Event {
+  time: string;
+  type: "container.created" | "container.exited" | "container.preempted" | "service.deployed" | "machine.update";
+  machine_id?: string;
+  service_name?: string;
+  container_id?: string;
+  message: string;
+}
+
+export interface Error {
+  error: string;
+}
+
+export class UncloudAPIError extends globalThis.Error {
+  constructor(
+    readonly status: number,
+    message: string,
+  ) {
+    super(message);
+  }
+}
+
+// Client for the read-only Uncloud HTTP API, e.g. new Client("http://127.0.0.1:51010", token).
+export class Client {
+  private readonly baseURL: string;
+
+  constructor(
+    baseURL: string,
+    private readonly token: string,
+  ) {
+    this.baseURL = baseURL.replace(/\/$/, "");
+  }
+
+  // List the cluster machines with their membership states.
+  listMachines(): Promise<Machine[]> {
+    return this.get("/v1/machines");
+  }
+
+  // List the services with their containers.
+  listServices(): Promise<Service[]> {
+    return this.get("/v1/services");
+  }
+
+  // List the last deployment of each service.
+  listDeployments(): Promise<Deployment[]> {
+    return this.get("/v1/deployments");
+  }
+
+  // List the events derived from the current cluster state.
+  // since: Only return events that occurred after the RFC 3339 timestamp or duration ago, e.g. 1h.
+  listEvents(since?: string): Promise<Event[]> {
+    const query = new URLSearchParams();
+    if (since !== undefined && since !== "") {
+      query.set("since", since);
+    }
+    return this.get("/v1/events", query);
+  }
+
+  private async get<T>(path: string, query?: URLSearchParams): Promise<T> {
+    const qs = query && query.size > 0 ? `?${query}` : "";
+    const resp = await fetch(`${this.baseURL}${path}${qs}`, {
+      headers: {
+        Authorization: `Bearer ${this.token}`,
+        Accept: "application/json",
+      },
+    });
+    if (!resp.ok) {
+      let message = `unexpected response status: ${resp.status}`;
+      try {
+        message = ((await resp.json()) as Error).error || message;
+      } catch {
+        // The response body is not a JSON error.
+      }
+      throw new UncloudAPIError(resp.status, message);
+    }
+    return (await resp.json()) as T;
+  }
+}
@@ -4,6 +4,8 @@ set -euo pipefail
 
 INSTALL_BIN_DIR=${INSTALL_BIN_DIR:-/usr/local/bin}
 INSTALL_SYSTEMD_DIR=${INSTALL_SYSTEMD_DIR:-/etc/systemd/system}
+# Maximum time the machine daemon can delay the system shutdown to gracefully stop the containers.
+LOGIND_INHIBIT_DELAY_MAX_SEC=${LOGIND_INHIBIT_DELAY_MAX_SEC:-60}
 
 UNCLOUD_GITHUB_URL="https://github.com/psviderski/uncloud"
 UNCLOUD_VERSION=${UNCLOUD_VERSION:-latest}
@@ -12,7 +14,11 @@ UNCLOUD_VERSION=${UNCLOUD_VERSION#v}
 UNCLOUD_USER="uncloud"
 # Add the specified Linux user to group $UNCLOUD_USER to allow the user to run uncloud commands without sudo.
 UNCLOUD_GROUP_ADD_USER=${UNCLOUD_GROUP_ADD_USER:-}
+UNCLOUD_READONLY_GROUP="uncloud-readonly"
 UNCLOUD_DATA_DIR=${UNCLOUD_DATA_DIR:-/var/lib/uncloud}
+# Limit the download rate of the binaries in bytes per second (curl --limit-rate format, e.g. 1048576 or 1M)
+# to avoid saturating a slow uplink.
+UNCLOUD_DOWNLOAD_LIMIT_RATE=${UNCLOUD_DOWNLOAD_LIMIT_RATE:-}
 
 CORROSION_GITHUB_URL="https://github.com/psviderski/corrosion"
 CORROSION_VERSION=${CORROSION_VERSION:-latest}
@@ -26,10 +32,79 @@ error() {
     exit 1
 }
 
+# Download a URL to a file with curl limiting the rate if UNCLOUD_DOWNLOAD_LIMIT_RATE is set.
+download() {
+    local url="$1"
+    local path="$2"
+    if [ -n "${UNCLOUD_DOWNLOAD_LIMIT_RATE}" ]; then
+        curl -fsSL --limit-rate "${UNCLOUD_DOWNLOAD_LIMIT_RATE}" -o "${path}" "${url}"
+    else
+        curl -fsSL -o "${path}" "${url}"
+    fi
+}
+
 command_exists() {
     command -v "$1" >/dev/null 2>&1
 }
 
+# Distro provisioner selected by detect_distro. Each provisioner implements the following functions:
+#   <provisioner>_install_packages - install the packages required by the install script and Uncloud daemon.
+#   <provisioner>_install_docker   - install Docker Engine and ensure docker.service is enabled and started.
+PROVISIONER=""
+DISTRO_ID=""
+DISTRO_NAME=""
+
+detect_distro() {
+    local id="" id_like="" name=""
+    if [[ -f /etc/os-release ]]; then
+        # shellcheck disable=SC1091
+        id=$(. /etc/os-release && echo "${ID:-}")
+        # shellcheck disable=SC1091
+        id_like=$(. /etc/os-release && echo "${ID_LIKE:-}")
+        # shellcheck disable=SC1091
+        name=$(. /etc/os-release && echo "${PRETTY_NAME:-${NAME:-}}")
+    fi
+    DISTRO_ID="${id:-unknown}"
+    DISTRO_NAME="${name:-${DISTRO_ID}}"
+
+    # Match the distro ID first, then fall back to the distros it's derived from (ID_LIKE).
+    local candidate
+    for candidate in "${id}" ${id_like}; do
+        case "${candidate}" in
+            debian|ubuntu|raspbian|linuxmint|pop)
+                PROVISIONER="debian"
+                ;;
+            amzn)
+                PROVISIONER="amzn"
+                ;;
+            fedora|rhel|centos|rocky|almalinux|ol)
+                PROVISIONER="rhel"
+                ;;
+            opensuse*|sles|suse)
+                PROVISIONER="suse"
+                ;;
+            arch|archarm|manjaro|endeavouros)
+                PROVISIONER="arch"
+                ;;
+            alpine)
+                PROVISIONER="alpine"
+                ;;
+            nixos)
+                PROVISIONER="nixos"
+                ;;
+        esac
+        if [[ -n "${PROVISIONER}" ]]; then
+            break
+        fi
+    done
+
+    if [[ -z "${PROVISIONER}" ]]; then
+        # Unknown distros fall back to the generic provisioner that relies on the Docker convenience script.
+        PROVISIONER="generic"
+    fi
+    log "✓ Detected Linux distribution: ${DISTRO_NAME} (provisioner: ${PROVISIONER})."
+}
+
 verify_system() {
   if [[ "$(uname -s)" != "Linux" ]]; then
       error "Uncloud machine must be a Linux system. Your system ($(uname -s)) is not supported."
@@ -42,12 +117,131 @@ verify_system() {
 Your system architecture ($arch) is not supported."
   fi
 
+  case "${PROVISIONER}" in
+      nixos)
+          error "NixOS is configured declaratively and can't be provisioned with the install script. \
+Use the NixOS module from ${UNCLOUD_GITHUB_URL}/blob/main/scripts/nixos/uncloud.nix instead \
+and add the machine with 'uc machine add --no-install'."
+          ;;
+      alpine)
+          # OpenRC support is deferred as the machine daemon manages the Corrosion and Docker services with systemd.
+          error "Alpine Linux is not supported yet as it uses OpenRC instead of systemd. The Uncloud machine daemon \
+requires systemd to manage its services. Use a systemd-based distribution such as Debian or Ubuntu instead."
+          ;;
+  esac
+
   if [[ ! -d /run/systemd/system ]]; then
       error "Cannot find systemd to use as a service manager for the Uncloud machine daemon. \
 Uncloud supports only systemd-based Linux systems for now."
   fi
 }
 
+# Generic provisioner for unknown distros.
+
+generic_install_packages() {
+    local missing=()
+    local cmd
+    for cmd in curl tar useradd gpasswd; do
+        if ! command_exists "${cmd}"; then
+            missing+=("${cmd}")
+        fi
+    done
+    if [[ ${#missing[@]} -gt 0 ]]; then
+        error "Required commands are missing: ${missing[*]}. \
+Please install them manually as your Linux distribution (${DISTRO_NAME}) is not recognised by the install script."
+    fi
+}
+
+generic_install_docker() {
+    curl -fsSL https://get.docker.com | sh
+}
+
+# Debian, Ubuntu, and derivatives.
+
+debian_install_packages() {
+    if command_exists curl && command_exists tar && command_exists gpasswd; then
+        return
+    fi
+    DEBIAN_FRONTEND=noninteractive apt-get update -qq
+    DEBIAN_FRONTEND=noninteractive apt-get install -y -qq ca-certificates curl tar passwd
+}
+
+debian_install_docker() {
+    curl -fsSL https://get.docker.com | sh
+}
+
+# Fedora, RHEL, CentOS, Rocky Linux, AlmaLinux, Oracle Linux.
+
+rhel_install_packages() {
+    if command_exists curl && command_exists tar && command_exists gpasswd; then
+        return
+    fi
+    dnf install -y -q ca-certificates curl tar shadow-utils
+}
+
+rhel_install_docker() {
+    if [[ "${DISTRO_ID}" == "fedora" || "${DISTRO_ID}" == "centos" || "${DISTRO_ID}" == "rhel" ]]; then
+        # The Docker convenience script supports these distros directly.
+        curl -fsSL https://get.docker.com | sh
+    else
+        # Use the Docker CE repository for CentOS which is compatible with other RHEL rebuilds.
+        dnf install -y -q dnf-plugins-core
+        dnf config-manager --add-repo https://download.docker.com/linux/centos/docker-ce.repo
+        dnf install -y -q docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-compose-plugin
+    fi
+    systemctl enable --now docker.service
+}
+
+# Amazon Linux.
+
+amzn_install_packages() {
+    if command_exists curl && command_exists tar && command_exists gpasswd; then
+        return
+    fi
+    # Amazon Linux 2023 ships curl-minimal that conflicts with curl, so install the missing commands only.
+    yum install -y -q tar shadow-utils
+}
+
+amzn_install_docker() {
+    # Docker isn't available in the Docker CE repositories for Amazon Linux but is packaged by Amazon.
+    yum install -y -q docker
+    systemctl enable --now docker.service
+}
+
+# openSUSE and SUSE Linux Enterprise.
+
+suse_install_packages() {
+    if command_exists curl && command_exists tar && command_exists gpasswd; then
+        return
+    fi
+    zypper --non-interactive --quiet install ca-certificates curl tar shadow
+}
+
+suse_install_docker() {
+    zypper --non-interactive --quiet install docker
+    systemctl enable --now docker.service
+}
+
+# Arch Linux and derivatives.
+
+arch_install_packages() {
+    if command_exists curl && command_exists tar && command_exists gpasswd; then
+        return
+    fi
+    pacman -Sy --noconfirm --needed ca-certificates curl tar shadow
+}
+
+arch_install_docker() {
+    pacman -Sy --noconfirm --needed docker
+    systemctl enable --now docker.service
+}
+
+install_packages() {
+    log "⏳ Installing required packages..."
+    "${PROVISIONER}_install_packages"
+    log "✓ Required packages installed."
+}
+
 install_docker() {
     if command_exists dockerd; then
         log "✓ Docker is already installed."
@@ -56,7 +250,7 @@ install_docker() {
     fi
 
     log "⏳ Installing Docker..."
-    curl -fsSL https://get.docker.com | sh
+    "${PROVISIONER}_install_docker"
     log "✓ Docker installed successfully."
 }
 
@@ -71,6 +265,16 @@ create_uncloud_user_and_group() {
         log "✓ Linux user and group '${UNCLOUD_USER}' created."
     fi
 
+    # Users in this group can only access the read-only API socket, e.g. for monitoring hosts.
+    if getent group "${UNCLOUD_READONLY_GROUP}" &> /dev/null; then
+        log "✓ Linux group '${UNCLOUD_READONLY_GROUP}' already exists."
+    else
+        if ! groupadd --system "${UNCLOUD_READONLY_GROUP}"; then
+            error "Failed to create Linux group '${UNCLOUD_READONLY_GROUP}'."
+        fi
+        log "✓ Linux group '${UNCLOUD_READONLY_GROUP}' created."
+    fi
+
     if [ -n "${UNCLOUD_GROUP_ADD_USER}" ]; then
         if ! gpasswd --add "${UNCLOUD_GROUP_ADD_USER}" "${UNCLOUD_USER}" > /dev/null; then
             error "Failed to add user '${UNCLOUD_GROUP_ADD_USER}' to group '${UNCLOUD_USER}'."
@@ -125,7 +329,7 @@ install_uncloud_binaries() {
     local uninstall_download_path="${tmp_dir}/uninstall.sh"
 
     log "⏳ Downloading uncloudd binary: ${uncloudd_url}"
-    if ! curl -fsSL -o "${uncloudd_download_path}" "${uncloudd_url}"; then
+    if ! download "${uncloudd_url}" "${uncloudd_download_path}"; then
         error "Failed to download uncloudd binary."
     fi
     tar -xf "${uncloudd_download_path}" --directory "${tmp_dir}"
@@ -135,7 +339,7 @@ install_uncloud_binaries() {
     log "✓ uncloudd binary installed: ${uncloudd_install_path}"
 
     log "⏳ Downloading uninstall script: ${uninstall_url}"
-    if ! curl -fsSL -o "${uninstall_download_path}" "${uninstall_url}"; then
+    if ! download "${uninstall_url}" "${uninstall_download_path}"; then
         error "Failed to download uninstall script."
     fi
     local uninstall_install_path="${INSTALL_BIN_DIR}/uncloud-uninstall"
@@ -177,6 +381,18 @@ WantedBy=multi-user.target
 EOF
     log "✓ Systemd unit file created: ${uncloud_service_path}"
 
+    # The machine daemon delays the system shutdown with a logind inhibitor lock to gracefully stop the containers
+    # and notify other machines. Extend the maximum delay as the default 5 seconds is too short for that.
+    local logind_conf_path="/etc/systemd/logind.conf.d/uncloud.conf"
+    mkdir -p "$(dirname "${logind_conf_path}")"
+    cat > "${logind_conf_path}" << EOF
+[Login]
+InhibitDelayMaxSec=${LOGIND_INHIBIT_DELAY_MAX_SEC}
+EOF
+    # logind reads its configuration only on start. The new delay will apply after the next reboot otherwise.
+    systemctl reload systemd-logind.service 2>/dev/null || true
+    log "✓ Systemd logind config created: ${logind_conf_path}"
+
     # Reload systemd to recognize the new or updated unit file.
     systemctl daemon-reload
     systemctl enable uncloud.service
@@ -209,7 +425,7 @@ install_corrosion() {
     local corrosion_download_path="${tmp_dir}/corrosion.tar.gz"
 
     log "⏳ Downloading uncloud-corrosion binary: ${corrosion_url}"
-    if ! curl -fsSL -o "${corrosion_download_path}" "${corrosion_url}"; then
+    if ! download "${corrosion_url}" "${corrosion_download_path}"; then
         error "Failed to download uncloud-corrosion binary."
     fi
     tar -xf "${corrosion_download_path}" -C "${tmp_dir}"
@@ -262,7 +478,9 @@ if [ "$EUID" -ne 0 ]; then
     error "Please run the install script with sudo or as root."
 fi
 
+detect_distro
 verify_system
+install_packages
 install_docker
 create_uncloud_user_and_group
 install_uncloud_binaries
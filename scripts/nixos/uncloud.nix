@@ -0,0 +1,111 @@
+# NixOS module for running an Uncloud machine.
+#
+# NixOS is configured declaratively so the imperative install script (scripts/install.sh) can't be used on it.
+# This module sets up the same components: Docker, the 'uncloud' user and group, and the uncloud and
+# uncloud-corrosion systemd services. Once the configuration is applied with 'nixos-rebuild switch', add the machine
+# to a cluster with 'uc machine init --no-install' or 'uc machine add --no-install'.
+#
+# Example configuration.nix:
+#
+#   imports = [ ./uncloud.nix ];
+#   services.uncloud = {
+#     enable = true;
+#     package = pkgs.callPackage ./uncloudd.nix { };
+#     corrosionPackage = pkgs.callPackage ./corrosion.nix { };
+#     groupUsers = [ "alice" ];
+#   };
+{ config, lib, ... }:
+
+let
+  cfg = config.services.uncloud;
+in
+{
+  options.services.uncloud = {
+    enable = lib.mkEnableOption "Uncloud machine daemon";
+
+    package = lib.mkOption {
+      type = lib.types.package;
+      description = "Package providing the uncloudd binary.";
+    };
+
+    corrosionPackage = lib.mkOption {
+      type = lib.types.package;
+      description = "Package providing the corrosion binary built from https://github.com/psviderski/corrosion.";
+    };
+
+    dataDir = lib.mkOption {
+      type = lib.types.path;
+      default = "/var/lib/uncloud";
+      description = "Directory where the Uncloud daemon and corrosion store their data.";
+    };
+
+    groupUsers = lib.mkOption {
+      type = lib.types.listOf lib.types.str;
+      default = [ ];
+      description = "Users added to the 'uncloud' group to allow them to access the Uncloud daemon socket.";
+    };
+  };
+
+  config = lib.mkIf cfg.enable {
+    virtualisation.docker.enable = true;
+
+    users.groups.uncloud.members = cfg.groupUsers;
+    users.users.uncloud = {
+      isSystemUser = true;
+      group = "uncloud";
+      home = "/nonexistent";
+    };
+
+    # The Uncloud daemon manages WireGuard interfaces and the firewall rules itself.
+    boot.kernelModules = [ "wireguard" ];
+
+    systemd.services.uncloud = {
+      description = "Uncloud machine daemon";
+      after = [ "network-online.target" "docker.service" ];
+      wants = [ "network-online.target" ];
+      wantedBy = [ "multi-user.target" ];
+      # The daemon calls systemctl to manage the uncloud-corrosion service and needs iptables for the firewall.
+      path = [ config.systemd.package config.networking.firewall.package ];
+      serviceConfig = {
+        Type = "notify";
+        ExecStart = "${cfg.package}/bin/uncloudd --data-dir ${cfg.dataDir}";
+        TimeoutStartSec = 15;
+        Restart = "always";
+        RestartSec = 2;
+
+        # Hardening options.
+        NoNewPrivileges = true;
+        ProtectSystem = "full";
+        ProtectControlGroups = true;
+        ProtectHome = "read-only";
+        ProtectKernelTunables = true;
+        PrivateTmp = true;
+        RestrictAddressFamilies = [ "AF_INET" "AF_INET6" "AF_UNIX" "AF_NETLINK" ];
+        RestrictNamespaces = true;
+      };
+    };
+
+    systemd.services.uncloud-corrosion = {
+      description = "Uncloud gossip-based distributed store";
+      partOf = [ "uncloud.service" ];
+      serviceConfig = {
+        Type = "simple";
+        ExecStart = "${cfg.corrosionPackage}/bin/corrosion agent -c ${cfg.dataDir}/corrosion/config.toml";
+        ExecReload = "${cfg.corrosionPackage}/bin/corrosion reload -c ${cfg.dataDir}/corrosion/config.toml";
+        Restart = "always";
+        RestartSec = 2;
+        User = "uncloud";
+        Group = "uncloud";
+
+        # Hardening options.
+        ProtectSystem = "full";
+        PrivateTmp = true;
+        NoNewPrivileges = true;
+        ProtectHome = true;
+        ProtectControlGroups = true;
+        ProtectKernelTunables = true;
+        RestrictAddressFamilies = [ "AF_INET" "AF_INET6" "AF_UNIX" ];
+      };
+    };
+  };
+}
@@ -7,8 +7,10 @@ AUTO_CONFIRM=${AUTO_CONFIRM:-false}
 INSTALL_BIN_DIR=${INSTALL_BIN_DIR:-/usr/local/bin}
 INSTALL_SYSTEMD_DIR=${INSTALL_SYSTEMD_DIR:-/etc/systemd/system}
 UNCLOUD_USER="uncloud"
+UNCLOUD_READONLY_GROUP="uncloud-readonly"
 UNCLOUD_DATA_DIR=${UNCLOUD_DATA_DIR:-/var/lib/uncloud}
 UNCLOUD_RUN_DIR=${UNCLOUD_RUN_DIR:-/run/uncloud}
+UNCLOUD_READONLY_RUN_DIR=${UNCLOUD_READONLY_RUN_DIR:-/run/uncloud-readonly}
 
 log() {
     echo -e "\033[1;32m$1\033[0m"
@@ -65,6 +67,7 @@ log "✓ Systemd services stopped."
 log "⏳ Removing systemd service files..."
 rm -fv "${INSTALL_SYSTEMD_DIR}/uncloud.service"
 rm -fv "${INSTALL_SYSTEMD_DIR}/uncloud-corrosion.service"
+rm -fv /etc/systemd/logind.conf.d/uncloud.conf
 systemctl daemon-reload
 log "✓ Systemd service files removed."
 
@@ -76,6 +79,7 @@ log "✓ Binaries removed."
 log "⏳ Removing data and run directories..."
 rm -rfv "${UNCLOUD_DATA_DIR}"
 rm -rfv "${UNCLOUD_RUN_DIR}"
+rm -rfv "${UNCLOUD_READONLY_RUN_DIR}"
 log "✓ Data and run directories removed."
 
 log "⏳ Removing Linux user and group..."
@@ -94,6 +98,11 @@ else
     log "Linux group '${UNCLOUD_USER}' does not exist or was already removed."
 fi
 
+if getent group "${UNCLOUD_READONLY_GROUP}" &> /dev/null; then
+    groupdel "${UNCLOUD_READONLY_GROUP}" || error "Failed to remove group ${UNCLOUD_READONLY_GROUP}."
+    log "✓ Linux group '${UNCLOUD_READONLY_GROUP}' removed."
+fi
+
 log "⏳ Looking for Docker containers and network created by Uncloud..."
 if command -v docker &> /dev/null; then
     uncloud_containers=$(docker ps -a --filter "label=uncloud.managed" -q)
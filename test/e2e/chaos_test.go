@@ -0,0 +1,143 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/ucind"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// convergenceTimeout is how long the cluster has to converge after the chaos faults stop being injected.
+const convergenceTimeout = 3 * time.Minute
+
+// assertClusterInvariants checks the invariants the cluster must eventually satisfy after faults: all machines are UP,
+// and every service has the expected number of running containers placed only on cluster machines.
+func assertClusterInvariants(t *testing.T, cli *client.Client, machines int, specs ...api.ServiceSpec) {
+	t.Helper()
+	ctx := context.Background()
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		members, err := cli.ListMachines(ctx, nil)
+		if !assert.NoError(c, err) {
+			return
+		}
+		if !assert.Len(c, members, machines) {
+			return
+		}
+		machineIDs := make(map[string]bool, len(members))
+		for _, m := range members {
+			assert.Equal(c, pb.MachineMember_UP, m.State, "machine '%s' state", m.Machine.Name)
+			machineIDs[m.Machine.Id] = true
+		}
+
+		for _, spec := range specs {
+			svc, err := cli.InspectService(ctx, spec.Name)
+			if !assert.NoError(c, err) {
+				continue
+			}
+
+			want := int(spec.Replicas)
+			if spec.Mode == api.ServiceModeGlobal {
+				want = machines
+			}
+			running := 0
+			for _, ctr := range svc.Containers {
+				assert.True(c, machineIDs[ctr.MachineID], "container '%s' is on unknown machine '%s'",
+					ctr.Container.Name, ctr.MachineID)
+				if ctr.Container.State.Running {
+					running++
+				}
+			}
+			assert.Len(c, svc.Containers, want, "containers of service '%s'", spec.Name)
+			assert.Equal(c, want, running, "running containers of service '%s'", spec.Name)
+		}
+	}, convergenceTimeout, 2*time.Second)
+}
+
+// TestChaos runs a cluster with the chaos mode enabled on all machines, deploys services while the faults are
+// being injected, and checks that the cluster converges to the desired state. It runs only if TEST_CHAOS_DURATION
+// is set to how long to inject the faults, e.g. 5m.
+func TestChaos(t *testing.T) {
+	durationEnv := os.Getenv("TEST_CHAOS_DURATION")
+	if durationEnv == "" {
+		t.Skip("TEST_CHAOS_DURATION is not set")
+	}
+	duration, err := time.ParseDuration(durationEnv)
+	require.NoError(t, err)
+	t.Parallel()
+
+	clusterName := "ucind-test.chaos"
+	ctx := context.Background()
+	machines := 3
+	chaosOpts := "interval=10s,store-delay=3s,peer-drop=0.2,peer-drop-duration=15s,container-kill=0.3"
+	if seed := os.Getenv("TEST_CHAOS_SEED"); seed != "" {
+		chaosOpts += ",seed=" + seed
+	}
+	c, _ := createTestCluster(t, clusterName, ucind.CreateClusterOptions{
+		Machines:   machines,
+		DaemonArgs: []string{"--chaos=" + chaosOpts},
+	}, true)
+
+	cli, err := c.Machines[0].Connect(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cli.Close()
+	})
+
+	specs := []api.ServiceSpec{
+		{
+			Name:      "test-chaos-replicated",
+			Mode:      api.ServiceModeReplicated,
+			Container: api.ContainerSpec{Image: "portainer/pause:latest"},
+			Replicas:  2,
+		},
+		{
+			Name:      "test-chaos-global",
+			Mode:      api.ServiceModeGlobal,
+			Container: api.ContainerSpec{Image: "portainer/pause:latest"},
+		},
+	}
+	t.Cleanup(func() {
+		for _, spec := range specs {
+			err := cli.RemoveService(ctx, spec.Name)
+			if !errors.Is(err, api.ErrNotFound) {
+				assert.NoError(t, err)
+			}
+		}
+	})
+
+	// Repeatedly redeploy the services while the faults are being injected. Deployments may fail because of
+	// the faults but the services must converge once they succeed.
+	deadline := time.Now().Add(duration)
+	for i := 0; time.Now().Before(deadline); i++ {
+		for j := range specs {
+			spec := specs[j]
+			spec.Container.Env = map[string]string{"ITERATION": fmt.Sprint(i)}
+			if _, err := cli.NewDeployment(spec, nil).Run(ctx); err != nil {
+				t.Logf("Deployment of service '%s' failed during chaos: %v", spec.Name, err)
+				continue
+			}
+			specs[j] = spec
+		}
+		time.Sleep(15 * time.Second)
+	}
+
+	// Redeploy the last successful specs to repair any containers killed or lost during the chaos and check that
+	// the cluster converges.
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		for _, spec := range specs {
+			_, err := cli.NewDeployment(spec, nil).Run(ctx)
+			assert.NoError(c, err, "deploy service '%s'", spec.Name)
+		}
+	}, convergenceTimeout, 5*time.Second)
+	assertClusterInvariants(t, cli, machines, specs...)
+}
@@ -0,0 +1,125 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/ucind"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// machineStateTimeout is how long to wait for the cluster membership state of a machine to change after it's
+// stopped or started.
+const machineStateTimeout = 2 * time.Minute
+
+// waitMachineState waits until the machine has the given membership state as seen by the machine cli is connected to.
+func waitMachineState(
+	t *testing.T, cli *client.Client, nameOrID string, state pb.MachineMember_MembershipState,
+) {
+	t.Helper()
+	ctx := context.Background()
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		m, err := cli.InspectMachine(ctx, nameOrID)
+		if !assert.NoError(c, err) {
+			return
+		}
+		assert.Equal(c, state, m.State, "machine '%s' state", nameOrID)
+	}, machineStateTimeout, time.Second)
+}
+
+func TestMachineFailover(t *testing.T) {
+	t.Parallel()
+
+	clusterName := "ucind-test.failover"
+	ctx := context.Background()
+	c, p := createTestCluster(t, clusterName, ucind.CreateClusterOptions{Machines: 3}, true)
+
+	cli, err := c.Machines[0].Connect(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cli.Close()
+	})
+
+	failed := c.Machines[2]
+	name := "test-failover"
+	t.Cleanup(func() {
+		err := cli.RemoveService(ctx, name)
+		if !errors.Is(err, api.ErrNotFound) {
+			assert.NoError(t, err)
+		}
+	})
+
+	// 1. Deploy a replicated service with a replica on each machine.
+	spec := api.ServiceSpec{
+		Name: name,
+		Mode: api.ServiceModeReplicated,
+		Container: api.ContainerSpec{
+			Image: "portainer/pause:latest",
+		},
+		Replicas: 3,
+	}
+	_, err = cli.NewDeployment(spec, nil).Run(ctx)
+	require.NoError(t, err)
+
+	svc, err := cli.InspectService(ctx, name)
+	require.NoError(t, err)
+	assertServiceMatchesSpec(t, svc, spec)
+	require.Len(t, serviceMachines(svc).ToSlice(), 3, "Expected a replica on each machine")
+
+	// 2. Stop a machine and wait for the other machines to detect it's down.
+	require.NoError(t, p.StopMachine(ctx, failed))
+	failedStarted := false
+	t.Cleanup(func() {
+		if !failedStarted {
+			_, err := p.StartMachine(ctx, failed)
+			assert.NoError(t, err)
+		}
+	})
+	waitMachineState(t, cli, failed.Name, pb.MachineMember_DOWN)
+
+	// The containers on the down machine are not listed.
+	svc, err = cli.InspectService(ctx, name)
+	require.NoError(t, err)
+	assert.Len(t, svc.Containers, 2)
+	assert.False(t, serviceMachines(svc).Contains(failed.ID), "Expected no containers on the down machine")
+
+	// 3. Scaling the service only schedules the replicas on the available machines.
+	_, err = cli.Scale(ctx, name, 4, client.DeployOptions{})
+	require.NoError(t, err)
+
+	svc, err = cli.InspectService(ctx, name)
+	require.NoError(t, err)
+	assert.Len(t, svc.Containers, 4)
+	assert.ElementsMatch(t, []string{c.Machines[0].ID, c.Machines[1].ID}, serviceMachines(svc).ToSlice(),
+		"Expected replicas only on the available machines")
+
+	// 4. Start the machine again and wait for it to rejoin the cluster.
+	failed, err = p.StartMachine(ctx, failed)
+	require.NoError(t, err)
+	failedStarted = true
+	waitMachineState(t, cli, failed.Name, pb.MachineMember_UP)
+
+	// The container on the recovered machine is listed again as it's restarted by Docker.
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		svc, err = cli.InspectService(ctx, name)
+		if !assert.NoError(c, err) {
+			return
+		}
+		assert.True(c, serviceMachines(svc).Contains(failed.ID), "Expected a container on the recovered machine")
+	}, machineStateTimeout, time.Second)
+
+	// 5. Scaling the service back down removes the excess replicas.
+	_, err = cli.Scale(ctx, name, 3, client.DeployOptions{})
+	require.NoError(t, err)
+
+	svc, err = cli.InspectService(ctx, name)
+	require.NoError(t, err)
+	assert.Len(t, svc.Containers, 3)
+}